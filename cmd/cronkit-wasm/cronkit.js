@@ -0,0 +1,42 @@
+// Loader of cronkit's WebAssembly build. Include wasm_exec.js from the Go
+// distribution first (make wasm copies it next to cronkit.wasm), then:
+//
+//   import { loadCronkit } from './cronkit.js';
+//   const cronkit = await loadCronkit();
+//   cronkit.explain('0 9 * * 1-5').result.description;
+//   // "At 09:00 on weekdays (Mon-Fri)"
+//
+// Every function returns a response object:
+//   {apiVersion, command, ok, result}, or {..., ok: false, error: {code, message}}
+
+let loading;
+
+// loadCronkit fetches and starts cronkit.wasm (by default, next to this
+// file) once, and resolves to the cronkit object
+export function loadCronkit(url = new URL('cronkit.wasm', import.meta.url)) {
+  if (!loading) {
+    loading = start(url).catch((err) => {
+      loading = undefined;
+      throw err;
+    });
+  }
+  return loading;
+}
+
+async function start(url) {
+  if (typeof globalThis.Go !== 'function') {
+    throw new Error('cronkit: load wasm_exec.js before cronkit.js');
+  }
+  const go = new globalThis.Go();
+  const response = fetch(url);
+  let instance;
+  if (WebAssembly.instantiateStreaming) {
+    ({ instance } = await WebAssembly.instantiateStreaming(response, go.importObject));
+  } else {
+    const bytes = await (await response).arrayBuffer();
+    ({ instance } = await WebAssembly.instantiate(bytes, go.importObject));
+  }
+  // run resolves when the program exits, which it never does
+  go.run(instance);
+  return globalThis.cronkit;
+}
@@ -0,0 +1,83 @@
+//go:build js && wasm
+
+// Command cronkit-wasm is cronkit's WebAssembly build. It sets a global
+// cronkit object whose functions explain, validate and list the runs of cron
+// expressions in the browser:
+//
+//	cronkit.explain(expression, options?)
+//	cronkit.validate(expression, options?)
+//	cronkit.validateCrontab(crontab, options?)
+//	cronkit.nextRuns(expression, options?)
+//	cronkit.exec(request)
+//
+// Options are an object of the request's locale and options, e.g.
+// {locale: "fr", count: 5, timezone: "Europe/Paris"}. Every function returns
+// a response object like "cronkit exec" prints. Build it with "make wasm",
+// and load it with cronkit.js.
+package main
+
+import (
+	"syscall/js"
+	_ "time/tzdata" // Browsers have no time zone database
+
+	"github.com/hzerrad/cronkit/internal/api"
+	"github.com/hzerrad/cronkit/internal/jsapi"
+)
+
+func main() {
+	cronkit := js.Global().Get("Object").New()
+	cronkit.Set("apiVersion", api.Version)
+	cronkit.Set("explain", function(jsapi.Explain))
+	cronkit.Set("validate", function(jsapi.Validate))
+	cronkit.Set("validateCrontab", function(jsapi.ValidateCrontab))
+	cronkit.Set("nextRuns", function(jsapi.NextRuns))
+	cronkit.Set("exec", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		return parse(jsapi.Exec(stringify(arg(args, 0))))
+	}))
+	js.Global().Set("cronkit", cronkit)
+
+	// Keep the functions callable
+	select {}
+}
+
+// function adapts a function of an input string and JSON options to
+// JavaScript, where the options are an object
+func function(fn func(input, opts string) string) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		return call(fn, args)
+	})
+}
+
+// call calls fn with the arguments of a JavaScript call
+func call(fn func(input, opts string) string, args []js.Value) js.Value {
+	// Anything but a string is a missing input, reported by the API
+	input := ""
+	if v := arg(args, 0); v.Type() == js.TypeString {
+		input = v.String()
+	}
+	return parse(fn(input, stringify(arg(args, 1))))
+}
+
+// arg returns argument i, or undefined when it was not passed
+func arg(args []js.Value, i int) js.Value {
+	if i < len(args) {
+		return args[i]
+	}
+	return js.Undefined()
+}
+
+// stringify encodes a JavaScript value as JSON; undefined and null give ""
+func stringify(v js.Value) string {
+	if v.IsUndefined() || v.IsNull() {
+		return ""
+	}
+	if v.Type() == js.TypeString {
+		return v.String()
+	}
+	return js.Global().Get("JSON").Call("stringify", v).String()
+}
+
+// parse decodes a JSON response into a JavaScript object
+func parse(data string) js.Value {
+	return js.Global().Get("JSON").Call("parse", data)
+}
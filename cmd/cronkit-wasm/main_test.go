@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/jsapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Functions made with js.FuncOf cannot be invoked from Go without blocking,
+// so the tests call what they run
+
+func TestCall(t *testing.T) {
+	t.Run("returns response objects", func(t *testing.T) {
+		resp := call(jsapi.Explain, []js.Value{js.ValueOf("0 9 * * 1-5")})
+		require.True(t, resp.Get("ok").Bool())
+		assert.Equal(t, "At 09:00 on weekdays (Mon-Fri)", resp.Get("result").Get("description").String())
+	})
+
+	t.Run("options objects", func(t *testing.T) {
+		options := js.Global().Get("Object").New()
+		options.Set("locale", "fr")
+		resp := call(jsapi.Explain, []js.Value{js.ValueOf("0 9 * * 1-5"), options})
+		require.True(t, resp.Get("ok").Bool())
+		assert.Equal(t, "fr", resp.Get("result").Get("locale").String())
+
+		options = parse(`{"count": 2, "from": "2025-01-01T00:00:00Z", "timezone": "Europe/Paris"}`)
+		resp = call(jsapi.NextRuns, []js.Value{js.ValueOf("@daily"), options})
+		require.True(t, resp.Get("ok").Bool(), stringify(resp))
+		runs := resp.Get("result").Get("nextRuns")
+		assert.Equal(t, 2, runs.Length())
+		assert.Equal(t, "2025-01-02T00:00:00+01:00", runs.Index(0).Get("timestamp").String())
+	})
+
+	t.Run("missing input", func(t *testing.T) {
+		for _, args := range [][]js.Value{nil, {js.Null()}, {js.ValueOf(5)}} {
+			resp := call(jsapi.Explain, args)
+			assert.False(t, resp.Get("ok").Bool())
+			assert.Equal(t, "invalid_request", resp.Get("error").Get("code").String())
+		}
+	})
+}
+
+func TestStringify(t *testing.T) {
+	assert.Equal(t, "", stringify(js.Undefined()))
+	assert.Equal(t, "", stringify(js.Null()))
+	assert.Equal(t, `{"a":1}`, stringify(js.ValueOf(`{"a":1}`)))
+	assert.Equal(t, `{"count":2}`, stringify(parse(`{"count": 2}`)))
+}
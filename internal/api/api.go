@@ -0,0 +1,584 @@
+// Package api implements cronkit's programmatic request/response protocol.
+// The same JSON documents are accepted by `cronkit exec --request` and by the
+// HTTP API, so automation written in any language has one stable entry point.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/hzerrad/cronkit/internal/render"
+	"github.com/hzerrad/cronkit/internal/schema"
+)
+
+// Version is the protocol version reported in every response
+const Version = "v1"
+
+// Supported request commands
+const (
+	CommandExplain  = "explain"
+	CommandNext     = "next"
+	CommandCheck    = "check"
+	CommandList     = "list"
+	CommandTimeline = "timeline"
+)
+
+// Error codes reported in failed responses
+const (
+	ErrInvalidRequest  = "invalid_request"
+	ErrUnknownCommand  = "unknown_command"
+	ErrExecutionFailed = "execution_failed"
+)
+
+// Limits for the next command (mirrors the CLI)
+const (
+	DefaultNextCount = 10
+	MaxNextCount     = 100
+)
+
+// timelineWidth is the width reported in timeline results, those of a
+// standard terminal
+const timelineWidth = 80
+
+// Request describes a single operation
+type Request struct {
+	Command    string  `json:"command"`              // Operation to run (explain, next, check, list, timeline)
+	Expression string  `json:"expression,omitempty"` // Cron expression (explain, next, check, timeline)
+	Crontab    string  `json:"crontab,omitempty"`    // Inline crontab content (check, list, timeline)
+	Locale     string  `json:"locale,omitempty"`     // Locale for parsing and descriptions (default: en)
+	Options    Options `json:"options,omitempty"`    // Command-specific options
+}
+
+// Options holds command-specific options. Unused options are ignored.
+type Options struct {
+	Count                 int    `json:"count,omitempty"`                 // next: number of runs (default: 10)
+	From                  string `json:"from,omitempty"`                  // next, timeline: start time in RFC3339 (default: now)
+	Timezone              string `json:"timezone,omitempty"`              // next, timeline: IANA timezone (default: UTC)
+	View                  string `json:"view,omitempty"`                  // timeline: day, hour, week or month (default: day)
+	Verbose               bool   `json:"verbose,omitempty"`               // check: include info-level issues
+	EnableFrequencyChecks *bool  `json:"enableFrequencyChecks,omitempty"` // check: default true
+	MaxRunsPerDay         int    `json:"maxRunsPerDay,omitempty"`         // check: default 1000
+	EnableHygieneChecks   bool   `json:"enableHygieneChecks,omitempty"`   // check
+	WarnOnOverlap         bool   `json:"warnOnOverlap,omitempty"`         // check
+	OverlapWindow         string `json:"overlapWindow,omitempty"`         // check: duration (default: 24h)
+	SuggestConsolidation  bool   `json:"suggestConsolidation,omitempty"`  // check
+	Horizon               string `json:"horizon,omitempty"`               // check: look-ahead for distant schedules (default: 2y)
+	All                   bool   `json:"all,omitempty"`                   // list: include comments and env vars
+	Dialect               string `json:"dialect,omitempty"`               // explain, next, check, timeline: standard, quartz or jenkins (default: standard)
+	JenkinsJob            string `json:"jenkinsJob,omitempty"`            // explain, next, check, timeline: job name H tokens are hashed from (jenkins dialect)
+}
+
+// Response is the result of executing a Request
+type Response struct {
+	APIVersion string      `json:"apiVersion"`
+	Command    string      `json:"command"`
+	OK         bool        `json:"ok"`
+	Result     interface{} `json:"result,omitempty"`
+	Error      *Error      `json:"error,omitempty"`
+}
+
+// Error describes why a request failed
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ExplainResult is the result of the explain command
+type ExplainResult struct {
+	Expression  string `json:"expression"`
+	Description string `json:"description"`
+	Locale      string `json:"locale"`
+	Resolved    string `json:"resolved,omitempty"` // Expression with Jenkins H tokens or ~ terms resolved
+}
+
+// NextRun is a single scheduled run
+type NextRun struct {
+	Number    int    `json:"number"`
+	Timestamp string `json:"timestamp"`
+}
+
+// NextResult is the result of the next command
+type NextResult struct {
+	Expression  string    `json:"expression"`
+	Description string    `json:"description"`
+	Timezone    string    `json:"timezone"`
+	Locale      string    `json:"locale"`
+	NextRuns    []NextRun `json:"nextRuns"`
+}
+
+// Issue is a validation issue in a check result
+type Issue struct {
+	Severity   string `json:"severity"`
+	Code       string `json:"code"`
+	LineNumber int    `json:"lineNumber"`
+	Expression string `json:"expression"`
+	Message    string `json:"message"`
+	Hint       string `json:"hint,omitempty"`
+}
+
+// CheckResult is the result of the check command
+type CheckResult struct {
+	Valid       bool    `json:"valid"`
+	TotalJobs   int     `json:"totalJobs"`
+	ValidJobs   int     `json:"validJobs"`
+	InvalidJobs int     `json:"invalidJobs"`
+	Issues      []Issue `json:"issues"`
+	Locale      string  `json:"locale"`
+}
+
+// ListJob is a job in a list result
+type ListJob struct {
+	LineNumber  int    `json:"lineNumber"`
+	Expression  string `json:"expression"`
+	Command     string `json:"command"`
+	Comment     string `json:"comment,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListEntry is a crontab entry in a list result (with options.all)
+type ListEntry struct {
+	LineNumber int      `json:"lineNumber"`
+	Type       string   `json:"type"`
+	Raw        string   `json:"raw"`
+	Job        *ListJob `json:"job,omitempty"`
+}
+
+// ListResult is the result of the list command
+type ListResult struct {
+	Jobs    []ListJob   `json:"jobs,omitempty"`
+	Entries []ListEntry `json:"entries,omitempty"`
+	Locale  string      `json:"locale"`
+}
+
+// TimelineResult is the result of the timeline command, the document printed
+// by "cronkit timeline --json"
+type TimelineResult map[string]interface{}
+
+// DecodeRequest reads a JSON request from r. Unknown fields are rejected so
+// that typos in automation fail loudly instead of being silently ignored.
+func DecodeRequest(r io.Reader) (Request, error) {
+	var req Request
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return Request{}, fmt.Errorf("invalid request: %w", err)
+	}
+	return req, nil
+}
+
+// Observer is notified of every request run by ExecuteObserved, e.g. to
+// collect metrics
+type Observer interface {
+	ObserveRequest(command string, ok bool, duration time.Duration)
+}
+
+// Execute runs a request and returns its response. It never panics on bad
+// input; failures are reported through Response.Error.
+func Execute(req Request) Response {
+	return ExecuteObserved(req, nil)
+}
+
+// ExecuteObserved runs a request like Execute and reports its command,
+// outcome and duration to obs, if non-nil
+func ExecuteObserved(req Request, obs Observer) Response {
+	return ExecuteContext(context.Background(), req, obs)
+}
+
+// ExecuteContext runs a request like ExecuteObserved, failing it with the
+// context's cause when ctx ends before the request completes
+func ExecuteContext(ctx context.Context, req Request, obs Observer) Response {
+	if obs == nil {
+		return execute(ctx, req)
+	}
+	start := time.Now()
+	resp := execute(ctx, req)
+	obs.ObserveRequest(strings.ToLower(req.Command), resp.OK, time.Since(start))
+	return resp
+}
+
+// execute implements ExecuteContext
+func execute(ctx context.Context, req Request) Response {
+	resp := Response{
+		APIVersion: Version,
+		Command:    req.Command,
+	}
+
+	locale := req.Locale
+	if locale == "" {
+		locale = "en"
+	}
+
+	var result interface{}
+	var err error
+
+	switch strings.ToLower(req.Command) {
+	case CommandExplain:
+		result, err = explain(req, locale)
+	case CommandNext:
+		result, err = next(ctx, req, locale)
+	case CommandCheck:
+		result, err = runCheck(ctx, req, locale)
+	case CommandList:
+		result, err = list(req, locale)
+	case CommandTimeline:
+		result, err = timeline(ctx, req, locale)
+	case "":
+		return fail(resp, ErrInvalidRequest, "missing command")
+	default:
+		return fail(resp, ErrUnknownCommand, fmt.Sprintf("unknown command %q (supported: %s)",
+			req.Command, strings.Join(Commands(), ", ")))
+	}
+
+	if err != nil {
+		code := ErrExecutionFailed
+		if reqErr, ok := err.(*requestError); ok {
+			code = ErrInvalidRequest
+			err = reqErr.err
+		}
+		return fail(resp, code, err.Error())
+	}
+
+	resp.OK = true
+	resp.Result = result
+	return resp
+}
+
+// Commands returns the supported request commands
+func Commands() []string {
+	return []string{CommandExplain, CommandNext, CommandCheck, CommandList, CommandTimeline}
+}
+
+// requestError marks errors caused by a malformed request rather than by execution
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+// invalid wraps a formatted message as a request error
+func invalid(format string, args ...interface{}) error {
+	return &requestError{err: fmt.Errorf(format, args...)}
+}
+
+// parserOptions returns the parser options for expression-based commands.
+// Six-field expressions with seconds are always accepted.
+func parserOptions(opts Options) (cronx.ParserOptions, error) {
+	dialect, err := cronx.ParseDialect(opts.Dialect)
+	if err != nil {
+		return cronx.ParserOptions{}, invalid("%w", err)
+	}
+	return cronx.ParserOptions{Seconds: cronx.SecondsOptional, Dialect: dialect, HashKey: opts.JenkinsJob}, nil
+}
+
+// fail fills resp with an error
+func fail(resp Response, code, message string) Response {
+	resp.OK = false
+	resp.Error = &Error{Code: code, Message: message}
+	return resp
+}
+
+func explain(req Request, locale string) (*ExplainResult, error) {
+	if req.Expression == "" {
+		return nil, invalid("explain requires an expression")
+	}
+
+	parserOpts, err := parserOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := cronx.NewParserWithOptions(locale, parserOpts).Parse(req.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression: %w", err)
+	}
+
+	return &ExplainResult{
+		Expression:  req.Expression,
+		Description: human.NewHumanizerForLocale(locale).Humanize(schedule),
+		Locale:      locale,
+		Resolved:    schedule.Resolved,
+	}, nil
+}
+
+func next(ctx context.Context, req Request, locale string) (*NextResult, error) {
+	if req.Expression == "" {
+		return nil, invalid("next requires an expression")
+	}
+
+	count := req.Options.Count
+	if count == 0 {
+		count = DefaultNextCount
+	}
+	if count < 1 || count > MaxNextCount {
+		return nil, invalid("invalid count: must be between 1 and %d", MaxNextCount)
+	}
+
+	loc, from, err := startTime(req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	parserOpts, err := parserOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := cronx.NewParserWithOptions(locale, parserOpts).Parse(req.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression: %w", err)
+	}
+
+	times, err := cronx.NewSchedulerWithOptions(parserOpts).NextContext(ctx, req.Expression, from, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next runs: %w", err)
+	}
+
+	runs := make([]NextRun, len(times))
+	for i, t := range times {
+		runs[i] = NextRun{Number: i + 1, Timestamp: t.In(loc).Format(time.RFC3339)}
+	}
+
+	return &NextResult{
+		Expression:  req.Expression,
+		Description: human.NewHumanizerForLocale(locale).Humanize(schedule),
+		Timezone:    loc.String(),
+		Locale:      locale,
+		NextRuns:    runs,
+	}, nil
+}
+
+// startTime returns the timezone and start time of next and timeline
+// requests: options.from (default: now) in options.timezone (default: UTC)
+func startTime(opts Options) (*time.Location, time.Time, error) {
+	loc := time.UTC
+	if opts.Timezone != "" {
+		parsed, err := time.LoadLocation(opts.Timezone)
+		if err != nil {
+			return nil, time.Time{}, invalid("invalid timezone: %w", err)
+		}
+		loc = parsed
+	}
+
+	from := time.Now().In(loc)
+	if opts.From != "" {
+		parsed, err := time.Parse(time.RFC3339, opts.From)
+		if err != nil {
+			return nil, time.Time{}, invalid("invalid from time (expected RFC3339): %w", err)
+		}
+		from = parsed.In(loc)
+	}
+	return loc, from, nil
+}
+
+func runCheck(ctx context.Context, req Request, locale string) (*CheckResult, error) {
+	if req.Expression == "" && req.Crontab == "" {
+		return nil, invalid("check requires an expression or crontab")
+	}
+
+	opts := req.Options
+	validator := check.NewValidator(locale)
+	validator.SetContext(ctx)
+	if opts.EnableFrequencyChecks != nil {
+		validator.SetFrequencyChecks(*opts.EnableFrequencyChecks)
+	}
+	if opts.MaxRunsPerDay > 0 {
+		validator.SetMaxRunsPerDay(opts.MaxRunsPerDay)
+	}
+	validator.SetHygieneChecks(opts.EnableHygieneChecks)
+	validator.SetConsolidationChecks(opts.SuggestConsolidation)
+	if opts.Horizon != "" {
+		horizon, err := check.ParseHorizon(opts.Horizon)
+		if err != nil {
+			return nil, invalid("%w", err)
+		}
+		validator.SetHorizon(horizon)
+	}
+	if opts.WarnOnOverlap {
+		if opts.OverlapWindow != "" {
+			window, err := time.ParseDuration(opts.OverlapWindow)
+			if err != nil {
+				return nil, invalid("invalid overlapWindow duration: %w", err)
+			}
+			validator.SetOverlapWindow(window)
+		}
+		validator.SetWarnOnOverlap(true)
+	}
+
+	var result check.ValidationResult
+	if req.Expression != "" {
+		parserOpts, err := parserOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		validator.SetParserOptions(parserOpts)
+		result = validator.ValidateExpression(req.Expression)
+	} else {
+		entries, err := crontab.ParseReader(strings.NewReader(req.Crontab))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab: %w", err)
+		}
+		result = validator.ValidateEntries(entries)
+	}
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+
+	// Like the CLI, info-level issues are only reported when verbose
+	issues := make([]Issue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		if issue.Severity == check.SeverityInfo && !opts.Verbose {
+			continue
+		}
+		issues = append(issues, Issue{
+			Severity:   issue.Severity.String(),
+			Code:       issue.Code,
+			LineNumber: issue.LineNumber,
+			Expression: issue.Expression,
+			Message:    issue.Message,
+			Hint:       issue.Hint,
+		})
+	}
+
+	return &CheckResult{
+		Valid:       result.Valid && len(issues) == 0,
+		TotalJobs:   result.TotalJobs,
+		ValidJobs:   result.ValidJobs,
+		InvalidJobs: result.InvalidJobs,
+		Issues:      issues,
+		Locale:      locale,
+	}, nil
+}
+
+func list(req Request, locale string) (*ListResult, error) {
+	entries, err := crontab.ParseReader(strings.NewReader(req.Crontab))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crontab: %w", err)
+	}
+
+	parser := cronx.NewParserWithLocale(locale)
+	humanizer := human.NewHumanizerForLocale(locale)
+	describe := func(job *crontab.Job) *ListJob {
+		lj := &ListJob{
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Command:    job.Command,
+			Comment:    job.Comment,
+		}
+		if schedule, err := parser.Parse(job.Expression); err == nil {
+			lj.Description = humanizer.Humanize(schedule)
+		}
+		return lj
+	}
+
+	result := &ListResult{Locale: locale}
+	if req.Options.All {
+		result.Entries = make([]ListEntry, 0, len(entries))
+		for _, entry := range entries {
+			le := ListEntry{
+				LineNumber: entry.LineNumber,
+				Type:       entryTypeName(entry.Type),
+				Raw:        entry.Raw,
+			}
+			if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+				le.Job = describe(entry.Job)
+			}
+			result.Entries = append(result.Entries, le)
+		}
+		return result, nil
+	}
+
+	result.Jobs = make([]ListJob, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			result.Jobs = append(result.Jobs, *describe(entry.Job))
+		}
+	}
+	return result, nil
+}
+
+func timeline(ctx context.Context, req Request, locale string) (TimelineResult, error) {
+	if req.Expression == "" && req.Crontab == "" {
+		return nil, invalid("timeline requires an expression or crontab")
+	}
+
+	view := render.DayView
+	if req.Options.View != "" {
+		parsed, err := render.ParseView(req.Options.View)
+		if err != nil {
+			return nil, invalid("%w", err)
+		}
+		view = parsed
+	}
+	loc, from, err := startTime(req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the CLI, dialects apply to single expressions and invalid crontab
+	// lines are skipped
+	var opts cronx.ParserOptions
+	var jobs []*crontab.Job
+	var skipped []crontab.SkippedLine
+	if req.Expression != "" {
+		if opts, err = parserOptions(req.Options); err != nil {
+			return nil, err
+		}
+		if _, err := cronx.NewParserWithOptions(locale, opts).Parse(req.Expression); err != nil {
+			return nil, fmt.Errorf("failed to parse expression: %w", err)
+		}
+		jobs = []*crontab.Job{{Expression: req.Expression, Command: "(single expression)", Valid: true}}
+	} else {
+		entries, err := crontab.ParseReader(strings.NewReader(req.Crontab))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab: %w", err)
+		}
+		jobs, skipped = crontab.Partition(entries)
+	}
+
+	tl := render.NewTimeline(view, render.ViewStart(view, from), timelineWidth)
+	scheduler := cronx.WithContext(ctx, cronx.NewSchedulerWithOptions(opts))
+	if err := tl.AddJobs(jobs, cronx.NewParserWithOptions(locale, opts), human.NewHumanizerForLocale(locale), scheduler); err != nil {
+		return nil, err
+	}
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+
+	result := TimelineResult(tl.RenderJSON())
+	result["schemaVersion"] = schema.Version
+	result["timezone"] = loc.String()
+	result["locale"] = locale
+	if req.Crontab != "" {
+		if skipped == nil {
+			skipped = []crontab.SkippedLine{}
+		}
+		result["skipped"] = skipped
+	}
+	return result, nil
+}
+
+// entryTypeName returns the name used for an entry type in list results
+func entryTypeName(t crontab.EntryType) string {
+	switch t {
+	case crontab.EntryTypeJob:
+		return "JOB"
+	case crontab.EntryTypeComment:
+		return "COMMENT"
+	case crontab.EntryTypeEnvVar:
+		return "ENV"
+	case crontab.EntryTypeEmpty:
+		return "EMPTY"
+	case crontab.EntryTypeInvalid:
+		return "INVALID"
+	default:
+		return "UNKNOWN"
+	}
+}
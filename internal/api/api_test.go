@@ -0,0 +1,318 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRequest(t *testing.T) {
+	t.Run("decodes a full request", func(t *testing.T) {
+		req, err := DecodeRequest(strings.NewReader(`{
+			"command": "check",
+			"crontab": "0 2 * * * /usr/bin/backup.sh\n",
+			"locale": "en",
+			"options": {"verbose": true, "enableFrequencyChecks": false}
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, CommandCheck, req.Command)
+		assert.True(t, req.Options.Verbose)
+		require.NotNil(t, req.Options.EnableFrequencyChecks)
+		assert.False(t, *req.Options.EnableFrequencyChecks)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := DecodeRequest(strings.NewReader(`{"command": "explain", "expresion": "* * * * *"}`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid request")
+	})
+
+	t.Run("rejects malformed JSON", func(t *testing.T) {
+		_, err := DecodeRequest(strings.NewReader(`{"command": `))
+		assert.Error(t, err)
+	})
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("explain", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "0 0 * * *"})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		assert.Equal(t, Version, resp.APIVersion)
+		result := resp.Result.(*ExplainResult)
+		assert.Equal(t, "At midnight every day", result.Description)
+		assert.Equal(t, "en", result.Locale)
+	})
+
+	t.Run("explain in another locale", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "0 9 * * 1-5", Locale: "fr"})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		result := resp.Result.(*ExplainResult)
+		assert.Equal(t, "À 09:00 en semaine (lun-ven)", result.Description)
+		assert.Equal(t, "fr", result.Locale)
+	})
+
+	t.Run("explain with a seconds field", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "*/10 * * * * *"})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		assert.Equal(t, "Every 10 seconds", resp.Result.(*ExplainResult).Description)
+	})
+
+	t.Run("explain with the quartz dialect", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "0 0 9 LW * ?", Options: Options{Dialect: "quartz"}})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		assert.Equal(t, "At 09:00 on the last weekday of the month", resp.Result.(*ExplainResult).Description)
+	})
+
+	t.Run("explain with the jenkins dialect", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "H 4 * * 1-5", Options: Options{Dialect: "jenkins", JenkinsJob: "my-job"}})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		result := resp.Result.(*ExplainResult)
+		assert.Equal(t, "18 4 * * 1-5", result.Resolved)
+		assert.Equal(t, "At 04:18 on weekdays (Mon-Fri)", result.Description)
+	})
+
+	t.Run("unknown dialect", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "0 0 * * *", Options: Options{Dialect: "systemd"}})
+		require.False(t, resp.OK)
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+	})
+
+	t.Run("command names are case-insensitive", func(t *testing.T) {
+		resp := Execute(Request{Command: "EXPLAIN", Expression: "@daily"})
+		assert.True(t, resp.OK)
+	})
+
+	t.Run("explain with invalid expression", func(t *testing.T) {
+		resp := Execute(Request{Command: "explain", Expression: "bad"})
+		assert.False(t, resp.OK)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, ErrExecutionFailed, resp.Error.Code)
+	})
+
+	t.Run("next from a fixed time and timezone", func(t *testing.T) {
+		resp := Execute(Request{
+			Command:    "next",
+			Expression: "0 9 * * *",
+			Options:    Options{Count: 2, From: "2025-01-01T00:00:00Z", Timezone: "America/New_York"},
+		})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		result := resp.Result.(*NextResult)
+		assert.Equal(t, "America/New_York", result.Timezone)
+		require.Len(t, result.NextRuns, 2)
+		assert.Equal(t, "2025-01-01T09:00:00-05:00", result.NextRuns[0].Timestamp)
+	})
+
+	t.Run("next defaults to ten runs", func(t *testing.T) {
+		resp := Execute(Request{Command: "next", Expression: "@hourly"})
+		require.True(t, resp.OK)
+		assert.Len(t, resp.Result.(*NextResult).NextRuns, DefaultNextCount)
+	})
+
+	t.Run("next rejects bad options", func(t *testing.T) {
+		for _, opts := range []Options{
+			{Count: MaxNextCount + 1},
+			{Timezone: "Nowhere/City"},
+			{From: "yesterday"},
+		} {
+			resp := Execute(Request{Command: "next", Expression: "@hourly", Options: opts})
+			assert.False(t, resp.OK)
+			assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+		}
+	})
+
+	t.Run("check inline crontab", func(t *testing.T) {
+		resp := Execute(Request{
+			Command: "check",
+			Crontab: "0 0 1 * 1 /usr/bin/report.sh\n99 * * * * /usr/bin/broken\n",
+		})
+		require.True(t, resp.OK)
+		result := resp.Result.(*CheckResult)
+		assert.False(t, result.Valid)
+		assert.Equal(t, 2, result.TotalJobs)
+		assert.Equal(t, 1, result.InvalidJobs)
+
+		codes := make([]string, 0, len(result.Issues))
+		for _, issue := range result.Issues {
+			codes = append(codes, issue.Code)
+		}
+		assert.Contains(t, codes, "CRON-001")
+		assert.Contains(t, codes, "CRON-003")
+	})
+
+	t.Run("check hides info issues unless verbose", func(t *testing.T) {
+		req := Request{
+			Command: "check",
+			Crontab: "0 2 * * * /usr/bin/a\n0 14 * * * /usr/bin/a\n",
+			Options: Options{SuggestConsolidation: true},
+		}
+
+		resp := Execute(req)
+		assert.Empty(t, resp.Result.(*CheckResult).Issues)
+
+		req.Options.Verbose = true
+		resp = Execute(req)
+		assert.NotEmpty(t, resp.Result.(*CheckResult).Issues)
+	})
+
+	t.Run("check rejects bad overlap window", func(t *testing.T) {
+		resp := Execute(Request{Command: "check", Expression: "* * * * *", Options: Options{WarnOnOverlap: true, OverlapWindow: "soon"}})
+		assert.False(t, resp.OK)
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+	})
+
+	t.Run("check with a custom horizon", func(t *testing.T) {
+		resp := Execute(Request{Command: "check", Expression: "0 0 29 2 *", Options: Options{Horizon: "1d", Verbose: true}})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		result := resp.Result.(*CheckResult)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, "CRON-014", result.Issues[0].Code)
+
+		resp = Execute(Request{Command: "check", Expression: "0 0 29 2 *", Options: Options{Horizon: "later"}})
+		assert.False(t, resp.OK)
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+	})
+
+	t.Run("check requires input", func(t *testing.T) {
+		resp := Execute(Request{Command: "check"})
+		assert.False(t, resp.OK)
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		resp := Execute(Request{Command: "list", Crontab: "# c\nSHELL=/bin/sh\n0 2 * * * /usr/bin/backup.sh # nightly\n"})
+		require.True(t, resp.OK)
+		result := resp.Result.(*ListResult)
+		require.Len(t, result.Jobs, 1)
+		assert.Equal(t, 3, result.Jobs[0].LineNumber)
+		assert.Equal(t, "/usr/bin/backup.sh", result.Jobs[0].Command)
+		assert.NotEmpty(t, result.Jobs[0].Description)
+		assert.Nil(t, result.Entries)
+	})
+
+	t.Run("list all entries", func(t *testing.T) {
+		resp := Execute(Request{Command: "list", Crontab: "# c\n0 2 * * * /usr/bin/backup.sh\n", Options: Options{All: true}})
+		require.True(t, resp.OK)
+		result := resp.Result.(*ListResult)
+		require.Len(t, result.Entries, 2)
+		assert.Equal(t, "COMMENT", result.Entries[0].Type)
+		assert.Equal(t, "JOB", result.Entries[1].Type)
+		require.NotNil(t, result.Entries[1].Job)
+	})
+
+	t.Run("timeline of an expression", func(t *testing.T) {
+		resp := Execute(Request{Command: "timeline", Expression: "0 */6 * * *", Options: Options{From: "2025-01-13T10:30:00Z", Timezone: "UTC"}})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		result := resp.Result.(TimelineResult)
+		assert.Equal(t, "day", result["view"])
+		assert.Equal(t, "2025-01-13T00:00:00Z", result["startTime"])
+		assert.Equal(t, "UTC", result["timezone"])
+		jobs := result["jobs"].([]map[string]interface{})
+		require.Len(t, jobs, 1)
+		assert.Equal(t, Execute(Request{Command: "explain", Expression: "0 */6 * * *"}).Result.(*ExplainResult).Description, jobs[0]["description"])
+		assert.Len(t, jobs[0]["runs"], 3)
+		assert.NotContains(t, result, "skipped")
+	})
+
+	t.Run("timeline of a crontab", func(t *testing.T) {
+		resp := Execute(Request{Command: "timeline", Crontab: "30 * * * * /usr/bin/a.sh\n30 * * * * /usr/bin/b.sh\nbad line\n", Options: Options{From: "2025-01-13T10:30:00Z", View: "hour"}})
+		require.True(t, resp.OK, "%+v", resp.Error)
+		result := resp.Result.(TimelineResult)
+		assert.Equal(t, "hour", result["view"])
+		assert.Len(t, result["jobs"], 2)
+		assert.Len(t, result["skipped"], 1)
+		assert.Len(t, result["overlaps"], 1)
+	})
+
+	t.Run("timeline rejects bad options", func(t *testing.T) {
+		for _, req := range []Request{
+			{Command: "timeline"},
+			{Command: "timeline", Expression: "@daily", Options: Options{View: "year"}},
+			{Command: "timeline", Expression: "@daily", Options: Options{From: "yesterday"}},
+		} {
+			resp := Execute(req)
+			require.False(t, resp.OK)
+			assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+		}
+		resp := Execute(Request{Command: "timeline", Expression: "invalid"})
+		require.False(t, resp.OK)
+		assert.Equal(t, ErrExecutionFailed, resp.Error.Code)
+	})
+
+	t.Run("missing and unknown commands", func(t *testing.T) {
+		resp := Execute(Request{})
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+
+		resp = Execute(Request{Command: "frobnicate"})
+		assert.Equal(t, ErrUnknownCommand, resp.Error.Code)
+		assert.Equal(t, "frobnicate", resp.Command)
+	})
+}
+
+func TestResponse_JSON(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		data, err := json.Marshal(Execute(Request{Command: "explain", Expression: "@hourly"}))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"apiVersion":"v1"`)
+		assert.Contains(t, string(data), `"ok":true`)
+		assert.NotContains(t, string(data), `"error"`)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		data, err := json.Marshal(Execute(Request{Command: "nope"}))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"ok":false`)
+		assert.Contains(t, string(data), `"code":"unknown_command"`)
+		assert.NotContains(t, string(data), `"result"`)
+	})
+}
+
+// recordingObserver records the requests reported by ExecuteObserved
+type recordingObserver struct {
+	commands []string
+	oks      []bool
+}
+
+func (o *recordingObserver) ObserveRequest(command string, ok bool, duration time.Duration) {
+	o.commands = append(o.commands, command)
+	o.oks = append(o.oks, ok)
+}
+
+func TestExecuteObserved(t *testing.T) {
+	obs := &recordingObserver{}
+
+	resp := ExecuteObserved(Request{Command: "EXPLAIN", Expression: "0 * * * *"}, obs)
+	assert.True(t, resp.OK)
+	resp = ExecuteObserved(Request{Command: "next", Expression: "invalid"}, obs)
+	assert.False(t, resp.OK)
+
+	assert.Equal(t, []string{"explain", "next"}, obs.commands)
+	assert.Equal(t, []bool{true, false}, obs.oks)
+
+	// A nil observer behaves like Execute
+	assert.True(t, ExecuteObserved(Request{Command: "explain", Expression: "@daily"}, nil).OK)
+}
+
+func TestExecuteContext(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("request timed out"))
+
+	for _, req := range []Request{
+		{Command: "next", Expression: "* * * * *"},
+		{Command: "check", Crontab: "0 * * * * /usr/bin/a.sh\n"},
+		{Command: "timeline", Expression: "* * * * *"},
+	} {
+		resp := ExecuteContext(ctx, req, nil)
+		require.False(t, resp.OK, req.Command)
+		assert.Equal(t, ErrExecutionFailed, resp.Error.Code)
+		assert.Contains(t, resp.Error.Message, "request timed out")
+	}
+
+	// Commands without scheduling complete
+	assert.True(t, ExecuteContext(ctx, Request{Command: "explain", Expression: "@daily"}, nil).OK)
+}
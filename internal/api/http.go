@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRequestBytes is the largest request body the HTTP API reads
+const DefaultMaxRequestBytes = 10 << 20
+
+// HandlerOptions configures the HTTP API handler
+type HandlerOptions struct {
+	Locale          string        // Locale of requests without one (default: en)
+	Timeout         time.Duration // Time a request may run before failing (0: no limit)
+	MaxRequestBytes int64         // Largest request body (default: DefaultMaxRequestBytes)
+	AllowOrigin     string        // Access-Control-Allow-Origin for browser clients (empty: none)
+	Observer        Observer      // Notified of every request, if non-nil
+}
+
+// NewHandler returns the HTTP API handler. It serves:
+//
+//	POST /v1/exec        a Request, as read by "cronkit exec"
+//	POST /v1/{command}   a Request for one command, without the command field
+//	GET  /openapi.json   the OpenAPI description of the API
+//	GET  /healthz        "ok" while the server is up
+//
+// Responses are Response documents. Failed requests are answered with
+// 400 Bad Request (invalid_request), 404 Not Found (unknown_command) or
+// 422 Unprocessable Entity (execution_failed).
+func NewHandler(opts HandlerOptions) http.Handler {
+	if opts.MaxRequestBytes <= 0 {
+		opts.MaxRequestBytes = DefaultMaxRequestBytes
+	}
+	h := &handler{opts: opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/exec", h.exec)
+	mux.HandleFunc("POST /v1/{command}", h.command)
+	mux.HandleFunc("GET /openapi.json", h.openAPI)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	if opts.AllowOrigin == "" {
+		return mux
+	}
+	return h.cors(mux)
+}
+
+// handler serves the HTTP API
+type handler struct {
+	opts HandlerOptions
+}
+
+// exec runs a request naming its command
+func (h *handler) exec(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decode(w, r)
+	if ok {
+		h.execute(w, r, req)
+	}
+}
+
+// command runs a request for the command in the path
+func (h *handler) command(w http.ResponseWriter, r *http.Request) {
+	command := r.PathValue("command")
+	if !slices.Contains(Commands(), command) {
+		h.write(w, fail(Response{APIVersion: Version, Command: command}, ErrUnknownCommand,
+			fmt.Sprintf("unknown command %q (supported: %s)", command, strings.Join(Commands(), ", "))))
+		return
+	}
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	if req.Command != "" && !strings.EqualFold(req.Command, command) {
+		h.write(w, fail(Response{APIVersion: Version, Command: command}, ErrInvalidRequest,
+			fmt.Sprintf("request command %q does not match the path /v1/%s", req.Command, command)))
+		return
+	}
+	req.Command = command
+	h.execute(w, r, req)
+}
+
+// decode reads the request body, answering malformed requests itself
+func (h *handler) decode(w http.ResponseWriter, r *http.Request) (Request, bool) {
+	req, err := DecodeRequest(http.MaxBytesReader(w, r.Body, h.opts.MaxRequestBytes))
+	if err != nil {
+		h.write(w, fail(Response{APIVersion: Version}, ErrInvalidRequest, err.Error()))
+		return Request{}, false
+	}
+	return req, true
+}
+
+// execute runs a decoded request within the request timeout
+func (h *handler) execute(w http.ResponseWriter, r *http.Request, req Request) {
+	if req.Locale == "" {
+		req.Locale = h.opts.Locale
+	}
+	ctx := r.Context()
+	if h.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, h.opts.Timeout,
+			fmt.Errorf("request timed out after %s", h.opts.Timeout))
+		defer cancel()
+	}
+	h.write(w, ExecuteContext(ctx, req, h.opts.Observer))
+}
+
+// write writes a response with the status of its error code
+func (h *handler) write(w http.ResponseWriter, resp Response) {
+	status := http.StatusOK
+	if resp.Error != nil {
+		status = errorStatus(resp.Error.Code)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(resp)
+}
+
+// errorStatus returns the HTTP status of an error code
+func errorStatus(code string) int {
+	switch code {
+	case ErrInvalidRequest:
+		return http.StatusBadRequest
+	case ErrUnknownCommand:
+		return http.StatusNotFound
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
+
+// openAPI serves the OpenAPI description
+func (h *handler) openAPI(w http.ResponseWriter, _ *http.Request) {
+	spec, err := OpenAPI()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(spec)
+}
+
+// cors lets browser clients on AllowOrigin call the API, answering their
+// preflight requests
+func (h *handler) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", h.opts.AllowOrigin)
+		if h.opts.AllowOrigin != "*" {
+			w.Header().Add("Vary", "Origin")
+		}
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// post sends a request to the handler and decodes its response
+func post(t *testing.T, handler http.Handler, path, body string) (int, Response) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+	return rec.Code, resp
+}
+
+func TestNewHandler(t *testing.T) {
+	handler := NewHandler(HandlerOptions{})
+
+	t.Run("exec", func(t *testing.T) {
+		status, resp := post(t, handler, "/v1/exec", `{"command": "explain", "expression": "0 0 * * *"}`)
+		assert.Equal(t, http.StatusOK, status)
+		require.True(t, resp.OK, "%+v", resp.Error)
+		assert.Equal(t, "explain", resp.Command)
+		assert.Equal(t, "At midnight every day", resp.Result.(map[string]interface{})["description"])
+	})
+
+	t.Run("command in the path", func(t *testing.T) {
+		for _, command := range Commands() {
+			status, resp := post(t, handler, "/v1/"+command, `{"expression": "0 0 * * *", "crontab": "0 0 * * * /usr/bin/a.sh\n"}`)
+			assert.Equal(t, http.StatusOK, status, command)
+			assert.True(t, resp.OK, "%s: %+v", command, resp.Error)
+			assert.Equal(t, command, resp.Command)
+		}
+	})
+
+	t.Run("request command must match the path", func(t *testing.T) {
+		status, resp := post(t, handler, "/v1/next", `{"command": "NEXT", "expression": "@daily"}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, resp.OK)
+
+		status, resp = post(t, handler, "/v1/next", `{"command": "explain", "expression": "@daily"}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+	})
+
+	t.Run("statuses of failures", func(t *testing.T) {
+		status, resp := post(t, handler, "/v1/explain", `{"expresion": "@daily"}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrInvalidRequest, resp.Error.Code)
+
+		status, resp = post(t, handler, "/v1/frobnicate", `{}`)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, ErrUnknownCommand, resp.Error.Code)
+
+		status, resp = post(t, handler, "/v1/exec", `{"command": "frobnicate"}`)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, ErrUnknownCommand, resp.Error.Code)
+
+		status, resp = post(t, handler, "/v1/explain", `{"expression": "invalid"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, ErrExecutionFailed, resp.Error.Code)
+	})
+
+	t.Run("other methods", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/explain", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("openapi and health", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		spec, err := OpenAPI()
+		require.NoError(t, err)
+		assert.Equal(t, string(spec), rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ok\n", rec.Body.String())
+	})
+}
+
+func TestNewHandler_Options(t *testing.T) {
+	t.Run("default locale", func(t *testing.T) {
+		handler := NewHandler(HandlerOptions{Locale: "fr"})
+		_, resp := post(t, handler, "/v1/explain", `{"expression": "0 9 * * 1-5"}`)
+		require.True(t, resp.OK)
+		assert.Equal(t, "fr", resp.Result.(map[string]interface{})["locale"])
+
+		_, resp = post(t, handler, "/v1/explain", `{"expression": "0 9 * * 1-5", "locale": "en"}`)
+		assert.Equal(t, "en", resp.Result.(map[string]interface{})["locale"])
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		handler := NewHandler(HandlerOptions{Timeout: time.Nanosecond})
+		status, resp := post(t, handler, "/v1/timeline", `{"expression": "* * * * * *", "options": {"view": "month"}}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Contains(t, resp.Error.Message, "request timed out after 1ns")
+	})
+
+	t.Run("request size", func(t *testing.T) {
+		handler := NewHandler(HandlerOptions{MaxRequestBytes: 64})
+		status, resp := post(t, handler, "/v1/check", `{"crontab": "`+strings.Repeat(`# comment\n`, 10)+`"}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, resp.Error.Message, "too large")
+	})
+
+	t.Run("observer", func(t *testing.T) {
+		obs := &recordingObserver{}
+		handler := NewHandler(HandlerOptions{Observer: obs})
+		post(t, handler, "/v1/next", `{"expression": "@daily"}`)
+		assert.Equal(t, []string{"next"}, obs.commands)
+	})
+
+	t.Run("cors", func(t *testing.T) {
+		handler := NewHandler(HandlerOptions{AllowOrigin: "https://ui.example.com"})
+		req := httptest.NewRequest(http.MethodOptions, "/v1/explain", nil)
+		req.Header.Set("Origin", "https://ui.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://ui.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), "POST")
+
+		_, resp := post(t, handler, "/v1/explain", `{"expression": "@daily"}`)
+		assert.True(t, resp.OK)
+
+		rec = httptest.NewRecorder()
+		NewHandler(HandlerOptions{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/schema"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification OpenAPI follows
+const OpenAPIVersion = "3.1.0"
+
+// object is a JSON object of the OpenAPI description
+type object = map[string]interface{}
+
+// commandSummaries describes the operation of each command
+var commandSummaries = map[string]string{
+	CommandExplain:  "Describe a cron expression in plain language",
+	CommandNext:     "List the next runs of a cron expression",
+	CommandCheck:    "Validate a cron expression or crontab",
+	CommandList:     "List the jobs of a crontab",
+	CommandTimeline: "Compute the runs and overlaps of jobs over a day, hour, week or month",
+}
+
+// commandResults holds the result type of each command
+var commandResults = map[string]reflect.Type{
+	CommandExplain:  reflect.TypeFor[ExplainResult](),
+	CommandNext:     reflect.TypeFor[NextResult](),
+	CommandCheck:    reflect.TypeFor[CheckResult](),
+	CommandList:     reflect.TypeFor[ListResult](),
+	CommandTimeline: reflect.TypeFor[TimelineResult](),
+}
+
+// OpenAPI returns the OpenAPI description of the HTTP API served by
+// NewHandler, as indented JSON. Schemas are generated from the request and
+// result types, so the description cannot drift from the API; the timeline
+// result is the JSON schema of "cronkit timeline --json".
+func OpenAPI() ([]byte, error) {
+	timeline, err := schema.Get(CommandTimeline)
+	if err != nil {
+		return nil, err
+	}
+	g := &schemaGenerator{schemas: object{}}
+	var timelineSchema object
+	if err := json.Unmarshal(timeline, &timelineSchema); err != nil {
+		return nil, fmt.Errorf("invalid timeline schema: %w", err)
+	}
+	g.schemas["TimelineResult"] = timelineSchema
+
+	request := g.ref(reflect.TypeFor[Request]())
+	response := g.ref(reflect.TypeFor[Response]())
+
+	// Requests with unknown fields are rejected; results may gain fields
+	// within a version
+	for _, name := range []string{"Request", "Options"} {
+		g.schemas[name].(object)["additionalProperties"] = false
+	}
+	g.schemas["Request"].(object)["properties"].(object)["command"] = object{"enum": Commands()}
+
+	// Requests to /v1/{command} may leave out the command
+	commandRequest := object{}
+	for key, value := range g.schemas["Request"].(object) {
+		commandRequest[key] = value
+	}
+	commandRequest["required"] = slices.DeleteFunc(slices.Clone(commandRequest["required"].([]string)),
+		func(field string) bool { return field == "command" })
+	commandRequest["description"] = "A request whose command is given by the path; a command field must match it"
+	g.schemas["CommandRequest"] = commandRequest
+
+	failures := object{
+		"400": jsonResponse("Invalid request (invalid_request)", response),
+		"422": jsonResponse("The command failed, e.g. on an invalid expression or after the request timeout (execution_failed)", response),
+	}
+	paths := object{
+		"/v1/exec": object{"post": object{
+			"operationId": "exec",
+			"summary":     "Run a request naming its command, as read by cronkit exec",
+			"requestBody": jsonBody(request),
+			"responses": merge(failures, object{
+				"200": jsonResponse("The command succeeded", response),
+				"404": jsonResponse("Unknown command (unknown_command)", response),
+			}),
+		}},
+		"/openapi.json": object{"get": object{
+			"operationId": "openapi",
+			"summary":     "This OpenAPI description",
+			"responses":   object{"200": jsonResponse("The OpenAPI description", object{"type": "object"})},
+		}},
+		"/healthz": object{"get": object{
+			"operationId": "healthz",
+			"summary":     "Report that the server is up",
+			"responses": object{"200": object{
+				"description": "The server is up",
+				"content":     object{"text/plain": object{"schema": object{"const": "ok\n"}}},
+			}},
+		}},
+	}
+	for _, command := range Commands() {
+		result := object{"allOf": []interface{}{response, object{
+			"properties": object{"result": g.ref(commandResults[command])},
+		}}}
+		paths["/v1/"+command] = object{"post": object{
+			"operationId": command,
+			"summary":     commandSummaries[command],
+			"requestBody": jsonBody(object{"$ref": "#/components/schemas/CommandRequest"}),
+			"responses":   merge(failures, object{"200": jsonResponse("The command succeeded", result)}),
+		}}
+	}
+
+	spec := object{
+		"openapi": OpenAPIVersion,
+		"info": object{
+			"title":       "cronkit API",
+			"version":     Version,
+			"description": "Explain, schedule, validate and visualize cron expressions and crontabs. Requests and responses are the documents read and written by cronkit exec.",
+		},
+		"paths":      paths,
+		"components": object{"schemas": g.schemas},
+	}
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// jsonBody returns a required JSON request body
+func jsonBody(s object) object {
+	return object{"required": true, "content": object{"application/json": object{"schema": s}}}
+}
+
+// jsonResponse returns a JSON response
+func jsonResponse(description string, s object) object {
+	return object{"description": description, "content": object{"application/json": object{"schema": s}}}
+}
+
+// merge returns the union of two objects
+func merge(a, b object) object {
+	merged := object{}
+	for key, value := range a {
+		merged[key] = value
+	}
+	for key, value := range b {
+		merged[key] = value
+	}
+	return merged
+}
+
+// schemaGenerator generates JSON schemas of Go types, adding named struct
+// types to the components it references
+type schemaGenerator struct {
+	schemas object
+}
+
+// ref returns a reference to the component schema of a named type,
+// generating it on first use
+func (g *schemaGenerator) ref(t reflect.Type) object {
+	ref := object{"$ref": "#/components/schemas/" + t.Name()}
+	if _, ok := g.schemas[t.Name()]; !ok {
+		g.schemas[t.Name()] = object{} // Reserved, in case the type refers to itself
+		g.schemas[t.Name()] = g.object(t)
+	}
+	return ref
+}
+
+// schema returns the schema of a type
+func (g *schemaGenerator) schema(t reflect.Type) object {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		return g.ref(t)
+	case reflect.Slice, reflect.Array:
+		return object{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return object{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return object{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}
+	default:
+		return object{} // Any value
+	}
+}
+
+// object returns the schema of a struct: its JSON fields as properties,
+// those without omitempty being required
+func (g *schemaGenerator) object(t reflect.Type) object {
+	properties := object{}
+	required := []string{}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	return object{"type": "object", "properties": properties, "required": required}
+}
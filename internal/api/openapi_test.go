@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI(t *testing.T) {
+	data, err := OpenAPI()
+	require.NoError(t, err)
+
+	var spec struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, OpenAPIVersion, spec.OpenAPI)
+
+	t.Run("every command has a path", func(t *testing.T) {
+		for _, command := range Commands() {
+			assert.Contains(t, spec.Paths, "/v1/"+command)
+			assert.Contains(t, spec.Paths["/v1/"+command], "post")
+		}
+		assert.Contains(t, spec.Paths, "/v1/exec")
+		assert.Contains(t, spec.Paths["/openapi.json"], "get")
+	})
+
+	t.Run("schemas follow the types", func(t *testing.T) {
+		request := spec.Components.Schemas["Request"]
+		assert.Equal(t, []interface{}{"command"}, request["required"])
+		assert.Equal(t, false, request["additionalProperties"])
+		assert.Empty(t, spec.Components.Schemas["CommandRequest"]["required"])
+
+		next := spec.Components.Schemas["NextResult"]
+		assert.Equal(t, map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"$ref": "#/components/schemas/NextRun"},
+		}, next["properties"].(map[string]interface{})["nextRuns"])
+
+		options := spec.Components.Schemas["Options"]["properties"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"type": "boolean"}, options["enableFrequencyChecks"])
+		assert.Contains(t, options, "view")
+	})
+
+	t.Run("every reference resolves", func(t *testing.T) {
+		var walk func(v interface{})
+		walk = func(v interface{}) {
+			switch v := v.(type) {
+			case map[string]interface{}:
+				ref, _ := v["$ref"].(string)
+				if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+					assert.Contains(t, spec.Components.Schemas, name)
+				}
+				for _, child := range v {
+					walk(child)
+				}
+			case []interface{}:
+				for _, child := range v {
+					walk(child)
+				}
+			}
+		}
+		var doc interface{}
+		require.NoError(t, json.Unmarshal(data, &doc))
+		walk(doc)
+	})
+
+	t.Run("timeline result is the timeline schema", func(t *testing.T) {
+		assert.Equal(t, "cronkit/v1/timeline.json", spec.Components.Schemas["TimelineResult"]["$id"])
+	})
+}
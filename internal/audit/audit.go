@@ -0,0 +1,202 @@
+// Package audit compares the job starts logged by a cron daemon with the
+// runs a crontab schedules, and reports the runs that were missed and the
+// runs that were not expected
+package audit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/runner"
+)
+
+// DefaultTolerance is how long after its scheduled minute a logged start
+// still counts as the scheduled run
+const DefaultTolerance = time.Minute
+
+// maxExpected bounds the scheduled runs computed per job
+const maxExpected = 100000
+
+// JobReport holds the expected and logged runs of one job
+type JobReport struct {
+	Job        *crontab.Job
+	Expected   int         // Runs scheduled in the audited window
+	Ran        int         // Scheduled runs found in the log
+	Missed     []time.Time // Scheduled runs not found in the log
+	Unexpected []Execution // Logged runs at times the job is not scheduled
+}
+
+// Report is the result of Audit
+type Report struct {
+	From, To   time.Time   // Audited window
+	Jobs       []JobReport // In crontab order, without @reboot jobs
+	Unknown    []Execution // Logged runs of commands that are no job of the crontab
+	Expected   int         // Total expected runs
+	Missed     int         // Total missed runs
+	Unexpected int         // Total unexpected runs, including Unknown
+}
+
+// Options configures Audit
+type Options struct {
+	From, To  time.Time       // Audited window (zero: the time span of the log)
+	Tolerance time.Duration   // Allowed start delay (default: DefaultTolerance)
+	Location  *time.Location  // Time zone of jobs without CRON_TZ= (default: time.Local)
+	Scheduler cronx.Scheduler // Computes scheduled runs (default: cronx.NewScheduler())
+}
+
+// Audit correlates the executions of a cron daemon log with the runs the
+// jobs scheduled between opts.From and opts.To. A logged run belongs to a
+// job when its command is the job's, and its user the job's user if the
+// job has one; it is the job's scheduled run when it started at most
+// opts.Tolerance after it. Scheduled runs too close to the end of the
+// window to have been logged are not expected.
+func Audit(jobs []*crontab.Job, log *Log, opts Options) (*Report, error) {
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = DefaultTolerance
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.Scheduler == nil {
+		opts.Scheduler = cronx.NewScheduler()
+	}
+	if opts.From.IsZero() {
+		opts.From = log.First
+	}
+	if opts.To.IsZero() {
+		opts.To = log.Last
+	}
+
+	var execs []Execution
+	for _, exec := range log.Executions {
+		if !exec.Time.Before(opts.From) && !exec.Time.After(opts.To) {
+			execs = append(execs, exec)
+		}
+	}
+	slices.SortStableFunc(execs, func(a, b Execution) int {
+		return a.Time.Compare(b.Time)
+	})
+	used := make([]bool, len(execs))
+
+	report := &Report{From: opts.From, To: opts.To}
+	for _, job := range jobs {
+		if isReboot(job) {
+			// Runs at boot are expected whenever they happen
+			for i, exec := range execs {
+				if matches(job, exec) {
+					used[i] = true
+				}
+			}
+			continue
+		}
+
+		scheduled, err := expected(job, opts)
+		if err != nil {
+			return nil, err
+		}
+		jr := JobReport{Job: job}
+		next := 0
+		for _, s := range scheduled {
+			for next < len(execs) && execs[next].Time.Before(s) {
+				next++
+			}
+			found := false
+			for i := next; i < len(execs) && !execs[i].Time.After(s.Add(opts.Tolerance)); i++ {
+				if used[i] || !matches(job, execs[i]) {
+					continue
+				}
+				used[i], found = true, true
+				next = i + 1
+				break
+			}
+			switch {
+			case found:
+				jr.Expected++
+				jr.Ran++
+			case s.Before(opts.From) || s.Add(opts.Tolerance).After(opts.To):
+				// Not missed: the run may have been logged outside the window
+			default:
+				jr.Expected++
+				jr.Missed = append(jr.Missed, s)
+			}
+		}
+		report.Jobs = append(report.Jobs, jr)
+	}
+
+	// Logged runs left over ran at unexpected times
+	for i, exec := range execs {
+		if used[i] {
+			continue
+		}
+		known := false
+		for j := range report.Jobs {
+			if matches(report.Jobs[j].Job, exec) {
+				report.Jobs[j].Unexpected = append(report.Jobs[j].Unexpected, exec)
+				known = true
+				break
+			}
+		}
+		if !known {
+			report.Unknown = append(report.Unknown, exec)
+		}
+	}
+
+	report.Unexpected = len(report.Unknown)
+	for _, jr := range report.Jobs {
+		report.Expected += jr.Expected
+		report.Missed += len(jr.Missed)
+		report.Unexpected += len(jr.Unexpected)
+	}
+	return report, nil
+}
+
+// expected returns the runs of job scheduled from opts.Tolerance before
+// opts.From to opts.To, so that runs logged at the start of the window are
+// matched to their scheduled minute
+func expected(job *crontab.Job, opts Options) ([]time.Time, error) {
+	loc, err := job.Location(opts.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	from := opts.From.Add(-opts.Tolerance).In(loc)
+	for {
+		batch, err := opts.Scheduler.Next(job.Expression, from, 1000)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: failed to calculate scheduled runs: %w", job.LineNumber, err)
+		}
+		for _, t := range batch {
+			if t.IsZero() || t.After(opts.To) {
+				return times, nil
+			}
+			if len(times) == maxExpected {
+				return nil, fmt.Errorf("line %d: more than %d scheduled runs to audit (audit a shorter window)", job.LineNumber, maxExpected)
+			}
+			times = append(times, t)
+			from = t
+		}
+	}
+}
+
+// matches reports whether exec is a run of job. Cron may log a command
+// with or without the standard input that follows its first %.
+func matches(job *crontab.Job, exec Execution) bool {
+	if job.User != "" && exec.User != job.User {
+		return false
+	}
+	if exec.Command == job.Command {
+		return true
+	}
+	command, _ := runner.SplitCommand(job.Command)
+	return exec.Command == strings.TrimSpace(command)
+}
+
+// isReboot reports whether job runs at boot rather than on a schedule
+func isReboot(job *crontab.Job) bool {
+	return strings.HasPrefix(job.Expression, "@reboot")
+}
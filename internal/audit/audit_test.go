@@ -0,0 +1,191 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(hour, min, sec int) time.Time {
+	return time.Date(2026, 10, 16, hour, min, sec, 0, time.UTC)
+}
+
+func TestParseLine(t *testing.T) {
+	now := at(12, 0, 0)
+
+	tests := []struct {
+		name    string
+		line    string
+		want    Execution
+		wantErr bool
+	}{
+		{
+			name: "debian syslog",
+			line: "Oct 16 02:00:01 web1 CRON[1234]: (root) CMD (/usr/bin/backup.sh --full)",
+			want: Execution{Time: at(2, 0, 1), User: "root", Command: "/usr/bin/backup.sh --full"},
+		},
+		{
+			name: "cronie",
+			line: "Oct  6 02:00:01 web1 CROND[99]: (app) CMD (run-parts /etc/cron.hourly)",
+			want: Execution{Time: at(2, 0, 1).AddDate(0, 0, -10), User: "app", Command: "run-parts /etc/cron.hourly"},
+		},
+		{
+			name: "rsyslog rfc3339",
+			line: "2026-10-16T04:00:02.123456+02:00 web1 CRON[7]: (root) CMD (echo (nested))",
+			want: Execution{Time: at(2, 0, 2).Add(123456 * time.Microsecond), User: "root", Command: "echo (nested)"},
+		},
+		{
+			name: "journalctl short-iso",
+			line: "2026-10-16T02:00:02+0000 web1 CRON[7]: (root) CMD (true)",
+			want: Execution{Time: at(2, 0, 2), User: "root", Command: "true"},
+		},
+		{
+			name: "last year",
+			line: "Dec 31 23:59:01 web1 CRON[1]: (root) CMD (true)",
+			want: Execution{Time: time.Date(2025, 12, 31, 23, 59, 1, 0, time.UTC), User: "root", Command: "true"},
+		},
+		{name: "session line", line: "Oct 16 02:00:01 web1 CRON[1234]: pam_unix(cron:session): session opened for user root", wantErr: true},
+		{name: "other program", line: "Oct 16 02:00:01 web1 sshd[1]: (root) CMD (true)", wantErr: true},
+		{name: "no timestamp", line: "CRON[1]: (root) CMD (true)", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLine(tt.line, time.UTC, now)
+			if tt.wantErr {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.True(t, tt.want.Time.Equal(got.Time), "time %s, want %s", got.Time, tt.want.Time)
+			assert.Equal(t, tt.want.User, got.User)
+			assert.Equal(t, tt.want.Command, got.Command)
+		})
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	input := strings.Join([]string{
+		"Oct 16 00:00:00 web1 systemd[1]: Starting daily tasks",
+		"Oct 16 00:00:01 web1 CRON[1]: (root) CMD (/usr/bin/hourly.sh)",
+		"garbage",
+		"Oct 16 01:30:00 web1 kernel: eth0 up",
+	}, "\n")
+
+	log, err := ParseLog(strings.NewReader(input), time.UTC, at(12, 0, 0))
+	require.NoError(t, err)
+	require.Len(t, log.Executions, 1)
+	assert.Equal(t, 2, log.Executions[0].Line)
+	assert.True(t, log.First.Equal(at(0, 0, 0)))
+	assert.True(t, log.Last.Equal(at(1, 30, 0)))
+}
+
+func TestAudit(t *testing.T) {
+	hourly := &crontab.Job{LineNumber: 1, Expression: "0 * * * *", Command: "/usr/bin/hourly.sh"}
+	backup := &crontab.Job{LineNumber: 2, Expression: "30 2 * * *", Command: "/usr/bin/backup.sh", User: "root"}
+	mail := &crontab.Job{LineNumber: 3, Expression: "15 * * * *", Command: "mail -s hi ops%Body"}
+	boot := &crontab.Job{LineNumber: 4, Expression: "@reboot", Command: "/usr/bin/start.sh"}
+	jobs := []*crontab.Job{hourly, backup, mail, boot}
+
+	exec := func(t time.Time, user, command string) Execution {
+		return Execution{Time: t, User: user, Command: command}
+	}
+	log := &Log{
+		First: at(0, 0, 0),
+		Last:  at(3, 20, 0),
+		Executions: []Execution{
+			exec(at(0, 0, 1), "app", hourly.Command),
+			exec(at(0, 15, 1), "app", "mail -s hi ops"),
+			exec(at(1, 15, 2), "app", mail.Command),
+			exec(at(2, 0, 2), "app", hourly.Command),
+			exec(at(2, 15, 1), "app", "mail -s hi ops"),
+			exec(at(2, 30, 1), "root", backup.Command),
+			exec(at(2, 47, 0), "app", hourly.Command), // Not scheduled
+			exec(at(3, 0, 1), "app", hourly.Command),
+			exec(at(3, 5, 0), "app", "/usr/bin/cleanup.sh"), // No such job
+			exec(at(3, 6, 0), "app", boot.Command),
+			exec(at(3, 15, 1), "app", mail.Command),
+		},
+	}
+
+	report, err := Audit(jobs, log, Options{Location: time.UTC})
+	require.NoError(t, err)
+	assert.True(t, report.From.Equal(at(0, 0, 0)))
+	assert.True(t, report.To.Equal(at(3, 20, 0)))
+	require.Len(t, report.Jobs, 3, "@reboot jobs have no schedule")
+
+	// The 01:00 run is missing
+	assert.Equal(t, 4, report.Jobs[0].Expected)
+	assert.Equal(t, 3, report.Jobs[0].Ran)
+	assert.Equal(t, []time.Time{at(1, 0, 0)}, report.Jobs[0].Missed)
+	require.Len(t, report.Jobs[0].Unexpected, 1)
+	assert.True(t, report.Jobs[0].Unexpected[0].Time.Equal(at(2, 47, 0)))
+
+	assert.Equal(t, 1, report.Jobs[1].Ran)
+	assert.Empty(t, report.Jobs[1].Missed)
+
+	// Logged with or without its standard input
+	assert.Equal(t, 4, report.Jobs[2].Ran)
+	assert.Empty(t, report.Jobs[2].Missed)
+
+	require.Len(t, report.Unknown, 1)
+	assert.Equal(t, "/usr/bin/cleanup.sh", report.Unknown[0].Command)
+
+	assert.Equal(t, 9, report.Expected)
+	assert.Equal(t, 1, report.Missed)
+	assert.Equal(t, 2, report.Unexpected)
+
+	t.Run("user must match", func(t *testing.T) {
+		other := &Log{First: at(2, 0, 0), Last: at(3, 0, 0), Executions: []Execution{exec(at(2, 30, 0), "app", backup.Command)}}
+		report, err := Audit([]*crontab.Job{backup}, other, Options{Location: time.UTC})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2, 30, 0)}, report.Jobs[0].Missed)
+		assert.Len(t, report.Unknown, 1)
+	})
+
+	t.Run("late start beyond tolerance", func(t *testing.T) {
+		late := &Log{First: at(2, 0, 0), Last: at(3, 0, 0), Executions: []Execution{exec(at(2, 33, 0), "root", backup.Command)}}
+		report, err := Audit([]*crontab.Job{backup}, late, Options{Location: time.UTC})
+		require.NoError(t, err)
+		assert.Len(t, report.Jobs[0].Missed, 1)
+		assert.Len(t, report.Jobs[0].Unexpected, 1)
+
+		report, err = Audit([]*crontab.Job{backup}, late, Options{Location: time.UTC, Tolerance: 5 * time.Minute})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Jobs[0].Ran)
+		assert.Zero(t, report.Unexpected)
+	})
+
+	t.Run("runs due at the end of the window are not missed", func(t *testing.T) {
+		short := &Log{First: at(2, 0, 0), Last: at(2, 30, 30)}
+		report, err := Audit([]*crontab.Job{backup}, short, Options{Location: time.UTC})
+		require.NoError(t, err)
+		assert.Zero(t, report.Expected)
+		assert.Zero(t, report.Missed)
+	})
+
+	t.Run("window from options", func(t *testing.T) {
+		report, err := Audit([]*crontab.Job{hourly}, log, Options{Location: time.UTC, From: at(1, 30, 0)})
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Jobs[0].Expected)
+		assert.Empty(t, report.Jobs[0].Missed)
+	})
+
+	t.Run("job time zone", func(t *testing.T) {
+		tz := &crontab.Job{LineNumber: 1, Expression: "30 4 * * *", Command: backup.Command, Timezone: "Europe/Paris"}
+		report, err := Audit([]*crontab.Job{tz}, log, Options{Location: time.UTC})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Jobs[0].Ran)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		bad := &crontab.Job{LineNumber: 7, Expression: "61 * * * *", Command: "x"}
+		_, err := Audit([]*crontab.Job{bad}, log, Options{Location: time.UTC})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 7")
+	})
+}
@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Execution is a job start logged by the cron daemon
+type Execution struct {
+	Time    time.Time
+	User    string
+	Command string
+	File    string // Log file, when set by the caller
+	Line    int    // Line of the log file
+}
+
+// Log is the contents of a cron daemon log
+type Log struct {
+	Executions []Execution // In log order
+	First      time.Time   // Timestamp of the first line
+	Last       time.Time   // Timestamp of the last line
+}
+
+var (
+	// Vixie cron and cronie log a job start as "CRON[pid]: (user) CMD (command)",
+	// cronie as "CROND[pid]"
+	cmdRegex = regexp.MustCompile(`\b(?:CRON|CROND|cron|crond)\[\d+\]: \(([^)]*)\) CMD \((.*)\)\s*$`)
+	// Traditional syslog timestamp: "Oct 16 02:00:01"
+	syslogTimeRegex = regexp.MustCompile(`^([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) `)
+	// RFC 3339 timestamp of rsyslog and journalctl -o short-iso
+	isoTimeRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2}))\s`)
+)
+
+// ParseLine parses a cron daemon log line. Timestamps without a time zone
+// are read in loc, and timestamps without a year get the year that puts
+// them at most a day after now. ok is false for lines that do not log a job
+// start.
+func ParseLine(line string, loc *time.Location, now time.Time) (exec Execution, ok bool) {
+	m := cmdRegex.FindStringSubmatch(line)
+	if m == nil {
+		return Execution{}, false
+	}
+	t, ok := parseTimestamp(line, loc, now)
+	if !ok {
+		return Execution{}, false
+	}
+	return Execution{Time: t, User: m[1], Command: strings.TrimSpace(m[2])}, true
+}
+
+// parseTimestamp parses the timestamp that starts a log line
+func parseTimestamp(line string, loc *time.Location, now time.Time) (time.Time, bool) {
+	if m := isoTimeRegex.FindStringSubmatch(line); m != nil {
+		value := m[1]
+		// journalctl writes the offset without a colon
+		if n := len(value); value[n-1] != 'Z' && value[n-3] != ':' {
+			value = value[:n-2] + ":" + value[n-2:]
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		return t, err == nil
+	}
+	if m := syslogTimeRegex.FindStringSubmatch(line); m != nil {
+		t, err := time.ParseInLocation("Jan _2 15:04:05", m[1], loc)
+		if err != nil {
+			return time.Time{}, false
+		}
+		now = now.In(loc)
+		t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// ParseLog reads the job starts of a cron daemon log, such as
+// /var/log/syslog or /var/log/cron, and the time span the log covers. Lines
+// of other programs only count towards the time span.
+func ParseLog(r io.Reader, loc *time.Location, now time.Time) (*Log, error) {
+	log := &Log{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		t, ok := parseTimestamp(line, loc, now)
+		if !ok {
+			continue
+		}
+		if log.First.IsZero() || t.Before(log.First) {
+			log.First = t
+		}
+		if t.After(log.Last) {
+			log.Last = t
+		}
+		if exec, ok := ParseLine(line, loc, now); ok {
+			exec.Line = n
+			log.Executions = append(log.Executions, exec)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	return log, nil
+}
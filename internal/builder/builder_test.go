@@ -0,0 +1,190 @@
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+
+func runes(s string) []KeyEvent {
+	events := make([]KeyEvent, 0, len(s))
+	for _, r := range s {
+		events = append(events, KeyEvent{Key: KeyRune, Rune: r})
+	}
+	return events
+}
+
+func TestNewModel(t *testing.T) {
+	m, err := NewModel("", 5, "en")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultExpression, m.Expression())
+
+	m, err = NewModel("0 9 * * 1-5", 5, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "0 9 * * 1-5", m.Expression())
+
+	_, err = NewModel("@daily", 5, "en")
+	assert.Error(t, err)
+	_, err = NewModel("0 0 9 * * 1-5", 5, "en")
+	assert.Error(t, err)
+}
+
+func TestModel_Update(t *testing.T) {
+	t.Run("typing replaces a field on entry and space moves on", func(t *testing.T) {
+		m, err := NewModel("", 5, "en")
+		require.NoError(t, err)
+
+		for _, ev := range runes("30 9 * * 1-5") {
+			m.Update(ev)
+		}
+		assert.Equal(t, "30 9 * * 1-5", m.Expression())
+	})
+
+	t.Run("arrows move and wrap around", func(t *testing.T) {
+		m, err := NewModel("", 5, "en")
+		require.NoError(t, err)
+
+		m.Update(KeyEvent{Key: KeyLeft})
+		m.Update(KeyEvent{Key: KeyRune, Rune: '1'})
+		m.Update(KeyEvent{Key: KeyRight})
+		m.Update(KeyEvent{Key: KeyTab})
+		m.Update(KeyEvent{Key: KeyRune, Rune: '5'})
+		assert.Equal(t, "* 5 * * 1", m.Expression())
+	})
+
+	t.Run("presets cycle in both directions", func(t *testing.T) {
+		m, err := NewModel("", 5, "en")
+		require.NoError(t, err)
+
+		m.Update(KeyEvent{Key: KeyDown})
+		assert.Equal(t, "0 * * * *", m.Expression())
+		m.Update(KeyEvent{Key: KeyDown})
+		assert.Equal(t, "*/5 * * * *", m.Expression())
+		m.Update(KeyEvent{Key: KeyUp})
+		m.Update(KeyEvent{Key: KeyUp})
+		m.Update(KeyEvent{Key: KeyUp})
+		assert.Equal(t, "30 * * * *", m.Expression())
+	})
+
+	t.Run("backspace edits the field", func(t *testing.T) {
+		m, err := NewModel("15 * * * *", 5, "en")
+		require.NoError(t, err)
+
+		m.Update(KeyEvent{Key: KeyBackspace})
+		m.Update(KeyEvent{Key: KeyRune, Rune: '0'})
+		assert.Equal(t, "10 * * * *", m.Expression())
+	})
+
+	t.Run("enter accepts only valid expressions", func(t *testing.T) {
+		m, err := NewModel("", 5, "en")
+		require.NoError(t, err)
+
+		for _, ev := range runes("61") {
+			m.Update(ev)
+		}
+		m.Update(KeyEvent{Key: KeyEnter})
+		assert.False(t, m.Done())
+
+		m.Update(KeyEvent{Key: KeyBackspace})
+		m.Update(KeyEvent{Key: KeyEnter})
+		assert.True(t, m.Done())
+		assert.Equal(t, "6 * * * *", m.Expression())
+	})
+
+	t.Run("escape cancels", func(t *testing.T) {
+		m, err := NewModel("", 5, "en")
+		require.NoError(t, err)
+
+		m.Update(KeyEvent{Key: KeyEscape})
+		assert.True(t, m.Canceled())
+		assert.False(t, m.Done())
+	})
+}
+
+func TestModel_View(t *testing.T) {
+	t.Run("valid expression shows description and next runs", func(t *testing.T) {
+		m, err := NewModel("0 9 * * 1-5", 3, "en")
+		require.NoError(t, err)
+		m.Update(KeyEvent{Key: KeyRight})
+
+		view := m.View(testNow)
+		assert.Contains(t, view, "[9]")
+		assert.Contains(t, view, "day-of-month")
+		assert.Contains(t, view, "Expression:  0 9 * * 1-5")
+		assert.Contains(t, view, "Description:")
+		assert.Contains(t, view, "Next 3 runs:")
+		assert.Contains(t, view, "1. 2026-01-05 09:00:00 UTC")
+		assert.Contains(t, view, "3. 2026-01-07 09:00:00 UTC")
+	})
+
+	t.Run("invalid expression shows the error", func(t *testing.T) {
+		m, err := NewModel("61 * * * *", 3, "en")
+		require.NoError(t, err)
+
+		view := m.View(testNow)
+		assert.Contains(t, view, "✗ Invalid:")
+		assert.NotContains(t, view, "Next 3 runs")
+	})
+}
+
+func TestReadKey(t *testing.T) {
+	input := "a\x1b[A\x1b[B\x1b[C\x1b[D\t\x7f\r\x1b[3~\x03"
+	reader := bufio.NewReader(strings.NewReader(input))
+
+	want := []KeyEvent{
+		{Key: KeyRune, Rune: 'a'},
+		{Key: KeyUp},
+		{Key: KeyDown},
+		{Key: KeyRight},
+		{Key: KeyLeft},
+		{Key: KeyTab},
+		{Key: KeyBackspace},
+		{Key: KeyEnter},
+		{Key: KeyUnknown},
+		{Key: KeyEscape},
+	}
+	for _, expected := range want {
+		ev, err := ReadKey(reader)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ev)
+	}
+
+	t.Run("lone escape", func(t *testing.T) {
+		ev, err := ReadKey(bufio.NewReader(strings.NewReader("\x1b")))
+		require.NoError(t, err)
+		assert.Equal(t, KeyEscape, ev.Key)
+	})
+}
+
+func TestRun(t *testing.T) {
+	now := func() time.Time { return testNow }
+
+	t.Run("returns the accepted expression", func(t *testing.T) {
+		m, err := NewModel("", 5, "en")
+		require.NoError(t, err)
+		out := new(bytes.Buffer)
+
+		expression, err := Run(m, strings.NewReader("0 9\x1b[C\x1b[C\x1b[C1-5\r"), out, now)
+		require.NoError(t, err)
+		assert.Equal(t, "0 9 * * 1-5", expression)
+		assert.Contains(t, out.String(), "Cron expression builder\r\n")
+		assert.True(t, strings.HasSuffix(out.String(), showCursor))
+	})
+
+	t.Run("cancel and end of input", func(t *testing.T) {
+		for _, input := range []string{"\x03", "0 9"} {
+			m, err := NewModel("", 5, "en")
+			require.NoError(t, err)
+
+			_, err = Run(m, strings.NewReader(input), new(bytes.Buffer), now)
+			assert.ErrorIs(t, err, ErrCanceled)
+		}
+	})
+}
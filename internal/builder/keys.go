@@ -0,0 +1,91 @@
+package builder
+
+import (
+	"bufio"
+	"unicode"
+)
+
+// Key identifies a key press understood by the builder
+type Key int
+
+const (
+	// KeyRune is a printable character, held in KeyEvent.Rune
+	KeyRune Key = iota
+	KeyLeft
+	KeyRight
+	KeyUp
+	KeyDown
+	KeyTab
+	KeyBackspace
+	KeyEnter
+	KeyEscape
+	// KeyUnknown is a control character or escape sequence the builder ignores
+	KeyUnknown
+)
+
+// KeyEvent is a decoded key press
+type KeyEvent struct {
+	Key  Key
+	Rune rune // Character typed, for KeyRune
+}
+
+// ReadKey decodes the next key press from a terminal in raw mode. Arrow keys
+// arrive as ANSI escape sequences (ESC [ A-D); a lone ESC or Ctrl-C cancels.
+func ReadKey(r *bufio.Reader) (KeyEvent, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return KeyEvent{}, err
+	}
+
+	switch ch {
+	case '\r', '\n':
+		return KeyEvent{Key: KeyEnter}, nil
+	case '\t':
+		return KeyEvent{Key: KeyTab}, nil
+	case 0x7f, 0x08:
+		return KeyEvent{Key: KeyBackspace}, nil
+	case 0x03, 0x04: // Ctrl-C, Ctrl-D
+		return KeyEvent{Key: KeyEscape}, nil
+	case 0x1b:
+		return readEscape(r)
+	}
+	if unicode.IsPrint(ch) {
+		return KeyEvent{Key: KeyRune, Rune: ch}, nil
+	}
+	return KeyEvent{Key: KeyUnknown}, nil
+}
+
+// readEscape decodes the rest of an escape sequence. An ESC with nothing
+// buffered after it is the escape key itself.
+func readEscape(r *bufio.Reader) (KeyEvent, error) {
+	if r.Buffered() == 0 {
+		return KeyEvent{Key: KeyEscape}, nil
+	}
+	if next, _ := r.Peek(1); next[0] != '[' && next[0] != 'O' {
+		return KeyEvent{Key: KeyEscape}, nil
+	}
+	_, _ = r.ReadByte()
+
+	code, err := r.ReadByte()
+	if err != nil {
+		return KeyEvent{}, err
+	}
+	switch code {
+	case 'A':
+		return KeyEvent{Key: KeyUp}, nil
+	case 'B':
+		return KeyEvent{Key: KeyDown}, nil
+	case 'C':
+		return KeyEvent{Key: KeyRight}, nil
+	case 'D':
+		return KeyEvent{Key: KeyLeft}, nil
+	}
+
+	// Skip the parameters of other sequences (e.g. ESC [ 3 ~ for delete)
+	for code >= '0' && code <= '9' || code == ';' {
+		if code, err = r.ReadByte(); err != nil {
+			return KeyEvent{}, err
+		}
+	}
+	return KeyEvent{Key: KeyUnknown}, nil
+}
@@ -0,0 +1,179 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/human"
+)
+
+// DefaultExpression is the expression the builder starts from when none is given
+const DefaultExpression = "* * * * *"
+
+// fieldNames are the display names of the five cron fields, in order
+var fieldNames = [5]string{"minute", "hour", "day-of-month", "month", "day-of-week"}
+
+// presets are common values for each field, cycled with the up and down keys
+var presets = [5][]string{
+	{"*", "0", "*/5", "*/15", "30"},
+	{"*", "0", "9", "9-17", "*/2"},
+	{"*", "1", "15", "1,15", "*/2"},
+	{"*", "1", "*/3", "6-8", "12"},
+	{"*", "1-5", "0,6", "1", "0"},
+}
+
+// Model is the state of the expression builder: the five field values and
+// the field being edited. It is independent of the terminal so that key
+// handling and rendering can be tested.
+type Model struct {
+	fields    [5]string
+	cursor    int
+	fresh     bool // The next typed character replaces the field instead of extending it
+	count     int
+	parser    cronx.Parser
+	scheduler cronx.Scheduler
+	humanizer human.Humanizer
+	done      bool
+	canceled  bool
+}
+
+// NewModel creates a builder starting from expression (DefaultExpression if
+// empty) that previews the next count runs. Only 5-field expressions can be
+// edited field by field.
+func NewModel(expression string, count int, locale string) (*Model, error) {
+	if strings.TrimSpace(expression) == "" {
+		expression = DefaultExpression
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != len(fieldNames) {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	m := &Model{
+		cursor:    0,
+		fresh:     true,
+		count:     count,
+		parser:    cronx.NewParserWithLocale(locale),
+		scheduler: cronx.NewScheduler(),
+		humanizer: human.NewHumanizerForLocale(locale),
+	}
+	copy(m.fields[:], fields)
+	return m, nil
+}
+
+// Expression returns the expression composed so far
+func (m *Model) Expression() string {
+	return strings.Join(m.fields[:], " ")
+}
+
+// Done reports whether the user accepted the expression
+func (m *Model) Done() bool {
+	return m.done
+}
+
+// Canceled reports whether the user left the builder without accepting
+func (m *Model) Canceled() bool {
+	return m.canceled
+}
+
+// Update applies a key press to the model
+func (m *Model) Update(ev KeyEvent) {
+	switch ev.Key {
+	case KeyLeft:
+		m.move(-1)
+	case KeyRight, KeyTab:
+		m.move(1)
+	case KeyUp:
+		m.cyclePreset(-1)
+	case KeyDown:
+		m.cyclePreset(1)
+	case KeyBackspace:
+		field := m.fields[m.cursor]
+		if field != "" {
+			m.fields[m.cursor] = field[:len(field)-1]
+		}
+		m.fresh = false
+	case KeyEnter:
+		if _, err := m.parser.Parse(m.Expression()); err == nil {
+			m.done = true
+		}
+	case KeyEscape:
+		m.canceled = true
+	case KeyRune:
+		if ev.Rune == ' ' {
+			m.move(1)
+			return
+		}
+		if m.fresh {
+			m.fields[m.cursor] = ""
+			m.fresh = false
+		}
+		m.fields[m.cursor] += string(ev.Rune)
+	}
+}
+
+// move selects the field delta positions away, wrapping around
+func (m *Model) move(delta int) {
+	m.cursor = (m.cursor + delta + len(m.fields)) % len(m.fields)
+	m.fresh = true
+}
+
+// cyclePreset replaces the current field with the next or previous preset
+func (m *Model) cyclePreset(delta int) {
+	options := presets[m.cursor]
+	next := 0
+	for i, preset := range options {
+		if preset == m.fields[m.cursor] {
+			next = (i + delta + len(options)) % len(options)
+			break
+		}
+	}
+	m.fields[m.cursor] = options[next]
+	m.fresh = true
+}
+
+// View renders the builder: the fields with the current one highlighted, the
+// description of the expression (or why it is invalid) and its next runs
+// after now. Lines are separated by "\n".
+func (m *Model) View(now time.Time) string {
+	var b strings.Builder
+	b.WriteString("Cron expression builder\n\n")
+
+	// Fields, each padded to the width of its name so the labels line up
+	var values, labels strings.Builder
+	for i, name := range fieldNames {
+		value := m.fields[i]
+		if value == "" {
+			value = "_"
+		}
+		width := max(len(name), len(value)+2)
+		cell := fmt.Sprintf(" %s ", value)
+		if i == m.cursor {
+			cell = fmt.Sprintf("[%s]", value)
+		}
+		values.WriteString(fmt.Sprintf("%-*s  ", width, cell))
+		labels.WriteString(fmt.Sprintf("%-*s  ", width, name))
+	}
+	b.WriteString("  " + strings.TrimRight(values.String(), " ") + "\n")
+	b.WriteString("  " + strings.TrimRight(labels.String(), " ") + "\n\n")
+
+	expression := m.Expression()
+	b.WriteString(fmt.Sprintf("  Expression:  %s\n", expression))
+	schedule, err := m.parser.Parse(expression)
+	if err != nil {
+		b.WriteString(fmt.Sprintf("  ✗ Invalid:   %s\n", err))
+	} else {
+		b.WriteString(fmt.Sprintf("  Description: %s\n", m.humanizer.Humanize(schedule)))
+		if times, err := m.scheduler.Next(expression, now, m.count); err == nil && len(times) > 0 {
+			b.WriteString(fmt.Sprintf("\n  Next %d runs:\n", len(times)))
+			for i, t := range times {
+				b.WriteString(fmt.Sprintf("    %d. %s\n", i+1, t.Format("2006-01-02 15:04:05 MST")))
+			}
+		}
+	}
+
+	b.WriteString("\n  ←/→ field  ↑/↓ presets  type to edit  enter accept  esc cancel\n")
+	return b.String()
+}
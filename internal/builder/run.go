@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrCanceled is returned by Run when the user leaves without accepting
+var ErrCanceled = errors.New("canceled")
+
+// ANSI sequences used to redraw the screen
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// Run drives the model with key presses read from in, redrawing the view on
+// out after each one, until the user accepts or cancels. It returns the
+// accepted expression, or ErrCanceled. The terminal must already be in raw
+// mode, so lines are terminated with "\r\n".
+func Run(m *Model, in io.Reader, out io.Writer, now func() time.Time) (string, error) {
+	reader := bufio.NewReader(in)
+	draw := func() {
+		view := strings.ReplaceAll(m.View(now()), "\n", "\r\n")
+		_, _ = fmt.Fprint(out, clearScreen+view)
+	}
+
+	_, _ = fmt.Fprint(out, hideCursor)
+	defer func() {
+		_, _ = fmt.Fprint(out, showCursor)
+	}()
+
+	draw()
+	for {
+		ev, err := ReadKey(reader)
+		if err == io.EOF {
+			return "", ErrCanceled
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read key: %w", err)
+		}
+
+		m.Update(ev)
+		switch {
+		case m.Done():
+			return m.Expression(), nil
+		case m.Canceled():
+			return "", ErrCanceled
+		}
+		draw()
+	}
+}
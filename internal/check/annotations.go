@@ -0,0 +1,133 @@
+package check
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultAnnotationPath is the path reported for issues that do not come
+// from a crontab file (stdin, the user crontab)
+const DefaultAnnotationPath = "crontab"
+
+// githubLevel returns the GitHub Actions workflow command for a severity
+func githubLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarn:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// githubEscapeData escapes the message of a workflow command
+func githubEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// githubEscapeProperty escapes a property value of a workflow command
+func githubEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// issueFile returns the file an issue belongs to: its own, when jobs of
+// several files were checked together, or path
+func issueFile(issue Issue, path string) string {
+	if issue.File != "" {
+		return issue.File
+	}
+	return path
+}
+
+// WriteGitHubAnnotations writes issues as GitHub Actions workflow commands
+// (::error, ::warning, ::notice), which annotate the lines of path in pull
+// requests. An empty path leaves the annotations unattached to a file.
+func WriteGitHubAnnotations(w io.Writer, issues []Issue, path string) error {
+	for _, issue := range issues {
+		props := []string{}
+		if file := issueFile(issue, path); file != "" {
+			props = append(props, "file="+githubEscapeProperty(file))
+			if issue.LineNumber > 0 {
+				props = append(props, fmt.Sprintf("line=%d", issue.LineNumber))
+			}
+		}
+		props = append(props, "title="+githubEscapeProperty(issue.Code))
+
+		message := issue.Message
+		if issue.Hint != "" {
+			message += "\nHint: " + issue.Hint
+		}
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubLevel(issue.Severity), strings.Join(props, ","), githubEscapeData(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gitlabIssue is an issue in the GitLab Code Quality report format
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// gitlabSeverity returns the Code Quality severity for a severity
+func gitlabSeverity(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "major"
+	case SeverityWarn:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// WriteGitLabCodeQuality writes issues as a GitLab Code Quality report, which
+// GitLab shows in merge requests when it is uploaded as a codequality
+// artifact. Issues without a line number are attached to line 1. An empty
+// path is reported as DefaultAnnotationPath.
+func WriteGitLabCodeQuality(w io.Writer, issues []Issue, path string) error {
+	if path == "" {
+		path = DefaultAnnotationPath
+	}
+
+	report := make([]gitlabIssue, 0, len(issues))
+	for _, issue := range issues {
+		description := issue.Message
+		if issue.Hint != "" {
+			description += " Hint: " + issue.Hint
+		}
+		line := max(issue.LineNumber, 1)
+		file := issueFile(issue, path)
+
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%s\x00%s", issue.Code, file, issue.LineNumber, issue.Expression, issue.Message)))
+		report = append(report, gitlabIssue{
+			Description: description,
+			CheckName:   issue.Code,
+			Fingerprint: hex.EncodeToString(sum[:16]),
+			Severity:    gitlabSeverity(issue.Severity),
+			Location:    gitlabLocation{Path: file, Lines: gitlabLines{Begin: line}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
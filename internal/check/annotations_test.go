@@ -0,0 +1,86 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var annotationIssues = []Issue{
+	{Severity: SeverityError, Code: CodeParseError, LineNumber: 3, Expression: "61 * * * *", Message: "Invalid cron expression: 61, out of range", Hint: "Fix the syntax\nerror"},
+	{Severity: SeverityWarn, Code: CodeOverlapDetected, Message: "Overlap detected: 3 jobs at 100%"},
+	{Severity: SeverityInfo, Code: CodeDistantFirstRun, LineNumber: 5, Message: "First run in 14 months"},
+}
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGitHubAnnotations(&buf, annotationIssues, "cron/jobs,prod.cron"))
+
+	assert.Equal(t,
+		"::error file=cron/jobs%2Cprod.cron,line=3,title=CRON-003::Invalid cron expression: 61, out of range%0AHint: Fix the syntax%0Aerror\n"+
+			"::warning file=cron/jobs%2Cprod.cron,title=CRON-012::Overlap detected: 3 jobs at 100%25\n"+
+			"::notice file=cron/jobs%2Cprod.cron,line=5,title=CRON-014::First run in 14 months\n",
+		buf.String())
+
+	t.Run("without a file", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteGitHubAnnotations(&buf, annotationIssues[2:], ""))
+		assert.Equal(t, "::notice title=CRON-014::First run in 14 months\n", buf.String())
+	})
+
+	t.Run("issues of several files", func(t *testing.T) {
+		var buf bytes.Buffer
+		issue := Issue{Severity: SeverityWarn, Code: CodeGitHubThrottled, LineNumber: 4, Message: "Too often", File: ".github/workflows/a.yml"}
+		require.NoError(t, WriteGitHubAnnotations(&buf, []Issue{issue}, ".github/workflows"))
+		assert.Equal(t, "::warning file=.github/workflows/a.yml,line=4,title=CRON-017::Too often\n", buf.String())
+	})
+
+	t.Run("no issues", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteGitHubAnnotations(&buf, nil, "jobs.cron"))
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestWriteGitLabCodeQuality(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGitLabCodeQuality(&buf, annotationIssues, "jobs.cron"))
+
+	var report []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report, 3)
+
+	assert.Equal(t, "CRON-003", report[0]["check_name"])
+	assert.Equal(t, "major", report[0]["severity"])
+	assert.Equal(t, "Invalid cron expression: 61, out of range Hint: Fix the syntax\nerror", report[0]["description"])
+	assert.Equal(t, map[string]interface{}{"path": "jobs.cron", "lines": map[string]interface{}{"begin": float64(3)}}, report[0]["location"])
+	assert.Equal(t, "minor", report[1]["severity"])
+	assert.Equal(t, float64(1), report[1]["location"].(map[string]interface{})["lines"].(map[string]interface{})["begin"])
+	assert.Equal(t, "info", report[2]["severity"])
+
+	fingerprints := map[interface{}]bool{}
+	for _, issue := range report {
+		assert.Len(t, issue["fingerprint"], 32)
+		fingerprints[issue["fingerprint"]] = true
+	}
+	assert.Len(t, fingerprints, 3, "fingerprints are unique")
+
+	t.Run("fingerprints are stable", func(t *testing.T) {
+		var again bytes.Buffer
+		require.NoError(t, WriteGitLabCodeQuality(&again, annotationIssues, "jobs.cron"))
+		assert.Equal(t, buf.String(), again.String())
+	})
+
+	t.Run("default path and empty report", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteGitLabCodeQuality(&buf, annotationIssues[:1], ""))
+		assert.Contains(t, buf.String(), `"path": "crontab"`)
+
+		buf.Reset()
+		require.NoError(t, WriteGitLabCodeQuality(&buf, nil, "jobs.cron"))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+}
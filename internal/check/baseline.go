@@ -0,0 +1,105 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// BaselineVersion is the version of the baseline file format
+const BaselineVersion = 1
+
+// Baseline is a set of issues accepted as they are, so that check reports
+// only the issues found since. Issues are matched by code, file, expression
+// and message, not by line, so that moving a job keeps it in the baseline;
+// an issue found more often than recorded is new.
+type Baseline struct {
+	Version int             `json:"version"`
+	Issues  []BaselineIssue `json:"issues"`
+
+	remaining map[baselineKey]int // Matches left per issue, built on first use
+}
+
+// BaselineIssue is an issue recorded in a baseline. The line number is
+// informational and not used for matching.
+type BaselineIssue struct {
+	Code       string `json:"code"`
+	File       string `json:"file,omitempty"`
+	LineNumber int    `json:"lineNumber"`
+	Expression string `json:"expression"`
+	Message    string `json:"message"`
+}
+
+// baselineKey identifies the issues a baseline issue matches
+type baselineKey struct {
+	code, file, expression, message string
+}
+
+// NewBaseline records issues in a baseline. Issues without a file of their
+// own are recorded as found in path.
+func NewBaseline(issues []Issue, path string) *Baseline {
+	b := &Baseline{Version: BaselineVersion, Issues: make([]BaselineIssue, len(issues))}
+	for i, issue := range issues {
+		b.Issues[i] = BaselineIssue{
+			Code:       issue.Code,
+			File:       issueFile(issue, path),
+			LineNumber: issue.LineNumber,
+			Expression: issue.Expression,
+			Message:    issue.Message,
+		}
+	}
+	return b
+}
+
+// LoadBaseline reads a baseline file written by Write
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline file %s: %w", path, err)
+	}
+	if b.Version != BaselineVersion {
+		return nil, fmt.Errorf("invalid baseline file %s: unsupported version %d", path, b.Version)
+	}
+	return &b, nil
+}
+
+// Write writes the baseline as indented JSON
+func (b *Baseline) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// Suppresses reports whether the baseline accepts an issue found in path,
+// using up the match: a baseline is meant for a single check.
+func (b *Baseline) Suppresses(issue Issue, path string) bool {
+	if b.remaining == nil {
+		b.remaining = make(map[baselineKey]int, len(b.Issues))
+		for _, recorded := range b.Issues {
+			b.remaining[baselineKey{recorded.Code, recorded.File, recorded.Expression, recorded.Message}]++
+		}
+	}
+	key := baselineKey{issue.Code, issueFile(issue, path), issue.Expression, issue.Message}
+	if b.remaining[key] == 0 {
+		return false
+	}
+	b.remaining[key]--
+	return true
+}
+
+// Filter drops the issues the baseline accepts, returning the new issues and
+// the number dropped
+func (b *Baseline) Filter(issues []Issue, path string) ([]Issue, int) {
+	kept := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !b.Suppresses(issue, path) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept, len(issues) - len(kept)
+}
@@ -0,0 +1,88 @@
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline(t *testing.T) {
+	conflict := Issue{Severity: SeverityWarn, Code: CodeDOMDOWConflict, LineNumber: 2, Expression: "0 0 1 * 1", Message: "Both day-of-month and day-of-week specified"}
+
+	t.Run("matches issues regardless of line", func(t *testing.T) {
+		b := NewBaseline([]Issue{conflict}, "jobs.cron")
+		moved := conflict
+		moved.LineNumber = 7
+		kept, suppressed := b.Filter([]Issue{moved, moved}, "jobs.cron")
+		assert.Equal(t, 1, suppressed)
+		assert.Equal(t, []Issue{moved}, kept, "an issue found more often than recorded is new")
+	})
+
+	t.Run("matches the file", func(t *testing.T) {
+		b := NewBaseline([]Issue{conflict}, "jobs.cron")
+		assert.False(t, b.Suppresses(conflict, "other.cron"))
+		own := conflict
+		own.File = "jobs.cron"
+		assert.True(t, b.Suppresses(own, "other.cron"))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewBaseline([]Issue{conflict}, "jobs.cron").Write(&buf))
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+
+		b, err := LoadBaseline(path)
+		require.NoError(t, err)
+		assert.Equal(t, []BaselineIssue{{Code: CodeDOMDOWConflict, File: "jobs.cron", LineNumber: 2, Expression: "0 0 1 * 1", Message: conflict.Message}}, b.Issues)
+		assert.True(t, b.Suppresses(conflict, "jobs.cron"))
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := LoadBaseline(filepath.Join(dir, "missing.json"))
+		assert.ErrorContains(t, err, "failed to read baseline")
+
+		for name, content := range map[string]string{"garbage.json": "{", "future.json": `{"version": 2, "issues": []}`} {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			_, err := LoadBaseline(path)
+			assert.ErrorContains(t, err, "invalid baseline file", name)
+		}
+	})
+}
+
+func TestValidator_IgnoreAnnotations(t *testing.T) {
+	const content = "0 0 1 * 1 /usr/bin/a.sh\n# cronkit:ignore CRON-001\n0 0 2 * 2 /usr/bin/b.sh\n*/1 * * * * /usr/bin/c.sh # cronkit:ignore\n"
+
+	codes := func(issues []Issue) []string {
+		var result []string
+		for _, issue := range issues {
+			result = append(result, fmt.Sprintf("%s@%d", issue.Code, issue.LineNumber))
+		}
+		return result
+	}
+
+	t.Run("entries", func(t *testing.T) {
+		entries, err := crontab.ParseReader(strings.NewReader(content))
+		require.NoError(t, err)
+		result := NewValidator("en").ValidateEntries(entries)
+		assert.Equal(t, []string{CodeDOMDOWConflict + "@1"}, codes(result.Issues))
+		assert.Equal(t, 3, result.Suppressed)
+	})
+
+	t.Run("crontab file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jobs.cron")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		result := NewValidator("en").ValidateCrontab(crontab.NewReader(), path)
+		assert.Equal(t, []string{CodeDOMDOWConflict + "@1"}, codes(result.Issues))
+		assert.Equal(t, 3, result.Suppressed)
+	})
+}
@@ -26,16 +26,48 @@ const (
 	CodeQuotingIssue = "CRON-011"
 	// CodeOverlapDetected indicates multiple jobs running at the same time
 	CodeOverlapDetected = "CRON-012"
+	// CodeConsolidationCandidate indicates jobs running the same command that could be merged
+	CodeConsolidationCandidate = "CRON-013"
+	// CodeDistantFirstRun indicates a schedule whose first run lies beyond the look-ahead horizon
+	CodeDistantFirstRun = "CRON-014"
+	// CodeDSTTransition indicates a schedule skipped or run twice by a daylight saving time change
+	CodeDSTTransition = "CRON-015"
+	// CodeCommandNotFound indicates the program a job runs does not exist (with an environment set)
+	CodeCommandNotFound = "CRON-016"
+	// CodeGitHubThrottled indicates a GitHub Actions schedule more frequent than GitHub runs workflows
+	CodeGitHubThrottled = "CRON-017"
+	// CodeGitHubUTC indicates a GitHub Actions schedule at fixed times, which GitHub evaluates in UTC
+	CodeGitHubUTC = "CRON-018"
+	// CodeInteractiveCommand indicates a command runs a program that needs a terminal
+	CodeInteractiveCommand = "CRON-019"
+	// CodeOutputDiscarded indicates a command whose output is lost: MAILTO is empty and it does not redirect output
+	CodeOutputDiscarded = "CRON-020"
+	// CodePolicyRunsPerDay indicates a schedule that runs more often than the policy's max-runs-per-day
+	CodePolicyRunsPerDay = "CRON-021"
+	// CodePolicySpacing indicates two jobs that run closer together than the policy's min-spacing
+	CodePolicySpacing = "CRON-022"
+	// CodePolicyWindow indicates a schedule that runs inside one of the policy's forbidden-windows
+	CodePolicyWindow = "CRON-023"
+	// CodePolicyMailto indicates a job without MAILTO when the policy requires one
+	CodePolicyMailto = "CRON-024"
+	// CodeHolidayRuns indicates a business-critical job running on weekdays only, which cron also runs on public holidays
+	CodeHolidayRuns = "CRON-025"
+	// CodeDependencyOrder indicates a job that starts before a job it runs after ("cronkit:after=") is expected to finish
+	CodeDependencyOrder = "CRON-026"
+	// CodeUnknownDependency indicates a job that runs after a job name no job of the crontab has
+	CodeUnknownDependency = "CRON-027"
+	// CodePolicyProtectedWindow indicates a schedule with upcoming runs inside one of the policy's protected-windows
+	CodePolicyProtectedWindow = "CRON-028"
 )
 
 // GetCodeSeverity returns the severity level for a given diagnostic code
 func GetCodeSeverity(code string) Severity {
 	switch code {
-	case CodeDOMDOWConflict, CodeRedundantPattern, CodeExcessiveRuns, CodePercentCharacter, CodeQuotingIssue, CodeOverlapDetected:
+	case CodeDOMDOWConflict, CodeRedundantPattern, CodeExcessiveRuns, CodePercentCharacter, CodeQuotingIssue, CodeOverlapDetected, CodeDSTTransition, CodeCommandNotFound, CodeGitHubThrottled, CodeInteractiveCommand, CodeOutputDiscarded, CodeHolidayRuns, CodeDependencyOrder, CodeUnknownDependency:
 		return SeverityWarn
-	case CodeMissingAbsolutePath, CodeMissingRedirection:
+	case CodeMissingAbsolutePath, CodeMissingRedirection, CodeConsolidationCandidate, CodeDistantFirstRun, CodeGitHubUTC:
 		return SeverityInfo
-	case CodeEmptySchedule, CodeParseError, CodeFileReadError, CodeInvalidStructure:
+	case CodeEmptySchedule, CodeParseError, CodeFileReadError, CodeInvalidStructure, CodePolicyRunsPerDay, CodePolicySpacing, CodePolicyWindow, CodePolicyMailto, CodePolicyProtectedWindow:
 		return SeverityError
 	default:
 		return SeverityError // Default to error for unknown codes
@@ -69,6 +101,38 @@ func GetCodeHint(code string) string {
 		return "Check that all quotes are properly closed and escaped. Use single quotes for literal strings, double quotes for variable expansion."
 	case CodeOverlapDetected:
 		return "Multiple jobs are scheduled to run at the same time. This may cause resource contention. Consider adjusting schedules to distribute load."
+	case CodeConsolidationCandidate:
+		return "Multiple jobs run the same command. Consolidating them into a single entry keeps the crontab easier to maintain."
+	case CodeDistantFirstRun:
+		return "The schedule runs, but not for a long time. Confirm the date combination is intended (e.g., February 29th only occurs in leap years)."
+	case CodeDSTTransition:
+		return "Runs in the hour skipped or repeated by a daylight saving time change may not happen or may happen twice. Move the job outside the transition window or schedule it in UTC."
+	case CodeCommandNotFound:
+		return "The job will fail with 'command not found'. Check the path, or set PATH= in the crontab so cron can find the program."
+	case CodeGitHubThrottled:
+		return "GitHub runs scheduled workflows at most every 5 minutes, and often later than scheduled under load. Use an interval of 5 minutes or more."
+	case CodeGitHubUTC:
+		return "GitHub evaluates schedules in UTC and does not support time zones. Convert the intended local time to UTC, keeping daylight saving time in mind."
+	case CodeInteractiveCommand:
+		return "Cron runs jobs without a terminal, so programs that wait for input hang or fail. Use a non-interactive alternative or flag (e.g. sudo -n, top -b)."
+	case CodeOutputDiscarded:
+		return "MAILTO=\"\" turns off cron's mail, so the job's output and errors are lost. Redirect output to a log file, e.g. command >> /var/log/command.log 2>&1"
+	case CodePolicyRunsPerDay:
+		return "The scheduling policy limits how often a job may run. Lower the frequency, or ask for the policy's max-runs-per-day to be raised."
+	case CodePolicySpacing:
+		return "The scheduling policy requires jobs to start apart from each other. Move one of the jobs by a few minutes, e.g. with 'cronkit suggest'."
+	case CodePolicyWindow:
+		return "The scheduling policy forbids runs at this time of day, e.g. during backups or maintenance. Move the job outside the window."
+	case CodePolicyMailto:
+		return "The scheduling policy requires job output to be mailed somewhere. Add a MAILTO=address line before the job."
+	case CodePolicyProtectedWindow:
+		return "The scheduling policy protects this window, e.g. a deploy freeze, from scheduled jobs. Move the job outside the window or off its days."
+	case CodeHolidayRuns:
+		return "Weekday schedules usually mean business days, but cron also runs them on public holidays. Make the command skip holidays, or confirm it must run on them."
+	case CodeDependencyOrder:
+		return "Start the job later than the job it runs after by at least that job's cronkit:duration=, or run both from one job (first && second)."
+	case CodeUnknownDependency:
+		return "Name the job it runs after with a cronkit:name= directive, or fix the name in cronkit:after=."
 	default:
 		return ""
 	}
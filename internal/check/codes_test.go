@@ -37,6 +37,66 @@ func TestGetCodeSeverity(t *testing.T) {
 			code:     CodeInvalidStructure,
 			expected: SeverityError,
 		},
+		{
+			name:     "Consolidation candidate",
+			code:     CodeConsolidationCandidate,
+			expected: SeverityInfo,
+		},
+		{
+			name:     "Distant first run",
+			code:     CodeDistantFirstRun,
+			expected: SeverityInfo,
+		},
+		{
+			name:     "DST transition",
+			code:     CodeDSTTransition,
+			expected: SeverityWarn,
+		},
+		{
+			name:     "Command not found",
+			code:     CodeCommandNotFound,
+			expected: SeverityWarn,
+		},
+		{
+			name:     "GitHub throttled",
+			code:     CodeGitHubThrottled,
+			expected: SeverityWarn,
+		},
+		{
+			name:     "GitHub UTC",
+			code:     CodeGitHubUTC,
+			expected: SeverityInfo,
+		},
+		{
+			name:     "Interactive command",
+			code:     CodeInteractiveCommand,
+			expected: SeverityWarn,
+		},
+		{
+			name:     "Output discarded",
+			code:     CodeOutputDiscarded,
+			expected: SeverityWarn,
+		},
+		{
+			name:     "Policy runs per day",
+			code:     CodePolicyRunsPerDay,
+			expected: SeverityError,
+		},
+		{
+			name:     "Policy spacing",
+			code:     CodePolicySpacing,
+			expected: SeverityError,
+		},
+		{
+			name:     "Policy window",
+			code:     CodePolicyWindow,
+			expected: SeverityError,
+		},
+		{
+			name:     "Policy MAILTO",
+			code:     CodePolicyMailto,
+			expected: SeverityError,
+		},
 		{
 			name:     "Unknown code",
 			code:     "CRON-999",
@@ -83,6 +143,51 @@ func TestGetCodeHint(t *testing.T) {
 			code:     CodeInvalidStructure,
 			expected: "Ensure the crontab file follows the correct format with valid cron expressions.",
 		},
+		{
+			name:     "Consolidation candidate",
+			code:     CodeConsolidationCandidate,
+			expected: "Multiple jobs run the same command. Consolidating them into a single entry keeps the crontab easier to maintain.",
+		},
+		{
+			name:     "Distant first run",
+			code:     CodeDistantFirstRun,
+			expected: "The schedule runs, but not for a long time. Confirm the date combination is intended (e.g., February 29th only occurs in leap years).",
+		},
+		{
+			name:     "DST transition",
+			code:     CodeDSTTransition,
+			expected: "Runs in the hour skipped or repeated by a daylight saving time change may not happen or may happen twice. Move the job outside the transition window or schedule it in UTC.",
+		},
+		{
+			name:     "Command not found",
+			code:     CodeCommandNotFound,
+			expected: "The job will fail with 'command not found'. Check the path, or set PATH= in the crontab so cron can find the program.",
+		},
+		{
+			name:     "GitHub throttled",
+			code:     CodeGitHubThrottled,
+			expected: "GitHub runs scheduled workflows at most every 5 minutes, and often later than scheduled under load. Use an interval of 5 minutes or more.",
+		},
+		{
+			name:     "GitHub UTC",
+			code:     CodeGitHubUTC,
+			expected: "GitHub evaluates schedules in UTC and does not support time zones. Convert the intended local time to UTC, keeping daylight saving time in mind.",
+		},
+		{
+			name:     "Interactive command",
+			code:     CodeInteractiveCommand,
+			expected: "Cron runs jobs without a terminal, so programs that wait for input hang or fail. Use a non-interactive alternative or flag (e.g. sudo -n, top -b).",
+		},
+		{
+			name:     "Output discarded",
+			code:     CodeOutputDiscarded,
+			expected: "MAILTO=\"\" turns off cron's mail, so the job's output and errors are lost. Redirect output to a log file, e.g. command >> /var/log/command.log 2>&1",
+		},
+		{
+			name:     "Policy window",
+			code:     CodePolicyWindow,
+			expected: "The scheduling policy forbids runs at this time of day, e.g. during backups or maintenance. Move the job outside the window.",
+		},
 		{
 			name:     "Unknown code",
 			code:     "CRON-999",
@@ -0,0 +1,53 @@
+package check
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// checkCommandExists reports a job whose program cannot be found in its
+// effective environment (see crontab.Job.ResolveCommand), and a SHELL set by
+// the crontab that does not exist, as cron then fails to start the job.
+// Programs that still contain shell syntax after expansion are not checked.
+func (v *Validator) checkCommandExists(job *crontab.Job) []Issue {
+	if v.environment == nil || job.Command == "" {
+		return nil
+	}
+
+	var messages []string
+	env := job.Environment(v.environment)
+	if shell, ok := job.Env["SHELL"]; ok && shell != "" {
+		if _, err := crontab.LookProgram(shell, env); err != nil {
+			messages = append(messages, fmt.Sprintf("Shell not found: %s (set by SHELL=)", shell))
+		}
+	}
+
+	program, _, err := job.ResolveCommand(v.environment)
+	if err != nil {
+		if strings.Contains(program, "/") {
+			path := program
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(env["HOME"], path)
+			}
+			messages = append(messages, fmt.Sprintf("Command not found: %s", path))
+		} else {
+			messages = append(messages, fmt.Sprintf("Command %q not found in PATH (%s)", program, env["PATH"]))
+		}
+	}
+
+	issues := make([]Issue, 0, len(messages))
+	for _, message := range messages {
+		issues = append(issues, Issue{
+			Severity:   GetCodeSeverity(CodeCommandNotFound),
+			Code:       CodeCommandNotFound,
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Message:    message,
+			Hint:       GetCodeHint(CodeCommandNotFound),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,110 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCommandExists(t *testing.T) {
+	home := t.TempDir()
+	bin := filepath.Join(home, "bin")
+	require.NoError(t, os.MkdirAll(bin, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "present.sh"), []byte("#!/bin/sh\n"), 0755))
+
+	content := strings.Join([]string{
+		"0 1 * * * ~/bin/present.sh",
+		"0 2 * * * ~/bin/missing.sh",
+		"0 3 * * * bin/present.sh",
+		"0 4 * * * present.sh --now",
+		"PATH=" + bin,
+		"0 5 * * * present.sh --now",
+		"0 6 * * * cd /tmp && present.sh",
+		"0 7 * * * $UNSET/run.sh",
+	}, "\n")
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+
+	t.Run("disabled without an environment", func(t *testing.T) {
+		result := NewValidator("en").ValidateEntries(entries)
+		for _, issue := range result.Issues {
+			assert.NotEqual(t, CodeCommandNotFound, issue.Code)
+		}
+	})
+
+	t.Run("reports missing programs", func(t *testing.T) {
+		validator := NewValidator("en")
+		validator.SetEnvironment(map[string]string{"HOME": home, "PATH": crontab.DefaultPath})
+		result := validator.ValidateEntries(entries)
+
+		var lines []int
+		for _, issue := range result.Issues {
+			if issue.Code == CodeCommandNotFound {
+				assert.Equal(t, SeverityWarn, issue.Severity)
+				lines = append(lines, issue.LineNumber)
+			}
+		}
+		assert.Equal(t, []int{2, 4}, lines)
+	})
+}
+
+func TestValidateCommandHygiene_Expanded(t *testing.T) {
+	entries, err := crontab.ParseReader(strings.NewReader("0 1 * * * ~/bin/run.sh\n"))
+	require.NoError(t, err)
+
+	hasMissingPath := func(v *Validator) bool {
+		for _, issue := range v.ValidateEntries(entries).Issues {
+			if issue.Code == CodeMissingAbsolutePath {
+				return true
+			}
+		}
+		return false
+	}
+
+	validator := NewValidator("en")
+	validator.SetHygieneChecks(true)
+	assert.True(t, hasMissingPath(validator))
+
+	validator.SetEnvironment(map[string]string{"HOME": "/home/alice"})
+	assert.False(t, hasMissingPath(validator), "~ should be resolved to an absolute path")
+}
+
+func TestCheckCommandExists_Shell(t *testing.T) {
+	entries, err := crontab.ParseReader(strings.NewReader("SHELL=/nonexistent/zsh\n0 1 * * * cd /tmp\n"))
+	require.NoError(t, err)
+
+	validator := NewValidator("en")
+	validator.SetEnvironment(map[string]string{"HOME": t.TempDir(), "PATH": crontab.DefaultPath})
+	result := validator.ValidateEntries(entries)
+
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, CodeCommandNotFound, result.Issues[0].Code)
+	assert.Equal(t, "Shell not found: /nonexistent/zsh (set by SHELL=)", result.Issues[0].Message)
+}
+
+func TestValidateCommandHygiene_EmptyMailto(t *testing.T) {
+	content := strings.Join([]string{
+		"0 1 * * * /usr/bin/report",
+		`MAILTO=""`,
+		"0 2 * * * /usr/bin/report",
+		"0 3 * * * /usr/bin/report >> /var/log/report.log 2>&1",
+	}, "\n")
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+
+	validator := NewValidator("en")
+	validator.SetHygieneChecks(true)
+	codes := map[int][]string{}
+	for _, issue := range validator.ValidateEntries(entries).Issues {
+		codes[issue.LineNumber] = append(codes[issue.LineNumber], issue.Code)
+	}
+
+	assert.Equal(t, []string{CodeMissingRedirection}, codes[1], "output is mailed")
+	assert.Equal(t, []string{CodeOutputDiscarded}, codes[3])
+	assert.Empty(t, codes[4])
+}
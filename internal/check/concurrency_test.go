@@ -0,0 +1,45 @@
+package check
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are meant to be run with the race detector (make test-unit)
+
+func TestValidator_ConcurrentUse(t *testing.T) {
+	validator := NewValidator("en")
+	validator.SetHygieneChecks(true)
+	validator.SetConsolidationChecks(true)
+
+	entries := []*crontab.Entry{
+		crontab.ParseLine("0 2 * * * /usr/bin/cleanup.sh", 1),
+		crontab.ParseLine("0 14 * * * /usr/bin/cleanup.sh", 2),
+		crontab.ParseLine("*/1 * * * * backup", 3),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			result := validator.ValidateExpression("0 0 1 * 1")
+			assert.True(t, result.Valid)
+		}()
+		go func() {
+			defer wg.Done()
+			result := validator.ValidateEntries(entries)
+			assert.Equal(t, 3, result.TotalJobs)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			// Reconfiguring while validations are in flight must not race
+			validator.SetMaxRunsPerDay(1000 + i)
+			validator.SetFrequencyChecks(true)
+		}(i)
+	}
+	wg.Wait()
+}
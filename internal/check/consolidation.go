@@ -0,0 +1,250 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// fieldNames lists the five cron fields in expression order
+var fieldNames = []string{"minute", "hour", "day-of-month", "month", "day-of-week"}
+
+// fieldBounds holds the allowed value range of each cron field in expression order
+var fieldBounds = [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// Field indexes into fieldNames and fieldBounds
+const (
+	fieldMinute = iota
+	fieldHour
+	fieldDayOfMonth
+	fieldMonth
+	fieldDayOfWeek
+)
+
+// consolidationJob is a parsed job considered for consolidation
+type consolidationJob struct {
+	job    *crontab.Job
+	fields []cronx.Field
+	values [][]int
+}
+
+// AnalyzeConsolidation groups jobs that run the same command and suggests
+// consolidating them. A job whose schedule is fully covered by another job
+// running the same command is reported as redundant; two jobs whose schedules
+// differ in exactly one field are reported with a proposed merged expression.
+func AnalyzeConsolidation(jobs []*crontab.Job, parser cronx.Parser) []Issue {
+	var issues []Issue
+
+	// Group valid jobs by normalized command, preserving file order
+	groups := make(map[string][]*consolidationJob)
+	var order []string
+	for _, job := range jobs {
+		if job == nil || !job.Valid {
+			continue
+		}
+		command := normalizeCommand(job.Command)
+		if command == "" {
+			continue
+		}
+
+		schedule, err := parser.Parse(job.Expression)
+		if err != nil {
+			continue
+		}
+
+		cj := newConsolidationJob(job, schedule)
+		if _, ok := groups[command]; !ok {
+			order = append(order, command)
+		}
+		groups[command] = append(groups[command], cj)
+	}
+
+	for _, command := range order {
+		group := groups[command]
+		if len(group) < 2 {
+			continue
+		}
+
+		// Redundant schedules: covered entirely by another job
+		redundant := make(map[int]bool)
+		for i, a := range group {
+			for j, b := range group {
+				if i == j || redundant[j] || !scheduleCovers(b, a) {
+					continue
+				}
+				// For identical schedules, only report the later job
+				if scheduleCovers(a, b) && i < j {
+					continue
+				}
+				redundant[i] = true
+				issues = append(issues, Issue{
+					Severity:   GetCodeSeverity(CodeConsolidationCandidate),
+					Code:       CodeConsolidationCandidate,
+					LineNumber: a.job.LineNumber,
+					Expression: a.job.Expression,
+					Message: fmt.Sprintf("Schedule is already covered by line %d running the same command; merged expression: %s",
+						b.job.LineNumber, b.job.Expression),
+					Hint: fmt.Sprintf("%s Remove this job and keep: %s %s", GetCodeHint(CodeConsolidationCandidate), b.job.Expression, b.job.Command),
+				})
+				break
+			}
+		}
+
+		// Mergeable schedules: identical except for a single field
+		for i := 0; i < len(group); i++ {
+			if redundant[i] {
+				continue
+			}
+			for j := i + 1; j < len(group); j++ {
+				if redundant[j] {
+					continue
+				}
+				merged, field, ok := mergeSchedules(group[i], group[j])
+				if !ok {
+					continue
+				}
+				a, b := group[i], group[j]
+				issues = append(issues, Issue{
+					Severity:   GetCodeSeverity(CodeConsolidationCandidate),
+					Code:       CodeConsolidationCandidate,
+					LineNumber: b.job.LineNumber,
+					Expression: b.job.Expression,
+					Message: fmt.Sprintf("Lines %d and %d run the same command on schedules that differ only in the %s field; merged expression: %s",
+						a.job.LineNumber, b.job.LineNumber, fieldNames[field], merged),
+					Hint: fmt.Sprintf("%s Consider replacing both jobs with: %s %s", GetCodeHint(CodeConsolidationCandidate), merged, a.job.Command),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// newConsolidationJob expands the schedule fields of a job
+func newConsolidationJob(job *crontab.Job, schedule *cronx.Schedule) *consolidationJob {
+	fields := []cronx.Field{schedule.Minute, schedule.Hour, schedule.DayOfMonth, schedule.Month, schedule.DayOfWeek}
+	values := make([][]int, len(fields))
+	for i, f := range fields {
+		values[i] = f.Values()
+	}
+	return &consolidationJob{job: job, fields: fields, values: values}
+}
+
+// normalizeCommand collapses whitespace so trivially different commands compare equal
+func normalizeCommand(command string) string {
+	return strings.Join(strings.Fields(command), " ")
+}
+
+// isStarField reports whether a day field uses cron's "*" semantics.
+// When either day field starts with '*', cron matches days with AND; otherwise with OR.
+func isStarField(f cronx.Field) bool {
+	raw := f.Raw()
+	return strings.HasPrefix(raw, "*") || raw == "?"
+}
+
+// isWildcard reports whether a field is exactly "*" (matches everything)
+func isWildcard(f cronx.Field) bool {
+	return f.Raw() == "*" || f.Raw() == "?"
+}
+
+// scheduleCovers reports whether every run of a is also a run of b.
+// The check is conservative: it may return false for some covering schedules.
+func scheduleCovers(b, a *consolidationJob) bool {
+	for _, i := range []int{fieldMinute, fieldHour, fieldMonth} {
+		if !isSubset(a.values[i], b.values[i]) {
+			return false
+		}
+	}
+
+	// b runs every day
+	if isWildcard(b.fields[fieldDayOfMonth]) && isWildcard(b.fields[fieldDayOfWeek]) {
+		return true
+	}
+
+	domSubset := isSubset(a.values[fieldDayOfMonth], b.values[fieldDayOfMonth])
+	dowSubset := isSubset(a.values[fieldDayOfWeek], b.values[fieldDayOfWeek])
+
+	aAnd := isStarField(a.fields[fieldDayOfMonth]) || isStarField(a.fields[fieldDayOfWeek])
+	bAnd := isStarField(b.fields[fieldDayOfMonth]) || isStarField(b.fields[fieldDayOfWeek])
+
+	switch {
+	case aAnd && !bAnd:
+		// a's days satisfy both of its fields; b needs either of its fields
+		return domSubset || dowSubset
+	case !aAnd && bAnd:
+		// a's days satisfy either of its fields; b needs both
+		return false
+	default:
+		return domSubset && dowSubset
+	}
+}
+
+// mergeSchedules returns a merged expression when two schedules differ in
+// exactly one field, along with the index of that field
+func mergeSchedules(a, b *consolidationJob) (string, int, bool) {
+	differing := -1
+	for i := range a.values {
+		dayField := i == fieldDayOfMonth || i == fieldDayOfWeek
+		if !equalInts(a.values[i], b.values[i]) || (dayField && isStarField(a.fields[i]) != isStarField(b.fields[i])) {
+			if differing != -1 {
+				return "", 0, false
+			}
+			differing = i
+		}
+	}
+	if differing == -1 {
+		return "", 0, false
+	}
+
+	// Merging one day field is only safe when the other day field is "*" in both
+	// schedules, so the DOM/DOW OR semantics cannot change
+	switch differing {
+	case fieldDayOfMonth:
+		if !isWildcard(a.fields[fieldDayOfWeek]) || !isWildcard(b.fields[fieldDayOfWeek]) {
+			return "", 0, false
+		}
+	case fieldDayOfWeek:
+		if !isWildcard(a.fields[fieldDayOfMonth]) || !isWildcard(b.fields[fieldDayOfMonth]) {
+			return "", 0, false
+		}
+	}
+
+	union := append(append([]int{}, a.values[differing]...), b.values[differing]...)
+	parts := make([]string, len(a.fields))
+	for i, f := range a.fields {
+		parts[i] = f.Raw()
+	}
+	bounds := fieldBounds[differing]
+	parts[differing] = cronx.CompressValues(union, bounds[0], bounds[1])
+
+	return strings.Join(parts, " "), differing, true
+}
+
+// isSubset reports whether every value in a (sorted) is present in b (sorted)
+func isSubset(a, b []int) bool {
+	j := 0
+	for _, v := range a {
+		for j < len(b) && b[j] < v {
+			j++
+		}
+		if j == len(b) || b[j] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// equalInts reports whether two int slices are equal
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
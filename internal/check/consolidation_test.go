@@ -0,0 +1,130 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func consolidationJobs(lines ...string) []*crontab.Job {
+	jobs := make([]*crontab.Job, 0, len(lines))
+	for i, line := range lines {
+		entry := crontab.ParseLine(line, i+1)
+		if entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	return jobs
+}
+
+func TestAnalyzeConsolidation(t *testing.T) {
+	parser := cronx.NewParser()
+
+	t.Run("schedules differing in one field are merged", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 2 * * * /usr/bin/cleanup.sh",
+			"0 14 * * * /usr/bin/cleanup.sh",
+		)
+		issues := AnalyzeConsolidation(jobs, parser)
+		require.Len(t, issues, 1)
+		assert.Equal(t, CodeConsolidationCandidate, issues[0].Code)
+		assert.Equal(t, SeverityInfo, issues[0].Severity)
+		assert.Equal(t, 2, issues[0].LineNumber)
+		assert.Contains(t, issues[0].Message, "hour field")
+		assert.Contains(t, issues[0].Message, "0 2,14 * * *")
+		assert.Contains(t, issues[0].Hint, "0 2,14 * * * /usr/bin/cleanup.sh")
+	})
+
+	t.Run("subset schedule is reported as redundant", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"*/15 * * * * /usr/bin/sync",
+			"0 * * * * /usr/bin/sync",
+		)
+		issues := AnalyzeConsolidation(jobs, parser)
+		require.Len(t, issues, 1)
+		assert.Equal(t, 2, issues[0].LineNumber)
+		assert.Contains(t, issues[0].Message, "covered by line 1")
+		assert.Contains(t, issues[0].Message, "*/15 * * * *")
+	})
+
+	t.Run("identical schedules report only the later job", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 3 * * * /usr/bin/backup",
+			"0 3 * * * /usr/bin/backup",
+		)
+		issues := AnalyzeConsolidation(jobs, parser)
+		require.Len(t, issues, 1)
+		assert.Equal(t, 2, issues[0].LineNumber)
+	})
+
+	t.Run("whitespace differences in commands are ignored", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 1 * * * /usr/bin/cleanup.sh  --all",
+			"0 2 * * * /usr/bin/cleanup.sh --all",
+		)
+		assert.Len(t, AnalyzeConsolidation(jobs, parser), 1)
+	})
+
+	t.Run("different commands are not grouped", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 2 * * * /usr/bin/a",
+			"0 14 * * * /usr/bin/b",
+		)
+		assert.Empty(t, AnalyzeConsolidation(jobs, parser))
+	})
+
+	t.Run("schedules differing in several fields are not merged", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 2 * * 1 /usr/bin/report",
+			"30 14 * * 1 /usr/bin/report",
+		)
+		assert.Empty(t, AnalyzeConsolidation(jobs, parser))
+	})
+
+	t.Run("weekday ranges merge into a compressed list", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 9 * * 1-3 /usr/bin/report",
+			"0 9 * * 4,5 /usr/bin/report",
+		)
+		issues := AnalyzeConsolidation(jobs, parser)
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0].Message, "0 9 * * 1-5")
+	})
+
+	t.Run("day fields are not merged when the other day field is restricted", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 9 1 * 1 /usr/bin/report",
+			"0 9 15 * 1 /usr/bin/report",
+		)
+		assert.Empty(t, AnalyzeConsolidation(jobs, parser))
+	})
+
+	t.Run("day-of-month OR semantics are respected for subsets", func(t *testing.T) {
+		// "1 * 1" runs on the 1st OR Mondays, which "1 * *" does not cover
+		jobs := consolidationJobs(
+			"0 0 1 * * /usr/bin/job",
+			"0 0 1 * 1 /usr/bin/job",
+		)
+		issues := AnalyzeConsolidation(jobs, parser)
+		require.Len(t, issues, 1)
+		assert.Equal(t, 1, issues[0].LineNumber, "the day-of-month job is covered by the OR schedule")
+	})
+
+	t.Run("invalid jobs are ignored", func(t *testing.T) {
+		jobs := consolidationJobs(
+			"0 2 * * * /usr/bin/cleanup.sh",
+			"99 14 * * * /usr/bin/cleanup.sh",
+		)
+		assert.Empty(t, AnalyzeConsolidation(jobs, parser))
+	})
+}
+
+func TestIsSubset(t *testing.T) {
+	assert.True(t, isSubset([]int{}, []int{1}))
+	assert.True(t, isSubset([]int{1, 3}, []int{1, 2, 3}))
+	assert.False(t, isSubset([]int{1, 4}, []int{1, 2, 3}))
+	assert.False(t, isSubset([]int{1}, []int{}))
+}
@@ -6,6 +6,14 @@ import "time"
 const (
 	// DefaultOverlapWindow is the default time window for overlap detection
 	DefaultOverlapWindow = 24 * time.Hour
+	// DefaultHorizon is the default look-ahead for empty and distant schedule detection
+	DefaultHorizon = 2 * 365 * 24 * time.Hour
+	// DSTLookahead is how far ahead daylight saving time transitions are checked
+	DSTLookahead = 366 * 24 * time.Hour
+	// HolidayLookahead is how far ahead runs on public holidays are looked for
+	HolidayLookahead = 366 * 24 * time.Hour
+	// ProtectedWindowLookahead is how far ahead runs inside protected windows are looked for
+	ProtectedWindowLookahead = 30 * 24 * time.Hour
 )
 
 // Scheduler run count limits for frequency calculations
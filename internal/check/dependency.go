@@ -0,0 +1,126 @@
+package check
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// dependencyWindow is how far ahead the runs of jobs are compared with the
+// runs of the jobs they run after: a week covers every day of daily and
+// weekly schedules
+const dependencyWindow = 7 * day
+
+// checkDependencies checks the "cronkit:after=" directives of jobs. A job
+// that runs after a name no job has is reported as CRON-027; a job that
+// starts before the run it waits for is expected to finish, given that
+// job's duration, or together with it, is reported as CRON-026. Runs are
+// compared over a week from ReferenceDate, in each job's time zone.
+func (v *Validator) checkDependencies(jobs []*crontab.Job) []Issue {
+	named := make(map[string]*crontab.Job)
+	for _, job := range jobs {
+		if name := job.Metadata.Name; name != "" && named[name] == nil {
+			named[name] = job
+		}
+	}
+
+	start, end := ReferenceDate, ReferenceDate.Add(dependencyWindow)
+	runs := make(map[*crontab.Job][]time.Time)
+	jobRuns := func(job *crontab.Job) []time.Time {
+		if r, ok := runs[job]; ok {
+			return r
+		}
+		// From a day early, so the first runs have the runs they wait for
+		runs[job] = v.runsBetween(job, start.Add(-day), end)
+		return runs[job]
+	}
+
+	var issues []Issue
+	for _, job := range jobs {
+		if !job.Valid {
+			continue
+		}
+		for _, name := range job.Metadata.After {
+			first, ok := named[name]
+			switch {
+			case !ok:
+				issues = append(issues, Issue{
+					Severity:   GetCodeSeverity(CodeUnknownDependency),
+					Code:       CodeUnknownDependency,
+					File:       job.Source,
+					LineNumber: job.LineNumber,
+					Expression: job.Expression,
+					Message:    fmt.Sprintf("Runs after %q, but no job is named %q", name, name),
+					Hint:       GetCodeHint(CodeUnknownDependency),
+				})
+				continue
+			case first == job || !first.Valid:
+				continue
+			}
+
+			var late []crontab.DependencyRun
+			total := 0
+			for _, r := range crontab.PairDependencyRuns(jobRuns(job), jobRuns(first), first.Duration) {
+				if r.Start.Before(start) {
+					continue
+				}
+				total++
+				if r.Late() {
+					late = append(late, r)
+				}
+			}
+			if len(late) == 0 {
+				continue
+			}
+
+			r := late[0]
+			message := fmt.Sprintf("Starts at %s, before %q (line %d), which it runs after, is expected to finish at %s",
+				r.Start.Format("Mon 15:04"), name, first.LineNumber, r.Finish.In(r.Start.Location()).Format("15:04"))
+			if !r.Start.After(r.After) {
+				message = fmt.Sprintf("Starts at %s, at the same time as %q (line %d), which it runs after",
+					r.Start.Format("Mon 15:04"), name, first.LineNumber)
+			}
+			issues = append(issues, Issue{
+				Severity:   GetCodeSeverity(CodeDependencyOrder),
+				Code:       CodeDependencyOrder,
+				File:       job.Source,
+				LineNumber: job.LineNumber,
+				Expression: job.Expression,
+				Message:    fmt.Sprintf("%s (%d of %d runs in a week)", message, len(late), total),
+				Hint:       GetCodeHint(CodeDependencyOrder),
+			})
+		}
+	}
+	sort.SliceStable(issues, func(a, b int) bool { return issues[a].LineNumber < issues[b].LineNumber })
+	return issues
+}
+
+// runsBetween returns the runs of a job from start until end, in the job's
+// time zone
+func (v *Validator) runsBetween(job *crontab.Job, start, end time.Time) []time.Time {
+	loc, err := job.Location(v.location)
+	if err != nil {
+		return nil
+	}
+	if loc != nil {
+		start = start.In(loc)
+	}
+
+	var runs []time.Time
+	query := start.Add(-time.Second)
+	for query.Before(end) {
+		times, err := v.scheduler.Next(job.Expression, query, MaxRunsForDailyCalculation)
+		if err != nil || len(times) == 0 || !times[len(times)-1].After(query) {
+			break
+		}
+		for _, t := range times {
+			if t.Before(end) {
+				runs = append(runs, t)
+			}
+		}
+		query = times[len(times)-1]
+	}
+	return runs
+}
@@ -0,0 +1,80 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator_CheckDependencies(t *testing.T) {
+	v := NewValidator("en")
+	job := func(line int, expression string, meta crontab.Metadata) *crontab.Job {
+		return &crontab.Job{LineNumber: line, Expression: expression, Valid: true, Metadata: meta, Duration: meta.Duration}
+	}
+	backup := job(1, "0 2 * * *", crontab.Metadata{Name: "backup", Duration: 30 * time.Minute})
+
+	t.Run("job starting before the job it runs after finishes", func(t *testing.T) {
+		report := job(2, "15 2 * * *", crontab.Metadata{After: []string{"backup"}})
+		issues := v.checkDependencies([]*crontab.Job{backup, report})
+		require.Len(t, issues, 1)
+		assert.Equal(t, CodeDependencyOrder, issues[0].Code)
+		assert.Equal(t, SeverityWarn, issues[0].Severity)
+		assert.Equal(t, 2, issues[0].LineNumber)
+		assert.Equal(t, `Starts at Wed 02:15, before "backup" (line 1), which it runs after, is expected to finish at 02:30 (7 of 7 runs in a week)`, issues[0].Message)
+	})
+
+	t.Run("job starting with the job it runs after", func(t *testing.T) {
+		dump := job(1, "0 2 * * *", crontab.Metadata{Name: "dump"})
+		upload := job(2, "0 2 * * 1", crontab.Metadata{After: []string{"dump"}})
+		issues := v.checkDependencies([]*crontab.Job{dump, upload})
+		require.Len(t, issues, 1)
+		assert.Equal(t, `Starts at Mon 02:00, at the same time as "dump" (line 1), which it runs after (1 of 1 runs in a week)`, issues[0].Message)
+	})
+
+	t.Run("job starting after the job it runs after finishes", func(t *testing.T) {
+		report := job(2, "45 2 * * *", crontab.Metadata{After: []string{"backup"}})
+		assert.Empty(t, v.checkDependencies([]*crontab.Job{backup, report}))
+
+		// Runs before backup in the day wait for the day before's backup
+		early := job(3, "0 1 * * *", crontab.Metadata{After: []string{"backup"}})
+		assert.Empty(t, v.checkDependencies([]*crontab.Job{backup, early}))
+	})
+
+	t.Run("job running after an unknown name", func(t *testing.T) {
+		report := job(2, "45 2 * * *", crontab.Metadata{After: []string{"backup", "sync"}})
+		issues := v.checkDependencies([]*crontab.Job{backup, report})
+		require.Len(t, issues, 1)
+		assert.Equal(t, CodeUnknownDependency, issues[0].Code)
+		assert.Equal(t, `Runs after "sync", but no job is named "sync"`, issues[0].Message)
+	})
+
+	t.Run("time zones are compared as instants", func(t *testing.T) {
+		utc := job(1, "0 2 * * *", crontab.Metadata{Name: "backup", Duration: 30 * time.Minute})
+		utc.Timezone = "UTC"
+		paris := job(2, "15 3 * * *", crontab.Metadata{After: []string{"backup"}})
+		paris.Timezone = "Europe/Paris"
+		issues := v.checkDependencies([]*crontab.Job{utc, paris})
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0].Message, "Starts at Wed 03:15")
+		assert.Contains(t, issues[0].Message, "finish at 03:30")
+	})
+}
+
+func TestValidator_DependencyIssues(t *testing.T) {
+	entries, err := crontab.ParseReader(strings.NewReader(`# cronkit:name=backup duration=30m
+0 2 * * * /usr/bin/backup.sh
+10 2 * * * /usr/bin/report.sh # cronkit:after=backup
+`))
+	require.NoError(t, err)
+
+	v := NewValidator("en")
+	var codes []string
+	for _, issue := range v.ValidateEntries(entries).Issues {
+		codes = append(codes, issue.Code)
+	}
+	assert.Contains(t, codes, CodeDependencyOrder)
+}
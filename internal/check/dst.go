@@ -0,0 +1,94 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// dstTransition is a change of UTC offset in a time zone. start and end
+// bound the affected wall-clock times, expressed as UTC times so that they
+// can be matched against a schedule without DST adjustments.
+type dstTransition struct {
+	gap   bool // Clocks move forward: wall-clock times in [start, end) do not exist
+	start time.Time
+	end   time.Time // For fall-back transitions, wall-clock times in [start, end) occur twice
+}
+
+// dstTransitions returns the offset changes of loc between from and to
+func dstTransitions(loc *time.Location, from, to time.Time) []dstTransition {
+	var transitions []dstTransition
+	t := from.In(loc)
+	for {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.Before(to) {
+			return transitions
+		}
+		_, before := t.Zone()
+		_, after := end.In(loc).Zone()
+
+		// Wall-clock time at the change, on the clock in use before it
+		wall := end.Add(time.Duration(before) * time.Second).UTC()
+		shift := time.Duration(after-before) * time.Second
+		switch {
+		case shift > 0:
+			transitions = append(transitions, dstTransition{gap: true, start: wall, end: wall.Add(shift)})
+		case shift < 0:
+			transitions = append(transitions, dstTransition{start: wall.Add(shift), end: wall})
+		}
+		t = end.In(loc)
+	}
+}
+
+// checkDST reports schedules with runs in the wall-clock hours skipped or
+// repeated by the DST transitions of loc within DSTLookahead of now. Only
+// schedules with a fixed hour are reported: jobs running every hour keep their
+// cadence across transitions, as in Vixie cron.
+func (v *Validator) checkDST(expression string, schedule *cronx.Schedule, lineNumber int, loc *time.Location, now time.Time) *Issue {
+	if strings.HasPrefix(schedule.Hour.Raw(), "*") {
+		return nil
+	}
+
+	var affected []string
+	var skipped, repeated bool
+	for _, tr := range dstTransitions(loc, now, now.Add(DSTLookahead)) {
+		// The scheduler runs in UTC here, so wall-clock times map one to one
+		times, err := v.scheduler.Next(expression, tr.start.Add(-time.Second), 1)
+		if err != nil || len(times) == 0 || times[0].IsZero() || !times[0].Before(tr.end) {
+			continue
+		}
+
+		run := times[0].Format("15:04")
+		if tr.gap {
+			skipped = true
+			affected = append(affected, fmt.Sprintf("%s (%s does not exist, run may be skipped)", tr.start.Format("2006-01-02"), run))
+		} else {
+			repeated = true
+			affected = append(affected, fmt.Sprintf("%s (%s occurs twice, job may run twice)", tr.start.Format("2006-01-02"), run))
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+
+	var effect string
+	switch {
+	case skipped && repeated:
+		effect = "skipped or run twice"
+	case skipped:
+		effect = "skipped"
+	default:
+		effect = "run twice"
+	}
+
+	return &Issue{
+		Severity:   GetCodeSeverity(CodeDSTTransition),
+		Code:       CodeDSTTransition,
+		LineNumber: lineNumber,
+		Expression: expression,
+		Message:    fmt.Sprintf("Schedule may be %s across daylight saving time transitions in %s", effect, loc),
+		Hint:       fmt.Sprintf("%s Affected dates: %s", GetCodeHint(CodeDSTTransition), strings.Join(affected, "; ")),
+	}
+}
@@ -0,0 +1,142 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSTTransitions(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	transitions := dstTransitions(newYork, from, from.AddDate(1, 0, 0))
+	require.Len(t, transitions, 2)
+
+	assert.True(t, transitions[0].gap)
+	assert.Equal(t, time.Date(2026, 3, 8, 2, 0, 0, 0, time.UTC), transitions[0].start)
+	assert.Equal(t, time.Date(2026, 3, 8, 3, 0, 0, 0, time.UTC), transitions[0].end)
+
+	assert.False(t, transitions[1].gap)
+	assert.Equal(t, time.Date(2026, 11, 1, 1, 0, 0, 0, time.UTC), transitions[1].start)
+	assert.Equal(t, time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC), transitions[1].end)
+
+	assert.Empty(t, dstTransitions(time.UTC, from, from.AddDate(1, 0, 0)))
+}
+
+func TestValidator_CheckDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		loc        *time.Location
+		message    string // Empty when no issue is expected
+		dates      []string
+	}{
+		{
+			name:       "run in the spring-forward gap",
+			expression: "30 2 * * *",
+			loc:        newYork,
+			message:    "may be skipped across daylight saving time transitions in America/New_York",
+			dates:      []string{"2026-03-08 (02:30 does not exist, run may be skipped)"},
+		},
+		{
+			name:       "run in the repeated fall-back hour",
+			expression: "30 1 * * *",
+			loc:        newYork,
+			message:    "may be run twice",
+			dates:      []string{"2026-11-01 (01:30 occurs twice, job may run twice)"},
+		},
+		{
+			name:       "both transitions",
+			expression: "15 1 * * 0",
+			loc:        london,
+			message:    "may be skipped or run twice",
+			dates:      []string{"2026-03-29 (01:15 does not exist", "2026-10-25 (01:15 occurs twice"},
+		},
+		{
+			name:       "weekday that misses the transition date",
+			expression: "30 2 * * 1-5",
+			loc:        newYork,
+		},
+		{
+			name:       "run outside the transition window",
+			expression: "0 4 * * *",
+			loc:        newYork,
+		},
+		{
+			name:       "hourly jobs keep their cadence",
+			expression: "30 * * * *",
+			loc:        newYork,
+		},
+		{
+			name:       "zone without DST",
+			expression: "30 2 * * *",
+			loc:        time.UTC,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewValidator("en")
+			schedule, err := validator.parser.Parse(tt.expression)
+			require.NoError(t, err)
+
+			issue := validator.checkDST(tt.expression, schedule, 3, tt.loc, now)
+			if tt.message == "" {
+				assert.Nil(t, issue)
+				return
+			}
+			require.NotNil(t, issue)
+			assert.Equal(t, CodeDSTTransition, issue.Code)
+			assert.Equal(t, SeverityWarn, issue.Severity)
+			assert.Equal(t, 3, issue.LineNumber)
+			assert.Contains(t, issue.Message, tt.message)
+			for _, date := range tt.dates {
+				assert.Contains(t, issue.Hint, date)
+			}
+		})
+	}
+}
+
+func TestValidator_DSTChecks(t *testing.T) {
+	t.Run("disabled without a timezone", func(t *testing.T) {
+		result := NewValidator("en").ValidateExpression("30 2 * * *")
+		assert.Empty(t, result.Issues)
+	})
+
+	t.Run("expression checked in the validator timezone", func(t *testing.T) {
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		validator := NewValidator("en")
+		validator.SetTimezone(loc)
+		result := validator.ValidateExpression("30 2 * * *")
+		assert.True(t, result.Valid)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, CodeDSTTransition, result.Issues[0].Code)
+	})
+
+	t.Run("crontab jobs checked in their CRON_TZ", func(t *testing.T) {
+		entries, err := crontab.ParseReader(strings.NewReader(
+			"30 2 * * * /usr/bin/local.sh\nCRON_TZ=America/New_York\n30 2 * * * /usr/bin/new-york.sh\n"))
+		require.NoError(t, err)
+
+		validator := NewValidator("en")
+		validator.SetTimezone(time.UTC)
+		result := validator.ValidateEntries(entries)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, CodeDSTTransition, result.Issues[0].Code)
+		assert.Equal(t, 3, result.Issues[0].LineNumber)
+	})
+}
@@ -0,0 +1,68 @@
+package check
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExitCodes are the exit codes of check by the most severe issue reported
+type ExitCodes struct {
+	Error int
+	Warn  int
+	Info  int
+}
+
+// DefaultExitCodes are 1 for errors and 2 for warnings and info messages
+var DefaultExitCodes = ExitCodes{Error: 1, Warn: 2, Info: 2}
+
+// ParseExitCodes parses a comma-separated list of severity=code pairs, e.g.
+// "error=1,warn=0". Severities not listed keep their default code.
+func ParseExitCodes(s string) (ExitCodes, error) {
+	codes := DefaultExitCodes
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return ExitCodes{}, fmt.Errorf("invalid exit code %q (expected severity=code, e.g. warn=0)", pair)
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || code < 0 || code > 125 {
+			return ExitCodes{}, fmt.Errorf("invalid exit code %q for %s (must be 0-125)", value, strings.TrimSpace(name))
+		}
+		severity, err := ParseFailOnLevel(strings.TrimSpace(name))
+		if err != nil {
+			return ExitCodes{}, fmt.Errorf("invalid severity %q (must be 'error', 'warn', or 'info')", strings.TrimSpace(name))
+		}
+		switch severity {
+		case SeverityError:
+			codes.Error = code
+		case SeverityWarn:
+			codes.Warn = code
+		case SeverityInfo:
+			codes.Info = code
+		}
+	}
+	return codes, nil
+}
+
+// For returns the exit code of a severity
+func (c ExitCodes) For(s Severity) int {
+	switch s {
+	case SeverityError:
+		return c.Error
+	case SeverityWarn:
+		return c.Warn
+	case SeverityInfo:
+		return c.Info
+	default:
+		return 0
+	}
+}
+
+// String formats the exit codes as ParseExitCodes reads them
+func (c ExitCodes) String() string {
+	return fmt.Sprintf("error=%d,warn=%d,info=%d", c.Error, c.Warn, c.Info)
+}
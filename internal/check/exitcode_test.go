@@ -0,0 +1,33 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExitCodes(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		codes, err := ParseExitCodes("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultExitCodes, codes)
+		assert.Equal(t, "error=1,warn=2,info=2", codes.String())
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		codes, err := ParseExitCodes("error=3, WARNING=0")
+		require.NoError(t, err)
+		assert.Equal(t, ExitCodes{Error: 3, Warn: 0, Info: 2}, codes)
+		assert.Equal(t, 3, codes.For(SeverityError))
+		assert.Equal(t, 0, codes.For(SeverityWarn))
+		assert.Equal(t, 2, codes.For(SeverityInfo))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"error", "error=one", "error=-1", "error=126", "fatal=1"} {
+			_, err := ParseExitCodes(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -16,26 +16,33 @@ func CalculateRunsPerDay(expression string, scheduler cronx.Scheduler) (int, err
 	queryTime := startTime.Add(-1 * time.Second) // Query from just before midnight
 	endTime := startTime.Add(DefaultOverlapWindow)
 
-	// Get all runs for a 24-hour period
-	// For every-minute schedules, we need 1440 runs. Use a larger limit to be safe.
-	times, err := scheduler.Next(expression, queryTime, MaxRunsForDailyCalculation)
-	if err != nil {
-		return 0, fmt.Errorf("failed to calculate runs: %w", err)
-	}
-
-	// Count runs that fall within the 24-hour window [startTime, endTime)
+	// Get all runs for a 24-hour period in batches.
+	// For every-minute schedules, we need 1440 runs; schedules with a seconds
+	// field may need up to 86400, so keep fetching until the window is covered.
 	count := 0
-	for _, t := range times {
-		if !t.Before(endTime) {
-			break
+	for {
+		times, err := scheduler.Next(expression, queryTime, MaxRunsForDailyCalculation)
+		if err != nil {
+			return 0, fmt.Errorf("failed to calculate runs: %w", err)
 		}
-		// Include all times >= startTime and < endTime
-		if !t.Before(startTime) {
-			count++
+
+		// Count runs that fall within the 24-hour window [startTime, endTime)
+		for _, t := range times {
+			if !t.Before(endTime) {
+				return count, nil
+			}
+			// Include all times >= startTime and < endTime
+			if !t.Before(startTime) {
+				count++
+			}
 		}
-	}
 
-	return count, nil
+		// Stop if the scheduler made no progress
+		if len(times) == 0 || !times[len(times)-1].After(queryTime) {
+			return count, nil
+		}
+		queryTime = times[len(times)-1]
+	}
 }
 
 // DetectRedundantPattern detects if a schedule uses redundant step patterns like */1
@@ -48,6 +55,9 @@ func DetectRedundantPattern(schedule *cronx.Schedule) bool {
 		schedule.Month,
 		schedule.DayOfWeek,
 	}
+	if schedule.HasSeconds() {
+		fields = append(fields, schedule.Second)
+	}
 
 	for _, field := range fields {
 		raw := field.Raw()
@@ -99,6 +109,14 @@ func EstimateRunFrequency(expression string, scheduler cronx.Scheduler) (runsPer
 // GetRedundantPatternSuggestion returns a suggestion for simplifying a redundant pattern
 func GetRedundantPatternSuggestion(expression string, schedule *cronx.Schedule) string {
 	parts := strings.Fields(expression)
+	if len(parts) == 6 && schedule.HasSeconds() {
+		// Simplify the seconds field, then the remaining 5 fields as usual
+		second := parts[0]
+		if strings.HasSuffix(second, "/1") {
+			second = "*"
+		}
+		return second + " " + GetRedundantPatternSuggestion(strings.Join(parts[1:], " "), schedule)
+	}
 	if len(parts) != 5 {
 		return expression // Can't simplify if not standard format
 	}
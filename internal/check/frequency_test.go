@@ -35,6 +35,13 @@ func TestCalculateRunsPerDay(t *testing.T) {
 		assert.Equal(t, 1440, runs, "Every minute should run 1440 times per day")
 	})
 
+	t.Run("should count sub-minute runs beyond a single batch", func(t *testing.T) {
+		seconds := cronx.NewSchedulerWithSeconds(cronx.SecondsOptional)
+		runs, err := CalculateRunsPerDay("*/10 * * * * *", seconds)
+		require.NoError(t, err)
+		assert.Equal(t, 8640, runs, "Every 10 seconds should run 8640 times per day")
+	})
+
 	t.Run("should return error for invalid expression", func(t *testing.T) {
 		_, err := CalculateRunsPerDay("invalid", scheduler)
 		require.Error(t, err)
@@ -0,0 +1,78 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+const (
+	// GitHubMinInterval is the shortest interval at which GitHub runs
+	// scheduled workflows
+	GitHubMinInterval = 5 * time.Minute
+
+	// githubIntervalSample is the number of runs inspected for the shortest
+	// interval: a day of runs at GitHubMinInterval
+	githubIntervalSample = 288
+)
+
+// checkGitHubSchedule reports GitHub Actions schedules that run more often
+// than GitHub allows (CRON-017) and, as INFO, schedules at fixed hours, which
+// GitHub evaluates in UTC (CRON-018). With a time zone set, the UTC time of
+// the next run is shown in that zone as well.
+func (v *Validator) checkGitHubSchedule(job *crontab.Job, schedule *cronx.Schedule) []Issue {
+	var issues []Issue
+
+	runs, err := v.scheduler.Next(job.Expression, ReferenceDate.Add(-time.Second), githubIntervalSample)
+	if err == nil && len(runs) > 1 {
+		shortest := runs[1].Sub(runs[0])
+		for i := 2; i < len(runs); i++ {
+			if gap := runs[i].Sub(runs[i-1]); gap < shortest {
+				shortest = gap
+			}
+		}
+		if shortest < GitHubMinInterval {
+			issues = append(issues, Issue{
+				Severity:   GetCodeSeverity(CodeGitHubThrottled),
+				Code:       CodeGitHubThrottled,
+				LineNumber: job.LineNumber,
+				Expression: job.Expression,
+				Message:    fmt.Sprintf("Runs as often as every %s, but GitHub runs scheduled workflows at most every %s", formatInterval(shortest), formatInterval(GitHubMinInterval)),
+				Hint:       GetCodeHint(CodeGitHubThrottled),
+			})
+		}
+	}
+
+	if !strings.HasPrefix(schedule.Hour.Raw(), "*") {
+		message := "Runs at fixed hours, which GitHub evaluates in UTC"
+		if next, err := v.scheduler.Next(job.Expression, time.Now().UTC(), 1); err == nil && len(next) == 1 && !next[0].IsZero() {
+			message += fmt.Sprintf(" (next run %s UTC", next[0].Format("2006-01-02 15:04"))
+			if v.location != nil && v.location.String() != "UTC" {
+				message += fmt.Sprintf(", %s in %s", next[0].In(v.location).Format("15:04"), v.location)
+			}
+			message += ")"
+		}
+		issues = append(issues, Issue{
+			Severity:   GetCodeSeverity(CodeGitHubUTC),
+			Code:       CodeGitHubUTC,
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Message:    message,
+			Hint:       GetCodeHint(CodeGitHubUTC),
+		})
+	}
+
+	return issues
+}
+
+// formatInterval formats a whole number of minutes, e.g. "1 minute" or "5 minutes"
+func formatInterval(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
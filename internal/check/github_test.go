@@ -0,0 +1,68 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func githubEntry(expression string, line int) *crontab.Entry {
+	return &crontab.Entry{
+		Type:       crontab.EntryTypeJob,
+		LineNumber: line,
+		Job: &crontab.Job{
+			LineNumber: line,
+			Expression: expression,
+			Command:    "Nightly",
+			Timezone:   "UTC",
+			Source:     ".github/workflows/nightly.yml",
+			Valid:      true,
+		},
+	}
+}
+
+func TestValidator_GitHubActions(t *testing.T) {
+	entries := []*crontab.Entry{
+		githubEntry("*/2 * * * *", 4),
+		githubEntry("*/5 * * * *", 5),
+		githubEntry("30 9 * * 1-5", 6),
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result := NewValidator("en").ValidateEntries(entries)
+		for _, issue := range result.Issues {
+			assert.NotEqual(t, CodeGitHubThrottled, issue.Code)
+			assert.NotEqual(t, CodeGitHubUTC, issue.Code)
+		}
+	})
+
+	t.Run("reports throttled and UTC schedules", func(t *testing.T) {
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		v.SetGitHubActions(true)
+		v.SetTimezone(time.FixedZone("CET", 3600))
+		result := v.ValidateEntries(entries)
+
+		require.Len(t, result.Issues, 2)
+		throttled := result.Issues[0]
+		assert.Equal(t, CodeGitHubThrottled, throttled.Code)
+		assert.Equal(t, SeverityWarn, throttled.Severity)
+		assert.Equal(t, 4, throttled.LineNumber)
+		assert.Equal(t, ".github/workflows/nightly.yml", throttled.File)
+		assert.Contains(t, throttled.Message, "every 2 minutes")
+
+		utc := result.Issues[1]
+		assert.Equal(t, CodeGitHubUTC, utc.Code)
+		assert.Equal(t, SeverityInfo, utc.Severity)
+		assert.Equal(t, 6, utc.LineNumber)
+		assert.Contains(t, utc.Message, "09:30 UTC, 10:30 in CET")
+	})
+}
+
+func TestFormatInterval(t *testing.T) {
+	assert.Equal(t, "1 minute", formatInterval(time.Minute))
+	assert.Equal(t, "5 minutes", formatInterval(5*time.Minute))
+}
@@ -0,0 +1,89 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/holiday"
+)
+
+// BusinessCriticalTag is the "cronkit: tags=" label of jobs whose weekday
+// schedules are checked against public holidays (CRON-025)
+const BusinessCriticalTag = "business-critical"
+
+// maxHolidaysListed is how many of the holidays a job runs on are named in
+// the hint of a CRON-025 issue
+const maxHolidaysListed = 3
+
+// checkHolidays reports business-critical jobs that run on weekdays only:
+// such schedules usually stand for business days, but cron runs them on the
+// public holidays that fall on a weekday too. With a holiday calendar, only
+// jobs that run on one of its holidays within HolidayLookahead of now are
+// reported, naming the first ones.
+func (v *Validator) checkHolidays(job *crontab.Job, schedule *cronx.Schedule, calendar *holiday.Calendar, now time.Time) *Issue {
+	if !job.Metadata.HasTag(BusinessCriticalTag) || !weekdaysOnly(schedule) {
+		return nil
+	}
+
+	issue := &Issue{
+		Severity:   GetCodeSeverity(CodeHolidayRuns),
+		Code:       CodeHolidayRuns,
+		LineNumber: job.LineNumber,
+		Expression: job.Expression,
+		Message:    "Business-critical job runs on weekdays only, including public holidays",
+		Hint:       GetCodeHint(CodeHolidayRuns),
+	}
+	if calendar == nil {
+		return issue
+	}
+
+	loc, err := job.Location(time.Local)
+	if err != nil {
+		return nil
+	}
+	now = now.In(loc)
+	var runs []string
+	total := 0
+	for _, h := range calendar.Between(now, now.Add(HolidayLookahead)) {
+		day := time.Date(h.Date.Year(), h.Date.Month(), h.Date.Day(), 0, 0, 0, 0, loc)
+		times, err := v.scheduler.Next(job.Expression, day.Add(-time.Second), 1)
+		if err != nil || len(times) == 0 || !times[0].Before(day.AddDate(0, 0, 1)) || times[0].Before(now) {
+			continue
+		}
+		total++
+		if len(runs) < maxHolidaysListed {
+			runs = append(runs, fmt.Sprintf("%s (%s)", h.Date.Format("2006-01-02"), h.Name))
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	if total > len(runs) {
+		runs = append(runs, fmt.Sprintf("and %d more", total-len(runs)))
+	}
+	issue.Message = fmt.Sprintf("Business-critical job runs on weekdays only, including %d public holiday(s) of %s in the next year", total, calendar.Name)
+	issue.Hint = fmt.Sprintf("%s Holidays it runs on: %s", GetCodeHint(CodeHolidayRuns), strings.Join(runs, ", "))
+	return issue
+}
+
+// weekdaysOnly reports whether a schedule runs on some of Monday to Friday
+// and on no other day: its day-of-week field is restricted to weekdays and
+// its day-of-month field is unrestricted
+func weekdaysOnly(schedule *cronx.Schedule) bool {
+	if schedule.Dialect == cronx.DialectQuartz || schedule.Dialect == cronx.DialectAWS || schedule.Quartz != nil {
+		return false
+	}
+	if schedule.DayOfWeek.IsEvery() || !schedule.DayOfMonth.IsEvery() {
+		return false
+	}
+	for _, day := range schedule.DayOfWeek.Values() {
+		if day == 0 || day >= 6 {
+			return false
+		}
+	}
+	return true
+}
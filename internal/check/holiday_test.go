@@ -0,0 +1,89 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/holiday"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator_CheckHolidays(t *testing.T) {
+	v := NewValidator("en")
+	us, ok := holiday.Country("US")
+	require.True(t, ok)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	critical := crontab.Metadata{Tags: []string{BusinessCriticalTag}}
+
+	job := func(expression string, meta crontab.Metadata) *crontab.Job {
+		return &crontab.Job{LineNumber: 3, Expression: expression, Valid: true, Metadata: meta, Timezone: "UTC"}
+	}
+	check := func(j *crontab.Job, calendar *holiday.Calendar) *Issue {
+		schedule, err := v.parser.Parse(j.Expression)
+		require.NoError(t, err)
+		return v.checkHolidays(j, schedule, calendar, now)
+	}
+
+	t.Run("weekday jobs are reported with the holidays they run on", func(t *testing.T) {
+		issue := check(job("0 9 * * 1-5", critical), us)
+		require.NotNil(t, issue)
+		assert.Equal(t, CodeHolidayRuns, issue.Code)
+		assert.Equal(t, SeverityWarn, issue.Severity)
+		assert.Equal(t, 3, issue.LineNumber)
+		assert.Contains(t, issue.Message, "public holiday(s) of United States (federal)")
+		assert.Contains(t, issue.Hint, "2026-01-19 (Martin Luther King Jr. Day), 2026-02-16 (Washington's Birthday), 2026-05-25 (Memorial Day), and")
+	})
+
+	t.Run("jobs that never run on a holiday are not reported", func(t *testing.T) {
+		assert.Nil(t, check(job("0 9 * * 1-5", critical), &holiday.Calendar{Name: "empty"}))
+
+		// Thanksgiving is the only federal holiday of 2026 on a Thursday
+		issue := check(job("0 9 * * 4", critical), us)
+		require.NotNil(t, issue)
+		assert.Contains(t, issue.Hint, "Holidays it runs on: 2026-11-26 (Thanksgiving Day)")
+	})
+
+	t.Run("without a calendar, every weekday job is reported", func(t *testing.T) {
+		issue := check(job("0 9 * * MON-FRI", critical), nil)
+		require.NotNil(t, issue)
+		assert.Equal(t, "Business-critical job runs on weekdays only, including public holidays", issue.Message)
+		assert.Equal(t, GetCodeHint(CodeHolidayRuns), issue.Hint)
+	})
+
+	t.Run("other jobs are not reported", func(t *testing.T) {
+		assert.Nil(t, check(job("0 9 * * 1-5", crontab.Metadata{Tags: []string{"reporting"}}), us))
+		assert.Nil(t, check(job("0 9 * * *", critical), us))
+		assert.Nil(t, check(job("0 9 * * 0-4", critical), us))
+		assert.Nil(t, check(job("0 9 1 * 1-5", critical), us))
+	})
+}
+
+func TestValidator_SetHolidays(t *testing.T) {
+	entries := []*crontab.Entry{{
+		Type:       crontab.EntryTypeJob,
+		LineNumber: 1,
+		Job: &crontab.Job{
+			LineNumber: 1,
+			Expression: "0 9 * * 1-5",
+			Command:    "/usr/bin/payroll.sh",
+			Valid:      true,
+			Metadata:   crontab.Metadata{Tags: []string{BusinessCriticalTag}},
+		},
+	}}
+
+	v := NewValidator("en")
+	v.SetHolidays(&holiday.Calendar{Name: "empty"})
+	for _, issue := range v.ValidateEntries(entries).Issues {
+		assert.NotEqual(t, CodeHolidayRuns, issue.Code)
+	}
+
+	us, _ := holiday.Country("US")
+	v.SetHolidays(us)
+	var codes []string
+	for _, issue := range v.ValidateEntries(entries).Issues {
+		codes = append(codes, issue.Code)
+	}
+	assert.Contains(t, codes, CodeHolidayRuns)
+}
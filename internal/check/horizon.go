@@ -0,0 +1,129 @@
+package check
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+const (
+	// day and year are the calendar-agnostic units used by horizon durations
+	day  = 24 * time.Hour
+	year = 365 * day
+)
+
+// ParseHorizon parses a look-ahead horizon such as "2y", "18mo", "6w", "90d" or
+// any duration accepted by time.ParseDuration (e.g. "720h").
+// Months count as 30 days and years as 365 days.
+func ParseHorizon(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"mo", 30 * day},
+		{"y", year},
+		{"w", 7 * day},
+		{"d", day},
+	}
+	for _, u := range units {
+		if !strings.HasSuffix(s, u.suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(s, u.suffix))
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid horizon: %q (expected a positive number, e.g. 2y, 18mo, 90d)", s)
+		}
+		return time.Duration(n) * u.unit, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid horizon: %q (e.g. 2y, 18mo, 6w, 90d, 720h)", s)
+	}
+	return d, nil
+}
+
+// FormatHorizon formats a horizon using the largest whole unit (e.g. "2y", "90d")
+func FormatHorizon(d time.Duration) string {
+	switch {
+	case d >= year && d%year == 0:
+		return fmt.Sprintf("%dy", d/year)
+	case d >= day && d%day == 0:
+		return fmt.Sprintf("%dd", d/day)
+	default:
+		return d.String()
+	}
+}
+
+// formatDistance describes how far ahead a run is (e.g. "14 months", "3 years")
+func formatDistance(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	days := int(d / day)
+	switch {
+	case days < 2:
+		return plural(int(d/time.Hour), "hour")
+	case days < 60:
+		return plural(days, "day")
+	case days < 730:
+		return plural(days/30, "month")
+	default:
+		return plural(days/365, "year")
+	}
+}
+
+// firstRun returns the first run of a schedule after now.
+// ok is false when the schedule never runs or cannot be evaluated.
+func firstRun(expression string, scheduler cronx.Scheduler, now time.Time) (time.Time, bool) {
+	times, err := scheduler.Next(expression, now, 1)
+	if err != nil || len(times) == 0 || times[0].IsZero() {
+		return time.Time{}, false
+	}
+	return times[0], true
+}
+
+// checkFirstRun reports schedules that never run (error) or whose first run
+// lies beyond the validator's horizon (info). empty is true when the schedule
+// never runs and the job must be counted as invalid.
+func (v *Validator) checkFirstRun(expression string, lineNumber int) (issue *Issue, empty bool) {
+	now := time.Now()
+	next, ok := firstRun(expression, v.scheduler, now)
+	if !ok {
+		return &Issue{
+			Severity:   SeverityError,
+			Code:       CodeEmptySchedule,
+			LineNumber: lineNumber,
+			Expression: expression,
+			Message:    "Schedule never runs (empty schedule)",
+			Hint:       GetCodeHint(CodeEmptySchedule),
+		}, true
+	}
+
+	horizon := v.horizon
+	if horizon <= 0 {
+		horizon = DefaultHorizon
+	}
+	distance := next.Sub(now)
+	if distance <= horizon {
+		return nil, false
+	}
+
+	return &Issue{
+		Severity:   GetCodeSeverity(CodeDistantFirstRun),
+		Code:       CodeDistantFirstRun,
+		LineNumber: lineNumber,
+		Expression: expression,
+		Message: fmt.Sprintf("First run in %s (%s), beyond the %s horizon",
+			formatDistance(distance), next.Format("2006-01-02"), FormatHorizon(horizon)),
+		Hint: GetCodeHint(CodeDistantFirstRun),
+	}, false
+}
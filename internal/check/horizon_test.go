@@ -0,0 +1,114 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHorizon(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"2y", 2 * 365 * 24 * time.Hour},
+		{"18mo", 18 * 30 * 24 * time.Hour},
+		{"6w", 6 * 7 * 24 * time.Hour},
+		{"90d", 90 * 24 * time.Hour},
+		{"720h", 720 * time.Hour},
+		{" 1Y ", 365 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseHorizon(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+
+	for _, input := range []string{"", "y", "-1y", "0d", "soon", "1.5y"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := ParseHorizon(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatHorizon(t *testing.T) {
+	assert.Equal(t, "2y", FormatHorizon(DefaultHorizon))
+	assert.Equal(t, "90d", FormatHorizon(90*24*time.Hour))
+	assert.Equal(t, "36h0m0s", FormatHorizon(36*time.Hour))
+}
+
+func TestFormatDistance(t *testing.T) {
+	assert.Equal(t, "5 hours", formatDistance(5*time.Hour))
+	assert.Equal(t, "47 hours", formatDistance(47*time.Hour))
+	assert.Equal(t, "45 days", formatDistance(45*day))
+	assert.Equal(t, "14 months", formatDistance(425*day))
+	assert.Equal(t, "3 years", formatDistance(3*year+10*day))
+}
+
+func TestValidator_Horizon(t *testing.T) {
+	t.Run("distant first run is reported as info", func(t *testing.T) {
+		validator := &Validator{
+			parser:    cronx.NewParserWithLocale("en"),
+			scheduler: &mockScheduler{returnDistant: true},
+			locale:    "en",
+			horizon:   DefaultHorizon,
+		}
+
+		result := validator.ValidateExpression("0 0 * * *")
+		assert.True(t, result.Valid)
+		assert.Equal(t, 1, result.ValidJobs)
+		require.Len(t, result.Issues, 1)
+		issue := result.Issues[0]
+		assert.Equal(t, CodeDistantFirstRun, issue.Code)
+		assert.Equal(t, SeverityInfo, issue.Severity)
+		assert.Contains(t, issue.Message, "First run in 3 years")
+		assert.Contains(t, issue.Message, "beyond the 2y horizon")
+	})
+
+	t.Run("a longer horizon suppresses the issue", func(t *testing.T) {
+		validator := &Validator{
+			parser:    cronx.NewParserWithLocale("en"),
+			scheduler: &mockScheduler{returnDistant: true},
+			locale:    "en",
+		}
+		validator.SetHorizon(4 * year)
+
+		result := validator.ValidateExpression("0 0 * * *")
+		assert.True(t, result.Valid)
+		assert.Empty(t, result.Issues)
+	})
+
+	t.Run("leap day beyond a short horizon", func(t *testing.T) {
+		validator := NewValidator("en")
+		validator.SetHorizon(24 * time.Hour)
+
+		result := validator.ValidateExpression("0 0 29 2 *")
+		assert.True(t, result.Valid)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, CodeDistantFirstRun, result.Issues[0].Code)
+	})
+
+	t.Run("impossible date is an error", func(t *testing.T) {
+		result := NewValidator("en").ValidateExpression("0 0 30 2 *")
+		assert.False(t, result.Valid)
+		require.NotEmpty(t, result.Issues)
+		assert.Equal(t, CodeEmptySchedule, result.Issues[0].Code)
+	})
+
+	t.Run("distant jobs in a crontab stay valid", func(t *testing.T) {
+		validator := NewValidator("en")
+		validator.SetHorizon(24 * time.Hour)
+
+		entries := parseLines([]string{"0 0 29 2 * /usr/bin/leap.sh", "0 0 30 2 * /usr/bin/never.sh"})
+		result := validator.ValidateEntries(entries)
+		assert.Equal(t, 1, result.ValidJobs)
+		assert.Equal(t, 1, result.InvalidJobs)
+	})
+}
@@ -1,9 +1,28 @@
 package check
 
 import (
+	"fmt"
+	"path/filepath"
 	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
 )
 
+// interactivePrograms are programs that need a terminal, with the flags that
+// make them run without one (none: always interactive)
+var interactivePrograms = map[string][]string{
+	"vi": nil, "vim": nil, "nvim": nil, "nano": nil, "emacs": nil, "pico": nil,
+	"less": nil, "more": nil, "man": nil, "htop": nil, "watch": nil, "passwd": nil,
+	"top":  {"-b"},
+	"su":   {"-c"},
+	"sudo": {"-n", "--non-interactive"},
+}
+
+// commandWrappers are programs that run the command given as their arguments
+var commandWrappers = map[string]bool{
+	"env": true, "exec": true, "nice": true, "nohup": true, "time": true,
+}
+
 // AnalyzeCommand performs static analysis on a command string for common issues
 func AnalyzeCommand(command string) []Issue {
 	var issues []Issue
@@ -44,6 +63,9 @@ func AnalyzeCommand(command string) []Issue {
 		})
 	}
 
+	// Check for programs that need a terminal
+	issues = append(issues, checkInteractive(command)...)
+
 	// Check for quoting/escaping issues
 	quotingIssues := checkQuotingEscaping(command)
 	issues = append(issues, quotingIssues...)
@@ -92,10 +114,123 @@ func checkOutputRedirection(command string) bool {
 		strings.Contains(command, "2>>")
 }
 
-// checkPercentCharacter checks if the command contains % character
-// In cron, % is interpreted as newline, which can cause unexpected behavior
+// checkPercentCharacter checks if the command contains an unescaped %
+// character. In cron, % is interpreted as newline, which can cause unexpected
+// behavior; \% is a literal %.
 func checkPercentCharacter(command string) bool {
-	return strings.Contains(command, "%")
+	for i := strings.Index(command, "%"); i >= 0; i = strings.Index(command, "%") {
+		if i == 0 || command[i-1] != '\\' {
+			return true
+		}
+		command = command[i+1:]
+	}
+	return false
+}
+
+// checkInteractive reports the programs of a command that need a terminal,
+// which cron does not provide
+func checkInteractive(command string) []Issue {
+	var issues []Issue
+	for _, segment := range commandSegments(command) {
+		program := interactiveProgram(strings.Fields(segment))
+		if program == "" {
+			continue
+		}
+		issues = append(issues, Issue{
+			Severity:   SeverityWarn,
+			Code:       CodeInteractiveCommand,
+			LineNumber: 0, // Will be set by caller
+			Expression: "",
+			Message:    fmt.Sprintf("Command runs interactive program %q, which needs a terminal", program),
+			Hint:       GetCodeHint(CodeInteractiveCommand),
+		})
+	}
+	return issues
+}
+
+// interactiveProgram returns the program of a simple command that needs a
+// terminal, looking past VAR=value assignments and wrappers such as nice and
+// sudo -n, or "" if it runs without one
+func interactiveProgram(words []string) string {
+	for len(words) > 0 {
+		word := strings.Trim(words[0], `"'`)
+		if name, _, ok := strings.Cut(word, "="); ok && crontab.IsVariableName(name) {
+			words = words[1:]
+			continue
+		}
+		program := filepath.Base(word)
+		args := words[1:]
+		if commandWrappers[program] {
+			// Skip the wrapper's options, such as nice -n 10
+			for len(args) > 0 && (strings.HasPrefix(args[0], "-") || isNumber(args[0])) {
+				args = args[1:]
+			}
+			words = args
+			continue
+		}
+
+		batchFlags, interactive := interactivePrograms[program]
+		if !interactive {
+			return ""
+		}
+		if !hasAnyFlag(args, batchFlags) {
+			return program
+		}
+		if program != "sudo" {
+			return ""
+		}
+		// sudo -n runs its command, which may need a terminal itself
+		for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+			args = args[1:]
+		}
+		words = args
+	}
+	return ""
+}
+
+// isNumber reports whether s is a whole number
+func isNumber(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// hasAnyFlag reports whether args include one of flags, alone or combined
+// with other single-letter flags (e.g. -bn1 for -b)
+func hasAnyFlag(args, flags []string) bool {
+	for _, arg := range args {
+		for _, flag := range flags {
+			if arg == flag || (len(flag) == 2 && !strings.HasPrefix(arg, "--") && strings.HasPrefix(arg, "-") && strings.Contains(arg[1:], flag[1:])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// commandSegments splits a command into the simple commands of its
+// pipelines and lists (separated by |, ;, & and their doubled forms),
+// ignoring separators inside quotes
+func commandSegments(command string) []string {
+	var segments []string
+	var quote rune
+	start := 0
+	for i, c := range command {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '|' || c == ';' || c == '&':
+			// Redirections such as 2>&1 and &> are not separators
+			if c == '&' && ((i > 0 && command[i-1] == '>') || (i+1 < len(command) && command[i+1] == '>')) {
+				continue
+			}
+			segments = append(segments, command[start:i])
+			start = i + 1
+		}
+	}
+	return append(segments, command[start:])
 }
 
 // checkQuotingEscaping checks for potential quoting/escaping issues
@@ -123,6 +123,11 @@ func TestCheckPercentCharacter(t *testing.T) {
 	t.Run("should detect percent character", func(t *testing.T) {
 		assert.True(t, checkPercentCharacter("command --date %Y-%m-%d"))
 		assert.True(t, checkPercentCharacter("command %"))
+		assert.True(t, checkPercentCharacter(`date +\%Y-%m`))
+	})
+
+	t.Run("should not flag escaped percent characters", func(t *testing.T) {
+		assert.False(t, checkPercentCharacter(`date +\%Y-\%m-\%d`))
 	})
 
 	t.Run("should not flag commands without percent", func(t *testing.T) {
@@ -149,3 +154,43 @@ func TestCheckQuotingEscaping(t *testing.T) {
 		assert.Equal(t, 0, len(issues))
 	})
 }
+
+func TestCheckInteractive(t *testing.T) {
+	tests := []struct {
+		command string
+		program string
+	}{
+		{"vim /etc/hosts", "vim"},
+		{"/usr/bin/top", "top"},
+		{"cd /srv && less app.log", "less"},
+		{"TERM=xterm nice -n 10 htop", "htop"},
+		{"sudo /usr/bin/backup.sh", "sudo"},
+		{"sudo -n vi /tmp/x", "vi"},
+		{"/usr/bin/backup.sh | more", "more"},
+		{"top -b -n 1 > /tmp/top.log", ""},
+		{"top -bn1", ""},
+		{"sudo -n /usr/bin/backup.sh", ""},
+		{"su -c /usr/bin/backup.sh postgres", ""},
+		{"/usr/bin/backup.sh > /var/log/backup.log 2>&1", ""},
+		{"echo 'vim | less' > /tmp/x", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			issues := checkInteractive(tt.command)
+			if tt.program == "" {
+				assert.Empty(t, issues)
+				return
+			}
+			require.Len(t, issues, 1)
+			assert.Equal(t, CodeInteractiveCommand, issues[0].Code)
+			assert.Equal(t, SeverityWarn, issues[0].Severity)
+			assert.Contains(t, issues[0].Message, `"`+tt.program+`"`)
+		})
+	}
+}
+
+func TestCommandSegments(t *testing.T) {
+	assert.Equal(t, []string{"a ", " b ", "", " c ", " d"}, commandSegments("a | b && c ; d"))
+	assert.Equal(t, []string{"a > /tmp/x 2>&1 ", " b &> /dev/null"}, commandSegments("a > /tmp/x 2>&1 ; b &> /dev/null"))
+	assert.Equal(t, []string{`echo "a|b"`}, commandSegments(`echo "a|b"`))
+}
@@ -0,0 +1,181 @@
+package check
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// IncrementalStats describes the work done by the last incremental validation
+type IncrementalStats struct {
+	Lines    int // Total lines in the validated content
+	Reparsed int // Lines parsed and checked from scratch
+	Reused   int // Lines whose cached result was reused
+}
+
+// lineResult is the cached outcome of validating a single crontab line.
+// Line numbers stored here are meaningless; they are rewritten on reuse.
+type lineResult struct {
+	raw    string
+	entry  *crontab.Entry
+	issues []Issue
+	valid  bool
+}
+
+// at returns a copy of the cached entry and issues placed at the given line number
+func (r *lineResult) at(lineNumber int) (*crontab.Entry, []Issue) {
+	entry := *r.entry
+	entry.LineNumber = lineNumber
+	if r.entry.Job != nil {
+		job := *r.entry.Job
+		job.LineNumber = lineNumber
+		entry.Job = &job
+	}
+
+	issues := make([]Issue, len(r.issues))
+	for i, issue := range r.issues {
+		issue.LineNumber = lineNumber
+		issues[i] = issue
+	}
+	return &entry, issues
+}
+
+// IncrementalValidator re-validates a crontab by re-parsing and re-checking only
+// the lines whose content changed since the previous call. Each line is tracked
+// by a fingerprint of its content, so inserting or deleting lines does not
+// invalidate the lines that merely moved. Cross-line analyses (overlaps,
+// consolidation) always run over the full set of jobs.
+//
+// An IncrementalValidator is safe for concurrent use, but calls are serialized.
+type IncrementalValidator struct {
+	validator *Validator
+	mu        sync.Mutex
+	cache     map[uint64]*lineResult
+	version   uint64
+	stats     IncrementalStats
+}
+
+// NewIncrementalValidator creates an incremental validator backed by v.
+// Changing v's settings invalidates all cached line results.
+func NewIncrementalValidator(v *Validator) *IncrementalValidator {
+	return &IncrementalValidator{
+		validator: v,
+		cache:     make(map[uint64]*lineResult),
+	}
+}
+
+// Validate validates the given crontab lines, reusing cached results for
+// lines that have not changed since the previous call
+func (iv *IncrementalValidator) Validate(lines []string) ValidationResult {
+	iv.mu.Lock()
+	defer iv.mu.Unlock()
+
+	v := iv.validator
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	// Settings changed: every cached result may be stale
+	if v.version != iv.version {
+		iv.cache = make(map[uint64]*lineResult)
+		iv.version = v.version
+	}
+
+	result := ValidationResult{
+		Valid:  true,
+		Issues: []Issue{},
+	}
+	stats := IncrementalStats{Lines: len(lines)}
+	seen := make(map[uint64]*lineResult, len(lines))
+	entries := make([]*crontab.Entry, 0, len(lines))
+
+	for i, line := range lines {
+		lineNumber := i + 1
+		fp := fingerprint(line)
+
+		cached, ok := seen[fp]
+		if !ok {
+			cached, ok = iv.cache[fp]
+		}
+		if ok && cached.raw == line {
+			stats.Reused++
+		} else {
+			cached = iv.validateLine(line)
+			stats.Reparsed++
+		}
+		seen[fp] = cached
+
+		entry, issues := cached.at(lineNumber)
+		entries = append(entries, entry)
+
+		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
+			continue
+		}
+
+		result.TotalJobs++
+		result.Jobs = append(result.Jobs, entry.Job)
+		if cached.valid {
+			result.ValidJobs++
+		} else {
+			result.Valid = false
+			result.InvalidJobs++
+		}
+		result.Issues = append(result.Issues, issues...)
+	}
+
+	// Drop results for lines that no longer exist
+	iv.cache = seen
+	iv.stats = stats
+
+	result.Issues = append(result.Issues, v.validateCrossLine(entries)...)
+
+	return result
+}
+
+// ValidateReader reads crontab content from r and validates it incrementally
+func (iv *IncrementalValidator) ValidateReader(r io.Reader) (ValidationResult, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return ValidationResult{}, fmt.Errorf("failed to read crontab: %w", err)
+	}
+	return iv.Validate(lines), nil
+}
+
+// Stats returns statistics about the most recent validation
+func (iv *IncrementalValidator) Stats() IncrementalStats {
+	iv.mu.Lock()
+	defer iv.mu.Unlock()
+	return iv.stats
+}
+
+// Reset discards all cached line results
+func (iv *IncrementalValidator) Reset() {
+	iv.mu.Lock()
+	defer iv.mu.Unlock()
+	iv.cache = make(map[uint64]*lineResult)
+	iv.stats = IncrementalStats{}
+}
+
+// validateLine parses and checks a single line from scratch
+func (iv *IncrementalValidator) validateLine(line string) *lineResult {
+	entry := crontab.ParseLine(line, 0)
+	res := &lineResult{raw: line, entry: entry, valid: true}
+	if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+		res.issues, res.valid = iv.validator.validateJob(entry.Job)
+	}
+	return res
+}
+
+// fingerprint returns a 64-bit FNV-1a hash of a line's content
+func fingerprint(line string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(line))
+	return h.Sum64()
+}
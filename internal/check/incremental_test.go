@@ -0,0 +1,140 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseLines(lines []string) []*crontab.Entry {
+	entries := make([]*crontab.Entry, 0, len(lines))
+	for i, line := range lines {
+		entries = append(entries, crontab.ParseLine(line, i+1))
+	}
+	return entries
+}
+
+func TestIncrementalValidator_Validate(t *testing.T) {
+	lines := []string{
+		"# Nightly jobs",
+		"SHELL=/bin/bash",
+		"0 2 * * * /usr/bin/backup.sh",
+		"0 0 1 * 1 /usr/bin/report.sh",
+		"99 * * * * /usr/bin/broken.sh",
+	}
+
+	t.Run("first run parses every line", func(t *testing.T) {
+		iv := NewIncrementalValidator(NewValidator("en"))
+		result := iv.Validate(lines)
+
+		assert.Equal(t, IncrementalStats{Lines: 5, Reparsed: 5, Reused: 0}, iv.Stats())
+		assert.Equal(t, 3, result.TotalJobs)
+		assert.Equal(t, 2, result.ValidJobs)
+		assert.Equal(t, 1, result.InvalidJobs)
+		assert.False(t, result.Valid)
+	})
+
+	t.Run("matches a full validation", func(t *testing.T) {
+		validator := NewValidator("en")
+		iv := NewIncrementalValidator(validator)
+
+		full := validator.ValidateEntries(parseLines(lines))
+		first := iv.Validate(lines)
+		second := iv.Validate(lines)
+
+		assert.Equal(t, full, first)
+		assert.Equal(t, full, second)
+	})
+
+	t.Run("unchanged lines are reused", func(t *testing.T) {
+		iv := NewIncrementalValidator(NewValidator("en"))
+		iv.Validate(lines)
+
+		changed := append([]string{}, lines...)
+		changed[2] = "30 2 * * * /usr/bin/backup.sh"
+		iv.Validate(changed)
+
+		assert.Equal(t, IncrementalStats{Lines: 5, Reparsed: 1, Reused: 4}, iv.Stats())
+	})
+
+	t.Run("inserted lines renumber reused issues", func(t *testing.T) {
+		iv := NewIncrementalValidator(NewValidator("en"))
+		iv.Validate(lines)
+
+		inserted := append([]string{"# header", ""}, lines...)
+		result := iv.Validate(inserted)
+		assert.Equal(t, 2, iv.Stats().Reparsed)
+
+		var lineNumbers []int
+		for _, issue := range result.Issues {
+			lineNumbers = append(lineNumbers, issue.LineNumber)
+		}
+		assert.Contains(t, lineNumbers, 6, "DOM/DOW conflict moves from line 4 to 6")
+		assert.Contains(t, lineNumbers, 7, "parse error moves from line 5 to 7")
+		assert.NotContains(t, lineNumbers, 4)
+	})
+
+	t.Run("reused results do not share line numbers between calls", func(t *testing.T) {
+		iv := NewIncrementalValidator(NewValidator("en"))
+		first := iv.Validate(lines)
+		iv.Validate(append([]string{""}, lines...))
+		third := iv.Validate(lines)
+		assert.Equal(t, first, third)
+	})
+
+	t.Run("settings changes invalidate the cache", func(t *testing.T) {
+		validator := NewValidator("en")
+		iv := NewIncrementalValidator(validator)
+		iv.Validate(lines)
+
+		validator.SetHygieneChecks(true)
+		result := iv.Validate(lines)
+		assert.Equal(t, 5, iv.Stats().Reparsed)
+
+		hasHygiene := false
+		for _, issue := range result.Issues {
+			if issue.Code == CodeMissingRedirection {
+				hasHygiene = true
+			}
+		}
+		assert.True(t, hasHygiene)
+	})
+
+	t.Run("cross-line analyses run over all jobs", func(t *testing.T) {
+		validator := NewValidator("en")
+		validator.SetConsolidationChecks(true)
+		iv := NewIncrementalValidator(validator)
+
+		content := []string{"0 2 * * * /usr/bin/cleanup.sh"}
+		result := iv.Validate(content)
+		assert.Empty(t, result.Issues)
+
+		content = append(content, "0 14 * * * /usr/bin/cleanup.sh")
+		result = iv.Validate(content)
+		assert.Equal(t, 1, iv.Stats().Reparsed)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, CodeConsolidationCandidate, result.Issues[0].Code)
+	})
+
+	t.Run("reset clears the cache", func(t *testing.T) {
+		iv := NewIncrementalValidator(NewValidator("en"))
+		iv.Validate(lines)
+		iv.Reset()
+		assert.Equal(t, IncrementalStats{}, iv.Stats())
+
+		iv.Validate(lines)
+		assert.Equal(t, 5, iv.Stats().Reparsed)
+	})
+}
+
+func TestIncrementalValidator_ValidateReader(t *testing.T) {
+	iv := NewIncrementalValidator(NewValidator("en"))
+	result, err := iv.ValidateReader(strings.NewReader("0 2 * * * /usr/bin/backup.sh\n*/5 * * * * /usr/bin/poll\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalJobs)
+	assert.True(t, result.Valid)
+	assert.Equal(t, 2, iv.Stats().Lines)
+}
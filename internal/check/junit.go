@@ -0,0 +1,151 @@
+package check
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitIssue renders an issue as one line of a test case's details
+func junitIssue(issue Issue) string {
+	line := fmt.Sprintf("%s %s: %s", strings.ToUpper(issue.Severity.String()), issue.Code, issue.Message)
+	if issue.Hint != "" {
+		line += "\nHint: " + issue.Hint
+	}
+	return line
+}
+
+// junitCase builds the test case for issues. Issues at or above failOn make
+// it fail, with the most severe issue's code as the failure type; the others
+// are reported as output so they stay visible without failing the test.
+func junitCase(className, name string, issues []Issue, failOn Severity) junitTestCase {
+	tc := junitTestCase{ClassName: className, Name: name}
+
+	var failing, other []string
+	var worst *Issue
+	for i, issue := range issues {
+		if issue.Severity < failOn {
+			other = append(other, junitIssue(issue))
+			continue
+		}
+		failing = append(failing, junitIssue(issue))
+		if worst == nil || issue.Severity > worst.Severity {
+			worst = &issues[i]
+		}
+	}
+
+	if worst != nil {
+		tc.Failure = &junitFailure{
+			Type:    worst.Code,
+			Message: worst.Message,
+			Text:    strings.Join(failing, "\n\n"),
+		}
+	}
+	tc.SystemOut = strings.Join(other, "\n\n")
+	return tc
+}
+
+// WriteJUnit writes a validation result as a JUnit XML report, which CI
+// servers such as Jenkins and GitLab show as test results. Every job is a
+// test case, named after its line, expression and command, that fails when
+// it has issues at or above failOn; issues that do not belong to a job
+// (e.g. overlaps, unreadable files) form a test case named after the
+// crontab. An empty path is reported as DefaultAnnotationPath.
+func WriteJUnit(w io.Writer, result ValidationResult, issues []Issue, path string, failOn Severity) error {
+	if path == "" {
+		path = DefaultAnnotationPath
+	}
+
+	// Jobs of several files are told apart by their source file
+	type location struct {
+		file string
+		line int
+	}
+	byLocation := make(map[location][]Issue)
+	for _, issue := range issues {
+		at := location{issue.File, issue.LineNumber}
+		byLocation[at] = append(byLocation[at], issue)
+	}
+
+	suite := junitTestSuite{Name: path}
+	seen := make(map[location]bool, len(result.Jobs))
+	for _, job := range result.Jobs {
+		at := location{job.Source, job.LineNumber}
+		className := path
+		if job.Source != "" {
+			className = job.Source
+		}
+		name := fmt.Sprintf("line %d: %s %s", job.LineNumber, job.Expression, job.Command)
+		suite.Cases = append(suite.Cases, junitCase(className, name, byLocation[at], failOn))
+		seen[at] = true
+	}
+
+	// Issues not attached to a job, and the result of single expressions
+	var rest []Issue
+	for _, issue := range issues {
+		if !seen[location{issue.File, issue.LineNumber}] {
+			rest = append(rest, issue)
+		}
+	}
+	if len(rest) > 0 || len(result.Jobs) == 0 {
+		name := path
+		if len(result.Jobs) == 0 && len(rest) > 0 && rest[0].Expression != "" {
+			name = rest[0].Expression
+		}
+		suite.Cases = append(suite.Cases, junitCase(path, name, rest, failOn))
+	}
+
+	for _, tc := range suite.Cases {
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+	}
+	report := junitTestSuites{
+		Name:     "cronkit check",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
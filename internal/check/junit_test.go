@@ -0,0 +1,78 @@
+package check
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	result := ValidationResult{
+		Jobs: []*crontab.Job{
+			{LineNumber: 1, Expression: "0 2 * * *", Command: "backup.sh"},
+			{LineNumber: 2, Expression: "61 * * * *", Command: "broken.sh"},
+			{LineNumber: 3, Expression: "0 0 1 * 1", Command: "report.sh"},
+		},
+	}
+	issues := []Issue{
+		{Severity: SeverityError, Code: CodeParseError, LineNumber: 2, Expression: "61 * * * *", Message: "Invalid cron expression", Hint: "Fix the syntax"},
+		{Severity: SeverityWarn, Code: CodeDOMDOWConflict, LineNumber: 3, Expression: "0 0 1 * 1", Message: "Both day fields set"},
+		{Severity: SeverityWarn, Code: CodeOverlapDetected, Message: "2 jobs start at 02:00"},
+	}
+
+	decode := func(t *testing.T, failOn Severity) junitTestSuites {
+		var buf bytes.Buffer
+		require.NoError(t, WriteJUnit(&buf, result, issues, "jobs.cron", failOn))
+		assert.Contains(t, buf.String(), xml.Header)
+
+		var report junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+		return report
+	}
+
+	t.Run("fails on errors", func(t *testing.T) {
+		report := decode(t, SeverityError)
+		assert.Equal(t, 4, report.Tests)
+		assert.Equal(t, 1, report.Failures)
+		require.Len(t, report.Suites, 1)
+
+		cases := report.Suites[0].Cases
+		require.Len(t, cases, 4)
+		assert.Equal(t, "line 1: 0 2 * * * backup.sh", cases[0].Name)
+		assert.Equal(t, "jobs.cron", cases[0].ClassName)
+		assert.Nil(t, cases[0].Failure)
+
+		require.NotNil(t, cases[1].Failure)
+		assert.Equal(t, CodeParseError, cases[1].Failure.Type)
+		assert.Equal(t, "Invalid cron expression", cases[1].Failure.Message)
+		assert.Contains(t, cases[1].Failure.Text, "Hint: Fix the syntax")
+
+		// Warnings below --fail-on are kept as output
+		assert.Nil(t, cases[2].Failure)
+		assert.Contains(t, cases[2].SystemOut, "WARN CRON-001")
+
+		assert.Equal(t, "jobs.cron", cases[3].Name)
+		assert.Contains(t, cases[3].SystemOut, "2 jobs start at 02:00")
+	})
+
+	t.Run("fails on warnings", func(t *testing.T) {
+		report := decode(t, SeverityWarn)
+		assert.Equal(t, 3, report.Failures)
+	})
+
+	t.Run("single expression", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteJUnit(&buf, ValidationResult{}, nil, "", SeverityError))
+
+		var report junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+		require.Len(t, report.Suites, 1)
+		assert.Equal(t, DefaultAnnotationPath, report.Suites[0].Name)
+		assert.Equal(t, 1, report.Tests)
+		assert.Zero(t, report.Failures)
+	})
+}
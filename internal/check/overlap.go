@@ -7,6 +7,8 @@ import (
 
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/parallel"
+	"github.com/hzerrad/cronkit/internal/stats"
 )
 
 // Overlap represents multiple jobs running at the same time
@@ -23,7 +25,11 @@ type OverlapStats struct {
 	MostProblematic []Overlap // Top N overlaps sorted by count
 }
 
-// AnalyzeOverlaps analyzes job overlaps within a time window
+// AnalyzeOverlaps analyzes job overlaps within a time window from now. Runs
+// last their job's Duration (or their start minute), as in the stats and
+// timeline commands, so a long job overlaps the jobs that start while it
+// runs. Jobs under CRON_TZ= or TZ= run in that zone; overlap times are in
+// local time.
 func AnalyzeOverlaps(jobs []*crontab.Job, timeWindow time.Duration, scheduler cronx.Scheduler, parser cronx.Parser) ([]Overlap, OverlapStats, error) {
 	if len(jobs) == 0 {
 		return []Overlap{}, OverlapStats{}, nil
@@ -33,49 +39,27 @@ func AnalyzeOverlaps(jobs []*crontab.Job, timeWindow time.Duration, scheduler cr
 	startTime := time.Now().Truncate(time.Minute)
 	endTime := startTime.Add(timeWindow)
 
-	// Collect all run times for all jobs
-	type jobRun struct {
-		time  time.Time
-		jobID string
-	}
-	var allRuns []jobRun
-
-	for _, job := range jobs {
-		if !job.Valid {
-			continue
+	// Minutes each job runs in within the time window, computed in parallel
+	running := parallel.Map(len(jobs), func(i int) map[time.Time]bool {
+		if !jobs[i].Valid {
+			return nil
 		}
+		return stats.RunningMinutes(scheduler, jobs[i], startTime, endTime)
+	})
 
+	// Group the jobs running by minute
+	overlapMap := make(map[time.Time][]string)
+	for i, minutes := range running {
 		// Get job identifier (use line number or expression)
-		jobID := fmt.Sprintf("line-%d", job.LineNumber)
-		if job.LineNumber == 0 {
-			jobID = job.Expression
-		}
-
-		// Get all runs for this job within the time window
-		times, err := scheduler.Next(job.Expression, startTime, 10000) // Large limit to get all runs
-		if err != nil {
-			continue // Skip jobs that can't be scheduled
+		jobID := fmt.Sprintf("line-%d", jobs[i].LineNumber)
+		if jobs[i].LineNumber == 0 {
+			jobID = jobs[i].Expression
 		}
-
-		for _, t := range times {
-			if t.After(endTime) || t.Equal(endTime) {
-				break
-			}
-			if !t.Before(startTime) {
-				allRuns = append(allRuns, jobRun{
-					time:  t.Truncate(time.Minute), // Round to minute for overlap detection
-					jobID: jobID,
-				})
-			}
+		for minute := range minutes {
+			overlapMap[minute] = append(overlapMap[minute], jobID)
 		}
 	}
 
-	// Group runs by time (minute precision)
-	overlapMap := make(map[time.Time][]string)
-	for _, run := range allRuns {
-		overlapMap[run.time] = append(overlapMap[run.time], run.jobID)
-	}
-
 	// Convert to Overlap structs
 	var overlaps []Overlap
 	for t, jobIDs := range overlapMap {
@@ -99,22 +83,22 @@ func AnalyzeOverlaps(jobs []*crontab.Job, timeWindow time.Duration, scheduler cr
 	})
 
 	// Calculate statistics
-	stats := OverlapStats{
+	overlapStats := OverlapStats{
 		TotalWindows:  len(overlaps),
 		MaxConcurrent: 0,
 	}
 
 	if len(overlaps) > 0 {
-		stats.MaxConcurrent = overlaps[0].Count
+		overlapStats.MaxConcurrent = overlaps[0].Count
 		// Get top 10 most problematic overlaps
 		topN := 10
 		if len(overlaps) < topN {
 			topN = len(overlaps)
 		}
-		stats.MostProblematic = overlaps[:topN]
+		overlapStats.MostProblematic = overlaps[:topN]
 	}
 
-	return overlaps, stats, nil
+	return overlaps, overlapStats, nil
 }
 
 // uniqueStrings removes duplicates from a string slice
@@ -0,0 +1,29 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/testutil"
+)
+
+// Jobs are computed on up to GOMAXPROCS workers; compare with
+// go test -bench=1kJobs -cpu 1,4 ./internal/check
+
+func BenchmarkAnalyzeOverlaps_1kJobs(b *testing.B) {
+	entries, err := crontab.ParseReader(strings.NewReader(testutil.GenerateCrontab(1000)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	jobs, _ := crontab.Partition(entries)
+	scheduler := cronx.NewScheduler()
+	parser := cronx.NewParser()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = AnalyzeOverlaps(jobs, 24*time.Hour, scheduler, parser)
+	}
+}
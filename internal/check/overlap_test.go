@@ -58,6 +58,38 @@ func TestAnalyzeOverlaps(t *testing.T) {
 		assert.Equal(t, 0, stats.MaxConcurrent)
 	})
 
+	t.Run("should detect jobs starting while a long job runs", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 1, Expression: "0 2 * * *", Valid: true, Duration: 20 * time.Minute},
+			{LineNumber: 2, Expression: "10 2 * * *", Valid: true},
+		}
+
+		// Two days, so a whole pair of runs falls in the window at any time
+		overlaps, stats, err := AnalyzeOverlaps(jobs, 48*time.Hour, scheduler, parser)
+		require.NoError(t, err)
+		require.NotEmpty(t, overlaps)
+		assert.Equal(t, 2, stats.MaxConcurrent)
+		assert.Equal(t, []string{"line-1", "line-2"}, overlaps[0].JobIDs)
+		assert.Equal(t, 10, overlaps[0].Time.Minute())
+
+		jobs[0].Duration = 0
+		overlaps, _, err = AnalyzeOverlaps(jobs, 48*time.Hour, scheduler, parser)
+		require.NoError(t, err)
+		assert.Empty(t, overlaps, "runs without a duration last their start minute")
+	})
+
+	t.Run("should detect overlaps across time zones", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 1, Expression: "0 2 * * *", Valid: true, Timezone: "UTC"},
+			{LineNumber: 2, Expression: "0 11 * * *", Valid: true, Timezone: "Asia/Tokyo"},
+		}
+
+		overlaps, stats, err := AnalyzeOverlaps(jobs, 48*time.Hour, scheduler, parser)
+		require.NoError(t, err)
+		require.NotEmpty(t, overlaps)
+		assert.Equal(t, 2, stats.MaxConcurrent)
+	})
+
 	t.Run("should handle invalid jobs gracefully", func(t *testing.T) {
 		jobs := []*crontab.Job{
 			{LineNumber: 1, Expression: "invalid", Valid: false},
@@ -0,0 +1,329 @@
+package check
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPolicyFile is the policy file check reads from the current
+// directory when no --policy is given
+const DefaultPolicyFile = ".cronkit.yaml"
+
+// policySpacingWindow is how far ahead runs are compared for min-spacing:
+// a week covers every day of daily and weekly schedules
+const policySpacingWindow = 7 * day
+
+// Policy is an organization's scheduling policy, enforced by check. A zero
+// rule is not enforced. It is read from the policy section of a policy file:
+//
+//	policy:
+//	  max-runs-per-day: 96
+//	  min-spacing: 5m
+//	  forbidden-windows:
+//	    - 00:00-01:00
+//	  protected-windows:
+//	    - name: deploy freeze
+//	      window: 09:00-10:00
+//	      days: mon-fri
+//	      timezone: Europe/Paris
+//	  require-mailto: true
+//	  rules:
+//	    - code: OPS-001
+//	      message: curl must fail on HTTP errors
+//	      command: '\bcurl\b'
+//	      require-command: '\s(--fail|-f)\b'
+type Policy struct {
+	MaxRunsPerDay    int                // Runs a job may make in a day (CRON-021)
+	MinSpacing       time.Duration      // Time between runs of two different jobs (CRON-022)
+	ForbiddenWindows []Window           // Times of day no job may run at (CRON-023)
+	ProtectedWindows []*ProtectedWindow // Named windows no job may run in, with the upcoming runs that do (CRON-028)
+	RequireMailto    bool               // Jobs must have a non-empty MAILTO (CRON-024)
+	Rules            []*PatternRule     // Declarative rules, reported with their own codes
+}
+
+// Window is a time of day range, [Start, End) in minutes after midnight.
+// A window whose End is not after its Start wraps past midnight.
+type Window struct {
+	Start, End int
+}
+
+// ParseWindow parses a window written "HH:MM-HH:MM", e.g. "23:30-01:00"
+func ParseWindow(s string) (Window, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return Window{}, fmt.Errorf("invalid window %q (expected HH:MM-HH:MM, e.g. 00:00-01:00)", s)
+	}
+	var w Window
+	var err error
+	if w.Start, err = parseClock(start); err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: %w", s, err)
+	}
+	if w.End, err = parseClock(end); err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: %w", s, err)
+	}
+	if w.Start == w.End {
+		return Window{}, fmt.Errorf("invalid window %q: start and end are the same", s)
+	}
+	return w, nil
+}
+
+// parseClock parses "HH:MM" (or "24:00") into minutes after midnight
+func parseClock(s string) (int, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(s), ":")
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+	if !ok || errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", s)
+	}
+	return h*60 + m, nil
+}
+
+// Contains reports whether a minute of the day lies within the window
+func (w Window) Contains(minute int) bool {
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	return minute >= w.Start || minute < w.End
+}
+
+// String formats the window as "HH:MM-HH:MM"
+func (w Window) String() string {
+	return fmt.Sprintf("%s-%s", formatClock(w.Start), formatClock(w.End))
+}
+
+// formatClock formats minutes after midnight as "HH:MM"
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// IsZero reports whether the policy enforces no rule
+func (p *Policy) IsZero() bool {
+	return p == nil || (p.MaxRunsPerDay == 0 && p.MinSpacing == 0 && len(p.ForbiddenWindows) == 0 && len(p.ProtectedWindows) == 0 && !p.RequireMailto && len(p.Rules) == 0)
+}
+
+// LoadPolicy reads the policy file at path. A missing file is no policy
+// (nil) unless required is set, as it is for paths given explicitly.
+func LoadPolicy(path string, required bool) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	policy, err := ParsePolicy(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// ParsePolicy decodes a policy file, rejecting unknown keys
+func ParsePolicy(r io.Reader) (*Policy, error) {
+	var file struct {
+		Policy struct {
+			MaxRunsPerDay    int                   `yaml:"max-runs-per-day"`
+			MinSpacing       string                `yaml:"min-spacing"`
+			ForbiddenWindows []string              `yaml:"forbidden-windows"`
+			ProtectedWindows []protectedWindowSpec `yaml:"protected-windows"`
+			RequireMailto    bool                  `yaml:"require-mailto"`
+			Rules            []patternRuleSpec     `yaml:"rules"`
+		} `yaml:"policy"`
+	}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	raw := file.Policy
+	if raw.MaxRunsPerDay < 0 {
+		return nil, fmt.Errorf("invalid max-runs-per-day %d: must not be negative", raw.MaxRunsPerDay)
+	}
+	policy := &Policy{MaxRunsPerDay: raw.MaxRunsPerDay, RequireMailto: raw.RequireMailto}
+	if raw.MinSpacing != "" {
+		spacing, err := time.ParseDuration(raw.MinSpacing)
+		if err != nil || spacing <= 0 {
+			return nil, fmt.Errorf("invalid min-spacing %q (e.g. 5m, 1h)", raw.MinSpacing)
+		}
+		policy.MinSpacing = spacing
+	}
+	for _, s := range raw.ForbiddenWindows {
+		w, err := ParseWindow(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forbidden-windows: %w", err)
+		}
+		policy.ForbiddenWindows = append(policy.ForbiddenWindows, w)
+	}
+	for _, spec := range raw.ProtectedWindows {
+		w, err := spec.compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected-windows: %w", err)
+		}
+		policy.ProtectedWindows = append(policy.ProtectedWindows, w)
+	}
+	for _, spec := range raw.Rules {
+		rule, err := spec.compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rules: %w", err)
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+	return policy, nil
+}
+
+// checkPolicy checks a job against the per-job rules of the policy
+func (v *Validator) checkPolicy(job *crontab.Job, schedule *cronx.Schedule) []Issue {
+	if v.policy.IsZero() {
+		return nil
+	}
+	issues := v.checkPolicySchedule(job.Expression, job.LineNumber, schedule)
+	if loc, err := job.Location(v.location); err == nil {
+		issues = append(issues, v.checkProtectedWindows(job.Expression, job.LineNumber, loc, time.Now())...)
+	}
+	if v.policy.RequireMailto {
+		if mailto, _ := job.Mailto(); mailto == "" {
+			issues = append(issues, policyIssue(CodePolicyMailto, job.LineNumber, job.Expression, "No MAILTO is set for the job (policy requires one)"))
+		}
+	}
+	return issues
+}
+
+// checkPolicySchedule checks a schedule against the rules of the policy that
+// do not depend on the crontab: runs per day and forbidden windows
+func (v *Validator) checkPolicySchedule(expression string, lineNumber int, schedule *cronx.Schedule) []Issue {
+	if v.policy.IsZero() {
+		return nil
+	}
+	var issues []Issue
+	if v.policy.MaxRunsPerDay > 0 {
+		runs, err := CalculateRunsPerDay(expression, v.scheduler)
+		if err == nil && runs > v.policy.MaxRunsPerDay {
+			issues = append(issues, policyIssue(CodePolicyRunsPerDay, lineNumber, expression,
+				fmt.Sprintf("Schedule runs %d times per day (policy allows at most %d)", runs, v.policy.MaxRunsPerDay)))
+		}
+	}
+	for _, w := range v.policy.ForbiddenWindows {
+		if minute, ok := firstMinuteIn(schedule, w); ok {
+			issues = append(issues, policyIssue(CodePolicyWindow, lineNumber, expression,
+				fmt.Sprintf("Schedule runs at %s, inside the forbidden window %s", formatClock(minute), w)))
+		}
+	}
+	return issues
+}
+
+// policyIssue builds an issue for a policy violation
+func policyIssue(code string, lineNumber int, expression, message string) Issue {
+	return Issue{
+		Severity:   GetCodeSeverity(code),
+		Code:       code,
+		LineNumber: lineNumber,
+		Expression: expression,
+		Message:    message,
+		Hint:       GetCodeHint(code),
+	}
+}
+
+// firstMinuteIn returns the first time of day the schedule's hour and minute
+// fields match within a window
+func firstMinuteIn(schedule *cronx.Schedule, w Window) (int, bool) {
+	for _, h := range schedule.Hour.Values() {
+		for _, m := range schedule.Minute.Values() {
+			if minute := h*60 + m; w.Contains(minute) {
+				return minute, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// checkPolicySpacing reports pairs of jobs whose runs come closer together
+// than the policy's min-spacing, comparing a week of runs, once per pair
+func (v *Validator) checkPolicySpacing(jobs []*crontab.Job) []Issue {
+	if v.policy == nil || v.policy.MinSpacing == 0 || len(jobs) < 2 {
+		return nil
+	}
+
+	type run struct {
+		time time.Time
+		job  int
+	}
+	var runs []run
+	start, end := ReferenceDate, ReferenceDate.Add(policySpacingWindow)
+	for i, job := range jobs {
+		if !job.Valid {
+			continue
+		}
+		query := start.Add(-time.Second)
+		for query.Before(end) {
+			times, err := v.scheduler.Next(job.Expression, query, MaxRunsForDailyCalculation)
+			if err != nil || len(times) == 0 || !times[len(times)-1].After(query) {
+				break
+			}
+			for _, t := range times {
+				if t.Before(end) {
+					runs = append(runs, run{time: t, job: i})
+				}
+			}
+			query = times[len(times)-1]
+		}
+	}
+	sort.SliceStable(runs, func(a, b int) bool { return runs[a].time.Before(runs[b].time) })
+
+	// Sweep the runs, comparing each with the earlier runs within min-spacing
+	type pair struct{ first, second int }
+	reported := map[pair]bool{}
+	var issues []Issue
+	low := 0
+	for i, r := range runs {
+		for r.time.Sub(runs[low].time) >= v.policy.MinSpacing {
+			low++
+		}
+		for _, earlier := range runs[low:i] {
+			if earlier.job == r.job {
+				continue
+			}
+			p := pair{min(earlier.job, r.job), max(earlier.job, r.job)}
+			if reported[p] {
+				continue
+			}
+			reported[p] = true
+			first, second := jobs[p.first], jobs[p.second]
+			apart := formatSpacing(r.time.Sub(earlier.time)) + " apart from"
+			if r.time.Equal(earlier.time) {
+				apart = "at the same time as"
+			}
+			issue := policyIssue(CodePolicySpacing, second.LineNumber, second.Expression,
+				fmt.Sprintf("Runs %s the job on line %d at %s (policy requires at least %s between jobs)",
+					apart, first.LineNumber, r.time.Format("Mon 15:04"), formatSpacing(v.policy.MinSpacing)))
+			issue.File = second.Source
+			issues = append(issues, issue)
+		}
+	}
+	sort.SliceStable(issues, func(a, b int) bool { return issues[a].LineNumber < issues[b].LineNumber })
+	return issues
+}
+
+// formatSpacing formats a duration without zero units, e.g. "5m" or "1h30m"
+func formatSpacing(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,170 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		input   string
+		window  Window
+		wantErr bool
+	}{
+		{input: "00:00-01:00", window: Window{Start: 0, End: 60}},
+		{input: " 23:30-01:15 ", window: Window{Start: 23*60 + 30, End: 75}},
+		{input: "22:00-24:00", window: Window{Start: 22 * 60, End: 24 * 60}},
+		{input: "01:00", wantErr: true},
+		{input: "01:00-01:00", wantErr: true},
+		{input: "25:00-26:00", wantErr: true},
+		{input: "01:60-02:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			w, err := ParseWindow(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.window, w)
+		})
+	}
+}
+
+func TestWindow_Contains(t *testing.T) {
+	night := Window{Start: 0, End: 60}
+	assert.True(t, night.Contains(0))
+	assert.True(t, night.Contains(59))
+	assert.False(t, night.Contains(60))
+
+	wrapping := Window{Start: 23 * 60, End: 30}
+	assert.True(t, wrapping.Contains(23*60+15))
+	assert.True(t, wrapping.Contains(15))
+	assert.False(t, wrapping.Contains(12*60))
+	assert.Equal(t, "23:00-00:30", wrapping.String())
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("every rule", func(t *testing.T) {
+		policy, err := ParsePolicy(strings.NewReader("policy:\n  max-runs-per-day: 96\n  min-spacing: 5m\n  forbidden-windows:\n    - 00:00-01:00\n  require-mailto: true\n"))
+		require.NoError(t, err)
+		assert.Equal(t, &Policy{
+			MaxRunsPerDay:    96,
+			MinSpacing:       5 * time.Minute,
+			ForbiddenWindows: []Window{{Start: 0, End: 60}},
+			RequireMailto:    true,
+		}, policy)
+		assert.False(t, policy.IsZero())
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		policy, err := ParsePolicy(strings.NewReader(""))
+		require.NoError(t, err)
+		assert.True(t, policy.IsZero())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, content := range []string{
+			"policy:\n  min-spacin: 5m\n",
+			"policy:\n  min-spacing: soon\n",
+			"policy:\n  max-runs-per-day: -1\n",
+			"policy:\n  forbidden-windows: [\"midnight\"]\n",
+		} {
+			_, err := ParsePolicy(strings.NewReader(content))
+			assert.Error(t, err, content)
+		}
+	})
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	policy, err := LoadPolicy(filepath.Join(dir, DefaultPolicyFile), false)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	_, err = LoadPolicy(filepath.Join(dir, DefaultPolicyFile), true)
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("policy:\n  require-mailto: yes please\n"), 0o644))
+	_, err = LoadPolicy(path, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid policy file "+path)
+}
+
+func TestValidator_Policy(t *testing.T) {
+	validate := func(t *testing.T, policy *Policy, content string) []Issue {
+		entries, err := crontab.ParseReader(strings.NewReader(content))
+		require.NoError(t, err)
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		v.SetPolicy(policy)
+		return v.ValidateEntries(entries).Issues
+	}
+	codes := func(issues []Issue) []string {
+		result := make([]string, len(issues))
+		for i, issue := range issues {
+			result[i] = issue.Code
+		}
+		return result
+	}
+
+	t.Run("max runs per day", func(t *testing.T) {
+		issues := validate(t, &Policy{MaxRunsPerDay: 24}, "*/30 * * * * /usr/bin/poll.sh\n0 * * * * /usr/bin/hourly.sh\n")
+		require.Len(t, issues, 1)
+		assert.Equal(t, CodePolicyRunsPerDay, issues[0].Code)
+		assert.Equal(t, SeverityError, issues[0].Severity)
+		assert.Equal(t, 1, issues[0].LineNumber)
+		assert.Equal(t, "Schedule runs 48 times per day (policy allows at most 24)", issues[0].Message)
+	})
+
+	t.Run("forbidden windows", func(t *testing.T) {
+		policy := &Policy{ForbiddenWindows: []Window{{Start: 23 * 60, End: 60}}}
+		issues := validate(t, policy, "30 0 * * * /usr/bin/a.sh\n0 2 * * * /usr/bin/b.sh\n45 10,23 * * * /usr/bin/c.sh\n")
+		assert.Equal(t, []string{CodePolicyWindow, CodePolicyWindow}, codes(issues))
+		assert.Equal(t, "Schedule runs at 00:30, inside the forbidden window 23:00-01:00", issues[0].Message)
+		assert.Equal(t, 3, issues[1].LineNumber)
+		assert.Contains(t, issues[1].Message, "23:45")
+	})
+
+	t.Run("required MAILTO", func(t *testing.T) {
+		issues := validate(t, &Policy{RequireMailto: true}, "0 1 * * * /usr/bin/a.sh\nMAILTO=ops@example.com\n0 2 * * * /usr/bin/b.sh\nMAILTO=\"\"\n0 3 * * * /usr/bin/c.sh\n")
+		assert.Equal(t, []string{CodePolicyMailto, CodePolicyMailto}, codes(issues))
+		assert.Equal(t, 1, issues[0].LineNumber)
+		assert.Equal(t, 5, issues[1].LineNumber)
+	})
+
+	t.Run("minimum spacing", func(t *testing.T) {
+		issues := validate(t, &Policy{MinSpacing: 5 * time.Minute}, "0 2 * * * /usr/bin/a.sh\n3 2 * * * /usr/bin/b.sh\n10 2 * * * /usr/bin/c.sh\n0 2 * * 0 /usr/bin/d.sh\n")
+		// Line 3 runs 7 minutes after line 2; line 4 runs with lines 1 and 2 on Sundays
+		require.Equal(t, []string{CodePolicySpacing, CodePolicySpacing, CodePolicySpacing}, codes(issues))
+		assert.Equal(t, 2, issues[0].LineNumber)
+		assert.Equal(t, "Runs 3m apart from the job on line 1 at Wed 02:03 (policy requires at least 5m between jobs)", issues[0].Message)
+		assert.Equal(t, 4, issues[1].LineNumber)
+		assert.Contains(t, issues[1].Message, "at the same time as the job on line 1 at Sun 02:00")
+		assert.Equal(t, 4, issues[2].LineNumber)
+		assert.Contains(t, issues[2].Message, "3m apart from the job on line 2 at Sun 02:03")
+	})
+
+	t.Run("single expressions skip crontab rules", func(t *testing.T) {
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		v.SetPolicy(&Policy{MaxRunsPerDay: 1, RequireMailto: true, MinSpacing: time.Hour})
+		result := v.ValidateExpression("0 */2 * * *")
+		assert.Equal(t, []string{CodePolicyRunsPerDay}, codes(result.Issues))
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		assert.Empty(t, validate(t, nil, "* * * * * /usr/bin/a.sh\n* * * * * /usr/bin/b.sh\n"))
+	})
+}
@@ -0,0 +1,161 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// maxProtectedRunsListed is how many of the runs inside a protected window
+// are given in the hint of a CRON-028 issue
+const maxProtectedRunsListed = 5
+
+// ProtectedWindow is a named time of day range no job may run in, such as a
+// deploy freeze, on some days of the week and in a time zone
+type ProtectedWindow struct {
+	Name     string
+	Window   Window
+	Days     string         // Days of the week as written, e.g. "mon-fri" (empty: every day)
+	Location *time.Location // Time zone of the window (nil: the job's)
+
+	weekdays [7]bool // Days the window starts on, Sunday=0
+}
+
+// protectedWindowSpec is a protected window as written in a policy file
+type protectedWindowSpec struct {
+	Name     string `yaml:"name"`
+	Window   string `yaml:"window"`
+	Days     string `yaml:"days"`
+	Timezone string `yaml:"timezone"`
+}
+
+// compile checks the spec and parses its window, days and time zone
+func (s protectedWindowSpec) compile() (*ProtectedWindow, error) {
+	if s.Window == "" {
+		return nil, fmt.Errorf("protected window %q without a window (e.g. 09:00-10:00)", s.Name)
+	}
+	w, err := ParseWindow(s.Window)
+	if err != nil {
+		return nil, err
+	}
+	pw := &ProtectedWindow{Name: s.Name, Window: w, Days: s.Days}
+
+	days := "*"
+	if s.Days != "" {
+		days = s.Days
+	}
+	schedule, err := cronx.NewParser().Parse("0 0 * * " + days)
+	if err != nil {
+		return nil, fmt.Errorf("invalid days %q (a day-of-week field, e.g. mon-fri or 1-5)", s.Days)
+	}
+	for _, day := range schedule.DayOfWeek.Values() {
+		pw.weekdays[day] = true
+	}
+
+	if s.Timezone != "" {
+		if pw.Location, err = time.LoadLocation(s.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+		}
+	}
+	return pw, nil
+}
+
+// String formats the window with its name, days and time zone, e.g.
+// "deploy freeze (09:00-10:00 mon-fri Europe/Paris)"
+func (w *ProtectedWindow) String() string {
+	parts := []string{w.Window.String()}
+	if w.Days != "" {
+		parts = append(parts, w.Days)
+	}
+	if w.Location != nil {
+		parts = append(parts, w.Location.String())
+	}
+	if w.Name == "" {
+		return strings.Join(parts, " ")
+	}
+	return fmt.Sprintf("%q (%s)", w.Name, strings.Join(parts, " "))
+}
+
+// Runs returns the runs of an expression from now until end that fall
+// inside the window, with the job in loc. The window is in its own time
+// zone, or loc.
+func (w *ProtectedWindow) Runs(scheduler cronx.Scheduler, expression string, loc *time.Location, now, end time.Time) []time.Time {
+	wloc := loc
+	if w.Location != nil {
+		wloc = w.Location
+	}
+	now = now.In(wloc)
+
+	var runs []time.Time
+	// From the day before, for windows wrapping past midnight
+	for day := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, wloc); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !w.weekdays[day.Weekday()] {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, w.Window.Start, 0, 0, wloc)
+		stop := time.Date(day.Year(), day.Month(), day.Day(), 0, w.Window.End, 0, 0, wloc)
+		if w.Window.End <= w.Window.Start {
+			stop = stop.AddDate(0, 0, 1)
+		}
+		if start.Before(now) {
+			start = now
+		}
+		if stop.After(end) {
+			stop = end
+		}
+
+		query := start.Add(-time.Second).In(loc)
+		for query.Before(stop) {
+			times, err := scheduler.Next(expression, query, MaxRunsForHourlyCalculation)
+			if err != nil || len(times) == 0 || !times[len(times)-1].After(query) {
+				break
+			}
+			for _, t := range times {
+				if t.Before(stop) {
+					runs = append(runs, t)
+				}
+			}
+			query = times[len(times)-1]
+		}
+	}
+	return runs
+}
+
+// checkProtectedWindows reports schedules with runs inside one of the
+// policy's protected windows within ProtectedWindowLookahead of now, giving
+// the first of them in the hint. A nil loc is the local time zone.
+func (v *Validator) checkProtectedWindows(expression string, lineNumber int, loc *time.Location, now time.Time) []Issue {
+	if v.policy == nil || len(v.policy.ProtectedWindows) == 0 {
+		return nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var issues []Issue
+	for _, w := range v.policy.ProtectedWindows {
+		runs := w.Runs(v.scheduler, expression, loc, now, now.Add(ProtectedWindowLookahead))
+		if len(runs) == 0 {
+			continue
+		}
+
+		wloc := loc
+		if w.Location != nil {
+			wloc = w.Location
+		}
+		listed := make([]string, 0, maxProtectedRunsListed+1)
+		for _, t := range runs[:min(len(runs), maxProtectedRunsListed)] {
+			listed = append(listed, t.In(wloc).Format("Mon 2006-01-02 15:04"))
+		}
+		if len(runs) > len(listed) {
+			listed = append(listed, fmt.Sprintf("and %d more", len(runs)-len(listed)))
+		}
+		issue := policyIssue(CodePolicyProtectedWindow, lineNumber, expression,
+			fmt.Sprintf("Schedule runs %d time(s) in the next %d days inside the protected window %s", len(runs), int(ProtectedWindowLookahead/day), w))
+		issue.Hint = fmt.Sprintf("%s Upcoming runs inside it: %s", GetCodeHint(CodePolicyProtectedWindow), strings.Join(listed, ", "))
+		issues = append(issues, issue)
+	}
+	return issues
+}
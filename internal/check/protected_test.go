@@ -0,0 +1,101 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy_ProtectedWindows(t *testing.T) {
+	policy, err := ParsePolicy(strings.NewReader(`policy:
+  protected-windows:
+    - name: deploy freeze
+      window: 09:00-10:00
+      days: mon-fri
+      timezone: Europe/Paris
+    - window: 23:30-00:30
+`))
+	require.NoError(t, err)
+	require.Len(t, policy.ProtectedWindows, 2)
+	assert.False(t, policy.IsZero())
+
+	freeze := policy.ProtectedWindows[0]
+	assert.Equal(t, "deploy freeze", freeze.Name)
+	assert.Equal(t, Window{Start: 9 * 60, End: 10 * 60}, freeze.Window)
+	assert.Equal(t, [7]bool{false, true, true, true, true, true, false}, freeze.weekdays)
+	assert.Equal(t, `"deploy freeze" (09:00-10:00 mon-fri Europe/Paris)`, freeze.String())
+	assert.Equal(t, "23:30-00:30", policy.ProtectedWindows[1].String())
+
+	for _, content := range []string{
+		"policy:\n  protected-windows:\n    - name: freeze\n",
+		"policy:\n  protected-windows:\n    - window: 09:00\n",
+		"policy:\n  protected-windows:\n    - window: 09:00-10:00\n      days: weekdays\n",
+		"policy:\n  protected-windows:\n    - window: 09:00-10:00\n      timezone: Mars/Olympus\n",
+		"policy:\n  protected-windows:\n    - window: 09:00-10:00\n      hours: 1\n",
+	} {
+		_, err := ParsePolicy(strings.NewReader(content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestValidator_CheckProtectedWindows(t *testing.T) {
+	// A Monday
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	freeze, err := protectedWindowSpec{Name: "deploy freeze", Window: "09:00-10:00", Days: "mon-fri"}.compile()
+	require.NoError(t, err)
+	v := NewValidator("en")
+	v.SetPolicy(&Policy{ProtectedWindows: []*ProtectedWindow{freeze}})
+
+	t.Run("runs inside the window are listed in the hint", func(t *testing.T) {
+		issues := v.checkProtectedWindows("*/15 9 * * *", 4, time.UTC, now)
+		require.Len(t, issues, 1)
+		assert.Equal(t, CodePolicyProtectedWindow, issues[0].Code)
+		assert.Equal(t, SeverityError, issues[0].Severity)
+		assert.Equal(t, 4, issues[0].LineNumber)
+		assert.Equal(t, `Schedule runs 88 time(s) in the next 30 days inside the protected window "deploy freeze" (09:00-10:00 mon-fri)`, issues[0].Message)
+		assert.True(t, strings.HasSuffix(issues[0].Hint, "Upcoming runs inside it: Mon 2026-10-19 09:00, Mon 2026-10-19 09:15, Mon 2026-10-19 09:30, Mon 2026-10-19 09:45, Tue 2026-10-20 09:00, and 83 more"), issues[0].Hint)
+	})
+
+	t.Run("runs outside the window or its days", func(t *testing.T) {
+		assert.Empty(t, v.checkProtectedWindows("0 10 * * *", 1, time.UTC, now))
+		assert.Empty(t, v.checkProtectedWindows("30 9 * * 0,6", 1, time.UTC, now))
+		assert.Empty(t, v.checkProtectedWindows("30 7 * * *", 1, time.UTC, now))
+	})
+
+	t.Run("windows in their own time zone", func(t *testing.T) {
+		paris, err := protectedWindowSpec{Window: "09:00-10:00", Days: "mon-fri", Timezone: "Europe/Paris"}.compile()
+		require.NoError(t, err)
+		v := NewValidator("en")
+		v.SetPolicy(&Policy{ProtectedWindows: []*ProtectedWindow{paris}})
+
+		// 07:30 UTC is 09:30 in Paris until summer time ends on Sunday 10-25;
+		// Monday's run is before now
+		issues := v.checkProtectedWindows("30 7 * * *", 1, time.UTC, now)
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0].Message, "runs 4 time(s)")
+		assert.True(t, strings.HasSuffix(issues[0].Hint, "Upcoming runs inside it: Tue 2026-10-20 09:30, Wed 2026-10-21 09:30, Thu 2026-10-22 09:30, Fri 2026-10-23 09:30"), issues[0].Hint)
+	})
+
+	t.Run("windows wrapping past midnight", func(t *testing.T) {
+		night, err := protectedWindowSpec{Window: "23:00-01:00", Days: "fri"}.compile()
+		require.NoError(t, err)
+		v := NewValidator("en")
+		v.SetPolicy(&Policy{ProtectedWindows: []*ProtectedWindow{night}})
+
+		issues := v.checkProtectedWindows("30 0 * * *", 1, time.UTC, now)
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0].Hint, "Sat 2026-10-24 00:30")
+	})
+
+	t.Run("single expressions", func(t *testing.T) {
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		v.SetPolicy(&Policy{ProtectedWindows: []*ProtectedWindow{{Window: Window{Start: 0, End: 24 * 60}, weekdays: [7]bool{true, true, true, true, true, true, true}}}})
+		result := v.ValidateExpression("0 12 * * *")
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, CodePolicyProtectedWindow, result.Issues[0].Code)
+	})
+}
@@ -0,0 +1,173 @@
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Rule is a validation rule compiled into cronkit. Evaluate is called with
+// each job entry whose expression is valid, and returns the issues it finds
+// (none when the job complies). Issues without a LineNumber or Expression get
+// the job's. Single expressions are evaluated as a job without a command.
+type Rule interface {
+	Evaluate(entry *crontab.Entry) []Issue
+}
+
+// RuleFunc adapts a function to a Rule
+type RuleFunc func(entry *crontab.Entry) []Issue
+
+// Evaluate calls f(entry)
+func (f RuleFunc) Evaluate(entry *crontab.Entry) []Issue {
+	return f(entry)
+}
+
+// registeredRules holds the rules added with RegisterRule
+var (
+	registeredRules []Rule
+	rulesMu         sync.RWMutex
+)
+
+// RegisterRule adds a rule every validator evaluates. It is meant to be
+// called from the init function of a package compiled into the binary, so
+// that the rule is in place before any validation starts.
+func RegisterRule(rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	registeredRules = append(registeredRules, rule)
+}
+
+// RegisteredRules returns the rules added with RegisterRule, in order
+func RegisteredRules() []Rule {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	return append([]Rule(nil), registeredRules...)
+}
+
+// PatternRule is a declarative rule from the rules list of a policy file.
+// The Command and Expression patterns select the jobs it applies to (all
+// jobs when both are nil); a selected job is reported when it fails to match
+// RequireCommand or RequireExpression, or, with neither set, always. Rules
+// with command patterns do not apply to single expressions.
+type PatternRule struct {
+	Code     string
+	Message  string
+	Hint     string
+	Severity Severity
+
+	Command           *regexp.Regexp
+	Expression        *regexp.Regexp
+	RequireCommand    *regexp.Regexp
+	RequireExpression *regexp.Regexp
+}
+
+// Evaluate reports the job when the rule selects it and it breaks the rule
+func (r *PatternRule) Evaluate(entry *crontab.Entry) []Issue {
+	job := entry.Job
+	if job.Command == "" && (r.Command != nil || r.RequireCommand != nil) {
+		return nil
+	}
+	if (r.Command != nil && !r.Command.MatchString(job.Command)) ||
+		(r.Expression != nil && !r.Expression.MatchString(job.Expression)) {
+		return nil
+	}
+	if r.RequireCommand != nil || r.RequireExpression != nil {
+		if (r.RequireCommand == nil || r.RequireCommand.MatchString(job.Command)) &&
+			(r.RequireExpression == nil || r.RequireExpression.MatchString(job.Expression)) {
+			return nil
+		}
+	}
+	return []Issue{{
+		Severity:   r.Severity,
+		Code:       r.Code,
+		LineNumber: job.LineNumber,
+		Expression: job.Expression,
+		Message:    r.Message,
+		Hint:       r.Hint,
+	}}
+}
+
+// patternRuleSpec is a rule as written in a policy file
+type patternRuleSpec struct {
+	Code              string `yaml:"code"`
+	Message           string `yaml:"message"`
+	Hint              string `yaml:"hint"`
+	Severity          string `yaml:"severity"`
+	Command           string `yaml:"command"`
+	Expression        string `yaml:"expression"`
+	RequireCommand    string `yaml:"require-command"`
+	RequireExpression string `yaml:"require-expression"`
+}
+
+// compile checks the spec and compiles its patterns
+func (s patternRuleSpec) compile() (*PatternRule, error) {
+	if s.Code == "" {
+		return nil, fmt.Errorf("rule without a code")
+	}
+	if strings.HasPrefix(strings.ToUpper(s.Code), "CRON-") {
+		return nil, fmt.Errorf("rule %s: CRON- codes are reserved for cronkit's own checks", s.Code)
+	}
+	if s.Message == "" {
+		return nil, fmt.Errorf("rule %s: message is required", s.Code)
+	}
+	rule := &PatternRule{Code: s.Code, Message: s.Message, Hint: s.Hint, Severity: SeverityError}
+	if s.Severity != "" {
+		if rule.Severity = SeverityFromString(s.Severity); rule.Severity == -1 {
+			return nil, fmt.Errorf("rule %s: invalid severity %q (expected info, warn or error)", s.Code, s.Severity)
+		}
+	}
+
+	patterns := []struct {
+		key    string
+		source string
+		target **regexp.Regexp
+	}{
+		{"command", s.Command, &rule.Command},
+		{"expression", s.Expression, &rule.Expression},
+		{"require-command", s.RequireCommand, &rule.RequireCommand},
+		{"require-expression", s.RequireExpression, &rule.RequireExpression},
+	}
+	for _, p := range patterns {
+		if p.source == "" {
+			continue
+		}
+		re, err := regexp.Compile(p.source)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: invalid %s pattern: %w", s.Code, p.key, err)
+		}
+		*p.target = re
+	}
+	return rule, nil
+}
+
+// evaluateRules runs the registered rules, the validator's own and the
+// policy's pattern rules against a job
+func (v *Validator) evaluateRules(job *crontab.Job) []Issue {
+	rules := append(RegisteredRules(), v.rules...)
+	if v.policy != nil {
+		for _, rule := range v.policy.Rules {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	entry := &crontab.Entry{Type: crontab.EntryTypeJob, LineNumber: job.LineNumber, Job: job}
+	var issues []Issue
+	for _, rule := range rules {
+		for _, issue := range rule.Evaluate(entry) {
+			if issue.LineNumber == 0 {
+				issue.LineNumber = job.LineNumber
+			}
+			if issue.Expression == "" {
+				issue.Expression = job.Expression
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,122 @@
+package check
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternRule_Evaluate(t *testing.T) {
+	job := func(expression, command string) *crontab.Entry {
+		return &crontab.Entry{Type: crontab.EntryTypeJob, LineNumber: 3, Job: &crontab.Job{LineNumber: 3, Expression: expression, Command: command, Valid: true}}
+	}
+
+	t.Run("forbids selected jobs", func(t *testing.T) {
+		rule := &PatternRule{Code: "OPS-001", Message: "No @reboot jobs", Severity: SeverityWarn, Expression: regexp.MustCompile(`^@reboot$`)}
+		assert.Empty(t, rule.Evaluate(job("0 0 * * *", "/usr/bin/a.sh")))
+
+		issues := rule.Evaluate(job("@reboot", "/usr/bin/a.sh"))
+		require.Len(t, issues, 1)
+		assert.Equal(t, Issue{Severity: SeverityWarn, Code: "OPS-001", LineNumber: 3, Expression: "@reboot", Message: "No @reboot jobs"}, issues[0])
+	})
+
+	t.Run("requires patterns of selected jobs", func(t *testing.T) {
+		rule := &PatternRule{
+			Code:           "OPS-002",
+			Message:        "curl must fail on HTTP errors",
+			Command:        regexp.MustCompile(`\bcurl\b`),
+			RequireCommand: regexp.MustCompile(`\s(--fail|-f)\b`),
+		}
+		assert.Empty(t, rule.Evaluate(job("0 * * * *", "/usr/bin/backup.sh")))
+		assert.Empty(t, rule.Evaluate(job("0 * * * *", "curl --fail https://example.com")))
+		assert.Len(t, rule.Evaluate(job("0 * * * *", "curl https://example.com")), 1)
+	})
+
+	t.Run("command rules skip single expressions", func(t *testing.T) {
+		rule := &PatternRule{Code: "OPS-003", Message: "Log output", RequireCommand: regexp.MustCompile(`>>`)}
+		assert.Empty(t, rule.Evaluate(job("0 * * * *", "")))
+	})
+}
+
+func TestParsePolicy_Rules(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		policy, err := ParsePolicy(strings.NewReader("policy:\n  rules:\n    - code: OPS-001\n      message: Hourly or slower\n      severity: warn\n      require-expression: '^[0-9]+ '\n"))
+		require.NoError(t, err)
+		require.Len(t, policy.Rules, 1)
+		assert.False(t, policy.IsZero())
+		assert.Equal(t, "OPS-001", policy.Rules[0].Code)
+		assert.Equal(t, SeverityWarn, policy.Rules[0].Severity)
+		assert.Equal(t, "^[0-9]+ ", policy.Rules[0].RequireExpression.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for content, want := range map[string]string{
+			"policy:\n  rules:\n    - message: m\n":                                         "without a code",
+			"policy:\n  rules:\n    - code: CRON-100\n      message: m\n":                   "reserved",
+			"policy:\n  rules:\n    - code: OPS-1\n":                                        "message is required",
+			"policy:\n  rules:\n    - code: OPS-1\n      message: m\n      severity: bad\n": "invalid severity",
+			"policy:\n  rules:\n    - code: OPS-1\n      message: m\n      command: '('\n":  "invalid command pattern",
+		} {
+			_, err := ParsePolicy(strings.NewReader(content))
+			require.Error(t, err, content)
+			assert.Contains(t, err.Error(), want)
+		}
+	})
+}
+
+func TestValidator_Rules(t *testing.T) {
+	forbidEveryMinute := RuleFunc(func(entry *crontab.Entry) []Issue {
+		if entry.Job.Expression != "* * * * *" {
+			return nil
+		}
+		return []Issue{{Severity: SeverityError, Code: "ACME-001", Message: "Every-minute jobs are not allowed"}}
+	})
+
+	t.Run("validator rules", func(t *testing.T) {
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		v.AddRule(forbidEveryMinute)
+
+		entries, err := crontab.ParseReader(strings.NewReader("0 * * * * /usr/bin/a.sh\n* * * * * /usr/bin/b.sh\n"))
+		require.NoError(t, err)
+		issues := v.ValidateEntries(entries).Issues
+		require.Len(t, issues, 1)
+		assert.Equal(t, "ACME-001", issues[0].Code)
+		assert.Equal(t, 2, issues[0].LineNumber)
+		assert.Equal(t, "* * * * *", issues[0].Expression)
+
+		result := v.ValidateExpression("* * * * *")
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, 0, result.Issues[0].LineNumber)
+	})
+
+	t.Run("registered rules apply to every validator", func(t *testing.T) {
+		saved := registeredRules
+		defer func() { registeredRules = saved }()
+		RegisterRule(forbidEveryMinute)
+		assert.Len(t, RegisteredRules(), len(saved)+1)
+
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		assert.Equal(t, "ACME-001", v.ValidateExpression("* * * * *").Issues[0].Code)
+	})
+
+	t.Run("policy rules", func(t *testing.T) {
+		policy, err := ParsePolicy(strings.NewReader("policy:\n  rules:\n    - code: OPS-001\n      message: Backups run at night\n      command: backup\n      require-expression: '^[0-9]+ [0-5] '\n"))
+		require.NoError(t, err)
+		v := NewValidator("en")
+		v.SetFrequencyChecks(false)
+		v.SetPolicy(policy)
+
+		entries, err := crontab.ParseReader(strings.NewReader("0 2 * * * /usr/bin/backup\n0 14 * * * /usr/bin/backup\n0 14 * * * /usr/bin/report\n"))
+		require.NoError(t, err)
+		issues := v.ValidateEntries(entries).Issues
+		require.Len(t, issues, 1)
+		assert.Equal(t, "OPS-001", issues[0].Code)
+		assert.Equal(t, 2, issues[0].LineNumber)
+	})
+}
@@ -0,0 +1,328 @@
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// MaxSuggestions is the most corrections suggested for one expression
+const MaxSuggestions = 3
+
+// Suggestion is a correction of an expression that fails to parse
+type Suggestion struct {
+	Expression string // The corrected expression, which parses
+	Reason     string // What was corrected
+}
+
+// suggestField describes a field of an expression for suggestions
+type suggestField struct {
+	name     string
+	min, max int
+	names    []string // Names of the values, indexed from min (days and months)
+}
+
+var (
+	secondField     = suggestField{name: "second", min: cronx.MinSecond, max: cronx.MaxSecond}
+	minuteField     = suggestField{name: "minute", min: cronx.MinMinute, max: cronx.MaxMinute}
+	hourField       = suggestField{name: "hour", min: cronx.MinHour, max: cronx.MaxHour}
+	dayOfMonthField = suggestField{name: "day-of-month", min: cronx.MinDayOfMonth, max: cronx.MaxDayOfMonth}
+	monthField      = suggestField{name: "month", min: cronx.MinMonth, max: cronx.MaxMonth, names: []string{
+		"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+	}}
+	dayOfWeekField = suggestField{name: "day-of-week", min: cronx.MinDayOfWeek, max: cronx.MaxDayOfWeek, names: []string{
+		"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT",
+	}}
+)
+
+// atomPattern matches the names and numbers of a field
+var atomPattern = regexp.MustCompile(`[A-Za-z]+|\d+`)
+
+// Suggest returns "did you mean" corrections of an expression that fails to
+// parse, best first: long or misspelled day and month names shortened
+// (MONDAY to MON), fields in the wrong order swapped (hour and minute, day of
+// month and month, month and day of week), and out-of-range values moved to
+// the nearest valid one (minute 60 to 0, day of week 7 to 0). Only
+// corrections that parse are returned, at most MaxSuggestions.
+func Suggest(parser cronx.Parser, expression string) []Suggestion {
+	fields := strings.Fields(expression)
+	var layout []suggestField
+	switch len(fields) {
+	case 5:
+		layout = []suggestField{minuteField, hourField, dayOfMonthField, monthField, dayOfWeekField}
+	case 6:
+		layout = []suggestField{secondField, minuteField, hourField, dayOfMonthField, monthField, dayOfWeekField}
+	default:
+		return nil
+	}
+
+	var candidates []Suggestion
+	add := func(fields []string, reasons []string) {
+		candidates = append(candidates, Suggestion{Expression: strings.Join(fields, " "), Reason: strings.Join(reasons, "; ")})
+	}
+
+	// Names are corrected first, so that other corrections apply to the result
+	base, reasons := fixNames(fields, layout)
+	if len(reasons) > 0 {
+		add(base, reasons)
+	}
+
+	// Values one past their bound are more likely slips than swapped fields
+	ranges := fixRanges(base, layout)
+	for _, fix := range ranges {
+		if fix.offByOne {
+			add(fix.fields, append(append([]string{}, reasons...), fix.reason))
+		}
+	}
+	for _, swap := range swapFields(base, layout) {
+		add(swap.fields, append(append([]string{}, reasons...), swap.reason))
+	}
+	for _, fix := range ranges {
+		if !fix.offByOne {
+			add(fix.fields, append(append([]string{}, reasons...), fix.reason))
+		}
+	}
+
+	var suggestions []Suggestion
+	seen := map[string]bool{expression: true}
+	for _, c := range candidates {
+		if seen[c.Expression] {
+			continue
+		}
+		seen[c.Expression] = true
+		if _, err := parser.Parse(c.Expression); err != nil {
+			continue
+		}
+		suggestions = append(suggestions, c)
+		if len(suggestions) == MaxSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
+
+// FormatSuggestions formats suggestions as a "Did you mean" sentence, or ""
+// when there are none
+func FormatSuggestions(suggestions []Suggestion) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	parts := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		parts[i] = fmt.Sprintf("'%s' (%s)", s.Expression, s.Reason)
+	}
+	return "Did you mean " + strings.Join(parts, " or ") + "?"
+}
+
+// correction is a candidate produced by one kind of correction
+type correction struct {
+	fields   []string
+	reason   string
+	offByOne bool // Every corrected value was one past its bound
+}
+
+// fixNames replaces day and month names that are not three-letter
+// abbreviations (MONDAY, Tues, sept) with the abbreviation
+func fixNames(fields []string, layout []suggestField) ([]string, []string) {
+	fixed := append([]string{}, fields...)
+	var reasons []string
+	for i, field := range layout {
+		if field.names == nil {
+			continue
+		}
+		fixed[i] = atomPattern.ReplaceAllStringFunc(fields[i], func(atom string) string {
+			abbrev := matchName(atom, field.names)
+			if abbrev == "" || strings.EqualFold(abbrev, atom) {
+				return atom
+			}
+			reasons = append(reasons, fmt.Sprintf("%s is not a %s name; use %s", atom, strings.TrimSuffix(field.name, "-of-week"), abbrev))
+			return abbrev
+		})
+	}
+	return fixed, reasons
+}
+
+// matchName returns the abbreviation of a name when the name starts with it
+// ("MONDAY", "Tues") or is one edit away from it ("MNO")
+func matchName(atom string, names []string) string {
+	upper := strings.ToUpper(atom)
+	if len(upper) < 3 {
+		return ""
+	}
+	for _, name := range names {
+		if strings.HasPrefix(upper, name) {
+			return name
+		}
+	}
+	if len(upper) == 3 {
+		for _, name := range names {
+			if transposed(upper, name) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// transposed reports whether two strings differ by two swapped adjacent letters
+func transposed(a, b string) bool {
+	if len(a) != len(b) || a == b {
+		return false
+	}
+	for i := 0; i+1 < len(a); i++ {
+		if a[i] != b[i] {
+			return a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+		}
+	}
+	return false
+}
+
+// swapFields detects fields given in the wrong order: an hour above 23 with
+// a minute that is a valid hour, a month above 12 with a day of month that is
+// a valid month, and day names in the month field with month names in the
+// day-of-week field
+func swapFields(fields []string, layout []suggestField) []correction {
+	index := func(name string) int {
+		for i, f := range layout {
+			if f.name == name {
+				return i
+			}
+		}
+		return -1
+	}
+	swap := func(a, b int, reason string) correction {
+		swapped := append([]string{}, fields...)
+		swapped[a], swapped[b] = swapped[b], swapped[a]
+		return correction{fields: swapped, reason: reason}
+	}
+
+	var corrections []correction
+	minute, hour := index(minuteField.name), index(hourField.name)
+	if v, ok := singleValue(fields[hour]); ok && v > cronx.MaxHour && v <= cronx.MaxMinute {
+		if m, ok := singleValue(fields[minute]); ok && m <= cronx.MaxHour {
+			corrections = append(corrections, swap(minute, hour, "minute and hour fields look swapped; the minute comes first"))
+		}
+	}
+
+	dom, month, dow := index(dayOfMonthField.name), index(monthField.name), index(dayOfWeekField.name)
+	if v, ok := singleValue(fields[month]); ok && v > cronx.MaxMonth && v <= cronx.MaxDayOfMonth {
+		if d, ok := singleValue(fields[dom]); ok && d <= cronx.MaxMonth {
+			corrections = append(corrections, swap(dom, month, "day-of-month and month fields look swapped; the day of month comes first"))
+		}
+	}
+
+	if hasName(fields[month], dayOfWeekField.names) || hasName(fields[dow], monthField.names) {
+		corrections = append(corrections, swap(month, dow, "month and day-of-week fields look swapped; the month comes first"))
+	}
+	return corrections
+}
+
+// singleValue returns the value of a field that is a single number
+func singleValue(field string) (int, bool) {
+	v, err := strconv.Atoi(field)
+	return v, err == nil
+}
+
+// hasName reports whether a field contains any of the names
+func hasName(field string, names []string) bool {
+	for _, atom := range atomPattern.FindAllString(field, -1) {
+		for _, name := range names {
+			if strings.EqualFold(atom, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fixRanges moves out-of-range values to the nearest valid value. A value one
+// past the maximum of a field starting at 0 also wraps to 0, which is what it
+// usually means (minute 60, hour 24, day of week 7 for Sunday); that
+// correction is suggested first. Steps (the N of */N) are left alone.
+func fixRanges(fields []string, layout []suggestField) []correction {
+	var wrapped, clamped []string
+	var wrapReasons, clampReasons []string
+	wraps, offByOne := false, true
+
+	for i, field := range layout {
+		wrap, clamp, wrapReason, clampReason, near := fixFieldRange(fields[i], field)
+		offByOne = offByOne && near
+		wrapped = append(wrapped, wrap)
+		clamped = append(clamped, clamp)
+		if wrapReason != "" {
+			wrapReasons = append(wrapReasons, wrapReason)
+			wraps = wraps || wrap != clamp
+		}
+		if clampReason != "" {
+			clampReasons = append(clampReasons, clampReason)
+		}
+	}
+
+	var corrections []correction
+	if len(wrapReasons) > 0 && wraps {
+		corrections = append(corrections, correction{fields: wrapped, reason: strings.Join(wrapReasons, "; "), offByOne: offByOne})
+	}
+	if len(clampReasons) > 0 {
+		corrections = append(corrections, correction{fields: clamped, reason: strings.Join(clampReasons, "; "), offByOne: offByOne})
+	}
+	return corrections
+}
+
+// fixFieldRange returns the wrapped and clamped versions of a field with the
+// reasons for the changes, or empty reasons when all values are in range, and
+// whether every out-of-range value was one past its bound
+func fixFieldRange(value string, field suggestField) (string, string, string, string, bool) {
+	var wrapReason, clampReason string
+	var wrapped, clamped strings.Builder
+	offByOne := true
+
+	last := 0
+	for _, loc := range atomPattern.FindAllStringIndex(value, -1) {
+		atom := value[loc[0]:loc[1]]
+		wrapped.WriteString(value[last:loc[0]])
+		clamped.WriteString(value[last:loc[0]])
+		last = loc[1]
+
+		n, err := strconv.Atoi(atom)
+		isStep := loc[0] > 0 && value[loc[0]-1] == '/'
+		if err != nil || isStep || (n >= field.min && n <= field.max) {
+			wrapped.WriteString(atom)
+			clamped.WriteString(atom)
+			continue
+		}
+
+		bound := field.max
+		if n < field.min {
+			bound = field.min
+		}
+		offByOne = offByOne && (n == bound+1 || n == bound-1)
+		clamped.WriteString(strconv.Itoa(bound))
+		clampReason = fmt.Sprintf("%s %d is outside %d-%d", field.name, n, field.min, field.max)
+
+		if field.min == 0 && n == field.max+1 {
+			wrapped.WriteString("0")
+			wrapReason = fmt.Sprintf("%s %d is outside %d-%d; %s", field.name, n, field.min, field.max, wrapMeaning(field))
+		} else {
+			wrapped.WriteString(strconv.Itoa(bound))
+			wrapReason = clampReason
+		}
+	}
+	wrapped.WriteString(value[last:])
+	clamped.WriteString(value[last:])
+	return wrapped.String(), clamped.String(), wrapReason, clampReason, offByOne
+}
+
+// wrapMeaning explains the value 0 of a field that wraps around
+func wrapMeaning(field suggestField) string {
+	switch field.name {
+	case dayOfWeekField.name:
+		return "Sunday is 0"
+	case hourField.name:
+		return "midnight is hour 0"
+	default:
+		return fmt.Sprintf("the %s after %d is 0", field.name, field.max)
+	}
+}
@@ -0,0 +1,59 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{"long day name", "0 0 * * MONDAY", []string{"0 0 * * MON"}},
+		{"long month name", "0 0 1 January *", []string{"0 0 1 JAN *"}},
+		{"day name range", "0 9 * * Tuesday-Friday", []string{"0 9 * * TUE-FRI"}},
+		{"transposed day name", "0 0 * * MNO", []string{"0 0 * * MON"}},
+		{"minute 60", "60 * * * *", []string{"0 * * * *", "59 * * * *"}},
+		{"hour 24", "0 24 * * *", []string{"0 0 * * *", "0 23 * * *", "24 0 * * *"}},
+		{"sunday as 7", "0 0 * * 7", []string{"0 0 * * 0", "0 0 * * 6"}},
+		{"day of month 0", "0 0 0 * *", []string{"0 0 1 * *"}},
+		{"minute and hour swapped", "9 30 * * *", []string{"30 9 * * *", "9 23 * * *"}},
+		{"day and month swapped", "0 0 12 25 *", []string{"0 0 25 12 *", "0 0 12 12 *"}},
+		{"month and weekday swapped", "0 0 * MON JAN", []string{"0 0 * JAN MON"}},
+		{"with seconds", "0 60 * * * *", []string{"0 0 * * * *", "0 59 * * * *"}},
+		{"steps are left alone", "*/60 * * * *", nil},
+		{"unknown field count", "0 0 *", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var expressions []string
+			for _, s := range Suggest(parser, tt.expression) {
+				expressions = append(expressions, s.Expression)
+				_, err := parser.Parse(s.Expression)
+				require.NoError(t, err)
+				assert.NotEmpty(t, s.Reason)
+			}
+			assert.Equal(t, tt.expected, expressions)
+		})
+	}
+
+	t.Run("reasons explain the correction", func(t *testing.T) {
+		suggestions := Suggest(parser, "0 0 * * 7")
+		require.NotEmpty(t, suggestions)
+		assert.Equal(t, "day-of-week 7 is outside 0-6; Sunday is 0", suggestions[0].Reason)
+	})
+}
+
+func TestFormatSuggestions(t *testing.T) {
+	assert.Empty(t, FormatSuggestions(nil))
+	assert.Equal(t, "Did you mean '0 0 * * MON' (MONDAY is not a day name; use MON)?",
+		FormatSuggestions([]Suggestion{{Expression: "0 0 * * MON", Reason: "MONDAY is not a day name; use MON"}}))
+}
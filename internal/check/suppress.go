@@ -0,0 +1,54 @@
+package check
+
+import "github.com/hzerrad/cronkit/internal/crontab"
+
+// ignoreIndex holds the jobs with "cronkit:ignore" annotations by line
+type ignoreIndex map[int][]*crontab.Job
+
+// newIgnoreIndex indexes the jobs that ignore diagnostics, nil when none do
+func newIgnoreIndex(jobs []*crontab.Job) ignoreIndex {
+	var index ignoreIndex
+	for _, job := range jobs {
+		index = index.add(job)
+	}
+	return index
+}
+
+// add indexes a job if it ignores diagnostics, returning the index, which is
+// allocated by the first such job
+func (index ignoreIndex) add(job *crontab.Job) ignoreIndex {
+	if len(job.Ignore) == 0 {
+		return index
+	}
+	if index == nil {
+		index = ignoreIndex{}
+	}
+	index[job.LineNumber] = append(index[job.LineNumber], job)
+	return index
+}
+
+// ignores reports whether the job an issue is reported on ignores its code
+func (index ignoreIndex) ignores(issue Issue) bool {
+	for _, job := range index[issue.LineNumber] {
+		if (issue.File == "" || issue.File == job.Source) && job.Ignores(issue.Code) {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressIgnored drops the issues ignored by the annotations of jobs,
+// returning the issues kept and the number dropped
+func suppressIgnored(issues []Issue, jobs []*crontab.Job) ([]Issue, int) {
+	index := newIgnoreIndex(jobs)
+	if index == nil {
+		return issues, 0
+	}
+	kept := issues[:0]
+	for _, issue := range issues {
+		if !index.ignores(issue) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept, len(issues) - len(kept)
+}
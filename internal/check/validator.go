@@ -1,11 +1,15 @@
 package check
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"sync"
 	"time"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/holiday"
 )
 
 // Issue represents a validation issue found in a cron expression or crontab
@@ -16,6 +20,9 @@ type Issue struct {
 	Expression string   // The cron expression (if applicable)
 	Message    string   // Human-readable issue description
 	Hint       string   // Optional fix suggestion
+	File       string   // File of the job, when jobs of several files are checked together (optional)
+
+	Suggestions []Suggestion // Corrected expressions, for expressions that fail to parse (optional)
 }
 
 // ValidationResult contains the results of validating a cron expression or crontab
@@ -25,10 +32,15 @@ type ValidationResult struct {
 	TotalJobs   int
 	ValidJobs   int
 	InvalidJobs int
+	Jobs        []*crontab.Job // Jobs validated, in crontab order (none for single expressions)
+	Suppressed  int            // Issues left out, ignored by "cronkit:ignore" annotations or a baseline
 }
 
-// Validator provides validation functionality for cron expressions and crontabs
+// Validator provides validation functionality for cron expressions and crontabs.
+// A Validator is safe for concurrent use: any number of goroutines may validate
+// at the same time, and setters wait for in-flight validations to finish.
 type Validator struct {
+	mu              sync.RWMutex
 	parser          cronx.Parser
 	scheduler       cronx.Scheduler
 	locale          string
@@ -37,6 +49,16 @@ type Validator struct {
 	enableHygiene   bool
 	warnOnOverlap   bool
 	overlapWindow   time.Duration
+	consolidation   bool
+	horizon         time.Duration
+	location        *time.Location    // Time zone for DST checks (nil: only jobs under CRON_TZ=)
+	environment     map[string]string // Base job environment for command expansion (nil: disabled)
+	githubActions   bool              // Check GitHub Actions schedule semantics (CRON-017, CRON-018)
+	policy          *Policy           // Scheduling policy (CRON-021 to CRON-024, nil: none)
+	holidays        *holiday.Calendar // Public holidays business-critical jobs are checked against (CRON-025, nil: none)
+	rules           []Rule            // Rules added with AddRule
+	ctx             context.Context   // Context validations run in (nil: never ends)
+	version         uint64            // Incremented whenever settings change
 }
 
 // NewValidator creates a new validator instance
@@ -49,36 +71,164 @@ func NewValidator(locale string) *Validator {
 		maxRunsPerDay:   1000,                 // Default threshold
 		warnOnOverlap:   false,                // Default: disabled
 		overlapWindow:   DefaultOverlapWindow, // Default: 24 hours
+		horizon:         DefaultHorizon,       // Default: 2 years
 	}
 }
 
 // SetFrequencyChecks enables or disables frequency analysis
 func (v *Validator) SetFrequencyChecks(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
 	v.enableFrequency = enabled
 }
 
 // SetMaxRunsPerDay sets the threshold for excessive runs warning
 func (v *Validator) SetMaxRunsPerDay(threshold int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
 	v.maxRunsPerDay = threshold
 }
 
 // SetHygieneChecks enables or disables command hygiene checks
 func (v *Validator) SetHygieneChecks(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
 	v.enableHygiene = enabled
 }
 
 // SetWarnOnOverlap enables or disables overlap warnings
 func (v *Validator) SetWarnOnOverlap(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
 	v.warnOnOverlap = enabled
 }
 
 // SetOverlapWindow sets the time window for overlap analysis
 func (v *Validator) SetOverlapWindow(window time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
 	v.overlapWindow = window
 }
 
+// SetConsolidationChecks enables or disables duplicate-command consolidation suggestions
+func (v *Validator) SetConsolidationChecks(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.consolidation = enabled
+}
+
+// SetHorizon sets how far ahead a schedule's first run may lie before it is
+// reported as distant (INFO). Schedules that never run are always errors.
+func (v *Validator) SetHorizon(horizon time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.horizon = horizon
+}
+
+// SetTimezone sets the time zone whose daylight saving time transitions are
+// checked (CRON-015). Jobs preceded by CRON_TZ= or TZ= are checked in their own
+// zone; a nil location checks only those jobs.
+func (v *Validator) SetTimezone(loc *time.Location) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.location = loc
+}
+
+// SetEnvironment sets the environment cron starts jobs with (see
+// crontab.DefaultEnvironment). Commands are then expanded in each job's
+// effective environment before hygiene checks, and checked for existence
+// (CRON-016). A nil environment disables both.
+func (v *Validator) SetEnvironment(env map[string]string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.environment = env
+}
+
+// SetGitHubActions enables or disables the checks for schedules of GitHub
+// Actions workflows: runs more often than GitHub allows (CRON-017) and
+// fixed times that GitHub evaluates in UTC (CRON-018)
+func (v *Validator) SetGitHubActions(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.githubActions = enabled
+}
+
+// SetPolicy sets the scheduling policy jobs are checked against (CRON-021 to
+// CRON-024). A nil policy enforces no rule.
+func (v *Validator) SetPolicy(policy *Policy) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.policy = policy
+}
+
+// SetHolidays sets the calendar of public holidays that business-critical
+// jobs running on weekdays only are checked against (CRON-025): they are
+// reported when they run on one of its holidays within the next year. With
+// a nil calendar, they are all reported.
+func (v *Validator) SetHolidays(calendar *holiday.Calendar) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.holidays = calendar
+}
+
+// AddRule adds a rule evaluated by this validator only, after the rules
+// added with RegisterRule
+func (v *Validator) AddRule(rule Rule) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.rules = append(v.rules, rule)
+}
+
+// SetParserOptions sets the seconds mode and dialect used to parse expressions
+func (v *Validator) SetParserOptions(opts cronx.ParserOptions) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.parser = cronx.NewParserWithOptions(v.locale, opts)
+	v.scheduler = cronx.NewSchedulerWithOptions(opts)
+	if v.ctx != nil {
+		v.scheduler = cronx.WithContext(v.ctx, v.scheduler)
+	}
+}
+
+// SetContext sets the context validations run in. Once it ends, no more runs
+// are computed, so the checks that need them find nothing; StreamEntries and
+// ValidateStream then stop and return the cause of its end, and callers of
+// the other methods should check ctx.Err() before trusting their results.
+func (v *Validator) SetContext(ctx context.Context) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version++
+	v.ctx = ctx
+	v.scheduler = cronx.WithContext(ctx, v.scheduler)
+}
+
+// ended returns the cause of the end of the validator's context, or nil
+func (v *Validator) ended() error {
+	if v.ctx == nil || v.ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(v.ctx)
+}
+
 // ValidateExpression validates a single cron expression
 func (v *Validator) ValidateExpression(expression string) ValidationResult {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
 	result := ValidationResult{
 		Valid:     true,
 		TotalJobs: 1,
@@ -97,6 +247,8 @@ func (v *Validator) ValidateExpression(expression string) ValidationResult {
 			Expression: expression,
 			Message:    fmt.Sprintf("Invalid cron expression: %s", err.Error()),
 			Hint:       GetCodeHint(CodeParseError),
+
+			Suggestions: Suggest(v.parser, expression),
 		})
 		return result
 	}
@@ -116,19 +268,21 @@ func (v *Validator) ValidateExpression(expression string) ValidationResult {
 		})
 	}
 
-	// Check for empty schedule
-	if detectEmptySchedule(expression, v.scheduler) {
-		result.Valid = false
-		result.InvalidJobs = 1
-		result.ValidJobs = 0
-		result.Issues = append(result.Issues, Issue{
-			Severity:   SeverityError,
-			Code:       CodeEmptySchedule,
-			LineNumber: 0,
-			Expression: expression,
-			Message:    "Schedule never runs (empty schedule)",
-			Hint:       GetCodeHint(CodeEmptySchedule),
-		})
+	// Check for empty or distant schedule
+	if issue, empty := v.checkFirstRun(expression, 0); issue != nil {
+		if empty {
+			result.Valid = false
+			result.InvalidJobs = 1
+			result.ValidJobs = 0
+		}
+		result.Issues = append(result.Issues, *issue)
+	}
+
+	// Check for runs skipped or repeated by DST transitions
+	if v.location != nil {
+		if issue := v.checkDST(expression, schedule, 0, v.location, time.Now()); issue != nil {
+			result.Issues = append(result.Issues, *issue)
+		}
 	}
 
 	// Frequency analysis (if enabled)
@@ -137,11 +291,21 @@ func (v *Validator) ValidateExpression(expression string) ValidationResult {
 		result.Issues = append(result.Issues, freqIssues...)
 	}
 
+	// Scheduling policy (if set)
+	result.Issues = append(result.Issues, v.checkPolicySchedule(expression, 0, schedule)...)
+	result.Issues = append(result.Issues, v.checkProtectedWindows(expression, 0, v.location, time.Now())...)
+
+	// Custom rules
+	result.Issues = append(result.Issues, v.evaluateRules(&crontab.Job{Expression: expression, Valid: true})...)
+
 	return result
 }
 
 // ValidateCrontab validates a crontab file
 func (v *Validator) ValidateCrontab(reader crontab.Reader, path string) ValidationResult {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
 	result := ValidationResult{
 		Valid:     true,
 		Issues:    []Issue{},
@@ -164,206 +328,287 @@ func (v *Validator) ValidateCrontab(reader crontab.Reader, path string) Validati
 		return result
 	}
 
-	// Validate each job entry
+	return v.validateAll(entries)
+}
+
+// validateAll runs the per-line checks on every job of entries, then the
+// checks comparing them, and drops the issues ignored by "cronkit:ignore"
+// annotations
+func (v *Validator) validateAll(entries []*crontab.Entry) ValidationResult {
+	result := ValidationResult{Valid: true, Issues: []Issue{}}
 	for _, entry := range entries {
 		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
 			continue
 		}
 
 		result.TotalJobs++
-
-		// Check if the job is valid
-		if !entry.Job.Valid {
+		result.Jobs = append(result.Jobs, entry.Job)
+		issues, valid := v.validateJob(entry.Job)
+		if valid {
+			result.ValidJobs++
+		} else {
 			result.Valid = false
 			result.InvalidJobs++
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeParseError,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    fmt.Sprintf("Invalid cron expression: %s", entry.Job.Error),
-				Hint:       GetCodeHint(CodeParseError),
-			})
-			continue
 		}
+		result.Issues = append(result.Issues, issues...)
+	}
+	result.Issues = append(result.Issues, v.validateCrossLine(entries)...)
+
+	// Issues ignored by "cronkit:ignore" annotations
+	result.Issues, result.Suppressed = suppressIgnored(result.Issues, result.Jobs)
+
+	return result
+}
+
+// ValidateEntries validates a slice of crontab entries (e.g., from stdin)
+func (v *Validator) ValidateEntries(entries []*crontab.Entry) ValidationResult {
+	issues := []Issue{}
+	result, _ := v.StreamEntries(entries, func(issue Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	result.Issues = issues
+	return result
+}
+
+// StreamEntries validates entries like ValidateEntries, but passes each issue
+// to emit as soon as its job is checked instead of collecting them, so large
+// crontabs can be reported as they are validated. Issues comparing jobs
+// (overlaps, consolidation, dependencies) come last. Issues ignored by
+// "cronkit:ignore" annotations are counted but not emitted. The result has
+// no Issues; validation stops at the first error returned by emit, or when
+// the context set with SetContext ends.
+func (v *Validator) StreamEntries(entries []*crontab.Entry, emit func(Issue) error) (ValidationResult, error) {
+	return v.stream(crontab.EntrySeq(entries), emit, true)
+}
+
+// ValidateStream validates a crontab as its entries are read, e.g. from
+// crontab.Reader.FileEntries, passing each issue to emit like StreamEntries.
+// Jobs are kept only when needed: all of them when overlap, consolidation or
+// policy spacing checks are on; otherwise those with "cronkit:name=" or
+// "cronkit:after=" directives, for the dependency checks, and those with
+// "cronkit:ignore" annotations, to suppress issues reported later. Without
+// those, a crontab of any size is validated in constant memory. The result
+// has no Issues or Jobs; validation stops at the first read error or error
+// returned by emit, or when the context set with SetContext ends.
+func (v *Validator) ValidateStream(entries iter.Seq2[*crontab.Entry, error], emit func(Issue) error) (ValidationResult, error) {
+	return v.stream(entries, emit, false)
+}
+
+// stream validates entries as they are read, keeping the jobs in the result
+// when keepJobs is set
+func (v *Validator) stream(entries iter.Seq2[*crontab.Entry, error], emit func(Issue) error, keepJobs bool) (ValidationResult, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	result := ValidationResult{Valid: true}
+	crossLine := v.warnOnOverlap || v.consolidation || (v.policy != nil && v.policy.MinSpacing > 0)
+	var kept []*crontab.Entry
+	var ignored ignoreIndex
+	emitKept := func(issue Issue) error {
+		if ignored.ignores(issue) {
+			result.Suppressed++
+			return nil
+		}
+		return emit(issue)
+	}
 
-		// Parse the schedule for additional checks
-		schedule, err := v.parser.Parse(entry.Job.Expression)
+	// Validate each job entry as it is read; its annotations are indexed
+	// first, so they apply to its own issues
+	for entry, err := range entries {
 		if err != nil {
-			// This shouldn't happen if Valid is true, but handle it anyway
-			result.Valid = false
-			result.InvalidJobs++
-			result.ValidJobs--
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeParseError,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    fmt.Sprintf("Failed to parse expression: %s", err.Error()),
-				Hint:       GetCodeHint(CodeParseError),
-			})
+			return result, err
+		}
+		if err := v.ended(); err != nil {
+			return result, err
+		}
+		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
 			continue
 		}
 
-		result.ValidJobs++
-
-		// Check for DOM/DOW conflict
-		if detectDOMDOWConflict(schedule) {
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityWarn,
-				Code:       CodeDOMDOWConflict,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    "Both day-of-month and day-of-week specified (runs if either condition is met)",
-				Hint:       GetCodeHint(CodeDOMDOWConflict),
-			})
+		result.TotalJobs++
+		if keepJobs {
+			result.Jobs = append(result.Jobs, entry.Job)
 		}
+		if crossLine || entry.Job.Metadata.Name != "" || len(entry.Job.Metadata.After) > 0 {
+			kept = append(kept, entry)
+		}
+		ignored = ignored.add(entry.Job)
 
-		// Check for empty schedule
-		if detectEmptySchedule(entry.Job.Expression, v.scheduler) {
+		issues, valid := v.validateJob(entry.Job)
+		if valid {
+			result.ValidJobs++
+		} else {
 			result.Valid = false
 			result.InvalidJobs++
-			result.ValidJobs--
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeEmptySchedule,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    "Schedule never runs (empty schedule)",
-				Hint:       GetCodeHint(CodeEmptySchedule),
-			})
 		}
-
-		// Frequency analysis (if enabled)
-		if v.enableFrequency {
-			freqIssues := v.validateFrequency(schedule, entry.Job.Expression)
-			for i := range freqIssues {
-				freqIssues[i].LineNumber = entry.Job.LineNumber
+		for _, issue := range issues {
+			if err := emitKept(issue); err != nil {
+				return result, err
 			}
-			result.Issues = append(result.Issues, freqIssues...)
 		}
+	}
 
-		// Command hygiene checks (if enabled)
-		if v.enableHygiene && entry.Job.Command != "" {
-			hygieneIssues := v.validateCommandHygiene(entry.Job)
-			result.Issues = append(result.Issues, hygieneIssues...)
+	crossLineIssues := v.validateCrossLine(kept)
+	if err := v.ended(); err != nil {
+		return result, err
+	}
+	for _, issue := range crossLineIssues {
+		if err := emitKept(issue); err != nil {
+			return result, err
 		}
 	}
 
-	// Overlap analysis (if enabled) - only for crontab validation
-	if v.warnOnOverlap && len(entries) > 1 {
-		overlapIssues := v.validateOverlaps(entries)
-		result.Issues = append(result.Issues, overlapIssues...)
-	}
+	return result, nil
+}
 
-	return result
+// validateJob runs all per-line checks for a single job and reports whether
+// it is valid. Issues carry the job's source file, if any.
+func (v *Validator) validateJob(job *crontab.Job) ([]Issue, bool) {
+	issues, valid := v.checkJob(job)
+	if job.Source != "" {
+		for i := range issues {
+			issues[i].File = job.Source
+		}
+	}
+	return issues, valid
 }
 
-// ValidateEntries validates a slice of crontab entries (e.g., from stdin)
-func (v *Validator) ValidateEntries(entries []*crontab.Entry) ValidationResult {
-	result := ValidationResult{
-		Valid:     true,
-		Issues:    []Issue{},
-		TotalJobs: 0,
-		ValidJobs: 0,
+// checkJob runs all per-line checks for a single job
+func (v *Validator) checkJob(job *crontab.Job) ([]Issue, bool) {
+	var issues []Issue
+
+	if !job.Valid {
+		return append(issues, Issue{
+			Severity:   SeverityError,
+			Code:       CodeParseError,
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Message:    fmt.Sprintf("Invalid cron expression: %s", job.Error),
+			Hint:       GetCodeHint(CodeParseError),
+
+			Suggestions: Suggest(v.parser, job.Expression),
+		}), false
 	}
 
-	// Validate each job entry
-	for _, entry := range entries {
-		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
-			continue
-		}
+	// Parse the schedule for additional checks
+	schedule, err := v.parser.Parse(job.Expression)
+	if err != nil {
+		// This shouldn't happen if Valid is true, but handle it anyway
+		return append(issues, Issue{
+			Severity:   SeverityError,
+			Code:       CodeParseError,
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Message:    fmt.Sprintf("Failed to parse expression: %s", err.Error()),
+			Hint:       GetCodeHint(CodeParseError),
 
-		result.TotalJobs++
+			Suggestions: Suggest(v.parser, job.Expression),
+		}), false
+	}
 
-		if !entry.Job.Valid {
-			result.Valid = false
-			result.InvalidJobs++
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeParseError,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    fmt.Sprintf("Invalid cron expression: %s", entry.Job.Error),
-				Hint:       GetCodeHint(CodeParseError),
-			})
-			continue
+	valid := true
+
+	// Check for DOM/DOW conflict
+	if detectDOMDOWConflict(schedule) {
+		issues = append(issues, Issue{
+			Severity:   SeverityWarn,
+			Code:       CodeDOMDOWConflict,
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Message:    "Both day-of-month and day-of-week specified (runs if either condition is met)",
+			Hint:       GetCodeHint(CodeDOMDOWConflict),
+		})
+	}
+
+	// Check for empty or distant schedule
+	if issue, empty := v.checkFirstRun(job.Expression, job.LineNumber); issue != nil {
+		if empty {
+			valid = false
 		}
+		issues = append(issues, *issue)
+	}
 
-		// Parse the schedule for additional checks
-		schedule, err := v.parser.Parse(entry.Job.Expression)
-		if err != nil {
-			// This shouldn't happen if Valid is true, but handle it anyway
-			result.Valid = false
-			result.InvalidJobs++
-			result.ValidJobs--
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeParseError,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    fmt.Sprintf("Failed to parse expression: %s", err.Error()),
-				Hint:       GetCodeHint(CodeParseError),
-			})
-			continue
+	// Check for runs skipped or repeated by DST transitions
+	if loc, err := job.Location(v.location); err == nil && loc != nil {
+		if issue := v.checkDST(job.Expression, schedule, job.LineNumber, loc, time.Now()); issue != nil {
+			issues = append(issues, *issue)
 		}
+	}
 
-		result.ValidJobs++
+	// Check for business-critical jobs that run on public holidays
+	if issue := v.checkHolidays(job, schedule, v.holidays, time.Now()); issue != nil {
+		issues = append(issues, *issue)
+	}
 
-		// Check for DOM/DOW conflict
-		if detectDOMDOWConflict(schedule) {
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityWarn,
-				Code:       CodeDOMDOWConflict,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    "Both day-of-month and day-of-week specified (runs if either condition is met)",
-				Hint:       GetCodeHint(CodeDOMDOWConflict),
-			})
+	// Frequency analysis (if enabled)
+	if v.enableFrequency {
+		freqIssues := v.validateFrequency(schedule, job.Expression)
+		for i := range freqIssues {
+			freqIssues[i].LineNumber = job.LineNumber
 		}
+		issues = append(issues, freqIssues...)
+	}
 
-		// Check for empty schedule
-		if detectEmptySchedule(entry.Job.Expression, v.scheduler) {
-			result.Valid = false
-			result.InvalidJobs++
-			result.ValidJobs--
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeEmptySchedule,
-				LineNumber: entry.Job.LineNumber,
-				Expression: entry.Job.Expression,
-				Message:    "Schedule never runs (empty schedule)",
-				Hint:       GetCodeHint(CodeEmptySchedule),
-			})
-		}
+	// Command hygiene checks (if enabled)
+	if v.enableHygiene && job.Command != "" {
+		issues = append(issues, v.validateCommandHygiene(job)...)
+	}
 
-		// Frequency analysis (if enabled)
-		if v.enableFrequency {
-			freqIssues := v.validateFrequency(schedule, entry.Job.Expression)
-			for i := range freqIssues {
-				freqIssues[i].LineNumber = entry.Job.LineNumber
-			}
-			result.Issues = append(result.Issues, freqIssues...)
-		}
+	// Command existence check (if an environment is set)
+	issues = append(issues, v.checkCommandExists(job)...)
 
-		// Command hygiene checks (if enabled)
-		if v.enableHygiene && entry.Job.Command != "" {
-			hygieneIssues := v.validateCommandHygiene(entry.Job)
-			result.Issues = append(result.Issues, hygieneIssues...)
-		}
+	// GitHub Actions schedule semantics (if enabled)
+	if v.githubActions {
+		issues = append(issues, v.checkGitHubSchedule(job, schedule)...)
 	}
 
+	// Scheduling policy (if set)
+	issues = append(issues, v.checkPolicy(job, schedule)...)
+
+	// Custom rules
+	issues = append(issues, v.evaluateRules(job)...)
+
+	return issues, valid
+}
+
+// validateCrossLine runs the analyses that compare jobs against each other
+func (v *Validator) validateCrossLine(entries []*crontab.Entry) []Issue {
+	var issues []Issue
+
 	// Overlap analysis (if enabled) - only for multiple entries
 	if v.warnOnOverlap && len(entries) > 1 {
-		overlapIssues := v.validateOverlaps(entries)
-		result.Issues = append(result.Issues, overlapIssues...)
+		issues = append(issues, v.validateOverlaps(entries)...)
 	}
 
-	return result
+	// Consolidation analysis (if enabled)
+	if v.consolidation && len(entries) > 1 {
+		issues = append(issues, v.validateConsolidation(entries)...)
+	}
+
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+
+	// Scheduling policy spacing between jobs (if set)
+	if v.policy != nil && v.policy.MinSpacing > 0 {
+		issues = append(issues, v.checkPolicySpacing(jobs)...)
+	}
+
+	// Jobs that start before the jobs they run after finish
+	issues = append(issues, v.checkDependencies(jobs)...)
+
+	return issues
 }
 
 // ValidateUserCrontab validates the current user's crontab
 func (v *Validator) ValidateUserCrontab(reader crontab.Reader) ValidationResult {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
 	result := ValidationResult{
 		Valid:     true,
 		Issues:    []Issue{},
@@ -386,102 +631,26 @@ func (v *Validator) ValidateUserCrontab(reader crontab.Reader) ValidationResult
 		return result
 	}
 
-	// Validate each job
+	entries := make([]*crontab.Entry, 0, len(jobs))
 	for _, job := range jobs {
-		result.TotalJobs++
-
-		if !job.Valid {
-			result.Valid = false
-			result.InvalidJobs++
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeParseError,
-				LineNumber: job.LineNumber,
-				Expression: job.Expression,
-				Message:    fmt.Sprintf("Invalid cron expression: %s", job.Error),
-				Hint:       GetCodeHint(CodeParseError),
-			})
-			continue
-		}
-
-		// Parse the schedule for additional checks
-		schedule, err := v.parser.Parse(job.Expression)
-		if err != nil {
-			result.Valid = false
-			result.InvalidJobs++
-			result.ValidJobs--
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeParseError,
-				LineNumber: job.LineNumber,
-				Expression: job.Expression,
-				Message:    fmt.Sprintf("Failed to parse expression: %s", err.Error()),
-				Hint:       GetCodeHint(CodeParseError),
-			})
-			continue
-		}
-
-		result.ValidJobs++
-
-		// Check for DOM/DOW conflict
-		if detectDOMDOWConflict(schedule) {
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityWarn,
-				Code:       CodeDOMDOWConflict,
-				LineNumber: job.LineNumber,
-				Expression: job.Expression,
-				Message:    "Both day-of-month and day-of-week specified (runs if either condition is met)",
-				Hint:       GetCodeHint(CodeDOMDOWConflict),
-			})
-		}
-
-		// Check for empty schedule
-		if detectEmptySchedule(job.Expression, v.scheduler) {
-			result.Valid = false
-			result.InvalidJobs++
-			result.ValidJobs--
-			result.Issues = append(result.Issues, Issue{
-				Severity:   SeverityError,
-				Code:       CodeEmptySchedule,
-				LineNumber: job.LineNumber,
-				Expression: job.Expression,
-				Message:    "Schedule never runs (empty schedule)",
-				Hint:       GetCodeHint(CodeEmptySchedule),
-			})
-		}
-
-		// Frequency analysis (if enabled)
-		if v.enableFrequency {
-			freqIssues := v.validateFrequency(schedule, job.Expression)
-			for i := range freqIssues {
-				freqIssues[i].LineNumber = job.LineNumber
-			}
-			result.Issues = append(result.Issues, freqIssues...)
-		}
-
-		// Command hygiene checks (if enabled)
-		if v.enableHygiene && job.Command != "" {
-			hygieneIssues := v.validateCommandHygiene(job)
-			result.Issues = append(result.Issues, hygieneIssues...)
-		}
+		entries = append(entries, &crontab.Entry{
+			Type:       crontab.EntryTypeJob,
+			LineNumber: job.LineNumber,
+			Job:        job,
+		})
 	}
+	return v.validateAll(entries)
+}
 
-	// Overlap analysis (if enabled) - only for multiple jobs
-	if v.warnOnOverlap && len(jobs) > 1 {
-		// Convert jobs to entries for overlap validation
-		entries := make([]*crontab.Entry, 0, len(jobs))
-		for _, job := range jobs {
-			entries = append(entries, &crontab.Entry{
-				Type:       crontab.EntryTypeJob,
-				LineNumber: job.LineNumber,
-				Job:        job,
-			})
+// validateConsolidation suggests merging jobs that run the same command
+func (v *Validator) validateConsolidation(entries []*crontab.Entry) []Issue {
+	jobs := make([]*crontab.Job, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
 		}
-		overlapIssues := v.validateOverlaps(entries)
-		result.Issues = append(result.Issues, overlapIssues...)
 	}
-
-	return result
+	return AnalyzeConsolidation(jobs, v.parser)
 }
 
 // validateOverlaps performs overlap analysis on a set of job entries
@@ -534,7 +703,26 @@ func min(a, b int) int {
 
 // validateCommandHygiene performs command hygiene analysis
 func (v *Validator) validateCommandHygiene(job *crontab.Job) []Issue {
-	issues := AnalyzeCommand(job.Command)
+	command := job.Command
+	if v.environment != nil {
+		command = job.ExpandedCommand(v.environment)
+	}
+	issues := AnalyzeCommand(command)
+
+	// With MAILTO empty, output that is not redirected is lost rather than mailed
+	if mailto, ok := job.Mailto(); ok && mailto == "" {
+		for i := range issues {
+			if issues[i].Code == CodeMissingRedirection {
+				issues[i] = Issue{
+					Severity: GetCodeSeverity(CodeOutputDiscarded),
+					Code:     CodeOutputDiscarded,
+					Message:  "Command output is discarded (MAILTO is empty and output is not redirected)",
+					Hint:     GetCodeHint(CodeOutputDiscarded),
+				}
+			}
+		}
+	}
+
 	// Set line number and expression for all issues
 	for i := range issues {
 		issues[i].LineNumber = job.LineNumber
@@ -581,21 +769,3 @@ func detectDOMDOWConflict(schedule *cronx.Schedule) bool {
 	// Both DOM and DOW are specified (not wildcards)
 	return !schedule.DayOfMonth.IsEvery() && !schedule.DayOfWeek.IsEvery()
 }
-
-// detectEmptySchedule checks if a schedule never runs
-func detectEmptySchedule(expression string, scheduler cronx.Scheduler) bool {
-	now := time.Now()
-	future := now.AddDate(2, 0, 0) // Check 2 years ahead
-
-	times, err := scheduler.Next(expression, now, 1)
-	if err != nil {
-		return true // Invalid = empty
-	}
-
-	// If no times found or first time is beyond our check window
-	if len(times) == 0 || times[0].After(future) {
-		return true
-	}
-
-	return false
-}
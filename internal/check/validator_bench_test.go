@@ -1,6 +1,7 @@
 package check
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -62,3 +63,19 @@ func BenchmarkValidateCrontab_Medium(b *testing.B) {
 		_ = validator.ValidateCrontab(reader, tmpfile.Name())
 	}
 }
+
+func BenchmarkIncrementalValidate_LargeSingleEdit(b *testing.B) {
+	lines := make([]string, 5000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%d %d * * * /usr/bin/job-%d.sh", i%60, (i/60)%24, i)
+	}
+	iv := NewIncrementalValidator(NewValidator("en"))
+	_ = iv.Validate(lines)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Simulate a single-line edit between saves
+		lines[i%len(lines)] = fmt.Sprintf("%d 3 * * * /usr/bin/edited-%d.sh", i%60, i)
+		_ = iv.Validate(lines)
+	}
+}
@@ -1,8 +1,11 @@
 package check
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,6 +45,50 @@ func TestSetWarnOnOverlap(t *testing.T) {
 	assert.True(t, validator.warnOnOverlap)
 }
 
+func TestSetConsolidationChecks(t *testing.T) {
+	validator := NewValidator("en")
+	validator.SetConsolidationChecks(true)
+	assert.True(t, validator.consolidation)
+
+	validator.SetConsolidationChecks(false)
+	assert.False(t, validator.consolidation)
+}
+
+func TestValidator_Consolidation(t *testing.T) {
+	lines := []string{
+		"0 2 * * * /usr/bin/cleanup.sh",
+		"0 14 * * * /usr/bin/cleanup.sh",
+	}
+	entries := make([]*crontab.Entry, 0, len(lines))
+	for i, line := range lines {
+		entries = append(entries, crontab.ParseLine(line, i+1))
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result := NewValidator("en").ValidateEntries(entries)
+		for _, issue := range result.Issues {
+			assert.NotEqual(t, CodeConsolidationCandidate, issue.Code)
+		}
+	})
+
+	t.Run("enabled reports info issue without failing validation", func(t *testing.T) {
+		validator := NewValidator("en")
+		validator.SetConsolidationChecks(true)
+		result := validator.ValidateEntries(entries)
+		assert.True(t, result.Valid)
+
+		var found *Issue
+		for i := range result.Issues {
+			if result.Issues[i].Code == CodeConsolidationCandidate {
+				found = &result.Issues[i]
+			}
+		}
+		require.NotNil(t, found)
+		assert.Equal(t, SeverityInfo, found.Severity)
+		assert.Contains(t, found.Message, "0 2,14 * * *")
+	})
+}
+
 func TestSetOverlapWindow(t *testing.T) {
 	validator := NewValidator("en")
 	window := 48 * time.Hour
@@ -243,33 +290,34 @@ func TestDetectDOMDOWConflict(t *testing.T) {
 	}
 }
 
-func TestDetectEmptySchedule(t *testing.T) {
+func TestFirstRun(t *testing.T) {
 	scheduler := cronx.NewScheduler()
+	now := time.Now()
 
-	t.Run("valid schedule should not be empty", func(t *testing.T) {
-		result := detectEmptySchedule("0 0 * * *", scheduler)
-		assert.False(t, result, "Daily schedule should not be empty")
+	t.Run("valid schedule runs", func(t *testing.T) {
+		_, ok := firstRun("0 0 * * *", scheduler, now)
+		assert.True(t, ok, "Daily schedule should run")
 	})
 
-	t.Run("invalid expression should be empty", func(t *testing.T) {
-		result := detectEmptySchedule("invalid", scheduler)
-		assert.True(t, result, "Invalid expression should be detected as empty")
+	t.Run("invalid expression never runs", func(t *testing.T) {
+		_, ok := firstRun("invalid", scheduler, now)
+		assert.False(t, ok, "Invalid expression should be detected as empty")
 	})
 
-	t.Run("expression that runs should not be empty", func(t *testing.T) {
-		result := detectEmptySchedule("*/15 * * * *", scheduler)
-		assert.False(t, result, "Every 15 minutes should not be empty")
+	t.Run("expression that runs", func(t *testing.T) {
+		next, ok := firstRun("*/15 * * * *", scheduler, now)
+		assert.True(t, ok, "Every 15 minutes should run")
+		assert.True(t, next.After(now))
 	})
 
-	t.Run("very far future schedule", func(t *testing.T) {
-		// This is a valid expression that runs, so should not be empty
-		result := detectEmptySchedule("0 0 1 1 *", scheduler)
-		assert.False(t, result, "Yearly schedule should not be empty")
+	t.Run("yearly schedule runs", func(t *testing.T) {
+		_, ok := firstRun("0 0 1 1 *", scheduler, now)
+		assert.True(t, ok, "Yearly schedule should run")
 	})
 
-	t.Run("complex valid expression", func(t *testing.T) {
-		result := detectEmptySchedule("*/30 * * * *", scheduler)
-		assert.False(t, result, "Every 30 minutes should not be empty")
+	t.Run("impossible date never runs", func(t *testing.T) {
+		_, ok := firstRun("0 0 30 2 *", scheduler, now)
+		assert.False(t, ok, "February 30th should never run")
 	})
 }
 
@@ -937,9 +985,32 @@ func (m *mockReader) ParseStdin() ([]*crontab.Entry, error) {
 	return m.entries, nil
 }
 
+func (m *mockReader) FileEntries(ctx context.Context, path string) iter.Seq2[*crontab.Entry, error] {
+	return m.entrySeq()
+}
+
+func (m *mockReader) StdinEntries(ctx context.Context) iter.Seq2[*crontab.Entry, error] {
+	return m.entrySeq()
+}
+
+func (m *mockReader) entrySeq() iter.Seq2[*crontab.Entry, error] {
+	return func(yield func(*crontab.Entry, error) bool) {
+		if m.err != nil {
+			yield(nil, m.err)
+			return
+		}
+		for _, entry := range m.entries {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
 type mockScheduler struct {
-	returnEmpty bool
-	returnError bool
+	returnEmpty   bool
+	returnDistant bool
+	returnError   bool
 }
 
 func (m *mockScheduler) Next(expression string, from time.Time, count int) ([]time.Time, error) {
@@ -947,13 +1018,46 @@ func (m *mockScheduler) Next(expression string, from time.Time, count int) ([]ti
 		return nil, &mockError{msg: "mock error"}
 	}
 	if m.returnEmpty {
-		// Return a time far in the future to simulate empty schedule
+		// Return the zero time, as robfig/cron does for schedules that never run
+		return []time.Time{{}}, nil
+	}
+	if m.returnDistant {
+		// Return a time beyond the default 2-year horizon
 		return []time.Time{from.AddDate(3, 0, 0)}, nil
 	}
 	// Return a normal time
 	return []time.Time{from.Add(time.Hour)}, nil
 }
 
+func (m *mockScheduler) NextContext(ctx context.Context, expression string, from time.Time, count int) ([]time.Time, error) {
+	return m.Next(expression, from, count)
+}
+
+func (m *mockScheduler) Prev(expression string, from time.Time, count int) ([]time.Time, error) {
+	if m.returnError {
+		return nil, &mockError{msg: "mock error"}
+	}
+	return []time.Time{from.Add(-time.Hour)}, nil
+}
+
+func (m *mockScheduler) Between(expression string, from, to time.Time) ([]time.Time, error) {
+	if m.returnError {
+		return nil, &mockError{msg: "mock error"}
+	}
+	return []time.Time{from.Add(time.Hour)}, nil
+}
+
+func (m *mockScheduler) BetweenContext(ctx context.Context, expression string, from, to time.Time) ([]time.Time, error) {
+	return m.Between(expression, from, to)
+}
+
+func (m *mockScheduler) Matches(expression string, at time.Time) (bool, error) {
+	if m.returnError {
+		return false, &mockError{msg: "mock error"}
+	}
+	return !m.returnEmpty, nil
+}
+
 type mockError struct {
 	msg string
 }
@@ -1220,6 +1324,26 @@ func TestValidator_ValidateEntries(t *testing.T) {
 		assert.Equal(t, 1, result.Issues[0].LineNumber)
 	})
 
+	t.Run("should suggest corrections of invalid expressions", func(t *testing.T) {
+		entries := []*crontab.Entry{
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 1,
+				Job: &crontab.Job{
+					LineNumber: 1,
+					Expression: "0 24 * * *",
+					Command:    "/usr/bin/backup.sh",
+					Valid:      false,
+					Error:      "value out of range",
+				},
+			},
+		}
+		result := validator.ValidateEntries(entries)
+		require.Len(t, result.Issues, 1)
+		require.NotEmpty(t, result.Issues[0].Suggestions)
+		assert.Equal(t, "0 0 * * *", result.Issues[0].Suggestions[0].Expression)
+	})
+
 	t.Run("should detect DOM/DOW conflicts", func(t *testing.T) {
 		entries := []*crontab.Entry{
 			{
@@ -1465,6 +1589,101 @@ func TestValidator_ValidateEntries_Comprehensive(t *testing.T) {
 	})
 }
 
+func TestValidator_StreamEntries(t *testing.T) {
+	validator := NewValidator("en")
+	validator.SetWarnOnOverlap(true)
+	validator.SetOverlapWindow(24 * time.Hour)
+
+	entries, err := crontab.ParseReader(strings.NewReader("0 * * * * /usr/bin/job1.sh\n" +
+		"0 * * * * /usr/bin/job2.sh\n" +
+		"0 0 1 * 1 /usr/bin/report.sh\n" +
+		"60 * * * * /usr/bin/broken.sh\n"))
+	require.NoError(t, err)
+
+	t.Run("emits the issues ValidateEntries collects, in order", func(t *testing.T) {
+		var issues []Issue
+		result, err := validator.StreamEntries(entries, func(issue Issue) error {
+			issues = append(issues, issue)
+			return nil
+		})
+		require.NoError(t, err)
+
+		expected := validator.ValidateEntries(entries)
+		assert.Equal(t, expected.Issues, issues)
+		assert.Empty(t, result.Issues)
+		assert.False(t, result.Valid)
+		assert.Equal(t, 4, result.TotalJobs)
+		assert.Equal(t, 3, result.ValidJobs)
+		assert.Equal(t, 1, result.InvalidJobs)
+	})
+
+	t.Run("stops at the first error of emit", func(t *testing.T) {
+		calls := 0
+		_, err := validator.StreamEntries(entries, func(Issue) error {
+			calls++
+			return fmt.Errorf("closed pipe")
+		})
+		require.EqualError(t, err, "closed pipe")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestValidator_ValidateStream(t *testing.T) {
+	content := "0 * * * * /usr/bin/job1.sh\n" +
+		"# cronkit:ignore CRON-001\n" +
+		"0 0 1 * 1 /usr/bin/report.sh\n" +
+		"0 * * * * /usr/bin/job2.sh\n" +
+		"60 * * * * /usr/bin/broken.sh\n"
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+
+	t.Run("emits the issues StreamEntries emits", func(t *testing.T) {
+		validator := NewValidator("en")
+		validator.SetWarnOnOverlap(true)
+		validator.SetOverlapWindow(24 * time.Hour)
+
+		var expected, issues []Issue
+		want, err := validator.StreamEntries(entries, func(issue Issue) error {
+			expected = append(expected, issue)
+			return nil
+		})
+		require.NoError(t, err)
+		result, err := validator.ValidateStream(crontab.Entries(context.Background(), strings.NewReader(content), crontab.LayoutUser), func(issue Issue) error {
+			issues = append(issues, issue)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, issues)
+		assert.Equal(t, 1, result.Suppressed)
+		assert.Empty(t, result.Jobs)
+		want.Jobs = nil
+		assert.Equal(t, want, result)
+	})
+
+	t.Run("stops when the context ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		validator := NewValidator("en")
+		validator.SetContext(ctx)
+		cause := fmt.Errorf("timed out")
+		cancel(cause)
+
+		result, err := validator.ValidateStream(crontab.EntrySeq(entries), func(Issue) error { return nil })
+		assert.ErrorIs(t, err, cause)
+		assert.Zero(t, result.TotalJobs)
+	})
+
+	t.Run("stops at a read error", func(t *testing.T) {
+		reader := &mockReader{err: assert.AnError}
+		result, err := NewValidator("en").ValidateStream(reader.FileEntries(context.Background(), "crontab"), func(Issue) error {
+			t.Fatal("no issue expected")
+			return nil
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Zero(t, result.TotalJobs)
+	})
+}
+
 func TestValidator_ValidateEntries_ParseErrorPath(t *testing.T) {
 	// This test specifically targets the parse error path in ValidateEntries
 	t.Run("should handle parse error when Valid is true", func(t *testing.T) {
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/audit"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/spf13/cobra"
+)
+
+type AuditCommand struct {
+	*cobra.Command
+	logs      []string
+	file      string
+	system    bool
+	stdin     bool
+	since     time.Duration
+	tolerance time.Duration
+	timezone  string
+	json      bool
+}
+
+func newAuditCommand() *AuditCommand {
+	ac := &AuditCommand{}
+	ac.Command = &cobra.Command{
+		Use:   "audit",
+		Short: "Find missed and unexpected job runs in the cron daemon's log",
+		Long: `Compare the job starts logged by the cron daemon with the runs the crontab
+schedules, and report:
+  - missed runs:     a job was due but the log shows no start within
+                     --tolerance of its scheduled minute
+  - unexpected runs: the log shows a start at a time the job is not
+                     scheduled, or of a command that is no job of the crontab
+
+The log is read from --log, which may be given several times, e.g. for
+rotated logs; files ending in .gz are decompressed. Job starts are the
+"CRON[pid]: (user) CMD (command)" lines of Vixie cron and Debian's cron, and
+the "CROND[pid]" lines of cronie, with traditional syslog or RFC 3339
+timestamps. Syslog timestamps have no year and are taken to be within the
+last year. Timestamps without a time zone are read in --timezone.
+
+The audited window is the time span of the log, or its last --since. @reboot
+jobs are not audited.
+
+Exit codes: 0 when every run happened as scheduled, 2 when a run was missed
+or unexpected, 1 on errors.
+
+Examples:
+  cronkit audit --log /var/log/syslog
+  cronkit audit --log /var/log/cron --file /etc/crontab --system
+  cronkit audit --log /var/log/syslog.1 --log /var/log/syslog --since 24h --json`,
+		Args: cobra.NoArgs,
+		RunE: ac.runAudit,
+	}
+
+	ac.Flags().StringArrayVar(&ac.logs, "log", nil, "Cron daemon log file, e.g. /var/log/syslog (required, repeatable)")
+	ac.Flags().StringVarP(&ac.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	ac.Flags().BoolVar(&ac.system, "system", false, systemUsage)
+	ac.Flags().BoolVar(&ac.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
+	ac.Flags().DurationVar(&ac.since, "since", 0, "Only audit the last duration of the log (0 audits the whole log)")
+	ac.Flags().DurationVar(&ac.tolerance, "tolerance", audit.DefaultTolerance, "How long after its scheduled minute a job may start")
+	ac.Flags().StringVar(&ac.timezone, "timezone", "", "Timezone of log timestamps and of jobs without CRON_TZ= (default: local timezone)")
+	ac.Flags().BoolVarP(&ac.json, "json", "j", false, "Output in JSON format")
+	_ = ac.MarkFlagRequired("log")
+	return ac
+}
+
+func init() {
+	rootCmd.AddCommand(newAuditCommand().Command)
+}
+
+func (ac *AuditCommand) runAudit(_ *cobra.Command, _ []string) error {
+	if ac.since < 0 {
+		return fmt.Errorf("invalid --since value: must not be negative")
+	}
+	if ac.tolerance <= 0 {
+		return fmt.Errorf("invalid --tolerance value: must be positive")
+	}
+	loc := time.Local
+	if ac.timezone != "" {
+		parsed, err := time.LoadLocation(ac.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		loc = parsed
+	}
+
+	log, err := ac.readLogs(loc, time.Now())
+	if err != nil {
+		return err
+	}
+	if log.First.IsZero() {
+		return fmt.Errorf("no timestamped lines in %s", strings.Join(ac.logs, ", "))
+	}
+	jobs, source, err := ac.readJobs()
+	if err != nil {
+		return err
+	}
+
+	opts := audit.Options{
+		Tolerance: ac.tolerance,
+		Location:  loc,
+		Scheduler: cronx.NewSchedulerWithSeconds(cronx.SecondsNone),
+	}
+	if ac.since > 0 {
+		opts.From = log.Last.Add(-ac.since)
+	}
+	report, err := audit.Audit(jobs, log, opts)
+	if err != nil {
+		return err
+	}
+
+	if ac.json {
+		err = ac.outputJSON(report, source)
+	} else {
+		ac.outputText(report, source)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Missed > 0 || report.Unexpected > 0 {
+		osExit(2)
+	}
+	return nil
+}
+
+// readLogs parses the --log files into one log
+func (ac *AuditCommand) readLogs(loc *time.Location, now time.Time) (*audit.Log, error) {
+	combined := &audit.Log{}
+	for _, path := range ac.logs {
+		log, err := readLog(path, loc, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, exec := range log.Executions {
+			exec.File = path
+			combined.Executions = append(combined.Executions, exec)
+		}
+		if combined.First.IsZero() || log.First.Before(combined.First) {
+			combined.First = log.First
+		}
+		if log.Last.After(combined.Last) {
+			combined.Last = log.Last
+		}
+	}
+	return combined, nil
+}
+
+// readLog parses a cron daemon log file, decompressing .gz files
+func readLog(path string, loc *time.Location, now time.Time) (*audit.Log, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress log %s: %w", path, err)
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+	log, err := audit.ParseLog(r, loc, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return log, nil
+}
+
+// readJobs reads the crontab to audit. Priority: --file > --stdin > user crontab
+func (ac *AuditCommand) readJobs() ([]*crontab.Job, string, error) {
+	reader := newCrontabReader(ac.system)
+	switch {
+	case ac.file != "":
+		jobs, err := reader.ReadFile(ac.file)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read crontab file %s: %w", ac.file, err)
+		}
+		return jobs, ac.file, nil
+	case ac.stdin || isStdinAvailable():
+		jobs, err := reader.ReadStdin()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		return jobs, "stdin", nil
+	default:
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		return jobs, "user", nil
+	}
+}
+
+// logPosition names the log line of an execution
+func (ac *AuditCommand) logPosition(exec audit.Execution) string {
+	if len(ac.logs) > 1 {
+		return fmt.Sprintf("%s line %d", exec.File, exec.Line)
+	}
+	return fmt.Sprintf("log line %d", exec.Line)
+}
+
+func (ac *AuditCommand) outputText(report *audit.Report, source string) {
+	ac.Printf("Audit of %s against %s\n", strings.Join(ac.logs, ", "), source)
+	ac.Printf("Window: %s to %s, %d run(s) expected\n", report.From.Format(time.RFC3339), report.To.Format(time.RFC3339), report.Expected)
+	if report.Missed == 0 && report.Unexpected == 0 {
+		ac.Println("✓ Every job ran as scheduled")
+		return
+	}
+
+	for _, jr := range report.Jobs {
+		if len(jr.Missed) == 0 && len(jr.Unexpected) == 0 {
+			continue
+		}
+		ac.Printf("\nLine %d: %s %s\n", jr.Job.LineNumber, jr.Job.Expression, jr.Job.Command)
+		ac.Printf("  %d of %d expected run(s) logged\n", jr.Ran, jr.Expected)
+		for _, missed := range jr.Missed {
+			ac.Printf("  ✗ Missed run scheduled %s\n", missed.Format(time.RFC3339))
+		}
+		for _, exec := range jr.Unexpected {
+			ac.Printf("  ⚠ Unexpected run at %s (%s)\n", exec.Time.Format(time.RFC3339), ac.logPosition(exec))
+		}
+	}
+	if len(report.Unknown) > 0 {
+		ac.Printf("\nNot in %s:\n", source)
+		for _, exec := range report.Unknown {
+			ac.Printf("  ⚠ %s (%s) %s (%s)\n", exec.Time.Format(time.RFC3339), exec.User, exec.Command, ac.logPosition(exec))
+		}
+	}
+	ac.Printf("\nSummary: %d missed, %d unexpected run(s)\n", report.Missed, report.Unexpected)
+}
+
+// AuditJobJSON is a job in the JSON output of the audit command
+type AuditJobJSON struct {
+	LineNumber int                  `json:"lineNumber"`
+	Expression string               `json:"expression"`
+	Command    string               `json:"command"`
+	User       string               `json:"user,omitempty"`
+	Expected   int                  `json:"expected"`
+	Ran        int                  `json:"ran"`
+	Missed     []string             `json:"missed"`
+	Unexpected []AuditExecutionJSON `json:"unexpected"`
+}
+
+// AuditExecutionJSON is a logged job start in the JSON output of the audit
+// command
+type AuditExecutionJSON struct {
+	Time    string `json:"time"`
+	User    string `json:"user"`
+	Command string `json:"command"`
+	Log     string `json:"log"`
+	LogLine int    `json:"logLine"`
+}
+
+func newAuditExecutionsJSON(execs []audit.Execution) []AuditExecutionJSON {
+	result := make([]AuditExecutionJSON, 0, len(execs))
+	for _, exec := range execs {
+		result = append(result, AuditExecutionJSON{
+			Time:    exec.Time.Format(time.RFC3339),
+			User:    exec.User,
+			Command: exec.Command,
+			Log:     exec.File,
+			LogLine: exec.Line,
+		})
+	}
+	return result
+}
+
+func (ac *AuditCommand) outputJSON(report *audit.Report, source string) error {
+	jobs := make([]AuditJobJSON, 0, len(report.Jobs))
+	for _, jr := range report.Jobs {
+		missed := make([]string, 0, len(jr.Missed))
+		for _, t := range jr.Missed {
+			missed = append(missed, t.Format(time.RFC3339))
+		}
+		jobs = append(jobs, AuditJobJSON{
+			LineNumber: jr.Job.LineNumber,
+			Expression: jr.Job.Expression,
+			Command:    jr.Job.Command,
+			User:       jr.Job.User,
+			Expected:   jr.Expected,
+			Ran:        jr.Ran,
+			Missed:     missed,
+			Unexpected: newAuditExecutionsJSON(jr.Unexpected),
+		})
+	}
+
+	result := map[string]interface{}{
+		"source":           source,
+		"logs":             ac.logs,
+		"from":             report.From.Format(time.RFC3339),
+		"to":               report.To.Format(time.RFC3339),
+		"toleranceSeconds": ac.tolerance.Seconds(),
+		"expected":         report.Expected,
+		"missed":           report.Missed,
+		"unexpected":       report.Unexpected,
+		"jobs":             jobs,
+		"unknown":          newAuditExecutionsJSON(report.Unknown),
+	}
+
+	encoder := json.NewEncoder(ac.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditCommand(t *testing.T) {
+	path := createTempFile(t, "0 * * * * /usr/bin/hourly.sh\n@reboot /usr/bin/start.sh\n")
+	const onTime = "2026-10-16T00:00:00Z host systemd[1]: Started\n" +
+		"2026-10-16T00:00:01Z host CRON[10]: (app) CMD (/usr/bin/hourly.sh)\n" +
+		"2026-10-16T01:00:02Z host CRON[11]: (app) CMD (/usr/bin/hourly.sh)\n" +
+		"2026-10-16T01:03:00Z host CRON[12]: (app) CMD (/usr/bin/start.sh)\n" +
+		"2026-10-16T02:00:01Z host CRON[13]: (app) CMD (/usr/bin/hourly.sh)\n" +
+		"2026-10-16T02:30:00Z host kernel: eth0 up\n"
+
+	run := func(t *testing.T, args ...string) (string, int, error) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		ac := newAuditCommand()
+		buf := new(bytes.Buffer)
+		ac.SetOut(buf)
+		ac.SetErr(new(bytes.Buffer))
+		ac.SetArgs(args)
+		err := ac.Execute()
+		return buf.String(), exitCode, err
+	}
+
+	t.Run("every job ran", func(t *testing.T) {
+		log := createTempFile(t, onTime)
+		output, exitCode, err := run(t, "--log", log, "--file", path, "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, output, "3 run(s) expected")
+		assert.Contains(t, output, "Every job ran as scheduled")
+	})
+
+	t.Run("missed and unexpected runs", func(t *testing.T) {
+		log := createTempFile(t, "2026-10-16T00:00:01Z host CRON[10]: (app) CMD (/usr/bin/hourly.sh)\n"+
+			"2026-10-16T01:20:00Z host CRON[11]: (app) CMD (/usr/bin/hourly.sh)\n"+
+			"2026-10-16T01:40:00Z host CRON[12]: (app) CMD (/usr/bin/other.sh)\n"+
+			"2026-10-16T02:30:00Z host kernel: eth0 up\n")
+		output, exitCode, err := run(t, "--log", log, "--file", path, "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+		assert.Contains(t, output, "Line 1: 0 * * * * /usr/bin/hourly.sh")
+		assert.Contains(t, output, "1 of 3 expected run(s) logged")
+		assert.Contains(t, output, "✗ Missed run scheduled 2026-10-16T01:00:00Z")
+		assert.Contains(t, output, "⚠ Unexpected run at 2026-10-16T01:20:00Z (log line 2)")
+		assert.Contains(t, output, "(app) /usr/bin/other.sh (log line 3)")
+		assert.Contains(t, output, "Summary: 2 missed, 2 unexpected run(s)")
+	})
+
+	t.Run("since limits the window", func(t *testing.T) {
+		log := createTempFile(t, "2026-10-16T00:00:00Z host systemd[1]: Started\n"+
+			"2026-10-16T02:00:01Z host CRON[13]: (app) CMD (/usr/bin/hourly.sh)\n"+
+			"2026-10-16T02:30:00Z host kernel: eth0 up\n")
+		_, exitCode, err := run(t, "--log", log, "--file", path, "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+
+		_, exitCode, err = run(t, "--log", log, "--file", path, "--timezone", "UTC", "--since", "1h")
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("rotated and compressed logs", func(t *testing.T) {
+		rotated := filepath.Join(t.TempDir(), "syslog.1.gz")
+		f, err := os.Create(rotated)
+		require.NoError(t, err)
+		gz := gzip.NewWriter(f)
+		_, err = gz.Write([]byte("2026-10-15T23:00:01Z host CRON[9]: (app) CMD (/usr/bin/hourly.sh)\n"))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		require.NoError(t, f.Close())
+
+		output, exitCode, err := run(t, "--log", rotated, "--log", createTempFile(t, onTime), "--file", path, "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, output, "4 run(s) expected")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		log := createTempFile(t, "2026-10-16T00:00:01Z host CRON[10]: (app) CMD (/usr/bin/hourly.sh)\n"+
+			"2026-10-16T01:40:00Z host CRON[12]: (app) CMD (/usr/bin/other.sh)\n"+
+			"2026-10-16T02:30:00Z host kernel: eth0 up\n")
+		output, exitCode, err := run(t, "--log", log, "--file", path, "--timezone", "UTC", "--json")
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+
+		var result struct {
+			From       string               `json:"from"`
+			To         string               `json:"to"`
+			Missed     int                  `json:"missed"`
+			Unexpected int                  `json:"unexpected"`
+			Jobs       []AuditJobJSON       `json:"jobs"`
+			Unknown    []AuditExecutionJSON `json:"unknown"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, "2026-10-16T00:00:01Z", result.From)
+		assert.Equal(t, "2026-10-16T02:30:00Z", result.To)
+		assert.Equal(t, 2, result.Missed)
+		assert.Equal(t, 1, result.Unexpected)
+		require.Len(t, result.Jobs, 1)
+		assert.Equal(t, []string{"2026-10-16T01:00:00Z", "2026-10-16T02:00:00Z"}, result.Jobs[0].Missed)
+		assert.Empty(t, result.Jobs[0].Unexpected)
+		require.Len(t, result.Unknown, 1)
+		assert.Equal(t, log, result.Unknown[0].Log)
+		assert.Equal(t, 2, result.Unknown[0].LogLine)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := run(t, "--file", path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"log" not set`)
+
+		_, _, err = run(t, "--log", createTempFile(t, "no timestamps\n"), "--file", path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no timestamped lines")
+
+		_, _, err = run(t, "--log", filepath.Join(t.TempDir(), "missing"), "--file", path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open log")
+
+		_, _, err = run(t, "--log", createTempFile(t, onTime), "--file", path, "--tolerance", "0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--tolerance")
+	})
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// batchError is the NDJSON line of an expression of a batch that failed
+type batchError struct {
+	Line       int    `json:"line"`
+	Expression string `json:"expression"`
+	Error      string `json:"error"`
+}
+
+// eachExpression calls fn with every expression read from r, one per line,
+// with its line number; fn writes the result of the expression. Blank lines
+// and # comments are skipped. When fn fails, the error takes the place of the
+// result, written to out as a batchError when it is set, else as an
+// "error: ..." line, and the batch goes on; once all are read, the process
+// exits with status 1 if any failed. eachExpression returns an error if
+// reading fails or the command's context is cancelled.
+func eachExpression(cmd *cobra.Command, r io.Reader, out *ndjsonWriter, fn func(line int, expression string) error) error {
+	scanner := bufio.NewScanner(r)
+	var line, total, failed int
+	for scanner.Scan() {
+		line++
+		expression := strings.TrimSpace(scanner.Text())
+		if expression == "" || strings.HasPrefix(expression, "#") {
+			continue
+		}
+		if err := contextErr(cmd); err != nil {
+			return err
+		}
+
+		total++
+		err := fn(line, expression)
+		if err == nil {
+			continue
+		}
+		failed++
+		if out == nil {
+			cmd.Printf("error: %v\n", err)
+		} else if err := out.Write(batchError{Line: line, Expression: expression, Error: err.Error()}); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read expressions from stdin: %w", err)
+	}
+	if failed > 0 {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%d of %d expression(s) failed\n", failed, total)
+		osExit(1)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 type BudgetCommand struct {
 	*cobra.Command
 	file          string
+	system        bool
 	stdin         bool
 	maxConcurrent int
 	window        string
@@ -40,6 +41,7 @@ Examples:
 	}
 
 	bc.Flags().StringVarP(&bc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	bc.Flags().BoolVar(&bc.system, "system", false, systemUsage)
 	bc.Flags().BoolVar(&bc.stdin, "stdin", false, "Read crontab from standard input")
 	bc.Flags().IntVar(&bc.maxConcurrent, "max-concurrent", 0, "Maximum concurrent jobs allowed (required)")
 	bc.Flags().StringVar(&bc.window, "window", "", "Time window for budget (e.g., 1m, 1h, 24h) (required)")
@@ -70,7 +72,7 @@ func (bc *BudgetCommand) runBudget(_ *cobra.Command, args []string) error {
 	}
 
 	// Read crontab
-	reader := crontab.NewReader()
+	reader := newCrontabReader(bc.system)
 	var jobs []*crontab.Job
 
 	if bc.stdin {
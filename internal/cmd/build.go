@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/builder"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// DefaultBuildPreviewCount is the number of upcoming runs the builder previews
+const DefaultBuildPreviewCount = 5
+
+type BuildCommand struct {
+	*cobra.Command
+	count int
+}
+
+func newBuildCommand() *BuildCommand {
+	bc := &BuildCommand{}
+	bc.Command = &cobra.Command{
+		Use:   "build [cron-expression]",
+		Short: "Compose a cron expression interactively",
+		Long: `Compose a cron expression field by field in an interactive terminal UI.
+
+The description of the expression and its next runs are updated as you edit.
+Start from an existing expression by passing it as an argument.
+
+Keys:
+  ←/→, tab, space   Move between fields
+  ↑/↓               Cycle through common values for the field
+  0-9, * , - / ...  Type a value (replaces the field when you enter it)
+  backspace         Delete the last character
+  enter             Accept the expression (only when it is valid)
+  esc, ctrl-c       Cancel
+
+The UI is drawn on standard error and the accepted expression is printed on
+standard output, so it can be captured:
+
+Examples:
+  cronkit build
+  cronkit build "0 9 * * 1-5"
+  expr=$(cronkit build) && cronkit next "$expr"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: bc.runBuild,
+	}
+
+	bc.Flags().IntVarP(&bc.count, "count", "c", DefaultBuildPreviewCount, "Number of upcoming runs to preview (1-100)")
+	return bc
+}
+
+func init() {
+	rootCmd.AddCommand(newBuildCommand().Command)
+}
+
+func (bc *BuildCommand) runBuild(_ *cobra.Command, args []string) error {
+	if bc.count < MinNextCount || bc.count > MaxNextCount {
+		return fmt.Errorf("count must be between %d and %d", MinNextCount, MaxNextCount)
+	}
+
+	expression := ""
+	if len(args) > 0 {
+		expression = args[0]
+	}
+	model, err := builder.NewModel(expression, bc.count, GetLocale())
+	if err != nil {
+		return fmt.Errorf("invalid starting expression: %w", err)
+	}
+
+	// Keys come from the terminal in raw mode, or from the command's input
+	// stream (for testability)
+	input := bc.InOrStdin()
+	restore := func() {}
+	if input == os.Stdin {
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return fmt.Errorf("build requires an interactive terminal")
+		}
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to enter raw mode: %w", err)
+		}
+		restore = func() {
+			_ = term.Restore(fd, state)
+		}
+	}
+
+	result, err := builder.Run(model, input, bc.ErrOrStderr(), time.Now)
+	restore()
+	// Leave the UI on a fresh line, whatever the outcome
+	_, _ = io.WriteString(bc.ErrOrStderr(), "\n")
+	if errors.Is(err, builder.ErrCanceled) {
+		return fmt.Errorf("no expression built: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	bc.Println(result)
+	return nil
+}
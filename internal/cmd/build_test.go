@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCommand(t *testing.T) {
+	t.Run("build command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"build"})
+		assert.NoError(t, err)
+		assert.Equal(t, "build", cmd.Name())
+	})
+
+	t.Run("prints the accepted expression on stdout", func(t *testing.T) {
+		bc := newBuildCommand()
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		bc.SetOut(stdout)
+		bc.SetErr(stderr)
+		bc.SetIn(strings.NewReader("\t\t\t\t\x1b[B\r"))
+		bc.SetArgs([]string{"0 9 * * *"})
+
+		require.NoError(t, bc.Execute())
+		assert.Equal(t, "0 9 * * 1-5\n", stdout.String())
+		assert.Contains(t, stderr.String(), "Next 5 runs:")
+	})
+
+	t.Run("cancel returns an error", func(t *testing.T) {
+		bc := newBuildCommand()
+		bc.SetOut(new(bytes.Buffer))
+		bc.SetErr(new(bytes.Buffer))
+		bc.SetIn(strings.NewReader("\x1b"))
+		bc.SetArgs([]string{})
+
+		err := bc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no expression built")
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		tests := []struct {
+			name string
+			args []string
+			want string
+		}{
+			{"alias start", []string{"@daily"}, "invalid starting expression"},
+			{"count out of range", []string{"--count", "0"}, "count must be between"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				bc := newBuildCommand()
+				bc.SetOut(new(bytes.Buffer))
+				bc.SetErr(new(bytes.Buffer))
+				bc.SetIn(strings.NewReader(""))
+				bc.SetArgs(tt.args)
+
+				err := bc.Execute()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.want)
+			})
+		}
+	})
+}
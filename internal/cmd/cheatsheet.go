@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/spf13/cobra"
+)
+
+// cheatsheetAllFields is the Fields of tokens allowed in every field
+const cheatsheetAllFields = "all"
+
+// CheatsheetCommand wraps cobra.Command with cheatsheet-specific functionality
+type CheatsheetCommand struct {
+	*cobra.Command
+	dialect    string
+	jenkinsJob string
+	json       bool
+}
+
+// CheatsheetField is a field in the JSON output of the cheatsheet command
+type CheatsheetField struct {
+	Name     string `json:"name"`
+	Min      int    `json:"min"`
+	Max      int    `json:"max"`
+	Names    string `json:"names,omitempty"`
+	Note     string `json:"note,omitempty"`
+	Optional bool   `json:"optional"`
+}
+
+// CheatsheetExample is a special token or alias in the JSON output of the
+// cheatsheet command, with its example described in the selected locale
+type CheatsheetExample struct {
+	Syntax      string `json:"syntax"`
+	Fields      string `json:"fields,omitempty"`
+	Meaning     string `json:"meaning,omitempty"`
+	Example     string `json:"example"`
+	Description string `json:"description"`
+}
+
+// CheatsheetResult represents the complete output for the cheatsheet command
+type CheatsheetResult struct {
+	Dialect     string              `json:"dialect"`
+	Locale      string              `json:"locale"`
+	Description string              `json:"description"`
+	Fields      []CheatsheetField   `json:"fields"`
+	Tokens      []CheatsheetExample `json:"tokens"`
+	Aliases     []CheatsheetExample `json:"aliases"`
+}
+
+func init() {
+	rootCmd.AddCommand(newCheatsheetCommand().Command)
+}
+
+// newCheatsheetCommand creates a fresh cheatsheet command instance
+func newCheatsheetCommand() *CheatsheetCommand {
+	cc := &CheatsheetCommand{}
+	cc.Command = &cobra.Command{
+		Args:  cobra.NoArgs,
+		RunE:  cc.runCheatsheet,
+		Use:   "cheatsheet",
+		Short: "Print a cron syntax reference for a dialect",
+		Long: `Print an offline reference of the cron syntax: the fields and their ranges,
+the special characters and the aliases of the selected dialect, each with an
+example described in the --locale language.
+
+The reference is built from the parser's own description of each dialect,
+and every example is parsed before it is printed, so it always matches what
+the other commands accept.
+
+Examples:
+  cronkit cheatsheet
+  cronkit cheatsheet --dialect quartz
+  cronkit cheatsheet --dialect aws --locale fr
+  cronkit cheatsheet --json`,
+	}
+
+	cc.Flags().StringVar(&cc.dialect, "dialect", "standard", dialectUsage)
+	cc.Flags().StringVar(&cc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+	cc.Flags().BoolVarP(&cc.json, "json", "j", false, "Output in JSON format")
+	return cc
+}
+
+func (cc *CheatsheetCommand) runCheatsheet(_ *cobra.Command, _ []string) error {
+	opts, err := parserOptions(false, cc.dialect, cc.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	result, err := buildCheatsheet(cronx.SyntaxOf(opts.Dialect), cronx.NewParserWithOptions(GetLocale(), opts), newHumanizer())
+	if err != nil {
+		return err
+	}
+
+	if cc.json {
+		encoder := json.NewEncoder(cc.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+	cc.Print(formatCheatsheet(result))
+	return nil
+}
+
+// buildCheatsheet describes a dialect's syntax, humanizing every example
+func buildCheatsheet(syntax cronx.Syntax, parser cronx.Parser, humanizer human.Humanizer) (CheatsheetResult, error) {
+	result := CheatsheetResult{
+		Dialect:     string(syntax.Dialect),
+		Locale:      GetLocale(),
+		Description: syntax.Description,
+		Fields:      make([]CheatsheetField, len(syntax.Fields)),
+		Tokens:      make([]CheatsheetExample, 0, len(syntax.Tokens)),
+		Aliases:     make([]CheatsheetExample, 0, len(syntax.Aliases)),
+	}
+	for i, field := range syntax.Fields {
+		result.Fields[i] = CheatsheetField(field)
+	}
+
+	describe := func(expression string) (string, error) {
+		schedule, err := parser.Parse(expression)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s example %q: %w", syntax.Dialect, expression, err)
+		}
+		return humanizer.Humanize(schedule), nil
+	}
+	for _, token := range syntax.Tokens {
+		description, err := describe(token.Example)
+		if err != nil {
+			return CheatsheetResult{}, err
+		}
+		result.Tokens = append(result.Tokens, CheatsheetExample{
+			Syntax:      token.Syntax,
+			Fields:      token.Fields,
+			Meaning:     token.Description,
+			Example:     token.Example,
+			Description: description,
+		})
+	}
+	for _, alias := range syntax.Aliases {
+		description, err := describe(alias.Name)
+		if err != nil {
+			return CheatsheetResult{}, err
+		}
+		result.Aliases = append(result.Aliases, CheatsheetExample{
+			Syntax:      alias.Name,
+			Example:     alias.Expression,
+			Description: description,
+		})
+	}
+	return result, nil
+}
+
+// formatCheatsheet renders the cheatsheet as text
+func formatCheatsheet(result CheatsheetResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Cron syntax: %s dialect\n%s\n", result.Dialect, result.Description)
+
+	sb.WriteString("\nFields\n")
+	fields := make([]string, len(result.Fields))
+	for i, field := range result.Fields {
+		fields[i] = field.Name
+		if field.Optional {
+			fields[i] = "[" + field.Name + "]"
+		}
+	}
+	width := maxWidth(fields)
+	for i, field := range result.Fields {
+		var details []string
+		if field.Optional {
+			details = append(details, "optional")
+		}
+		if field.Names != "" {
+			details = append(details, field.Names)
+		}
+		if field.Note != "" {
+			details = append(details, field.Note)
+		}
+		line := fmt.Sprintf("  %-*s  %d-%d", width, fields[i], field.Min, field.Max)
+		if len(details) > 0 {
+			line = fmt.Sprintf("%-*s  %s", width+13, line, strings.Join(details, ", "))
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString("\nSpecial characters\n")
+	syntaxes := make([]string, len(result.Tokens))
+	for i, token := range result.Tokens {
+		syntaxes[i] = token.Syntax
+	}
+	width = maxWidth(syntaxes)
+	for _, token := range result.Tokens {
+		meaning := token.Meaning
+		if token.Fields != cheatsheetAllFields {
+			meaning += " (" + token.Fields + ")"
+		}
+		fmt.Fprintf(&sb, "  %-*s  %s\n", width, token.Syntax, meaning)
+		fmt.Fprintf(&sb, "  %-*s  e.g. %s  →  %s\n", width, "", token.Example, token.Description)
+	}
+
+	sb.WriteString("\nAliases\n")
+	if len(result.Aliases) == 0 {
+		sb.WriteString("  none\n")
+		return sb.String()
+	}
+	names := make([]string, len(result.Aliases))
+	expressions := make([]string, len(result.Aliases))
+	for i, alias := range result.Aliases {
+		names[i], expressions[i] = alias.Syntax, alias.Example
+	}
+	nameWidth, expressionWidth := maxWidth(names), maxWidth(expressions)
+	for _, alias := range result.Aliases {
+		fmt.Fprintf(&sb, "  %-*s  %-*s  %s\n", nameWidth, alias.Syntax, expressionWidth, alias.Example, alias.Description)
+	}
+	return sb.String()
+}
+
+// maxWidth returns the length of the longest string
+func maxWidth(values []string) int {
+	width := 0
+	for _, v := range values {
+		if len(v) > width {
+			width = len(v)
+		}
+	}
+	return width
+}
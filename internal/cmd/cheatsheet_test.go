@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheatsheetCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, error) {
+		cc := newCheatsheetCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs(args)
+		err := cc.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("cheatsheet command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"cheatsheet"})
+		assert.NoError(t, err)
+		assert.Equal(t, "cheatsheet", cmd.Name())
+	})
+
+	t.Run("standard dialect", func(t *testing.T) {
+		output, err := run(t)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Cron syntax: standard dialect")
+		assert.Contains(t, output, "  [second]      0-59       optional, leads a 6-field expression\n")
+		assert.Contains(t, output, "  day-of-week   0-6        SUN-SAT, Sunday=0\n")
+		assert.Contains(t, output, "e.g. */15 9-17 * * MON-FRI  →  Every 15 minutes")
+		assert.Contains(t, output, "  @daily     0 0 * * *  At midnight every day\n")
+		assert.NotContains(t, output, "L-n")
+	})
+
+	t.Run("quartz dialect lists the day modifiers", func(t *testing.T) {
+		output, err := run(t, "--dialect", "quartz")
+		require.NoError(t, err)
+		assert.Contains(t, output, "L-n")
+		assert.Contains(t, output, "(day-of-week)")
+		assert.Contains(t, output, "  [year]        1970-2099  optional\n")
+	})
+
+	t.Run("aws dialect has rate and no aliases", func(t *testing.T) {
+		output, err := run(t, "--dialect", "eventbridge")
+		require.NoError(t, err)
+		assert.Contains(t, output, "rate(value unit)")
+		assert.Contains(t, output, "Aliases\n  none\n")
+	})
+
+	t.Run("examples are described in the locale", func(t *testing.T) {
+		oldLocale := locale
+		locale = "fr"
+		defer func() { locale = oldLocale }()
+
+		output, err := run(t)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Toutes les minutes")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		output, err := run(t, "--dialect", "jenkins", "--json")
+		require.NoError(t, err)
+
+		var result CheatsheetResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, "jenkins", result.Dialect)
+		assert.Len(t, result.Fields, 5)
+		assert.Len(t, result.Aliases, 7)
+		for _, token := range result.Tokens {
+			assert.NotEmpty(t, token.Description, token.Syntax)
+		}
+	})
+
+	t.Run("unknown dialect", func(t *testing.T) {
+		_, err := run(t, "--dialect", "vixen")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --dialect value")
+	})
+}
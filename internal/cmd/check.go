@@ -1,20 +1,41 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/color"
 	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/schema"
+	"github.com/hzerrad/cronkit/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
+// Output formats of the check command
+const (
+	checkFormatText   = "text"
+	checkFormatJSON   = "json"
+	checkFormatGitHub = "github"
+	checkFormatGitLab = "gitlab"
+	checkFormatJUnit  = "junit"
+	checkFormatCSV    = tabularFormatCSV
+	checkFormatTSV    = tabularFormatTSV
+)
+
 type CheckCommand struct {
 	*cobra.Command
 	file            string
+	system          bool
 	json            bool
+	ndjson          bool
+	outputSchema    bool
 	verbose         bool
 	failOn          string
 	groupBy         string
@@ -24,10 +45,27 @@ type CheckCommand struct {
 	enableHygiene   bool
 	warnOnOverlap   bool
 	overlapWindow   string
+	consolidate     bool
+	horizon         string
+	seconds         bool
+	dialect         string
+	jenkinsJob      string
+	timezone        string
+	holidays        string
+	expand          bool
+	format          string
+	workflows       string
+	policy          string
+	baseline        string
+	updateBaseline  bool
+	exitCodesFlag   string
+	exitCodes       check.ExitCodes
+	quiet           bool
+	painter         *color.Painter // Colors of text output
 }
 
 func newCheckCommand() *CheckCommand {
-	cc := &CheckCommand{}
+	cc := &CheckCommand{exitCodes: check.DefaultExitCodes}
 	cc.Command = &cobra.Command{
 		Use:   "check [cron-expression]",
 		Short: "Validate cron expressions and crontab files",
@@ -40,28 +78,110 @@ This command checks for:
   - Invalid crontab file structure
   - Redundant patterns (e.g., */1 instead of *)
   - Excessive run counts (configurable threshold)
+  - Jobs running the same command that could be consolidated (--suggest-consolidation)
+  - Runs skipped or repeated by daylight saving time changes (--timezone, or
+    CRON_TZ= in the crontab)
+  - Programs that do not exist once ~ and variables are resolved (--expand)
+  - Violations of a scheduling policy (--policy, or .cronkit.yaml in the
+    current directory)
+  - Jobs tagged business-critical ("# cronkit:tags=business-critical") that
+    run on weekdays only, and so on public holidays too; with --holidays,
+    only those running on one of its holidays in the next year, named in the
+    hint
+
+With --github-workflows, the on.schedule cron entries of GitHub Actions
+workflow files (a file, or every .yml/.yaml file of a directory) are checked
+instead. On top of the checks above, schedules running more often than every
+5 minutes are reported, as GitHub throttles them, and schedules at fixed
+hours are noted (INFO) because GitHub evaluates them in UTC.
+
+A policy file enforces an organization's scheduling rules as errors:
+
+  policy:
+    max-runs-per-day: 96       # CRON-021: runs a job may make in a day
+    min-spacing: 5m            # CRON-022: time between runs of two jobs
+    forbidden-windows:         # CRON-023: times of day no job may run
+      - 00:00-01:00
+    protected-windows:         # CRON-028: named windows, e.g. a deploy freeze
+      - name: deploy freeze
+        window: 09:00-10:00
+        days: mon-fri          # Optional; the hint lists upcoming runs inside
+    require-mailto: true       # CRON-024: jobs must have a MAILTO
+    rules:                     # Rules of your own, reported with their code
+      - code: OPS-001
+        message: curl must fail on HTTP errors
+        severity: warn         # info, warn or error (default)
+        command: '\bcurl\b'    # Applies to jobs whose command matches...
+        require-command: '\s(--fail|-f)\b'  # ...and requires this to match
+
+Issues of a job can be silenced with a comment on the line above it or at
+the end of its line: "# cronkit:ignore CRON-001 CRON-009" ignores those codes,
+"# cronkit:ignore" every code. To adopt check on an existing crontab, record
+its issues once with --baseline FILE --update-baseline; later runs with
+--baseline FILE report, and fail on, new issues only.
+
+The exit code is 0 unless an issue at or above --fail-on is reported; it is
+then 1 for errors and 2 for warnings or info messages, or the codes given with
+--exit-codes (e.g. error=1,warn=0 to never fail on warnings). With --quiet,
+nothing is printed and the exit code is the only result.
+
+With --format csv or tsv, each issue is a row with the columns:
+  severity, code, file, line, expression, message, hint
+
+With --ndjson, each issue is written as a line of JSON, in the format of the
+issues of --json, as soon as its job is checked, so large crontabs can be
+piped to tools such as jq. The exit code follows --fail-on as with --json.
 
 Examples:
   cronkit check "0 0 * * *"              # Validate a single expression
   cronkit check --file /etc/crontab       # Validate a crontab file
   cronkit check                           # Validate user's crontab
   cronkit check "0 0 1 * 1" --verbose    # Show warnings (DOM/DOW conflicts)
-  cronkit check --file sample.cron --json # JSON output`,
-		RunE: cc.runCheck,
-		Args: cobra.MaximumNArgs(1),
+  cronkit check --file sample.cron --json # JSON output
+  cronkit check --file big.cron --ndjson | jq -r .code  # Stream issues
+  cronkit check --file sample.cron --format github  # GitHub Actions annotations
+  cronkit check --file sample.cron --format junit > report.xml  # CI test report
+  cronkit check --file sample.cron --format csv --verbose > issues.csv
+  cronkit check --github-workflows .github/workflows --format github
+  cronkit check "30 2 * * *" --timezone America/New_York --verbose
+  cronkit check --file jobs.cron --policy ops/cron-policy.yaml
+  cronkit check --file jobs.cron --baseline baseline.json --update-baseline
+  cronkit check --file jobs.cron --baseline baseline.json  # New issues only
+  cronkit check --file jobs.cron --quiet --exit-codes error=3 || echo "failed: $?"`,
+		RunE:              cc.runCheck,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeExpression,
 	}
 
 	cc.Flags().StringVarP(&cc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
-	cc.Flags().BoolVarP(&cc.json, "json", "j", false, "Output in JSON format")
+	cc.Flags().BoolVar(&cc.system, "system", false, systemUsage)
+	cc.Flags().BoolVarP(&cc.json, "json", "j", false, "Output in JSON format (same as --format json)")
+	cc.Flags().BoolVar(&cc.ndjson, "ndjson", false, fmt.Sprintf(ndjsonUsage, "issue"))
+	cc.Flags().BoolVar(&cc.outputSchema, "output-schema", false, outputSchemaUsage)
+	cc.Flags().StringVar(&cc.format, "format", checkFormatText, "Output format: 'text', 'json', 'github' (Actions annotations), 'gitlab' (Code Quality report), 'junit' (XML test report), or 'csv'/'tsv' (one row per issue, columns listed above)")
 	cc.Flags().BoolVarP(&cc.verbose, "verbose", "v", false, "Show warnings (DOM/DOW conflicts) as well as errors")
 	cc.Flags().StringVar(&cc.failOn, "fail-on", "error", "Severity level to fail on: 'error' (default), 'warn', or 'info'")
+	cc.Flags().StringVar(&cc.exitCodesFlag, "exit-codes", check.DefaultExitCodes.String(), "Exit code of each severity when it fails the check, e.g. 'error=1,warn=0' (unlisted severities keep their default)")
+	cc.Flags().BoolVarP(&cc.quiet, "quiet", "q", false, "Print nothing; report the result through the exit code only")
 	cc.Flags().StringVar(&cc.groupBy, "group-by", "none", "Group issues by: 'none' (default), 'severity', 'line', or 'job'")
 	cc.Flags().BoolVar(&cc.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
 	cc.Flags().BoolVar(&cc.enableFrequency, "enable-frequency-checks", true, "Enable frequency analysis (redundant patterns, excessive runs)")
 	cc.Flags().IntVar(&cc.maxRunsPerDay, "max-runs-per-day", DefaultMaxRunsPerDay, "Threshold for excessive runs warning (default: 1000)")
-	cc.Flags().BoolVar(&cc.enableHygiene, "enable-hygiene-checks", false, "Enable command hygiene checks (absolute paths, redirections, %, quoting)")
+	cc.Flags().BoolVar(&cc.enableHygiene, "enable-hygiene-checks", false, "Enable command hygiene checks (absolute paths, redirections, %, quoting, interactive programs)")
 	cc.Flags().BoolVar(&cc.warnOnOverlap, "warn-on-overlap", false, "Enable overlap warnings (multiple jobs running simultaneously)")
 	cc.Flags().StringVar(&cc.overlapWindow, "overlap-window", "24h", "Time window for overlap analysis (default: 24h, e.g., 1h, 24h, 48h)")
+	cc.Flags().BoolVar(&cc.consolidate, "suggest-consolidation", false, "Suggest merging jobs that run the same command (INFO issues with a merged expression)")
+	cc.Flags().StringVar(&cc.horizon, "horizon", "2y", "Look-ahead for empty schedule detection; runs further out are reported as INFO (e.g., 90d, 18mo, 2y)")
+	cc.Flags().BoolVar(&cc.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	cc.Flags().StringVar(&cc.dialect, "dialect", "standard", dialectUsage)
+	cc.Flags().StringVar(&cc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+	cc.Flags().BoolVar(&cc.expand, "expand", false, expandUsage+"; warns about programs that do not exist (CRON-016)")
+	cc.Flags().StringVar(&cc.workflows, "github-workflows", "", "Check the on.schedule cron entries of GitHub Actions workflow files (a file, or a directory of .yml/.yaml files)")
+	cc.Flags().StringVar(&cc.policy, "policy", "", "Scheduling policy file to enforce (defaults to "+check.DefaultPolicyFile+" in the current directory, if any)")
+	cc.Flags().StringVar(&cc.baseline, "baseline", "", "Baseline file of accepted issues: only issues not in it are reported and fail the check")
+	cc.Flags().BoolVar(&cc.updateBaseline, "update-baseline", false, "Write the issues found to the --baseline file instead of reporting them")
+	cc.Flags().StringVar(&cc.timezone, "timezone", "", "Warn about runs skipped or repeated by DST transitions in this timezone (e.g., 'America/New_York')")
+	cc.Flags().StringVar(&cc.holidays, "holidays", "", holidaysUsage+"; business-critical weekday jobs are checked against its holidays (CRON-025)")
 
 	return cc
 }
@@ -71,16 +191,85 @@ func init() {
 }
 
 func (cc *CheckCommand) runCheck(_ *cobra.Command, args []string) error {
+	if cc.outputSchema {
+		return writeSchema(cc.OutOrStdout(), "check")
+	}
+
 	// Validate --fail-on flag
 	failOnSeverity, err := check.ParseFailOnLevel(cc.failOn)
 	if err != nil {
 		return fmt.Errorf("invalid --fail-on value: %w", err)
 	}
+	if cc.exitCodes, err = check.ParseExitCodes(cc.exitCodesFlag); err != nil {
+		return fmt.Errorf("invalid --exit-codes value: %w", err)
+	}
+	if cc.quiet {
+		cc.SetOut(io.Discard)
+	}
+
+	format := cc.format
+	if cc.json {
+		format = checkFormatJSON
+	}
+	switch format {
+	case checkFormatText, checkFormatJSON, checkFormatGitHub, checkFormatGitLab, checkFormatJUnit, checkFormatCSV, checkFormatTSV:
+	default:
+		return fmt.Errorf("invalid --format value %q (supported: text, json, github, gitlab, junit, csv, tsv)", cc.format)
+	}
+	if err := validateNDJSON(cc.Command, cc.ndjson); err != nil {
+		return err
+	}
+	if cc.updateBaseline && cc.baseline == "" {
+		return fmt.Errorf("--update-baseline requires --baseline")
+	}
+	if cc.updateBaseline && cc.ndjson {
+		return fmt.Errorf("--update-baseline cannot be used with --ndjson")
+	}
+	var baseline *check.Baseline
+	if cc.baseline != "" && !cc.updateBaseline {
+		if baseline, err = check.LoadBaseline(cc.baseline); err != nil {
+			return fmt.Errorf("%w (create it with --update-baseline)", err)
+		}
+	}
 
 	validator := check.NewValidator(GetLocale())
+	validator.SetContext(cc.Context())
 	validator.SetFrequencyChecks(cc.enableFrequency)
 	validator.SetMaxRunsPerDay(cc.maxRunsPerDay)
 	validator.SetHygieneChecks(cc.enableHygiene)
+	validator.SetConsolidationChecks(cc.consolidate)
+
+	env, err := jobEnvironment(cc.expand)
+	if err != nil {
+		return err
+	}
+	validator.SetEnvironment(env)
+
+	horizon, err := check.ParseHorizon(cc.horizon)
+	if err != nil {
+		return fmt.Errorf("invalid --horizon value: %w", err)
+	}
+	validator.SetHorizon(horizon)
+
+	policy, err := loadPolicy(cc.policy)
+	if err != nil {
+		return err
+	}
+	validator.SetPolicy(policy)
+
+	if cc.timezone != "" {
+		loc, err := time.LoadLocation(cc.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		validator.SetTimezone(loc)
+	}
+
+	calendar, err := loadHolidays(cc.holidays)
+	if err != nil {
+		return err
+	}
+	validator.SetHolidays(calendar)
 
 	// Parse overlap window duration
 	if cc.warnOnOverlap {
@@ -92,14 +281,30 @@ func (cc *CheckCommand) runCheck(_ *cobra.Command, args []string) error {
 		validator.SetWarnOnOverlap(true)
 	}
 
-	reader := crontab.NewReader()
+	reader := newCrontabReader(cc.system)
+	if cc.ndjson && len(args) == 0 {
+		return cc.outputNDJSON(validator, reader, baseline, failOnSeverity)
+	}
 
 	var result check.ValidationResult
 
-	// Priority: expression arg > --file > --stdin > user crontab
+	// Priority: expression arg > --github-workflows > --file > --stdin > user crontab
 	if len(args) == 1 {
-		// Single expression validation
+		// Single expression validation (crontab files never carry a seconds field)
+		opts, err := parserOptions(cc.seconds, cc.dialect, cc.jenkinsJob)
+		if err != nil {
+			return err
+		}
+		validator.SetParserOptions(opts)
 		result = validator.ValidateExpression(args[0])
+	} else if cc.workflows != "" {
+		// GitHub Actions workflow schedules
+		entries, err := workflowEntries(cc.workflows)
+		if err != nil {
+			return err
+		}
+		validator.SetGitHubActions(true)
+		result = validator.ValidateEntries(entries)
 	} else if cc.file != "" {
 		// File validation
 		result = validator.ValidateCrontab(reader, cc.file)
@@ -121,18 +326,160 @@ func (cc *CheckCommand) runCheck(_ *cobra.Command, args []string) error {
 		// User crontab validation
 		result = validator.ValidateUserCrontab(reader)
 	}
+	// Checks find no runs once --timeout expires, so their results are incomplete
+	if err := contextErr(cc.Command); err != nil {
+		return err
+	}
+
+	if cc.updateBaseline {
+		return cc.writeBaseline(result)
+	}
+	if baseline != nil {
+		var suppressed int
+		result.Issues, suppressed = baseline.Filter(result.Issues, cc.sourcePath())
+		result.Suppressed += suppressed
+	}
 
 	// Output based on format
-	if cc.json {
+	switch {
+	case cc.ndjson:
+		return cc.outputIssuesNDJSON(result, failOnSeverity)
+	case format == checkFormatJSON:
 		return cc.outputJSON(result, failOnSeverity)
+	case format == checkFormatGitHub || format == checkFormatGitLab:
+		return cc.outputAnnotations(format, result, failOnSeverity)
+	case format == checkFormatJUnit:
+		return cc.outputJUnit(result, failOnSeverity)
+	case format == checkFormatCSV || format == checkFormatTSV:
+		return cc.outputTabular(format, result, failOnSeverity)
 	}
 
 	return cc.outputText(result, failOnSeverity)
 }
 
+// loadPolicy reads the --policy file, else the default policy file of the
+// current directory when there is one
+func loadPolicy(path string) (*check.Policy, error) {
+	if path == "" {
+		return check.LoadPolicy(check.DefaultPolicyFile, false)
+	}
+	return check.LoadPolicy(path, true)
+}
+
+// writeBaseline records the issues of a result in the --baseline file
+func (cc *CheckCommand) writeBaseline(result check.ValidationResult) error {
+	var buf bytes.Buffer
+	if err := check.NewBaseline(result.Issues, cc.sourcePath()).Write(&buf); err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(cc.baseline, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	cc.Printf("Wrote %d issue(s) to baseline %s\n", len(result.Issues), cc.baseline)
+	return nil
+}
+
+// workflowEntries returns the schedules of the GitHub Actions workflow files
+// at path as crontab entries
+func workflowEntries(path string) ([]*crontab.Entry, error) {
+	files, err := workflow.Find(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflows: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no workflow files (.yml, .yaml) found in %s", path)
+	}
+
+	var entries []*crontab.Entry
+	for _, file := range files {
+		wf, err := workflow.Load(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workflow: %w", err)
+		}
+		entries = append(entries, wf.Entries()...)
+	}
+	return entries, nil
+}
+
+// sourcePath returns the path reported for issues that do not carry their own file
+func (cc *CheckCommand) sourcePath() string {
+	if cc.workflows != "" {
+		return cc.workflows
+	}
+	return cc.file
+}
+
+// outputAnnotations writes issues in a CI annotation format
+func (cc *CheckCommand) outputAnnotations(format string, result check.ValidationResult, failOn check.Severity) error {
+	issuesToShow := cc.filterIssues(result.Issues)
+
+	write := check.WriteGitHubAnnotations
+	if format == checkFormatGitLab {
+		write = check.WriteGitLabCodeQuality
+	}
+	if err := write(cc.OutOrStdout(), issuesToShow, cc.sourcePath()); err != nil {
+		return fmt.Errorf("failed to write %s annotations: %w", format, err)
+	}
+
+	exitCode := calculateExitCode(result, issuesToShow, failOn, cc.exitCodes)
+	if exitCode != 0 {
+		osExit(exitCode)
+	}
+
+	return nil
+}
+
+// outputJUnit writes the result as a JUnit XML test report
+func (cc *CheckCommand) outputJUnit(result check.ValidationResult, failOn check.Severity) error {
+	issuesToShow := cc.filterIssues(result.Issues)
+
+	if err := check.WriteJUnit(cc.OutOrStdout(), result, issuesToShow, cc.sourcePath(), failOn); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	exitCode := calculateExitCode(result, issuesToShow, failOn, cc.exitCodes)
+	if exitCode != 0 {
+		osExit(exitCode)
+	}
+
+	return nil
+}
+
+// outputTabular writes a CSV or TSV row per issue
+func (cc *CheckCommand) outputTabular(format string, result check.ValidationResult, failOn check.Severity) error {
+	issuesToShow := cc.filterIssues(result.Issues)
+
+	rows := make([][]string, 0, len(issuesToShow))
+	for _, issue := range issuesToShow {
+		file := issue.File
+		if file == "" {
+			file = cc.sourcePath()
+		}
+		line := ""
+		if issue.LineNumber > 0 {
+			line = strconv.Itoa(issue.LineNumber)
+		}
+		rows = append(rows, []string{
+			issue.Severity.String(), issue.Code, file, line, issue.Expression, issue.Message, issue.Hint,
+		})
+	}
+	header := []string{"severity", "code", "file", "line", "expression", "message", "hint"}
+	if err := writeTable(cc.OutOrStdout(), format, header, rows); err != nil {
+		return err
+	}
+
+	exitCode := calculateExitCode(result, issuesToShow, failOn, cc.exitCodes)
+	if exitCode != 0 {
+		osExit(exitCode)
+	}
+
+	return nil
+}
+
 func (cc *CheckCommand) outputText(result check.ValidationResult, failOn check.Severity) error {
 	// Filter issues based on verbose flag
 	issuesToShow := cc.filterIssues(result.Issues)
+	cc.painter = painterFor(cc.OutOrStderr())
 
 	// Separate errors, warnings, and info for display
 	var errors []check.Issue
@@ -152,29 +499,30 @@ func (cc *CheckCommand) outputText(result check.ValidationResult, failOn check.S
 
 	// Print summary
 	if len(errors) == 0 && len(warnings) == 0 && len(info) == 0 {
-		cc.Printf("✓ All valid\n")
+		cc.Println(cc.painter.Paint(color.Success, "✓ All valid"))
 		if result.TotalJobs > 0 {
 			cc.Printf("  %d job(s) validated\n", result.TotalJobs)
 		}
+		cc.printSuppressed(result)
 		return nil
 	}
 
 	// Print error summary
 	if len(errors) > 0 {
-		cc.Printf("✗ Found %d error(s)\n", len(errors))
+		cc.Println(cc.painter.Paintf(color.Error, "✗ Found %d error(s)", len(errors)))
 		if len(warnings) > 0 {
-			cc.Printf("⚠ Found %d warning(s)\n", len(warnings))
+			cc.Println(cc.painter.Paintf(color.Warning, "⚠ Found %d warning(s)", len(warnings)))
 		}
 		if len(info) > 0 {
-			cc.Printf("ℹ Found %d info message(s)\n", len(info))
+			cc.Println(cc.painter.Paintf(color.Info, "ℹ Found %d info message(s)", len(info)))
 		}
 	} else if len(warnings) > 0 {
-		cc.Printf("⚠ Found %d warning(s)\n", len(warnings))
+		cc.Println(cc.painter.Paintf(color.Warning, "⚠ Found %d warning(s)", len(warnings)))
 		if len(info) > 0 {
-			cc.Printf("ℹ Found %d info message(s)\n", len(info))
+			cc.Println(cc.painter.Paintf(color.Info, "ℹ Found %d info message(s)", len(info)))
 		}
 	} else if len(info) > 0 {
-		cc.Printf("ℹ Found %d info message(s)\n", len(info))
+		cc.Println(cc.painter.Paintf(color.Info, "ℹ Found %d info message(s)", len(info)))
 	}
 
 	if result.TotalJobs > 0 {
@@ -182,6 +530,7 @@ func (cc *CheckCommand) outputText(result check.ValidationResult, failOn check.S
 		cc.Printf("  Valid: %d\n", result.ValidJobs)
 		cc.Printf("  Invalid: %d\n", result.InvalidJobs)
 	}
+	cc.printSuppressed(result)
 
 	cc.Println()
 
@@ -228,7 +577,7 @@ func (cc *CheckCommand) outputText(result check.ValidationResult, failOn check.S
 	}
 
 	// Set exit code based on result and fail-on threshold
-	exitCode := calculateExitCode(result, issuesToShow, failOn)
+	exitCode := calculateExitCode(result, issuesToShow, failOn, cc.exitCodes)
 	if exitCode != 0 {
 		osExit(exitCode)
 	}
@@ -243,26 +592,20 @@ func (cc *CheckCommand) outputJSON(result check.ValidationResult, failOn check.S
 	// Convert issues to JSON format with all fields
 	jsonIssues := make([]map[string]interface{}, len(issuesToShow))
 	for i, issue := range issuesToShow {
-		jsonIssue := map[string]interface{}{
-			"severity":   issue.Severity.String(),
-			"code":       issue.Code,
-			"lineNumber": issue.LineNumber,
-			"expression": issue.Expression,
-			"message":    issue.Message,
-		}
-		if issue.Hint != "" {
-			jsonIssue["hint"] = issue.Hint
-		}
-		jsonIssues[i] = jsonIssue
+		jsonIssues[i] = issueJSON(issue)
 	}
 
 	output := map[string]interface{}{
-		"valid":       result.Valid && len(issuesToShow) == 0,
-		"totalJobs":   result.TotalJobs,
-		"validJobs":   result.ValidJobs,
-		"invalidJobs": result.InvalidJobs,
-		"issues":      jsonIssues,
-		"locale":      GetLocale(),
+		"schemaVersion": schema.Version,
+		"valid":         result.Valid && len(issuesToShow) == 0,
+		"totalJobs":     result.TotalJobs,
+		"validJobs":     result.ValidJobs,
+		"invalidJobs":   result.InvalidJobs,
+		"issues":        jsonIssues,
+		"locale":        GetLocale(),
+	}
+	if result.Suppressed > 0 {
+		output["suppressed"] = result.Suppressed
 	}
 
 	encoder := json.NewEncoder(cc.OutOrStdout())
@@ -272,7 +615,7 @@ func (cc *CheckCommand) outputJSON(result check.ValidationResult, failOn check.S
 	}
 
 	// Set exit code based on result and fail-on threshold
-	exitCode := calculateExitCode(result, issuesToShow, failOn)
+	exitCode := calculateExitCode(result, issuesToShow, failOn, cc.exitCodes)
 	if exitCode != 0 {
 		osExit(exitCode)
 	}
@@ -280,16 +623,115 @@ func (cc *CheckCommand) outputJSON(result check.ValidationResult, failOn check.S
 	return nil
 }
 
+// outputNDJSON validates the crontab read from --github-workflows, --file,
+// standard input or the user's crontab, writing each issue shown as a line of
+// JSON as soon as its job is checked. Files and standard input are validated
+// as they are read, so crontabs of any size are checked in constant memory.
+func (cc *CheckCommand) outputNDJSON(validator *check.Validator, reader crontab.Reader, baseline *check.Baseline, failOn check.Severity) error {
+	var entries iter.Seq2[*crontab.Entry, error]
+	// Same priority as runCheck: --github-workflows > --file > --stdin > user crontab
+	switch {
+	case cc.workflows != "":
+		workflow, err := workflowEntries(cc.workflows)
+		if err != nil {
+			return err
+		}
+		entries = crontab.EntrySeq(workflow)
+		validator.SetGitHubActions(true)
+	case cc.file != "":
+		entries = readErrors(reader.FileEntries(cc.Context(), cc.file), "failed to read crontab file")
+	case cc.stdin || isStdinAvailable():
+		entries = readErrors(reader.StdinEntries(cc.Context()), "failed to read crontab from stdin")
+	default:
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		var user []*crontab.Entry
+		for _, job := range jobs {
+			user = append(user, &crontab.Entry{Type: crontab.EntryTypeJob, LineNumber: job.LineNumber, Job: job})
+		}
+		entries = crontab.EntrySeq(user)
+	}
+
+	out := newNDJSONWriter(cc.OutOrStdout())
+	// Only the most severe issue shown is kept, for the exit code
+	var worst []check.Issue
+	result, err := validator.ValidateStream(entries, func(issue check.Issue) error {
+		if baseline != nil && baseline.Suppresses(issue, cc.sourcePath()) {
+			return nil
+		}
+		if len(cc.filterIssues([]check.Issue{issue})) == 0 {
+			return nil
+		}
+		if len(worst) == 0 || issue.Severity > worst[0].Severity {
+			worst = []check.Issue{issue}
+		}
+		return out.Write(issueJSON(issue))
+	})
+	if err != nil {
+		return err
+	}
+
+	if exitCode := calculateExitCode(result, worst, failOn, cc.exitCodes); exitCode != 0 {
+		osExit(exitCode)
+	}
+	return nil
+}
+
+// outputIssuesNDJSON writes the issues shown of a single expression's
+// validation as lines of JSON
+func (cc *CheckCommand) outputIssuesNDJSON(result check.ValidationResult, failOn check.Severity) error {
+	issuesToShow := cc.filterIssues(result.Issues)
+	out := newNDJSONWriter(cc.OutOrStdout())
+	for _, issue := range issuesToShow {
+		if err := out.Write(issueJSON(issue)); err != nil {
+			return err
+		}
+	}
+
+	if exitCode := calculateExitCode(result, issuesToShow, failOn, cc.exitCodes); exitCode != 0 {
+		osExit(exitCode)
+	}
+	return nil
+}
+
+// issueJSON returns an issue in the JSON format, with all fields
+func issueJSON(issue check.Issue) map[string]interface{} {
+	jsonIssue := map[string]interface{}{
+		"severity":   issue.Severity.String(),
+		"code":       issue.Code,
+		"lineNumber": issue.LineNumber,
+		"expression": issue.Expression,
+		"message":    issue.Message,
+	}
+	if issue.Hint != "" {
+		jsonIssue["hint"] = issue.Hint
+	}
+	if issue.File != "" {
+		jsonIssue["file"] = issue.File
+	}
+	if len(issue.Suggestions) > 0 {
+		suggestions := make([]map[string]string, len(issue.Suggestions))
+		for j, suggestion := range issue.Suggestions {
+			suggestions[j] = map[string]string{"expression": suggestion.Expression, "reason": suggestion.Reason}
+		}
+		jsonIssue["suggestions"] = suggestions
+	}
+	return jsonIssue
+}
+
 // osExit is a variable that can be overridden in tests
 var osExit = os.Exit
 
 // calculateExitCode determines the appropriate exit code based on validation result,
-// issues shown, and fail-on threshold.
+// issues shown, fail-on threshold and the exit codes of each severity.
 // Returns:
 //   - 0: No issues, or only issues below the fail-on threshold
-//   - 1: Errors present (or configured severity level reached)
-//   - 2: Warnings present (only if fail-on is warn or info)
-func calculateExitCode(result check.ValidationResult, issuesToShow []check.Issue, failOn check.Severity) int {
+//   - codes.Error: Errors present (1 by default)
+//   - codes.Warn, codes.Info: Warnings or info messages are the most severe
+//     issues and reach the fail-on threshold (2 by default)
+func calculateExitCode(result check.ValidationResult, issuesToShow []check.Issue, failOn check.Severity, codes check.ExitCodes) int {
 	if len(issuesToShow) == 0 {
 		return 0
 	}
@@ -307,17 +749,7 @@ func calculateExitCode(result check.ValidationResult, issuesToShow []check.Issue
 		return 0
 	}
 
-	// Determine exit code based on highest severity
-	switch highestSeverity {
-	case check.SeverityError:
-		return 1
-	case check.SeverityWarn:
-		return 2
-	case check.SeverityInfo:
-		return 2
-	default:
-		return 0
-	}
+	return codes.For(highestSeverity)
 }
 
 // filterIssues filters issues based on the verbose flag
@@ -482,24 +914,35 @@ func (cc *CheckCommand) printIssuesGrouped(issues []check.Issue, mode GroupByMod
 
 // printGroupHeader prints a header for a group of issues
 func (cc *CheckCommand) printGroupHeader(title string, count int) {
-	cc.Printf("━━━ %s (%d issue(s)) ━━━\n", title, count)
+	cc.Println(cc.painter.Paintf(color.Heading, "━━━ %s (%d issue(s)) ━━━", title, count))
+}
+
+// issueLocation returns the "Line N: " prefix of an issue, or "file:N: " when
+// the issue belongs to one of several files checked together
+func issueLocation(issue check.Issue) string {
+	switch {
+	case issue.File != "" && issue.LineNumber > 0:
+		return fmt.Sprintf("%s:%d: ", issue.File, issue.LineNumber)
+	case issue.File != "":
+		return issue.File + ": "
+	case issue.LineNumber > 0:
+		return fmt.Sprintf("Line %d: ", issue.LineNumber)
+	}
+	return ""
 }
 
 // printIssue prints a single issue with all its details
 func (cc *CheckCommand) printIssue(issue check.Issue) {
-	lineInfo := ""
-	if issue.LineNumber > 0 {
-		lineInfo = fmt.Sprintf("Line %d: ", issue.LineNumber)
-	}
+	lineInfo := issueLocation(issue)
 
 	prefix := ""
 	switch issue.Severity {
 	case check.SeverityError:
-		prefix = "✗ ERROR: "
+		prefix = cc.painter.Paint(color.Error, "✗ ERROR:") + " "
 	case check.SeverityWarn:
-		prefix = "⚠ WARNING: "
+		prefix = cc.painter.Paint(color.Warning, "⚠ WARNING:") + " "
 	case check.SeverityInfo:
-		prefix = "ℹ INFO: "
+		prefix = cc.painter.Paint(color.Info, "ℹ INFO:") + " "
 	}
 
 	// Display diagnostic code if available
@@ -517,17 +960,24 @@ func (cc *CheckCommand) printIssue(issue check.Issue) {
 
 	// Display hint if available
 	if issue.Hint != "" {
-		cc.Printf("    Hint: %s\n", issue.Hint)
+		cc.Printf("    %s\n", cc.painter.Paint(color.Muted, "Hint: "+issue.Hint))
+	}
+	for _, suggestion := range issue.Suggestions {
+		cc.Printf("    Did you mean: %s (%s)\n", suggestion.Expression, suggestion.Reason)
+	}
+}
+
+// printSuppressed prints how many issues were left out, if any
+func (cc *CheckCommand) printSuppressed(result check.ValidationResult) {
+	if result.Suppressed > 0 {
+		cc.Printf("  Suppressed: %d (cronkit:ignore or baseline)\n", result.Suppressed)
 	}
 }
 
 // printWarningsCompact prints warnings in a compact format (one line per warning)
 func (cc *CheckCommand) printWarningsCompact(warnings []check.Issue) {
 	for _, issue := range warnings {
-		lineInfo := ""
-		if issue.LineNumber > 0 {
-			lineInfo = fmt.Sprintf("Line %d: ", issue.LineNumber)
-		}
+		lineInfo := issueLocation(issue)
 
 		codeInfo := ""
 		if issue.Code != "" {
@@ -535,9 +985,9 @@ func (cc *CheckCommand) printWarningsCompact(warnings []check.Issue) {
 		}
 
 		if issue.Expression != "" {
-			cc.Printf("  ⚠ %s%s%s - %s\n", lineInfo, issue.Message, codeInfo, issue.Expression)
+			cc.Printf("  %s %s%s%s - %s\n", cc.painter.Paint(color.Warning, "⚠"), lineInfo, issue.Message, codeInfo, issue.Expression)
 		} else {
-			cc.Printf("  ⚠ %s%s%s\n", lineInfo, issue.Message, codeInfo)
+			cc.Printf("  %s %s%s%s\n", cc.painter.Paint(color.Warning, "⚠"), lineInfo, issue.Message, codeInfo)
 		}
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hzerrad/cronkit/internal/check"
@@ -66,6 +67,31 @@ func TestCheckCommand(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("check invalid expression suggests corrections", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(buf)
+		cc.SetArgs([]string{"0 0 * * MONDAY", "--json"})
+
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		require.NoError(t, cc.Execute())
+
+		var result struct {
+			Issues []struct {
+				Suggestions []map[string]string `json:"suggestions"`
+			} `json:"issues"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Issues, 1)
+		require.Len(t, result.Issues[0].Suggestions, 1)
+		assert.Equal(t, "0 0 * * MON", result.Issues[0].Suggestions[0]["expression"])
+		assert.Equal(t, "MONDAY is not a day name; use MON", result.Issues[0].Suggestions[0]["reason"])
+	})
+
 	t.Run("check expression with DOM/DOW conflict", func(t *testing.T) {
 		cc := newCheckCommand()
 		buf := new(bytes.Buffer)
@@ -592,7 +618,7 @@ func TestCheckCommand(t *testing.T) {
 
 	t.Run("calculateExitCode with no issues", func(t *testing.T) {
 		result := check.ValidationResult{Valid: true, Issues: []check.Issue{}}
-		exitCode := calculateExitCode(result, []check.Issue{}, check.SeverityError)
+		exitCode := calculateExitCode(result, []check.Issue{}, check.SeverityError, check.DefaultExitCodes)
 		assert.Equal(t, 0, exitCode)
 	})
 
@@ -603,7 +629,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityError, Code: check.CodeParseError},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityError)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityError, check.DefaultExitCodes)
 		assert.Equal(t, 1, exitCode)
 	})
 
@@ -614,7 +640,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityWarn, Code: check.CodeDOMDOWConflict},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityError)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityError, check.DefaultExitCodes)
 		assert.Equal(t, 0, exitCode, "Warnings should not cause exit with --fail-on error")
 	})
 
@@ -625,7 +651,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityWarn, Code: check.CodeDOMDOWConflict},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityWarn)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityWarn, check.DefaultExitCodes)
 		assert.Equal(t, 2, exitCode)
 	})
 
@@ -636,7 +662,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityWarn, Code: check.CodeDOMDOWConflict},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityError)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityError, check.DefaultExitCodes)
 		assert.Equal(t, 0, exitCode, "Warnings should not cause exit with --fail-on error")
 	})
 
@@ -647,7 +673,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityError, Code: check.CodeParseError},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityWarn)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityWarn, check.DefaultExitCodes)
 		assert.Equal(t, 1, exitCode, "Errors should cause exit code 1 even with --fail-on warn")
 	})
 
@@ -658,7 +684,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityInfo, Code: ""},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityInfo)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityInfo, check.DefaultExitCodes)
 		assert.Equal(t, 2, exitCode)
 	})
 
@@ -670,7 +696,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.SeverityError, Code: check.CodeParseError},
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityError)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityError, check.DefaultExitCodes)
 		assert.Equal(t, 1, exitCode, "Should return 1 for errors when mixed with warnings")
 	})
 
@@ -681,7 +707,7 @@ func TestCheckCommand(t *testing.T) {
 				{Severity: check.Severity(999), Code: ""}, // Invalid severity
 			},
 		}
-		exitCode := calculateExitCode(result, result.Issues, check.SeverityError)
+		exitCode := calculateExitCode(result, result.Issues, check.SeverityError, check.DefaultExitCodes)
 		assert.Equal(t, 0, exitCode, "Should return 0 for invalid severity")
 	})
 
@@ -1734,3 +1760,709 @@ func TestCheckCommand_GroupBy(t *testing.T) {
 		// Should default to no grouping
 	})
 }
+
+func TestCheckCommand_SuggestConsolidation(t *testing.T) {
+	testFile := createTempFile(t, "0 2 * * * /usr/bin/cleanup.sh\n0 14 * * * /usr/bin/cleanup.sh\n")
+
+	t.Run("flag is registered and disabled by default", func(t *testing.T) {
+		cc := newCheckCommand()
+		flag := cc.Flags().Lookup("suggest-consolidation")
+		require.NotNil(t, flag)
+		assert.Equal(t, "false", flag.DefValue)
+	})
+
+	t.Run("reports merged expression in JSON output", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--suggest-consolidation", "--json", "--verbose"})
+
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "CRON-013")
+		assert.Contains(t, buf.String(), "0 2,14 * * *")
+	})
+
+	t.Run("no suggestions without the flag", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--json", "--verbose"})
+
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "CRON-013")
+	})
+}
+
+func TestCheckCommand_Seconds(t *testing.T) {
+	oldExit := osExit
+	osExit = func(code int) {}
+	defer func() { osExit = oldExit }()
+
+	t.Run("six-field expression is valid", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 */5 * * * *", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"valid": true`)
+	})
+
+	t.Run("--seconds rejects five-field expression", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 * * *", "--seconds", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"valid": false`)
+		assert.Contains(t, buf.String(), "expected 6 fields")
+	})
+}
+
+func TestCheckCommand_Dialect(t *testing.T) {
+	oldExit := osExit
+	osExit = func(code int) {}
+	defer func() { osExit = oldExit }()
+
+	t.Run("quartz expression is valid", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 12 ? * FRI#3", "--dialect", "quartz", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"valid": true`)
+	})
+
+	t.Run("quartz requires a '?' day field", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 12 * * *", "--dialect", "quartz", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"valid": false`)
+		assert.Contains(t, buf.String(), "must be '?'")
+	})
+
+	t.Run("quartz schedule that never runs", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 12 30 2 ?", "--dialect", "quartz", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "CRON-002")
+	})
+}
+
+func TestCheckCommand_Horizon(t *testing.T) {
+	oldExit := osExit
+	osExit = func(code int) {}
+	defer func() { osExit = oldExit }()
+
+	t.Run("flag defaults to two years", func(t *testing.T) {
+		cc := newCheckCommand()
+		flag := cc.Flags().Lookup("horizon")
+		require.NotNil(t, flag)
+		assert.Equal(t, "2y", flag.DefValue)
+	})
+
+	t.Run("distant first run is reported as info", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 29 2 *", "--horizon", "1d", "--verbose"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "CRON-014")
+		assert.Contains(t, buf.String(), "beyond the 1d horizon")
+	})
+
+	t.Run("invalid horizon", func(t *testing.T) {
+		cc := newCheckCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"* * * * *", "--horizon", "soon"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --horizon value")
+	})
+}
+
+func TestCheckCommand_Timezone(t *testing.T) {
+	oldExit := osExit
+	osExit = func(code int) {}
+	defer func() { osExit = oldExit }()
+
+	t.Run("warns about runs in a DST gap", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"30 2 * * *", "--timezone", "America/New_York", "--verbose"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		output := buf.String()
+		assert.Contains(t, output, "CRON-015")
+		assert.Contains(t, output, "02:30 does not exist")
+	})
+
+	t.Run("warns about runs in a DST gap in a crontab file", func(t *testing.T) {
+		testFile := createTempFile(t, "30 2 * * * /bin/true\n")
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--timezone", "America/New_York", "--verbose"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		output := buf.String()
+		assert.Contains(t, output, "CRON-015")
+		assert.NotContains(t, output, "All valid")
+	})
+
+	t.Run("no warning without a timezone", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"30 2 * * *", "--verbose"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "CRON-015")
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		cc := newCheckCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"30 2 * * *", "--timezone", "Mars/Olympus"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid timezone")
+	})
+}
+
+func TestCheckCommand_Holidays(t *testing.T) {
+	oldExit := osExit
+	osExit = func(code int) {}
+	defer func() { osExit = oldExit }()
+
+	testFile := createTempFile(t, "# cronkit:tags=business-critical\n0 9 * * 1-5 /usr/bin/payroll.sh\n0 9 * * 1-5 /usr/bin/report.sh\n")
+
+	t.Run("names the holidays business-critical jobs run on", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--verbose", "--holidays", "US"})
+
+		require.NoError(t, cc.Execute())
+		output := buf.String()
+		assert.Equal(t, 1, strings.Count(output, "CRON-025"))
+		assert.Contains(t, output, "Line 2:")
+		assert.Contains(t, output, "public holiday(s) of United States (federal)")
+		assert.Contains(t, output, "Holidays it runs on: ")
+	})
+
+	t.Run("invalid calendar", func(t *testing.T) {
+		cc := newCheckCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"--file", testFile, "--holidays", "Atlantis"})
+
+		assert.ErrorContains(t, cc.Execute(), "invalid --holidays value")
+	})
+}
+
+func TestCheckCommand_Expand(t *testing.T) {
+	oldExit := osExit
+	osExit = func(code int) {}
+	defer func() { osExit = oldExit }()
+
+	testFile := createTempFile(t, "0 2 * * * /bin/sh -c true\n0 3 * * * $HOME/no-such-script.sh\n")
+
+	t.Run("warns about missing programs", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--expand", "--verbose"})
+
+		require.NoError(t, cc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "CRON-016")
+		assert.Contains(t, output, "no-such-script.sh")
+		assert.NotContains(t, output, "Line 1:")
+	})
+
+	t.Run("no existence checks without --expand", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--verbose"})
+
+		require.NoError(t, cc.Execute())
+		assert.NotContains(t, buf.String(), "CRON-016")
+	})
+}
+
+func TestCheckCommand_Format(t *testing.T) {
+	var exitCode int
+	oldExit := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = oldExit }()
+
+	testFile := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n61 * * * * /usr/bin/broken.sh\n")
+
+	t.Run("github", func(t *testing.T) {
+		exitCode = 0
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--format", "github"})
+
+		require.NoError(t, cc.Execute())
+		assert.True(t, strings.HasPrefix(buf.String(), "::error file="+testFile+",line=2,title=CRON-003::"))
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("gitlab", func(t *testing.T) {
+		exitCode = 0
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--format", "gitlab"})
+
+		require.NoError(t, cc.Execute())
+		var report []map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+		require.Len(t, report, 1)
+		assert.Equal(t, "CRON-003", report[0]["check_name"])
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		exitCode = 0
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--format", "junit"})
+
+		require.NoError(t, cc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, `<testsuites name="cronkit check" tests="2" failures="1">`)
+		assert.Contains(t, output, `name="line 1: 0 2 * * * /usr/bin/backup.sh"></testcase>`)
+		assert.Contains(t, output, `<failure type="CRON-003"`)
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		exitCode = 0
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--format", "csv"})
+
+		require.NoError(t, cc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "severity,code,file,line,expression,message,hint", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "error,CRON-003,"+testFile+",2,61 * * * *,"))
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 * * *", "--format", "tsv"})
+
+		require.NoError(t, cc.Execute())
+		assert.Equal(t, "severity\tcode\tfile\tline\texpression\tmessage\thint\n", buf.String())
+	})
+
+	t.Run("json flag overrides format", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 * * *", "--format", "github", "--json"})
+
+		require.NoError(t, cc.Execute())
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, true, result["valid"])
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		cc := newCheckCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"0 0 * * *", "--format", "xml"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --format")
+	})
+}
+
+func TestCheckCommand_GitHubWorkflows(t *testing.T) {
+	dir := t.TempDir()
+	nightly := filepath.Join(dir, "nightly.yml")
+	require.NoError(t, os.WriteFile(nightly, []byte("name: Nightly\non:\n  schedule:\n    - cron: '*/2 * * * *'\n    - cron: '30 9 * * 1-5'\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ci.yaml"), []byte("on: [push]\n"), 0o644))
+
+	t.Run("checks every workflow of a directory", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--github-workflows", dir, "--verbose"})
+
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		require.NoError(t, cc.Execute())
+		assert.Equal(t, 0, exitCode, "warnings are below the default --fail-on")
+		output := buf.String()
+		assert.Contains(t, output, "Total jobs: 2")
+		assert.Contains(t, output, nightly+":4: ⚠ WARNING: Runs as often as every 2 minutes")
+		assert.Contains(t, output, "[CRON-017]")
+		assert.Contains(t, output, nightly+":5: ℹ INFO: Runs at fixed hours, which GitHub evaluates in UTC")
+	})
+
+	t.Run("annotates the workflow file", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--github-workflows", dir, "--format", "github"})
+
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		require.NoError(t, cc.Execute())
+		assert.Contains(t, buf.String(), "::warning file="+nightly+",line=4,title=CRON-017::")
+	})
+
+	t.Run("reports the file in JSON", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--github-workflows", nightly, "--json"})
+
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		require.NoError(t, cc.Execute())
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		issues := result["issues"].([]interface{})
+		require.Len(t, issues, 1)
+		assert.Equal(t, nightly, issues[0].(map[string]interface{})["file"])
+	})
+
+	t.Run("fails without workflow files", func(t *testing.T) {
+		cc := newCheckCommand()
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"--github-workflows", t.TempDir()})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no workflow files")
+	})
+
+	t.Run("fails on an invalid workflow", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.yml")
+		require.NoError(t, os.WriteFile(bad, []byte("on: [push\n"), 0o644))
+
+		cc := newCheckCommand()
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"--github-workflows", bad})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read workflow")
+	})
+}
+
+func TestCheckCommand_NDJSON(t *testing.T) {
+	var exitCode int
+	oldExit := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = oldExit }()
+
+	run := func(t *testing.T, args ...string) ([]map[string]interface{}, error) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs(args)
+		if err := cc.Execute(); err != nil {
+			return nil, err
+		}
+		var issues []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var issue map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &issue))
+			issues = append(issues, issue)
+		}
+		return issues, nil
+	}
+
+	testFile := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n61 * * * * /usr/bin/broken.sh\n0 0 1 * 1 /usr/bin/report.sh\n")
+
+	t.Run("an issue per line", func(t *testing.T) {
+		exitCode = 0
+		issues, err := run(t, "--file", testFile, "--ndjson")
+		require.NoError(t, err)
+		require.Len(t, issues, 2)
+		assert.Equal(t, "CRON-003", issues[0]["code"])
+		assert.Equal(t, float64(2), issues[0]["lineNumber"])
+		assert.Equal(t, "error", issues[0]["severity"])
+		assert.Equal(t, float64(3), issues[1]["lineNumber"])
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("same issues as JSON", func(t *testing.T) {
+		issues, err := run(t, "--file", testFile, "--ndjson", "--verbose")
+		require.NoError(t, err)
+
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--file", testFile, "--json", "--verbose"})
+		require.NoError(t, cc.Execute())
+		var result struct{ Issues []map[string]interface{} }
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, result.Issues, issues)
+	})
+
+	t.Run("single expression", func(t *testing.T) {
+		exitCode = 0
+		issues, err := run(t, "0 0 * * *", "--ndjson")
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("fail-on follows the issues shown", func(t *testing.T) {
+		exitCode = 0
+		warnOnly := createTempFile(t, "0 0 1 * 1 /usr/bin/report.sh\n")
+		issues, err := run(t, "--file", warnOnly, "--ndjson", "--fail-on", "warn")
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, 2, exitCode)
+	})
+
+	t.Run("cannot be combined with --format", func(t *testing.T) {
+		_, err := run(t, "--file", testFile, "--ndjson", "--format", "csv")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--ndjson cannot be used with --json or --format")
+	})
+}
+
+func TestCheckCommand_Policy(t *testing.T) {
+	var exitCode int
+	oldExit := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = oldExit }()
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs(args)
+		err := cc.Execute()
+		return buf.String(), err
+	}
+
+	testFile := createTempFile(t, "MAILTO=ops@example.com\n30 0 * * * /usr/bin/backup.sh\n0 3 * * * /usr/bin/report.sh\n")
+	policyFile := createTempFile(t, "policy:\n  forbidden-windows:\n    - 00:00-01:00\n")
+
+	t.Run("--policy enforces the policy as errors", func(t *testing.T) {
+		exitCode = 0
+		output, err := run(t, "--file", testFile, "--policy", policyFile)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Line 2: ✗ ERROR: Schedule runs at 00:30, inside the forbidden window 00:00-01:00 [CRON-023]")
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("policy rules report with their own codes", func(t *testing.T) {
+		rulesFile := createTempFile(t, "policy:\n  rules:\n    - code: OPS-001\n      message: Reports run on weekdays\n      severity: warn\n      command: report\n      require-expression: ' 1-5$'\n")
+		exitCode = 0
+		output, err := run(t, "--file", testFile, "--policy", rulesFile, "--verbose")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Line 3: ⚠ WARNING: Reports run on weekdays [OPS-001]")
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run(check.DefaultPolicyFile+" is read from the current directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, check.DefaultPolicyFile), []byte("policy:\n  max-runs-per-day: 12\n"), 0o644))
+		t.Chdir(dir)
+
+		exitCode = 0
+		output, err := run(t, "*/30 * * * *")
+		require.NoError(t, err)
+		assert.Contains(t, output, "[CRON-021]")
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("without a policy file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		exitCode = 0
+		_, err := run(t, "--file", testFile)
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("missing --policy file", func(t *testing.T) {
+		_, err := run(t, "--file", testFile, "--policy", filepath.Join(t.TempDir(), "policy.yaml"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read policy file")
+	})
+}
+
+func TestCheckCommand_Baseline(t *testing.T) {
+	var exitCode int
+	oldExit := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = oldExit }()
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs(args)
+		err := cc.Execute()
+		return buf.String(), err
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	testFile := filepath.Join(dir, "jobs.cron")
+	baselineFile := filepath.Join(dir, "baseline.json")
+	require.NoError(t, os.WriteFile(testFile, []byte("0 0 1 * 1 /usr/bin/a.sh\n"), 0o644))
+
+	t.Run("--update-baseline records the issues", func(t *testing.T) {
+		exitCode = 0
+		output, err := run(t, "--file", testFile, "--baseline", baselineFile, "--update-baseline")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Wrote 1 issue(s) to baseline")
+		assert.Equal(t, 0, exitCode)
+		assert.FileExists(t, baselineFile)
+	})
+
+	t.Run("--baseline reports new issues only", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(testFile, []byte("# cron jobs\n0 0 1 * 1 /usr/bin/a.sh\n0 0 2 * 2 /usr/bin/b.sh\n"), 0o644))
+
+		exitCode = 0
+		output, err := run(t, "--file", testFile, "--baseline", baselineFile, "--fail-on", "warn", "--json")
+		require.NoError(t, err)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		issues := result["issues"].([]interface{})
+		require.Len(t, issues, 1)
+		assert.Equal(t, "0 0 2 * 2", issues[0].(map[string]interface{})["expression"])
+		assert.Equal(t, float64(1), result["suppressed"])
+		assert.Equal(t, 2, exitCode)
+	})
+
+	t.Run("cronkit:ignore annotations", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(testFile, []byte("# cronkit:ignore CRON-001\n0 0 1 * 1 /usr/bin/a.sh\n"), 0o644))
+
+		exitCode = 0
+		output, err := run(t, "--file", testFile, "--fail-on", "warn")
+		require.NoError(t, err)
+		assert.Contains(t, output, "✓ All valid")
+		assert.Contains(t, output, "Suppressed: 1")
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("invalid flag use", func(t *testing.T) {
+		_, err := run(t, "--file", testFile, "--update-baseline")
+		assert.ErrorContains(t, err, "--update-baseline requires --baseline")
+
+		_, err = run(t, "--file", testFile, "--baseline", filepath.Join(dir, "missing.json"))
+		assert.ErrorContains(t, err, "create it with --update-baseline")
+	})
+}
+
+func TestCheckCommand_ExitCodesAndQuiet(t *testing.T) {
+	var exitCode int
+	oldExit := osExit
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = oldExit }()
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs(args)
+		err := cc.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("--exit-codes maps severities to exit codes", func(t *testing.T) {
+		exitCode = 0
+		_, err := run(t, "0 0 1 * 1", "--fail-on", "warn", "--exit-codes", "warn=7")
+		require.NoError(t, err)
+		assert.Equal(t, 7, exitCode)
+
+		exitCode = -1
+		_, err = run(t, "0 0 1 * 1", "--fail-on", "warn", "--exit-codes", "warn=0")
+		require.NoError(t, err)
+		assert.Equal(t, -1, exitCode, "a zero exit code does not exit early")
+
+		exitCode = 0
+		_, err = run(t, "invalid", "--exit-codes", "error=3")
+		require.NoError(t, err)
+		assert.Equal(t, 3, exitCode)
+	})
+
+	t.Run("--quiet prints nothing", func(t *testing.T) {
+		exitCode = 0
+		output, err := run(t, "invalid", "--quiet")
+		require.NoError(t, err)
+		assert.Empty(t, output)
+		assert.Equal(t, 1, exitCode)
+
+		exitCode = 0
+		output, err = run(t, "0 0 * * *", "-q", "--json")
+		require.NoError(t, err)
+		assert.Empty(t, output)
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("invalid --exit-codes", func(t *testing.T) {
+		_, err := run(t, "0 0 * * *", "--exit-codes", "warn=two")
+		assert.ErrorContains(t, err, "invalid --exit-codes value")
+	})
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/hzerrad/cronkit/internal/color"
+)
+
+// painterFor returns the painter of the --theme setting for output to w, or
+// nil when that output is not colored (see color.Enabled and --no-color)
+func painterFor(w io.Writer) *color.Painter {
+	if !color.Enabled(w, noColor) {
+		return nil
+	}
+	theme, err := color.LookupTheme(themeName)
+	if err != nil {
+		theme, _ = color.LookupTheme(color.DefaultTheme)
+	}
+	return color.NewPainter(theme)
+}
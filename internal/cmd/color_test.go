@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPainterFor(t *testing.T) {
+	t.Cleanup(func() { noColor, themeName = false, color.DefaultTheme })
+	t.Setenv(color.EnvNoColor, "")
+	t.Setenv(color.EnvForceColor, "")
+
+	assert.Nil(t, painterFor(&bytes.Buffer{}), "buffers are not terminals")
+
+	t.Setenv(color.EnvForceColor, "1")
+	themeName = "high-contrast"
+	assert.Equal(t, "\x1b[1;4;91mfailed\x1b[0m", painterFor(&bytes.Buffer{}).Paint(color.Error, "failed"))
+
+	noColor = true
+	assert.Nil(t, painterFor(&bytes.Buffer{}))
+}
+
+func TestColoredOutput(t *testing.T) {
+	t.Setenv(color.EnvNoColor, "")
+	t.Setenv(color.EnvForceColor, "1")
+	oldExit := osExit
+	osExit = func(code int) {}
+	t.Cleanup(func() { osExit = oldExit })
+
+	t.Run("check", func(t *testing.T) {
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetErr(buf)
+		cc.SetArgs([]string{"60 0 * * *"})
+		require.NoError(t, cc.Execute())
+		assert.Contains(t, buf.String(), "\x1b[31m✗ Found 1 error(s)\x1b[0m\n")
+		assert.Contains(t, buf.String(), "\x1b[31m✗ ERROR:\x1b[0m ")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		lc := newListCommand()
+		buf := new(bytes.Buffer)
+		lc.SetOut(buf)
+		lc.SetErr(buf)
+		lc.SetArgs([]string{"--file", createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n0 25 * * * /usr/bin/late.sh\n")})
+		require.NoError(t, lc.Execute())
+		assert.Contains(t, buf.String(), "\x1b[1mLINE  EXPRESSION")
+		assert.Contains(t, buf.String(), "\x1b[31m(invalid)         \x1b[0m  /usr/bin/late.sh")
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		dc := newDiffCommand()
+		dc.oldFile = createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+		dc.newFile = createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n*/15 * * * * /usr/bin/check.sh\n")
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		require.NoError(t, dc.runDiff(nil, nil))
+		assert.Contains(t, buf.String(), "\x1b[32m+ */15 * * * *  /usr/bin/check.sh\x1b[0m")
+	})
+
+	t.Run("--no-color", func(t *testing.T) {
+		t.Cleanup(func() { noColor = false })
+		noColor = true
+		cc := newCheckCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 * * *"})
+		require.NoError(t, cc.Execute())
+		assert.Equal(t, "✓ All valid\n  1 job(s) validated\n", buf.String())
+	})
+}
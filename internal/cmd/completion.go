@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/spf13/cobra"
+)
+
+// expressionAliases are the cron aliases offered as the expression argument
+// of explain, next and check
+var expressionAliases = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// crontabFileFlags are the flags completed with crontab-looking files
+var crontabFileFlags = []string{"file", "old-file", "new-file"}
+
+// crontabSniffSize is how much of a file is read to tell whether it is a
+// crontab
+const crontabSniffSize = 4096
+
+// zoneinfoDirs are the usual locations of the IANA time zone database,
+// searched after $ZONEINFO
+var zoneinfoDirs = []string{"/usr/share/zoneinfo", "/usr/share/lib/zoneinfo", "/usr/lib/locale/TZ", "/etc/zoneinfo"}
+
+// commonTimezones are offered when no time zone database is installed
+var commonTimezones = []string{
+	"UTC", "Local",
+	"America/New_York", "America/Chicago", "America/Denver", "America/Los_Angeles", "America/Sao_Paulo",
+	"Europe/London", "Europe/Paris", "Europe/Berlin", "Europe/Madrid", "Europe/Moscow",
+	"Asia/Dubai", "Asia/Kolkata", "Asia/Shanghai", "Asia/Singapore", "Asia/Tokyo",
+	"Australia/Sydney", "Pacific/Auckland",
+}
+
+// registerCompletions adds dynamic completions to cmd and its subcommands:
+// crontab-looking files for --file, --old-file and --new-file, and IANA
+// zone names for --timezone. Flags with a completion already are left alone.
+func registerCompletions(cmd *cobra.Command) {
+	for _, name := range crontabFileFlags {
+		registerFlagCompletion(cmd, name, completeCrontabFiles)
+	}
+	registerFlagCompletion(cmd, "timezone", completeTimezones)
+	for _, sub := range cmd.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+// registerFlagCompletion completes a flag of cmd, if it has one
+func registerFlagCompletion(cmd *cobra.Command, name string, complete func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) {
+	if cmd.Flags().Lookup(name) == nil {
+		return
+	}
+	if _, ok := cmd.GetFlagCompletionFunc(name); ok {
+		return
+	}
+	_ = cmd.RegisterFlagCompletionFunc(name, complete)
+}
+
+// completeExpression completes the expression argument with the cron
+// aliases, described in the current locale
+func completeExpression(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	parser := cronx.NewParserWithLocale(GetLocale())
+	humanizer := newHumanizer()
+	var completions []string
+	for _, alias := range expressionAliases {
+		if !strings.HasPrefix(alias, toComplete) {
+			continue
+		}
+		completion := alias
+		if schedule, err := parser.Parse(alias); err == nil {
+			completion += "\t" + humanizer.Humanize(schedule)
+		}
+		completions = append(completions, completion)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCrontabFiles completes a path with the directories and the
+// crontab-looking files of its directory. When the directory has no crontab,
+// the shell's default file completion is used instead.
+func completeCrontabFiles(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	dir, prefix := filepath.Split(toComplete)
+	entries, err := os.ReadDir(dirOrCurrent(dir))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	var dirs, files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		path := dir + name
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+		case info.IsDir():
+			dirs = append(dirs, path+string(filepath.Separator))
+		case info.Mode().IsRegular() && looksLikeCrontab(path):
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	// Directories end in a separator, so the path can be continued
+	return append(files, dirs...), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+// dirOrCurrent returns dir, or the current directory when it is empty
+func dirOrCurrent(dir string) string {
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// looksLikeCrontab reports whether a file is named like a crontab (crontab,
+// *.cron, *.crontab) or starts with a valid cron job
+func looksLikeCrontab(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	if name == "crontab" || strings.HasSuffix(name, ".cron") || strings.HasSuffix(name, ".crontab") {
+		return true
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	entries, err := crontab.ParseReader(io.LimitReader(file, crontabSniffSize))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil && entry.Job.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// completeTimezones completes IANA time zone names from the system's time
+// zone database
+func completeTimezones(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, zone := range timezoneNames() {
+		if strings.HasPrefix(zone, toComplete) {
+			completions = append(completions, zone)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// timezoneNames returns the zone names of the first time zone database found,
+// sorted, or commonTimezones when there is none
+func timezoneNames() []string {
+	dirs := zoneinfoDirs
+	if dir := os.Getenv("ZONEINFO"); dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+
+	for _, dir := range dirs {
+		names := map[string]bool{"UTC": true, "Local": true}
+		_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || path == dir {
+				return nil
+			}
+			// Zone names are capitalized ("America/New_York", "Etc/GMT+2");
+			// the database's other files ("zone.tab", "posixrules") and
+			// variants ("posix/", "right/") are not
+			if first := entry.Name()[0]; first < 'A' || first > 'Z' || strings.Contains(entry.Name(), ".") {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if entry.Type().IsRegular() || entry.Type()&fs.ModeSymlink != 0 {
+				names[filepath.ToSlash(strings.TrimPrefix(path, dir+string(filepath.Separator)))] = true
+			}
+			return nil
+		})
+		if len(names) > 2 {
+			zones := make([]string, 0, len(names))
+			for name := range names {
+				zones = append(zones, name)
+			}
+			sort.Strings(zones)
+			return zones
+		}
+	}
+	return commonTimezones
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteExpression(t *testing.T) {
+	t.Run("completes aliases with their description", func(t *testing.T) {
+		completions, directive := completeExpression(nil, nil, "@d")
+		assert.Equal(t, []string{"@daily\tAt midnight every day"}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+
+	t.Run("completes every alias from an empty argument", func(t *testing.T) {
+		completions, _ := completeExpression(nil, nil, "")
+		assert.Len(t, completions, len(expressionAliases))
+	})
+
+	t.Run("only completes the first argument", func(t *testing.T) {
+		completions, _ := completeExpression(nil, []string{"@daily"}, "")
+		assert.Empty(t, completions)
+	})
+
+	t.Run("is registered on explain, next and check", func(t *testing.T) {
+		for _, name := range []string{"explain", "next", "check"} {
+			cmd, _, err := rootCmd.Find([]string{name})
+			require.NoError(t, err)
+			assert.NotNil(t, cmd.ValidArgsFunction, name)
+		}
+	})
+}
+
+func TestCompleteCrontabFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	write("jobs.txt", "# Backups\n0 2 * * * /usr/bin/backup.sh\n")
+	write("backup.cron", "")
+	write("notes.txt", "Nothing to schedule here\n")
+	write(".hidden.cron", "")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "cron.d"), 0o755))
+
+	t.Run("completes crontabs and directories", func(t *testing.T) {
+		completions, directive := completeCrontabFiles(nil, nil, dir+"/")
+		assert.Equal(t, []string{
+			filepath.Join(dir, "backup.cron"),
+			filepath.Join(dir, "jobs.txt"),
+			filepath.Join(dir, "cron.d") + string(filepath.Separator),
+		}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoSpace|cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+
+	t.Run("filters by prefix", func(t *testing.T) {
+		completions, _ := completeCrontabFiles(nil, nil, filepath.Join(dir, "j"))
+		assert.Equal(t, []string{filepath.Join(dir, "jobs.txt")}, completions)
+	})
+
+	t.Run("completes hidden files for a dot prefix", func(t *testing.T) {
+		completions, _ := completeCrontabFiles(nil, nil, filepath.Join(dir, ".h"))
+		assert.Equal(t, []string{filepath.Join(dir, ".hidden.cron")}, completions)
+	})
+
+	t.Run("falls back to file completion without crontabs", func(t *testing.T) {
+		completions, directive := completeCrontabFiles(nil, nil, filepath.Join(dir, "n"))
+		assert.Empty(t, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveDefault, directive)
+	})
+
+	t.Run("is registered on file flags", func(t *testing.T) {
+		registerCompletions(rootCmd)
+		for _, args := range [][]string{{"check", "file"}, {"diff", "old-file"}, {"diff", "new-file"}, {"next", "timezone"}} {
+			cmd, _, err := rootCmd.Find(args[:1])
+			require.NoError(t, err)
+			_, ok := cmd.GetFlagCompletionFunc(args[1])
+			assert.True(t, ok, "%s --%s", args[0], args[1])
+		}
+	})
+}
+
+func TestCompleteTimezones(t *testing.T) {
+	t.Run("completes zones from the time zone database", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"America/New_York", "America/Chicago", "Europe/Paris", "posix/Europe/Paris", "zone.tab"} {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte("TZif"), 0o644))
+		}
+		t.Setenv("ZONEINFO", dir)
+
+		completions, directive := completeTimezones(nil, nil, "America/")
+		assert.Equal(t, []string{"America/Chicago", "America/New_York"}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+		completions, _ = completeTimezones(nil, nil, "")
+		assert.Equal(t, []string{"America/Chicago", "America/New_York", "Europe/Paris", "Local", "UTC"}, completions)
+	})
+
+	t.Run("falls back to common zones", func(t *testing.T) {
+		oldDirs := zoneinfoDirs
+		zoneinfoDirs = nil
+		defer func() { zoneinfoDirs = oldDirs }()
+		t.Setenv("ZONEINFO", t.TempDir())
+
+		completions, _ := completeTimezones(nil, nil, "Asia/T")
+		assert.Equal(t, []string{"Asia/Tokyo"}, completions)
+	})
+}
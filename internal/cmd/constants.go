@@ -1,5 +1,7 @@
 package cmd
 
+import "time"
+
 // Next command constants
 const (
 	// DefaultNextCount is the default number of runs to show
@@ -8,6 +10,9 @@ const (
 	MinNextCount = 1
 	// MaxNextCount is the maximum number of runs to show
 	MaxNextCount = 100
+	// MaxHolidaySearch is how far ahead next looks for runs off holidays
+	// with --skip-holidays
+	MaxHolidaySearch = 5 * 366 * 24 * time.Hour
 )
 
 // Check command constants
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/eventbridge"
+	"github.com/hzerrad/cronkit/internal/systemd"
+	"github.com/spf13/cobra"
+)
+
+// Conversion formats
+const (
+	formatCron    = "cron"
+	formatSystemd = "systemd"
+	formatAWS     = "aws"
+)
+
+type ConvertCommand struct {
+	*cobra.Command
+	from string
+	to   string
+	json bool
+}
+
+func newConvertCommand() *ConvertCommand {
+	cc := &ConvertCommand{}
+	cc.Command = &cobra.Command{
+		Use:   "convert <expression>",
+		Short: "Convert between cron, systemd OnCalendar= and EventBridge syntax",
+		Long: `Convert a cron expression to systemd timer OnCalendar= syntax or to an
+Amazon EventBridge schedule expression, or back.
+
+The input is validated, and the result is explained in plain English. When
+the two formats cannot express the same schedule exactly (e.g. seconds, years
+or time zones in OnCalendar=), a warning describes what changed.
+
+Cron runs a job when either the day-of-month or the day-of-week field matches,
+while systemd requires both. Such schedules are converted to two OnCalendar=
+entries, which a timer unit accepts, or to two EventBridge rules, since
+EventBridge requires one of the day fields to be '?'.
+
+EventBridge rate(...) expressions convert to cron when their interval divides
+an hour or a day. EventBridge counts the interval from the creation of the
+rule, while cron runs on the clock.
+
+Examples:
+  cronkit convert "0 9 * * 1-5"
+  cronkit convert "*/15 * * * *" --json
+  cronkit convert --from systemd "Mon..Fri *-*-* 09:00:00"
+  cronkit convert --from systemd "OnCalendar=daily"
+  cronkit convert "0 9 * * 1-5" --to aws
+  cronkit convert --from aws "cron(0/15 * ? * MON-FRI *)"
+  cronkit convert --from aws "rate(5 minutes)"`,
+		Args: cobra.ExactArgs(1),
+		RunE: cc.runConvert,
+	}
+
+	cc.Flags().StringVar(&cc.from, "from", formatCron, "Format of the input expression: 'cron', 'systemd' or 'aws' (EventBridge)")
+	cc.Flags().StringVar(&cc.to, "to", "", "Format to convert cron to: 'systemd' (default) or 'aws'; systemd and aws convert to cron")
+	cc.Flags().BoolVarP(&cc.json, "json", "j", false, "Output in JSON format")
+	return cc
+}
+
+func init() {
+	rootCmd.AddCommand(newConvertCommand().Command)
+}
+
+func (cc *ConvertCommand) runConvert(_ *cobra.Command, args []string) error {
+	parser := cronx.NewParserWithSeconds(GetLocale(), cronx.SecondsOptional)
+	humanizer := newHumanizer()
+
+	from := strings.ToLower(cc.from)
+	to := strings.ToLower(cc.to)
+	if to == "" {
+		to = formatSystemd
+		if from != formatCron {
+			to = formatCron
+		}
+	}
+
+	var (
+		conversion convertResult
+		schedule   *cronx.Schedule
+		err        error
+	)
+
+	switch from {
+	case formatCron:
+		if to != formatSystemd && to != formatAWS {
+			return fmt.Errorf("invalid --to value %q for cron input (supported: systemd, aws)", cc.to)
+		}
+		if schedule, err = parser.Parse(args[0]); err != nil {
+			return fmt.Errorf("failed to parse expression: %w", err)
+		}
+		if to == formatAWS {
+			conversion, err = fromEventBridge(eventbridge.FromCron(schedule))
+		} else {
+			conversion, err = fromSystemd(systemd.FromCron(schedule))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert expression: %w", err)
+		}
+	case formatSystemd, formatAWS:
+		if to != formatCron {
+			return fmt.Errorf("invalid --to value %q for %s input (supported: cron)", cc.to, from)
+		}
+		if from == formatAWS {
+			if conversion, err = fromEventBridge(eventbridge.ToCron(args[0])); err != nil {
+				return fmt.Errorf("failed to convert EventBridge expression: %w", err)
+			}
+		} else if conversion, err = fromSystemd(systemd.ToCron(args[0])); err != nil {
+			return fmt.Errorf("failed to convert OnCalendar expression: %w", err)
+		}
+		if schedule, err = parser.Parse(conversion.Output[0]); err != nil {
+			return fmt.Errorf("failed to parse converted expression: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid --from value %q (supported: cron, systemd, aws)", cc.from)
+	}
+
+	description := humanizer.Humanize(schedule)
+	if cc.json {
+		return cc.outputJSON(to, conversion, description)
+	}
+
+	switch to {
+	case formatSystemd:
+		cc.Println("[Timer]")
+		for _, calendar := range conversion.Output {
+			cc.Printf("OnCalendar=%s\n", calendar)
+		}
+	case formatAWS:
+		for _, rule := range conversion.Output {
+			cc.Println(rule)
+		}
+	default:
+		cc.Println(conversion.Output[0])
+	}
+	cc.Printf("# %s\n", description)
+
+	for _, warning := range conversion.Warnings {
+		cc.Printf("⚠ WARNING: %s\n", warning)
+	}
+	return nil
+}
+
+// convertResult is the result of a conversion in any direction
+type convertResult struct {
+	Input    string
+	Output   []string
+	Warnings []string
+}
+
+// fromSystemd adapts the result of a systemd conversion
+func fromSystemd(c *systemd.Conversion, err error) (convertResult, error) {
+	if err != nil {
+		return convertResult{}, err
+	}
+	return convertResult{Input: c.Input, Output: c.Output, Warnings: c.Warnings}, nil
+}
+
+// fromEventBridge adapts the result of an EventBridge conversion
+func fromEventBridge(c *eventbridge.Conversion, err error) (convertResult, error) {
+	if err != nil {
+		return convertResult{}, err
+	}
+	return convertResult{Input: c.Input, Output: c.Output, Warnings: c.Warnings}, nil
+}
+
+func (cc *ConvertCommand) outputJSON(to string, conversion convertResult, description string) error {
+	warnings := conversion.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	result := map[string]interface{}{
+		"from":        strings.ToLower(cc.from),
+		"to":          to,
+		"input":       conversion.Input,
+		"output":      conversion.Output,
+		"description": description,
+		"warnings":    warnings,
+		"exact":       len(conversion.Warnings) == 0,
+	}
+
+	encoder := json.NewEncoder(cc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
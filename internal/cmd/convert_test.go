@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCommand(t *testing.T) {
+	t.Run("convert command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"convert"})
+		assert.NoError(t, err)
+		assert.Equal(t, "convert", cmd.Name())
+	})
+
+	t.Run("cron to systemd", func(t *testing.T) {
+		cc := newConvertCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 9 * * 1-5"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "[Timer]")
+		assert.Contains(t, output, "OnCalendar=Mon..Fri *-*-* 09:00:00")
+		assert.Contains(t, output, "# At 09:00 on weekdays (Mon-Fri)")
+		assert.NotContains(t, output, "WARNING")
+	})
+
+	t.Run("cron with both day fields uses two entries", func(t *testing.T) {
+		cc := newConvertCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 0 1 * 1"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "OnCalendar=*-*-01 00:00:00\nOnCalendar=Mon *-*-* 00:00:00\n")
+	})
+
+	t.Run("systemd to cron with warnings", func(t *testing.T) {
+		cc := newConvertCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--from", "systemd", "*-*-* 12:00:30 UTC"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "0 12 * * *\n")
+		assert.Contains(t, output, "⚠ WARNING: seconds 30 dropped")
+		assert.Contains(t, output, "⚠ WARNING: time zone UTC dropped")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		cc := newConvertCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--from", "systemd", "weekly", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "systemd", result["from"])
+		assert.Equal(t, "cron", result["to"])
+		assert.Equal(t, []interface{}{"0 0 * * 1"}, result["output"])
+		assert.Equal(t, "At midnight every Monday", result["description"])
+		assert.Equal(t, []interface{}{}, result["warnings"])
+		assert.Equal(t, true, result["exact"])
+	})
+
+	t.Run("invalid cron expression", func(t *testing.T) {
+		cc := newConvertCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"61 * * * *"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse expression")
+	})
+
+	t.Run("unrepresentable OnCalendar expression", func(t *testing.T) {
+		cc := newConvertCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"--from", "systemd", "*-*~01"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be represented in cron")
+	})
+
+	t.Run("cron to EventBridge", func(t *testing.T) {
+		cc := newConvertCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"0 9 * * 1-5", "--to", "aws"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, "cron(0 9 ? * MON-FRI *)\n# At 09:00 on weekdays (Mon-Fri)\n", buf.String())
+	})
+
+	t.Run("EventBridge rate to cron", func(t *testing.T) {
+		cc := newConvertCommand()
+		buf := new(bytes.Buffer)
+		cc.SetOut(buf)
+		cc.SetArgs([]string{"--from", "aws", "rate(15 minutes)", "--json"})
+
+		err := cc.Execute()
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "aws", result["from"])
+		assert.Equal(t, "cron", result["to"])
+		assert.Equal(t, []interface{}{"*/15 * * * *"}, result["output"])
+		assert.Equal(t, false, result["exact"])
+	})
+
+	t.Run("invalid --to", func(t *testing.T) {
+		cc := newConvertCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"--from", "aws", "rate(5 minutes)", "--to", "systemd"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --to value")
+	})
+
+	t.Run("invalid --from", func(t *testing.T) {
+		cc := newConvertCommand()
+		cc.SetOut(new(bytes.Buffer))
+		cc.SetErr(new(bytes.Buffer))
+		cc.SetArgs([]string{"--from", "launchd", "0 0 * * *"})
+
+		err := cc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --from value")
+	})
+}
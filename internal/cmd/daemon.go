@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/daemon"
+	"github.com/hzerrad/cronkit/internal/history"
+	"github.com/hzerrad/cronkit/internal/notify"
+	"github.com/hzerrad/cronkit/internal/runner"
+	"github.com/hzerrad/cronkit/internal/state"
+	"github.com/spf13/cobra"
+)
+
+type DaemonCommand struct {
+	*cobra.Command
+	file            string
+	system          bool
+	timezone        string
+	dryRun          bool
+	noHistory       bool
+	shutdownTimeout time.Duration
+}
+
+func newDaemonCommand() *DaemonCommand {
+	dc := &DaemonCommand{}
+	dc.Command = &cobra.Command{
+		Use:   "daemon",
+		Short: "Schedule and run the jobs of a crontab in the foreground",
+		Long: `Run the jobs of a crontab file at their scheduled times, like a minimal cron
+daemon. This makes cronkit usable as the scheduler of containers that have
+no crond.
+
+Jobs run the way cron runs them (see 'cronkit run'). Every event is logged to
+standard output as one JSON object per line: the daemon's start and stop, each
+job's start, every line of its output, and its finish with exit code and
+duration. A job does not start while its previous run is still running. Jobs
+with invalid expressions or time zones are logged and skipped.
+
+On SIGINT or SIGTERM no further jobs start, and running jobs get
+--shutdown-timeout to finish before they are terminated.
+
+Each run is recorded in the run log of the state directory, shown by
+'cronkit history', unless --no-history is given. With --dry-run, jobs are
+logged when they are due but not run.
+
+When the "notifications" section of the config file configures Slack, a
+webhook or email, every run that exits with a non-zero code or cannot start
+is also sent there, with the last lines of its output.
+
+Examples:
+  cronkit daemon --file /etc/crontabs/app
+  cronkit daemon --file jobs.cron --timezone UTC
+  cronkit daemon --file jobs.cron --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: dc.runDaemon,
+	}
+
+	dc.Flags().StringVarP(&dc.file, "file", "f", "", "Path to crontab file (required)")
+	dc.Flags().BoolVar(&dc.system, "system", false, systemUsage)
+	dc.Flags().StringVar(&dc.timezone, "timezone", "", "Timezone of jobs without CRON_TZ= (default: local timezone)")
+	dc.Flags().BoolVar(&dc.dryRun, "dry-run", false, "Log jobs when they are due without running them")
+	dc.Flags().BoolVar(&dc.noHistory, "no-history", false, "Do not record runs in the run log of the state directory")
+	dc.Flags().DurationVar(&dc.shutdownTimeout, "shutdown-timeout", daemon.DefaultShutdownTimeout, "How long running jobs may take to finish on shutdown")
+	_ = dc.MarkFlagRequired("file")
+	return dc
+}
+
+func init() {
+	rootCmd.AddCommand(newDaemonCommand().Command)
+}
+
+func (dc *DaemonCommand) runDaemon(cmd *cobra.Command, _ []string) error {
+	if dc.shutdownTimeout < 0 {
+		return fmt.Errorf("invalid --shutdown-timeout value: must not be negative")
+	}
+	loc := time.Local
+	if dc.timezone != "" {
+		parsed, err := time.LoadLocation(dc.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		loc = parsed
+	}
+
+	jobs, err := newCrontabReader(dc.system).ReadFile(dc.file)
+	if err != nil {
+		return fmt.Errorf("failed to read crontab file %s: %w", dc.file, err)
+	}
+	env, err := crontab.DefaultEnvironment("")
+	if err != nil {
+		return fmt.Errorf("failed to resolve job environment: %w", err)
+	}
+	notifier, err := notify.New(notifications)
+	if err != nil {
+		return err
+	}
+
+	opts := daemon.Options{
+		Scheduler:       cronx.NewScheduler(),
+		Location:        loc,
+		Environment:     env,
+		DryRun:          dc.dryRun,
+		ShutdownTimeout: dc.shutdownTimeout,
+		Log:             dc.logger(),
+	}
+	if !dc.noHistory && !dc.dryRun {
+		store, err := state.OpenDefault()
+		if err != nil {
+			return fmt.Errorf("%w (use --no-history to run without recording runs)", err)
+		}
+		opts.OnFinish = func(job *crontab.Job, scheduled time.Time, result runner.Result) {
+			if err := history.Append(store, runRecord(job, scheduled, result)); err != nil {
+				opts.Log(daemon.Event{Time: time.Now(), Event: daemon.EventError, Line: job.LineNumber, Message: fmt.Sprintf("failed to record run: %v", err)})
+			}
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if notifier != nil {
+		failures := &failureNotifier{ctx: context.WithoutCancel(ctx), notifier: notifier, file: dc.file, log: opts.Log}
+		defer failures.wait()
+		opts.Log = failures.observe
+	}
+	return daemon.Run(ctx, jobs, opts)
+}
+
+// maxFailureOutput is how many of the last output lines of a failed run are
+// included in its notification
+const maxFailureOutput = 10
+
+// failureNotifier passes daemon events on to log and sends a notification
+// for every run that fails
+type failureNotifier struct {
+	ctx      context.Context
+	notifier *notify.Notifier
+	file     string
+	log      func(daemon.Event)
+
+	mu      sync.Mutex
+	output  map[failureKey][]string // Last output lines of running jobs
+	pending sync.WaitGroup
+}
+
+// failureKey identifies a run
+type failureKey struct {
+	line      int
+	scheduled time.Time
+}
+
+// observe logs event and notifies failed runs. Notifications are sent in
+// the background, so that slow destinations do not hold up jobs.
+func (f *failureNotifier) observe(event daemon.Event) {
+	f.log(event)
+	if event.Expression == "" {
+		return
+	}
+
+	key := failureKey{event.Line, event.Scheduled}
+	f.mu.Lock()
+	if f.output == nil {
+		f.output = make(map[failureKey][]string)
+	}
+	var output []string
+	switch event.Event {
+	case daemon.EventOutput:
+		lines := append(f.output[key], event.Message)
+		if len(lines) > maxFailureOutput {
+			lines = lines[1:]
+		}
+		f.output[key] = lines
+	case daemon.EventFinish, daemon.EventError:
+		output = f.output[key]
+		delete(f.output, key)
+	}
+	f.mu.Unlock()
+
+	failed := event.Event == daemon.EventError || (event.Event == daemon.EventFinish && event.ExitCode != nil && *event.ExitCode != 0)
+	if !failed {
+		return
+	}
+	n := notify.Event{
+		Kind:       notify.EventFailure,
+		Source:     "daemon",
+		Time:       event.Time,
+		Crontab:    f.file,
+		Line:       event.Line,
+		Expression: event.Expression,
+		Command:    event.Command,
+		ExitCode:   event.ExitCode,
+		Details:    strings.Join(output, "\n"),
+	}
+	if event.ExitCode != nil {
+		n.Title = fmt.Sprintf("Job on line %d failed with exit code %d: %s", event.Line, *event.ExitCode, event.Command)
+	} else {
+		n.Title = fmt.Sprintf("Job on line %d could not run: %s", event.Line, event.Message)
+	}
+
+	f.pending.Add(1)
+	go func() {
+		defer f.pending.Done()
+		if err := f.notifier.Notify(f.ctx, n); err != nil {
+			f.log(daemon.Event{Time: time.Now(), Event: daemon.EventError, Line: event.Line, Message: err.Error()})
+		}
+	}()
+}
+
+// wait returns once the notifications being sent are delivered or failed
+func (f *failureNotifier) wait() {
+	f.pending.Wait()
+}
+
+// logger returns a daemon logger writing one JSON event per line
+func (dc *DaemonCommand) logger() func(daemon.Event) {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(dc.OutOrStdout())
+	return func(event daemon.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := encoder.Encode(event); err != nil {
+			dc.PrintErrf("failed to write log event: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/daemon"
+	"github.com/hzerrad/cronkit/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemonCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jobs.cron")
+	require.NoError(t, os.WriteFile(file, []byte("* * * * * /usr/bin/true\n61 * * * * /usr/bin/false\n"), 0o644))
+
+	t.Run("logs JSON events until stopped", func(t *testing.T) {
+		dc := newDaemonCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--file", file, "--dry-run"})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		require.NoError(t, dc.ExecuteContext(ctx))
+
+		var kinds []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var event map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+			kinds = append(kinds, event["event"].(string))
+		}
+		assert.Equal(t, []string{"skipped", "start", "stop"}, kinds)
+	})
+
+	t.Run("requires a file", func(t *testing.T) {
+		dc := newDaemonCommand()
+		dc.SetOut(new(bytes.Buffer))
+		dc.SetErr(new(bytes.Buffer))
+		dc.SetArgs([]string{})
+		assert.Error(t, dc.Execute())
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		dc := newDaemonCommand()
+		dc.SetOut(new(bytes.Buffer))
+		dc.SetErr(new(bytes.Buffer))
+		dc.SetArgs([]string{"--file", file, "--timezone", "Mars/Olympus"})
+		assert.Error(t, dc.Execute())
+	})
+}
+
+func TestFailureNotifier(t *testing.T) {
+	var mu sync.Mutex
+	var sent []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		sent = append(sent, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+	notifier, err := notify.New(notify.Config{Webhook: &notify.HookConfig{URL: server.URL}})
+	require.NoError(t, err)
+
+	var logged []string
+	f := &failureNotifier{ctx: context.Background(), notifier: notifier, file: "jobs.cron", log: func(e daemon.Event) { logged = append(logged, e.Event) }}
+	due := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	event := func(kind, message string, exitCode *int) daemon.Event {
+		return daemon.Event{Time: due, Event: kind, Line: 2, Expression: "0 9 * * *", Command: "/usr/bin/backup.sh", Scheduled: due, Message: message, ExitCode: exitCode}
+	}
+	ok, failed := 0, 3
+
+	f.observe(event(daemon.EventRun, "", nil))
+	f.observe(event(daemon.EventOutput, "copying", nil))
+	f.observe(event(daemon.EventOutput, "disk full", nil))
+	f.observe(event(daemon.EventFinish, "", &failed))
+	f.observe(event(daemon.EventFinish, "", &ok))
+	f.observe(event(daemon.EventError, "fork/exec /bin/sh: no such file or directory", nil))
+	f.observe(daemon.Event{Time: due, Event: daemon.EventError, Line: 2, Message: "failed to record run"})
+	f.wait()
+
+	assert.Equal(t, []string{"run", "output", "output", "finish", "finish", "error", "error"}, logged)
+	require.Len(t, sent, 2, "only failed runs are sent")
+	byTitle := map[string]map[string]interface{}{}
+	for _, body := range sent {
+		byTitle[body["title"].(string)] = body
+	}
+	exited := byTitle["Job on line 2 failed with exit code 3: /usr/bin/backup.sh"]
+	require.NotNil(t, exited)
+	assert.Equal(t, "failure", exited["kind"])
+	assert.Equal(t, "daemon", exited["source"])
+	assert.Equal(t, "jobs.cron", exited["crontab"])
+	assert.Equal(t, "copying\ndisk full", exited["details"])
+	assert.NotNil(t, byTitle["Job on line 2 could not run: fork/exec /bin/sh: no such file or directory"])
+}
@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
 	"github.com/hzerrad/cronkit/internal/diff"
 	"github.com/spf13/cobra"
 )
@@ -17,15 +17,20 @@ type DiffCommand struct {
 	newStdin       bool
 	format         string
 	json           bool
+	outputSchema   bool
 	ignoreComments bool
 	ignoreEnv      bool
 	showUnchanged  bool
+	system         bool
+	from           string
+	to             string
+	semantic       bool
 }
 
 func newDiffCommand() *DiffCommand {
 	dc := &DiffCommand{}
 	dc.Command = &cobra.Command{
-		Use:   "diff [old-file] [new-file]",
+		Use:   "diff [old] [new]",
 		Short: "Compare crontabs semantically",
 		Long: `Compare two crontabs semantically, showing what actually changed.
 
@@ -36,8 +41,31 @@ This command performs semantic comparison (not just line-by-line), identifying:
   - Comment changes
   - Environment variable changes
 
+Each crontab can be read from a file, standard input, the installed user
+crontab, or a file at a git revision, given as an argument or with
+--from/--to:
+  <path>, file:<path>   a crontab file
+  -, stdin              standard input
+  live                  the installed user crontab (crontab -l)
+  git:<rev>:<path>      <path> at git revision <rev>, as read by 'git show'
+                        (relative to the repository root, or to the current
+                        directory when it starts with ./)
+
+With a single argument, the installed user crontab is compared with it,
+showing what installing it would change.
+
+A job whose expression changed is shown as a removed and an added job. With
+--semantic, it is shown as a modified job instead, and its old and new
+expressions are compared by their runs: a change to an expression with the
+same runs (e.g. 0 0 * * * to @daily) is reported as a no-op, and a change of
+frequency as e.g. "was 24 runs/day, now 1 run/day".
+
 Examples:
   cronkit diff old.cron new.cron
+  cronkit diff new.cron                                  # live vs new.cron
+  cronkit diff --from git:HEAD~1:crontab --to live
+  cronkit diff git:main:ops/crontab ops/crontab
+  cronkit diff old.cron new.cron --semantic
   cronkit diff --old-file old.cron --new-file new.cron --json
   cronkit diff --old-stdin --new-file new.cron
   cronkit diff old.cron new.cron --format unified`,
@@ -49,11 +77,16 @@ Examples:
 	dc.Flags().StringVar(&dc.newFile, "new-file", "", "Path to new crontab file")
 	dc.Flags().BoolVar(&dc.oldStdin, "old-stdin", false, "Read old crontab from standard input")
 	dc.Flags().BoolVar(&dc.newStdin, "new-stdin", false, "Read new crontab from standard input")
+	dc.Flags().StringVar(&dc.from, "from", "", "Old crontab source: path, '-', 'live' or git:<rev>:<path>")
+	dc.Flags().StringVar(&dc.to, "to", "", "New crontab source: path, '-', 'live' or git:<rev>:<path>")
 	dc.Flags().StringVar(&dc.format, "format", "text", "Output format: 'text' (default), 'json', or 'unified'")
 	dc.Flags().BoolVarP(&dc.json, "json", "j", false, "Output in JSON format (shorthand for --format json)")
+	dc.Flags().BoolVar(&dc.outputSchema, "output-schema", false, outputSchemaUsage)
 	dc.Flags().BoolVar(&dc.ignoreComments, "ignore-comments", false, "Ignore comment-only changes")
 	dc.Flags().BoolVar(&dc.ignoreEnv, "ignore-env", false, "Ignore environment variable changes")
 	dc.Flags().BoolVar(&dc.showUnchanged, "show-unchanged", false, "Show unchanged jobs (default: false)")
+	dc.Flags().BoolVar(&dc.system, "system", false, systemUsage)
+	dc.Flags().BoolVar(&dc.semantic, "semantic", false, "Match jobs whose schedule changed by command and compare the runs of their old and new expressions")
 
 	return dc
 }
@@ -63,81 +96,36 @@ func init() {
 }
 
 func (dc *DiffCommand) runDiff(_ *cobra.Command, args []string) error {
-	reader := crontab.NewReader()
-
-	// Determine old crontab source
-	var oldEntries []*crontab.Entry
-	var err error
-
-	if dc.oldStdin {
-		// Read from stdin manually to support command input
-		inputReader := dc.InOrStdin()
-		scanner := bufio.NewScanner(inputReader)
-		lineNumber := 0
-		oldEntries = make([]*crontab.Entry, 0)
-		for scanner.Scan() {
-			lineNumber++
-			line := scanner.Text()
-			entry := crontab.ParseLine(line, lineNumber)
-			oldEntries = append(oldEntries, entry)
-		}
-		if err = scanner.Err(); err != nil {
-			return fmt.Errorf("failed to read old crontab from stdin: %w", err)
-		}
-	} else if dc.oldFile != "" {
-		oldEntries, err = reader.ParseFile(dc.oldFile)
-		if err != nil {
-			return fmt.Errorf("failed to read old crontab file: %w", err)
-		}
-	} else if len(args) >= 1 {
-		oldEntries, err = reader.ParseFile(args[0])
-		if err != nil {
-			return fmt.Errorf("failed to read old crontab file: %w", err)
-		}
-	} else {
-		return fmt.Errorf("must specify old crontab source (--old-file, --old-stdin, or positional argument)")
-	}
-
-	// Determine new crontab source
-	var newEntries []*crontab.Entry
-
-	if dc.newStdin {
-		// Read from stdin manually to support command input
-		inputReader := dc.InOrStdin()
-		scanner := bufio.NewScanner(inputReader)
-		lineNumber := 0
-		newEntries = make([]*crontab.Entry, 0)
-		for scanner.Scan() {
-			lineNumber++
-			line := scanner.Text()
-			entry := crontab.ParseLine(line, lineNumber)
-			newEntries = append(newEntries, entry)
-		}
-		if err = scanner.Err(); err != nil {
-			return fmt.Errorf("failed to read new crontab from stdin: %w", err)
-		}
-	} else if dc.newFile != "" {
-		newEntries, err = reader.ParseFile(dc.newFile)
-		if err != nil {
-			return fmt.Errorf("failed to read new crontab file: %w", err)
-		}
-	} else if len(args) >= 2 {
-		newEntries, err = reader.ParseFile(args[1])
-		if err != nil {
-			return fmt.Errorf("failed to read new crontab file: %w", err)
-		}
-	} else if len(args) == 1 && !dc.oldStdin && dc.oldFile == "" {
-		// If only one arg and old wasn't specified, treat it as new
-		newEntries, err = reader.ParseFile(args[0])
-		if err != nil {
-			return fmt.Errorf("failed to read new crontab file: %w", err)
-		}
-	} else {
-		return fmt.Errorf("must specify new crontab source (--new-file, --new-stdin, or positional argument)")
+	if dc.outputSchema {
+		return writeSchema(dc.OutOrStdout(), "diff")
+	}
+
+	oldSource, newSource, err := dc.sources(args)
+	if err != nil {
+		return err
+	}
+	if oldSource.Kind == diff.SourceStdin && newSource.Kind == diff.SourceStdin {
+		return fmt.Errorf("only one crontab can be read from standard input")
+	}
+
+	layout := crontab.LayoutAuto
+	if dc.system {
+		layout = crontab.LayoutSystem
+	}
+	oldEntries, err := oldSource.Entries(dc.InOrStdin(), layout)
+	if err != nil {
+		return fmt.Errorf("failed to read old crontab %s: %w", oldSource, err)
+	}
+	newEntries, err := newSource.Entries(dc.InOrStdin(), layout)
+	if err != nil {
+		return fmt.Errorf("failed to read new crontab %s: %w", newSource, err)
 	}
 
 	// Perform semantic diff
 	result := diff.CompareCrontabs(oldEntries, newEntries)
+	if dc.semantic {
+		diff.AnalyzeSchedules(result, cronx.NewScheduler())
+	}
 
 	// Determine output format
 	outputFormat := dc.format
@@ -152,16 +140,82 @@ func (dc *DiffCommand) runDiff(_ *cobra.Command, args []string) error {
 	}
 
 	// Render output
+	output := dc.OutOrStdout()
 	options := &diff.RenderOptions{
 		ShowUnchanged:  dc.showUnchanged,
 		IgnoreComments: dc.ignoreComments,
 		IgnoreEnv:      dc.ignoreEnv,
+		Painter:        painterFor(output),
 	}
 
-	output := dc.OutOrStdout()
 	if err := renderer.Render(output, result, options); err != nil {
 		return fmt.Errorf("failed to render diff: %w", err)
 	}
 
 	return nil
 }
+
+// sources returns the old and new crontab sources: --old-stdin, --old-file
+// or --from, and --new-stdin, --new-file or --to, with the positional
+// arguments filling in those not given by flags. A single positional
+// argument without an old source is compared with the live user crontab.
+func (dc *DiffCommand) sources(args []string) (oldSource, newSource diff.Source, err error) {
+	oldSpec, err := pickSource("old", dc.oldStdin, dc.oldFile, dc.from)
+	if err != nil {
+		return diff.Source{}, diff.Source{}, err
+	}
+	newSpec, err := pickSource("new", dc.newStdin, dc.newFile, dc.to)
+	if err != nil {
+		return diff.Source{}, diff.Source{}, err
+	}
+
+	switch {
+	case oldSpec == "" && newSpec == "" && len(args) == 1:
+		oldSpec, newSpec = string(diff.SourceLive), args[0]
+	case oldSpec == "" && newSpec == "" && len(args) == 2:
+		oldSpec, newSpec = args[0], args[1]
+	case len(args) > 0 && oldSpec != "" && newSpec != "", len(args) > 1 && (oldSpec != "" || newSpec != ""):
+		return diff.Source{}, diff.Source{}, fmt.Errorf("too many crontab sources: %d argument(s) in addition to flags", len(args))
+	case oldSpec == "" && len(args) == 1:
+		oldSpec = args[0]
+	case newSpec == "" && len(args) == 1:
+		newSpec = args[0]
+	}
+	if oldSpec == "" {
+		return diff.Source{}, diff.Source{}, fmt.Errorf("must specify old crontab source (--from, --old-file, --old-stdin, or positional argument)")
+	}
+	if newSpec == "" {
+		return diff.Source{}, diff.Source{}, fmt.Errorf("must specify new crontab source (--to, --new-file, --new-stdin, or positional argument)")
+	}
+
+	if oldSource, err = diff.ParseSource(oldSpec); err != nil {
+		return diff.Source{}, diff.Source{}, err
+	}
+	if newSource, err = diff.ParseSource(newSpec); err != nil {
+		return diff.Source{}, diff.Source{}, err
+	}
+	return oldSource, newSource, nil
+}
+
+// pickSource returns the source specification given by the stdin, file and
+// source flags of one side of the diff, or "" if none is set
+func pickSource(side string, stdin bool, file, spec string) (string, error) {
+	given := 0
+	result := ""
+	if stdin {
+		given++
+		result = string(diff.SourceStdin)
+	}
+	if file != "" {
+		given++
+		result = "file:" + file
+	}
+	if spec != "" {
+		given++
+		result = spec
+	}
+	if given > 1 {
+		return "", fmt.Errorf("more than one %s crontab source given", side)
+	}
+	return result, nil
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -308,3 +310,115 @@ func TestDiffCommand_Additional(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestDiffCommand_Sources(t *testing.T) {
+	oldContent := "0 2 * * * /usr/bin/backup.sh\n"
+	newContent := "0 2 * * * /usr/bin/backup.sh\n*/15 * * * * /usr/bin/check.sh\n"
+
+	run := func(t *testing.T, stdin string, args ...string) (string, error) {
+		dc := newDiffCommand()
+		var buf bytes.Buffer
+		dc.SetOut(&buf)
+		dc.SetErr(new(bytes.Buffer))
+		dc.SetIn(strings.NewReader(stdin))
+		dc.SetArgs(args)
+		err := dc.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("single argument is compared with the live crontab", func(t *testing.T) {
+		fakeCrontab(t, oldContent)
+		output, err := run(t, "", createTempFile(t, newContent))
+		require.NoError(t, err)
+		assert.Contains(t, output, "Added Jobs (1)")
+		assert.Contains(t, output, "/usr/bin/check.sh")
+	})
+
+	t.Run("from file to live", func(t *testing.T) {
+		fakeCrontab(t, newContent)
+		output, err := run(t, "", "--from", createTempFile(t, oldContent), "--to", "live")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Added Jobs (1)")
+	})
+
+	t.Run("dash reads stdin", func(t *testing.T) {
+		output, err := run(t, oldContent, "-", createTempFile(t, newContent))
+		require.NoError(t, err)
+		assert.Contains(t, output, "Added Jobs (1)")
+	})
+
+	t.Run("flag and positional argument", func(t *testing.T) {
+		output, err := run(t, "", "--from", createTempFile(t, oldContent), createTempFile(t, newContent))
+		require.NoError(t, err)
+		assert.Contains(t, output, "Added Jobs (1)")
+	})
+
+	t.Run("git revisions", func(t *testing.T) {
+		repo := t.TempDir()
+		git := func(args ...string) {
+			cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+			cmd.Dir = repo
+			output, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(output))
+		}
+		git("init", "-q")
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "crontab"), []byte(oldContent), 0o644))
+		git("add", "crontab")
+		git("commit", "-q", "-m", "first")
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "crontab"), []byte(newContent), 0o644))
+		git("commit", "-q", "-am", "second")
+		t.Chdir(repo)
+
+		output, err := run(t, "", "--from", "git:HEAD~1:crontab", "--to", "git:HEAD:crontab", "--json")
+		require.NoError(t, err)
+		assert.Contains(t, output, `"added": 1`)
+
+		_, err = run(t, "", "git:nosuchrev:crontab", "crontab")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read old crontab git:nosuchrev:crontab")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		file := createTempFile(t, oldContent)
+
+		_, err := run(t, "", "-", "stdin")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only one crontab can be read from standard input")
+
+		_, err = run(t, "", "--from", file, "--old-file", file, file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "more than one old crontab source")
+
+		_, err = run(t, "", "--from", file, "--to", file, file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too many crontab sources")
+
+		_, err = run(t, "", "git:HEAD", file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid git source")
+	})
+}
+
+func TestDiffCommand_Semantic(t *testing.T) {
+	oldFile := createTempFile(t, "0 * * * * /usr/bin/poll.sh\n0 0 * * * /usr/bin/backup.sh\n")
+	newFile := createTempFile(t, "0 0 * * * /usr/bin/poll.sh\n@daily /usr/bin/backup.sh\n")
+
+	dc := newDiffCommand()
+	var buf bytes.Buffer
+	dc.SetOut(&buf)
+	dc.SetArgs([]string{oldFile, newFile, "--semantic"})
+	require.NoError(t, dc.Execute())
+
+	output := buf.String()
+	assert.NotContains(t, output, "Added Jobs")
+	assert.Contains(t, output, "Modified Jobs (2)")
+	assert.Contains(t, output, "Frequency: was 24 runs/day, now 1 run/day")
+	assert.Contains(t, output, "Same schedule: no-op change")
+
+	dc = newDiffCommand()
+	buf.Reset()
+	dc.SetOut(&buf)
+	dc.SetArgs([]string{oldFile, newFile})
+	require.NoError(t, dc.Execute())
+	assert.Contains(t, buf.String(), "Added Jobs (2)")
+}
@@ -1,10 +1,11 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 	"io"
+	"iter"
 	"os"
+	"strings"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/doc"
@@ -13,13 +14,22 @@ import (
 
 type DocCommand struct {
 	*cobra.Command
-	file            string
+	files           []string
+	system          bool
+	dirs            []string
+	host            string
 	stdin           bool
 	output          string
 	format          string
+	outputSchema    bool
 	includeNext     int
 	includeWarnings bool
 	includeStats    bool
+	skipInvalid     bool
+	expand          bool
+	template        string
+	title           string
+	logo            string
 }
 
 func newDocCommand() *DocCommand {
@@ -29,27 +39,79 @@ func newDocCommand() *DocCommand {
 		Short: "Generate documentation from crontab files",
 		Long: `Generate human-readable documentation from crontab files.
 
-This command creates markdown, HTML, or JSON documentation that includes:
+This command creates markdown, HTML, JSON, PDF or man page documentation
+that includes:
   - Job summaries with descriptions
   - Schedule details
   - Command information
   - Optional: next runs, warnings, and statistics
 
+With --format mermaid, the upcoming runs of each job are drawn as a Mermaid
+gantt chart instead (one section per job, --include-next runs each, 5 by
+default), fenced as a code block that renders in GitHub markdown; paste the
+chart body into Confluence's Mermaid macro.
+
+With --format html, the page is self-contained: the job table can be
+filtered with a search box and sorted by clicking its headers, and a timeline
+draws the runs of the next 24 hours or 7 days, marking runs that overlap
+other jobs.
+
+With --format pdf, the document is a PDF file to attach to change requests.
+With --format man, it is a roff man page for section 5 named after the
+crontab file, which servers can install as /usr/share/man/man5/<name>.5 and
+show with "man 5 <name>".
+
+With --template, the document is rendered with a Go template instead of a
+built-in format, so it can match a team's runbook format. The template gets
+the document model (the fields of --format json) and helper functions such as
+jobGroups and lineLocation; given a directory, its *.tmpl files are parsed
+together and doc.tmpl is rendered. Templates named *.html are HTML-escaped.
+--title and --logo set the document's title and a logo shown above it.
+
+Invalid lines are listed in a "Skipped Lines" section instead of the job
+table; use --skip-invalid=false to abort on the first invalid line instead.
+
+Several crontabs are documented together by repeating --file and adding
+--dir for directories of crontabs, such as /etc/cron.d or the per-user
+crontabs of /var/spool/cron/crontabs. The document then has a section per
+file, and overlap warnings cover jobs of different files.
+
+With --host, the document covers all scheduled work of the host: /etc/crontab,
+/etc/cron.d, /etc/anacrontab, and the scripts of /etc/cron.hourly, daily,
+weekly and monthly with the schedule of the line that runs them.
+
 Examples:
   cronkit doc --file /etc/crontab --output docs.md
   cronkit doc --file crontab.txt --format html --output docs.html
-  cronkit doc --stdin --format json --include-next 5`,
+  cronkit doc --stdin --format json --include-next 5
+  cronkit doc --file /etc/crontab --format mermaid --include-next 3 >> RUNBOOK.md
+  cronkit doc --file /etc/crontab --dir /etc/cron.d --output system-cron.md
+  cronkit doc --host --format html --output host-cron.html
+  cronkit doc --file /etc/cron.d/backup --format pdf --output backup-cron.pdf
+  cronkit doc --file /etc/cron.d/backup --format man --output /usr/share/man/man5/backup.5
+  cronkit doc --file /etc/crontab --template runbook.md.tmpl --title "Billing cron jobs"
+  cronkit doc --file /etc/crontab --format html --logo https://example.com/logo.png`,
 		RunE: dc.runDoc,
 		Args: cobra.NoArgs,
 	}
 
-	dc.Flags().StringVarP(&dc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	dc.Flags().StringArrayVarP(&dc.files, "file", "f", nil, "Path to crontab file, repeatable (defaults to user's crontab if no --file or --dir is given)")
+	dc.Flags().BoolVar(&dc.system, "system", false, systemUsage)
+	dc.Flags().StringArrayVar(&dc.dirs, "dir", nil, "Directory of crontab files to include, such as /etc/cron.d (repeatable)")
+	dc.Flags().StringVar(&dc.host, "host", "", hostUsage)
+	dc.Flags().Lookup("host").NoOptDefVal = defaultHostDir
 	dc.Flags().BoolVar(&dc.stdin, "stdin", false, "Read crontab from standard input")
 	dc.Flags().StringVarP(&dc.output, "output", "o", "", "Output file path (defaults to stdout)")
-	dc.Flags().StringVar(&dc.format, "format", "md", "Output format: 'md' (markdown), 'html', or 'json'")
+	dc.Flags().StringVar(&dc.format, "format", "md", "Output format: 'md' (markdown), 'html', 'json', 'mermaid' (gantt chart of upcoming runs), 'pdf', or 'man' (roff man page, section 5)")
+	dc.Flags().BoolVar(&dc.outputSchema, "output-schema", false, outputSchemaUsage)
 	dc.Flags().IntVar(&dc.includeNext, "include-next", 0, "Include next N runs per job (0 = disabled)")
-	dc.Flags().BoolVar(&dc.includeWarnings, "include-warnings", false, "Include validation warnings")
+	dc.Flags().BoolVar(&dc.includeWarnings, "include-warnings", false, "Include check engine issues as severity badges linked to code docs")
 	dc.Flags().BoolVar(&dc.includeStats, "include-stats", false, "Include frequency statistics")
+	dc.Flags().BoolVar(&dc.skipInvalid, "skip-invalid", true, skipInvalidUsage)
+	dc.Flags().BoolVar(&dc.expand, "expand", false, expandUsage)
+	dc.Flags().StringVar(&dc.template, "template", "", "Go template file, or directory of templates with a doc.tmpl, to render the document with instead of --format")
+	dc.Flags().StringVar(&dc.title, "title", "", "Document title (default \"Crontab Documentation\")")
+	dc.Flags().StringVar(&dc.logo, "logo", "", "URL or path of a logo image shown above the title")
 
 	return dc
 }
@@ -59,86 +121,87 @@ func init() {
 }
 
 func (dc *DocCommand) runDoc(_ *cobra.Command, _ []string) error {
+	if dc.outputSchema {
+		return writeSchema(dc.OutOrStdout(), "doc")
+	}
+
 	// Validate format
-	if dc.format != "md" && dc.format != "html" && dc.format != "json" {
-		return fmt.Errorf("invalid format: %s (must be 'md', 'html', or 'json')", dc.format)
+	switch dc.format {
+	case "md", "html", "json", "mermaid", "pdf", "man":
+	default:
+		return fmt.Errorf("invalid format: %s (must be 'md', 'html', 'json', 'mermaid', 'pdf', or 'man')", dc.format)
+	}
+	if dc.template != "" && dc.Flags().Changed("format") {
+		return fmt.Errorf("--template cannot be used with --format")
 	}
 
 	// Create generator
 	generator := doc.NewGenerator(GetLocale())
-	reader := crontab.NewReader()
+	reader := newCrontabReader(dc.system)
 
-	var entries []*crontab.Entry
-	var source string
-	var err error
+	sources, err := dc.readSources(reader)
+	if err != nil {
+		return err
+	}
 
-	// Determine input source
-	if dc.stdin {
-		// Read from command's input (for testability) or os.Stdin
-		inputReader := dc.InOrStdin()
-		if inputReader != os.Stdin {
-			// Read from command's input stream
-			scanner := bufio.NewScanner(inputReader)
-			lineNumber := 0
-			entries = make([]*crontab.Entry, 0)
-			for scanner.Scan() {
-				lineNumber++
-				line := scanner.Text()
-				entry := crontab.ParseLine(line, lineNumber)
-				entries = append(entries, entry)
+	for _, source := range sources {
+		if _, _, err := partitionEntries(source.Entries, dc.skipInvalid); err != nil {
+			if len(sources) > 1 {
+				return fmt.Errorf("%s: %w", source.Name, err)
 			}
-			if err = scanner.Err(); err != nil {
-				return fmt.Errorf("failed to read crontab from stdin: %w", err)
-			}
-		} else {
-			entries, err = reader.ParseStdin()
-		}
-		source = "stdin"
-	} else if dc.file != "" {
-		entries, err = reader.ParseFile(dc.file)
-		source = dc.file
-	} else {
-		// Read user crontab
-		jobs, err := reader.ReadUser()
-		if err != nil {
-			return fmt.Errorf("failed to read user crontab: %w", err)
-		}
-		entries = make([]*crontab.Entry, 0, len(jobs))
-		for _, job := range jobs {
-			entries = append(entries, &crontab.Entry{
-				Type:       crontab.EntryTypeJob,
-				LineNumber: job.LineNumber,
-				Job:        job,
-			})
+			return err
 		}
-		source = "user crontab"
 	}
 
+	// Generate document
+	env, err := jobEnvironment(dc.expand)
 	if err != nil {
-		return fmt.Errorf("failed to read crontab: %w", err)
+		return err
+	}
+
+	includeNext := dc.includeNext
+	if dc.format == "mermaid" && includeNext == 0 {
+		includeNext = doc.MermaidDefaultRuns
 	}
 
-	// Generate document
 	options := doc.GenerateOptions{
-		IncludeNext:     dc.includeNext,
+		IncludeNext:     includeNext,
 		IncludeWarnings: dc.includeWarnings,
 		IncludeStats:    dc.includeStats,
+		SkipInvalid:     dc.skipInvalid,
+		IncludeTimeline: dc.format == "html" || dc.template != "",
+		Env:             env,
 	}
 
-	document, err := generator.GenerateDocument(entries, source, options)
+	document, err := generator.GenerateSources(sources, options)
 	if err != nil {
 		return fmt.Errorf("failed to generate document: %w", err)
 	}
+	if dc.title != "" {
+		document.Title = dc.title
+	}
+	document.Logo = dc.logo
 
 	// Select renderer
 	var renderer doc.Renderer
-	switch dc.format {
-	case "md":
+	switch {
+	case dc.template != "":
+		renderer, err = doc.NewTemplateRenderer(dc.template)
+		if err != nil {
+			return err
+		}
+	case dc.format == "md":
 		renderer = &doc.MarkdownRenderer{}
-	case "html":
+	case dc.format == "html":
 		renderer = &doc.HTMLRenderer{}
-	case "json":
+	case dc.format == "json":
 		renderer = &doc.JSONRenderer{}
+	case dc.format == "mermaid":
+		renderer = &doc.MermaidRenderer{}
+	case dc.format == "pdf":
+		renderer = &doc.PDFRenderer{}
+	case dc.format == "man":
+		renderer = &doc.ManRenderer{}
 	}
 
 	// Determine output destination
@@ -164,3 +227,86 @@ func (dc *DocCommand) runDoc(_ *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// readSources reads the crontabs to document: the host's, standard input,
+// the --file and --dir crontabs, or the user's crontab
+func (dc *DocCommand) readSources(reader crontab.Reader) ([]doc.Source, error) {
+	if dc.host != "" {
+		if dc.stdin || len(dc.files) > 0 || len(dc.dirs) > 0 {
+			return nil, fmt.Errorf("--host cannot be used with --file, --dir or --stdin")
+		}
+		hostSources, err := readHost(dc.host)
+		if err != nil {
+			return nil, err
+		}
+		sources := make([]doc.Source, len(hostSources))
+		for i, source := range hostSources {
+			sources[i] = doc.Source{Name: source.Name, Entries: source.Entries}
+		}
+		return sources, nil
+	}
+
+	if dc.stdin {
+		entries, err := documentedEntries(dc.readStdin(reader))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab: %w", err)
+		}
+		return []doc.Source{{Name: "stdin", Entries: entries}}, nil
+	}
+
+	if len(dc.files) == 0 && len(dc.dirs) == 0 {
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		return []doc.Source{{Name: "user crontab", Entries: crontab.JobEntries(jobs)}}, nil
+	}
+
+	paths := append([]string{}, dc.files...)
+	for _, dir := range dc.dirs {
+		files, err := crontab.ListDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab directory: %w", err)
+		}
+		paths = append(paths, files...)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no crontab files found in %s", strings.Join(dc.dirs, ", "))
+	}
+
+	sources := make([]doc.Source, 0, len(paths))
+	for _, path := range paths {
+		entries, err := documentedEntries(reader.FileEntries(dc.Context(), path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab: %w", err)
+		}
+		sources = append(sources, doc.Source{Name: path, Entries: entries})
+	}
+	return sources, nil
+}
+
+// readStdin reads a crontab from the command's input (for testability) or
+// os.Stdin
+func (dc *DocCommand) readStdin(reader crontab.Reader) iter.Seq2[*crontab.Entry, error] {
+	inputReader := dc.InOrStdin()
+	if inputReader == os.Stdin {
+		return reader.StdinEntries(dc.Context())
+	}
+	return readErrors(crontab.Entries(dc.Context(), inputReader, crontabLayout(dc.system)), "failed to read crontab from stdin")
+}
+
+// documentedEntries collects the entries a document covers as they are read:
+// jobs, annotated with the lines before them, and invalid lines. Comments
+// and variables are dropped, so large crontabs only hold their jobs.
+func documentedEntries(entries iter.Seq2[*crontab.Entry, error]) ([]*crontab.Entry, error) {
+	var kept []*crontab.Entry
+	for entry, err := range entries {
+		if err != nil {
+			return nil, err
+		}
+		if entry.Type == crontab.EntryTypeJob || entry.Type == crontab.EntryTypeInvalid {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, nil
+}
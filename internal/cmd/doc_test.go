@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/hzerrad/cronkit/internal/doc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -68,6 +70,8 @@ func TestDocCommand(t *testing.T) {
 		assert.Contains(t, output, "<!DOCTYPE html>")
 		assert.Contains(t, output, "<html>")
 		assert.Contains(t, output, "<title>Crontab Documentation</title>")
+		assert.Contains(t, output, `id="timeline-data"`)
+		assert.Contains(t, output, `id="filter"`)
 	})
 
 	t.Run("should generate JSON from file", func(t *testing.T) {
@@ -86,6 +90,50 @@ func TestDocCommand(t *testing.T) {
 		assert.Contains(t, output, `"Jobs"`)
 	})
 
+	t.Run("should generate PDF from file", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+
+		testFile := filepath.Join("..", "..", "testdata", "crontab", "valid", "sample.cron")
+		dc.SetArgs([]string{"--file", testFile, "--format", "pdf"})
+
+		require.NoError(t, dc.Execute())
+		assert.True(t, strings.HasPrefix(buf.String(), "%PDF-1.4\n"))
+		assert.True(t, strings.HasSuffix(buf.String(), "%%EOF\n"))
+	})
+
+	t.Run("should generate man page named after the file", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+
+		testFile := filepath.Join("..", "..", "testdata", "crontab", "valid", "sample.cron")
+		dc.SetArgs([]string{"--file", testFile, "--format", "man"})
+
+		require.NoError(t, dc.Execute())
+		assert.True(t, strings.HasPrefix(buf.String(), `.TH "SAMPLE.CRON" 5 `))
+		assert.Contains(t, buf.String(), ".SH JOBS\n")
+	})
+
+	t.Run("should generate mermaid gantt chart from file", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+
+		testFile := filepath.Join("..", "..", "testdata", "crontab", "valid", "sample.cron")
+		dc.SetArgs([]string{"--file", testFile, "--format", "mermaid", "--include-next", "2"})
+
+		err := dc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.True(t, strings.HasPrefix(output, "```mermaid\ngantt\n"))
+		assert.Contains(t, output, "dateFormat YYYY-MM-DD HH:mm")
+		assert.Contains(t, output, "    section Line ")
+		assert.Contains(t, output, ", 1m\n")
+	})
+
 	t.Run("should write to output file", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		outputFile := filepath.Join(tmpDir, "output.md")
@@ -120,6 +168,22 @@ func TestDocCommand(t *testing.T) {
 		assert.Contains(t, output, "check.sh")
 	})
 
+	t.Run("should annotate jobs read from stdin", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+
+		dc.SetIn(strings.NewReader("# cronkit:name=backup owner=infra\n0 2 * * * /usr/bin/backup.sh\n"))
+		dc.SetArgs([]string{"--stdin", "--format", "json"})
+		require.NoError(t, dc.Execute())
+
+		var document doc.Document
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+		require.Len(t, document.Jobs, 1)
+		require.NotNil(t, document.Jobs[0].Metadata)
+		assert.Equal(t, "infra", document.Jobs[0].Metadata.Owner)
+	})
+
 	t.Run("should include next runs when requested", func(t *testing.T) {
 		dc := newDocCommand()
 		buf := new(bytes.Buffer)
@@ -285,3 +349,102 @@ func TestDocCommand(t *testing.T) {
 		assert.Contains(t, buf.String(), "# Crontab Documentation")
 	})
 }
+
+func TestDocCommand_Sources(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "backup"), []byte("0 2 * * * /usr/bin/backup.sh\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report"), []byte("garbage\n0 3 * * * /usr/bin/report.sh\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report~"), []byte("0 4 * * * /usr/bin/old.sh\n"), 0o644))
+	systemFile := createTempFile(t, "*/5 * * * * /usr/bin/poll\n")
+
+	t.Run("merges files and directories with a section per source", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--file", systemFile, "--dir", dir})
+
+		require.NoError(t, dc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "## Sources")
+		assert.Contains(t, output, "### "+systemFile+"\n")
+		assert.Contains(t, output, "### "+filepath.Join(dir, "backup")+"\n")
+		assert.Contains(t, output, "### Job at "+filepath.Join(dir, "report")+" line 2")
+		assert.Contains(t, output, filepath.Join(dir, "report")+" line 1: `garbage`")
+		assert.NotContains(t, output, "old.sh")
+	})
+
+	t.Run("json records the source of each job", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--file", systemFile, "--file", filepath.Join(dir, "backup"), "--format", "json"})
+
+		require.NoError(t, dc.Execute())
+		assert.Contains(t, buf.String(), `"Source": "`+filepath.Join(dir, "backup")+`"`)
+		assert.Contains(t, buf.String(), `"Sections"`)
+	})
+
+	t.Run("a single file has no sections", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--file", systemFile})
+
+		require.NoError(t, dc.Execute())
+		assert.NotContains(t, buf.String(), "## Sources")
+		assert.Contains(t, buf.String(), "### Job at Line 1")
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		dc := newDocCommand()
+		dc.SetOut(new(bytes.Buffer))
+		dc.SetErr(new(bytes.Buffer))
+		dc.SetArgs([]string{"--dir", t.TempDir()})
+
+		assert.ErrorContains(t, dc.Execute(), "no crontab files found")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		dc := newDocCommand()
+		dc.SetOut(new(bytes.Buffer))
+		dc.SetErr(new(bytes.Buffer))
+		dc.SetArgs([]string{"--dir", filepath.Join(dir, "missing")})
+
+		assert.ErrorContains(t, dc.Execute(), "failed to read crontab directory")
+	})
+}
+
+func TestDocCommand_Template(t *testing.T) {
+	crontabFile := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+	tmpl := filepath.Join(t.TempDir(), "runbook.md.tmpl")
+	require.NoError(t, os.WriteFile(tmpl, []byte("# {{.Title}}\n{{range .Jobs}}* {{.Expression}} {{.Command}}\n{{end}}"), 0o644))
+
+	t.Run("renders the document with the template and title", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--file", crontabFile, "--template", tmpl, "--title", "Billing"})
+
+		require.NoError(t, dc.Execute())
+		assert.Equal(t, "# Billing\n* 0 2 * * * /usr/bin/backup.sh\n", buf.String())
+	})
+
+	t.Run("rejects --format", func(t *testing.T) {
+		dc := newDocCommand()
+		dc.SetOut(new(bytes.Buffer))
+		dc.SetErr(new(bytes.Buffer))
+		dc.SetArgs([]string{"--file", crontabFile, "--template", tmpl, "--format", "html"})
+
+		assert.ErrorContains(t, dc.Execute(), "--template cannot be used with --format")
+	})
+
+	t.Run("adds the logo to built-in formats", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--file", crontabFile, "--logo", "https://example.com/logo.png"})
+
+		require.NoError(t, dc.Execute())
+		assert.Contains(t, buf.String(), "![logo](https://example.com/logo.png)")
+	})
+}
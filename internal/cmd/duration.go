@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// defaultDurationUsage is the help text of the --default-duration flag of
+// overlap analysis commands
+const defaultDurationUsage = "Expected run duration of jobs without a '# cronkit:duration=' directive (e.g., 5m); by default such runs last their start minute"
+
+// applyDefaultDuration sets the expected duration of the jobs that have no
+// duration directive
+func applyDefaultDuration(jobs []*crontab.Job, d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("invalid --default-duration: must not be negative")
+	}
+	for _, job := range jobs {
+		if job.Duration == 0 {
+			job.Duration = d
+		}
+	}
+	return nil
+}
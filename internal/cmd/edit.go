@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+// DefaultEditor is the editor used when neither $VISUAL nor $EDITOR is set
+const DefaultEditor = "vi"
+
+type EditCommand struct {
+	*cobra.Command
+	force  bool
+	dryRun bool
+	failOn string
+}
+
+func newEditCommand() *EditCommand {
+	ec := &EditCommand{}
+	ec.Command = &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the user's crontab with validation before installing it",
+		Long: `Edit the current user's crontab, like 'crontab -e', with a validation gate.
+
+The crontab is opened in $VISUAL or $EDITOR (default: vi). When the editor
+exits, the changes are shown as a semantic diff and the new crontab is run
+through the same checks as 'cronkit check'. A crontab with issues at or above
+the --fail-on severity is not installed unless --force is given; the edited
+copy is kept so no work is lost.
+
+Examples:
+  cronkit edit
+  EDITOR=nano cronkit edit
+  cronkit edit --fail-on warn      # Also refuse crontabs with warnings
+  cronkit edit --dry-run           # Review the diff and issues without installing`,
+		Args: cobra.NoArgs,
+		RunE: ec.runEdit,
+	}
+
+	ec.Flags().BoolVar(&ec.force, "force", false, "Install the crontab even if validation fails")
+	ec.Flags().BoolVar(&ec.dryRun, "dry-run", false, "Show the diff and validation result without installing")
+	ec.Flags().StringVar(&ec.failOn, "fail-on", "error", "Refuse to install at this severity: error, warn, or info")
+	return ec
+}
+
+func init() {
+	rootCmd.AddCommand(newEditCommand().Command)
+}
+
+func (ec *EditCommand) runEdit(_ *cobra.Command, _ []string) error {
+	failOn, err := check.ParseFailOnLevel(ec.failOn)
+	if err != nil {
+		return fmt.Errorf("invalid --fail-on value: %w", err)
+	}
+
+	original, err := crontab.ReadUserRaw()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "cronkit-edit-*.cron")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	keep := false
+	defer func() {
+		if !keep {
+			_ = os.Remove(path)
+		}
+	}()
+	_, err = file.WriteString(original)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := ec.runEditor(path); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read edited crontab: %w", err)
+	}
+	edited := string(data)
+	if edited == original {
+		ec.Println("No changes made; crontab left unchanged")
+		return nil
+	}
+
+	oldEntries, err := crontab.ParseReader(strings.NewReader(original))
+	if err != nil {
+		return fmt.Errorf("failed to parse current crontab: %w", err)
+	}
+	newEntries, err := crontab.ParseReader(strings.NewReader(edited))
+	if err != nil {
+		return fmt.Errorf("failed to parse edited crontab: %w", err)
+	}
+
+	renderer, err := diff.NewRenderer("text")
+	if err != nil {
+		return fmt.Errorf("failed to create renderer: %w", err)
+	}
+	if err := renderer.Render(ec.OutOrStdout(), diff.CompareCrontabs(oldEntries, newEntries), &diff.RenderOptions{}); err != nil {
+		return fmt.Errorf("failed to render diff: %w", err)
+	}
+
+	result := check.NewValidator(GetLocale()).ValidateEntries(newEntries)
+	blocking := printIssues(ec.OutOrStderr(), result.Issues, failOn)
+
+	switch {
+	case blocking > 0 && !ec.force:
+		keep = true
+		return fmt.Errorf("refusing to install a crontab with %d issue(s) at or above %s severity (use --force to install anyway); your changes were kept in %s", blocking, failOn, path)
+	case ec.dryRun:
+		ec.Println("\nDry run: crontab not installed")
+		return nil
+	}
+
+	if err := crontab.InstallUser(edited); err != nil {
+		keep = true
+		return fmt.Errorf("%w; your changes were kept in %s", err, path)
+	}
+	ec.Println("\n✓ Crontab installed")
+	return nil
+}
+
+// runEditor opens path in the user's editor, attached to the terminal
+func (ec *EditCommand) runEditor(path string) error {
+	editor := editorCommand()
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return fmt.Errorf("no editor configured")
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = ec.InOrStdin()
+	cmd.Stdout = ec.OutOrStdout()
+	cmd.Stderr = ec.ErrOrStderr()
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
+
+// printIssues lists validation issues on w and returns how many are at or
+// above failOn
+func printIssues(w io.Writer, issues []check.Issue, failOn check.Severity) int {
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(w, "\n✓ All valid")
+		return 0
+	}
+
+	blocking := 0
+	_, _ = fmt.Fprintln(w)
+	for _, issue := range issues {
+		if issue.Severity >= failOn {
+			blocking++
+		}
+		icon := "ℹ"
+		switch issue.Severity {
+		case check.SeverityError:
+			icon = "✗"
+		case check.SeverityWarn:
+			icon = "⚠"
+		}
+		_, _ = fmt.Fprintf(w, "%s [%s] Line %d: %s\n", icon, issue.Code, issue.LineNumber, issue.Message)
+		if issue.Hint != "" {
+			_, _ = fmt.Fprintf(w, "    Hint: %s\n", issue.Hint)
+		}
+	}
+	return blocking
+}
+
+// editorCommand returns the editor to run: $VISUAL, then $EDITOR, then DefaultEditor
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return DefaultEditor
+}
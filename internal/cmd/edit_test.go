@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCrontab installs a crontab command on PATH that keeps the user's
+// crontab in a file, and returns the path of that file
+func fakeCrontab(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	store := filepath.Join(dir, "crontab.txt")
+	require.NoError(t, os.WriteFile(store, []byte(content), 0o600))
+
+	script := `#!/bin/sh
+case "$1" in
+-l) cat "` + store + `" ;;
+-) cat > "` + store + `" ;;
+*) exit 2 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crontab"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return store
+}
+
+// fakeEditor sets $EDITOR to a script that replaces the edited file with content
+func fakeEditor(t *testing.T, content string) {
+	t.Helper()
+	dir := t.TempDir()
+	source := filepath.Join(dir, "new.cron")
+	require.NoError(t, os.WriteFile(source, []byte(content), 0o600))
+
+	editor := filepath.Join(dir, "editor")
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\ncp \""+source+"\" \"$1\"\n"), 0o755))
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+}
+
+func runEdit(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	ec := newEditCommand()
+	buf := new(bytes.Buffer)
+	ec.SetOut(buf)
+	ec.SetErr(buf)
+	ec.SetArgs(args)
+	err := ec.Execute()
+	return buf.String(), err
+}
+
+func TestEditCommand(t *testing.T) {
+	const original = "0 2 * * * /usr/bin/backup.sh\n"
+
+	t.Run("installs a valid crontab and shows the diff", func(t *testing.T) {
+		store := fakeCrontab(t, original)
+		fakeEditor(t, original+"30 6 * * 1-5 /usr/bin/report.sh\n")
+
+		output, err := runEdit(t)
+		require.NoError(t, err)
+		assert.Contains(t, output, "/usr/bin/report.sh")
+		assert.Contains(t, output, "✓ Crontab installed")
+
+		installed, err := os.ReadFile(store)
+		require.NoError(t, err)
+		assert.Contains(t, string(installed), "30 6 * * 1-5 /usr/bin/report.sh")
+	})
+
+	t.Run("refuses an invalid crontab and keeps the changes", func(t *testing.T) {
+		store := fakeCrontab(t, original)
+		fakeEditor(t, original+"61 * * * * /usr/bin/broken.sh\n")
+
+		output, err := runEdit(t)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "refusing to install")
+		assert.Contains(t, output, "[CRON-")
+
+		installed, readErr := os.ReadFile(store)
+		require.NoError(t, readErr)
+		assert.Equal(t, original, string(installed))
+
+		_, kept, found := strings.Cut(err.Error(), "your changes were kept in ")
+		require.True(t, found)
+		t.Cleanup(func() { _ = os.Remove(kept) })
+		edited, readErr := os.ReadFile(kept)
+		require.NoError(t, readErr)
+		assert.Contains(t, string(edited), "/usr/bin/broken.sh")
+	})
+
+	t.Run("installs an invalid crontab with --force", func(t *testing.T) {
+		store := fakeCrontab(t, original)
+		fakeEditor(t, "61 * * * * /usr/bin/broken.sh\n")
+
+		_, err := runEdit(t, "--force")
+		require.NoError(t, err)
+
+		installed, err := os.ReadFile(store)
+		require.NoError(t, err)
+		assert.Equal(t, "61 * * * * /usr/bin/broken.sh\n", string(installed))
+	})
+
+	t.Run("dry run does not install", func(t *testing.T) {
+		store := fakeCrontab(t, original)
+		fakeEditor(t, "@daily /usr/bin/cleanup.sh\n")
+
+		output, err := runEdit(t, "--dry-run")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Dry run: crontab not installed")
+
+		installed, err := os.ReadFile(store)
+		require.NoError(t, err)
+		assert.Equal(t, original, string(installed))
+	})
+
+	t.Run("unchanged crontab", func(t *testing.T) {
+		fakeCrontab(t, original)
+		fakeEditor(t, original)
+
+		output, err := runEdit(t)
+		require.NoError(t, err)
+		assert.Contains(t, output, "No changes made")
+	})
+
+	t.Run("invalid --fail-on", func(t *testing.T) {
+		_, err := runEdit(t, "--fail-on", "fatal")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --fail-on value")
+	})
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, DefaultEditor, editorCommand())
+
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", editorCommand())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", editorCommand())
+}
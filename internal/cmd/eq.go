@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/spf13/cobra"
+)
+
+// EqCommand wraps cobra.Command with eq-specific functionality
+type EqCommand struct {
+	*cobra.Command
+	json bool
+}
+
+// EqExpression is an expression compared by the eq command
+type EqExpression struct {
+	Expression  string `json:"expression"`
+	Canonical   string `json:"canonical"`
+	Description string `json:"description"`
+}
+
+// EqResult represents the complete output for the eq command
+type EqResult struct {
+	Equivalent  bool           `json:"equivalent"`
+	Expressions []EqExpression `json:"expressions"`
+}
+
+func init() {
+	rootCmd.AddCommand(newEqCommand().Command)
+}
+
+// newEqCommand creates a fresh eq command instance
+func newEqCommand() *EqCommand {
+	ec := &EqCommand{}
+	ec.Command = &cobra.Command{
+		Args:  cobra.ExactArgs(2),
+		RunE:  ec.runEq,
+		Use:   "eq <expression> <expression>",
+		Short: "Check whether two cron expressions produce the same schedule",
+		Long: `Check whether two cron expressions run at exactly the same times, for
+reviewing refactored crontabs.
+
+Both expressions are rewritten in a canonical form, which lists the values
+each field matches: names become numbers, aliases are expanded, runs of
+values become ranges or steps, and a day field that matches every day is
+dropped when cron would combine it with the other one. The expressions are
+equivalent when their canonical forms are the same.
+
+Exits with status 1 when the expressions are not equivalent, so it can be
+used in scripts.
+
+Examples:
+  cronkit eq "0 0 * * *" "@daily"
+  cronkit eq "0,15,30,45 9-17 * * MON-FRI" "*/15 9-17 * * 1-5"
+  cronkit eq "0 0 1-31 * 1" "0 0 * * 1" --json`,
+	}
+
+	ec.Flags().BoolVarP(&ec.json, "json", "j", false, "Output in JSON format")
+	return ec
+}
+
+func (ec *EqCommand) runEq(_ *cobra.Command, args []string) error {
+	parser := cronx.NewParserWithSeconds(GetLocale(), cronx.SecondsOptional)
+	humanizer := newHumanizer()
+
+	result := EqResult{Expressions: make([]EqExpression, len(args))}
+	for i, expression := range args {
+		canonical, err := cronx.Canonicalize(expression)
+		if err != nil {
+			return fmt.Errorf("invalid expression %q: %w", expression, err)
+		}
+		schedule, err := parser.Parse(expression)
+		if err != nil {
+			return fmt.Errorf("invalid expression %q: %w", expression, err)
+		}
+		result.Expressions[i] = EqExpression{
+			Expression:  expression,
+			Canonical:   canonical,
+			Description: humanizer.Humanize(schedule),
+		}
+	}
+	result.Equivalent = result.Expressions[0].Canonical == result.Expressions[1].Canonical
+
+	if ec.json {
+		encoder := json.NewEncoder(ec.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	} else {
+		ec.outputText(result)
+	}
+
+	// Like match, report a difference with the exit code so scripts can test it
+	if !result.Equivalent {
+		osExit(1)
+	}
+	return nil
+}
+
+func (ec *EqCommand) outputText(result EqResult) {
+	if result.Equivalent {
+		ec.Println("✓ Equivalent: both expressions run at the same times")
+	} else {
+		ec.Println("✗ Not equivalent: the expressions run at different times")
+	}
+
+	width := 0
+	for _, e := range result.Expressions {
+		width = max(width, len(e.Expression))
+	}
+	ec.Println()
+	for _, e := range result.Expressions {
+		ec.Printf("  %-*s  canonical: %s\n", width, e.Expression, e.Canonical)
+		ec.Printf("  %-*s  %s\n", width, "", e.Description)
+	}
+}
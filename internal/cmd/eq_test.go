@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, int, error) {
+		exitCode := 0
+		oldExit := osExit
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		ec := newEqCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs(args)
+		err := ec.Execute()
+		return buf.String(), exitCode, err
+	}
+
+	t.Run("eq command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"eq"})
+		assert.NoError(t, err)
+		assert.Equal(t, "eq", cmd.Name())
+	})
+
+	t.Run("equivalent expressions", func(t *testing.T) {
+		output, exitCode, err := run(t, "0,15,30,45 9-17 * * MON-FRI", "*/15 9-17 * * 1-5")
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, output, "✓ Equivalent")
+		assert.Contains(t, output, "canonical: */15 9-17 * * 1-5")
+	})
+
+	t.Run("different expressions exit 1", func(t *testing.T) {
+		output, exitCode, err := run(t, "0 0 1-31 * 1", "0 0 * * 1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, output, "✗ Not equivalent")
+		assert.Contains(t, output, "canonical: 0 0 * * *")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		output, exitCode, err := run(t, "0 0 * * *", "@daily", "--json")
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+
+		var result EqResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.True(t, result.Equivalent)
+		require.Len(t, result.Expressions, 2)
+		assert.Equal(t, "@daily", result.Expressions[1].Expression)
+		assert.Equal(t, "0 0 * * *", result.Expressions[1].Canonical)
+		assert.NotEmpty(t, result.Expressions[1].Description)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, _, err := run(t, "0 0 * * *", "@reboot")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid expression "@reboot"`)
+	})
+
+	t.Run("requires two expressions", func(t *testing.T) {
+		_, _, err := run(t, "0 0 * * *")
+		assert.Error(t, err)
+	})
+}
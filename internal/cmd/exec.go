@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hzerrad/cronkit/internal/api"
+	"github.com/spf13/cobra"
+)
+
+type ExecCommand struct {
+	*cobra.Command
+	request string
+}
+
+func newExecCommand() *ExecCommand {
+	ec := &ExecCommand{}
+	ec.Command = &cobra.Command{
+		Use:   "exec",
+		Short: "Run an operation described by a JSON request",
+		Long: `Run a cronkit operation described by a JSON request document and print a JSON response.
+
+This is the stable programmatic entry point for automation written in any language.
+Requests and responses use the same format as the HTTP API.
+
+Supported commands: explain, next, check, list, timeline
+
+Request format:
+  {
+    "command": "check",
+    "crontab": "0 2 * * * /usr/bin/backup.sh\n",
+    "locale": "en",
+    "options": {"verbose": true, "enableHygieneChecks": true}
+  }
+
+Response format:
+  {"apiVersion": "v1", "command": "check", "ok": true, "result": {...}}
+  {"apiVersion": "v1", "command": "check", "ok": false, "error": {"code": "...", "message": "..."}}
+
+Exits with code 1 when the response is not ok.
+
+Examples:
+  cronkit exec --request request.json
+  echo '{"command":"explain","expression":"0 9 * * 1-5"}' | cronkit exec
+  cronkit exec --request - < request.json`,
+		RunE: ec.runExec,
+		Args: cobra.NoArgs,
+	}
+
+	ec.Flags().StringVarP(&ec.request, "request", "r", "", "Path to JSON request file ('-' for stdin; defaults to stdin if not a terminal)")
+
+	return ec
+}
+
+func init() {
+	rootCmd.AddCommand(newExecCommand().Command)
+}
+
+func (ec *ExecCommand) runExec(_ *cobra.Command, _ []string) error {
+	var input io.Reader
+	switch {
+	case ec.request == "-":
+		input = os.Stdin
+	case ec.request != "":
+		file, err := os.Open(ec.request)
+		if err != nil {
+			return fmt.Errorf("failed to open request file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		input = file
+	case isStdinAvailable():
+		input = os.Stdin
+	default:
+		return fmt.Errorf("no request provided: use --request <file> or pipe a JSON request to stdin")
+	}
+
+	var resp api.Response
+	req, err := api.DecodeRequest(input)
+	if err != nil {
+		resp = api.Response{
+			APIVersion: api.Version,
+			Error:      &api.Error{Code: api.ErrInvalidRequest, Message: err.Error()},
+		}
+	} else {
+		if req.Locale == "" {
+			req.Locale = GetLocale()
+		}
+		resp = api.Execute(req)
+	}
+
+	encoder := json.NewEncoder(ec.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(resp); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if !resp.OK {
+		osExit(1)
+	}
+
+	return nil
+}
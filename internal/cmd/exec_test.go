@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecCommand(t *testing.T) {
+	t.Run("exec command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"exec"})
+		require.NoError(t, err)
+		assert.Equal(t, "exec", cmd.Name())
+	})
+
+	t.Run("exec command should have request flag", func(t *testing.T) {
+		ec := newExecCommand()
+		assert.NotNil(t, ec.Flags().Lookup("request"))
+	})
+
+	t.Run("runs request from file", func(t *testing.T) {
+		requestFile := createTempFile(t, `{"command": "explain", "expression": "0 0 * * *"}`)
+
+		ec := newExecCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.request = requestFile
+
+		err := ec.runExec(nil, nil)
+		require.NoError(t, err)
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		assert.Equal(t, "v1", resp["apiVersion"])
+		assert.Equal(t, true, resp["ok"])
+		result := resp["result"].(map[string]interface{})
+		assert.Equal(t, "At midnight every day", result["description"])
+	})
+
+	t.Run("failed request writes error response and exits 1", func(t *testing.T) {
+		requestFile := createTempFile(t, `{"command": "explain", "expression": "bad"}`)
+
+		exitCode := 0
+		oldExit := osExit
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		ec := newExecCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.request = requestFile
+
+		err := ec.runExec(nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, exitCode)
+
+		var resp api.Response
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		assert.False(t, resp.OK)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, api.ErrExecutionFailed, resp.Error.Code)
+	})
+
+	t.Run("malformed request is reported as invalid_request", func(t *testing.T) {
+		requestFile := createTempFile(t, `{"command": "explain", "unknown": 1}`)
+
+		exitCode := 0
+		oldExit := osExit
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		ec := newExecCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.request = requestFile
+
+		require.NoError(t, ec.runExec(nil, nil))
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, buf.String(), api.ErrInvalidRequest)
+	})
+
+	t.Run("missing request file", func(t *testing.T) {
+		ec := newExecCommand()
+		ec.request = "/nonexistent/request.json"
+		err := ec.runExec(nil, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open request file")
+	})
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// expandUsage is the help text of the --expand flag shared by commands that show or check job commands
+const expandUsage = "Resolve ~, $HOME and other variable references in commands using the crontab's environment"
+
+// jobEnvironment returns the base environment commands are expanded in when
+// expand is set, and nil otherwise
+func jobEnvironment(expand bool) (map[string]string, error) {
+	if !expand {
+		return nil, nil
+	}
+	env, err := crontab.DefaultEnvironment("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve job environment: %w", err)
+	}
+	return env, nil
+}
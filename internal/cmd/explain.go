@@ -3,7 +3,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
 	"github.com/hzerrad/cronkit/internal/human"
 	"github.com/spf13/cobra"
@@ -11,30 +16,85 @@ import (
 
 type ExplainCommand struct {
 	*cobra.Command
-	json bool
+	json       bool
+	fields     bool
+	seconds    bool
+	dialect    string
+	jenkinsJob string
+	file       string
+	inPlace    bool
+	system     bool
+	stdin      bool
+	ndjson     bool
 }
 
+// explainCommentPrefix starts the comments explain --file writes above jobs
+const explainCommentPrefix = "# explain: "
+
 func newExplainCommand() *ExplainCommand {
 	ec := &ExplainCommand{}
 	ec.Command = &cobra.Command{
-		Args:  cobra.ExactArgs(1),
-		Use:   "explain <cron-expression>",
-		Short: "Explain a cron expression in plain English",
-		RunE:  ec.runExplain,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if ec.file != "" || ec.stdin {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeExpression,
+		Use:               "explain <cron-expression>",
+		Short:             "Explain a cron expression in plain English",
+		RunE:              ec.runExplain,
 		Long: `Convert a cron expression to human-readable text.
 
 Supports:
   - Standard 5-field cron expressions
+  - 6-field expressions with a leading seconds field (Quartz-style)
   - Cron aliases (@daily, @hourly, @weekly, @monthly, @yearly)
   - Case-insensitive day and month names
+  - Jenkins H tokens with --dialect jenkins (H, H(0-30), H/15); the values
+    are hashed from --jenkins-job as Jenkins does, and the resolved
+    expression is shown
+
+With --fields, a table breaks the expression down field by field: the
+wildcards, ranges, single values and steps each field is made of, and the
+values it matches.
+
+With --file, a crontab is printed with a comment explaining each job above
+it, such as "# explain: At 02:00 every day", turning it into a
+self-documenting crontab; --in-place writes it back to the file instead.
+The explanations written by a previous run are replaced, so running it again
+after editing the crontab updates them. Jobs that fail to parse, and
+@reboot jobs, are left without an explanation.
+
+With --stdin, expressions are read from standard input, one per line, and
+explained one per line as they are read, or as NDJSON with --ndjson, so
+tools can explain many expressions in one process. Blank lines and #
+comments are skipped. An expression that fails to parse gives an
+"error: ..." line (an object with an "error" field with --ndjson), and the
+command exits with status 1 once all are explained.
 
 Examples:
   cronkit explain "0 0 * * *"
   cronkit explain "*/15 9-17 * * 1-5"
-  cronkit explain "@daily" --json`,
+  cronkit explain "@daily" --json
+  cronkit explain "*/15 9-17/2,22 * * MON-FRI" --fields
+  cronkit explain "0 */5 * * * *"
+  cronkit explain "H 4 * * 1-5" --dialect jenkins --jenkins-job folder/nightly
+  cronkit explain --file jobs.cron
+  cronkit explain --file jobs.cron --in-place
+  cut -d' ' -f1-5 jobs.txt | cronkit explain --stdin --ndjson`,
 	}
 
 	ec.Flags().BoolVarP(&ec.json, "json", "j", false, "Output in JSON format")
+	ec.Flags().BoolVar(&ec.fields, "fields", false, "Break the expression down field by field")
+	ec.Flags().BoolVar(&ec.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	ec.Flags().StringVar(&ec.dialect, "dialect", "standard", dialectUsage)
+	ec.Flags().StringVar(&ec.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+	ec.Flags().StringVarP(&ec.file, "file", "f", "", "Print a crontab file with an explanation above each job")
+	ec.Flags().BoolVar(&ec.inPlace, "in-place", false, "With --file, write the explained crontab back to the file")
+	ec.Flags().BoolVar(&ec.system, "system", false, systemUsage)
+	ec.Flags().BoolVar(&ec.stdin, "stdin", false, "Read cron expressions from standard input, one per line")
+	ec.Flags().BoolVar(&ec.ndjson, "ndjson", false, fmt.Sprintf(ndjsonUsage, "expression")+" (with --stdin)")
 	return ec
 }
 
@@ -43,34 +103,202 @@ func init() {
 }
 
 func (ec *ExplainCommand) runExplain(_ *cobra.Command, args []string) error {
+	if ec.stdin {
+		return ec.runExplainStdin()
+	}
+	if ec.ndjson {
+		return fmt.Errorf("--ndjson requires --stdin")
+	}
+	if ec.file != "" {
+		return ec.runExplainFile()
+	}
+	if ec.inPlace {
+		return fmt.Errorf("--in-place requires --file")
+	}
 	expression := args[0]
 
 	// Parse the cron expression with the specified locale
-	parser := cronx.NewParserWithLocale(GetLocale())
+	opts, err := parserOptions(ec.seconds, ec.dialect, ec.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	parser := cronx.NewParserWithOptions(GetLocale(), opts)
 	schedule, err := parser.Parse(expression)
 	if err != nil {
+		if suggestions := check.Suggest(parser, expression); len(suggestions) > 0 {
+			return fmt.Errorf("failed to parse expression: %w\n%s", err, check.FormatSuggestions(suggestions))
+		}
 		return fmt.Errorf("failed to parse expression: %w", err)
 	}
 
 	// Humanize the schedule
-	humanizer := human.NewHumanizer()
+	humanizer := newHumanizer()
 	description := humanizer.Humanize(schedule)
 
+	var fields []ExplainField
+	if ec.fields {
+		fields = explainFields(schedule)
+	}
+
 	// Output based on format flag
 	if ec.json {
-		return ec.outputJSON(expression, description)
+		return ec.outputJSON(expression, description, schedule.Resolved, fields)
 	}
 
 	ec.Println(description)
+	if schedule.Resolved != "" {
+		ec.Printf("Resolved: %s\n", schedule.Resolved)
+	}
+	if ec.fields {
+		ec.outputFields(schedule, fields)
+	}
 	return nil
 }
 
-func (ec *ExplainCommand) outputJSON(expression, description string) error {
+// ExplainField is a row of the field breakdown of explain --fields
+type ExplainField struct {
+	Field   string             `json:"field"`
+	Pattern string             `json:"pattern"`
+	Parts   []ExplainFieldPart `json:"parts"`
+	Values  []int              `json:"values"`
+}
+
+// ExplainFieldPart is a comma-separated component of a field
+type ExplainFieldPart struct {
+	Pattern     string `json:"pattern"`
+	Kind        string `json:"kind"` // "every", "range" or "value"
+	Start       int    `json:"start"`
+	End         int    `json:"end"`
+	Step        int    `json:"step"`
+	Description string `json:"description"`
+}
+
+// explainFields breaks a schedule down field by field. Rates have no fields,
+// and the day fields of Quartz expressions with L, W or # modifiers are not
+// broken down.
+func explainFields(schedule *cronx.Schedule) []ExplainField {
+	fields := []ExplainField{}
+	if schedule.Rate > 0 {
+		return fields
+	}
+
+	add := func(name string, f cronx.Field) {
+		field := ExplainField{Field: name, Pattern: f.Raw(), Values: f.Values()}
+		for _, part := range f.Parts() {
+			field.Parts = append(field.Parts, explainFieldPart(part))
+		}
+		fields = append(fields, field)
+	}
+	if schedule.HasSeconds() {
+		add("second", schedule.Second)
+	}
+	add("minute", schedule.Minute)
+	add("hour", schedule.Hour)
+	if schedule.Quartz == nil {
+		add("day-of-month", schedule.DayOfMonth)
+	}
+	add("month", schedule.Month)
+	if schedule.Quartz == nil {
+		add("day-of-week", schedule.DayOfWeek)
+	}
+	if schedule.Year != nil {
+		add("year", schedule.Year)
+	}
+	return fields
+}
+
+// explainFieldPart describes a component of a field
+func explainFieldPart(part cronx.FieldPart) ExplainFieldPart {
+	result := ExplainFieldPart{Pattern: part.Raw, Start: part.Start, End: part.End, Step: part.Step}
+	switch {
+	case part.Every:
+		result.Kind = "every"
+		result.Description = "every value"
+		if part.Step > 1 {
+			result.Description = fmt.Sprintf("every %d from %d", part.Step, part.Start)
+		}
+		return result
+	case part.Range:
+		result.Kind = "range"
+	default:
+		result.Kind = "value"
+	}
+
+	if part.Start == part.End {
+		result.Description = strconv.Itoa(part.Start)
+	} else {
+		result.Description = fmt.Sprintf("%d to %d", part.Start, part.End)
+	}
+	if part.Step > 1 {
+		result.Description += fmt.Sprintf(", every %d", part.Step)
+	}
+	return result
+}
+
+// outputFields prints the field breakdown as a table, one row per component
+func (ec *ExplainCommand) outputFields(schedule *cronx.Schedule, fields []ExplainField) {
+	ec.Println()
+	if len(fields) == 0 {
+		ec.Println("Rate expressions have no fields to break down.")
+		return
+	}
+
+	widths := []int{len("Field"), len("Pattern"), len("Parts")}
+	for _, f := range fields {
+		widths[0] = max(widths[0], len(f.Field))
+		for _, part := range f.Parts {
+			widths[1] = max(widths[1], len(part.Pattern))
+			widths[2] = max(widths[2], len(part.Description))
+		}
+	}
+
+	row := func(field, pattern, part, values string) {
+		ec.Println(strings.TrimRight(fmt.Sprintf("%-*s  %-*s  %-*s  %s", widths[0], field, widths[1], pattern, widths[2], part, values), " "))
+	}
+	row("Field", "Pattern", "Parts", "Values")
+	for _, f := range fields {
+		values := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			values[i] = strconv.Itoa(v)
+		}
+		all := strings.Join(values, ",")
+		if len(f.Parts) == 1 && f.Parts[0].Kind == "every" && f.Parts[0].Step == 1 {
+			all = "all"
+		}
+		for i, part := range f.Parts {
+			if i == 0 {
+				row(f.Field, part.Pattern, part.Description, all)
+			} else {
+				row("", part.Pattern, part.Description, "")
+			}
+		}
+	}
+
+	if eitherDay(schedule) {
+		ec.Println()
+		ec.Println("Both day fields are restricted, so cron runs when either matches.")
+	} else if schedule.MatchesBothDays() && schedule.Quartz == nil && !schedule.DayOfMonth.IsEvery() && !schedule.DayOfWeek.IsEvery() {
+		ec.Println()
+		ec.Printf("Both day fields are restricted, so %s runs only when both match.\n", schedule.Dialect)
+	}
+	if schedule.Quartz != nil {
+		ec.Println()
+		ec.Println("The day fields use L, W or # modifiers, which are not broken down.")
+	}
+}
+
+func (ec *ExplainCommand) outputJSON(expression, description, resolved string, fields []ExplainField) error {
 	result := map[string]interface{}{
 		"expression":  expression,
 		"description": description,
 		"locale":      GetLocale(),
 	}
+	if resolved != "" {
+		result["resolved"] = resolved
+	}
+	if fields != nil {
+		result["fields"] = fields
+	}
 
 	encoder := json.NewEncoder(ec.OutOrStdout())
 	encoder.SetIndent("", "  ")
@@ -80,3 +308,122 @@ func (ec *ExplainCommand) outputJSON(expression, description string) error {
 
 	return nil
 }
+
+// runExplainStdin explains the expressions of standard input, one per line
+func (ec *ExplainCommand) runExplainStdin() error {
+	if ec.file != "" || ec.inPlace {
+		return fmt.Errorf("--stdin cannot be used with --file or --in-place")
+	}
+	// "output: json" from the config file applies to single expressions only
+	if ec.Flags().Changed("json") || ec.fields {
+		return fmt.Errorf("--json and --fields explain a single expression; use --ndjson with --stdin")
+	}
+
+	opts, err := parserOptions(ec.seconds, ec.dialect, ec.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	parser := cronx.NewParserWithOptions(GetLocale(), opts)
+	humanizer := newHumanizer()
+	var out *ndjsonWriter
+	if ec.ndjson {
+		out = newNDJSONWriter(ec.OutOrStdout())
+	}
+
+	return eachExpression(ec.Command, ec.InOrStdin(), out, func(line int, expression string) error {
+		schedule, err := parser.Parse(expression)
+		if err != nil {
+			return fmt.Errorf("failed to parse expression: %w", err)
+		}
+		description := humanizer.Humanize(schedule)
+		if out == nil {
+			ec.Println(description)
+			return nil
+		}
+
+		result := map[string]interface{}{
+			"line":        line,
+			"expression":  expression,
+			"description": description,
+			"locale":      GetLocale(),
+		}
+		if schedule.Resolved != "" {
+			result["resolved"] = schedule.Resolved
+		}
+		return out.Write(result)
+	})
+}
+
+// runExplainFile prints the crontab of --file, or writes it back with
+// --in-place, with an explanation above each job
+func (ec *ExplainCommand) runExplainFile() error {
+	if ec.json || ec.fields {
+		return fmt.Errorf("--json and --fields explain a single expression and cannot be used with --file")
+	}
+
+	opts, err := parserOptions(ec.seconds, ec.dialect, ec.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	entries, err := newCrontabReader(ec.system).ParseFile(ec.file)
+	if err != nil {
+		return fmt.Errorf("failed to read crontab file %s: %w", ec.file, err)
+	}
+
+	explained, jobs := explainCrontab(entries, cronx.NewParserWithOptions(GetLocale(), opts), newHumanizer())
+	if !ec.inPlace {
+		ec.Print(explained)
+		return nil
+	}
+
+	info, err := os.Stat(ec.file)
+	if err != nil {
+		return fmt.Errorf("failed to read crontab file %s: %w", ec.file, err)
+	}
+	if err := os.WriteFile(ec.file, []byte(explained), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write crontab file %s: %w", ec.file, err)
+	}
+	ec.Printf("Explained %d job(s) in %s\n", jobs, ec.file)
+	return nil
+}
+
+// explainCrontab returns the crontab of entries with an explanation comment
+// above each job that parses, naming the time zone of jobs after a CRON_TZ=
+// line, replacing the ones written before, and the number of jobs explained
+func explainCrontab(entries []*crontab.Entry, parser cronx.Parser, humanizer human.Humanizer) (string, int) {
+	var (
+		lines   []string
+		pending []string // Explanations of a previous run, dropped if a job follows
+		jobs    int
+	)
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeComment && strings.HasPrefix(strings.TrimSpace(entry.Raw), explainCommentPrefix) {
+			pending = append(pending, entry.Raw)
+			continue
+		}
+		if entry.Type != crontab.EntryTypeJob {
+			lines = append(lines, pending...)
+			lines = append(lines, entry.Raw)
+			pending = nil
+			continue
+		}
+
+		pending = nil
+		if schedule, err := parser.Parse(entry.Job.Expression); err == nil {
+			indent := entry.Raw[:len(entry.Raw)-len(strings.TrimLeft(entry.Raw, " \t"))]
+			description := humanizer.Humanize(schedule)
+			if entry.Job.Timezone != "" {
+				description += " (" + entry.Job.Timezone + ")"
+			}
+			lines = append(lines, indent+explainCommentPrefix+description)
+			jobs++
+		}
+		lines = append(lines, entry.Raw)
+	}
+	lines = append(lines, pending...)
+
+	if len(lines) == 0 {
+		return "", 0
+	}
+	return strings.Join(lines, "\n") + "\n", jobs
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,32 @@ func TestExplainCommand(t *testing.T) {
 		assert.Contains(t, buf.String(), "At midnight")
 	})
 
+	t.Run("explain notes values picked at random", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"~ 6 * * *"})
+
+		err := ec.Execute()
+		require.NoError(t, err)
+		assert.Regexp(t, `^At 06:\d\d every day \(random, fixed per host\)\nResolved: \d+ 6 \* \* \*\n$`, buf.String())
+	})
+
+	t.Run("explain in the --locale language", func(t *testing.T) {
+		oldLocale := locale
+		locale = "es"
+		defer func() { locale = oldLocale }()
+
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"0 9 * * 1-5"})
+
+		err := ec.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, "A las 09:00 de lunes a viernes\n", buf.String())
+	})
+
 	t.Run("explain cron alias", func(t *testing.T) {
 		ec := newExplainCommand()
 		buf := new(bytes.Buffer)
@@ -74,6 +102,17 @@ func TestExplainCommand(t *testing.T) {
 		assert.Contains(t, result["description"], "midnight")
 	})
 
+	t.Run("invalid expression suggests corrections", func(t *testing.T) {
+		ec := newExplainCommand()
+		ec.SetOut(new(bytes.Buffer))
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs([]string{"0 0 * * MONDAY"})
+
+		err := ec.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Did you mean '0 0 * * MON'")
+	})
+
 	t.Run("fail on invalid cron expression", func(t *testing.T) {
 		ec := newExplainCommand()
 		ec.SetArgs([]string{"invalid"})
@@ -99,12 +138,246 @@ func TestExplainCommand(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("explain auto-detects a seconds field", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"*/10 * * * * *"})
+
+		err := ec.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Every 10 seconds")
+	})
+
+	t.Run("explain --seconds requires six fields", func(t *testing.T) {
+		ec := newExplainCommand()
+		ec.SetOut(new(bytes.Buffer))
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs([]string{"0 0 * * *", "--seconds"})
+
+		err := ec.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected 6 fields")
+	})
+
+	t.Run("explain --dialect quartz", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"0 0 12 ? * 6L", "--dialect", "quartz"})
+
+		err := ec.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "on the last Friday of the month")
+	})
+
+	t.Run("explain --dialect jenkins", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"H 4 * * 1-5", "--dialect", "jenkins", "--jenkins-job", "my-job"})
+
+		err := ec.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, "At 04:18 on weekdays (Mon-Fri)\nResolved: 18 4 * * 1-5\n", buf.String())
+	})
+
+	t.Run("explain --dialect jenkins --json", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"H/15 * * * *", "--dialect", "jenkins", "--jenkins-job", "my-job", "--json"})
+
+		err := ec.Execute()
+		require.NoError(t, err)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "3,18,33,48 * * * *", result["resolved"])
+	})
+
+	t.Run("explain rejects unknown dialect", func(t *testing.T) {
+		ec := newExplainCommand()
+		ec.SetOut(new(bytes.Buffer))
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs([]string{"0 0 * * *", "--dialect", "systemd"})
+
+		err := ec.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --dialect value")
+	})
+
+	t.Run("explain --fields", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"*/15 9-17/2,22 5/10 * MON-FRI", "--fields"})
+
+		require.NoError(t, ec.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "Field         Pattern  Parts              Values\n")
+		assert.Contains(t, output, "minute        */15     every 15 from 0    0,15,30,45\n")
+		assert.Contains(t, output, "hour          9-17/2   9 to 17, every 2   9,11,13,15,17,22\n              22       22\n")
+		assert.Contains(t, output, "day-of-month  5/10     5 to 31, every 10  5,15,25\n")
+		assert.Contains(t, output, "month         *        every value        all\n")
+		assert.Contains(t, output, "day-of-week   MON-FRI  1 to 5             1,2,3,4,5\n")
+		assert.Contains(t, output, "cron runs when either matches")
+	})
+
+	t.Run("explain --fields --json", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"0 */5 * * * *", "--fields", "--json"})
+
+		require.NoError(t, ec.Execute())
+		var result struct {
+			Fields []ExplainField `json:"fields"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Fields, 6)
+		assert.Equal(t, "second", result.Fields[0].Field)
+		minute := result.Fields[1]
+		assert.Equal(t, "*/5", minute.Pattern)
+		assert.Equal(t, []ExplainFieldPart{{Pattern: "*/5", Kind: "every", Start: 0, End: 59, Step: 5, Description: "every 5 from 0"}}, minute.Parts)
+		assert.Len(t, minute.Values, 12)
+	})
+
+	t.Run("explain --fields without fields", func(t *testing.T) {
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"rate(5 minutes)", "--dialect", "aws", "--fields"})
+
+		require.NoError(t, ec.Execute())
+		assert.Contains(t, buf.String(), "Rate expressions have no fields to break down.")
+	})
+
+	t.Run("explain --file", func(t *testing.T) {
+		file := createTempFile(t, "MAILTO=ops\n# Nightly\n0 2 * * * /usr/bin/backup.sh\n@reboot /usr/bin/start.sh\n  */5 * * * * /usr/bin/poll.sh\n")
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"--file", file})
+
+		require.NoError(t, ec.Execute())
+		assert.Equal(t, "MAILTO=ops\n# Nightly\n# explain: At 02:00 every day\n0 2 * * * /usr/bin/backup.sh\n"+
+			"@reboot /usr/bin/start.sh\n  # explain: Every 5 minutes\n  */5 * * * * /usr/bin/poll.sh\n", buf.String())
+	})
+
+	t.Run("explain --file names CRON_TZ= zones", func(t *testing.T) {
+		file := createTempFile(t, "CRON_TZ=Europe/Paris\n0 2 * * * /usr/bin/backup.sh\n")
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"--file", file})
+
+		require.NoError(t, ec.Execute())
+		assert.Equal(t, "CRON_TZ=Europe/Paris\n# explain: At 02:00 every day (Europe/Paris)\n0 2 * * * /usr/bin/backup.sh\n", buf.String())
+	})
+
+	t.Run("explain --file --in-place replaces earlier explanations", func(t *testing.T) {
+		file := createTempFile(t, "# explain: At 01:00 every day\n0 2 * * * /usr/bin/backup.sh\n# explain: orphaned\n")
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{"--file", file, "--in-place"})
+
+		require.NoError(t, ec.Execute())
+		assert.Equal(t, "Explained 1 job(s) in "+file+"\n", buf.String())
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "# explain: At 02:00 every day\n0 2 * * * /usr/bin/backup.sh\n# explain: orphaned\n", string(content))
+	})
+
+	t.Run("explain --file rejects conflicting arguments", func(t *testing.T) {
+		file := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+		for _, args := range [][]string{
+			{"0 0 * * *", "--file", file},
+			{"--file", file, "--json"},
+			{"0 0 * * *", "--in-place"},
+		} {
+			ec := newExplainCommand()
+			ec.SetOut(new(bytes.Buffer))
+			ec.SetErr(new(bytes.Buffer))
+			ec.SetArgs(args)
+			assert.Error(t, ec.Execute(), args)
+		}
+	})
+
+	t.Run("explain --stdin", func(t *testing.T) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		ec := newExplainCommand()
+		buf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetErr(errBuf)
+		ec.SetIn(strings.NewReader("0 9 * * 1-5\n\n# comment\n61 * * * *\n@daily\n"))
+		ec.SetArgs([]string{"--stdin"})
+
+		require.NoError(t, ec.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "At 09:00 on weekdays (Mon-Fri)", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "error: failed to parse expression"), lines[1])
+		assert.Equal(t, "At midnight every day", lines[2])
+		assert.Equal(t, 1, exitCode)
+		assert.Contains(t, errBuf.String(), "1 of 3 expression(s) failed")
+	})
+
+	t.Run("explain --stdin --ndjson", func(t *testing.T) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		ec := newExplainCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetIn(strings.NewReader("0 9 * * 1-5\nbad\n"))
+		ec.SetArgs([]string{"--stdin", "--ndjson"})
+
+		require.NoError(t, ec.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &result))
+		assert.Equal(t, float64(1), result["line"])
+		assert.Equal(t, "At 09:00 on weekdays (Mon-Fri)", result["description"])
+		result = nil
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &result))
+		assert.Equal(t, float64(2), result["line"])
+		assert.Equal(t, "bad", result["expression"])
+		assert.Contains(t, result["error"], "failed to parse expression")
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("explain --stdin rejects conflicting arguments", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"0 0 * * *", "--stdin"},
+			{"--stdin", "--json"},
+			{"--stdin", "--fields"},
+			{"--stdin", "--file", "jobs.cron"},
+			{"0 0 * * *", "--ndjson"},
+		} {
+			ec := newExplainCommand()
+			ec.SetOut(new(bytes.Buffer))
+			ec.SetErr(new(bytes.Buffer))
+			ec.SetIn(strings.NewReader("0 0 * * *\n"))
+			ec.SetArgs(args)
+			assert.Error(t, ec.Execute(), args)
+		}
+	})
+
 	t.Run("outputJSON error handling", func(t *testing.T) {
 		ec := newExplainCommand()
 		// Use an error writer to trigger JSON encoding error
 		ec.SetOut(&explainErrorWriter{})
 
-		err := ec.outputJSON("0 0 * * *", "At midnight every day")
+		err := ec.outputJSON("0 0 * * *", "At midnight every day", "", nil)
 		// Should return error from JSON encoding
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to encode JSON")
@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/debug"
+	"github.com/hzerrad/cronkit/internal/export"
+	"github.com/hzerrad/cronkit/internal/iac"
+	"github.com/spf13/cobra"
+)
+
+// newExportCommand creates the export command, which groups exporters of
+// crontabs to monitoring and infrastructure as code tools
+func newExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export crontabs to monitoring and infrastructure as code tools",
+		Long: `Export crontab health metrics (job frequency, overlaps and validation
+status) in formats consumed by monitoring tools, or the jobs of a crontab as
+Ansible tasks and Terraform resources, to codify hand-managed crontabs.`,
+	}
+	cmd.AddCommand(newExportPrometheusCommand().Command)
+	cmd.AddCommand(newExportAnsibleCommand().Command)
+	cmd.AddCommand(newExportTerraformCommand().Command)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newExportCommand())
+}
+
+// ExportPrometheusCommand wraps cobra.Command with export prometheus functionality
+type ExportPrometheusCommand struct {
+	*cobra.Command
+	overlapWindow string
+	listen        string
+	debugListen   string
+}
+
+func newExportPrometheusCommand() *ExportPrometheusCommand {
+	ec := &ExportPrometheusCommand{}
+	ec.Command = &cobra.Command{
+		Use:   "prometheus [crontab...]",
+		Short: "Print or serve crontab metrics in the Prometheus exposition format",
+		Long: `Print crontab metrics in the Prometheus text exposition format.
+
+For every crontab, the exporter reports whether it passes validation, the
+number of valid and invalid jobs, validation issues by severity, scheduled
+runs per day, and how many minutes of the --overlap-window have several jobs
+starting at once. Job series (runs per day, validity, issues) are labeled
+with the crontab, line number, expression and command.
+
+Without arguments, the crontab is read from standard input, or the current
+user's crontab is used. Write the output to a file for the node_exporter
+textfile collector, or pass --listen to serve it at /metrics; crontab files
+are then read again on every scrape.
+
+Examples:
+  cronkit export prometheus /etc/crontab
+  cronkit export prometheus /etc/crontab /etc/cron.d/* > /var/lib/node_exporter/cron.prom
+  cronkit export prometheus /etc/crontab --listen :9787
+  cronkit export prometheus /etc/crontab --listen :9787 --debug-listen localhost:6060`,
+		RunE: ec.runPrometheus,
+	}
+
+	ec.Flags().StringVar(&ec.overlapWindow, "overlap-window", "24h", "Time window for overlap metrics (e.g., 1h, 24h, 48h)")
+	ec.Flags().StringVar(&ec.listen, "listen", "", "Serve the metrics at /metrics on this address (e.g., ':9787') instead of printing them")
+	ec.Flags().StringVar(&ec.debugListen, "debug-listen", "", "With --listen, serve pprof and internal metrics on this address (e.g., 'localhost:6060')")
+	return ec
+}
+
+func (ec *ExportPrometheusCommand) runPrometheus(cmd *cobra.Command, args []string) error {
+	window, err := time.ParseDuration(ec.overlapWindow)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid --overlap-window value %q: must be a positive duration (e.g., 1h, 24h)", ec.overlapWindow)
+	}
+	if ec.debugListen != "" && ec.listen == "" {
+		return fmt.Errorf("--debug-listen requires --listen")
+	}
+
+	collect, err := ec.collector(args, export.Options{Locale: GetLocale(), OverlapWindow: window})
+	if err != nil {
+		return err
+	}
+
+	if ec.listen == "" {
+		crontabs, err := collect()
+		if err != nil {
+			return err
+		}
+		return export.WritePrometheus(ec.OutOrStdout(), crontabs)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return ec.serve(ctx, collect)
+}
+
+// collector returns a function collecting the metrics of the crontabs in
+// args. Standard input can only be read once, so its content is kept.
+func (ec *ExportPrometheusCommand) collector(args []string, opts export.Options) (func() ([]*export.CrontabMetrics, error), error) {
+	if len(args) > 0 {
+		return func() ([]*export.CrontabMetrics, error) {
+			reader := crontab.NewReader()
+			crontabs := make([]*export.CrontabMetrics, 0, len(args))
+			for _, path := range args {
+				entries, err := reader.ParseFile(path)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read crontab file %s: %w", path, err)
+				}
+				crontabs = append(crontabs, export.Collect(entries, path, opts))
+			}
+			return crontabs, nil
+		}, nil
+	}
+
+	if isStdinAvailable() {
+		data, err := io.ReadAll(ec.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		return func() ([]*export.CrontabMetrics, error) {
+			entries, err := crontab.ParseReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse crontab: %w", err)
+			}
+			return []*export.CrontabMetrics{export.Collect(entries, "stdin", opts)}, nil
+		}, nil
+	}
+
+	return func() ([]*export.CrontabMetrics, error) {
+		content, err := crontab.ReadUserRaw()
+		if err != nil {
+			return nil, err
+		}
+		entries, err := crontab.ParseReader(strings.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse user crontab: %w", err)
+		}
+		return []*export.CrontabMetrics{export.Collect(entries, "user", opts)}, nil
+	}, nil
+}
+
+// metricsHandler serves the collected metrics, recording scrapes in metrics
+func metricsHandler(collect func() ([]*export.CrontabMetrics, error), metrics *debug.Metrics) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		start := time.Now()
+		crontabs, err := collect()
+		metrics.ObserveRequest("metrics", err == nil, time.Since(start))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := export.WritePrometheus(&buf, crontabs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", export.PrometheusContentType)
+		_, _ = w.Write(buf.Bytes())
+	})
+	return mux
+}
+
+// serve serves the metrics on --listen (and the debug handler on
+// --debug-listen) until ctx is canceled
+func (ec *ExportPrometheusCommand) serve(ctx context.Context, collect func() ([]*export.CrontabMetrics, error)) error {
+	metrics := debug.NewMetrics()
+	servers := []*http.Server{{
+		Addr:              ec.listen,
+		Handler:           metricsHandler(collect, metrics),
+		ReadHeaderTimeout: 10 * time.Second,
+	}}
+	if ec.debugListen != "" {
+		servers = append(servers, debug.NewServer(ec.debugListen, metrics))
+	}
+
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("failed to serve on %s: %w", server.Addr, err)
+			}
+		}()
+	}
+	ec.PrintErrf("Serving Prometheus metrics on %s/metrics\n", ec.listen)
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, server := range servers {
+		_ = server.Shutdown(shutdownCtx)
+	}
+	return err
+}
+
+// ExportAnsibleCommand wraps cobra.Command with export ansible functionality
+type ExportAnsibleCommand struct {
+	*cobra.Command
+	system   bool
+	user     string
+	cronFile string
+}
+
+func newExportAnsibleCommand() *ExportAnsibleCommand {
+	ec := &ExportAnsibleCommand{}
+	ec.Command = &cobra.Command{
+		Use:   "ansible [crontab]",
+		Short: "Print the jobs of a crontab as ansible.builtin.cron tasks",
+		Long: `Print the variables and jobs of a crontab as a YAML list of
+ansible.builtin.cron tasks, to manage a hand-written crontab with Ansible.
+
+Each job becomes a task named after its cronkit:name directive, its comment
+or its command; the cron module finds the entries it manages by these names.
+Schedules are kept as written, and @aliases become special_time values.
+Variables become env tasks, which the cron module writes before every job.
+Jobs with invalid schedules and variables set again with another value are
+left out, and listed on standard error with variables set after a job.
+
+Jobs of system crontabs keep their user column as the task's user, and are
+written to --cron-file, which defaults to the crontab file.
+
+Without an argument, the crontab is read from standard input, or the current
+user's crontab is used.
+
+Examples:
+  cronkit export ansible jobs.cron > roles/app/tasks/cron.yml
+  cronkit export ansible --user deploy
+  cronkit export ansible /etc/cron.d/backup --cron-file backup`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: ec.runAnsible,
+	}
+
+	ec.Flags().BoolVar(&ec.system, "system", false, systemUsage)
+	ec.Flags().StringVar(&ec.user, "user", "", "User whose crontab the tasks manage, for jobs without a user column")
+	ec.Flags().StringVar(&ec.cronFile, "cron-file", "", "File under /etc/cron.d (or absolute path) the tasks manage instead of a user's crontab")
+	return ec
+}
+
+func (ec *ExportAnsibleCommand) runAnsible(_ *cobra.Command, args []string) error {
+	entries, source, err := exportEntries(ec.Command, args, ec.system)
+	if err != nil {
+		return err
+	}
+	opts := iac.AnsibleOptions{User: ec.user, CronFile: ec.cronFile, Source: source}
+	if opts.CronFile == "" && len(args) > 0 && hasUserColumn(entries) {
+		opts.CronFile = args[0]
+	}
+
+	skipped, err := iac.WriteAnsible(ec.OutOrStdout(), entries, opts)
+	if err != nil {
+		return err
+	}
+	printSkippedExports(ec.Command, skipped)
+	return nil
+}
+
+// ExportTerraformCommand wraps cobra.Command with export terraform functionality
+type ExportTerraformCommand struct {
+	*cobra.Command
+	system   bool
+	provider string
+	timezone string
+}
+
+func newExportTerraformCommand() *ExportTerraformCommand {
+	ec := &ExportTerraformCommand{}
+	ec.Command = &cobra.Command{
+		Use:   "terraform [crontab]",
+		Short: "Print the jobs of a crontab as cloud scheduler Terraform resources",
+		Long: `Print the jobs of a crontab as Terraform resources of a cloud scheduler,
+to move hand-managed crontabs to infrastructure as code.
+
+With --provider aws, each job becomes an aws_scheduler_schedule (Amazon
+EventBridge Scheduler) whose target is var.target_arn, invoked with
+var.role_arn. EventBridge needs one of the day fields to be '?', so jobs
+that restrict both become two schedules. With --provider gcp, each job
+becomes a google_cloud_scheduler_job posting to var.target_uri.
+
+Targets receive the job as JSON: {"command": "...", "user": "..."}. Jobs
+are scheduled in their CRON_TZ= zone, else in --timezone, else in the
+scheduler's default (UTC). @reboot jobs and jobs with invalid schedules are
+left out and listed on standard error, and conversion warnings are written
+as comments.
+
+Without an argument, the crontab is read from standard input, or the current
+user's crontab is used.
+
+Examples:
+  cronkit export terraform jobs.cron > schedules.tf
+  cronkit export terraform jobs.cron --provider gcp --timezone Europe/Paris`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: ec.runTerraform,
+	}
+
+	ec.Flags().BoolVar(&ec.system, "system", false, systemUsage)
+	ec.Flags().StringVar(&ec.provider, "provider", iac.ProviderAWS, "Cloud scheduler: 'aws' (EventBridge Scheduler) or 'gcp' (Cloud Scheduler)")
+	ec.Flags().StringVar(&ec.timezone, "timezone", "", "Time zone of jobs without CRON_TZ= (default: the scheduler's, UTC)")
+	return ec
+}
+
+func (ec *ExportTerraformCommand) runTerraform(_ *cobra.Command, args []string) error {
+	if !slices.Contains(iac.Providers(), ec.provider) {
+		return fmt.Errorf("invalid --provider value %q (supported: %s)", ec.provider, strings.Join(iac.Providers(), ", "))
+	}
+	if ec.timezone != "" {
+		if _, err := time.LoadLocation(ec.timezone); err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+	}
+	entries, source, err := exportEntries(ec.Command, args, ec.system)
+	if err != nil {
+		return err
+	}
+
+	skipped, err := iac.WriteTerraform(ec.OutOrStdout(), entries, iac.TerraformOptions{Provider: ec.provider, Timezone: ec.timezone, Source: source})
+	if err != nil {
+		return err
+	}
+	printSkippedExports(ec.Command, skipped)
+	return nil
+}
+
+// exportEntries reads the crontab of the file in args, else of standard
+// input, else of the current user, and names its source
+func exportEntries(cmd *cobra.Command, args []string, system bool) ([]*crontab.Entry, string, error) {
+	switch {
+	case len(args) > 0:
+		entries, err := newCrontabReader(system).ParseFile(args[0])
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read crontab file %s: %w", args[0], err)
+		}
+		return entries, args[0], nil
+	case isStdinAvailable():
+		entries, err := crontab.ParseReaderLayout(cmd.InOrStdin(), crontabLayout(system))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		return entries, "stdin", nil
+	default:
+		content, err := crontab.ReadUserRaw()
+		if err != nil {
+			return nil, "", err
+		}
+		entries, err := crontab.ParseReader(strings.NewReader(content))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse user crontab: %w", err)
+		}
+		return entries, "the user crontab", nil
+	}
+}
+
+// hasUserColumn reports whether the jobs of entries were read with the user
+// column of system crontabs
+func hasUserColumn(entries []*crontab.Entry) bool {
+	for _, job := range jobsFromEntries(entries) {
+		if job.User != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// printSkippedExports lists the lines an export left out or changed on
+// standard error, keeping standard output valid YAML or HCL
+func printSkippedExports(cmd *cobra.Command, skipped []iac.Skipped) {
+	if len(skipped) == 0 {
+		return
+	}
+	cmd.PrintErrf("⚠ %d line(s) not exported as written:\n", len(skipped))
+	for _, s := range skipped {
+		cmd.PrintErrf("  %s\n", s)
+	}
+}
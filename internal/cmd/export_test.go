@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/debug"
+	"github.com/hzerrad/cronkit/internal/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPrometheusCommand(t *testing.T) {
+	path := createTempFile(t, "*/5 * * * * /usr/bin/poll.sh\n0 * * * * /usr/bin/hourly.sh\n")
+
+	t.Run("prints metrics for each crontab", func(t *testing.T) {
+		other := createTempFile(t, "61 * * * * /usr/bin/broken.sh\n")
+
+		ec := newExportPrometheusCommand()
+		buf := new(bytes.Buffer)
+		ec.SetOut(buf)
+		ec.SetArgs([]string{path, other})
+
+		require.NoError(t, ec.Execute())
+		output := buf.String()
+		assert.Contains(t, output, `cronkit_crontab_valid{source="`+path+`"} 1`)
+		assert.Contains(t, output, `cronkit_crontab_valid{source="`+other+`"} 0`)
+		assert.Contains(t, output, `cronkit_crontab_max_concurrent_jobs{source="`+path+`"} 2`)
+		assert.Contains(t, output, `line="1",expression="*/5 * * * *",command="/usr/bin/poll.sh"} 288`)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		ec := newExportPrometheusCommand()
+		ec.SetOut(new(bytes.Buffer))
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs([]string{"/nonexistent/crontab"})
+
+		err := ec.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read crontab file")
+	})
+
+	t.Run("invalid overlap window", func(t *testing.T) {
+		ec := newExportPrometheusCommand()
+		ec.SetOut(new(bytes.Buffer))
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs([]string{path, "--overlap-window", "-1h"})
+
+		err := ec.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --overlap-window")
+	})
+
+	t.Run("debug listener requires listen", func(t *testing.T) {
+		ec := newExportPrometheusCommand()
+		ec.SetOut(new(bytes.Buffer))
+		ec.SetErr(new(bytes.Buffer))
+		ec.SetArgs([]string{path, "--debug-listen", "localhost:6060"})
+
+		err := ec.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--debug-listen requires --listen")
+	})
+}
+
+func TestMetricsHandler(t *testing.T) {
+	path := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+	ec := newExportPrometheusCommand()
+	collect, err := ec.collector([]string{path}, export.Options{})
+	require.NoError(t, err)
+
+	metrics := debug.NewMetrics()
+	handler := metricsHandler(collect, metrics)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, export.PrometheusContentType, rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "# HELP cronkit_crontab_valid"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	failing, err := ec.collector([]string{"/nonexistent/crontab"}, export.Options{})
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	metricsHandler(failing, metrics).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	snap := metrics.Snapshot()
+	require.Len(t, snap.Requests, 1)
+	assert.Equal(t, debug.RequestCount{Command: "metrics", OK: 1, Failed: 1}, snap.Requests[0])
+}
+
+func TestExportAnsibleCommand(t *testing.T) {
+	t.Run("prints tasks and lists skipped lines", func(t *testing.T) {
+		path := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n61 * * * * /usr/bin/broken.sh\n")
+		ec := newExportAnsibleCommand()
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+		ec.SetOut(out)
+		ec.SetErr(errOut)
+		ec.SetArgs([]string{path, "--user", "deploy"})
+
+		require.NoError(t, ec.Execute())
+		assert.Contains(t, out.String(), "- name: Cron job /usr/bin/backup.sh\n  ansible.builtin.cron:\n")
+		assert.Contains(t, out.String(), "    user: deploy\n")
+		assert.Contains(t, errOut.String(), "⚠ 1 line(s) not exported as written:\n  line 2: invalid expression")
+	})
+
+	t.Run("system crontabs are written to their file", func(t *testing.T) {
+		path := createTempFile(t, "0 2 * * * root /usr/bin/backup.sh\n")
+		ec := newExportAnsibleCommand()
+		out := new(bytes.Buffer)
+		ec.SetOut(out)
+		ec.SetArgs([]string{path, "--system"})
+
+		require.NoError(t, ec.Execute())
+		assert.Contains(t, out.String(), "    user: root\n    cron_file: "+path+"\n")
+	})
+}
+
+func TestExportTerraformCommand(t *testing.T) {
+	path := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+
+	t.Run("prints resources of the provider", func(t *testing.T) {
+		for provider, resource := range map[string]string{"aws": "aws_scheduler_schedule", "gcp": "google_cloud_scheduler_job"} {
+			ec := newExportTerraformCommand()
+			out := new(bytes.Buffer)
+			ec.SetOut(out)
+			ec.SetArgs([]string{path, "--provider", provider, "--timezone", "Europe/Paris"})
+
+			require.NoError(t, ec.Execute())
+			assert.Contains(t, out.String(), `resource "`+resource+`" "usr_bin_backup_sh" {`)
+			assert.Contains(t, out.String(), `"Europe/Paris"`)
+		}
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		for _, args := range [][]string{{"--provider", "azure"}, {"--timezone", "Mars/Olympus"}} {
+			ec := newExportTerraformCommand()
+			ec.SetOut(new(bytes.Buffer))
+			ec.SetErr(new(bytes.Buffer))
+			ec.SetArgs(append([]string{path}, args...))
+			assert.Error(t, ec.Execute(), args)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/fleet"
+	"github.com/spf13/cobra"
+)
+
+// newFleetCommand creates the fleet command, which groups analyses spanning
+// the crontabs of many hosts
+func newFleetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Analyze the crontabs of many hosts together",
+		Long: `Analyze the crontabs of a fleet of hosts together.
+
+Each crontab file is one host, named after the file without its extension
+(web01.cron is host web01). Directories contribute one host per file.`,
+	}
+	cmd.AddCommand(newFleetDuplicatesCommand().Command)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newFleetCommand())
+}
+
+// FleetDuplicatesCommand wraps cobra.Command with fleet duplicates functionality
+type FleetDuplicatesCommand struct {
+	*cobra.Command
+	rewrites []string
+	minHosts int
+	all      bool
+	json     bool
+}
+
+func newFleetDuplicatesCommand() *FleetDuplicatesCommand {
+	fc := &FleetDuplicatesCommand{}
+	fc.Command = &cobra.Command{
+		Use:   "duplicates <crontab|directory>...",
+		Short: "Find commands duplicated across hosts with differing schedules",
+		Long: `Find commands that run on many hosts with differing schedules: drifted
+copies of what should be a single standardized job.
+
+Commands are normalized before they are compared: the host name is replaced
+by {host}, each --rewrite is applied in order, and whitespace is collapsed.
+A rewrite is a regular expression and its replacement separated by "=>";
+the replacement may reference capture groups as $1.
+
+Schedules are compared by the times they match, so "@daily" and "0 0 * * *"
+are the same schedule.
+
+Examples:
+  cronkit fleet duplicates crontabs/
+  cronkit fleet duplicates web01.cron web02.cron db01.cron --min-hosts 3
+  cronkit fleet duplicates crontabs/ --rewrite '/home/[^/]+/=>/home/USER/'
+  cronkit fleet duplicates crontabs/ --all --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: fc.runDuplicates,
+	}
+
+	fc.Flags().StringArrayVar(&fc.rewrites, "rewrite", nil, "Rewrite commands before comparing them ('pattern=>replacement', repeatable)")
+	fc.Flags().IntVar(&fc.minHosts, "min-hosts", fleet.DefaultMinHosts, "Minimum number of hosts a command must run on")
+	fc.Flags().BoolVar(&fc.all, "all", false, "Also report duplicates whose schedules all agree")
+	fc.Flags().BoolVarP(&fc.json, "json", "j", false, "Output in JSON format")
+	return fc
+}
+
+func (fc *FleetDuplicatesCommand) runDuplicates(_ *cobra.Command, args []string) error {
+	if fc.minHosts < 1 {
+		return fmt.Errorf("invalid --min-hosts: must be at least 1")
+	}
+
+	rewrites := make([]fleet.Rewrite, 0, len(fc.rewrites))
+	for _, spec := range fc.rewrites {
+		rw, err := fleet.ParseRewrite(spec)
+		if err != nil {
+			return err
+		}
+		rewrites = append(rewrites, rw)
+	}
+
+	hosts, err := fleet.LoadHosts(args)
+	if err != nil {
+		return err
+	}
+
+	duplicates := fleet.FindDuplicates(hosts, fleet.DuplicateOptions{
+		Normalizer:        fleet.NewNormalizer(rewrites),
+		MinHosts:          fc.minHosts,
+		IncludeConsistent: fc.all,
+	})
+
+	if fc.json {
+		return fc.outputJSON(len(hosts), duplicates)
+	}
+	fc.outputText(len(hosts), duplicates)
+	return nil
+}
+
+func (fc *FleetDuplicatesCommand) outputText(hostCount int, duplicates []fleet.Duplicate) {
+	if len(duplicates) == 0 {
+		if fc.all {
+			fc.Printf("No commands found on %d or more of %d hosts\n", fc.minHosts, hostCount)
+		} else {
+			fc.Printf("No drifted duplicates found across %d hosts\n", hostCount)
+		}
+		return
+	}
+
+	noun := "commands"
+	if len(duplicates) == 1 {
+		noun = "command"
+	}
+	fc.Printf("Found %d duplicated %s across %d hosts:\n", len(duplicates), noun, hostCount)
+	for _, dup := range duplicates {
+		status := "consistent"
+		if dup.Drifted {
+			status = fmt.Sprintf("%d schedules", len(dup.Schedules))
+		}
+		fc.Printf("\n%s  (%d hosts, %s)\n", dup.Command, len(dup.Hosts), status)
+
+		width := 0
+		for _, variant := range dup.Schedules {
+			width = max(width, len(variant.Expression))
+		}
+		for _, variant := range dup.Schedules {
+			fc.Printf("  %-*s  %s\n", width, variant.Expression, strings.Join(variant.Hosts, ", "))
+		}
+	}
+}
+
+func (fc *FleetDuplicatesCommand) outputJSON(hostCount int, duplicates []fleet.Duplicate) error {
+	if duplicates == nil {
+		duplicates = []fleet.Duplicate{}
+	}
+	result := map[string]interface{}{
+		"hosts":      hostCount,
+		"minHosts":   fc.minHosts,
+		"duplicates": duplicates,
+	}
+
+	encoder := json.NewEncoder(fc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
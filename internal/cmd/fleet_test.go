@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetDuplicatesCommand(t *testing.T) {
+	fleetDir := filepath.Join("..", "..", "testdata", "fleet")
+
+	t.Run("fleet duplicates should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"fleet", "duplicates"})
+		assert.NoError(t, err)
+		assert.Equal(t, "duplicates", cmd.Name())
+	})
+
+	t.Run("text output lists drifted schedules", func(t *testing.T) {
+		fc := newFleetDuplicatesCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{fleetDir})
+
+		err := fc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Found 1 duplicated command across 3 hosts")
+		assert.Contains(t, output, "/srv/{host}/bin/backup.sh --quiet  (3 hosts, 2 schedules)")
+		assert.Contains(t, output, "  0 2 * * *   web01, web02\n")
+		assert.Contains(t, output, "  30 3 * * *  db01\n")
+		assert.NotContains(t, output, "logrotate")
+	})
+
+	t.Run("--all includes consistent duplicates", func(t *testing.T) {
+		fc := newFleetDuplicatesCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{fleetDir, "--all"})
+
+		err := fc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "/usr/sbin/logrotate /etc/logrotate.conf  (3 hosts, consistent)")
+	})
+
+	t.Run("JSON output with rewrites", func(t *testing.T) {
+		fc := newFleetDuplicatesCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{fleetDir, "--rewrite", "/home/[^/]+/=>/home/USER/", "--json"})
+
+		err := fc.Execute()
+		require.NoError(t, err)
+
+		var result struct {
+			Hosts      int `json:"hosts"`
+			MinHosts   int `json:"minHosts"`
+			Duplicates []struct {
+				Command string   `json:"command"`
+				Hosts   []string `json:"hosts"`
+				Drifted bool     `json:"drifted"`
+			} `json:"duplicates"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, 3, result.Hosts)
+		assert.Equal(t, 2, result.MinHosts)
+		require.Len(t, result.Duplicates, 2)
+		assert.Equal(t, "/home/USER/bin/healthcheck.sh", result.Duplicates[1].Command)
+		assert.True(t, result.Duplicates[1].Drifted)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		fc := newFleetDuplicatesCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{fleetDir, "--min-hosts", "4"})
+
+		err := fc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "No drifted duplicates found across 3 hosts")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			args []string
+			want string
+		}{
+			{"invalid rewrite", []string{fleetDir, "--rewrite", "nope"}, "expected pattern=>replacement"},
+			{"invalid min hosts", []string{fleetDir, "--min-hosts", "0"}, "invalid --min-hosts"},
+			{"missing path", []string{"/nonexistent/fleet"}, "failed to read"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				fc := newFleetDuplicatesCommand()
+				fc.SetOut(new(bytes.Buffer))
+				fc.SetErr(new(bytes.Buffer))
+				fc.SetArgs(tt.args)
+
+				err := fc.Execute()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.want)
+			})
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of commands that propose edits
+const (
+	editFormatText  = "text"
+	editFormatPatch = "patch"
+)
+
+type FmtCommand struct {
+	*cobra.Command
+	file     string
+	stdin    bool
+	align    string
+	aliases  string
+	sort     string
+	simplify bool
+	check    bool
+	format   string
+}
+
+func newFmtCommand() *FmtCommand {
+	fc := &FmtCommand{}
+	fc.Command = &cobra.Command{
+		Use:   "fmt",
+		Short: "Format a crontab",
+		Long: `Format a crontab and print the result to standard output.
+
+Job lines are laid out according to the alignment strategy:
+  - columns:      pad schedule fields so they line up within each block of
+                  jobs (blocks are separated by blank lines)
+  - single-space: separate the schedule fields and command with one space
+  - preserve:     keep the original spacing, including tabs
+
+Schedules can also be normalized:
+  --aliases expand:   write @daily and the other aliases as five fields
+  --aliases contract: write schedules that have an alias as the alias
+  --simplify:         collapse lists into ranges (1,2,3,4,5 becomes 1-5) and
+                      ranges covering a whole minute, hour or month field
+                      into *
+
+With --sort, the jobs of each block are ordered by the time of day of their
+first run (from a Monday midnight, with @reboot jobs first) or by command.
+Blank lines, variables and unparseable lines separate blocks and stay in
+place; the comment lines directly above a job move with it.
+
+Comments, environment variables and commands are kept as written; trailing
+whitespace is removed. Formatting is idempotent: formatting the output again
+yields identical text. The input file is never modified.
+
+With --check, nothing is printed when the crontab is already formatted;
+otherwise a message is printed and the command exits with code 2, for CI.
+
+With --format patch, the changes are printed as a unified diff against the
+input instead, which can be reviewed and applied with 'git apply' or
+'patch -p1'. Nothing is printed when the crontab is already formatted.
+
+Examples:
+  cronkit fmt --file /etc/crontab
+  cronkit fmt --file jobs.cron --align single-space
+  cronkit fmt --file jobs.cron --aliases contract --simplify --sort time
+  cronkit fmt --file jobs.cron --format patch | git apply
+  cronkit fmt --file jobs.cron --check
+  crontab -l | cronkit fmt --align preserve`,
+		Args: cobra.NoArgs,
+		RunE: fc.runFmt,
+	}
+
+	fc.Flags().StringVarP(&fc.file, "file", "f", "", "Path to crontab file")
+	fc.Flags().BoolVar(&fc.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
+	fc.Flags().StringVar(&fc.align, "align", string(crontab.AlignColumns), "Alignment strategy: 'columns', 'single-space', or 'preserve'")
+	fc.Flags().StringVar(&fc.aliases, "aliases", string(crontab.AliasPreserve), "Alias normalization: 'preserve', 'expand' (@daily to 0 0 * * *), or 'contract' (0 0 * * * to @daily)")
+	fc.Flags().StringVar(&fc.sort, "sort", string(crontab.SortNone), "Order of the jobs within each block: 'none', 'time', or 'command'")
+	fc.Flags().BoolVar(&fc.simplify, "simplify", false, "Collapse lists of values into ranges (1,2,3,4,5 to 1-5)")
+	fc.Flags().BoolVar(&fc.check, "check", false, "Print nothing and exit 0 if the crontab is formatted, otherwise report it and exit 2")
+	fc.Flags().StringVar(&fc.format, "format", editFormatText, "Output format: 'text' (formatted crontab) or 'patch' (unified diff against the input)")
+
+	return fc
+}
+
+func init() {
+	rootCmd.AddCommand(newFmtCommand().Command)
+}
+
+func (fc *FmtCommand) runFmt(_ *cobra.Command, _ []string) error {
+	align, err := crontab.ParseAlignMode(fc.align)
+	if err != nil {
+		return fmt.Errorf("invalid --align value: %w", err)
+	}
+	aliases, err := crontab.ParseAliasMode(fc.aliases)
+	if err != nil {
+		return fmt.Errorf("invalid --aliases value: %w", err)
+	}
+	order, err := crontab.ParseSortMode(fc.sort)
+	if err != nil {
+		return fmt.Errorf("invalid --sort value: %w", err)
+	}
+
+	if fc.format != editFormatText && fc.format != editFormatPatch {
+		return fmt.Errorf("invalid --format value %q (supported: text, patch)", fc.format)
+	}
+
+	var (
+		original string
+		name     string
+	)
+	switch {
+	case fc.file != "":
+		data, err := os.ReadFile(fc.file)
+		if err != nil {
+			return fmt.Errorf("failed to read crontab file %s: %w", fc.file, err)
+		}
+		original, name = string(data), fc.file
+	case fc.stdin || isStdinAvailable():
+		data, err := io.ReadAll(fc.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		original, name = string(data), "crontab"
+	default:
+		return fmt.Errorf("no crontab given: use --file or pipe a crontab on standard input")
+	}
+
+	entries, err := crontab.ParseReader(strings.NewReader(original))
+	if err != nil {
+		return fmt.Errorf("failed to parse crontab: %w", err)
+	}
+	formatted := crontab.Format(entries, crontab.FormatOptions{
+		Align:    align,
+		Aliases:  aliases,
+		Sort:     order,
+		Simplify: fc.simplify,
+	})
+
+	if fc.check {
+		if formatted != original {
+			fc.PrintErrf("%s is not formatted (run 'cronkit fmt' to see the changes)\n", name)
+			osExit(2)
+		}
+		return nil
+	}
+	if fc.format == editFormatPatch {
+		fc.Print(diff.Patch(name, original, formatted))
+		return nil
+	}
+	fc.Print(formatted)
+	return nil
+}
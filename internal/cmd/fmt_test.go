@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFmtCommand(t *testing.T) {
+	t.Run("fmt command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"fmt"})
+		assert.NoError(t, err)
+		assert.Equal(t, "fmt", cmd.Name())
+	})
+
+	t.Run("formats a file with aligned columns by default", func(t *testing.T) {
+		file := createTempFile(t, "0 2 * * * /backup.sh\n*/15 * * * * /poll.sh\n")
+		fc := newFmtCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{"--file", file})
+
+		err := fc.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, "0    2 * * * /backup.sh\n*/15 * * * * /poll.sh\n", buf.String())
+	})
+
+	t.Run("reads stdin", func(t *testing.T) {
+		fc := newFmtCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetIn(strings.NewReader("0\t2\t*  *  *   /backup.sh\n"))
+		fc.SetArgs([]string{"--stdin", "--align", "single-space"})
+
+		err := fc.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, "0 2 * * * /backup.sh\n", buf.String())
+	})
+
+	t.Run("output matches the golden file", func(t *testing.T) {
+		dir := filepath.Join("..", "..", "testdata", "crontab", "fmt")
+		golden, err := os.ReadFile(filepath.Join(dir, "messy.columns.golden"))
+		require.NoError(t, err)
+
+		fc := newFmtCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{"--file", filepath.Join(dir, "messy.cron")})
+
+		require.NoError(t, fc.Execute())
+		assert.Equal(t, string(golden), buf.String())
+	})
+
+	t.Run("invalid --align", func(t *testing.T) {
+		fc := newFmtCommand()
+		fc.SetOut(new(bytes.Buffer))
+		fc.SetErr(new(bytes.Buffer))
+		fc.SetArgs([]string{"--stdin", "--align", "tabs"})
+
+		err := fc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --align value")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		fc := newFmtCommand()
+		fc.SetOut(new(bytes.Buffer))
+		fc.SetErr(new(bytes.Buffer))
+		fc.SetArgs([]string{"--file", "/nonexistent/crontab"})
+
+		err := fc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read crontab file")
+	})
+
+	t.Run("prints a patch with --format patch", func(t *testing.T) {
+		file := createTempFile(t, "0 2 * * *   /bin/a\n*/5 * * * * /bin/b\n")
+		fc := newFmtCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{"--file", file, "--format", "patch"})
+
+		require.NoError(t, fc.Execute())
+		name := strings.TrimPrefix(file, "/")
+		assert.Equal(t, "--- a/"+name+"\n+++ b/"+name+"\n"+
+			"@@ -1,2 +1,2 @@\n"+
+			"-0 2 * * *   /bin/a\n"+
+			"+0   2 * * * /bin/a\n"+
+			" */5 * * * * /bin/b\n", buf.String())
+	})
+
+	t.Run("prints nothing with --format patch when already formatted", func(t *testing.T) {
+		fc := newFmtCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetIn(strings.NewReader("0 2 * * * /bin/a\n"))
+		fc.SetArgs([]string{"--stdin", "--format", "patch"})
+
+		require.NoError(t, fc.Execute())
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("normalizes aliases, lists and order", func(t *testing.T) {
+		file := createTempFile(t, "0 9 * * 1,2,3,4,5 /bin/report\n@daily /bin/backup\n")
+		fc := newFmtCommand()
+		buf := new(bytes.Buffer)
+		fc.SetOut(buf)
+		fc.SetArgs([]string{"--file", file, "--align", "single-space", "--aliases", "expand", "--simplify", "--sort", "time"})
+
+		require.NoError(t, fc.Execute())
+		assert.Equal(t, "0 0 * * * /bin/backup\n0 9 * * 1-5 /bin/report\n", buf.String())
+	})
+
+	t.Run("--check", func(t *testing.T) {
+		run := func(content string) (string, string, int) {
+			oldExit := osExit
+			exitCode := 0
+			osExit = func(code int) { exitCode = code }
+			defer func() { osExit = oldExit }()
+
+			fc := newFmtCommand()
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			fc.SetOut(stdout)
+			fc.SetErr(stderr)
+			fc.SetArgs([]string{"--file", createTempFile(t, content), "--check", "--aliases", "contract"})
+			require.NoError(t, fc.Execute())
+			return stdout.String(), stderr.String(), exitCode
+		}
+
+		stdout, stderr, exitCode := run("@daily /bin/a\n")
+		assert.Equal(t, 0, exitCode)
+		assert.Empty(t, stdout)
+		assert.Empty(t, stderr)
+
+		stdout, stderr, exitCode = run("0 0 * * * /bin/a\n")
+		assert.Equal(t, 2, exitCode)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "is not formatted")
+	})
+
+	for _, flag := range []string{"--aliases", "--sort"} {
+		t.Run("invalid "+flag, func(t *testing.T) {
+			fc := newFmtCommand()
+			fc.SetOut(new(bytes.Buffer))
+			fc.SetErr(new(bytes.Buffer))
+			fc.SetArgs([]string{"--stdin", flag, "bogus"})
+
+			err := fc.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid "+flag+" value")
+		})
+	}
+
+	t.Run("invalid --format", func(t *testing.T) {
+		fc := newFmtCommand()
+		fc.SetOut(new(bytes.Buffer))
+		fc.SetErr(new(bytes.Buffer))
+		fc.SetArgs([]string{"--stdin", "--format", "html"})
+
+		err := fc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --format value")
+	})
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// GapsCommand wraps cobra.Command with gaps-specific functionality
+type GapsCommand struct {
+	*cobra.Command
+	from       string
+	horizon    string
+	maxRatio   float64
+	json       bool
+	timezone   string
+	seconds    bool
+	dialect    string
+	jenkinsJob string
+}
+
+// GapJSON is a gap between two consecutive runs
+type GapJSON struct {
+	Start   string  `json:"start"`
+	End     string  `json:"end"`
+	Seconds float64 `json:"seconds"`
+}
+
+// GapsResult represents the complete output for the gaps command
+type GapsResult struct {
+	Expression  string   `json:"expression"`
+	Description string   `json:"description"`
+	From        string   `json:"from"`
+	Until       string   `json:"until"`
+	Timezone    string   `json:"timezone"`
+	Runs        int      `json:"runs"`
+	Shortest    *GapJSON `json:"shortest"`
+	Longest     *GapJSON `json:"longest"`
+	MeanSeconds float64  `json:"meanSeconds"`
+	Ratio       float64  `json:"ratio"`
+	MaxRatio    float64  `json:"maxRatio"`
+	Irregular   bool     `json:"irregular"`
+	Sparse      bool     `json:"sparse"`
+	Truncated   bool     `json:"truncated"`
+}
+
+func init() {
+	rootCmd.AddCommand(newGapsCommand().Command)
+}
+
+// newGapsCommand creates a fresh gaps command instance
+func newGapsCommand() *GapsCommand {
+	gc := &GapsCommand{}
+	gc.Command = &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		RunE:  gc.runGaps,
+		Use:   "gaps <cron-expression>",
+		Short: "Analyze the intervals between consecutive runs of a schedule",
+		Long: `Compute the shortest, longest and mean intervals between consecutive runs of
+a cron expression over a horizon.
+
+Schedules whose longest gap is more than --max-ratio times the shortest are
+flagged as irregular, e.g. "0 0 31 * *" runs 31 or 61 days apart and
+"*/45 * * * *" runs 45 or 15 minutes apart. Schedules that run less than
+twice within the horizon, e.g. "0 0 29 2 *", are flagged as sparse.
+
+At most 100000 runs are examined; longer horizons are cut short.
+
+Examples:
+  cronkit gaps "0 0 31 * *"
+  cronkit gaps "*/45 * * * *" --horizon 1d
+  cronkit gaps "0 0 29 2 *" --horizon 10y --json
+  cronkit gaps "0 9 * * 1-5" --max-ratio 3 --timezone Europe/Paris`,
+	}
+
+	gc.Command.Flags().StringVar(&gc.from, "from", "", fmt.Sprintf(fromUsage, "Start of the horizon"))
+	gc.Command.Flags().StringVar(&gc.horizon, "horizon", "1y", "How far ahead to examine runs (e.g., 1d, 90d, 1y)")
+	gc.Command.Flags().Float64Var(&gc.maxRatio, "max-ratio", stats.DefaultGapRatio, "Flag schedules whose longest gap is more than this many times the shortest")
+	gc.Command.Flags().BoolVarP(&gc.json, "json", "j", false, "Output in JSON format")
+	gc.Command.Flags().StringVar(&gc.timezone, "timezone", "", "Timezone for calculations (e.g., 'America/New_York', 'UTC', defaults to local timezone)")
+	gc.Command.Flags().BoolVar(&gc.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	gc.Command.Flags().StringVar(&gc.dialect, "dialect", "standard", dialectUsage)
+	gc.Command.Flags().StringVar(&gc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+
+	return gc
+}
+
+func (gc *GapsCommand) runGaps(_ *cobra.Command, args []string) error {
+	expression := args[0]
+
+	horizon, err := check.ParseHorizon(gc.horizon)
+	if err != nil {
+		return fmt.Errorf("invalid --horizon value: %w", err)
+	}
+	if gc.maxRatio < 1 {
+		return fmt.Errorf("invalid --max-ratio: must be at least 1")
+	}
+
+	loc := time.Local
+	if gc.timezone != "" {
+		parsedLoc, err := time.LoadLocation(gc.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		loc = parsedLoc
+	}
+
+	from := time.Now().In(loc)
+	if gc.from != "" {
+		parsed, err := parseTimeFlag("from", gc.from, loc)
+		if err != nil {
+			return err
+		}
+		from = parsed
+	}
+
+	opts, err := parserOptions(gc.seconds, gc.dialect, gc.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	schedule, err := cronx.NewParserWithOptions(GetLocale(), opts).Parse(expression)
+	if err != nil {
+		return fmt.Errorf("failed to parse expression: %w", err)
+	}
+
+	report, err := stats.AnalyzeGaps(cronx.WithContext(gc.Context(), cronx.NewSchedulerWithOptions(opts)), expression, stats.GapOptions{
+		From:     from,
+		Horizon:  horizon,
+		MaxRatio: gc.maxRatio,
+	})
+	if err != nil {
+		return err
+	}
+	description := newHumanizer().Humanize(schedule)
+
+	if gc.json {
+		return gc.outputJSON(report, description, loc)
+	}
+	gc.outputText(report, description, horizon, loc)
+	return nil
+}
+
+func (gc *GapsCommand) outputText(report *stats.GapReport, description string, horizon time.Duration, loc *time.Location) {
+	format := getTimeFormat()
+	stamp := func(t time.Time) string {
+		return format.stamp(t.In(loc))
+	}
+
+	gc.Printf("Gaps between runs of \"%s\" (%s)\n", report.Expression, description)
+	runWord := "runs"
+	if report.Runs == 1 {
+		runWord = "run"
+	}
+	gc.Printf("Horizon: %s from %s (%d %s)\n\n", check.FormatHorizon(horizon), stamp(report.From), report.Runs, runWord)
+
+	if report.Sparse {
+		gc.Printf("⚠ Sparse: fewer than two runs within %s, so no gap can be measured; try a longer --horizon\n", check.FormatHorizon(horizon))
+		return
+	}
+
+	gc.Printf("  Shortest: %-8s %s → %s\n", stats.FormatGap(report.Shortest.Duration()), stamp(report.Shortest.Start), stamp(report.Shortest.End))
+	gc.Printf("  Longest:  %-8s %s → %s\n", stats.FormatGap(report.Longest.Duration()), stamp(report.Longest.Start), stamp(report.Longest.End))
+	gc.Printf("  Mean:     %s\n", stats.FormatGap(report.Mean))
+
+	if report.Truncated {
+		gc.Printf("\nOnly the first %d runs were examined (until %s)\n", stats.MaxGapRuns, stamp(report.Until))
+	}
+	if report.Irregular {
+		gc.Printf("\n⚠ Irregular: the longest gap is %.2fx the shortest (more than %gx)\n", report.Ratio, gc.maxRatio)
+	}
+}
+
+func (gc *GapsCommand) outputJSON(report *stats.GapReport, description string, loc *time.Location) error {
+	gap := func(g stats.Gap) *GapJSON {
+		if report.Sparse {
+			return nil
+		}
+		return &GapJSON{
+			Start:   g.Start.In(loc).Format(time.RFC3339),
+			End:     g.End.In(loc).Format(time.RFC3339),
+			Seconds: g.Duration().Seconds(),
+		}
+	}
+
+	result := GapsResult{
+		Expression:  report.Expression,
+		Description: description,
+		From:        report.From.In(loc).Format(time.RFC3339),
+		Until:       report.Until.In(loc).Format(time.RFC3339),
+		Timezone:    loc.String(),
+		Runs:        report.Runs,
+		Shortest:    gap(report.Shortest),
+		Longest:     gap(report.Longest),
+		MeanSeconds: report.Mean.Seconds(),
+		Ratio:       report.Ratio,
+		MaxRatio:    gc.maxRatio,
+		Irregular:   report.Irregular,
+		Sparse:      report.Sparse,
+		Truncated:   report.Truncated,
+	}
+
+	encoder := json.NewEncoder(gc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
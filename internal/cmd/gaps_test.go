@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGapsCommand(t *testing.T) {
+	t.Run("gaps command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"gaps"})
+		assert.NoError(t, err)
+		assert.Equal(t, "gaps", cmd.Name())
+	})
+
+	t.Run("gaps text output flags irregular schedules", func(t *testing.T) {
+		gc := newGapsCommand()
+		buf := new(bytes.Buffer)
+		gc.SetOut(buf)
+		gc.SetArgs([]string{"0 0 31 * *", "--from", "2026-01-01T00:00:00Z", "--timezone", "UTC"})
+
+		require.NoError(t, gc.Execute())
+
+		output := buf.String()
+		assert.Contains(t, output, `Gaps between runs of "0 0 31 * *"`)
+		assert.Contains(t, output, "Horizon: 1y from 2026-01-01 00:00:00 UTC (7 runs)")
+		assert.Contains(t, output, "Shortest: 31d      2026-07-31 00:00:00 UTC → 2026-08-31 00:00:00 UTC")
+		assert.Contains(t, output, "Longest:  61d")
+		assert.Contains(t, output, "⚠ Irregular: the longest gap is 1.97x the shortest (more than 1.5x)")
+	})
+
+	t.Run("gaps text output flags sparse schedules", func(t *testing.T) {
+		gc := newGapsCommand()
+		buf := new(bytes.Buffer)
+		gc.SetOut(buf)
+		gc.SetArgs([]string{"0 0 29 2 *", "--from", "2026-01-01T00:00:00Z", "--timezone", "UTC"})
+
+		require.NoError(t, gc.Execute())
+		assert.Contains(t, buf.String(), "⚠ Sparse: fewer than two runs")
+	})
+
+	t.Run("gaps JSON output", func(t *testing.T) {
+		gc := newGapsCommand()
+		buf := new(bytes.Buffer)
+		gc.SetOut(buf)
+		gc.SetArgs([]string{"*/45 * * * *", "--from", "2026-01-01T00:00:00Z", "--timezone", "UTC", "--horizon", "1d", "--json"})
+
+		require.NoError(t, gc.Execute())
+
+		var result GapsResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "2026-01-02T00:00:00Z", result.Until)
+		assert.Equal(t, 49, result.Runs)
+		require.NotNil(t, result.Shortest)
+		assert.Equal(t, GapJSON{Start: "2026-01-01T00:45:00Z", End: "2026-01-01T01:00:00Z", Seconds: 900}, *result.Shortest)
+		assert.Equal(t, 2700.0, result.Longest.Seconds)
+		assert.Equal(t, 3.0, result.Ratio)
+		assert.True(t, result.Irregular)
+		assert.False(t, result.Sparse)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"invalid"},
+			{"* * * * *", "--horizon", "soon"},
+			{"* * * * *", "--max-ratio", "0.5"},
+			{"* * * * *", "--from", "someday"},
+		} {
+			gc := newGapsCommand()
+			gc.SetOut(new(bytes.Buffer))
+			gc.SetErr(new(bytes.Buffer))
+			gc.SetArgs(args)
+			assert.Error(t, gc.Execute(), args)
+		}
+	})
+}
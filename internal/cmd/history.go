@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/history"
+	"github.com/hzerrad/cronkit/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+type HistoryCommand struct {
+	*cobra.Command
+	history string
+	since   time.Duration
+	limit   int
+	json    bool
+}
+
+func newHistoryCommand() *HistoryCommand {
+	hc := &HistoryCommand{}
+	hc.Command = &cobra.Command{
+		Use:   "history [job]",
+		Short: "Show past runs of jobs, with success rates and duration percentiles",
+		Long: `Show the recorded runs of jobs.
+
+Runs are recorded in the run log of the state directory by 'cronkit daemon'
+and by 'cronkit run --record', which can wrap a job in a crontab:
+
+  0 2 * * * cronkit run backup --record
+
+Without arguments, every job is summarized: its number of runs, success rate
+(runs that exited with code 0), 50th, 90th and 99th percentile and maximum
+durations, and its last run. With a job, selected by its cronkit:name or a
+part of its command, its most recent runs are listed too.
+
+Runs can also be read from --history: a file with one JSON run record per
+line, e.g.
+  {"command":"/usr/local/bin/backup.sh","start":"2026-10-16T02:00:04Z","end":"2026-10-16T02:14:10Z","exitCode":0}
+
+Examples:
+  cronkit history
+  cronkit history backup
+  cronkit history backup --limit 50 --since 168h
+  cronkit history --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: hc.runHistory,
+	}
+
+	hc.Flags().StringVar(&hc.history, "history", "", "File of JSON run records, one per line (default: the run log in the state directory)")
+	hc.Flags().DurationVar(&hc.since, "since", 0, "Only show runs that started within this duration (0 shows all)")
+	hc.Flags().IntVar(&hc.limit, "limit", 20, "Number of recent runs to list for a job")
+	hc.Flags().BoolVarP(&hc.json, "json", "j", false, "Output in JSON format")
+	return hc
+}
+
+func init() {
+	rootCmd.AddCommand(newHistoryCommand().Command)
+}
+
+func (hc *HistoryCommand) runHistory(_ *cobra.Command, args []string) error {
+	if hc.since < 0 {
+		return fmt.Errorf("invalid --since value: must not be negative")
+	}
+	if hc.limit < 0 {
+		return fmt.Errorf("invalid --limit value: must not be negative")
+	}
+
+	records, err := history.ReadFile(hc.history)
+	if err != nil {
+		return err
+	}
+	selector := ""
+	if len(args) == 1 {
+		selector = args[0]
+	}
+	since := time.Time{}
+	if hc.since > 0 {
+		since = time.Now().Add(-hc.since)
+	}
+	records = slices.DeleteFunc(records, func(rec history.Record) bool {
+		return rec.Start.Before(since) || (selector != "" && !rec.Matches(selector))
+	})
+	if selector != "" && len(records) == 0 {
+		return fmt.Errorf("no recorded runs of %q", selector)
+	}
+
+	// Most recent runs first
+	slices.SortStableFunc(records, func(a, b history.Record) int {
+		return b.Start.Compare(a.Start)
+	})
+	var recent []history.Record
+	if selector != "" {
+		recent = records[:min(hc.limit, len(records))]
+	}
+
+	summaries := history.Summarize(records)
+	if hc.json {
+		return hc.outputJSON(summaries, recent)
+	}
+	hc.outputText(summaries, recent)
+	return nil
+}
+
+func (hc *HistoryCommand) outputText(summaries []history.Summary, recent []history.Record) {
+	if len(summaries) == 0 {
+		hc.Println("No recorded runs")
+		return
+	}
+
+	format := getTimeFormat()
+	for i, s := range summaries {
+		if i > 0 {
+			hc.Println()
+		}
+		title := s.Command
+		if s.Name != "" {
+			title = s.Name + ": " + s.Command
+		}
+		if s.Expression != "" {
+			title = s.Expression + " " + title
+		}
+		hc.Println(title)
+		hc.Printf("  Runs: %d, %.1f%% succeeded (%d failed)\n", s.Runs, s.SuccessRate()*100, s.Failures)
+		hc.Printf("  Duration: p50 %s, p90 %s, p99 %s, max %s\n", roundDuration(s.P50), roundDuration(s.P90), roundDuration(s.P99), roundDuration(s.Max))
+		hc.Printf("  Last run: %s (exit %d, took %s)\n", format.stamp(s.Last.Start), s.Last.ExitCode, roundDuration(s.Last.Duration()))
+	}
+
+	if len(recent) == 0 {
+		return
+	}
+	hc.Printf("\nRecent runs:\n")
+	for _, rec := range recent {
+		status := "✓"
+		if rec.ExitCode != 0 {
+			status = "✗"
+		}
+		hc.Printf("  %s %s  exit %d  %s\n", status, format.stamp(rec.Start), rec.ExitCode, roundDuration(rec.Duration()))
+	}
+}
+
+// roundDuration rounds a run duration for display
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Millisecond)
+}
+
+// HistorySummaryJSON is a job in the JSON output of the history command
+type HistorySummaryJSON struct {
+	Name        string         `json:"name,omitempty"`
+	Command     string         `json:"command"`
+	Expression  string         `json:"expression,omitempty"`
+	Runs        int            `json:"runs"`
+	Failures    int            `json:"failures"`
+	SuccessRate float64        `json:"successRate"`
+	P50Seconds  float64        `json:"p50Seconds"`
+	P90Seconds  float64        `json:"p90Seconds"`
+	P99Seconds  float64        `json:"p99Seconds"`
+	MaxSeconds  float64        `json:"maxSeconds"`
+	LastRun     HistoryRunJSON `json:"lastRun"`
+}
+
+// HistoryRunJSON is a run in the JSON output of the history command
+type HistoryRunJSON struct {
+	Command         string  `json:"command"`
+	Scheduled       string  `json:"scheduled,omitempty"`
+	Start           string  `json:"start"`
+	End             string  `json:"end"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	ExitCode        int     `json:"exitCode"`
+}
+
+func newHistoryRunJSON(rec history.Record) HistoryRunJSON {
+	run := HistoryRunJSON{
+		Command:         rec.Command,
+		Start:           rec.Start.Format(time.RFC3339),
+		End:             rec.End.Format(time.RFC3339),
+		DurationSeconds: rec.Duration().Seconds(),
+		ExitCode:        rec.ExitCode,
+	}
+	if !rec.Scheduled.IsZero() {
+		run.Scheduled = rec.Scheduled.Format(time.RFC3339)
+	}
+	return run
+}
+
+func (hc *HistoryCommand) outputJSON(summaries []history.Summary, recent []history.Record) error {
+	jobs := make([]HistorySummaryJSON, 0, len(summaries))
+	for _, s := range summaries {
+		jobs = append(jobs, HistorySummaryJSON{
+			Name:        s.Name,
+			Command:     s.Command,
+			Expression:  s.Expression,
+			Runs:        s.Runs,
+			Failures:    s.Failures,
+			SuccessRate: s.SuccessRate(),
+			P50Seconds:  s.P50.Seconds(),
+			P90Seconds:  s.P90.Seconds(),
+			P99Seconds:  s.P99.Seconds(),
+			MaxSeconds:  s.Max.Seconds(),
+			LastRun:     newHistoryRunJSON(s.Last),
+		})
+	}
+	result := map[string]interface{}{"jobs": jobs}
+	if recent != nil {
+		runs := make([]HistoryRunJSON, 0, len(recent))
+		for _, rec := range recent {
+			runs = append(runs, newHistoryRunJSON(rec))
+		}
+		result["runs"] = runs
+	}
+
+	encoder := json.NewEncoder(hc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// runRecord returns the run record of a run of job
+func runRecord(job *crontab.Job, scheduled time.Time, result runner.Result) history.Record {
+	return history.Record{
+		Name:       job.Metadata.Name,
+		Command:    job.Command,
+		Expression: job.Expression,
+		Scheduled:  scheduled,
+		Start:      result.Start,
+		End:        result.End,
+		ExitCode:   result.ExitCode,
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryCommand(t *testing.T) {
+	runs := filepath.Join(t.TempDir(), "runs.jsonl")
+	require.NoError(t, os.WriteFile(runs, []byte(`{"name":"backup","command":"/usr/bin/backup","expression":"0 2 * * *","start":"2026-10-14T02:00:00Z","end":"2026-10-14T02:10:00Z","exitCode":0}
+{"name":"backup","command":"/usr/bin/backup","expression":"0 2 * * *","start":"2026-10-15T02:00:00Z","end":"2026-10-15T02:20:00Z","exitCode":1}
+{"command":"/usr/bin/report","start":"2026-10-15T03:00:00Z","end":"2026-10-15T03:00:02Z","exitCode":0}
+`), 0o644))
+
+	t.Run("summarizes every job", func(t *testing.T) {
+		hc := newHistoryCommand()
+		buf := new(bytes.Buffer)
+		hc.SetOut(buf)
+		hc.SetArgs([]string{"--history", runs})
+
+		require.NoError(t, hc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "0 2 * * * backup: /usr/bin/backup\n")
+		assert.Contains(t, output, "  Runs: 2, 50.0% succeeded (1 failed)\n")
+		assert.Contains(t, output, "  Duration: p50 10m0s, p90 20m0s, p99 20m0s, max 20m0s\n")
+		assert.Contains(t, output, "/usr/bin/report\n")
+		assert.NotContains(t, output, "Recent runs")
+	})
+
+	t.Run("lists the recent runs of a job", func(t *testing.T) {
+		hc := newHistoryCommand()
+		buf := new(bytes.Buffer)
+		hc.SetOut(buf)
+		hc.SetArgs([]string{"backup", "--history", runs, "--json"})
+
+		require.NoError(t, hc.Execute())
+		var result struct {
+			Jobs []HistorySummaryJSON `json:"jobs"`
+			Runs []HistoryRunJSON     `json:"runs"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Jobs, 1)
+		assert.Equal(t, 0.5, result.Jobs[0].SuccessRate)
+		require.Len(t, result.Runs, 2)
+		assert.Equal(t, "2026-10-15T02:00:00Z", result.Runs[0].Start)
+		assert.Equal(t, 1, result.Runs[0].ExitCode)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		hc := newHistoryCommand()
+		hc.SetOut(new(bytes.Buffer))
+		hc.SetErr(new(bytes.Buffer))
+		hc.SetArgs([]string{"nightly", "--history", runs})
+		assert.Error(t, hc.Execute())
+	})
+
+	t.Run("run --record adds to the run log", func(t *testing.T) {
+		t.Setenv("CRONKIT_STATE_DIR", t.TempDir())
+		file := filepath.Join(t.TempDir(), "jobs.cron")
+		require.NoError(t, os.WriteFile(file, []byte("# cronkit:name=hello\n* * * * * echo hello\n"), 0o644))
+
+		rc := newRunCommand()
+		rc.SetOut(new(bytes.Buffer))
+		rc.SetErr(new(bytes.Buffer))
+		rc.SetArgs([]string{"hello", "--file", file, "--record"})
+		require.NoError(t, rc.Execute())
+
+		hc := newHistoryCommand()
+		buf := new(bytes.Buffer)
+		hc.SetOut(buf)
+		hc.SetArgs([]string{"hello"})
+		require.NoError(t, hc.Execute())
+		assert.Contains(t, buf.String(), "* * * * * hello: echo hello\n")
+		assert.Contains(t, buf.String(), "Runs: 1, 100.0% succeeded")
+	})
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/holiday"
+)
+
+// holidaysUsage is the help text of the --holidays flag
+var holidaysUsage = "Public holiday calendar: a country code (" + strings.Join(holiday.Countries(), ", ") + ") or the path of an ICS file"
+
+// loadHolidays returns the holiday calendar named by a --holidays value, or
+// nil when it is empty
+func loadHolidays(spec string) (*holiday.Calendar, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	calendar, err := holiday.Load(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --holidays value: %w", err)
+	}
+	return calendar, nil
+}
+
+// nextOffHolidays returns the next count runs of an expression after from
+// that do not fall on a holiday of calendar, and the runs on holidays passed
+// over to find them, at most count of them. The search stops
+// MaxHolidaySearch after from, returning fewer runs.
+func nextOffHolidays(ctx context.Context, scheduler cronx.Scheduler, expression string, from time.Time, count int, calendar *holiday.Calendar) (runs, skipped []time.Time, err error) {
+	query := from
+	end := from.Add(MaxHolidaySearch)
+	for len(runs) < count && query.Before(end) {
+		times, err := scheduler.NextContext(ctx, expression, query, count)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(times) == 0 || !times[len(times)-1].After(query) {
+			break
+		}
+		for _, t := range times {
+			if len(runs) == count || !t.Before(end) {
+				break
+			}
+			if _, ok := calendar.Lookup(t); !ok {
+				runs = append(runs, t)
+			} else if len(skipped) < count {
+				skipped = append(skipped, t)
+			}
+		}
+		query = times[len(times)-1]
+	}
+	return runs, skipped, nil
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// hostUsage is the help text of the --host flag shared by list, doc and timeline
+const hostUsage = "Read all scheduled work of the host: /etc/crontab, /etc/cron.d, /etc/anacrontab and the scripts of /etc/cron.{hourly,daily,weekly,monthly} (--host=<dir> reads another configuration directory)"
+
+// defaultHostDir is the configuration directory --host reads without a value
+const defaultHostDir = "/etc"
+
+// readHost reads the crontabs, anacrontab and run-parts directories of the
+// host configuration in etc
+func readHost(etc string) ([]crontab.HostSource, error) {
+	sources, err := crontab.ReadHost(etc)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no crontabs, anacrontab or cron.hourly, daily, weekly or monthly scripts found in %s", etc)
+	}
+	return sources, nil
+}
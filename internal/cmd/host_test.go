@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createHostDir creates a host configuration directory with a system
+// crontab, an anacrontab and a cron.daily script
+func createHostDir(t *testing.T) string {
+	t.Helper()
+	etc := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(etc, "crontab"), []byte("0 2 * * * root /usr/bin/backup\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(etc, "anacrontab"), []byte("START_HOURS_RANGE=3-22\n1 5 cron.daily run-parts /etc/cron.daily\n"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(etc, "cron.daily"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(etc, "cron.daily", "logrotate"), []byte("#!/bin/sh\n"), 0o755))
+	return etc
+}
+
+func TestHostFlag(t *testing.T) {
+	etc := createHostDir(t)
+	script := filepath.Join(etc, "cron.daily", "logrotate")
+
+	t.Run("list shows every job with its source", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--host=" + etc})
+
+		require.NoError(t, cmd.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "SOURCE")
+		assert.Contains(t, output, filepath.Join(etc, "crontab"))
+		assert.Contains(t, output, "via run-parts "+filepath.Join(etc, "cron.daily")+", from "+filepath.Join(etc, "anacrontab")+" line 2")
+	})
+
+	t.Run("list json includes source and trigger", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--host=" + etc, "--json"})
+
+		require.NoError(t, cmd.Execute())
+		var result struct {
+			Jobs []map[string]interface{} `json:"jobs"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Jobs, 3)
+		assert.Equal(t, filepath.Join(etc, "anacrontab"), result.Jobs[1]["source"])
+		assert.Equal(t, "5 3 * * *", result.Jobs[1]["expression"])
+		assert.Equal(t, "anacron job cron.daily (period 1, delay 5m, from 03:00)", result.Jobs[1]["trigger"])
+		assert.Equal(t, script, result.Jobs[2]["command"])
+	})
+
+	t.Run("list rejects --host with --file", func(t *testing.T) {
+		cmd := newListCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"--host=" + etc, "--file", filepath.Join(etc, "crontab")})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--host cannot be used")
+	})
+
+	t.Run("doc has a section per source", func(t *testing.T) {
+		dc := newDocCommand()
+		buf := new(bytes.Buffer)
+		dc.SetOut(buf)
+		dc.SetArgs([]string{"--host=" + etc})
+
+		require.NoError(t, dc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "### "+filepath.Join(etc, "cron.daily")+"\n")
+		assert.Contains(t, output, "**Runs Via:** anacron job cron.daily")
+	})
+
+	t.Run("timeline ids name the source", func(t *testing.T) {
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--host=" + etc, "--json", "--from", "2026-01-01T00:00:00Z", "--timezone", "UTC"})
+
+		require.NoError(t, tc.Execute())
+		assert.Contains(t, buf.String(), `"id": "`+filepath.Join(etc, "cron.daily")+`:1"`)
+	})
+
+	t.Run("empty configuration directory", func(t *testing.T) {
+		cmd := newListCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"--host=" + t.TempDir()})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no crontabs")
+	})
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+type InitCommand struct {
+	*cobra.Command
+	output    string
+	force     bool
+	yes       bool
+	list      bool
+	schedules []string
+	shell     string
+	path      string
+	mailTo    string
+
+	answers *bufio.Scanner // Answers to the prompts, nil once they run out
+}
+
+func newInitCommand() *InitCommand {
+	ic := &InitCommand{}
+	ic.Command = &cobra.Command{
+		Use:   "init [template...]",
+		Short: "Generate a starter crontab from templates of common jobs",
+		Long: `Generate a starter crontab from built-in templates of common jobs:
+backup, logrotate, cert-renewal and report.
+
+The crontab starts with an environment preamble setting SHELL, PATH and
+MAILTO, as cron's defaults (/bin/sh and a minimal PATH) are a common cause of
+jobs that work in a shell but fail under cron. Each job is preceded by its
+description and a cronkit: directive naming it and declaring its duration.
+
+Without templates as arguments, init asks which templates to include; it
+then asks for the schedule of each job, offering a few common ones or any
+cron expression, and for the MAILTO address. Prompts are written to standard
+error and answered on standard input; --yes skips them, taking every
+template (or those given) on its default schedule. The result is run through
+the checks of 'cronkit check' before it is written to standard output, or to
+--output.
+
+Examples:
+  cronkit init                                 # Choose templates and schedules
+  cronkit init backup logrotate --yes          # Default schedules, no prompts
+  cronkit init backup --schedule "backup=0 4 * * *" --mailto ops@example.com -y
+  cronkit init --yes --output starter.cron
+  cronkit init --list`,
+		Args:      cobra.ArbitraryArgs,
+		ValidArgs: scaffold.Names(),
+		RunE:      ic.runInit,
+	}
+
+	ic.Flags().StringVarP(&ic.output, "output", "o", "", "Write the crontab to this file instead of standard output")
+	ic.Flags().BoolVar(&ic.force, "force", false, "Overwrite the --output file if it exists")
+	ic.Flags().BoolVarP(&ic.yes, "yes", "y", false, "Do not prompt: take the templates given (default: all) on their default schedules")
+	ic.Flags().BoolVar(&ic.list, "list", false, "List the templates and their schedules, and exit")
+	ic.Flags().StringArrayVar(&ic.schedules, "schedule", nil, "Schedule of a template's job as template=expression, instead of asking (repeatable)")
+	ic.Flags().StringVar(&ic.shell, "shell", scaffold.DefaultShell, "SHELL of the jobs")
+	ic.Flags().StringVar(&ic.path, "path", scaffold.DefaultPath, "PATH of the jobs")
+	ic.Flags().StringVar(&ic.mailTo, "mailto", scaffold.DefaultMailTo, "MAILTO address cron mails job output to (empty: none)")
+	return ic
+}
+
+func init() {
+	rootCmd.AddCommand(newInitCommand().Command)
+}
+
+func (ic *InitCommand) runInit(_ *cobra.Command, args []string) error {
+	if ic.list {
+		return ic.listTemplates()
+	}
+
+	var selected []scaffold.Template
+	for _, name := range args {
+		t, err := scaffold.Lookup(name)
+		if err != nil {
+			return err
+		}
+		selected = append(selected, t)
+	}
+	schedules, err := ic.parseSchedules()
+	if err != nil {
+		return err
+	}
+	if ic.output != "" && !ic.force {
+		if _, err := os.Stat(ic.output); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite it)", ic.output)
+		}
+	}
+
+	if !ic.yes {
+		ic.answers = bufio.NewScanner(ic.InOrStdin())
+	}
+	if len(selected) == 0 {
+		for _, t := range scaffold.Templates() {
+			if ic.confirm(fmt.Sprintf("Include %s (%s)?", t.Name, t.Description)) {
+				selected = append(selected, t)
+			}
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("no templates selected")
+		}
+	}
+
+	parser := cronx.NewParser()
+	jobs := make([]scaffold.Job, 0, len(selected))
+	for _, t := range selected {
+		expression, ok := schedules[t.Name]
+		if !ok {
+			expression = ic.chooseSchedule(parser, t)
+		}
+		jobs = append(jobs, scaffold.Job{Template: t, Expression: expression})
+	}
+	mailTo := ic.mailTo
+	if !ic.Flags().Changed("mailto") {
+		mailTo = ic.ask(fmt.Sprintf("MAILTO, where cron mails job output (- for none) [%s]: ", ic.mailTo), ic.mailTo)
+		if mailTo == "-" {
+			mailTo = ""
+		}
+	}
+
+	text := scaffold.Render(scaffold.Env{Shell: ic.shell, Path: ic.path, MailTo: mailTo}, jobs)
+	entries, err := crontab.ParseReader(strings.NewReader(text))
+	if err != nil {
+		return fmt.Errorf("failed to parse generated crontab: %w", err)
+	}
+	result := check.NewValidator(GetLocale()).ValidateEntries(entries)
+	if blocking := printIssues(ic.ErrOrStderr(), result.Issues, check.SeverityError); blocking > 0 {
+		return fmt.Errorf("generated crontab has %d error(s); not written", blocking)
+	}
+
+	if ic.output == "" {
+		_, err := io.WriteString(ic.OutOrStdout(), text)
+		return err
+	}
+	if err := os.WriteFile(ic.output, []byte(text), 0o644); err != nil {
+		return fmt.Errorf("failed to write crontab: %w", err)
+	}
+	_, _ = fmt.Fprintf(ic.ErrOrStderr(), "Wrote %d job(s) to %s\n", len(jobs), ic.output)
+	return nil
+}
+
+// listTemplates writes the templates with their schedules, the default
+// first
+func (ic *InitCommand) listTemplates() error {
+	humanizer := newHumanizer()
+	parser := cronx.NewParser()
+	for i, t := range scaffold.Templates() {
+		if i > 0 {
+			ic.Println()
+		}
+		ic.Printf("%s - %s\n", t.Name, t.Description)
+		ic.Printf("  %s\n", t.Command)
+		for n, expression := range t.Schedules {
+			schedule, err := parser.Parse(expression)
+			if err != nil {
+				return fmt.Errorf("template %s: %w", t.Name, err)
+			}
+			suffix := ""
+			if n == 0 {
+				suffix = " (default)"
+			}
+			ic.Printf("  %d) %-14s %s%s\n", n+1, expression, humanizer.Humanize(schedule), suffix)
+		}
+	}
+	return nil
+}
+
+// parseSchedules returns the expressions of the --schedule flags by
+// template name
+func (ic *InitCommand) parseSchedules() (map[string]string, error) {
+	parser := cronx.NewParser()
+	schedules := make(map[string]string, len(ic.schedules))
+	for _, value := range ic.schedules {
+		name, expression, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --schedule value %q (expected template=expression)", value)
+		}
+		t, err := scaffold.Lookup(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --schedule value %q: %w", value, err)
+		}
+		expression = strings.TrimSpace(expression)
+		if _, err := parser.Parse(expression); err != nil {
+			return nil, fmt.Errorf("invalid --schedule value %q: %w", value, err)
+		}
+		schedules[t.Name] = expression
+	}
+	return schedules, nil
+}
+
+// chooseSchedule asks for the schedule of a template's job: the number of
+// one of its schedules or a cron expression, until a valid one is given.
+// Without an answer, the default schedule is taken.
+func (ic *InitCommand) chooseSchedule(parser cronx.Parser, t scaffold.Template) string {
+	if ic.answers == nil {
+		return t.DefaultSchedule()
+	}
+	humanizer := newHumanizer()
+	ic.prompt("\nSchedule of %s:\n", t.Name)
+	for n, expression := range t.Schedules {
+		description := ""
+		if schedule, err := parser.Parse(expression); err == nil {
+			description = humanizer.Humanize(schedule)
+		}
+		ic.prompt("  %d) %-14s %s\n", n+1, expression, description)
+	}
+	for {
+		answer := ic.ask("Number or cron expression [1]: ", "1")
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(t.Schedules) {
+			return t.Schedules[n-1]
+		}
+		if _, err := parser.Parse(answer); err != nil {
+			ic.prompt("✗ %v\n", err)
+			continue
+		}
+		return answer
+	}
+}
+
+// confirm asks a yes or no question, yes by default, until it is answered
+// with yes or no
+func (ic *InitCommand) confirm(question string) bool {
+	for {
+		switch strings.ToLower(ic.ask(question+" [Y/n]: ", "y")) {
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+		ic.prompt("✗ Answer y or n\n")
+	}
+}
+
+// ask prompts for an answer on a line of its own, returning def for an
+// empty line or once the answers run out
+func (ic *InitCommand) ask(prompt, def string) string {
+	if ic.answers == nil {
+		return def
+	}
+	ic.prompt("%s", prompt)
+	if !ic.answers.Scan() {
+		if err := ic.answers.Err(); err != nil {
+			ic.prompt("\n✗ %v\n", err)
+		}
+		ic.answers = nil
+		ic.prompt("\n")
+		return def
+	}
+	if answer := strings.TrimSpace(ic.answers.Text()); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// prompt writes prompts to standard error, leaving standard output to the
+// crontab
+func (ic *InitCommand) prompt(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(ic.ErrOrStderr(), format, args...)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitCommand(t *testing.T) {
+	run := func(t *testing.T, stdin string, args ...string) (string, string, error) {
+		ic := newInitCommand()
+		out := new(bytes.Buffer)
+		errOut := new(bytes.Buffer)
+		ic.SetOut(out)
+		ic.SetErr(errOut)
+		ic.SetIn(strings.NewReader(stdin))
+		ic.SetArgs(args)
+		err := ic.Execute()
+		return out.String(), errOut.String(), err
+	}
+
+	t.Run("default schedules without prompts", func(t *testing.T) {
+		output, stderr, err := run(t, "", "--yes")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output, "# Starter crontab generated by cronkit init\n"))
+		assert.Contains(t, output, "\nSHELL=/bin/bash\n")
+		assert.Contains(t, output, "\nMAILTO=root\n")
+		assert.Contains(t, output, "\n30 2 * * * /usr/local/bin/backup.sh")
+		assert.Contains(t, output, "\n0 0 * * * /usr/sbin/logrotate")
+		assert.Contains(t, output, "\n17 3,15 * * * certbot renew")
+		assert.Contains(t, output, "\n0 7 * * 1-5 /usr/local/bin/generate-report.sh")
+		assert.Contains(t, stderr, "✓ All valid")
+	})
+
+	t.Run("templates and schedules from flags", func(t *testing.T) {
+		output, _, err := run(t, "", "backup", "--schedule", "backup=0 4 * * *", "--mailto", "", "-y")
+		require.NoError(t, err)
+		assert.Contains(t, output, "\nMAILTO=\"\"\n")
+		assert.Contains(t, output, "\n0 4 * * * /usr/local/bin/backup.sh")
+		assert.NotContains(t, output, "logrotate")
+	})
+
+	t.Run("prompts for templates, schedules and MAILTO", func(t *testing.T) {
+		answers := strings.Join([]string{"y", "n", "no", "", "2", "not cron", "0 6 * * 1", "ops@example.com"}, "\n") + "\n"
+		output, stderr, err := run(t, answers)
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "Include backup (Back up files and databases to /var/backups)? [Y/n]: ")
+		assert.Contains(t, stderr, "Schedule of backup:\n  1) 30 2 * * *     At 02:30 every day\n")
+		assert.Contains(t, stderr, "✗ ")
+		assert.Contains(t, output, "\n0 */6 * * * /usr/local/bin/backup.sh")
+		assert.Contains(t, output, "\n0 6 * * 1 /usr/local/bin/generate-report.sh")
+		assert.NotContains(t, output, "logrotate")
+		assert.NotContains(t, output, "certbot")
+		assert.Contains(t, output, "\nMAILTO=ops@example.com\n")
+	})
+
+	t.Run("asks again for answers other than yes or no", func(t *testing.T) {
+		output, stderr, err := run(t, "nope\nno\nn\nyes\nn\n", "--mailto", "")
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "✗ Answer y or n\n")
+		assert.Contains(t, output, "certbot renew")
+		assert.NotContains(t, output, "backup.sh")
+		assert.NotContains(t, output, "logrotate")
+		assert.NotContains(t, output, "generate-report.sh")
+		assert.Contains(t, output, "# cronkit:name=cert-renewal duration=10m\n")
+	})
+
+	t.Run("defaults once the answers run out", func(t *testing.T) {
+		output, _, err := run(t, "", "report")
+		require.NoError(t, err)
+		assert.Contains(t, output, "\n0 7 * * 1-5 /usr/local/bin/generate-report.sh")
+		assert.Contains(t, output, "\nMAILTO=root\n")
+	})
+
+	t.Run("writes --output", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "starter.cron")
+		output, stderr, err := run(t, "", "logrotate", "-y", "--output", path)
+		require.NoError(t, err)
+		assert.Empty(t, output)
+		assert.Contains(t, stderr, "Wrote 1 job(s) to "+path)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "/usr/sbin/logrotate")
+
+		_, _, err = run(t, "", "logrotate", "-y", "--output", path)
+		assert.ErrorContains(t, err, "already exists (use --force to overwrite it)")
+		_, _, err = run(t, "", "backup", "-y", "--output", path, "--force")
+		require.NoError(t, err)
+	})
+
+	t.Run("lists the templates", func(t *testing.T) {
+		output, _, err := run(t, "", "--list")
+		require.NoError(t, err)
+		assert.Contains(t, output, "cert-renewal - Renew TLS certificates")
+		assert.Contains(t, output, "  1) 30 2 * * *     At 02:30 every day (default)\n")
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		_, _, err := run(t, "", "deploy", "-y")
+		assert.ErrorContains(t, err, `unknown template "deploy"`)
+		_, _, err = run(t, "", "backup", "-y", "--schedule", "backup")
+		assert.ErrorContains(t, err, "expected template=expression")
+		_, _, err = run(t, "", "backup", "-y", "--schedule", "backup=61 * * * *")
+		assert.ErrorContains(t, err, `invalid --schedule value "backup=61 * * * *"`)
+		_, _, err = run(t, "n\nn\nn\nn\n")
+		assert.ErrorContains(t, err, "no templates selected")
+	})
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/diff"
+	"github.com/hzerrad/cronkit/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+type JitterizeCommand struct {
+	*cobra.Command
+	file   string
+	stdin  bool
+	seed   string
+	spread int
+	random bool
+	format string
+}
+
+// osHostname is a variable that can be overridden in tests
+var osHostname = os.Hostname
+
+func newJitterizeCommand() *JitterizeCommand {
+	jc := &JitterizeCommand{}
+	jc.Command = &cobra.Command{
+		Use:   "jitterize",
+		Short: "Move jobs off fixed minutes, differently on every host",
+		Long: `Rewrite the jobs of a crontab that start on a fixed minute to start on a
+minute picked within --spread minutes after it, for fleets where many hosts
+share the same crontab and would otherwise all run a job at once.
+
+Minutes are picked by hashing --seed, the host name by default, with the
+job's schedule and command: a host gets the same crontab every time it is
+jitterized, and different hosts get different minutes. Jobs never move into
+another hour, and jobs whose minute field is a list, range, step or ~ range
+are left alone; aliases such as @daily are written as five fields when they
+move.
+
+With --random, the minute is written as an OpenBSD random range instead, such
+as "0~29", which OpenBSD cron picks from when it loads the crontab. Other
+cron implementations do not support it.
+
+The rewritten crontab is printed to standard output and a summary of the
+changes to standard error. With --format patch, the changes are printed as a
+unified diff against the input instead. The input file is never modified.
+
+Examples:
+  cronkit jitterize --file fleet.cron > /etc/cron.d/fleet
+  cronkit jitterize --file fleet.cron --spread 15 --seed web-1
+  cronkit jitterize --file fleet.cron --random --format patch | git apply`,
+		Args: cobra.NoArgs,
+		RunE: jc.runJitterize,
+	}
+
+	jc.Flags().StringVarP(&jc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	jc.Flags().BoolVar(&jc.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
+	jc.Flags().StringVar(&jc.seed, "seed", "", "Seed of the picked minutes (default: the host name)")
+	jc.Flags().IntVar(&jc.spread, "spread", stats.MinutesPerHour, "Minutes after the original minute to pick from (1-60)")
+	jc.Flags().BoolVar(&jc.random, "random", false, "Write OpenBSD random ranges (a~b), picked by cron, instead of seeded minutes")
+	jc.Flags().StringVar(&jc.format, "format", editFormatText, "Output format: 'text' (rewritten crontab) or 'patch' (unified diff against the input)")
+	return jc
+}
+
+func init() {
+	rootCmd.AddCommand(newJitterizeCommand().Command)
+}
+
+func (jc *JitterizeCommand) runJitterize(_ *cobra.Command, _ []string) error {
+	if jc.spread < 1 || jc.spread > stats.MinutesPerHour {
+		return fmt.Errorf("invalid --spread value %d: must be between 1 and %d", jc.spread, stats.MinutesPerHour)
+	}
+	if jc.format != editFormatText && jc.format != editFormatPatch {
+		return fmt.Errorf("invalid --format value %q (supported: text, patch)", jc.format)
+	}
+	if jc.random && jc.Flags().Changed("seed") {
+		return fmt.Errorf("--seed cannot be used with --random, whose minutes cron picks")
+	}
+
+	seed := jc.seed
+	if seed == "" && !jc.random {
+		host, err := osHostname()
+		if err != nil {
+			return fmt.Errorf("failed to get the host name (use --seed): %w", err)
+		}
+		seed = host
+	}
+
+	original, name, err := readCrontabText(jc.Command, jc.file, jc.stdin)
+	if err != nil {
+		return err
+	}
+	entries, err := crontab.ParseReader(strings.NewReader(original))
+	if err != nil {
+		return fmt.Errorf("failed to parse crontab: %w", err)
+	}
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+
+	suggestions := stats.NewCalculator().Jitterize(jobs, stats.JitterizeOptions{Seed: seed, Spread: jc.spread, Random: jc.random})
+	lines := strings.Split(original, "\n")
+	for _, s := range suggestions {
+		if i := s.Job.LineNumber - 1; i >= 0 && i < len(lines) {
+			lines[i], _ = crontab.ReplaceSchedule(lines[i], s.Expression)
+		}
+	}
+	patched := strings.Join(lines, "\n")
+
+	jc.printSummary(suggestions, seed)
+	if jc.format == editFormatPatch {
+		jc.Print(diff.Patch(name, original, patched))
+		return nil
+	}
+	jc.Print(patched)
+	return nil
+}
+
+// printSummary describes the changes on standard error, keeping standard
+// output a valid crontab or patch
+func (jc *JitterizeCommand) printSummary(suggestions []stats.JitterSuggestion, seed string) {
+	if len(suggestions) == 0 {
+		jc.PrintErrln("✓ No jobs to move")
+		return
+	}
+	if jc.random {
+		jc.PrintErrf("Moved %d job(s) to random minutes:\n", len(suggestions))
+	} else {
+		jc.PrintErrf("Moved %d job(s) with seed %q:\n", len(suggestions), seed)
+	}
+	for _, s := range suggestions {
+		if jc.random {
+			jc.PrintErrf("  Line %d: %s → %s\n", s.Job.LineNumber, s.Job.Expression, s.Expression)
+		} else {
+			jc.PrintErrf("  Line %d: %s → %s (+%dm)\n", s.Job.LineNumber, s.Job.Expression, s.Expression, s.Offset)
+		}
+	}
+}
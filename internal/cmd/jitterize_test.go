@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitterizeCommand(t *testing.T) {
+	content := "# Fleet jobs\n0 0 * * * /usr/bin/a.sh\n30 2 * * * /usr/bin/b.sh # b\n@daily /usr/bin/c.sh\n*/5 * * * * /usr/bin/poll.sh\n"
+	path := createTempFile(t, content)
+
+	run := func(t *testing.T, args ...string) (string, string, error) {
+		jc := newJitterizeCommand()
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		jc.SetOut(stdout)
+		jc.SetErr(stderr)
+		jc.SetArgs(args)
+		err := jc.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	t.Run("moves fixed minutes by seed", func(t *testing.T) {
+		stdout, stderr, err := run(t, "--file", path, "--seed", "web-1")
+		require.NoError(t, err)
+		again, _, err := run(t, "--file", path, "--seed", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, stdout, again)
+		assert.NotEqual(t, content, stdout)
+		assert.Contains(t, stderr, `with seed "web-1"`)
+
+		lines := strings.Split(stdout, "\n")
+		assert.Equal(t, "# Fleet jobs", lines[0])
+		assert.Equal(t, "*/5 * * * * /usr/bin/poll.sh", lines[4])
+		assert.True(t, strings.HasSuffix(lines[2], " 2 * * * /usr/bin/b.sh # b"), lines[2])
+		parser := cronx.NewParser()
+		for _, line := range lines[1:4] {
+			schedule, err := parser.Parse(strings.Join(strings.Fields(line)[:5], " "))
+			require.NoError(t, err, line)
+			assert.True(t, schedule.Minute.IsSingle(), line)
+		}
+	})
+
+	t.Run("defaults to the host name", func(t *testing.T) {
+		oldHostname := osHostname
+		defer func() { osHostname = oldHostname }()
+
+		osHostname = func() (string, error) { return "web-1", nil }
+		byHost, _, err := run(t, "--file", path)
+		require.NoError(t, err)
+		bySeed, _, err := run(t, "--file", path, "--seed", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, bySeed, byHost)
+
+		osHostname = func() (string, error) { return "", errors.New("no host name") }
+		_, _, err = run(t, "--file", path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use --seed")
+	})
+
+	t.Run("random ranges", func(t *testing.T) {
+		stdout, _, err := run(t, "--file", path, "--random", "--spread", "30")
+		require.NoError(t, err)
+		assert.Equal(t, "# Fleet jobs\n0~29 0 * * * /usr/bin/a.sh\n30~59 2 * * * /usr/bin/b.sh # b\n0~29 0 * * * /usr/bin/c.sh\n*/5 * * * * /usr/bin/poll.sh\n", stdout)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		stdout, _, err := run(t, "--file", path, "--random", "--format", "patch")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "-0 0 * * * /usr/bin/a.sh\n")
+		assert.Contains(t, stdout, "+0~59 0 * * * /usr/bin/a.sh\n")
+	})
+
+	t.Run("nothing to move", func(t *testing.T) {
+		stdout, stderr, err := run(t, "--file", createTempFile(t, "*/5 * * * * /usr/bin/poll.sh\n"), "--seed", "web-1", "--format", "patch")
+		require.NoError(t, err)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "No jobs to move")
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"--spread", "0"},
+			{"--spread", "61"},
+			{"--format", "xml"},
+			{"--random", "--seed", "web-1"},
+		} {
+			_, _, err := run(t, append([]string{"--file", path}, args...)...)
+			assert.Error(t, err, args)
+		}
+	})
+}
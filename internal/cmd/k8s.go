@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/k8s"
+	"github.com/spf13/cobra"
+)
+
+// newK8sCommand creates the k8s command, which groups commands reading
+// Kubernetes clusters
+func newK8sCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Read the CronJobs of Kubernetes clusters",
+		Long: `Read the CronJobs of Kubernetes clusters through the Kubernetes API, so that
+their schedules can be analyzed like crontabs.`,
+	}
+	cmd.AddCommand(newK8sImportCommand().Command)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newK8sCommand())
+}
+
+// K8sImportCommand wraps cobra.Command with k8s import functionality
+type K8sImportCommand struct {
+	*cobra.Command
+	kubeconfig    string
+	context       string
+	namespace     string
+	allNamespaces bool
+	json          bool
+}
+
+// newK8sClient creates Kubernetes clients; tests replace it
+var newK8sClient = k8s.NewClient
+
+func newK8sImportCommand() *K8sImportCommand {
+	kc := &K8sImportCommand{}
+	kc.Command = &cobra.Command{
+		Use:   "import",
+		Short: "Print the CronJobs of a Kubernetes cluster as a crontab",
+		Long: `List the CronJobs of a Kubernetes cluster and print them as a crontab, so
+that check, timeline, stats, doc and every other command reading crontabs
+can analyze the cluster's schedules.
+
+Each CronJob is preceded by a "cronkit:" directive naming it
+namespace/name, with its namespace, its time zone (spec.timeZone) and its
+concurrency policy unless it is Allow. Its command is what its containers
+run, or their images when they use the image's entrypoint. Suspended
+CronJobs are written as comments.
+
+The cluster is reached with the kubeconfig (--kubeconfig, else $KUBECONFIG,
+else ~/.kube/config) and its current context, or --context. CronJobs are
+read from the context's namespace, --namespace, or all namespaces with
+--all-namespaces.
+
+Examples:
+  cronkit k8s import --context prod --all-namespaces > prod.cron
+  cronkit k8s import --context prod -A | cronkit check --stdin
+  cronkit k8s import -n billing | cronkit stats --stdin
+  cronkit k8s import --context prod -A --json`,
+		Args: cobra.NoArgs,
+		RunE: kc.runImport,
+	}
+
+	kc.Flags().StringVar(&kc.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file (default: $KUBECONFIG, else ~/.kube/config)")
+	kc.Flags().StringVar(&kc.context, "context", "", "Kubeconfig context of the cluster (default: the current context)")
+	kc.Flags().StringVarP(&kc.namespace, "namespace", "n", "", "Namespace to read CronJobs from (default: the context's namespace)")
+	kc.Flags().BoolVarP(&kc.allNamespaces, "all-namespaces", "A", false, "Read CronJobs from all namespaces")
+	kc.Flags().BoolVarP(&kc.json, "json", "j", false, "Output the CronJobs in JSON format")
+	return kc
+}
+
+func (kc *K8sImportCommand) runImport(cmd *cobra.Command, _ []string) error {
+	if kc.allNamespaces && kc.namespace != "" {
+		return fmt.Errorf("--namespace cannot be used with --all-namespaces")
+	}
+	client, err := newK8sClient(kc.kubeconfig, kc.context)
+	if err != nil {
+		return err
+	}
+	namespace := kc.namespace
+	if namespace == "" && !kc.allNamespaces {
+		namespace = client.Namespace
+	}
+
+	jobs, err := client.CronJobs(cmd.Context(), namespace)
+	if err != nil {
+		return err
+	}
+
+	if kc.json {
+		if jobs == nil {
+			jobs = []k8s.CronJob{}
+		}
+		encoder := json.NewEncoder(kc.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(jobs)
+	}
+
+	scope := "namespace " + namespace
+	if namespace == "" {
+		scope = "all namespaces"
+	}
+	if client.Context != "" {
+		scope += " of context " + client.Context
+	}
+	kc.Print(k8s.Crontab(jobs, fmt.Sprintf("Kubernetes CronJobs in %s, imported by cronkit on %s", scope, time.Now().UTC().Format(time.RFC3339))))
+
+	suspended := 0
+	for _, job := range jobs {
+		if job.Suspended {
+			suspended++
+		}
+	}
+	kc.PrintErrf("Imported %d CronJob(s) from %s", len(jobs), scope)
+	if suspended > 0 {
+		kc.PrintErrf(" (%d suspended, written as comments)", suspended)
+	}
+	kc.PrintErrln()
+	return nil
+}
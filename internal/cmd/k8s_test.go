@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sImportCommand(t *testing.T) {
+	cronJob := func(namespace, name, schedule string) *batchv1.CronJob {
+		cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		cj.Spec.Schedule = schedule
+		cj.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{{Image: "acme/" + name}}
+		return cj
+	}
+	clientset := fake.NewClientset(cronJob("billing", "invoice", "0 2 * * *"), cronJob("analytics", "report", "*/15 * * * *"))
+
+	var gotContext string
+	newK8sClient = func(_, context string) (*k8s.Client, error) {
+		gotContext = context
+		if context == "missing" {
+			return nil, errors.New(`failed to load kubeconfig context "missing"`)
+		}
+		return k8s.NewClientFor(clientset, "billing"), nil
+	}
+	t.Cleanup(func() { newK8sClient = k8s.NewClient })
+
+	run := func(args ...string) (string, string, error) {
+		kc := newK8sImportCommand()
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+		kc.SetOut(out)
+		kc.SetErr(errOut)
+		kc.SetArgs(args)
+		err := kc.Execute()
+		return out.String(), errOut.String(), err
+	}
+
+	t.Run("context namespace", func(t *testing.T) {
+		out, errOut, err := run("--context", "prod")
+		require.NoError(t, err)
+		assert.Equal(t, "prod", gotContext)
+		assert.Contains(t, out, "# Kubernetes CronJobs in namespace billing, imported by cronkit on ")
+		assert.Contains(t, out, "# cronkit:name=billing/invoice namespace=billing\n0 2 * * * acme/invoice\n")
+		assert.NotContains(t, out, "analytics")
+		assert.Equal(t, "Imported 1 CronJob(s) from namespace billing\n", errOut)
+	})
+
+	t.Run("all namespaces as JSON", func(t *testing.T) {
+		out, _, err := run("-A", "--json")
+		require.NoError(t, err)
+		var jobs []k8s.CronJob
+		require.NoError(t, json.Unmarshal([]byte(out), &jobs))
+		require.Len(t, jobs, 2)
+		assert.Equal(t, "analytics", jobs[0].Namespace)
+		assert.Equal(t, "*/15 * * * *", jobs[0].Schedule)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := run("-A", "-n", "billing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--namespace cannot be used with --all-namespaces")
+
+		_, _, err = run("--context", "missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing")
+	})
+}
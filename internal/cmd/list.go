@@ -1,30 +1,46 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hzerrad/cronkit/internal/color"
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
 	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/hzerrad/cronkit/internal/query"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
+// Widest the description and command columns of list get, unless --wide
 const (
-	maxDescriptionLength  = 36
-	maxCommandLength      = 40
-	maxDescriptionDisplay = 33 // for truncation
-	maxCommandDisplay     = 37 // for truncation
+	maxDescriptionLength = 36
+	maxCommandLength     = 40
 )
 
 type ListCommand struct {
 	*cobra.Command
-	file  string
-	all   bool
-	json  bool
-	stdin bool
+	file    string
+	system  bool
+	host    string
+	all     bool
+	json    bool
+	ndjson  bool
+	format  string
+	stdin   bool
+	expand  bool
+	filters []string
+	sort    string
+	columns []string
+	wide    bool
+	wrap    bool
 }
 
 func newListCommand() *ListCommand {
@@ -37,16 +53,59 @@ func newListCommand() *ListCommand {
 Examples:
   cronkit list                        # List current user's cron jobs
   cronkit list --file /etc/crontab    # List jobs from specific file
+  cronkit list --host                 # All cron, anacron and run-parts jobs of the host
   cronkit list --all                  # Include comments and environment variables
   cronkit list --json                 # Output as JSON
-  cronkit list --file sample.cron --json > jobs.json`,
+  cronkit list --expand               # Show commands with ~ and $VARs resolved
+  cronkit list --filter owner=infra --filter "runs>=24"
+  cronkit list --filter tag=db --sort next
+  cronkit list --file sample.cron --json > jobs.json
+  cronkit list --file sample.cron --format csv > jobs.csv
+  cronkit list --file big.cron --ndjson | jq -r .command
+  cronkit list --columns schedule,next,owner,command --wide
+
+The text table shows the columns selected with --columns, in order:
+  source, line, schedule (or expression), description, next, user,
+  command, name, owner, tags, timezone, trigger
+By default it shows line, schedule, description and command, with source
+and user for host listings and system crontabs. Descriptions and commands
+are truncated to fit; --wrap wraps them onto more lines instead, and --wide
+shows them in full.
+
+Filters have the form <field><op><value> and all must match:
+  command=<text>      Command contains the text (ignoring case)
+  name=, owner=       "cronkit:name=" and "cronkit:owner=" directives
+  tag=<tag>           One of the "cronkit:tags=" directive
+  frequency=<class>   secondly, minutely, hourly, daily, weekly, monthly,
+                      yearly or never, by the shortest interval between runs
+  runs<op><n>         Runs per day, with =, !=, <, <=, > or >=
+Fields also accept != to exclude matches.
+
+With --format csv or tsv, each job is a row with the columns:
+  source, line, expression, description, user, command, resolved_command,
+  comment, name, owner, tags, trigger
+With --all, each line is a row with the columns line, type and raw.
+
+With --ndjson, each job (or line, with --all) is written as a line of JSON, in
+the format of the jobs (or entries) of --json.`,
 		RunE: lc.runList,
 	}
 
 	lc.Flags().StringVarP(&lc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	lc.Flags().BoolVar(&lc.system, "system", false, systemUsage)
+	lc.Flags().StringVar(&lc.host, "host", "", hostUsage)
+	lc.Flags().Lookup("host").NoOptDefVal = defaultHostDir
 	lc.Flags().BoolVarP(&lc.all, "all", "a", false, "Show all entries including comments and environment variables")
 	lc.Flags().BoolVarP(&lc.json, "json", "j", false, "Output in JSON format")
+	lc.Flags().StringVar(&lc.format, "format", tabularFormatText, fmt.Sprintf(tabularFormatUsage, "job"))
+	lc.Flags().BoolVar(&lc.ndjson, "ndjson", false, fmt.Sprintf(ndjsonUsage, "job"))
 	lc.Flags().BoolVar(&lc.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
+	lc.Flags().BoolVar(&lc.expand, "expand", false, expandUsage)
+	lc.Flags().StringArrayVar(&lc.filters, "filter", nil, "Only list jobs matching a filter, e.g. owner=infra or runs>=24 (repeatable)")
+	lc.Flags().StringVar(&lc.sort, "sort", "line", "Order jobs by line number (line), soonest next run (next) or most frequent first (frequency)")
+	lc.Flags().StringSliceVar(&lc.columns, "columns", nil, "Columns of the text table, comma-separated, e.g. schedule,description,next,command,owner (columns listed above)")
+	lc.Flags().BoolVar(&lc.wide, "wide", false, "Show descriptions and commands in full in the text table instead of truncating them")
+	lc.Flags().BoolVar(&lc.wrap, "wrap", false, "Wrap long descriptions and commands onto more lines in the text table instead of truncating them")
 
 	return lc
 }
@@ -56,14 +115,77 @@ func init() {
 }
 
 func (lc *ListCommand) runList(_ *cobra.Command, args []string) error {
-	reader := crontab.NewReader()
+	format, err := tabularFormat(lc.format, lc.json)
+	if err != nil {
+		return err
+	}
+	lc.format = format
+	if err := validateNDJSON(lc.Command, lc.ndjson); err != nil {
+		return err
+	}
+
+	filters := make([]query.Filter, 0, len(lc.filters))
+	for _, expr := range lc.filters {
+		f, err := query.ParseFilter(expr)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, f)
+	}
+	sortKey, err := query.ParseSortKey(lc.sort)
+	if err != nil {
+		return err
+	}
+	if lc.all && (len(filters) > 0 || sortKey != query.SortLine) {
+		return fmt.Errorf("--filter and --sort cannot be used with --all")
+	}
+	if len(lc.columns) > 0 || lc.wide || lc.wrap {
+		if lc.all || lc.ndjson || lc.format != tabularFormatText {
+			return fmt.Errorf("--columns, --wide and --wrap only apply to the text table of jobs")
+		}
+		if lc.wide && lc.wrap {
+			return fmt.Errorf("--wide and --wrap cannot be used together")
+		}
+	}
+	columns, err := parseListColumns(lc.columns)
+	if err != nil {
+		return err
+	}
+
+	reader := newCrontabReader(lc.system)
+
+	// Output written a line at a time in crontab order is written as the
+	// file or standard input is read, so crontabs of any size are listed in
+	// constant memory
+	if lc.host == "" && sortKey == query.SortLine && (lc.ndjson || isTabular(lc.format)) {
+		switch {
+		case lc.file != "":
+			return lc.streamList(readErrors(reader.FileEntries(lc.Context(), lc.file), "failed to read crontab file "+lc.file), filters)
+		case lc.stdin || isStdinAvailable():
+			return lc.streamList(readErrors(reader.StdinEntries(lc.Context()), "failed to read crontab from stdin"), filters)
+		}
+	}
 
 	var jobs []*crontab.Job
 	var entries []*crontab.Entry
-	var err error
 
-	// Priority: --file > --stdin > user crontab
-	if lc.file != "" {
+	// Priority: --host > --file > --stdin > user crontab
+	if lc.host != "" {
+		if lc.file != "" || lc.stdin || lc.all {
+			return fmt.Errorf("--host cannot be used with --file, --stdin or --all")
+		}
+		sources, err := readHost(lc.host)
+		if err != nil {
+			return err
+		}
+		for _, source := range sources {
+			for _, entry := range source.Entries {
+				if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+					jobs = append(jobs, entry.Job)
+				}
+			}
+		}
+	} else if lc.file != "" {
 		if lc.all {
 			entries, err = reader.ParseFile(lc.file)
 		} else {
@@ -112,51 +234,214 @@ func (lc *ListCommand) runList(_ *cobra.Command, args []string) error {
 		return lc.outputAllEntries(entries)
 	}
 
+	if len(filters) > 0 || sortKey != query.SortLine {
+		jobs, err = queryJobs(jobs, filters, sortKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Handle empty job list
 	if len(jobs) == 0 {
-		if lc.json {
+		if lc.ndjson {
+			return nil
+		}
+		if isTabular(lc.format) {
+			return lc.outputJobsTabular(jobs, nil)
+		}
+		if lc.format == tabularFormatJSON {
 			return lc.outputJSON(map[string]interface{}{"jobs": []interface{}{}})
 		}
+		if len(filters) > 0 {
+			lc.Println("No cron jobs match the filters")
+			return nil
+		}
 		lc.Println("No cron jobs found")
 		return nil
 	}
 
+	env, err := jobEnvironment(lc.expand)
+	if err != nil {
+		return err
+	}
+
 	// Output results
-	if lc.json {
-		return lc.outputJobsJSON(jobs)
+	switch {
+	case lc.ndjson:
+		return lc.outputJobsNDJSON(jobs, env)
+	case lc.format == tabularFormatJSON:
+		return lc.outputJobsJSON(jobs, env)
+	case isTabular(lc.format):
+		return lc.outputJobsTabular(jobs, env)
 	}
 
-	return lc.outputJobsTable(jobs)
+	return lc.outputJobsTable(jobs, env, columns)
 }
 
-func (lc *ListCommand) outputJobsJSON(jobs []*crontab.Job) error {
-	type jobOutput struct {
-		LineNumber  int    `json:"lineNumber"`
-		Expression  string `json:"expression"`
-		Command     string `json:"command"`
-		Comment     string `json:"comment,omitempty"`
-		Description string `json:"description,omitempty"`
+// streamList writes each job matching the filters (or each line, with --all)
+// as an NDJSON line or CSV or TSV row as soon as it is read
+func (lc *ListCommand) streamList(entries iter.Seq2[*crontab.Entry, error], filters []query.Filter) error {
+	var env map[string]string
+	if !lc.all {
+		var err error
+		if env, err = jobEnvironment(lc.expand); err != nil {
+			return err
+		}
 	}
-
-	output := make([]jobOutput, 0, len(jobs))
 	parser := cronx.NewParserWithLocale(GetLocale())
+	humanizer := newHumanizer()
+	scheduler := cronx.NewScheduler()
+	now := time.Now()
+
+	var write func(*crontab.Entry) error
+	var table *csv.Writer
+	switch {
+	case lc.ndjson:
+		out := newNDJSONWriter(lc.OutOrStdout())
+		write = func(entry *crontab.Entry) error {
+			if lc.all {
+				return out.Write(newListEntry(entry))
+			}
+			return out.Write(newListJob(entry.Job, env, parser))
+		}
+	default:
+		table = newTableWriter(lc.OutOrStdout(), lc.format)
+		header := listJobColumns
+		if lc.all {
+			header = []string{"line", "type", "raw"}
+		}
+		if err := table.Write(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", lc.format, err)
+		}
+		write = func(entry *crontab.Entry) error {
+			if lc.all {
+				return table.Write([]string{strconv.Itoa(entry.LineNumber), entryTypeString(entry.Type), entry.Raw})
+			}
+			return table.Write(listJobRow(entry.Job, env, parser, humanizer))
+		}
+	}
 
-	for _, job := range jobs {
-		jo := jobOutput{
-			LineNumber: job.LineNumber,
-			Expression: job.Expression,
-			Command:    job.Command,
-			Comment:    job.Comment,
+	for entry, err := range entries {
+		if err != nil {
+			return err
+		}
+		if !lc.all && (entry.Type != crontab.EntryTypeJob || entry.Job == nil) {
+			continue
+		}
+		if len(filters) > 0 {
+			row, err := query.NewRow(scheduler, entry.Job, now, time.Local)
+			if err != nil {
+				return err
+			}
+			if len(query.Apply([]query.Row{row}, filters)) == 0 {
+				continue
+			}
+		}
+		if err := write(entry); err != nil {
+			return err
 		}
+	}
 
-		// Try to parse and humanize the expression
-		schedule, err := parser.Parse(job.Expression)
-		if err == nil {
-			humanizer := human.NewHumanizer()
-			jo.Description = humanizer.Humanize(schedule)
+	if table != nil {
+		table.Flush()
+		if err := table.Error(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", lc.format, err)
 		}
+	}
+	return nil
+}
+
+// queryJobs returns the jobs matching every filter, ordered by sortKey
+func queryJobs(jobs []*crontab.Job, filters []query.Filter, sortKey query.SortKey) ([]*crontab.Job, error) {
+	scheduler := cronx.NewScheduler()
+	now := time.Now()
+	rows := make([]query.Row, 0, len(jobs))
+	for _, job := range jobs {
+		row, err := query.NewRow(scheduler, job, now, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	rows = query.Apply(rows, filters)
+	query.Sort(rows, sortKey)
 
-		output = append(output, jo)
+	matched := make([]*crontab.Job, len(rows))
+	for i, row := range rows {
+		matched[i] = row.Job
+	}
+	return matched, nil
+}
+
+// resolvedCommand returns the job's command expanded in env, or "" when env
+// is nil or expansion changes nothing
+func resolvedCommand(job *crontab.Job, env map[string]string) string {
+	if env == nil {
+		return ""
+	}
+	if resolved := job.ExpandedCommand(env); resolved != job.Command {
+		return resolved
+	}
+	return ""
+}
+
+// jobMetadata returns the metadata declared by the job's "cronkit:"
+// directives, or nil when there are none
+func jobMetadata(job *crontab.Job) *crontab.Metadata {
+	if job.Metadata.IsZero() {
+		return nil
+	}
+	meta := job.Metadata
+	return &meta
+}
+
+// listJob is a job in the JSON output of list
+type listJob struct {
+	LineNumber      int               `json:"lineNumber"`
+	Expression      string            `json:"expression"`
+	User            string            `json:"user,omitempty"`
+	Timezone        string            `json:"timezone,omitempty"`
+	Command         string            `json:"command"`
+	ResolvedCommand string            `json:"resolvedCommand,omitempty"`
+	Comment         string            `json:"comment,omitempty"`
+	Metadata        *crontab.Metadata `json:"metadata,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Source          string            `json:"source,omitempty"`
+	Trigger         string            `json:"trigger,omitempty"`
+}
+
+// newListJob returns a job in the JSON output format, described when its
+// expression parses
+func newListJob(job *crontab.Job, env map[string]string, parser cronx.Parser) listJob {
+	jo := listJob{
+		LineNumber:      job.LineNumber,
+		Expression:      job.Expression,
+		User:            job.User,
+		Timezone:        job.Timezone,
+		Command:         job.Command,
+		ResolvedCommand: resolvedCommand(job, env),
+		Comment:         job.Comment,
+		Metadata:        jobMetadata(job),
+		Source:          job.Source,
+		Trigger:         job.Trigger,
+	}
+
+	// Try to parse and humanize the expression
+	schedule, err := parser.Parse(job.Expression)
+	if err == nil {
+		humanizer := newHumanizer()
+		jo.Description = humanizer.Humanize(schedule)
+	}
+	return jo
+}
+
+func (lc *ListCommand) outputJobsJSON(jobs []*crontab.Job, env map[string]string) error {
+	output := make([]listJob, 0, len(jobs))
+	parser := cronx.NewParserWithLocale(GetLocale())
+
+	for _, job := range jobs {
+		output = append(output, newListJob(job, env, parser))
 	}
 
 	return lc.outputJSON(map[string]interface{}{
@@ -165,40 +450,108 @@ func (lc *ListCommand) outputJobsJSON(jobs []*crontab.Job) error {
 	})
 }
 
+// outputJobsNDJSON writes each job as a line of JSON, as soon as it is described
+func (lc *ListCommand) outputJobsNDJSON(jobs []*crontab.Job, env map[string]string) error {
+	out := newNDJSONWriter(lc.OutOrStdout())
+	parser := cronx.NewParserWithLocale(GetLocale())
+	for _, job := range jobs {
+		if err := out.Write(newListJob(job, env, parser)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputJobsTabular writes a CSV or TSV row per job
+func (lc *ListCommand) outputJobsTabular(jobs []*crontab.Job, env map[string]string) error {
+	parser := cronx.NewParserWithLocale(GetLocale())
+	humanizer := newHumanizer()
+
+	rows := make([][]string, 0, len(jobs))
+	for _, job := range jobs {
+		rows = append(rows, listJobRow(job, env, parser, humanizer))
+	}
+	return writeTable(lc.OutOrStdout(), lc.format, listJobColumns, rows)
+}
+
+// listJobColumns are the columns of the CSV and TSV output of list
+var listJobColumns = []string{"source", "line", "expression", "description", "user", "command", "resolved_command",
+	"comment", "name", "owner", "tags", "trigger"}
+
+// listJobRow returns the CSV or TSV row of a job
+func listJobRow(job *crontab.Job, env map[string]string, parser cronx.Parser, humanizer human.Humanizer) []string {
+	description := ""
+	if schedule, err := parser.Parse(job.Expression); err == nil {
+		description = humanizer.Humanize(schedule)
+	}
+	meta := job.Metadata
+	return []string{
+		job.Source, strconv.Itoa(job.LineNumber), job.Expression, description, job.User,
+		job.Command, resolvedCommand(job, env), job.Comment,
+		meta.Name, meta.Owner, strings.Join(meta.Tags, ","), job.Trigger,
+	}
+}
+
+// listEntry is a line of the crontab in the JSON output of list --all
+type listEntry struct {
+	LineNumber int           `json:"lineNumber"`
+	Type       string        `json:"type"`
+	Raw        string        `json:"raw"`
+	Job        *listEntryJob `json:"job,omitempty"`
+}
+
+// listEntryJob is the job of a listEntry
+type listEntryJob struct {
+	Expression string            `json:"expression"`
+	User       string            `json:"user,omitempty"`
+	Command    string            `json:"command"`
+	Comment    string            `json:"comment,omitempty"`
+	Metadata   *crontab.Metadata `json:"metadata,omitempty"`
+}
+
+// newListEntry returns a line of the crontab in the JSON output format
+func newListEntry(entry *crontab.Entry) listEntry {
+	eo := listEntry{
+		LineNumber: entry.LineNumber,
+		Type:       entryTypeString(entry.Type),
+		Raw:        entry.Raw,
+	}
+
+	if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+		eo.Job = &listEntryJob{
+			Expression: entry.Job.Expression,
+			User:       entry.Job.User,
+			Command:    entry.Job.Command,
+			Comment:    entry.Job.Comment,
+			Metadata:   jobMetadata(entry.Job),
+		}
+	}
+	return eo
+}
+
 func (lc *ListCommand) outputAllEntries(entries []*crontab.Entry) error {
-	if lc.json {
-		type entryOutput struct {
-			LineNumber int    `json:"lineNumber"`
-			Type       string `json:"type"`
-			Raw        string `json:"raw"`
-			Job        *struct {
-				Expression string `json:"expression"`
-				Command    string `json:"command"`
-				Comment    string `json:"comment,omitempty"`
-			} `json:"job,omitempty"`
-		}
-
-		output := make([]entryOutput, 0, len(entries))
+	if isTabular(lc.format) {
+		rows := make([][]string, 0, len(entries))
 		for _, entry := range entries {
-			eo := entryOutput{
-				LineNumber: entry.LineNumber,
-				Type:       entryTypeString(entry.Type),
-				Raw:        entry.Raw,
-			}
+			rows = append(rows, []string{strconv.Itoa(entry.LineNumber), entryTypeString(entry.Type), entry.Raw})
+		}
+		return writeTable(lc.OutOrStdout(), lc.format, []string{"line", "type", "raw"}, rows)
+	}
 
-			if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
-				eo.Job = &struct {
-					Expression string `json:"expression"`
-					Command    string `json:"command"`
-					Comment    string `json:"comment,omitempty"`
-				}{
-					Expression: entry.Job.Expression,
-					Command:    entry.Job.Command,
-					Comment:    entry.Job.Comment,
-				}
+	if lc.ndjson {
+		out := newNDJSONWriter(lc.OutOrStdout())
+		for _, entry := range entries {
+			if err := out.Write(newListEntry(entry)); err != nil {
+				return err
 			}
+		}
+		return nil
+	}
 
-			output = append(output, eo)
+	if lc.format == tabularFormatJSON {
+		output := make([]listEntry, 0, len(entries))
+		for _, entry := range entries {
+			output = append(output, newListEntry(entry))
 		}
 
 		return lc.outputJSON(map[string]interface{}{
@@ -208,48 +561,203 @@ func (lc *ListCommand) outputAllEntries(entries []*crontab.Entry) error {
 	}
 
 	// Table output for all entries
+	painter := painterFor(lc.OutOrStderr())
 	for _, entry := range entries {
-		typeStr := entryTypeString(entry.Type)
-		lc.Printf("%-4d  %-10s  %s\n", entry.LineNumber, typeStr, entry.Raw)
+		typeStr := fmt.Sprintf("%-10s", entryTypeString(entry.Type))
+		switch entry.Type {
+		case crontab.EntryTypeInvalid:
+			typeStr = painter.Paint(color.Error, typeStr)
+		case crontab.EntryTypeComment, crontab.EntryTypeEmpty:
+			typeStr = painter.Paint(color.Muted, typeStr)
+		}
+		lc.Printf("%-4d  %s  %s\n", entry.LineNumber, typeStr, entry.Raw)
 	}
 
 	return nil
 }
 
-func (lc *ListCommand) outputJobsTable(jobs []*crontab.Job) error {
-	parser := cronx.NewParserWithLocale(GetLocale())
-	humanizer := human.NewHumanizer()
+// List columns of the text table
+const (
+	listColumnSource      = "source"
+	listColumnLine        = "line"
+	listColumnSchedule    = "schedule"
+	listColumnDescription = "description"
+	listColumnNext        = "next"
+	listColumnUser        = "user"
+	listColumnCommand     = "command"
+	listColumnName        = "name"
+	listColumnOwner       = "owner"
+	listColumnTags        = "tags"
+	listColumnTimezone    = "timezone"
+	listColumnTrigger     = "trigger"
+)
 
-	// Print header
-	lc.Println("LINE  EXPRESSION        DESCRIPTION                          COMMAND")
-	lc.Println("────  ────────────────  ───────────────────────────────────  ────────────────────────")
+// listTableColumns are the columns --columns selects from, by name
+var listTableColumns = map[string]tableColumn{
+	listColumnSource:      {header: "SOURCE"},
+	listColumnLine:        {header: "LINE"},
+	listColumnSchedule:    {header: "EXPRESSION"},
+	listColumnDescription: {header: "DESCRIPTION", limit: maxDescriptionLength},
+	listColumnNext:        {header: "NEXT"},
+	listColumnUser:        {header: "USER"},
+	listColumnCommand:     {header: "COMMAND", limit: maxCommandLength},
+	listColumnName:        {header: "NAME"},
+	listColumnOwner:       {header: "OWNER"},
+	listColumnTags:        {header: "TAGS"},
+	listColumnTimezone:    {header: "TIMEZONE"},
+	listColumnTrigger:     {header: "TRIGGER"},
+}
 
-	for _, job := range jobs {
-		description := ""
-		schedule, err := parser.Parse(job.Expression)
-		if err == nil {
-			description = humanizer.Humanize(schedule)
-		} else {
-			description = "(invalid)"
-		}
+// listColumnOrder is the order listTableColumns are listed in errors
+var listColumnOrder = []string{
+	listColumnSource, listColumnLine, listColumnSchedule, listColumnDescription, listColumnNext, listColumnUser,
+	listColumnCommand, listColumnName, listColumnOwner, listColumnTags, listColumnTimezone, listColumnTrigger,
+}
 
-		// Truncate long descriptions
-		if len(description) > maxDescriptionLength {
-			description = description[:maxDescriptionDisplay] + "..."
+// parseListColumns returns the column names of --columns, with "expression"
+// as an alias of schedule, or nil for the default columns
+func parseListColumns(names []string) ([]string, error) {
+	var columns []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "expression" {
+			name = listColumnSchedule
+		}
+		if _, ok := listTableColumns[name]; !ok {
+			return nil, fmt.Errorf("invalid --columns value %q (supported: %s)", name, strings.Join(listColumnOrder, ", "))
 		}
+		columns = append(columns, name)
+	}
+	return columns, nil
+}
+
+// defaultListColumns returns the columns of the text table without
+// --columns: source for host listings and user for system crontabs, when
+// some job has one, then line, schedule, description and command
+func defaultListColumns(jobs []*crontab.Job) []string {
+	var source, user bool
+	for _, job := range jobs {
+		source = source || job.Source != ""
+		user = user || job.User != ""
+	}
+	var columns []string
+	if source {
+		columns = append(columns, listColumnSource)
+	}
+	columns = append(columns, listColumnLine, listColumnSchedule, listColumnDescription)
+	if user {
+		columns = append(columns, listColumnUser)
+	}
+	return append(columns, listColumnCommand)
+}
 
-		// Truncate long commands
-		command := job.Command
-		if len(command) > maxCommandLength {
-			command = command[:maxCommandDisplay] + "..."
+// outputJobsTable writes the jobs as an aligned table of columns (the
+// defaults when columns is nil), with the trigger, resolved command and
+// directives of each job below it unless a column shows them
+func (lc *ListCommand) outputJobsTable(jobs []*crontab.Job, env map[string]string, columns []string) error {
+	parser := cronx.NewParserWithLocale(GetLocale())
+	humanizer := newHumanizer()
+	scheduler := cronx.NewScheduler()
+	now := time.Now()
+	if columns == nil {
+		columns = defaultListColumns(jobs)
+	}
+
+	table := &textTable{noteColumn: -1}
+	shown := make(map[string]bool)
+	for i, name := range columns {
+		table.columns = append(table.columns, listTableColumns[name])
+		shown[name] = true
+		if name == listColumnCommand {
+			table.noteColumn = i
 		}
+	}
 
-		lc.Printf("%-4d  %-16s  %-36s  %s\n", job.LineNumber, job.Expression, description, command)
+	for _, job := range jobs {
+		row := tableRow{cells: make([]tableCell, len(columns))}
+		for i, name := range columns {
+			row.cells[i] = listCell(name, job, parser, humanizer, scheduler, now)
+		}
+		if table.noteColumn >= 0 {
+			if job.Trigger != "" && !shown[listColumnTrigger] {
+				row.notes = append(row.notes, tableCell{text: "via " + job.Trigger, role: color.Muted, style: true})
+			}
+			if resolved := resolvedCommand(job, env); resolved != "" {
+				row.notes = append(row.notes, tableCell{text: "→ " + resolved, role: color.Muted, style: true})
+			}
+			if meta := jobMetadata(job); meta != nil && !shown[listColumnName] && !shown[listColumnOwner] && !shown[listColumnTags] {
+				row.notes = append(row.notes, tableCell{text: "# " + meta.String(), role: color.Muted, style: true})
+			}
+		}
+		table.rows = append(table.rows, row)
 	}
 
+	overflow := overflowTruncate
+	switch {
+	case lc.wide:
+		overflow = overflowNone
+	case lc.wrap:
+		overflow = overflowWrap
+	}
+	table.write(lc.OutOrStderr(), painterFor(lc.OutOrStderr()), overflow)
 	return nil
 }
 
+// listCell returns the cell of a job in a column of the text table
+func listCell(column string, job *crontab.Job, parser cronx.Parser, humanizer human.Humanizer, scheduler cronx.Scheduler, now time.Time) tableCell {
+	switch column {
+	case listColumnSource:
+		return tableCell{text: job.Source}
+	case listColumnLine:
+		return tableCell{text: strconv.Itoa(job.LineNumber)}
+	case listColumnSchedule:
+		return tableCell{text: job.Expression}
+	case listColumnDescription:
+		schedule, err := parser.Parse(job.Expression)
+		if err != nil {
+			return tableCell{text: "(invalid)", role: color.Error, style: true}
+		}
+		return tableCell{text: humanizer.Humanize(schedule)}
+	case listColumnNext:
+		return tableCell{text: nextRunText(job, scheduler, now)}
+	case listColumnUser:
+		return tableCell{text: job.User}
+	case listColumnCommand:
+		return tableCell{text: job.Command}
+	case listColumnName:
+		return tableCell{text: job.Metadata.Name}
+	case listColumnOwner:
+		return tableCell{text: job.Metadata.Owner}
+	case listColumnTags:
+		return tableCell{text: strings.Join(job.Metadata.Tags, ",")}
+	case listColumnTimezone:
+		return tableCell{text: job.Timezone}
+	case listColumnTrigger:
+		return tableCell{text: job.Trigger}
+	}
+	return tableCell{}
+}
+
+// nextRunText formats the next run of a job after now in its time zone, or
+// the local one: "never" when it does not run again, "" when it is invalid
+func nextRunText(job *crontab.Job, scheduler cronx.Scheduler, now time.Time) string {
+	if !job.Valid {
+		return ""
+	}
+	loc, err := job.Location(time.Local)
+	if err != nil {
+		return ""
+	}
+	times, err := scheduler.Next(job.Expression, now.In(loc), 1)
+	if err != nil {
+		return ""
+	}
+	if len(times) == 0 || times[0].IsZero() {
+		return "never"
+	}
+	return getTimeFormat().stamp(times[0])
+}
+
 func entryTypeString(t crontab.EntryType) string {
 	switch t {
 	case crontab.EntryTypeJob:
@@ -273,6 +781,21 @@ func (lc *ListCommand) outputJSON(data interface{}) error {
 	return encoder.Encode(data)
 }
 
+// readErrors prefixes the read errors of an entry iterator with what was
+// being read, e.g. "failed to read crontab file"
+func readErrors(entries iter.Seq2[*crontab.Entry, error], what string) iter.Seq2[*crontab.Entry, error] {
+	return func(yield func(*crontab.Entry, error) bool) {
+		for entry, err := range entries {
+			if err != nil {
+				err = fmt.Errorf("%s: %w", what, err)
+			}
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
+
 // isStdinAvailable checks if stdin is available (not a terminal)
 func isStdinAvailable() bool {
 	return !term.IsTerminal(int(os.Stdin.Fd()))
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
@@ -805,3 +806,359 @@ func TestListCommand_StdinPaths(t *testing.T) {
 		_ = buf.String()
 	})
 }
+
+func TestListCommand_Expand(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	testFile := createTempFile(t, "LOGS=/var/log/jobs\n0 2 * * * ~/bin/backup.sh > $LOGS/backup.log\n0 3 * * * /usr/bin/true\n")
+
+	t.Run("text shows resolved commands", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--expand"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "→ "+home+"/bin/backup.sh > /var/log/jobs/backup.log")
+	})
+
+	t.Run("json includes resolvedCommand when it differs", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--expand", "--json"})
+
+		require.NoError(t, cmd.Execute())
+		var result struct {
+			Jobs []map[string]interface{} `json:"jobs"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Jobs, 2)
+		assert.Equal(t, home+"/bin/backup.sh > /var/log/jobs/backup.log", result.Jobs[0]["resolvedCommand"])
+		assert.NotContains(t, result.Jobs[1], "resolvedCommand")
+	})
+
+	t.Run("commands are shown as written by default", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile})
+
+		require.NoError(t, cmd.Execute())
+		assert.NotContains(t, buf.String(), "→")
+	})
+}
+
+func TestListCommand_Directives(t *testing.T) {
+	testFile := createTempFile(t, "# cronkit:name=backup owner=infra tags=db,critical\n0 2 * * * /usr/bin/backup.sh\n0 3 * * * /usr/bin/true\n")
+
+	t.Run("text shows directives", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), "# name=backup owner=infra tags=db,critical")
+		assert.Equal(t, 1, strings.Count(buf.String(), "# name="))
+	})
+
+	t.Run("json includes metadata", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--json"})
+
+		require.NoError(t, cmd.Execute())
+		var result struct {
+			Jobs []map[string]interface{} `json:"jobs"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Jobs, 2)
+		assert.Equal(t, map[string]interface{}{
+			"name":  "backup",
+			"owner": "infra",
+			"tags":  []interface{}{"db", "critical"},
+		}, result.Jobs[0]["metadata"])
+		assert.NotContains(t, result.Jobs[1], "metadata")
+	})
+
+	t.Run("all entries json includes metadata", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--all", "--json"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), `"owner": "infra"`)
+	})
+}
+
+func TestListCommand_System(t *testing.T) {
+	testFile := createTempFile(t, "SHELL=/bin/sh\n17 * * * * root run-parts /etc/cron.hourly\n@daily www-data /usr/bin/rotate\n")
+
+	t.Run("text shows user column", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--system"})
+
+		require.NoError(t, cmd.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "USER")
+		assert.Contains(t, output, "www-data  /usr/bin/rotate")
+		assert.Contains(t, output, "root      run-parts /etc/cron.hourly")
+	})
+
+	t.Run("json includes user", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--system", "--json"})
+
+		require.NoError(t, cmd.Execute())
+		var result struct {
+			Jobs []map[string]interface{} `json:"jobs"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Jobs, 2)
+		assert.Equal(t, "root", result.Jobs[0]["user"])
+		assert.Equal(t, "run-parts /etc/cron.hourly", result.Jobs[0]["command"])
+	})
+
+	t.Run("user crontabs have no user column", func(t *testing.T) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", testFile, "--json"})
+
+		require.NoError(t, cmd.Execute())
+		assert.NotContains(t, buf.String(), `"user"`)
+		assert.Contains(t, buf.String(), `"command": "root run-parts /etc/cron.hourly"`)
+	})
+
+	t.Run("cron.d files are detected", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "cron.d")
+		require.NoError(t, os.Mkdir(dir, 0o755))
+		path := filepath.Join(dir, "backup")
+		require.NoError(t, os.WriteFile(path, []byte("0 2 * * * backup /usr/bin/backup\n"), 0o644))
+
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs([]string{"--file", path, "--json"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, buf.String(), `"user": "backup"`)
+	})
+}
+
+func TestListCommand_FilterAndSort(t *testing.T) {
+	testFile := createTempFile(t, `# cronkit:owner=infra tags=db
+0 2 * * * /usr/bin/backup.sh
+*/5 * * * * /usr/bin/poll # cronkit:owner=web
+0 * * * * /usr/bin/db-sync # cronkit:tags=db
+`)
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(append([]string{"--file", testFile}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("filters combine", func(t *testing.T) {
+		out, err := run(t, "--filter", "tag=db", "--filter", "runs>=24", "--json")
+		require.NoError(t, err)
+		var result struct {
+			Jobs []map[string]interface{} `json:"jobs"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		require.Len(t, result.Jobs, 1)
+		assert.Equal(t, "/usr/bin/db-sync", result.Jobs[0]["command"])
+	})
+
+	t.Run("sort by frequency", func(t *testing.T) {
+		out, err := run(t, "--sort", "frequency")
+		require.NoError(t, err)
+		poll, sync, backup := strings.Index(out, "poll"), strings.Index(out, "db-sync"), strings.Index(out, "backup")
+		assert.True(t, poll < sync && sync < backup, out)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		out, err := run(t, "--filter", "owner=nobody")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No cron jobs match the filters")
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := run(t, "--filter", "color=red")
+		assert.ErrorContains(t, err, `unknown field "color"`)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		_, err := run(t, "--sort", "owner")
+		assert.ErrorContains(t, err, "invalid sort key")
+	})
+
+	t.Run("not with --all", func(t *testing.T) {
+		_, err := run(t, "--all", "--filter", "owner=infra")
+		assert.ErrorContains(t, err, "cannot be used with --all")
+	})
+}
+
+func TestListCommand_Format(t *testing.T) {
+	testFile := createTempFile(t, "# cronkit:owner=infra tags=db,nightly\n0 2 * * * /usr/bin/backup.sh\n")
+
+	t.Run("csv", func(t *testing.T) {
+		lc := newListCommand()
+		buf := new(bytes.Buffer)
+		lc.SetOut(buf)
+		lc.SetArgs([]string{"--file", testFile, "--format", "csv"})
+
+		require.NoError(t, lc.Execute())
+		assert.Equal(t, "source,line,expression,description,user,command,resolved_command,comment,name,owner,tags,trigger\n"+
+			",2,0 2 * * *,At 02:00 every day,,/usr/bin/backup.sh,,,,infra,\"db,nightly\",\n", buf.String())
+	})
+
+	t.Run("tsv with all entries", func(t *testing.T) {
+		lc := newListCommand()
+		buf := new(bytes.Buffer)
+		lc.SetOut(buf)
+		lc.SetArgs([]string{"--file", testFile, "--all", "--format", "tsv"})
+
+		require.NoError(t, lc.Execute())
+		assert.Equal(t, "line\ttype\traw\n1\tCOMMENT\t# cronkit:owner=infra tags=db,nightly\n2\tJOB\t0 2 * * * /usr/bin/backup.sh\n", buf.String())
+	})
+
+	t.Run("csv header without jobs", func(t *testing.T) {
+		lc := newListCommand()
+		buf := new(bytes.Buffer)
+		lc.SetOut(buf)
+		lc.SetArgs([]string{"--file", testFile, "--filter", "owner=nobody", "--format", "csv"})
+
+		require.NoError(t, lc.Execute())
+		assert.True(t, strings.HasPrefix(buf.String(), "source,line,"))
+		assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	})
+}
+
+func TestListCommand_NDJSON(t *testing.T) {
+	testFile := createTempFile(t, "# cronkit:owner=infra\n0 2 * * * /usr/bin/backup.sh\n*/5 * * * * /usr/bin/poll\n")
+
+	run := func(t *testing.T, args ...string) []string {
+		lc := newListCommand()
+		buf := new(bytes.Buffer)
+		lc.SetOut(buf)
+		lc.SetArgs(args)
+		require.NoError(t, lc.Execute())
+		return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	}
+
+	t.Run("a job per line", func(t *testing.T) {
+		lines := run(t, "--file", testFile, "--ndjson")
+		require.Len(t, lines, 2)
+		var job map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &job))
+		assert.Equal(t, float64(2), job["lineNumber"])
+		assert.Equal(t, "/usr/bin/backup.sh", job["command"])
+		assert.Equal(t, "At 02:00 every day", job["description"])
+		assert.Equal(t, "infra", job["metadata"].(map[string]interface{})["owner"])
+	})
+
+	t.Run("an entry per line with --all", func(t *testing.T) {
+		lines := run(t, "--file", testFile, "--all", "--ndjson")
+		require.Len(t, lines, 3)
+		assert.JSONEq(t, `{"lineNumber":1,"type":"COMMENT","raw":"# cronkit:owner=infra"}`, lines[0])
+	})
+
+	t.Run("filtered as read", func(t *testing.T) {
+		lines := run(t, "--file", testFile, "--filter", "runs>24", "--ndjson")
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], `"command":"/usr/bin/poll"`)
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+		lines := run(t, "--file", testFile, "--sort", "frequency", "--ndjson")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"command":"/usr/bin/poll"`)
+	})
+
+	t.Run("nothing without jobs", func(t *testing.T) {
+		lc := newListCommand()
+		buf := new(bytes.Buffer)
+		lc.SetOut(buf)
+		lc.SetArgs([]string{"--file", testFile, "--filter", "owner=nobody", "--ndjson"})
+		require.NoError(t, lc.Execute())
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("cannot be combined with --json", func(t *testing.T) {
+		lc := newListCommand()
+		lc.SetOut(new(bytes.Buffer))
+		lc.SetErr(new(bytes.Buffer))
+		lc.SetArgs([]string{"--file", testFile, "--ndjson", "--json"})
+		err := lc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--ndjson cannot be used with --json or --format")
+	})
+}
+
+func TestListCommand_Columns(t *testing.T) {
+	testFile := createTempFile(t, `# cronkit:name=backup owner=infra
+0 2 * * * /usr/bin/backup.sh --full --destination /mnt/backups/nightly > /var/log/backup.log
+0 0 1 1 * /usr/bin/new-year
+`)
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		cmd := newListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(append([]string{"--file", testFile}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("default columns", func(t *testing.T) {
+		out, err := run(t)
+		require.NoError(t, err)
+		lines := strings.Split(out, "\n")
+		assert.Equal(t, "LINE  EXPRESSION  DESCRIPTION                 COMMAND", lines[0])
+		assert.Contains(t, out, "/usr/bin/backup.sh --full --destinati...")
+		assert.Contains(t, out, "# name=backup owner=infra")
+	})
+
+	t.Run("selected columns", func(t *testing.T) {
+		out, err := run(t, "--columns", "owner,schedule,next,command")
+		require.NoError(t, err)
+		lines := strings.Split(out, "\n")
+		assert.Equal(t, "OWNER  EXPRESSION  NEXT                     COMMAND", lines[0])
+		assert.True(t, strings.HasPrefix(lines[2], "infra  0 2 * * *   "), lines[2])
+		assert.NotContains(t, out, "# name=backup", "directives shown in columns are not repeated")
+		assert.NotContains(t, out, "DESCRIPTION")
+	})
+
+	t.Run("wide and wrap", func(t *testing.T) {
+		out, err := run(t, "--wide", "--columns", "line,command")
+		require.NoError(t, err)
+		assert.Contains(t, out, "/usr/bin/backup.sh --full --destination /mnt/backups/nightly > /var/log/backup.log\n")
+
+		out, err = run(t, "--wrap", "--columns", "line,command")
+		require.NoError(t, err)
+		assert.Contains(t, out, "2     /usr/bin/backup.sh --full --destination\n      /mnt/backups/nightly >\n      /var/log/backup.log\n")
+	})
+
+	t.Run("invalid columns and combinations", func(t *testing.T) {
+		_, err := run(t, "--columns", "schedule,color")
+		assert.ErrorContains(t, err, `invalid --columns value "color"`)
+		_, err = run(t, "--wide", "--wrap")
+		assert.ErrorContains(t, err, "--wide and --wrap cannot be used together")
+		_, err = run(t, "--columns", "line", "--json")
+		assert.ErrorContains(t, err, "only apply to the text table")
+	})
+}
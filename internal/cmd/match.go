@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/spf13/cobra"
+)
+
+// MatchCommand wraps cobra.Command with match-specific functionality
+type MatchCommand struct {
+	*cobra.Command
+	at         string
+	window     time.Duration
+	json       bool
+	timezone   string
+	seconds    bool
+	dialect    string
+	jenkinsJob string
+}
+
+// MatchField explains whether one field of an expression matches the instant
+type MatchField struct {
+	Field   string `json:"field"`
+	Value   int    `json:"value"`
+	Pattern string `json:"pattern"`
+	Matches bool   `json:"matches"`
+}
+
+// MatchResult represents the complete output for the match command
+type MatchResult struct {
+	Expression  string       `json:"expression"`
+	Description string       `json:"description"`
+	At          string       `json:"at"`
+	Timezone    string       `json:"timezone"`
+	Matches     bool         `json:"matches"`
+	Fields      []MatchField `json:"fields"`
+	EitherDay   bool         `json:"eitherDay"`
+	PreviousRun string       `json:"previousRun,omitempty"`
+	NextRun     string       `json:"nextRun,omitempty"`
+	Window      string       `json:"window,omitempty"`
+	WindowRuns  []string     `json:"windowRuns,omitempty"`
+	Truncated   bool         `json:"truncated,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(newMatchCommand().Command)
+}
+
+// newMatchCommand creates a fresh match command instance
+func newMatchCommand() *MatchCommand {
+	mc := &MatchCommand{}
+	mc.Command = &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		RunE:  mc.runMatch,
+		Use:   "match <cron-expression>",
+		Short: "Check whether a cron expression fires at a given time",
+		Long: `Check whether a cron expression fires at a specific instant, for questions
+like "why did my job run at 3:17?".
+
+The instant is compared to the minute (to the second for expressions with a
+seconds field), so 03:17:42 matches "17 3 * * *". Each field is checked
+against the instant, and when the expression does not fire the previous and
+next runs around the instant are shown.
+
+With --window, the runs within that long before or after the instant are
+listed too (at most 100).
+
+Exits with status 1 when the expression does not fire at the instant, so it
+can be used in scripts.
+
+Examples:
+  cronkit match "*/17 * * * *" --at 2025-03-01T03:17:00Z
+  cronkit match "0 3 * * *" --at 2025-03-01T03:17:00Z --window 30m
+  cronkit match "0 9 * * 1-5" --at 2025-03-01T09:00:00-05:00 --timezone America/New_York --json`,
+	}
+
+	mc.Command.Flags().StringVar(&mc.at, "at", "", "Instant to check (RFC3339 format, defaults to current time)")
+	mc.Command.Flags().DurationVar(&mc.window, "window", 0, "Also list the runs within this long of the instant (e.g., 10m, 2h)")
+	mc.Command.Flags().BoolVarP(&mc.json, "json", "j", false, "Output in JSON format")
+	mc.Command.Flags().StringVar(&mc.timezone, "timezone", "", "Timezone for calculations (e.g., 'America/New_York', 'UTC', defaults to local timezone)")
+	mc.Command.Flags().BoolVar(&mc.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	mc.Command.Flags().StringVar(&mc.dialect, "dialect", "standard", dialectUsage)
+	mc.Command.Flags().StringVar(&mc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+
+	return mc
+}
+
+func (mc *MatchCommand) runMatch(_ *cobra.Command, args []string) error {
+	expression := args[0]
+
+	if mc.window < 0 {
+		return fmt.Errorf("invalid --window: must not be negative")
+	}
+
+	loc := time.Local
+	if mc.timezone != "" {
+		parsedLoc, err := time.LoadLocation(mc.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		loc = parsedLoc
+	}
+
+	at := time.Now().In(loc)
+	if mc.at != "" {
+		parsed, err := time.Parse(time.RFC3339, mc.at)
+		if err != nil {
+			return fmt.Errorf("invalid --at time (expected RFC3339): %w", err)
+		}
+		at = parsed.In(loc)
+	}
+
+	opts, err := parserOptions(mc.seconds, mc.dialect, mc.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	schedule, err := cronx.NewParserWithOptions(GetLocale(), opts).Parse(expression)
+	if err != nil {
+		return fmt.Errorf("failed to parse expression: %w", err)
+	}
+	scheduler := cronx.NewSchedulerWithOptions(opts)
+
+	matches, err := scheduler.Matches(expression, at)
+	if err != nil {
+		return fmt.Errorf("failed to match expression: %w", err)
+	}
+
+	result := MatchResult{
+		Expression:  expression,
+		Description: newHumanizer().Humanize(schedule),
+		At:          at.Format(time.RFC3339),
+		Timezone:    loc.String(),
+		Matches:     matches,
+		Fields:      matchFields(schedule, at),
+		EitherDay:   eitherDay(schedule),
+	}
+
+	var prev, next time.Time
+	if !matches {
+		prev, next, err = surroundingRuns(scheduler, expression, at)
+		if err != nil {
+			return err
+		}
+		result.PreviousRun, result.NextRun = formatRun(prev, loc), formatRun(next, loc)
+	}
+
+	var runs []time.Time
+	if mc.window > 0 {
+		runs, result.Truncated, err = windowRuns(scheduler, expression, at.Add(-mc.window), at.Add(mc.window))
+		if err != nil {
+			return err
+		}
+		result.Window = mc.window.String()
+		result.WindowRuns = make([]string, len(runs))
+		for i, run := range runs {
+			result.WindowRuns[i] = run.In(loc).Format(time.RFC3339)
+		}
+	}
+
+	if mc.json {
+		if err := mc.outputJSON(result); err != nil {
+			return err
+		}
+	} else {
+		mc.outputText(result, at, prev, next, runs, loc)
+	}
+
+	// Like check, report a mismatch with the exit code so scripts can test it
+	if !matches {
+		osExit(1)
+	}
+	return nil
+}
+
+// matchFields checks each field of a schedule against the instant. Rates have
+// no fields, and Quartz day modifiers (L, W, #) are not broken down.
+func matchFields(schedule *cronx.Schedule, at time.Time) []MatchField {
+	if schedule.Rate > 0 {
+		return []MatchField{}
+	}
+
+	check := func(name string, f cronx.Field, value int) MatchField {
+		return MatchField{Field: name, Value: value, Pattern: f.Raw(), Matches: containsValue(f.Values(), value)}
+	}
+
+	var fields []MatchField
+	if schedule.HasSeconds() {
+		fields = append(fields, check("second", schedule.Second, at.Second()))
+	}
+	fields = append(fields,
+		check("minute", schedule.Minute, at.Minute()),
+		check("hour", schedule.Hour, at.Hour()),
+	)
+	if schedule.Quartz == nil {
+		fields = append(fields, check("day-of-month", schedule.DayOfMonth, at.Day()))
+	}
+	fields = append(fields, check("month", schedule.Month, int(at.Month())))
+	if schedule.Quartz == nil {
+		fields = append(fields, check("day-of-week", schedule.DayOfWeek, int(at.Weekday())))
+	}
+	if schedule.Year != nil {
+		fields = append(fields, check("year", schedule.Year, at.Year()))
+	}
+	return fields
+}
+
+// eitherDay reports whether a schedule runs on days matching either day field,
+// which cron does when neither the day of month nor the day of week is '*'
+func eitherDay(schedule *cronx.Schedule) bool {
+	if schedule.Rate > 0 || schedule.Quartz != nil || schedule.MatchesBothDays() {
+		return false
+	}
+	star := func(f cronx.Field) bool {
+		return strings.HasPrefix(f.Raw(), "*") || f.Raw() == "?"
+	}
+	return !star(schedule.DayOfMonth) && !star(schedule.DayOfWeek)
+}
+
+// containsValue reports whether a sorted list of field values contains v
+func containsValue(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// surroundingRuns returns the last run before and the first run after an
+// instant; either is the zero time when there is none
+func surroundingRuns(scheduler cronx.Scheduler, expression string, at time.Time) (time.Time, time.Time, error) {
+	prev, err := scheduler.Prev(expression, at, 1)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to calculate previous run: %w", err)
+	}
+	next, err := scheduler.Next(expression, at, 1)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to calculate next run: %w", err)
+	}
+	return prev[0], next[0], nil
+}
+
+// windowRuns returns the runs from start to end inclusive, at most
+// MaxNextCount, and whether more were left out
+func windowRuns(scheduler cronx.Scheduler, expression string, start, end time.Time) ([]time.Time, bool, error) {
+	times, err := scheduler.Next(expression, start.Add(-time.Second), MaxNextCount+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to calculate runs: %w", err)
+	}
+	runs := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		if t.IsZero() || t.After(end) {
+			return runs, false, nil
+		}
+		if len(runs) == MaxNextCount {
+			return runs, true, nil
+		}
+		runs = append(runs, t)
+	}
+	return runs, false, nil
+}
+
+// formatRun formats a run in RFC3339, or "" for the zero time
+func formatRun(t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+func (mc *MatchCommand) outputText(result MatchResult, at, prev, next time.Time, runs []time.Time, loc *time.Location) {
+	format := getTimeFormat()
+	stamp := func(t time.Time) string {
+		if t.IsZero() {
+			return "none"
+		}
+		return format.stamp(t.In(loc))
+	}
+
+	verdict := "fires"
+	if !result.Matches {
+		verdict = "does not fire"
+	}
+	mc.Printf("\"%s\" %s at %s\n", result.Expression, verdict, stamp(at))
+	mc.Printf("(%s)\n", result.Description)
+
+	if len(result.Fields) > 0 {
+		mc.Println()
+		for _, f := range result.Fields {
+			check := "matches"
+			if !f.Matches {
+				check = "does not match"
+			}
+			mc.Printf("  %-13s %-5d %s %s\n", f.Field, f.Value, check, f.Pattern)
+		}
+		if result.EitherDay {
+			mc.Println("  Both day fields are restricted, so cron runs when either matches.")
+		}
+	}
+
+	if !result.Matches {
+		mc.Println()
+		mc.Printf("Previous run: %s\n", stamp(prev))
+		mc.Printf("Next run:     %s\n", stamp(next))
+	}
+
+	if result.Window != "" {
+		mc.Println()
+		if len(runs) == 0 {
+			mc.Printf("No runs within %s of %s\n", result.Window, stamp(at))
+			return
+		}
+		mc.Printf("Runs within %s of %s:\n", result.Window, stamp(at))
+		for _, run := range runs {
+			mc.Printf("  %s\n", stamp(run))
+		}
+		if result.Truncated {
+			mc.Printf("  (first %d shown)\n", MaxNextCount)
+		}
+	}
+}
+
+func (mc *MatchCommand) outputJSON(result MatchResult) error {
+	encoder := json.NewEncoder(mc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
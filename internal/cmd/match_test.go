@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCommand(t *testing.T) {
+	t.Run("match command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"match"})
+		assert.NoError(t, err)
+		assert.Equal(t, "match", cmd.Name())
+	})
+
+	t.Run("match command should have metadata", func(t *testing.T) {
+		mc := newMatchCommand()
+		assert.NotEmpty(t, mc.Short)
+		assert.NotEmpty(t, mc.Long)
+		assert.Contains(t, mc.Use, "match")
+	})
+
+	t.Run("expression fires at the instant", func(t *testing.T) {
+		exitCode := 0
+		oldExit := osExit
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		mc := newMatchCommand()
+		buf := new(bytes.Buffer)
+		mc.SetOut(buf)
+		mc.SetArgs([]string{"*/17 * * * *", "--at", "2025-03-01T03:17:42Z", "--timezone", "UTC"})
+
+		require.NoError(t, mc.Execute())
+		assert.Equal(t, 0, exitCode)
+
+		output := buf.String()
+		assert.Contains(t, output, `"*/17 * * * *" fires at 2025-03-01 03:17:42 UTC`)
+		assert.Contains(t, output, "minute        17    matches */17")
+		assert.NotContains(t, output, "Next run")
+	})
+
+	t.Run("expression does not fire at the instant", func(t *testing.T) {
+		exitCode := 0
+		oldExit := osExit
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		mc := newMatchCommand()
+		buf := new(bytes.Buffer)
+		mc.SetOut(buf)
+		mc.SetArgs([]string{"0 3 * * *", "--at", "2025-03-01T03:17:00Z", "--timezone", "UTC", "--window", "30m"})
+
+		require.NoError(t, mc.Execute())
+		assert.Equal(t, 1, exitCode)
+
+		output := buf.String()
+		assert.Contains(t, output, `"0 3 * * *" does not fire at 2025-03-01 03:17:00 UTC`)
+		assert.Contains(t, output, "minute        17    does not match 0")
+		assert.Contains(t, output, "Previous run: 2025-03-01 03:00:00 UTC")
+		assert.Contains(t, output, "Next run:     2025-03-02 03:00:00 UTC")
+		assert.Contains(t, output, "Runs within 30m0s of 2025-03-01 03:17:00 UTC:\n  2025-03-01 03:00:00 UTC\n")
+	})
+
+	t.Run("JSON output explains either day field", func(t *testing.T) {
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		mc := newMatchCommand()
+		buf := new(bytes.Buffer)
+		mc.SetOut(buf)
+		mc.SetArgs([]string{"0 0 1 * 1", "--at", "2025-03-01T00:00:00Z", "--timezone", "UTC", "--json"})
+
+		require.NoError(t, mc.Execute())
+
+		var result MatchResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.True(t, result.Matches)
+		assert.True(t, result.EitherDay)
+		require.Len(t, result.Fields, 5)
+		assert.Equal(t, MatchField{Field: "day-of-month", Value: 1, Pattern: "1", Matches: true}, result.Fields[2])
+		assert.Equal(t, MatchField{Field: "day-of-week", Value: 6, Pattern: "1", Matches: false}, result.Fields[4])
+		assert.Empty(t, result.NextRun)
+	})
+
+	t.Run("window runs are capped", func(t *testing.T) {
+		oldExit := osExit
+		osExit = func(code int) {}
+		defer func() { osExit = oldExit }()
+
+		mc := newMatchCommand()
+		buf := new(bytes.Buffer)
+		mc.SetOut(buf)
+		mc.SetArgs([]string{"* * * * *", "--at", "2025-03-01T00:00:00Z", "--timezone", "UTC", "--window", "2h", "--json"})
+
+		require.NoError(t, mc.Execute())
+
+		var result MatchResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Len(t, result.WindowRuns, MaxNextCount)
+		assert.True(t, result.Truncated)
+		assert.Equal(t, "2025-02-28T22:00:00Z", result.WindowRuns[0])
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"invalid"},
+			{"* * * * *", "--at", "yesterday"},
+			{"* * * * *", "--window", "-1m"},
+			{"* * * * *", "--timezone", "Nowhere/Special"},
+		} {
+			mc := newMatchCommand()
+			mc.SetOut(new(bytes.Buffer))
+			mc.SetErr(new(bytes.Buffer))
+			mc.SetArgs(args)
+			assert.Error(t, mc.Execute(), args)
+		}
+	})
+}
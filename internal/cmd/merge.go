@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+type MergeCommand struct {
+	*cobra.Command
+	output string
+	system bool
+	json   bool
+}
+
+func newMergeCommand() *MergeCommand {
+	mc := &MergeCommand{}
+	mc.Command = &cobra.Command{
+		Use:   "merge <base> <ours> <theirs>",
+		Short: "Three-way merge crontabs job by job",
+		Long: `Merge the changes made to a crontab on two sides, ours and theirs, since their
+common ancestor, base.
+
+Jobs are matched across the three versions by their user and command, and
+variables by name, not by line: a job that moved on one side and changed on
+the other merges cleanly. The comment lines directly above a job, such as
+"# cronkit:" directives, belong to it. A job or variable changed on one side
+only takes that side's version, including deletions. One changed on both
+sides in different ways is a conflict, written between git-style conflict
+markers.
+
+The merged crontab follows the layout of ours, with the jobs only theirs
+added placed after the job that precedes them in theirs. Comments attached
+to no job are taken from ours.
+
+The merged crontab is written to standard output, or to --output. Exit
+codes: 0 when the merge is clean, 2 when there are conflicts, 1 on errors.
+
+To use cronkit as the git merge driver of crontab files:
+  git config merge.cronkit.name "cronkit crontab merge"
+  git config merge.cronkit.driver "cronkit merge %O %A %B --output %A"
+  echo "*.cron merge=cronkit" >> .gitattributes
+
+Examples:
+  cronkit merge base.cron ours.cron theirs.cron
+  cronkit merge base.cron ours.cron theirs.cron --output merged.cron
+  cronkit merge base.cron ours.cron theirs.cron --json`,
+		Args: cobra.ExactArgs(3),
+		RunE: mc.runMerge,
+	}
+
+	mc.Flags().StringVarP(&mc.output, "output", "o", "", "Write the merged crontab to this file instead of standard output")
+	mc.Flags().BoolVar(&mc.system, "system", false, systemUsage)
+	mc.Flags().BoolVarP(&mc.json, "json", "j", false, "Output the merge result in JSON format")
+	return mc
+}
+
+func init() {
+	rootCmd.AddCommand(newMergeCommand().Command)
+}
+
+func (mc *MergeCommand) runMerge(_ *cobra.Command, args []string) error {
+	reader := newCrontabReader(mc.system)
+	versions := make([][]*crontab.Entry, len(args))
+	for i, path := range args {
+		entries, err := reader.ParseFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read crontab file %s: %w", path, err)
+		}
+		versions[i] = entries
+	}
+
+	result := diff.Merge(versions[0], versions[1], versions[2])
+	if mc.output != "" {
+		if err := os.WriteFile(mc.output, []byte(result.Text), 0o644); err != nil {
+			return fmt.Errorf("failed to write merged crontab: %w", err)
+		}
+	}
+
+	switch {
+	case mc.json:
+		if err := mc.outputJSON(result); err != nil {
+			return err
+		}
+	case mc.output == "":
+		mc.Print(result.Text)
+	}
+	if len(result.Conflicts) > 0 {
+		if !mc.json {
+			lines := make([]string, 0, len(result.Conflicts))
+			for _, c := range result.Conflicts {
+				lines = append(lines, strconv.Itoa(c.Line))
+			}
+			mc.PrintErrf("%d merge conflict(s), marked at line(s) %s\n", len(result.Conflicts), strings.Join(lines, ", "))
+		}
+		osExit(2)
+	}
+	return nil
+}
+
+// MergeConflictJSON is a conflict in the JSON output of the merge command
+type MergeConflictJSON struct {
+	Line   int      `json:"line"`
+	Base   []string `json:"base"`
+	Ours   []string `json:"ours"`
+	Theirs []string `json:"theirs"`
+}
+
+func (mc *MergeCommand) outputJSON(result *diff.MergeResult) error {
+	conflicts := make([]MergeConflictJSON, 0, len(result.Conflicts))
+	for _, c := range result.Conflicts {
+		conflicts = append(conflicts, MergeConflictJSON{
+			Line:   c.Line,
+			Base:   nonNil(c.Base),
+			Ours:   nonNil(c.Ours),
+			Theirs: nonNil(c.Theirs),
+		})
+	}
+
+	encoder := json.NewEncoder(mc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]interface{}{
+		"clean":     len(conflicts) == 0,
+		"merged":    result.Text,
+		"conflicts": conflicts,
+	}); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// nonNil returns lines, or an empty slice for nil so that JSON shows []
+func nonNil(lines []string) []string {
+	if lines == nil {
+		return []string{}
+	}
+	return lines
+}
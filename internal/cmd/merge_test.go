@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeCommand(t *testing.T) {
+	base := "MAILTO=ops@example.com\n0 2 * * * /usr/bin/backup.sh\n*/5 * * * * /usr/bin/poll.sh\n"
+	basePath := createTempFile(t, base)
+
+	run := func(t *testing.T, args ...string) (string, string, int, error) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		mc := newMergeCommand()
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		mc.SetOut(stdout)
+		mc.SetErr(stderr)
+		mc.SetArgs(args)
+		err := mc.Execute()
+		return stdout.String(), stderr.String(), exitCode, err
+	}
+
+	t.Run("clean merge", func(t *testing.T) {
+		ours := createTempFile(t, strings.Replace(base, "0 2 * * *", "30 2 * * *", 1))
+		theirs := createTempFile(t, base+"0 6 * * * /usr/bin/report.sh\n")
+
+		output, _, exitCode, err := run(t, basePath, ours, theirs)
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Equal(t, "MAILTO=ops@example.com\n30 2 * * * /usr/bin/backup.sh\n*/5 * * * * /usr/bin/poll.sh\n0 6 * * * /usr/bin/report.sh\n", output)
+	})
+
+	t.Run("conflicts written to output file", func(t *testing.T) {
+		ours := createTempFile(t, strings.Replace(base, "0 2 * * *", "30 2 * * *", 1))
+		theirs := createTempFile(t, strings.Replace(base, "0 2 * * *", "0 3 * * *", 1))
+		merged := filepath.Join(t.TempDir(), "merged.cron")
+
+		output, stderr, exitCode, err := run(t, basePath, ours, theirs, "--output", merged)
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+		assert.Empty(t, output)
+		assert.Contains(t, stderr, "1 merge conflict(s), marked at line(s) 2")
+
+		content, err := os.ReadFile(merged)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "<<<<<<< ours\n30 2 * * * /usr/bin/backup.sh\n=======\n0 3 * * * /usr/bin/backup.sh\n>>>>>>> theirs\n")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		ours := createTempFile(t, strings.Replace(base, "0 2 * * * /usr/bin/backup.sh\n", "", 1))
+		theirs := createTempFile(t, strings.Replace(base, "0 2 * * *", "0 3 * * *", 1))
+
+		output, _, exitCode, err := run(t, basePath, ours, theirs, "--json")
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+
+		var result struct {
+			Clean     bool                `json:"clean"`
+			Merged    string              `json:"merged"`
+			Conflicts []MergeConflictJSON `json:"conflicts"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.False(t, result.Clean)
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, []string{}, result.Conflicts[0].Ours)
+		assert.Equal(t, []string{"0 3 * * * /usr/bin/backup.sh"}, result.Conflicts[0].Theirs)
+		assert.Contains(t, result.Merged, ">>>>>>> theirs")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, _, err := run(t, basePath, basePath, filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read crontab file")
+	})
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// ndjsonUsage is the help text of the --ndjson flag of check, list and next
+const ndjsonUsage = "Stream newline-delimited JSON, one %s object per line, written as results are computed"
+
+// ndjsonWriter writes values as newline-delimited JSON: one compact JSON
+// document per line, written as soon as it is given, for pipelines such as
+// "cronkit check --ndjson | jq ..."
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write writes a value on its own line
+func (w *ndjsonWriter) Write(v interface{}) error {
+	if err := w.encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write NDJSON: %w", err)
+	}
+	return nil
+}
+
+// validateNDJSON rejects --ndjson combined with the other output formats
+// given on the command line; it takes precedence over "output: json" from the
+// config file
+func validateNDJSON(cmd *cobra.Command, ndjson bool) error {
+	if ndjson && (cmd.Flags().Changed("json") || cmd.Flags().Changed("format")) {
+		return fmt.Errorf("--ndjson cannot be used with --json or --format")
+	}
+	return nil
+}
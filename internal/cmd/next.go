@@ -3,19 +3,43 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
-	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/hzerrad/cronkit/internal/holiday"
+	"github.com/hzerrad/cronkit/internal/schema"
 	"github.com/spf13/cobra"
 )
 
 // NextCommand wraps cobra.Command with next-specific functionality
 type NextCommand struct {
 	*cobra.Command
-	count    int
-	json     bool
-	timezone string
+	count        int
+	json         bool
+	ndjson       bool
+	outputSchema bool
+	format       string
+	timezone     string
+	seconds      bool
+	dialect      string
+	jenkinsJob   string
+	file         string
+	system       bool
+	stdin        bool
+	skipInvalid  bool
+	expressions  bool
+	holidays     string
+	skipHolidays bool
+	until        string
+	forSpan      string
+	from         string
+	calendar     *holiday.Calendar // Loaded from holidays
+	start        time.Time         // Time the runs are after (--from, or the current time)
+	end          time.Time         // End of the --until or --for window (zero: --count runs)
 }
 
 // NextRun represents a single scheduled run time
@@ -23,15 +47,57 @@ type NextRun struct {
 	Number    int    `json:"number"`
 	Timestamp string `json:"timestamp"`
 	Relative  string `json:"relative"`
+	Holiday   string `json:"holiday,omitempty"` // Holiday the run falls on (with --holidays)
+}
+
+// NextWindow represents the window of runs shown with --until or --for
+type NextWindow struct {
+	From  string `json:"from"`
+	Until string `json:"until"`
+	Total int    `json:"total"` // Runs in the window, without those skipped on holidays
 }
 
 // NextResult represents the complete output for the next command
 type NextResult struct {
-	Expression  string    `json:"expression"`
-	Description string    `json:"description"`
-	Timezone    string    `json:"timezone"`
-	Locale      string    `json:"locale"`
-	NextRuns    []NextRun `json:"nextRuns"`
+	SchemaVersion int         `json:"schemaVersion"`
+	Expression    string      `json:"expression"`
+	Description   string      `json:"description"`
+	Timezone      string      `json:"timezone"`
+	Locale        string      `json:"locale"`
+	Window        *NextWindow `json:"window,omitempty"` // With --until or --for
+	NextRuns      []NextRun   `json:"nextRuns"`
+	SkippedRuns   []NextRun   `json:"skippedRuns,omitempty"` // Runs on holidays passed over (with --skip-holidays)
+}
+
+// NextBatchResult represents the next runs of an expression read with
+// --stdin --expressions, streamed as NDJSON
+type NextBatchResult struct {
+	Line int `json:"line"`
+	NextResult
+}
+
+// NextJob represents the upcoming runs of one job of a crontab
+type NextJob struct {
+	LineNumber  int         `json:"lineNumber"`
+	Expression  string      `json:"expression"`
+	User        string      `json:"user,omitempty"`
+	Command     string      `json:"command"`
+	Description string      `json:"description"`
+	Timezone    string      `json:"timezone"`
+	Window      *NextWindow `json:"window,omitempty"` // With --until or --for
+	NextRuns    []NextRun   `json:"nextRuns"`
+	SkippedRuns []NextRun   `json:"skippedRuns,omitempty"` // Runs on holidays passed over (with --skip-holidays)
+}
+
+// NextCrontabResult represents the output for the next command in crontab mode
+type NextCrontabResult struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	Source        string                `json:"source"`
+	Timezone      string                `json:"timezone"`
+	Locale        string                `json:"locale"`
+	Window        *NextWindow           `json:"window,omitempty"` // With --until or --for, totaling the runs of all jobs
+	Jobs          []NextJob             `json:"jobs"`
+	Skipped       []crontab.SkippedLine `json:"skipped"`
 }
 
 func init() {
@@ -43,10 +109,11 @@ func init() {
 func newNextCommand() *NextCommand {
 	nc := &NextCommand{}
 	nc.Command = &cobra.Command{
-		Args:  cobra.ExactArgs(1),
-		RunE:  nc.runNext,
-		Use:   "next <cron-expression>",
-		Short: "Show next scheduled run times for a cron expression",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeExpression,
+		RunE:              nc.runNext,
+		Use:               "next <cron-expression> | --file <crontab>",
+		Short:             "Show next scheduled run times for a cron expression",
 		Long: `Calculate and display the next scheduled run times for a cron expression.
 
 This command helps you understand when a cron job will actually run in the future.
@@ -54,27 +121,96 @@ It shows both the exact timestamps and relative times (e.g., "in 2 hours").
 
 Supports:
   - Standard 5-field cron expressions (minute, hour, day-of-month, month, day-of-week)
+  - 6-field expressions with a leading seconds field (Quartz-style)
   - Cron aliases (@daily, @hourly, @weekly, @monthly, @yearly)
+  - Crontab files (--file or --stdin), showing the next runs of every job;
+    CRON_TZ= and TZ= lines set the time zone of the jobs that follow them;
+    invalid lines are skipped and listed as warnings (--skip-invalid=false
+    aborts on the first one instead)
   - Custom count with --count flag (1-100 runs, default: 10)
+  - Every run in a window with --until (a date, meaning midnight in
+    --timezone, or an RFC3339 time) or --for (a span such as 72h, 2w or
+    1mo from now) instead of --count, followed by the total number of runs
+  - Runs after another time than now with --from, an RFC3339 time or a
+    relative one such as now+2h, "tomorrow 09:00" or monday
   - JSON output with --json flag for programmatic use
+  - CSV or TSV output with --format csv|tsv, one row per run with the columns
+    line, expression, description, user, command, timezone, run, timestamp
+    and relative (line, user and command are empty for a single expression)
+  - Streaming output with --ndjson, one line of JSON per run of a single
+    expression, or per job of a crontab with its runs, written as they are
+    computed; skipped lines are listed on standard error
+  - Batches of expressions with --stdin --expressions, reading one expression
+    per line instead of a crontab and writing the runs of each on one line,
+    as text or NDJSON; blank lines and # comments are skipped, and an
+    expression that fails gives an "error: ..." line (an object with an
+    "error" field with --ndjson) and an exit status of 1
+  - Public holidays with --holidays, a country code (DE, FR, GB, US) or an
+    ICS file: runs on a holiday are marked with its name; with
+    --skip-holidays, they are passed over and listed apart, showing the runs
+    a job that skips holidays makes instead
 
 Examples:
   cronkit next "*/15 * * * *"              # Next 10 runs (default)
   cronkit next "@daily" --count 5          # Next 5 runs
+  cronkit next "0 */6 * * *" --for 72h     # Every run in the next 3 days
+  cronkit next "0 9 * * 1-5" --until 2027-02-01
+  cronkit next "*/30 * * * *" --from "tomorrow 09:00" -c 4
   cronkit next "0 9 * * 1-5" -c 3          # Next 3 runs (short flag)
   cronkit next "0 14 * * *" --json         # JSON output
-  cronkit next "*/5 9-17 * * 1-5" -c 20    # Business hours monitoring`,
+  cronkit next "*/5 9-17 * * 1-5" -c 20    # Business hours monitoring
+  cronkit next "*/30 * * * * *" -c 4       # Every 30 seconds
+  cronkit next --file /etc/crontab -c 3    # Next 3 runs of every job
+  cronkit next --file /etc/crontab --format csv > runs.csv
+  cronkit next --file big.cron --ndjson | jq 'select(.user == "root")'
+  cronkit next --stdin --expressions -c 1 --ndjson < expressions.txt
+  cronkit next "0 9 * * *" --timezone Europe/Paris
+  cronkit next "0 9 * * 1-5" --holidays US --skip-holidays`,
 	}
 
 	nc.Command.Flags().IntVarP(&nc.count, "count", "c", DefaultNextCount, "Number of runs to show (1-100, default: 10)")
+	nc.Command.Flags().StringVar(&nc.until, "until", "", "Show every run until a date (midnight in --timezone, e.g. 2025-02-01) or RFC3339 time, and their total, instead of --count runs")
+	nc.Command.Flags().StringVar(&nc.from, "from", "", fmt.Sprintf(fromUsage, "Show runs after this time"))
+	nc.Command.Flags().StringVar(&nc.forSpan, "for", "", "Show every run in a span from now (e.g. 72h, 2w, 1mo), and their total, instead of --count runs")
 	nc.Command.Flags().BoolVarP(&nc.json, "json", "j", false, "Output in JSON format")
-	nc.Command.Flags().StringVar(&nc.timezone, "timezone", "", "Timezone for calculations (e.g., 'America/New_York', 'UTC', defaults to local timezone)")
+	nc.Command.Flags().BoolVar(&nc.ndjson, "ndjson", false, fmt.Sprintf(ndjsonUsage, "run (or crontab job)"))
+	nc.Command.Flags().BoolVar(&nc.outputSchema, "output-schema", false, outputSchemaUsage)
+	nc.Command.Flags().StringVar(&nc.format, "format", tabularFormatText, fmt.Sprintf(tabularFormatUsage, "run"))
+	nc.Command.Flags().StringVar(&nc.timezone, "timezone", "", "Timezone for calculations (e.g., 'America/New_York', 'UTC', defaults to local timezone); crontab jobs under CRON_TZ= or TZ= use that zone instead")
+	nc.Command.Flags().BoolVar(&nc.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	nc.Command.Flags().StringVar(&nc.dialect, "dialect", "standard", dialectUsage)
+	nc.Command.Flags().StringVar(&nc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+	nc.Command.Flags().StringVarP(&nc.file, "file", "f", "", "Show the next runs of every job in a crontab file")
+	nc.Command.Flags().BoolVar(&nc.system, "system", false, systemUsage)
+	nc.Command.Flags().BoolVar(&nc.stdin, "stdin", false, "Read a crontab from standard input")
+	nc.Command.Flags().BoolVar(&nc.skipInvalid, "skip-invalid", true, skipInvalidUsage)
+	nc.Command.Flags().BoolVar(&nc.expressions, "expressions", false, "With --stdin, read one cron expression per line instead of a crontab")
+	nc.Command.Flags().StringVar(&nc.holidays, "holidays", "", holidaysUsage+"; runs on its holidays are marked")
+	nc.Command.Flags().BoolVar(&nc.skipHolidays, "skip-holidays", false, "Pass over runs on the --holidays calendar's holidays, listing them apart")
 
 	return nc
 }
 
 func (nc *NextCommand) runNext(_ *cobra.Command, args []string) error {
-	expression := args[0]
+	if nc.outputSchema {
+		return writeSchema(nc.OutOrStdout(), "next")
+	}
+
+	crontabMode := nc.file != "" || nc.stdin
+	if crontabMode && len(args) > 0 {
+		return fmt.Errorf("cannot combine a cron expression with --file or --stdin")
+	}
+	if !crontabMode && len(args) == 0 {
+		return fmt.Errorf("requires a cron expression, --file or --stdin")
+	}
+	format, err := tabularFormat(nc.format, nc.json)
+	if err != nil {
+		return err
+	}
+	nc.format = format
+	if err := validateNDJSON(nc.Command, nc.ndjson); err != nil {
+		return err
+	}
 
 	// Validate count range
 	if nc.count < MinNextCount {
@@ -84,6 +220,13 @@ func (nc *NextCommand) runNext(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid count: must be at most %d", MaxNextCount)
 	}
 
+	if nc.skipHolidays && nc.holidays == "" {
+		return fmt.Errorf("--skip-holidays requires --holidays")
+	}
+	if nc.calendar, err = loadHolidays(nc.holidays); err != nil {
+		return err
+	}
+
 	// Determine timezone
 	loc := time.Local
 	if nc.timezone != "" {
@@ -93,72 +236,246 @@ func (nc *NextCommand) runNext(_ *cobra.Command, args []string) error {
 		}
 		loc = parsedLoc
 	}
+	nc.start = time.Now().In(loc)
+	if nc.from != "" {
+		if nc.start, err = parseTimeFlag("from", nc.from, loc); err != nil {
+			return err
+		}
+	}
+	if err := nc.parseWindow(loc); err != nil {
+		return err
+	}
 
 	// Create scheduler and calculate next runs
-	scheduler := cronx.NewScheduler()
-	now := time.Now().In(loc)
+	opts, err := parserOptions(nc.seconds, nc.dialect, nc.jenkinsJob)
+	if err != nil {
+		return err
+	}
+	if nc.expressions {
+		return nc.runNextExpressions(opts, loc)
+	}
+	if crontabMode {
+		return nc.runNextCrontab(opts, loc)
+	}
+
+	expression := args[0]
+	scheduler := cronx.NewSchedulerWithOptions(opts)
+	now := nc.start
 
-	times, err := scheduler.Next(expression, now, nc.count)
+	times, skipped, err := nc.nextRuns(scheduler, expression, now)
 	if err != nil {
 		return fmt.Errorf("failed to calculate next runs: %w", err)
 	}
 
 	// Get human description with the specified locale
-	parser := cronx.NewParserWithLocale(GetLocale())
+	parser := cronx.NewParserWithOptions(GetLocale(), opts)
 	schedule, err := parser.Parse(expression)
 	if err != nil {
 		return fmt.Errorf("failed to parse expression: %w", err)
 	}
 
-	humanizer := human.NewHumanizer()
+	humanizer := newHumanizer()
 	description := humanizer.Humanize(schedule)
 
 	// Output based on format
-	if nc.json {
-		return nc.outputNextJSON(expression, description, times, now, loc)
+	switch {
+	case nc.ndjson:
+		out := newNDJSONWriter(nc.OutOrStdout())
+		for i, t := range times {
+			run := nc.nextRun(i+1, t.In(loc), now)
+			if err := out.Write(run); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nc.format == tabularFormatJSON:
+		return nc.outputNextJSON(expression, description, times, skipped, now, loc)
+	case isTabular(nc.format):
+		for i, t := range times {
+			times[i] = t.In(loc)
+		}
+		job := NextJob{Expression: expression, Description: description, Timezone: loc.String()}
+		return nc.outputNextTabular([]NextJob{job}, [][]time.Time{times}, now)
 	}
 
-	return nc.outputNextText(expression, description, times, loc)
+	return nc.outputNextText(expression, description, times, skipped, loc)
 }
 
-func (nc *NextCommand) outputNextText(expression, description string, times []time.Time, loc *time.Location) error {
-	// Header with count
+// parseWindow sets the end of the window of runs from --until or --for,
+// which is counted from the start of the runs. A date given to --until is
+// midnight in loc.
+func (nc *NextCommand) parseWindow(loc *time.Location) error {
+	if nc.until == "" && nc.forSpan == "" {
+		return nil
+	}
+	if nc.until != "" && nc.forSpan != "" {
+		return fmt.Errorf("--until and --for cannot be used together")
+	}
+	if nc.Flags().Changed("count") {
+		return fmt.Errorf("--count cannot be used with --until or --for")
+	}
+
+	if nc.forSpan != "" {
+		span, err := check.ParseHorizon(nc.forSpan)
+		if err != nil {
+			return fmt.Errorf("invalid --for span: %w", err)
+		}
+		nc.end = nc.start.Add(span)
+		return nil
+	}
+
+	end, err := time.Parse(time.RFC3339, nc.until)
+	if err != nil {
+		if end, err = time.ParseInLocation(time.DateOnly, nc.until, loc); err != nil {
+			return fmt.Errorf("invalid --until time %q (expected a date like 2025-02-01 or RFC3339)", nc.until)
+		}
+	}
+	if !end.After(nc.start) {
+		return fmt.Errorf("--until %s is not after the start of the runs", nc.until)
+	}
+	nc.end = end
+	return nil
+}
+
+// window returns the JSON form of the window of runs from from, with
+// --until or --for, and nil otherwise
+func (nc *NextCommand) window(from time.Time, total int, loc *time.Location) *NextWindow {
+	if nc.end.IsZero() {
+		return nil
+	}
+	return &NextWindow{
+		From:  from.In(loc).Format(time.RFC3339),
+		Until: nc.end.In(loc).Format(time.RFC3339),
+		Total: total,
+	}
+}
+
+// nextRuns returns the next runs of an expression after from, or those
+// until the end of the window with --until or --for, and, with
+// --skip-holidays, the runs on holidays passed over to find them
+func (nc *NextCommand) nextRuns(scheduler cronx.Scheduler, expression string, from time.Time) (times, skipped []time.Time, err error) {
+	if !nc.end.IsZero() {
+		all, err := scheduler.BetweenContext(nc.Context(), expression, from, nc.end)
+		if err != nil || !nc.skipHolidays {
+			return all, nil, err
+		}
+		for _, t := range all {
+			if _, ok := nc.calendar.Lookup(t); ok {
+				skipped = append(skipped, t)
+			} else {
+				times = append(times, t)
+			}
+		}
+		return times, skipped, nil
+	}
+	if nc.skipHolidays {
+		return nextOffHolidays(nc.Context(), scheduler, expression, from, nc.count, nc.calendar)
+	}
+	times, err = scheduler.NextContext(nc.Context(), expression, from, nc.count)
+	return times, nil, err
+}
+
+// nextRun returns the JSON form of a run, marked with the holiday it falls
+// on (with --holidays)
+func (nc *NextCommand) nextRun(number int, t, now time.Time) NextRun {
+	return NextRun{
+		Number:    number,
+		Timestamp: t.Format(time.RFC3339),
+		Relative:  formatRelativeTime(now, t),
+		Holiday:   nc.holidayName(t),
+	}
+}
+
+// nextRunList returns the JSON form of runs, in loc
+func (nc *NextCommand) nextRunList(times []time.Time, now time.Time, loc *time.Location) []NextRun {
+	if len(times) == 0 {
+		return nil
+	}
+	runs := make([]NextRun, len(times))
+	for i, t := range times {
+		runs[i] = nc.nextRun(i+1, t.In(loc), now)
+	}
+	return runs
+}
+
+// holidayName returns the name of the holiday a run falls on, if any
+func (nc *NextCommand) holidayName(t time.Time) string {
+	if nc.calendar == nil {
+		return ""
+	}
+	name, _ := nc.calendar.Lookup(t)
+	return name
+}
+
+// stamp formats a run for text output, with the holiday it falls on
+func (nc *NextCommand) stamp(format timeFormat, t time.Time) string {
+	if name := nc.holidayName(t); name != "" {
+		return fmt.Sprintf("%s (holiday: %s)", format.stamp(t), name)
+	}
+	return format.stamp(t)
+}
+
+// printSkippedRuns lists the runs passed over on holidays, indented
+func (nc *NextCommand) printSkippedRuns(indent string, skipped []time.Time, format timeFormat) {
+	if len(skipped) == 0 {
+		return
+	}
+	nc.Printf("%sSkipped on holidays:\n", indent)
+	for _, t := range skipped {
+		nc.Printf("%s  - %s (%s)\n", indent, format.stamp(t), nc.holidayName(t))
+	}
+}
+
+func (nc *NextCommand) outputNextText(expression, description string, times, skipped []time.Time, loc *time.Location) error {
+	format := getTimeFormat()
+
+	// Header with count, or the end of the window
 	runWord := "runs"
 	if len(times) == 1 {
 		runWord = "run"
 	}
-	nc.Printf("Next %d %s for \"%s\" (%s):\n\n",
-		len(times), runWord, expression, description)
+	if nc.end.IsZero() {
+		nc.Printf("Next %d %s for \"%s\" (%s):\n\n",
+			len(times), runWord, expression, description)
+	} else {
+		nc.Printf("Runs for \"%s\" (%s) until %s:\n\n",
+			expression, description, format.stamp(nc.end.In(loc)))
+	}
 
 	// List each run with timestamp in the specified timezone
 	for i, t := range times {
-		tInLoc := t.In(loc)
-		nc.Printf("%d. %s\n",
-			i+1, tInLoc.Format("2006-01-02 15:04:05 MST"))
+		nc.Printf("%d. %s\n", i+1, nc.stamp(format, t.In(loc)))
+	}
+	if !nc.end.IsZero() {
+		nc.Printf("\nTotal: %d %s\n", len(times), runWord)
+	}
+	if len(skipped) > 0 {
+		nc.Println()
+		for i, t := range skipped {
+			skipped[i] = t.In(loc)
+		}
+		nc.printSkippedRuns("", skipped, format)
 	}
 
 	return nil
 }
 
-func (nc *NextCommand) outputNextJSON(expression, description string, times []time.Time, now time.Time, loc *time.Location) error {
-	// Build next runs array
-	runs := make([]NextRun, len(times))
-	for i, t := range times {
-		tInLoc := t.In(loc)
-		runs[i] = NextRun{
-			Number:    i + 1,
-			Timestamp: tInLoc.Format(time.RFC3339),
-			Relative:  formatRelativeTime(now, t),
-		}
+func (nc *NextCommand) outputNextJSON(expression, description string, times, skipped []time.Time, now time.Time, loc *time.Location) error {
+	runs := nc.nextRunList(times, now, loc)
+	if runs == nil {
+		runs = []NextRun{}
 	}
 
 	// Build result structure
 	result := NextResult{
-		Expression:  expression,
-		Description: description,
-		Timezone:    loc.String(),
-		Locale:      GetLocale(),
-		NextRuns:    runs,
+		SchemaVersion: schema.Version,
+		Expression:    expression,
+		Description:   description,
+		Timezone:      loc.String(),
+		Locale:        GetLocale(),
+		Window:        nc.window(now, len(runs), loc),
+		NextRuns:      runs,
+		SkippedRuns:   nc.nextRunList(skipped, now, loc),
 	}
 
 	// Encode as JSON with indentation
@@ -171,6 +488,234 @@ func (nc *NextCommand) outputNextJSON(expression, description string, times []ti
 	return nil
 }
 
+// runNextExpressions shows the next runs of the expressions of standard
+// input, one expression per line
+func (nc *NextCommand) runNextExpressions(opts cronx.ParserOptions, loc *time.Location) error {
+	if !nc.stdin || nc.file != "" {
+		return fmt.Errorf("--expressions requires --stdin, and cannot be used with --file")
+	}
+	// "output: json" from the config file applies to single expressions only
+	if nc.Flags().Changed("json") || isTabular(nc.format) {
+		return fmt.Errorf("--expressions writes text or --ndjson, and cannot be used with --json or --format")
+	}
+	if nc.calendar != nil {
+		return fmt.Errorf("--expressions cannot be used with --holidays")
+	}
+
+	scheduler := cronx.NewSchedulerWithOptions(opts)
+	parser := cronx.NewParserWithOptions(GetLocale(), opts)
+	humanizer := newHumanizer()
+	format := getTimeFormat()
+	now := nc.start
+	var out *ndjsonWriter
+	if nc.ndjson {
+		out = newNDJSONWriter(nc.OutOrStdout())
+	}
+
+	return eachExpression(nc.Command, nc.InOrStdin(), out, func(line int, expression string) error {
+		times, _, err := nc.nextRuns(scheduler, expression, now)
+		if err != nil {
+			return fmt.Errorf("failed to calculate next runs: %w", err)
+		}
+		if out == nil {
+			stamps := make([]string, len(times))
+			for i, t := range times {
+				stamps[i] = format.stamp(t.In(loc))
+			}
+			nc.Println(strings.Join(stamps, ", "))
+			return nil
+		}
+
+		schedule, err := parser.Parse(expression)
+		if err != nil {
+			return fmt.Errorf("failed to parse expression: %w", err)
+		}
+		runs := make([]NextRun, len(times))
+		for i, t := range times {
+			runs[i] = NextRun{Number: i + 1, Timestamp: t.In(loc).Format(time.RFC3339), Relative: formatRelativeTime(now, t)}
+		}
+		return out.Write(NextBatchResult{Line: line, NextResult: NextResult{
+			SchemaVersion: schema.Version,
+			Expression:    expression,
+			Description:   humanizer.Humanize(schedule),
+			Timezone:      loc.String(),
+			Locale:        GetLocale(),
+			Window:        nc.window(now, len(runs), loc),
+			NextRuns:      runs,
+		}})
+	})
+}
+
+// runNextCrontab shows the next runs of every valid job in a crontab. Jobs
+// preceded by CRON_TZ= or TZ= are scheduled in that zone; the others in loc.
+func (nc *NextCommand) runNextCrontab(opts cronx.ParserOptions, loc *time.Location) error {
+	var (
+		entries []*crontab.Entry
+		source  string
+		err     error
+	)
+	if nc.file != "" {
+		source = nc.file
+		entries, err = newCrontabReader(nc.system).ParseFile(nc.file)
+		if err != nil {
+			return fmt.Errorf("failed to read crontab file: %w", err)
+		}
+	} else {
+		source = "stdin"
+		entries, err = crontab.ParseReaderLayout(nc.InOrStdin(), crontabLayout(nc.system))
+		if err != nil {
+			return fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+	}
+	jobs, skipped, err := partitionEntries(entries, nc.skipInvalid)
+	if err != nil {
+		return err
+	}
+
+	scheduler := cronx.NewSchedulerWithOptions(opts)
+	parser := cronx.NewParserWithOptions(GetLocale(), opts)
+	humanizer := newHumanizer()
+	now := nc.start
+
+	result := NextCrontabResult{
+		SchemaVersion: schema.Version,
+		Source:        source,
+		Timezone:      loc.String(),
+		Locale:        GetLocale(),
+		Jobs:          []NextJob{},
+		Skipped:       skipped,
+	}
+	var jobTimes [][]time.Time     // Run times of result.Jobs, in each job's zone
+	var skippedTimes [][]time.Time // Runs of result.Jobs passed over on holidays
+	var out *ndjsonWriter          // Set to write jobs as they are computed, instead of collecting them
+	if nc.ndjson {
+		out = newNDJSONWriter(nc.OutOrStdout())
+		for _, s := range skipped {
+			_, _ = fmt.Fprintf(nc.ErrOrStderr(), "⚠ WARNING: Skipped line %d: %s (%s)\n", s.LineNumber, s.Line, s.Reason)
+		}
+	}
+	for _, job := range jobs {
+		jobLoc, err := job.Location(loc)
+		if err != nil {
+			return err
+		}
+
+		times, skippedRuns, err := nc.nextRuns(scheduler, job.Expression, now.In(jobLoc))
+		if err != nil {
+			return fmt.Errorf("line %d: failed to calculate next runs: %w", job.LineNumber, err)
+		}
+		schedule, err := parser.Parse(job.Expression)
+		if err != nil {
+			return fmt.Errorf("line %d: failed to parse expression: %w", job.LineNumber, err)
+		}
+
+		runs := nc.nextRunList(times, now, jobLoc)
+		if runs == nil {
+			runs = []NextRun{}
+		}
+		for i, t := range times {
+			times[i] = t.In(jobLoc)
+		}
+		nextJob := NextJob{
+			LineNumber:  job.LineNumber,
+			Expression:  job.Expression,
+			User:        job.User,
+			Command:     job.Command,
+			Description: humanizer.Humanize(schedule),
+			Timezone:    jobLoc.String(),
+			Window:      nc.window(now, len(runs), jobLoc),
+			NextRuns:    runs,
+			SkippedRuns: nc.nextRunList(skippedRuns, now, jobLoc),
+		}
+		if out != nil {
+			if err := out.Write(nextJob); err != nil {
+				return err
+			}
+			continue
+		}
+		for i, t := range skippedRuns {
+			skippedRuns[i] = t.In(jobLoc)
+		}
+		result.Jobs = append(result.Jobs, nextJob)
+		jobTimes = append(jobTimes, times)
+		skippedTimes = append(skippedTimes, skippedRuns)
+	}
+	if out != nil {
+		return nil
+	}
+	total := 0
+	for _, times := range jobTimes {
+		total += len(times)
+	}
+	result.Window = nc.window(now, total, loc)
+
+	if isTabular(nc.format) {
+		return nc.outputNextTabular(result.Jobs, jobTimes, now)
+	}
+	if nc.format == tabularFormatJSON {
+		encoder := json.NewEncoder(nc.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	if len(result.Jobs) == 0 {
+		nc.Printf("No valid jobs found in %s\n", source)
+	}
+	format := getTimeFormat()
+	for i, job := range result.Jobs {
+		if i > 0 {
+			nc.Println()
+		}
+		nc.Printf("Line %d: \"%s\" (%s) [%s]\n", job.LineNumber, job.Expression, job.Description, job.Timezone)
+		if job.User != "" {
+			nc.Printf("  User: %s\n", job.User)
+		}
+		nc.Printf("  Command: %s\n", job.Command)
+		for n, t := range jobTimes[i] {
+			nc.Printf("  %d. %s\n", n+1, nc.stamp(format, t))
+		}
+		if !nc.end.IsZero() {
+			nc.Printf("  Total: %d run(s)\n", len(jobTimes[i]))
+		}
+		nc.printSkippedRuns("  ", skippedTimes[i], format)
+	}
+	if !nc.end.IsZero() && len(result.Jobs) > 0 {
+		nc.Printf("\nTotal: %d run(s) of %d job(s) until %s\n", total, len(result.Jobs), format.stamp(nc.end.In(loc)))
+	}
+	printSkipped(nc.Command, skipped)
+	return nil
+}
+
+// outputNextTabular writes a CSV or TSV row per run of the jobs; times are
+// the run times of each job
+func (nc *NextCommand) outputNextTabular(jobs []NextJob, times [][]time.Time, now time.Time) error {
+	var rows [][]string
+	for i, job := range jobs {
+		line := ""
+		if job.LineNumber > 0 {
+			line = strconv.Itoa(job.LineNumber)
+		}
+		for n, t := range times[i] {
+			row := []string{
+				line, job.Expression, job.Description, job.User, job.Command, job.Timezone,
+				strconv.Itoa(n + 1), t.Format(time.RFC3339), formatRelativeTime(now, t),
+			}
+			if nc.calendar != nil {
+				row = append(row, nc.holidayName(t))
+			}
+			rows = append(rows, row)
+		}
+	}
+	header := []string{"line", "expression", "description", "user", "command", "timezone", "run", "timestamp", "relative"}
+	if nc.calendar != nil {
+		header = append(header, "holiday")
+	}
+	return writeTable(nc.OutOrStdout(), nc.format, header, rows)
+}
+
 // formatRelativeTime converts a duration between two times to a human-readable format.
 func formatRelativeTime(from, to time.Time) string {
 	duration := to.Sub(from)
@@ -42,6 +42,52 @@ func TestNextCommand(t *testing.T) {
 		assert.Contains(t, output, "10.")
 	})
 
+	t.Run("next with --time-format 12h", func(t *testing.T) {
+		oldFormat := timeFormatFlag
+		timeFormatFlag = "12h"
+		defer func() { timeFormatFlag = oldFormat }()
+
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"30 14 * * *", "--count", "1", "--timezone", "UTC"})
+
+		err := nc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "(At 2:30 PM every day)")
+		assert.Regexp(t, `1\. \d{4}-\d{2}-\d{2} 2:30:00 PM UTC`, output)
+	})
+
+	t.Run("next with a seconds field", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"*/20 * * * * *", "--count", "3", "--timezone", "UTC"})
+
+		err := nc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Every 20 seconds")
+		assert.Regexp(t, `1\. \d{4}-\d{2}-\d{2} \d{2}:\d{2}:(00|20|40) UTC`, output)
+	})
+
+	t.Run("next with the quartz dialect", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"0 0 12 ? * 6L", "--dialect", "quartz", "--count", "3", "--timezone", "UTC"})
+
+		err := nc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "on the last Friday of the month")
+		assert.Regexp(t, `1\. \d{4}-\d{2}-(2[2-9]|3[01]) 12:00:00 UTC`, output)
+	})
+
 	t.Run("next with custom count", func(t *testing.T) {
 		nc := newNextCommand()
 		buf := new(bytes.Buffer)
@@ -270,4 +316,392 @@ func TestNextCommand(t *testing.T) {
 		// Should not error and should produce output
 		assert.Contains(t, output, "Next 1 run")
 	})
+
+	t.Run("next with a crontab file respects CRON_TZ", func(t *testing.T) {
+		file := createTempFile(t, "0 9 * * * /bin/utc.sh\nCRON_TZ=Asia/Tokyo\n0 9 * * * /bin/tokyo.sh\n")
+
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"--file", file, "--timezone", "UTC", "-c", "2", "--json"})
+
+		err := nc.Execute()
+		require.NoError(t, err)
+
+		var result NextCrontabResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "UTC", result.Timezone)
+		require.Len(t, result.Jobs, 2)
+
+		assert.Equal(t, "UTC", result.Jobs[0].Timezone)
+		assert.Equal(t, "Asia/Tokyo", result.Jobs[1].Timezone)
+		assert.Equal(t, "/bin/tokyo.sh", result.Jobs[1].Command)
+		require.Len(t, result.Jobs[1].NextRuns, 2)
+		for _, run := range result.Jobs[1].NextRuns {
+			ts, err := time.Parse(time.RFC3339, run.Timestamp)
+			require.NoError(t, err)
+			assert.Equal(t, 0, ts.UTC().Hour(), "09:00 in Tokyo is midnight UTC")
+			assert.True(t, strings.HasSuffix(run.Timestamp, "+09:00"))
+		}
+	})
+
+	t.Run("next with a crontab on stdin (text)", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetIn(strings.NewReader("TZ=America/New_York\n30 6 * * 1-5 /bin/report.sh\ninvalid line\n"))
+		nc.SetArgs([]string{"--stdin", "-c", "1"})
+
+		err := nc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, `Line 2: "30 6 * * 1-5"`)
+		assert.Contains(t, output, "[America/New_York]")
+		assert.Contains(t, output, "Command: /bin/report.sh")
+		assert.Regexp(t, `1\. \d{4}-\d{2}-\d{2} 06:30:00 E[SD]T`, output)
+	})
+
+	t.Run("next --stdin --expressions", func(t *testing.T) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetErr(new(bytes.Buffer))
+		nc.SetIn(strings.NewReader("0 9 * * *\n# comment\nbad\n30 6 * * *\n"))
+		nc.SetArgs([]string{"--stdin", "--expressions", "-c", "2", "--timezone", "UTC"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2} 09:00:00 UTC, \d{4}-\d{2}-\d{2} 09:00:00 UTC$`, lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "error: "), lines[1])
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2} 06:30:00 UTC, `, lines[2])
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("next --stdin --expressions --ndjson", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetIn(strings.NewReader("@daily\n@hourly\n"))
+		nc.SetArgs([]string{"--stdin", "--expressions", "-c", "3", "--timezone", "UTC", "--ndjson"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var result NextBatchResult
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &result))
+		assert.Equal(t, 2, result.Line)
+		assert.Equal(t, "@hourly", result.Expression)
+		assert.Equal(t, "At the start of every hour", result.Description)
+		assert.Len(t, result.NextRuns, 3)
+	})
+
+	t.Run("next --expressions errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"--expressions", "0 0 * * *"},
+			{"--stdin", "--expressions", "--json"},
+			{"--stdin", "--expressions", "--format", "csv"},
+		} {
+			nc := newNextCommand()
+			nc.SetOut(new(bytes.Buffer))
+			nc.SetErr(new(bytes.Buffer))
+			nc.SetIn(strings.NewReader("0 0 * * *\n"))
+			nc.SetArgs(args)
+			assert.Error(t, nc.Execute(), args)
+		}
+	})
+
+	t.Run("next with a crontab without jobs", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetIn(strings.NewReader("# nothing here\n"))
+		nc.SetArgs([]string{"--stdin"})
+
+		err := nc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "No valid jobs found in stdin")
+	})
+
+	t.Run("next crontab mode errors", func(t *testing.T) {
+		file := createTempFile(t, "CRON_TZ=Mars/Olympus\n0 9 * * * /bin/job.sh\n")
+		tests := []struct {
+			name string
+			args []string
+			want string
+		}{
+			{"expression and file", []string{"* * * * *", "--file", file}, "cannot combine"},
+			{"invalid CRON_TZ", []string{"--file", file}, "invalid time zone"},
+			{"missing file", []string{"--file", "/nonexistent/crontab"}, "failed to read crontab file"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				nc := newNextCommand()
+				nc.SetOut(new(bytes.Buffer))
+				nc.SetErr(new(bytes.Buffer))
+				nc.SetArgs(tt.args)
+
+				err := nc.Execute()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.want)
+			})
+		}
+	})
+}
+
+func TestNextCommand_Format(t *testing.T) {
+	t.Run("csv for an expression", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"0 9 * * *", "--count", "2", "--timezone", "UTC", "--format", "csv"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "line,expression,description,user,command,timezone,run,timestamp,relative", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], ",0 9 * * *,At 09:00 every day,,,UTC,1,"))
+		assert.Contains(t, lines[2], "T09:00:00Z,in ")
+	})
+
+	t.Run("tsv for a crontab", func(t *testing.T) {
+		testFile := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n*/5 * * * * /usr/bin/poll\n")
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"--file", testFile, "--count", "3", "--timezone", "UTC", "--format", "tsv"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 7)
+		assert.True(t, strings.HasPrefix(lines[1], "1\t0 2 * * *\tAt 02:00 every day\t\t/usr/bin/backup.sh\tUTC\t1\t"))
+		assert.True(t, strings.HasPrefix(lines[6], "2\t*/5 * * * *\t"))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		nc := newNextCommand()
+		nc.SetOut(new(bytes.Buffer))
+		nc.SetErr(new(bytes.Buffer))
+		nc.SetArgs([]string{"0 9 * * *", "--format", "yaml"})
+
+		assert.ErrorContains(t, nc.Execute(), "invalid --format")
+	})
+}
+
+func TestNextCommand_NDJSON(t *testing.T) {
+	t.Run("a run per line for an expression", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"0 9 * * *", "--count", "3", "--timezone", "UTC", "--ndjson"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		var run NextRun
+		require.NoError(t, json.Unmarshal([]byte(lines[2]), &run))
+		assert.Equal(t, 3, run.Number)
+		assert.True(t, strings.HasSuffix(run.Timestamp, "T09:00:00Z"))
+	})
+
+	t.Run("a job per line for a crontab", func(t *testing.T) {
+		testFile := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\nbad line\n*/5 * * * * /usr/bin/poll\n")
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		errBuf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetErr(errBuf)
+		nc.SetArgs([]string{"--file", testFile, "--count", "2", "--timezone", "UTC", "--ndjson"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var job NextJob
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &job))
+		assert.Equal(t, 3, job.LineNumber)
+		assert.Equal(t, "/usr/bin/poll", job.Command)
+		assert.Len(t, job.NextRuns, 2)
+		assert.Contains(t, errBuf.String(), "Skipped line 2")
+	})
+}
+
+func TestNextCommand_Holidays(t *testing.T) {
+	t.Run("marks runs on holidays", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"0 9 24-26 12 *", "--count", "3", "--timezone", "UTC", "--holidays", "DE"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 5)
+		assert.NotContains(t, lines[2], "holiday")
+		assert.True(t, strings.HasSuffix(lines[3], "(holiday: Christmas Day)"), lines[3])
+		assert.True(t, strings.HasSuffix(lines[4], "(holiday: Second Day of Christmas)"), lines[4])
+	})
+
+	t.Run("skips runs on holidays", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"0 9 24-26 12 *", "--count", "2", "--timezone", "UTC", "--holidays", "DE", "--skip-holidays", "--json"})
+
+		require.NoError(t, nc.Execute())
+		var result NextResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.NextRuns, 2)
+		for _, run := range result.NextRuns {
+			assert.Contains(t, run.Timestamp, "-12-24T09:00:00Z")
+			assert.Empty(t, run.Holiday)
+		}
+		require.Len(t, result.SkippedRuns, 2)
+		assert.Equal(t, "Christmas Day", result.SkippedRuns[0].Holiday)
+		assert.Equal(t, "Second Day of Christmas", result.SkippedRuns[1].Holiday)
+	})
+
+	t.Run("lists skipped runs of crontab jobs", func(t *testing.T) {
+		testFile := createTempFile(t, "0 9 24-26 12 * /usr/bin/report.sh\n")
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"--file", testFile, "--count", "2", "--timezone", "UTC", "--holidays", "de", "--skip-holidays"})
+
+		require.NoError(t, nc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "  2. ")
+		assert.Contains(t, output, "  Skipped on holidays:\n")
+		assert.Contains(t, output, "09:00:00 UTC (Christmas Day)")
+	})
+
+	t.Run("adds a holiday column to CSV", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"0 9 25 12 *", "--count", "1", "--timezone", "UTC", "--holidays", "US", "--format", "csv"})
+
+		require.NoError(t, nc.Execute())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasSuffix(lines[0], ",relative,holiday"))
+		assert.True(t, strings.HasSuffix(lines[1], ",Christmas Day"))
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		nc := newNextCommand()
+		nc.SetArgs([]string{"@daily", "--skip-holidays"})
+		assert.ErrorContains(t, nc.Execute(), "--skip-holidays requires --holidays")
+
+		nc = newNextCommand()
+		nc.SetArgs([]string{"@daily", "--holidays", "Atlantis"})
+		assert.ErrorContains(t, nc.Execute(), `invalid --holidays value: unknown holiday calendar "Atlantis"`)
+	})
+}
+
+func TestNextCommand_Window(t *testing.T) {
+	execute := func(t *testing.T, stdin string, args ...string) (string, error) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetErr(new(bytes.Buffer))
+		nc.SetIn(strings.NewReader(stdin))
+		nc.SetArgs(args)
+		err := nc.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("every run until the end of --for, with the total", func(t *testing.T) {
+		output, err := execute(t, "", "0 */6 * * *", "--for", "72h", "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output, `Runs for "0 */6 * * *" (`), output)
+		assert.Contains(t, output, "12. ")
+		assert.NotContains(t, output, "13. ")
+		assert.True(t, strings.HasSuffix(output, "\nTotal: 12 runs\n"), output)
+	})
+
+	t.Run("json window", func(t *testing.T) {
+		until := time.Now().UTC().AddDate(0, 0, 3).Format(time.DateOnly)
+		output, err := execute(t, "", "0 9 * * *", "--until", until, "--timezone", "UTC", "--json")
+		require.NoError(t, err)
+		var result NextResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		require.NotNil(t, result.Window)
+		assert.Equal(t, until+"T00:00:00Z", result.Window.Until)
+		assert.Equal(t, len(result.NextRuns), result.Window.Total)
+		assert.GreaterOrEqual(t, result.Window.Total, 2)
+		assert.LessOrEqual(t, result.Window.Total, 3)
+	})
+
+	t.Run("totals per job of a crontab", func(t *testing.T) {
+		output, err := execute(t, "0 */12 * * * /usr/bin/a\n0 0 1 1 * /usr/bin/b\n", "--stdin", "--for", "2d", "--timezone", "UTC", "--json")
+		require.NoError(t, err)
+		var result NextCrontabResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		require.Len(t, result.Jobs, 2)
+		assert.Equal(t, 4, result.Jobs[0].Window.Total)
+		require.NotNil(t, result.Window)
+		assert.Equal(t, result.Jobs[0].Window.Total+result.Jobs[1].Window.Total, result.Window.Total)
+
+		output, err = execute(t, "0 */12 * * * /usr/bin/a\n", "--stdin", "--for", "2d", "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.Contains(t, output, "  Total: 4 run(s)\n")
+		assert.Contains(t, output, "\nTotal: 4 run(s) of 1 job(s) until ")
+	})
+
+	t.Run("windows from --from", func(t *testing.T) {
+		output, err := execute(t, "", "0 9 * * *", "--from", "2030-01-01T00:00:00Z", "--for", "1w", "--timezone", "UTC", "--json")
+		require.NoError(t, err)
+		var result NextResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, &NextWindow{From: "2030-01-01T00:00:00Z", Until: "2030-01-08T00:00:00Z", Total: 7}, result.Window)
+		assert.Equal(t, "2030-01-01T09:00:00Z", result.NextRuns[0].Timestamp)
+	})
+
+	t.Run("windows with too many runs", func(t *testing.T) {
+		_, err := execute(t, "", "* * * * *", "--for", "1y")
+		assert.ErrorContains(t, err, "more than 100000 runs")
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		_, err := execute(t, "", "@daily", "--for", "2d", "--until", "2099-01-01")
+		assert.ErrorContains(t, err, "--until and --for cannot be used together")
+		_, err = execute(t, "", "@daily", "--for", "2d", "--count", "3")
+		assert.ErrorContains(t, err, "--count cannot be used with --until or --for")
+		_, err = execute(t, "", "@daily", "--for", "soon")
+		assert.ErrorContains(t, err, "invalid --for span")
+		_, err = execute(t, "", "@daily", "--until", "tomorrow")
+		assert.ErrorContains(t, err, `invalid --until time "tomorrow"`)
+		_, err = execute(t, "", "@daily", "--until", "2020-01-01")
+		assert.ErrorContains(t, err, "is not after the start of the runs")
+	})
+}
+
+func TestNextCommand_From(t *testing.T) {
+	t.Run("runs after a relative time", func(t *testing.T) {
+		nc := newNextCommand()
+		buf := new(bytes.Buffer)
+		nc.SetOut(buf)
+		nc.SetArgs([]string{"*/30 * * * *", "--from", "tomorrow 09:00", "-c", "2", "--timezone", "UTC", "--json"})
+
+		require.NoError(t, nc.Execute())
+		var result NextResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+		require.Len(t, result.NextRuns, 2)
+		assert.Equal(t, tomorrow+"T09:30:00Z", result.NextRuns[0].Timestamp)
+		assert.Equal(t, tomorrow+"T10:00:00Z", result.NextRuns[1].Timestamp)
+	})
+
+	t.Run("invalid --from time", func(t *testing.T) {
+		nc := newNextCommand()
+		nc.SetOut(new(bytes.Buffer))
+		nc.SetErr(new(bytes.Buffer))
+		nc.SetArgs([]string{"@daily", "--from", "someday"})
+		assert.ErrorContains(t, nc.Execute(), `invalid --from time: cannot read "someday" as a time`)
+	})
 }
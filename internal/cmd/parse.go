@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/natural"
+	"github.com/spf13/cobra"
+)
+
+type ParseCommand struct {
+	*cobra.Command
+	json bool
+}
+
+func newParseCommand() *ParseCommand {
+	pc := &ParseCommand{}
+	pc.Command = &cobra.Command{
+		Use:   "parse <phrase>",
+		Short: "Translate an English phrase into a cron expression",
+		Long: `Translate a schedule written in plain English into a cron expression,
+and explain the expression back to confirm the meaning.
+
+Phrases combine frequencies (every 15 minutes, every 2 hours, hourly, daily,
+weekly, monthly, yearly), times (at 9am, at 9:30 and 17:00, noon, midnight),
+time ranges (from 9am to 5pm), days of the week (on weekdays, every monday and
+friday, mon-fri), days of the month (on the 1st and 15th) and months (in
+january, march through may). Schedules without a time run at midnight.
+
+Ambiguous phrases, such as "at 9" (AM or PM), and schedules standard cron
+cannot express, such as "the first monday of the month", fail with
+suggestions.
+
+Examples:
+  cronkit parse "every weekday at 9am"
+  cronkit parse "every 15 minutes from 9am to 5pm on weekdays"
+  cronkit parse "on the 1st and 15th at noon" --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: pc.runParse,
+	}
+
+	pc.Flags().BoolVarP(&pc.json, "json", "j", false, "Output in JSON format")
+	return pc
+}
+
+func init() {
+	rootCmd.AddCommand(newParseCommand().Command)
+}
+
+func (pc *ParseCommand) runParse(_ *cobra.Command, args []string) error {
+	// Unquoted phrases arrive as several arguments
+	phrase := strings.Join(args, " ")
+
+	expression, err := natural.Parse(phrase)
+	if err != nil {
+		var nerr *natural.Error
+		if pc.json && errors.As(err, &nerr) {
+			if encodeErr := pc.outputErrorJSON(nerr); encodeErr != nil {
+				return encodeErr
+			}
+		}
+		return err
+	}
+
+	schedule, err := cronx.NewParser().Parse(expression)
+	if err != nil {
+		return fmt.Errorf("failed to parse translated expression %q: %w", expression, err)
+	}
+	description := newHumanizer().Humanize(schedule)
+
+	if pc.json {
+		return pc.outputJSON(phrase, expression, description)
+	}
+	pc.Println(expression)
+	pc.Printf("# %s\n", description)
+	return nil
+}
+
+func (pc *ParseCommand) outputJSON(phrase, expression, description string) error {
+	result := map[string]interface{}{
+		"input":       phrase,
+		"expression":  expression,
+		"description": description,
+		"locale":      GetLocale(),
+	}
+
+	encoder := json.NewEncoder(pc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// outputErrorJSON writes why a phrase could not be translated and the
+// suggested meanings
+func (pc *ParseCommand) outputErrorJSON(nerr *natural.Error) error {
+	suggestions := make([]map[string]string, 0, len(nerr.Suggestions))
+	for _, s := range nerr.Suggestions {
+		suggestion := map[string]string{"phrase": s.Phrase, "expression": s.Expression}
+		if s.Dialect != "" {
+			suggestion["dialect"] = s.Dialect
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	result := map[string]interface{}{
+		"input":       nerr.Input,
+		"error":       nerr.Reason,
+		"suggestions": suggestions,
+	}
+
+	encoder := json.NewEncoder(pc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	t.Run("parse command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"parse"})
+		assert.NoError(t, err)
+		assert.Equal(t, "parse", cmd.Name())
+	})
+
+	t.Run("phrase to cron", func(t *testing.T) {
+		pc := newParseCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"every weekday at 9am"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, "0 9 * * 1-5\n# At 09:00 on weekdays (Mon-Fri)\n", buf.String())
+	})
+
+	t.Run("unquoted phrase", func(t *testing.T) {
+		pc := newParseCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"every", "15", "minutes"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "*/15 * * * *")
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		pc := newParseCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"on the 1st and 15th at noon", "--json"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "on the 1st and 15th at noon", result["input"])
+		assert.Equal(t, "0 12 1,15 * *", result["expression"])
+		assert.Equal(t, "en", result["locale"])
+		assert.NotEmpty(t, result["description"])
+	})
+
+	t.Run("ambiguous phrase fails with suggestions", func(t *testing.T) {
+		pc := newParseCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetErr(new(bytes.Buffer))
+		pc.SetArgs([]string{"every weekday at 9"})
+
+		err := pc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "could be AM or PM")
+		assert.Contains(t, err.Error(), "every weekday at 9pm  (0 21 * * 1-5)")
+	})
+
+	t.Run("json error lists suggestions", func(t *testing.T) {
+		pc := newParseCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetErr(new(bytes.Buffer))
+		pc.SetArgs([]string{"first friday of the month", "--json"})
+
+		err := pc.Execute()
+		require.Error(t, err)
+
+		var result struct {
+			Error       string              `json:"error"`
+			Suggestions []map[string]string `json:"suggestions"`
+		}
+		require.NoError(t, json.NewDecoder(buf).Decode(&result))
+		assert.Contains(t, result.Error, "standard cron")
+		require.Len(t, result.Suggestions, 1)
+		assert.Equal(t, "quartz", result.Suggestions[0]["dialect"])
+		assert.Equal(t, "0 0 0 ? * FRI#1", result.Suggestions[0]["expression"])
+	})
+}
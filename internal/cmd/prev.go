@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/spf13/cobra"
+)
+
+// PrevCommand wraps cobra.Command with prev-specific functionality
+type PrevCommand struct {
+	*cobra.Command
+	count      int
+	json       bool
+	from       string
+	timezone   string
+	seconds    bool
+	dialect    string
+	jenkinsJob string
+}
+
+// PrevRun represents a single past run time
+type PrevRun struct {
+	Number    int    `json:"number"`
+	Timestamp string `json:"timestamp"`
+	Relative  string `json:"relative"`
+}
+
+// PrevResult represents the complete output for the prev command
+type PrevResult struct {
+	Expression   string    `json:"expression"`
+	Description  string    `json:"description"`
+	From         string    `json:"from"`
+	Timezone     string    `json:"timezone"`
+	Locale       string    `json:"locale"`
+	PreviousRuns []PrevRun `json:"previousRuns"`
+}
+
+func init() {
+	rootCmd.AddCommand(newPrevCommand().Command)
+}
+
+// newPrevCommand creates a fresh prev command instance
+func newPrevCommand() *PrevCommand {
+	pc := &PrevCommand{}
+	pc.Command = &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		RunE:  pc.runPrev,
+		Use:   "prev <cron-expression>",
+		Short: "Show previous run times for a cron expression",
+		Long: `Calculate and display the times a cron expression last fired before a given time.
+
+This command helps with incident analysis, e.g. "did the backup run last night?".
+Runs are listed most recent first, with relative times (e.g., "3 hours ago").
+
+Supports the same expressions as 'next', including 6-field expressions with a
+leading seconds field and the Quartz dialect.
+
+Examples:
+  cronkit prev "0 2 * * *"                               # Last 10 runs before now
+  cronkit prev "0 2 * * *" -c 1                          # Did the backup run last night?
+  cronkit prev "*/15 * * * *" --from 2026-01-05T09:00:00Z
+  cronkit prev "0 9 * * 1-5" --timezone America/New_York --json`,
+	}
+
+	pc.Command.Flags().IntVarP(&pc.count, "count", "c", DefaultNextCount, "Number of runs to show (1-100, default: 10)")
+	pc.Command.Flags().BoolVarP(&pc.json, "json", "j", false, "Output in JSON format")
+	pc.Command.Flags().StringVar(&pc.from, "from", "", fmt.Sprintf(fromUsage, "Show runs before this time"))
+	pc.Command.Flags().StringVar(&pc.timezone, "timezone", "", "Timezone for calculations (e.g., 'America/New_York', 'UTC', defaults to local timezone)")
+	pc.Command.Flags().BoolVar(&pc.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	pc.Command.Flags().StringVar(&pc.dialect, "dialect", "standard", dialectUsage)
+	pc.Command.Flags().StringVar(&pc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+
+	return pc
+}
+
+func (pc *PrevCommand) runPrev(_ *cobra.Command, args []string) error {
+	expression := args[0]
+
+	if pc.count < MinNextCount {
+		return fmt.Errorf("invalid count: must be at least %d", MinNextCount)
+	}
+	if pc.count > MaxNextCount {
+		return fmt.Errorf("invalid count: must be at most %d", MaxNextCount)
+	}
+
+	loc := time.Local
+	if pc.timezone != "" {
+		parsedLoc, err := time.LoadLocation(pc.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		loc = parsedLoc
+	}
+
+	from := time.Now().In(loc)
+	if pc.from != "" {
+		parsed, err := parseTimeFlag("from", pc.from, loc)
+		if err != nil {
+			return err
+		}
+		from = parsed
+	}
+
+	opts, err := parserOptions(pc.seconds, pc.dialect, pc.jenkinsJob)
+	if err != nil {
+		return err
+	}
+
+	times, err := cronx.NewSchedulerWithOptions(opts).Prev(expression, from, pc.count)
+	if err != nil {
+		return fmt.Errorf("failed to calculate previous runs: %w", err)
+	}
+	// Schedules that never ran within the search window yield zero times
+	for i, t := range times {
+		if t.IsZero() {
+			times = times[:i]
+			break
+		}
+	}
+
+	schedule, err := cronx.NewParserWithOptions(GetLocale(), opts).Parse(expression)
+	if err != nil {
+		return fmt.Errorf("failed to parse expression: %w", err)
+	}
+	description := newHumanizer().Humanize(schedule)
+
+	if pc.json {
+		return pc.outputPrevJSON(expression, description, times, from, loc)
+	}
+	return pc.outputPrevText(expression, description, times, from, loc)
+}
+
+func (pc *PrevCommand) outputPrevText(expression, description string, times []time.Time, from time.Time, loc *time.Location) error {
+	if len(times) == 0 {
+		pc.Printf("No previous runs for \"%s\" (%s)\n", expression, description)
+		return nil
+	}
+
+	runWord := "runs"
+	if len(times) == 1 {
+		runWord = "run"
+	}
+	pc.Printf("Previous %d %s for \"%s\" (%s):\n\n",
+		len(times), runWord, expression, description)
+
+	format := getTimeFormat()
+	for i, t := range times {
+		pc.Printf("%d. %s (%s)\n", i+1, format.stamp(t.In(loc)), formatTimeAgo(from, t))
+	}
+
+	return nil
+}
+
+func (pc *PrevCommand) outputPrevJSON(expression, description string, times []time.Time, from time.Time, loc *time.Location) error {
+	runs := make([]PrevRun, len(times))
+	for i, t := range times {
+		runs[i] = PrevRun{
+			Number:    i + 1,
+			Timestamp: t.In(loc).Format(time.RFC3339),
+			Relative:  formatTimeAgo(from, t),
+		}
+	}
+
+	result := PrevResult{
+		Expression:   expression,
+		Description:  description,
+		From:         from.Format(time.RFC3339),
+		Timezone:     loc.String(),
+		Locale:       GetLocale(),
+		PreviousRuns: runs,
+	}
+
+	encoder := json.NewEncoder(pc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// formatTimeAgo converts the duration from a past time to now into a
+// human-readable format, e.g. "3 hours ago"
+func formatTimeAgo(now, past time.Time) string {
+	duration := now.Sub(past)
+
+	switch {
+	case duration < time.Minute:
+		return "less than a minute ago"
+	case duration < time.Hour:
+		return pluralAgo(int(duration.Minutes()), "minute")
+	case duration < 24*time.Hour:
+		return pluralAgo(int(duration.Hours()), "hour")
+	default:
+		return pluralAgo(int(duration.Hours()/24), "day")
+	}
+}
+
+// pluralAgo formats "1 hour ago" or "N hours ago"
+func pluralAgo(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s ago", unit)
+	}
+	return fmt.Sprintf("%d %ss ago", n, unit)
+}
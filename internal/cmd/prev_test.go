@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrevCommand(t *testing.T) {
+	t.Run("prev command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"prev"})
+		assert.NoError(t, err)
+		assert.Equal(t, "prev", cmd.Name())
+	})
+
+	t.Run("prev command should have metadata", func(t *testing.T) {
+		pc := newPrevCommand()
+		assert.NotEmpty(t, pc.Short)
+		assert.NotEmpty(t, pc.Long)
+		assert.Contains(t, pc.Use, "prev")
+	})
+
+	t.Run("prev text output", func(t *testing.T) {
+		pc := newPrevCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"0 2 * * *", "-c", "2", "--from", "2026-01-05T09:00:00Z", "--timezone", "UTC"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, `Previous 2 runs for "0 2 * * *" (At 02:00 every day)`)
+		assert.Contains(t, output, "1. 2026-01-05 02:00:00 UTC (7 hours ago)\n")
+		assert.Contains(t, output, "2. 2026-01-04 02:00:00 UTC (1 day ago)\n")
+	})
+
+	t.Run("prev JSON output", func(t *testing.T) {
+		pc := newPrevCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"30 17 * * 1-5", "--count", "2", "--from", "2026-01-05T09:00:00Z", "--timezone", "UTC", "--json"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+
+		var result PrevResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "30 17 * * 1-5", result.Expression)
+		assert.Equal(t, "2026-01-05T09:00:00Z", result.From)
+		assert.Equal(t, "UTC", result.Timezone)
+		require.Len(t, result.PreviousRuns, 2)
+		assert.Equal(t, PrevRun{Number: 1, Timestamp: "2026-01-02T17:30:00Z", Relative: "2 days ago"}, result.PreviousRuns[0])
+		assert.Equal(t, "2026-01-01T17:30:00Z", result.PreviousRuns[1].Timestamp)
+	})
+
+	t.Run("prev from a relative time", func(t *testing.T) {
+		pc := newPrevCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"0 * * * *", "-c", "1", "--from", "tomorrow 09:30", "--timezone", "UTC", "--json"})
+
+		require.NoError(t, pc.Execute())
+		var result PrevResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+		assert.Equal(t, tomorrow+"T09:30:00Z", result.From)
+		assert.Equal(t, tomorrow+"T09:00:00Z", result.PreviousRuns[0].Timestamp)
+	})
+
+	t.Run("prev with the quartz dialect", func(t *testing.T) {
+		pc := newPrevCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"0 0 12 L * ?", "--dialect", "quartz", "-c", "1", "--from", "2026-01-05T09:00:00Z", "--timezone", "UTC"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "1. 2025-12-31 12:00:00 UTC")
+	})
+
+	t.Run("prev with a schedule that never ran", func(t *testing.T) {
+		pc := newPrevCommand()
+		buf := new(bytes.Buffer)
+		pc.SetOut(buf)
+		pc.SetArgs([]string{"0 0 30 2 *"})
+
+		err := pc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "No previous runs")
+	})
+
+	t.Run("prev errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			args []string
+			want string
+		}{
+			{"invalid expression", []string{"invalid"}, "failed to calculate previous runs"},
+			{"count too low", []string{"* * * * *", "-c", "0"}, "at least"},
+			{"count too high", []string{"* * * * *", "-c", "101"}, "at most"},
+			{"invalid from", []string{"* * * * *", "--from", "someday"}, "invalid --from time"},
+			{"invalid timezone", []string{"* * * * *", "--timezone", "Mars/Olympus"}, "invalid timezone"},
+			{"invalid dialect", []string{"* * * * *", "--dialect", "unknown"}, "unknown dialect"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pc := newPrevCommand()
+				pc.SetOut(new(bytes.Buffer))
+				pc.SetErr(new(bytes.Buffer))
+				pc.SetArgs(tt.args)
+
+				err := pc.Execute()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.want)
+			})
+		}
+	})
+}
+
+func TestFormatTimeAgo(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		past time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "less than a minute ago"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-45 * time.Minute), "45 minutes ago"},
+		{now.Add(-time.Hour), "1 hour ago"},
+		{now.Add(-7 * time.Hour), "7 hours ago"},
+		{now.Add(-24 * time.Hour), "1 day ago"},
+		{now.Add(-72 * time.Hour), "3 days ago"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatTimeAgo(now, tt.past))
+	}
+}
@@ -1,8 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/hzerrad/cronkit/internal/config"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/hzerrad/cronkit/internal/notify"
 	"github.com/spf13/cobra"
 )
 
@@ -11,6 +21,17 @@ var (
 	commit  = "none"
 	date    = "unknown"
 	locale  string // Global locale flag for symbol parsing
+
+	timeFormatFlag string // Global --time-format flag
+	configPath     string // Global --config flag
+	cacheStats     bool   // Global --cache-stats debug flag
+	noColor        bool   // Global --no-color flag
+	themeName      string // Global --theme flag
+
+	notifications notify.Config // "notifications" section of the config file
+
+	timeout       time.Duration      // Global --timeout flag
+	cancelTimeout context.CancelFunc // Releases the --timeout timer
 )
 
 var rootCmd = &cobra.Command{
@@ -31,9 +52,31 @@ Features:
   - Generate documentation (Markdown, HTML, JSON)
   - Calculate statistics and analyze concurrency budgets
   - Compare crontabs semantically
+  - Run a job once the way cron would, or schedule a crontab's jobs
 
-Read-only and safe by design - never executes or modifies crontabs.`,
+Read-only and safe by design - never executes or modifies crontabs unless
+explicitly asked to (edit, run, daemon).`,
 	Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+		if _, err := parseTimeFormat(timeFormatFlag); err != nil {
+			return err
+		}
+		if _, err := color.LookupTheme(themeName); err != nil {
+			return fmt.Errorf("invalid --theme value: %w", err)
+		}
+		return applyTimeout(cmd)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
+		if cacheStats {
+			printCacheStats(cmd.ErrOrStderr())
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Default behavior when no subcommand is specified
 		_ = cmd.Help()
@@ -42,12 +85,78 @@ Read-only and safe by design - never executes or modifies crontabs.`,
 
 // Execute runs the root command
 func Execute() error {
+	// Subcommands register themselves in init, so their flags exist by now
+	registerCompletions(rootCmd)
 	return rootCmd.Execute()
 }
 
 func init() {
 	// Global flags - these apply to all subcommands
-	rootCmd.PersistentFlags().StringVar(&locale, "locale", "en", "Locale for parsing day/month names (default: 'en', e.g., 'en', 'fr', 'es')")
+	rootCmd.PersistentFlags().StringVar(&locale, "locale", "en", "Locale of schedule descriptions and day/month names: en (default), es, fr, de or pt")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file of default settings (defaults to $CRONKIT_CONFIG, else ~/.config/cronkit/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&timeFormatFlag, "time-format", timeFormat24Hour, "How text output writes times: 24h (default), 12h (AM/PM) or a Go time layout such as '02 Jan 3:04 PM'")
+	rootCmd.PersistentFlags().BoolVar(&cacheStats, "cache-stats", false, "Print parse and schedule cache statistics to stderr when the command ends (debugging)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Never color output (also when NO_COLOR is set); colors are otherwise used on terminals")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", color.DefaultTheme, "Colors of terminal output: "+strings.Join(color.Themes(), " or "))
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Give up computing runs after this long, e.g. 30s, for huge crontabs or horizons (default: no limit)")
+}
+
+// applyTimeout bounds the context of cmd by --timeout
+func applyTimeout(cmd *cobra.Command) error {
+	if timeout < 0 {
+		return fmt.Errorf("invalid --timeout value %s (must not be negative)", timeout)
+	}
+	if timeout == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeoutCause(cmd.Context(), timeout, fmt.Errorf("timed out after %s (--timeout)", timeout))
+	cmd.SetContext(ctx)
+	cancelTimeout = cancel
+	return nil
+}
+
+// contextErr returns why the context of cmd ended, e.g. "timed out after 5s
+// (--timeout)", or nil while it has not
+func contextErr(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	if ctx == nil || ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
+
+// printCacheStats writes the hit counts of the process-wide parse and
+// schedule caches
+func printCacheStats(w io.Writer) {
+	for _, c := range []struct {
+		name  string
+		stats cronx.CacheStats
+	}{
+		{"Parse cache", cronx.ParseCacheStats()},
+		{"Schedule cache", cronx.ScheduleCacheStats()},
+	} {
+		_, _ = fmt.Fprintf(w, "%s: %d hits, %d misses (%.1f%% hit rate)\n", c.name, c.stats.Hits, c.stats.Misses, c.stats.HitRate()*100)
+	}
+}
+
+// applyConfig sets the flags of cmd that were not given on the command line
+// from CRONKIT_* environment variables and the config file, and keeps the
+// notification settings of the config file
+func applyConfig(cmd *cobra.Command) error {
+	path, required := configPath, configPath != ""
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			return err
+		}
+		required = os.Getenv(config.EnvConfig) != ""
+	}
+	cfg, err := config.Load(path, required)
+	if err != nil {
+		return err
+	}
+	notifications = cfg.Notifications
+	return config.Merge(cmd.Flags(), cmd.Name(), cfg, path)
 }
 
 // GetLocale returns the current locale setting
@@ -58,6 +167,89 @@ func GetLocale() string {
 	return locale
 }
 
+// Presets of the --time-format flag; any other value is a Go time layout
+const (
+	timeFormat24Hour = "24h"
+	timeFormat12Hour = "12h"
+)
+
+// timeFormat is how text output writes times, selected with --time-format
+type timeFormat struct {
+	timestamp string      // Layout of run timestamps
+	clock     string      // Layout of times of day (timeline headers)
+	zone      bool        // Whether the time zone abbreviation follows run timestamps
+	human     human.Clock // Clock of schedule descriptions
+}
+
+// parseTimeFormat resolves a --time-format value. Custom layouts are used
+// verbatim and switch descriptions to the 12-hour clock when they contain PM.
+func parseTimeFormat(value string) (timeFormat, error) {
+	switch strings.ToLower(value) {
+	case "", timeFormat24Hour:
+		return timeFormat{timestamp: "2006-01-02 15:04:05", clock: "15:04", zone: true, human: human.Clock24Hour}, nil
+	case timeFormat12Hour:
+		return timeFormat{timestamp: "2006-01-02 3:04:05 PM", clock: "3:04 PM", zone: true, human: human.Clock12Hour}, nil
+	}
+
+	// A layout without any element of the reference time formats any time to itself
+	probe := time.Date(2001, time.November, 22, 16, 7, 8, 0, time.UTC)
+	if probe.Format(value) == value {
+		return timeFormat{}, fmt.Errorf("invalid --time-format value %q: expected 24h, 12h or a Go time layout such as '2006-01-02 3:04 PM'", value)
+	}
+	if strings.Contains(value, "PM") || strings.Contains(value, "pm") {
+		return timeFormat{timestamp: value, clock: "3:04 PM", human: human.Clock12Hour}, nil
+	}
+	return timeFormat{timestamp: value, clock: "15:04", human: human.Clock24Hour}, nil
+}
+
+// getTimeFormat returns the current --time-format setting. The root command
+// validates the flag, so invalid values fall back to the 24-hour clock.
+func getTimeFormat() timeFormat {
+	f, err := parseTimeFormat(timeFormatFlag)
+	if err != nil {
+		f, _ = parseTimeFormat(timeFormat24Hour)
+	}
+	return f
+}
+
+// stamp formats a run time in the layout of the time format
+func (f timeFormat) stamp(t time.Time) string {
+	if f.zone {
+		return t.Format(f.timestamp + " MST")
+	}
+	return t.Format(f.timestamp)
+}
+
+// newHumanizer returns a humanizer for the --locale and --time-format settings
+func newHumanizer() human.Humanizer {
+	return human.NewHumanizerWithOptions(human.Options{Locale: GetLocale(), Clock: getTimeFormat().human})
+}
+
+// secondsMode returns the parser seconds mode for commands with a --seconds flag.
+// Six-field expressions are always auto-detected; --seconds makes the seconds field mandatory.
+func secondsMode(required bool) cronx.SecondsMode {
+	if required {
+		return cronx.SecondsRequired
+	}
+	return cronx.SecondsOptional
+}
+
+// Usage of the --dialect and --jenkins-job flags
+const (
+	dialectUsage    = "Cron dialect of the expression: standard (vixie), quartz (L, W, #, ?), jenkins (H), aws (EventBridge cron(...) and rate(...)), spring, node-cron or cron4j"
+	jenkinsJobUsage = "Full name of the Jenkins job that H tokens are hashed from (jenkins dialect), e.g. 'folder/nightly'"
+)
+
+// parserOptions returns the parser options for commands with --seconds,
+// --dialect and --jenkins-job flags
+func parserOptions(seconds bool, dialect, jenkinsJob string) (cronx.ParserOptions, error) {
+	d, err := cronx.ParseDialect(dialect)
+	if err != nil {
+		return cronx.ParserOptions{}, fmt.Errorf("invalid --dialect value: %w", err)
+	}
+	return cronx.ParserOptions{Seconds: secondsMode(seconds), Dialect: d, HashKey: jenkinsJob}, nil
+}
+
 // SetOutput sets the output and error writers for the root command
 func SetOutput(out, err interface{}) {
 	if w, ok := out.(interface{ Write([]byte) (int, error) }); ok {
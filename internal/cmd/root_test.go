@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -74,6 +80,43 @@ func TestGetLocale(t *testing.T) {
 	})
 }
 
+func TestParseTimeFormat(t *testing.T) {
+	at := time.Date(2025, 3, 7, 14, 30, 0, 0, time.UTC)
+
+	t.Run("24h is the default", func(t *testing.T) {
+		f, err := parseTimeFormat("")
+		require.NoError(t, err)
+		assert.Equal(t, "2025-03-07 14:30:00 UTC", f.stamp(at))
+		assert.Equal(t, human.Clock24Hour, f.human)
+	})
+
+	t.Run("12h uses AM/PM", func(t *testing.T) {
+		f, err := parseTimeFormat("12h")
+		require.NoError(t, err)
+		assert.Equal(t, "2025-03-07 2:30:00 PM UTC", f.stamp(at))
+		assert.Equal(t, "3:04 PM", f.clock)
+		assert.Equal(t, human.Clock12Hour, f.human)
+	})
+
+	t.Run("custom layouts are used verbatim", func(t *testing.T) {
+		f, err := parseTimeFormat("Mon 02 Jan 3:04 pm")
+		require.NoError(t, err)
+		assert.Equal(t, "Fri 07 Mar 2:30 pm", f.stamp(at))
+		assert.Equal(t, human.Clock12Hour, f.human)
+
+		f, err = parseTimeFormat("02/01 15:04")
+		require.NoError(t, err)
+		assert.Equal(t, "07/03 14:30", f.stamp(at))
+		assert.Equal(t, human.Clock24Hour, f.human)
+	})
+
+	t.Run("layouts without time elements are rejected", func(t *testing.T) {
+		_, err := parseTimeFormat("ampm")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --time-format value")
+	})
+}
+
 func TestSetOutput(t *testing.T) {
 	t.Run("SetOutput with valid writers", func(t *testing.T) {
 		outBuf := new(bytes.Buffer)
@@ -92,3 +135,78 @@ func TestSetOutput(t *testing.T) {
 		// Should not panic
 	})
 }
+
+func TestApplyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("output: json\ntimeline-width: 120\ntimezone: UTC\n"), 0o644))
+	t.Cleanup(func() { configPath = "" })
+
+	t.Run("config file sets unset flags", func(t *testing.T) {
+		configPath = path
+		tc := newTimelineCommand()
+		require.NoError(t, tc.ParseFlags([]string{"--width", "60"}))
+
+		require.NoError(t, applyConfig(tc.Command))
+		assert.True(t, tc.json)
+		assert.Equal(t, 60, tc.width)
+		assert.Equal(t, "UTC", tc.timezone)
+	})
+
+	t.Run("explicit config file must exist", func(t *testing.T) {
+		configPath = filepath.Join(t.TempDir(), "missing.yaml")
+		tc := newTimelineCommand()
+		require.NoError(t, tc.ParseFlags(nil))
+
+		assert.Error(t, applyConfig(tc.Command))
+	})
+
+	t.Run("default config file is optional", func(t *testing.T) {
+		configPath = ""
+		t.Setenv("CRONKIT_CONFIG", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		tc := newTimelineCommand()
+		require.NoError(t, tc.ParseFlags(nil))
+
+		require.NoError(t, applyConfig(tc.Command))
+		assert.False(t, tc.json)
+	})
+}
+
+func TestPrintCacheStats(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printCacheStats(buf)
+	assert.Contains(t, buf.String(), "Parse cache: ")
+	assert.Contains(t, buf.String(), "Schedule cache: ")
+	assert.Contains(t, buf.String(), "% hit rate)\n")
+}
+
+func TestApplyTimeout(t *testing.T) {
+	t.Cleanup(func() {
+		timeout = 0
+		cancelTimeout = nil
+	})
+
+	t.Run("no limit by default", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+		require.NoError(t, applyTimeout(cmd))
+		_, ok := cmd.Context().Deadline()
+		assert.False(t, ok)
+		assert.NoError(t, contextErr(cmd))
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		timeout = -time.Second
+		assert.ErrorContains(t, applyTimeout(&cobra.Command{}), "invalid --timeout value -1s")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		timeout = time.Millisecond
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+		require.NoError(t, applyTimeout(cmd))
+		<-cmd.Context().Done()
+		assert.EqualError(t, contextErr(cmd), "timed out after 1ms (--timeout)")
+		cancelTimeout()
+	})
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/history"
+	"github.com/hzerrad/cronkit/internal/runner"
+	"github.com/hzerrad/cronkit/internal/state"
+	"github.com/spf13/cobra"
+)
+
+type RunCommand struct {
+	*cobra.Command
+	file   string
+	system bool
+	stdin  bool
+	dryRun bool
+	record bool
+	json   bool
+}
+
+func newRunCommand() *RunCommand {
+	rc := &RunCommand{}
+	rc.Command = &cobra.Command{
+		Use:   "run <job>",
+		Short: "Run a crontab job once, the way cron would",
+		Long: `Run one job of a crontab now, in an environment that replicates cron's.
+
+The job is selected by its line number or by the name given with a
+"# cronkit:name=" directive. Like cron, cronkit passes the command to $SHELL
+(default: /bin/sh) with -c, from $HOME, with only HOME, LOGNAME, USER, SHELL
+and PATH=/usr/bin:/bin set, plus the variables the crontab sets before the
+job. The job has no terminal, and the text after the first unescaped % of the
+command is its standard input.
+
+The job's output is streamed as it runs, and cronkit exits with the job's
+exit code. With --record, the run is added to the run log of the state
+directory, shown by 'cronkit history'; a crontab line can then run its job
+through cronkit to record it. With --dry-run, nothing is run: the command,
+working directory, standard input and environment are printed instead.
+
+Jobs of system crontabs are run as the current user, not as the user of
+their crontab line.
+
+Examples:
+  cronkit run 3 --file jobs.cron             # Run the job on line 3
+  cronkit run backup                         # Run the job named "backup"
+  cronkit run backup --dry-run               # Show what would be run
+  cronkit run backup --record                # Run and record in the history
+  cronkit run 2 --file /etc/crontab --dry-run --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: rc.runRun,
+	}
+
+	rc.Flags().StringVarP(&rc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	rc.Flags().BoolVar(&rc.system, "system", false, systemUsage)
+	rc.Flags().BoolVar(&rc.stdin, "stdin", false, "Read crontab from standard input")
+	rc.Flags().BoolVar(&rc.dryRun, "dry-run", false, "Print what would be run, with the resolved environment, without running it")
+	rc.Flags().BoolVar(&rc.record, "record", false, "Record the run in the run log of the state directory")
+	rc.Flags().BoolVarP(&rc.json, "json", "j", false, "Output the --dry-run plan in JSON format")
+	return rc
+}
+
+func init() {
+	rootCmd.AddCommand(newRunCommand().Command)
+}
+
+func (rc *RunCommand) runRun(_ *cobra.Command, args []string) error {
+	if rc.json && !rc.dryRun {
+		return fmt.Errorf("--json can only be used with --dry-run")
+	}
+
+	jobs, err := rc.readJobs()
+	if err != nil {
+		return err
+	}
+	job, err := selectJob(jobs, args[0])
+	if err != nil {
+		return err
+	}
+
+	base, err := crontab.DefaultEnvironment("")
+	if err != nil {
+		return fmt.Errorf("failed to resolve job environment: %w", err)
+	}
+	plan := runner.NewPlan(job, base)
+
+	if rc.dryRun {
+		if rc.json {
+			return rc.outputPlanJSON(job, plan)
+		}
+		rc.outputPlanText(job, plan)
+		return nil
+	}
+
+	var store *state.Store
+	if rc.record {
+		if store, err = state.OpenDefault(); err != nil {
+			return err
+		}
+	}
+
+	rc.warnUser(job)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	result, err := plan.Run(ctx, rc.OutOrStdout(), rc.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	rc.PrintErrf("Exit code: %d (took %s)\n", result.ExitCode, result.Duration().Round(time.Millisecond))
+	if store != nil {
+		if err := history.Append(store, runRecord(job, time.Time{}, result)); err != nil {
+			return fmt.Errorf("failed to record run: %w", err)
+		}
+	}
+	if result.ExitCode != 0 {
+		osExit(exitCodeOf(result))
+	}
+	return nil
+}
+
+// readJobs reads the crontab of the job. Priority: --file > --stdin > user crontab
+func (rc *RunCommand) readJobs() ([]*crontab.Job, error) {
+	reader := newCrontabReader(rc.system)
+	switch {
+	case rc.file != "":
+		jobs, err := reader.ReadFile(rc.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab file %s: %w", rc.file, err)
+		}
+		return jobs, nil
+	case rc.stdin:
+		jobs, err := reader.ReadStdin()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		return jobs, nil
+	default:
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		return jobs, nil
+	}
+}
+
+// selectJob returns the job on the line given by selector, or the job whose
+// cronkit:name directive is selector
+func selectJob(jobs []*crontab.Job, selector string) (*crontab.Job, error) {
+	if line, err := strconv.Atoi(selector); err == nil {
+		for _, job := range jobs {
+			if job.LineNumber == line {
+				return job, nil
+			}
+		}
+		return nil, fmt.Errorf("no job on line %d", line)
+	}
+
+	var found *crontab.Job
+	for _, job := range jobs {
+		if job.Metadata.Name != selector {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("several jobs are named %q (lines %d and %d); select one by line number", selector, found.LineNumber, job.LineNumber)
+		}
+		found = job
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no job named %q (select a job by line number or cronkit:name directive)", selector)
+	}
+	return found, nil
+}
+
+// warnUser notes when a job of a system crontab belongs to another user
+func (rc *RunCommand) warnUser(job *crontab.Job) {
+	if job.User == "" {
+		return
+	}
+	if current, err := user.Current(); err == nil && current.Username == job.User {
+		return
+	}
+	rc.PrintErrf("Note: line %d runs as %s under cron; running as the current user\n", job.LineNumber, job.User)
+}
+
+// exitCodeOf returns the exit code cronkit exits with for a failed run: the
+// job's, or 1 when it was killed by a signal
+func exitCodeOf(result runner.Result) int {
+	if result.ExitCode < 0 {
+		return 1
+	}
+	return result.ExitCode
+}
+
+func (rc *RunCommand) outputPlanText(job *crontab.Job, plan runner.Plan) {
+	rc.Printf("Line %d: %s %s\n", job.LineNumber, job.Expression, job.Command)
+	rc.Printf("Would run: %s -c %s\n", plan.Shell, shellQuote(plan.Command))
+	rc.Printf("Directory: %s\n", plan.Dir)
+	if plan.Stdin != "" {
+		rc.Printf("Standard input: %q\n", plan.Stdin)
+	}
+	if job.User != "" {
+		rc.Printf("User: %s (run as the current user)\n", job.User)
+	}
+	rc.Println("Environment:")
+	for _, v := range plan.Env {
+		rc.Printf("  %s\n", v)
+	}
+}
+
+// RunPlanJSON is the JSON output of run --dry-run
+type RunPlanJSON struct {
+	LineNumber  int               `json:"lineNumber"`
+	Expression  string            `json:"expression"`
+	Command     string            `json:"command"`
+	User        string            `json:"user,omitempty"`
+	Shell       string            `json:"shell"`
+	Run         string            `json:"run"`
+	Stdin       string            `json:"stdin,omitempty"`
+	Directory   string            `json:"directory"`
+	Environment map[string]string `json:"environment"`
+}
+
+func (rc *RunCommand) outputPlanJSON(job *crontab.Job, plan runner.Plan) error {
+	env := make(map[string]string, len(plan.Env))
+	for _, v := range plan.Env {
+		name, value, _ := strings.Cut(v, "=")
+		env[name] = value
+	}
+
+	encoder := json.NewEncoder(rc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(RunPlanJSON{
+		LineNumber:  job.LineNumber,
+		Expression:  job.Expression,
+		Command:     job.Command,
+		User:        job.User,
+		Shell:       plan.Shell,
+		Run:         plan.Command,
+		Stdin:       plan.Stdin,
+		Directory:   plan.Dir,
+		Environment: env,
+	}); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// shellQuote quotes s as a single shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jobs.cron")
+	require.NoError(t, os.WriteFile(file, []byte("GREETING=hello\n# cronkit:name=greet\n*/5 * * * * echo $GREETING\n0 1 * * * exit 3\n0 2 * * * cat%line one%line two\n"), 0o644))
+
+	t.Run("runs the job by name", func(t *testing.T) {
+		rc := newRunCommand()
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		rc.SetOut(stdout)
+		rc.SetErr(stderr)
+		rc.SetArgs([]string{"greet", "--file", file})
+
+		require.NoError(t, rc.Execute())
+		assert.Equal(t, "hello\n", stdout.String())
+		assert.Contains(t, stderr.String(), "Exit code: 0")
+	})
+
+	t.Run("exits with the job's exit code", func(t *testing.T) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		rc := newRunCommand()
+		rc.SetOut(new(bytes.Buffer))
+		stderr := new(bytes.Buffer)
+		rc.SetErr(stderr)
+		rc.SetArgs([]string{"4", "--file", file})
+
+		require.NoError(t, rc.Execute())
+		assert.Equal(t, 3, exitCode)
+		assert.Contains(t, stderr.String(), "Exit code: 3")
+	})
+
+	t.Run("dry run prints the plan", func(t *testing.T) {
+		rc := newRunCommand()
+		buf := new(bytes.Buffer)
+		rc.SetOut(buf)
+		rc.SetArgs([]string{"5", "--file", file, "--dry-run"})
+
+		require.NoError(t, rc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "Would run: /bin/sh -c 'cat'")
+		assert.Contains(t, output, `Standard input: "line one\nline two"`)
+		assert.Contains(t, output, "  GREETING=hello\n")
+		assert.Contains(t, output, "  PATH=/usr/bin:/bin\n")
+	})
+
+	t.Run("dry run JSON", func(t *testing.T) {
+		rc := newRunCommand()
+		buf := new(bytes.Buffer)
+		rc.SetOut(buf)
+		rc.SetArgs([]string{"greet", "--file", file, "--dry-run", "--json"})
+
+		require.NoError(t, rc.Execute())
+		var plan RunPlanJSON
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &plan))
+		assert.Equal(t, 3, plan.LineNumber)
+		assert.Equal(t, "echo $GREETING", plan.Run)
+		assert.Equal(t, "hello", plan.Environment["GREETING"])
+	})
+
+	t.Run("unknown jobs", func(t *testing.T) {
+		for _, selector := range []string{"1", "nightly"} {
+			rc := newRunCommand()
+			rc.SetOut(new(bytes.Buffer))
+			rc.SetErr(new(bytes.Buffer))
+			rc.SetArgs([]string{selector, "--file", file})
+			assert.Error(t, rc.Execute(), selector)
+		}
+	})
+
+	t.Run("json requires dry run", func(t *testing.T) {
+		rc := newRunCommand()
+		rc.SetOut(new(bytes.Buffer))
+		rc.SetErr(new(bytes.Buffer))
+		rc.SetArgs([]string{"greet", "--file", file, "--json"})
+		assert.Error(t, rc.Execute())
+	})
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+// outputSchemaUsage is the help text of the --output-schema flag of the
+// commands with a JSON schema
+const outputSchemaUsage = "Print the JSON schema of the command's JSON output and exit"
+
+// SchemaCommand wraps cobra.Command with schema-specific functionality
+type SchemaCommand struct {
+	*cobra.Command
+}
+
+func init() {
+	rootCmd.AddCommand(newSchemaCommand().Command)
+}
+
+// newSchemaCommand creates a fresh schema command instance
+func newSchemaCommand() *SchemaCommand {
+	sc := &SchemaCommand{}
+	sc.Command = &cobra.Command{
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: schema.Commands(),
+		RunE:      sc.runSchema,
+		Use:       "schema [command]",
+		Short:     "Print the JSON schema of a command's JSON output",
+		Long: fmt.Sprintf(`Print the JSON schema (draft 2020-12) of the JSON output of a command, for
+validating it in scripts and CI pipelines. Without a command, list the
+commands with a schema.
+
+Every JSON payload has a "schemaVersion" field, currently %d. It is raised
+when a field is removed, renamed or changes meaning; new fields may be added
+within a version. The same schema is printed by the command's
+--output-schema flag.
+
+Commands: %s
+
+Examples:
+  cronkit schema check
+  cronkit schema next > next.schema.json
+  cronkit check --output-schema`, schema.Version, strings.Join(schema.Commands(), ", ")),
+	}
+	return sc
+}
+
+func (sc *SchemaCommand) runSchema(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		for _, command := range schema.Commands() {
+			sc.Println(command)
+		}
+		return nil
+	}
+	return writeSchema(sc.OutOrStdout(), args[0])
+}
+
+// writeSchema writes the JSON schema of a command's JSON output
+func writeSchema(w io.Writer, command string) error {
+	data, err := schema.Get(command)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/schema"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, error) {
+		sc := newSchemaCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetErr(new(bytes.Buffer))
+		sc.SetArgs(args)
+		err := sc.Execute()
+		return buf.String(), err
+	}
+
+	t.Run("schema command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"schema"})
+		assert.NoError(t, err)
+		assert.Equal(t, "schema", cmd.Name())
+	})
+
+	t.Run("lists commands without arguments", func(t *testing.T) {
+		output, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, "check\ndiff\ndoc\nnext\nstats\ntimeline\n", output)
+	})
+
+	t.Run("prints a command's schema", func(t *testing.T) {
+		output, err := run(t, "check")
+		require.NoError(t, err)
+		expected, err := schema.Get("check")
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), output)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, err := run(t, "eq")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no JSON schema for "eq"`)
+	})
+}
+
+func TestOutputSchema(t *testing.T) {
+	oldExit := osExit
+	osExit = func(int) {}
+	defer func() { osExit = oldExit }()
+
+	file := createTempFile(t, "# Backups\n0 2 * * * /usr/bin/backup.sh\n*/15 * * * * /usr/bin/poll.sh\n")
+	newFile := createTempFile(t, "0 3 * * * /usr/bin/backup.sh\n")
+
+	commands := []struct {
+		name    string
+		command func() *cobra.Command
+		args    []string
+	}{
+		{"check", func() *cobra.Command { return newCheckCommand().Command }, []string{"--file", file, "--json"}},
+		{"next", func() *cobra.Command { return newNextCommand().Command }, []string{"--file", file, "--json"}},
+		{"timeline", func() *cobra.Command { return newTimelineCommand().Command }, []string{"--file", file, "--json"}},
+		{"stats", func() *cobra.Command { return newStatsCommand().Command }, []string{"--file", file, "--json"}},
+		{"diff", func() *cobra.Command { return newDiffCommand().Command }, []string{"--old-file", file, "--new-file", newFile, "--json"}},
+		{"doc", func() *cobra.Command { return newDocCommand().Command }, []string{"--file", file, "--format", "json"}},
+	}
+
+	execute := func(t *testing.T, cmd *cobra.Command, args ...string) []byte {
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+		return buf.Bytes()
+	}
+
+	for _, tc := range commands {
+		t.Run(tc.name+" --output-schema prints the schema", func(t *testing.T) {
+			output := execute(t, tc.command(), "--output-schema")
+			expected, err := schema.Get(tc.name)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(output))
+		})
+
+		t.Run(tc.name+" JSON output has the schema's version and required fields", func(t *testing.T) {
+			var payload map[string]interface{}
+			require.NoError(t, json.Unmarshal(execute(t, tc.command(), tc.args...), &payload))
+			assert.Equal(t, float64(schema.Version), payload[schema.VersionField(tc.name)])
+
+			data, err := schema.Get(tc.name)
+			require.NoError(t, err)
+			var def struct {
+				Required []string
+				OneOf    []struct{ Required []string }
+			}
+			require.NoError(t, json.Unmarshal(data, &def))
+			required := def.Required
+			if len(def.OneOf) > 0 {
+				// The crontab form of next's output
+				required = def.OneOf[len(def.OneOf)-1].Required
+			}
+			require.NotEmpty(t, required)
+			for _, field := range required {
+				assert.Contains(t, payload, field)
+			}
+		})
+	}
+}
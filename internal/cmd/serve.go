@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/api"
+	"github.com/hzerrad/cronkit/internal/debug"
+	"github.com/spf13/cobra"
+)
+
+// ServeCommand wraps cobra.Command with serve functionality
+type ServeCommand struct {
+	*cobra.Command
+	port           int
+	address        string
+	requestTimeout time.Duration
+	allowOrigin    string
+	debugListen    string
+	openAPI        bool
+}
+
+func newServeCommand() *ServeCommand {
+	sc := &ServeCommand{}
+	sc.Command = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve explain, next, check, list and timeline over an HTTP JSON API",
+		Long: `Serve cronkit over an HTTP JSON API, so internal tools and web UIs can call it
+without spawning processes.
+
+Requests and responses are the documents read and written by 'cronkit exec':
+
+  POST /v1/exec        a request naming its command
+  POST /v1/{command}   a request for explain, next, check, list or timeline,
+                       without the command field
+  GET  /openapi.json   the OpenAPI 3.1 description of the API
+  GET  /healthz        "ok" while the server is up
+
+Successful requests are answered with 200 OK, malformed ones with 400,
+unknown commands with 404 and failed commands (e.g. an invalid expression,
+or a request running past --request-timeout) with 422.
+
+The server listens on localhost unless --address says otherwise; it has no
+authentication, so put it behind a proxy before exposing it. It stops on
+SIGINT or SIGTERM, letting requests in flight complete.
+
+Examples:
+  cronkit serve
+  cronkit serve --port 9000 --address 0.0.0.0
+  cronkit serve --allow-origin https://cron-ui.example.com
+  cronkit serve --openapi > openapi.json
+  curl -s localhost:8080/v1/explain -d '{"expression": "0 9 * * 1-5"}'`,
+		RunE: sc.runServe,
+		Args: cobra.NoArgs,
+	}
+
+	sc.Flags().IntVarP(&sc.port, "port", "p", 8080, "Port to listen on (0 picks a free port)")
+	sc.Flags().StringVar(&sc.address, "address", "localhost", "Address to listen on ('0.0.0.0' for every interface)")
+	sc.Flags().DurationVar(&sc.requestTimeout, "request-timeout", 30*time.Second, "Time a request may run before failing (0: no limit)")
+	sc.Flags().StringVar(&sc.allowOrigin, "allow-origin", "", "Let browser clients on this origin call the API (CORS), e.g. 'https://ui.example.com' or '*'")
+	sc.Flags().StringVar(&sc.debugListen, "debug-listen", "", "Serve pprof and internal metrics on this address (e.g., 'localhost:6060')")
+	sc.Flags().BoolVar(&sc.openAPI, "openapi", false, "Print the OpenAPI description of the API and exit")
+
+	return sc
+}
+
+func init() {
+	rootCmd.AddCommand(newServeCommand().Command)
+}
+
+func (sc *ServeCommand) runServe(cmd *cobra.Command, _ []string) error {
+	if sc.openAPI {
+		spec, err := api.OpenAPI()
+		if err != nil {
+			return err
+		}
+		sc.Println(string(spec))
+		return nil
+	}
+
+	if sc.port < 0 || sc.port > 65535 {
+		return fmt.Errorf("invalid --port %d: must be between 0 and 65535", sc.port)
+	}
+	if sc.requestTimeout < 0 {
+		return fmt.Errorf("invalid --request-timeout %s: must not be negative", sc.requestTimeout)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return sc.serve(ctx)
+}
+
+// serve serves the API (and the debug handler on --debug-listen) until ctx
+// is canceled
+func (sc *ServeCommand) serve(ctx context.Context) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(sc.address, strconv.Itoa(sc.port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	metrics := debug.NewMetrics()
+	server := &http.Server{
+		Handler: api.NewHandler(api.HandlerOptions{
+			Locale:      GetLocale(),
+			Timeout:     sc.requestTimeout,
+			AllowOrigin: sc.allowOrigin,
+			Observer:    metrics,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	servers := []*http.Server{server}
+
+	errs := make(chan error, 2)
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			errs <- fmt.Errorf("failed to serve on %s: %w", listener.Addr(), err)
+		}
+	}()
+	if sc.debugListen != "" {
+		debugServer := debug.NewServer(sc.debugListen, metrics)
+		servers = append(servers, debugServer)
+		go func() {
+			if err := debugServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("failed to serve on %s: %w", debugServer.Addr, err)
+			}
+		}()
+	}
+	sc.PrintErrf("Serving the cronkit API on http://%s\n", listener.Addr())
+
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, server := range servers {
+		_ = server.Shutdown(shutdownCtx)
+	}
+	return err
+}
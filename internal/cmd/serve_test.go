@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeCommand(t *testing.T) {
+	t.Run("prints the OpenAPI description", func(t *testing.T) {
+		sc := newServeCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetArgs([]string{"--openapi"})
+
+		require.NoError(t, sc.Execute())
+		var spec map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &spec))
+		assert.Equal(t, "3.1.0", spec["openapi"])
+		assert.Contains(t, spec["paths"], "/v1/timeline")
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		for args, want := range map[string]string{
+			"--port 70000":          "invalid --port",
+			"--request-timeout -1s": "invalid --request-timeout",
+		} {
+			sc := newServeCommand()
+			sc.SetOut(new(bytes.Buffer))
+			sc.SetErr(new(bytes.Buffer))
+			sc.SetArgs(strings.Fields(args))
+
+			err := sc.Execute()
+			require.Error(t, err, args)
+			assert.Contains(t, err.Error(), want)
+		}
+	})
+
+	t.Run("serves until the context ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stderr, stderrWriter := io.Pipe()
+		sc := newServeCommand()
+		sc.SetOut(new(bytes.Buffer))
+		sc.SetErr(stderrWriter)
+		sc.SetArgs([]string{"--port", "0"})
+
+		done := make(chan error, 1)
+		go func() { done <- sc.ExecuteContext(ctx) }()
+
+		line, err := bufio.NewReader(stderr).ReadString('\n')
+		require.NoError(t, err)
+		url := strings.TrimSpace(strings.TrimPrefix(line, "Serving the cronkit API on "))
+		require.True(t, strings.HasPrefix(url, "http://127.0.0.1:"), line)
+
+		resp, err := http.Post(url+"/v1/next", "application/json", strings.NewReader(`{"expression": "@daily", "options": {"count": 2}}`))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var body struct {
+			OK     bool `json:"ok"`
+			Result struct {
+				NextRuns []interface{} `json:"nextRuns"`
+			} `json:"result"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.True(t, body.OK)
+		assert.Len(t, body.Result.NextRuns, 2)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		sc := newServeCommand()
+		sc.SetOut(new(bytes.Buffer))
+		sc.SetErr(new(bytes.Buffer))
+		sc.SetArgs([]string{"--address", "256.0.0.1"})
+
+		err := sc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to listen")
+	})
+}
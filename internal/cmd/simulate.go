@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+type SimulateCommand struct {
+	*cobra.Command
+	file            string
+	system          bool
+	stdin           bool
+	add             []string
+	window          time.Duration
+	limit           int
+	json            bool
+	skipInvalid     bool
+	defaultDuration time.Duration
+}
+
+// SimulateLoadJSON is the load of a crontab in the JSON output of simulate
+type SimulateLoadJSON struct {
+	Jobs            int `json:"jobs"`
+	RunsPerDay      int `json:"runsPerDay"`
+	BusiestHour     int `json:"busiestHour"`
+	BusiestHourRuns int `json:"busiestHourRuns"`
+	OverlapMinutes  int `json:"overlapMinutes"`
+	MaxConcurrent   int `json:"maxConcurrent"`
+}
+
+// SimulateAddedJSON is a hypothetical job in the JSON output of simulate
+type SimulateAddedJSON struct {
+	ID         string `json:"id"`
+	Expression string `json:"expression"`
+	Command    string `json:"command"`
+}
+
+// SimulateWindowJSON is a collision window in the JSON output of simulate
+type SimulateWindowJSON struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Jobs  []string `json:"jobs"`
+}
+
+// SimulateResult is the JSON output of simulate
+type SimulateResult struct {
+	From             string                `json:"from"`
+	Window           string                `json:"window"`
+	Added            []SimulateAddedJSON   `json:"added"`
+	Before           SimulateLoadJSON      `json:"before"`
+	After            SimulateLoadJSON      `json:"after"`
+	CollisionWindows []SimulateWindowJSON  `json:"collisionWindows"`
+	Skipped          []crontab.SkippedLine `json:"skipped"`
+}
+
+func newSimulateCommand() *SimulateCommand {
+	sc := &SimulateCommand{}
+	sc.Command = &cobra.Command{
+		Use:   "simulate",
+		Short: "Show how adding jobs would change the load of a crontab",
+		Long: `Overlay hypothetical jobs on a crontab and compare its load with and
+without them, before the change is deployed:
+  - Jobs and runs per day
+  - Busiest hour of the day and the runs starting in it
+  - Overlap minutes (minutes with more than one job running) and the most
+    jobs running at once over the next --window
+  - Collision windows: the periods in which an added job runs with other jobs
+
+Each --add is a crontab line, in the layout of the crontab (with a user column
+for system crontabs), and may end with a '# cronkit:duration=15m' directive.
+Jobs are identified by their line (line-3) and added jobs by their order
+(added-1).
+
+Examples:
+  cronkit simulate --file crontab.txt --add "*/5 * * * * /usr/bin/newjob.sh"
+  cronkit simulate --add "0 2 * * * /usr/bin/backup.sh # cronkit:duration=30m"
+  cronkit simulate --file /etc/crontab --add "0 3 * * * root /usr/bin/a.sh" --window 168h
+  cronkit simulate --file crontab.txt --add "@hourly /usr/bin/poll.sh" --json`,
+		RunE: sc.runSimulate,
+		Args: cobra.NoArgs,
+	}
+
+	sc.Flags().StringVarP(&sc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	sc.Flags().BoolVar(&sc.system, "system", false, systemUsage)
+	sc.Flags().BoolVar(&sc.stdin, "stdin", false, "Read crontab from standard input")
+	sc.Flags().StringArrayVarP(&sc.add, "add", "a", nil, "Crontab line of a hypothetical job (repeatable, required)")
+	sc.Flags().DurationVar(&sc.window, "window", stats.OneDay, "Time window of the overlap analysis, from now (e.g. 1h, 24h, 168h; at most a week)")
+	sc.Flags().IntVar(&sc.limit, "limit", 10, "Number of collision windows to show in text output (0 for all)")
+	sc.Flags().BoolVarP(&sc.json, "json", "j", false, "Output in JSON format")
+	sc.Flags().BoolVar(&sc.skipInvalid, "skip-invalid", true, skipInvalidUsage)
+	sc.Flags().DurationVar(&sc.defaultDuration, "default-duration", 0, defaultDurationUsage)
+
+	return sc
+}
+
+func init() {
+	rootCmd.AddCommand(newSimulateCommand().Command)
+}
+
+func (sc *SimulateCommand) runSimulate(_ *cobra.Command, _ []string) error {
+	if len(sc.add) == 0 {
+		return fmt.Errorf("--add is required (e.g. --add \"*/5 * * * * /usr/bin/newjob.sh\")")
+	}
+	if sc.window <= 0 || sc.window > stats.MaxWindow {
+		return fmt.Errorf("invalid --window value %s (must be positive and at most %s)", sc.window, formatWindow(stats.MaxWindow))
+	}
+	if sc.limit < 0 {
+		return fmt.Errorf("invalid --limit: must not be negative")
+	}
+
+	reader := newCrontabReader(sc.system)
+	var entries []*crontab.Entry
+	var err error
+	layout := crontabLayout(sc.system)
+
+	if sc.stdin {
+		entries, err = reader.ParseStdin()
+		if err != nil {
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+	} else if sc.file != "" {
+		entries, err = reader.ParseFile(sc.file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		if !sc.system {
+			layout = crontab.DetectLayout(sc.file)
+		}
+	} else {
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		entries = crontab.JobEntries(jobs)
+	}
+
+	jobs, skipped, err := partitionEntries(entries, sc.skipInvalid)
+	if err != nil {
+		return err
+	}
+	added, err := parseAddedJobs(sc.add, layout)
+	if err != nil {
+		return err
+	}
+	if err := applyDefaultDuration(append(jobs, added...), sc.defaultDuration); err != nil {
+		return err
+	}
+
+	sim := stats.NewCalculatorWithContext(sc.Context()).Simulate(jobs, added, time.Now(), sc.window)
+	if err := contextErr(sc.Command); err != nil {
+		return err
+	}
+	if sc.json {
+		return sc.outputJSON(sim, added, skipped)
+	}
+	sc.outputText(sim, added, skipped)
+	return nil
+}
+
+// parseAddedJobs parses the --add lines, each of which must be a valid job
+func parseAddedJobs(lines []string, layout crontab.Layout) ([]*crontab.Job, error) {
+	var added []*crontab.Job
+	for _, line := range lines {
+		entries, err := crontab.ParseReaderLayout(strings.NewReader(line), layout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --add job %q: %w", line, err)
+		}
+		jobs, skipped := crontab.Partition(entries)
+		if len(skipped) > 0 {
+			return nil, fmt.Errorf("invalid --add job %q: %s", line, skipped[0].Reason)
+		}
+		if len(jobs) != 1 {
+			return nil, fmt.Errorf("invalid --add job %q: expected a crontab line with a schedule and a command", line)
+		}
+		jobs[0].LineNumber = 0
+		added = append(added, jobs[0])
+	}
+	return added, nil
+}
+
+func (sc *SimulateCommand) outputText(sim *stats.Simulation, added []*crontab.Job, skipped []crontab.SkippedLine) {
+	format := getTimeFormat()
+	noun := "jobs"
+	if len(added) == 1 {
+		noun = "job"
+	}
+
+	sc.Printf("Simulation: adding %d %s (next %s)\n", len(added), noun, formatWindow(sim.Window))
+	sc.Println(strings.Repeat("=", 50))
+
+	sc.Printf("\nAdded Jobs:\n")
+	for i, job := range added {
+		sc.Printf("  added-%d: %s %s\n", i+1, job.Expression, job.Command)
+	}
+
+	hour := func(l stats.Load) string {
+		if l.BusiestHour.RunCount == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%02d:00", l.BusiestHour.Hour)
+	}
+	rows := []struct {
+		label         string
+		before, after int
+	}{
+		{"Jobs", sim.Before.Jobs, sim.After.Jobs},
+		{"Runs per Day", sim.Before.RunsPerDay, sim.After.RunsPerDay},
+		{"Busiest Hour Runs", sim.Before.BusiestHour.RunCount, sim.After.BusiestHour.RunCount},
+		{"Overlap Minutes", sim.Before.OverlapMinutes, sim.After.OverlapMinutes},
+		{"Max Concurrent Jobs", sim.Before.MaxConcurrent, sim.After.MaxConcurrent},
+	}
+	sc.Printf("\n  %-20s %-8s %-8s %s\n", "", "Before", "After", "Change")
+	for i, row := range rows {
+		if i == 2 {
+			sc.Printf("  %-20s %-8s %s\n", "Busiest Hour", hour(sim.Before), hour(sim.After))
+		}
+		sc.Printf("  %-20s %-8d %-8d %+d\n", row.label, row.before, row.after, row.after-row.before)
+	}
+
+	if len(sim.CollisionWindows) == 0 {
+		sc.Printf("\n✓ The added jobs do not run at the same time as other jobs\n")
+	} else {
+		sc.Printf("\nCollision Windows (%d):\n", len(sim.CollisionWindows))
+		for i, w := range sim.CollisionWindows {
+			if sc.limit > 0 && i >= sc.limit {
+				sc.Printf("  ... and %d more (use --limit 0 to show all)\n", len(sim.CollisionWindows)-i)
+				break
+			}
+			sc.Printf("  %s → %s  %s\n", w.Start.Format("Mon ")+w.Start.Format(format.clock), w.End.Format(format.clock), strings.Join(w.Jobs, ", "))
+		}
+	}
+
+	printSkipped(sc.Command, skipped)
+}
+
+func (sc *SimulateCommand) outputJSON(sim *stats.Simulation, added []*crontab.Job, skipped []crontab.SkippedLine) error {
+	load := func(l stats.Load) SimulateLoadJSON {
+		return SimulateLoadJSON{
+			Jobs:            l.Jobs,
+			RunsPerDay:      l.RunsPerDay,
+			BusiestHour:     l.BusiestHour.Hour,
+			BusiestHourRuns: l.BusiestHour.RunCount,
+			OverlapMinutes:  l.OverlapMinutes,
+			MaxConcurrent:   l.MaxConcurrent,
+		}
+	}
+
+	result := SimulateResult{
+		From:             sim.Start.Format(time.RFC3339),
+		Window:           sim.Window.String(),
+		Added:            []SimulateAddedJSON{},
+		Before:           load(sim.Before),
+		After:            load(sim.After),
+		CollisionWindows: []SimulateWindowJSON{},
+		Skipped:          skipped,
+	}
+	for i, job := range added {
+		result.Added = append(result.Added, SimulateAddedJSON{ID: fmt.Sprintf("added-%d", i+1), Expression: job.Expression, Command: job.Command})
+	}
+	for _, w := range sim.CollisionWindows {
+		result.CollisionWindows = append(result.CollisionWindows, SimulateWindowJSON{
+			Start: w.Start.Format(time.RFC3339),
+			End:   w.End.Format(time.RFC3339),
+			Jobs:  w.Jobs,
+		})
+	}
+
+	encoder := json.NewEncoder(sc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, error) {
+		sc := newSimulateCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetErr(new(bytes.Buffer))
+		sc.SetArgs(args)
+		err := sc.Execute()
+		return buf.String(), err
+	}
+	crontabFile := func(t *testing.T) string {
+		return createTempFile(t, "0 2 * * * /usr/bin/backup.sh # cronkit:duration=10m\n0 */6 * * * /usr/bin/sync.sh\n")
+	}
+
+	t.Run("simulate command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"simulate"})
+		assert.NoError(t, err)
+		assert.Equal(t, "simulate", cmd.Name())
+	})
+
+	t.Run("should compare the load before and after", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--add", "*/5 2 * * * /usr/bin/newjob.sh")
+		require.NoError(t, err)
+
+		assert.Contains(t, output, "Simulation: adding 1 job (next 24h)")
+		assert.Contains(t, output, "added-1: */5 2 * * * /usr/bin/newjob.sh")
+		assert.Regexp(t, `Jobs\s+2\s+3\s+\+1`, output)
+		assert.Regexp(t, `Busiest Hour\s+00:00\s+02:00`, output)
+		assert.Regexp(t, `Max Concurrent Jobs\s+1\s+2\s+\+1`, output)
+		assert.Contains(t, output, "Collision Windows (2):")
+		assert.Contains(t, output, "line-1, added-1")
+	})
+
+	t.Run("should report jobs that run alone", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--add", "30 4 * * * /usr/bin/a.sh", "--add", "45 4 * * * /usr/bin/b.sh")
+		require.NoError(t, err)
+		assert.Contains(t, output, "adding 2 jobs")
+		assert.Contains(t, output, "do not run at the same time as other jobs")
+	})
+
+	t.Run("should output JSON", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--add", "0 2 * * * /usr/bin/newjob.sh # cronkit:duration=30m", "--json")
+		require.NoError(t, err)
+
+		var result SimulateResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, "added-1", result.Added[0].ID)
+		assert.Equal(t, 2, result.Before.Jobs)
+		assert.Equal(t, 3, result.After.Jobs)
+		assert.Equal(t, 10, result.After.OverlapMinutes)
+		require.Len(t, result.CollisionWindows, 1)
+		assert.Equal(t, []string{"line-1", "added-1"}, result.CollisionWindows[0].Jobs)
+	})
+
+	t.Run("should limit collision windows", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--add", "* * * * * /usr/bin/poll.sh", "--limit", "2")
+		require.NoError(t, err)
+		assert.Contains(t, output, "... and ")
+		assert.Contains(t, output, "more (use --limit 0 to show all)")
+	})
+
+	t.Run("should validate flags", func(t *testing.T) {
+		for want, args := range map[string][]string{
+			"--add is required":          {"--file", crontabFile(t)},
+			"invalid --add job":          {"--file", crontabFile(t), "--add", "not a job"},
+			"invalid --window value":     {"--file", crontabFile(t), "--add", "0 * * * * a", "--window", "0s"},
+			"invalid --limit":            {"--file", crontabFile(t), "--add", "0 * * * * a", "--limit", "-1"},
+			"failed to read file":        {"--file", "/nonexistent/crontab", "--add", "0 * * * * a"},
+			"invalid --add job \"@x":     {"--file", crontabFile(t), "--add", "@x /usr/bin/a"},
+			"invalid --default-duration": {"--file", crontabFile(t), "--add", "0 * * * * a", "--default-duration", "-1m"},
+		} {
+			_, err := run(t, args...)
+			require.Error(t, err, args)
+			assert.Contains(t, err.Error(), want)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/spf13/cobra"
+)
+
+// skipInvalidUsage is the help text of the --skip-invalid flag of analysis commands
+const skipInvalidUsage = "Analyze the valid jobs and list invalid lines as warnings; with --skip-invalid=false, abort on the first invalid line"
+
+// partitionEntries splits entries into valid jobs and skipped lines. Unless
+// skip is set, the first invalid line aborts the analysis.
+func partitionEntries(entries []*crontab.Entry, skip bool) ([]*crontab.Job, []crontab.SkippedLine, error) {
+	jobs, skipped := crontab.Partition(entries)
+	if !skip && len(skipped) > 0 {
+		return nil, nil, fmt.Errorf("%s (use --skip-invalid to analyze the remaining jobs)", skipped[0])
+	}
+	if skipped == nil {
+		skipped = []crontab.SkippedLine{}
+	}
+	return jobs, skipped, nil
+}
+
+// printSkipped writes the warnings section listing skipped lines, if any
+func printSkipped(cmd *cobra.Command, skipped []crontab.SkippedLine) {
+	if len(skipped) == 0 {
+		return
+	}
+	noun := "lines"
+	if len(skipped) == 1 {
+		noun = "line"
+	}
+	cmd.Printf("\n⚠ WARNING: Skipped %d invalid %s:\n", len(skipped), noun)
+	for _, s := range skipped {
+		cmd.Printf("  Line %d: %s (%s)\n", s.LineNumber, s.Line, s.Reason)
+	}
+}
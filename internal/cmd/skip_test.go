@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const skipInvalidCrontab = "0 * * * * /bin/ok.sh\n61 * * * * /bin/broken.sh\ngarbage line\n"
+
+func TestSkipInvalid(t *testing.T) {
+	file := createTempFile(t, skipInvalidCrontab)
+
+	commands := []struct {
+		name string
+		cmd  func() *cobra.Command
+		args []string
+	}{
+		{"timeline", func() *cobra.Command { return newTimelineCommand().Command }, []string{"--file", file}},
+		{"stats", func() *cobra.Command { return newStatsCommand().Command }, []string{"--file", file}},
+		{"doc", func() *cobra.Command { return newDocCommand().Command }, []string{"--file", file}},
+		{"next", func() *cobra.Command { return newNextCommand().Command }, []string{"--file", file, "-c", "1"}},
+	}
+
+	for _, tc := range commands {
+		t.Run(tc.name+" skips invalid lines by default", func(t *testing.T) {
+			cmd := tc.cmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetArgs(tc.args)
+
+			require.NoError(t, cmd.Execute())
+			output := buf.String()
+			assert.Contains(t, output, "Line 2: ")
+			assert.Contains(t, output, "61 * * * *")
+			assert.Contains(t, output, "garbage line")
+		})
+
+		t.Run(tc.name+" aborts with --skip-invalid=false", func(t *testing.T) {
+			cmd := tc.cmd()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append(tc.args, "--skip-invalid=false"))
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "line 2: invalid cron expression")
+		})
+	}
+
+	t.Run("text output lists skipped lines as warnings", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetArgs([]string{"--file", file})
+
+		require.NoError(t, sc.Execute())
+		assert.Contains(t, buf.String(), "Total Jobs: 1")
+		assert.Contains(t, buf.String(), "⚠ WARNING: Skipped 2 invalid lines:")
+		assert.Contains(t, buf.String(), "Line 3: garbage line (not a valid crontab line)")
+	})
+
+	jsonCommands := []struct {
+		name string
+		cmd  func() *cobra.Command
+		args []string
+	}{
+		{"timeline", func() *cobra.Command { return newTimelineCommand().Command }, []string{"--file", file, "--json"}},
+		{"stats", func() *cobra.Command { return newStatsCommand().Command }, []string{"--file", file, "--json"}},
+		{"next", func() *cobra.Command { return newNextCommand().Command }, []string{"--file", file, "-c", "1", "--json"}},
+	}
+	for _, tc := range jsonCommands {
+		t.Run(tc.name+" reports skipped lines in JSON", func(t *testing.T) {
+			cmd := tc.cmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetArgs(tc.args)
+			require.NoError(t, cmd.Execute())
+
+			var result struct {
+				Skipped []struct {
+					LineNumber int    `json:"lineNumber"`
+					Line       string `json:"line"`
+					Reason     string `json:"reason"`
+				} `json:"skipped"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+			require.Len(t, result.Skipped, 2)
+			assert.Equal(t, 2, result.Skipped[0].LineNumber)
+			assert.Equal(t, "garbage line", result.Skipped[1].Line)
+		})
+	}
+
+	t.Run("stats JSON keeps metrics at the top level", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetArgs([]string{"--file", file, "--json"})
+		require.NoError(t, sc.Execute())
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Contains(t, result, "TotalRunsPerDay")
+		assert.Contains(t, result, "Skipped")
+	})
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/history"
+	"github.com/hzerrad/cronkit/internal/sla"
+	"github.com/spf13/cobra"
+)
+
+type SLACommand struct {
+	*cobra.Command
+	file          string
+	system        bool
+	stdin         bool
+	history       string
+	since         time.Duration
+	lateThreshold time.Duration
+	timezone      string
+	json          bool
+}
+
+func newSLACommand() *SLACommand {
+	sc := &SLACommand{}
+	sc.Command = &cobra.Command{
+		Use:   "sla",
+		Short: "Report jobs whose recent runs breached their SLA",
+		Long: `Check the recorded runs of each job against its service level and report
+the breaches.
+
+A job's SLA is its maximum run duration, annotated in a comment on the job
+line or in the comment lines directly above it:
+
+  # @sla: 10m
+  0 2 * * * /usr/local/bin/backup.sh
+
+Two kinds of breach are reported:
+  - duration: a run took longer than the job's SLA
+  - late:     a run started more than --late-threshold after its scheduled
+              minute (checked for every job, with or without an SLA)
+
+Runs are read from the run log in the state directory, or from --history: a
+file with one JSON run record per line, e.g.
+  {"command":"/usr/local/bin/backup.sh","start":"2026-10-16T02:00:04Z","end":"2026-10-16T02:14:10Z","exitCode":0}
+Records are matched to jobs by command (and by expression, if recorded).
+
+Exit codes: 0 when no breach is found, 2 when at least one run breached its
+SLA, 1 on errors. This makes the command suitable for alerting wrappers.
+
+Examples:
+  cronkit sla --file /etc/crontab
+  cronkit sla --history runs.jsonl --since 168h
+  cronkit sla --late-threshold 5m --json`,
+		Args: cobra.NoArgs,
+		RunE: sc.runSLA,
+	}
+
+	sc.Flags().StringVarP(&sc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	sc.Flags().BoolVar(&sc.system, "system", false, systemUsage)
+	sc.Flags().BoolVar(&sc.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
+	sc.Flags().StringVar(&sc.history, "history", "", "File of JSON run records, one per line (default: the run log in the state directory)")
+	sc.Flags().DurationVar(&sc.since, "since", 24*time.Hour, "Only check runs that started within this duration")
+	sc.Flags().DurationVar(&sc.lateThreshold, "late-threshold", sla.DefaultLateThreshold, "Report runs that started later than this after their scheduled minute (0 disables)")
+	sc.Flags().StringVar(&sc.timezone, "timezone", "", "Timezone of jobs without CRON_TZ= (default: local timezone)")
+	sc.Flags().BoolVarP(&sc.json, "json", "j", false, "Output in JSON format")
+	return sc
+}
+
+func init() {
+	rootCmd.AddCommand(newSLACommand().Command)
+}
+
+func (sc *SLACommand) runSLA(_ *cobra.Command, _ []string) error {
+	if sc.since < 0 {
+		return fmt.Errorf("invalid --since value: must not be negative")
+	}
+	if sc.lateThreshold < 0 {
+		return fmt.Errorf("invalid --late-threshold value: must not be negative")
+	}
+	loc := time.Local
+	if sc.timezone != "" {
+		parsed, err := time.LoadLocation(sc.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w (use IANA timezone name like 'America/New_York' or 'UTC')", err)
+		}
+		loc = parsed
+	}
+
+	jobs, source, err := sc.readJobs()
+	if err != nil {
+		return err
+	}
+	records, err := history.ReadFile(sc.history)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	opts := sla.Options{
+		LateThreshold: sc.lateThreshold,
+		Location:      loc,
+		Scheduler:     cronx.NewSchedulerWithSeconds(cronx.SecondsNone),
+	}
+	if sc.since > 0 {
+		opts.Since = now.Add(-sc.since)
+	}
+	report, err := sla.Evaluate(jobs, records, opts)
+	if err != nil {
+		return err
+	}
+
+	if sc.json {
+		err = sc.outputJSON(report, source, opts.Since)
+	} else {
+		sc.outputText(report, source)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Breaches > 0 {
+		osExit(2)
+	}
+	return nil
+}
+
+// readJobs reads the crontab to check. Priority: --file > --stdin > user crontab
+func (sc *SLACommand) readJobs() ([]*crontab.Job, string, error) {
+	reader := newCrontabReader(sc.system)
+	switch {
+	case sc.file != "":
+		jobs, err := reader.ReadFile(sc.file)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read crontab file %s: %w", sc.file, err)
+		}
+		return jobs, sc.file, nil
+	case sc.stdin || isStdinAvailable():
+		jobs, err := reader.ReadStdin()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		return jobs, "stdin", nil
+	default:
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		return jobs, "user", nil
+	}
+}
+
+func (sc *SLACommand) outputText(report *sla.Report, source string) {
+	sc.Printf("SLA report for %s: %d run(s) checked\n", source, report.Runs)
+	if report.Breaches == 0 {
+		sc.Println("✓ No SLA breaches found")
+		return
+	}
+
+	jobs := 0
+	for _, jr := range report.Jobs {
+		if len(jr.Breaches) == 0 {
+			continue
+		}
+		jobs++
+		sc.Printf("\nLine %d: %s %s\n", jr.Job.LineNumber, jr.Job.Expression, jr.Job.Command)
+		for _, b := range jr.Breaches {
+			start := b.Run.Start.Format(time.RFC3339)
+			switch b.Kind {
+			case sla.BreachDuration:
+				sc.Printf("  ✗ Run started %s took %s (SLA: %s)\n", start, b.Duration, jr.Job.SLA)
+			case sla.BreachLate:
+				sc.Printf("  ⚠ Run scheduled %s started %s late\n", b.Scheduled.Format(time.RFC3339), b.Delay)
+			}
+		}
+	}
+	sc.Printf("\nSummary: %d breach(es) in %d job(s)\n", report.Breaches, jobs)
+}
+
+// SLABreachJSON is a breach in the JSON output of the sla command
+type SLABreachJSON struct {
+	LineNumber      int     `json:"lineNumber"`
+	Expression      string  `json:"expression"`
+	Command         string  `json:"command"`
+	Kind            string  `json:"kind"`
+	SLASeconds      float64 `json:"slaSeconds,omitempty"`
+	Scheduled       string  `json:"scheduled,omitempty"`
+	Start           string  `json:"start"`
+	End             string  `json:"end"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	DelaySeconds    float64 `json:"delaySeconds,omitempty"`
+	ExitCode        int     `json:"exitCode"`
+}
+
+func (sc *SLACommand) outputJSON(report *sla.Report, source string, since time.Time) error {
+	breaches := []SLABreachJSON{}
+	for _, jr := range report.Jobs {
+		for _, b := range jr.Breaches {
+			entry := SLABreachJSON{
+				LineNumber:      jr.Job.LineNumber,
+				Expression:      jr.Job.Expression,
+				Command:         jr.Job.Command,
+				Kind:            string(b.Kind),
+				SLASeconds:      jr.Job.SLA.Seconds(),
+				Start:           b.Run.Start.Format(time.RFC3339),
+				End:             b.Run.End.Format(time.RFC3339),
+				DurationSeconds: b.Duration.Seconds(),
+				DelaySeconds:    b.Delay.Seconds(),
+				ExitCode:        b.Run.ExitCode,
+			}
+			if !b.Scheduled.IsZero() {
+				entry.Scheduled = b.Scheduled.Format(time.RFC3339)
+			}
+			breaches = append(breaches, entry)
+		}
+	}
+
+	result := map[string]interface{}{
+		"source":               source,
+		"runs":                 report.Runs,
+		"lateThresholdSeconds": sc.lateThreshold.Seconds(),
+		"breaches":             breaches,
+	}
+	if !since.IsZero() {
+		result["since"] = since.Format(time.RFC3339)
+	}
+
+	encoder := json.NewEncoder(sc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLACommand(t *testing.T) {
+	path := createTempFile(t, "# @sla: 10m\n0 2 * * * /usr/bin/backup.sh\n30 * * * * /usr/bin/poll.sh\n")
+
+	// Runs relative to now so they fall within --since
+	start := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Hour)
+	runs := func(duration time.Duration) string {
+		return fmt.Sprintf(`{"command":"/usr/bin/backup.sh","scheduled":%q,"start":%q,"end":%q,"exitCode":0}`+"\n",
+			start.Format(time.RFC3339), start.Format(time.RFC3339), start.Add(duration).Format(time.RFC3339))
+	}
+
+	run := func(t *testing.T, args ...string) (string, int, error) {
+		oldExit := osExit
+		exitCode := 0
+		osExit = func(code int) { exitCode = code }
+		defer func() { osExit = oldExit }()
+
+		sc := newSLACommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetErr(new(bytes.Buffer))
+		sc.SetArgs(args)
+		err := sc.Execute()
+		return buf.String(), exitCode, err
+	}
+
+	t.Run("no breaches", func(t *testing.T) {
+		history := createTempFile(t, runs(5*time.Minute))
+		output, exitCode, err := run(t, "--file", path, "--history", history)
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, output, "1 run(s) checked")
+		assert.Contains(t, output, "No SLA breaches found")
+	})
+
+	t.Run("duration breach", func(t *testing.T) {
+		history := createTempFile(t, runs(25*time.Minute))
+		output, exitCode, err := run(t, "--file", path, "--history", history)
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+		assert.Contains(t, output, "Line 2: 0 2 * * * /usr/bin/backup.sh")
+		assert.Contains(t, output, "took 25m0s (SLA: 10m0s)")
+		assert.Contains(t, output, "Summary: 1 breach(es) in 1 job(s)")
+	})
+
+	t.Run("late start", func(t *testing.T) {
+		late := start.Add(30*time.Minute + 5*time.Minute)
+		history := createTempFile(t, fmt.Sprintf(`{"command":"/usr/bin/poll.sh","start":%q,"end":%q,"exitCode":0}`+"\n",
+			late.Format(time.RFC3339), late.Add(time.Minute).Format(time.RFC3339)))
+		output, exitCode, err := run(t, "--file", path, "--history", history, "--timezone", "UTC")
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+		assert.Contains(t, output, "started 5m0s late")
+
+		_, exitCode, err = run(t, "--file", path, "--history", history, "--timezone", "UTC", "--late-threshold", "10m")
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		history := createTempFile(t, runs(25*time.Minute))
+		output, exitCode, err := run(t, "--file", path, "--history", history, "--json")
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+
+		var result struct {
+			Runs     int             `json:"runs"`
+			Breaches []SLABreachJSON `json:"breaches"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, 1, result.Runs)
+		require.Len(t, result.Breaches, 1)
+		assert.Equal(t, "duration", result.Breaches[0].Kind)
+		assert.Equal(t, 600.0, result.Breaches[0].SLASeconds)
+		assert.Equal(t, 1500.0, result.Breaches[0].DurationSeconds)
+	})
+
+	t.Run("invalid history", func(t *testing.T) {
+		history := createTempFile(t, "not json\n")
+		_, _, err := run(t, "--file", path, "--history", history)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid run record")
+	})
+
+	t.Run("missing history file", func(t *testing.T) {
+		_, _, err := run(t, "--file", path, "--history", "/nonexistent/runs.jsonl")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open run history")
+	})
+
+	t.Run("state directory", func(t *testing.T) {
+		t.Setenv("CRONKIT_STATE_DIR", t.TempDir())
+		output, exitCode, err := run(t, "--file", path)
+		require.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, output, "0 run(s) checked")
+	})
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+type SlotsCommand struct {
+	*cobra.Command
+	file            string
+	system          bool
+	stdin           bool
+	duration        time.Duration
+	within          string
+	count           int
+	json            bool
+	skipInvalid     bool
+	defaultDuration time.Duration
+}
+
+// SlotJSON is a range of start times in the JSON output of slots
+type SlotJSON struct {
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	Peak       int    `json:"peak"`
+	Busy       int    `json:"busyMinutes"`
+	Expression string `json:"expression"`
+}
+
+// SlotsResult is the JSON output of slots
+type SlotsResult struct {
+	Duration string                `json:"duration"`
+	Within   string                `json:"within"`
+	Slots    []SlotJSON            `json:"slots"`
+	Skipped  []crontab.SkippedLine `json:"skipped"`
+}
+
+func newSlotsCommand() *SlotsCommand {
+	sc := &SlotsCommand{}
+	sc.Command = &cobra.Command{
+		Use:   "slots",
+		Short: "Find the quietest time of day to schedule a new job",
+		Long: `Search a week of a crontab's runs for the daily start times of a new job
+that meet the fewest other jobs, and suggest a cron expression for each of the
+quietest ranges of start times.
+
+Slots are ranked by the most jobs running at once during a run of the new job,
+then by the minutes of other jobs running during its runs over the week. The
+whole run of --duration fits in the --within window (HH:MM-HH:MM, which may
+wrap past midnight; the whole day by default). Existing jobs run for their
+'# cronkit:duration=' directive, or --default-duration.
+
+Examples:
+  cronkit slots --file crontab.txt --duration 20m --within "02:00-06:00"
+  cronkit slots --duration 1h --within "22:00-02:00" --count 3
+  cronkit slots --file /etc/crontab --duration 5m --json`,
+		RunE: sc.runSlots,
+		Args: cobra.NoArgs,
+	}
+
+	sc.Flags().StringVarP(&sc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	sc.Flags().BoolVar(&sc.system, "system", false, systemUsage)
+	sc.Flags().BoolVar(&sc.stdin, "stdin", false, "Read crontab from standard input")
+	sc.Flags().DurationVar(&sc.duration, "duration", time.Minute, "How long the new job runs (e.g. 5m, 20m, 1h)")
+	sc.Flags().StringVar(&sc.within, "within", "", "Time of day window the new job's runs must fit in, HH:MM-HH:MM (default: the whole day)")
+	sc.Flags().IntVar(&sc.count, "count", stats.DefaultSlotCount, "Number of slots to suggest")
+	sc.Flags().BoolVarP(&sc.json, "json", "j", false, "Output in JSON format")
+	sc.Flags().BoolVar(&sc.skipInvalid, "skip-invalid", true, skipInvalidUsage)
+	sc.Flags().DurationVar(&sc.defaultDuration, "default-duration", 0, defaultDurationUsage)
+
+	return sc
+}
+
+func init() {
+	rootCmd.AddCommand(newSlotsCommand().Command)
+}
+
+func (sc *SlotsCommand) runSlots(_ *cobra.Command, _ []string) error {
+	if sc.duration < time.Minute {
+		return fmt.Errorf("invalid --duration %s: must be at least 1m", sc.duration)
+	}
+	if sc.count < 1 {
+		return fmt.Errorf("invalid --count: must be at least 1")
+	}
+	window := check.Window{}
+	if sc.within != "" {
+		w, err := check.ParseWindow(sc.within)
+		if err != nil {
+			return fmt.Errorf("invalid --within: %w", err)
+		}
+		window = w
+	}
+
+	reader := newCrontabReader(sc.system)
+	var entries []*crontab.Entry
+	var err error
+
+	if sc.stdin {
+		entries, err = reader.ParseStdin()
+		if err != nil {
+			return fmt.Errorf("failed to read from stdin: %w", err)
+		}
+	} else if sc.file != "" {
+		entries, err = reader.ParseFile(sc.file)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+	} else {
+		jobs, err := reader.ReadUser()
+		if err != nil {
+			return fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		entries = crontab.JobEntries(jobs)
+	}
+
+	jobs, skipped, err := partitionEntries(entries, sc.skipInvalid)
+	if err != nil {
+		return err
+	}
+	if err := applyDefaultDuration(jobs, sc.defaultDuration); err != nil {
+		return err
+	}
+
+	slots, err := stats.NewCalculatorWithContext(sc.Context()).FindSlots(jobs, stats.SlotOptions{
+		Duration:    sc.duration,
+		WithinStart: window.Start,
+		WithinEnd:   window.End % stats.MinutesPerDay,
+		Count:       sc.count,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid --duration: %w", err)
+	}
+
+	within := "00:00-24:00"
+	if sc.within != "" {
+		within = window.String()
+	}
+	if sc.json {
+		return sc.outputJSON(slots, within, skipped)
+	}
+	sc.outputText(slots, within, skipped)
+	return nil
+}
+
+func (sc *SlotsCommand) outputText(slots []stats.Slot, within string, skipped []crontab.SkippedLine) {
+	format := getTimeFormat()
+	clock := func(minute int) string {
+		return time.Date(2000, 1, 1, minute/60, minute%60, 0, 0, time.UTC).Format(format.clock)
+	}
+
+	sc.Printf("Quietest slots for a %s job within %s (over a week)\n", formatWindow(sc.duration), within)
+	sc.Println(strings.Repeat("=", 50))
+	sc.Println()
+	for i, slot := range slots {
+		load := "no other jobs running"
+		if slot.Peak > 0 {
+			noun := "jobs"
+			if slot.Peak == 1 {
+				noun = "job"
+			}
+			load = fmt.Sprintf("up to %d other %s running, %d busy minutes a week", slot.Peak, noun, slot.Busy)
+		}
+		starts := "start at " + clock(slot.Start)
+		if slot.End != slot.Start {
+			starts = fmt.Sprintf("start %s-%s", clock(slot.Start), clock(slot.End))
+		}
+		sc.Printf("  %d. %-24s %-16s %s\n", i+1, starts, slot.Expression, load)
+	}
+
+	printSkipped(sc.Command, skipped)
+}
+
+func (sc *SlotsCommand) outputJSON(slots []stats.Slot, within string, skipped []crontab.SkippedLine) error {
+	clock := func(minute int) string {
+		return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+	}
+	result := SlotsResult{
+		Duration: sc.duration.String(),
+		Within:   within,
+		Slots:    []SlotJSON{},
+		Skipped:  skipped,
+	}
+	for _, slot := range slots {
+		result.Slots = append(result.Slots, SlotJSON{
+			Start:      clock(slot.Start),
+			End:        clock(slot.End),
+			Peak:       slot.Peak,
+			Busy:       slot.Busy,
+			Expression: slot.Expression,
+		})
+	}
+
+	encoder := json.NewEncoder(sc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
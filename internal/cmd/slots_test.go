@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotsCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, error) {
+		sc := newSlotsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetErr(new(bytes.Buffer))
+		sc.SetArgs(args)
+		err := sc.Execute()
+		return buf.String(), err
+	}
+	crontabFile := func(t *testing.T) string {
+		return createTempFile(t, "0 2 * * * /usr/bin/backup.sh # cronkit:duration=30m\n0 4 * * * /usr/bin/report.sh # cronkit:duration=1h\n")
+	}
+
+	t.Run("slots command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"slots"})
+		assert.NoError(t, err)
+		assert.Equal(t, "slots", cmd.Name())
+	})
+
+	t.Run("should suggest the quietest slots", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--duration", "20m", "--within", "02:00-06:00")
+		require.NoError(t, err)
+
+		assert.Contains(t, output, "Quietest slots for a 20m job within 02:00-06:00 (over a week)")
+		assert.Regexp(t, `1\. start 02:30-03:40\s+5 3 \* \* \*\s+no other jobs running`, output)
+		assert.Regexp(t, `2\. start 05:00-05:40\s+20 5 \* \* \*`, output)
+		assert.Contains(t, output, "up to 1 other job running")
+	})
+
+	t.Run("should output JSON", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--duration", "1h", "--within", "23:00-01:00", "--json")
+		require.NoError(t, err)
+
+		var result SlotsResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, "23:00-01:00", result.Within)
+		require.Len(t, result.Slots, 1)
+		assert.Equal(t, SlotJSON{Start: "23:00", End: "00:00", Expression: "30 23 * * *"}, result.Slots[0])
+	})
+
+	t.Run("should limit the number of slots", func(t *testing.T) {
+		output, err := run(t, "--file", crontabFile(t), "--count", "1")
+		require.NoError(t, err)
+		assert.Contains(t, output, "within 00:00-24:00")
+		assert.Contains(t, output, "1. ")
+		assert.NotContains(t, output, "2. ")
+	})
+
+	t.Run("should validate flags", func(t *testing.T) {
+		for want, args := range map[string][]string{
+			"invalid --duration 30s": {"--file", crontabFile(t), "--duration", "30s"},
+			"does not fit":           {"--file", crontabFile(t), "--duration", "2h", "--within", "02:00-03:00"},
+			"invalid --within":       {"--file", crontabFile(t), "--within", "2am"},
+			"invalid --count":        {"--file", crontabFile(t), "--count", "0"},
+			"failed to read file":    {"--file", "/nonexistent/crontab"},
+		} {
+			_, err := run(t, args...)
+			require.Error(t, err, args)
+			assert.Contains(t, err.Error(), want)
+		}
+	})
+}
@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+// snippetLibraryUsage is the help text of the --library flag
+var snippetLibraryUsage = fmt.Sprintf("Snippet library file (defaults to $%s, else %s in the config directory)", snippet.EnvSnippets, snippet.FileName)
+
+// newSnippetCommand creates the snippet command, which groups the commands
+// managing the snippet library
+func newSnippetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snippet",
+		Short: "Keep a library of named cron expressions and job lines",
+		Long: `Keep a personal library of named cron expressions and job lines, so the
+schedules you use often are written the same way in every crontab.
+
+Snippets are validated with the checks of 'cronkit check' when they are
+saved. The library is a YAML file, snippets.yaml in the config directory
+($XDG_CONFIG_HOME/cronkit or ~/.config/cronkit), or $` + snippet.EnvSnippets + `.`,
+	}
+	cmd.AddCommand(newSnippetSaveCommand().Command)
+	cmd.AddCommand(newSnippetListCommand().Command)
+	cmd.AddCommand(newSnippetInsertCommand().Command)
+	cmd.AddCommand(newSnippetRemoveCommand().Command)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newSnippetCommand())
+}
+
+// loadSnippets reads the snippet library at path, or the default one
+func loadSnippets(path string) (*snippet.Library, error) {
+	if path == "" {
+		var err error
+		if path, err = snippet.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return snippet.Load(path)
+}
+
+// completeSnippetNames completes the first argument with the names of the
+// snippets of the default library
+func completeSnippetNames(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	library, err := loadSnippets("")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, s := range library.List() {
+		if strings.HasPrefix(s.Name, toComplete) {
+			completions = append(completions, s.Name+"\t"+s.Line(""))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// SnippetSaveCommand wraps cobra.Command with snippet save functionality
+type SnippetSaveCommand struct {
+	*cobra.Command
+	library     string
+	command     string
+	description string
+	force       bool
+}
+
+func newSnippetSaveCommand() *SnippetSaveCommand {
+	sc := &SnippetSaveCommand{}
+	sc.Command = &cobra.Command{
+		Use:   "save <name> <cron-expression | job-line>",
+		Short: "Save a cron expression or job line under a name",
+		Long: `Save a cron expression, or a whole job line (a schedule and its command),
+in the snippet library under a name.
+
+The snippet is run through the checks of 'cronkit check': one with errors is
+not saved, and warnings are listed. A snippet of the same name is replaced
+only with --force.
+
+Examples:
+  cronkit snippet save business-hours "*/15 9-17 * * 1-5"
+  cronkit snippet save nightly-backup "30 2 * * * /usr/local/bin/backup.sh" -d "Nightly backup"
+  cronkit snippet save weekly-report "0 7 * * 1" --command /usr/local/bin/report.sh`,
+		Args: cobra.ExactArgs(2),
+		RunE: sc.runSave,
+	}
+
+	sc.Flags().StringVar(&sc.library, "library", "", snippetLibraryUsage)
+	sc.Flags().StringVar(&sc.command, "command", "", "Command of the job, making a cron expression a job line")
+	sc.Flags().StringVarP(&sc.description, "description", "d", "", "What the snippet is for")
+	sc.Flags().BoolVar(&sc.force, "force", false, "Replace a snippet of the same name")
+	return sc
+}
+
+func (sc *SnippetSaveCommand) runSave(_ *cobra.Command, args []string) error {
+	name, value := args[0], strings.TrimSpace(args[1])
+	if err := snippet.ValidateName(name); err != nil {
+		return err
+	}
+	s, err := parseSnippet(name, value, strings.TrimSpace(sc.command))
+	if err != nil {
+		return err
+	}
+	s.Description = sc.description
+
+	validator := check.NewValidator(GetLocale())
+	var result check.ValidationResult
+	if s.IsJob() {
+		entries, err := crontab.ParseReader(strings.NewReader(s.Line("") + "\n"))
+		if err != nil {
+			return fmt.Errorf("failed to parse job line: %w", err)
+		}
+		result = validator.ValidateEntries(entries)
+	} else {
+		result = validator.ValidateExpression(s.Expression)
+	}
+	if len(result.Issues) > 0 {
+		if blocking := printIssues(sc.ErrOrStderr(), result.Issues, check.SeverityError); blocking > 0 {
+			return fmt.Errorf("refusing to save a snippet with %d error(s)", blocking)
+		}
+	}
+
+	library, err := loadSnippets(sc.library)
+	if err != nil {
+		return err
+	}
+	if err := library.Put(s, sc.force); err != nil {
+		return fmt.Errorf("%w (use --force to replace it)", err)
+	}
+	if err := library.Save(); err != nil {
+		return err
+	}
+	sc.Printf("Saved snippet %q: %s\n", s.Name, s.Line(""))
+	return nil
+}
+
+// parseSnippet reads a snippet's value: a cron expression, with command as
+// the command of its job when given, or a whole job line
+func parseSnippet(name, value, command string) (snippet.Snippet, error) {
+	s := snippet.Snippet{Name: name}
+	_, exprErr := cronx.NewParser().Parse(value)
+	if exprErr == nil {
+		s.Expression, s.Command = value, command
+		return s, nil
+	}
+
+	entries, err := crontab.ParseReader(strings.NewReader(value + "\n"))
+	if err != nil || len(entries) != 1 || entries[0].Job == nil || !entries[0].Job.Valid {
+		return s, fmt.Errorf("invalid snippet %q: not a cron expression (%v) or a job line", value, exprErr)
+	}
+	if command != "" {
+		return s, fmt.Errorf("--command cannot be used with a job line, which has a command")
+	}
+	s.Expression, s.Command = entries[0].Job.Expression, entries[0].Job.Command
+	return s, nil
+}
+
+// SnippetListCommand wraps cobra.Command with snippet list functionality
+type SnippetListCommand struct {
+	*cobra.Command
+	library string
+	json    bool
+}
+
+// SnippetListResult is the JSON output of snippet list
+type SnippetListResult struct {
+	Library  string           `json:"library"`
+	Snippets []SnippetSummary `json:"snippets"`
+}
+
+// SnippetSummary is a snippet with the description of its schedule
+type SnippetSummary struct {
+	snippet.Snippet
+	Schedule string `json:"schedule"` // Plain-language description of the expression
+}
+
+func newSnippetListCommand() *SnippetListCommand {
+	lc := &SnippetListCommand{}
+	lc.Command = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the saved snippets",
+		Long: `List the snippets of the library with a description of their schedules.
+
+Examples:
+  cronkit snippet list
+  cronkit snippet list --json`,
+		Args: cobra.NoArgs,
+		RunE: lc.runList,
+	}
+
+	lc.Flags().StringVar(&lc.library, "library", "", snippetLibraryUsage)
+	lc.Flags().BoolVarP(&lc.json, "json", "j", false, "Output in JSON format")
+	return lc
+}
+
+func (lc *SnippetListCommand) runList(_ *cobra.Command, _ []string) error {
+	library, err := loadSnippets(lc.library)
+	if err != nil {
+		return err
+	}
+
+	parser := cronx.NewParser()
+	humanizer := newHumanizer()
+	summaries := make([]SnippetSummary, 0, len(library.List()))
+	for _, s := range library.List() {
+		summary := SnippetSummary{Snippet: s}
+		if schedule, err := parser.Parse(s.Expression); err == nil {
+			summary.Schedule = humanizer.Humanize(schedule)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if lc.json {
+		encoder := json.NewEncoder(lc.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(SnippetListResult{Library: library.Path(), Snippets: summaries}); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	if len(summaries) == 0 {
+		lc.Printf("No snippets saved in %s\n", library.Path())
+		return nil
+	}
+	table := &textTable{
+		columns: []tableColumn{
+			{header: "NAME"},
+			{header: "EXPRESSION"},
+			{header: "SCHEDULE", limit: maxDescriptionLength},
+			{header: "COMMAND", limit: maxCommandLength},
+		},
+		noteColumn: 1,
+	}
+	for _, s := range summaries {
+		row := tableRow{cells: []tableCell{{text: s.Name}, {text: s.Expression}, {text: s.Schedule}, {text: s.Command}}}
+		if s.Description != "" {
+			row.notes = append(row.notes, tableCell{text: "# " + s.Description, role: color.Muted, style: true})
+		}
+		table.rows = append(table.rows, row)
+	}
+	table.write(lc.OutOrStdout(), painterFor(lc.OutOrStdout()), overflowTruncate)
+	return nil
+}
+
+// SnippetInsertCommand wraps cobra.Command with snippet insert functionality
+type SnippetInsertCommand struct {
+	*cobra.Command
+	library string
+	file    string
+	command string
+	force   bool
+}
+
+func newSnippetInsertCommand() *SnippetInsertCommand {
+	ic := &SnippetInsertCommand{}
+	ic.Command = &cobra.Command{
+		Use:   "insert <name>",
+		Short: "Print a snippet, or append it to a crontab file",
+		Long: `Print the line of a snippet, or append it to a crontab file with --file.
+
+A snippet that is a cron expression is printed as is, to use in other
+commands, or given a command with --command; appending one to a crontab
+requires --command. Lines are appended after a comment naming the snippet
+(and its description), and a job already in the crontab is not appended
+again unless --force is given. A missing crontab file is created.
+
+Examples:
+  cronkit snippet insert nightly-backup
+  cronkit next "$(cronkit snippet insert business-hours)"
+  cronkit snippet insert nightly-backup --file jobs.cron
+  cronkit snippet insert business-hours --command /usr/local/bin/poll.sh --file jobs.cron`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippetNames,
+		RunE:              ic.runInsert,
+	}
+
+	ic.Flags().StringVar(&ic.library, "library", "", snippetLibraryUsage)
+	ic.Flags().StringVarP(&ic.file, "file", "f", "", "Crontab file to append the snippet to (instead of printing it)")
+	ic.Flags().StringVar(&ic.command, "command", "", "Command of the job, instead of the snippet's")
+	ic.Flags().BoolVar(&ic.force, "force", false, "Append the job even if the crontab already has it")
+	return ic
+}
+
+func (ic *SnippetInsertCommand) runInsert(_ *cobra.Command, args []string) error {
+	library, err := loadSnippets(ic.library)
+	if err != nil {
+		return err
+	}
+	s, err := library.Get(args[0])
+	if err != nil {
+		return err
+	}
+	command := strings.TrimSpace(ic.command)
+	line := s.Line(command)
+	if ic.file == "" {
+		ic.Println(line)
+		return nil
+	}
+	if !s.IsJob() && command == "" {
+		return fmt.Errorf("snippet %q is a schedule without a command; give one with --command", s.Name)
+	}
+
+	content := ""
+	perm := fs.FileMode(0o644)
+	data, err := os.ReadFile(ic.file)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read crontab file: %w", err)
+	default:
+		content = string(data)
+		if info, err := os.Stat(ic.file); err == nil {
+			perm = info.Mode().Perm()
+		}
+	}
+
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse crontab file: %w", err)
+	}
+	expression, jobCommand := s.Expression, command
+	if jobCommand == "" {
+		jobCommand = s.Command
+	}
+	for _, entry := range entries {
+		if entry.Job != nil && entry.Job.Expression == expression && entry.Job.Command == jobCommand && !ic.force {
+			return fmt.Errorf("%s already has this job at line %d (use --force to append it again)", ic.file, entry.LineNumber)
+		}
+	}
+
+	comment := "# " + s.Name
+	if s.Description != "" {
+		comment += ": " + s.Description
+	}
+	var sb strings.Builder
+	sb.WriteString(content)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		sb.WriteString("\n")
+	}
+	if content != "" && !strings.HasSuffix(sb.String(), "\n\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(comment + "\n")
+	lineNumber := strings.Count(sb.String(), "\n") + 1
+	sb.WriteString(line + "\n")
+
+	if err := os.WriteFile(ic.file, []byte(sb.String()), perm); err != nil {
+		return fmt.Errorf("failed to write crontab file: %w", err)
+	}
+	ic.Printf("Inserted snippet %q into %s at line %d\n", s.Name, ic.file, lineNumber)
+	return nil
+}
+
+// SnippetRemoveCommand wraps cobra.Command with snippet remove functionality
+type SnippetRemoveCommand struct {
+	*cobra.Command
+	library string
+}
+
+func newSnippetRemoveCommand() *SnippetRemoveCommand {
+	rc := &SnippetRemoveCommand{}
+	rc.Command = &cobra.Command{
+		Use:               "remove <name>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove a snippet from the library",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippetNames,
+		RunE:              rc.runRemove,
+	}
+
+	rc.Flags().StringVar(&rc.library, "library", "", snippetLibraryUsage)
+	return rc
+}
+
+func (rc *SnippetRemoveCommand) runRemove(_ *cobra.Command, args []string) error {
+	library, err := loadSnippets(rc.library)
+	if err != nil {
+		return err
+	}
+	if err := library.Remove(args[0]); err != nil {
+		return err
+	}
+	if err := library.Save(); err != nil {
+		return err
+	}
+	rc.Printf("Removed snippet %q\n", args[0])
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnippetCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, string, error) {
+		cmd := newSnippetCommand()
+		out := new(bytes.Buffer)
+		errOut := new(bytes.Buffer)
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), errOut.String(), err
+	}
+	newLibrary := func(t *testing.T) string {
+		library := filepath.Join(t.TempDir(), "snippets.yaml")
+		_, _, err := run(t, "save", "business-hours", "*/15 9-17 * * 1-5", "--library", library)
+		require.NoError(t, err)
+		_, _, err = run(t, "save", "nightly-backup", "30 2 * * * /usr/local/bin/backup.sh", "-d", "Nightly backup", "--library", library)
+		require.NoError(t, err)
+		return library
+	}
+
+	t.Run("save expressions and job lines", func(t *testing.T) {
+		library := filepath.Join(t.TempDir(), "snippets.yaml")
+		output, _, err := run(t, "save", "weekdays", "0 9 * * 1-5", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "Saved snippet \"weekdays\": 0 9 * * 1-5\n", output)
+
+		output, _, err = run(t, "save", "report", "0 7 * * 1", "--command", "/bin/report", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "Saved snippet \"report\": 0 7 * * 1 /bin/report\n", output)
+
+		output, _, err = run(t, "save", "backup", "@daily /bin/backup", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "Saved snippet \"backup\": @daily /bin/backup\n", output)
+	})
+
+	t.Run("save rejects invalid snippets", func(t *testing.T) {
+		library := filepath.Join(t.TempDir(), "snippets.yaml")
+		_, _, err := run(t, "save", "bad", "61 * * * *", "--library", library)
+		assert.ErrorContains(t, err, "invalid snippet")
+
+		_, _, err = run(t, "save", "bad name", "0 * * * *", "--library", library)
+		assert.ErrorContains(t, err, "invalid snippet name")
+
+		_, _, err = run(t, "save", "job", "0 * * * * /bin/a", "--command", "/bin/b", "--library", library)
+		assert.ErrorContains(t, err, "--command cannot be used with a job line")
+
+		_, err = os.Stat(library)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("save lists warnings", func(t *testing.T) {
+		library := filepath.Join(t.TempDir(), "snippets.yaml")
+		_, stderr, err := run(t, "save", "every-minute", "* * * * *", "--library", library)
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "CRON-007")
+	})
+
+	t.Run("save replaces only with --force", func(t *testing.T) {
+		library := newLibrary(t)
+		_, _, err := run(t, "save", "business-hours", "0 9-17 * * 1-5", "--library", library)
+		assert.ErrorContains(t, err, "already exists")
+
+		_, _, err = run(t, "save", "business-hours", "0 9-17 * * 1-5", "--force", "--library", library)
+		require.NoError(t, err)
+		output, _, err := run(t, "insert", "business-hours", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "0 9-17 * * 1-5\n", output)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		library := newLibrary(t)
+		output, _, err := run(t, "list", "--library", library)
+		require.NoError(t, err)
+		assert.Contains(t, output, "NAME")
+		assert.Contains(t, output, "business-hours  */15 9-17 * * 1-5")
+		assert.Contains(t, output, "At 02:30 every day")
+		assert.Contains(t, output, "/usr/local/bin/backup.sh")
+		assert.Contains(t, output, "# Nightly backup")
+	})
+
+	t.Run("list JSON", func(t *testing.T) {
+		library := newLibrary(t)
+		output, _, err := run(t, "list", "--json", "--library", library)
+		require.NoError(t, err)
+		var result SnippetListResult
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		assert.Equal(t, library, result.Library)
+		require.Len(t, result.Snippets, 2)
+		assert.Equal(t, "nightly-backup", result.Snippets[1].Name)
+		assert.Equal(t, "/usr/local/bin/backup.sh", result.Snippets[1].Command)
+		assert.Equal(t, "At 02:30 every day", result.Snippets[1].Schedule)
+	})
+
+	t.Run("list an empty library", func(t *testing.T) {
+		library := filepath.Join(t.TempDir(), "snippets.yaml")
+		output, _, err := run(t, "list", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "No snippets saved in "+library+"\n", output)
+	})
+
+	t.Run("insert prints the line", func(t *testing.T) {
+		library := newLibrary(t)
+		output, _, err := run(t, "insert", "nightly-backup", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "30 2 * * * /usr/local/bin/backup.sh\n", output)
+
+		output, _, err = run(t, "insert", "business-hours", "--command", "/bin/poll", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "*/15 9-17 * * 1-5 /bin/poll\n", output)
+
+		_, _, err = run(t, "insert", "missing", "--library", library)
+		assert.ErrorContains(t, err, "no such snippet")
+	})
+
+	t.Run("insert into a crontab file", func(t *testing.T) {
+		library := newLibrary(t)
+		file := filepath.Join(t.TempDir(), "jobs.cron")
+		require.NoError(t, os.WriteFile(file, []byte("0 1 * * * /bin/true"), 0o600))
+
+		output, _, err := run(t, "insert", "nightly-backup", "--file", file, "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "Inserted snippet \"nightly-backup\" into "+file+" at line 4\n", output)
+
+		_, _, err = run(t, "insert", "nightly-backup", "--file", file, "--library", library)
+		assert.ErrorContains(t, err, "already has this job at line 4")
+
+		_, _, err = run(t, "insert", "business-hours", "--file", file, "--library", library)
+		assert.ErrorContains(t, err, "--command")
+
+		_, _, err = run(t, "insert", "business-hours", "--command", "/bin/poll", "--file", file, "--library", library)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "0 1 * * * /bin/true\n\n# nightly-backup: Nightly backup\n30 2 * * * /usr/local/bin/backup.sh\n\n# business-hours\n*/15 9-17 * * 1-5 /bin/poll\n", string(data))
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("insert creates a missing crontab file", func(t *testing.T) {
+		library := newLibrary(t)
+		file := filepath.Join(t.TempDir(), "new.cron")
+		_, _, err := run(t, "insert", "nightly-backup", "-f", file, "--library", library)
+		require.NoError(t, err)
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "# nightly-backup: Nightly backup\n30 2 * * * /usr/local/bin/backup.sh\n", string(data))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		library := newLibrary(t)
+		output, _, err := run(t, "rm", "business-hours", "--library", library)
+		require.NoError(t, err)
+		assert.Equal(t, "Removed snippet \"business-hours\"\n", output)
+
+		_, _, err = run(t, "remove", "business-hours", "--library", library)
+		assert.ErrorContains(t, err, "no such snippet")
+	})
+}
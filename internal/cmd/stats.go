@@ -3,21 +3,31 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/schema"
 	"github.com/hzerrad/cronkit/internal/stats"
 	"github.com/spf13/cobra"
 )
 
 type StatsCommand struct {
 	*cobra.Command
-	file      string
-	stdin     bool
-	json      bool
-	verbose   bool
-	top       int
-	aggregate bool
+	file            string
+	system          bool
+	stdin           bool
+	json            bool
+	outputSchema    bool
+	format          string
+	verbose         bool
+	top             int
+	aggregate       bool
+	skipInvalid     bool
+	defaultDuration time.Duration
+	window          time.Duration
+	heatmap         bool
 }
 
 func newStatsCommand() *StatsCommand {
@@ -27,24 +37,46 @@ func newStatsCommand() *StatsCommand {
 		Short: "Calculate and display crontab statistics",
 		Long: `Calculate and display statistics about crontab jobs including:
   - Run frequency metrics (runs per day, per hour)
-  - Hour distribution histogram
+  - Hour distribution histogram of the runs of a day
   - Most/least frequent jobs
-  - Collision analysis (busiest hours, quiet windows)
+  - Collision analysis over the next --window (busiest hours, share of
+    minutes with more than one job running, most jobs running at once); jobs
+    annotated with '# cronkit:duration=15m' (or given --default-duration)
+    collide with the jobs that start while they run
+  - Field value distribution (e.g. "62% of jobs run at minute 0")
+  - With --heatmap, a weekday × hour grid of the runs of a week, shaded by
+    density to spot scheduling hotspots at a glance
+
+Invalid lines are left out of the statistics and listed as warnings; use
+--skip-invalid=false to abort on the first invalid line instead.
+
+With --format csv or tsv, each valid job is a row with the columns:
+  line, expression, user, command, runs_per_day, runs_per_hour
 
 Examples:
   cronkit stats --file /etc/crontab
   cronkit stats --file crontab.txt --json
-  cronkit stats --top 10 --verbose`,
+  cronkit stats --file crontab.txt --format csv > frequencies.csv
+  cronkit stats --top 10 --verbose
+  cronkit stats --file crontab.txt --window 168h  # Collisions over a week
+  cronkit stats --file crontab.txt --heatmap`,
 		RunE: sc.runStats,
 		Args: cobra.NoArgs,
 	}
 
 	sc.Flags().StringVarP(&sc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	sc.Flags().BoolVar(&sc.system, "system", false, systemUsage)
 	sc.Flags().BoolVar(&sc.stdin, "stdin", false, "Read crontab from standard input")
 	sc.Flags().BoolVarP(&sc.json, "json", "j", false, "Output in JSON format")
+	sc.Flags().BoolVar(&sc.outputSchema, "output-schema", false, outputSchemaUsage)
+	sc.Flags().StringVar(&sc.format, "format", tabularFormatText, fmt.Sprintf(tabularFormatUsage, "job"))
 	sc.Flags().BoolVarP(&sc.verbose, "verbose", "v", false, "Show detailed statistics")
 	sc.Flags().IntVar(&sc.top, "top", DefaultStatsTopN, "Number of top items to show (default: 5)")
 	sc.Flags().BoolVar(&sc.aggregate, "aggregate", false, "Aggregate statistics from multiple sources")
+	sc.Flags().BoolVar(&sc.skipInvalid, "skip-invalid", true, skipInvalidUsage)
+	sc.Flags().DurationVar(&sc.defaultDuration, "default-duration", 0, defaultDurationUsage)
+	sc.Flags().BoolVar(&sc.heatmap, "heatmap", false, "Show a weekday × hour heatmap of the runs of a week")
+	sc.Flags().DurationVar(&sc.window, "window", stats.OneDay, "Time window of the collision analysis, from now (e.g. 1h, 24h, 168h; at most a week)")
 
 	return sc
 }
@@ -54,57 +86,116 @@ func init() {
 }
 
 func (sc *StatsCommand) runStats(_ *cobra.Command, _ []string) error {
-	reader := crontab.NewReader()
-	calculator := stats.NewCalculator()
+	if sc.outputSchema {
+		return writeSchema(sc.OutOrStdout(), "stats")
+	}
+
+	format, err := tabularFormat(sc.format, sc.json)
+	if err != nil {
+		return err
+	}
+
+	if sc.heatmap && isTabular(format) {
+		return fmt.Errorf("--heatmap is not available with --format %s", format)
+	}
+	if sc.window <= 0 || sc.window > stats.MaxWindow {
+		return fmt.Errorf("invalid --window value %s (must be positive and at most %s)", sc.window, formatWindow(stats.MaxWindow))
+	}
+
+	reader := newCrontabReader(sc.system)
+	calculator := stats.NewCalculatorWithContext(sc.Context())
 
-	var jobs []*crontab.Job
-	var err error
+	var entries []*crontab.Entry
 
 	// Determine input source
 	if sc.stdin {
-		entries, err := reader.ParseStdin()
+		entries, err = reader.ParseStdin()
 		if err != nil {
 			return fmt.Errorf("failed to read from stdin: %w", err)
 		}
-		jobs = extractJobs(entries)
 	} else if sc.file != "" {
-		entries, err := reader.ParseFile(sc.file)
+		entries, err = reader.ParseFile(sc.file)
 		if err != nil {
 			return fmt.Errorf("failed to read file: %w", err)
 		}
-		jobs = extractJobs(entries)
 	} else {
-		jobs, err = reader.ReadUser()
+		jobs, err := reader.ReadUser()
 		if err != nil {
 			return fmt.Errorf("failed to read user crontab: %w", err)
 		}
+		entries = crontab.JobEntries(jobs)
 	}
 
+	jobs, skipped, err := partitionEntries(entries, sc.skipInvalid)
 	if err != nil {
 		return err
 	}
 
+	if err := applyDefaultDuration(jobs, sc.defaultDuration); err != nil {
+		return err
+	}
+
 	// Calculate metrics
-	metrics, err := calculator.CalculateMetrics(jobs, stats.OneDay)
+	metrics, err := calculator.CalculateMetrics(jobs, sc.window)
 	if err != nil {
 		return fmt.Errorf("failed to calculate metrics: %w", err)
 	}
 
+	var heatmap *stats.Heatmap
+	if sc.heatmap {
+		h := calculator.CalculateHeatmap(jobs)
+		heatmap = &h
+		if err := contextErr(sc.Command); err != nil {
+			return err
+		}
+	}
+
 	// Output
-	if sc.json {
-		return sc.outputJSON(metrics)
+	switch {
+	case format == tabularFormatJSON:
+		return sc.outputJSON(metrics, heatmap, skipped)
+	case isTabular(format):
+		return sc.outputTabular(format, metrics, jobs)
 	}
 
-	return sc.outputText(metrics, calculator, jobs)
+	return sc.outputText(metrics, heatmap, calculator, jobs, skipped)
 }
 
-func (sc *StatsCommand) outputJSON(metrics *stats.Metrics) error {
+func (sc *StatsCommand) outputJSON(metrics *stats.Metrics, heatmap *stats.Heatmap, skipped []crontab.SkippedLine) error {
+	result := struct {
+		SchemaVersion int // Named like the other fields, which are written under their Go names
+		*stats.Metrics
+		Window  string         // Time window of the collision analysis
+		Heatmap *stats.Heatmap `json:",omitempty"` // Runs of a week by weekday and hour, with --heatmap
+		Skipped []crontab.SkippedLine
+	}{schema.Version, metrics, sc.window.String(), heatmap, skipped}
+
 	encoder := json.NewEncoder(sc.OutOrStdout())
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(metrics)
+	return encoder.Encode(result)
 }
 
-func (sc *StatsCommand) outputText(metrics *stats.Metrics, calculator *stats.Calculator, jobs []*crontab.Job) error {
+// outputTabular writes a CSV or TSV row per valid job with its run frequency
+func (sc *StatsCommand) outputTabular(format string, metrics *stats.Metrics, jobs []*crontab.Job) error {
+	// Metrics list the frequencies of the valid jobs, in order
+	rows := make([][]string, 0, len(metrics.JobFrequencies))
+	i := 0
+	for _, job := range jobs {
+		if !job.Valid || i >= len(metrics.JobFrequencies) {
+			continue
+		}
+		freq := metrics.JobFrequencies[i]
+		i++
+		rows = append(rows, []string{
+			strconv.Itoa(job.LineNumber), job.Expression, job.User, job.Command,
+			strconv.Itoa(freq.RunsPerDay), strconv.Itoa(freq.RunsPerHour),
+		})
+	}
+	header := []string{"line", "expression", "user", "command", "runs_per_day", "runs_per_hour"}
+	return writeTable(sc.OutOrStdout(), format, header, rows)
+}
+
+func (sc *StatsCommand) outputText(metrics *stats.Metrics, heatmap *stats.Heatmap, calculator *stats.Calculator, jobs []*crontab.Job, skipped []crontab.SkippedLine) error {
 	sc.Println("Crontab Statistics")
 	sc.Println(strings.Repeat("=", 50))
 
@@ -114,43 +205,77 @@ func (sc *StatsCommand) outputText(metrics *stats.Metrics, calculator *stats.Cal
 	sc.Printf("  Total Runs per Day: %d\n", metrics.TotalRunsPerDay)
 	sc.Printf("  Total Runs per Hour: %d\n", metrics.TotalRunsPerHour)
 
-	// Most frequent jobs
+	// Most and least frequent jobs
 	mostFrequent := calculator.IdentifyMostFrequent(jobs, sc.top)
 	if len(mostFrequent) > 0 {
 		sc.Printf("\nTop %d Most Frequent Jobs:\n", sc.top)
-		for i, freq := range mostFrequent {
-			sc.Printf("  %d. %s (%d runs/day, %d runs/hour)\n",
-				i+1, freq.Expression, freq.RunsPerDay, freq.RunsPerHour)
+		sc.printFrequencies(mostFrequent)
+	}
+	// With no more jobs than --top, the least frequent are the same jobs
+	if len(metrics.JobFrequencies) > sc.top {
+		sc.Printf("\nTop %d Least Frequent Jobs:\n", sc.top)
+		sc.printFrequencies(calculator.IdentifyLeastFrequent(jobs, sc.top))
+	}
+
+	// Field value hotspots (thundering-herd risk)
+	var hotspots []string
+	for _, dist := range []stats.FieldDistribution{metrics.Fields.Minute, metrics.Fields.Hour, metrics.Fields.DayOfWeek} {
+		if summary := dist.Summary(); summary != "" {
+			hotspots = append(hotspots, summary)
+		}
+	}
+	if len(hotspots) > 0 {
+		sc.Printf("\nField Hotspots:\n")
+		for _, hotspot := range hotspots {
+			sc.Printf("  %s\n", hotspot)
 		}
 	}
 
 	// Hour histogram
-	if sc.verbose {
+	if metrics.TotalRunsPerDay > 0 {
 		sc.Printf("\n%s\n", stats.GenerateHistogram(metrics.HourHistogram, stats.DefaultHistogramWidth))
 	}
+	if heatmap != nil {
+		sc.Printf("\n%s\n", stats.GenerateHeatmap(*heatmap))
+	}
+	if sc.verbose {
+		sc.Printf("\n%s\n", stats.GenerateFieldTable(metrics.Fields, sc.top, stats.DefaultHistogramWidth))
+	}
 
 	// Collision stats
-	if sc.verbose && len(metrics.Collisions.BusiestHours) > 0 {
-		sc.Printf("\nBusiest Hours:\n")
+	if len(metrics.Collisions.BusiestHours) > 0 {
+		sc.Printf("\nCollisions (next %s):\n", formatWindow(sc.window))
+		sc.Printf("  Collision Frequency: %.2f%%\n", metrics.Collisions.CollisionFrequency)
+		sc.Printf("  Max Concurrent Jobs: %d\n", metrics.Collisions.MaxConcurrent)
+		sc.Printf("  Busiest Hours:\n")
 		for i, hour := range metrics.Collisions.BusiestHours {
 			if i >= sc.top {
 				break
 			}
-			sc.Printf("  %02d:00 - %d runs\n", hour.Hour, hour.RunCount)
+			sc.Printf("    %02d:00 - %d runs\n", hour.Hour, hour.RunCount)
 		}
-		sc.Printf("\nCollision Frequency: %.2f%%\n", metrics.Collisions.CollisionFrequency)
-		sc.Printf("Max Concurrent Jobs: %d\n", metrics.Collisions.MaxConcurrent)
 	}
 
+	printSkipped(sc.Command, skipped)
 	return nil
 }
 
-func extractJobs(entries []*crontab.Entry) []*crontab.Job {
-	jobs := make([]*crontab.Job, 0)
-	for _, entry := range entries {
-		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
-			jobs = append(jobs, entry.Job)
-		}
+// printFrequencies prints a numbered list of job frequencies
+func (sc *StatsCommand) printFrequencies(frequencies []stats.JobFrequency) {
+	for i, freq := range frequencies {
+		sc.Printf("  %d. %s (%d runs/day, %d runs/hour)\n",
+			i+1, freq.Expression, freq.RunsPerDay, freq.RunsPerHour)
+	}
+}
+
+// formatWindow formats a window without zero units, e.g. "24h" or "1h30m"
+func formatWindow(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
 	}
-	return jobs
+	return s
 }
@@ -53,6 +53,90 @@ func TestStatsCommand(t *testing.T) {
 		assert.Contains(t, output, "Total Runs per Hour")
 	})
 
+	t.Run("should report field hotspots", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+
+		testFile := createTempFile(t, "0 2 * * * /usr/bin/a\n0 * * * * /usr/bin/b\n")
+		sc.SetArgs([]string{"--file", testFile, "--verbose"})
+
+		err := sc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Field Hotspots")
+		assert.Contains(t, output, "100% of jobs run at minute 0")
+		assert.Contains(t, output, "Field Value Distribution")
+	})
+
+	t.Run("should show histogram, least frequent jobs and collisions", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+
+		testFile := createTempFile(t, "*/15 * * * * /usr/bin/a\n0 2 * * * /usr/bin/b\n0 */6 * * * /usr/bin/c\n")
+		sc.SetArgs([]string{"--file", testFile, "--top", "2", "--window", "168h"})
+
+		err := sc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Hour Distribution")
+		assert.Contains(t, output, "Top 2 Least Frequent Jobs:\n  1. 0 2 * * * (1 runs/day")
+		assert.Contains(t, output, "Collisions (next 168h):")
+		assert.Contains(t, output, "Max Concurrent Jobs: 2")
+		assert.NotContains(t, output, "Field Value Distribution")
+	})
+
+	t.Run("should show a heatmap", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+
+		testFile := createTempFile(t, "0 9 * * MON-FRI /usr/bin/a\n")
+		sc.SetArgs([]string{"--file", testFile, "--heatmap"})
+
+		err := sc.Execute()
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Weekly Run Heatmap")
+		assert.Contains(t, output, "Mon  ·· ·· ·· ·· ·· ·· ·· ·· ·· ██ ··")
+		assert.Contains(t, output, "Sun  ·· ··")
+	})
+
+	t.Run("should include the heatmap in JSON", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+
+		testFile := createTempFile(t, "0 9 * * MON-FRI /usr/bin/a\n")
+		sc.SetArgs([]string{"--file", testFile, "--heatmap", "--json"})
+
+		err := sc.Execute()
+		require.NoError(t, err)
+
+		var result struct{ Heatmap [][]int }
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Heatmap, 7)
+		assert.Equal(t, 1, result.Heatmap[1][9])
+		assert.Equal(t, 0, result.Heatmap[0][9])
+	})
+
+	t.Run("should reject invalid windows", func(t *testing.T) {
+		for _, window := range []string{"0s", "-1h", "169h"} {
+			sc := newStatsCommand()
+			sc.SetOut(new(bytes.Buffer))
+			sc.SetErr(new(bytes.Buffer))
+			sc.SetArgs([]string{"--file", createTempFile(t, "0 * * * * /usr/bin/a\n"), "--window", window})
+
+			err := sc.Execute()
+			require.Error(t, err, window)
+			assert.Contains(t, err.Error(), "invalid --window value")
+		}
+	})
+
 	t.Run("should output JSON format", func(t *testing.T) {
 		sc := newStatsCommand()
 		buf := new(bytes.Buffer)
@@ -71,6 +155,7 @@ func TestStatsCommand(t *testing.T) {
 		assert.Contains(t, result, "TotalRunsPerDay")
 		assert.Contains(t, result, "TotalRunsPerHour")
 		assert.Contains(t, result, "JobFrequencies")
+		assert.Equal(t, "24h0m0s", result["Window"])
 	})
 
 	t.Run("should show verbose output", func(t *testing.T) {
@@ -314,3 +399,38 @@ func TestOutputText(t *testing.T) {
 		assert.Contains(t, output, "Crontab Statistics")
 	})
 }
+
+func TestStatsCommand_Format(t *testing.T) {
+	testFile := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n30 * * * * echo \"a, b\"\ninvalid line\n")
+
+	t.Run("csv", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetArgs([]string{"--file", testFile, "--format", "csv"})
+
+		require.NoError(t, sc.Execute())
+		assert.Equal(t, "line,expression,user,command,runs_per_day,runs_per_hour\n"+
+			"1,0 2 * * *,,/usr/bin/backup.sh,1,0\n"+
+			"2,30 * * * *,,\"echo \"\"a, b\"\"\",24,1\n", buf.String())
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		sc := newStatsCommand()
+		buf := new(bytes.Buffer)
+		sc.SetOut(buf)
+		sc.SetArgs([]string{"--file", testFile, "--format", "tsv"})
+
+		require.NoError(t, sc.Execute())
+		assert.Contains(t, buf.String(), "1\t0 2 * * *\t\t/usr/bin/backup.sh\t1\t0\n")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		sc := newStatsCommand()
+		sc.SetOut(new(bytes.Buffer))
+		sc.SetErr(new(bytes.Buffer))
+		sc.SetArgs([]string{"--file", testFile, "--format", "xlsx"})
+
+		assert.ErrorContains(t, sc.Execute(), `invalid --format value "xlsx"`)
+	})
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/diff"
+	"github.com/hzerrad/cronkit/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+type SuggestCommand struct {
+	*cobra.Command
+	file    string
+	stdin   bool
+	minJobs int
+	spread  int
+	format  string
+	json    bool
+}
+
+func newSuggestCommand() *SuggestCommand {
+	sc := &SuggestCommand{}
+	sc.Command = &cobra.Command{
+		Use:   "suggest",
+		Short: "Suggest minute offsets that spread out jobs stacked on the same minute",
+		Long: `Find jobs stacked on the same minute and print the crontab with their
+schedules rewritten to spread the load.
+
+Jobs with the same schedule that start on the same minute (e.g. twelve jobs
+at "0 0 * * *", or a mix of "0 0 * * *" and @daily) form a stack. The first
+job of each stack keeps its schedule; the others are moved to evenly spaced
+minutes within --spread minutes after it, skipping minutes already used by
+jobs on the same hours and days. Jobs never move into another hour, and jobs
+whose minute field is a list, range or step are left alone.
+
+The rewritten crontab is printed to standard output and a summary of the
+changes to standard error. With --format patch, the changes are printed as a
+unified diff against the input instead. The input file is never modified.
+
+Examples:
+  cronkit suggest --file /etc/crontab
+  cronkit suggest --file jobs.cron --spread 15 --format patch | git apply
+  crontab -l | cronkit suggest --min-jobs 3 --json`,
+		Args: cobra.NoArgs,
+		RunE: sc.runSuggest,
+	}
+
+	sc.Flags().StringVarP(&sc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	sc.Flags().BoolVar(&sc.stdin, "stdin", false, "Read crontab from standard input (automatic if stdin is not a terminal)")
+	sc.Flags().IntVar(&sc.minJobs, "min-jobs", 2, "Number of jobs on the same minute that counts as a stack")
+	sc.Flags().IntVar(&sc.spread, "spread", stats.DefaultSpreadMinutes, "Minutes after the stacked minute to spread jobs over (1-60)")
+	sc.Flags().StringVar(&sc.format, "format", editFormatText, "Output format: 'text' (rewritten crontab) or 'patch' (unified diff against the input)")
+	sc.Flags().BoolVarP(&sc.json, "json", "j", false, "Output the stacks and suggestions in JSON format")
+	return sc
+}
+
+func init() {
+	rootCmd.AddCommand(newSuggestCommand().Command)
+}
+
+func (sc *SuggestCommand) runSuggest(_ *cobra.Command, _ []string) error {
+	if sc.minJobs < 2 {
+		return fmt.Errorf("invalid --min-jobs value %d: must be at least 2", sc.minJobs)
+	}
+	if sc.spread < 1 || sc.spread > stats.MinutesPerHour {
+		return fmt.Errorf("invalid --spread value %d: must be between 1 and %d", sc.spread, stats.MinutesPerHour)
+	}
+	if sc.format != editFormatText && sc.format != editFormatPatch {
+		return fmt.Errorf("invalid --format value %q (supported: text, patch)", sc.format)
+	}
+
+	original, name, err := readCrontabText(sc.Command, sc.file, sc.stdin)
+	if err != nil {
+		return err
+	}
+	entries, err := crontab.ParseReader(strings.NewReader(original))
+	if err != nil {
+		return fmt.Errorf("failed to parse crontab: %w", err)
+	}
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+
+	plan := stats.NewCalculator().SuggestJitter(jobs, stats.JitterOptions{MinJobs: sc.minJobs, Spread: sc.spread})
+	if sc.json {
+		return sc.outputJSON(plan, name)
+	}
+
+	lines := strings.Split(original, "\n")
+	for _, s := range plan.Suggestions {
+		if i := s.Job.LineNumber - 1; i >= 0 && i < len(lines) {
+			lines[i], _ = crontab.ReplaceSchedule(lines[i], s.Expression)
+		}
+	}
+	patched := strings.Join(lines, "\n")
+
+	sc.printSummary(plan)
+	if sc.format == editFormatPatch {
+		sc.Print(diff.Patch(name, original, patched))
+		return nil
+	}
+	sc.Print(patched)
+	return nil
+}
+
+// readCrontabText returns the crontab that suggest and jitterize rewrite, and
+// the name to use in patches. Priority: --file > --stdin > user crontab
+func readCrontabText(cmd *cobra.Command, file string, stdin bool) (string, string, error) {
+	switch {
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read crontab file %s: %w", file, err)
+		}
+		return string(data), file, nil
+	case stdin || isStdinAvailable():
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read crontab from stdin: %w", err)
+		}
+		return string(data), "crontab", nil
+	default:
+		content, err := crontab.ReadUserRaw()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read user crontab: %w", err)
+		}
+		return content, "crontab", nil
+	}
+}
+
+// printSummary describes the stacks and proposed changes on standard error,
+// keeping standard output a valid crontab or patch
+func (sc *SuggestCommand) printSummary(plan stats.JitterPlan) {
+	if len(plan.Stacks) == 0 {
+		sc.PrintErrln("✓ No stacked jobs found")
+		return
+	}
+	for _, stack := range plan.Stacks {
+		sc.PrintErrf("⚠ %d jobs start at the same minute: %s\n", len(stack.Jobs), stack.Expression)
+	}
+	for _, s := range plan.Suggestions {
+		sc.PrintErrf("  Line %d: %s → %s (+%dm)\n", s.Job.LineNumber, s.Job.Expression, s.Expression, s.Offset)
+	}
+	if plan.Unresolved > 0 {
+		sc.PrintErrf("  %d job(s) left in place: no free minutes within --spread\n", plan.Unresolved)
+	}
+}
+
+// SuggestJobJSON is a job of a stack in the JSON output of the suggest command
+type SuggestJobJSON struct {
+	LineNumber int    `json:"lineNumber"`
+	Expression string `json:"expression"`
+	Command    string `json:"command"`
+}
+
+// SuggestionJSON is a proposed schedule in the JSON output of the suggest command
+type SuggestionJSON struct {
+	LineNumber    int    `json:"lineNumber"`
+	Command       string `json:"command"`
+	Expression    string `json:"expression"`
+	Suggested     string `json:"suggested"`
+	OffsetMinutes int    `json:"offsetMinutes"`
+}
+
+func (sc *SuggestCommand) outputJSON(plan stats.JitterPlan, source string) error {
+	stacks := make([]map[string]interface{}, len(plan.Stacks))
+	for i, stack := range plan.Stacks {
+		jobs := make([]SuggestJobJSON, len(stack.Jobs))
+		for j, job := range stack.Jobs {
+			jobs[j] = SuggestJobJSON{LineNumber: job.LineNumber, Expression: job.Expression, Command: job.Command}
+		}
+		stacks[i] = map[string]interface{}{
+			"expression": stack.Expression,
+			"jobs":       jobs,
+		}
+	}
+
+	suggestions := make([]SuggestionJSON, len(plan.Suggestions))
+	for i, s := range plan.Suggestions {
+		suggestions[i] = SuggestionJSON{
+			LineNumber:    s.Job.LineNumber,
+			Command:       s.Job.Command,
+			Expression:    s.Job.Expression,
+			Suggested:     s.Expression,
+			OffsetMinutes: s.Offset,
+		}
+	}
+
+	result := map[string]interface{}{
+		"source":      source,
+		"stacks":      stacks,
+		"suggestions": suggestions,
+		"unresolved":  plan.Unresolved,
+	}
+	encoder := json.NewEncoder(sc.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestCommand(t *testing.T) {
+	content := "# Nightly jobs\n0 0 * * * /usr/bin/a.sh\n0 0 * * * /usr/bin/b.sh # b\n@daily /usr/bin/c.sh\n*/5 * * * * /usr/bin/poll.sh\n"
+	path := createTempFile(t, content)
+
+	run := func(t *testing.T, args ...string) (string, string, error) {
+		sc := newSuggestCommand()
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		sc.SetOut(stdout)
+		sc.SetErr(stderr)
+		sc.SetArgs(args)
+		err := sc.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	t.Run("prints the rewritten crontab", func(t *testing.T) {
+		stdout, stderr, err := run(t, "--file", path)
+		require.NoError(t, err)
+		assert.Equal(t, "# Nightly jobs\n0 0 * * * /usr/bin/a.sh\n10 0 * * * /usr/bin/b.sh # b\n20 0 * * * /usr/bin/c.sh\n*/5 * * * * /usr/bin/poll.sh\n", stdout)
+		assert.Contains(t, stderr, "3 jobs start at the same minute: 0 0 * * *")
+		assert.Contains(t, stderr, "Line 4: @daily → 20 0 * * * (+20m)")
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		stdout, _, err := run(t, "--file", path, "--format", "patch", "--spread", "10")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "-0 0 * * * /usr/bin/b.sh # b\n")
+		assert.Contains(t, stdout, "+3 0 * * * /usr/bin/b.sh # b\n")
+	})
+
+	t.Run("nothing stacked", func(t *testing.T) {
+		stdout, stderr, err := run(t, "--file", path, "--min-jobs", "4", "--format", "patch")
+		require.NoError(t, err)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "No stacked jobs found")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		stdout, _, err := run(t, "--file", path, "--json")
+		require.NoError(t, err)
+
+		var result struct {
+			Stacks      []map[string]interface{} `json:"stacks"`
+			Suggestions []SuggestionJSON         `json:"suggestions"`
+			Unresolved  int                      `json:"unresolved"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+		assert.Len(t, result.Stacks, 1)
+		require.Len(t, result.Suggestions, 2)
+		assert.Equal(t, SuggestionJSON{LineNumber: 3, Command: "/usr/bin/b.sh", Expression: "0 0 * * *", Suggested: "10 0 * * *", OffsetMinutes: 10}, result.Suggestions[0])
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		_, _, err := run(t, "--file", path, "--spread", "0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --spread")
+
+		_, _, err = run(t, "--file", path, "--min-jobs", "1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --min-jobs")
+
+		_, _, err = run(t, "--file", path, "--format", "xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --format")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, err := run(t, "--file", "/nonexistent/crontab")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read crontab file")
+	})
+}
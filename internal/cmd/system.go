@@ -0,0 +1,24 @@
+package cmd
+
+import "github.com/hzerrad/cronkit/internal/crontab"
+
+// systemUsage is the help text of the --system flag shared by commands that read crontab files
+const systemUsage = "Read the crontab as a system crontab, whose jobs name their user after the schedule (automatic for /etc/crontab and cron.d files)"
+
+// newCrontabReader returns a reader of system crontabs when system is set,
+// and otherwise one that detects them by path
+func newCrontabReader(system bool) crontab.Reader {
+	if system {
+		return crontab.NewReaderWithLayout(crontab.LayoutSystem)
+	}
+	return crontab.NewReader()
+}
+
+// crontabLayout returns the layout of crontabs read from text rather than a
+// file, such as standard input
+func crontabLayout(system bool) crontab.Layout {
+	if system {
+		return crontab.LayoutSystem
+	}
+	return crontab.LayoutUser
+}
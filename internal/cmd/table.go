@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hzerrad/cronkit/internal/color"
+)
+
+// How cells wider than their column's limit are written
+const (
+	overflowTruncate = iota // Cut, ending with "..."
+	overflowWrap            // Wrapped onto more lines, at spaces when possible
+	overflowNone            // Written in full, widening the column (--wide)
+)
+
+// tableColumn is a column of a text table
+type tableColumn struct {
+	header string
+	limit  int // Widest the column gets (0: as wide as its widest cell)
+}
+
+// tableCell is a cell of a text table, or a note below a row
+type tableCell struct {
+	text  string
+	role  color.Role
+	style bool // Whether the cell is painted in role
+}
+
+// tableRow is a row of a text table, with notes written on lines of their
+// own below it
+type tableRow struct {
+	cells []tableCell
+	notes []tableCell
+}
+
+// textTable is an aligned table of text output, with columns as wide as
+// their widest cell up to their limit
+type textTable struct {
+	columns    []tableColumn
+	rows       []tableRow
+	noteColumn int // Column the notes of rows are indented to
+}
+
+// widths returns the width of each column with the overflow mode
+func (t *textTable) widths(overflow int) []int {
+	widths := make([]int, len(t.columns))
+	for i, column := range t.columns {
+		widths[i] = utf8.RuneCountInString(column.header)
+		for _, row := range t.rows {
+			widths[i] = max(widths[i], utf8.RuneCountInString(row.cells[i].text))
+		}
+		if column.limit > 0 && overflow != overflowNone {
+			widths[i] = min(widths[i], max(column.limit, utf8.RuneCountInString(column.header)))
+		}
+	}
+	return widths
+}
+
+// write writes the header, a rule and the rows of the table, two spaces
+// between columns. The last column is not padded.
+func (t *textTable) write(w io.Writer, painter *color.Painter, overflow int) {
+	widths := t.widths(overflow)
+	last := len(t.columns) - 1
+
+	headers := make([]string, len(t.columns))
+	rules := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		headers[i] = pad(column.header, widths[i], i == last)
+		rules[i] = strings.Repeat("─", widths[i])
+	}
+	_, _ = fmt.Fprintln(w, painter.Paint(color.Heading, strings.Join(headers, "  ")))
+	_, _ = fmt.Fprintln(w, painter.Paint(color.Muted, strings.Join(rules, "  ")))
+
+	indent := 0
+	for i := 0; i < t.noteColumn && i < len(widths); i++ {
+		indent += widths[i] + 2
+	}
+	for _, row := range t.rows {
+		lines := make([][]string, len(row.cells))
+		height := 1
+		for i, cell := range row.cells {
+			lines[i] = fit(cell.text, widths[i], overflow)
+			height = max(height, len(lines[i]))
+		}
+		for l := 0; l < height; l++ {
+			parts := make([]string, len(row.cells))
+			for i, cell := range row.cells {
+				text := ""
+				if l < len(lines[i]) {
+					text = lines[i][l]
+				}
+				text = pad(text, widths[i], i == last)
+				if cell.style {
+					text = painter.Paint(cell.role, text)
+				}
+				parts[i] = text
+			}
+			_, _ = fmt.Fprintln(w, strings.TrimRight(strings.Join(parts, "  "), " "))
+		}
+		for _, note := range row.notes {
+			text := note.text
+			if note.style {
+				text = painter.Paint(note.role, text)
+			}
+			_, _ = fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", indent), text)
+		}
+	}
+}
+
+// pad pads s with spaces to width, unless it is the last column
+func pad(s string, width int, last bool) string {
+	if last {
+		return s
+	}
+	return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+}
+
+// fit returns the lines of s in a column of a width: s itself when it fits
+// or overflow is overflowNone, else s truncated or wrapped
+func fit(s string, width int, overflow int) []string {
+	runes := []rune(s)
+	if len(runes) <= width || overflow == overflowNone || width <= 0 {
+		return []string{s}
+	}
+	if overflow == overflowTruncate {
+		if width <= 3 {
+			return []string{string(runes[:width])}
+		}
+		return []string{string(runes[:width-3]) + "..."}
+	}
+
+	var lines []string
+	for len(runes) > width {
+		cut := width
+		// Break at the last space that fits, if any
+		for i := width; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	if len(runes) > 0 {
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, []string{"short"}, fit("short", 10, overflowTruncate))
+	assert.Equal(t, []string{"a long ..."}, fit("a long description", 10, overflowTruncate))
+	assert.Equal(t, []string{"a long description"}, fit("a long description", 10, overflowNone))
+	assert.Equal(t, []string{"a long", "unbreakabl", "eword"}, fit("a long unbreakableword", 10, overflowWrap))
+	assert.Equal(t, []string{"Tous les", "jours à", "02:00"}, fit("Tous les jours à 02:00", 8, overflowWrap))
+}
+
+func TestTextTable_Write(t *testing.T) {
+	table := &textTable{
+		columns: []tableColumn{{header: "LINE"}, {header: "DESCRIPTION", limit: 12}, {header: "COMMAND"}},
+		rows: []tableRow{
+			{cells: []tableCell{{text: "1"}, {text: "At 02:00 every day"}, {text: "/usr/bin/backup.sh"}}, notes: []tableCell{{text: "# owner=infra"}}},
+			{cells: []tableCell{{text: "12"}, {text: "(invalid)", role: color.Error, style: true}, {text: "/usr/bin/x"}}},
+		},
+		noteColumn: 2,
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+		table.write(&buf, nil, overflowTruncate)
+		assert.Equal(t, "LINE  DESCRIPTION   COMMAND\n"+
+			"────  ────────────  ──────────────────\n"+
+			"1     At 02:00 ...  /usr/bin/backup.sh\n"+
+			"                    # owner=infra\n"+
+			"12    (invalid)     /usr/bin/x\n", buf.String())
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		var buf bytes.Buffer
+		table.write(&buf, nil, overflowWrap)
+		assert.Contains(t, buf.String(), "1     At 02:00      /usr/bin/backup.sh\n      every day\n")
+	})
+
+	t.Run("wide", func(t *testing.T) {
+		var buf bytes.Buffer
+		table.write(&buf, nil, overflowNone)
+		assert.Contains(t, buf.String(), "1     At 02:00 every day  /usr/bin/backup.sh\n")
+	})
+}
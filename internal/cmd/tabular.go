@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Output formats of the commands with flat tabular output (list, next, stats
+// and check): one row per job, run or issue, for spreadsheets
+const (
+	tabularFormatText = "text"
+	tabularFormatJSON = "json"
+	tabularFormatCSV  = "csv"
+	tabularFormatTSV  = "tsv"
+)
+
+// tabularFormatUsage is the help text of the --format flag of list, next and stats
+const tabularFormatUsage = "Output format: 'text', 'json' (same as --json), or 'csv'/'tsv' (one row per %s, columns listed above)"
+
+// tabularFormat returns the output format selected by --format and --json,
+// which overrides it
+func tabularFormat(format string, json bool) (string, error) {
+	if json {
+		return tabularFormatJSON, nil
+	}
+	switch format {
+	case tabularFormatText, tabularFormatJSON, tabularFormatCSV, tabularFormatTSV:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid --format value %q (supported: text, json, csv, tsv)", format)
+}
+
+// isTabular reports whether format writes rows of comma- or tab-separated values
+func isTabular(format string) bool {
+	return format == tabularFormatCSV || format == tabularFormatTSV
+}
+
+// writeTable writes a header and rows as CSV, or TSV with the tsv format.
+// Fields are quoted as RFC 4180 requires, so commands containing the
+// separator, quotes or newlines stay in their column.
+func writeTable(w io.Writer, format string, header []string, rows [][]string) error {
+	writer := newTableWriter(w, format)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", format, err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", format, err)
+	}
+	return nil
+}
+
+// newTableWriter returns a writer of CSV rows, or TSV rows with the tsv
+// format, for output written a row at a time; it must be flushed
+func newTableWriter(w io.Writer, format string) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if format == tabularFormatTSV {
+		writer.Comma = '\t'
+	}
+	return writer
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/natural"
+)
+
+// fromUsage is the help text of --from flags, given what the time is
+const fromUsage = "%s: an RFC3339 time or a relative one such as now+2h, 'tomorrow 09:00' or monday (defaults to current time)"
+
+// parseTimeFlag parses the value of a time flag such as --from, relative to
+// the current time in loc (see natural.ParseTime), and returns it in loc
+func parseTimeFlag(name, value string, loc *time.Location) (time.Time, error) {
+	t, err := natural.ParseTime(value, time.Now().In(loc))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s time: %w", name, err)
+	}
+	return t.In(loc), nil
+}
@@ -3,29 +3,42 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
 	"github.com/hzerrad/cronkit/internal/human"
 	"github.com/hzerrad/cronkit/internal/render"
+	"github.com/hzerrad/cronkit/internal/schema"
 	"github.com/spf13/cobra"
 )
 
 // TimelineCommand wraps cobra.Command with timeline-specific functionality
 type TimelineCommand struct {
 	*cobra.Command
-	file         string
-	json         bool
-	view         string
-	from         string
-	width        int
-	timezone     string
-	export       string
-	locale       string
-	showOverlaps bool
+	file            string
+	system          bool
+	host            string
+	json            bool
+	outputSchema    bool
+	view            string
+	from            string
+	width           int
+	timezone        string
+	export          string
+	exportFormat    string
+	locale          string
+	showOverlaps    bool
+	seconds         bool
+	defaultDuration time.Duration
+	dialect         string
+	jenkinsJob      string
+	skipInvalid     bool
 }
 
 func init() {
@@ -46,42 +59,72 @@ This command helps visualize cron schedules over time, making it easy to see whe
 and identify potential conflicts or resource contention.
 
 Supports:
-  - Single cron expression (provided as argument)
+  - Single cron expression (provided as argument, 5 fields or 6 with seconds)
   - Crontab file (via --file flag)
   - User's crontab (default when no argument or --file provided)
   - Day view (24 hours, default) or hour view (60 minutes) via --view flag
+  - Week view (Monday to Sunday) and month view via --view week|month: a grid
+    of days by hours shaded by the number of runs, with the free hours and
+    the longest free window, for finding maintenance slots
   - JSON output with --json flag for programmatic use
+  - Job durations: a '# cronkit:duration=15m' comment on or above a job (or
+    --default-duration) makes its runs last that long, so --show-overlaps
+    reports jobs that are still running when others start
+  - SVG and PNG images with --export-format svg|png (or an --export path
+    ending in .svg or .png), with one lane per job; hovering a run in the
+    SVG shows the job's description and run time
+
+Invalid crontab lines are skipped and listed as warnings after the timeline;
+use --skip-invalid=false to abort on the first invalid line instead.
 
 Examples:
   cronkit timeline "*/15 * * * *"              # Timeline for single expression
   cronkit timeline --file /etc/crontab          # Timeline for crontab file
+  cronkit timeline --host                        # All cron, anacron and run-parts jobs of the host
   cronkit timeline "*/5 * * * *" --view hour    # Hour view timeline
+  cronkit timeline --file /etc/crontab --view week  # Weekly grid of runs per hour
   cronkit timeline --file jobs.cron --json       # JSON output
+  cronkit timeline --file jobs.cron --show-overlaps --default-duration 5m
+  cronkit timeline --file jobs.cron --export timeline.svg  # SVG image for a wiki page
   cronkit timeline                               # Timeline for user's crontab`,
 	}
 
 	tc.Command.Flags().StringVarP(&tc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	tc.Command.Flags().BoolVar(&tc.system, "system", false, systemUsage)
+	tc.Command.Flags().StringVar(&tc.host, "host", "", hostUsage)
+	tc.Command.Flags().Lookup("host").NoOptDefVal = defaultHostDir
 	tc.Command.Flags().BoolVarP(&tc.json, "json", "j", false, "Output in JSON format")
-	tc.Command.Flags().StringVar(&tc.view, "view", "day", "Timeline view type: 'day' (24 hours) or 'hour' (60 minutes, default: 'day')")
-	tc.Command.Flags().StringVar(&tc.from, "from", "", "Start time for timeline (RFC3339 format, defaults to current time)")
+	tc.Command.Flags().BoolVar(&tc.outputSchema, "output-schema", false, outputSchemaUsage)
+	tc.Command.Flags().StringVar(&tc.view, "view", "day", "Timeline view type: 'day' (24 hours), 'hour' (60 minutes), 'week' (7 days by hour) or 'month' (default: 'day')")
+	tc.Command.Flags().StringVar(&tc.from, "from", "", fmt.Sprintf(fromUsage, "Start time for timeline"))
 	tc.Command.Flags().IntVar(&tc.width, "width", 0, "Terminal width (0 = auto-detect, defaults to 80 if detection fails)")
 	tc.Command.Flags().StringVar(&tc.timezone, "timezone", "", "Timezone for timeline (e.g., 'America/New_York', 'UTC', defaults to local timezone)")
-	tc.Command.Flags().StringVar(&tc.export, "export", "", "Export timeline to file (format determined by extension: .txt, .json)")
+	tc.Command.Flags().StringVar(&tc.export, "export", "", "Export timeline to file (format determined by extension: .txt, .json, .svg, .png)")
+	tc.Command.Flags().StringVar(&tc.exportFormat, "export-format", "", "Render the timeline as an image: 'svg' or 'png' (written to --export, or standard output)")
 	tc.Command.Flags().BoolVar(&tc.showOverlaps, "show-overlaps", false, "Show detailed overlap information in output")
+	tc.Command.Flags().DurationVar(&tc.defaultDuration, "default-duration", 0, defaultDurationUsage)
+	tc.Command.Flags().BoolVar(&tc.seconds, "seconds", false, "Require a leading seconds field (6-field expressions are detected automatically)")
+	tc.Command.Flags().StringVar(&tc.dialect, "dialect", "standard", dialectUsage)
+	tc.Command.Flags().StringVar(&tc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+	tc.Command.Flags().BoolVar(&tc.skipInvalid, "skip-invalid", true, skipInvalidUsage)
 
 	return tc
 }
 
 func (tc *TimelineCommand) runTimeline(_ *cobra.Command, args []string) error {
+	if tc.outputSchema {
+		return writeSchema(tc.OutOrStdout(), "timeline")
+	}
+
+	imageFormat, err := tc.imageFormat()
+	if err != nil {
+		return err
+	}
+
 	// Determine timeline view
-	var timelineView render.TimelineView
-	switch tc.view {
-	case "day":
-		timelineView = render.DayView
-	case "hour":
-		timelineView = render.HourView
-	default:
-		return fmt.Errorf("invalid view type: %s (must be 'day' or 'hour')", tc.view)
+	timelineView, err := render.ParseView(tc.view)
+	if err != nil {
+		return err
 	}
 
 	// Determine timezone
@@ -97,19 +140,15 @@ func (tc *TimelineCommand) runTimeline(_ *cobra.Command, args []string) error {
 	// Determine start time
 	startTime := time.Now().In(loc)
 	if tc.from != "" {
-		parsed, err := time.Parse(time.RFC3339, tc.from)
+		parsed, err := parseTimeFlag("from", tc.from, loc)
 		if err != nil {
-			return fmt.Errorf("invalid --from time format: %w (expected RFC3339)", err)
+			return err
 		}
-		startTime = parsed.In(loc)
+		startTime = parsed
 	}
 
 	// Round down start time based on view
-	if timelineView == render.DayView {
-		startTime = time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location())
-	} else {
-		startTime = time.Date(startTime.Year(), startTime.Month(), startTime.Day(), startTime.Hour(), 0, 0, 0, startTime.Location())
-	}
+	startTime = render.ViewStart(timelineView, startTime)
 
 	// Determine width (auto-detect if not specified)
 	width := detectTerminalWidth()
@@ -122,6 +161,8 @@ func (tc *TimelineCommand) runTimeline(_ *cobra.Command, args []string) error {
 
 	// Create timeline
 	timeline := render.NewTimeline(timelineView, startTime, width)
+	format := getTimeFormat()
+	timeline.SetTimeFormat(format.timestamp, format.clock)
 
 	// Get locale
 	locale := GetLocale()
@@ -131,12 +172,20 @@ func (tc *TimelineCommand) runTimeline(_ *cobra.Command, args []string) error {
 
 	// Parse jobs
 	var jobs []*crontab.Job
-	var err error
+	var skipped []crontab.SkippedLine
+	var opts cronx.ParserOptions
 
+	if len(args) > 0 && tc.host != "" {
+		return fmt.Errorf("--host cannot be used with an expression")
+	}
 	if len(args) > 0 {
 		// Single expression provided
 		expression := args[0]
-		parser := cronx.NewParserWithLocale(locale)
+		opts, err = parserOptions(tc.seconds, tc.dialect, tc.jenkinsJob)
+		if err != nil {
+			return err
+		}
+		parser := cronx.NewParserWithOptions(locale, opts)
 		_, err = parser.Parse(expression)
 		if err != nil {
 			return fmt.Errorf("invalid cron expression: %w", err)
@@ -153,85 +202,68 @@ func (tc *TimelineCommand) runTimeline(_ *cobra.Command, args []string) error {
 		}
 	} else {
 		// Read from file or user crontab
-		reader := crontab.NewReader()
-		if tc.file != "" {
-			jobs, err = reader.ReadFile(tc.file)
+		reader := newCrontabReader(tc.system)
+		var entries []*crontab.Entry
+		if tc.host != "" {
+			if tc.file != "" {
+				return fmt.Errorf("--host cannot be used with --file")
+			}
+			sources, err := readHost(tc.host)
+			if err != nil {
+				return err
+			}
+			for _, source := range sources {
+				entries = append(entries, source.Entries...)
+			}
+		} else if tc.file != "" {
+			entries, err = reader.ParseFile(tc.file)
 			if err != nil {
 				return fmt.Errorf("failed to read crontab file: %w", err)
 			}
 		} else {
-			jobs, err = reader.ReadUser()
+			userJobs, err := reader.ReadUser()
 			if err != nil {
 				return fmt.Errorf("failed to read user crontab: %w", err)
 			}
+			entries = crontab.JobEntries(userJobs)
 		}
-	}
-
-	// Process jobs and add runs to timeline
-	parser := cronx.NewParserWithLocale(locale)
-	humanizer := human.NewHumanizer()
-	scheduler := cronx.NewScheduler()
-
-	// Calculate how many runs to get based on view
-	var runCount int
-	var timeRange time.Duration
-	if timelineView == render.DayView {
-		timeRange = 24 * time.Hour // Using literal for comparison, OneDay constant is in stats package
-		runCount = 200             // Enough to cover a day for most schedules
-	} else {
-		timeRange = time.Hour
-		runCount = 100 // Enough to cover an hour for most schedules
-	}
-
-	for _, job := range jobs {
-		if !job.Valid {
-			continue
-		}
-
-		// Parse expression
-		schedule, err := parser.Parse(job.Expression)
+		jobs, skipped, err = partitionEntries(entries, tc.skipInvalid)
 		if err != nil {
-			continue // Skip invalid expressions
+			return err
 		}
+	}
 
-		// Get human description
-		description := humanizer.Humanize(schedule)
-
-		// Generate job ID
-		jobID := fmt.Sprintf("job-%d", job.LineNumber)
-		if job.LineNumber == 0 {
-			jobID = fmt.Sprintf("expr-%s", job.Expression)
-		}
+	if err := applyDefaultDuration(jobs, tc.defaultDuration); err != nil {
+		return err
+	}
 
-		// Set job info
-		timeline.SetJobInfo(jobID, job.Expression, description)
+	// Process jobs and add runs to timeline
+	parser := cronx.NewParserWithOptions(locale, opts)
+	humanizer := human.NewHumanizerWithOptions(human.Options{Locale: locale, Clock: format.human})
+	scheduler := cronx.WithContext(tc.Context(), cronx.NewSchedulerWithOptions(opts))
 
-		// Calculate next runs
-		times, err := scheduler.Next(job.Expression, startTime, runCount)
-		if err != nil {
-			continue // Skip if we can't calculate runs
-		}
+	if err := timeline.AddJobs(jobs, parser, humanizer, scheduler); err != nil {
+		return err
+	}
+	if err := contextErr(tc.Command); err != nil {
+		return err
+	}
 
-		// Add runs that fall within the timeline range
-		endTime := startTime.Add(timeRange)
-		for _, runTime := range times {
-			if runTime.Before(endTime) && !runTime.Before(startTime) {
-				timeline.AddJobRun(jobID, runTime)
-			}
-			// Stop if we've gone past the end time
-			if !runTime.Before(endTime) {
-				break
-			}
-		}
+	if imageFormat != "" {
+		return tc.exportImage(timeline, imageFormat)
 	}
 
 	// Output based on format
 	var output string
 	if tc.json {
 		result := timeline.RenderJSON()
-		// Add timezone and locale to JSON output
+		// Add schema version, timezone and locale to JSON output
+		result["schemaVersion"] = schema.Version
 		result["timezone"] = loc.String()
 		result["locale"] = locale
+		if skipped != nil {
+			result["skipped"] = skipped
+		}
 
 		// If exporting JSON, write to file, otherwise to stdout
 		if tc.export != "" {
@@ -261,17 +293,19 @@ func (tc *TimelineCommand) runTimeline(_ *cobra.Command, args []string) error {
 	// Text output
 	output = timeline.Render(tc.showOverlaps)
 
-	// Handle export if specified
+	// Handle export if specified, without colors
 	if tc.export != "" {
 		if err := tc.exportTimeline(output, timeline); err != nil {
 			return fmt.Errorf("failed to export timeline: %w", err)
 		}
-		// Also print to stdout when exporting
-		tc.Print(output)
-	} else {
-		// Normal output
-		tc.Print(output)
 	}
+	// Also print to stdout when exporting
+	if painter := painterFor(tc.OutOrStderr()); painter != nil {
+		timeline.SetPainter(painter)
+		output = timeline.Render(tc.showOverlaps)
+	}
+	tc.Print(output)
+	printSkipped(tc.Command, skipped)
 
 	return nil
 }
@@ -308,3 +342,56 @@ func (tc *TimelineCommand) exportTimeline(textOutput string, timeline *render.Ti
 
 	return nil
 }
+
+// Image formats of --export-format
+const (
+	timelineImageSVG = "svg"
+	timelineImagePNG = "png"
+)
+
+// imageFormat returns the image format to render, from --export-format or
+// the extension of --export, or "" for text and JSON output
+func (tc *TimelineCommand) imageFormat() (string, error) {
+	switch format := strings.ToLower(tc.exportFormat); format {
+	case timelineImageSVG, timelineImagePNG:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid --export-format value %q (supported: svg, png)", tc.exportFormat)
+	}
+
+	switch strings.ToLower(filepath.Ext(tc.export)) {
+	case ".svg":
+		return timelineImageSVG, nil
+	case ".png":
+		return timelineImagePNG, nil
+	}
+	return "", nil
+}
+
+// exportImage renders the timeline as an image to --export, or to standard
+// output when no path is given
+func (tc *TimelineCommand) exportImage(timeline *render.Timeline, format string) error {
+	var w io.Writer = tc.OutOrStdout()
+	if tc.export != "" {
+		file, err := os.Create(tc.export)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		w = file
+	}
+
+	var err error
+	if format == timelineImagePNG {
+		err = render.NewPNGTimeline(timeline).Render(w)
+	} else {
+		err = render.NewSVGTimeline(timeline).Render(w)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render %s timeline: %w", format, err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/testutil"
+)
+
+// Jobs are computed on up to GOMAXPROCS workers; compare with
+// go test -run '^$' -bench=1kJobs -cpu 1,4 ./internal/cmd
+
+func BenchmarkTimeline_1kJobs(b *testing.B) {
+	file := filepath.Join(b.TempDir(), "large.cron")
+	if err := os.WriteFile(file, []byte(testutil.GenerateCrontab(1000)), 0o644); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tc := newTimelineCommand()
+		tc.SetOut(io.Discard)
+		tc.SetArgs([]string{"--file", file, "--json"})
+		if err := tc.Execute(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
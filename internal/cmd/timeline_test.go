@@ -3,8 +3,10 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +41,17 @@ func TestTimelineCommand(t *testing.T) {
 		assert.Contains(t, output, "Every 15 minutes") // Check for humanized description
 	})
 
+	t.Run("timeline with a seconds field", func(t *testing.T) {
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"*/30 * * * * *", "--view", "hour", "--json"})
+
+		err := tc.Execute()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Every 30 seconds")
+	})
+
 	t.Run("timeline with --view hour", func(t *testing.T) {
 		tc := newTimelineCommand()
 		buf := new(bytes.Buffer)
@@ -169,6 +182,37 @@ func TestTimelineCommand(t *testing.T) {
 		assert.Equal(t, "hour", result["view"])
 	})
 
+	t.Run("timeline with --view week", func(t *testing.T) {
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"0 2 * * 1-5", "--view", "week", "--from", "2026-10-16T10:00:00Z", "--timezone", "UTC"})
+
+		require.NoError(t, tc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "Timeline for 2026-10-12 to 2026-10-18 (Week View)")
+		assert.Contains(t, output, "Fri 10-16  · · █")
+		assert.Contains(t, output, "Free hours: 163 of 168")
+	})
+
+	t.Run("timeline with --view month JSON output", func(t *testing.T) {
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"*/30 * * * *", "--view", "month", "--from", "2026-02-10T10:00:00Z", "--timezone", "UTC", "--json"})
+
+		require.NoError(t, tc.Execute())
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "month", result["view"])
+		assert.Equal(t, "2026-02-01T00:00:00Z", result["startTime"])
+		days := result["days"].([]interface{})
+		require.Len(t, days, 28)
+		// Every hour of the month has two runs
+		hours := days[27].(map[string]interface{})["hours"].([]interface{})
+		assert.Equal(t, 2.0, hours[23])
+	})
+
 	t.Run("timeline detects overlaps", func(t *testing.T) {
 		// Create jobs that run at the same time
 		tempFile := createTempCrontab(t, "0 * * * * /usr/bin/job1.sh\n0 * * * * /usr/bin/job2.sh\n")
@@ -244,6 +288,29 @@ func TestTimelineCommand(t *testing.T) {
 		assert.Contains(t, output, "Overlap Summary")
 	})
 
+	t.Run("timeline overlaps with job durations", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "crontab")
+		require.NoError(t, os.WriteFile(file, []byte("0 2 * * * /usr/bin/backup.sh # cronkit:duration=20m\n10 2 * * * /usr/bin/report.sh\n"), 0o644))
+
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--file", file, "--show-overlaps", "--from", "2026-01-01T00:00:00Z", "--timezone", "UTC"})
+
+		require.NoError(t, tc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "Total overlap windows: 1")
+		assert.Contains(t, output, "2026-01-01 02:10:00: 2 job(s) (job-1, job-2)")
+
+		tc = newTimelineCommand()
+		buf = new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--file", file, "--show-overlaps", "--from", "2026-01-01T00:00:00Z", "--timezone", "UTC", "--default-duration", "5m"})
+
+		require.NoError(t, tc.Execute())
+		assert.Contains(t, buf.String(), "Total overlap windows: 5", "the report runs 02:10-02:14, during the backup")
+	})
+
 	t.Run("timeline without --show-overlaps flag (backward compatibility)", func(t *testing.T) {
 		tc := newTimelineCommand()
 		buf := new(bytes.Buffer)
@@ -441,6 +508,24 @@ func TestTimelineCommand(t *testing.T) {
 		assert.Contains(t, output, "Timeline")
 	})
 
+	t.Run("timeline with CRON_TZ in a crontab file", func(t *testing.T) {
+		tempFile := createTempCrontab(t, "0 9 * * * /usr/bin/utc.sh\nCRON_TZ=Asia/Tokyo\n0 12 * * * /usr/bin/tokyo.sh\n")
+		defer func() {
+			_ = os.Remove(tempFile)
+		}()
+
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--file", tempFile, "--timezone", "UTC", "--from", "2026-01-05T00:00:00Z", "--json"})
+
+		err := tc.Execute()
+		require.NoError(t, err)
+		output := buf.String()
+		assert.Contains(t, output, `"time": "2026-01-05T09:00:00Z"`)
+		assert.Contains(t, output, `"time": "2026-01-05T03:00:00Z"`, "12:00 in Tokyo is 03:00 UTC")
+	})
+
 	t.Run("timeline export with text format and show-overlaps", func(t *testing.T) {
 		tempFile := createTempCrontab(t, "")
 		defer func() {
@@ -693,6 +778,67 @@ func TestTimelineCommand_Export(t *testing.T) {
 	})
 }
 
+func TestTimelineCommand_ImageExport(t *testing.T) {
+	t.Run("should export SVG by file extension", func(t *testing.T) {
+		exportFile := filepath.Join(t.TempDir(), "timeline.svg")
+
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"0 2 * * *", "--from", "2026-10-16T00:00:00Z", "--timezone", "UTC", "--export", exportFile})
+
+		require.NoError(t, tc.Execute())
+		assert.Empty(t, buf.String())
+
+		content, err := os.ReadFile(exportFile)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "<svg "))
+		assert.Contains(t, string(content), "<title>At 02:00")
+	})
+
+	t.Run("should write PNG to stdout with --export-format", func(t *testing.T) {
+		tc := newTimelineCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"*/15 * * * *", "--export-format", "png"})
+
+		require.NoError(t, tc.Execute())
+		img, err := png.Decode(buf)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, img.Bounds().Dx())
+	})
+
+	t.Run("should prefer --export-format over the extension", func(t *testing.T) {
+		exportFile := filepath.Join(t.TempDir(), "timeline.out")
+
+		tc := newTimelineCommand()
+		tc.SetArgs([]string{"*/15 * * * *", "--export-format", "SVG", "--export", exportFile})
+
+		require.NoError(t, tc.Execute())
+		content, err := os.ReadFile(exportFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "<svg ")
+	})
+
+	t.Run("should reject an unknown export format", func(t *testing.T) {
+		tc := newTimelineCommand()
+		tc.SetArgs([]string{"*/15 * * * *", "--export-format", "gif"})
+
+		err := tc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --export-format")
+	})
+
+	t.Run("should handle image export file creation error", func(t *testing.T) {
+		tc := newTimelineCommand()
+		tc.SetArgs([]string{"*/15 * * * *", "--export", "/nonexistent/directory/timeline.png"})
+
+		err := tc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create export file")
+	})
+}
+
 func TestTimelineCommand_JSONExportError(t *testing.T) {
 	t.Run("should handle JSON export file creation error", func(t *testing.T) {
 		// Test the error path when creating JSON export file fails (line 238-240)
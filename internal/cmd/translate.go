@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/translate"
+	"github.com/spf13/cobra"
+)
+
+// TranslateCommand wraps cobra.Command with translate functionality
+type TranslateCommand struct {
+	*cobra.Command
+	from       string
+	to         string
+	jenkinsJob string
+	json       bool
+}
+
+func newTranslateCommand() *TranslateCommand {
+	tc := &TranslateCommand{}
+	tc.Command = &cobra.Command{
+		Use:   "translate <expression>",
+		Short: "Translate a cron expression between dialects",
+		Long: `Translate a cron expression from one cron dialect to another, such as a
+Spring @Scheduled expression to a Vixie crontab schedule.
+
+Dialects differ in subtle ways: Spring and Quartz lead with a seconds field,
+Quartz numbers days of the week 1-7 from Sunday, Spring names them MON-SUN
+1-7, and Vixie cron runs a job when either day field matches while Quartz,
+Spring, node-cron and cron4j require both. Such differences are translated
+exactly: a Vixie schedule restricting both day fields becomes one expression
+per day field in dialects that require both.
+
+Constructs the target dialect cannot express, such as L, W and # in Vixie
+cron, seconds in cron4j or years in Spring, are approximated by fields that
+run at least as often, and a warning describes each approximation.
+
+Examples:
+  cronkit translate --from spring --to vixie "0 0 9 * * MON-FRI"
+  cronkit translate --from vixie --to spring "0 12 1 * 1"
+  cronkit translate --from quartz --to node-cron "0 0 12 ? * FRI#3"
+  cronkit translate --from cron4j --to quartz "0 5 * * * | 0 12 L * *"
+  cronkit translate --from spring --to cron4j "0 0 12 L * *" --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: tc.runTranslate,
+	}
+
+	tc.Flags().StringVar(&tc.from, "from", "standard", "Cron dialect of the expression: standard (vixie), quartz, jenkins, aws, spring, node-cron or cron4j")
+	tc.Flags().StringVar(&tc.to, "to", "standard", "Cron dialect to translate to: standard (vixie), quartz, jenkins, spring, node-cron or cron4j")
+	tc.Flags().StringVar(&tc.jenkinsJob, "jenkins-job", "", jenkinsJobUsage)
+	tc.Flags().BoolVarP(&tc.json, "json", "j", false, "Output in JSON format")
+	return tc
+}
+
+func init() {
+	rootCmd.AddCommand(newTranslateCommand().Command)
+}
+
+// TranslateResult is the JSON output of the translate command
+type TranslateResult struct {
+	From         string   `json:"from"`
+	To           string   `json:"to"`
+	Input        string   `json:"input"`
+	Output       []string `json:"output"`
+	Descriptions []string `json:"descriptions"`
+	Warnings     []string `json:"warnings"`
+	Exact        bool     `json:"exact"`
+}
+
+func (tc *TranslateCommand) runTranslate(_ *cobra.Command, args []string) error {
+	from, err := cronx.ParseDialect(tc.from)
+	if err != nil {
+		return fmt.Errorf("invalid --from value: %w", err)
+	}
+	to, err := cronx.ParseDialect(tc.to)
+	if err != nil {
+		return fmt.Errorf("invalid --to value: %w", err)
+	}
+
+	if to == cronx.DialectAWS {
+		return fmt.Errorf("translate does not write EventBridge expressions; use cronkit convert --to aws")
+	}
+
+	conversion, err := translate.Translate(args[0], from, to, tc.jenkinsJob)
+	if err != nil {
+		return fmt.Errorf("failed to translate expression: %w", err)
+	}
+
+	// Describe the output as the target dialect reads it
+	parser := cronx.NewParserWithOptions(GetLocale(), cronx.ParserOptions{Seconds: cronx.SecondsOptional, Dialect: to})
+	humanizer := newHumanizer()
+	result := TranslateResult{
+		From:     string(from),
+		To:       string(to),
+		Input:    conversion.Input,
+		Output:   conversion.Output,
+		Warnings: conversion.Warnings,
+		Exact:    len(conversion.Warnings) == 0,
+	}
+	for _, expression := range conversion.Output {
+		description := ""
+		if schedule, err := parser.Parse(expression); err == nil {
+			description = humanizer.Humanize(schedule)
+		}
+		result.Descriptions = append(result.Descriptions, description)
+	}
+
+	if tc.json {
+		if result.Warnings == nil {
+			result.Warnings = []string{}
+		}
+		encoder := json.NewEncoder(tc.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	for _, expression := range result.Output {
+		tc.Println(expression)
+	}
+	for _, description := range result.Descriptions {
+		if description != "" {
+			tc.Printf("# %s\n", description)
+		}
+	}
+	for _, warning := range result.Warnings {
+		tc.Printf("⚠ WARNING: %s\n", warning)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateCommand(t *testing.T) {
+	t.Run("translate command should be registered", func(t *testing.T) {
+		cmd, _, err := rootCmd.Find([]string{"translate"})
+		assert.NoError(t, err)
+		assert.Equal(t, "translate", cmd.Name())
+	})
+
+	t.Run("spring to vixie", func(t *testing.T) {
+		tc := newTranslateCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--from", "spring", "--to", "vixie", "0 0 9 * * MON-FRI"})
+
+		require.NoError(t, tc.Execute())
+		assert.Equal(t, "0 9 * * 1-5\n# At 09:00 on weekdays (Mon-Fri)\n", buf.String())
+	})
+
+	t.Run("untranslatable constructs are warned about", func(t *testing.T) {
+		tc := newTranslateCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--from", "quartz", "0 0 12 L * ?"})
+
+		require.NoError(t, tc.Execute())
+		output := buf.String()
+		assert.Contains(t, output, "0 12 28-31 * *\n")
+		assert.Contains(t, output, "⚠ WARNING: standard has no L (last day of the month)")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		tc := newTranslateCommand()
+		buf := new(bytes.Buffer)
+		tc.SetOut(buf)
+		tc.SetArgs([]string{"--from", "vixie", "--to", "spring", "0 12 1 * 1", "--json"})
+
+		require.NoError(t, tc.Execute())
+		var result TranslateResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, "standard", result.From)
+		assert.Equal(t, "spring", result.To)
+		assert.Equal(t, []string{"0 0 12 1 * *", "0 0 12 * * 1"}, result.Output)
+		assert.Len(t, result.Descriptions, 2)
+		assert.Equal(t, []string{}, result.Warnings)
+		assert.True(t, result.Exact)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			args       []string
+			errContain string
+		}{
+			{[]string{"--from", "launchd", "0 0 * * *"}, "invalid --from value"},
+			{[]string{"--to", "vixen", "0 0 * * *"}, "invalid --to value"},
+			{[]string{"--to", "aws", "0 0 * * *"}, "use cronkit convert --to aws"},
+			{[]string{"--from", "spring", "0 0 * * *"}, "invalid spring expression"},
+		}
+		for _, tt := range tests {
+			tc := newTranslateCommand()
+			tc.SetOut(new(bytes.Buffer))
+			tc.SetErr(new(bytes.Buffer))
+			tc.SetArgs(tt.args)
+
+			err := tc.Execute()
+			require.Error(t, err, tt.args)
+			assert.Contains(t, err.Error(), tt.errContain, tt.args)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/diff"
+	"github.com/hzerrad/cronkit/internal/notify"
+	"github.com/hzerrad/cronkit/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+type WatchCommand struct {
+	*cobra.Command
+	file          string
+	interval      time.Duration
+	overlapWindow string
+}
+
+func newWatchCommand() *WatchCommand {
+	wc := &WatchCommand{}
+	wc.Command = &cobra.Command{
+		Use:   "watch",
+		Short: "Re-validate a crontab every time it changes",
+		Long: `Watch a crontab file (or the current user's crontab) and report every change.
+
+On each change, the differences from the previous version are shown as a
+semantic diff, the crontab is run through the same checks as 'cronkit check'
+(only changed lines are re-checked), and the overlap statistics of the next
+--overlap-window are printed next to their previous values.
+
+When the "notifications" section of the config file configures Slack, a
+webhook or email, a change that brings new warnings or errors (regression)
+or more jobs running at once (overlap) is also sent there.
+
+Files are watched through file system notifications, so changes made by
+editors that replace the file are seen too. The user's crontab is read with
+'crontab -l' every --interval. Press Ctrl+C to stop.
+
+Examples:
+  cronkit watch --file /etc/crontab
+  cronkit watch                          # Watch the current user's crontab
+  cronkit watch --interval 10s --overlap-window 1h`,
+		Args: cobra.NoArgs,
+		RunE: wc.runWatch,
+	}
+
+	wc.Flags().StringVarP(&wc.file, "file", "f", "", "Path to crontab file (defaults to user's crontab if not specified)")
+	wc.Flags().DurationVar(&wc.interval, "interval", watch.DefaultInterval, "How often to read the user's crontab (ignored with --file)")
+	wc.Flags().StringVar(&wc.overlapWindow, "overlap-window", "24h", "Time window for overlap statistics (e.g., 1h, 24h, 48h)")
+	return wc
+}
+
+func init() {
+	rootCmd.AddCommand(newWatchCommand().Command)
+}
+
+func (wc *WatchCommand) runWatch(cmd *cobra.Command, _ []string) error {
+	window, err := time.ParseDuration(wc.overlapWindow)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid --overlap-window value %q: must be a positive duration (e.g., 1h, 24h)", wc.overlapWindow)
+	}
+
+	notifier, err := notify.New(notifications)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if wc.file != "" {
+		wc.Printf("Watching %s for changes (Ctrl+C to stop)\n", wc.file)
+		return watch.File(ctx, wc.file, watch.Options{}, wc.handler(ctx, window, notifier))
+	}
+
+	if wc.interval <= 0 {
+		return fmt.Errorf("invalid --interval value: must be positive")
+	}
+	wc.Println("Watching the user crontab for changes (Ctrl+C to stop)")
+	return watch.Poll(ctx, crontab.ReadUserRaw, watch.Options{Interval: wc.interval}, wc.handler(ctx, window, notifier))
+}
+
+// handler returns a watch handler that reports each version of the crontab
+// against the previous one, sending regressions to notifier
+func (wc *WatchCommand) handler(ctx context.Context, window time.Duration, notifier *notify.Notifier) watch.Handler {
+	validator := check.NewIncrementalValidator(check.NewValidator(GetLocale()))
+	scheduler := cronx.NewScheduler()
+	parser := cronx.NewParserWithLocale(GetLocale())
+
+	var (
+		previous []*crontab.Entry
+		issues   []check.Issue
+		overlaps *check.OverlapStats
+	)
+
+	return func(content string, err error) {
+		stamp := time.Now().Format("15:04:05")
+		if err != nil {
+			wc.Printf("\n[%s] ✗ %v\n", stamp, err)
+			return
+		}
+
+		entries, err := crontab.ParseReader(strings.NewReader(content))
+		if err != nil {
+			wc.Printf("\n[%s] ✗ failed to parse crontab: %v\n", stamp, err)
+			return
+		}
+
+		changed := previous != nil
+		if !changed {
+			wc.Printf("\n[%s] Loaded %d job(s)\n", stamp, len(jobsFromEntries(entries)))
+		} else {
+			wc.Printf("\n[%s] Crontab changed\n", stamp)
+			if err := wc.renderDiff(previous, entries); err != nil {
+				wc.Printf("✗ %v\n", err)
+			}
+		}
+		previous = entries
+
+		result, err := validator.ValidateReader(strings.NewReader(content))
+		if err != nil {
+			wc.Printf("✗ %v\n", err)
+			return
+		}
+		printIssues(wc.OutOrStderr(), result.Issues, check.SeverityError)
+		if added := newIssues(issues, result.Issues); changed && len(added) > 0 {
+			wc.notify(ctx, notifier, regressionEvent(added))
+		}
+		issues = result.Issues
+
+		_, stats, err := check.AnalyzeOverlaps(jobsFromEntries(entries), window, scheduler, parser)
+		if err != nil {
+			return
+		}
+		wc.Printf("\nOverlaps (next %s): %s", wc.overlapWindow, describeOverlaps(stats))
+		if overlaps != nil && (overlaps.MaxConcurrent != stats.MaxConcurrent || overlaps.TotalWindows != stats.TotalWindows) {
+			wc.Printf(" (was: %s)", describeOverlaps(*overlaps))
+		}
+		wc.Println()
+		if overlaps != nil && (stats.MaxConcurrent > overlaps.MaxConcurrent || stats.TotalWindows > overlaps.TotalWindows) {
+			wc.notify(ctx, notifier, notify.Event{
+				Kind:    notify.EventOverlap,
+				Title:   "More jobs overlap after a crontab change",
+				Details: fmt.Sprintf("Overlaps (next %s): %s (was: %s)", wc.overlapWindow, describeOverlaps(stats), describeOverlaps(*overlaps)),
+			})
+		}
+		overlaps = &stats
+	}
+}
+
+// notify sends event about the watched crontab, printing delivery errors
+func (wc *WatchCommand) notify(ctx context.Context, notifier *notify.Notifier, event notify.Event) {
+	event.Source = "watch"
+	event.Crontab = wc.file
+	if err := notifier.Notify(ctx, event); err != nil {
+		wc.Printf("✗ %v\n", err)
+	}
+}
+
+// newIssues returns the warnings and errors of current that previous does
+// not have. Issues are compared without their line numbers, so that moving
+// a job is not a regression.
+func newIssues(previous, current []check.Issue) []check.Issue {
+	type key struct{ code, expression, message string }
+	seen := make(map[key]int)
+	for _, issue := range previous {
+		seen[key{issue.Code, issue.Expression, issue.Message}]++
+	}
+	var added []check.Issue
+	for _, issue := range current {
+		k := key{issue.Code, issue.Expression, issue.Message}
+		if seen[k] > 0 {
+			seen[k]--
+			continue
+		}
+		if issue.Severity >= check.SeverityWarn {
+			added = append(added, issue)
+		}
+	}
+	return added
+}
+
+// regressionEvent returns the notification of new validation issues
+func regressionEvent(issues []check.Issue) notify.Event {
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		lines = append(lines, fmt.Sprintf("[%s] Line %d: %s", issue.Code, issue.LineNumber, issue.Message))
+	}
+	return notify.Event{
+		Kind:       notify.EventRegression,
+		Title:      fmt.Sprintf("%d new validation issue(s) after a crontab change", len(issues)),
+		Details:    strings.Join(lines, "\n"),
+		Line:       issues[0].LineNumber,
+		Expression: issues[0].Expression,
+	}
+}
+
+func (wc *WatchCommand) renderDiff(old, new []*crontab.Entry) error {
+	renderer, err := diff.NewRenderer("text")
+	if err != nil {
+		return fmt.Errorf("failed to create renderer: %w", err)
+	}
+	if err := renderer.Render(wc.OutOrStdout(), diff.CompareCrontabs(old, new), &diff.RenderOptions{}); err != nil {
+		return fmt.Errorf("failed to render diff: %w", err)
+	}
+	return nil
+}
+
+// jobsFromEntries returns the jobs of entries
+func jobsFromEntries(entries []*crontab.Entry) []*crontab.Job {
+	jobs := make([]*crontab.Job, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	return jobs
+}
+
+// describeOverlaps summarizes overlap statistics in one phrase
+func describeOverlaps(stats check.OverlapStats) string {
+	if stats.TotalWindows == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("up to %d jobs at once, in %d minute(s)", stats.MaxConcurrent, stats.TotalWindows)
+}
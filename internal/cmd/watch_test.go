@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for use by a running command and a test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchCommand(t *testing.T) {
+	t.Run("reports changes to a file", func(t *testing.T) {
+		path := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+
+		wc := newWatchCommand()
+		out := &syncBuffer{}
+		wc.SetOut(out)
+		wc.SetArgs([]string{"--file", path})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- wc.ExecuteContext(ctx) }()
+
+		require.Eventually(t, func() bool { return strings.Contains(out.String(), "Overlaps") }, 5*time.Second, 10*time.Millisecond)
+		assert.Contains(t, out.String(), "Loaded 1 job(s)")
+		assert.Contains(t, out.String(), "✓ All valid")
+		assert.Contains(t, out.String(), "Overlaps (next 24h): none")
+
+		require.NoError(t, os.WriteFile(path, []byte("0 2 * * * /usr/bin/backup.sh\n0 2 * * * /usr/bin/report.sh\n61 * * * * /usr/bin/broken.sh\n"), 0644))
+		require.Eventually(t, func() bool { return strings.Contains(out.String(), "(was: none)") }, 5*time.Second, 10*time.Millisecond)
+
+		output := out.String()
+		assert.Contains(t, output, "Crontab changed")
+		assert.Contains(t, output, "+ 0 2 * * *  /usr/bin/report.sh")
+		assert.Contains(t, output, "[CRON-003] Line 3")
+		assert.Contains(t, output, "up to 2 jobs at once, in 1 minute(s) (was: none)")
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("notifies regressions and new overlaps", func(t *testing.T) {
+		messages := make(chan string, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct{ Text string }
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			messages <- body.Text
+		}))
+		defer server.Close()
+		notifications = notify.Config{Slack: &notify.SlackConfig{WebhookURL: server.URL}}
+		t.Cleanup(func() { notifications = notify.Config{} })
+
+		path := createTempFile(t, "0 2 * * * /usr/bin/backup.sh\n")
+		wc := newWatchCommand()
+		out := &syncBuffer{}
+		wc.SetOut(out)
+		wc.SetArgs([]string{"--file", path})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- wc.ExecuteContext(ctx) }()
+		require.Eventually(t, func() bool { return strings.Contains(out.String(), "Overlaps") }, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, os.WriteFile(path, []byte("0 2 * * * /usr/bin/backup.sh\n0 2 * * * /usr/bin/report.sh\n61 * * * * /usr/bin/broken.sh\n"), 0644))
+		var received []string
+		for len(received) < 2 {
+			select {
+			case msg := <-messages:
+				received = append(received, msg)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("received %d notification(s): %v", len(received), received)
+			}
+		}
+		cancel()
+		assert.NoError(t, <-done)
+
+		assert.Contains(t, received[0], "[cronkit watch] 1 new validation issue(s) after a crontab change")
+		assert.Contains(t, received[0], "[CRON-003] Line 3")
+		assert.Equal(t, "[cronkit watch] More jobs overlap after a crontab change\nOverlaps (next 24h): up to 2 jobs at once, in 1 minute(s) (was: none)", received[1])
+	})
+
+	t.Run("invalid overlap window", func(t *testing.T) {
+		wc := newWatchCommand()
+		wc.SetOut(new(bytes.Buffer))
+		wc.SetErr(new(bytes.Buffer))
+		wc.SetArgs([]string{"--file", "jobs.cron", "--overlap-window", "soon"})
+
+		err := wc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --overlap-window")
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		wc := newWatchCommand()
+		wc.SetOut(new(bytes.Buffer))
+		wc.SetErr(new(bytes.Buffer))
+		wc.SetArgs([]string{"--interval", "0s"})
+
+		err := wc.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --interval")
+	})
+}
@@ -0,0 +1,145 @@
+// Package color colors text output with ANSI escape codes, in one of a few
+// themes, when it goes to a terminal
+package color
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"golang.org/x/term"
+)
+
+// Environment variables that turn colors off or on regardless of the
+// terminal (see https://no-color.org)
+const (
+	EnvNoColor    = "NO_COLOR"
+	EnvForceColor = "FORCE_COLOR"
+)
+
+// DefaultTheme is the theme used when none is selected
+const DefaultTheme = "default"
+
+// Role is what a piece of text means, which themes give a style
+type Role int
+
+// Roles of colored text
+const (
+	Error   Role = iota // Errors, failures and late runs
+	Warning             // Warnings and overlaps
+	Info                // Informational messages
+	Success             // Passed checks
+	Added               // Added lines of a diff
+	Removed             // Removed lines of a diff
+	Changed             // Modified lines of a diff
+	Heading             // Titles and section headers
+	Muted               // Secondary details, e.g. hints and metadata
+)
+
+// Theme is a set of styles by role, as SGR parameters (e.g. "1;31" for bold
+// red). Roles without a style are left plain.
+type Theme struct {
+	Name        string
+	Description string
+	Styles      map[Role]string
+}
+
+var themes = map[string]Theme{
+	DefaultTheme: {
+		Name:        DefaultTheme,
+		Description: "errors red, warnings yellow, additions green",
+		Styles: map[Role]string{
+			Error:   "31",
+			Warning: "33",
+			Info:    "36",
+			Success: "32",
+			Added:   "32",
+			Removed: "31",
+			Changed: "33",
+			Heading: "1",
+			Muted:   "2",
+		},
+	},
+	"high-contrast": {
+		Name:        "high-contrast",
+		Description: "bold bright colors safe for red-green color blindness, errors also underlined",
+		Styles: map[Role]string{
+			Error:   "1;4;91",
+			Warning: "1;93",
+			Info:    "1;96",
+			Success: "1;94",
+			Added:   "1;94",
+			Removed: "1;91",
+			Changed: "1;93",
+			Heading: "1;4",
+		},
+	},
+}
+
+// Themes returns the names of the themes, sorted
+func Themes() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns the theme of a name, or DefaultTheme for ""
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (available: %v)", name, Themes())
+	}
+	return theme, nil
+}
+
+// Enabled reports whether output to w is colored: not when noColor is set
+// (--no-color) or NO_COLOR is, else when FORCE_COLOR is set or w is a
+// terminal other than TERM=dumb
+func Enabled(w io.Writer, noColor bool) bool {
+	if noColor || os.Getenv(EnvNoColor) != "" {
+		return false
+	}
+	if os.Getenv(EnvForceColor) != "" {
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Painter styles text by role in a theme. A nil Painter leaves text plain,
+// so output that may go to files or pipes can be painted unconditionally.
+type Painter struct {
+	theme Theme
+}
+
+// NewPainter returns a painter of a theme
+func NewPainter(theme Theme) *Painter {
+	return &Painter{theme: theme}
+}
+
+// Paint wraps s in the style of role, if any
+func (p *Painter) Paint(role Role, s string) string {
+	if p == nil || s == "" {
+		return s
+	}
+	style := p.theme.Styles[role]
+	if style == "" {
+		return s
+	}
+	return "\x1b[" + style + "m" + s + "\x1b[0m"
+}
+
+// Paintf formats according to a format specifier and paints the result
+func (p *Painter) Paintf(role Role, format string, args ...interface{}) string {
+	return p.Paint(role, fmt.Sprintf(format, args...))
+}
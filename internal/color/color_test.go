@@ -0,0 +1,74 @@
+package color
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupTheme(t *testing.T) {
+	assert.Equal(t, []string{"default", "high-contrast"}, Themes())
+
+	theme, err := LookupTheme("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTheme, theme.Name)
+
+	theme, err = LookupTheme("high-contrast")
+	require.NoError(t, err)
+	assert.Equal(t, "high-contrast", theme.Name)
+
+	_, err = LookupTheme("neon")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown theme "neon" (available: [default high-contrast])`)
+}
+
+func TestPainter_Paint(t *testing.T) {
+	theme, err := LookupTheme(DefaultTheme)
+	require.NoError(t, err)
+	p := NewPainter(theme)
+
+	assert.Equal(t, "\x1b[31m✗ failed\x1b[0m", p.Paint(Error, "✗ failed"))
+	assert.Equal(t, "\x1b[32m+ 3 added\x1b[0m", p.Paintf(Added, "+ %d added", 3))
+	assert.Equal(t, "", p.Paint(Error, ""))
+
+	t.Run("roles without a style are plain", func(t *testing.T) {
+		theme, err := LookupTheme("high-contrast")
+		require.NoError(t, err)
+		assert.Equal(t, "hint", NewPainter(theme).Paint(Muted, "hint"))
+		assert.Equal(t, "\x1b[1;4;91mERROR\x1b[0m", NewPainter(theme).Paint(Error, "ERROR"))
+	})
+
+	t.Run("nil painters are plain", func(t *testing.T) {
+		var p *Painter
+		assert.Equal(t, "✗ failed", p.Paint(Error, "✗ failed"))
+		assert.Equal(t, "+ 3 added", p.Paintf(Added, "+ %d added", 3))
+	})
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv(EnvNoColor, "")
+	t.Setenv(EnvForceColor, "")
+
+	t.Run("not a terminal", func(t *testing.T) {
+		assert.False(t, Enabled(&bytes.Buffer{}, false))
+		file, err := os.CreateTemp(t.TempDir(), "out")
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+		assert.False(t, Enabled(file, false))
+	})
+
+	t.Run("FORCE_COLOR", func(t *testing.T) {
+		t.Setenv(EnvForceColor, "1")
+		assert.True(t, Enabled(&bytes.Buffer{}, false))
+		assert.False(t, Enabled(&bytes.Buffer{}, true), "--no-color wins")
+	})
+
+	t.Run("NO_COLOR wins over FORCE_COLOR", func(t *testing.T) {
+		t.Setenv(EnvForceColor, "1")
+		t.Setenv(EnvNoColor, "1")
+		assert.False(t, Enabled(&bytes.Buffer{}, false))
+	})
+}
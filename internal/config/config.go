@@ -0,0 +1,185 @@
+// Package config loads cronkit's defaults from a YAML config file and
+// CRONKIT_* environment variables, and merges them into command flags.
+//
+// A setting is taken from, in order of precedence: the command line flag,
+// its environment variable, the config file, and the flag's built-in
+// default. The config file is the --config path, else $CRONKIT_CONFIG, else
+// $XDG_CONFIG_HOME/cronkit/config.yaml, else ~/.config/cronkit/config.yaml:
+//
+//	locale: fr
+//	timezone: Europe/Paris
+//	output: json
+//	fail-on: warn
+//	exit-codes: error=1,warn=0
+//	timeline-width: 120
+//	default-duration: 5m
+//	theme: high-contrast
+//
+// The "notifications" section configures where watch and daemon report
+// problems (see package notify).
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/notify"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfig overrides the config file location
+const EnvConfig = "CRONKIT_CONFIG"
+
+// Config is the contents of a config file. Values are written as on the
+// command line and validated by the flags they set.
+type Config struct {
+	Locale          string `yaml:"locale"`           // --locale
+	Timezone        string `yaml:"timezone"`         // --timezone
+	Output          string `yaml:"output"`           // text, or json for --json
+	FailOn          string `yaml:"fail-on"`          // check --fail-on
+	ExitCodes       string `yaml:"exit-codes"`       // check --exit-codes
+	TimelineWidth   string `yaml:"timeline-width"`   // timeline --width
+	DefaultDuration string `yaml:"default-duration"` // --default-duration of timeline and stats
+	Theme           string `yaml:"theme"`            // --theme of colored output
+
+	Notifications notify.Config `yaml:"notifications"` // Notifications of watch and daemon
+}
+
+// Output values
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// setting ties a config file key to its environment variable and the flag
+// it sets
+type setting struct {
+	key     string
+	flag    string
+	command string // Only for this command, when set
+	value   func(c Config) string
+}
+
+var settings = []setting{
+	{key: "locale", flag: "locale", value: func(c Config) string { return c.Locale }},
+	{key: "timezone", flag: "timezone", value: func(c Config) string { return c.Timezone }},
+	{key: "output", flag: "json", value: func(c Config) string { return c.Output }},
+	{key: "fail-on", flag: "fail-on", value: func(c Config) string { return c.FailOn }},
+	{key: "exit-codes", flag: "exit-codes", value: func(c Config) string { return c.ExitCodes }},
+	{key: "timeline-width", flag: "width", command: "timeline", value: func(c Config) string { return c.TimelineWidth }},
+	{key: "default-duration", flag: "default-duration", value: func(c Config) string { return c.DefaultDuration }},
+	{key: "theme", flag: "theme", value: func(c Config) string { return c.Theme }},
+}
+
+// EnvName returns the environment variable of a config file key, e.g.
+// CRONKIT_FAIL_ON for fail-on
+func EnvName(key string) string {
+	return "CRONKIT_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// DefaultPath returns the config file location: $CRONKIT_CONFIG, else
+// $XDG_CONFIG_HOME/cronkit/config.yaml, else ~/.config/cronkit/config.yaml
+func DefaultPath() (string, error) {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config file: %w", err)
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Dir returns the directory of cronkit's configuration, such as the config
+// file and the snippet library: $XDG_CONFIG_HOME/cronkit, else
+// ~/.config/cronkit
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "cronkit"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "cronkit"), nil
+}
+
+// Load reads the config file at path. A missing file is an empty config
+// unless required is set, as it is for paths given explicitly.
+func Load(path string, required bool) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfg, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes a config file, rejecting unknown keys
+func Parse(r io.Reader) (Config, error) {
+	var cfg Config
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, err
+	}
+	if cfg.Output != "" && cfg.Output != OutputText && cfg.Output != OutputJSON {
+		return Config{}, fmt.Errorf("invalid output %q (use %s or %s)", cfg.Output, OutputText, OutputJSON)
+	}
+	if err := cfg.Notifications.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Merge sets the flags of a command that were not given on the command line
+// to their environment variable, else to the config file value. Flags the
+// command does not have are left alone, and merged flags are not marked as
+// changed. source names the config file in errors.
+func Merge(flags *pflag.FlagSet, command string, cfg Config, source string) error {
+	for _, s := range settings {
+		if s.command != "" && s.command != command {
+			continue
+		}
+		flag := flags.Lookup(s.flag)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		value, origin := s.value(cfg), source
+		if env, ok := os.LookupEnv(EnvName(s.key)); ok && env != "" {
+			value, origin = env, EnvName(s.key)
+		}
+		if value == "" {
+			continue
+		}
+
+		if s.key == "output" {
+			switch value {
+			case OutputText:
+				continue
+			case OutputJSON:
+				value = "true"
+			default:
+				return fmt.Errorf("invalid output %q from %s (use %s or %s)", value, origin, OutputText, OutputJSON)
+			}
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s %q from %s: %w", s.key, value, origin, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPath(t *testing.T) {
+	t.Run("CRONKIT_CONFIG takes precedence", func(t *testing.T) {
+		t.Setenv(EnvConfig, "/srv/cronkit.yaml")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+		path, err := DefaultPath()
+		require.NoError(t, err)
+		assert.Equal(t, "/srv/cronkit.yaml", path)
+	})
+
+	t.Run("XDG_CONFIG_HOME", func(t *testing.T) {
+		t.Setenv(EnvConfig, "")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+		path, err := DefaultPath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/xdg", "cronkit", "config.yaml"), path)
+	})
+
+	t.Run("home directory", func(t *testing.T) {
+		t.Setenv(EnvConfig, "")
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", "/home/alice")
+		path, err := DefaultPath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/home/alice", ".config", "cronkit", "config.yaml"), path)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("all settings", func(t *testing.T) {
+		cfg, err := Parse(strings.NewReader("locale: fr\ntimezone: Europe/Paris\noutput: json\nfail-on: warn\nexit-codes: warn=0\ntimeline-width: 120\ndefault-duration: 5m\ntheme: high-contrast\n"))
+		require.NoError(t, err)
+		assert.Equal(t, Config{
+			Locale:          "fr",
+			Timezone:        "Europe/Paris",
+			Output:          OutputJSON,
+			FailOn:          "warn",
+			ExitCodes:       "warn=0",
+			TimelineWidth:   "120",
+			DefaultDuration: "5m",
+			Theme:           "high-contrast",
+		}, cfg)
+	})
+
+	t.Run("notifications", func(t *testing.T) {
+		cfg, err := Parse(strings.NewReader("notifications:\n  events: [failure]\n  slack:\n    webhook-url: https://hooks.slack.com/services/T/B/X\n  email:\n    host: smtp.example.com\n    from: cron@example.com\n    to: [ops@example.com]\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"failure"}, cfg.Notifications.Events)
+		assert.Equal(t, "https://hooks.slack.com/services/T/B/X", cfg.Notifications.Slack.WebhookURL)
+		assert.Equal(t, []string{"ops@example.com"}, cfg.Notifications.Email.To)
+
+		_, err = Parse(strings.NewReader("notifications:\n  webhook:\n    headers: {X-Token: abc}\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook notifications need a url")
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		cfg, err := Parse(strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Equal(t, Config{}, cfg)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("colour: always\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "colour")
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("output: xml\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid output "xml"`)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "config.yaml")
+
+	t.Run("missing default file", func(t *testing.T) {
+		cfg, err := Load(missing, false)
+		require.NoError(t, err)
+		assert.Equal(t, Config{}, cfg)
+	})
+
+	t.Run("missing required file", func(t *testing.T) {
+		_, err := Load(missing, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid file names the path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("locale: [fr\n"), 0o644))
+		_, err := Load(path, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), path)
+	})
+}
+
+// newFlags returns flags like those of the timeline command
+func newFlags(width *int, duration *time.Duration, json *bool, timezone *string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("timeline", pflag.ContinueOnError)
+	flags.IntVar(width, "width", 0, "")
+	flags.DurationVar(duration, "default-duration", 0, "")
+	flags.BoolVar(json, "json", false, "")
+	flags.StringVar(timezone, "timezone", "", "")
+	return flags
+}
+
+func TestMerge(t *testing.T) {
+	cfg := Config{Timezone: "UTC", Output: OutputJSON, TimelineWidth: "120", DefaultDuration: "5m", FailOn: "warn"}
+
+	t.Run("config file sets defaults", func(t *testing.T) {
+		var width int
+		var duration time.Duration
+		var json bool
+		var timezone string
+		flags := newFlags(&width, &duration, &json, &timezone)
+		require.NoError(t, flags.Parse(nil))
+
+		require.NoError(t, Merge(flags, "timeline", cfg, "config.yaml"))
+		assert.Equal(t, 120, width)
+		assert.Equal(t, 5*time.Minute, duration)
+		assert.True(t, json)
+		assert.Equal(t, "UTC", timezone)
+		assert.False(t, flags.Changed("width"))
+	})
+
+	t.Run("flags and environment take precedence", func(t *testing.T) {
+		t.Setenv(EnvName("timezone"), "Asia/Tokyo")
+		t.Setenv(EnvName("output"), OutputText)
+		var width int
+		var duration time.Duration
+		var json bool
+		var timezone string
+		flags := newFlags(&width, &duration, &json, &timezone)
+		require.NoError(t, flags.Parse([]string{"--width", "60"}))
+
+		require.NoError(t, Merge(flags, "timeline", cfg, "config.yaml"))
+		assert.Equal(t, 60, width)
+		assert.Equal(t, "Asia/Tokyo", timezone)
+		assert.False(t, json)
+	})
+
+	t.Run("command-specific settings", func(t *testing.T) {
+		var width int
+		var duration time.Duration
+		var json bool
+		var timezone string
+		flags := newFlags(&width, &duration, &json, &timezone)
+		require.NoError(t, flags.Parse(nil))
+
+		require.NoError(t, Merge(flags, "stats", cfg, "config.yaml"))
+		assert.Equal(t, 0, width, "timeline-width only applies to timeline")
+		assert.Equal(t, 5*time.Minute, duration)
+	})
+
+	t.Run("invalid values name their origin", func(t *testing.T) {
+		t.Setenv(EnvName("default-duration"), "soon")
+		var width int
+		var duration time.Duration
+		var json bool
+		var timezone string
+		flags := newFlags(&width, &duration, &json, &timezone)
+		require.NoError(t, flags.Parse(nil))
+
+		err := Merge(flags, "timeline", cfg, "config.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid default-duration "soon" from CRONKIT_DEFAULT_DURATION`)
+
+		t.Setenv(EnvName("default-duration"), "")
+		err = Merge(flags, "timeline", Config{TimelineWidth: "wide"}, "config.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid timeline-width "wide" from config.yaml`)
+	})
+}
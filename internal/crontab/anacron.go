@@ -0,0 +1,144 @@
+package crontab
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// anacronPeriods are the named periods of anacrontab jobs, in days. Anacron
+// runs @monthly jobs once per calendar month, modeled as the 1st.
+var anacronPeriods = map[string]int{
+	"@daily":    1,
+	"@weekly":   7,
+	"@monthly":  30,
+	"@yearly":   365,
+	"@annually": 365,
+}
+
+// ParseAnacrontab reads all entries of an anacrontab (/etc/anacrontab) from r.
+// Anacron runs each job once per period of days, delay minutes after it
+// starts within START_HOURS_RANGE. Since anacron catches up on missed runs
+// rather than following a calendar, jobs are given the cron expression that
+// approximates their schedule on a host that is always up: a daily job with
+// a 5 minute delay and START_HOURS_RANGE=3-22 becomes "5 3 * * *". Trigger
+// describes the anacron schedule itself. Jobs run as root.
+func ParseAnacrontab(r io.Reader) ([]*Entry, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// START_HOURS_RANGE applies to every job, wherever it is set
+	startHour := 0
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "START_HOURS_RANGE="); ok {
+			if hour, err := strconv.Atoi(strings.TrimSpace(strings.SplitN(value, "-", 2)[0])); err == nil && hour >= 0 && hour < 24 {
+				startHour = hour
+			}
+		}
+	}
+
+	entries := make([]*Entry, len(lines))
+	for i, line := range lines {
+		entries[i] = parseAnacronLine(line, i+1, startHour)
+	}
+	applyEnvironment(entries)
+	applyDirectives(entries)
+	applyIgnores(entries)
+	return entries, nil
+}
+
+// parseAnacronLine parses a line of an anacrontab: "<period> <delay>
+// <identifier> <command>", a variable, a comment or an empty line
+func parseAnacronLine(line string, lineNumber, startHour int) *Entry {
+	entry := &Entry{LineNumber: lineNumber, Raw: line}
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		entry.Type = EntryTypeEmpty
+		return entry
+	case strings.HasPrefix(trimmed, "#"):
+		entry.Type = EntryTypeComment
+		return entry
+	case envVarRegex.MatchString(trimmed):
+		entry.Type = EntryTypeEnvVar
+		return entry
+	}
+
+	fields := strings.Fields(trimmed)
+	if len(fields) < 4 {
+		entry.Type = EntryTypeInvalid
+		return entry
+	}
+	period, ok := anacronPeriod(fields[0])
+	delay, err := strconv.Atoi(fields[1])
+	if !ok || err != nil || delay < 0 {
+		entry.Type = EntryTypeInvalid
+		return entry
+	}
+
+	// Keep the spacing of the command, as ParseLine does
+	start := fieldStart(trimmed, 3)
+	var command, comment string
+	if idx := strings.Index(trimmed[start:], "#"); idx != -1 {
+		command = strings.TrimSpace(trimmed[start : start+idx])
+		comment = strings.TrimSpace(trimmed[start+idx+1:])
+	} else {
+		command = trimmed[start:]
+	}
+
+	expression, err := anacronExpression(period, delay, startHour)
+	job := &Job{
+		LineNumber: lineNumber,
+		Expression: expression,
+		Command:    command,
+		User:       "root",
+		Comment:    comment,
+		Valid:      err == nil,
+		Trigger:    fmt.Sprintf("anacron job %s (period %s, delay %dm, from %02d:00)", fields[2], fields[0], delay, startHour),
+	}
+	if err != nil {
+		job.Expression = fields[0]
+		job.Error = err.Error()
+	}
+	entry.Type = EntryTypeJob
+	entry.Job = job
+	return entry
+}
+
+// anacronPeriod returns the period of an anacrontab job in days
+func anacronPeriod(field string) (int, bool) {
+	if days, ok := anacronPeriods[strings.ToLower(field)]; ok {
+		return days, true
+	}
+	days, err := strconv.Atoi(field)
+	return days, err == nil
+}
+
+// anacronExpression returns the cron expression approximating an anacron job
+// run every period days, delay minutes after startHour
+func anacronExpression(period, delay, startHour int) (string, error) {
+	minutes := startHour*60 + delay
+	minute, hour := minutes%60, (minutes/60)%24
+	switch {
+	case period == 1:
+		return fmt.Sprintf("%d %d * * *", minute, hour), nil
+	case period == 7:
+		return fmt.Sprintf("%d %d * * 0", minute, hour), nil
+	case period == 30 || period == 31:
+		return fmt.Sprintf("%d %d 1 * *", minute, hour), nil
+	case period == 365 || period == 366:
+		return fmt.Sprintf("%d %d 1 1 *", minute, hour), nil
+	case period > 1 && period <= 28:
+		return fmt.Sprintf("%d %d */%d * *", minute, hour, period), nil
+	default:
+		return "", fmt.Errorf("anacron period of %d days has no cron equivalent", period)
+	}
+}
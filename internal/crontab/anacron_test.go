@@ -0,0 +1,69 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnacrontab(t *testing.T) {
+	content := `# /etc/anacrontab
+SHELL=/bin/sh
+START_HOURS_RANGE=3-22
+
+1	5	cron.daily	nice run-parts /etc/cron.daily
+7 25 cron.weekly nice run-parts /etc/cron.weekly
+@monthly 45 cron.monthly nice run-parts /etc/cron.monthly
+3 70 backup /usr/bin/backup # every three days
+90 10 quarterly /usr/bin/report
+1 five broken /usr/bin/true
+`
+	entries, err := ParseAnacrontab(strings.NewReader(content))
+	require.NoError(t, err)
+	require.Len(t, entries, 10)
+
+	assert.Equal(t, EntryTypeComment, entries[0].Type)
+	assert.Equal(t, EntryTypeEnvVar, entries[1].Type)
+	assert.Equal(t, EntryTypeEmpty, entries[3].Type)
+	assert.Equal(t, EntryTypeInvalid, entries[9].Type)
+
+	tests := []struct {
+		line       int
+		expression string
+		command    string
+		trigger    string
+	}{
+		{5, "5 3 * * *", "nice run-parts /etc/cron.daily", "anacron job cron.daily (period 1, delay 5m, from 03:00)"},
+		{6, "25 3 * * 0", "nice run-parts /etc/cron.weekly", "anacron job cron.weekly (period 7, delay 25m, from 03:00)"},
+		{7, "45 3 1 * *", "nice run-parts /etc/cron.monthly", "anacron job cron.monthly (period @monthly, delay 45m, from 03:00)"},
+		{8, "10 4 */3 * *", "/usr/bin/backup", "anacron job backup (period 3, delay 70m, from 03:00)"},
+	}
+	for _, tt := range tests {
+		job := entries[tt.line-1].Job
+		require.NotNil(t, job, "line %d", tt.line)
+		assert.True(t, job.Valid, "line %d", tt.line)
+		assert.Equal(t, tt.line, job.LineNumber)
+		assert.Equal(t, tt.expression, job.Expression)
+		assert.Equal(t, tt.command, job.Command)
+		assert.Equal(t, tt.trigger, job.Trigger)
+		assert.Equal(t, "root", job.User)
+		assert.Equal(t, "/bin/sh", job.Env["SHELL"])
+	}
+	assert.Equal(t, "every three days", entries[7].Job.Comment)
+
+	quarterly := entries[8].Job
+	require.NotNil(t, quarterly)
+	assert.False(t, quarterly.Valid)
+	assert.Equal(t, "90", quarterly.Expression)
+	assert.Contains(t, quarterly.Error, "no cron equivalent")
+}
+
+func TestParseAnacrontab_NoStartHours(t *testing.T) {
+	entries, err := ParseAnacrontab(strings.NewReader("@daily 10 daily run-parts /etc/cron.daily\n"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.NotNil(t, entries[0].Job)
+	assert.Equal(t, "10 0 * * *", entries[0].Job.Expression)
+}
@@ -0,0 +1,36 @@
+package crontab
+
+import "time"
+
+// DependencyRun pairs a run of a job with the run of a job it runs after
+// ("cronkit:after=") that it waits for
+type DependencyRun struct {
+	Start  time.Time // Start of the job's run
+	After  time.Time // Start of the run it waits for
+	Finish time.Time // When the run it waits for is expected to finish
+}
+
+// Late reports whether the job starts before the run it waits for has
+// finished, or together with it
+func (r DependencyRun) Late() bool {
+	return !r.Start.After(r.After) || r.Start.Before(r.Finish)
+}
+
+// PairDependencyRuns pairs each run of a job with the latest run of the job
+// it runs after that started since the job's previous run, if any. Runs are
+// in order; runs of the job it runs after last d.
+func PairDependencyRuns(runs, after []time.Time, d time.Duration) []DependencyRun {
+	var pairs []DependencyRun
+	next := 0 // First run of after not waited for yet
+	for _, start := range runs {
+		latest := -1
+		for next < len(after) && !after[next].After(start) {
+			latest = next
+			next++
+		}
+		if latest >= 0 {
+			pairs = append(pairs, DependencyRun{Start: start, After: after[latest], Finish: after[latest].Add(d)})
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,43 @@
+package crontab
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairDependencyRuns(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("pairs each run with the latest run since the previous one", func(t *testing.T) {
+		runs := []time.Time{at(3, 0), at(4, 0), at(5, 0)}
+		after := []time.Time{at(1, 0), at(2, 0), at(4, 30)}
+		pairs := PairDependencyRuns(runs, after, 30*time.Minute)
+		assert.Equal(t, []DependencyRun{
+			{Start: at(3, 0), After: at(2, 0), Finish: at(2, 30)},
+			{Start: at(5, 0), After: at(4, 30), Finish: at(5, 0)},
+		}, pairs)
+		assert.False(t, pairs[0].Late())
+		assert.False(t, pairs[1].Late())
+	})
+
+	t.Run("run starting before the other finishes is late", func(t *testing.T) {
+		pairs := PairDependencyRuns([]time.Time{at(2, 15)}, []time.Time{at(2, 0)}, 30*time.Minute)
+		assert.Len(t, pairs, 1)
+		assert.True(t, pairs[0].Late())
+	})
+
+	t.Run("runs starting together are late", func(t *testing.T) {
+		pairs := PairDependencyRuns([]time.Time{at(2, 0)}, []time.Time{at(2, 0)}, 0)
+		assert.Len(t, pairs, 1)
+		assert.True(t, pairs[0].Late())
+	})
+
+	t.Run("no runs", func(t *testing.T) {
+		assert.Empty(t, PairDependencyRuns([]time.Time{at(1, 0)}, []time.Time{at(2, 0)}, 0))
+		assert.Empty(t, PairDependencyRuns(nil, []time.Time{at(2, 0)}, 0))
+	})
+}
@@ -0,0 +1,43 @@
+package crontab
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ignoredSuffixes mark editor backups and package manager leftovers, which
+// cron does not read from a crontab directory
+var ignoredSuffixes = []string{"~", ".swp", ".bak", ".dpkg-old", ".dpkg-dist", ".dpkg-new", ".rpmsave", ".rpmnew", ".rpmorig"}
+
+// ListDir returns the crontab files of a directory such as /etc/cron.d or
+// /var/spool/cron/crontabs, sorted by name. Subdirectories, hidden files,
+// editor backups and package manager leftovers are left out, as cron does.
+func ListDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || ignoredFile(name) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ignoredFile reports whether cron leaves a file of a crontab directory out
+func ignoredFile(name string) bool {
+	for _, suffix := range ignoredSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package crontab
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"backup", "anacron", ".placeholder", "backup~", "logrotate.dpkg-old", "php.rpmnew"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("0 * * * * root true\n"), 0o644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o755))
+
+	files, err := ListDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "anacron"), filepath.Join(dir, "backup")}, files)
+
+	_, err = ListDir(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,240 @@
+package crontab
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DirectivePrefix introduces structured metadata in a comment, e.g.
+// "# cronkit:name=backup owner=infra tz=UTC duration=10m tags=db,critical"
+// on the lines before a job or at the end of the job line
+const DirectivePrefix = "cronkit:"
+
+// Directive keys with a meaning of their own; other keys are kept as Extra
+const (
+	DirectiveName     = "name"     // Short name of the job
+	DirectiveOwner    = "owner"    // Team or person responsible for the job
+	DirectiveTimezone = "tz"       // Time zone the job is scheduled in, overriding CRON_TZ= and TZ=
+	DirectiveDuration = "duration" // Expected run duration, for overlap detection
+	DirectiveTags     = "tags"     // Comma-separated labels
+	DirectiveAfter    = "after"    // Comma-separated names of the jobs that must finish before the job starts
+)
+
+// Metadata is what "cronkit:" directives declare about a job. The reader
+// also applies the time zone and duration to the job's Timezone and Duration.
+type Metadata struct {
+	Name     string
+	Owner    string
+	Timezone string
+	Duration time.Duration
+	Tags     []string
+	After    []string          // Names of the jobs it runs after
+	Extra    map[string]string // Keys without a meaning of their own
+}
+
+// IsZero reports whether no directive declared anything
+func (m Metadata) IsZero() bool {
+	return m.Name == "" && m.Owner == "" && m.Timezone == "" && m.Duration == 0 && len(m.Tags) == 0 && len(m.After) == 0 && len(m.Extra) == 0
+}
+
+// HasTag reports whether the metadata has a tag, ignoring case
+func (m Metadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the metadata as a directive body, e.g.
+// "name=backup owner=infra tags=db,critical"
+func (m Metadata) String() string {
+	var parts []string
+	add := func(key, value string) {
+		if value == "" {
+			return
+		}
+		if strings.ContainsAny(value, " \t\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+		parts = append(parts, key+"="+value)
+	}
+	add(DirectiveName, m.Name)
+	add(DirectiveOwner, m.Owner)
+	add(DirectiveTimezone, m.Timezone)
+	if m.Duration > 0 {
+		add(DirectiveDuration, FormatDuration(m.Duration))
+	}
+	add(DirectiveTags, strings.Join(m.Tags, ","))
+	add(DirectiveAfter, strings.Join(m.After, ","))
+	keys := make([]string, 0, len(m.Extra))
+	for key := range m.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, m.Extra[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatDuration formats a duration as written in directives, without zero
+// units, e.g. "30m" or "1h30m"
+func FormatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// MarshalJSON encodes the metadata as an object with the directive keys,
+// leaving out the ones not declared
+func (m Metadata) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(m.Extra)+6)
+	for key, value := range m.Extra {
+		out[key] = value
+	}
+	if m.Name != "" {
+		out[DirectiveName] = m.Name
+	}
+	if m.Owner != "" {
+		out[DirectiveOwner] = m.Owner
+	}
+	if m.Timezone != "" {
+		out[DirectiveTimezone] = m.Timezone
+	}
+	if m.Duration > 0 {
+		out[DirectiveDuration] = m.Duration.String()
+	}
+	if len(m.Tags) > 0 {
+		out[DirectiveTags] = m.Tags
+	}
+	if len(m.After) > 0 {
+		out[DirectiveAfter] = m.After
+	}
+	return json.Marshal(out)
+}
+
+// ParseDirective returns the key=value pairs of a "cronkit:" directive in
+// comment, or false when the comment has none. Values may be double-quoted
+// to contain spaces; the pairs end at the first word that is not one.
+func ParseDirective(comment string) (map[string]string, bool) {
+	_, body, found := strings.Cut(comment, DirectivePrefix)
+	if !found {
+		return nil, false
+	}
+
+	pairs := make(map[string]string)
+	rest := strings.TrimSpace(body)
+	for rest != "" {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				break
+			}
+			pairs[strings.ToLower(key)] = value[1 : end+1]
+			rest = strings.TrimSpace(value[end+2:])
+			continue
+		}
+		word, after, _ := strings.Cut(value, " ")
+		pairs[strings.ToLower(key)] = strings.TrimSpace(word)
+		rest = strings.TrimSpace(after)
+	}
+	return pairs, len(pairs) > 0
+}
+
+// apply sets the declared keys on the metadata. Durations that are not
+// positive Go durations (e.g. "90s", "10m", "1h30m") are ignored.
+func (m *Metadata) apply(pairs map[string]string) {
+	for key, value := range pairs {
+		switch key {
+		case DirectiveName:
+			m.Name = value
+		case DirectiveOwner:
+			m.Owner = value
+		case DirectiveTimezone:
+			m.Timezone = value
+		case DirectiveDuration:
+			if d, err := time.ParseDuration(value); err == nil && d > 0 {
+				m.Duration = d
+			}
+		case DirectiveTags:
+			m.Tags = splitList(value)
+		case DirectiveAfter:
+			m.After = splitList(value)
+		default:
+			if m.Extra == nil {
+				m.Extra = make(map[string]string)
+			}
+			m.Extra[key] = value
+		}
+	}
+}
+
+// splitList returns the non-empty items of a comma-separated value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// applyDirectives records on every job the metadata declared by the
+// directives in the block of comment lines directly above it and in its
+// inline comment, which wins for keys declared in both. A declared time zone
+// or duration also sets the job's Timezone or Duration.
+func applyDirectives(entries []*Entry) {
+	var state directiveState
+	for _, entry := range entries {
+		state.apply(entry)
+	}
+}
+
+// directiveState holds the directives of the current comment block
+type directiveState struct {
+	pending []map[string]string
+}
+
+// apply records the metadata on a job, or collects the directives of a
+// comment line
+func (s *directiveState) apply(entry *Entry) {
+	switch entry.Type {
+	case EntryTypeComment:
+		if pairs, ok := ParseDirective(entry.Raw); ok {
+			s.pending = append(s.pending, pairs)
+		}
+	case EntryTypeJob:
+		if entry.Job != nil {
+			var meta Metadata
+			for _, pairs := range s.pending {
+				meta.apply(pairs)
+			}
+			if pairs, ok := ParseDirective(entry.Job.Comment); ok {
+				meta.apply(pairs)
+			}
+			entry.Job.Metadata = meta
+			if meta.Timezone != "" {
+				entry.Job.Timezone = meta.Timezone
+			}
+			entry.Job.Duration = meta.Duration
+		}
+		s.pending = nil
+	default:
+		s.pending = nil
+	}
+}
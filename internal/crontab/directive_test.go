@@ -0,0 +1,114 @@
+package crontab
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    map[string]string
+		ok      bool
+	}{
+		{"# cronkit:duration=15m", map[string]string{"duration": "15m"}, true},
+		{"# cronkit:name=backup owner=infra tz=UTC tags=db,critical", map[string]string{
+			"name": "backup", "owner": "infra", "tz": "UTC", "tags": "db,critical",
+		}, true},
+		{`# cronkit:name="nightly backup" Owner=infra`, map[string]string{"name": "nightly backup", "owner": "infra"}, true},
+		{"nightly backup cronkit:duration=1h30m @sla: 2h", map[string]string{"duration": "1h30m"}, true},
+		{"# cronkit:", nil, false},
+		{"# cronkit: see the wiki", nil, false},
+		{"# plain comment", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			got, ok := ParseDirective(tt.comment)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	var meta Metadata
+	assert.True(t, meta.IsZero())
+
+	meta.apply(map[string]string{
+		"name":     "nightly backup",
+		"owner":    "infra",
+		"duration": "10m",
+		"tags":     "db, critical,",
+		"after":    "dump,sync",
+		"ticket":   "OPS-12",
+	})
+	assert.False(t, meta.IsZero())
+	assert.Equal(t, []string{"db", "critical"}, meta.Tags)
+	assert.True(t, meta.HasTag("DB"))
+	assert.False(t, meta.HasTag("web"))
+	assert.Equal(t, []string{"dump", "sync"}, meta.After)
+	assert.Equal(t, `name="nightly backup" owner=infra duration=10m tags=db,critical after=dump,sync ticket=OPS-12`, meta.String())
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"nightly backup","owner":"infra","duration":"10m0s","tags":["db","critical"],"after":["dump","sync"],"ticket":"OPS-12"}`, string(data))
+
+	t.Run("invalid duration is ignored", func(t *testing.T) {
+		var meta Metadata
+		meta.apply(map[string]string{"duration": "long"})
+		meta.apply(map[string]string{"duration": "0s"})
+		assert.True(t, meta.IsZero())
+	})
+}
+
+func TestParseReader_Directives(t *testing.T) {
+	entries, err := ParseReader(strings.NewReader(`CRON_TZ=America/New_York
+# Nightly backup
+# cronkit:name=backup owner=infra tz=UTC duration=20m
+# cronkit:tags=db,critical
+# @sla: 30m
+0 2 * * * /usr/bin/backup.sh
+# cronkit:name=report owner=data
+0 3 * * * /usr/bin/report.sh # cronkit:name=weekly-report duration=5m
+# cronkit:name=orphan
+
+0 4 * * * /usr/bin/cleanup.sh
+`))
+	require.NoError(t, err)
+
+	var jobs []*Job
+	for _, entry := range entries {
+		if entry.Type == EntryTypeJob {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	require.Len(t, jobs, 3)
+
+	assert.Equal(t, Metadata{
+		Name:     "backup",
+		Owner:    "infra",
+		Timezone: "UTC",
+		Duration: 20 * time.Minute,
+		Tags:     []string{"db", "critical"},
+	}, jobs[0].Metadata)
+	assert.Equal(t, "UTC", jobs[0].Timezone, "tz= overrides CRON_TZ=")
+	assert.Equal(t, 20*time.Minute, jobs[0].Duration)
+	assert.Equal(t, 30*time.Minute, jobs[0].SLA)
+
+	// The inline directive wins over the block above the job
+	assert.Equal(t, "weekly-report", jobs[1].Metadata.Name)
+	assert.Equal(t, "data", jobs[1].Metadata.Owner)
+	assert.Equal(t, 5*time.Minute, jobs[1].Duration)
+	assert.Equal(t, "America/New_York", jobs[1].Timezone)
+
+	// A blank line ends the block
+	assert.True(t, jobs[2].Metadata.IsZero())
+	assert.Zero(t, jobs[2].Duration)
+}
@@ -0,0 +1,188 @@
+package crontab
+
+import (
+	"fmt"
+	"maps"
+	"os/user"
+	"strings"
+)
+
+// Defaults cron sets in the environment of every job (Vixie cron, cronie)
+const (
+	DefaultShell = "/bin/sh"
+	DefaultPath  = "/usr/bin:/bin"
+)
+
+// DefaultEnvironment returns the environment cron starts the jobs of
+// username with: HOME from the password database, LOGNAME, USER, SHELL and
+// PATH. An empty username selects the current user.
+func DefaultEnvironment(username string) (map[string]string, error) {
+	var (
+		u   *user.User
+		err error
+	)
+	if username == "" {
+		u, err = user.Current()
+	} else {
+		u, err = user.Lookup(username)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	return map[string]string{
+		"HOME":    u.HomeDir,
+		"LOGNAME": u.Username,
+		"USER":    u.Username,
+		"SHELL":   DefaultShell,
+		"PATH":    DefaultPath,
+	}, nil
+}
+
+// Environment returns the job's effective environment: base overridden by
+// the variables the crontab sets before the job
+func (j *Job) Environment(base map[string]string) map[string]string {
+	env := make(map[string]string, len(base)+len(j.Env))
+	maps.Copy(env, base)
+	maps.Copy(env, j.Env)
+	return env
+}
+
+// ExpandedCommand returns the job's command with ~, $VAR and ${VAR} resolved
+// in its effective environment (see Environment)
+func (j *Job) ExpandedCommand(base map[string]string) string {
+	return ExpandCommand(j.Command, j.Environment(base))
+}
+
+// ExpandCommand resolves a leading ~ (or ~/) in each word to $HOME and
+// substitutes $VAR and ${VAR} references from env, the way the shell would
+// before running the command. Text in single quotes and characters escaped
+// with a backslash are left alone, as are references to unset variables.
+func ExpandCommand(command string, env map[string]string) string {
+	var b strings.Builder
+	inSingle, inDouble := false, false
+	wordStart := true
+
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case inSingle:
+			if c == '\'' {
+				inSingle = false
+			}
+			b.WriteByte(c)
+		case c == '\\' && i+1 < len(command):
+			b.WriteByte(c)
+			b.WriteByte(command[i+1])
+			i++
+		case c == '\'' && !inDouble:
+			inSingle = true
+			b.WriteByte(c)
+		case c == '"':
+			inDouble = !inDouble
+			b.WriteByte(c)
+		case c == '~' && wordStart && !inDouble && tildeEnds(command, i+1):
+			if home, ok := env["HOME"]; ok {
+				b.WriteString(home)
+			} else {
+				b.WriteByte(c)
+			}
+		case c == '$':
+			name, end := variableName(command, i+1)
+			value, ok := env[name]
+			if name == "" || !ok {
+				b.WriteByte(c)
+				continue
+			}
+			b.WriteString(value)
+			i = end - 1
+		default:
+			b.WriteByte(c)
+		}
+		wordStart = !inSingle && !inDouble && strings.IndexByte(" \t=:;|&(", c) >= 0
+	}
+	return b.String()
+}
+
+// tildeEnds reports whether a ~ followed by command[i:] stands for $HOME:
+// it must end the word or be followed by a slash
+func tildeEnds(command string, i int) bool {
+	return i == len(command) || strings.IndexByte("/ \t;|&)", command[i]) >= 0
+}
+
+// variableName returns the name of the variable referenced at command[i:]
+// (just after a $) and the index after the reference, supporting $NAME and
+// ${NAME}. The name is empty when there is no valid reference.
+func variableName(command string, i int) (string, int) {
+	if i < len(command) && command[i] == '{' {
+		end := strings.IndexByte(command[i:], '}')
+		if end == -1 {
+			return "", i
+		}
+		name := command[i+1 : i+end]
+		if !IsVariableName(name) {
+			return "", i
+		}
+		return name, i + end + 1
+	}
+
+	end := i
+	for end < len(command) && isVariableChar(command[end], end == i) {
+		end++
+	}
+	return command[i:end], end
+}
+
+// IsVariableName reports whether name is a valid shell variable name:
+// letters, digits and underscores, not starting with a digit
+func IsVariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isVariableChar(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// isVariableChar reports whether c can appear in a variable name (digits
+// only after the first character)
+func isVariableChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (!first && c >= '0' && c <= '9')
+}
+
+// applyEnvironment records on every job the variables set by the VAR= lines
+// preceding it. Jobs between two assignments share the same map.
+func applyEnvironment(entries []*Entry) {
+	var state environmentState
+	for _, entry := range entries {
+		state.apply(entry)
+	}
+}
+
+// environmentState holds the variables set by the lines read so far
+type environmentState struct {
+	env map[string]string
+}
+
+// apply records the current variables on a job, or sets a variable from a
+// VAR= line
+func (s *environmentState) apply(entry *Entry) {
+	switch entry.Type {
+	case EntryTypeEnvVar:
+		name, value, ok := parseEnvVar(entry.Raw)
+		if !ok {
+			return
+		}
+		next := make(map[string]string, len(s.env)+1)
+		maps.Copy(next, s.env)
+		next[name] = value
+		s.env = next
+	case EntryTypeJob:
+		if entry.Job != nil {
+			entry.Job.Env = s.env
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandCommand(t *testing.T) {
+	env := map[string]string{"HOME": "/home/alice", "LOGS": "/var/log/jobs"}
+
+	tests := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{"tilde at start", "~/bin/backup.sh", "/home/alice/bin/backup.sh"},
+		{"bare tilde argument", "cd ~ && ls", "cd /home/alice && ls"},
+		{"tilde inside word", "echo a~b", "echo a~b"},
+		{"dollar variable", "$HOME/run.sh > $LOGS/run.log", "/home/alice/run.sh > /var/log/jobs/run.log"},
+		{"braced variable", "${HOME}/run.sh", "/home/alice/run.sh"},
+		{"double quotes", `echo "$LOGS"`, `echo "/var/log/jobs"`},
+		{"single quotes", `echo '$HOME ~'`, `echo '$HOME ~'`},
+		{"escaped dollar", `echo \$HOME`, `echo \$HOME`},
+		{"unset variable", "$UNSET/run.sh", "$UNSET/run.sh"},
+		{"no references", "/usr/bin/true", "/usr/bin/true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExpandCommand(tt.command, env))
+		})
+	}
+}
+
+func TestJobEnvironment(t *testing.T) {
+	entries, err := ParseReader(strings.NewReader(strings.Join([]string{
+		"0 1 * * * $HOME/first.sh",
+		"HOME=/srv/app",
+		"SCRIPTS=/opt/scripts",
+		"0 2 * * * $SCRIPTS/second.sh $HOME",
+		"SCRIPTS=/usr/local/scripts",
+		"0 3 * * * $SCRIPTS/third.sh",
+	}, "\n")))
+	require.NoError(t, err)
+
+	var jobs []*Job
+	for _, entry := range entries {
+		if entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	require.Len(t, jobs, 3)
+
+	base := map[string]string{"HOME": "/home/alice", "PATH": DefaultPath}
+	assert.Equal(t, "/home/alice/first.sh", jobs[0].ExpandedCommand(base))
+	assert.Equal(t, "/opt/scripts/second.sh /srv/app", jobs[1].ExpandedCommand(base))
+	assert.Equal(t, "/usr/local/scripts/third.sh", jobs[2].ExpandedCommand(base))
+
+	t.Run("earlier jobs keep their environment", func(t *testing.T) {
+		assert.Equal(t, "/opt/scripts", jobs[1].Env["SCRIPTS"])
+		assert.Empty(t, jobs[0].Env)
+	})
+
+	t.Run("base is not modified", func(t *testing.T) {
+		env := jobs[1].Environment(base)
+		assert.Equal(t, "/srv/app", env["HOME"])
+		assert.Equal(t, "/home/alice", base["HOME"])
+		assert.Equal(t, DefaultPath, env["PATH"])
+	})
+}
+
+func TestDefaultEnvironment(t *testing.T) {
+	env, err := DefaultEnvironment("")
+	require.NoError(t, err)
+	assert.NotEmpty(t, env["HOME"])
+	assert.NotEmpty(t, env["USER"])
+	assert.Equal(t, env["USER"], env["LOGNAME"])
+	assert.Equal(t, DefaultShell, env["SHELL"])
+	assert.Equal(t, DefaultPath, env["PATH"])
+
+	_, err = DefaultEnvironment("no-such-user-cronkit")
+	assert.Error(t, err)
+}
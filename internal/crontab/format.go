@@ -0,0 +1,193 @@
+package crontab
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AlignMode selects how the fields of job lines are laid out when formatting
+type AlignMode string
+
+const (
+	// AlignColumns pads schedule fields so they line up across each block of
+	// jobs (blocks are separated by blank lines)
+	AlignColumns AlignMode = "columns"
+	// AlignSingleSpace separates every field with a single space
+	AlignSingleSpace AlignMode = "single-space"
+	// AlignPreserve keeps the original spacing, including tabs
+	AlignPreserve AlignMode = "preserve"
+)
+
+// ParseAlignMode returns the alignment mode with the given name
+func ParseAlignMode(s string) (AlignMode, error) {
+	switch mode := AlignMode(strings.ToLower(s)); mode {
+	case AlignColumns, AlignSingleSpace, AlignPreserve:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown alignment %q (supported: columns, single-space, preserve)", s)
+	}
+}
+
+// FormatOptions configures crontab formatting
+type FormatOptions struct {
+	Align    AlignMode // Field layout of job lines (default: AlignColumns)
+	Aliases  AliasMode // How @aliases are written (default: AliasPreserve)
+	Sort     SortMode  // Order of the jobs within each block (default: SortNone)
+	Simplify bool      // Collapse lists of values into ranges
+}
+
+// jobLine is a job line split into its schedule fields and the rest of the line
+type jobLine struct {
+	fields []string // Five schedule fields, or a single @alias
+	rest   string   // Command and inline comment, as written
+}
+
+// Format lays out crontab entries according to opts, after normalizing
+// their schedules and ordering their jobs. Comments, environment variables
+// and unparseable lines are kept as written, minus trailing whitespace.
+// Formatting is idempotent: formatting the output again yields the same text.
+func Format(entries []*Entry, opts FormatOptions) string {
+	align := opts.Align
+	if align == "" {
+		align = AlignColumns
+	}
+	entries = sortEntries(entries, opts.Sort)
+
+	lines := make([]string, len(entries))
+	var block []int // Indexes of the job lines in the current block
+	flush := func() {
+		alignBlock(block, lines)
+		block = block[:0]
+	}
+
+	for i, entry := range entries {
+		raw := strings.TrimRight(entry.Raw, " \t\r")
+		lines[i] = raw
+
+		switch {
+		case entry.Type == EntryTypeEmpty:
+			lines[i] = ""
+			if align == AlignColumns {
+				flush()
+			}
+		case entry.Type != EntryTypeJob:
+			// Kept as written
+		default:
+			job, ok := splitJobLine(raw)
+			if !ok {
+				break
+			}
+			changed := job.normalize(opts)
+			switch {
+			case align == AlignPreserve:
+				if changed {
+					lines[i], _ = ReplaceSchedule(raw, strings.Join(job.fields, " "))
+				}
+			case align == AlignSingleSpace:
+				lines[i] = job.join(nil, 0)
+			default:
+				lines[i] = job.join(nil, 0)
+				block = append(block, i)
+			}
+		}
+	}
+	if align == AlignColumns {
+		flush()
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// alignBlock lays out the job lines at the given indexes in aligned columns
+func alignBlock(block []int, lines []string) {
+	jobs := make(map[int]jobLine, len(block))
+	widths := make([]int, 5)
+	for _, i := range block {
+		job, ok := splitJobLine(lines[i])
+		if !ok {
+			continue
+		}
+		jobs[i] = job
+		if len(job.fields) != 5 {
+			continue
+		}
+		for f, field := range job.fields {
+			widths[f] = max(widths[f], len(field))
+		}
+	}
+
+	// Aliases share the width of the whole schedule so commands line up
+	schedule := 4
+	for _, w := range widths {
+		schedule += w
+	}
+	for _, job := range jobs {
+		if len(job.fields) == 1 {
+			schedule = max(schedule, len(job.fields[0]))
+		}
+	}
+
+	for i, job := range jobs {
+		lines[i] = job.join(widths, schedule)
+	}
+}
+
+// join renders a job line, padding fields to widths and the schedule to
+// scheduleWidth. Nil widths produce single-space separation.
+func (j jobLine) join(widths []int, scheduleWidth int) string {
+	var b strings.Builder
+	for f, field := range j.fields {
+		if f > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(field)
+		if widths != nil && len(j.fields) == 5 && f < len(j.fields)-1 {
+			b.WriteString(strings.Repeat(" ", widths[f]-len(field)))
+		}
+	}
+	if pad := scheduleWidth - b.Len(); pad > 0 {
+		b.WriteString(strings.Repeat(" ", pad))
+	}
+	b.WriteByte(' ')
+	b.WriteString(j.rest)
+	return b.String()
+}
+
+// splitJobLine splits a job line into its schedule fields and the rest of the
+// line. It reports false when the line does not have a schedule and a command.
+func splitJobLine(line string) (jobLine, bool) {
+	n := 5
+	if strings.HasPrefix(strings.TrimSpace(line), "@") {
+		n = 1
+	}
+
+	fields := make([]string, 0, n)
+	rest := strings.TrimLeft(line, " \t")
+	for len(fields) < n {
+		end := strings.IndexAny(rest, " \t")
+		if end == -1 {
+			return jobLine{}, false
+		}
+		fields = append(fields, rest[:end])
+		rest = strings.TrimLeft(rest[end:], " \t")
+	}
+	if rest == "" {
+		return jobLine{}, false
+	}
+	return jobLine{fields: fields, rest: rest}, true
+}
+
+// ReplaceSchedule returns the job line with its schedule (five fields or an
+// @alias) replaced by expression, keeping the indentation, command and
+// comment as written. It reports false when line is not a job line.
+func ReplaceSchedule(line, expression string) (string, bool) {
+	job, ok := splitJobLine(line)
+	if !ok {
+		return line, false
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	return indent + expression + " " + job.rest, true
+}
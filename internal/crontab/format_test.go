@@ -0,0 +1,112 @@
+package crontab
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// formatLines formats the given lines with the given alignment
+func formatLines(t *testing.T, content string, align AlignMode) string {
+	t.Helper()
+	entries, err := ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	return Format(entries, FormatOptions{Align: align})
+}
+
+func TestParseAlignMode(t *testing.T) {
+	for _, name := range []string{"columns", "single-space", "PRESERVE"} {
+		mode, err := ParseAlignMode(name)
+		require.NoError(t, err)
+		assert.Equal(t, AlignMode(strings.ToLower(name)), mode)
+	}
+
+	_, err := ParseAlignMode("tabs")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown alignment")
+}
+
+func TestFormat(t *testing.T) {
+	t.Run("columns align fields within blocks", func(t *testing.T) {
+		input := "0 2 * * * /backup.sh\n*/15 9-17 * * 1-5 /poll.sh\n\n30 4 1,15 * * /report.sh\n"
+		expected := "0    2    * * *   /backup.sh\n*/15 9-17 * * 1-5 /poll.sh\n\n30 4 1,15 * * /report.sh\n"
+		assert.Equal(t, expected, formatLines(t, input, AlignColumns))
+	})
+
+	t.Run("columns pad aliases to the schedule width", func(t *testing.T) {
+		input := "@daily /a.sh\n0 0 1 1 * /b.sh\n"
+		expected := "@daily    /a.sh\n0 0 1 1 * /b.sh\n"
+		assert.Equal(t, expected, formatLines(t, input, AlignColumns))
+	})
+
+	t.Run("single-space collapses separators but not the command", func(t *testing.T) {
+		input := "  0\t2  * * *\t/backup.sh  --full\t# nightly   \n"
+		expected := "0 2 * * * /backup.sh  --full\t# nightly\n"
+		assert.Equal(t, expected, formatLines(t, input, AlignSingleSpace))
+	})
+
+	t.Run("preserve keeps tabs and only trims trailing whitespace", func(t *testing.T) {
+		input := "0\t2\t*\t*\t*\t/backup.sh \t\r\n"
+		assert.Equal(t, "0\t2\t*\t*\t*\t/backup.sh\n", formatLines(t, input, AlignPreserve))
+	})
+
+	t.Run("non-job lines are kept", func(t *testing.T) {
+		input := "# comment  \nSHELL=/bin/sh\nnot a job\n"
+		assert.Equal(t, "# comment\nSHELL=/bin/sh\nnot a job\n", formatLines(t, input, AlignColumns))
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		assert.Equal(t, "", Format(nil, FormatOptions{}))
+	})
+
+	t.Run("default alignment is columns", func(t *testing.T) {
+		input := "0 2 * * * /a\n*/5 * * * * /b\n"
+		entries, err := ParseReader(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, formatLines(t, input, AlignColumns), Format(entries, FormatOptions{}))
+	})
+}
+
+// TestFormat_Fixtures formats real-world crontabs and compares the result with
+// golden files, then checks that formatting is idempotent
+func TestFormat_Fixtures(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "crontab", "fmt")
+	for _, fixture := range []string{"messy", "tabs"} {
+		input, err := os.ReadFile(filepath.Join(dir, fixture+".cron"))
+		require.NoError(t, err)
+
+		for _, align := range []AlignMode{AlignColumns, AlignSingleSpace, AlignPreserve} {
+			t.Run(fixture+"/"+string(align), func(t *testing.T) {
+				golden, err := os.ReadFile(filepath.Join(dir, fixture+"."+string(align)+".golden"))
+				require.NoError(t, err)
+
+				once := formatLines(t, string(input), align)
+				assert.Equal(t, string(golden), once)
+
+				twice := formatLines(t, once, align)
+				assert.Equal(t, once, twice, "formatting is not idempotent")
+			})
+		}
+	}
+}
+
+func TestReplaceSchedule(t *testing.T) {
+	tests := []struct {
+		line, expression, want string
+		ok                     bool
+	}{
+		{"0 0 * * * /usr/bin/backup.sh # nightly", "15 0 * * *", "15 0 * * * /usr/bin/backup.sh # nightly", true},
+		{"  0\t0 * * *\t/usr/bin/backup.sh", "5 0 * * *", "  5 0 * * * /usr/bin/backup.sh", true},
+		{"@daily /usr/bin/backup.sh", "10 0 * * *", "10 0 * * * /usr/bin/backup.sh", true},
+		{"# comment", "0 0 * * *", "# comment", false},
+	}
+	for _, tt := range tests {
+		got, ok := ReplaceSchedule(tt.line, tt.expression)
+		assert.Equal(t, tt.ok, ok, tt.line)
+		assert.Equal(t, tt.want, got, tt.line)
+	}
+}
@@ -0,0 +1,209 @@
+package crontab
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// periodicDirs are the directories whose scripts run-parts runs every hour,
+// day, week and month, with the schedule Debian's /etc/crontab gives them
+var periodicDirs = []struct {
+	name       string
+	expression string
+}{
+	{"cron.hourly", "17 * * * *"},
+	{"cron.daily", "25 6 * * *"},
+	{"cron.weekly", "47 6 * * 7"},
+	{"cron.monthly", "52 6 1 * *"},
+}
+
+// runPartsName matches the script names run-parts runs; others, such as
+// "backup.sh" or "README", are left out
+var runPartsName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// HostSource is a crontab, anacrontab or run-parts directory of a host
+type HostSource struct {
+	Name    string // Path of the file or directory
+	Entries []*Entry
+}
+
+// ReadHost reads all scheduled work of a host whose configuration lives in
+// etc (usually /etc): the system crontab, the files of cron.d, the
+// anacrontab, and the scripts of the cron.hourly, cron.daily, cron.weekly and
+// cron.monthly directories, in that order. Missing files and directories are
+// skipped.
+//
+// Scripts become jobs with the schedule of the line that runs their
+// directory with run-parts, preferring anacrontab jobs to crontab lines (as
+// Debian's /etc/crontab defers to anacron when it is installed), or Debian's
+// default schedule when no line does. Their Trigger names that line. Every
+// job records its file or directory in Source.
+func ReadHost(etc string) ([]HostSource, error) {
+	var sources []HostSource
+	add := func(path string, entries []*Entry) {
+		for _, entry := range entries {
+			if entry.Job != nil {
+				entry.Job.Source = path
+			}
+		}
+		sources = append(sources, HostSource{Name: path, Entries: entries})
+	}
+
+	reader := NewReaderWithLayout(LayoutSystem)
+	paths := []string{filepath.Join(etc, "crontab")}
+	cronD, err := ListDir(filepath.Join(etc, "cron.d"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read crontab directory: %w", err)
+	}
+	paths = append(paths, cronD...)
+	for _, path := range paths {
+		entries, err := reader.ParseFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		add(path, entries)
+	}
+
+	anacrontab := filepath.Join(etc, "anacrontab")
+	entries, err := readAnacrontab(anacrontab)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", anacrontab, err)
+	}
+	if err == nil {
+		add(anacrontab, entries)
+	}
+
+	for _, periodic := range periodicDirs {
+		dir := filepath.Join(etc, periodic.name)
+		scripts, err := runPartsScripts(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+		if len(scripts) == 0 {
+			continue
+		}
+
+		trigger := &Job{Expression: periodic.expression, User: "root", Valid: true}
+		via := fmt.Sprintf("run-parts %s, on the default schedule (no crontab or anacrontab line runs it)", dir)
+		if job := runPartsTrigger(sources, anacrontab, periodic.name); job != nil {
+			trigger = job
+			via = fmt.Sprintf("run-parts %s, from %s line %d", dir, job.Source, job.LineNumber)
+		}
+		add(dir, scriptEntries(scripts, trigger, via))
+	}
+	return sources, nil
+}
+
+// readAnacrontab reads the anacrontab at path
+func readAnacrontab(path string) (entries []*Entry, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing file: %w", closeErr)
+		}
+	}()
+	return ParseAnacrontab(file)
+}
+
+// runPartsScripts returns the scripts of a directory that run-parts runs:
+// executable files with plain names, sorted by name
+func runPartsScripts(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []string
+	for _, entry := range entries {
+		if entry.IsDir() || !runPartsName.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode().Perm()&0o111 != 0 {
+			scripts = append(scripts, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return scripts, nil
+}
+
+// runPartsTrigger returns the job that runs a periodic directory with
+// run-parts, looking in the anacrontab first
+func runPartsTrigger(sources []HostSource, anacrontab, dir string) *Job {
+	for _, fromAnacron := range []bool{true, false} {
+		for _, source := range sources {
+			if (source.Name == anacrontab) != fromAnacron {
+				continue
+			}
+			for _, entry := range source.Entries {
+				if entry.Job != nil && runsParts(entry.Job.Command, dir) {
+					return entry.Job
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// runsParts reports whether a command runs run-parts on a directory named
+// dir, such as "cd / && run-parts --report /etc/cron.daily"
+func runsParts(command, dir string) bool {
+	if !strings.Contains(command, "run-parts") {
+		return false
+	}
+	words := strings.FieldsFunc(command, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`()[]{};&|'"`, r)
+	})
+	for _, word := range words {
+		if strings.HasSuffix(strings.TrimSuffix(word, "/"), "/"+dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptEntries models the scripts of a run-parts directory as jobs with the
+// schedule of trigger, numbered in the order run-parts runs them
+func scriptEntries(scripts []string, trigger *Job, via string) []*Entry {
+	entries := make([]*Entry, len(scripts))
+	for i, script := range scripts {
+		user := trigger.User
+		if user == "" {
+			user = "root"
+		}
+		entries[i] = &Entry{
+			Type:       EntryTypeJob,
+			LineNumber: i + 1,
+			Raw:        script,
+			Job: &Job{
+				LineNumber: i + 1,
+				Expression: trigger.Expression,
+				Command:    script,
+				User:       user,
+				Valid:      trigger.Valid,
+				Error:      trigger.Error,
+				Timezone:   trigger.Timezone,
+				Env:        trigger.Env,
+				Trigger:    via,
+			},
+		}
+	}
+	return entries
+}
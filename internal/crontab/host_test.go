@@ -0,0 +1,96 @@
+package crontab
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeHostFile writes a file of a test host configuration
+func writeHostFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), mode))
+}
+
+func TestReadHost(t *testing.T) {
+	etc := t.TempDir()
+	writeHostFile(t, filepath.Join(etc, "crontab"), "SHELL=/bin/sh\n17 * * * * root cd / && run-parts --report /etc/cron.hourly\n25 6 * * * root test -x /usr/sbin/anacron || ( cd / && run-parts --report /etc/cron.daily )\n", 0o644)
+	writeHostFile(t, filepath.Join(etc, "cron.d", "backup"), "0 2 * * * backup /usr/bin/backup\n", 0o644)
+	writeHostFile(t, filepath.Join(etc, "anacrontab"), "START_HOURS_RANGE=3-22\n1 5 cron.daily run-parts /etc/cron.daily\n", 0o644)
+	writeHostFile(t, filepath.Join(etc, "cron.hourly", "0anacron"), "#!/bin/sh\n", 0o755)
+	writeHostFile(t, filepath.Join(etc, "cron.daily", "logrotate"), "#!/bin/sh\n", 0o755)
+	writeHostFile(t, filepath.Join(etc, "cron.daily", "apt-compat"), "#!/bin/sh\n", 0o755)
+	writeHostFile(t, filepath.Join(etc, "cron.daily", "notes.txt"), "not a script\n", 0o755)
+	writeHostFile(t, filepath.Join(etc, "cron.daily", "disabled"), "#!/bin/sh\n", 0o644)
+	writeHostFile(t, filepath.Join(etc, "cron.weekly", "fstrim"), "#!/bin/sh\n", 0o755)
+
+	sources, err := ReadHost(etc)
+	require.NoError(t, err)
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	assert.Equal(t, []string{
+		filepath.Join(etc, "crontab"),
+		filepath.Join(etc, "cron.d", "backup"),
+		filepath.Join(etc, "anacrontab"),
+		filepath.Join(etc, "cron.hourly"),
+		filepath.Join(etc, "cron.daily"),
+		filepath.Join(etc, "cron.weekly"),
+	}, names)
+
+	t.Run("system crontabs have users and sources", func(t *testing.T) {
+		job := sources[1].Entries[0].Job
+		require.NotNil(t, job)
+		assert.Equal(t, "backup", job.User)
+		assert.Equal(t, filepath.Join(etc, "cron.d", "backup"), job.Source)
+	})
+
+	t.Run("scripts follow the crontab line that runs them", func(t *testing.T) {
+		entries := sources[3].Entries
+		require.Len(t, entries, 1)
+		job := entries[0].Job
+		assert.Equal(t, "17 * * * *", job.Expression)
+		assert.Equal(t, filepath.Join(etc, "cron.hourly", "0anacron"), job.Command)
+		assert.Equal(t, "root", job.User)
+		assert.Equal(t, filepath.Join(etc, "cron.hourly"), job.Source)
+		assert.Contains(t, job.Trigger, filepath.Join(etc, "crontab")+" line 2")
+	})
+
+	t.Run("anacron takes precedence over crontab lines", func(t *testing.T) {
+		entries := sources[4].Entries
+		require.Len(t, entries, 2, "only executable scripts with plain names run")
+		assert.Equal(t, filepath.Join(etc, "cron.daily", "apt-compat"), entries[0].Job.Command)
+		assert.Equal(t, filepath.Join(etc, "cron.daily", "logrotate"), entries[1].Job.Command)
+		assert.Equal(t, 2, entries[1].Job.LineNumber)
+		assert.Equal(t, "5 3 * * *", entries[1].Job.Expression)
+		assert.Contains(t, entries[1].Job.Trigger, filepath.Join(etc, "anacrontab")+" line 2")
+	})
+
+	t.Run("directories nothing runs get the default schedule", func(t *testing.T) {
+		job := sources[5].Entries[0].Job
+		assert.Equal(t, "47 6 * * 7", job.Expression)
+		assert.True(t, job.Valid)
+		assert.Contains(t, job.Trigger, "default schedule")
+	})
+}
+
+func TestReadHost_Empty(t *testing.T) {
+	sources, err := ReadHost(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+}
+
+func TestRunsParts(t *testing.T) {
+	assert.True(t, runsParts("cd / && run-parts --report /etc/cron.daily", "cron.daily"))
+	assert.True(t, runsParts("test -x /usr/sbin/anacron || ( cd / && run-parts --report /etc/cron.daily )", "cron.daily"))
+	assert.True(t, runsParts("run-parts /etc/cron.weekly/", "cron.weekly"))
+	assert.False(t, runsParts("run-parts /etc/cron.daily", "cron.weekly"))
+	assert.False(t, runsParts("ls /etc/cron.daily", "cron.daily"))
+	assert.False(t, runsParts("run-parts /etc/cron.daily.old", "cron.daily"))
+}
@@ -0,0 +1,77 @@
+package crontab
+
+import "strings"
+
+// IgnoreAnnotation suppresses diagnostics of a job in a comment, e.g.
+// "# cronkit:ignore CRON-001 CRON-009" on the line before the job or at the
+// end of the job line. Without codes, every diagnostic of the job is ignored.
+const IgnoreAnnotation = "cronkit:ignore"
+
+// IgnoreAll is recorded for an annotation without codes
+const IgnoreAll = "*"
+
+// ParseIgnore returns the codes of a "cronkit:ignore" annotation in comment,
+// or IgnoreAll when it lists none. Codes may be separated by spaces or
+// commas; a "--" ends the list, leaving room for a reason.
+func ParseIgnore(comment string) ([]string, bool) {
+	_, value, found := strings.Cut(comment, IgnoreAnnotation)
+	if !found || (value != "" && !strings.ContainsAny(value[:1], " \t,")) {
+		return nil, false
+	}
+	value, _, _ = strings.Cut(value, "--")
+
+	var codes []string
+	for _, code := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		codes = append(codes, strings.ToUpper(code))
+	}
+	if len(codes) == 0 {
+		codes = []string{IgnoreAll}
+	}
+	return codes, true
+}
+
+// Ignores reports whether a "cronkit:ignore" annotation suppresses a
+// diagnostic code for the job
+func (j *Job) Ignores(code string) bool {
+	for _, c := range j.Ignore {
+		if c == IgnoreAll || strings.EqualFold(c, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyIgnores records on every job the codes ignored by the annotations in
+// its inline comment and in the block of comment lines directly above it
+func applyIgnores(entries []*Entry) {
+	var state ignoreState
+	for _, entry := range entries {
+		state.apply(entry)
+	}
+}
+
+// ignoreState holds the codes ignored by the current comment block
+type ignoreState struct {
+	pending []string
+}
+
+// apply records the ignored codes on a job, or collects them from a comment
+// line
+func (s *ignoreState) apply(entry *Entry) {
+	switch entry.Type {
+	case EntryTypeComment:
+		if codes, ok := ParseIgnore(entry.Raw); ok {
+			s.pending = append(s.pending, codes...)
+		}
+	case EntryTypeJob:
+		if entry.Job != nil {
+			entry.Job.Ignore = s.pending
+			if codes, ok := ParseIgnore(entry.Job.Comment); ok {
+				entry.Job.Ignore = append(entry.Job.Ignore, codes...)
+			}
+		}
+		s.pending = nil
+	default:
+		s.pending = nil
+	}
+}
@@ -0,0 +1,59 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIgnore(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    []string
+		ok      bool
+	}{
+		{"# cronkit:ignore CRON-001", []string{"CRON-001"}, true},
+		{"cronkit:ignore cron-001, CRON-009 -- runs on the 1st Monday", []string{"CRON-001", "CRON-009"}, true},
+		{"# cronkit:ignore", []string{IgnoreAll}, true},
+		{"# cronkit:ignored CRON-001", nil, false},
+		{"# cronkit:name=backup", nil, false},
+		{"# plain comment", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			got, ok := ParseIgnore(tt.comment)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseReader_Ignore(t *testing.T) {
+	entries, err := ParseReader(strings.NewReader(`# cronkit:ignore CRON-001
+# cronkit:name=report
+0 0 1 * 1 /usr/bin/report.sh
+*/1 * * * * /usr/bin/poll.sh # cronkit:ignore
+0 4 * * * /usr/bin/cleanup.sh
+
+# cronkit:ignore CRON-009
+
+0 5 * * * /usr/bin/sync.sh
+`))
+	require.NoError(t, err)
+
+	jobs := map[string]*Job{}
+	for _, entry := range entries {
+		if entry.Type == EntryTypeJob {
+			jobs[entry.Job.Command] = entry.Job
+		}
+	}
+	assert.Equal(t, []string{"CRON-001"}, jobs["/usr/bin/report.sh"].Ignore)
+	assert.Equal(t, "report", jobs["/usr/bin/report.sh"].Metadata.Name)
+	assert.True(t, jobs["/usr/bin/report.sh"].Ignores("cron-001"))
+	assert.False(t, jobs["/usr/bin/report.sh"].Ignores("CRON-009"))
+	assert.True(t, jobs["/usr/bin/poll.sh"].Ignores("CRON-006"))
+	assert.Empty(t, jobs["/usr/bin/cleanup.sh"].Ignore)
+	assert.Empty(t, jobs["/usr/bin/sync.sh"].Ignore) // Separated from the annotation by a blank line
+}
@@ -1,13 +1,24 @@
 package crontab
 
+import "time"
+
 // Job represents a single cron job entry from a crontab file
 type Job struct {
-	LineNumber int    // Line number in the crontab file (1-indexed)
-	Expression string // Cron expression (e.g., "0 0 * * *")
-	Command    string // Command to execute
-	Comment    string // Inline or preceding comment (optional)
-	Valid      bool   // Whether the expression is valid
-	Error      string // Parse error if Valid is false
+	LineNumber int               // Line number in the crontab file (1-indexed)
+	Expression string            // Cron expression (e.g., "0 0 * * *")
+	Command    string            // Command to execute
+	User       string            // User the job runs as, from the user column of system crontabs (optional)
+	Comment    string            // Inline or preceding comment (optional)
+	Valid      bool              // Whether the expression is valid
+	Error      string            // Parse error if Valid is false
+	Timezone   string            // Time zone set by a preceding CRON_TZ= or TZ= line (optional)
+	Env        map[string]string // Variables set by preceding VAR= lines (optional, shared between jobs)
+	SLA        time.Duration     // Maximum run duration from an "@sla:" annotation (optional)
+	Duration   time.Duration     // Expected run duration from a "cronkit:duration=" directive (optional)
+	Metadata   Metadata          // Declared by "cronkit:" directives (optional)
+	Source     string            // File the job was read from, when jobs of several files are checked together (optional)
+	Trigger    string            // What runs a job modeled from anacron or a run-parts directory, which has no crontab line of its own (optional)
+	Ignore     []string          // Diagnostic codes suppressed by "cronkit:ignore" annotations, or IgnoreAll (optional)
 }
 
 // EntryType represents the type of line in a crontab
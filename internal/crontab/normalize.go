@@ -0,0 +1,248 @@
+package crontab
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// AliasMode selects how @aliases and the schedules they stand for are written
+// when formatting
+type AliasMode string
+
+const (
+	// AliasPreserve keeps schedules as written
+	AliasPreserve AliasMode = "preserve"
+	// AliasExpand replaces @aliases with their five fields ("@daily" becomes
+	// "0 0 * * *"); @reboot has no fields and is kept
+	AliasExpand AliasMode = "expand"
+	// AliasContract replaces schedules that have an @alias with the alias
+	// ("0 0 * * *" becomes "@daily")
+	AliasContract AliasMode = "contract"
+)
+
+// ParseAliasMode returns the alias mode with the given name
+func ParseAliasMode(s string) (AliasMode, error) {
+	switch mode := AliasMode(strings.ToLower(s)); mode {
+	case AliasPreserve, AliasExpand, AliasContract:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown alias mode %q (supported: preserve, expand, contract)", s)
+	}
+}
+
+// SortMode selects how jobs are ordered when formatting
+type SortMode string
+
+const (
+	// SortNone keeps jobs in their original order
+	SortNone SortMode = "none"
+	// SortTime orders jobs by the time of day of their first run from a
+	// Monday midnight; @reboot jobs come first
+	SortTime SortMode = "time"
+	// SortCommand orders jobs by command
+	SortCommand SortMode = "command"
+)
+
+// ParseSortMode returns the sort mode with the given name
+func ParseSortMode(s string) (SortMode, error) {
+	switch mode := SortMode(strings.ToLower(s)); mode {
+	case SortNone, SortTime, SortCommand:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown sort order %q (supported: none, time, command)", s)
+	}
+}
+
+// aliasFields are the fields of the schedules that have an @alias, in the
+// order the aliases are preferred when contracting
+var aliasFields = []struct {
+	alias  string
+	fields string
+}{
+	{"@yearly", "0 0 1 1 *"},
+	{"@annually", "0 0 1 1 *"},
+	{"@monthly", "0 0 1 * *"},
+	{"@weekly", "0 0 * * 0"},
+	{"@daily", "0 0 * * *"},
+	{"@midnight", "0 0 * * *"},
+	{"@hourly", "0 * * * *"},
+}
+
+// fieldBounds are the values of the minute, hour, day-of-month, month and
+// day-of-week fields
+var fieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// normalize rewrites the schedule of a job line according to opts and
+// reports whether it changed
+func (j *jobLine) normalize(opts FormatOptions) bool {
+	fields := append([]string(nil), j.fields...)
+
+	if len(fields) == 1 && opts.Aliases == AliasExpand {
+		for _, a := range aliasFields {
+			if strings.EqualFold(fields[0], a.alias) {
+				fields = strings.Fields(a.fields)
+				break
+			}
+		}
+	}
+
+	if len(fields) == 5 && opts.Simplify {
+		for f := range fields {
+			fields[f] = simplifyField(fields[f], f)
+		}
+	}
+
+	if len(fields) == 5 && opts.Aliases == AliasContract {
+		schedule := make([]string, 5)
+		for f, field := range fields {
+			schedule[f] = trimNumber(field)
+		}
+		for _, a := range aliasFields {
+			if strings.Join(schedule, " ") == a.fields {
+				fields = []string{a.alias}
+				break
+			}
+		}
+	}
+
+	changed := strings.Join(fields, " ") != strings.Join(j.fields, " ")
+	j.fields = fields
+	return changed
+}
+
+// simplifyField rewrites a list of values and ranges of the field at index f
+// as its sorted, deduplicated runs: runs of three or more values become
+// ranges ("1,2,3,4,5" becomes "1-5", "1-3,2-6" becomes "1-6"). A range
+// covering every minute, hour or month becomes "*"; the day fields keep their
+// restriction, since "*" there changes how they combine. Fields with steps,
+// names or other syntax are kept as written.
+func simplifyField(field string, f int) string {
+	if !strings.ContainsAny(field, ",-") {
+		return field
+	}
+
+	var values []int
+	for _, part := range strings.Split(field, ",") {
+		low, high, isRange := strings.Cut(part, "-")
+		if !isRange {
+			high = low
+		}
+		lo, err := strconv.Atoi(low)
+		if err != nil {
+			return field
+		}
+		hi, err := strconv.Atoi(high)
+		if err != nil || lo > hi || lo < fieldBounds[f][0] || hi > fieldBounds[f][1] {
+			return field
+		}
+		for v := lo; v <= hi; v++ {
+			values = append(values, v)
+		}
+	}
+	sort.Ints(values)
+
+	var runs []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] <= values[j]+1 {
+			j++
+		}
+		start, end := values[i], values[j]
+		switch {
+		case f != 2 && f != 4 && start == fieldBounds[f][0] && end == fieldBounds[f][1]:
+			return "*"
+		case end-start >= 2:
+			runs = append(runs, fmt.Sprintf("%d-%d", start, end))
+		case end > start:
+			runs = append(runs, strconv.Itoa(start), strconv.Itoa(end))
+		default:
+			runs = append(runs, strconv.Itoa(start))
+		}
+		i = j + 1
+	}
+	return strings.Join(runs, ",")
+}
+
+// trimNumber strips the leading zeros of a number, so that "00" compares
+// equal to "0"
+func trimNumber(field string) string {
+	if n, err := strconv.Atoi(field); err == nil && n >= 0 {
+		return strconv.Itoa(n)
+	}
+	return strings.ToLower(field)
+}
+
+// sortEntries orders the jobs of each block of entries by mode. Blocks are
+// separated by blank lines, variables and unparseable lines, which stay in
+// place since they affect the jobs below them. The comment lines directly
+// above a job move with it; comments at the end of a block stay there.
+func sortEntries(entries []*Entry, mode SortMode) []*Entry {
+	if mode == "" || mode == SortNone {
+		return entries
+	}
+
+	type unit struct {
+		entries []*Entry
+		job     *Job
+		key     int // Minute of the day of the first run
+	}
+	scheduler := cronx.NewScheduler()
+	reference := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // A Monday
+	sorted := make([]*Entry, 0, len(entries))
+	var (
+		units    []unit
+		comments []*Entry
+	)
+	flush := func() {
+		sort.SliceStable(units, func(a, b int) bool {
+			x, y := units[a], units[b]
+			if mode == SortCommand {
+				return x.job.Command < y.job.Command
+			}
+			return x.key < y.key
+		})
+		for _, u := range units {
+			sorted = append(sorted, u.entries...)
+		}
+		sorted = append(sorted, comments...)
+		units, comments = nil, nil
+	}
+
+	for _, entry := range entries {
+		switch {
+		case entry.Type == EntryTypeComment:
+			comments = append(comments, entry)
+		case entry.Type == EntryTypeJob && entry.Job != nil:
+			u := unit{entries: append(comments, entry), job: entry.Job}
+			comments = nil
+			if mode == SortTime {
+				u.key = firstRun(scheduler, entry.Job, reference)
+			}
+			units = append(units, u)
+		default:
+			flush()
+			sorted = append(sorted, entry)
+		}
+	}
+	flush()
+	return sorted
+}
+
+// firstRun returns the minute of the day of the first run of a job at or
+// after reference: -1 for @reboot jobs, so they sort first, and a minute
+// after the end of the day for jobs that fail to parse, so they sort last
+func firstRun(scheduler cronx.Scheduler, job *Job, reference time.Time) int {
+	if strings.EqualFold(job.Expression, "@reboot") {
+		return -1
+	}
+	runs, err := scheduler.Next(job.Expression, reference.Add(-time.Second), 1)
+	if err != nil || len(runs) == 0 {
+		return 24 * 60
+	}
+	return runs[0].Hour()*60 + runs[0].Minute()
+}
@@ -0,0 +1,104 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func formatWith(t *testing.T, content string, opts FormatOptions) string {
+	t.Helper()
+	entries, err := ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	return Format(entries, opts)
+}
+
+func TestParseAliasAndSortMode(t *testing.T) {
+	aliases, err := ParseAliasMode("Contract")
+	require.NoError(t, err)
+	assert.Equal(t, AliasContract, aliases)
+	_, err = ParseAliasMode("shorten")
+	assert.ErrorContains(t, err, "unknown alias mode")
+
+	order, err := ParseSortMode("time")
+	require.NoError(t, err)
+	assert.Equal(t, SortTime, order)
+	_, err = ParseSortMode("line")
+	assert.ErrorContains(t, err, "unknown sort order")
+}
+
+func TestSimplifyField(t *testing.T) {
+	tests := []struct {
+		field string
+		index int
+		want  string
+	}{
+		{"1,2,3,4,5", 4, "1-5"},
+		{"5,1,3,2,4", 4, "1-5"},
+		{"1-3,2-6,10", 2, "1-6,10"},
+		{"1,2", 1, "1,2"},
+		{"1-2", 1, "1,2"},
+		{"0,15,30,45", 0, "0,15,30,45"},
+		{"0-59", 0, "*"},
+		{"0-11,12-23", 1, "*"},
+		{"1-31", 2, "1-31"},
+		{"0-6", 4, "0-6"},
+		{"5-5", 1, "5"},
+		{"*/5", 0, "*/5"},
+		{"1-10/2", 1, "1-10/2"},
+		{"MON-FRI", 4, "MON-FRI"},
+		{"22-2", 1, "22-2"},
+		{"50-70", 0, "50-70"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.want, simplifyField(tt.field, tt.index))
+		})
+	}
+}
+
+func TestFormat_Normalize(t *testing.T) {
+	t.Run("expand aliases", func(t *testing.T) {
+		input := "@daily /a.sh\n@weekly /b.sh\n@reboot /c.sh\n"
+		expected := "0 0 * * * /a.sh\n0 0 * * 0 /b.sh\n@reboot /c.sh\n"
+		assert.Equal(t, expected, formatWith(t, input, FormatOptions{Align: AlignSingleSpace, Aliases: AliasExpand}))
+	})
+
+	t.Run("contract schedules", func(t *testing.T) {
+		input := "00 0 * * * /a.sh\n0 0 1 1 * /b.sh\n0 * * * * /c.sh\n0 1 * * * /d.sh\n"
+		expected := "@daily /a.sh\n@yearly /b.sh\n@hourly /c.sh\n0 1 * * * /d.sh\n"
+		assert.Equal(t, expected, formatWith(t, input, FormatOptions{Align: AlignSingleSpace, Aliases: AliasContract}))
+	})
+
+	t.Run("simplify before contracting", func(t *testing.T) {
+		input := "0 0-23 * * * /a.sh\n"
+		assert.Equal(t, "@hourly /a.sh\n", formatWith(t, input, FormatOptions{Aliases: AliasContract, Simplify: true}))
+	})
+
+	t.Run("preserve keeps the spacing of unchanged lines", func(t *testing.T) {
+		input := "0\t9\t*\t*\t1,2,3,4,5\t/a.sh\n0\t9\t*\t*\t0\t/b.sh\n"
+		expected := "0 9 * * 1-5 /a.sh\n0\t9\t*\t*\t0\t/b.sh\n"
+		assert.Equal(t, expected, formatWith(t, input, FormatOptions{Align: AlignPreserve, Simplify: true}))
+	})
+
+	t.Run("sort by time within blocks", func(t *testing.T) {
+		input := "0 9 * * * /late.sh\n# Early\n0 2 * * * /early.sh\n@reboot /boot.sh\nMAILTO=ops\n30 1 * * * /other.sh\n0 0 * * * /first.sh\n# trailing\n"
+		expected := "@reboot /boot.sh\n# Early\n0 2 * * * /early.sh\n0 9 * * * /late.sh\nMAILTO=ops\n0 0 * * * /first.sh\n30 1 * * * /other.sh\n# trailing\n"
+		assert.Equal(t, expected, formatWith(t, input, FormatOptions{Align: AlignSingleSpace, Sort: SortTime}))
+	})
+
+	t.Run("sort by command", func(t *testing.T) {
+		input := "0 1 * * * /usr/bin/zip.sh\n0 2 * * * /usr/bin/backup.sh\n\n0 3 * * * /c.sh\n0 4 * * * /b.sh\n"
+		expected := "0 2 * * * /usr/bin/backup.sh\n0 1 * * * /usr/bin/zip.sh\n\n0 4 * * * /b.sh\n0 3 * * * /c.sh\n"
+		assert.Equal(t, expected, formatWith(t, input, FormatOptions{Align: AlignSingleSpace, Sort: SortCommand}))
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		opts := FormatOptions{Aliases: AliasContract, Sort: SortTime, Simplify: true}
+		input := "*/5 * * * * /poll.sh\n0 0 * * 1,2,3 /a.sh\n@daily /b.sh\n0 0-23 * * * /c.sh\n"
+		once := formatWith(t, input, opts)
+		assert.Equal(t, once, formatWith(t, once, opts))
+	})
+}
@@ -15,8 +15,21 @@ var (
 	cronAliasRegex = regexp.MustCompile(`^@(reboot|yearly|annually|monthly|weekly|daily|hourly)`)
 )
 
-// ParseLine parses a single line from a crontab file and returns an Entry
+// ParseLine parses a single line from a user crontab and returns an Entry
 func ParseLine(line string, lineNumber int) *Entry {
+	return ParseLineLayout(line, lineNumber, LayoutUser)
+}
+
+// ParseSystemLine parses a single line from a system crontab (/etc/crontab,
+// /etc/cron.d), whose jobs name the user they run as between the schedule
+// and the command
+func ParseSystemLine(line string, lineNumber int) *Entry {
+	return ParseLineLayout(line, lineNumber, LayoutSystem)
+}
+
+// ParseLineLayout parses a single line of a crontab in the given layout;
+// LayoutAuto is read as LayoutUser
+func ParseLineLayout(line string, lineNumber int, layout Layout) *Entry {
 	entry := &Entry{
 		LineNumber: lineNumber,
 		Raw:        line,
@@ -44,7 +57,7 @@ func ParseLine(line string, lineNumber int) *Entry {
 	}
 
 	// Try to parse as cron job
-	job := parseJob(trimmed, lineNumber)
+	job := parseJob(trimmed, lineNumber, layout == LayoutSystem)
 	if job != nil {
 		entry.Type = EntryTypeJob
 		entry.Job = job
@@ -56,54 +69,38 @@ func ParseLine(line string, lineNumber int) *Entry {
 	return entry
 }
 
-// parseJob attempts to parse a cron job line
-// Returns nil if the line cannot be parsed as a job
-func parseJob(line string, lineNumber int) *Job {
+// parseJob attempts to parse a cron job line, whose user column follows the
+// schedule when system is set. Returns nil if the line cannot be parsed as a
+// job.
+func parseJob(line string, lineNumber int, system bool) *Job {
 	// Check for cron aliases first
 	if cronAliasRegex.MatchString(line) {
-		return parseAliasJob(line, lineNumber)
+		return parseScheduledJob(line, lineNumber, 1, system)
 	}
+	return parseScheduledJob(line, lineNumber, 5, system)
+}
 
+// parseScheduledJob parses a job whose schedule is its first scheduleFields
+// fields (1 for aliases such as @daily, 5 for cron expressions)
+func parseScheduledJob(line string, lineNumber int, scheduleFields int, system bool) *Job {
 	// Split by whitespace (handles both spaces and tabs)
 	fields := strings.Fields(line)
 
-	// Need at least 6 fields (5 for expression + 1 for command)
-	if len(fields) < 6 {
-		return nil
+	// Need the schedule, the user of system crontabs, and a command
+	commandField := scheduleFields
+	if system {
+		commandField++
 	}
-
-	// Extract cron expression (first 5 fields)
-	expression := strings.Join(fields[0:5], " ")
-
-	// Find the command (everything after the expression)
-	// We need to find where the command starts in the original line to preserve spacing
-	exprEnd := 0
-	fieldCount := 0
-	for i, char := range line {
-		if !isWhitespace(byte(char)) {
-			// Check if we've seen 5 non-whitespace segments
-			if i > 0 && isWhitespace(line[i-1]) {
-				fieldCount++
-				if fieldCount == 5 {
-					// Find start of command (skip whitespace after 5th field)
-					for j := i; j < len(line); j++ {
-						if isWhitespace(line[j]) {
-							continue
-						}
-						exprEnd = j
-						break
-					}
-					break
-				}
-			}
-		}
+	if len(fields) <= commandField {
+		return nil
 	}
 
-	if exprEnd == 0 {
+	// Find where the command starts in the original line to preserve spacing
+	start := fieldStart(line, commandField)
+	if start < 0 {
 		return nil
 	}
-
-	commandAndComment := line[exprEnd:]
+	commandAndComment := line[start:]
 
 	// Extract inline comment if present
 	var command, comment string
@@ -115,6 +112,7 @@ func parseJob(line string, lineNumber int) *Job {
 	}
 
 	// Validate the expression using our parser
+	expression := strings.Join(fields[:scheduleFields], " ")
 	parser := cronx.NewParser()
 	_, err := parser.Parse(expression)
 
@@ -125,6 +123,9 @@ func parseJob(line string, lineNumber int) *Job {
 		Comment:    comment,
 		Valid:      err == nil,
 	}
+	if system {
+		job.User = fields[scheduleFields]
+	}
 
 	if err != nil {
 		job.Error = err.Error()
@@ -133,42 +134,25 @@ func parseJob(line string, lineNumber int) *Job {
 	return job
 }
 
-// parseAliasJob parses a cron job with an alias (@daily, @hourly, etc.)
-func parseAliasJob(line string, lineNumber int) *Job {
-	fields := strings.Fields(line)
-	if len(fields) < 2 {
-		return nil
-	}
-
-	alias := fields[0]
-	commandAndComment := strings.TrimSpace(line[len(alias):])
-
-	// Extract inline comment if present
-	var command, comment string
-	if idx := strings.Index(commandAndComment, "#"); idx != -1 {
-		command = strings.TrimSpace(commandAndComment[:idx])
-		comment = strings.TrimSpace(commandAndComment[idx+1:])
-	} else {
-		command = commandAndComment
-	}
-
-	// Validate the alias using our parser
-	parser := cronx.NewParser()
-	_, err := parser.Parse(alias)
-
-	job := &Job{
-		LineNumber: lineNumber,
-		Expression: alias,
-		Command:    command,
-		Comment:    comment,
-		Valid:      err == nil,
+// fieldStart returns the index in line of the field following its first n
+// whitespace-separated fields, or -1 when there is none
+func fieldStart(line string, n int) int {
+	i := 0
+	for field := 0; field <= n; field++ {
+		for i < len(line) && isWhitespace(line[i]) {
+			i++
+		}
+		if field == n {
+			break
+		}
+		for i < len(line) && !isWhitespace(line[i]) {
+			i++
+		}
 	}
-
-	if err != nil {
-		job.Error = err.Error()
+	if i >= len(line) {
+		return -1
 	}
-
-	return job
+	return i
 }
 
 // isWhitespace checks if a byte is whitespace (space or tab)
@@ -1,10 +1,11 @@
 package crontab
 
 import (
-	"bufio"
+	"context"
 	"fmt"
+	"io"
+	"iter"
 	"os"
-	"os/exec"
 	"strings"
 )
 
@@ -24,14 +25,29 @@ type Reader interface {
 
 	// ParseStdin reads all entries (including comments, env vars) from standard input
 	ParseStdin() ([]*Entry, error)
+
+	// FileEntries iterates over the entries of a file as they are read
+	FileEntries(ctx context.Context, path string) iter.Seq2[*Entry, error]
+
+	// StdinEntries iterates over the entries of standard input as they are read
+	StdinEntries(ctx context.Context) iter.Seq2[*Entry, error]
 }
 
 // reader implements the Reader interface
-type reader struct{}
+type reader struct {
+	layout Layout
+}
 
-// NewReader creates a new crontab reader
+// NewReader creates a new crontab reader. Files named /etc/crontab or found
+// in a cron.d directory are read as system crontabs, with a user column.
 func NewReader() Reader {
-	return &reader{}
+	return &reader{layout: LayoutAuto}
+}
+
+// NewReaderWithLayout creates a crontab reader that reads files and standard
+// input in the given layout; LayoutAuto detects it from the file path
+func NewReaderWithLayout(layout Layout) Reader {
+	return &reader{layout: layout}
 }
 
 // ReadFile reads and parses cron jobs from a file
@@ -54,26 +70,23 @@ func (r *reader) ReadFile(path string) ([]*Job, error) {
 
 // ReadUser reads cron jobs from the current user's crontab using `crontab -l`
 func (r *reader) ReadUser() ([]*Job, error) {
-	// Execute `crontab -l` to get user's crontab
-	cmd := exec.Command("crontab", "-l")
-	output, err := cmd.Output()
+	content, err := ReadUserRaw()
+	if err != nil {
+		return nil, err
+	}
+	if content == "" {
+		// No crontab for user - return empty list, not an error
+		return []*Job{}, nil
+	}
+
+	entries, err := ParseReader(strings.NewReader(content))
 	if err != nil {
-		// If exit code is 1, it might mean no crontab exists
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 1 {
-				// No crontab for user - return empty list, not an error
-				return []*Job{}, nil
-			}
-		}
 		return nil, fmt.Errorf("failed to read user crontab: %w", err)
 	}
 
-	// Parse the output line by line
-	lines := strings.Split(string(output), "\n")
+	// Extract only job entries
 	var jobs []*Job
-
-	for lineNum, line := range lines {
-		entry := ParseLine(line, lineNum+1)
+	for _, entry := range entries {
 		if entry.Type == EntryTypeJob && entry.Job != nil {
 			jobs = append(jobs, entry.Job)
 		}
@@ -94,17 +107,8 @@ func (r *reader) ParseFile(path string) (entries []*Entry, err error) {
 		}
 	}()
 
-	scanner := bufio.NewScanner(file)
-	lineNumber := 0
-
-	for scanner.Scan() {
-		lineNumber++
-		line := scanner.Text()
-		entry := ParseLine(line, lineNumber)
-		entries = append(entries, entry)
-	}
-
-	if err := scanner.Err(); err != nil {
+	entries, err = ParseReaderLayout(file, r.layout.resolve(path))
+	if err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
 
@@ -131,19 +135,32 @@ func (r *reader) ReadStdin() ([]*Job, error) {
 
 // ParseStdin reads all entries from standard input
 func (r *reader) ParseStdin() (entries []*Entry, err error) {
-	scanner := bufio.NewScanner(os.Stdin)
-	lineNumber := 0
-
-	for scanner.Scan() {
-		lineNumber++
-		line := scanner.Text()
-		entry := ParseLine(line, lineNumber)
-		entries = append(entries, entry)
-	}
-
-	if err := scanner.Err(); err != nil {
+	entries, err = ParseReaderLayout(os.Stdin, r.layout.resolve(""))
+	if err != nil {
 		return nil, fmt.Errorf("error reading stdin: %w", err)
 	}
 
 	return entries, nil
 }
+
+// ParseReader reads all entries (including comments, env vars) of a user
+// crontab from r. Jobs record the time zone set by the CRON_TZ= or TZ= lines
+// preceding them, the variables set before them, their "@sla:" annotation,
+// the metadata of their "cronkit:" directives and the diagnostics their
+// "cronkit:ignore" annotations suppress.
+func ParseReader(r io.Reader) ([]*Entry, error) {
+	return ParseReaderLayout(r, LayoutUser)
+}
+
+// ParseReaderLayout reads all entries of a crontab in the given layout from
+// r, like ParseReader; LayoutAuto is read as LayoutUser
+func ParseReaderLayout(r io.Reader, layout Layout) ([]*Entry, error) {
+	var entries []*Entry
+	for entry, err := range Entries(context.Background(), r, layout) {
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -448,3 +448,21 @@ func TestParseFile_JobWithZeroLineNumber(t *testing.T) {
 		assert.Greater(t, job.LineNumber, 0, "Jobs from file should have line numbers > 0")
 	}
 }
+
+func TestParseReader(t *testing.T) {
+	t.Run("parses all entries with line numbers", func(t *testing.T) {
+		entries, err := ParseReader(strings.NewReader("# comment\nSHELL=/bin/sh\n0 2 * * * /usr/bin/backup.sh\n"))
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, EntryTypeComment, entries[0].Type)
+		assert.Equal(t, EntryTypeEnvVar, entries[1].Type)
+		assert.Equal(t, EntryTypeJob, entries[2].Type)
+		assert.Equal(t, 3, entries[2].Job.LineNumber)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		entries, err := ParseReader(strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,82 @@
+package crontab
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrProgramNotFound is returned when a program cannot be found on disk or
+// in $PATH
+var ErrProgramNotFound = errors.New("program not found")
+
+// shellBuiltins are programs the shell provides without a file on disk
+var shellBuiltins = map[string]bool{
+	".": true, ":": true, "[": true, "cd": true, "command": true, "echo": true,
+	"eval": true, "exec": true, "exit": true, "export": true, "false": true,
+	"if": true, "for": true, "while": true, "case": true, "{": true, "(": true,
+	"printf": true, "pwd": true, "read": true, "set": true, "source": true,
+	"test": true, "true": true, "ulimit": true, "umask": true, "unset": true,
+}
+
+// CommandProgram returns the program a command runs: its first word after
+// any VAR=value assignments, without quotes
+func CommandProgram(command string) string {
+	for _, word := range strings.Fields(command) {
+		if name, _, ok := strings.Cut(word, "="); ok && IsVariableName(name) {
+			continue
+		}
+		return strings.Trim(word, `"'`)
+	}
+	return ""
+}
+
+// LookProgram finds a program the way cron's shell would in env: paths are
+// checked on disk, relative ones from $HOME (where cron starts jobs), and
+// bare names are looked up in $PATH. It returns the file found, or "" for
+// shell builtins and programs that still contain shell syntax, which cannot
+// be resolved. Missing programs return ErrProgramNotFound.
+func LookProgram(program string, env map[string]string) (string, error) {
+	if program == "" || shellBuiltins[program] || strings.ContainsAny(program, "$`*?;|&<>(){}") {
+		return "", nil
+	}
+
+	if strings.Contains(program, "/") {
+		path := program
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(env["HOME"], path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", ErrProgramNotFound
+		}
+		return path, nil
+	}
+
+	for _, dir := range filepath.SplitList(env["PATH"]) {
+		path := filepath.Join(dir, program)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", ErrProgramNotFound
+}
+
+// ResolveCommand finds the program the job runs in its effective environment
+// (see Environment), after expanding ~ and variables. It returns the program
+// and the file it resolves to, which is "" for programs LookProgram cannot
+// resolve.
+func (j *Job) ResolveCommand(base map[string]string) (program, path string, err error) {
+	env := j.Environment(base)
+	program = CommandProgram(ExpandCommand(j.Command, env))
+	path, err = LookProgram(program, env)
+	return program, path, err
+}
+
+// Mailto returns the MAILTO set by the crontab before the job, and whether
+// it is set. An empty MAILTO turns off cron's mail of job output, so output
+// that is not redirected is lost.
+func (j *Job) Mailto() (string, bool) {
+	mailto, ok := j.Env["MAILTO"]
+	return mailto, ok
+}
@@ -0,0 +1,88 @@
+package crontab
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandProgram(t *testing.T) {
+	assert.Equal(t, "/usr/bin/backup", CommandProgram("/usr/bin/backup --full"))
+	assert.Equal(t, "run.sh", CommandProgram("LANG=C TZ=UTC run.sh"))
+	assert.Equal(t, "/opt/tool", CommandProgram(`"/opt/tool" --verbose`))
+	assert.Equal(t, "", CommandProgram("   "))
+}
+
+func TestLookProgram(t *testing.T) {
+	home := t.TempDir()
+	bin := filepath.Join(home, "bin")
+	require.NoError(t, os.MkdirAll(filepath.Join(bin, "subdir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "tool"), []byte("#!/bin/sh\n"), 0o755))
+	env := map[string]string{"HOME": home, "PATH": "/nonexistent:" + bin}
+
+	tests := []struct {
+		program string
+		path    string
+		err     error
+	}{
+		{"tool", filepath.Join(bin, "tool"), nil},
+		{filepath.Join(bin, "tool"), filepath.Join(bin, "tool"), nil},
+		{"bin/tool", filepath.Join(bin, "tool"), nil},
+		{"subdir", "", ErrProgramNotFound},
+		{"missing", "", ErrProgramNotFound},
+		{"/nonexistent/tool", "", ErrProgramNotFound},
+		{"cd", "", nil},
+		{"$APP/run.sh", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.program, func(t *testing.T) {
+			path, err := LookProgram(tt.program, env)
+			assert.Equal(t, tt.path, path)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestJobResolveCommand(t *testing.T) {
+	home := t.TempDir()
+	bin := filepath.Join(home, "bin")
+	require.NoError(t, os.MkdirAll(bin, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "tool"), []byte("#!/bin/sh\n"), 0o755))
+
+	content := strings.Join([]string{
+		"0 1 * * * tool",
+		"PATH=" + bin,
+		"MAILTO=\"\"",
+		"0 2 * * * tool --now",
+		"0 3 * * * $HOME/bin/tool",
+	}, "\n")
+	entries, err := ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	base := map[string]string{"HOME": home, "PATH": DefaultPath}
+
+	program, path, err := entries[0].Job.ResolveCommand(base)
+	assert.Equal(t, "tool", program)
+	assert.Empty(t, path)
+	assert.ErrorIs(t, err, ErrProgramNotFound, "PATH is only set after the first job")
+
+	_, path, err = entries[3].Job.ResolveCommand(base)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(bin, "tool"), path)
+
+	program, path, err = entries[4].Job.ResolveCommand(base)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "bin", "tool"), program)
+	assert.Equal(t, program, path)
+
+	t.Run("MAILTO in effect", func(t *testing.T) {
+		_, ok := entries[0].Job.Mailto()
+		assert.False(t, ok)
+		mailto, ok := entries[3].Job.Mailto()
+		assert.True(t, ok)
+		assert.Empty(t, mailto)
+	})
+}
@@ -0,0 +1,55 @@
+package crontab
+
+import "fmt"
+
+// SkippedLine is a crontab line left out of an analysis because it is invalid
+type SkippedLine struct {
+	LineNumber int    `json:"lineNumber"`
+	Line       string `json:"line"`   // Expression of an invalid job, or the raw line
+	Reason     string `json:"reason"` // Why the line was skipped
+}
+
+// String formats the skipped line as "line N: reason"
+func (s SkippedLine) String() string {
+	return fmt.Sprintf("line %d: %s", s.LineNumber, s.Reason)
+}
+
+// Partition splits entries into valid jobs and skipped lines: jobs with an
+// invalid expression and lines that are neither jobs, comments nor
+// environment variables
+func Partition(entries []*Entry) (jobs []*Job, skipped []SkippedLine) {
+	for _, entry := range entries {
+		switch {
+		case entry.Type == EntryTypeJob && entry.Job != nil:
+			if entry.Job.Valid {
+				jobs = append(jobs, entry.Job)
+				continue
+			}
+			skipped = append(skipped, SkippedLine{
+				LineNumber: entry.Job.LineNumber,
+				Line:       entry.Job.Expression,
+				Reason:     fmt.Sprintf("invalid cron expression: %s", entry.Job.Error),
+			})
+		case entry.Type == EntryTypeInvalid:
+			skipped = append(skipped, SkippedLine{
+				LineNumber: entry.LineNumber,
+				Line:       entry.Raw,
+				Reason:     "not a valid crontab line",
+			})
+		}
+	}
+	return jobs, skipped
+}
+
+// JobEntries wraps jobs in entries, for sources that only provide jobs
+func JobEntries(jobs []*Job) []*Entry {
+	entries := make([]*Entry, 0, len(jobs))
+	for _, job := range jobs {
+		entries = append(entries, &Entry{
+			Type:       EntryTypeJob,
+			LineNumber: job.LineNumber,
+			Job:        job,
+		})
+	}
+	return entries
+}
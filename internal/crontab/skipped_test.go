@@ -0,0 +1,54 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartition(t *testing.T) {
+	input := strings.Join([]string{
+		"# nightly jobs",
+		"SHELL=/bin/bash",
+		"0 2 * * * /bin/backup.sh",
+		"61 * * * * /bin/broken.sh",
+		"not a crontab line",
+		"",
+		"@daily /bin/cleanup.sh",
+	}, "\n")
+
+	entries, err := ParseReader(strings.NewReader(input))
+	require.NoError(t, err)
+
+	jobs, skipped := Partition(entries)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "0 2 * * *", jobs[0].Expression)
+	assert.Equal(t, "@daily", jobs[1].Expression)
+
+	require.Len(t, skipped, 2)
+	assert.Equal(t, 4, skipped[0].LineNumber)
+	assert.Equal(t, "61 * * * *", skipped[0].Line)
+	assert.Contains(t, skipped[0].Reason, "invalid cron expression")
+	assert.Equal(t, 5, skipped[1].LineNumber)
+	assert.Equal(t, "not a crontab line", skipped[1].Line)
+	assert.Equal(t, "line 5: not a valid crontab line", skipped[1].String())
+}
+
+func TestJobEntries(t *testing.T) {
+	jobs := []*Job{
+		{LineNumber: 1, Expression: "0 * * * *", Valid: true},
+		{LineNumber: 3, Expression: "bad", Error: "parse error"},
+	}
+
+	entries := JobEntries(jobs)
+	require.Len(t, entries, 2)
+	assert.Equal(t, EntryTypeJob, entries[1].Type)
+	assert.Equal(t, 3, entries[1].LineNumber)
+
+	valid, skipped := Partition(entries)
+	assert.Len(t, valid, 1)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "invalid cron expression: parse error", skipped[0].Reason)
+}
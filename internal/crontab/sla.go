@@ -0,0 +1,55 @@
+package crontab
+
+import (
+	"strings"
+	"time"
+)
+
+// SLAAnnotation introduces a job's maximum run duration in a comment, e.g.
+// "# @sla: 10m" on the line before the job or at the end of the job line
+const SLAAnnotation = "@sla:"
+
+// ParseSLA returns the duration of an "@sla:" annotation in comment. It
+// reports false when the comment has no annotation or its duration is not
+// a positive Go duration (e.g. "90s", "10m", "1h30m").
+func ParseSLA(comment string) (time.Duration, bool) {
+	_, value, found := strings.Cut(comment, SLAAnnotation)
+	if !found {
+		return 0, false
+	}
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	d, err := time.ParseDuration(fields[0])
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// slaState holds the SLA annotated in the current block of comment lines
+type slaState struct {
+	pending time.Duration
+}
+
+// apply records on a job the SLA annotated in its inline comment or in the
+// block of comment lines directly above it, or collects it from a comment
+func (s *slaState) apply(entry *Entry) {
+	switch entry.Type {
+	case EntryTypeComment:
+		if d, ok := ParseSLA(entry.Raw); ok {
+			s.pending = d
+		}
+	case EntryTypeJob:
+		if entry.Job != nil {
+			entry.Job.SLA = s.pending
+			if d, ok := ParseSLA(entry.Job.Comment); ok {
+				entry.Job.SLA = d
+			}
+		}
+		s.pending = 0
+	default:
+		s.pending = 0
+	}
+}
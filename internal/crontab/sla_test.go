@@ -0,0 +1,60 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSLA(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    time.Duration
+		ok      bool
+	}{
+		{"# @sla: 10m", 10 * time.Minute, true},
+		{"nightly backup @sla: 1h30m", 90 * time.Minute, true},
+		{"@sla:90s extra words", 90 * time.Second, true},
+		{"# @sla:", 0, false},
+		{"# @sla: ten minutes", 0, false},
+		{"# @sla: -5m", 0, false},
+		{"# plain comment", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			got, ok := ParseSLA(tt.comment)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseReader_SLA(t *testing.T) {
+	entries, err := ParseReader(strings.NewReader(`# Nightly backup
+# @sla: 10m
+0 2 * * * /usr/bin/backup.sh
+0 3 * * * /usr/bin/report.sh # @sla: 5m
+0 4 * * * /usr/bin/cleanup.sh
+
+# @sla: 1h
+
+0 5 * * * /usr/bin/sync.sh
+`))
+	require.NoError(t, err)
+
+	slas := map[string]time.Duration{}
+	for _, entry := range entries {
+		if entry.Type == EntryTypeJob {
+			slas[entry.Job.Command] = entry.Job.SLA
+		}
+	}
+	assert.Equal(t, map[string]time.Duration{
+		"/usr/bin/backup.sh":  10 * time.Minute,
+		"/usr/bin/report.sh":  5 * time.Minute,
+		"/usr/bin/cleanup.sh": 0,
+		"/usr/bin/sync.sh":    0, // Separated from the annotation by a blank line
+	}, slas)
+}
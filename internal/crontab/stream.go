@@ -0,0 +1,109 @@
+package crontab
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+)
+
+// maxLineSize is the longest crontab line read; longer lines are an error
+const maxLineSize = 1 << 20
+
+// annotator records on each job the context set by the lines before it, as
+// they are read: time zone, variables, SLA, directives and ignored codes
+type annotator struct {
+	timezones  timezoneState
+	env        environmentState
+	slas       slaState
+	directives directiveState
+	ignores    ignoreState
+}
+
+// apply annotates an entry and updates the context with it
+func (a *annotator) apply(entry *Entry) {
+	a.timezones.apply(entry)
+	a.env.apply(entry)
+	a.slas.apply(entry)
+	a.directives.apply(entry)
+	a.ignores.apply(entry)
+}
+
+// Entries returns an iterator over the entries of a crontab in the given
+// layout read from r, annotated like ParseReaderLayout. Each entry is parsed
+// as its line is read and earlier entries are not kept, so crontabs of any
+// size and endless streams are read in constant memory. A read error, or the
+// cause of the end of ctx, is yielded with a nil entry and ends the iteration.
+func Entries(ctx context.Context, r io.Reader, layout Layout) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		var annotations annotator
+		lineNumber := 0
+		for scanner.Scan() {
+			if ctx.Err() != nil {
+				yield(nil, context.Cause(ctx))
+				return
+			}
+			lineNumber++
+			entry := ParseLineLayout(scanner.Text(), lineNumber, layout)
+			annotations.apply(entry)
+			if !yield(entry, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// EntrySeq returns an iterator over entries already read, for code that
+// takes the entries of a crontab as they are read
+func EntrySeq(entries []*Entry) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		for _, entry := range entries {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FileEntries iterates over the entries of a crontab file like Entries,
+// keeping the file open until the iteration ends
+func (r *reader) FileEntries(ctx context.Context, path string) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		file, err := os.Open(path)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to open file: %w", err))
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		for entry, err := range Entries(ctx, file, r.layout.resolve(path)) {
+			if err != nil {
+				err = fmt.Errorf("error reading file: %w", err)
+			}
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
+
+// StdinEntries iterates over the entries of standard input like Entries
+func (r *reader) StdinEntries(ctx context.Context) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		for entry, err := range Entries(ctx, os.Stdin, r.layout.resolve("")) {
+			if err != nil {
+				err = fmt.Errorf("error reading stdin: %w", err)
+			}
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package crontab
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// endlessReader repeats a crontab line forever
+type endlessReader struct {
+	line string
+	pos  int
+}
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.line[r.pos]
+		r.pos = (r.pos + 1) % len(r.line)
+	}
+	return len(p), nil
+}
+
+func TestEntries(t *testing.T) {
+	const content = `CRON_TZ=America/New_York
+MAILTO=ops@example.com
+# cronkit:name=backup owner=infra
+# @sla: 30m
+0 2 * * * /usr/bin/backup.sh
+# cronkit:ignore CRON-001
+0 0 1 * 1 /usr/bin/report.sh
+not a crontab line
+`
+
+	t.Run("annotates like ParseReader", func(t *testing.T) {
+		expected, err := ParseReader(strings.NewReader(content))
+		require.NoError(t, err)
+
+		var entries []*Entry
+		for entry, err := range Entries(context.Background(), strings.NewReader(content), LayoutUser) {
+			require.NoError(t, err)
+			entries = append(entries, entry)
+		}
+		assert.Equal(t, expected, entries)
+		assert.Equal(t, "backup", entries[4].Job.Metadata.Name)
+		assert.True(t, entries[6].Job.Ignores("CRON-001"))
+	})
+
+	t.Run("reads endless input until the loop stops", func(t *testing.T) {
+		count := 0
+		for entry, err := range Entries(context.Background(), &endlessReader{line: "* * * * * /usr/bin/poll.sh\n"}, LayoutUser) {
+			require.NoError(t, err)
+			count++
+			if entry.LineNumber == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("stops when the context ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var lastErr error
+		count := 0
+		for entry, err := range Entries(ctx, &endlessReader{line: "* * * * * /usr/bin/poll.sh\n"}, LayoutUser) {
+			if err != nil {
+				assert.Nil(t, entry)
+				lastErr = err
+				continue
+			}
+			if count++; count == 2 {
+				cancel()
+			}
+		}
+		assert.ErrorIs(t, lastErr, context.Canceled)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("long lines", func(t *testing.T) {
+		long := "0 * * * * /usr/bin/echo " + strings.Repeat("x", 100*1024) + "\n"
+		var entries []*Entry
+		for entry, err := range Entries(context.Background(), strings.NewReader(long), LayoutUser) {
+			require.NoError(t, err)
+			entries = append(entries, entry)
+		}
+		require.Len(t, entries, 1)
+		assert.True(t, entries[0].Job.Valid)
+
+		var lastErr error
+		for _, err := range Entries(context.Background(), strings.NewReader(strings.Repeat("x", maxLineSize+1)), LayoutUser) {
+			lastErr = err
+		}
+		assert.Error(t, lastErr)
+	})
+}
+
+func TestReader_FileEntries(t *testing.T) {
+	reader := NewReader()
+
+	t.Run("sample file", func(t *testing.T) {
+		expected, err := reader.ParseFile("../../testdata/crontab/valid/sample.cron")
+		require.NoError(t, err)
+
+		var entries []*Entry
+		for entry, err := range reader.FileEntries(context.Background(), "../../testdata/crontab/valid/sample.cron") {
+			require.NoError(t, err)
+			entries = append(entries, entry)
+		}
+		assert.Equal(t, expected, entries)
+	})
+
+	t.Run("reader layout", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "backup")
+		require.NoError(t, os.WriteFile(path, []byte("0 2 * * * root /usr/bin/backup.sh\n"), 0o644))
+
+		for entry, err := range NewReaderWithLayout(LayoutSystem).FileEntries(context.Background(), path) {
+			require.NoError(t, err)
+			assert.Equal(t, "root", entry.Job.User)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		var lastErr error
+		for entry, err := range reader.FileEntries(context.Background(), "/nonexistent/crontab") {
+			assert.Nil(t, entry)
+			lastErr = err
+		}
+		require.Error(t, lastErr)
+		assert.Contains(t, lastErr.Error(), "failed to open file")
+	})
+}
+
+func TestEntrySeq(t *testing.T) {
+	entries := []*Entry{{Type: EntryTypeComment, LineNumber: 1}, {Type: EntryTypeEmpty, LineNumber: 2}}
+	var got []*Entry
+	for entry, err := range EntrySeq(entries) {
+		require.NoError(t, err)
+		got = append(got, entry)
+		break
+	}
+	assert.Equal(t, entries[:1], got)
+}
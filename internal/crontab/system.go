@@ -0,0 +1,40 @@
+package crontab
+
+import "path/filepath"
+
+// Layout tells whether the job lines of a crontab have a user column
+type Layout int
+
+const (
+	// LayoutAuto reads files named /etc/crontab or found in a cron.d
+	// directory as system crontabs, and everything else as user crontabs
+	LayoutAuto Layout = iota
+	// LayoutUser is "<schedule> <command>", as edited with crontab -e
+	LayoutUser
+	// LayoutSystem is "<schedule> <user> <command>", as in /etc/crontab and
+	// /etc/cron.d, where each job names the user it runs as
+	LayoutSystem
+)
+
+// DetectLayout returns the layout of the crontab at path: the system layout
+// for /etc/crontab and the files of cron.d directories, the user layout
+// otherwise
+func DetectLayout(path string) Layout {
+	clean := filepath.Clean(path)
+	if clean == "/etc/crontab" || filepath.Base(filepath.Dir(clean)) == "cron.d" {
+		return LayoutSystem
+	}
+	return LayoutUser
+}
+
+// resolve returns the layout used to read the crontab at path ("" for
+// standard input and user crontabs)
+func (l Layout) resolve(path string) Layout {
+	if l != LayoutAuto {
+		return l
+	}
+	if path == "" {
+		return LayoutUser
+	}
+	return DetectLayout(path)
+}
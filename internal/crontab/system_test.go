@@ -0,0 +1,151 @@
+package crontab
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSystemLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantType    EntryType
+		wantExpr    string
+		wantUser    string
+		wantCommand string
+		wantComment string
+	}{
+		{
+			name:        "cron expression",
+			line:        "17 * * * * root cd / && run-parts --report /etc/cron.hourly",
+			wantType:    EntryTypeJob,
+			wantExpr:    "17 * * * *",
+			wantUser:    "root",
+			wantCommand: "cd / && run-parts --report /etc/cron.hourly",
+		},
+		{
+			name:        "alias",
+			line:        "@daily  www-data\t/usr/bin/warmup # Warm caches",
+			wantType:    EntryTypeJob,
+			wantExpr:    "@daily",
+			wantUser:    "www-data",
+			wantCommand: "/usr/bin/warmup",
+			wantComment: "Warm caches",
+		},
+		{
+			name:     "missing command",
+			line:     "0 0 * * * root",
+			wantType: EntryTypeInvalid,
+		},
+		{
+			name:     "alias missing command",
+			line:     "@daily root",
+			wantType: EntryTypeInvalid,
+		},
+		{
+			name:     "environment variable",
+			line:     "MAILTO=ops@example.com",
+			wantType: EntryTypeEnvVar,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := ParseSystemLine(tt.line, 1)
+			require.NotNil(t, entry)
+			assert.Equal(t, tt.wantType, entry.Type)
+			if tt.wantType != EntryTypeJob {
+				assert.Nil(t, entry.Job)
+				return
+			}
+			require.NotNil(t, entry.Job)
+			assert.True(t, entry.Job.Valid)
+			assert.Equal(t, tt.wantExpr, entry.Job.Expression)
+			assert.Equal(t, tt.wantUser, entry.Job.User)
+			assert.Equal(t, tt.wantCommand, entry.Job.Command)
+			assert.Equal(t, tt.wantComment, entry.Job.Comment)
+		})
+	}
+}
+
+func TestParseLine_NoUserColumn(t *testing.T) {
+	entry := ParseLine("0 0 * * * root /usr/bin/backup", 1)
+	require.NotNil(t, entry.Job)
+	assert.Empty(t, entry.Job.User)
+	assert.Equal(t, "root /usr/bin/backup", entry.Job.Command)
+}
+
+func TestDetectLayout(t *testing.T) {
+	tests := []struct {
+		path string
+		want Layout
+	}{
+		{"/etc/crontab", LayoutSystem},
+		{"/etc/cron.d/backup", LayoutSystem},
+		{"deploy/cron.d/backup", LayoutSystem},
+		{"/etc/../etc/crontab", LayoutSystem},
+		{"/var/spool/cron/crontabs/alice", LayoutUser},
+		{"crontab", LayoutUser},
+		{"/etc/cron.daily/logrotate", LayoutUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLayout(tt.path))
+		})
+	}
+}
+
+func TestReader_Layout(t *testing.T) {
+	content := "0 2 * * * root /usr/bin/backup\n"
+	dir := filepath.Join(t.TempDir(), "cron.d")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	systemPath := filepath.Join(dir, "backup")
+	userPath := filepath.Join(t.TempDir(), "backup.cron")
+	require.NoError(t, os.WriteFile(systemPath, []byte(content), 0o644))
+	require.NoError(t, os.WriteFile(userPath, []byte(content), 0o644))
+
+	t.Run("auto-detects cron.d files", func(t *testing.T) {
+		jobs, err := NewReader().ReadFile(systemPath)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, "root", jobs[0].User)
+		assert.Equal(t, "/usr/bin/backup", jobs[0].Command)
+	})
+
+	t.Run("reads other files as user crontabs", func(t *testing.T) {
+		jobs, err := NewReader().ReadFile(userPath)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Empty(t, jobs[0].User)
+		assert.Equal(t, "root /usr/bin/backup", jobs[0].Command)
+	})
+
+	t.Run("forced system layout", func(t *testing.T) {
+		jobs, err := NewReaderWithLayout(LayoutSystem).ReadFile(userPath)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, "root", jobs[0].User)
+	})
+
+	t.Run("forced user layout", func(t *testing.T) {
+		jobs, err := NewReaderWithLayout(LayoutUser).ReadFile(systemPath)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Empty(t, jobs[0].User)
+	})
+
+	t.Run("ParseReaderLayout keeps directives", func(t *testing.T) {
+		entries, err := ParseReaderLayout(strings.NewReader("# cronkit: owner=infra\n"+content), LayoutSystem)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.NotNil(t, entries[1].Job)
+		assert.Equal(t, "root", entries[1].Job.User)
+		assert.Equal(t, "infra", entries[1].Job.Metadata.Owner)
+	})
+}
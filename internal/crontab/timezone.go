@@ -0,0 +1,79 @@
+package crontab
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Environment variables that set the time zone of the jobs following them.
+// CRON_TZ (cronie, robfig/cron) only affects scheduling and takes precedence
+// over TZ, which some cron implementations also honour.
+const (
+	CronTZVar = "CRON_TZ"
+	TZVar     = "TZ"
+)
+
+// Location returns the time zone the job is scheduled in, or fallback when
+// the crontab does not set one
+func (j *Job) Location(fallback *time.Location) (*time.Location, error) {
+	if j.Timezone == "" {
+		return fallback, nil
+	}
+	loc, err := time.LoadLocation(j.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: invalid time zone %q: %w", j.LineNumber, j.Timezone, err)
+	}
+	return loc, nil
+}
+
+// timezoneState holds the time zones set by the CRON_TZ= and TZ= lines read
+// so far
+type timezoneState struct {
+	cronTZ, tz string
+}
+
+// apply records on a job the time zone set by the CRON_TZ= or TZ= lines
+// preceding it, or updates the time zone from such a line
+func (s *timezoneState) apply(entry *Entry) {
+	switch entry.Type {
+	case EntryTypeEnvVar:
+		name, value, _ := parseEnvVar(entry.Raw)
+		switch name {
+		case CronTZVar:
+			s.cronTZ = value
+		case TZVar:
+			s.tz = value
+		}
+	case EntryTypeJob:
+		if entry.Job == nil {
+			return
+		}
+		entry.Job.Timezone = s.tz
+		if s.cronTZ != "" {
+			entry.Job.Timezone = s.cronTZ
+		}
+	}
+}
+
+// parseEnvVar splits an environment variable line into its name and value,
+// removing quotes around the value
+func parseEnvVar(line string) (name, value string, ok bool) {
+	name, value, ok = strings.Cut(strings.TrimSpace(line), "=")
+	if !ok {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return strings.TrimSpace(name), value, true
+}
+
+// EnvVar returns the name and value of an environment variable entry
+func (e *Entry) EnvVar() (name, value string, ok bool) {
+	if e.Type != EntryTypeEnvVar {
+		return "", "", false
+	}
+	return parseEnvVar(e.Raw)
+}
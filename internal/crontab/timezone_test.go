@@ -0,0 +1,76 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReader_Timezones(t *testing.T) {
+	input := strings.Join([]string{
+		"0 1 * * * /bin/local",
+		"TZ=Europe/Paris",
+		"0 2 * * * /bin/paris",
+		`CRON_TZ="America/New_York"`,
+		"0 3 * * * /bin/new-york",
+		"TZ=Asia/Tokyo",
+		"0 4 * * * /bin/still-new-york",
+		"CRON_TZ=",
+		"@daily /bin/tokyo",
+	}, "\n")
+
+	entries, err := ParseReader(strings.NewReader(input))
+	require.NoError(t, err)
+
+	var zones []string
+	for _, entry := range entries {
+		if entry.Type == EntryTypeJob {
+			zones = append(zones, entry.Job.Timezone)
+		}
+	}
+	assert.Equal(t, []string{"", "Europe/Paris", "America/New_York", "America/New_York", "Asia/Tokyo"}, zones)
+}
+
+func TestJob_Location(t *testing.T) {
+	t.Run("falls back without a time zone", func(t *testing.T) {
+		loc, err := (&Job{}).Location(time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, time.UTC, loc)
+	})
+
+	t.Run("loads the job time zone", func(t *testing.T) {
+		loc, err := (&Job{Timezone: "America/New_York"}).Location(time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", loc.String())
+	})
+
+	t.Run("reports invalid time zones with the line number", func(t *testing.T) {
+		_, err := (&Job{LineNumber: 7, Timezone: "Mars/Olympus"}).Location(time.UTC)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 7")
+		assert.Contains(t, err.Error(), "Mars/Olympus")
+	})
+}
+
+func TestParseEnvVar(t *testing.T) {
+	tests := []struct {
+		line, name, value string
+	}{
+		{"CRON_TZ=UTC", "CRON_TZ", "UTC"},
+		{"  TZ = 'Europe/Berlin'  ", "TZ", "Europe/Berlin"},
+		{`PATH="/usr/bin:/bin"`, "PATH", "/usr/bin:/bin"},
+		{"EMPTY=", "EMPTY", ""},
+	}
+	for _, tt := range tests {
+		name, value, ok := parseEnvVar(tt.line)
+		assert.True(t, ok, tt.line)
+		assert.Equal(t, tt.name, name, tt.line)
+		assert.Equal(t, tt.value, value, tt.line)
+	}
+
+	_, _, ok := parseEnvVar("no assignment")
+	assert.False(t, ok)
+}
@@ -0,0 +1,39 @@
+package crontab
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ReadUserRaw returns the text of the current user's crontab as printed by
+// `crontab -l`, or "" when the user has no crontab
+func ReadUserRaw() (string, error) {
+	output, err := exec.Command("crontab", "-l").Output()
+	if err != nil {
+		// Exit code 1 means the user has no crontab
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read user crontab: %w", err)
+	}
+	return string(output), nil
+}
+
+// InstallUser replaces the current user's crontab with content using `crontab -`
+func InstallUser(content string) error {
+	cmd := exec.Command("crontab", "-")
+	cmd.Stdin = strings.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("failed to install user crontab: %s: %w", msg, err)
+		}
+		return fmt.Errorf("failed to install user crontab: %w", err)
+	}
+	return nil
+}
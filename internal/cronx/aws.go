@@ -0,0 +1,143 @@
+package cronx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateUnits maps the units of EventBridge rate expressions to their duration
+var rateUnits = map[string]time.Duration{
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+}
+
+// parseAWS parses an Amazon EventBridge schedule expression: cron(...) with
+// six fields (minute hour day-of-month month day-of-week year) and the Quartz
+// day modifiers, or rate(value unit). The cron( ) wrapper is optional.
+func (p *parser) parseAWS(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	lower := strings.ToLower(spec)
+	switch {
+	case strings.HasPrefix(lower, "rate(") && strings.HasSuffix(spec, ")"):
+		return parseRate(expression, spec[len("rate("):len(spec)-1], p.symbols)
+	case strings.HasPrefix(lower, "cron(") && strings.HasSuffix(spec, ")"):
+		spec = spec[len("cron(") : len(spec)-1]
+	case strings.HasPrefix(spec, "@"):
+		return nil, fmt.Errorf("EventBridge does not support aliases; use cron(...) or rate(...)")
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("expected 6 fields (%s), got %d", fieldList(awsSyntax.Fields), len(fields))
+	}
+
+	// EventBridge cron is Quartz without the seconds field
+	schedule, err := p.parseQuartz("0 " + strings.Join(fields, " "))
+	if err != nil {
+		return nil, err
+	}
+	schedule.Original = expression
+	schedule.Dialect = DialectAWS
+	schedule.Second = nil
+	return schedule, nil
+}
+
+// parseRate parses the "value unit" of a rate expression. EventBridge
+// requires the singular unit for a value of 1 and the plural otherwise.
+func parseRate(expression, spec string, symbols SymbolRegistry) (*Schedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("rate expressions need a value and a unit, as in rate(5 minutes)")
+	}
+
+	value, err := strconv.Atoi(parts[0])
+	if err != nil || value < 1 {
+		return nil, fmt.Errorf("rate value must be a positive integer, got %q", parts[0])
+	}
+	unit := strings.ToLower(parts[1])
+	d, ok := rateUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("unknown rate unit %q (supported: minute, minutes, hour, hours, day, days)", parts[1])
+	}
+	if plural := strings.HasSuffix(unit, "s"); plural != (value > 1) {
+		if value == 1 {
+			return nil, fmt.Errorf("a rate of 1 takes a singular unit: rate(1 %s)", strings.TrimSuffix(unit, "s"))
+		}
+		return nil, fmt.Errorf("a rate of %d takes a plural unit: rate(%d %ss)", value, value, unit)
+	}
+
+	rate := time.Duration(value) * d
+	fields := strings.Fields(RateToStandard(rate))
+	if len(fields) != 5 {
+		fields = strings.Fields(approximateRate(rate))
+	}
+	return &Schedule{
+		Original:   expression,
+		Dialect:    DialectAWS,
+		Rate:       rate,
+		Minute:     parseField(fields[0], MinMinute, MaxMinute, symbols),
+		Hour:       parseField(fields[1], MinHour, MaxHour, symbols),
+		DayOfMonth: parseField(fields[2], MinDayOfMonth, MaxDayOfMonth, symbols),
+		Month:      parseField(fields[3], MinMonth, MaxMonth, symbols),
+		DayOfWeek:  parseField(fields[4], MinDayOfWeek, MaxDayOfWeek, symbols),
+	}, nil
+}
+
+// RateToStandard returns the standard cron expression that runs at the
+// interval of a rate, or an empty string when cron cannot express it: the
+// interval must divide an hour (minutes), a day (hours), or be one day
+func RateToStandard(rate time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case rate == day:
+		return "0 0 * * *"
+	case rate == time.Hour:
+		return "0 * * * *"
+	case rate == time.Minute:
+		return "* * * * *"
+	case rate < time.Hour && rate%time.Minute == 0 && time.Hour%rate == 0:
+		return fmt.Sprintf("*/%d * * * *", rate/time.Minute)
+	case rate < day && rate%time.Hour == 0 && day%rate == 0:
+		return fmt.Sprintf("0 */%d * * *", rate/time.Hour)
+	}
+	return ""
+}
+
+// approximateRate returns the fields of a rate that cron cannot express
+// exactly, rounded to a step within the enclosing hour, day or month. They
+// only serve checks that inspect fields; runs are computed from the rate.
+func approximateRate(rate time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case rate < time.Hour:
+		return fmt.Sprintf("*/%d * * * *", rate/time.Minute)
+	case rate < day:
+		return fmt.Sprintf("0 */%d * * *", rate/time.Hour)
+	case rate < 28*day:
+		return fmt.Sprintf("0 0 */%d * *", rate/day)
+	}
+	return "0 0 1 * *"
+}
+
+// rateNext returns the first run of a rate strictly after from. EventBridge
+// counts intervals from the creation of the rule, which is not known here;
+// runs are placed on the grid of the interval since Go's zero time, which is
+// aligned with midnight UTC for intervals that divide a day.
+func rateNext(rate time.Duration, from time.Time) time.Time {
+	return from.Truncate(rate).Add(rate)
+}
+
+// ratePrev returns the last run of a rate strictly before from
+func ratePrev(rate time.Duration, from time.Time) time.Time {
+	t := from.Truncate(rate)
+	if !t.Before(from) {
+		t = t.Add(-rate)
+	}
+	return t
+}
@@ -0,0 +1,148 @@
+package cronx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_AWS(t *testing.T) {
+	parser := NewParserWithOptions("en", ParserOptions{Dialect: DialectAWS})
+
+	t.Run("cron expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			"cron(0 12 * * ? *)",
+			"cron(0/15 8-17 ? * MON-FRI *)",
+			"CRON(15 10 L * ? 2026)",
+			"0 8 1W * ? *",
+			"0 18 ? * 6L *",
+			"0 9 ? * 2#1 2026-2030",
+		} {
+			schedule, err := parser.Parse(expression)
+			require.NoError(t, err, expression)
+			assert.Equal(t, DialectAWS, schedule.Dialect, expression)
+			assert.Equal(t, expression, schedule.Original, expression)
+			assert.False(t, schedule.HasSeconds(), expression)
+			assert.NotNil(t, schedule.Year, expression)
+			assert.Zero(t, schedule.Rate, expression)
+		}
+	})
+
+	t.Run("day-of-week runs from SUN=1", func(t *testing.T) {
+		schedule, err := parser.Parse("cron(0 9 ? * 2-6 *)")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, schedule.DayOfWeek.Values())
+	})
+
+	t.Run("rate expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			rate       time.Duration
+			minute     string
+			hour       string
+		}{
+			{"rate(1 minute)", time.Minute, "*", "*"},
+			{"rate(5 minutes)", 5 * time.Minute, "*/5", "*"},
+			{"rate(1 hour)", time.Hour, "0", "*"},
+			{"rate(6 hours)", 6 * time.Hour, "0", "*/6"},
+			{"Rate(1 Day)", 24 * time.Hour, "0", "0"},
+			{"rate(7 minutes)", 7 * time.Minute, "*/7", "*"},
+		}
+		for _, tt := range tests {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err, tt.expression)
+			assert.Equal(t, tt.rate, schedule.Rate, tt.expression)
+			assert.Equal(t, tt.minute, schedule.Minute.Raw(), tt.expression)
+			assert.Equal(t, tt.hour, schedule.Hour.Raw(), tt.expression)
+		}
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			contains   string
+		}{
+			{"cron(0 12 * * *)", "expected 6 fields"},
+			{"cron(0 12 * * * *)", "must be '?'"},
+			{"cron(0 12 ? * ? *)", "only one of"},
+			{"@daily", "does not support aliases"},
+			{"rate(1 minutes)", "singular unit"},
+			{"rate(5 minute)", "plural unit"},
+			{"rate(0 minutes)", "positive integer"},
+			{"rate(5 weeks)", "unknown rate unit"},
+			{"rate(5)", "value and a unit"},
+		}
+		for _, tt := range tests {
+			_, err := parser.Parse(tt.expression)
+			require.Error(t, err, tt.expression)
+			assert.Contains(t, err.Error(), tt.contains, tt.expression)
+		}
+	})
+}
+
+func TestRateToStandard(t *testing.T) {
+	tests := []struct {
+		rate     time.Duration
+		expected string
+	}{
+		{time.Minute, "* * * * *"},
+		{15 * time.Minute, "*/15 * * * *"},
+		{time.Hour, "0 * * * *"},
+		{8 * time.Hour, "0 */8 * * *"},
+		{24 * time.Hour, "0 0 * * *"},
+		{7 * time.Minute, ""},
+		{90 * time.Minute, ""},
+		{48 * time.Hour, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, RateToStandard(tt.rate), tt.rate.String())
+	}
+}
+
+func TestScheduler_AWS(t *testing.T) {
+	scheduler := NewSchedulerWithOptions(ParserOptions{Dialect: DialectAWS})
+	from := time.Date(2026, 1, 5, 10, 7, 30, 0, time.UTC)
+
+	t.Run("rates run on the interval grid", func(t *testing.T) {
+		times, err := scheduler.Next("rate(90 minutes)", from, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC),
+			time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC),
+			time.Date(2026, 1, 5, 13, 30, 0, 0, time.UTC),
+		}, times)
+
+		times, err = scheduler.Prev("rate(90 minutes)", from, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+			time.Date(2026, 1, 5, 7, 30, 0, 0, time.UTC),
+		}, times)
+	})
+
+	t.Run("runs on the grid are excluded", func(t *testing.T) {
+		onGrid := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+		next, err := scheduler.Next("rate(5 minutes)", onGrid, 1)
+		require.NoError(t, err)
+		assert.Equal(t, onGrid.Add(5*time.Minute), next[0])
+
+		prev, err := scheduler.Prev("rate(5 minutes)", onGrid, 1)
+		require.NoError(t, err)
+		assert.Equal(t, onGrid.Add(-5*time.Minute), prev[0])
+	})
+
+	t.Run("cron expressions use the Quartz day rules", func(t *testing.T) {
+		times, err := scheduler.Next("cron(15 10 L * ? 2026)", from, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 1, 31, 10, 15, 0, 0, time.UTC),
+			time.Date(2026, 2, 28, 10, 15, 0, 0, time.UTC),
+		}, times)
+
+		times, err = scheduler.Next("cron(0 9 ? * MON-FRI 2026)", time.Date(2026, 12, 31, 10, 0, 0, 0, time.UTC), 1)
+		require.NoError(t, err)
+		assert.True(t, times[0].IsZero(), "no run after the last year")
+	})
+}
@@ -0,0 +1,42 @@
+package cronx
+
+import "sync/atomic"
+
+// Process-wide parse cache counters, shared by all parsers
+var (
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+)
+
+// Process-wide schedule cache counters, shared by all schedulers
+var (
+	scheduleHits   atomic.Uint64
+	scheduleMisses atomic.Uint64
+)
+
+// CacheStats reports how often parsers or schedulers answered from their cache
+type CacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// HitRate returns the fraction of lookups answered from the cache (0-1), or 0
+// before any lookup
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// ParseCacheStats returns the parse cache counters of all parsers in the process
+func ParseCacheStats() CacheStats {
+	return CacheStats{Hits: cacheHits.Load(), Misses: cacheMisses.Load()}
+}
+
+// ScheduleCacheStats returns the schedule cache counters of all schedulers in
+// the process
+func ScheduleCacheStats() CacheStats {
+	return CacheStats{Hits: scheduleHits.Load(), Misses: scheduleMisses.Load()}
+}
@@ -0,0 +1,28 @@
+package cronx_test
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCacheStats(t *testing.T) {
+	before := cronx.ParseCacheStats()
+
+	parser := cronx.NewParser()
+	for i := 0; i < 3; i++ {
+		_, err := parser.Parse("17 4 * * 2")
+		require.NoError(t, err)
+	}
+
+	after := cronx.ParseCacheStats()
+	assert.GreaterOrEqual(t, after.Misses-before.Misses, uint64(1))
+	assert.GreaterOrEqual(t, after.Hits-before.Hits, uint64(2))
+}
+
+func TestCacheStats_HitRate(t *testing.T) {
+	assert.Zero(t, cronx.CacheStats{}.HitRate())
+	assert.InDelta(t, 0.75, cronx.CacheStats{Hits: 3, Misses: 1}.HitRate(), 1e-9)
+}
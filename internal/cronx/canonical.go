@@ -0,0 +1,82 @@
+package cronx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// canonicalParser parses the expressions given to Canonicalize
+var canonicalParser = NewParserWithSeconds("en", SecondsOptional)
+
+// Canonicalize rewrites a standard cron expression (5 fields, 6 with
+// seconds, or an @alias) in a canonical form: every field lists the values
+// it matches in the notation of CompressValues, with names replaced by
+// numbers, aliases expanded and a zero seconds field dropped. Expressions
+// that produce the same runs have the same canonical form, including how the
+// day-of-month and day-of-week fields combine: when both are restricted a
+// day matching either runs, so "0 0 1-31 * 1" is "0 0 * * *". Impossible
+// dates are not resolved: "0 0 30 2 *" never runs but keeps its fields.
+func Canonicalize(expression string) (string, error) {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(strings.ToLower(expression), "@every") {
+		return "", fmt.Errorf("%q runs at intervals, not at fixed times", expression)
+	}
+	schedule, err := canonicalParser.Parse(expression)
+	if err != nil {
+		return "", err
+	}
+
+	fields := []string{
+		CompressValues(schedule.Minute.Values(), MinMinute, MaxMinute),
+		CompressValues(schedule.Hour.Values(), MinHour, MaxHour),
+		CompressValues(schedule.DayOfMonth.Values(), MinDayOfMonth, MaxDayOfMonth),
+		CompressValues(schedule.Month.Values(), MinMonth, MaxMonth),
+		CompressValues(schedule.DayOfWeek.Values(), MinDayOfWeek, MaxDayOfWeek),
+	}
+
+	// A restricted day field that matches every day makes the other one
+	// irrelevant when both are restricted; when only one is, the other
+	// already is "*"
+	if !unrestricted(schedule.DayOfMonth) && !unrestricted(schedule.DayOfWeek) &&
+		(fields[2] == "*" || fields[4] == "*") {
+		fields[2], fields[4] = "*", "*"
+	}
+	if unrestricted(schedule.DayOfMonth) {
+		fields[2] = "*"
+	}
+	if unrestricted(schedule.DayOfWeek) {
+		fields[4] = "*"
+	}
+
+	if schedule.Second != nil {
+		if second := CompressValues(schedule.Second.Values(), MinSecond, MaxSecond); second != "0" {
+			fields = append([]string{second}, fields...)
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// Equivalent reports whether two standard cron expressions produce the same
+// runs, by comparing their canonical forms
+func Equivalent(a, b string) (bool, error) {
+	canonicalA, err := Canonicalize(a)
+	if err != nil {
+		return false, err
+	}
+	canonicalB, err := Canonicalize(b)
+	if err != nil {
+		return false, err
+	}
+	return canonicalA == canonicalB, nil
+}
+
+// unrestricted reports whether a day field leaves the days to the other day
+// field, as "*" or "?" without a step does
+func unrestricted(f Field) bool {
+	for _, part := range strings.Split(f.Raw(), ",") {
+		if part == "*" || part == "?" || part == "*/1" {
+			return true
+		}
+	}
+	return false
+}
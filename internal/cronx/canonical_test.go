@@ -0,0 +1,80 @@
+package cronx_test
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"0 0 * * *", "0 0 * * *"},
+		{"@daily", "0 0 * * *"},
+		{"@weekly", "0 0 * * 0"},
+		{"0,15,30,45 * * * *", "*/15 * * * *"},
+		{"0-59/15 * * * *", "*/15 * * * *"},
+		{"0 9 * * MON-FRI", "0 9 * * 1-5"},
+		{"0 9 * jan,feb,mar 1,2,3,4,5", "0 9 * 1-3 1-5"},
+		{"0 0-23 * * *", "0 * * * *"},
+		{"  30   2 * * *  ", "30 2 * * *"},
+		{"0 0 1-31 * 1", "0 0 * * *"},
+		{"0 0 1 * 0-6", "0 0 * * *"},
+		{"0 0 * * 0-6", "0 0 * * *"},
+		{"0 0 1 * 1", "0 0 1 * 1"},
+		{"0 0 */2 * 1", "0 0 */2 * 1"},
+		{"0 0 0 * * *", "0 0 * * *"},
+		{"*/30 0 0 * * *", "0,30 0 0 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			canonical, err := cronx.Canonicalize(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, canonical)
+
+			again, err := cronx.Canonicalize(canonical)
+			require.NoError(t, err)
+			assert.Equal(t, canonical, again, "canonical forms are canonical")
+		})
+	}
+
+	for _, expression := range []string{"", "@reboot", "@every 5m", "60 * * * *", "* * * *"} {
+		t.Run("invalid "+expression, func(t *testing.T) {
+			_, err := cronx.Canonicalize(expression)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		equivalent bool
+	}{
+		{"0 0 * * *", "@midnight", true},
+		{"*/20 * * * *", "0,20,40 * * * *", true},
+		{"0 9 * * 1-5", "0 9 * * MON,TUE,WED,THU,FRI", true},
+		{"0 0 1 * *", "@monthly", true},
+		{"0 0 * * 1", "0 0 1-31 * 1", false},
+		{"0 0 * * *", "0 0 * * 1", false},
+		{"0 2 * * *", "0 3 * * *", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" = "+tt.b, func(t *testing.T) {
+			equivalent, err := cronx.Equivalent(tt.a, tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.equivalent, equivalent)
+		})
+	}
+
+	_, err := cronx.Equivalent("0 0 * * *", "bogus")
+	assert.Error(t, err)
+	_, err = cronx.Equivalent("bogus", "0 0 * * *")
+	assert.Error(t, err)
+}
@@ -0,0 +1,262 @@
+package cronx
+
+import (
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// everyPrefix starts robfig/cron's fixed-interval descriptor, e.g. "@every 90m"
+const everyPrefix = "@every "
+
+// compiledSchedule holds the fields of a schedule as bitsets, bit v of a mask
+// being set when the field matches v, so that runs are found with a few bit
+// operations per day instead of walking the expanded field values
+type compiledSchedule struct {
+	second uint64 // Bit 0 only for schedules without a seconds field
+	minute uint64
+	hour   uint64
+	dom    uint64 // Bits 1-31
+	month  uint64 // Bits 1-12
+	dow    uint64 // Bits 0-6, Sunday=0
+
+	years  [3]uint64 // Bit y-MinYear, used when hasYears is set
+	either bool      // Standard cron: a day matches when either day field matches
+	quartz *QuartzDays
+
+	hasYears bool
+	every    time.Duration // Interval of an @every descriptor, 0 otherwise
+}
+
+// compileSchedule compiles the fields of a schedule. Rate schedules are not
+// compiled, since their fields only approximate the rate.
+func compileSchedule(s *Schedule) *compiledSchedule {
+	if s.Rate > 0 {
+		return nil
+	}
+	if strings.HasPrefix(s.Original, everyPrefix) {
+		// Like robfig/cron, intervals are whole seconds and at least one second
+		every, _ := time.ParseDuration(s.Original[len(everyPrefix):])
+		every = max(every-every%time.Second, time.Second)
+		return &compiledSchedule{every: every}
+	}
+
+	c := &compiledSchedule{
+		second: 1,
+		minute: fieldMask(s.Minute, MinMinute, MaxMinute),
+		hour:   fieldMask(s.Hour, MinHour, MaxHour),
+		dom:    fieldMask(s.DayOfMonth, MinDayOfMonth, MaxDayOfMonth),
+		month:  fieldMask(s.Month, MinMonth, MaxMonth),
+		dow:    fieldMask(s.DayOfWeek, MinDayOfWeek, MaxDayOfWeek),
+		quartz: s.Quartz,
+	}
+	if s.HasSeconds() {
+		c.second = fieldMask(s.Second, MinSecond, MaxSecond)
+	}
+	if s.Year != nil {
+		c.hasYears = true
+		for _, y := range s.Year.Values() {
+			if y >= MinYear && y <= MaxYear {
+				c.years[(y-MinYear)/64] |= 1 << ((y - MinYear) % 64)
+			}
+		}
+	}
+	// Like robfig/cron, days match with OR unless either day field has a
+	// '*' (or '?') without a step
+	if !s.MatchesBothDays() {
+		c.either = !hasBareStar(s.DayOfMonth) && !hasBareStar(s.DayOfWeek)
+	}
+	return c
+}
+
+// hasBareStar reports whether a component of a field is '*' or '?' without a step
+func hasBareStar(f Field) bool {
+	for _, part := range f.Parts() {
+		if (part.Every || part.Raw == "?") && part.Step <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldMask returns the bitset of the values a field matches; "?" matches
+// every value, as in robfig/cron
+func fieldMask(f Field, min, max int) uint64 {
+	var mask uint64
+	if f.Raw() == "?" {
+		for v := min; v <= max; v++ {
+			mask |= 1 << v
+		}
+		return mask
+	}
+	for _, v := range f.Values() {
+		if v >= 0 && v < 64 {
+			mask |= 1 << v
+		}
+	}
+	return mask
+}
+
+// nextBit returns the lowest set bit of mask at or above from, or -1
+func nextBit(mask uint64, from int) int {
+	if from >= 64 {
+		return -1
+	}
+	rest := mask & (^uint64(0) << from)
+	if rest == 0 {
+		return -1
+	}
+	return bits.TrailingZeros64(rest)
+}
+
+// countsFromStart reports whether the runs of the schedule are intervals
+// counted from the start time, as for rate(...) and @every, rather than
+// times on the wall clock
+func (s *Schedule) countsFromStart() bool {
+	return s.Rate > 0 || strings.HasPrefix(s.Original, everyPrefix)
+}
+
+// NextAfter returns the first run of the schedule strictly after t, or the
+// zero time when there is none within searchYears. Runs follow the wall clock
+// of t's location like robfig/cron: a time skipped by a DST change does not
+// run, and a repeated one runs twice. Schedules returned by a parser are
+// compiled once, so calls neither parse nor expand fields.
+func (s *Schedule) NextAfter(t time.Time) time.Time {
+	if s.Rate > 0 {
+		return rateNext(s.Rate, t)
+	}
+	c := s.compiled
+	if c == nil {
+		c = compileSchedule(s)
+	}
+	return c.next(t)
+}
+
+// next returns the first run strictly after from. Within a zone period the
+// wall clock is a fixed offset from UTC, so each period is searched as UTC
+// wall-clock time and the result shifted back.
+func (c *compiledSchedule) next(from time.Time) time.Time {
+	if c.every > 0 {
+		return from.Add(c.every - time.Duration(from.Nanosecond()))
+	}
+
+	loc := from.Location()
+	t := from.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(searchYears, 0, 0)
+	for t.Before(limit) {
+		_, offset := t.Zone()
+		_, end := t.ZoneBounds()
+		if !end.IsZero() && !end.After(t) {
+			// Zones extended by a rule can report a period ending at t at the
+			// turn of a year; no zone changes its offset then
+			end = t.Add(24 * time.Hour)
+		}
+		until := limit
+		if !end.IsZero() && end.Before(limit) {
+			until = end
+		}
+
+		shift := time.Duration(offset) * time.Second
+		if wall, ok := c.nextWall(t.UTC().Add(shift), until.UTC().Add(shift)); ok {
+			return wall.Add(-shift).In(loc)
+		}
+		t = until
+	}
+	return time.Time{}
+}
+
+// nextWall returns the first wall-clock time at or after from and before
+// until (both in UTC) that the schedule matches
+func (c *compiledSchedule) nextWall(from, until time.Time) (time.Time, bool) {
+	y, month, d := from.Date()
+	mo := int(month)
+	h, m, s := from.Clock()
+	uy, umonth, ud := until.Date()
+	last := dayIndex(uy, int(umonth), ud)
+
+	for dayIndex(y, mo, d) <= last {
+		switch {
+		case c.hasYears && !c.yearMatches(y):
+			next := c.nextYear(y + 1)
+			if next < 0 {
+				return time.Time{}, false
+			}
+			y, mo, d, h, m, s = next, 1, 1, 0, 0, 0
+		case c.month&(1<<mo) == 0:
+			if mo++; mo > 12 {
+				y, mo = y+1, 1
+			}
+			d, h, m, s = 1, 0, 0, 0
+		case d > daysIn(y, time.Month(mo)):
+			if mo++; mo > 12 {
+				y, mo = y+1, 1
+			}
+			d, h, m, s = 1, 0, 0, 0
+		default:
+			if c.dayMatches(y, mo, d) {
+				if hh, mm, ss, ok := c.nextClock(h, m, s); ok {
+					run := time.Date(y, time.Month(mo), d, hh, mm, ss, 0, time.UTC)
+					return run, run.Before(until)
+				}
+			}
+			d, h, m, s = d+1, 0, 0, 0
+		}
+	}
+	return time.Time{}, false
+}
+
+// dayIndex orders calendar days
+func dayIndex(y, mo, d int) int {
+	return y<<9 | mo<<5 | d
+}
+
+// yearMatches reports whether the year field matches y
+func (c *compiledSchedule) yearMatches(y int) bool {
+	i := y - MinYear
+	return i >= 0 && y <= MaxYear && c.years[i/64]&(1<<(i%64)) != 0
+}
+
+// nextYear returns the first year at or after y the year field matches, or -1
+func (c *compiledSchedule) nextYear(y int) int {
+	for i := max(y-MinYear, 0); i <= MaxYear-MinYear; i++ {
+		if c.years[i/64]&(1<<(i%64)) != 0 {
+			return MinYear + i
+		}
+	}
+	return -1
+}
+
+// dayMatches reports whether the day fields and Quartz modifiers match a day
+// of a matching month
+func (c *compiledSchedule) dayMatches(y, mo, d int) bool {
+	day := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, time.UTC)
+	dom := c.dom&(1<<d) != 0
+	dow := c.dow&(1<<int(day.Weekday())) != 0
+	if c.either {
+		if !dom && !dow {
+			return false
+		}
+	} else if !dom || !dow {
+		return false
+	}
+	return c.quartz == nil || c.quartz.matches(day)
+}
+
+// nextClock returns the first time of day at or after h:m:s that the time
+// fields match
+func (c *compiledSchedule) nextClock(h, m, s int) (int, int, int, bool) {
+	for hh := nextBit(c.hour, h); hh >= 0; hh = nextBit(c.hour, hh+1) {
+		if hh > h {
+			m, s = 0, 0
+		}
+		for mm := nextBit(c.minute, m); mm >= 0; mm = nextBit(c.minute, mm+1) {
+			if mm > m {
+				s = 0
+			}
+			if ss := nextBit(c.second, s); ss >= 0 {
+				return hh, mm, ss, true
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
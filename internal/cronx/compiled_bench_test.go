@@ -0,0 +1,46 @@
+package cronx
+
+import (
+	"testing"
+	"time"
+)
+
+// benchmarkExpressions cover the common shapes of schedules: frequent, daily,
+// restricted to a few days, and rare
+var benchmarkExpressions = map[string]string{
+	"Every5Min":  "*/5 * * * *",
+	"Daily":      "30 2 * * *",
+	"Weekdays":   "*/15 9-17 * * MON-FRI",
+	"LastOfYear": "0 0 31 12 *",
+}
+
+// BenchmarkSchedule_NextAfter measures a run lookup on the compiled schedule
+func BenchmarkSchedule_NextAfter(b *testing.B) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, expression := range benchmarkExpressions {
+		schedule, err := NewParser().Parse(expression)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = schedule.NextAfter(from)
+			}
+		})
+	}
+}
+
+// BenchmarkRobfigNext measures the previous implementation: parsing the
+// expression with robfig/cron and iterating its schedule
+func BenchmarkRobfigNext(b *testing.B) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	parser := newCronParser(false)
+	for name, expression := range benchmarkExpressions {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				spec, _ := parser.Parse(expression)
+				_ = spec.Next(from)
+			}
+		})
+	}
+}
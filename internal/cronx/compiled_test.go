@@ -0,0 +1,135 @@
+package cronx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_NextAfter_MatchesRobfig(t *testing.T) {
+	expressions := []string{
+		"* * * * *",
+		"*/7 * * * *",
+		"30 2 * * *",
+		"30 1 * * *",
+		"0 0 1 * 1",
+		"0 12 */2 * 1-5",
+		"0 0 ? * MON",
+		"0 */5 1-10 JAN-MAR MON-FRI",
+		"5/15 3 * * *",
+		"0 0 31 * *",
+		"0 0 29 2 *",
+		"@hourly",
+		"@every 90m",
+	}
+	zones := []string{"UTC", "America/New_York", "Europe/London", "Asia/Kolkata"}
+
+	parser := NewParser()
+	robfig := newCronParser(false)
+	for _, zone := range zones {
+		loc, err := time.LoadLocation(zone)
+		require.NoError(t, err)
+		for _, expression := range expressions {
+			t.Run(zone+" "+expression, func(t *testing.T) {
+				schedule, err := parser.Parse(expression)
+				require.NoError(t, err)
+				spec, err := robfig.Parse(expression)
+				require.NoError(t, err)
+
+				got, want := time.Date(2025, 1, 1, 0, 0, 0, 0, loc), time.Date(2025, 1, 1, 0, 0, 0, 0, loc)
+				for i := 0; i < 500; i++ {
+					got, want = schedule.NextAfter(got), spec.Next(want)
+					if want.IsZero() {
+						break // robfig/cron gives up after five years without a run
+					}
+					require.Equal(t, want.String(), got.String(), "run %d", i+1)
+				}
+			})
+		}
+	}
+}
+
+func TestSchedule_NextAfter(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		opts       ParserOptions
+		expression string
+		from       time.Time
+		want       time.Time
+	}{
+		{
+			name:       "strictly after from",
+			expression: "0 0 * * *",
+			from:       from,
+			want:       time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "seconds field",
+			opts:       ParserOptions{Seconds: SecondsOptional},
+			expression: "*/20 30 * * * *",
+			from:       from.Add(30*time.Minute + 15*time.Second),
+			want:       from.Add(30*time.Minute + 20*time.Second),
+		},
+		{
+			name:       "quartz last weekday",
+			opts:       ParserOptions{Dialect: DialectQuartz},
+			expression: "0 0 9 LW * ?",
+			from:       from,
+			want:       time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "quartz year field",
+			opts:       ParserOptions{Dialect: DialectQuartz},
+			expression: "0 0 0 1 1 ? 2027",
+			from:       from,
+			want:       time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "quartz past year",
+			opts:       ParserOptions{Dialect: DialectQuartz},
+			expression: "0 0 0 1 1 ? 2020",
+			from:       from,
+		},
+		{
+			name:       "repeated wall-clock time runs twice",
+			expression: "30 1 * * *",
+			from:       time.Date(2025, 11, 2, 1, 30, 0, 0, newYork), // 01:30 EDT
+			want:       time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC).In(newYork),
+		},
+		{
+			name:       "never runs",
+			expression: "0 0 30 2 *",
+			from:       from,
+		},
+		{
+			name:       "rate",
+			opts:       ParserOptions{Dialect: DialectAWS},
+			expression: "rate(7 minutes)",
+			from:       from,
+			want:       from.Truncate(7 * time.Minute).Add(7 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParserWithOptions("en", tt.opts).Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.String(), schedule.NextAfter(tt.from).String())
+		})
+	}
+}
+
+func TestNextBit(t *testing.T) {
+	mask := uint64(1<<3 | 1<<10 | 1<<63)
+	assert.Equal(t, 3, nextBit(mask, 0))
+	assert.Equal(t, 10, nextBit(mask, 4))
+	assert.Equal(t, 63, nextBit(mask, 11))
+	assert.Equal(t, -1, nextBit(mask, 64))
+	assert.Equal(t, -1, nextBit(0, 0))
+}
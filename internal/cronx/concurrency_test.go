@@ -0,0 +1,49 @@
+package cronx_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are meant to be run with the race detector (make test-unit)
+
+func TestParser_ConcurrentUse(t *testing.T) {
+	parser := cronx.NewParser()
+	expressions := []string{"*/5 * * * *", "0 9 * * 1-5", "@daily", "30 2 1 * *", "0 0 * * MON"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			expr := expressions[i%len(expressions)]
+			schedule, err := parser.Parse(expr)
+			assert.NoError(t, err)
+			assert.Equal(t, expr, schedule.Original)
+			_ = schedule.Minute.Values()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestScheduler_ConcurrentUse(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			times, err := scheduler.Next("*/15 * * * *", from, 4)
+			assert.NoError(t, err)
+			assert.Len(t, times, 4)
+			assert.Equal(t, from.Add(15*time.Minute), times[0])
+		}()
+	}
+	wg.Wait()
+}
@@ -2,6 +2,10 @@ package cronx
 
 // Cron field value ranges
 const (
+	// MinSecond is the minimum second value (0)
+	MinSecond = 0
+	// MaxSecond is the maximum second value (59)
+	MaxSecond = 59
 	// MinMinute is the minimum minute value (0)
 	MinMinute = 0
 	// MaxMinute is the maximum minute value (59)
@@ -0,0 +1,83 @@
+package cronx
+
+import (
+	"context"
+	"time"
+)
+
+// contextScheduler is a Scheduler bound to a context
+type contextScheduler struct {
+	ctx       context.Context
+	scheduler Scheduler
+}
+
+// WithContext returns a scheduler computing runs with s until ctx ends, after
+// which its methods return the cause of ctx's end (see context.Cause). It lets code that takes a Scheduler,
+// such as validation, statistics and timelines, be cancelled or timed out
+// without a context parameter on each of its functions.
+func WithContext(ctx context.Context, s Scheduler) Scheduler {
+	if c, ok := s.(*contextScheduler); ok {
+		s = c.scheduler
+	}
+	return &contextScheduler{ctx: ctx, scheduler: s}
+}
+
+// Next implements the Scheduler Next method with the bound context
+func (s *contextScheduler) Next(expression string, from time.Time, count int) ([]time.Time, error) {
+	return s.scheduler.NextContext(s.ctx, expression, from, count)
+}
+
+// NextContext implements the Scheduler NextContext method, stopping when
+// either ctx or the bound context ends
+func (s *contextScheduler) NextContext(ctx context.Context, expression string, from time.Time, count int) ([]time.Time, error) {
+	if s.ctx.Err() != nil {
+		return nil, context.Cause(s.ctx)
+	}
+	ctx, release := s.join(ctx)
+	defer release()
+	return s.scheduler.NextContext(ctx, expression, from, count)
+}
+
+// Between implements the Scheduler Between method with the bound context
+func (s *contextScheduler) Between(expression string, from, to time.Time) ([]time.Time, error) {
+	return s.scheduler.BetweenContext(s.ctx, expression, from, to)
+}
+
+// BetweenContext implements the Scheduler BetweenContext method, stopping
+// when either ctx or the bound context ends
+func (s *contextScheduler) BetweenContext(ctx context.Context, expression string, from, to time.Time) ([]time.Time, error) {
+	if s.ctx.Err() != nil {
+		return nil, context.Cause(s.ctx)
+	}
+	ctx, release := s.join(ctx)
+	defer release()
+	return s.scheduler.BetweenContext(ctx, expression, from, to)
+}
+
+// join returns a context that ends when either ctx or the bound context
+// ends, with the cause of the first, and the function releasing it
+func (s *contextScheduler) join(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(s.ctx, func() { cancel(context.Cause(s.ctx)) })
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// Prev implements the Scheduler Prev method, once the bound context is checked
+func (s *contextScheduler) Prev(expression string, from time.Time, count int) ([]time.Time, error) {
+	if s.ctx.Err() != nil {
+		return nil, context.Cause(s.ctx)
+	}
+	return s.scheduler.Prev(expression, from, count)
+}
+
+// Matches implements the Scheduler Matches method, once the bound context is
+// checked
+func (s *contextScheduler) Matches(expression string, at time.Time) (bool, error) {
+	if s.ctx.Err() != nil {
+		return false, context.Cause(s.ctx)
+	}
+	return s.scheduler.Matches(expression, at)
+}
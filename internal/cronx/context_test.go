@@ -0,0 +1,112 @@
+package cronx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_NextContext(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("same runs as Next", func(t *testing.T) {
+		expected, err := scheduler.Next("*/5 * * * *", from, 10)
+		require.NoError(t, err)
+		times, err := scheduler.NextContext(context.Background(), "*/5 * * * *", from, 10)
+		require.NoError(t, err)
+		assert.Equal(t, expected, times)
+	})
+
+	t.Run("ended context", func(t *testing.T) {
+		cause := errors.New("gave up")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		times, err := scheduler.NextContext(ctx, "* * * * *", from, 1_000_000)
+		assert.ErrorIs(t, err, cause)
+		assert.Nil(t, times)
+	})
+
+	t.Run("parse errors come first", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := scheduler.NextContext(ctx, "60 * * * *", from, 1)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestScheduler_Between(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("same runs as Next within the window", func(t *testing.T) {
+		expected, err := scheduler.Next("*/5 * * * *", from, 12)
+		require.NoError(t, err)
+		times, err := scheduler.Between("*/5 * * * *", from, from.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, expected[:11], times, "the end of the window is excluded")
+	})
+
+	t.Run("no runs in the window", func(t *testing.T) {
+		times, err := scheduler.Between("0 0 30 2 *", from, from.AddDate(2, 0, 0))
+		require.NoError(t, err)
+		assert.Empty(t, times)
+	})
+
+	t.Run("too many runs", func(t *testing.T) {
+		_, err := scheduler.Between("* * * * *", from, from.AddDate(1, 0, 0))
+		assert.ErrorContains(t, err, "more than 100000 runs")
+	})
+
+	t.Run("ended context", func(t *testing.T) {
+		cause := errors.New("gave up")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		times, err := scheduler.BetweenContext(ctx, "* * * * *", from, from.AddDate(0, 1, 0))
+		assert.ErrorIs(t, err, cause)
+		assert.Nil(t, times)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := scheduler.Between("invalid", from, from.Add(time.Hour))
+		assert.Error(t, err)
+	})
+}
+
+func TestWithContext(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := cronx.WithContext(ctx, cronx.NewScheduler())
+
+	times, err := scheduler.Next("0 * * * *", from, 2)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC), times[0])
+	ok, err := scheduler.Matches("0 * * * *", from)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	cancel()
+	_, err = scheduler.Next("0 * * * *", from, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = scheduler.NextContext(context.Background(), "0 * * * *", from, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = scheduler.Prev("0 * * * *", from, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = scheduler.Between("0 * * * *", from, from.Add(time.Hour))
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = scheduler.BetweenContext(context.Background(), "0 * * * *", from, from.Add(time.Hour))
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = scheduler.Matches("0 * * * *", from)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Binding again replaces the context
+	times, err = cronx.WithContext(context.Background(), scheduler).Next("0 * * * *", from, 1)
+	require.NoError(t, err)
+	assert.Len(t, times, 1)
+}
@@ -0,0 +1,57 @@
+package cronx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cron4jSeparator joins the patterns of a cron4j expression, which runs when
+// any of them matches
+const Cron4jSeparator = "|"
+
+// parseCron4j parses a cron4j scheduling pattern: five fields, with L in
+// day-of-month for the last day of the month. A day must match both day
+// fields. Patterns joined with | are not one schedule, so they are rejected;
+// each can be parsed on its own.
+func (p *parser) parseCron4j(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	switch {
+	case strings.HasPrefix(spec, "@"):
+		return nil, fmt.Errorf("cron4j does not support aliases; write the fields")
+	case strings.Contains(spec, Cron4jSeparator):
+		return nil, fmt.Errorf("patterns joined with %s run when any of them matches; parse each pattern on its own", Cron4jSeparator)
+	}
+
+	fields := strings.Fields(strings.ToUpper(spec))
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (%s), got %d", fieldList(cron4jSyntax.Fields), len(fields))
+	}
+	for _, f := range fields {
+		if strings.ContainsAny(f, "?#~") {
+			return nil, fmt.Errorf("cron4j does not support %q", f)
+		}
+	}
+
+	var days *QuartzDays
+	switch dom := fields[2]; {
+	case dom == "L":
+		days = &QuartzDays{LastDay: true}
+		fields[2] = "*"
+	case strings.Contains(dom, "L"):
+		return nil, fmt.Errorf("invalid day-of-month %q: L must be used alone", dom)
+	}
+
+	if _, err := p.cronParser.Parse(strings.Join(fields, " ")); err != nil {
+		return nil, fieldsError(err)
+	}
+	return &Schedule{
+		Original:   expression,
+		Dialect:    DialectCron4j,
+		Minute:     parseField(fields[0], MinMinute, MaxMinute, p.symbols),
+		Hour:       parseField(fields[1], MinHour, MaxHour, p.symbols),
+		DayOfMonth: parseField(fields[2], MinDayOfMonth, MaxDayOfMonth, p.symbols),
+		Month:      parseField(fields[3], MinMonth, MaxMonth, p.symbols),
+		DayOfWeek:  parseField(fields[4], MinDayOfWeek, MaxDayOfWeek, p.symbols),
+		Quartz:     days,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package cronx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Cron4j(t *testing.T) {
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectCron4j})
+
+	t.Run("L is the last day of the month", func(t *testing.T) {
+		schedule, err := parser.Parse("0 12 L * *")
+		require.NoError(t, err)
+		assert.Equal(t, cronx.DialectCron4j, schedule.Dialect)
+		require.NotNil(t, schedule.Quartz)
+		assert.Equal(t, cronx.QuartzDays{LastDay: true}, *schedule.Quartz)
+		assert.True(t, schedule.DayOfMonth.IsEvery())
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			errContain string
+		}{
+			{"0 5 * * * | 0 12 * * *", "parse each pattern on its own"},
+			{"0 0 0 * * *", "expected 5 fields"},
+			{"0 12 1,L * *", "L must be used alone"},
+			{"0 12 ? * mon", "does not support"},
+			{"@daily", "does not support aliases"},
+			{"0 12 * * 7", "value out of range"},
+		}
+		for _, tt := range tests {
+			_, err := parser.Parse(tt.expression)
+			require.Error(t, err, tt.expression)
+			assert.Contains(t, err.Error(), tt.errContain, tt.expression)
+		}
+	})
+}
+
+func TestScheduler_Cron4j(t *testing.T) {
+	scheduler := cronx.NewSchedulerWithOptions(cronx.ParserOptions{Dialect: cronx.DialectCron4j})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	times, err := scheduler.Next("0 12 L * *", from, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC),
+	}, times)
+
+	times, err = scheduler.Next("0 12 1-7 * mon", from, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC), times[0])
+}
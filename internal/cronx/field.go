@@ -1,6 +1,7 @@
 package cronx
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -39,10 +40,28 @@ type Field interface {
 
 	// Raw returns the raw field string
 	Raw() string
+
+	// Values returns every value matched by the field, sorted and de-duplicated
+	Values() []int
+
+	// Parts returns the comma-separated components of the field
+	Parts() []FieldPart
+}
+
+// FieldPart is a comma-separated component of a field: a wildcard, range or
+// single value, with an optional step
+type FieldPart struct {
+	Raw   string // Component as written
+	Every bool   // Wildcard ("*")
+	Range bool   // Range ("a-b")
+	Start int    // First value: the field minimum for wildcards
+	End   int    // Last value of the span: the field maximum for wildcards and single values with a step
+	Step  int    // Step, 1 when there is none
 }
 
 // fieldPart represents a component of a field (a single value, range, etc.)
 type fieldPart struct {
+	raw        string
 	isEvery    bool
 	isRange    bool
 	rangeStart int
@@ -79,7 +98,7 @@ func parseField(raw string, min, max int, registry SymbolRegistry) Field {
 
 // parsePart parses a single component of a field (handles *, ranges, steps, single values)
 func parsePart(raw string, registry SymbolRegistry) fieldPart {
-	part := fieldPart{step: 1} // Default: no step
+	part := fieldPart{raw: raw, step: 1} // Default: no step
 
 	// Handle Step notation (/)
 	if strings.Contains(raw, "/") {
@@ -212,3 +231,55 @@ func (f *field) Value() int {
 func (f *field) Raw() string {
 	return f.raw
 }
+
+// Values returns every value matched by the field (expanding wildcards, ranges, and steps)
+func (f *field) Values() []int {
+	seen := make(map[int]bool)
+	for _, p := range f.parts {
+		step := p.step
+		if step < 1 {
+			step = 1
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case p.isRange:
+			start, end = p.rangeStart, p.rangeEnd
+		case p.isSingle:
+			start = p.value
+			if step == 1 {
+				end = p.value // A bare value without a step matches only itself
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			seen[v] = true
+		}
+	}
+
+	values := make([]int, 0, len(seen))
+	for v := range seen {
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	return values
+}
+
+// Parts returns the comma-separated components of the field
+func (f *field) Parts() []FieldPart {
+	parts := make([]FieldPart, len(f.parts))
+	for i, p := range f.parts {
+		part := FieldPart{Raw: p.raw, Every: p.isEvery, Range: p.isRange, Start: f.min, End: f.max, Step: max(p.step, 1)}
+		switch {
+		case p.isRange:
+			part.Start, part.End = p.rangeStart, p.rangeEnd
+		case p.isSingle:
+			part.Start = p.value
+			if part.Step == 1 {
+				part.End = p.value
+			}
+		}
+		parts[i] = part
+	}
+	return parts
+}
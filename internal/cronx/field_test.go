@@ -176,3 +176,55 @@ func TestParseValue_SymbolParsing(t *testing.T) {
 		})
 	}
 }
+
+// TestValues tests expansion of fields into their matching values
+func TestValues(t *testing.T) {
+	parser := cronx.NewParser()
+
+	tests := []struct {
+		name       string
+		expression string
+		field      func(*cronx.Schedule) cronx.Field
+		expected   []int
+	}{
+		{"wildcard hour", "0 * * * *", func(s *cronx.Schedule) cronx.Field { return s.Hour }, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}},
+		{"single value", "30 * * * *", func(s *cronx.Schedule) cronx.Field { return s.Minute }, []int{30}},
+		{"step over wildcard", "*/15 * * * *", func(s *cronx.Schedule) cronx.Field { return s.Minute }, []int{0, 15, 30, 45}},
+		{"range", "0 9-12 * * *", func(s *cronx.Schedule) cronx.Field { return s.Hour }, []int{9, 10, 11, 12}},
+		{"range with step", "0 0-10/5 * * *", func(s *cronx.Schedule) cronx.Field { return s.Hour }, []int{0, 5, 10}},
+		{"value with step", "5/20 * * * *", func(s *cronx.Schedule) cronx.Field { return s.Minute }, []int{5, 25, 45}},
+		{"list is sorted and de-duplicated", "0 0 * * 5,1,1-2", func(s *cronx.Schedule) cronx.Field { return s.DayOfWeek }, []int{1, 2, 5}},
+		{"day of month wildcard", "0 0 * * *", func(s *cronx.Schedule) cronx.Field { return s.DayOfMonth }, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+		{"month names", "0 0 1 JAN,MAR *", func(s *cronx.Schedule) cronx.Field { return s.Month }, []int{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.field(schedule).Values())
+		})
+	}
+}
+
+func TestParts(t *testing.T) {
+	schedule, err := cronx.NewParser().Parse("*/15 9-17/2,22 5/10 * MON-FRI")
+	require.NoError(t, err)
+
+	assert.Equal(t, []cronx.FieldPart{
+		{Raw: "*/15", Every: true, Start: 0, End: 59, Step: 15},
+	}, schedule.Minute.Parts())
+	assert.Equal(t, []cronx.FieldPart{
+		{Raw: "9-17/2", Range: true, Start: 9, End: 17, Step: 2},
+		{Raw: "22", Start: 22, End: 22, Step: 1},
+	}, schedule.Hour.Parts())
+	assert.Equal(t, []cronx.FieldPart{
+		{Raw: "5/10", Start: 5, End: 31, Step: 10},
+	}, schedule.DayOfMonth.Parts())
+	assert.Equal(t, []cronx.FieldPart{
+		{Raw: "*", Every: true, Start: 1, End: 12, Step: 1},
+	}, schedule.Month.Parts())
+	assert.Equal(t, []cronx.FieldPart{
+		{Raw: "MON-FRI", Range: true, Start: 1, End: 5, Step: 1},
+	}, schedule.DayOfWeek.Parts())
+}
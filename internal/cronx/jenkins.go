@@ -0,0 +1,154 @@
+package cronx
+
+import (
+	"crypto/md5"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jenkinsBounds are the values an H token may take in each of the five
+// fields. Jenkins limits day-of-month to 1-28, valid in every month, and
+// day-of-week to 0-6, so that Sunday is not picked twice as often.
+var jenkinsBounds = [5][2]int{
+	{MinMinute, MaxMinute},
+	{MinHour, MaxHour},
+	{MinDayOfMonth, 28},
+	{MinMonth, MaxMonth},
+	{MinDayOfWeek, MaxDayOfWeek},
+}
+
+// jenkinsHash picks the values of H tokens the way Jenkins does: a
+// java.util.Random seeded from the MD5 digest of the job's full name,
+// consumed token by token from left to right
+type jenkinsHash struct {
+	seed int64
+}
+
+// newJenkinsHash returns the hash of Jenkins's hudson.scheduler.Hash.from(key)
+func newJenkinsHash(key string) *jenkinsHash {
+	digest := md5.Sum([]byte(key))
+	for i := 8; i < len(digest); i++ {
+		digest[i%8] ^= digest[i]
+	}
+	var seed int64
+	for i := 0; i < 8; i++ {
+		seed = seed<<8 + int64(digest[i])
+	}
+	return &jenkinsHash{seed: (seed ^ 0x5DEECE66D) & (1<<48 - 1)}
+}
+
+// next returns a value in [0, n), as java.util.Random.nextInt(n)
+func (h *jenkinsHash) next(n int) int {
+	bits := func() int32 {
+		h.seed = (h.seed*0x5DEECE66D + 0xB) & (1<<48 - 1)
+		return int32(h.seed >> 17)
+	}
+
+	r := bits()
+	bound := int32(n)
+	if bound&(bound-1) == 0 {
+		return int((int64(bound) * int64(r)) >> 31)
+	}
+	for u := r; ; u = bits() {
+		r = u % bound
+		if u-r+(bound-1) >= 0 {
+			return int(r)
+		}
+	}
+}
+
+// parseJenkins parses an expression of the Jenkins dialect: five standard
+// fields in which H, H(a-b), H/n and H(a-b)/n pick values by hashing the
+// parser's hash key. The H tokens are resolved to plain values, so the
+// schedule runs like the standard expression in Resolved.
+func (p *parser) parseJenkins(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if strings.HasPrefix(spec, "@") {
+		alias, ok := findAlias(jenkinsAliases, spec)
+		if !ok {
+			return nil, fmt.Errorf("unsupported alias %q (supported: %s)", spec, aliasNames(jenkinsAliases))
+		}
+		spec = alias
+	}
+
+	fields := strings.Fields(strings.ToUpper(spec))
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (%s), got %d", fieldList(jenkinsSyntax.Fields), len(fields))
+	}
+
+	hash := newJenkinsHash(p.hashKey)
+	for i, f := range fields {
+		resolved, err := resolveJenkinsField(f, i, hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %w", jenkinsSyntax.Fields[i].Name, f, err)
+		}
+		fields[i] = resolved
+	}
+
+	resolved := strings.Join(fields, " ")
+	schedule, err := p.parseStandard(resolved)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Original = expression
+	schedule.Dialect = DialectJenkins
+	schedule.Resolved = resolved
+	return schedule, nil
+}
+
+// resolveJenkinsField replaces the H terms of a field with the values they
+// hash to. Other terms are kept as written.
+func resolveJenkinsField(f string, index int, hash *jenkinsHash) (string, error) {
+	terms := strings.Split(f, ",")
+	for t, term := range terms {
+		if !strings.HasPrefix(term, "H") {
+			continue
+		}
+
+		low, high := jenkinsBounds[index][0], jenkinsBounds[index][1]
+		rest := term[1:]
+		if strings.HasPrefix(rest, "(") {
+			end := strings.Index(rest, ")")
+			if end == -1 {
+				return "", fmt.Errorf("missing ')' in %q", term)
+			}
+			from, to, ok := strings.Cut(rest[1:end], "-")
+			a, errA := strconv.Atoi(from)
+			b, errB := strconv.Atoi(to)
+			if !ok || errA != nil || errB != nil {
+				return "", fmt.Errorf("H range must be H(a-b), got %q", term)
+			}
+			field := jenkinsSyntax.Fields[index]
+			if a < field.Min || b > field.Max || a > b {
+				return "", fmt.Errorf("H range %d-%d must lie within %d-%d", a, b, field.Min, field.Max)
+			}
+			low, high = a, b
+			rest = rest[end+1:]
+		}
+
+		step := 1
+		if rest != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(rest, "/"))
+			if !strings.HasPrefix(rest, "/") || err != nil {
+				return "", fmt.Errorf("unexpected %q after H", rest)
+			}
+			if n < 1 || n > high-low+1 {
+				return "", fmt.Errorf("step %d must be between 1 and %d", n, high-low+1)
+			}
+			step = n
+		}
+
+		// H picks one value; H/n picks an offset and repeats every n
+		if step == 1 {
+			terms[t] = strconv.Itoa(low + hash.next(high-low+1))
+			continue
+		}
+		var values []string
+		for v := low + hash.next(step); v <= high; v += step {
+			values = append(values, strconv.Itoa(v))
+		}
+		terms[t] = strings.Join(values, ",")
+	}
+	return strings.Join(terms, ","), nil
+}
@@ -0,0 +1,102 @@
+package cronx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJenkinsHash_Next(t *testing.T) {
+	// java.util.Random(42): nextInt(10) == 0, as the JDK documents by example
+	h := &jenkinsHash{seed: (42 ^ 0x5DEECE66D) & (1<<48 - 1)}
+	assert.Equal(t, 0, h.next(10))
+
+	// Powers of two take the high bits of the same draw
+	h = &jenkinsHash{seed: (42 ^ 0x5DEECE66D) & (1<<48 - 1)}
+	assert.Equal(t, 11, h.next(16))
+
+	// The same key always yields the same sequence
+	a, b := newJenkinsHash("folder/job"), newJenkinsHash("folder/job")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, a.next(60), b.next(60))
+	}
+}
+
+func TestParser_Jenkins(t *testing.T) {
+	parser := NewParserWithOptions("en", ParserOptions{Dialect: DialectJenkins, HashKey: "my-job"})
+
+	tests := []struct {
+		expression string
+		resolved   string
+	}{
+		{"H 4 * * 1-5", "18 4 * * 1-5"},
+		{"H/15 * * * *", "3,18,33,48 * * * *"},
+		{"H(0-30) H(9-17)/2 * * *", "18 9,11,13,15,17 * * *"},
+		{"H H H H H", "18 17 21 6 1"},
+		{"h 4 * * mon", "18 4 * * MON"},
+		{"0,H(30-59) * * * *", "0,48 * * * *"},
+		{"@midnight", "18 2 * * *"},
+		{"@weekly", "18 17 * * 6"},
+		{"*/5 * * * *", "*/5 * * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, DialectJenkins, schedule.Dialect)
+			assert.Equal(t, tt.expression, schedule.Original)
+			assert.Equal(t, tt.resolved, schedule.Resolved)
+		})
+	}
+
+	t.Run("values stay within the field", func(t *testing.T) {
+		for _, key := range []string{"", "a", "b", "folder/nightly", "release-42"} {
+			p := NewParserWithOptions("en", ParserOptions{Dialect: DialectJenkins, HashKey: key})
+			schedule, err := p.Parse("H H H H H")
+			require.NoError(t, err, key)
+			assert.LessOrEqual(t, schedule.DayOfMonth.Value(), 28, key)
+			assert.LessOrEqual(t, schedule.DayOfWeek.Value(), 6, key)
+
+			schedule, err = p.Parse("H(10-20) * * * *")
+			require.NoError(t, err, key)
+			assert.GreaterOrEqual(t, schedule.Minute.Value(), 10, key)
+			assert.LessOrEqual(t, schedule.Minute.Value(), 20, key)
+		}
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			"H * * *",
+			"H(0-60) * * * *",
+			"H(30-10) * * * *",
+			"H(0-30 * * * *",
+			"H/0 * * * *",
+			"H(0-9)/20 * * * *",
+			"Hx * * * *",
+			"H 24 * * *",
+			"@reboot",
+		} {
+			_, err := parser.Parse(expression)
+			assert.Error(t, err, expression)
+		}
+	})
+}
+
+func TestScheduler_Jenkins(t *testing.T) {
+	opts := ParserOptions{Dialect: DialectJenkins, HashKey: "my-job"}
+	scheduler := NewSchedulerWithOptions(opts)
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // A Monday
+
+	times, err := scheduler.Next("H 4 * * 1-5", from, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 3, 2, 4, 18, 0, 0, time.UTC),
+		time.Date(2026, 3, 3, 4, 18, 0, 0, time.UTC),
+	}, times)
+
+	prev, err := scheduler.Prev("H 4 * * 1-5", from, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 27, 4, 18, 0, 0, time.UTC), prev[0])
+}
@@ -0,0 +1,67 @@
+package cronx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nodeCronNames replaces the full month and day names node-cron accepts with
+// the abbreviations robfig/cron knows
+var nodeCronNames = strings.NewReplacer(
+	"JANUARY", "JAN", "FEBRUARY", "FEB", "MARCH", "MAR", "APRIL", "APR", "JUNE", "JUN", "JULY", "JUL",
+	"AUGUST", "AUG", "SEPTEMBER", "SEP", "OCTOBER", "OCT", "NOVEMBER", "NOV", "DECEMBER", "DEC",
+	"MONDAY", "MON", "TUESDAY", "TUE", "WEDNESDAY", "WED", "THURSDAY", "THU", "FRIDAY", "FRI",
+	"SATURDAY", "SAT", "SUNDAY", "SUN",
+)
+
+// parseNodeCron parses a node-cron expression: five fields, optionally led by
+// seconds, with full or abbreviated names and day-of-week 0-7. node-cron has
+// no aliases or modifiers, and a day must match both day fields.
+func (p *parser) parseNodeCron(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("node-cron does not support aliases; write the fields")
+	}
+
+	fields := strings.Fields(nodeCronNames.Replace(strings.ToUpper(spec)))
+	if len(fields) != 5 && len(fields) != 6 {
+		return nil, fmt.Errorf("expected 5 fields (or 6 with seconds), got %d", len(fields))
+	}
+	for _, f := range fields {
+		if strings.ContainsAny(f, "?#~") {
+			return nil, fmt.Errorf("node-cron does not support %q", f)
+		}
+	}
+
+	withSeconds := len(fields) == 6
+	last := len(fields) - 1
+	dow, err := sundaySeven(fields[last])
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week %q: %w", fields[last], err)
+	}
+	fields[last] = dow
+
+	cronParser := p.cronParser
+	if withSeconds {
+		cronParser = p.secondsParser
+	}
+	if _, err := cronParser.Parse(strings.Join(fields, " ")); err != nil {
+		return nil, fieldsError(err)
+	}
+
+	var second Field
+	if withSeconds {
+		second = parseField(fields[0], MinSecond, MaxSecond, p.symbols)
+		fields = fields[1:]
+	}
+	return &Schedule{
+		Original:   expression,
+		Dialect:    DialectNodeCron,
+		Second:     second,
+		Minute:     parseField(fields[0], MinMinute, MaxMinute, p.symbols),
+		Hour:       parseField(fields[1], MinHour, MaxHour, p.symbols),
+		DayOfMonth: parseField(fields[2], MinDayOfMonth, MaxDayOfMonth, p.symbols),
+		Month:      parseField(fields[3], MinMonth, MaxMonth, p.symbols),
+		DayOfWeek:  parseField(fields[4], MinDayOfWeek, MaxDayOfWeek, p.symbols),
+	}, nil
+}
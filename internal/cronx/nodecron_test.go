@@ -0,0 +1,68 @@
+package cronx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_NodeCron(t *testing.T) {
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectNodeCron})
+
+	t.Run("seconds are optional", func(t *testing.T) {
+		schedule, err := parser.Parse("0 9 * * *")
+		require.NoError(t, err)
+		assert.Equal(t, cronx.DialectNodeCron, schedule.Dialect)
+		assert.False(t, schedule.HasSeconds())
+
+		schedule, err = parser.Parse("*/10 * * * * *")
+		require.NoError(t, err)
+		assert.True(t, schedule.HasSeconds())
+		assert.Equal(t, 10, schedule.Second.Step())
+	})
+
+	t.Run("full names and Sunday as 7", func(t *testing.T) {
+		schedule, err := parser.Parse("0 9 * January,July Monday-Friday")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 7}, schedule.Month.Values())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, schedule.DayOfWeek.Values())
+
+		schedule, err = parser.Parse("0 9 * * 5-7")
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 5, 6}, schedule.DayOfWeek.Values())
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			errContain string
+		}{
+			{"@daily", "does not support aliases"},
+			{"0 0 12 ? * MON", "does not support \"?\""},
+			{"0 12 * * 5#2", "does not support"},
+			{"0 12 * *", "expected 5 fields"},
+			{"0 24 * * *", "value out of range"},
+		}
+		for _, tt := range tests {
+			_, err := parser.Parse(tt.expression)
+			require.Error(t, err, tt.expression)
+			assert.Contains(t, err.Error(), tt.errContain, tt.expression)
+		}
+	})
+}
+
+func TestScheduler_NodeCron(t *testing.T) {
+	scheduler := cronx.NewSchedulerWithOptions(cronx.ParserOptions{Dialect: cronx.DialectNodeCron})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Vixie cron would also run on January 1-4
+	times, err := scheduler.Next("0 12 1-7 * 1", from, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC),
+	}, times)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/robfig/cron/v3"
 )
@@ -11,24 +12,75 @@ import (
 // Schedule represents a parsed cron schedule with field information.
 type Schedule struct {
 	Original   string // The original cron expression string
+	Second     Field  // Second field (MinSecond-MaxSecond), nil for 5-field expressions
 	Minute     Field  // Minute field (MinMinute-MaxMinute)
 	Hour       Field  // Hour field (MinHour-MaxHour)
 	DayOfMonth Field  // Day of month field (MinDayOfMonth-MaxDayOfMonth)
 	Month      Field  // Month field (MinMonth-MaxMonth)
 	DayOfWeek  Field  // Day of week field (MinDayOfWeek-MaxDayOfWeek, Sunday=0)
+
+	// Quartz dialect only
+	Dialect Dialect     // Dialect the expression was parsed with (empty for standard)
+	Year    Field       // Year field (MinYear-MaxYear), nil when absent
+	Quartz  *QuartzDays // L, W and # day modifiers (also Spring, and cron4j's L), nil when none are used
+
+	// Jenkins dialect, and OpenBSD ~ terms
+	Resolved string // Standard expression with the H tokens or ~ terms replaced by the values they pick
+	Random   bool   // Whether ~ terms picked values at random (fixed per hash key and expression)
+
+	// AWS dialect only
+	Rate time.Duration // Interval of a rate(...) expression, 0 for cron(...); the fields approximate it
+
+	compiled *compiledSchedule // Fields as bitsets, set by the parser
 }
 
-// Parser is the abstraction layer for cron expression parsing
+// HasSeconds reports whether the schedule was parsed from a 6-field expression
+func (s *Schedule) HasSeconds() bool {
+	return s.Second != nil
+}
+
+// MatchesBothDays reports whether the schedule runs only on days matching
+// both day fields. Vixie cron runs on days matching either field when neither
+// is '*'; Quartz, EventBridge, Spring, node-cron and cron4j require both.
+func (s *Schedule) MatchesBothDays() bool {
+	switch s.Dialect {
+	case DialectQuartz, DialectSpring, DialectNodeCron, DialectCron4j:
+		return true
+	case DialectAWS:
+		return s.Rate == 0
+	}
+	return false
+}
+
+// SecondsMode controls whether a parser accepts a leading seconds field
+type SecondsMode int
+
+const (
+	// SecondsNone accepts standard 5-field expressions only (default)
+	SecondsNone SecondsMode = iota
+	// SecondsOptional accepts 5 or 6 fields; a 6-field expression starts with seconds
+	SecondsOptional
+	// SecondsRequired accepts 6-field expressions only (Quartz-style)
+	SecondsRequired
+)
+
+// Parser is the abstraction layer for cron expression parsing.
+// Implementations must be safe for concurrent use; returned Schedules are shared
+// between callers and must be treated as read-only.
 type Parser interface {
 	Parse(expression string) (*Schedule, error)
 }
 
 // parser implements Parser interface
 type parser struct {
-	cronParser cron.Parser
-	symbols    SymbolRegistry
-	cache      map[string]*Schedule
-	cacheMu    sync.RWMutex
+	cronParser    cron.Parser
+	secondsParser cron.Parser
+	seconds       SecondsMode
+	dialect       Dialect
+	hashKey       string
+	symbols       SymbolRegistry
+	cache         map[string]*Schedule
+	cacheMu       sync.RWMutex
 }
 
 // NewParser creates a new cron expression parser with English locale (default)
@@ -38,17 +90,80 @@ func NewParser() Parser {
 
 // NewParserWithLocale creates a new cron expression parser with a specific locale
 func NewParserWithLocale(locale string) Parser {
+	return NewParserWithSeconds(locale, SecondsNone)
+}
+
+// NewParserWithSeconds creates a new cron expression parser with a specific
+// locale that handles a leading seconds field according to mode
+func NewParserWithSeconds(locale string, mode SecondsMode) Parser {
+	return NewParserWithOptions(locale, ParserOptions{Seconds: mode})
+}
+
+// ParserOptions configures optional parser features
+type ParserOptions struct {
+	Seconds SecondsMode // How a leading seconds field is handled (standard dialect only)
+	Dialect Dialect     // Cron syntax variant (default: DialectStandard)
+	HashKey string      // Seed of H tokens: the job's full name, as Jenkins uses (Jenkins dialect); also seeds ~ terms
+}
+
+// NewParserWithOptions creates a new cron expression parser with a specific
+// locale and options
+func NewParserWithOptions(locale string, opts ParserOptions) Parser {
 	symbols, _ := GetSymbolRegistry(locale)
 	return &parser{
-		cronParser: cron.NewParser(
-			cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
-		),
-		symbols: symbols,
-		cache:   make(map[string]*Schedule),
+		cronParser:    newCronParser(false),
+		secondsParser: newCronParser(true),
+		seconds:       opts.Seconds,
+		dialect:       opts.Dialect,
+		hashKey:       opts.HashKey,
+		symbols:       symbols,
+		cache:         make(map[string]*Schedule),
+	}
+}
+
+// newCronParser creates the underlying robfig/cron parser, optionally with a seconds field
+func newCronParser(withSeconds bool) cron.Parser {
+	options := cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor
+	if withSeconds {
+		options |= cron.Second
+	}
+	return cron.NewParser(options)
+}
+
+// fieldsError simplifies the error robfig/cron reports for the standard
+// equivalent of a dialect's fields
+func fieldsError(err error) error {
+	if strings.Contains(err.Error(), "above maximum") || strings.Contains(err.Error(), "below minimum") {
+		return fmt.Errorf("value out of range: %w", err)
+	}
+	return fmt.Errorf("failed to parse expression: %w", err)
+}
+
+// fieldCountError checks the number of fields against the parser's seconds mode
+// and reports whether the expression carries a seconds field
+func (p *parser) fieldCountError(expression string) (bool, error) {
+	if strings.HasPrefix(expression, "@") {
+		return false, nil
+	}
+	n := len(strings.Fields(expression))
+	switch p.seconds {
+	case SecondsOptional:
+		if n != 5 && n != 6 {
+			return false, fmt.Errorf("expected 5 fields (or 6 with seconds), got %d", n)
+		}
+		return n == 6, nil
+	case SecondsRequired:
+		if n != 6 {
+			return false, fmt.Errorf("expected 6 fields (second minute hour day-of-month month day-of-week), got %d", n)
+		}
+		return true, nil
+	default:
+		return false, nil
 	}
 }
 
-// Parse parses a cron expression (5-field format or @alias)
+// Parse parses a cron expression (5-field format, 6-field format with seconds
+// when enabled, or @alias)
 // Results are cached to improve performance when parsing the same expression multiple times
 func (p *parser) Parse(expression string) (*Schedule, error) {
 	if expression == "" {
@@ -59,10 +174,46 @@ func (p *parser) Parse(expression string) (*Schedule, error) {
 	p.cacheMu.RLock()
 	if cached, ok := p.cache[expression]; ok {
 		p.cacheMu.RUnlock()
+		cacheHits.Add(1)
 		return cached, nil
 	}
 	p.cacheMu.RUnlock()
+	cacheMisses.Add(1)
+
+	var schedule *Schedule
+	var err error
+	switch {
+	case p.dialect == DialectQuartz && !strings.HasPrefix(expression, "@"):
+		schedule, err = p.parseQuartz(expression)
+	case p.dialect == DialectJenkins:
+		schedule, err = p.parseJenkins(expression)
+	case p.dialect == DialectAWS:
+		schedule, err = p.parseAWS(expression)
+	case p.dialect == DialectSpring:
+		schedule, err = p.parseSpring(expression)
+	case p.dialect == DialectNodeCron:
+		schedule, err = p.parseNodeCron(expression)
+	case p.dialect == DialectCron4j:
+		schedule, err = p.parseCron4j(expression)
+	default:
+		schedule, err = p.parseStandard(expression)
+	}
+	if err != nil {
+		return nil, err
+	}
+	schedule.compiled = compileSchedule(schedule)
+
+	// Cache the result (write lock)
+	p.cacheMu.Lock()
+	p.cache[expression] = schedule
+	p.cacheMu.Unlock()
+
+	return schedule, nil
+}
 
+// parseStandard parses a standard 5-field expression, a 6-field expression
+// with seconds when enabled, or an @alias
+func (p *parser) parseStandard(expression string) (*Schedule, error) {
 	// Store original for reference
 	original := expression
 
@@ -73,8 +224,28 @@ func (p *parser) Parse(expression string) (*Schedule, error) {
 		normalized = strings.ToUpper(expression)
 	}
 
+	withSeconds, err := p.fieldCountError(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve OpenBSD's random ~ terms before handing the fields to robfig/cron
+	resolved := ""
+	if !strings.HasPrefix(expression, "@") && strings.Contains(normalized, "~") {
+		fields, err := resolveRandom(strings.Fields(normalized), withSeconds, p.hashKey, original)
+		if err != nil {
+			return nil, err
+		}
+		resolved = strings.Join(fields, " ")
+		normalized = resolved
+	}
+
 	// Use robfig/cron to parse (BOUNDARY: only place we call external library)
-	_, err := p.cronParser.Parse(normalized)
+	cronParser := p.cronParser
+	if withSeconds {
+		cronParser = p.secondsParser
+	}
+	_, err = cronParser.Parse(normalized)
 	if err != nil {
 		// Simplify error messages for expected cases
 		errStr := err.Error()
@@ -89,47 +260,39 @@ func (p *parser) Parse(expression string) (*Schedule, error) {
 
 	// Parse individual fields
 	var fields []string
+	var second Field
 	if strings.HasPrefix(expression, "@") {
 		// Handle aliases (which robfig expands internally)
 		fields = aliasToFields(expression)
 	} else {
 		fields = strings.Fields(normalized)
+		if withSeconds {
+			second = parseField(fields[0], MinSecond, MaxSecond, p.symbols)
+			fields = fields[1:]
+		}
 		if len(fields) != 5 {
 			return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
 		}
 	}
 
 	schedule := &Schedule{
+		Second:     second,
 		Original:   original,
 		Minute:     parseField(fields[0], MinMinute, MaxMinute, p.symbols),
 		Hour:       parseField(fields[1], MinHour, MaxHour, p.symbols),
 		DayOfMonth: parseField(fields[2], MinDayOfMonth, MaxDayOfMonth, p.symbols),
 		Month:      parseField(fields[3], MinMonth, MaxMonth, p.symbols),
 		DayOfWeek:  parseField(fields[4], MinDayOfWeek, MaxDayOfWeek, p.symbols),
+		Resolved:   resolved,
+		Random:     resolved != "",
 	}
-
-	// Cache the result (write lock)
-	p.cacheMu.Lock()
-	p.cache[expression] = schedule
-	p.cacheMu.Unlock()
-
 	return schedule, nil
 }
 
 // aliasToFields converts cron aliases to field representation
 func aliasToFields(alias string) []string {
-	switch strings.ToLower(alias) {
-	case "@yearly", "@annually":
-		return []string{"0", "0", "1", "1", "*"}
-	case "@monthly":
-		return []string{"0", "0", "1", "*", "*"}
-	case "@weekly":
-		return []string{"0", "0", "*", "*", "0"}
-	case "@daily", "@midnight":
-		return []string{"0", "0", "*", "*", "*"}
-	case "@hourly":
-		return []string{"0", "*", "*", "*", "*"}
-	default:
-		return []string{"*", "*", "*", "*", "*"} // fallback
+	if expression, ok := findAlias(standardAliases, alias); ok {
+		return strings.Fields(expression)
 	}
+	return []string{"*", "*", "*", "*", "*"} // fallback
 }
@@ -0,0 +1,329 @@
+package cronx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect identifies a cron syntax variant
+type Dialect string
+
+const (
+	// DialectStandard is Vixie/POSIX cron: 5 fields, optionally led by seconds (default)
+	DialectStandard Dialect = "standard"
+	// DialectQuartz is the Quartz scheduler syntax: seconds, an optional year
+	// field, day-of-week 1-7 (Sunday=1) and the L, W, # and ? modifiers
+	DialectQuartz Dialect = "quartz"
+	// DialectJenkins is the Jenkins (Hudson) trigger syntax: standard fields
+	// plus the H token, which spreads jobs by hashing the job name
+	DialectJenkins Dialect = "jenkins"
+	// DialectAWS is the Amazon EventBridge syntax: cron(...) with minute to
+	// year fields and the Quartz modifiers, or rate(value unit)
+	DialectAWS Dialect = "aws"
+	// DialectSpring is the Spring Framework CronExpression syntax: six fields
+	// led by seconds, day-of-week 0-7 (MON-SUN=1-7), the L, W and #
+	// modifiers, and days that must match both day fields
+	DialectSpring Dialect = "spring"
+	// DialectNodeCron is the node-cron syntax: 5 fields, optionally led by
+	// seconds, day-of-week 0-7 and days that must match both day fields
+	DialectNodeCron Dialect = "node-cron"
+	// DialectCron4j is the cron4j syntax: 5 fields, L for the last day of the
+	// month, patterns joined with | and days that must match both day fields
+	DialectCron4j Dialect = "cron4j"
+)
+
+// ParseDialect returns the dialect with the given name. An empty name selects
+// the standard dialect.
+func ParseDialect(name string) (Dialect, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		return DialectStandard, nil
+	}
+	supported := make([]string, 0, len(Syntaxes()))
+	for _, syntax := range Syntaxes() {
+		for _, n := range syntax.Names {
+			if n == key {
+				return syntax.Dialect, nil
+			}
+		}
+		supported = append(supported, syntax.Names[0])
+	}
+	return "", fmt.Errorf("unknown dialect %q (supported: %s)", name, strings.Join(supported, ", "))
+}
+
+// Quartz year field range
+const (
+	// MinYear is the minimum Quartz year value (1970)
+	MinYear = 1970
+	// MaxYear is the maximum Quartz year value (2099)
+	MaxYear = 2099
+)
+
+// quartzWeekdays maps day-of-week names to standard values (Sunday=0)
+var quartzWeekdays = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// QuartzDays holds the Quartz day modifiers that have no standard cron
+// equivalent. The zero value means no modifier is in use; the corresponding
+// day field of the Schedule is then "*".
+type QuartzDays struct {
+	LastDay        bool // "L" or "L-n" in day-of-month: last day of the month
+	LastDayOffset  int  // n in "L-n": days before the last day of the month
+	LastWeekday    bool // "LW" in day-of-month: last weekday (Mon-Fri) of the month
+	NearestWeekday int  // n in "nW" in day-of-month: weekday nearest to day n
+	Weekday        int  // Day of week (0-6, Sunday=0) used by NthWeekday and LastOfWeekday
+	NthWeekday     int  // k in "d#k" in day-of-week: kth occurrence of Weekday in the month
+	LastOfWeekday  bool // "dL" in day-of-week: last occurrence of Weekday in the month
+}
+
+// HasDayOfMonth reports whether a day-of-month modifier (L, LW, W) is in use
+func (q *QuartzDays) HasDayOfMonth() bool {
+	return q.LastDay || q.LastWeekday || q.NearestWeekday > 0
+}
+
+// HasDayOfWeek reports whether a day-of-week modifier (#, L) is in use
+func (q *QuartzDays) HasDayOfWeek() bool {
+	return q.NthWeekday > 0 || q.LastOfWeekday
+}
+
+// matches reports whether the day t satisfies the modifiers
+func (q *QuartzDays) matches(t time.Time) bool {
+	last := daysIn(t.Year(), t.Month())
+	day := t.Day()
+
+	switch {
+	case q.LastWeekday:
+		if day != nearestWeekday(t.Year(), t.Month(), last) {
+			return false
+		}
+	case q.LastDay:
+		if day != last-q.LastDayOffset {
+			return false
+		}
+	case q.NearestWeekday > 0:
+		if q.NearestWeekday > last || day != nearestWeekday(t.Year(), t.Month(), q.NearestWeekday) {
+			return false
+		}
+	}
+
+	switch {
+	case q.NthWeekday > 0:
+		return int(t.Weekday()) == q.Weekday && (day-1)/7+1 == q.NthWeekday
+	case q.LastOfWeekday:
+		return int(t.Weekday()) == q.Weekday && day+7 > last
+	}
+	return true
+}
+
+// daysIn returns the number of days in the given month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday (Mon-Fri) nearest to the given day
+// without leaving the month, following Quartz's "W" rules
+func nearestWeekday(year int, month time.Month, day int) int {
+	last := daysIn(year, month)
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	default:
+		return day
+	}
+}
+
+// parseQuartz parses a 6 or 7-field Quartz expression:
+// second minute hour day-of-month month day-of-week [year]
+func (p *parser) parseQuartz(expression string) (*Schedule, error) {
+	fields := strings.Fields(strings.ToUpper(expression))
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("expected 6 or 7 fields (%s), got %d", fieldList(quartzSyntax.Fields), len(fields))
+	}
+
+	dom, dow := fields[3], fields[5]
+	if dom != "?" && dow != "?" {
+		return nil, fmt.Errorf("one of day-of-month or day-of-week must be '?'")
+	}
+	if dom == "?" && dow == "?" {
+		return nil, fmt.Errorf("only one of day-of-month or day-of-week may be '?'")
+	}
+
+	days := &QuartzDays{}
+	domField, err := parseQuartzDayOfMonth(dom, days)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-month %q: %w", dom, err)
+	}
+	dowField, err := parseQuartzDayOfWeek(dow, days)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week %q: %w", dow, err)
+	}
+
+	// Validate the plain fields with robfig/cron using their standard equivalents
+	standard := strings.Join([]string{fields[0], fields[1], fields[2], domField, fields[4], dowField}, " ")
+	if _, err := p.secondsParser.Parse(standard); err != nil {
+		return nil, fieldsError(err)
+	}
+
+	var year Field
+	if len(fields) == 7 {
+		if err := validateYearField(fields[6]); err != nil {
+			return nil, fmt.Errorf("invalid year %q: %w", fields[6], err)
+		}
+		year = parseField(fields[6], MinYear, MaxYear, p.symbols)
+	}
+
+	schedule := &Schedule{
+		Original:   expression,
+		Dialect:    DialectQuartz,
+		Second:     parseField(fields[0], MinSecond, MaxSecond, p.symbols),
+		Minute:     parseField(fields[1], MinMinute, MaxMinute, p.symbols),
+		Hour:       parseField(fields[2], MinHour, MaxHour, p.symbols),
+		DayOfMonth: parseField(domField, MinDayOfMonth, MaxDayOfMonth, p.symbols),
+		Month:      parseField(fields[4], MinMonth, MaxMonth, p.symbols),
+		DayOfWeek:  parseField(dowField, MinDayOfWeek, MaxDayOfWeek, p.symbols),
+		Year:       year,
+	}
+	if days.HasDayOfMonth() || days.HasDayOfWeek() {
+		schedule.Quartz = days
+	}
+	return schedule, nil
+}
+
+// parseQuartzDayOfMonth records L, L-n, LW and nW modifiers in days and returns
+// the standard field to use in their place
+func parseQuartzDayOfMonth(token string, days *QuartzDays) (string, error) {
+	switch {
+	case token == "?":
+		return "*", nil
+	case token == "LW":
+		days.LastWeekday = true
+		return "*", nil
+	case token == "L":
+		days.LastDay = true
+		return "*", nil
+	case strings.HasPrefix(token, "L-"):
+		n, err := strconv.Atoi(token[2:])
+		if err != nil || n < 1 || n > 30 {
+			return "", fmt.Errorf("offset must be between 1 and 30")
+		}
+		days.LastDay = true
+		days.LastDayOffset = n
+		return "*", nil
+	case strings.HasSuffix(token, "W"):
+		n, err := strconv.Atoi(strings.TrimSuffix(token, "W"))
+		if err != nil || n < MinDayOfMonth || n > MaxDayOfMonth {
+			return "", fmt.Errorf("W requires a single day between 1 and 31")
+		}
+		days.NearestWeekday = n
+		return "*", nil
+	case strings.ContainsAny(token, "LW#"):
+		return "", fmt.Errorf("unsupported modifier")
+	}
+	return token, nil
+}
+
+// parseQuartzDayOfWeek records dL and d#k modifiers in days and returns the
+// standard field (Sunday=0) to use in place of the Quartz field (Sunday=1)
+func parseQuartzDayOfWeek(token string, days *QuartzDays) (string, error) {
+	switch {
+	case token == "?":
+		return "*", nil
+	case token == "L":
+		// A bare L is the last day of the week: Saturday
+		return "6", nil
+	case strings.Contains(token, "#"):
+		base, nth, _ := strings.Cut(token, "#")
+		weekday, err := quartzWeekday(base)
+		if err != nil {
+			return "", err
+		}
+		n, err := strconv.Atoi(nth)
+		if err != nil || n < 1 || n > 5 {
+			return "", fmt.Errorf("# requires an occurrence between 1 and 5")
+		}
+		days.Weekday = weekday
+		days.NthWeekday = n
+		return "*", nil
+	case strings.HasSuffix(token, "L"):
+		weekday, err := quartzWeekday(strings.TrimSuffix(token, "L"))
+		if err != nil {
+			return "", err
+		}
+		days.Weekday = weekday
+		days.LastOfWeekday = true
+		return "*", nil
+	case strings.ContainsAny(token, "LW"):
+		return "", fmt.Errorf("unsupported modifier")
+	}
+
+	// Shift numeric values from 1-7 to 0-6; steps are left untouched
+	parts := strings.Split(token, ",")
+	for i, part := range parts {
+		base, step, hasStep := strings.Cut(part, "/")
+		bounds := strings.Split(base, "-")
+		for j, b := range bounds {
+			if b == "*" {
+				continue
+			}
+			weekday, err := quartzWeekday(b)
+			if err != nil {
+				return "", err
+			}
+			bounds[j] = strconv.Itoa(weekday)
+		}
+		parts[i] = strings.Join(bounds, "-")
+		if hasStep {
+			parts[i] += "/" + step
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// quartzWeekday converts a Quartz day-of-week value (1-7 or SUN-SAT) to a
+// standard one (0-6, Sunday=0)
+func quartzWeekday(s string) (int, error) {
+	if v, ok := quartzWeekdays[s]; ok {
+		return v, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 7 {
+		return 0, fmt.Errorf("day-of-week must be 1-7 (SUN-SAT), got %q", s)
+	}
+	return n - 1, nil
+}
+
+// validateYearField checks that every value of a year field lies within MinYear-MaxYear
+func validateYearField(token string) error {
+	for _, part := range strings.Split(token, ",") {
+		base, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+		if base == "*" {
+			continue
+		}
+		for _, b := range strings.Split(base, "-") {
+			n, err := strconv.Atoi(b)
+			if err != nil {
+				return fmt.Errorf("invalid value %q", b)
+			}
+			if n < MinYear || n > MaxYear {
+				return fmt.Errorf("value %d out of range (%d-%d)", n, MinYear, MaxYear)
+			}
+		}
+	}
+	return nil
+}
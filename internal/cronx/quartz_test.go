@@ -0,0 +1,218 @@
+package cronx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDialect(t *testing.T) {
+	for _, name := range []string{"", "standard", "Vixie"} {
+		d, err := cronx.ParseDialect(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, cronx.DialectStandard, d)
+	}
+
+	d, err := cronx.ParseDialect("QUARTZ")
+	require.NoError(t, err)
+	assert.Equal(t, cronx.DialectQuartz, d)
+
+	d, err = cronx.ParseDialect("Hudson")
+	require.NoError(t, err)
+	assert.Equal(t, cronx.DialectJenkins, d)
+
+	d, err = cronx.ParseDialect("EventBridge")
+	require.NoError(t, err)
+	assert.Equal(t, cronx.DialectAWS, d)
+
+	_, err = cronx.ParseDialect("systemd")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown dialect")
+}
+
+func TestParser_Quartz(t *testing.T) {
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectQuartz})
+
+	t.Run("day-of-week is 1-7 with Sunday=1", func(t *testing.T) {
+		schedule, err := parser.Parse("0 15 10 ? * 2-6")
+		require.NoError(t, err)
+		assert.Equal(t, cronx.DialectQuartz, schedule.Dialect)
+		assert.True(t, schedule.HasSeconds())
+		assert.True(t, schedule.DayOfMonth.IsEvery())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, schedule.DayOfWeek.Values())
+		assert.Nil(t, schedule.Quartz)
+		assert.Nil(t, schedule.Year)
+	})
+
+	t.Run("modifiers", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   cronx.QuartzDays
+		}{
+			{"0 0 0 L * ?", cronx.QuartzDays{LastDay: true}},
+			{"0 0 0 L-3 * ?", cronx.QuartzDays{LastDay: true, LastDayOffset: 3}},
+			{"0 0 0 LW * ?", cronx.QuartzDays{LastWeekday: true}},
+			{"0 0 0 15W * ?", cronx.QuartzDays{NearestWeekday: 15}},
+			{"0 0 12 ? * 6L", cronx.QuartzDays{Weekday: 5, LastOfWeekday: true}},
+			{"0 0 12 ? * FRIL", cronx.QuartzDays{Weekday: 5, LastOfWeekday: true}},
+			{"0 0 12 ? * 2#1", cronx.QuartzDays{Weekday: 1, NthWeekday: 1}},
+			{"0 0 12 ? * fri#3", cronx.QuartzDays{Weekday: 5, NthWeekday: 3}},
+		}
+		for _, tt := range tests {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err, tt.expression)
+			require.NotNil(t, schedule.Quartz, tt.expression)
+			assert.Equal(t, tt.expected, *schedule.Quartz, tt.expression)
+		}
+	})
+
+	t.Run("bare L in day-of-week is Saturday", func(t *testing.T) {
+		schedule, err := parser.Parse("0 0 12 ? * L")
+		require.NoError(t, err)
+		assert.Equal(t, []int{6}, schedule.DayOfWeek.Values())
+	})
+
+	t.Run("year field", func(t *testing.T) {
+		schedule, err := parser.Parse("0 0 12 1 1 ? 2027-2029")
+		require.NoError(t, err)
+		require.NotNil(t, schedule.Year)
+		assert.Equal(t, []int{2027, 2028, 2029}, schedule.Year.Values())
+	})
+
+	t.Run("aliases are accepted", func(t *testing.T) {
+		schedule, err := parser.Parse("@daily")
+		require.NoError(t, err)
+		assert.Empty(t, schedule.Dialect)
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			errContain string
+		}{
+			{"0 0 * * *", "expected 6 or 7 fields"},
+			{"0 0 12 * * *", "must be '?'"},
+			{"0 0 12 ? * ?", "only one of"},
+			{"0 0 12 ? * 0", "day-of-week must be 1-7"},
+			{"0 0 12 ? * 8", "day-of-week must be 1-7"},
+			{"0 0 12 ? * FRI#6", "occurrence between 1 and 5"},
+			{"0 0 12 32W * ?", "W requires a single day"},
+			{"0 0 12 1-5W * ?", "W requires a single day"},
+			{"0 0 12 L-31 * ?", "offset must be between 1 and 30"},
+			{"0 0 12 1L * ?", "unsupported modifier"},
+			{"0 0 24 ? * 1", "value out of range"},
+			{"0 0 12 1 * ? 1969", "out of range (1970-2099)"},
+		}
+		for _, tt := range tests {
+			_, err := parser.Parse(tt.expression)
+			require.Error(t, err, tt.expression)
+			assert.Contains(t, err.Error(), tt.errContain, tt.expression)
+		}
+	})
+
+	t.Run("standard parser rejects quartz modifiers", func(t *testing.T) {
+		_, err := cronx.NewParserWithSeconds("en", cronx.SecondsOptional).Parse("0 0 12 ? * 6L")
+		require.Error(t, err)
+	})
+}
+
+func TestScheduler_Quartz(t *testing.T) {
+	scheduler := cronx.NewSchedulerWithOptions(cronx.ParserOptions{Dialect: cronx.DialectQuartz})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   []time.Time
+	}{
+		{
+			name:       "last day of the month",
+			expression: "0 0 0 L * ?",
+			expected:   []time.Time{date(1, 31, 0), date(2, 28, 0), date(3, 31, 0)},
+		},
+		{
+			name:       "three days before the last day",
+			expression: "0 0 0 L-3 * ?",
+			expected:   []time.Time{date(1, 28, 0), date(2, 25, 0), date(3, 28, 0)},
+		},
+		{
+			name:       "last weekday of the month",
+			expression: "0 0 9 LW * ?",
+			expected:   []time.Time{date(1, 30, 9), date(2, 27, 9), date(3, 31, 9)},
+		},
+		{
+			name:       "nearest weekday moves off weekends",
+			expression: "0 0 9 15W * ?",
+			expected:   []time.Time{date(1, 15, 9), date(2, 16, 9), date(3, 16, 9)},
+		},
+		{
+			name:       "nearest weekday stays within the month",
+			expression: "0 0 9 1W 8 ?",
+			expected:   []time.Time{date(8, 3, 9)},
+		},
+		{
+			name:       "last Friday of the month",
+			expression: "0 0 12 ? * 6L",
+			expected:   []time.Time{date(1, 30, 12), date(2, 27, 12), date(3, 27, 12)},
+		},
+		{
+			name:       "third Friday of the month",
+			expression: "0 0 12 ? * FRI#3",
+			expected:   []time.Time{date(1, 16, 12), date(2, 20, 12), date(3, 20, 12)},
+		},
+		{
+			name:       "weekdays",
+			expression: "0 30 8 ? * MON-FRI",
+			expected: []time.Time{
+				date(1, 1, 8).Add(30 * time.Minute),
+				date(1, 2, 8).Add(30 * time.Minute),
+				date(1, 5, 8).Add(30 * time.Minute),
+			},
+		},
+		{
+			name:       "every 15 seconds",
+			expression: "*/15 * * * * ?",
+			expected: []time.Time{
+				from.Add(15 * time.Second),
+				from.Add(30 * time.Second),
+				from.Add(45 * time.Second),
+			},
+		},
+		{
+			name:       "year field",
+			expression: "0 0 12 1 1 ? 2027",
+			expected:   []time.Time{time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC), {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := scheduler.Next(tt.expression, from, len(tt.expected))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, times)
+		})
+	}
+
+	t.Run("skips wall-clock times in a DST gap", func(t *testing.T) {
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		// 2026-03-08 02:30 does not exist in New York
+		times, err := scheduler.Next("0 30 2 * 3 ?", time.Date(2026, 3, 7, 12, 0, 0, 0, loc), 2)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 9, 2, 30, 0, 0, loc), times[0])
+		assert.Equal(t, time.Date(2026, 3, 10, 2, 30, 0, 0, loc), times[1])
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := scheduler.Next("0 0 12 * * *", from, 1)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package cronx
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// randomBounds are the values a ~ term may take in each of the five fields.
+// Day-of-week stops at 6, so that Sunday is not picked twice as often.
+var randomBounds = [5][2]int{
+	{MinMinute, MaxMinute},
+	{MinHour, MaxHour},
+	{MinDayOfMonth, MaxDayOfMonth},
+	{MinMonth, MaxMonth},
+	{MinDayOfWeek, 6},
+}
+
+// resolveRandom replaces the ~ terms of an expression's fields with the
+// values they pick, as OpenBSD cron does: "~" is a random value of the field,
+// "a~b" a random value from a to b, and either bound may be left out. OpenBSD
+// picks them when it loads the crontab; here they are drawn from a generator
+// seeded with key and the expression, so that a parser always resolves an
+// expression the same way. Other terms are kept as written.
+func resolveRandom(fields []string, withSeconds bool, key, expression string) ([]string, error) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key + "\x00" + expression))
+	seed := h.Sum64()
+	rng := rand.New(rand.NewPCG(seed, seed>>1))
+
+	resolved := make([]string, len(fields))
+	for i, f := range fields {
+		bounds := [2]int{MinSecond, MaxSecond}
+		name := secondField.Name
+		if index := i - btoi(withSeconds); index >= 0 {
+			bounds, name = randomBounds[index], standardFields[index].Name
+		}
+
+		terms := strings.Split(f, ",")
+		for t, term := range terms {
+			from, to, ok := strings.Cut(term, "~")
+			if !ok {
+				continue
+			}
+			low, high := bounds[0], bounds[1]
+			var err error
+			if from != "" {
+				low, err = strconv.Atoi(from)
+			}
+			if err == nil && to != "" {
+				high, err = strconv.Atoi(to)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s field %q: random range must be ~, a~b, a~ or ~b, got %q", name, f, term)
+			}
+			if low < bounds[0] || high > bounds[1] || low > high {
+				return nil, fmt.Errorf("invalid %s field %q: random range %d~%d must lie within %d-%d", name, f, low, high, bounds[0], bounds[1])
+			}
+			terms[t] = strconv.Itoa(low + rng.IntN(high-low+1))
+		}
+		resolved[i] = strings.Join(terms, ",")
+	}
+	return resolved, nil
+}
+
+// btoi returns 1 for true and 0 for false
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package cronx
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Random(t *testing.T) {
+	parser := NewParser()
+
+	t.Run("resolves ~ terms within their bounds", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			field      int
+			low, high  int
+		}{
+			{"~ 3 * * *", 0, 0, 59},
+			{"0 ~ * * *", 1, 0, 23},
+			{"0~30 * * * *", 0, 0, 30},
+			{"45~ * * * *", 0, 45, 59},
+			{"0 ~6 * * *", 1, 0, 6},
+			{"0 0 * * ~", 4, 0, 6},
+			{"0 0 ~ * *", 2, 1, 31},
+		}
+		for _, tt := range tests {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err, tt.expression)
+			assert.Equal(t, tt.expression, schedule.Original)
+			fields := strings.Fields(schedule.Resolved)
+			require.Len(t, fields, 5, schedule.Resolved)
+			value, err := strconv.Atoi(fields[tt.field])
+			require.NoError(t, err, schedule.Resolved)
+			assert.GreaterOrEqual(t, value, tt.low, tt.expression)
+			assert.LessOrEqual(t, value, tt.high, tt.expression)
+		}
+	})
+
+	t.Run("keeps other terms", func(t *testing.T) {
+		schedule, err := parser.Parse("0,~ */2 * * 1-5")
+		require.NoError(t, err)
+		assert.Regexp(t, `^0,\d+ \*/2 \* \* 1-5$`, schedule.Resolved)
+
+		schedule, err = parser.Parse("0 0 * * *")
+		require.NoError(t, err)
+		assert.Empty(t, schedule.Resolved)
+	})
+
+	t.Run("is seeded by the hash key and the expression", func(t *testing.T) {
+		expression := "~ ~ * * *"
+		a, err := NewParser().Parse(expression)
+		require.NoError(t, err)
+		b, err := NewParser().Parse(expression)
+		require.NoError(t, err)
+		assert.Equal(t, a.Resolved, b.Resolved)
+
+		resolved := make(map[string]bool)
+		for _, key := range []string{"web-1", "web-2", "web-3", "web-4"} {
+			schedule, err := NewParserWithOptions("en", ParserOptions{HashKey: key}).Parse(expression)
+			require.NoError(t, err)
+			resolved[schedule.Resolved] = true
+		}
+		assert.Greater(t, len(resolved), 1)
+	})
+
+	t.Run("with seconds", func(t *testing.T) {
+		schedule, err := NewParserWithSeconds("en", SecondsOptional).Parse("~ 0 12 * * *")
+		require.NoError(t, err)
+		assert.Regexp(t, `^\d+ 0 12 \* \* \*$`, schedule.Resolved)
+		assert.True(t, schedule.HasSeconds())
+	})
+
+	t.Run("rejects invalid ranges", func(t *testing.T) {
+		for _, expression := range []string{"70~ * * * *", "30~10 * * * *", "a~b * * * *", "~/5 * * * *", "0 0 * * ~7"} {
+			_, err := parser.Parse(expression)
+			assert.Error(t, err, expression)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package cronx
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCachedRuns bounds the run times held by the schedule cache (about 24 MB);
+// the cache is emptied when it would grow past it
+const maxCachedRuns = 1 << 20
+
+// scheduleKey identifies the runs computed by Scheduler.Next. Runs only depend
+// on the bucket of the start time: its minute for schedules without a seconds
+// field, its second otherwise.
+type scheduleKey struct {
+	opts       ParserOptions
+	expression string
+	location   *time.Location
+	bucket     int64 // Start of the bucket, in Unix nanoseconds
+}
+
+// scheduleCache holds the runs computed by all schedulers of the process, so
+// repeated lookups of the same jobs (stats, timeline, simulate) compute their
+// runs once. The longest list computed for a key is kept.
+var scheduleCache = struct {
+	sync.RWMutex
+	runs map[scheduleKey][]time.Time
+	size int // Run times held
+}{runs: make(map[scheduleKey][]time.Time)}
+
+// newScheduleKey returns the cache key of the runs of an expression from a time
+func newScheduleKey(opts ParserOptions, parsed *Schedule, expression string, from time.Time) scheduleKey {
+	bucket := from.Truncate(time.Second)
+	// Local minutes only line up with absolute ones in zones offset by whole minutes
+	if _, offset := from.Zone(); !parsed.HasSeconds() && offset%60 == 0 {
+		bucket = from.Truncate(time.Minute)
+	}
+	return scheduleKey{
+		opts:       opts,
+		expression: expression,
+		location:   from.Location(),
+		bucket:     bucket.UnixNano(),
+	}
+}
+
+// cachedRuns returns a copy of the first count cached runs of a key
+func cachedRuns(key scheduleKey, count int) ([]time.Time, bool) {
+	scheduleCache.RLock()
+	runs := scheduleCache.runs[key]
+	scheduleCache.RUnlock()
+	if len(runs) < count || count == 0 {
+		scheduleMisses.Add(1)
+		return nil, false
+	}
+	scheduleHits.Add(1)
+
+	times := make([]time.Time, count)
+	copy(times, runs)
+	return times, true
+}
+
+// cacheRuns stores a copy of the runs of a key, unless a longer list is held
+func cacheRuns(key scheduleKey, times []time.Time) {
+	if len(times) > maxCachedRuns {
+		return
+	}
+
+	scheduleCache.Lock()
+	defer scheduleCache.Unlock()
+	previous := len(scheduleCache.runs[key])
+	if previous >= len(times) {
+		return
+	}
+	if scheduleCache.size-previous+len(times) > maxCachedRuns {
+		clear(scheduleCache.runs)
+		scheduleCache.size, previous = 0, 0
+	}
+	scheduleCache.runs[key] = append([]time.Time(nil), times...)
+	scheduleCache.size += len(times) - previous
+}
+
+// ResetScheduleCache empties the schedule cache and zeroes its counters
+func ResetScheduleCache() {
+	scheduleCache.Lock()
+	defer scheduleCache.Unlock()
+	clear(scheduleCache.runs)
+	scheduleCache.size = 0
+	scheduleHits.Store(0)
+	scheduleMisses.Store(0)
+}
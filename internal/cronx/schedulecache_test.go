@@ -0,0 +1,87 @@
+package cronx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_NextCache(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+	from := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("repeated lookups are answered from the cache", func(t *testing.T) {
+		cronx.ResetScheduleCache()
+		first, err := scheduler.Next("*/20 * * * *", from, 3)
+		require.NoError(t, err)
+		second, err := scheduler.Next("*/20 * * * *", from.Add(30*time.Second), 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, first[:2], second)
+		assert.Equal(t, cronx.CacheStats{Hits: 1, Misses: 1}, cronx.ScheduleCacheStats())
+	})
+
+	t.Run("longer lookups are recomputed", func(t *testing.T) {
+		cronx.ResetScheduleCache()
+		_, err := scheduler.Next("0 * * * *", from, 2)
+		require.NoError(t, err)
+		times, err := scheduler.Next("0 * * * *", from, 4)
+		require.NoError(t, err)
+
+		assert.Len(t, times, 4)
+		assert.Equal(t, time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC), times[3])
+		assert.Equal(t, uint64(2), cronx.ScheduleCacheStats().Misses)
+	})
+
+	t.Run("cached runs cannot be modified by callers", func(t *testing.T) {
+		cronx.ResetScheduleCache()
+		times, err := scheduler.Next("0 0 * * *", from, 1)
+		require.NoError(t, err)
+		times[0] = time.Time{}
+
+		times, err = scheduler.Next("0 0 * * *", from, 1)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), times[0])
+	})
+
+	t.Run("seconds schedules are cached per second", func(t *testing.T) {
+		cronx.ResetScheduleCache()
+		seconds := cronx.NewSchedulerWithSeconds(cronx.SecondsOptional)
+		first, err := seconds.Next("*/10 * * * * *", from, 1)
+		require.NoError(t, err)
+		second, err := seconds.Next("*/10 * * * * *", from.Add(15*time.Second), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, from.Add(10*time.Second), first[0])
+		assert.Equal(t, from.Add(20*time.Second), second[0])
+		assert.Zero(t, cronx.ScheduleCacheStats().Hits)
+	})
+
+	t.Run("time zones are cached apart", func(t *testing.T) {
+		cronx.ResetScheduleCache()
+		tokyo := time.FixedZone("JST", 9*60*60)
+		utc, err := scheduler.Next("0 9 * * *", from, 1)
+		require.NoError(t, err)
+		jst, err := scheduler.Next("0 9 * * *", from.In(tokyo), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), utc[0])
+		assert.Equal(t, time.Date(2025, 1, 2, 9, 0, 0, 0, tokyo), jst[0])
+		assert.Equal(t, tokyo, jst[0].Location())
+	})
+
+	t.Run("@every intervals are not cached", func(t *testing.T) {
+		cronx.ResetScheduleCache()
+		first, err := scheduler.Next("@every 30s", from.Add(5*time.Second), 1)
+		require.NoError(t, err)
+		second, err := scheduler.Next("@every 30s", from.Add(50*time.Second), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, from.Add(35*time.Second), first[0])
+		assert.Equal(t, from.Add(80*time.Second), second[0])
+		assert.Equal(t, cronx.CacheStats{}, cronx.ScheduleCacheStats())
+	})
+}
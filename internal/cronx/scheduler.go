@@ -1,22 +1,45 @@
 package cronx
 
 import (
+	"context"
 	"fmt"
 	"time"
-
-	"github.com/robfig/cron/v3"
 )
 
 // Scheduler calculates next run times for cron schedules.
+// Implementations must be safe for concurrent use.
 type Scheduler interface {
 	// Next calculates the next N occurrences of a cron expression starting from the given time.
 	Next(expression string, from time.Time, count int) ([]time.Time, error)
+
+	// NextContext is Next, returning the cause of ctx's end (see
+	// context.Cause) instead of runs once ctx ends, so that large counts can
+	// be cancelled or timed out.
+	NextContext(ctx context.Context, expression string, from time.Time, count int) ([]time.Time, error)
+
+	// Prev calculates the last N occurrences of a cron expression before the
+	// given time, most recent first.
+	Prev(expression string, from time.Time, count int) ([]time.Time, error)
+
+	// Between returns the runs of a cron expression after from and before
+	// to, in order. Windows with more than MaxBetweenRuns runs are an error.
+	Between(expression string, from, to time.Time) ([]time.Time, error)
+
+	// BetweenContext is Between, returning the cause of ctx's end (see
+	// context.Cause) instead of runs once ctx ends.
+	BetweenContext(ctx context.Context, expression string, from, to time.Time) ([]time.Time, error)
+
+	// Matches reports whether a cron expression fires at the given instant.
+	// Instants are compared to the second for expressions with a seconds
+	// field and to the minute otherwise, so 09:00:42 matches "0 9 * * *".
+	Matches(expression string, at time.Time) (bool, error)
 }
 
-// robfigScheduler implements the Scheduler interface using robfig/cron library.
+// robfigScheduler implements the Scheduler interface for expressions
+// validated by robfig/cron, computing runs from compiled schedules.
 type robfigScheduler struct {
-	parser     Parser
-	cronParser cron.Parser
+	parser Parser
+	opts   ParserOptions
 }
 
 // NewScheduler creates a new Scheduler instance using the robfig/cron implementation.
@@ -26,39 +49,160 @@ func NewScheduler() Scheduler {
 
 // NewRobfigScheduler creates a new robfig/cron-based scheduler.
 func NewRobfigScheduler() Scheduler {
+	return NewSchedulerWithSeconds(SecondsNone)
+}
+
+// NewSchedulerWithSeconds creates a new robfig/cron-based scheduler that
+// handles a leading seconds field according to mode
+func NewSchedulerWithSeconds(mode SecondsMode) Scheduler {
+	return NewSchedulerWithOptions(ParserOptions{Seconds: mode})
+}
+
+// NewSchedulerWithOptions creates a new scheduler for expressions accepted by
+// a parser with the given options. Runs of every dialect, including the L, W
+// and # modifiers, year fields and rates robfig/cron does not support, are
+// computed natively.
+func NewSchedulerWithOptions(opts ParserOptions) Scheduler {
 	return &robfigScheduler{
-		parser: NewParser(),
-		cronParser: cron.NewParser(
-			cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
-		),
+		parser: NewParserWithOptions("en", opts),
+		opts:   opts,
 	}
 }
 
-// Next implements the Scheduler Next method using robfig/cron library
+// Next implements the Scheduler Next method from the compiled fields of the
+// parsed schedule. Runs are cached per expression, time zone and minute (or
+// second) of from.
 func (s *robfigScheduler) Next(expression string, from time.Time, count int) ([]time.Time, error) {
-	// Step 1: Validate the expression using our internal parser
+	return s.NextContext(context.Background(), expression, from, count)
+}
+
+// NextContext implements the Scheduler NextContext method like Next, checking
+// ctx between batches of runs
+func (s *robfigScheduler) NextContext(ctx context.Context, expression string, from time.Time, count int) ([]time.Time, error) {
+	// Validate the expression using our internal parser
 	// This ensures consistent error messages across all implementations
-	if _, err := s.parser.Parse(expression); err != nil {
+	parsed, err := s.parser.Parse(expression)
+	if err != nil {
 		return nil, err
 	}
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+
+	// Rates and @every intervals count from the exact start time, so they
+	// are not cached
+	if parsed.countsFromStart() {
+		return next(ctx, parsed, from, count)
+	}
 
-	// Step 2: Parse the expression with robfig/cron to get a Schedule
-	schedule, err := s.cronParser.Parse(expression)
+	key := newScheduleKey(s.opts, parsed, expression, from)
+	if times, ok := cachedRuns(key, count); ok {
+		return times, nil
+	}
+	times, err := next(ctx, parsed, from, count)
 	if err != nil {
-		// This shouldn't happen if our parser validation is correct,
-		// but we handle it just in case
-		return nil, fmt.Errorf("failed to parse cron expression: %w", err)
+		return nil, err
 	}
+	cacheRuns(key, times)
+	return times, nil
+}
+
+// nextBatch is how many runs next computes between checks of its context
+const nextBatch = 256
 
-	// Step 3: Calculate the next N occurrences using robfig/cron's Schedule.Next()
-	times := make([]time.Time, 0, count)
+// next calculates the next count runs of a schedule, or returns the cause of
+// ctx's end once ctx ends
+func next(ctx context.Context, parsed *Schedule, from time.Time, count int) ([]time.Time, error) {
+	times := make([]time.Time, count)
 	current := from
+	for i := range times {
+		if i%nextBatch == 0 && ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+		// Like robfig/cron, report the zero time once no further run exists
+		if current = parsed.NextAfter(current); current.IsZero() {
+			break
+		}
+		times[i] = current
+	}
+	return times, nil
+}
 
-	for i := 0; i < count; i++ {
-		next := schedule.Next(current)
-		times = append(times, next)
-		current = next
+// MaxBetweenRuns is the most runs Between returns, so that a window of a
+// year of an every-second schedule fails instead of exhausting memory
+const MaxBetweenRuns = 100000
+
+// Between implements the Scheduler Between method. Runs in windows are not
+// cached.
+func (s *robfigScheduler) Between(expression string, from, to time.Time) ([]time.Time, error) {
+	return s.BetweenContext(context.Background(), expression, from, to)
+}
+
+// BetweenContext implements the Scheduler BetweenContext method like
+// Between, checking ctx between batches of runs
+func (s *robfigScheduler) BetweenContext(ctx context.Context, expression string, from, to time.Time) ([]time.Time, error) {
+	parsed, err := s.parser.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	current := from
+	for {
+		if len(times)%nextBatch == 0 && ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+		if current = parsed.NextAfter(current); current.IsZero() || !current.Before(to) {
+			return times, nil
+		}
+		if len(times) == MaxBetweenRuns {
+			return nil, fmt.Errorf("more than %d runs between %s and %s", MaxBetweenRuns, from.Format(time.RFC3339), to.Format(time.RFC3339))
+		}
+		times = append(times, current)
+	}
+}
+
+// Prev implements the Scheduler Prev method. robfig/cron cannot search
+// backwards, so previous runs are computed by walking back day by day.
+func (s *robfigScheduler) Prev(expression string, from time.Time, count int) ([]time.Time, error) {
+	parsed, err := s.parser.Parse(expression)
+	if err != nil {
+		return nil, err
 	}
 
+	times := make([]time.Time, count)
+	current := from
+	for i := 0; i < count; i++ {
+		if parsed.Rate > 0 {
+			current = ratePrev(parsed.Rate, current)
+			times[i] = current
+			continue
+		}
+		// Report the zero time once no earlier run exists
+		if current = searchPrev(parsed, current); current.IsZero() {
+			break
+		}
+		times[i] = current
+	}
 	return times, nil
 }
+
+// Matches implements the Scheduler Matches method by checking that the first
+// run from just before the instant is the instant itself, so it agrees with
+// Next for every dialect and across DST transitions.
+func (s *robfigScheduler) Matches(expression string, at time.Time) (bool, error) {
+	parsed, err := s.parser.Parse(expression)
+	if err != nil {
+		return false, err
+	}
+
+	instant := at.Truncate(time.Minute)
+	if parsed.HasSeconds() {
+		instant = at.Truncate(time.Second)
+	}
+	times, err := s.Next(expression, instant.Add(-time.Second), 1)
+	if err != nil {
+		return false, err
+	}
+	return len(times) == 1 && times[0].Equal(instant), nil
+}
@@ -580,3 +580,209 @@ func TestScheduler_Next_TimeProgression(t *testing.T) {
 			"time at index %d should be after 'from' time", i)
 	}
 }
+
+func TestScheduler_Prev(t *testing.T) {
+	from := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC) // Monday
+
+	tests := []struct {
+		name       string
+		opts       cronx.ParserOptions
+		expression string
+		want       []time.Time
+	}{
+		{
+			name:       "daily at 02:00",
+			expression: "0 2 * * *",
+			want: []time.Time{
+				time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 4, 2, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 3, 2, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "excludes from itself",
+			expression: "0 9 * * *",
+			want: []time.Time{
+				time.Date(2026, 1, 4, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "weekdays across a weekend",
+			expression: "30 17 * * 1-5",
+			want: []time.Time{
+				time.Date(2026, 1, 2, 17, 30, 0, 0, time.UTC),
+				time.Date(2026, 1, 1, 17, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "day-of-month or day-of-week",
+			expression: "0 0 1,15 * 1",
+			want: []time.Time{
+				time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "seconds field",
+			opts:       cronx.ParserOptions{Seconds: cronx.SecondsOptional},
+			expression: "*/20 * * * * *",
+			want: []time.Time{
+				time.Date(2026, 1, 5, 8, 59, 40, 0, time.UTC),
+				time.Date(2026, 1, 5, 8, 59, 20, 0, time.UTC),
+			},
+		},
+		{
+			name:       "quartz last day of month",
+			opts:       cronx.ParserOptions{Dialect: cronx.DialectQuartz},
+			expression: "0 0 12 L * ?",
+			want: []time.Time{
+				time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC),
+				time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC),
+				time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "alias",
+			expression: "@monthly",
+			want: []time.Time{
+				time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := cronx.NewSchedulerWithOptions(tt.opts).Prev(tt.expression, from, len(tt.want))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, times)
+		})
+	}
+}
+
+func TestScheduler_Prev_MatchesNext(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+	from := time.Date(2025, 12, 18, 17, 7, 0, 0, time.UTC)
+
+	for _, expression := range []string{"*/15 * * * *", "0 9 * * 1-5", "0 0 1,15 * 1", "5 4 * 2 0", "@weekly"} {
+		t.Run(expression, func(t *testing.T) {
+			next, err := scheduler.Next(expression, from, 5)
+			require.NoError(t, err)
+
+			prev, err := scheduler.Prev(expression, next[4].Add(time.Second), 5)
+			require.NoError(t, err)
+			for i := range prev {
+				assert.Equal(t, next[4-i], prev[i], "run %d", i)
+			}
+		})
+	}
+}
+
+func TestScheduler_Prev_NoEarlierRun(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+	from := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	times, err := scheduler.Prev("0 0 30 2 *", from, 2)
+	require.NoError(t, err)
+	assert.Len(t, times, 2)
+	assert.True(t, times[0].IsZero())
+	assert.True(t, times[1].IsZero())
+}
+
+func TestScheduler_Prev_InvalidExpression(t *testing.T) {
+	_, err := cronx.NewScheduler().Prev("invalid", time.Now(), 1)
+	assert.Error(t, err)
+}
+
+func TestScheduler_Matches(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		opts       cronx.ParserOptions
+		expression string
+		at         time.Time
+		want       bool
+	}{
+		{
+			name:       "exact minute",
+			expression: "17 3 * * *",
+			at:         time.Date(2025, 3, 1, 3, 17, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "within the minute",
+			expression: "17 3 * * *",
+			at:         time.Date(2025, 3, 1, 3, 17, 42, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "other minute",
+			expression: "0 3 * * *",
+			at:         time.Date(2025, 3, 1, 3, 17, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			name:       "step",
+			expression: "*/17 * * * *",
+			at:         time.Date(2025, 3, 1, 3, 17, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "day-of-month or day-of-week",
+			expression: "0 0 1 * 1",
+			at:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), // Saturday the 1st
+			want:       true,
+		},
+		{
+			name:       "wrong weekday",
+			expression: "0 9 * * 1-5",
+			at:         time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), // Saturday
+			want:       false,
+		},
+		{
+			name:       "seconds field compares seconds",
+			opts:       cronx.ParserOptions{Seconds: cronx.SecondsOptional},
+			expression: "30 17 3 * * *",
+			at:         time.Date(2025, 3, 1, 3, 17, 0, 0, time.UTC),
+			want:       false,
+		},
+		{
+			name:       "quartz last day of month",
+			opts:       cronx.ParserOptions{Dialect: cronx.DialectQuartz},
+			expression: "0 0 12 L * ?",
+			at:         time.Date(2025, 2, 28, 12, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "local time zone",
+			expression: "0 9 * * *",
+			at:         time.Date(2025, 3, 1, 9, 0, 0, 0, newYork),
+			want:       true,
+		},
+		{
+			name:       "skipped by DST",
+			expression: "30 2 * * *",
+			at:         time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC).In(newYork), // 03:30 EDT
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := cronx.NewSchedulerWithOptions(tt.opts).Matches(tt.expression, tt.at)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, matches)
+		})
+	}
+}
+
+func TestScheduler_Matches_InvalidExpression(t *testing.T) {
+	_, err := cronx.NewScheduler().Matches("invalid", time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package cronx
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// searchYears bounds the day-by-day search for a schedule's runs
+const searchYears = 10
+
+// calendarDays holds the expanded day-level fields of a schedule
+type calendarDays struct {
+	years     []int // nil when the schedule has no year field
+	months    []int
+	monthDays []int
+	weekdays  []int
+	either    bool // Standard cron: a day matches when either day field matches
+	modifiers *QuartzDays
+}
+
+// newCalendarDays expands the day-level fields of a schedule
+func newCalendarDays(s *Schedule) *calendarDays {
+	d := &calendarDays{
+		months:    s.Month.Values(),
+		monthDays: s.DayOfMonth.Values(),
+		weekdays:  s.DayOfWeek.Values(),
+		modifiers: s.Quartz,
+	}
+	if s.Year != nil {
+		d.years = s.Year.Values()
+	}
+	// Cron matches days with OR unless either day field starts with '*'
+	if !s.MatchesBothDays() {
+		d.either = !isStarField(s.DayOfMonth) && !isStarField(s.DayOfWeek)
+	}
+	return d
+}
+
+// isStarField reports whether a field starts with '*' (or is '?')
+func isStarField(f Field) bool {
+	return strings.HasPrefix(f.Raw(), "*") || f.Raw() == "?"
+}
+
+// matches reports whether the schedule runs on the given day
+func (d *calendarDays) matches(day time.Time) bool {
+	if d.years != nil && !containsInt(d.years, day.Year()) {
+		return false
+	}
+	if !containsInt(d.months, int(day.Month())) {
+		return false
+	}
+
+	dom := containsInt(d.monthDays, day.Day())
+	dow := containsInt(d.weekdays, int(day.Weekday()))
+	if d.either {
+		if !dom && !dow {
+			return false
+		}
+	} else if !dom || !dow {
+		return false
+	}
+	return d.modifiers == nil || d.modifiers.matches(day)
+}
+
+// clock holds the expanded time-of-day fields of a schedule
+type clock struct {
+	hours   []int
+	minutes []int
+	seconds []int // {0} for schedules without a seconds field
+}
+
+// newClock expands the time-of-day fields of a schedule
+func newClock(s *Schedule) *clock {
+	c := &clock{hours: s.Hour.Values(), minutes: s.Minute.Values(), seconds: []int{0}}
+	if s.HasSeconds() {
+		c.seconds = s.Second.Values()
+	}
+	return c
+}
+
+// at returns the wall-clock time on day, reporting false when it does not
+// exist (DST gap)
+func at(day time.Time, h, m, sec int) (time.Time, bool) {
+	run := time.Date(day.Year(), day.Month(), day.Day(), h, m, sec, 0, day.Location())
+	return run, run.Hour() == h && run.Minute() == m
+}
+
+// searchPrev returns the last run of a schedule strictly before from, or the
+// zero time if there is none within searchYears
+func searchPrev(s *Schedule, from time.Time) time.Time {
+	t := from.Add(-time.Nanosecond).Truncate(time.Second)
+	days, c := newCalendarDays(s), newClock(s)
+
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	end := start.AddDate(-searchYears, 0, 0)
+	for day := start; day.After(end); day = day.AddDate(0, 0, -1) {
+		if !days.matches(day) {
+			continue
+		}
+		last := day.Equal(start)
+		for hi := len(c.hours) - 1; hi >= 0; hi-- {
+			h := c.hours[hi]
+			if last && h > t.Hour() {
+				continue
+			}
+			for mi := len(c.minutes) - 1; mi >= 0; mi-- {
+				m := c.minutes[mi]
+				if last && h == t.Hour() && m > t.Minute() {
+					continue
+				}
+				for si := len(c.seconds) - 1; si >= 0; si-- {
+					sec := c.seconds[si]
+					if last && h == t.Hour() && m == t.Minute() && sec > t.Second() {
+						continue
+					}
+					if run, ok := at(day, h, m, sec); ok && !run.After(t) {
+						return run
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// containsInt reports whether v is in the sorted slice values
+func containsInt(values []int, v int) bool {
+	i := sort.SearchInts(values, v)
+	return i < len(values) && values[i] == v
+}
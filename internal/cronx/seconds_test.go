@@ -0,0 +1,101 @@
+package cronx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Seconds(t *testing.T) {
+	t.Run("default parser rejects six fields", func(t *testing.T) {
+		_, err := cronx.NewParser().Parse("0 */5 * * * *")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected 5 fields")
+	})
+
+	t.Run("optional mode accepts five and six fields", func(t *testing.T) {
+		parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+
+		schedule, err := parser.Parse("0 */5 * * * *")
+		require.NoError(t, err)
+		require.True(t, schedule.HasSeconds())
+		assert.Equal(t, "0", schedule.Second.Raw())
+		assert.Equal(t, 5, schedule.Minute.Step())
+		assert.True(t, schedule.DayOfWeek.IsEvery())
+
+		schedule, err = parser.Parse("0 9 * * MON")
+		require.NoError(t, err)
+		assert.False(t, schedule.HasSeconds())
+		assert.Nil(t, schedule.Second)
+
+		schedule, err = parser.Parse("@hourly")
+		require.NoError(t, err)
+		assert.False(t, schedule.HasSeconds())
+	})
+
+	t.Run("optional mode rejects other field counts", func(t *testing.T) {
+		parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+		for _, expr := range []string{"0 0 *", "0 0 * * * * *"} {
+			_, err := parser.Parse(expr)
+			require.Error(t, err, expr)
+			assert.Contains(t, err.Error(), "expected 5 fields (or 6 with seconds)")
+		}
+	})
+
+	t.Run("required mode needs six fields", func(t *testing.T) {
+		parser := cronx.NewParserWithSeconds("en", cronx.SecondsRequired)
+
+		_, err := parser.Parse("*/15 * * * * *")
+		require.NoError(t, err)
+
+		_, err = parser.Parse("0 0 * * *")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected 6 fields")
+	})
+
+	t.Run("seconds out of range", func(t *testing.T) {
+		parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+		_, err := parser.Parse("60 * * * * *")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "out of range")
+	})
+}
+
+func TestScheduler_Seconds(t *testing.T) {
+	from := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("sub-minute schedule", func(t *testing.T) {
+		scheduler := cronx.NewSchedulerWithSeconds(cronx.SecondsOptional)
+		times, err := scheduler.Next("*/15 * * * * *", from, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			from.Add(15 * time.Second),
+			from.Add(30 * time.Second),
+			from.Add(45 * time.Second),
+			from.Add(60 * time.Second),
+		}, times)
+	})
+
+	t.Run("fixed second within minute steps", func(t *testing.T) {
+		scheduler := cronx.NewSchedulerWithSeconds(cronx.SecondsOptional)
+		times, err := scheduler.Next("30 */5 * * * *", from, 2)
+		require.NoError(t, err)
+		assert.Equal(t, from.Add(30*time.Second), times[0])
+		assert.Equal(t, from.Add(5*time.Minute+30*time.Second), times[1])
+	})
+
+	t.Run("five fields still work in optional mode", func(t *testing.T) {
+		scheduler := cronx.NewSchedulerWithSeconds(cronx.SecondsOptional)
+		times, err := scheduler.Next("0 13 * * *", from, 1)
+		require.NoError(t, err)
+		assert.Equal(t, from.Add(time.Hour), times[0])
+	})
+
+	t.Run("default scheduler rejects six fields", func(t *testing.T) {
+		_, err := cronx.NewScheduler().Next("*/15 * * * * *", from, 1)
+		require.Error(t, err)
+	})
+}
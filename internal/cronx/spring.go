@@ -0,0 +1,159 @@
+package cronx
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// springWeekdayNames replaces the day-of-week names of Spring expressions
+// with their numbers: Spring numbers MON-SUN 1-7, like java.time
+var springWeekdayNames = strings.NewReplacer(
+	"MON", "1", "TUE", "2", "WED", "3", "THU", "4", "FRI", "5", "SAT", "6", "SUN", "7",
+)
+
+// parseSpring parses a Spring CronExpression: six fields (second minute hour
+// day-of-month month day-of-week) or a macro. Unlike Quartz, neither day
+// field has to be '?', and a day must match both day fields.
+func (p *parser) parseSpring(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if strings.HasPrefix(spec, "@") {
+		alias, ok := findAlias(springAliases, spec)
+		if !ok {
+			return nil, fmt.Errorf("unsupported macro %q (supported: %s)", spec, aliasNames(springAliases))
+		}
+		spec = alias
+	}
+
+	fields := strings.Fields(strings.ToUpper(spec))
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("expected 6 fields (%s), got %d", fieldList(springSyntax.Fields), len(fields))
+	}
+
+	days := &QuartzDays{}
+	domField, err := parseQuartzDayOfMonth(fields[3], days)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-month %q: %w", fields[3], err)
+	}
+	dowField, err := parseSpringDayOfWeek(fields[5], days)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week %q: %w", fields[5], err)
+	}
+
+	standard := strings.Join([]string{fields[0], fields[1], fields[2], domField, fields[4], dowField}, " ")
+	if _, err := p.secondsParser.Parse(standard); err != nil {
+		return nil, fieldsError(err)
+	}
+
+	schedule := &Schedule{
+		Original:   expression,
+		Dialect:    DialectSpring,
+		Second:     parseField(fields[0], MinSecond, MaxSecond, p.symbols),
+		Minute:     parseField(fields[1], MinMinute, MaxMinute, p.symbols),
+		Hour:       parseField(fields[2], MinHour, MaxHour, p.symbols),
+		DayOfMonth: parseField(domField, MinDayOfMonth, MaxDayOfMonth, p.symbols),
+		Month:      parseField(fields[4], MinMonth, MaxMonth, p.symbols),
+		DayOfWeek:  parseField(dowField, MinDayOfWeek, MaxDayOfWeek, p.symbols),
+	}
+	if days.HasDayOfMonth() || days.HasDayOfWeek() {
+		schedule.Quartz = days
+	}
+	return schedule, nil
+}
+
+// parseSpringDayOfWeek records dL and d#k modifiers in days and returns the
+// standard field (0-6, Sunday=0) to use in place of the Spring field, whose
+// names number MON-SUN 1-7 and whose numbers take Sunday as 0 or 7
+func parseSpringDayOfWeek(token string, days *QuartzDays) (string, error) {
+	token = springWeekdayNames.Replace(token)
+	switch {
+	case token == "?":
+		return "*", nil
+	case strings.Contains(token, "#"):
+		base, nth, _ := strings.Cut(token, "#")
+		weekday, err := sundaySevenWeekday(base)
+		if err != nil {
+			return "", err
+		}
+		n, err := strconv.Atoi(nth)
+		if err != nil || n < 1 || n > 5 {
+			return "", fmt.Errorf("# requires an occurrence between 1 and 5")
+		}
+		days.Weekday = weekday
+		days.NthWeekday = n
+		return "*", nil
+	case token == "L":
+		return "", fmt.Errorf("L needs a day before it, e.g. 5L for the last Friday")
+	case strings.HasSuffix(token, "L"):
+		weekday, err := sundaySevenWeekday(strings.TrimSuffix(token, "L"))
+		if err != nil {
+			return "", err
+		}
+		days.Weekday = weekday
+		days.LastOfWeekday = true
+		return "*", nil
+	case strings.ContainsAny(token, "LW"):
+		return "", fmt.Errorf("unsupported modifier")
+	}
+	return sundaySeven(token)
+}
+
+// sundaySevenWeekday converts a day-of-week value of a field numbered 0-7,
+// where 0 and 7 are Sunday, to a standard one (0-6, Sunday=0)
+func sundaySevenWeekday(s string) (int, error) {
+	if v, ok := quartzWeekdays[s]; ok {
+		return v, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 7 {
+		return 0, fmt.Errorf("day-of-week must be 0-7, got %q", s)
+	}
+	return n % 7, nil
+}
+
+// sundaySeven converts a day-of-week field numbered 0-7, where 0 and 7 are
+// Sunday, to a standard field (0-6). robfig/cron rejects 7, so terms that
+// reach it are written as the values they stand for; other terms are kept.
+func sundaySeven(token string) (string, error) {
+	terms := strings.Split(token, ",")
+	for i, term := range terms {
+		base, step, hasStep := strings.Cut(term, "/")
+		bounds := strings.Split(base, "-")
+		if base == "*" || bounds[len(bounds)-1] != "7" {
+			continue
+		}
+		if len(bounds) > 2 {
+			return "", fmt.Errorf("invalid range %q", base)
+		}
+
+		low, err := sundaySevenWeekday(bounds[0])
+		if err != nil {
+			return "", err
+		}
+		if bounds[0] == "7" {
+			low = 7
+		}
+		n := 1
+		if hasStep {
+			if n, err = strconv.Atoi(step); err != nil || n < 1 {
+				return "", fmt.Errorf("invalid step %q", step)
+			}
+		}
+		if len(bounds) == 1 && !hasStep {
+			terms[i] = "0"
+			continue
+		}
+		var days []int
+		for v := low; v <= 7; v += n {
+			days = append(days, v%7)
+		}
+		slices.Sort(days)
+		values := make([]string, len(days))
+		for j, day := range days {
+			values[j] = strconv.Itoa(day)
+		}
+		terms[i] = strings.Join(values, ",")
+	}
+	return strings.Join(terms, ","), nil
+}
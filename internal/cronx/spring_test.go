@@ -0,0 +1,102 @@
+package cronx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Spring(t *testing.T) {
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectSpring})
+
+	t.Run("day-of-week is 0-7 and names number MON-SUN 1-7", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   []int
+		}{
+			{"0 0 9 * * 1-5", []int{1, 2, 3, 4, 5}},
+			{"0 0 9 * * 7", []int{0}},
+			{"0 0 9 * * 0", []int{0}},
+			{"0 0 9 * * SAT-SUN", []int{0, 6}},
+			{"0 0 9 * * MON-SUN", []int{0, 1, 2, 3, 4, 5, 6}},
+			{"0 0 9 * * 1-7/2", []int{0, 1, 3, 5}},
+		}
+		for _, tt := range tests {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err, tt.expression)
+			assert.Equal(t, cronx.DialectSpring, schedule.Dialect)
+			assert.True(t, schedule.HasSeconds())
+			assert.Equal(t, tt.expected, schedule.DayOfWeek.Values(), tt.expression)
+		}
+	})
+
+	t.Run("modifiers number days like the day-of-week field", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   cronx.QuartzDays
+		}{
+			{"0 0 0 L * *", cronx.QuartzDays{LastDay: true}},
+			{"0 0 0 15W * ?", cronx.QuartzDays{NearestWeekday: 15}},
+			{"0 0 12 ? * 5L", cronx.QuartzDays{Weekday: 5, LastOfWeekday: true}},
+			{"0 0 12 * * FRI#3", cronx.QuartzDays{Weekday: 5, NthWeekday: 3}},
+			{"0 0 12 * * 7#1", cronx.QuartzDays{Weekday: 0, NthWeekday: 1}},
+		}
+		for _, tt := range tests {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err, tt.expression)
+			require.NotNil(t, schedule.Quartz, tt.expression)
+			assert.Equal(t, tt.expected, *schedule.Quartz, tt.expression)
+		}
+	})
+
+	t.Run("macros have a seconds field", func(t *testing.T) {
+		schedule, err := parser.Parse("@hourly")
+		require.NoError(t, err)
+		assert.Equal(t, "0", schedule.Second.Raw())
+		assert.Equal(t, "0", schedule.Minute.Raw())
+		assert.True(t, schedule.Hour.IsEvery())
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			errContain string
+		}{
+			{"0 9 * * *", "expected 6 fields"},
+			{"0 0 9 * * 8", "value out of range"},
+			{"0 0 12 ? * L", "L needs a day before it"},
+			{"0 0 12 ? * MON#6", "occurrence between 1 and 5"},
+			{"0 0 12 1L * ?", "unsupported modifier"},
+			{"@reboot", "unsupported macro"},
+		}
+		for _, tt := range tests {
+			_, err := parser.Parse(tt.expression)
+			require.Error(t, err, tt.expression)
+			assert.Contains(t, err.Error(), tt.errContain, tt.expression)
+		}
+	})
+}
+
+func TestScheduler_Spring(t *testing.T) {
+	scheduler := cronx.NewSchedulerWithOptions(cronx.ParserOptions{Dialect: cronx.DialectSpring})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 12, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("days match both day fields", func(t *testing.T) {
+		times, err := scheduler.Next("0 0 12 1-7 * MON", from, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{date(1, 5), date(2, 2), date(3, 2)}, times)
+	})
+
+	t.Run("last Friday of the month", func(t *testing.T) {
+		times, err := scheduler.Next("0 0 12 * * 5L", from, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{date(1, 30), date(2, 27), date(3, 27)}, times)
+	})
+}
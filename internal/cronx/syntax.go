@@ -0,0 +1,262 @@
+package cronx
+
+import "strings"
+
+// Syntax describes the expressions a dialect accepts. The parser reads the
+// dialect names, aliases and field names from it, so references built from
+// Syntaxes stay in step with what Parse accepts.
+type Syntax struct {
+	Dialect     Dialect
+	Names       []string    // Names ParseDialect accepts, the canonical one first
+	Description string      // One-line summary of the dialect
+	Fields      []FieldSpec // Fields in the order they are written
+	Tokens      []Token     // Special characters and forms
+	Aliases     []Alias     // @ shorthands, nil when the dialect has none
+}
+
+// FieldSpec describes a field of an expression
+type FieldSpec struct {
+	Name     string // e.g. "day-of-month"
+	Min, Max int    // Numeric range
+	Names    string // Names accepted in place of numbers, e.g. "JAN-DEC"
+	Note     string // Further rules, e.g. "Sunday=0"
+	Optional bool   // The field may be left out
+}
+
+// Token is a special character or form of a dialect
+type Token struct {
+	Syntax      string // As written, e.g. "L-n"
+	Fields      string // Fields it is allowed in
+	Description string
+	Example     string // A complete expression using it
+}
+
+// Alias is an @ shorthand and the expression it stands for
+type Alias struct {
+	Name       string
+	Expression string
+}
+
+// Field specs shared by the dialects
+var (
+	secondField      = FieldSpec{Name: "second", Min: MinSecond, Max: MaxSecond}
+	minuteField      = FieldSpec{Name: "minute", Min: MinMinute, Max: MaxMinute}
+	hourField        = FieldSpec{Name: "hour", Min: MinHour, Max: MaxHour}
+	dayOfMonthField  = FieldSpec{Name: "day-of-month", Min: MinDayOfMonth, Max: MaxDayOfMonth}
+	monthField       = FieldSpec{Name: "month", Min: MinMonth, Max: MaxMonth, Names: "JAN-DEC"}
+	dayOfWeekField   = FieldSpec{Name: "day-of-week", Min: MinDayOfWeek, Max: MaxDayOfWeek, Names: "SUN-SAT", Note: "Sunday=0"}
+	quartzWeekField  = FieldSpec{Name: "day-of-week", Min: 1, Max: 7, Names: "SUN-SAT", Note: "Sunday=1"}
+	yearField        = FieldSpec{Name: "year", Min: MinYear, Max: MaxYear}
+	springWeekField  = FieldSpec{Name: "day-of-week", Min: 0, Max: 7, Names: "MON-SUN", Note: "Sunday=0 or 7; names number MON-SUN 1-7"}
+	sevenWeekField   = FieldSpec{Name: "day-of-week", Min: 0, Max: 7, Names: "SUN-SAT", Note: "Sunday=0 or 7"}
+	standardFields   = []FieldSpec{minuteField, hourField, dayOfMonthField, monthField, dayOfWeekField}
+	optionalSeconds  = FieldSpec{Name: "second", Min: MinSecond, Max: MaxSecond, Note: "leads a 6-field expression", Optional: true}
+	optionalYear     = FieldSpec{Name: "year", Min: MinYear, Max: MaxYear, Optional: true}
+	quartzDaysTokens = []Token{
+		{Syntax: "?", Fields: "day-of-month, day-of-week", Description: "No specific value; exactly one of the day fields must be ?"},
+		{Syntax: "L", Fields: "day-of-month", Description: "Last day of the month"},
+		{Syntax: "L-n", Fields: "day-of-month", Description: "n days before the last day of the month, n 1-30"},
+		{Syntax: "LW", Fields: "day-of-month", Description: "Last weekday (Mon-Fri) of the month"},
+		{Syntax: "nW", Fields: "day-of-month", Description: "Weekday nearest to day n, within the month"},
+		{Syntax: "dL", Fields: "day-of-week", Description: "Last day d of the month"},
+		{Syntax: "d#k", Fields: "day-of-week", Description: "kth day d of the month, k 1-5"},
+	}
+)
+
+// standardAliases are the aliases of the standard and Quartz dialects
+var standardAliases = []Alias{
+	{Name: "@yearly", Expression: "0 0 1 1 *"},
+	{Name: "@annually", Expression: "0 0 1 1 *"},
+	{Name: "@monthly", Expression: "0 0 1 * *"},
+	{Name: "@weekly", Expression: "0 0 * * 0"},
+	{Name: "@daily", Expression: "0 0 * * *"},
+	{Name: "@midnight", Expression: "0 0 * * *"},
+	{Name: "@hourly", Expression: "0 * * * *"},
+}
+
+// springAliases are the macros of the Spring dialect, which has a seconds field
+var springAliases = []Alias{
+	{Name: "@yearly", Expression: "0 0 0 1 1 *"},
+	{Name: "@annually", Expression: "0 0 0 1 1 *"},
+	{Name: "@monthly", Expression: "0 0 0 1 * *"},
+	{Name: "@weekly", Expression: "0 0 0 * * 0"},
+	{Name: "@daily", Expression: "0 0 0 * * *"},
+	{Name: "@midnight", Expression: "0 0 0 * * *"},
+	{Name: "@hourly", Expression: "0 0 * * * *"},
+}
+
+// jenkinsAliases are the aliases of the Jenkins dialect: Jenkins spreads
+// aliased jobs like H tokens
+var jenkinsAliases = []Alias{
+	{Name: "@yearly", Expression: "H H H H *"},
+	{Name: "@annually", Expression: "H H H H *"},
+	{Name: "@monthly", Expression: "H H H * *"},
+	{Name: "@weekly", Expression: "H H * * H"},
+	{Name: "@daily", Expression: "H H * * *"},
+	{Name: "@midnight", Expression: "H H(0-2) * * *"},
+	{Name: "@hourly", Expression: "H * * * *"},
+}
+
+var (
+	standardSyntax = Syntax{
+		Dialect:     DialectStandard,
+		Names:       []string{"standard", "vixie", "posix"},
+		Description: "Vixie/POSIX cron: 5 fields, optionally led by seconds",
+		Fields:      append([]FieldSpec{optionalSeconds}, standardFields...),
+		Tokens: []Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "* * * * *"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "0 9-17 * * *"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "0 0 * * SAT,SUN"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "*/15 9-17 * * MON-FRI"},
+			{Syntax: "~, a~b", Fields: "all", Description: "One random value of the field or from a to b (OpenBSD); cronkit seeds it from the expression", Example: "~ 3 * * *"},
+		},
+		Aliases: standardAliases,
+	}
+
+	quartzSyntax = Syntax{
+		Dialect:     DialectQuartz,
+		Names:       []string{"quartz"},
+		Description: "Quartz scheduler: seconds, an optional year, day-of-week 1-7 and the L, W, # and ? modifiers",
+		Fields:      []FieldSpec{secondField, minuteField, hourField, dayOfMonthField, monthField, quartzWeekField, optionalYear},
+		Tokens: append([]Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "0 * * ? * *"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "0 0 9-17 ? * *"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "0 0 0 ? * SAT,SUN"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "0 */15 9-17 ? * MON-FRI"},
+		}, withExamples(quartzDaysTokens,
+			"0 0 12 ? * MON", "0 0 12 L * ?", "0 0 12 L-3 * ?", "0 0 12 LW * ?", "0 0 12 15W * ?", "0 0 12 ? * 6L", "0 0 12 ? * 2#1")...),
+		Aliases: standardAliases,
+	}
+
+	jenkinsSyntax = Syntax{
+		Dialect:     DialectJenkins,
+		Names:       []string{"jenkins", "hudson"},
+		Description: "Jenkins triggers: standard fields plus H, which spreads jobs by hashing the job name",
+		Fields:      standardFields,
+		Tokens: []Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "* * * * *"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "H 9-17 * * *"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "H 0 * * SAT,SUN"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "*/15 9-17 * * MON-FRI"},
+			{Syntax: "H", Fields: "all", Description: "One value hashed from the job name", Example: "H H * * *"},
+			{Syntax: "H(a-b)", Fields: "all", Description: "One hashed value from a to b", Example: "H H(0-7) * * *"},
+			{Syntax: "H/n, H(a-b)/n", Fields: "all", Description: "Every nth value from a hashed start", Example: "H/15 * * * *"},
+		},
+		Aliases: jenkinsAliases,
+	}
+
+	awsSyntax = Syntax{
+		Dialect:     DialectAWS,
+		Names:       []string{"aws", "eventbridge"},
+		Description: "Amazon EventBridge: cron(...) with minute to year fields and the Quartz modifiers, or rate(...)",
+		Fields:      []FieldSpec{minuteField, hourField, dayOfMonthField, monthField, quartzWeekField, yearField},
+		Tokens: append([]Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "cron(* * ? * * *)"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "cron(0 9-17 ? * * *)"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "cron(0 0 ? * SAT,SUN *)"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "cron(*/15 9-17 ? * MON-FRI *)"},
+		}, append(withExamples(quartzDaysTokens,
+			"cron(0 12 ? * MON *)", "cron(0 12 L * ? *)", "cron(0 12 L-3 * ? *)", "cron(0 12 LW * ? *)", "cron(0 12 15W * ? *)", "cron(0 12 ? * 6L *)", "cron(0 12 ? * 2#1 *)"),
+			Token{Syntax: "rate(value unit)", Fields: "whole expression", Description: "Every value minutes, hours or days (singular unit for 1)", Example: "rate(5 minutes)"},
+		)...),
+	}
+
+	springSyntax = Syntax{
+		Dialect:     DialectSpring,
+		Names:       []string{"spring"},
+		Description: "Spring CronExpression: seconds first, day-of-week 0-7 (MON-SUN=1-7), the L, W and # modifiers; days match both day fields",
+		Fields:      []FieldSpec{secondField, minuteField, hourField, dayOfMonthField, monthField, springWeekField},
+		Tokens: append([]Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "0 * * * * *"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "0 0 9-17 * * *"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "0 0 0 * * SAT,SUN"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "0 */15 9-17 * * MON-FRI"},
+		}, withExamples(quartzDaysTokens,
+			"0 0 12 ? * MON", "0 0 12 L * ?", "0 0 12 L-3 * ?", "0 0 12 LW * ?", "0 0 12 15W * ?", "0 0 12 ? * 5L", "0 0 12 ? * MON#1")...),
+		Aliases: springAliases,
+	}
+
+	nodeCronSyntax = Syntax{
+		Dialect:     DialectNodeCron,
+		Names:       []string{"node-cron", "nodecron", "node"},
+		Description: "node-cron: 5 fields, optionally led by seconds, day-of-week 0-7 and full names; days match both day fields",
+		Fields:      []FieldSpec{optionalSeconds, minuteField, hourField, dayOfMonthField, monthField, sevenWeekField},
+		Tokens: []Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "* * * * *"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "0 9-17 * * *"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "0 0 * * Saturday,Sunday"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "*/10 */15 9-17 * * 1-5"},
+		},
+	}
+
+	cron4jSyntax = Syntax{
+		Dialect:     DialectCron4j,
+		Names:       []string{"cron4j"},
+		Description: "cron4j: 5 fields, L for the last day of the month and patterns joined with |; days match both day fields",
+		Fields:      standardFields,
+		Tokens: []Token{
+			{Syntax: "*", Fields: "all", Description: "Every value of the field", Example: "* * * * *"},
+			{Syntax: "a-b", Fields: "all", Description: "Every value from a to b", Example: "0 9-17 * * *"},
+			{Syntax: "a,b", Fields: "all", Description: "Each listed value or range", Example: "0 0 * * sat,sun"},
+			{Syntax: "*/n, a-b/n", Fields: "all", Description: "Every nth value of the field or range", Example: "*/15 9-17 * * mon-fri"},
+			{Syntax: "L", Fields: "day-of-month", Description: "Last day of the month", Example: "0 12 L * *"},
+		},
+	}
+)
+
+// Syntaxes returns the syntax of every dialect, standard first
+func Syntaxes() []Syntax {
+	return []Syntax{standardSyntax, quartzSyntax, jenkinsSyntax, awsSyntax, springSyntax, nodeCronSyntax, cron4jSyntax}
+}
+
+// SyntaxOf returns the syntax of a dialect; the empty dialect is standard
+func SyntaxOf(d Dialect) Syntax {
+	for _, s := range Syntaxes() {
+		if s.Dialect == d {
+			return s
+		}
+	}
+	return standardSyntax
+}
+
+// withExamples returns a copy of tokens with the given examples
+func withExamples(tokens []Token, examples ...string) []Token {
+	result := make([]Token, len(tokens))
+	for i, token := range tokens {
+		token.Example = examples[i]
+		result[i] = token
+	}
+	return result
+}
+
+// findAlias returns the expression of an alias, matched case-insensitively
+func findAlias(aliases []Alias, name string) (string, bool) {
+	for _, alias := range aliases {
+		if strings.EqualFold(alias.Name, name) {
+			return alias.Expression, true
+		}
+	}
+	return "", false
+}
+
+// aliasNames lists the names of aliases for error messages
+func aliasNames(aliases []Alias) string {
+	names := make([]string, len(aliases))
+	for i, alias := range aliases {
+		names[i] = alias.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// fieldList names the fields of a syntax for error messages, optional ones
+// in brackets, e.g. "second minute ... day-of-week [year]"
+func fieldList(fields []FieldSpec) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+		if field.Optional {
+			names[i] = "[" + field.Name + "]"
+		}
+	}
+	return strings.Join(names, " ")
+}
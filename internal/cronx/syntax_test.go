@@ -0,0 +1,59 @@
+package cronx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntaxes(t *testing.T) {
+	for _, syntax := range Syntaxes() {
+		t.Run(string(syntax.Dialect), func(t *testing.T) {
+			for _, name := range syntax.Names {
+				d, err := ParseDialect(name)
+				require.NoError(t, err)
+				assert.Equal(t, syntax.Dialect, d)
+			}
+
+			parser := NewParserWithOptions("en", ParserOptions{Dialect: syntax.Dialect, Seconds: SecondsOptional})
+			for _, token := range syntax.Tokens {
+				_, err := parser.Parse(token.Example)
+				assert.NoError(t, err, "%s: %s", token.Syntax, token.Example)
+			}
+			for _, alias := range syntax.Aliases {
+				_, err := parser.Parse(alias.Name)
+				assert.NoError(t, err, alias.Name)
+			}
+		})
+	}
+
+	t.Run("aliases expand to the documented expression", func(t *testing.T) {
+		parser := NewParser()
+		for _, alias := range SyntaxOf(DialectStandard).Aliases {
+			aliased, err := parser.Parse(alias.Name)
+			require.NoError(t, err)
+			expanded, err := parser.Parse(alias.Expression)
+			require.NoError(t, err)
+			assert.Equal(t, expanded.DayOfWeek, aliased.DayOfWeek, alias.Name)
+			assert.Equal(t, expanded.Hour, aliased.Hour, alias.Name)
+		}
+	})
+
+	t.Run("EventBridge has no aliases", func(t *testing.T) {
+		assert.Empty(t, SyntaxOf(DialectAWS).Aliases)
+		_, err := NewParserWithOptions("en", ParserOptions{Dialect: DialectAWS}).Parse("@daily")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown dialects list the canonical names", func(t *testing.T) {
+		_, err := ParseDialect("Vixen")
+		require.Error(t, err)
+		assert.Equal(t, `unknown dialect "Vixen" (supported: standard, quartz, jenkins, aws, spring, node-cron, cron4j)`, err.Error())
+	})
+
+	t.Run("field lists bracket optional fields", func(t *testing.T) {
+		assert.Equal(t, "second minute hour day-of-month month day-of-week [year]", fieldList(SyntaxOf(DialectQuartz).Fields))
+		assert.Equal(t, "minute hour day-of-month month day-of-week year", fieldList(SyntaxOf(DialectAWS).Fields))
+	})
+}
@@ -0,0 +1,97 @@
+package cronx
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompressValues renders a set of field values as the shortest conventional
+// cron field notation: "*" for the full range, "*/N" for a step over the full
+// range, and otherwise a comma-separated list where runs of three or more
+// consecutive values collapse into "a-b" ranges.
+func CompressValues(values []int, min, max int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	sorted := uniqueSortedInts(values)
+
+	// Full range
+	if len(sorted) == max-min+1 && sorted[0] == min && sorted[len(sorted)-1] == max {
+		return "*"
+	}
+
+	// Step over the full range (e.g., 0,15,30,45 -> */15)
+	if step, ok := fullRangeStep(sorted, min, max); ok {
+		return "*/" + strconv.Itoa(step)
+	}
+
+	var parts []string
+	for _, run := range Runs(sorted) {
+		switch {
+		case run[1]-run[0] >= 2:
+			parts = append(parts, strconv.Itoa(run[0])+"-"+strconv.Itoa(run[1]))
+		default:
+			for v := run[0]; v <= run[1]; v++ {
+				parts = append(parts, strconv.Itoa(v))
+			}
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Runs groups sorted values into runs of consecutive values, each given by
+// its first and last value
+func Runs(values []int) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		result = append(result, [2]int{values[i], values[j]})
+		i = j + 1
+	}
+	return result
+}
+
+// fullRangeStep reports whether values are exactly min, min+N, min+2N, ... up to max
+func fullRangeStep(values []int, min, max int) (int, bool) {
+	if len(values) < 3 || values[0] != min {
+		return 0, false
+	}
+
+	step := values[1] - values[0]
+	if step < 2 {
+		return 0, false
+	}
+
+	for i := 1; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+
+	// The sequence must run until no further step fits in the range
+	if values[len(values)-1]+step <= max {
+		return 0, false
+	}
+
+	return step, true
+}
+
+// uniqueSortedInts returns a sorted copy of values without duplicates
+func uniqueSortedInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
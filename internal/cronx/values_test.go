@@ -0,0 +1,41 @@
+package cronx_test
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		min, max int
+		expected string
+	}{
+		{"empty", nil, 0, 59, ""},
+		{"single", []int{5}, 0, 59, "5"},
+		{"pair stays a list", []int{2, 14}, 0, 23, "2,14"},
+		{"consecutive pair stays a list", []int{1, 2}, 0, 6, "1,2"},
+		{"run collapses to range", []int{1, 2, 3, 4, 5}, 0, 6, "1-5"},
+		{"mixed runs and values", []int{0, 5, 6, 7, 9}, 0, 23, "0,5-7,9"},
+		{"unsorted with duplicates", []int{7, 5, 6, 5}, 0, 23, "5-7"},
+		{"full range", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 1, 12, "*"},
+		{"step over full range", []int{0, 15, 30, 45}, 0, 59, "*/15"},
+		{"partial step is a list", []int{0, 15, 30}, 0, 59, "0,15,30"},
+		{"step not from min is a list", []int{5, 20, 35, 50}, 0, 59, "5,20,35,50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cronx.CompressValues(tt.values, tt.min, tt.max))
+		})
+	}
+}
+
+func TestRuns(t *testing.T) {
+	assert.Empty(t, cronx.Runs(nil))
+	assert.Equal(t, [][2]int{{1, 1}}, cronx.Runs([]int{1}))
+	assert.Equal(t, [][2]int{{0, 0}, {2, 4}, {6, 7}}, cronx.Runs([]int{0, 2, 3, 4, 6, 7}))
+}
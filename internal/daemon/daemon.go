@@ -0,0 +1,212 @@
+// Package daemon schedules the jobs of a crontab and runs them in-process,
+// for containers and other environments without a cron daemon. Every start,
+// finish and line of job output is logged as a structured event.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/runner"
+)
+
+// DefaultShutdownTimeout is how long running jobs may take to finish after
+// the daemon is asked to stop
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Event kinds
+const (
+	EventStart   = "start"   // Daemon started
+	EventStop    = "stop"    // Daemon stopped
+	EventRun     = "run"     // Job started
+	EventOutput  = "output"  // Line of job output
+	EventFinish  = "finish"  // Job finished
+	EventDryRun  = "dry-run" // Job due, not run with Options.DryRun
+	EventSkipped = "skipped" // Job not scheduled (invalid expression or time zone)
+	EventError   = "error"   // Job could not be run
+)
+
+// Event is a structured log entry
+type Event struct {
+	Time       time.Time `json:"time"`
+	Event      string    `json:"event"`
+	Line       int       `json:"line,omitempty"`
+	Expression string    `json:"expression,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	Scheduled  time.Time `json:"scheduled,omitzero"`
+	Stream     string    `json:"stream,omitempty"`   // stdout or stderr, for output events
+	Message    string    `json:"message,omitempty"`  // Output line, or what happened
+	ExitCode   *int      `json:"exitCode,omitempty"` // For finish events; -1 when killed by a signal
+	Duration   float64   `json:"durationSeconds,omitempty"`
+	Jobs       int       `json:"jobs,omitempty"` // Jobs scheduled, for start events
+}
+
+// Options configures a daemon
+type Options struct {
+	Scheduler       cronx.Scheduler
+	Location        *time.Location    // Time zone of jobs without CRON_TZ= (nil: local time)
+	Environment     map[string]string // Environment cron starts jobs with (see crontab.DefaultEnvironment)
+	DryRun          bool              // Log due jobs without running them
+	ShutdownTimeout time.Duration     // How long running jobs may take to finish on shutdown
+	Log             func(Event)       // Receives every event; must be safe for concurrent use
+
+	// OnFinish is called after every run (optional)
+	OnFinish func(job *crontab.Job, scheduled time.Time, result runner.Result)
+}
+
+// Run schedules the valid jobs and runs each at its due times until ctx is
+// cancelled. A job does not start while its previous run is still running.
+// On cancellation no further runs start, and running jobs get
+// ShutdownTimeout to finish before they are terminated.
+func Run(ctx context.Context, jobs []*crontab.Job, opts Options) error {
+	if opts.Scheduler == nil {
+		return fmt.Errorf("no scheduler")
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	scheduled := 0
+	for _, job := range jobs {
+		jobLoc, err := job.Location(loc)
+		if err == nil && !job.Valid {
+			err = fmt.Errorf("invalid expression: %s", job.Error)
+		}
+		if err != nil {
+			opts.Log(jobEvent(EventSkipped, job, time.Time{}, err.Error()))
+			continue
+		}
+		scheduled++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			schedule(ctx, jobCtx, job, jobLoc, opts)
+		}()
+	}
+	opts.Log(Event{Time: time.Now(), Event: EventStart, Jobs: scheduled})
+
+	<-ctx.Done()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(opts.ShutdownTimeout):
+		cancelJobs()
+		<-done
+	}
+	opts.Log(Event{Time: time.Now(), Event: EventStop})
+	return nil
+}
+
+// schedule runs job at each of its due times until ctx is cancelled. Runs
+// use jobCtx, which outlives ctx by the shutdown timeout.
+func schedule(ctx, jobCtx context.Context, job *crontab.Job, loc *time.Location, opts Options) {
+	plan := runner.NewPlan(job, opts.Environment)
+	for {
+		next, err := opts.Scheduler.Next(job.Expression, time.Now().In(loc), 1)
+		if err != nil || len(next) == 0 {
+			opts.Log(jobEvent(EventSkipped, job, time.Time{}, "no future runs"))
+			return
+		}
+		due := next[0]
+
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if opts.DryRun {
+			opts.Log(jobEvent(EventDryRun, job, due, "would run: "+plan.Command))
+			continue
+		}
+		run(jobCtx, job, due, plan, opts)
+	}
+}
+
+// run runs job once, logging its output and result
+func run(ctx context.Context, job *crontab.Job, due time.Time, plan runner.Plan, opts Options) {
+	opts.Log(jobEvent(EventRun, job, due, ""))
+	stdout := &lineWriter{emit: func(line string) { opts.Log(outputEvent(job, due, "stdout", line)) }}
+	stderr := &lineWriter{emit: func(line string) { opts.Log(outputEvent(job, due, "stderr", line)) }}
+	result, err := plan.Run(ctx, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+	if err != nil {
+		opts.Log(jobEvent(EventError, job, due, err.Error()))
+		return
+	}
+
+	event := jobEvent(EventFinish, job, due, "")
+	event.ExitCode = &result.ExitCode
+	event.Duration = result.Duration().Seconds()
+	opts.Log(event)
+	if opts.OnFinish != nil {
+		opts.OnFinish(job, due, result)
+	}
+}
+
+// jobEvent returns an event about job
+func jobEvent(kind string, job *crontab.Job, due time.Time, message string) Event {
+	return Event{
+		Time:       time.Now(),
+		Event:      kind,
+		Line:       job.LineNumber,
+		Expression: job.Expression,
+		Command:    job.Command,
+		Scheduled:  due,
+		Message:    message,
+	}
+}
+
+// outputEvent returns an event for a line of job output
+func outputEvent(job *crontab.Job, due time.Time, stream, line string) Event {
+	event := jobEvent(EventOutput, job, due, line)
+	event.Stream = stream
+	event.Command = ""
+	return event
+}
+
+// lineWriter calls emit with every complete line written to it
+type lineWriter struct {
+	emit    func(line string)
+	partial []byte
+}
+
+var _ io.Writer = (*lineWriter)(nil)
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.emit(string(w.partial[:i]))
+		w.partial = w.partial[i+1:]
+	}
+}
+
+// Flush emits the last line, if it had no newline
+func (w *lineWriter) Flush() {
+	if len(w.partial) > 0 {
+		w.emit(string(w.partial))
+		w.partial = nil
+	}
+}
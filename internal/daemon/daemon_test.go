@@ -0,0 +1,181 @@
+package daemon
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/runner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// everyTick is a scheduler whose jobs are due every 50ms
+type everyTick struct{}
+
+func (everyTick) Next(_ string, from time.Time, count int) ([]time.Time, error) {
+	times := make([]time.Time, count)
+	for i := range times {
+		times[i] = from.Add(time.Duration(i+1) * 50 * time.Millisecond)
+	}
+	return times, nil
+}
+
+func (e everyTick) NextContext(_ context.Context, expression string, from time.Time, count int) ([]time.Time, error) {
+	return e.Next(expression, from, count)
+}
+
+func (everyTick) Prev(string, time.Time, int) ([]time.Time, error) { return nil, nil }
+
+func (everyTick) Between(string, time.Time, time.Time) ([]time.Time, error) { return nil, nil }
+
+func (everyTick) BetweenContext(context.Context, string, time.Time, time.Time) ([]time.Time, error) {
+	return nil, nil
+}
+
+func (everyTick) Matches(string, time.Time) (bool, error) { return true, nil }
+
+// recorder collects events
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) log(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) kinds(line int) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var kinds []string
+	for _, e := range r.events {
+		if e.Line == line {
+			kinds = append(kinds, e.Event)
+		}
+	}
+	return kinds
+}
+
+func (r *recorder) all() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func parseJobs(t *testing.T, content string) []*crontab.Job {
+	t.Helper()
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	return jobs
+}
+
+func TestRun(t *testing.T) {
+	jobs := parseJobs(t, "* * * * * echo hello; echo oops >&2; exit 2\n61 * * * * never\n")
+	rec := &recorder{}
+	var finished []runner.Result
+	var mu sync.Mutex
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(180 * time.Millisecond)
+		cancel()
+	}()
+	err := Run(ctx, jobs, Options{
+		Scheduler:       everyTick{},
+		Environment:     map[string]string{"HOME": t.TempDir(), "PATH": crontab.DefaultPath},
+		ShutdownTimeout: time.Second,
+		Log:             rec.log,
+		OnFinish: func(_ *crontab.Job, _ time.Time, result runner.Result) {
+			mu.Lock()
+			defer mu.Unlock()
+			finished = append(finished, result)
+		},
+	})
+	require.NoError(t, err)
+
+	events := rec.all()
+	require.NotEmpty(t, events)
+	assert.Equal(t, EventSkipped, events[0].Event)
+	assert.Equal(t, 2, events[0].Line)
+	assert.Equal(t, EventStart, events[1].Event)
+	assert.Equal(t, 1, events[1].Jobs)
+	assert.Equal(t, EventStop, events[len(events)-1].Event)
+
+	kinds := rec.kinds(1)
+	require.GreaterOrEqual(t, len(kinds), 4)
+	assert.Equal(t, []string{EventRun, EventOutput, EventOutput, EventFinish}, kinds[:4])
+
+	for _, e := range events {
+		switch {
+		case e.Event == EventOutput && e.Stream == "stdout":
+			assert.Equal(t, "hello", e.Message)
+		case e.Event == EventOutput:
+			assert.Equal(t, "stderr", e.Stream)
+			assert.Equal(t, "oops", e.Message)
+		case e.Event == EventFinish:
+			require.NotNil(t, e.ExitCode)
+			assert.Equal(t, 2, *e.ExitCode)
+			assert.False(t, e.Scheduled.IsZero())
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.NotEmpty(t, finished)
+}
+
+func TestRun_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	jobs := parseJobs(t, "* * * * * touch "+dir+"/ran\n")
+	rec := &recorder{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+	require.NoError(t, Run(ctx, jobs, Options{Scheduler: everyTick{}, DryRun: true, Log: rec.log}))
+
+	kinds := rec.kinds(1)
+	require.NotEmpty(t, kinds)
+	for _, kind := range kinds {
+		assert.Equal(t, EventDryRun, kind)
+	}
+	assert.NoFileExists(t, dir+"/ran")
+}
+
+func TestRun_ShutdownTimeout(t *testing.T) {
+	jobs := parseJobs(t, "* * * * * sleep 30\n")
+	rec := &recorder{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.NoError(t, Run(ctx, jobs, Options{
+		Scheduler:       everyTick{},
+		Environment:     map[string]string{"HOME": t.TempDir(), "PATH": crontab.DefaultPath},
+		ShutdownTimeout: 100 * time.Millisecond,
+		Log:             rec.log,
+	}))
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	kinds := rec.kinds(1)
+	assert.Equal(t, []string{EventRun, EventFinish}, kinds)
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{emit: func(line string) { lines = append(lines, line) }}
+	_, _ = w.Write([]byte("one\ntw"))
+	_, _ = w.Write([]byte("o\nthree"))
+	w.Flush()
+	assert.Equal(t, []string{"one", "two", "three"}, lines)
+}
@@ -0,0 +1,89 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ api.Observer = (*Metrics)(nil)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveRequest("next", true, 2*time.Millisecond)
+	m.ObserveRequest("next", false, 4*time.Millisecond)
+	m.ObserveRequest("check", true, time.Millisecond)
+	m.ObserveRequest("", false, time.Millisecond)
+	m.ObserveDuration("overlap-analysis", 10*time.Millisecond)
+	m.Time("timed")()
+
+	snap := m.Snapshot()
+	assert.Equal(t, []RequestCount{
+		{Command: "check", OK: 1},
+		{Command: "next", OK: 1, Failed: 1},
+		{Command: "unknown", Failed: 1},
+	}, snap.Requests)
+
+	next := snap.Durations["next"]
+	assert.Equal(t, uint64(2), next.Count)
+	assert.Equal(t, 4*time.Millisecond, next.Max)
+	assert.Equal(t, 3*time.Millisecond, next.Mean())
+	assert.Equal(t, uint64(1), snap.Durations["overlap-analysis"].Count)
+	assert.Equal(t, uint64(1), snap.Durations["timed"].Count)
+	assert.Zero(t, DurationStats{}.Mean())
+}
+
+func TestHandler(t *testing.T) {
+	m := NewMetrics()
+	api.ExecuteObserved(api.Request{Command: "explain", Expression: "0 0 * * *"}, m)
+	server := httptest.NewServer(Handler(m))
+	defer server.Close()
+
+	t.Run("serves metrics as JSON", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/debug/metrics")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		var snap Snapshot
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+		assert.Equal(t, []RequestCount{{Command: "explain", OK: 1}}, snap.Requests)
+		assert.GreaterOrEqual(t, snap.ParseCache.Misses, uint64(1))
+	})
+
+	t.Run("rejects other methods on metrics", func(t *testing.T) {
+		resp, err := http.Post(server.URL+"/debug/metrics", "application/json", nil)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("serves pprof", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/debug/pprof/goroutine?debug=1")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("nothing outside /debug", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestNewServer(t *testing.T) {
+	server := NewServer("localhost:6060", NewMetrics())
+	assert.Equal(t, "localhost:6060", server.Addr)
+	assert.NotNil(t, server.Handler)
+	assert.NotZero(t, server.ReadHeaderTimeout)
+}
@@ -0,0 +1,140 @@
+// Package debug exposes profiling and internal metrics of long-running
+// cronkit processes on a separate, opt-in listen address
+package debug
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// Metrics collects request counts and analysis durations. It implements
+// api.Observer and is safe for concurrent use.
+type Metrics struct {
+	mu        sync.Mutex
+	started   time.Time
+	requests  map[string]*requestStats
+	durations map[string]*DurationStats
+}
+
+// requestStats counts the requests of one command
+type requestStats struct {
+	ok     uint64
+	failed uint64
+}
+
+// DurationStats summarizes the durations of one operation
+type DurationStats struct {
+	Count uint64        `json:"count"`
+	Total time.Duration `json:"totalNs"`
+	Max   time.Duration `json:"maxNs"`
+}
+
+// Mean returns the average duration, or 0 before any observation
+func (d DurationStats) Mean() time.Duration {
+	if d.Count == 0 {
+		return 0
+	}
+	return d.Total / time.Duration(d.Count)
+}
+
+// NewMetrics creates an empty metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{
+		started:   time.Now(),
+		requests:  make(map[string]*requestStats),
+		durations: make(map[string]*DurationStats),
+	}
+}
+
+// ObserveRequest records a request of the given command and its duration
+func (m *Metrics) ObserveRequest(command string, ok bool, duration time.Duration) {
+	if command == "" {
+		command = "unknown"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, found := m.requests[command]
+	if !found {
+		stats = &requestStats{}
+		m.requests[command] = stats
+	}
+	if ok {
+		stats.ok++
+	} else {
+		stats.failed++
+	}
+	m.observeLocked(command, duration)
+}
+
+// ObserveDuration records how long an analysis step took
+func (m *Metrics) ObserveDuration(name string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observeLocked(name, duration)
+}
+
+// Time starts timing an analysis step; call the returned function when it ends
+func (m *Metrics) Time(name string) func() {
+	start := time.Now()
+	return func() { m.ObserveDuration(name, time.Since(start)) }
+}
+
+func (m *Metrics) observeLocked(name string, duration time.Duration) {
+	stats, found := m.durations[name]
+	if !found {
+		stats = &DurationStats{}
+		m.durations[name] = stats
+	}
+	stats.Count++
+	stats.Total += duration
+	stats.Max = max(stats.Max, duration)
+}
+
+// RequestCount is the number of requests of one command
+type RequestCount struct {
+	Command string `json:"command"`
+	OK      uint64 `json:"ok"`
+	Failed  uint64 `json:"failed"`
+}
+
+// Snapshot is a point-in-time copy of the metrics
+type Snapshot struct {
+	Uptime       time.Duration            `json:"uptimeNs"`
+	Requests     []RequestCount           `json:"requests"` // Ordered by command
+	Durations    map[string]DurationStats `json:"durations"`
+	ParseCache   cronx.CacheStats         `json:"parseCache"`
+	CacheHitRate float64                  `json:"parseCacheHitRate"`
+
+	ScheduleCache        cronx.CacheStats `json:"scheduleCache"`
+	ScheduleCacheHitRate float64          `json:"scheduleCacheHitRate"`
+}
+
+// Snapshot returns a copy of the current metrics, including the parse and
+// schedule cache counters of the process
+func (m *Metrics) Snapshot() Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snap := Snapshot{
+		Uptime:    time.Since(m.started),
+		Requests:  make([]RequestCount, 0, len(m.requests)),
+		Durations: make(map[string]DurationStats, len(m.durations)),
+	}
+	for command, stats := range m.requests {
+		snap.Requests = append(snap.Requests, RequestCount{Command: command, OK: stats.ok, Failed: stats.failed})
+	}
+	sort.Slice(snap.Requests, func(i, j int) bool { return snap.Requests[i].Command < snap.Requests[j].Command })
+	for name, stats := range m.durations {
+		snap.Durations[name] = *stats
+	}
+
+	snap.ParseCache = cronx.ParseCacheStats()
+	snap.CacheHitRate = snap.ParseCache.HitRate()
+	snap.ScheduleCache = cronx.ScheduleCacheStats()
+	snap.ScheduleCacheHitRate = snap.ScheduleCache.HitRate()
+	return snap
+}
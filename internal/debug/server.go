@@ -0,0 +1,43 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// Handler serves the pprof endpoints under /debug/pprof/ and a JSON snapshot
+// of metrics at /debug/metrics. The handlers are registered on a dedicated
+// mux, never on http.DefaultServeMux, so they are only reachable on the
+// debug listen address.
+func Handler(metrics *Metrics) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(metrics.Snapshot())
+	})
+	return mux
+}
+
+// NewServer creates the debug HTTP server for addr (e.g. "localhost:6060").
+// Bind it to a loopback or otherwise private address: profiles expose
+// internals of the process.
+func NewServer(addr string, metrics *Metrics) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           Handler(metrics),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
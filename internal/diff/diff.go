@@ -24,7 +24,8 @@ type Change struct {
 	NewEntry      *crontab.Entry
 	OldJob        *crontab.Job
 	NewJob        *crontab.Job
-	FieldsChanged []string // For modified jobs: which fields changed (expression, command, comment)
+	FieldsChanged []string            // For modified jobs: which fields changed (expression, command, comment)
+	Schedule      *ScheduleComparison // For expression changes found by AnalyzeSchedules
 }
 
 // Diff represents the semantic differences between two crontabs
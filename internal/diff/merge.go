@@ -0,0 +1,221 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Conflict markers of merged crontabs, as written by git
+const (
+	MarkerOurs   = "<<<<<<< ours"
+	MarkerSplit  = "======="
+	MarkerTheirs = ">>>>>>> theirs"
+)
+
+// MergeConflict is a job or variable that both sides changed differently
+type MergeConflict struct {
+	Line   int      // Line of the conflict's first marker in the merged crontab
+	Base   []string // Lines in the base crontab (nil if absent)
+	Ours   []string // Lines in our crontab (nil if deleted)
+	Theirs []string // Lines in their crontab (nil if deleted)
+}
+
+// MergeResult is the result of Merge
+type MergeResult struct {
+	Text      string // Merged crontab, with conflict markers
+	Conflicts []MergeConflict
+}
+
+// mergeItem is a job, variable or unparseable line of a crontab, with the
+// comment lines directly above it, such as "# cronkit:" directives
+type mergeItem struct {
+	key   string   // Identity of the item across versions
+	lines []string // Comment lines and the item's line
+	norm  string   // Lines with whitespace collapsed, for comparison
+}
+
+// mergeElement is an item or a line attached to no item (blank lines and
+// comments separated from the next item by a blank line)
+type mergeElement struct {
+	item *mergeItem
+	line string
+}
+
+// Merge performs a three-way merge of two crontabs derived from base. Jobs
+// are matched across versions by their user and command, variables by
+// name, rather than by line. A job or variable changed on one side only
+// takes that side's version, including deletions; one changed on both sides
+// in different ways is a conflict, written between git-style conflict
+// markers. The merged crontab follows our layout, with the items only they
+// added placed after the item that precedes them in their crontab.
+// Comments attached to no item are taken from ours.
+func Merge(base, ours, theirs []*crontab.Entry) *MergeResult {
+	baseItems := itemsByKey(mergeElements(base))
+	oursElements := mergeElements(ours)
+	oursItems := itemsByKey(oursElements)
+	theirsElements := mergeElements(theirs)
+	theirsItems := itemsByKey(theirsElements)
+
+	// Items only they have go after the preceding item of theirs that we have
+	inserted := make(map[string][]*mergeItem)
+	anchor := ""
+	for _, element := range theirsElements {
+		if element.item == nil {
+			continue
+		}
+		if _, ok := oursItems[element.item.key]; ok {
+			anchor = element.item.key
+			continue
+		}
+		inserted[anchor] = append(inserted[anchor], element.item)
+	}
+
+	m := &merger{}
+	emitInserted := func(key string) {
+		for _, item := range inserted[key] {
+			m.emit(baseItems[item.key], nil, item)
+		}
+	}
+	first := true
+	for _, element := range oursElements {
+		if element.item == nil {
+			m.lines = append(m.lines, element.line)
+			continue
+		}
+		if first {
+			emitInserted("")
+			first = false
+		}
+		key := element.item.key
+		m.emit(baseItems[key], element.item, theirsItems[key])
+		emitInserted(key)
+	}
+	if first {
+		emitInserted("")
+	}
+
+	text := strings.Join(m.lines, "\n")
+	if len(m.lines) > 0 {
+		text += "\n"
+	}
+	return &MergeResult{Text: text, Conflicts: m.conflicts}
+}
+
+// merger accumulates the merged crontab
+type merger struct {
+	lines     []string
+	conflicts []MergeConflict
+}
+
+// emit writes the merged version of an item from its base, our and their
+// versions, any of which may be nil
+func (m *merger) emit(base, ours, theirs *mergeItem) {
+	var result *mergeItem
+	switch {
+	case sameItem(ours, theirs), sameItem(base, theirs):
+		result = ours
+	case sameItem(base, ours):
+		result = theirs
+	default:
+		m.conflicts = append(m.conflicts, MergeConflict{
+			Line:   len(m.lines) + 1,
+			Base:   itemLines(base),
+			Ours:   itemLines(ours),
+			Theirs: itemLines(theirs),
+		})
+		m.lines = append(m.lines, MarkerOurs)
+		m.lines = append(m.lines, itemLines(ours)...)
+		m.lines = append(m.lines, MarkerSplit)
+		m.lines = append(m.lines, itemLines(theirs)...)
+		m.lines = append(m.lines, MarkerTheirs)
+		return
+	}
+	m.lines = append(m.lines, itemLines(result)...)
+}
+
+// sameItem reports whether two versions of an item are equal, ignoring
+// whitespace; nil versions are absent
+func sameItem(a, b *mergeItem) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.norm == b.norm
+}
+
+func itemLines(item *mergeItem) []string {
+	if item == nil {
+		return nil
+	}
+	return item.lines
+}
+
+// mergeElements splits a crontab into items and unattached lines
+func mergeElements(entries []*crontab.Entry) []mergeElement {
+	var elements []mergeElement
+	var comments []string
+	occurrences := make(map[string]int)
+	for _, entry := range entries {
+		switch entry.Type {
+		case crontab.EntryTypeComment:
+			comments = append(comments, entry.Raw)
+			continue
+		case crontab.EntryTypeEmpty:
+			for _, line := range comments {
+				elements = append(elements, mergeElement{line: line})
+			}
+			comments = nil
+			elements = append(elements, mergeElement{line: entry.Raw})
+			continue
+		}
+
+		key := itemKey(entry)
+		occurrences[key]++
+		lines := append(comments, entry.Raw)
+		comments = nil
+		norm := make([]string, len(lines))
+		for i, line := range lines {
+			norm[i] = collapseSpace(line)
+		}
+		elements = append(elements, mergeElement{item: &mergeItem{
+			key:   fmt.Sprintf("%s\x00%d", key, occurrences[key]),
+			lines: lines,
+			norm:  strings.Join(norm, "\n"),
+		}})
+	}
+	for _, line := range comments {
+		elements = append(elements, mergeElement{line: line})
+	}
+	return elements
+}
+
+// itemKey returns the identity of a job (its user and command), variable
+// (its name) or unparseable line (its text)
+func itemKey(entry *crontab.Entry) string {
+	switch entry.Type {
+	case crontab.EntryTypeJob:
+		if entry.Job != nil {
+			return "job\x00" + entry.Job.User + "\x00" + collapseSpace(entry.Job.Command)
+		}
+	case crontab.EntryTypeEnvVar:
+		name, _, _ := strings.Cut(entry.Raw, "=")
+		return "env\x00" + strings.TrimSpace(name)
+	}
+	return "line\x00" + collapseSpace(entry.Raw)
+}
+
+func itemsByKey(elements []mergeElement) map[string]*mergeItem {
+	items := make(map[string]*mergeItem)
+	for _, element := range elements {
+		if element.item != nil {
+			items[element.item.key] = element.item
+		}
+	}
+	return items
+}
+
+// collapseSpace trims s and replaces runs of whitespace with single spaces
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
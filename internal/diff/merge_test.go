@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCrontab(t *testing.T, content string) []*crontab.Entry {
+	t.Helper()
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	return entries
+}
+
+func TestMerge(t *testing.T) {
+	base := `# Maintenance jobs
+
+MAILTO=ops@example.com
+0 2 * * * /usr/bin/backup.sh
+# cronkit:owner=web
+*/5 * * * * /usr/bin/poll.sh
+0 4 * * * /usr/bin/cleanup.sh
+`
+
+	tests := []struct {
+		name      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts int
+	}{
+		{
+			name:   "changes to different jobs",
+			ours:   strings.Replace(base, "0 2 * * *", "30 2 * * *", 1),
+			theirs: strings.Replace(base, "*/5 * * * *", "*/10 * * * *", 1),
+			want:   strings.Replace(strings.Replace(base, "0 2 * * *", "30 2 * * *", 1), "*/5 * * * *", "*/10 * * * *", 1),
+		},
+		{
+			name:   "moved and changed jobs are matched by command",
+			ours:   "# Maintenance jobs\n\nMAILTO=ops@example.com\n0 4 * * * /usr/bin/cleanup.sh\n0 2 * * * /usr/bin/backup.sh\n# cronkit:owner=web\n*/5 * * * * /usr/bin/poll.sh\n",
+			theirs: strings.Replace(base, "0 4 * * *", "0 5 * * *", 1),
+			want:   "# Maintenance jobs\n\nMAILTO=ops@example.com\n0 5 * * * /usr/bin/cleanup.sh\n0 2 * * * /usr/bin/backup.sh\n# cronkit:owner=web\n*/5 * * * * /usr/bin/poll.sh\n",
+		},
+		{
+			name:   "jobs added on both sides",
+			ours:   base + "0 6 * * * /usr/bin/ours.sh\n",
+			theirs: strings.Replace(base, "0 2 * * * /usr/bin/backup.sh\n", "0 2 * * * /usr/bin/backup.sh\n# Added by them\n15 3 * * * /usr/bin/theirs.sh\n", 1),
+			want:   strings.Replace(base, "0 2 * * * /usr/bin/backup.sh\n", "0 2 * * * /usr/bin/backup.sh\n# Added by them\n15 3 * * * /usr/bin/theirs.sh\n", 1) + "0 6 * * * /usr/bin/ours.sh\n",
+		},
+		{
+			name:   "deletion on one side",
+			ours:   base,
+			theirs: strings.Replace(base, "0 4 * * * /usr/bin/cleanup.sh\n", "", 1),
+			want:   strings.Replace(base, "0 4 * * * /usr/bin/cleanup.sh\n", "", 1),
+		},
+		{
+			name:   "same change on both sides",
+			ours:   strings.Replace(base, "ops@", "alerts@", 1),
+			theirs: strings.Replace(base, "ops@", "alerts@", 1),
+			want:   strings.Replace(base, "ops@", "alerts@", 1),
+		},
+		{
+			name:   "whitespace changes are not conflicts",
+			ours:   strings.Replace(base, "0 2 * * * /usr/bin/backup.sh", "0  2  *  *  *  /usr/bin/backup.sh", 1),
+			theirs: base,
+			want:   strings.Replace(base, "0 2 * * * /usr/bin/backup.sh", "0  2  *  *  *  /usr/bin/backup.sh", 1),
+		},
+		{
+			name:   "directive change is a change of its job",
+			ours:   base,
+			theirs: strings.Replace(base, "owner=web", "owner=platform", 1),
+			want:   strings.Replace(base, "owner=web", "owner=platform", 1),
+		},
+		{
+			name:      "conflicting schedules",
+			ours:      strings.Replace(base, "0 2 * * *", "30 2 * * *", 1),
+			theirs:    strings.Replace(base, "0 2 * * *", "0 3 * * *", 1),
+			want:      strings.Replace(base, "0 2 * * * /usr/bin/backup.sh\n", "<<<<<<< ours\n30 2 * * * /usr/bin/backup.sh\n=======\n0 3 * * * /usr/bin/backup.sh\n>>>>>>> theirs\n", 1),
+			conflicts: 1,
+		},
+		{
+			name:      "change against deletion",
+			ours:      strings.Replace(base, "0 4 * * * /usr/bin/cleanup.sh\n", "", 1),
+			theirs:    strings.Replace(base, "0 4 * * *", "0 5 * * *", 1),
+			want:      strings.Replace(base, "0 4 * * * /usr/bin/cleanup.sh\n", "<<<<<<< ours\n=======\n0 5 * * * /usr/bin/cleanup.sh\n>>>>>>> theirs\n", 1),
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Merge(parseCrontab(t, base), parseCrontab(t, tt.ours), parseCrontab(t, tt.theirs))
+			assert.Equal(t, tt.want, result.Text)
+			assert.Len(t, result.Conflicts, tt.conflicts)
+		})
+	}
+
+	t.Run("conflict details", func(t *testing.T) {
+		result := Merge(parseCrontab(t, base),
+			parseCrontab(t, strings.Replace(base, "0 2 * * *", "30 2 * * *", 1)),
+			parseCrontab(t, strings.Replace(base, "0 2 * * *", "0 3 * * *", 1)))
+		require.Len(t, result.Conflicts, 1)
+		c := result.Conflicts[0]
+		assert.Equal(t, 4, c.Line)
+		assert.Equal(t, []string{"0 2 * * * /usr/bin/backup.sh"}, c.Base)
+		assert.Equal(t, []string{"30 2 * * * /usr/bin/backup.sh"}, c.Ours)
+		assert.Equal(t, []string{"0 3 * * * /usr/bin/backup.sh"}, c.Theirs)
+	})
+
+	t.Run("repeated commands are matched in order", func(t *testing.T) {
+		base := "0 1 * * * /usr/bin/sync.sh\n0 13 * * * /usr/bin/sync.sh\n"
+		ours := "0 1 * * * /usr/bin/sync.sh\n0 14 * * * /usr/bin/sync.sh\n"
+		theirs := "0 2 * * * /usr/bin/sync.sh\n0 13 * * * /usr/bin/sync.sh\n"
+		result := Merge(parseCrontab(t, base), parseCrontab(t, ours), parseCrontab(t, theirs))
+		assert.Equal(t, "0 2 * * * /usr/bin/sync.sh\n0 14 * * * /usr/bin/sync.sh\n", result.Text)
+		assert.Empty(t, result.Conflicts)
+	})
+
+	t.Run("empty ours takes their additions", func(t *testing.T) {
+		result := Merge(nil, nil, parseCrontab(t, "0 1 * * * /usr/bin/sync.sh\n"))
+		assert.Equal(t, "0 1 * * * /usr/bin/sync.sh\n", result.Text)
+	})
+}
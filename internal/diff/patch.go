@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContextLines is the number of unchanged lines shown around each hunk
+const DefaultContextLines = 3
+
+// patchOp is one line of an edit script: kept (' '), removed ('-') or added ('+')
+type patchOp struct {
+	kind byte
+	line string // Including its "\n" terminator, if any
+}
+
+// Patch returns a unified diff that turns oldText into newText, applyable
+// with `git apply` or `patch -p1`. Both sides are labelled with path under
+// the a/ and b/ prefixes git uses. It returns "" when the texts are equal.
+func Patch(path, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+	name := strings.TrimPrefix(path, "/")
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", name, name)
+	for _, hunk := range hunks(ops, DefaultContextLines) {
+		writeHunk(&b, ops, hunk)
+	}
+	return b.String()
+}
+
+// splitLines splits text into lines, each keeping its "\n" terminator; only
+// the last line may lack one
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a shortest edit script from a to b using their longest
+// common subsequence. Crontabs are small, so the quadratic table is fine.
+func diffLines(a, b []string) []patchOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]patchOp, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			ops = append(ops, patchOp{' ', a[i]})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, patchOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, patchOp{'+', b[j]})
+			j++
+		}
+	}
+	return ops
+}
+
+// hunks groups changed ops into [start, end) ranges with context lines on
+// each side; changes separated by at most 2*context unchanged lines share a hunk
+func hunks(ops []patchOp, context int) [][2]int {
+	var ranges [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		start := max(0, i-context)
+		end := i + 1
+		// Extend over following changes that are close enough
+		for k := end; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				if k-end <= 2*context {
+					end = k + 1
+					continue
+				}
+				break
+			}
+		}
+		end = min(len(ops), end+context)
+		ranges = append(ranges, [2]int{start, end})
+		i = end - 1
+	}
+	return ranges
+}
+
+// writeHunk writes the ops in hunk with its "@@ -l,s +l,s @@" header
+func writeHunk(b *strings.Builder, ops []patchOp, hunk [2]int) {
+	oldLine, newLine := 0, 0
+	for _, op := range ops[:hunk[0]] {
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+	for _, op := range ops[hunk[0]:hunk[1]] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	// An empty side is addressed by the line before it
+	if oldCount > 0 {
+		oldLine++
+	}
+	if newCount > 0 {
+		newLine++
+	}
+	_, _ = fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+
+	for _, op := range ops[hunk[0]:hunk[1]] {
+		b.WriteByte(op.kind)
+		b.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
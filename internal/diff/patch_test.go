@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch(t *testing.T) {
+	t.Run("equal texts produce no patch", func(t *testing.T) {
+		assert.Empty(t, Patch("crontab", "a\n", "a\n"))
+	})
+
+	t.Run("single change with context", func(t *testing.T) {
+		old := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\n"
+		new := "l1\nl2\nl3\nl4\nX5\nl6\nl7\nl8\n"
+
+		assert.Equal(t, strings.Join([]string{
+			"--- a/etc/crontab",
+			"+++ b/etc/crontab",
+			"@@ -2,7 +2,7 @@",
+			" l2",
+			" l3",
+			" l4",
+			"-l5",
+			"+X5",
+			" l6",
+			" l7",
+			" l8",
+			"",
+		}, "\n"), Patch("/etc/crontab", old, new))
+	})
+
+	t.Run("distant changes get separate hunks", func(t *testing.T) {
+		var lines []string
+		for i := 0; i < 20; i++ {
+			lines = append(lines, fmt.Sprintf("l%d", i+1))
+		}
+		old := strings.Join(lines, "\n") + "\n"
+		lines[0], lines[19] = "first", "last"
+		new := strings.Join(lines, "\n") + "\n"
+
+		patch := Patch("jobs.cron", old, new)
+		assert.Contains(t, patch, "@@ -1,4 +1,4 @@\n-l1\n+first\n l2\n l3\n l4\n")
+		assert.Contains(t, patch, "@@ -17,4 +17,4 @@\n l17\n l18\n l19\n-l20\n+last\n")
+	})
+
+	t.Run("additions to an empty file", func(t *testing.T) {
+		assert.Equal(t, "--- a/new.cron\n+++ b/new.cron\n@@ -0,0 +1,1 @@\n+@daily /bin/job\n",
+			Patch("new.cron", "", "@daily /bin/job\n"))
+	})
+
+	t.Run("missing final newline is marked", func(t *testing.T) {
+		patch := Patch("jobs.cron", "a\nb", "a\nb\n")
+		assert.Contains(t, patch, "-b\n\\ No newline at end of file\n+b\n")
+	})
+}
@@ -6,6 +6,10 @@ import (
 	"io"
 	"strings"
 	"time"
+
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/schema"
 )
 
 // Renderer interface for different output formats
@@ -18,6 +22,7 @@ type RenderOptions struct {
 	ShowUnchanged  bool
 	IgnoreComments bool
 	IgnoreEnv      bool
+	Painter        *color.Painter // Colors of text and unified output (nil: none)
 }
 
 // TextRenderer renders diff in human-readable text format
@@ -29,16 +34,17 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 	if opts == nil {
 		opts = &RenderOptions{}
 	}
+	p := opts.Painter
 
-	_, _ = fmt.Fprintf(w, "Crontab Diff\n")
+	_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Heading, "Crontab Diff"))
 	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n\n")
 
 	// Show added jobs
 	if len(diff.Added) > 0 {
-		_, _ = fmt.Fprintf(w, "Added Jobs (%d):\n", len(diff.Added))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Heading, "Added Jobs (%d):", len(diff.Added)))
 		_, _ = fmt.Fprintf(w, "─────────────────────────────────────────────────────────────\n")
 		for _, change := range diff.Added {
-			_, _ = fmt.Fprintf(w, "+ %s  %s\n", change.NewJob.Expression, change.NewJob.Command)
+			_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Added, "+ %s  %s", change.NewJob.Expression, change.NewJob.Command))
 			if change.NewJob.Comment != "" {
 				_, _ = fmt.Fprintf(w, "  # %s\n", change.NewJob.Comment)
 			}
@@ -48,10 +54,10 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 
 	// Show removed jobs
 	if len(diff.Removed) > 0 {
-		_, _ = fmt.Fprintf(w, "Removed Jobs (%d):\n", len(diff.Removed))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Heading, "Removed Jobs (%d):", len(diff.Removed)))
 		_, _ = fmt.Fprintf(w, "─────────────────────────────────────────────────────────────\n")
 		for _, change := range diff.Removed {
-			_, _ = fmt.Fprintf(w, "- %s  %s\n", change.OldJob.Expression, change.OldJob.Command)
+			_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Removed, "- %s  %s", change.OldJob.Expression, change.OldJob.Command))
 			if change.OldJob.Comment != "" {
 				_, _ = fmt.Fprintf(w, "  # %s\n", change.OldJob.Comment)
 			}
@@ -61,10 +67,10 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 
 	// Show modified jobs
 	if len(diff.Modified) > 0 {
-		_, _ = fmt.Fprintf(w, "Modified Jobs (%d):\n", len(diff.Modified))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Heading, "Modified Jobs (%d):", len(diff.Modified)))
 		_, _ = fmt.Fprintf(w, "─────────────────────────────────────────────────────────────\n")
 		for _, change := range diff.Modified {
-			_, _ = fmt.Fprintf(w, "~ %s  %s\n", change.NewJob.Expression, change.NewJob.Command)
+			_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Changed, "~ %s  %s", change.NewJob.Expression, change.NewJob.Command))
 			_, _ = fmt.Fprintf(w, "  Fields changed: %s\n", strings.Join(change.FieldsChanged, ", "))
 
 			// Show old values for changed fields
@@ -73,6 +79,16 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 				case "expression":
 					_, _ = fmt.Fprintf(w, "    Old expression: %s\n", change.OldJob.Expression)
 					_, _ = fmt.Fprintf(w, "    New expression: %s\n", change.NewJob.Expression)
+					if schedule := change.Schedule; schedule != nil {
+						switch {
+						case schedule.Equivalent:
+							_, _ = fmt.Fprintf(w, "    Same schedule: no-op change\n")
+						case schedule.FrequencyChanged():
+							_, _ = fmt.Fprintf(w, "    Frequency: was %s, now %s\n", FormatFrequency(schedule.OldRunsPerDay), FormatFrequency(schedule.NewRunsPerDay))
+						default:
+							_, _ = fmt.Fprintf(w, "    Frequency: unchanged (%s), at different times\n", FormatFrequency(schedule.NewRunsPerDay))
+						}
+					}
 				case "command":
 					_, _ = fmt.Fprintf(w, "    Old command: %s\n", change.OldJob.Command)
 					_, _ = fmt.Fprintf(w, "    New command: %s\n", change.NewJob.Command)
@@ -87,7 +103,7 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 
 	// Show unchanged jobs (if requested)
 	if opts.ShowUnchanged && len(diff.Unchanged) > 0 {
-		_, _ = fmt.Fprintf(w, "Unchanged Jobs (%d):\n", len(diff.Unchanged))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Heading, "Unchanged Jobs (%d):", len(diff.Unchanged)))
 		_, _ = fmt.Fprintf(w, "─────────────────────────────────────────────────────────────\n")
 		for _, change := range diff.Unchanged {
 			_, _ = fmt.Fprintf(w, "  %s  %s\n", change.NewJob.Expression, change.NewJob.Command)
@@ -97,16 +113,16 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 
 	// Show environment variable changes
 	if !opts.IgnoreEnv && len(diff.EnvChanges) > 0 {
-		_, _ = fmt.Fprintf(w, "Environment Variable Changes (%d):\n", len(diff.EnvChanges))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Heading, "Environment Variable Changes (%d):", len(diff.EnvChanges)))
 		_, _ = fmt.Fprintf(w, "─────────────────────────────────────────────────────────────\n")
 		for _, envChange := range diff.EnvChanges {
 			switch envChange.Type {
 			case ChangeTypeAdded:
-				_, _ = fmt.Fprintf(w, "+ %s=%s\n", envChange.Key, envChange.NewValue)
+				_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Added, "+ %s=%s", envChange.Key, envChange.NewValue))
 			case ChangeTypeRemoved:
-				_, _ = fmt.Fprintf(w, "- %s=%s\n", envChange.Key, envChange.OldValue)
+				_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Removed, "- %s=%s", envChange.Key, envChange.OldValue))
 			case ChangeTypeModified:
-				_, _ = fmt.Fprintf(w, "~ %s\n", envChange.Key)
+				_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Changed, "~ %s", envChange.Key))
 				_, _ = fmt.Fprintf(w, "    Old: %s\n", envChange.OldValue)
 				_, _ = fmt.Fprintf(w, "    New: %s\n", envChange.NewValue)
 			}
@@ -116,14 +132,14 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 
 	// Show comment changes
 	if !opts.IgnoreComments && len(diff.CommentChanges) > 0 {
-		_, _ = fmt.Fprintf(w, "Comment Changes (%d):\n", len(diff.CommentChanges))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Heading, "Comment Changes (%d):", len(diff.CommentChanges)))
 		_, _ = fmt.Fprintf(w, "─────────────────────────────────────────────────────────────\n")
 		for _, commentChange := range diff.CommentChanges {
 			switch commentChange.Type {
 			case ChangeTypeAdded:
-				_, _ = fmt.Fprintf(w, "+ %s\n", commentChange.NewLine)
+				_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Added, "+ %s", commentChange.NewLine))
 			case ChangeTypeRemoved:
-				_, _ = fmt.Fprintf(w, "- %s\n", commentChange.OldLine)
+				_, _ = fmt.Fprintf(w, "%s\n", p.Paintf(color.Removed, "- %s", commentChange.OldLine))
 			}
 		}
 		_, _ = fmt.Fprintf(w, "\n")
@@ -134,8 +150,12 @@ func (r *TextRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 	if totalChanges == 0 {
 		_, _ = fmt.Fprintf(w, "No changes detected.\n")
 	} else {
-		_, _ = fmt.Fprintf(w, "Summary: %d added, %d removed, %d modified\n",
+		_, _ = fmt.Fprintf(w, "Summary: %d added, %d removed, %d modified",
 			len(diff.Added), len(diff.Removed), len(diff.Modified))
+		if noOps := diff.NoOpScheduleChanges(); noOps > 0 {
+			_, _ = fmt.Fprintf(w, " (%d with the same schedule)", noOps)
+		}
+		_, _ = fmt.Fprintf(w, "\n")
 	}
 
 	return nil
@@ -162,6 +182,9 @@ func (r *JSONRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 		OldCommand    string   `json:"oldCommand,omitempty"`
 		OldComment    string   `json:"oldComment,omitempty"`
 		OldLineNumber int      `json:"oldLineNumber,omitempty"`
+		SameSchedule  *bool    `json:"sameSchedule,omitempty"`
+		OldRunsPerDay *float64 `json:"oldRunsPerDay,omitempty"`
+		NewRunsPerDay *float64 `json:"newRunsPerDay,omitempty"`
 	}
 
 	type EnvChangeJSON struct {
@@ -178,6 +201,7 @@ func (r *JSONRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 	}
 
 	type DiffJSON struct {
+		SchemaVersion  int                 `json:"schemaVersion"`
 		Added          []JobChangeJSON     `json:"added"`
 		Removed        []JobChangeJSON     `json:"removed"`
 		Modified       []JobChangeJSON     `json:"modified"`
@@ -189,6 +213,7 @@ func (r *JSONRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 	}
 
 	result := DiffJSON{
+		SchemaVersion:  schema.Version,
 		Added:          []JobChangeJSON{},
 		Removed:        []JobChangeJSON{},
 		Modified:       []JobChangeJSON{},
@@ -202,6 +227,9 @@ func (r *JSONRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 		},
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 	}
+	if noOps := diff.NoOpScheduleChanges(); noOps > 0 {
+		result.Summary["noOp"] = noOps
+	}
 
 	// Convert added jobs
 	for _, change := range diff.Added {
@@ -227,7 +255,7 @@ func (r *JSONRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 
 	// Convert modified jobs
 	for _, change := range diff.Modified {
-		result.Modified = append(result.Modified, JobChangeJSON{
+		modified := JobChangeJSON{
 			Type:          "modified",
 			Expression:    change.NewJob.Expression,
 			Command:       change.NewJob.Command,
@@ -238,7 +266,13 @@ func (r *JSONRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) e
 			OldCommand:    change.OldJob.Command,
 			OldComment:    change.OldJob.Comment,
 			OldLineNumber: change.OldJob.LineNumber,
-		})
+		}
+		if schedule := change.Schedule; schedule != nil {
+			modified.SameSchedule = &schedule.Equivalent
+			modified.OldRunsPerDay = &schedule.OldRunsPerDay
+			modified.NewRunsPerDay = &schedule.NewRunsPerDay
+		}
+		result.Modified = append(result.Modified, modified)
 	}
 
 	// Convert unchanged jobs (if requested)
@@ -302,47 +336,43 @@ type UnifiedRenderer struct{}
 
 // Render renders the diff in unified diff format
 func (r *UnifiedRenderer) Render(w io.Writer, diff *Diff, options *RenderOptions) error {
-	_ = options // Not used in unified format
+	var p *color.Painter
+	if options != nil {
+		p = options.Painter
+	}
 
-	_, _ = fmt.Fprintf(w, "--- old crontab\n")
-	_, _ = fmt.Fprintf(w, "+++ new crontab\n")
-	_, _ = fmt.Fprintf(w, "@@ -1 +1 @@\n")
+	_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Heading, "--- old crontab"))
+	_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Heading, "+++ new crontab"))
+	_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Info, "@@ -1 +1 @@"))
 
 	// Show removed jobs
 	for _, change := range diff.Removed {
-		_, _ = fmt.Fprintf(w, "-%s %s", change.OldJob.Expression, change.OldJob.Command)
-		if change.OldJob.Comment != "" {
-			_, _ = fmt.Fprintf(w, " # %s", change.OldJob.Comment)
-		}
-		_, _ = fmt.Fprintf(w, "\n")
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Removed, "-"+unifiedLine(change.OldJob)))
 	}
 
 	// Show added jobs
 	for _, change := range diff.Added {
-		_, _ = fmt.Fprintf(w, "+%s %s", change.NewJob.Expression, change.NewJob.Command)
-		if change.NewJob.Comment != "" {
-			_, _ = fmt.Fprintf(w, " # %s", change.NewJob.Comment)
-		}
-		_, _ = fmt.Fprintf(w, "\n")
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Added, "+"+unifiedLine(change.NewJob)))
 	}
 
 	// Show modified jobs (as remove + add)
 	for _, change := range diff.Modified {
-		_, _ = fmt.Fprintf(w, "-%s %s", change.OldJob.Expression, change.OldJob.Command)
-		if change.OldJob.Comment != "" {
-			_, _ = fmt.Fprintf(w, " # %s", change.OldJob.Comment)
-		}
-		_, _ = fmt.Fprintf(w, "\n")
-		_, _ = fmt.Fprintf(w, "+%s %s", change.NewJob.Expression, change.NewJob.Command)
-		if change.NewJob.Comment != "" {
-			_, _ = fmt.Fprintf(w, " # %s", change.NewJob.Comment)
-		}
-		_, _ = fmt.Fprintf(w, "\n")
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Removed, "-"+unifiedLine(change.OldJob)))
+		_, _ = fmt.Fprintf(w, "%s\n", p.Paint(color.Added, "+"+unifiedLine(change.NewJob)))
 	}
 
 	return nil
 }
 
+// unifiedLine formats a job as a line of a unified diff, without its sign
+func unifiedLine(job *crontab.Job) string {
+	line := job.Expression + " " + job.Command
+	if job.Comment != "" {
+		line += " # " + job.Comment
+	}
+	return line
+}
+
 // NewRenderer creates a renderer based on format name
 func NewRenderer(format string) (Renderer, error) {
 	switch format {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/hzerrad/cronkit/internal/color"
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -520,3 +521,27 @@ func TestJSONRenderer_EnvVarAllTypes(t *testing.T) {
 	assert.Contains(t, output, `"PATH"`)
 	assert.Contains(t, output, `"HOME"`)
 }
+
+func TestRenderers_Painter(t *testing.T) {
+	theme, err := color.LookupTheme(color.DefaultTheme)
+	require.NoError(t, err)
+	diff := &Diff{
+		Added:   []Change{{Type: ChangeTypeAdded, NewJob: &crontab.Job{Expression: "*/5 * * * *", Command: "/usr/bin/new.sh"}}},
+		Removed: []Change{{Type: ChangeTypeRemoved, OldJob: &crontab.Job{Expression: "0 1 * * *", Command: "/usr/bin/old.sh"}}},
+	}
+	options := &RenderOptions{Painter: color.NewPainter(theme)}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&TextRenderer{}).Render(&buf, diff, options))
+	assert.Contains(t, buf.String(), "\x1b[32m+ */5 * * * *  /usr/bin/new.sh\x1b[0m\n")
+	assert.Contains(t, buf.String(), "\x1b[31m- 0 1 * * *  /usr/bin/old.sh\x1b[0m\n")
+
+	buf.Reset()
+	require.NoError(t, (&UnifiedRenderer{}).Render(&buf, diff, options))
+	assert.Contains(t, buf.String(), "\x1b[32m+*/5 * * * * /usr/bin/new.sh\x1b[0m\n")
+	assert.Contains(t, buf.String(), "\x1b[31m-0 1 * * * /usr/bin/old.sh\x1b[0m\n")
+
+	buf.Reset()
+	require.NoError(t, (&UnifiedRenderer{}).Render(&buf, diff, &RenderOptions{}))
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
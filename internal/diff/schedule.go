@@ -0,0 +1,187 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// scheduleEpoch and scheduleYears bound the runs compared by
+// AnalyzeSchedules: 28 years repeat every combination of weekday, date and
+// leap year
+var scheduleEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const scheduleYears = 28
+
+// ScheduleComparison compares the runs of a job before and after its
+// expression changed
+type ScheduleComparison struct {
+	Equivalent    bool    // Both expressions produce the same runs
+	OldRunsPerDay float64 // Average runs per day of the old expression
+	NewRunsPerDay float64 // Average runs per day of the new expression
+}
+
+// FrequencyChanged reports whether the expressions run at different average
+// frequencies
+func (c *ScheduleComparison) FrequencyChanged() bool {
+	return c.OldRunsPerDay != c.NewRunsPerDay
+}
+
+// AnalyzeSchedules pairs each removed job with an added job running the same
+// command as the same user, turning them into a modified job whose
+// expression changed, and compares the runs of the two expressions. Jobs
+// whose expressions the scheduler cannot compute, such as @reboot, are
+// paired without a comparison.
+func AnalyzeSchedules(d *Diff, scheduler cronx.Scheduler) {
+	var removed []Change
+	for _, old := range d.Removed {
+		i := slices.IndexFunc(d.Added, func(c Change) bool {
+			return sameJobCommand(old.OldJob, c.NewJob)
+		})
+		if i < 0 {
+			removed = append(removed, old)
+			continue
+		}
+
+		added := d.Added[i]
+		d.Added = slices.Delete(d.Added, i, i+1)
+		change := Change{
+			Type:          ChangeTypeModified,
+			OldJob:        old.OldJob,
+			NewJob:        added.NewJob,
+			FieldsChanged: detectFieldChanges(old.OldJob, added.NewJob),
+		}
+		if old.OldJob.Valid && added.NewJob.Valid {
+			comparison, err := CompareSchedules(scheduler, old.OldJob.Expression, added.NewJob.Expression)
+			if err == nil {
+				change.Schedule = comparison
+			}
+		}
+		d.Modified = append(d.Modified, change)
+	}
+	d.Removed = removed
+}
+
+// NoOpScheduleChanges returns the number of modified jobs whose new
+// expression produces the same runs as the old one
+func (d *Diff) NoOpScheduleChanges() int {
+	count := 0
+	for _, change := range d.Modified {
+		if change.Schedule != nil && change.Schedule.Equivalent {
+			count++
+		}
+	}
+	return count
+}
+
+// sameJobCommand reports whether two jobs run the same command as the same user
+func sameJobCommand(a, b *crontab.Job) bool {
+	return a.User == b.User && strings.TrimSpace(a.Command) == strings.TrimSpace(b.Command)
+}
+
+// CompareSchedules compares the runs of two expressions in UTC. A schedule
+// runs at the same times of day on every day it runs, so the expressions
+// are equivalent when they run on the same days of a 28-year horizon and at
+// the same times on those days.
+func CompareSchedules(scheduler cronx.Scheduler, oldExpr, newExpr string) (*ScheduleComparison, error) {
+	oldDays, oldTimes, err := dailyRuns(scheduler, oldExpr)
+	if err != nil {
+		return nil, err
+	}
+	newDays, newTimes, err := dailyRuns(scheduler, newExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	horizon := scheduleEpoch.AddDate(scheduleYears, 0, 0).Sub(scheduleEpoch).Hours() / 24
+	return &ScheduleComparison{
+		Equivalent:    slices.Equal(oldDays, newDays) && slices.Equal(oldTimes, newTimes),
+		OldRunsPerDay: float64(len(oldDays)*len(oldTimes)) / horizon,
+		NewRunsPerDay: float64(len(newDays)*len(newTimes)) / horizon,
+	}, nil
+}
+
+// dailyRuns returns the days of the horizon on which expr runs, and the
+// times of day of its runs on the first of them
+func dailyRuns(scheduler cronx.Scheduler, expr string) (days []time.Time, times []time.Duration, err error) {
+	end := scheduleEpoch.AddDate(scheduleYears, 0, 0)
+	// Next is strict, and robfig/cron rounds up to the second
+	from := scheduleEpoch.Add(-time.Second)
+	for {
+		next, err := scheduler.Next(expr, from, 1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to calculate runs of %q: %w", expr, err)
+		}
+		run := next[0]
+		if run.IsZero() || !run.Before(end) {
+			return days, times, nil
+		}
+
+		day := time.Date(run.Year(), run.Month(), run.Day(), 0, 0, 0, 0, time.UTC)
+		days = append(days, day)
+		nextDay := day.AddDate(0, 0, 1)
+		if times == nil {
+			if times, err = timesOfDay(scheduler, expr, day, nextDay); err != nil {
+				return nil, nil, err
+			}
+		}
+		from = nextDay.Add(-time.Second)
+	}
+}
+
+// timesOfDay returns the offsets from day of the runs of expr before nextDay
+func timesOfDay(scheduler cronx.Scheduler, expr string, day, nextDay time.Time) ([]time.Duration, error) {
+	var times []time.Duration
+	from := day.Add(-time.Second)
+	for {
+		batch, err := scheduler.Next(expr, from, 1440)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate runs of %q: %w", expr, err)
+		}
+		for _, run := range batch {
+			if run.IsZero() || !run.Before(nextDay) {
+				return times, nil
+			}
+			times = append(times, run.Sub(day))
+			from = run
+		}
+	}
+}
+
+// FormatFrequency describes an average number of runs per day, in runs per
+// day, week, month or year, whichever reads best
+func FormatFrequency(runsPerDay float64) string {
+	units := []struct {
+		name string
+		days float64
+	}{
+		{"day", 1},
+		{"week", 7},
+		{"month", 365.25 / 12},
+		{"year", 365.25},
+	}
+	for i, unit := range units {
+		runs := math.Round(runsPerDay*unit.days*10) / 10
+		if runs >= 1 || i == len(units)-1 {
+			return formatRuns(runs) + "/" + unit.name
+		}
+	}
+	return ""
+}
+
+// formatRuns formats a run count, with one decimal when it is not whole
+func formatRuns(runs float64) string {
+	noun := "runs"
+	if runs == 1 {
+		noun = "run"
+	}
+	if runs == float64(int64(runs)) {
+		return fmt.Sprintf("%d %s", int64(runs), noun)
+	}
+	return fmt.Sprintf("%.1f %s", runs, noun)
+}
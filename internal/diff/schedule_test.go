@@ -0,0 +1,116 @@
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSchedules(t *testing.T) {
+	scheduler := cronx.NewScheduler()
+
+	tests := []struct {
+		name       string
+		old, new   string
+		equivalent bool
+		oldFreq    string
+		newFreq    string
+	}{
+		{name: "alias", old: "0 0 * * *", new: "@daily", equivalent: true, oldFreq: "1 run/day", newFreq: "1 run/day"},
+		{name: "step and list", old: "*/15 * * * *", new: "0,15,30,45 * * * *", equivalent: true, oldFreq: "96 runs/day", newFreq: "96 runs/day"},
+		{name: "day names", old: "0 9 * * MON-FRI", new: "0 9 * * 1,2,3,4,5", equivalent: true, oldFreq: "5 runs/week", newFreq: "5 runs/week"},
+		{name: "hourly to daily", old: "0 * * * *", new: "0 0 * * *", oldFreq: "24 runs/day", newFreq: "1 run/day"},
+		{name: "same frequency, other time", old: "0 2 * * *", new: "30 3 * * *", oldFreq: "1 run/day", newFreq: "1 run/day"},
+		{name: "day of month or weekday", old: "0 0 1-31 * 1", new: "0 0 * * 1", oldFreq: "1 run/day", newFreq: "1 run/week"},
+		{name: "monthly", old: "0 0 1 * *", new: "0 0 1 */3 *", oldFreq: "1 run/month", newFreq: "4 runs/year"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareSchedules(scheduler, tt.old, tt.new)
+			require.NoError(t, err)
+			assert.Equal(t, tt.equivalent, got.Equivalent)
+			assert.Equal(t, tt.oldFreq, FormatFrequency(got.OldRunsPerDay))
+			assert.Equal(t, tt.newFreq, FormatFrequency(got.NewRunsPerDay))
+		})
+	}
+
+	_, err := CompareSchedules(scheduler, "0 0 * * *", "@reboot")
+	assert.Error(t, err)
+}
+
+func TestFormatFrequency(t *testing.T) {
+	assert.Equal(t, "1440 runs/day", FormatFrequency(1440))
+	assert.Equal(t, "1.5 runs/day", FormatFrequency(1.5))
+	assert.Equal(t, "2 runs/week", FormatFrequency(2.0/7))
+	assert.Equal(t, "0 runs/year", FormatFrequency(0))
+}
+
+func TestAnalyzeSchedules(t *testing.T) {
+	parse := func(content string) []*crontab.Entry {
+		entries, err := crontab.ParseReader(strings.NewReader(content))
+		require.NoError(t, err)
+		return entries
+	}
+	oldEntries := parse("0 * * * * /usr/bin/poll.sh\n0 0 * * * /usr/bin/backup.sh\n0 5 * * * /usr/bin/gone.sh\n@reboot /usr/bin/start.sh\n")
+	newEntries := parse("0 0 * * * /usr/bin/poll.sh\n@daily /usr/bin/backup.sh\n0 6 * * * /usr/bin/new.sh\n@reboot /usr/bin/start.sh --fast\n0 1 * * * /usr/bin/start.sh\n")
+
+	d := CompareCrontabs(oldEntries, newEntries)
+	require.Len(t, d.Modified, 0)
+
+	AnalyzeSchedules(d, cronx.NewScheduler())
+	require.Len(t, d.Removed, 1)
+	require.Len(t, d.Added, 2)
+	require.Len(t, d.Modified, 3)
+	assert.Equal(t, 1, d.NoOpScheduleChanges())
+
+	for _, change := range d.Modified {
+		assert.Equal(t, []string{"expression"}, change.FieldsChanged)
+		switch change.NewJob.Command {
+		case "/usr/bin/start.sh":
+			assert.Nil(t, change.Schedule, "@reboot has no runs to compare")
+		case "/usr/bin/poll.sh":
+			assert.False(t, change.Schedule.Equivalent)
+			assert.Equal(t, 24.0, change.Schedule.OldRunsPerDay)
+			assert.Equal(t, 1.0, change.Schedule.NewRunsPerDay)
+		case "/usr/bin/backup.sh":
+			assert.True(t, change.Schedule.Equivalent)
+		default:
+			t.Errorf("unexpected modified job %q", change.NewJob.Command)
+		}
+	}
+
+	t.Run("rendered as text and JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&TextRenderer{}).Render(&buf, d, nil))
+		output := buf.String()
+		assert.Contains(t, output, "Frequency: was 24 runs/day, now 1 run/day")
+		assert.Contains(t, output, "Same schedule: no-op change")
+		assert.Contains(t, output, "3 modified (1 with the same schedule)")
+
+		buf.Reset()
+		require.NoError(t, (&JSONRenderer{}).Render(&buf, d, nil))
+		output = buf.String()
+		assert.Contains(t, output, `"sameSchedule": true`)
+		assert.Contains(t, output, `"oldRunsPerDay": 24`)
+		assert.Contains(t, output, `"noOp": 1`)
+	})
+
+	t.Run("user must match", func(t *testing.T) {
+		old, err := crontab.ParseReaderLayout(strings.NewReader("0 0 * * * root /usr/bin/backup.sh\n"), crontab.LayoutSystem)
+		require.NoError(t, err)
+		updated, err := crontab.ParseReaderLayout(strings.NewReader("0 1 * * * app /usr/bin/backup.sh\n"), crontab.LayoutSystem)
+		require.NoError(t, err)
+
+		d := CompareCrontabs(old, updated)
+		AnalyzeSchedules(d, cronx.NewScheduler())
+		assert.Len(t, d.Removed, 1)
+		assert.Len(t, d.Added, 1)
+		assert.Empty(t, d.Modified)
+	})
+}
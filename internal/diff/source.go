@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// SourceKind identifies where a compared crontab is read from
+type SourceKind string
+
+const (
+	// SourceFile is a crontab file
+	SourceFile SourceKind = "file"
+	// SourceStdin is a crontab read from standard input
+	SourceStdin SourceKind = "stdin"
+	// SourceLive is the installed crontab of the current user
+	SourceLive SourceKind = "live"
+	// SourceGit is a file at a git revision
+	SourceGit SourceKind = "git"
+)
+
+// Source is a crontab to compare
+type Source struct {
+	Kind SourceKind
+	Path string // File path, for SourceFile and SourceGit
+	Rev  string // Git revision, for SourceGit
+}
+
+// ParseSource parses a source specification:
+//
+//	-, stdin             standard input
+//	live                 the installed user crontab (crontab -l)
+//	git:<rev>:<path>     <path> at git revision <rev>, as read by git show
+//	file:<path>, <path>  a crontab file
+func ParseSource(spec string) (Source, error) {
+	switch {
+	case spec == "":
+		return Source{}, fmt.Errorf("empty crontab source")
+	case spec == "-" || spec == string(SourceStdin):
+		return Source{Kind: SourceStdin}, nil
+	case spec == string(SourceLive):
+		return Source{Kind: SourceLive}, nil
+	case strings.HasPrefix(spec, "git:"):
+		rev, path, ok := strings.Cut(strings.TrimPrefix(spec, "git:"), ":")
+		if !ok || rev == "" || path == "" {
+			return Source{}, fmt.Errorf("invalid git source %q (use git:<revision>:<path>, e.g. git:HEAD~1:crontab)", spec)
+		}
+		return Source{Kind: SourceGit, Rev: rev, Path: path}, nil
+	case strings.HasPrefix(spec, "file:"):
+		return Source{Kind: SourceFile, Path: strings.TrimPrefix(spec, "file:")}, nil
+	default:
+		return Source{Kind: SourceFile, Path: spec}, nil
+	}
+}
+
+// String returns the source in the form ParseSource reads
+func (s Source) String() string {
+	switch s.Kind {
+	case SourceGit:
+		return "git:" + s.Rev + ":" + s.Path
+	case SourceFile:
+		return s.Path
+	default:
+		return string(s.Kind)
+	}
+}
+
+// Entries reads and parses the crontab of the source. With
+// crontab.LayoutAuto, files and git files are read in the layout their path
+// implies, and standard input and the live crontab as user crontabs.
+func (s Source) Entries(stdin io.Reader, layout crontab.Layout) ([]*crontab.Entry, error) {
+	if layout == crontab.LayoutAuto {
+		layout = crontab.LayoutUser
+		if s.Path != "" {
+			layout = crontab.DetectLayout(s.Path)
+		}
+	}
+
+	var r io.Reader
+	switch s.Kind {
+	case SourceFile:
+		f, err := os.Open(s.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	case SourceStdin:
+		r = stdin
+	case SourceLive:
+		content, err := crontab.ReadUserRaw()
+		if err != nil {
+			return nil, err
+		}
+		r = strings.NewReader(content)
+	case SourceGit:
+		content, err := gitShow(s.Rev, s.Path)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(content)
+	default:
+		return nil, fmt.Errorf("unknown crontab source kind %q", s.Kind)
+	}
+
+	entries, err := crontab.ParseReaderLayout(r, layout)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", s, err)
+	}
+	return entries, nil
+}
+
+// gitShow returns the contents of path at revision rev of the git
+// repository of the working directory. Like git show, paths are relative to
+// the repository root unless they start with ./ or ../.
+func gitShow(rev, path string) ([]byte, error) {
+	cmd := exec.Command("git", "show", rev+":"+path)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to read %s at %s: %s", path, rev, msg)
+		}
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+	return output, nil
+}
@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Source
+		wantErr bool
+	}{
+		{spec: "-", want: Source{Kind: SourceStdin}},
+		{spec: "stdin", want: Source{Kind: SourceStdin}},
+		{spec: "live", want: Source{Kind: SourceLive}},
+		{spec: "git:HEAD~1:crontab", want: Source{Kind: SourceGit, Rev: "HEAD~1", Path: "crontab"}},
+		{spec: "git:main:./ops/jobs.cron", want: Source{Kind: SourceGit, Rev: "main", Path: "./ops/jobs.cron"}},
+		{spec: "file:live", want: Source{Kind: SourceFile, Path: "live"}},
+		{spec: "/etc/crontab", want: Source{Kind: SourceFile, Path: "/etc/crontab"}},
+		{spec: "", wantErr: true},
+		{spec: "git:HEAD", wantErr: true},
+		{spec: "git::crontab", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseSource(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, "git:HEAD~1:crontab", Source{Kind: SourceGit, Rev: "HEAD~1", Path: "crontab"}.String())
+	assert.Equal(t, "live", Source{Kind: SourceLive}.String())
+	assert.Equal(t, "jobs.cron", Source{Kind: SourceFile, Path: "jobs.cron"}.String())
+}
+
+func TestSourceEntries(t *testing.T) {
+	jobs := func(entries []*crontab.Entry) []string {
+		var result []string
+		for _, entry := range entries {
+			if entry.Job != nil {
+				result = append(result, entry.Job.Expression+" "+entry.Job.Command)
+			}
+		}
+		return result
+	}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jobs.cron")
+		require.NoError(t, os.WriteFile(path, []byte("0 2 * * * /usr/bin/backup.sh\n"), 0o644))
+
+		entries, err := Source{Kind: SourceFile, Path: path}.Entries(nil, crontab.LayoutAuto)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0 2 * * * /usr/bin/backup.sh"}, jobs(entries))
+	})
+
+	t.Run("system layout detected by path", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "cron.d")
+		require.NoError(t, os.Mkdir(dir, 0o755))
+		path := filepath.Join(dir, "app")
+		require.NoError(t, os.WriteFile(path, []byte("0 2 * * * root /usr/bin/backup.sh\n"), 0o644))
+
+		entries, err := Source{Kind: SourceFile, Path: path}.Entries(nil, crontab.LayoutAuto)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "root", entries[0].Job.User)
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		entries, err := Source{Kind: SourceStdin}.Entries(strings.NewReader("*/5 * * * * /usr/bin/poll\n"), crontab.LayoutAuto)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"*/5 * * * * /usr/bin/poll"}, jobs(entries))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Source{Kind: SourceFile, Path: filepath.Join(t.TempDir(), "missing")}.Entries(nil, crontab.LayoutAuto)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open file")
+	})
+
+	t.Run("git revision", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not installed")
+		}
+		repo := t.TempDir()
+		git := func(args ...string) {
+			cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+			cmd.Dir = repo
+			output, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(output))
+		}
+		git("init", "-q")
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "crontab"), []byte("0 2 * * * /usr/bin/backup.sh\n"), 0o644))
+		git("add", "crontab")
+		git("commit", "-q", "-m", "first")
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "crontab"), []byte("0 3 * * * /usr/bin/backup.sh\n"), 0o644))
+		git("commit", "-q", "-am", "second")
+		t.Chdir(repo)
+
+		entries, err := Source{Kind: SourceGit, Rev: "HEAD~1", Path: "crontab"}.Entries(nil, crontab.LayoutAuto)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0 2 * * * /usr/bin/backup.sh"}, jobs(entries))
+
+		_, err = Source{Kind: SourceGit, Rev: "HEAD", Path: "missing"}.Entries(nil, crontab.LayoutAuto)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read missing at HEAD")
+	})
+}
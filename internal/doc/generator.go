@@ -2,17 +2,21 @@ package doc
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hzerrad/cronkit/internal/check"
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
 	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/hzerrad/cronkit/internal/stats"
 )
 
 // Generator generates documentation from crontab entries
 type Generator struct {
 	parser    cronx.Parser
 	scheduler cronx.Scheduler
+	validator *check.Validator
 	locale    string
 }
 
@@ -21,6 +25,7 @@ func NewGenerator(locale string) *Generator {
 	return &Generator{
 		parser:    cronx.NewParserWithLocale(locale),
 		scheduler: cronx.NewScheduler(),
+		validator: newWarningValidator(locale),
 		locale:    locale,
 	}
 }
@@ -28,21 +33,47 @@ func NewGenerator(locale string) *Generator {
 // Document represents a complete documentation structure
 type Document struct {
 	Title       string
+	Logo        string `json:",omitempty"` // Image URL or path shown above the title, for branding
 	GeneratedAt time.Time
 	Source      string
 	Jobs        []JobDocument
 	Metadata    Metadata
+	Warnings    []Warning              `json:",omitempty"` // Issues not tied to a single job (e.g., overlaps)
+	FieldStats  *stats.FieldStats      `json:",omitempty"` // Field value distribution across jobs (with stats)
+	Skipped     []crontab.SkippedLine  `json:",omitempty"` // Invalid lines left out of Jobs (with SkipInvalid)
+	Sections    []Section              `json:",omitempty"` // One per source, when the document covers several
+	Timeline    map[string]interface{} `json:",omitempty"` // Runs of the next TimelineDays days, in the timeline command's JSON model (with IncludeTimeline)
+}
+
+// Section describes one of the sources of a document covering several
+// crontabs. Its jobs are the document's jobs with that Source.
+type Section struct {
+	Source   string
+	Metadata Metadata
+	Skipped  []crontab.SkippedLine `json:",omitempty"` // Invalid lines left out of Jobs (with SkipInvalid)
+}
+
+// Source is a crontab to document together with others
+type Source struct {
+	Name    string // Path or description of the crontab (e.g. "user crontab")
+	Entries []*crontab.Entry
 }
 
 // JobDocument represents documentation for a single job
 type JobDocument struct {
 	LineNumber  int
+	Source      string `json:",omitempty"` // Source of the job, when the document covers several
 	Expression  string
 	Description string
+	User        string `json:",omitempty"` // User the job runs as, in system crontabs
+	Timezone    string `json:",omitempty"` // Time zone the job is scheduled in, set by CRON_TZ=, TZ= or a tz directive
 	Command     string
+	Resolved    string `json:",omitempty"` // Command with ~ and variables resolved, when it differs (with Env)
 	Comment     string
+	Metadata    *crontab.Metadata `json:",omitempty"` // Declared by "cronkit:" directives, when any
+	Trigger     string            `json:",omitempty"` // What runs a job modeled from anacron or a run-parts directory
 	NextRuns    []time.Time
-	Warnings    []string
+	Warnings    []Warning
 	Stats       *JobStats
 }
 
@@ -73,6 +104,23 @@ func (g *Generator) GenerateDocument(entries []*crontab.Entry, source string, op
 		},
 	}
 
+	// Run the check engine once over all entries
+	var warningsByLine map[lineKey][]Warning
+	if options.IncludeWarnings {
+		warningsByLine, doc.Warnings = g.collectWarnings(entries)
+	}
+
+	if options.SkipInvalid {
+		_, doc.Skipped = crontab.Partition(entries)
+	}
+
+	if options.IncludeStats {
+		fieldStats := stats.NewCalculator().CalculateFieldStats(jobsFromEntries(entries))
+		doc.FieldStats = &fieldStats
+	}
+
+	descriptions := make(map[*crontab.Job]string)
+
 	// Process each entry
 	for _, entry := range entries {
 		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
@@ -83,13 +131,34 @@ func (g *Generator) GenerateDocument(entries []*crontab.Entry, source string, op
 
 		jobDoc := JobDocument{
 			LineNumber: entry.Job.LineNumber,
+			Source:     entry.Job.Source,
 			Expression: entry.Job.Expression,
+			User:       entry.Job.User,
+			Timezone:   entry.Job.Timezone,
 			Command:    entry.Job.Command,
 			Comment:    entry.Job.Comment,
+			Trigger:    entry.Job.Trigger,
+		}
+		if !entry.Job.Metadata.IsZero() {
+			meta := entry.Job.Metadata
+			jobDoc.Metadata = &meta
+		}
+
+		if options.Env != nil {
+			if resolved := entry.Job.ExpandedCommand(options.Env); resolved != entry.Job.Command {
+				jobDoc.Resolved = resolved
+			}
+		}
+
+		if options.IncludeWarnings {
+			jobDoc.Warnings = warningsByLine[lineKey{source: entry.Job.Source, line: entry.Job.LineNumber}]
 		}
 
 		if !entry.Job.Valid {
 			doc.Metadata.InvalidJobs++
+			if options.SkipInvalid {
+				continue
+			}
 			jobDoc.Description = fmt.Sprintf("Invalid expression: %s", entry.Job.Error)
 			doc.Jobs = append(doc.Jobs, jobDoc)
 			continue
@@ -100,24 +169,23 @@ func (g *Generator) GenerateDocument(entries []*crontab.Entry, source string, op
 		// Generate human-readable description
 		schedule, err := g.parser.Parse(entry.Job.Expression)
 		if err == nil {
-			humanizer := human.NewHumanizer()
+			humanizer := human.NewHumanizerForLocale(g.locale)
 			jobDoc.Description = humanizer.Humanize(schedule)
+			descriptions[entry.Job] = jobDoc.Description
 		}
 
-		// Get next runs if requested
+		// Get next runs if requested, in the job's time zone
 		if options.IncludeNext > 0 {
-			times, err := g.scheduler.Next(entry.Job.Expression, time.Now(), options.IncludeNext)
+			now := time.Now()
+			if loc, err := entry.Job.Location(time.Local); err == nil {
+				now = now.In(loc)
+			}
+			times, err := g.scheduler.Next(entry.Job.Expression, now, options.IncludeNext)
 			if err == nil {
 				jobDoc.NextRuns = times
 			}
 		}
 
-		// Get warnings if requested
-		if options.IncludeWarnings {
-			// This would require validator integration - simplified for now
-			jobDoc.Warnings = []string{}
-		}
-
 		// Get stats if requested
 		if options.IncludeStats {
 			stats := g.calculateJobStats(entry.Job.Expression)
@@ -127,6 +195,56 @@ func (g *Generator) GenerateDocument(entries []*crontab.Entry, source string, op
 		doc.Jobs = append(doc.Jobs, jobDoc)
 	}
 
+	if options.IncludeTimeline {
+		doc.Timeline = g.buildTimeline(jobsFromEntries(entries), descriptions, doc.GeneratedAt)
+	}
+
+	return doc, nil
+}
+
+// GenerateSources generates one document from several crontabs, such as
+// /etc/crontab, the files of /etc/cron.d and user crontabs, with a section
+// per source. Jobs record their source in Job.Source, so the check engine
+// reports overlaps between jobs of different sources.
+func (g *Generator) GenerateSources(sources []Source, options GenerateOptions) (*Document, error) {
+	if len(sources) == 1 {
+		return g.GenerateDocument(sources[0].Entries, sources[0].Name, options)
+	}
+
+	names := make([]string, 0, len(sources))
+	var entries []*crontab.Entry
+	for _, source := range sources {
+		names = append(names, source.Name)
+		for _, entry := range source.Entries {
+			if entry.Job != nil {
+				entry.Job.Source = source.Name
+			}
+		}
+		entries = append(entries, source.Entries...)
+	}
+
+	doc, err := g.GenerateDocument(entries, strings.Join(names, ", "), options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Line numbers only identify skipped lines within their source
+	doc.Skipped = nil
+	for _, source := range sources {
+		section := Section{Source: source.Name}
+		for _, job := range jobsFromEntries(source.Entries) {
+			section.Metadata.TotalJobs++
+			if job.Valid {
+				section.Metadata.ValidJobs++
+			} else {
+				section.Metadata.InvalidJobs++
+			}
+		}
+		if options.SkipInvalid {
+			_, section.Skipped = crontab.Partition(source.Entries)
+		}
+		doc.Sections = append(doc.Sections, section)
+	}
 	return doc, nil
 }
 
@@ -174,9 +292,23 @@ func (g *Generator) calculateJobStats(expression string) *JobStats {
 	}
 }
 
+// jobsFromEntries returns the jobs among crontab entries
+func jobsFromEntries(entries []*crontab.Entry) []*crontab.Job {
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	return jobs
+}
+
 // GenerateOptions contains options for document generation
 type GenerateOptions struct {
-	IncludeNext     int  // Number of next runs to include (0 = disabled)
-	IncludeWarnings bool // Include validation warnings
-	IncludeStats    bool // Include frequency statistics
+	IncludeNext     int               // Number of next runs to include (0 = disabled)
+	IncludeWarnings bool              // Include check engine issues with severity badges
+	IncludeStats    bool              // Include frequency statistics
+	SkipInvalid     bool              // List invalid lines in Skipped instead of Jobs
+	IncludeTimeline bool              // Include the runs of the next TimelineDays days, as HTML documents draw them
+	Env             map[string]string // Base environment to resolve commands in (nil = disabled)
 }
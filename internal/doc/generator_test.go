@@ -1,8 +1,11 @@
 package doc
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/hzerrad/cronkit/internal/check"
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,6 +72,140 @@ func TestGenerateDocument(t *testing.T) {
 		assert.Equal(t, 1, doc.Metadata.InvalidJobs)
 	})
 
+	t.Run("should list invalid lines as skipped with SkipInvalid", func(t *testing.T) {
+		entries := []*crontab.Entry{
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 1,
+				Job: &crontab.Job{
+					LineNumber: 1,
+					Expression: "0 * * * *",
+					Command:    "/usr/bin/backup.sh",
+					Valid:      true,
+				},
+			},
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 2,
+				Job: &crontab.Job{
+					LineNumber: 2,
+					Expression: "61 * * * *",
+					Command:    "/usr/bin/broken.sh",
+					Valid:      false,
+					Error:      "parse error",
+				},
+			},
+			{Type: crontab.EntryTypeInvalid, LineNumber: 3, Raw: "garbage"},
+		}
+
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{SkipInvalid: true})
+		require.NoError(t, err)
+		require.Len(t, doc.Jobs, 1)
+		assert.Equal(t, 1, doc.Jobs[0].LineNumber)
+		assert.Equal(t, 1, doc.Metadata.InvalidJobs)
+		require.Len(t, doc.Skipped, 2)
+		assert.Equal(t, 2, doc.Skipped[0].LineNumber)
+		assert.Equal(t, "garbage", doc.Skipped[1].Line)
+
+		var md bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &md))
+		assert.Contains(t, md.String(), "## Skipped Lines")
+		assert.Contains(t, md.String(), "Line 2: `61 * * * *`")
+
+		var html bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &html))
+		assert.Contains(t, html.String(), "<h2>Skipped Lines</h2>")
+	})
+
+	t.Run("should generate a section per source", func(t *testing.T) {
+		system, err := crontab.ParseReader(strings.NewReader("0 2 * * * /usr/bin/backup.sh\n"))
+		require.NoError(t, err)
+		drop, err := crontab.ParseReader(strings.NewReader("garbage\n0 2 * * * /usr/bin/report.sh\n"))
+		require.NoError(t, err)
+
+		doc, err := gen.GenerateSources([]Source{
+			{Name: "/etc/crontab", Entries: system},
+			{Name: "/etc/cron.d/report", Entries: drop},
+		}, GenerateOptions{IncludeWarnings: true, SkipInvalid: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/etc/crontab, /etc/cron.d/report", doc.Source)
+		require.Len(t, doc.Jobs, 2)
+		assert.Equal(t, "/etc/crontab", doc.Jobs[0].Source)
+		assert.Equal(t, "/etc/cron.d/report", doc.Jobs[1].Source)
+		require.Len(t, doc.Sections, 2)
+		assert.Equal(t, 1, doc.Sections[1].Metadata.TotalJobs)
+		require.Len(t, doc.Sections[1].Skipped, 1)
+		assert.Empty(t, doc.Skipped)
+
+		// Jobs of different sources overlap
+		var codes []string
+		for _, w := range doc.Warnings {
+			codes = append(codes, w.Code)
+		}
+		assert.Contains(t, codes, "CRON-012")
+	})
+
+	t.Run("should include directive metadata", func(t *testing.T) {
+		entries, err := crontab.ParseReader(strings.NewReader("# cronkit:name=backup owner=infra duration=1h30m tags=db\n0 2 * * * /usr/bin/backup.sh\n0 3 * * * /usr/bin/true\n"))
+		require.NoError(t, err)
+
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{})
+		require.NoError(t, err)
+		require.Len(t, doc.Jobs, 2)
+		require.NotNil(t, doc.Jobs[0].Metadata)
+		assert.Equal(t, "infra", doc.Jobs[0].Metadata.Owner)
+		assert.Nil(t, doc.Jobs[1].Metadata)
+
+		var md bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &md))
+		assert.Contains(t, md.String(), "**Metadata:**\n\n- Name: backup\n- Owner: infra\n- Duration: 1h30m\n- Tags: db\n")
+
+		var html bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &html))
+		assert.Contains(t, html.String(), "<li>Owner: infra</li>")
+	})
+
+	t.Run("should resolve commands with Env", func(t *testing.T) {
+		entries := []*crontab.Entry{
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 1,
+				Job: &crontab.Job{
+					LineNumber: 1,
+					Expression: "0 * * * *",
+					Command:    "~/bin/backup.sh",
+					Valid:      true,
+				},
+			},
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 2,
+				Job: &crontab.Job{
+					LineNumber: 2,
+					Expression: "0 1 * * *",
+					Command:    "/usr/bin/true",
+					Valid:      true,
+				},
+			},
+		}
+
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{Env: map[string]string{"HOME": "/home/alice"}})
+		require.NoError(t, err)
+		require.Len(t, doc.Jobs, 2)
+		assert.Equal(t, "/home/alice/bin/backup.sh", doc.Jobs[0].Resolved)
+		assert.Empty(t, doc.Jobs[1].Resolved)
+
+		var md bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &md))
+		assert.Contains(t, md.String(), "**Resolved Command:**\n```bash\n/home/alice/bin/backup.sh\n```")
+		assert.Equal(t, 1, strings.Count(md.String(), "Resolved Command"))
+
+		doc, err = gen.GenerateDocument(entries, "test.cron", GenerateOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, doc.Jobs[0].Resolved)
+	})
+
 	t.Run("should include next runs when requested", func(t *testing.T) {
 		entries := []*crontab.Entry{
 			{
@@ -92,6 +229,33 @@ func TestGenerateDocument(t *testing.T) {
 		assert.Greater(t, len(doc.Jobs[0].NextRuns), 0)
 	})
 
+	t.Run("should compute next runs in the job's time zone", func(t *testing.T) {
+		entries := []*crontab.Entry{
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 2,
+				Job: &crontab.Job{
+					LineNumber: 2,
+					Expression: "0 9 * * *",
+					Command:    "/usr/bin/report.sh",
+					Timezone:   "Asia/Tokyo",
+					Valid:      true,
+				},
+			},
+		}
+
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{IncludeNext: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Tokyo", doc.Jobs[0].Timezone)
+		require.Len(t, doc.Jobs[0].NextRuns, 2)
+		assert.Equal(t, "Asia/Tokyo", doc.Jobs[0].NextRuns[0].Location().String())
+		assert.Equal(t, 9, doc.Jobs[0].NextRuns[0].Hour())
+
+		var md bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &md))
+		assert.Contains(t, md.String(), "**Timezone:** Asia/Tokyo")
+	})
+
 	t.Run("should include stats when requested", func(t *testing.T) {
 		entries := []*crontab.Entry{
 			{
@@ -114,6 +278,46 @@ func TestGenerateDocument(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, doc.Jobs[0].Stats)
 		assert.Greater(t, doc.Jobs[0].Stats.RunsPerDay, 0)
+		require.NotNil(t, doc.FieldStats)
+		assert.Equal(t, 1, doc.FieldStats.Jobs)
+		assert.Equal(t, "100% of jobs run at minute 0", doc.FieldStats.Minute.Summary())
+	})
+
+	t.Run("should include a week of runs with IncludeTimeline", func(t *testing.T) {
+		entries := []*crontab.Entry{
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 1,
+				Job: &crontab.Job{
+					LineNumber: 1,
+					Expression: "0 2 * * *",
+					Command:    "/usr/bin/backup.sh",
+					Valid:      true,
+				},
+			},
+			{
+				Type:       crontab.EntryTypeJob,
+				LineNumber: 2,
+				Job: &crontab.Job{
+					LineNumber: 2,
+					Expression: "invalid",
+					Command:    "/usr/bin/broken.sh",
+				},
+			},
+		}
+
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{IncludeTimeline: true})
+		require.NoError(t, err)
+		require.NotNil(t, doc.Timeline)
+		jobs := doc.Timeline["jobs"].([]map[string]interface{})
+		require.Len(t, jobs, 1)
+		assert.Equal(t, "job-1", jobs[0]["id"])
+		assert.Equal(t, "At 02:00 every day", jobs[0]["description"])
+		assert.Len(t, jobs[0]["runs"], TimelineDays)
+
+		doc, err = gen.GenerateDocument(entries, "test.cron", GenerateOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, doc.Timeline)
 	})
 }
 
@@ -242,4 +446,71 @@ func TestCalculateJobStats(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "Hourly backup", doc.Jobs[0].Comment)
 	})
+
+	t.Run("system crontab jobs keep their user", func(t *testing.T) {
+		gen := NewGenerator("en")
+		entries := []*crontab.Entry{
+			crontab.ParseSystemLine("0 2 * * * root /usr/bin/backup.sh", 1),
+			crontab.ParseSystemLine("0 3 * * * www-data /usr/bin/rotate.sh", 2),
+		}
+
+		doc, err := gen.GenerateDocument(entries, "/etc/crontab", GenerateOptions{})
+		require.NoError(t, err)
+		require.Len(t, doc.Jobs, 2)
+		assert.Equal(t, "root", doc.Jobs[0].User)
+		assert.Equal(t, "/usr/bin/backup.sh", doc.Jobs[0].Command)
+
+		var md, html bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &md))
+		assert.Contains(t, md.String(), "| Line | Expression | Description | User | Command |")
+		assert.Contains(t, md.String(), "**User:** www-data")
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &html))
+		assert.Contains(t, html.String(), "<th>User</th>")
+		assert.Contains(t, html.String(), "<td>www-data</td>")
+	})
+}
+
+func TestGenerateDocument_IncludeWarnings(t *testing.T) {
+	gen := NewGenerator("en")
+	entries := []*crontab.Entry{
+		crontab.ParseLine("0 0 1 * 1 /usr/bin/report.sh > /dev/null 2>&1", 1),
+		crontab.ParseLine("*/1 * * * * backup", 2),
+		crontab.ParseLine("0 0 * * * /usr/bin/clean.sh > /dev/null 2>&1", 3),
+	}
+
+	t.Run("warnings come from the full check engine", func(t *testing.T) {
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{IncludeWarnings: true})
+		require.NoError(t, err)
+		require.Len(t, doc.Jobs, 3)
+
+		codes := func(warnings []Warning) []string {
+			var result []string
+			for _, w := range warnings {
+				result = append(result, w.Code)
+			}
+			return result
+		}
+
+		assert.Equal(t, []string{check.CodeDOMDOWConflict}, codes(doc.Jobs[0].Warnings))
+		// Frequency and hygiene rules both apply to line 2
+		assert.Contains(t, codes(doc.Jobs[1].Warnings), check.CodeRedundantPattern)
+		assert.Contains(t, codes(doc.Jobs[1].Warnings), check.CodeMissingAbsolutePath)
+		assert.Empty(t, doc.Jobs[2].Warnings)
+
+		// Every-minute job overlaps the others: reported at document level
+		assert.Contains(t, codes(doc.Warnings), check.CodeOverlapDetected)
+
+		for _, w := range doc.Jobs[0].Warnings {
+			assert.NotEmpty(t, w.Hint)
+		}
+	})
+
+	t.Run("no warnings unless requested", func(t *testing.T) {
+		doc, err := gen.GenerateDocument(entries, "test.cron", GenerateOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, doc.Warnings)
+		for _, job := range doc.Jobs {
+			assert.Nil(t, job.Warnings)
+		}
+	})
 }
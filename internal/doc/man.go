@@ -0,0 +1,157 @@
+package doc
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ManSection is the manual section of generated man pages: file formats and
+// configuration files, where crontab(5) lives
+const ManSection = "5"
+
+// manText escapes the characters roff interprets in running text
+var manText = strings.NewReplacer(`\`, `\e`, "-", `\-`, "\n", " ")
+
+// manName matches the characters kept in a page name
+var manName = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// ManRenderer renders documents as a roff man page for section 5, to be
+// installed on servers and read with "man 5 <name>"
+type ManRenderer struct{}
+
+// Render renders a document as a man page named after its source file
+// ("/etc/cron.d/backup" gives backup(5)), or "crontab" when the document
+// covers several sources
+func (r *ManRenderer) Render(doc *Document, w io.Writer) error {
+	name := manPageName(doc)
+	_, _ = fmt.Fprintf(w, ".TH %s %s %s cronkit %s\n",
+		manQuote(strings.ToUpper(name)), ManSection, doc.GeneratedAt.Format("2006-01-02"), manQuote(doc.Title))
+	_, _ = fmt.Fprintf(w, ".SH NAME\n%s \\- %s\n", manEscape(name), manEscape(doc.Title))
+
+	_, _ = fmt.Fprintf(w, ".SH DESCRIPTION\n")
+	_, _ = fmt.Fprintf(w, "Scheduled jobs of \\fB%s\\fR: %d jobs (%d valid, %d invalid).\n",
+		manEscape(doc.Source), doc.Metadata.TotalJobs, doc.Metadata.ValidJobs, doc.Metadata.InvalidJobs)
+	_, _ = fmt.Fprintf(w, "Generated %s.\n", doc.GeneratedAt.Format(time.RFC3339))
+	for _, section := range doc.Sections {
+		_, _ = fmt.Fprintf(w, ".IP \\(bu 2\n\\fB%s\\fR: %d jobs (%d valid, %d invalid)\n",
+			manEscape(section.Source), section.Metadata.TotalJobs, section.Metadata.ValidJobs, section.Metadata.InvalidJobs)
+	}
+
+	_, _ = fmt.Fprintf(w, ".SH JOBS\n")
+	for _, job := range doc.Jobs {
+		_, _ = fmt.Fprintf(w, ".SS %s\n", manEscape(lineLocation(job.Source, job.LineNumber)))
+		_, _ = fmt.Fprintf(w, ".TP\n.B Schedule\n\\f(CW%s\\fR \\- %s\n", manEscape(job.Expression), manEscape(job.Description))
+		if job.User != "" {
+			_, _ = fmt.Fprintf(w, ".TP\n.B User\n%s\n", manEscape(job.User))
+		}
+		if job.Timezone != "" {
+			_, _ = fmt.Fprintf(w, ".TP\n.B Timezone\n%s\n", manEscape(job.Timezone))
+		}
+		if job.Trigger != "" {
+			_, _ = fmt.Fprintf(w, ".TP\n.B Runs Via\n%s\n", manEscape(job.Trigger))
+		}
+		_, _ = fmt.Fprintf(w, ".TP\n.B Command\n.nf\n%s\n.fi\n", manLiteral(job.Command))
+		if job.Resolved != "" {
+			_, _ = fmt.Fprintf(w, ".TP\n.B Resolved Command\n.nf\n%s\n.fi\n", manLiteral(job.Resolved))
+		}
+		if job.Comment != "" {
+			_, _ = fmt.Fprintf(w, ".TP\n.B Comment\n%s\n", manEscape(job.Comment))
+		}
+		if job.Metadata != nil {
+			for _, row := range metadataRows(job.Metadata) {
+				_, _ = fmt.Fprintf(w, ".TP\n.B %s\n%s\n", manEscape(row[0]), manEscape(row[1]))
+			}
+		}
+		if len(job.NextRuns) > 0 {
+			_, _ = fmt.Fprintf(w, ".TP\n.B Next Runs\n")
+			for i, t := range job.NextRuns {
+				if i >= 10 {
+					break
+				}
+				_, _ = fmt.Fprintf(w, "%s\n.br\n", manEscape(t.Format(time.RFC3339)))
+			}
+		}
+		if job.Stats != nil {
+			_, _ = fmt.Fprintf(w, ".TP\n.B Statistics\n%d runs per day, %d per hour\n", job.Stats.RunsPerDay, job.Stats.RunsPerHour)
+		}
+		renderManWarnings(w, job.Warnings)
+	}
+
+	if len(doc.Warnings) > 0 {
+		_, _ = fmt.Fprintf(w, ".SH WARNINGS\n")
+		renderManWarnings(w, doc.Warnings)
+	}
+
+	if skipped := skippedLines(doc); len(skipped) > 0 {
+		_, _ = fmt.Fprintf(w, ".SH SKIPPED LINES\n")
+		for _, line := range skipped {
+			_, _ = fmt.Fprintf(w, ".IP \\(bu 2\n%s: \\f(CW%s\\fR (%s)\n",
+				manEscape(lineLocation(line.Source, line.LineNumber)), manEscape(line.Line), manEscape(line.Reason))
+		}
+	}
+
+	if doc.FieldStats != nil && doc.FieldStats.Jobs > 0 {
+		_, _ = fmt.Fprintf(w, ".SH FIELD VALUE DISTRIBUTION\n")
+		for _, dist := range fieldDistributions(doc.FieldStats) {
+			if summary := dist.Summary(); summary != "" {
+				_, _ = fmt.Fprintf(w, ".IP \\(bu 2\n%s\n", manEscape(summary))
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, ".SH SEE ALSO\n.BR crontab (5),\n.BR cron (8)\n")
+	return nil
+}
+
+// renderManWarnings writes warnings as tagged paragraphs: severity and code,
+// then the message and hint
+func renderManWarnings(w io.Writer, warnings []Warning) {
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(w, ".TP\n.B %s %s\n%s\n", strings.ToUpper(warning.Severity.String()), warning.Code, manEscape(warning.Message))
+		if warning.Hint != "" {
+			_, _ = fmt.Fprintf(w, ".br\nHint: %s\n", manEscape(warning.Hint))
+		}
+	}
+}
+
+// manPageName names the page after the document's source file, lowercased
+// and stripped of characters man page names avoid
+func manPageName(doc *Document) string {
+	if len(doc.Sections) > 0 {
+		return "crontab"
+	}
+	name := manName.ReplaceAllString(strings.ToLower(filepath.Base(doc.Source)), "-")
+	name = strings.Trim(name, ".-")
+	if name == "" || name == "stdin" {
+		return "crontab"
+	}
+	return name
+}
+
+// manEscape escapes text for roff, guarding lines that would start with a
+// control character
+func manEscape(s string) string {
+	s = manText.Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// manQuote escapes text as a quoted macro argument
+func manQuote(s string) string {
+	return `"` + strings.ReplaceAll(manText.Replace(s), `"`, `\(dq`) + `"`
+}
+
+// manLiteral escapes a command shown in no-fill mode, line by line
+func manLiteral(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = manEscape(line)
+	}
+	return strings.Join(lines, "\n")
+}
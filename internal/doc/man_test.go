@@ -0,0 +1,56 @@
+package doc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManRenderer(t *testing.T) {
+	doc := &Document{
+		Title:       "Backup jobs",
+		GeneratedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		Source:      "/etc/cron.d/Backup",
+		Jobs: []JobDocument{
+			{
+				LineNumber:  3,
+				Expression:  "0 2 * * *",
+				Description: "At 02:00 every day",
+				Command:     `backup.sh --full \ .hidden`,
+				Metadata:    &crontab.Metadata{Owner: "infra", Duration: 20 * time.Minute},
+			},
+		},
+		Metadata: Metadata{TotalJobs: 1, ValidJobs: 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&ManRenderer{}).Render(doc, &buf))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, `.TH "BACKUP" 5 2026-03-02 cronkit "Backup jobs"`+"\n"))
+	assert.Contains(t, output, ".SH NAME\nbackup \\- Backup jobs\n")
+	assert.Contains(t, output, "Scheduled jobs of \\fB/etc/cron.d/Backup\\fR: 1 jobs (1 valid, 0 invalid).\n")
+	assert.Contains(t, output, ".SS Line 3\n")
+	assert.Contains(t, output, ".TP\n.B Duration\n20m\n")
+	assert.Contains(t, output, ".nf\nbackup.sh \\-\\-full \\e .hidden\n.fi\n")
+	assert.True(t, strings.HasSuffix(output, ".SH SEE ALSO\n.BR crontab (5),\n.BR cron (8)\n"))
+}
+
+func TestManPageName(t *testing.T) {
+	assert.Equal(t, "backup", manPageName(&Document{Source: "/etc/cron.d/backup"}))
+	assert.Equal(t, "user-crontab", manPageName(&Document{Source: "user crontab"}))
+	assert.Equal(t, "crontab", manPageName(&Document{Source: "stdin"}))
+	assert.Equal(t, "crontab", manPageName(&Document{Source: "a, b", Sections: []Section{{Source: "a"}, {Source: "b"}}}))
+}
+
+func TestManEscape(t *testing.T) {
+	assert.Equal(t, `\&.profile`, manEscape(".profile"))
+	assert.Equal(t, `\&'quoted'`, manEscape("'quoted'"))
+	assert.Equal(t, `a \- b`, manEscape("a - b"))
+	assert.Equal(t, `"say \(dqhi\(dq"`, manQuote(`say "hi"`))
+}
@@ -0,0 +1,75 @@
+package doc
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+const (
+	// MermaidDefaultRuns is the number of upcoming runs charted per job when
+	// the document was generated without next runs
+	MermaidDefaultRuns = 5
+
+	// mermaidTimeFormat is the Go layout matching the chart's dateFormat
+	mermaidTimeFormat = "2006-01-02 15:04"
+)
+
+// mermaidText replaces the characters that end a section or task name in
+// Mermaid gantt syntax. Colons become a look-alike ratio sign so times in
+// descriptions stay readable.
+var mermaidText = strings.NewReplacer(":", "∶", ";", ",", "#", "", "\n", " ", "%%", "%")
+
+// MermaidRenderer renders the upcoming runs of a document as a Mermaid gantt
+// chart, fenced as a code block so it renders in GitHub markdown
+type MermaidRenderer struct{}
+
+// Render renders a document as a Mermaid gantt chart with one section per
+// job and one task per upcoming run, lasting the job's "cronkit:duration="
+// or, as in the timeline and stats commands, its start minute. Runs are charted in the zone
+// of doc.GeneratedAt, as the HTML timeline draws them, so jobs under CRON_TZ=
+// line up with the others. Invalid jobs and jobs without upcoming runs are
+// left out.
+func (r *MermaidRenderer) Render(doc *Document, w io.Writer) error {
+	_, _ = fmt.Fprintf(w, "```mermaid\n")
+	_, _ = fmt.Fprintf(w, "gantt\n")
+	_, _ = fmt.Fprintf(w, "    title %s\n", mermaidText.Replace(doc.Title+" ("+doc.Source+")"))
+	_, _ = fmt.Fprintf(w, "    dateFormat YYYY-MM-DD HH:mm\n")
+	_, _ = fmt.Fprintf(w, "    axisFormat %%m-%%d %%H:%%M\n")
+	_, _ = fmt.Fprintf(w, "    todayMarker off\n")
+
+	for _, job := range doc.Jobs {
+		if len(job.NextRuns) == 0 {
+			continue
+		}
+
+		section := job.Description
+		if section == "" {
+			section = job.Expression
+		}
+		_, _ = fmt.Fprintf(w, "    section %s %s\n", mermaidText.Replace(lineLocation(job.Source, job.LineNumber)), mermaidText.Replace(section))
+
+		command := truncateCommand(job.Command)
+		length := mermaidDuration(job.Metadata)
+		for _, t := range job.NextRuns {
+			_, _ = fmt.Fprintf(w, "    %s :%s, %s\n", mermaidText.Replace(command), t.In(doc.GeneratedAt.Location()).Format(mermaidTimeFormat), length)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "```\n")
+	return nil
+}
+
+// mermaidDuration returns the length of a job's tasks in whole minutes, as
+// Mermaid durations have a single unit: the declared duration rounded up, or
+// a minute
+func mermaidDuration(meta *crontab.Metadata) string {
+	minutes := 1
+	if meta != nil && meta.Duration > 0 {
+		minutes = int((meta.Duration + time.Minute - 1) / time.Minute)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -0,0 +1,97 @@
+package doc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMermaidRenderer(t *testing.T) {
+	run := time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC)
+	doc := &Document{
+		Title:  "Crontab Documentation",
+		Source: "test.cron",
+		Jobs: []JobDocument{
+			{
+				LineNumber:  3,
+				Expression:  "0 2 * * *",
+				Description: "At 02:00 every day",
+				Command:     "/usr/bin/backup.sh --to s3://bucket; echo #done",
+				NextRuns:    []time.Time{run, run.AddDate(0, 0, 1)},
+			},
+			{
+				LineNumber:  5,
+				Expression:  "0 3 * * *",
+				Description: "At 03:00 every day",
+				Command:     "/usr/bin/vacuum.sh",
+				Metadata:    &crontab.Metadata{Duration: 90 * time.Minute},
+				NextRuns:    []time.Time{run.Add(time.Hour)},
+			},
+			{
+				LineNumber:  6,
+				Expression:  "0 4 * * *",
+				Description: "At 04:00 every day",
+				Command:     "/usr/bin/ping.sh",
+				Metadata:    &crontab.Metadata{Duration: 30 * time.Second},
+				NextRuns:    []time.Time{run.Add(2 * time.Hour)},
+			},
+			{
+				LineNumber:  4,
+				Expression:  "invalid",
+				Description: "Invalid expression: bad",
+				Command:     "/usr/bin/never.sh",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&MermaidRenderer{}).Render(doc, &buf))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "```mermaid\ngantt\n"))
+	assert.True(t, strings.HasSuffix(output, "```\n"))
+	assert.Contains(t, output, "    title Crontab Documentation (test.cron)\n")
+	assert.Contains(t, output, "    section Line 3 At 02∶00 every day\n")
+	assert.Contains(t, output, "    /usr/bin/backup.sh --to s3∶//bucket, echo done :2026-03-02 02:00, 1m\n")
+	assert.Contains(t, output, ":2026-03-03 02:00, 1m\n")
+	assert.Contains(t, output, "    /usr/bin/vacuum.sh :2026-03-02 03:00, 90m\n", "tasks last the job's duration")
+	assert.Contains(t, output, "    /usr/bin/ping.sh :2026-03-02 04:00, 1m\n", "durations are rounded up to whole minutes")
+	assert.NotContains(t, output, "never.sh", "jobs without upcoming runs are left out")
+}
+
+func TestMermaidRenderer_MixedZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	doc := &Document{
+		Title:       "Crontab Documentation",
+		Source:      "test.cron",
+		GeneratedAt: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Jobs: []JobDocument{
+			{
+				LineNumber: 1,
+				Expression: "0 2 * * *",
+				Command:    "/usr/bin/local.sh",
+				NextRuns:   []time.Time{time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC)},
+			},
+			{
+				LineNumber: 3,
+				Expression: "0 2 * * *",
+				Timezone:   "America/New_York",
+				Command:    "/usr/bin/zoned.sh",
+				NextRuns:   []time.Time{time.Date(2026, 3, 2, 2, 0, 0, 0, newYork)},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&MermaidRenderer{}).Render(doc, &buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "    /usr/bin/local.sh :2026-03-02 02:00, 1m\n")
+	assert.Contains(t, output, "    /usr/bin/zoned.sh :2026-03-02 07:00, 1m\n", "runs under CRON_TZ= are charted in the document's zone")
+}
@@ -0,0 +1,301 @@
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PDF page layout, in points (A4)
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+	pdfLeading    = 1.35 // Line height, relative to the font size
+	pdfBodySize   = 10
+)
+
+// pdfFont is one of the standard PDF fonts, which viewers provide, so
+// documents need no embedded font files
+type pdfFont struct {
+	resource string  // Name in the page resources
+	base     string  // PostScript name
+	width    float64 // Average glyph width, relative to the font size, used to wrap lines
+}
+
+var (
+	pdfRegular = pdfFont{resource: "F1", base: "Helvetica", width: 0.5}
+	pdfBold    = pdfFont{resource: "F2", base: "Helvetica-Bold", width: 0.55}
+	pdfMono    = pdfFont{resource: "F3", base: "Courier", width: 0.6}
+	pdfFonts   = []pdfFont{pdfRegular, pdfBold, pdfMono}
+)
+
+// pdfWinAnsi maps the characters of Windows-1252 outside Latin-1 to their
+// codes; other characters outside Latin-1 are written as "?"
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfLine is a line of text, wrapped to the page width when written
+type pdfLine struct {
+	font   pdfFont
+	size   float64
+	indent float64
+	space  float64 // Extra space above the line
+	text   string
+}
+
+// PDFRenderer renders documents as a PDF file, for attaching schedule
+// documentation to change requests. The PDF is written without external
+// dependencies, in the standard Helvetica and Courier fonts.
+type PDFRenderer struct{}
+
+// Render renders a document as PDF
+func (r *PDFRenderer) Render(doc *Document, w io.Writer) error {
+	var lines []pdfLine
+	heading := func(size float64, text string) {
+		lines = append(lines, pdfLine{font: pdfBold, size: size, space: size * 0.8, text: text})
+	}
+	text := func(indent float64, text string) {
+		lines = append(lines, pdfLine{font: pdfRegular, size: pdfBodySize, indent: indent, text: text})
+	}
+	field := func(label, value string) {
+		text(0, label+": "+value)
+	}
+	code := func(indent float64, text string) {
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, pdfLine{font: pdfMono, size: pdfBodySize - 1, indent: indent, text: line})
+		}
+	}
+
+	heading(20, doc.Title)
+	field("Generated", doc.GeneratedAt.Format(time.RFC3339))
+	field("Source", doc.Source)
+
+	heading(14, "Summary")
+	text(10, fmt.Sprintf("Total Jobs: %d", doc.Metadata.TotalJobs))
+	text(10, fmt.Sprintf("Valid Jobs: %d", doc.Metadata.ValidJobs))
+	text(10, fmt.Sprintf("Invalid Jobs: %d", doc.Metadata.InvalidJobs))
+
+	if len(doc.Sections) > 0 {
+		heading(14, "Sources")
+		for _, section := range doc.Sections {
+			text(10, fmt.Sprintf("%s: %d jobs (%d valid, %d invalid)",
+				section.Source, section.Metadata.TotalJobs, section.Metadata.ValidJobs, section.Metadata.InvalidJobs))
+		}
+	}
+
+	heading(14, "Jobs")
+	for _, group := range jobGroups(doc) {
+		if group.Source != "" {
+			heading(11, group.Source)
+		}
+		for _, job := range group.Jobs {
+			code(10, fmt.Sprintf("%4d  %-15s  %s", job.LineNumber, job.Expression, truncateCommand(job.Command)))
+		}
+	}
+
+	if len(doc.Warnings) > 0 {
+		heading(14, "Warnings")
+		lines = append(lines, pdfWarningLines(doc.Warnings)...)
+	}
+
+	if skipped := skippedLines(doc); len(skipped) > 0 {
+		heading(14, "Skipped Lines")
+		for _, line := range skipped {
+			text(10, fmt.Sprintf("%s: %s (%s)", lineLocation(line.Source, line.LineNumber), line.Line, line.Reason))
+		}
+	}
+
+	if doc.FieldStats != nil && doc.FieldStats.Jobs > 0 {
+		heading(14, "Field Value Distribution")
+		for _, dist := range fieldDistributions(doc.FieldStats) {
+			if summary := dist.Summary(); summary != "" {
+				text(10, summary)
+			}
+		}
+	}
+
+	for _, job := range doc.Jobs {
+		heading(12, "Job at "+lineLocation(job.Source, job.LineNumber))
+		field("Expression", job.Expression)
+		field("Description", job.Description)
+		if job.User != "" {
+			field("User", job.User)
+		}
+		if job.Timezone != "" {
+			field("Timezone", job.Timezone)
+		}
+		if job.Trigger != "" {
+			field("Runs Via", job.Trigger)
+		}
+		text(0, "Command:")
+		code(10, job.Command)
+		if job.Resolved != "" {
+			text(0, "Resolved Command:")
+			code(10, job.Resolved)
+		}
+		if job.Comment != "" {
+			field("Comment", job.Comment)
+		}
+		if job.Metadata != nil {
+			for _, row := range metadataRows(job.Metadata) {
+				field(row[0], row[1])
+			}
+		}
+		if len(job.NextRuns) > 0 {
+			text(0, "Next Runs:")
+			for i, t := range job.NextRuns {
+				if i >= 10 {
+					break
+				}
+				text(10, t.Format(time.RFC3339))
+			}
+		}
+		if len(job.Warnings) > 0 {
+			text(0, "Warnings:")
+			lines = append(lines, pdfWarningLines(job.Warnings)...)
+		}
+		if job.Stats != nil {
+			field("Statistics", fmt.Sprintf("%d runs per day, %d per hour", job.Stats.RunsPerDay, job.Stats.RunsPerHour))
+		}
+	}
+
+	return writePDF(w, doc.Title, pdfPages(lines))
+}
+
+// pdfWarningLines returns warnings as lines of severity, code and message,
+// with their hints
+func pdfWarningLines(warnings []Warning) []pdfLine {
+	var lines []pdfLine
+	for _, warning := range warnings {
+		lines = append(lines, pdfLine{font: pdfRegular, size: pdfBodySize, indent: 10,
+			text: fmt.Sprintf("[%s] %s: %s", strings.ToUpper(warning.Severity.String()), warning.Code, warning.Message)})
+		if warning.Hint != "" {
+			lines = append(lines, pdfLine{font: pdfRegular, size: pdfBodySize, indent: 20, text: "Hint: " + warning.Hint})
+		}
+	}
+	return lines
+}
+
+// pdfPages wraps lines to the page width and breaks them into pages, as the
+// content streams of the pages
+func pdfPages(lines []pdfLine) []string {
+	var pages []string
+	var page bytes.Buffer
+	y := float64(pdfPageHeight - pdfMargin)
+	for _, line := range lines {
+		width := (pdfPageWidth - 2*pdfMargin - line.indent) / (line.size * line.font.width)
+		for i, text := range wrapPDFText(line.text, int(width)) {
+			height := line.size * pdfLeading
+			if i == 0 {
+				height += line.space
+			}
+			if y-height < pdfMargin && page.Len() > 0 {
+				pages = append(pages, page.String())
+				page.Reset()
+				y = pdfPageHeight - pdfMargin
+			}
+			y -= height
+			_, _ = fmt.Fprintf(&page, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n",
+				line.font.resource, line.size, pdfMargin+line.indent, y, pdfString(text))
+		}
+	}
+	return append(pages, page.String())
+}
+
+// wrapPDFText breaks text into lines of at most width characters, at spaces
+// when possible
+func wrapPDFText(text string, width int) []string {
+	runes := []rune(text)
+	if width < 1 || len(runes) <= width {
+		return []string{text}
+	}
+	var lines []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > width/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
+
+// pdfString escapes text for a PDF string literal in WinAnsiEncoding
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			_, _ = fmt.Fprintf(&b, "\\%03o", r)
+		case pdfWinAnsi[r] != 0:
+			_, _ = fmt.Fprintf(&b, "\\%03o", pdfWinAnsi[r])
+		case r == '\t':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// writePDF writes a PDF file of pages of text: the catalog, the page tree,
+// the fonts, a page and content stream per page, and the cross-reference
+// table locating them
+func writePDF(w io.Writer, title string, pages []string) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		_, _ = fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-3 are the catalog, page tree and info, then the fonts, then
+	// a page and its content per page
+	firstFont := 4
+	firstPage := firstFont + len(pdfFonts)
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	fonts := make([]string, len(pdfFonts))
+	for i, font := range pdfFonts {
+		fonts[i] = fmt.Sprintf("/%s %d 0 R", font.resource, firstFont+i)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (cronkit) >>", pdfString(title)))
+	for _, font := range pdfFonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.base))
+	}
+	for i, content := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, strings.Join(fonts, " "), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	_, _ = fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		_, _ = fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	_, _ = fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
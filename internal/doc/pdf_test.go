@@ -0,0 +1,70 @@
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFRenderer(t *testing.T) {
+	doc := &Document{
+		Title:       "Crontab (Backup)",
+		GeneratedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		Source:      "test.cron",
+		Jobs: []JobDocument{
+			{LineNumber: 3, Expression: "0 2 * * *", Description: "Tous les jours à 02:00", Command: "/usr/bin/backup.sh"},
+		},
+		Metadata: Metadata{TotalJobs: 1, ValidJobs: 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&PDFRenderer{}).Render(doc, &buf))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(output, "%%EOF\n"))
+	assert.Contains(t, output, "/Title (Crontab \\(Backup\\))")
+	assert.Contains(t, output, "(Description: Tous les jours \\340 02:00) Tj")
+	assert.Contains(t, output, "/BaseFont /Courier")
+
+	// Every cross-reference entry locates its object
+	start, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(output)[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(output[start:], "xref\n"))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(output[start:], -1)
+	require.NotEmpty(t, offsets)
+	for i, offset := range offsets {
+		at, err := strconv.Atoi(offset[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output[at:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+}
+
+func TestPDFPages(t *testing.T) {
+	lines := make([]pdfLine, 200)
+	for i := range lines {
+		lines[i] = pdfLine{font: pdfRegular, size: pdfBodySize, text: fmt.Sprintf("line %d", i)}
+	}
+	pages := pdfPages(lines)
+	assert.Len(t, pages, 4)
+	assert.Contains(t, pages[0], "(line 0) Tj")
+	assert.Contains(t, pages[3], "(line 199) Tj")
+}
+
+func TestWrapPDFText(t *testing.T) {
+	assert.Equal(t, []string{"short"}, wrapPDFText("short", 10))
+	assert.Equal(t, []string{"one two", "three four"}, wrapPDFText("one two three four", 10))
+	assert.Equal(t, []string{"abcdefghij", "klm"}, wrapPDFText("abcdefghijklm", 10))
+}
+
+func TestPDFString(t *testing.T) {
+	assert.Equal(t, `a \(b\) \\ c`, pdfString(`a (b) \ c`))
+	assert.Equal(t, `caf\351 \226 ?`, pdfString("café – ✓"))
+}
@@ -1,15 +1,24 @@
 package doc
 
 import (
+	_ "embed"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"io"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/schema"
+	"github.com/hzerrad/cronkit/internal/stats"
 )
 
 const (
 	maxCommandLengthDoc  = 50
 	maxCommandDisplayDoc = 47 // for truncation
+	fieldStatsTopN       = 5  // values shown per field in the distribution table
 )
 
 // ReferenceDate is a fixed date used for consistent calculations
@@ -27,6 +36,9 @@ type MarkdownRenderer struct{}
 // Render renders a document as Markdown
 func (r *MarkdownRenderer) Render(doc *Document, w io.Writer) error {
 	// Write header
+	if doc.Logo != "" {
+		_, _ = fmt.Fprintf(w, "![logo](%s)\n\n", doc.Logo)
+	}
 	_, _ = fmt.Fprintf(w, "# %s\n\n", doc.Title)
 	_, _ = fmt.Fprintf(w, "**Generated:** %s\n", doc.GeneratedAt.Format(time.RFC3339))
 	_, _ = fmt.Fprintf(w, "**Source:** %s\n\n", doc.Source)
@@ -37,34 +49,94 @@ func (r *MarkdownRenderer) Render(doc *Document, w io.Writer) error {
 	_, _ = fmt.Fprintf(w, "- Valid Jobs: %d\n", doc.Metadata.ValidJobs)
 	_, _ = fmt.Fprintf(w, "- Invalid Jobs: %d\n\n", doc.Metadata.InvalidJobs)
 
-	// Write jobs table
+	if len(doc.Sections) > 0 {
+		_, _ = fmt.Fprintf(w, "## Sources\n\n")
+		for _, section := range doc.Sections {
+			_, _ = fmt.Fprintf(w, "- `%s`: %d jobs (%d valid, %d invalid)\n",
+				section.Source, section.Metadata.TotalJobs, section.Metadata.ValidJobs, section.Metadata.InvalidJobs)
+		}
+		_, _ = fmt.Fprintf(w, "\n")
+	}
+
+	// Write jobs table, per source when there are several
 	_, _ = fmt.Fprintf(w, "## Jobs\n\n")
-	_, _ = fmt.Fprintf(w, "| Line | Expression | Description | Command |\n")
-	_, _ = fmt.Fprintf(w, "|------|------------|------------|----------|\n")
+	for _, group := range jobGroups(doc) {
+		if group.Source != "" {
+			_, _ = fmt.Fprintf(w, "### %s\n\n", group.Source)
+		}
+		users := hasUsers(group.Jobs)
+		if users {
+			_, _ = fmt.Fprintf(w, "| Line | Expression | Description | User | Command |\n")
+			_, _ = fmt.Fprintf(w, "|------|------------|------------|------|----------|\n")
+		} else {
+			_, _ = fmt.Fprintf(w, "| Line | Expression | Description | Command |\n")
+			_, _ = fmt.Fprintf(w, "|------|------------|------------|----------|\n")
+		}
 
-	for _, job := range doc.Jobs {
-		// Truncate command for table display
-		command := job.Command
-		if len(command) > maxCommandLengthDoc {
-			command = command[:maxCommandDisplayDoc] + "..."
+		for _, job := range group.Jobs {
+			// Truncate command for table display
+			command := truncateCommand(job.Command)
+			if users {
+				_, _ = fmt.Fprintf(w, "| %d | `%s` | %s | %s | `%s` |\n",
+					job.LineNumber, job.Expression, job.Description, job.User, command)
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "| %d | `%s` | %s | `%s` |\n",
+				job.LineNumber, job.Expression, job.Description, command)
+		}
+
+		_, _ = fmt.Fprintf(w, "\n")
+	}
+
+	if len(doc.Warnings) > 0 {
+		_, _ = fmt.Fprintf(w, "## Warnings\n\n")
+		renderMarkdownWarnings(w, doc.Warnings)
+	}
+
+	if skipped := skippedLines(doc); len(skipped) > 0 {
+		_, _ = fmt.Fprintf(w, "## Skipped Lines\n\n")
+		for _, line := range skipped {
+			_, _ = fmt.Fprintf(w, "- ⚠️ %s: `%s` (%s)\n", lineLocation(line.Source, line.LineNumber), line.Line, line.Reason)
 		}
-		_, _ = fmt.Fprintf(w, "| %d | `%s` | %s | `%s` |\n",
-			job.LineNumber, job.Expression, job.Description, command)
+		_, _ = fmt.Fprintf(w, "\n")
 	}
 
-	_, _ = fmt.Fprintf(w, "\n")
+	if doc.FieldStats != nil && doc.FieldStats.Jobs > 0 {
+		_, _ = fmt.Fprintf(w, "## Field Value Distribution\n\n")
+		renderMarkdownFieldStats(w, doc.FieldStats)
+	}
 
 	// Write detailed job information
 	for _, job := range doc.Jobs {
-		_, _ = fmt.Fprintf(w, "### Job at Line %d\n\n", job.LineNumber)
+		_, _ = fmt.Fprintf(w, "### Job at %s\n\n", lineLocation(job.Source, job.LineNumber))
 		_, _ = fmt.Fprintf(w, "**Expression:** `%s`\n\n", job.Expression)
 		_, _ = fmt.Fprintf(w, "**Description:** %s\n\n", job.Description)
+		if job.User != "" {
+			_, _ = fmt.Fprintf(w, "**User:** %s\n\n", job.User)
+		}
+		if job.Timezone != "" {
+			_, _ = fmt.Fprintf(w, "**Timezone:** %s\n\n", job.Timezone)
+		}
+		if job.Trigger != "" {
+			_, _ = fmt.Fprintf(w, "**Runs Via:** %s\n\n", job.Trigger)
+		}
 		_, _ = fmt.Fprintf(w, "**Command:**\n```bash\n%s\n```\n\n", job.Command)
+		if job.Resolved != "" {
+			_, _ = fmt.Fprintf(w, "**Resolved Command:**\n```bash\n%s\n```\n\n", job.Resolved)
+		}
 
 		if job.Comment != "" {
 			_, _ = fmt.Fprintf(w, "**Comment:** %s\n\n", job.Comment)
 		}
 
+		if rows := metadataRows(job.Metadata); len(rows) > 0 {
+			_, _ = fmt.Fprintf(w, "**Metadata:**\n\n")
+			for _, row := range rows {
+				_, _ = fmt.Fprintf(w, "- %s: %s\n", row[0], row[1])
+			}
+			_, _ = fmt.Fprintf(w, "\n")
+		}
+
 		if len(job.NextRuns) > 0 {
 			_, _ = fmt.Fprintf(w, "**Next Runs:**\n\n")
 			for i, t := range job.NextRuns {
@@ -78,10 +150,7 @@ func (r *MarkdownRenderer) Render(doc *Document, w io.Writer) error {
 
 		if len(job.Warnings) > 0 {
 			_, _ = fmt.Fprintf(w, "**Warnings:**\n\n")
-			for _, warning := range job.Warnings {
-				_, _ = fmt.Fprintf(w, "- ⚠️ %s\n", warning)
-			}
-			_, _ = fmt.Fprintf(w, "\n")
+			renderMarkdownWarnings(w, job.Warnings)
 		}
 
 		if job.Stats != nil {
@@ -94,97 +163,191 @@ func (r *MarkdownRenderer) Render(doc *Document, w io.Writer) error {
 	return nil
 }
 
-// HTMLRenderer renders documents in HTML format
+// HTMLRenderer renders documents in HTML format, as a self-contained page
+// with a searchable, sortable job table and, when the document has a
+// timeline, an interactive chart of upcoming runs
 type HTMLRenderer struct{}
 
+//go:embed templates/html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("html").Funcs(templateFuncs).Parse(htmlTemplateText))
+
+// templateFuncs are the functions available to the HTML template and to
+// user-supplied templates
+var templateFuncs = template.FuncMap{
+	"rfc3339":            func(t time.Time) string { return t.Format(time.RFC3339) },
+	"jobGroups":          jobGroups,
+	"hasUsers":           hasUsers,
+	"jobID":              func(job JobDocument) string { return timelineJobID(job.Source, job.LineNumber) },
+	"truncate":           truncateCommand,
+	"skippedLines":       skippedLines,
+	"lineLocation":       lineLocation,
+	"metadataRows":       metadataRows,
+	"fieldDistributions": fieldDistributions,
+	"topValues":          func(dist stats.FieldDistribution) []stats.ValueUsage { return dist.Top(fieldStatsTopN) },
+	"fieldLabel":         stats.FieldValueLabel,
+	"heat":               heatColor,
+	"percent":            func(percent float64) string { return fmt.Sprintf("%.0f%%", percent) },
+}
+
 // Render renders a document as HTML
 func (r *HTMLRenderer) Render(doc *Document, w io.Writer) error {
-	_, _ = fmt.Fprintf(w, `<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>%s</title>
-    <style>
-        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; margin: 40px; }
-        h1 { color: #333; }
-        h2 { color: #666; margin-top: 30px; }
-        table { border-collapse: collapse; width: 100%%; margin: 20px 0; }
-        th, td { border: 1px solid #ddd; padding: 8px; text-align: left; }
-        th { background-color: #f2f2f2; }
-        code { background-color: #f4f4f4; padding: 2px 4px; border-radius: 3px; }
-        pre { background-color: #f4f4f4; padding: 10px; border-radius: 5px; overflow-x: auto; }
-        .warning { color: #ff9800; }
-    </style>
-</head>
-<body>
-`, doc.Title)
-
-	_, _ = fmt.Fprintf(w, "<h1>%s</h1>\n", doc.Title)
-	_, _ = fmt.Fprintf(w, "<p><strong>Generated:</strong> %s</p>\n", doc.GeneratedAt.Format(time.RFC3339))
-	_, _ = fmt.Fprintf(w, "<p><strong>Source:</strong> %s</p>\n", doc.Source)
-
-	_, _ = fmt.Fprintf(w, "<h2>Summary</h2>\n<ul>\n")
-	_, _ = fmt.Fprintf(w, "<li>Total Jobs: %d</li>\n", doc.Metadata.TotalJobs)
-	_, _ = fmt.Fprintf(w, "<li>Valid Jobs: %d</li>\n", doc.Metadata.ValidJobs)
-	_, _ = fmt.Fprintf(w, "<li>Invalid Jobs: %d</li>\n</ul>\n", doc.Metadata.InvalidJobs)
-
-	_, _ = fmt.Fprintf(w, "<h2>Jobs</h2>\n<table>\n<thead>\n<tr><th>Line</th><th>Expression</th><th>Description</th><th>Command</th></tr>\n</thead>\n<tbody>\n")
-	for _, job := range doc.Jobs {
-		command := job.Command
-		if len(command) > maxCommandLengthDoc {
-			command = command[:maxCommandDisplayDoc] + "..."
+	return htmlTemplate.Execute(w, doc)
+}
+
+// renderMarkdownWarnings writes warnings as a list of severity badges linking to code docs
+func renderMarkdownWarnings(w io.Writer, warnings []Warning) {
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(w, "- %s **%s** [`%s`](%s): %s\n",
+			severityIcon(warning.Severity), strings.ToUpper(warning.Severity.String()), warning.Code, warning.Link(), warning.Message)
+		if warning.Hint != "" {
+			_, _ = fmt.Fprintf(w, "  - _Hint:_ %s\n", warning.Hint)
 		}
-		_, _ = fmt.Fprintf(w, "<tr><td>%d</td><td><code>%s</code></td><td>%s</td><td><code>%s</code></td></tr>\n",
-			job.LineNumber, job.Expression, job.Description, command)
 	}
-	_, _ = fmt.Fprintf(w, "</tbody>\n</table>\n")
+	_, _ = fmt.Fprintf(w, "\n")
+}
 
-	for _, job := range doc.Jobs {
-		_, _ = fmt.Fprintf(w, "<h3>Job at Line %d</h3>\n", job.LineNumber)
-		_, _ = fmt.Fprintf(w, "<p><strong>Expression:</strong> <code>%s</code></p>\n", job.Expression)
-		_, _ = fmt.Fprintf(w, "<p><strong>Description:</strong> %s</p>\n", job.Description)
-		_, _ = fmt.Fprintf(w, "<p><strong>Command:</strong></p><pre>%s</pre>\n", job.Command)
+// jobGroup is the jobs of one source of a document
+type jobGroup struct {
+	Source string // Empty for single-source documents
+	Jobs   []JobDocument
+}
 
-		if job.Comment != "" {
-			_, _ = fmt.Fprintf(w, "<p><strong>Comment:</strong> %s</p>\n", job.Comment)
+// jobGroups returns the jobs of a document per source, in the order of its
+// sections, or all jobs in a single group when it has one source
+func jobGroups(doc *Document) []jobGroup {
+	if len(doc.Sections) == 0 {
+		return []jobGroup{{Jobs: doc.Jobs}}
+	}
+	groups := make([]jobGroup, 0, len(doc.Sections))
+	for _, section := range doc.Sections {
+		group := jobGroup{Source: section.Source}
+		for _, job := range doc.Jobs {
+			if job.Source == section.Source {
+				group.Jobs = append(group.Jobs, job)
+			}
 		}
+		groups = append(groups, group)
+	}
+	return groups
+}
 
-		if len(job.NextRuns) > 0 {
-			_, _ = fmt.Fprintf(w, "<p><strong>Next Runs:</strong></p><ul>\n")
-			for i, t := range job.NextRuns {
-				if i >= 10 {
-					break
-				}
-				_, _ = fmt.Fprintf(w, "<li>%s</li>\n", t.Format(time.RFC3339))
-			}
-			_, _ = fmt.Fprintf(w, "</ul>\n")
+// hasUsers reports whether any of the jobs names its user, as system crontab
+// jobs do
+func hasUsers(jobs []JobDocument) bool {
+	for _, job := range jobs {
+		if job.User != "" {
+			return true
 		}
+	}
+	return false
+}
 
-		if len(job.Warnings) > 0 {
-			_, _ = fmt.Fprintf(w, "<p><strong>Warnings:</strong></p><ul class=\"warning\">\n")
-			for _, warning := range job.Warnings {
-				_, _ = fmt.Fprintf(w, "<li>⚠️ %s</li>\n", warning)
-			}
-			_, _ = fmt.Fprintf(w, "</ul>\n")
+// sourcedLine is a skipped line with the source it belongs to
+type sourcedLine struct {
+	crontab.SkippedLine
+	Source string
+}
+
+// skippedLines returns the skipped lines of a document and of its sections
+func skippedLines(doc *Document) []sourcedLine {
+	var lines []sourcedLine
+	for _, skipped := range doc.Skipped {
+		lines = append(lines, sourcedLine{SkippedLine: skipped})
+	}
+	for _, section := range doc.Sections {
+		for _, skipped := range section.Skipped {
+			lines = append(lines, sourcedLine{SkippedLine: skipped, Source: section.Source})
 		}
+	}
+	return lines
+}
 
-		if job.Stats != nil {
-			_, _ = fmt.Fprintf(w, "<p><strong>Statistics:</strong></p><ul>\n")
-			_, _ = fmt.Fprintf(w, "<li>Runs per day: %d</li>\n", job.Stats.RunsPerDay)
-			_, _ = fmt.Fprintf(w, "<li>Runs per hour: %d</li>\n</ul>\n", job.Stats.RunsPerHour)
+// lineLocation names a line, with its source when the document has several:
+// "Line 3" or "/etc/cron.d/backup line 3"
+func lineLocation(source string, line int) string {
+	if source == "" {
+		return fmt.Sprintf("Line %d", line)
+	}
+	return fmt.Sprintf("%s line %d", source, line)
+}
+
+// truncateCommand shortens a command for job tables
+func truncateCommand(command string) string {
+	if len(command) > maxCommandLengthDoc {
+		return command[:maxCommandDisplayDoc] + "..."
+	}
+	return command
+}
+
+// metadataRows returns the declared directives of a job as key and value
+// pairs, in display order. The time zone is left out: it is shown as the
+// job's Timezone, which the tz directive sets.
+func metadataRows(meta *crontab.Metadata) [][2]string {
+	var rows [][2]string
+	if meta == nil {
+		return nil
+	}
+	add := func(key, value string) {
+		if value != "" {
+			rows = append(rows, [2]string{key, value})
 		}
 	}
+	add("Name", meta.Name)
+	add("Owner", meta.Owner)
+	if meta.Duration > 0 {
+		add("Duration", crontab.FormatDuration(meta.Duration))
+	}
+	add("Tags", strings.Join(meta.Tags, ", "))
+	keys := make([]string, 0, len(meta.Extra))
+	for key := range meta.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, meta.Extra[key])
+	}
+	return rows
+}
 
-	_, _ = fmt.Fprintf(w, "</body>\n</html>\n")
-	return nil
+// heatColor shades a field distribution cell by the share of jobs using its value
+func heatColor(percent float64) template.CSS {
+	return template.CSS(fmt.Sprintf("rgba(211, 47, 47, %.2f)", percent/100))
+}
+
+// fieldDistributions returns the distributions shown in documentation, in display order
+func fieldDistributions(fs *stats.FieldStats) []stats.FieldDistribution {
+	return []stats.FieldDistribution{fs.Minute, fs.Hour, fs.DayOfWeek}
+}
+
+// renderMarkdownFieldStats writes the most used field values as a summary list and table
+func renderMarkdownFieldStats(w io.Writer, fs *stats.FieldStats) {
+	for _, dist := range fieldDistributions(fs) {
+		if summary := dist.Summary(); summary != "" {
+			_, _ = fmt.Fprintf(w, "- %s\n", summary)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n| Field | Value | Jobs | Share |\n")
+	_, _ = fmt.Fprintf(w, "|-------|-------|------|-------|\n")
+	for _, dist := range fieldDistributions(fs) {
+		for _, u := range dist.Top(fieldStatsTopN) {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %d | %.0f%% |\n",
+				dist.Field, stats.FieldValueLabel(dist.Field, u.Value), u.Jobs, u.Percent)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n")
 }
 
 // JSONRenderer renders documents in JSON format
 type JSONRenderer struct{}
 
-// Render renders a document as JSON
+// Render renders a document as JSON, with the version of its schema
 func (r *JSONRenderer) Render(doc *Document, w io.Writer) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(doc)
+	return encoder.Encode(struct {
+		SchemaVersion int `json:"schemaVersion"`
+		*Document
+	}{schema.Version, doc})
 }
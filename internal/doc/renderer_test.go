@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/stats"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,8 +122,10 @@ func TestMarkdownRenderer_WithAllSections(t *testing.T) {
 				Command:     "/usr/bin/backup.sh",
 				Comment:     "Hourly backup",
 				NextRuns:    []time.Time{time.Now().Add(1 * time.Hour)},
-				Warnings:    []string{"Warning: test"},
-				Stats:       &JobStats{RunsPerDay: 24, RunsPerHour: 1},
+				Warnings: []Warning{
+					{Severity: check.SeverityWarn, Code: check.CodeDOMDOWConflict, Message: "Warning: test", Hint: "Fix it"},
+				},
+				Stats: &JobStats{RunsPerDay: 24, RunsPerHour: 1},
 			},
 		},
 		Metadata: Metadata{
@@ -292,8 +297,10 @@ func TestHTMLRenderer_WithAllSections(t *testing.T) {
 				Command:     "/usr/bin/backup.sh",
 				Comment:     "Hourly backup",
 				NextRuns:    []time.Time{time.Now().Add(1 * time.Hour)},
-				Warnings:    []string{"Warning: test"},
-				Stats:       &JobStats{RunsPerDay: 24, RunsPerHour: 1},
+				Warnings: []Warning{
+					{Severity: check.SeverityWarn, Code: check.CodeDOMDOWConflict, Message: "Warning: test", Hint: "Fix it"},
+				},
+				Stats: &JobStats{RunsPerDay: 24, RunsPerHour: 1},
 			},
 		},
 		Metadata: Metadata{
@@ -313,3 +320,155 @@ func TestHTMLRenderer_WithAllSections(t *testing.T) {
 	assert.Contains(t, output, "Statistics")
 	assert.Contains(t, output, "Hourly backup")
 }
+
+func TestRenderWarningBadges(t *testing.T) {
+	doc := &Document{
+		Title:       "Test Documentation",
+		GeneratedAt: time.Now(),
+		Source:      "test.cron",
+		Jobs: []JobDocument{
+			{
+				LineNumber: 3,
+				Expression: "0 0 1 * 1",
+				Command:    "backup",
+				Warnings: []Warning{
+					{Severity: check.SeverityWarn, Code: check.CodeDOMDOWConflict, Message: "Both fields set", Hint: "Use one"},
+					{Severity: check.SeverityInfo, Code: check.CodeMissingAbsolutePath, Message: "Relative command"},
+				},
+			},
+		},
+		Warnings: []Warning{
+			{Severity: check.SeverityWarn, Code: check.CodeOverlapDetected, Message: "Overlap detected"},
+		},
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &buf))
+		output := buf.String()
+
+		assert.Contains(t, output, "- ⚠️ **WARN** [`CRON-001`]("+CodeDocsURL+"#cron-001): Both fields set")
+		assert.Contains(t, output, "  - _Hint:_ Use one")
+		assert.Contains(t, output, "- ℹ️ **INFO** [`CRON-008`]("+CodeDocsURL+"#cron-008): Relative command")
+		assert.Contains(t, output, "## Warnings")
+		assert.Contains(t, output, "#cron-012): Overlap detected")
+	})
+
+	t.Run("html", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &buf))
+		output := buf.String()
+
+		assert.Contains(t, output, `<span class="badge badge-warn">warn</span> <a href="`+CodeDocsURL+`#cron-001"><code>CRON-001</code></a> Both fields set`)
+		assert.Contains(t, output, `<span class="hint">Hint: Use one</span>`)
+		assert.Contains(t, output, `<span class="badge badge-info">info</span>`)
+		assert.Contains(t, output, "<h2>Warnings</h2>")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&JSONRenderer{}).Render(doc, &buf))
+		output := buf.String()
+
+		assert.Contains(t, output, `"Severity": "warn"`)
+		assert.Contains(t, output, `"Code": "CRON-001"`)
+		assert.Contains(t, output, `"Hint": "Use one"`)
+	})
+}
+
+func TestWarning_Link(t *testing.T) {
+	w := Warning{Code: "CRON-013"}
+	assert.Equal(t, CodeDocsURL+"#cron-013", w.Link())
+}
+
+func TestRenderFieldStats(t *testing.T) {
+	fieldStats := stats.NewCalculator().CalculateFieldStats([]*crontab.Job{
+		{LineNumber: 1, Expression: "0 2 * * *", Valid: true},
+		{LineNumber: 2, Expression: "0 * * * 1", Valid: true},
+	})
+	doc := &Document{
+		Title:      "Test",
+		Source:     "test.cron",
+		Jobs:       []JobDocument{},
+		FieldStats: &fieldStats,
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(doc, &buf))
+		output := buf.String()
+		assert.Contains(t, output, "## Field Value Distribution")
+		assert.Contains(t, output, "- 100% of jobs run at minute 0")
+		assert.Contains(t, output, "| minute | :00 | 2 | 100% |")
+		assert.Contains(t, output, "| day-of-week | Mon | 1 | 50% |")
+	})
+
+	t.Run("html", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &buf))
+		output := buf.String()
+		assert.Contains(t, output, "<h2>Field Value Distribution</h2>")
+		assert.Contains(t, output, "<li>100% of jobs run at minute 0</li>")
+		assert.Contains(t, output, "rgba(211, 47, 47, 1.00)")
+	})
+
+	t.Run("omitted without stats", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&MarkdownRenderer{}).Render(&Document{Title: "Test"}, &buf))
+		assert.NotContains(t, buf.String(), "Field Value Distribution")
+	})
+}
+
+func TestHTMLRenderer_Interactive(t *testing.T) {
+	doc := &Document{
+		Title:  "Test",
+		Source: "test.cron",
+		Jobs: []JobDocument{
+			{LineNumber: 1, Expression: "0 2 * * *", Description: "At 02:00 every day", Command: `echo "<b>" > /tmp/out 2>&1`},
+		},
+		Timeline: map[string]interface{}{
+			"startTime": "2025-01-01T00:00:00Z",
+			"jobs": []map[string]interface{}{
+				{"id": "job-1", "runs": []map[string]interface{}{{"time": "2025-01-01T02:00:00Z", "overlaps": 0}}},
+			},
+		},
+	}
+
+	t.Run("escapes job fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &buf))
+		output := buf.String()
+		assert.Contains(t, output, `<tr data-job="job-1">`)
+		assert.Contains(t, output, `<pre>echo &#34;&lt;b&gt;&#34; &gt; /tmp/out 2&gt;&amp;1</pre>`)
+		assert.NotContains(t, output, "<b>")
+	})
+
+	t.Run("embeds the timeline as JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(doc, &buf))
+		output := buf.String()
+		assert.Contains(t, output, `<input type="search" id="filter"`)
+		assert.Contains(t, output, "<h2>Timeline</h2>")
+		assert.Contains(t, output, `<script type="application/json" id="timeline-data">{"jobs":[{"id":"job-1",`)
+		assert.Contains(t, output, `"startTime":"2025-01-01T00:00:00Z"`)
+	})
+
+	t.Run("shows the logo above the title", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(&Document{Title: "Test", Logo: "logo.png"}, &buf))
+		assert.Contains(t, buf.String(), "<img class=\"logo\" src=\"logo.png\" alt=\"logo\">\n<h1>Test</h1>")
+
+		buf.Reset()
+		require.NoError(t, (&MarkdownRenderer{}).Render(&Document{Title: "Test", Logo: "logo.png"}, &buf))
+		assert.Contains(t, buf.String(), "![logo](logo.png)\n\n# Test")
+	})
+
+	t.Run("omits the timeline without one", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&HTMLRenderer{}).Render(&Document{Title: "Test"}, &buf))
+		output := buf.String()
+		assert.NotContains(t, output, `id="timeline-data"`)
+		assert.NotContains(t, output, "<h2>Timeline</h2>")
+		assert.Contains(t, output, "</html>")
+	})
+}
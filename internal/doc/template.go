@@ -0,0 +1,75 @@
+package doc
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+// TemplateMain is the template executed when a template directory is given;
+// the directory's other *.tmpl files define templates it can include
+const TemplateMain = "doc.tmpl"
+
+// executor is the part of text/template and html/template templates that
+// TemplateRenderer uses
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// TemplateRenderer renders documents with a user-supplied Go template, such
+// as a team's runbook format. Templates receive the *Document and the same
+// functions as the built-in HTML template (jobGroups, lineLocation,
+// metadataRows, ...). Templates named *.html or *.htm are parsed with
+// html/template, which escapes values; others with text/template.
+type TemplateRenderer struct {
+	tmpl executor
+}
+
+// NewTemplateRenderer parses a template file, or the *.tmpl files of a
+// directory, executing the directory's TemplateMain
+func NewTemplateRenderer(path string) (*TemplateRenderer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	files := []string{path}
+	main := filepath.Base(path)
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template directory: %w", err)
+		}
+		main = TemplateMain
+		if _, err := os.Stat(filepath.Join(path, main)); err != nil {
+			return nil, fmt.Errorf("template directory %s has no %s", path, main)
+		}
+	}
+
+	var tmpl executor
+	if isHTMLTemplate(main) {
+		tmpl, err = htmltemplate.New(main).Funcs(templateFuncs).ParseFiles(files...)
+	} else {
+		tmpl, err = texttemplate.New(main).Funcs(texttemplate.FuncMap(templateFuncs)).ParseFiles(files...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return &TemplateRenderer{tmpl: tmpl}, nil
+}
+
+// Render renders a document with the template
+func (r *TemplateRenderer) Render(doc *Document, w io.Writer) error {
+	return r.tmpl.Execute(w, doc)
+}
+
+// isHTMLTemplate reports whether a template file produces HTML, by its
+// extension: "runbook.html", "doc.html.tmpl"
+func isHTMLTemplate(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, ".tmpl"))
+	return strings.HasSuffix(name, ".html") || strings.HasSuffix(name, ".htm")
+}
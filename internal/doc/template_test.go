@@ -0,0 +1,68 @@
+package doc
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRenderer(t *testing.T) {
+	doc := &Document{
+		Title:  "Runbook",
+		Source: "test.cron",
+		Jobs: []JobDocument{
+			{LineNumber: 3, Expression: "0 2 * * *", Command: "backup.sh > /tmp/out"},
+		},
+	}
+
+	t.Run("renders a text template file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "runbook.md.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte("# {{.Title}}\n{{range .Jobs}}- {{lineLocation .Source .LineNumber}}: {{.Command}}\n{{end}}"), 0o644))
+
+		renderer, err := NewTemplateRenderer(path)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, renderer.Render(doc, &buf))
+		assert.Equal(t, "# Runbook\n- Line 3: backup.sh > /tmp/out\n", buf.String())
+	})
+
+	t.Run("escapes HTML templates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "runbook.html")
+		require.NoError(t, os.WriteFile(path, []byte("{{range .Jobs}}<pre>{{.Command}}</pre>{{end}}"), 0o644))
+
+		renderer, err := NewTemplateRenderer(path)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, renderer.Render(doc, &buf))
+		assert.Equal(t, "<pre>backup.sh &gt; /tmp/out</pre>", buf.String())
+	})
+
+	t.Run("renders the doc.tmpl of a directory with its partials", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, TemplateMain), []byte(`{{range .Jobs}}{{template "job" .}}{{end}}`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "job.tmpl"), []byte(`{{define "job"}}{{.Expression}}{{end}}`), 0o644))
+
+		renderer, err := NewTemplateRenderer(dir)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, renderer.Render(doc, &buf))
+		assert.Equal(t, "0 2 * * *", buf.String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewTemplateRenderer(filepath.Join(t.TempDir(), "missing.tmpl"))
+		assert.ErrorContains(t, err, "failed to read template")
+
+		_, err = NewTemplateRenderer(t.TempDir())
+		assert.ErrorContains(t, err, "has no doc.tmpl")
+
+		path := filepath.Join(t.TempDir(), "bad.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte("{{.Title"), 0o644))
+		_, err = NewTemplateRenderer(path)
+		assert.ErrorContains(t, err, "failed to parse template")
+	})
+}
@@ -0,0 +1,71 @@
+package doc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/render"
+)
+
+const (
+	// TimelineDays is how many days of upcoming runs IncludeTimeline covers
+	TimelineDays = 7
+
+	timelineBatch = 1000 // Runs fetched per scheduler call
+)
+
+// timelineJobID identifies a job in a document's timeline: "job-3", or
+// "/etc/cron.d/backup:3" when the document has several sources, as the
+// timeline command does
+func timelineJobID(source string, line int) string {
+	if source != "" {
+		return fmt.Sprintf("%s:%d", source, line)
+	}
+	return fmt.Sprintf("job-%d", line)
+}
+
+// buildTimeline returns the runs of the valid jobs from start until
+// TimelineDays later, in the JSON model of the timeline command's week view
+func (g *Generator) buildTimeline(jobs []*crontab.Job, descriptions map[*crontab.Job]string, start time.Time) map[string]interface{} {
+	start = start.Truncate(time.Minute)
+	end := start.AddDate(0, 0, TimelineDays)
+	timeline := render.NewTimeline(render.WeekView, start, 0)
+
+	for _, job := range jobs {
+		if !job.Valid {
+			continue
+		}
+		id := timelineJobID(job.Source, job.LineNumber)
+		timeline.SetJobInfo(id, job.Expression, descriptions[job])
+		timeline.SetJobDuration(id, job.Duration)
+		timeline.SetJobMetadata(id, job.Metadata)
+		timeline.SetJobUser(id, job.User)
+
+		// Jobs under CRON_TZ= or TZ= run in that zone but are drawn in start's
+		loc, err := job.Location(start.Location())
+		if err != nil {
+			loc = start.Location()
+		}
+		from := start.Add(-time.Second).In(loc)
+		for {
+			times, err := g.scheduler.Next(job.Expression, from, timelineBatch)
+			if err != nil || len(times) == 0 {
+				break
+			}
+			done := false
+			for _, t := range times {
+				if t.IsZero() || !t.Before(end) {
+					done = true
+					break
+				}
+				timeline.AddJobRun(id, t.In(start.Location()))
+			}
+			if done {
+				break
+			}
+			from = times[len(times)-1]
+		}
+	}
+	return timeline.RenderJSON()
+}
@@ -0,0 +1,78 @@
+package doc
+
+import (
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// CodeDocsURL is the diagnostic code reference that warning badges link to.
+// Each code is documented under an anchor named after the lowercase code (e.g., #cron-001).
+const CodeDocsURL = "https://github.com/hzerrad/cronkit/blob/main/docs/DIAGNOSTIC_CODES.md"
+
+// Warning is a check engine issue attached to a job or to the whole document
+type Warning struct {
+	Severity check.Severity
+	Code     string
+	Message  string
+	Hint     string `json:",omitempty"`
+}
+
+// Link returns the documentation URL for the warning's diagnostic code
+func (w Warning) Link() string {
+	return CodeDocsURL + "#" + strings.ToLower(w.Code)
+}
+
+// newWarningValidator returns a validator with every check engine rule enabled
+func newWarningValidator(locale string) *check.Validator {
+	validator := check.NewValidator(locale)
+	validator.SetFrequencyChecks(true)
+	validator.SetHygieneChecks(true)
+	validator.SetWarnOnOverlap(true)
+	validator.SetConsolidationChecks(true)
+	return validator
+}
+
+// lineKey identifies a line of one of the sources of a document
+type lineKey struct {
+	source string // Job.Source, empty for single-source documents
+	line   int
+}
+
+// collectWarnings runs the check engine over entries and returns the issues
+// grouped by source and line number, plus those not tied to a single line
+func (g *Generator) collectWarnings(entries []*crontab.Entry) (map[lineKey][]Warning, []Warning) {
+	byLine := make(map[lineKey][]Warning)
+	var global []Warning
+
+	result := g.validator.ValidateEntries(entries)
+	for _, issue := range result.Issues {
+		warning := Warning{
+			Severity: issue.Severity,
+			Code:     issue.Code,
+			Message:  issue.Message,
+			Hint:     issue.Hint,
+		}
+		if issue.LineNumber > 0 {
+			key := lineKey{source: issue.File, line: issue.LineNumber}
+			byLine[key] = append(byLine[key], warning)
+		} else {
+			global = append(global, warning)
+		}
+	}
+
+	return byLine, global
+}
+
+// severityIcon returns the icon used for a severity badge
+func severityIcon(severity check.Severity) string {
+	switch severity {
+	case check.SeverityError:
+		return "❌"
+	case check.SeverityWarn:
+		return "⚠️"
+	default:
+		return "ℹ️"
+	}
+}
@@ -0,0 +1,169 @@
+// Package eventbridge converts between cron expressions and Amazon
+// EventBridge schedule expressions.
+package eventbridge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// Conversion is the result of converting between cron and EventBridge syntax
+type Conversion struct {
+	Input    string   // The expression that was converted
+	Output   []string // Converted expressions (cron to EventBridge may need two rules)
+	Warnings []string // Semantics that could not be represented exactly
+}
+
+// dayNames are the EventBridge day-of-week names indexed by cron value (Sunday=0)
+var dayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// FromCron converts a parsed cron schedule to EventBridge cron(...)
+// expressions. EventBridge requires one of the day fields to be '?', so a
+// schedule that restricts both is converted to two expressions, one per
+// rule, since cron runs when either day field matches.
+func FromCron(schedule *cronx.Schedule) (*Conversion, error) {
+	if schedule.Quartz != nil {
+		return nil, fmt.Errorf("quartz day modifiers (L, W, #) cannot be converted from a cron schedule")
+	}
+
+	conv := &Conversion{Input: schedule.Original}
+	if schedule.HasSeconds() {
+		if values := schedule.Second.Values(); len(values) != 1 || values[0] != 0 {
+			conv.Warnings = append(conv.Warnings, fmt.Sprintf(
+				"seconds %s dropped: EventBridge runs at second 0", schedule.Second.Raw()))
+		}
+	}
+
+	minute := formatField(schedule.Minute, cronx.MinMinute, cronx.MaxMinute, true)
+	hour := formatField(schedule.Hour, cronx.MinHour, cronx.MaxHour, true)
+	month := formatField(schedule.Month, cronx.MinMonth, cronx.MaxMonth, true)
+	day := formatField(schedule.DayOfMonth, cronx.MinDayOfMonth, cronx.MaxDayOfMonth, true)
+	weekdays := formatWeekdays(schedule.DayOfWeek)
+
+	rule := func(dom, dow string) string {
+		return fmt.Sprintf("cron(%s %s %s %s %s *)", minute, hour, dom, month, dow)
+	}
+
+	switch {
+	case !isStar(schedule.DayOfMonth) && !isStar(schedule.DayOfWeek):
+		// Cron matches days with OR when neither day field starts with '*'
+		conv.Output = []string{rule(day, "?"), rule("?", weekdays)}
+	case weekdays == "*":
+		conv.Output = []string{rule(day, "?")}
+	case day == "*":
+		conv.Output = []string{rule("?", weekdays)}
+	default:
+		return nil, fmt.Errorf("schedules restricting both day-of-month and day-of-week (run when both match) cannot be expressed: EventBridge requires one of them to be '?'")
+	}
+	return conv, nil
+}
+
+// ToCron converts an EventBridge cron(...) or rate(...) expression to a
+// 5-field cron expression. Years have no cron equivalent and are dropped
+// with a warning; the L, W and # modifiers and rates whose interval does not
+// divide an hour or a day are rejected.
+func ToCron(expression string) (*Conversion, error) {
+	input := strings.TrimSpace(expression)
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectAWS})
+	schedule, err := parser.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := &Conversion{Input: input}
+	if schedule.Rate > 0 {
+		cron := cronx.RateToStandard(schedule.Rate)
+		if cron == "" {
+			return nil, fmt.Errorf("%s cannot be represented in cron: the interval must divide an hour or a day", input)
+		}
+		conv.Output = []string{cron}
+		conv.Warnings = append(conv.Warnings,
+			"EventBridge counts rate intervals from the creation of the rule; cron runs on the clock")
+		return conv, nil
+	}
+
+	if schedule.Quartz != nil {
+		return nil, fmt.Errorf("day modifiers (L, W, #) in %s cannot be represented in cron", input)
+	}
+	if schedule.Year != nil && !schedule.Year.IsEvery() {
+		conv.Warnings = append(conv.Warnings, fmt.Sprintf(
+			"year restriction %s dropped: cron runs every year", schedule.Year.Raw()))
+	}
+
+	cron := strings.Join([]string{
+		formatField(schedule.Minute, cronx.MinMinute, cronx.MaxMinute, false),
+		formatField(schedule.Hour, cronx.MinHour, cronx.MaxHour, false),
+		formatField(schedule.DayOfMonth, cronx.MinDayOfMonth, cronx.MaxDayOfMonth, false),
+		formatField(schedule.Month, cronx.MinMonth, cronx.MaxMonth, false),
+		formatField(schedule.DayOfWeek, cronx.MinDayOfWeek, cronx.MaxDayOfWeek, false),
+	}, " ")
+	if _, err := cronx.NewParser().Parse(cron); err != nil {
+		return nil, fmt.Errorf("converted expression %q is invalid: %w", cron, err)
+	}
+	conv.Output = []string{cron}
+	return conv, nil
+}
+
+// isStar reports whether a cron day field starts with '*' (or is '?'), which
+// makes cron combine the two day fields with AND
+func isStar(f cronx.Field) bool {
+	return strings.HasPrefix(f.Raw(), "*") || f.Raw() == "?"
+}
+
+// formatField formats the values matched by a field: "*", a step, or a list
+// of values and ranges. EventBridge steps are written "start/step"; cron
+// steps "*/step" or "start-max/step".
+func formatField(f cronx.Field, min, max int, aws bool) string {
+	values := f.Values()
+	if len(values) == max-min+1 {
+		return "*"
+	}
+
+	// A progression that continues to the end of the range is a step
+	if len(values) >= 2 {
+		step := values[1] - values[0]
+		progression := step > 1 && values[len(values)-1]+step > max
+		for i := 2; progression && i < len(values); i++ {
+			progression = values[i]-values[i-1] == step
+		}
+		switch {
+		case progression && aws:
+			return fmt.Sprintf("%d/%d", values[0], step)
+		case progression && values[0] == min:
+			return fmt.Sprintf("*/%d", step)
+		case progression:
+			return fmt.Sprintf("%d-%d/%d", values[0], max, step)
+		}
+	}
+
+	var parts []string
+	for _, run := range cronx.Runs(values) {
+		if run[0] == run[1] {
+			parts = append(parts, fmt.Sprint(run[0]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", run[0], run[1]))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatWeekdays formats a cron day-of-week field using EventBridge day
+// names, which avoids the shift from Sunday=0 to Sunday=1
+func formatWeekdays(f cronx.Field) string {
+	values := f.Values()
+	if len(values) == len(dayNames) {
+		return "*"
+	}
+
+	var parts []string
+	for _, run := range cronx.Runs(values) {
+		if run[0] == run[1] {
+			parts = append(parts, dayNames[run[0]])
+		} else {
+			parts = append(parts, dayNames[run[0]]+"-"+dayNames[run[1]])
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,103 @@
+package eventbridge_test
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/eventbridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromCron(t *testing.T) {
+	parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{"daily", "0 0 * * *", []string{"cron(0 0 * * ? *)"}},
+		{"weekdays", "0 9 * * 1-5", []string{"cron(0 9 ? * MON-FRI *)"}},
+		{"minute step", "*/15 * * * *", []string{"cron(0/15 * * * ? *)"}},
+		{"hour list", "30 8,12,18 * * *", []string{"cron(30 8,12,18 * * ? *)"}},
+		{"month step and day range", "0 0 1-5 */3 *", []string{"cron(0 0 1-5 1/3 ? *)"}},
+		{"weekday list", "0 0 * * 0,6", []string{"cron(0 0 ? * SUN,SAT *)"}},
+		{"alias", "@weekly", []string{"cron(0 0 ? * SUN *)"}},
+		{"both day fields use two rules", "0 0 1,15 * 1", []string{"cron(0 0 1,15 * ? *)", "cron(0 0 ? * MON *)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+
+			conv, err := eventbridge.FromCron(schedule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expression, conv.Input)
+			assert.Equal(t, tt.expected, conv.Output)
+			assert.Empty(t, conv.Warnings)
+		})
+	}
+
+	t.Run("seconds are dropped with a warning", func(t *testing.T) {
+		schedule, err := parser.Parse("30 0 12 * * *")
+		require.NoError(t, err)
+
+		conv, err := eventbridge.FromCron(schedule)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cron(0 12 * * ? *)"}, conv.Output)
+		require.Len(t, conv.Warnings, 1)
+		assert.Contains(t, conv.Warnings[0], "seconds 30 dropped")
+	})
+
+	t.Run("day fields combined with AND are rejected", func(t *testing.T) {
+		schedule, err := parser.Parse("0 0 */2 * 1")
+		require.NoError(t, err)
+
+		_, err = eventbridge.FromCron(schedule)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be expressed")
+	})
+}
+
+func TestToCron(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+		warnings   int
+	}{
+		{"daily", "cron(0 0 * * ? *)", "0 0 * * *", 0},
+		{"without wrapper", "0 9 ? * MON-FRI *", "0 9 * * 1-5", 0},
+		{"numeric weekdays start at SUN=1", "cron(0 9 ? * 2-6 *)", "0 9 * * 1-5", 0},
+		{"steps", "cron(0/15 8-17 ? * * *)", "*/15 8-17 * * *", 0},
+		{"offset step", "cron(5/20 * * * ? *)", "5-59/20 * * * *", 0},
+		{"year is dropped", "cron(0 12 1 1 ? 2027)", "0 12 1 1 *", 1},
+		{"rate", "rate(10 minutes)", "*/10 * * * *", 1},
+		{"daily rate", "rate(1 day)", "0 0 * * *", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := eventbridge.ToCron(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expression, conv.Input)
+			assert.Equal(t, []string{tt.expected}, conv.Output)
+			assert.Len(t, conv.Warnings, tt.warnings)
+		})
+	}
+
+	t.Run("unrepresentable expressions", func(t *testing.T) {
+		for _, expression := range []string{"rate(7 minutes)", "rate(2 days)", "cron(0 12 L * ? *)", "cron(0 12 ? * 6#3 *)"} {
+			_, err := eventbridge.ToCron(expression)
+			require.Error(t, err, expression)
+			assert.Contains(t, err.Error(), "cannot be represented in cron", expression)
+		}
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := eventbridge.ToCron("cron(0 12 * * * *)")
+		require.Error(t, err)
+	})
+}
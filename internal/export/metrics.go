@@ -0,0 +1,114 @@
+// Package export turns crontab analyses into formats consumed by other
+// monitoring tools.
+package export
+
+import (
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// DefaultOverlapWindow is the look-ahead of the overlap metrics
+const DefaultOverlapWindow = 24 * time.Hour
+
+// CrontabMetrics holds the health metrics of one crontab
+type CrontabMetrics struct {
+	Source          string
+	Jobs            []JobMetrics
+	ValidJobs       int
+	InvalidJobs     int
+	Issues          map[check.Severity]int // Validation issues by severity
+	TotalRunsPerDay int
+	OverlapWindow   time.Duration
+	OverlapMinutes  int // Minutes within OverlapWindow in which several jobs start
+	MaxConcurrent   int // Most jobs starting in the same minute within OverlapWindow
+}
+
+// Valid reports whether the crontab passes validation without errors
+func (m *CrontabMetrics) Valid() bool {
+	return m.Issues[check.SeverityError] == 0
+}
+
+// JobMetrics holds the metrics of one job
+type JobMetrics struct {
+	LineNumber int
+	Expression string
+	Command    string
+	Valid      bool
+	RunsPerDay int
+	Issues     map[check.Severity]int // Validation issues reported on the job's line, by severity
+}
+
+// Options configures metric collection
+type Options struct {
+	Locale        string        // Locale for day and month names (default: "en")
+	OverlapWindow time.Duration // Look-ahead for overlap metrics (default: DefaultOverlapWindow)
+}
+
+// Collect computes the metrics of the crontab entries read from source
+func Collect(entries []*crontab.Entry, source string, opts Options) *CrontabMetrics {
+	locale := opts.Locale
+	if locale == "" {
+		locale = "en"
+	}
+	window := opts.OverlapWindow
+	if window <= 0 {
+		window = DefaultOverlapWindow
+	}
+
+	m := &CrontabMetrics{
+		Source:        source,
+		Jobs:          []JobMetrics{},
+		Issues:        map[check.Severity]int{},
+		OverlapWindow: window,
+	}
+
+	issuesByLine := make(map[int]map[check.Severity]int)
+	for _, issue := range check.NewValidator(locale).ValidateEntries(entries).Issues {
+		m.Issues[issue.Severity]++
+		if issuesByLine[issue.LineNumber] == nil {
+			issuesByLine[issue.LineNumber] = map[check.Severity]int{}
+		}
+		issuesByLine[issue.LineNumber][issue.Severity]++
+	}
+
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob && entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+
+	parser := cronx.NewParserWithLocale(locale)
+	scheduler := cronx.NewScheduler()
+	for _, job := range jobs {
+		jm := JobMetrics{
+			LineNumber: job.LineNumber,
+			Expression: job.Expression,
+			Command:    job.Command,
+			Valid:      job.Valid,
+			Issues:     issuesByLine[job.LineNumber],
+		}
+		if jm.Issues == nil {
+			jm.Issues = map[check.Severity]int{}
+		}
+		if job.Valid {
+			if runs, err := check.CalculateRunsPerDay(job.Expression, scheduler); err == nil {
+				jm.RunsPerDay = runs
+			}
+			m.ValidJobs++
+		} else {
+			m.InvalidJobs++
+		}
+		m.TotalRunsPerDay += jm.RunsPerDay
+		m.Jobs = append(m.Jobs, jm)
+	}
+
+	if _, overlaps, err := check.AnalyzeOverlaps(jobs, window, scheduler, parser); err == nil {
+		m.OverlapMinutes = overlaps.TotalWindows
+		m.MaxConcurrent = overlaps.MaxConcurrent
+	}
+	return m
+}
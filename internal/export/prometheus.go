@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/check"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// severities lists the issue severities in exposition order
+var severities = []check.Severity{check.SeverityError, check.SeverityWarn, check.SeverityInfo}
+
+// label is a Prometheus label name and value
+type label struct {
+	name, value string
+}
+
+// promWriter writes metric families in the Prometheus text format
+type promWriter struct {
+	w *bufio.Writer
+}
+
+func (p *promWriter) family(name, help string) {
+	_, _ = fmt.Fprintf(p.w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func (p *promWriter) sample(name string, value float64, labels ...label) {
+	_, _ = p.w.WriteString(name)
+	if len(labels) > 0 {
+		_ = p.w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				_ = p.w.WriteByte(',')
+			}
+			_, _ = fmt.Fprintf(p.w, "%s=\"%s\"", l.name, escapeLabel(l.value))
+		}
+		_ = p.w.WriteByte('}')
+	}
+	_, _ = fmt.Fprintf(p.w, " %s\n", strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// escapeLabel escapes a label value: backslash, double quote and line feed
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// boolValue returns 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// WritePrometheus writes the metrics of the crontabs in the Prometheus text
+// exposition format. Each series is labeled with the crontab's source; job
+// series also carry the line number, expression and command.
+func WritePrometheus(w io.Writer, crontabs []*CrontabMetrics) error {
+	p := &promWriter{w: bufio.NewWriter(w)}
+	source := func(m *CrontabMetrics) label { return label{"source", m.Source} }
+	jobLabels := func(m *CrontabMetrics, j JobMetrics) []label {
+		return []label{source(m), {"line", strconv.Itoa(j.LineNumber)}, {"expression", j.Expression}, {"command", j.Command}}
+	}
+
+	p.family("cronkit_crontab_valid", "Whether the crontab passes validation without errors (1) or not (0).")
+	for _, m := range crontabs {
+		p.sample("cronkit_crontab_valid", boolValue(m.Valid()), source(m))
+	}
+
+	p.family("cronkit_crontab_jobs", "Number of jobs in the crontab, by schedule validity.")
+	for _, m := range crontabs {
+		p.sample("cronkit_crontab_jobs", float64(m.ValidJobs), source(m), label{"status", "valid"})
+		p.sample("cronkit_crontab_jobs", float64(m.InvalidJobs), source(m), label{"status", "invalid"})
+	}
+
+	p.family("cronkit_crontab_issues", "Number of validation issues in the crontab, by severity.")
+	for _, m := range crontabs {
+		for _, severity := range severities {
+			p.sample("cronkit_crontab_issues", float64(m.Issues[severity]), source(m), label{"severity", severity.String()})
+		}
+	}
+
+	p.family("cronkit_crontab_runs_per_day", "Scheduled runs per day of all jobs in the crontab.")
+	for _, m := range crontabs {
+		p.sample("cronkit_crontab_runs_per_day", float64(m.TotalRunsPerDay), source(m))
+	}
+
+	p.family("cronkit_crontab_overlap_minutes", "Minutes within the overlap window in which more than one job starts.")
+	for _, m := range crontabs {
+		p.sample("cronkit_crontab_overlap_minutes", float64(m.OverlapMinutes), source(m))
+	}
+
+	p.family("cronkit_crontab_max_concurrent_jobs", "Most jobs starting in the same minute within the overlap window.")
+	for _, m := range crontabs {
+		p.sample("cronkit_crontab_max_concurrent_jobs", float64(m.MaxConcurrent), source(m))
+	}
+
+	p.family("cronkit_crontab_overlap_window_seconds", "Look-ahead of the overlap metrics, in seconds.")
+	for _, m := range crontabs {
+		p.sample("cronkit_crontab_overlap_window_seconds", m.OverlapWindow.Seconds(), source(m))
+	}
+
+	p.family("cronkit_job_valid", "Whether the job's schedule is valid (1) or not (0).")
+	for _, m := range crontabs {
+		for _, j := range m.Jobs {
+			p.sample("cronkit_job_valid", boolValue(j.Valid), jobLabels(m, j)...)
+		}
+	}
+
+	p.family("cronkit_job_runs_per_day", "Scheduled runs per day of the job.")
+	for _, m := range crontabs {
+		for _, j := range m.Jobs {
+			p.sample("cronkit_job_runs_per_day", float64(j.RunsPerDay), jobLabels(m, j)...)
+		}
+	}
+
+	p.family("cronkit_job_issues", "Number of validation issues reported on the job, by severity.")
+	for _, m := range crontabs {
+		for _, j := range m.Jobs {
+			for _, severity := range severities {
+				p.sample("cronkit_job_issues", float64(j.Issues[severity]), append(jobLabels(m, j), label{"severity", severity.String()})...)
+			}
+		}
+	}
+
+	return p.w.Flush()
+}
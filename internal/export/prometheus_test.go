@@ -0,0 +1,94 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, content string) []*crontab.Entry {
+	t.Helper()
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	return entries
+}
+
+func TestCollect(t *testing.T) {
+	entries := parse(t, strings.Join([]string{
+		"SHELL=/bin/sh",
+		"*/5 * * * * /usr/bin/poll.sh",
+		"0 * * * * /usr/bin/hourly.sh",
+		"61 * * * * /usr/bin/broken.sh",
+	}, "\n"))
+
+	m := Collect(entries, "jobs.cron", Options{})
+	assert.Equal(t, "jobs.cron", m.Source)
+	assert.Equal(t, 2, m.ValidJobs)
+	assert.Equal(t, 1, m.InvalidJobs)
+	assert.False(t, m.Valid())
+	assert.Equal(t, 1, m.Issues[check.SeverityError])
+	assert.Equal(t, 288+24, m.TotalRunsPerDay)
+	assert.Equal(t, DefaultOverlapWindow, m.OverlapWindow)
+	assert.Equal(t, 24, m.OverlapMinutes, "the hourly job collides with the poll every hour")
+	assert.Equal(t, 2, m.MaxConcurrent)
+
+	require.Len(t, m.Jobs, 3)
+	assert.Equal(t, JobMetrics{LineNumber: 2, Expression: "*/5 * * * *", Command: "/usr/bin/poll.sh", Valid: true, RunsPerDay: 288, Issues: map[check.Severity]int{}}, m.Jobs[0])
+	assert.False(t, m.Jobs[2].Valid)
+	assert.Equal(t, 0, m.Jobs[2].RunsPerDay)
+	assert.Equal(t, 1, m.Jobs[2].Issues[check.SeverityError])
+
+	t.Run("overlap window", func(t *testing.T) {
+		m := Collect(entries, "jobs.cron", Options{OverlapWindow: time.Hour})
+		assert.Equal(t, time.Hour, m.OverlapWindow)
+		assert.LessOrEqual(t, m.OverlapMinutes, 1)
+	})
+
+	t.Run("empty crontab", func(t *testing.T) {
+		m := Collect(nil, "empty.cron", Options{})
+		assert.True(t, m.Valid())
+		assert.Empty(t, m.Jobs)
+		assert.Zero(t, m.MaxConcurrent)
+	})
+}
+
+func TestWritePrometheus(t *testing.T) {
+	m := Collect(parse(t, "0 2 * * * /usr/bin/backup.sh \"nightly\"\n"), `/etc/cron.d/back\up`, Options{})
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheus(&buf, []*CrontabMetrics{m}))
+	output := buf.String()
+
+	assert.Contains(t, output, "# HELP cronkit_crontab_valid ")
+	assert.Contains(t, output, "# TYPE cronkit_crontab_valid gauge\n")
+	assert.Contains(t, output, `cronkit_crontab_valid{source="/etc/cron.d/back\\up"} 1`+"\n")
+	assert.Contains(t, output, `cronkit_crontab_jobs{source="/etc/cron.d/back\\up",status="valid"} 1`+"\n")
+	assert.Contains(t, output, `cronkit_crontab_issues{source="/etc/cron.d/back\\up",severity="error"} 0`+"\n")
+	assert.Contains(t, output, `cronkit_crontab_overlap_window_seconds{source="/etc/cron.d/back\\up"} 86400`+"\n")
+	assert.Contains(t, output, `cronkit_job_runs_per_day{source="/etc/cron.d/back\\up",line="1",expression="0 2 * * *",command="/usr/bin/backup.sh \"nightly\""} 1`+"\n")
+	assert.Contains(t, output, `command="/usr/bin/backup.sh \"nightly\"",severity="warn"} 0`+"\n")
+
+	// Every sample belongs to a declared family
+	families := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if name, ok := strings.CutPrefix(line, "# TYPE "); ok {
+			families[strings.Fields(name)[0]] = true
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := line[:strings.IndexAny(line, "{ ")]
+		assert.True(t, families[name], "sample %q before its TYPE line", line)
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	assert.Equal(t, `a\\b\"c\nd`, escapeLabel("a\\b\"c\nd"))
+}
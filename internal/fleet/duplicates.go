@@ -0,0 +1,149 @@
+package fleet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// DefaultMinHosts is the default number of hosts a command must run on to be
+// reported as duplicated
+const DefaultMinHosts = 2
+
+// Occurrence is one copy of a duplicated job
+type Occurrence struct {
+	Host       string `json:"host"`
+	LineNumber int    `json:"lineNumber"`
+	Expression string `json:"expression"`
+	Command    string `json:"command"`
+}
+
+// ScheduleVariant is one of the schedules a duplicated command runs on, with
+// the hosts using it
+type ScheduleVariant struct {
+	Expression string   `json:"expression"`
+	Hosts      []string `json:"hosts"`
+}
+
+// Duplicate is a normalized command found on several hosts
+type Duplicate struct {
+	Command     string            `json:"command"`
+	Hosts       []string          `json:"hosts"`
+	Schedules   []ScheduleVariant `json:"schedules"` // Most widely used first
+	Drifted     bool              `json:"drifted"`   // True when the hosts use different schedules
+	Occurrences []Occurrence      `json:"occurrences"`
+}
+
+// DuplicateOptions configures duplicate detection
+type DuplicateOptions struct {
+	Normalizer        *Normalizer // Command normalizer (default: no rewrites)
+	MinHosts          int         // Minimum number of hosts (default: DefaultMinHosts)
+	IncludeConsistent bool        // Also report duplicates whose schedules all agree
+}
+
+// FindDuplicates reports normalized commands that run on at least
+// opts.MinHosts hosts. Schedules are compared by the times they match, so
+// "@daily" and "0 0 * * *" are the same schedule. Unless
+// opts.IncludeConsistent is set, only drifted duplicates are reported.
+// Results are ordered by number of hosts, then command.
+func FindDuplicates(hosts []*Host, opts DuplicateOptions) []Duplicate {
+	normalizer := opts.Normalizer
+	if normalizer == nil {
+		normalizer = NewNormalizer(nil)
+	}
+	minHosts := opts.MinHosts
+	if minHosts < 1 {
+		minHosts = DefaultMinHosts
+	}
+
+	parser := cronx.NewParser()
+	occurrences := make(map[string][]Occurrence)
+	scheduleKeys := make(map[string][]string)
+	for _, host := range hosts {
+		for _, job := range host.Jobs {
+			if job == nil || !job.Valid {
+				continue
+			}
+			command := normalizer.Normalize(job.Command, host.Name)
+			if command == "" {
+				continue
+			}
+			schedule, err := parser.Parse(job.Expression)
+			if err != nil {
+				continue
+			}
+			occurrences[command] = append(occurrences[command], Occurrence{
+				Host:       host.Name,
+				LineNumber: job.LineNumber,
+				Expression: job.Expression,
+				Command:    job.Command,
+			})
+			scheduleKeys[command] = append(scheduleKeys[command], scheduleKey(schedule))
+		}
+	}
+
+	var duplicates []Duplicate
+	for command, occs := range occurrences {
+		dup := newDuplicate(command, occs, scheduleKeys[command])
+		if len(dup.Hosts) < minHosts || (!dup.Drifted && !opts.IncludeConsistent) {
+			continue
+		}
+		duplicates = append(duplicates, dup)
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if len(duplicates[i].Hosts) != len(duplicates[j].Hosts) {
+			return len(duplicates[i].Hosts) > len(duplicates[j].Hosts)
+		}
+		return duplicates[i].Command < duplicates[j].Command
+	})
+	return duplicates
+}
+
+// newDuplicate groups the occurrences of a command by schedule
+func newDuplicate(command string, occs []Occurrence, keys []string) Duplicate {
+	dup := Duplicate{Command: command, Occurrences: occs}
+
+	hostSeen := make(map[string]bool)
+	variantIndex := make(map[string]int)
+	for i, occ := range occs {
+		if !hostSeen[occ.Host] {
+			hostSeen[occ.Host] = true
+			dup.Hosts = append(dup.Hosts, occ.Host)
+		}
+
+		idx, ok := variantIndex[keys[i]]
+		if !ok {
+			idx = len(dup.Schedules)
+			variantIndex[keys[i]] = idx
+			dup.Schedules = append(dup.Schedules, ScheduleVariant{Expression: occ.Expression})
+		}
+		variant := &dup.Schedules[idx]
+		if len(variant.Hosts) == 0 || variant.Hosts[len(variant.Hosts)-1] != occ.Host {
+			variant.Hosts = append(variant.Hosts, occ.Host)
+		}
+	}
+
+	sort.SliceStable(dup.Schedules, func(i, j int) bool {
+		return len(dup.Schedules[i].Hosts) > len(dup.Schedules[j].Hosts)
+	})
+	dup.Drifted = len(dup.Schedules) > 1
+	return dup
+}
+
+// scheduleKey identifies a schedule by the values of its fields, so that
+// equivalent expressions share a key
+func scheduleKey(s *cronx.Schedule) string {
+	fields := []cronx.Field{s.Minute, s.Hour, s.DayOfMonth, s.Month, s.DayOfWeek}
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = strings.Trim(fmt.Sprint(f.Values()), "[]")
+	}
+	// Restricting both day fields switches cron to OR semantics
+	if !strings.HasPrefix(s.DayOfMonth.Raw(), "*") && !strings.HasPrefix(s.DayOfWeek.Raw(), "*") {
+		parts = append(parts, "or")
+	}
+	return strings.Join(parts, "|")
+}
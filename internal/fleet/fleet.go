@@ -0,0 +1,73 @@
+// Package fleet analyzes the crontabs of many hosts together
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Host is a machine of the fleet and the jobs of its crontab
+type Host struct {
+	Name string         // Host name, derived from the crontab file name
+	Path string         // Path of the crontab file
+	Jobs []*crontab.Job // Jobs of the crontab
+}
+
+// LoadHosts reads one crontab per host. A file is a single host named after
+// the file without its extension (web01.cron is host web01); a directory
+// contributes one host per regular file it contains.
+func LoadHosts(paths []string) ([]*Host, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		dirEntries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", path, err)
+		}
+		for _, entry := range dirEntries {
+			if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	reader := crontab.NewReader()
+	hosts := make([]*Host, 0, len(files))
+	seen := make(map[string]string)
+	for _, file := range files {
+		name := HostName(file)
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("host %q is defined by both %s and %s", name, other, file)
+		}
+		seen[name] = file
+
+		jobs, err := reader.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crontab file %s: %w", file, err)
+		}
+		hosts = append(hosts, &Host{Name: name, Path: file, Jobs: jobs})
+	}
+
+	sort.SliceStable(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+	return hosts, nil
+}
+
+// HostName returns the host name for a crontab file: its base name without
+// the extension
+func HostName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
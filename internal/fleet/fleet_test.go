@@ -0,0 +1,129 @@
+package fleet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fleetDir = filepath.Join("..", "..", "testdata", "fleet")
+
+func TestLoadHosts(t *testing.T) {
+	t.Run("loads a directory, one host per file", func(t *testing.T) {
+		hosts, err := LoadHosts([]string{fleetDir})
+		require.NoError(t, err)
+		require.Len(t, hosts, 3)
+		assert.Equal(t, "db01", hosts[0].Name)
+		assert.Equal(t, "web01", hosts[1].Name)
+		assert.Equal(t, "web02", hosts[2].Name)
+		assert.Len(t, hosts[1].Jobs, 3)
+	})
+
+	t.Run("loads individual files", func(t *testing.T) {
+		hosts, err := LoadHosts([]string{filepath.Join(fleetDir, "web02.cron"), filepath.Join(fleetDir, "db01.cron")})
+		require.NoError(t, err)
+		require.Len(t, hosts, 2)
+		assert.Equal(t, "db01", hosts[0].Name)
+		assert.Equal(t, filepath.Join(fleetDir, "db01.cron"), hosts[0].Path)
+	})
+
+	t.Run("rejects duplicate host names", func(t *testing.T) {
+		dir := t.TempDir()
+		other := filepath.Join(dir, "web01.txt")
+		require.NoError(t, os.WriteFile(other, []byte("0 * * * * /bin/true\n"), 0o600))
+
+		_, err := LoadHosts([]string{fleetDir, other})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `host "web01"`)
+	})
+
+	t.Run("reports missing paths", func(t *testing.T) {
+		_, err := LoadHosts([]string{"/nonexistent/fleet"})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseRewrite(t *testing.T) {
+	rw, err := ParseRewrite(`/home/[^/]+/=>/home/USER/`)
+	require.NoError(t, err)
+	assert.Equal(t, "/home/USER/bin/x", rw.Pattern.ReplaceAllString("/home/deploy/bin/x", rw.Replacement))
+
+	rw, err = ParseRewrite(`\s+--quiet=>`)
+	require.NoError(t, err)
+	assert.Empty(t, rw.Replacement)
+
+	for _, spec := range []string{"no-separator", "=>empty", "([=>x"} {
+		_, err := ParseRewrite(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNormalizer_Normalize(t *testing.T) {
+	rw, err := ParseRewrite(`/home/[^/]+/=>/home/USER/`)
+	require.NoError(t, err)
+	n := NewNormalizer([]Rewrite{rw})
+
+	assert.Equal(t, "/srv/{host}/bin/backup.sh --quiet", n.Normalize("/srv/web02/bin/backup.sh   --quiet", "web02"))
+	assert.Equal(t, "/home/USER/bin/healthcheck.sh", n.Normalize("/home/deploy/bin/healthcheck.sh", "web01"))
+	assert.Equal(t, "/bin/true", n.Normalize(" /bin/true ", ""))
+	assert.Equal(t, "/bin/job", n.Normalize("/bin/job", "b"), "host names only match whole words")
+}
+
+func TestFindDuplicates(t *testing.T) {
+	hosts, err := LoadHosts([]string{fleetDir})
+	require.NoError(t, err)
+
+	t.Run("reports drifted duplicates only by default", func(t *testing.T) {
+		dups := FindDuplicates(hosts, DuplicateOptions{})
+		require.Len(t, dups, 1)
+
+		dup := dups[0]
+		assert.Equal(t, "/srv/{host}/bin/backup.sh --quiet", dup.Command)
+		assert.Equal(t, []string{"db01", "web01", "web02"}, dup.Hosts)
+		assert.True(t, dup.Drifted)
+		assert.Equal(t, []ScheduleVariant{
+			{Expression: "0 2 * * *", Hosts: []string{"web01", "web02"}},
+			{Expression: "30 3 * * *", Hosts: []string{"db01"}},
+		}, dup.Schedules)
+		assert.Len(t, dup.Occurrences, 3)
+	})
+
+	t.Run("treats equivalent expressions as the same schedule", func(t *testing.T) {
+		dups := FindDuplicates(hosts, DuplicateOptions{IncludeConsistent: true})
+		require.Len(t, dups, 2)
+
+		logrotate := dups[1]
+		assert.Equal(t, "/usr/sbin/logrotate /etc/logrotate.conf", logrotate.Command)
+		assert.False(t, logrotate.Drifted)
+		require.Len(t, logrotate.Schedules, 1)
+		assert.Len(t, logrotate.Schedules[0].Hosts, 3)
+	})
+
+	t.Run("applies rewrites", func(t *testing.T) {
+		rw, err := ParseRewrite(`/home/[^/]+/=>/home/USER/`)
+		require.NoError(t, err)
+
+		dups := FindDuplicates(hosts, DuplicateOptions{Normalizer: NewNormalizer([]Rewrite{rw})})
+		require.Len(t, dups, 2)
+		assert.Equal(t, "/home/USER/bin/healthcheck.sh", dups[1].Command)
+		assert.Equal(t, []string{"web01", "web02"}, dups[1].Hosts)
+	})
+
+	t.Run("honours the minimum number of hosts", func(t *testing.T) {
+		dups := FindDuplicates(hosts, DuplicateOptions{MinHosts: 4})
+		assert.Empty(t, dups)
+	})
+
+	t.Run("compares day-of-month/day-of-week OR semantics", func(t *testing.T) {
+		// Both fields match the same values, but 1-31 makes cron run every day
+		a := &Host{Name: "a", Jobs: []*crontab.Job{{Expression: "0 0 1-31 * 1", Command: "/bin/job", Valid: true}}}
+		b := &Host{Name: "b", Jobs: []*crontab.Job{{Expression: "0 0 * * 1", Command: "/bin/job", Valid: true}}}
+		dups := FindDuplicates([]*Host{a, b}, DuplicateOptions{})
+		require.Len(t, dups, 1)
+		assert.True(t, dups[0].Drifted)
+	})
+}
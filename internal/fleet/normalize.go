@@ -0,0 +1,59 @@
+package fleet
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// HostPlaceholder replaces the host name in normalized commands
+const HostPlaceholder = "{host}"
+
+// Rewrite is a regular expression substitution applied to commands before
+// they are compared across hosts
+type Rewrite struct {
+	Pattern     *regexp.Regexp
+	Replacement string // May reference capture groups as $1 or ${name}
+}
+
+// ParseRewrite parses a rewrite written as "pattern=>replacement", e.g.
+// `/home/[^/]+/=>/home/USER/`. The replacement may be empty.
+func ParseRewrite(spec string) (Rewrite, error) {
+	pattern, replacement, ok := strings.Cut(spec, "=>")
+	if !ok {
+		return Rewrite{}, fmt.Errorf("invalid rewrite %q: expected pattern=>replacement", spec)
+	}
+	if pattern == "" {
+		return Rewrite{}, fmt.Errorf("invalid rewrite %q: empty pattern", spec)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Rewrite{}, fmt.Errorf("invalid rewrite pattern %q: %w", pattern, err)
+	}
+	return Rewrite{Pattern: re, Replacement: replacement}, nil
+}
+
+// Normalizer turns commands into a host-independent form so copies of the
+// same job on different hosts compare equal
+type Normalizer struct {
+	rewrites []Rewrite
+}
+
+// NewNormalizer creates a normalizer applying rewrites in order
+func NewNormalizer(rewrites []Rewrite) *Normalizer {
+	return &Normalizer{rewrites: rewrites}
+}
+
+// Normalize returns the normalized form of a command run on host: the host
+// name (as a whole word) is replaced by HostPlaceholder, the rewrites are applied in order, and
+// runs of whitespace are collapsed.
+func (n *Normalizer) Normalize(command, host string) string {
+	if host != "" {
+		hostPattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(host) + `\b`)
+		command = hostPattern.ReplaceAllLiteralString(command, HostPlaceholder)
+	}
+	for _, rw := range n.rewrites {
+		command = rw.Pattern.ReplaceAllString(command, rw.Replacement)
+	}
+	return strings.Join(strings.Fields(command), " ")
+}
@@ -0,0 +1,98 @@
+// Package history records the runs of cron jobs in the state directory
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/state"
+)
+
+// Schema describes run records in the state directory
+var Schema = state.Schema{Name: "run", Version: 1}
+
+// LogName is the run log, relative to the state directory
+var LogName = filepath.Join(state.RunsDir, "runs.log")
+
+// Record describes one run of a job
+type Record struct {
+	Name       string    `json:"name,omitempty"`       // Name from the job's cronkit:name directive (optional)
+	Command    string    `json:"command"`              // Command as written in the crontab
+	Expression string    `json:"expression,omitempty"` // Schedule of the job (optional)
+	Scheduled  time.Time `json:"scheduled,omitzero"`   // Minute the run was due (optional)
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	ExitCode   int       `json:"exitCode"`
+}
+
+// Duration returns how long the run took
+func (r Record) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Append adds rec to the run log of store
+func Append(store *state.Store, rec Record) error {
+	return store.Append(LogName, Schema, rec)
+}
+
+// Load returns the run records in the run log of store, oldest first
+func Load(store *state.Store) ([]Record, error) {
+	var records []Record
+	err := store.ReadLog(LogName, Schema, func(data json.RawMessage) error {
+		var rec Record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return fmt.Errorf("failed to decode run record: %w", err)
+		}
+		records = append(records, rec)
+		return nil
+	})
+	return records, err
+}
+
+// ReadRecords reads run records written one JSON object per line, as
+// exported from the run log or produced by a wrapper script. Blank lines
+// are skipped.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: invalid run record: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read run records: %w", err)
+	}
+	return records, nil
+}
+
+// ReadFile returns the run records of the file at path, written as for
+// ReadRecords, or those of the run log in the default state directory when
+// path is empty
+func ReadFile(path string) ([]Record, error) {
+	if path == "" {
+		store, err := state.OpenDefault()
+		if err != nil {
+			return nil, err
+		}
+		return Load(store)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open run history: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return ReadRecords(f)
+}
@@ -0,0 +1,79 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendLoad(t *testing.T) {
+	store, err := state.Open(t.TempDir())
+	require.NoError(t, err)
+
+	records, err := Load(store)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	start := time.Date(2026, 10, 16, 2, 0, 4, 0, time.UTC)
+	first := Record{Command: "/usr/bin/backup.sh", Expression: "0 2 * * *", Start: start, End: start.Add(14 * time.Minute)}
+	second := Record{Command: "/usr/bin/report.sh", Start: start, End: start.Add(time.Second), ExitCode: 1}
+	require.NoError(t, Append(store, first))
+	require.NoError(t, Append(store, second))
+
+	records, err = Load(store)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "/usr/bin/backup.sh", records[0].Command)
+	assert.True(t, records[0].Start.Equal(start))
+	assert.Equal(t, 14*time.Minute, records[0].Duration())
+	assert.Equal(t, 1, records[1].ExitCode)
+}
+
+func TestReadRecords(t *testing.T) {
+	t.Run("valid records", func(t *testing.T) {
+		records, err := ReadRecords(strings.NewReader(`{"command":"a.sh","start":"2026-10-16T02:00:04Z","end":"2026-10-16T02:01:04Z","exitCode":0}
+
+{"command":"b.sh","scheduled":"2026-10-16T03:00:00Z","start":"2026-10-16T03:05:00Z","end":"2026-10-16T03:06:00Z","exitCode":2}
+`))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, time.Minute, records[0].Duration())
+		assert.True(t, records[0].Scheduled.IsZero())
+		assert.Equal(t, 3, records[1].Scheduled.Hour())
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		_, err := ReadRecords(strings.NewReader("{\"command\":\"a.sh\"}\nnot json\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+}
+
+func TestReadFile(t *testing.T) {
+	t.Run("records file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "runs.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte(`{"command":"a.sh","start":"2026-10-16T02:00:04Z","end":"2026-10-16T02:01:04Z","exitCode":0}`+"\n"), 0o644))
+		records, err := ReadFile(path)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "a.sh", records[0].Command)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadFile(filepath.Join(t.TempDir(), "missing.jsonl"))
+		assert.ErrorContains(t, err, "failed to open run history")
+	})
+
+	t.Run("state directory", func(t *testing.T) {
+		t.Setenv(state.EnvStateDir, t.TempDir())
+		records, err := ReadFile("")
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
@@ -0,0 +1,97 @@
+package history
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Summary describes the recorded runs of one job
+type Summary struct {
+	Name       string // Job name, if recorded
+	Command    string
+	Expression string
+	Runs       int
+	Failures   int // Runs with a non-zero exit code
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	Last       Record // Most recent run
+}
+
+// SuccessRate returns the share of runs that exited with code 0, from 0 to 1
+func (s Summary) SuccessRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Runs-s.Failures) / float64(s.Runs)
+}
+
+// Summarize groups records by job (command and expression) and summarizes
+// each group, ordered by command
+func Summarize(records []Record) []Summary {
+	type key struct{ command, expression string }
+	groups := map[key][]Record{}
+	var keys []key
+	for _, rec := range records {
+		k := key{rec.Command, rec.Expression}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], rec)
+	}
+	slices.SortFunc(keys, func(a, b key) int {
+		if c := strings.Compare(a.command, b.command); c != 0 {
+			return c
+		}
+		return strings.Compare(a.expression, b.expression)
+	})
+
+	summaries := make([]Summary, 0, len(keys))
+	for _, k := range keys {
+		summaries = append(summaries, summarize(groups[k]))
+	}
+	return summaries
+}
+
+// summarize summarizes the runs of one job
+func summarize(records []Record) Summary {
+	s := Summary{Command: records[0].Command, Expression: records[0].Expression, Runs: len(records)}
+	durations := make([]time.Duration, len(records))
+	for i, rec := range records {
+		durations[i] = rec.Duration()
+		if rec.ExitCode != 0 {
+			s.Failures++
+		}
+		if rec.Name != "" {
+			s.Name = rec.Name
+		}
+		if i == 0 || rec.Start.After(s.Last.Start) {
+			s.Last = rec
+		}
+	}
+	slices.Sort(durations)
+	s.P50 = Percentile(durations, 50)
+	s.P90 = Percentile(durations, 90)
+	s.P99 = Percentile(durations, 99)
+	s.Max = durations[len(durations)-1]
+	return s
+}
+
+// Percentile returns the p-th percentile (0 to 100) of sorted durations by
+// the nearest-rank method, or 0 for no durations
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// Matches reports whether rec is a run of the job selected by selector: its
+// recorded name, or a part of its command
+func (r Record) Matches(selector string) bool {
+	return r.Name == selector || strings.Contains(r.Command, selector)
+}
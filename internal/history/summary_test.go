@@ -0,0 +1,57 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, time.Duration(5), Percentile(durations, 50))
+	assert.Equal(t, time.Duration(9), Percentile(durations, 90))
+	assert.Equal(t, time.Duration(10), Percentile(durations, 99))
+	assert.Equal(t, time.Duration(1), Percentile(durations, 0))
+	assert.Equal(t, time.Duration(0), Percentile(nil, 50))
+}
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC)
+	run := func(command string, day int, took time.Duration, exitCode int) Record {
+		s := start.AddDate(0, 0, day)
+		return Record{Command: command, Expression: "0 2 * * *", Start: s, End: s.Add(took), ExitCode: exitCode}
+	}
+	records := []Record{
+		run("/usr/bin/report", 0, time.Second, 0),
+		run("/usr/bin/backup", 2, 12*time.Minute, 1),
+		run("/usr/bin/backup", 0, 10*time.Minute, 0),
+		run("/usr/bin/backup", 1, 11*time.Minute, 0),
+	}
+	records[1].Name = "backup"
+
+	summaries := Summarize(records)
+	require.Len(t, summaries, 2)
+
+	backup := summaries[0]
+	assert.Equal(t, "/usr/bin/backup", backup.Command)
+	assert.Equal(t, "backup", backup.Name)
+	assert.Equal(t, 3, backup.Runs)
+	assert.Equal(t, 1, backup.Failures)
+	assert.InDelta(t, 2.0/3, backup.SuccessRate(), 1e-9)
+	assert.Equal(t, 11*time.Minute, backup.P50)
+	assert.Equal(t, 12*time.Minute, backup.P99)
+	assert.Equal(t, 12*time.Minute, backup.Max)
+	assert.Equal(t, 1, backup.Last.ExitCode, "the most recent run is last")
+
+	assert.Equal(t, "/usr/bin/report", summaries[1].Command)
+	assert.Equal(t, 1.0, summaries[1].SuccessRate())
+}
+
+func TestRecordMatches(t *testing.T) {
+	rec := Record{Name: "backup", Command: "/usr/local/bin/db-dump --all"}
+	assert.True(t, rec.Matches("backup"))
+	assert.True(t, rec.Matches("db-dump"))
+	assert.False(t, rec.Matches("report"))
+}
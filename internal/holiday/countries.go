@@ -0,0 +1,178 @@
+package holiday
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// countries are the built-in calendars, by ISO 3166 country code
+var countries = map[string]struct {
+	name  string
+	rules func(year int) []Holiday
+}{
+	"DE": {"Germany", germany},
+	"FR": {"France", france},
+	"GB": {"United Kingdom (England and Wales)", unitedKingdom},
+	"US": {"United States (federal)", unitedStates},
+}
+
+// countryAliases are other codes accepted for built-in calendars
+var countryAliases = map[string]string{
+	"UK": "GB",
+}
+
+// Country returns the built-in calendar of a country code, in any case
+func Country(code string) (*Calendar, bool) {
+	code = strings.ToUpper(code)
+	if alias, ok := countryAliases[code]; ok {
+		code = alias
+	}
+	country, ok := countries[code]
+	if !ok {
+		return nil, false
+	}
+	return &Calendar{Name: country.name, rules: country.rules}, true
+}
+
+// Countries returns the codes of the built-in calendars, sorted
+func Countries() []string {
+	codes := make([]string, 0, len(countries))
+	for code := range countries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// unitedStates returns the federal holidays of a year, with the Friday or
+// Monday observed when one falls on a weekend
+func unitedStates(year int) []Holiday {
+	holidays := []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day"},
+		{nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday"},
+		{nthWeekday(year, time.May, time.Monday, -1), "Memorial Day"},
+		{date(year, time.July, 4), "Independence Day"},
+		{nthWeekday(year, time.September, time.Monday, 1), "Labor Day"},
+		{nthWeekday(year, time.October, time.Monday, 2), "Columbus Day"},
+		{date(year, time.November, 11), "Veterans Day"},
+		{nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+	}
+	if year >= 2021 {
+		holidays = append(holidays, Holiday{date(year, time.June, 19), "Juneteenth National Independence Day"})
+	}
+	for _, h := range holidays {
+		switch h.Date.Weekday() {
+		case time.Saturday:
+			holidays = append(holidays, Holiday{h.Date.AddDate(0, 0, -1), h.Name + " (observed)"})
+		case time.Sunday:
+			holidays = append(holidays, Holiday{h.Date.AddDate(0, 0, 1), h.Name + " (observed)"})
+		}
+	}
+	return holidays
+}
+
+// unitedKingdom returns the bank holidays of England and Wales in a year,
+// with the substitute days given when one falls on a weekend. One-off bank
+// holidays, such as those of royal events, are not included.
+func unitedKingdom(year int) []Holiday {
+	easter := easterSunday(year)
+	holidays := []Holiday{
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{nthWeekday(year, time.May, time.Monday, 1), "Early May Bank Holiday"},
+		{nthWeekday(year, time.May, time.Monday, -1), "Spring Bank Holiday"},
+		{nthWeekday(year, time.August, time.Monday, -1), "Summer Bank Holiday"},
+	}
+	taken := make(map[time.Time]bool)
+	for _, h := range []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "Boxing Day"},
+	} {
+		holidays = append(holidays, h)
+		taken[h.Date] = true
+		if !isWeekend(h.Date) {
+			continue
+		}
+		day := h.Date
+		for isWeekend(day) || taken[day] {
+			day = day.AddDate(0, 0, 1)
+		}
+		taken[day] = true
+		holidays = append(holidays, Holiday{day, h.Name + " (substitute day)"})
+	}
+	return holidays
+}
+
+// france returns the public holidays of metropolitan France in a year
+func france(year int) []Holiday {
+	easter := easterSunday(year)
+	return []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{date(year, time.May, 1), "Labour Day"},
+		{date(year, time.May, 8), "Victory in Europe Day"},
+		{easter.AddDate(0, 0, 39), "Ascension Day"},
+		{easter.AddDate(0, 0, 50), "Whit Monday"},
+		{date(year, time.July, 14), "Bastille Day"},
+		{date(year, time.August, 15), "Assumption Day"},
+		{date(year, time.November, 1), "All Saints' Day"},
+		{date(year, time.November, 11), "Armistice Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+	}
+}
+
+// germany returns the public holidays observed in all German states in a
+// year; holidays of single states are not included
+func germany(year int) []Holiday {
+	easter := easterSunday(year)
+	return []Holiday{
+		{date(year, time.January, 1), "New Year's Day"},
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{date(year, time.May, 1), "Labour Day"},
+		{easter.AddDate(0, 0, 39), "Ascension Day"},
+		{easter.AddDate(0, 0, 50), "Whit Monday"},
+		{date(year, time.October, 3), "German Unity Day"},
+		{date(year, time.December, 25), "Christmas Day"},
+		{date(year, time.December, 26), "Second Day of Christmas"},
+	}
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar
+// (anonymous Gregorian algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
+
+// nthWeekday returns the nth weekday of a month, counting from its end when
+// n is negative (-1 is the last)
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := date(year, month+1, 0)
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -offset+7*(n+1))
+	}
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// isWeekend reports whether a day is a Saturday or Sunday
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
@@ -0,0 +1,121 @@
+// Package holiday provides calendars of public holidays, built in for a few
+// countries or read from ICS files, to find the runs of a schedule that fall
+// on a holiday
+package holiday
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a day off of a calendar
+type Holiday struct {
+	Date time.Time // The day, at midnight UTC
+	Name string
+}
+
+// Calendar is a set of holidays: one-off days, days recurring every year and,
+// for built-in calendars, the holidays of a country computed for each year.
+// A Calendar is safe for concurrent use.
+type Calendar struct {
+	Name      string
+	days      map[time.Time]string // One-off days, at midnight UTC
+	recurring []recurrence
+	rules     func(year int) []Holiday // Holidays of a year, for built-in calendars
+}
+
+// Load returns the built-in calendar of a country code, such as "US" (see
+// Countries), or else the calendar of the ICS file at spec
+func Load(spec string) (*Calendar, error) {
+	if c, ok := Country(spec); ok {
+		return c, nil
+	}
+	f, err := os.Open(spec)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unknown holiday calendar %q: not a country code (%s) or an ICS file", spec, strings.Join(Countries(), ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holiday calendar: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	c, err := ParseICS(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", spec, err)
+	}
+	if c.Name == "" {
+		c.Name = spec
+	}
+	return c, nil
+}
+
+// Holidays returns the holidays of a year, in date order
+func (c *Calendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for day, name := range c.days {
+		if day.Year() == year {
+			holidays = append(holidays, Holiday{Date: day, Name: name})
+		}
+	}
+	for _, r := range c.recurring {
+		if day, ok := r.on(year); ok {
+			holidays = append(holidays, Holiday{Date: day, Name: r.name})
+		}
+	}
+	if c.rules != nil {
+		// Days observed instead of a weekend holiday may fall in the year
+		// before or after it
+		for y := year - 1; y <= year+1; y++ {
+			for _, h := range c.rules(y) {
+				if h.Date.Year() == year {
+					holidays = append(holidays, h)
+				}
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// Lookup returns the name of the holiday on the day of t, in t's time zone
+func (c *Calendar) Lookup(t time.Time) (string, bool) {
+	day := dayOf(t)
+	for _, h := range c.Holidays(t.Year()) {
+		if h.Date.Equal(day) {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// Between returns the holidays on the days from the day of from to the day
+// before the day of to, in date order
+func (c *Calendar) Between(from, to time.Time) []Holiday {
+	start, end := dayOf(from), dayOf(to)
+	var holidays []Holiday
+	for year := start.Year(); year <= end.Year(); year++ {
+		for _, h := range c.Holidays(year) {
+			if !h.Date.Before(start) && h.Date.Before(end) {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	return holidays
+}
+
+// dayOf returns the day of t, in t's time zone, at midnight UTC
+func dayOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return date(year, month, day)
+}
+
+// date returns a day at midnight UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
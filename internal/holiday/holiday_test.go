@@ -0,0 +1,110 @@
+package holiday
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func names(holidays []Holiday) map[string]string {
+	m := make(map[string]string)
+	for _, h := range holidays {
+		m[h.Date.Format("2006-01-02")] = h.Name
+	}
+	return m
+}
+
+func TestCountry(t *testing.T) {
+	t.Run("United States", func(t *testing.T) {
+		c, ok := Country("us")
+		require.True(t, ok)
+		days := names(c.Holidays(2026))
+		assert.Equal(t, "Martin Luther King Jr. Day", days["2026-01-19"])
+		assert.Equal(t, "Memorial Day", days["2026-05-25"])
+		assert.Equal(t, "Independence Day (observed)", days["2026-07-03"])
+		assert.Equal(t, "Thanksgiving Day", days["2026-11-26"])
+		assert.Equal(t, "Juneteenth National Independence Day", days["2026-06-19"])
+		assert.NotContains(t, names(c.Holidays(2020)), "2020-06-19")
+	})
+
+	t.Run("observed days may fall in the year before", func(t *testing.T) {
+		c, _ := Country("US")
+		// New Year's Day 2028 is a Saturday
+		assert.Equal(t, "New Year's Day (observed)", names(c.Holidays(2027))["2027-12-31"])
+	})
+
+	t.Run("United Kingdom substitute days", func(t *testing.T) {
+		c, ok := Country("UK")
+		require.True(t, ok)
+		assert.Equal(t, "United Kingdom (England and Wales)", c.Name)
+		// Christmas 2027 is a Saturday and Boxing Day a Sunday
+		days := names(c.Holidays(2027))
+		assert.Equal(t, "Christmas Day (substitute day)", days["2027-12-27"])
+		assert.Equal(t, "Boxing Day (substitute day)", days["2027-12-28"])
+		assert.Equal(t, "Good Friday", days["2027-03-26"])
+	})
+
+	t.Run("Easter-based holidays", func(t *testing.T) {
+		c, _ := Country("FR")
+		days := names(c.Holidays(2025))
+		assert.Equal(t, "Easter Monday", days["2025-04-21"])
+		assert.Equal(t, "Ascension Day", days["2025-05-29"])
+		assert.Equal(t, "Whit Monday", days["2025-06-09"])
+
+		c, _ = Country("DE")
+		assert.Equal(t, "German Unity Day", names(c.Holidays(2025))["2025-10-03"])
+	})
+
+	t.Run("unknown codes", func(t *testing.T) {
+		_, ok := Country("XX")
+		assert.False(t, ok)
+		assert.Equal(t, []string{"DE", "FR", "GB", "US"}, Countries())
+	})
+}
+
+func TestLookup(t *testing.T) {
+	c, _ := Country("US")
+	name, ok := c.Lookup(time.Date(2025, 12, 25, 9, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Christmas Day", name)
+
+	// The day is taken in the time's zone
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	_, ok = c.Lookup(time.Date(2025, 12, 25, 20, 0, 0, 0, time.UTC).In(tokyo))
+	assert.False(t, ok)
+
+	_, ok = c.Lookup(time.Date(2025, 12, 24, 9, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestBetween(t *testing.T) {
+	c, _ := Country("US")
+	holidays := c.Between(time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.Len(t, holidays, 2)
+	assert.Equal(t, "Christmas Day", holidays[0].Name)
+	assert.Equal(t, "New Year's Day", holidays[1].Name)
+}
+
+func TestLoad(t *testing.T) {
+	c, err := Load("gb")
+	require.NoError(t, err)
+	assert.Equal(t, "United Kingdom (England and Wales)", c.Name)
+
+	path := filepath.Join(t.TempDir(), "company.ics")
+	require.NoError(t, os.WriteFile(path, []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20251226\nSUMMARY:Company day\nEND:VEVENT\nEND:VCALENDAR\n"), 0o644))
+	c, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, path, c.Name)
+	name, ok := c.Lookup(time.Date(2025, 12, 26, 10, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Company day", name)
+
+	_, err = Load("nowhere")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a country code (DE, FR, GB, US) or an ICS file")
+}
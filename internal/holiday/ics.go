@@ -0,0 +1,258 @@
+package holiday
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recurrence is an event of an ICS file repeated every year, on a fixed day
+// or on the nth weekday of a month
+type recurrence struct {
+	name    string
+	start   time.Time // First occurrence
+	month   time.Month
+	day     int          // Day of the month, 0 for the nth weekday
+	weekday time.Weekday // With nth
+	nth     int          // nth weekday of the month, negative from its end
+	last    int          // Last year it occurs, 0 for no end
+}
+
+// on returns the occurrence of a recurrence in a year
+func (r recurrence) on(year int) (time.Time, bool) {
+	if year < r.start.Year() || (r.last != 0 && year > r.last) {
+		return time.Time{}, false
+	}
+	if r.nth != 0 {
+		return nthWeekday(year, r.month, r.weekday, r.nth), true
+	}
+	day := date(year, r.month, r.day)
+	if day.Month() != r.month { // February 29th in other years
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+// weekdays are the ICS names of the days of the week
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// icsEvent holds the properties of a VEVENT read so far
+type icsEvent struct {
+	line    int // Line of BEGIN:VEVENT
+	summary string
+	start   time.Time
+	end     time.Time // Exclusive, zero when absent
+	rrule   string
+}
+
+// ParseICS reads the holidays of an iCalendar (RFC 5545) file: each VEVENT
+// is a holiday on the days from its DTSTART to the day before its DTEND, or
+// on its DTSTART day alone. Events may repeat yearly with an RRULE of
+// FREQ=YEARLY, on their start date or on a BYDAY weekday of a BYMONTH, and
+// end with COUNT or UNTIL; other recurrence rules are reported as errors.
+// Times of day and time zones are ignored: a holiday lasts the whole day
+// it starts on.
+func ParseICS(r io.Reader) (*Calendar, error) {
+	c := &Calendar{days: make(map[time.Time]string)}
+	var event *icsEvent
+
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ICS file: %w", err)
+	}
+	for _, line := range lines {
+		name, value := splitICSProperty(line.text)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = &icsEvent{line: line.number}
+		case name == "END" && value == "VEVENT":
+			if event == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", line.number)
+			}
+			if err := c.addEvent(event); err != nil {
+				return nil, err
+			}
+			event = nil
+		case name == "X-WR-CALNAME" && event == nil:
+			c.Name = unescapeICS(value)
+		case event == nil:
+		case name == "SUMMARY":
+			event.summary = unescapeICS(value)
+		case name == "DTSTART" || name == "DTEND":
+			day, err := parseICSDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q: %w", line.number, name, value, err)
+			}
+			if name == "DTSTART" {
+				event.start = day
+			} else if len(value) == len("20060102") {
+				event.end = day
+			} else {
+				// A date-time end falls within the last day of the event
+				event.end = day.AddDate(0, 0, 1)
+			}
+		case name == "RRULE":
+			event.rrule = value
+		}
+	}
+	if event != nil {
+		return nil, fmt.Errorf("line %d: BEGIN:VEVENT without END:VEVENT", event.line)
+	}
+	return c, nil
+}
+
+// addEvent adds the days of an event to the calendar
+func (c *Calendar) addEvent(event *icsEvent) error {
+	if event.start.IsZero() {
+		return fmt.Errorf("line %d: event without DTSTART", event.line)
+	}
+	name := event.summary
+	if name == "" {
+		name = "Holiday"
+	}
+	if event.rrule != "" {
+		r, err := parseRRule(event.rrule, event.start, name)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", event.line, err)
+		}
+		c.recurring = append(c.recurring, r)
+		return nil
+	}
+
+	end := event.end
+	if !end.After(event.start) {
+		end = event.start.AddDate(0, 0, 1)
+	}
+	for day := event.start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		c.days[day] = name
+	}
+	return nil
+}
+
+// parseRRule returns the yearly recurrence of an event starting on start
+func parseRRule(rule string, start time.Time, name string) (recurrence, error) {
+	r := recurrence{name: name, start: start, month: start.Month(), day: start.Day()}
+	var count int
+	var until time.Time
+	for _, part := range strings.Split(rule, ";") {
+		key, value, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			if !strings.EqualFold(value, "YEARLY") {
+				return r, fmt.Errorf("unsupported RRULE %q: only FREQ=YEARLY is supported", rule)
+			}
+		case "INTERVAL":
+			if value != "1" {
+				return r, fmt.Errorf("unsupported RRULE %q: only yearly events are supported", rule)
+			}
+		case "BYMONTH":
+			var month int
+			month, err = strconv.Atoi(value)
+			if err == nil && (month < 1 || month > 12) {
+				err = fmt.Errorf("month out of range")
+			}
+			r.month = time.Month(month)
+		case "BYMONTHDAY":
+			r.day, err = strconv.Atoi(value)
+		case "BYDAY":
+			r.weekday, r.nth, err = parseByDay(value)
+			r.day = 0
+		case "COUNT":
+			count, err = strconv.Atoi(value)
+		case "UNTIL":
+			until, err = parseICSDate(value)
+		case "WKST":
+		default:
+			return r, fmt.Errorf("unsupported RRULE %q: %s is not supported", rule, key)
+		}
+		if err != nil {
+			return r, fmt.Errorf("invalid RRULE %q: %s: %w", rule, key, err)
+		}
+	}
+	if count > 0 {
+		r.last = start.Year() + count - 1
+	}
+	if !until.IsZero() {
+		r.last = until.Year()
+		if day, ok := r.on(r.last); ok && day.After(until) {
+			r.last--
+		}
+	}
+	return r, nil
+}
+
+// parseByDay parses a BYDAY value naming the nth weekday of a month, such
+// as 3MO or -1MO
+func parseByDay(value string) (time.Weekday, int, error) {
+	if len(value) < 3 {
+		return 0, 0, fmt.Errorf("expected a weekday with its position, such as 3MO")
+	}
+	weekday, ok := weekdays[strings.ToUpper(value[len(value)-2:])]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown weekday %q", value[len(value)-2:])
+	}
+	nth, err := strconv.Atoi(strings.TrimPrefix(value[:len(value)-2], "+"))
+	if err != nil || nth == 0 || nth < -5 || nth > 5 {
+		return 0, 0, fmt.Errorf("expected a weekday with its position, such as 3MO")
+	}
+	return weekday, nth, nil
+}
+
+// parseICSDate returns the day of an ICS DATE or DATE-TIME value
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("expected YYYYMMDD")
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYYMMDD")
+	}
+	return day, nil
+}
+
+// icsLine is a content line of an ICS file, with the number of its first
+// physical line
+type icsLine struct {
+	number int
+	text   string
+}
+
+// unfoldICS returns the content lines of an ICS file, joining the lines
+// folded onto lines starting with a space or tab
+func unfoldICS(r io.Reader) ([]icsLine, error) {
+	var lines []icsLine
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, icsLine{number: number, text: text})
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitICSProperty splits a content line into its upper-cased name, without
+// parameters, and its value
+func splitICSProperty(line string) (name, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	name, _, _ = strings.Cut(head, ";")
+	return strings.ToUpper(name), strings.TrimSpace(value)
+}
+
+// unescapeICS resolves the backslash escapes of an ICS text value
+func unescapeICS(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,80 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testICS = `BEGIN:VCALENDAR
+VERSION:2.0
+X-WR-CALNAME:Acme holidays
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20251224
+DTEND;VALUE=DATE:20251227
+SUMMARY:Winter break\, office closed
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:20250704T000000Z
+SUMMARY:Summer
+  party
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20200101
+RRULE:FREQ=YEARLY
+SUMMARY:New Year
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20200120
+RRULE:FREQ=YEARLY;BYMONTH=1;BYDAY=3MO;UNTIL=20240101
+SUMMARY:MLK
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParseICS(t *testing.T) {
+	c, err := ParseICS(strings.NewReader(strings.ReplaceAll(testICS, "\n", "\r\n")))
+	require.NoError(t, err)
+	assert.Equal(t, "Acme holidays", c.Name)
+
+	t.Run("multi-day events end the day before DTEND", func(t *testing.T) {
+		days := names(c.Holidays(2025))
+		assert.Equal(t, "Winter break, office closed", days["2025-12-24"])
+		assert.Equal(t, "Winter break, office closed", days["2025-12-26"])
+		assert.NotContains(t, days, "2025-12-27")
+	})
+
+	t.Run("folded lines and date-times", func(t *testing.T) {
+		assert.Equal(t, "Summer party", names(c.Holidays(2025))["2025-07-04"])
+	})
+
+	t.Run("yearly events", func(t *testing.T) {
+		assert.Equal(t, "New Year", names(c.Holidays(2031))["2031-01-01"])
+		assert.NotContains(t, names(c.Holidays(2019)), "2019-01-01")
+
+		assert.Equal(t, "MLK", names(c.Holidays(2023))["2023-01-16"])
+		assert.NotContains(t, names(c.Holidays(2024)), "2024-01-15", "UNTIL falls before the 2024 occurrence")
+	})
+}
+
+func TestParseICS_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ics     string
+		wantErr string
+	}{
+		{"unsupported rule", "BEGIN:VEVENT\nDTSTART:20250101\nRRULE:FREQ=WEEKLY\nEND:VEVENT\n", "line 1: unsupported RRULE \"FREQ=WEEKLY\""},
+		{"invalid date", "BEGIN:VEVENT\nDTSTART:2025-01-01\nEND:VEVENT\n", "line 2: invalid DTSTART"},
+		{"missing start", "BEGIN:VEVENT\nSUMMARY:Day\nEND:VEVENT\n", "event without DTSTART"},
+		{"unterminated event", "BEGIN:VEVENT\nDTSTART:20250101\n", "BEGIN:VEVENT without END:VEVENT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseICS(strings.NewReader(tt.ics))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
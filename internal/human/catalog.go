@@ -0,0 +1,155 @@
+package human
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// feminineSuffix marks the variant of a phrase about a single day of the week
+// used when the day's name is feminine (e.g., "dow_nth_feminine")
+const feminineSuffix = "_feminine"
+
+// Catalog is the message catalog of a locale: the phrase templates and words
+// from which the humanizer assembles descriptions. Phrases missing from a
+// catalog, and empty day or month names, fall back to English.
+type Catalog struct {
+	Locale     string           // Locale identifier (e.g., "fr", "pt-BR")
+	Grammar    *Grammar         // Plural rules, slot order and list conventions (default: the grammar registered for Locale)
+	Phrases    map[string]Forms // Phrase templates keyed by phrase identifier (see PhraseIDs)
+	Days       [7]string        // Day-of-week names, Sunday first
+	DayPlurals [7]string        // Plural day-of-week names, for lists of days (default: Days)
+	DayGenders [7]Gender        // Grammatical gender of each day name, used for agreement
+	Months     [12]string       // Month names, January first
+	Nth        [6]string        // Words for the nth occurrence of a weekday (Quartz "#"), indexed 1-5
+}
+
+// EnglishCatalog is the default catalog
+var EnglishCatalog = &Catalog{
+	Locale:  "en",
+	Grammar: EnglishGrammar,
+	Phrases: englishPhrases,
+	Days:    [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	Nth: englishNth,
+}
+
+// PhraseIDs returns the identifiers of the phrases a catalog can translate,
+// sorted. Catalogs may also define a feminine variant (id + "_feminine") of
+// the phrases about a single day of the week: every_dow, dow_nth and dow_last,
+// and of dow_list, which agrees with the first day of the list.
+func PhraseIDs() []string {
+	ids := make([]string, 0, len(englishPhrases))
+	for id := range englishPhrases {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// catalogs holds all available catalogs by locale. Access it through
+// RegisterCatalog and GetCatalog, which are safe for concurrent use.
+var catalogs = map[string]*Catalog{
+	"en": EnglishCatalog,
+	"es": spanishCatalog,
+	"fr": frenchCatalog,
+	"de": germanCatalog,
+	"pt": portugueseCatalog,
+}
+
+// catalogMu guards catalogs
+var catalogMu sync.RWMutex
+
+// RegisterCatalog adds (or replaces) the catalog for its locale. The
+// catalog's grammar, when set, is registered as well.
+func RegisterCatalog(c *Catalog) {
+	catalogMu.Lock()
+	catalogs[c.Locale] = c
+	catalogMu.Unlock()
+
+	if c.Grammar != nil {
+		RegisterGrammar(c.Grammar)
+	}
+}
+
+// GetCatalog returns the catalog for the given locale, or for its language
+// when the region has none ("pt-BR" uses "pt").
+// Falls back to English if the locale is not found
+func GetCatalog(locale string) (*Catalog, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	if c, ok := catalogs[locale]; ok {
+		return c, true
+	}
+	lang := strings.ToLower(locale)
+	if idx := strings.IndexAny(lang, "-_"); idx != -1 {
+		lang = lang[:idx]
+	}
+	if c, ok := catalogs[lang]; ok {
+		return c, true
+	}
+	return EnglishCatalog, false
+}
+
+// phrase returns the forms of a phrase, falling back to English
+func (c *Catalog) phrase(id string) Forms {
+	if forms, ok := c.Phrases[id]; ok {
+		return forms
+	}
+	return englishPhrases[id]
+}
+
+// dayPhrase returns the forms of a phrase about the given day of the week,
+// preferring the feminine variant when the day's name is feminine
+func (c *Catalog) dayPhrase(id string, day int) Forms {
+	if day >= 0 && day < len(c.DayGenders) && c.DayGenders[day] == GenderFeminine {
+		if forms, ok := c.Phrases[id+feminineSuffix]; ok {
+			return forms
+		}
+	}
+	return c.phrase(id)
+}
+
+// day returns the name of a day of the week (0=Sunday)
+func (c *Catalog) day(day int) string {
+	if day >= 0 && day < len(c.Days) && c.Days[day] != "" {
+		return c.Days[day]
+	}
+	return dayName(day)
+}
+
+// dayPlural returns the plural name of a day of the week (0=Sunday), falling
+// back to its name
+func (c *Catalog) dayPlural(day int) string {
+	if day >= 0 && day < len(c.DayPlurals) && c.DayPlurals[day] != "" {
+		return c.DayPlurals[day]
+	}
+	return c.day(day)
+}
+
+// dayGender returns the grammatical gender of a day's name
+func (c *Catalog) dayGender(day int) Gender {
+	if day >= 0 && day < len(c.DayGenders) {
+		return c.DayGenders[day]
+	}
+	return GenderNeuter
+}
+
+// month returns the name of a month (1=January)
+func (c *Catalog) month(month int) string {
+	if month >= 1 && month <= len(c.Months) && c.Months[month-1] != "" {
+		return c.Months[month-1]
+	}
+	return formatMonth(month)
+}
+
+// nth returns the word for the nth occurrence of a weekday
+func (c *Catalog) nth(n int) string {
+	if n >= 1 && n < len(c.Nth) && c.Nth[n] != "" {
+		return c.Nth[n]
+	}
+	return englishNth[n]
+}
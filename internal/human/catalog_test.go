@@ -0,0 +1,130 @@
+package human
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs(t *testing.T) {
+	parser := cronx.NewParser()
+	quartz := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectQuartz})
+
+	tests := []struct {
+		locale     string
+		expression string
+		quartz     bool
+		expected   string
+	}{
+		{"es", "0 9 * * 1-5", false, "A las 09:00 de lunes a viernes"},
+		{"es", "*/15 9-17 * * *", false, "Cada 15 minutos entre las 09:00 y las 17:59 todos los días"},
+		{"es", "0 0 1 1 *", false, "A medianoche el 1 de enero"},
+		{"es", "0 0 12 ? * 2#1", true, "A las 12:00 el primer lunes del mes"},
+		{"es", "0 10 * * 0,6", false, "A las 10:00 los domingos y sábados"},
+		{"es", "0 10 * * 1,3,6", false, "A las 10:00 los lunes, miércoles y sábados"},
+		{"fr", "0 9 * * 1-5", false, "À 09:00 en semaine (lun-ven)"},
+		{"fr", "0 0 1 1 *", false, "À minuit le 1er janvier"},
+		{"fr", "0 12 * 3-6 2", false, "À 12:00 chaque mardi de mars à juin"},
+		{"fr", "*/1 * * * *", false, "Toutes les minutes"},
+		{"de", "30 8 * * 1,3", false, "Um 08:30 am Montag und Mittwoch"},
+		{"de", "0 0 1 1 *", false, "Um Mitternacht am 1. Januar"},
+		{"de", "0 0 12 ? * 1L", true, "Um 12:00 am letzten Sonntag des Monats"},
+		{"pt", "0 12 * * 2", false, "Às 12:00 toda terça-feira"},
+		{"pt", "0 12 * * 6", false, "Às 12:00 todo sábado"},
+		{"pt", "0 0 12 ? * 2#1", true, "Às 12:00 na primeira segunda-feira do mês"},
+		{"pt", "0 0 12 ? * 1#2", true, "Às 12:00 no segundo domingo do mês"},
+		{"pt", "0 0 12 ? * 3L", true, "Às 12:00 na última terça-feira do mês"},
+		{"pt", "0 10 * * 0,6", false, "Às 10:00 aos domingos e sábados"},
+		{"pt", "0 10 * * 1,3", false, "Às 10:00 às segundas-feiras e quartas-feiras"},
+		{"pt-BR", "0 0 * * *", false, "À meia-noite todos os dias"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+" "+tt.expression, func(t *testing.T) {
+			p := parser
+			if tt.quartz {
+				p = quartz
+			}
+			schedule, err := p.Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, NewHumanizerForLocale(tt.locale).Humanize(schedule))
+		})
+	}
+
+	t.Run("built-in catalogs translate every phrase", func(t *testing.T) {
+		for _, locale := range []string{"es", "fr", "de", "pt"} {
+			catalog, ok := GetCatalog(locale)
+			require.True(t, ok, locale)
+			for _, id := range PhraseIDs() {
+				assert.Contains(t, catalog.Phrases, id, "%s: %s", locale, id)
+			}
+			for i, day := range catalog.Days {
+				assert.NotEmpty(t, day, "%s: day %d", locale, i)
+			}
+			for i, month := range catalog.Months {
+				assert.NotEmpty(t, month, "%s: month %d", locale, i+1)
+			}
+		}
+	})
+}
+
+func TestCatalogRegistry(t *testing.T) {
+	t.Run("unknown locale falls back to english", func(t *testing.T) {
+		c, ok := GetCatalog("zz")
+		assert.False(t, ok)
+		assert.Equal(t, EnglishCatalog, c)
+	})
+
+	t.Run("regions fall back to their language", func(t *testing.T) {
+		c, ok := GetCatalog("fr_CA")
+		assert.True(t, ok)
+		assert.Equal(t, "fr", c.Locale)
+	})
+
+	t.Run("custom catalogs can be registered", func(t *testing.T) {
+		custom := &Catalog{
+			Locale: "test-nl",
+			Grammar: &Grammar{
+				Locale:      "test-nl",
+				Plural:      PluralRuleOneOther,
+				SlotOrder:   []Slot{SlotTime, SlotDay, SlotMonth},
+				Conjunction: "en",
+			},
+			Phrases: map[string]Forms{
+				phraseAt:             {PluralOther: "Om {times}"},
+				phraseEveryDayOfWeek: {PluralOther: "elke {day}"},
+			},
+			Days: [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		}
+		RegisterCatalog(custom)
+		defer func() {
+			delete(catalogs, "test-nl")
+			delete(grammars, "test-nl")
+		}()
+
+		c, ok := GetCatalog("test-nl")
+		require.True(t, ok)
+		assert.Equal(t, custom, c)
+		g, ok := GetGrammar("test-nl")
+		require.True(t, ok)
+		assert.Equal(t, custom.Grammar, g)
+
+		humanizer := NewHumanizerForLocale("test-nl")
+		schedule, err := cronx.NewParser().Parse("0 9 * * 1")
+		require.NoError(t, err)
+		assert.Equal(t, "Om 09:00 elke maandag", humanizer.Humanize(schedule))
+
+		// Missing phrases and names fall back to English
+		schedule, err = cronx.NewParser().Parse("0 9 * 1 *")
+		require.NoError(t, err)
+		assert.Equal(t, "Om 09:00 every day in January", humanizer.Humanize(schedule))
+	})
+
+	t.Run("phrase identifiers are sorted", func(t *testing.T) {
+		ids := PhraseIDs()
+		assert.Contains(t, ids, phraseEveryMinute)
+		assert.IsIncreasing(t, ids)
+	})
+}
@@ -0,0 +1,56 @@
+package human
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests are meant to be run with the race detector (make test-unit)
+
+func TestHumanizer_ConcurrentUse(t *testing.T) {
+	parser := cronx.NewParser()
+	humanizer := NewHumanizer()
+
+	schedule, err := parser.Parse("0 9 * * 1-5")
+	require.NoError(t, err)
+	expected := humanizer.Humanize(schedule)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, expected, humanizer.Humanize(schedule))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGrammarRegistry_ConcurrentUse(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		locale := fmt.Sprintf("test-concurrent-%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterGrammar(&Grammar{Locale: locale})
+		}()
+		go func() {
+			defer wg.Done()
+			g, _ := GetGrammar(locale)
+			assert.NotNil(t, g)
+		}()
+	}
+	wg.Wait()
+
+	grammarMu.Lock()
+	for i := 0; i < 20; i++ {
+		delete(grammars, fmt.Sprintf("test-concurrent-%d", i))
+	}
+	grammarMu.Unlock()
+}
@@ -20,6 +20,51 @@ func formatTime(hour, minute int) string {
 	return fmt.Sprintf("%02d:%02d", hour, minute)
 }
 
+// formatTime12 formats hour and minute on a 12-hour clock as H:MM AM/PM
+func formatTime12(hour, minute int) string {
+	suffix := "AM"
+	if hour >= 12 {
+		suffix = "PM"
+	}
+	hour %= 12
+	if hour == 0 {
+		hour = 12
+	}
+	return fmt.Sprintf("%d:%02d %s", hour, minute, suffix)
+}
+
+// Clock selects how descriptions write times of day
+type Clock int
+
+const (
+	Clock24Hour Clock = iota // 24-hour clock: "14:30" (default)
+	Clock12Hour              // 12-hour clock with AM/PM: "2:30 PM"
+)
+
+// time formats hour and minute on the clock
+func (c Clock) time(hour, minute int) string {
+	if c == Clock12Hour {
+		return formatTime12(hour, minute)
+	}
+	return formatTime(hour, minute)
+}
+
+// hourStart formats the first minute of an hour (HH:00)
+func (c Clock) hourStart(hour int) string {
+	if c == Clock12Hour {
+		return formatTime12(hour, 0)
+	}
+	return formatHour(hour)
+}
+
+// hourEnd formats the last minute of an hour (HH:59)
+func (c Clock) hourEnd(hour int) string {
+	if c == Clock12Hour {
+		return formatTime12(hour, 59)
+	}
+	return formatHourEnd(hour)
+}
+
 // formatList formats a slice of strings with Oxford comma
 func formatList(items []string) string {
 	switch len(items) {
@@ -45,6 +45,15 @@ func TestFormatTime(t *testing.T) {
 	})
 }
 
+func TestFormatTime12(t *testing.T) {
+	t.Run("should format times on a 12-hour clock", func(t *testing.T) {
+		assert.Equal(t, "12:00 AM", formatTime12(0, 0))
+		assert.Equal(t, "9:05 AM", formatTime12(9, 5))
+		assert.Equal(t, "12:30 PM", formatTime12(12, 30))
+		assert.Equal(t, "11:59 PM", formatTime12(23, 59))
+	})
+}
+
 func TestFormatList(t *testing.T) {
 	t.Run("should format empty list", func(t *testing.T) {
 		result := formatList([]string{})
@@ -0,0 +1,321 @@
+package human
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PluralCategory is a CLDR plural category used to select the correct
+// grammatical form of a phrase for a given count
+type PluralCategory int
+
+const (
+	// PluralOther is the general (fallback) plural category
+	PluralOther PluralCategory = iota
+	// PluralZero is used by locales with a dedicated zero form (e.g., Arabic)
+	PluralZero
+	// PluralOne is the singular category
+	PluralOne
+	// PluralTwo is the dual category (e.g., Arabic)
+	PluralTwo
+	// PluralFew is the paucal category (e.g., Slavic 2-4)
+	PluralFew
+	// PluralMany is the "many" category (e.g., Slavic 5-20)
+	PluralMany
+)
+
+// String returns the CLDR name of the plural category
+func (c PluralCategory) String() string {
+	switch c {
+	case PluralZero:
+		return "zero"
+	case PluralOne:
+		return "one"
+	case PluralTwo:
+		return "two"
+	case PluralFew:
+		return "few"
+	case PluralMany:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+// PluralRule selects the plural category for a count
+type PluralRule func(n int) PluralCategory
+
+// Gender is the grammatical gender of a word, used by agreement hooks
+type Gender int
+
+const (
+	// GenderNeuter is the default gender (and the only one English needs)
+	GenderNeuter Gender = iota
+	// GenderMasculine is the masculine gender
+	GenderMasculine
+	// GenderFeminine is the feminine gender
+	GenderFeminine
+)
+
+// Slot identifies an ordered position in a humanized sentence
+type Slot int
+
+const (
+	// SlotTime holds the time-of-day phrase ("At 09:00")
+	SlotTime Slot = iota
+	// SlotDay holds the day phrase ("every Monday")
+	SlotDay
+	// SlotMonth holds the month phrase ("in January")
+	SlotMonth
+)
+
+// Forms maps plural categories to phrase templates.
+// Templates use {n} for the count and {name} for named arguments.
+// PluralOther must always be present as the fallback form.
+type Forms map[PluralCategory]string
+
+// AgreementFunc inflects a word so it agrees with a gender and plural category
+type AgreementFunc func(word string, gender Gender, category PluralCategory) string
+
+// OrdinalFunc formats n as an ordinal number agreeing with the given gender
+type OrdinalFunc func(n int, gender Gender) string
+
+// Grammar describes the locale-specific rules used to assemble descriptions
+type Grammar struct {
+	Locale      string        // Locale identifier (e.g., "en", "ru")
+	Plural      PluralRule    // Plural category selection
+	SlotOrder   []Slot        // Order in which sentence slots are joined
+	Agree       AgreementFunc // Optional gender/number agreement hook
+	Ordinal     OrdinalFunc   // Ordinal number formatting
+	Conjunction string        // Word joining the last two list items ("and")
+	SerialComma bool          // Whether to use a serial (Oxford) comma
+}
+
+// Category returns the plural category for n (PluralOther if no rule is set)
+func (g *Grammar) Category(n int) PluralCategory {
+	if g.Plural == nil {
+		return PluralOther
+	}
+	return g.Plural(n)
+}
+
+// Pluralize selects the form matching n and substitutes {n} and named arguments
+func (g *Grammar) Pluralize(forms Forms, n int, args map[string]string) string {
+	template, ok := forms[g.Category(n)]
+	if !ok {
+		template = forms[PluralOther]
+	}
+
+	merged := make(map[string]string, len(args)+1)
+	for k, v := range args {
+		merged[k] = v
+	}
+	merged["n"] = fmt.Sprintf("%d", n)
+
+	return Expand(template, merged)
+}
+
+// Inflect applies the agreement hook to a word (identity when no hook is set)
+func (g *Grammar) Inflect(word string, gender Gender, n int) string {
+	if g.Agree == nil {
+		return word
+	}
+	return g.Agree(word, gender, g.Category(n))
+}
+
+// FormatOrdinal formats n as an ordinal using the locale's ordinal hook
+func (g *Grammar) FormatOrdinal(n int, gender Gender) string {
+	if g.Ordinal == nil {
+		return fmt.Sprintf("%d", n)
+	}
+	return g.Ordinal(n, gender)
+}
+
+// Assemble joins the non-empty slot phrases in the locale's slot order
+func (g *Grammar) Assemble(slots map[Slot]string) string {
+	parts := make([]string, 0, len(g.SlotOrder))
+	for _, slot := range g.SlotOrder {
+		if phrase := slots[slot]; phrase != "" {
+			parts = append(parts, phrase)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// List joins items using the locale's conjunction and comma conventions
+func (g *Grammar) List(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return fmt.Sprintf("%s %s %s", items[0], g.Conjunction, items[1])
+	default:
+		rest := strings.Join(items[:len(items)-1], ", ")
+		if g.SerialComma {
+			return fmt.Sprintf("%s, %s %s", rest, g.Conjunction, items[len(items)-1])
+		}
+		return fmt.Sprintf("%s %s %s", rest, g.Conjunction, items[len(items)-1])
+	}
+}
+
+// Expand replaces {name} placeholders in template with values from args.
+// Unknown placeholders are left untouched.
+func Expand(template string, args map[string]string) string {
+	if len(args) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	pairs := make([]string, 0, len(args)*2)
+	for k, v := range args {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// PluralRuleOneOther is the rule for English, German, Spanish, Portuguese, etc.
+// (1 is singular, everything else is plural)
+func PluralRuleOneOther(n int) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralRuleFrench treats 0 and 1 as singular
+func PluralRuleFrench(n int) PluralCategory {
+	if n == 0 || n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralRuleEastSlavic is the rule for Russian, Ukrainian, and Belarusian
+func PluralRuleEastSlavic(n int) PluralCategory {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRulePolish is the rule for Polish
+func PluralRulePolish(n int) PluralCategory {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRuleArabic is the rule for Arabic (six categories)
+func PluralRuleArabic(n int) PluralCategory {
+	mod100 := n % 100
+	switch {
+	case n == 0:
+		return PluralZero
+	case n == 1:
+		return PluralOne
+	case n == 2:
+		return PluralTwo
+	case mod100 >= 3 && mod100 <= 10:
+		return PluralFew
+	case mod100 >= 11 && mod100 <= 99:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// PluralRuleNone is the rule for locales without grammatical number (e.g., Japanese)
+func PluralRuleNone(_ int) PluralCategory {
+	return PluralOther
+}
+
+// pluralRules maps language codes to their plural rule
+var pluralRules = map[string]PluralRule{
+	"en": PluralRuleOneOther,
+	"de": PluralRuleOneOther,
+	"es": PluralRuleOneOther,
+	"it": PluralRuleOneOther,
+	"nl": PluralRuleOneOther,
+	"pt": PluralRuleOneOther,
+	"fr": PluralRuleFrench,
+	"ru": PluralRuleEastSlavic,
+	"uk": PluralRuleEastSlavic,
+	"be": PluralRuleEastSlavic,
+	"pl": PluralRulePolish,
+	"ar": PluralRuleArabic,
+	"ja": PluralRuleNone,
+	"zh": PluralRuleNone,
+	"ko": PluralRuleNone,
+}
+
+// PluralRuleFor returns the plural rule for a locale (e.g., "ru" or "pt-BR").
+// Falls back to the one/other rule if the language is unknown.
+func PluralRuleFor(locale string) PluralRule {
+	lang := strings.ToLower(locale)
+	if idx := strings.IndexAny(lang, "-_"); idx != -1 {
+		lang = lang[:idx]
+	}
+	if rule, ok := pluralRules[lang]; ok {
+		return rule
+	}
+	return PluralRuleOneOther
+}
+
+// englishOrdinal formats n with an English ordinal suffix (1st, 2nd, 3rd)
+func englishOrdinal(n int, _ Gender) string {
+	return fmt.Sprintf("%d%s", n, ordinalSuffix(n))
+}
+
+// EnglishGrammar is the default grammar
+var EnglishGrammar = &Grammar{
+	Locale:      "en",
+	Plural:      PluralRuleOneOther,
+	SlotOrder:   []Slot{SlotTime, SlotDay, SlotMonth},
+	Ordinal:     englishOrdinal,
+	Conjunction: "and",
+	SerialComma: true,
+}
+
+// grammars holds all available grammars by locale. Access it through
+// RegisterGrammar and GetGrammar, which are safe for concurrent use.
+var grammars = map[string]*Grammar{
+	"en": EnglishGrammar,
+	"es": spanishGrammar,
+	"fr": frenchGrammar,
+	"de": germanGrammar,
+	"pt": portugueseGrammar,
+}
+
+// grammarMu guards grammars
+var grammarMu sync.RWMutex
+
+// RegisterGrammar adds (or replaces) the grammar for its locale
+func RegisterGrammar(g *Grammar) {
+	grammarMu.Lock()
+	defer grammarMu.Unlock()
+	grammars[g.Locale] = g
+}
+
+// GetGrammar returns the grammar for the given locale
+// Falls back to English if the locale is not found
+func GetGrammar(locale string) (*Grammar, bool) {
+	grammarMu.RLock()
+	defer grammarMu.RUnlock()
+	if g, ok := grammars[locale]; ok {
+		return g, true
+	}
+	return EnglishGrammar, false
+}
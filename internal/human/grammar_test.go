@@ -0,0 +1,302 @@
+package human
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		locale   string
+		n        int
+		expected PluralCategory
+	}{
+		// English: one/other
+		{"en", 0, PluralOther},
+		{"en", 1, PluralOne},
+		{"en", 2, PluralOther},
+		{"en", 21, PluralOther},
+		// French: 0 and 1 are singular
+		{"fr", 0, PluralOne},
+		{"fr", 1, PluralOne},
+		{"fr", 2, PluralOther},
+		// Russian: one/few/many
+		{"ru", 1, PluralOne},
+		{"ru", 2, PluralFew},
+		{"ru", 4, PluralFew},
+		{"ru", 5, PluralMany},
+		{"ru", 11, PluralMany},
+		{"ru", 12, PluralMany},
+		{"ru", 21, PluralOne},
+		{"ru", 22, PluralFew},
+		{"ru", 111, PluralMany},
+		// Ukrainian shares the East Slavic rule
+		{"uk", 23, PluralFew},
+		// Polish: only exactly 1 is singular
+		{"pl", 1, PluralOne},
+		{"pl", 3, PluralFew},
+		{"pl", 5, PluralMany},
+		{"pl", 21, PluralMany},
+		{"pl", 22, PluralFew},
+		// Arabic: six categories
+		{"ar", 0, PluralZero},
+		{"ar", 1, PluralOne},
+		{"ar", 2, PluralTwo},
+		{"ar", 3, PluralFew},
+		{"ar", 10, PluralFew},
+		{"ar", 11, PluralMany},
+		{"ar", 99, PluralMany},
+		{"ar", 100, PluralOther},
+		{"ar", 103, PluralFew},
+		// Japanese: no grammatical number
+		{"ja", 1, PluralOther},
+		// Regional variants use the language rule
+		{"pt-BR", 1, PluralOne},
+		{"ru_RU", 3, PluralFew},
+		// Unknown locales fall back to one/other
+		{"xx", 1, PluralOne},
+		{"xx", 7, PluralOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.locale, tt.n), func(t *testing.T) {
+			rule := PluralRuleFor(tt.locale)
+			assert.Equal(t, tt.expected, rule(tt.n), "locale %s, n=%d", tt.locale, tt.n)
+		})
+	}
+}
+
+func TestPluralCategory_String(t *testing.T) {
+	assert.Equal(t, "zero", PluralZero.String())
+	assert.Equal(t, "one", PluralOne.String())
+	assert.Equal(t, "two", PluralTwo.String())
+	assert.Equal(t, "few", PluralFew.String())
+	assert.Equal(t, "many", PluralMany.String())
+	assert.Equal(t, "other", PluralOther.String())
+}
+
+func TestGrammar_Pluralize(t *testing.T) {
+	minutes := map[string]Forms{
+		"en": {PluralOne: "{n} minute", PluralOther: "{n} minutes"},
+		"ru": {PluralOne: "{n} минуту", PluralFew: "{n} минуты", PluralMany: "{n} минут", PluralOther: "{n} минуты"},
+		"pl": {PluralOne: "{n} minutę", PluralFew: "{n} minuty", PluralMany: "{n} minut", PluralOther: "{n} minuty"},
+		"ar": {
+			PluralZero:  "{n} دقيقة",
+			PluralOne:   "دقيقة واحدة",
+			PluralTwo:   "دقيقتان",
+			PluralFew:   "{n} دقائق",
+			PluralMany:  "{n} دقيقة",
+			PluralOther: "{n} دقيقة",
+		},
+	}
+
+	tests := []struct {
+		locale   string
+		n        int
+		expected string
+	}{
+		{"en", 1, "1 minute"},
+		{"en", 5, "5 minutes"},
+		{"ru", 1, "1 минуту"},
+		{"ru", 3, "3 минуты"},
+		{"ru", 5, "5 минут"},
+		{"ru", 21, "21 минуту"},
+		{"pl", 1, "1 minutę"},
+		{"pl", 2, "2 minuty"},
+		{"pl", 15, "15 minut"},
+		{"ar", 1, "دقيقة واحدة"},
+		{"ar", 2, "دقيقتان"},
+		{"ar", 5, "5 دقائق"},
+		{"ar", 15, "15 دقيقة"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.expected, func(t *testing.T) {
+			g := &Grammar{Locale: tt.locale, Plural: PluralRuleFor(tt.locale)}
+			assert.Equal(t, tt.expected, g.Pluralize(minutes[tt.locale], tt.n, nil))
+		})
+	}
+
+	t.Run("falls back to other when category form is missing", func(t *testing.T) {
+		g := &Grammar{Locale: "ru", Plural: PluralRuleEastSlavic}
+		result := g.Pluralize(Forms{PluralOther: "every {n} {unit}"}, 3, map[string]string{"unit": "x"})
+		assert.Equal(t, "every 3 x", result)
+	})
+
+	t.Run("grammar without plural rule uses other", func(t *testing.T) {
+		g := &Grammar{}
+		assert.Equal(t, PluralOther, g.Category(1))
+	})
+}
+
+func TestGrammar_Assemble(t *testing.T) {
+	slots := map[Slot]string{
+		SlotTime:  "T",
+		SlotDay:   "D",
+		SlotMonth: "M",
+	}
+
+	tests := []struct {
+		name     string
+		order    []Slot
+		slots    map[Slot]string
+		expected string
+	}{
+		{"english order", []Slot{SlotTime, SlotDay, SlotMonth}, slots, "T D M"},
+		{"day first", []Slot{SlotDay, SlotMonth, SlotTime}, slots, "D M T"},
+		{"skips empty slots", []Slot{SlotTime, SlotDay, SlotMonth}, map[Slot]string{SlotTime: "T", SlotMonth: "M"}, "T M"},
+		{"no slots", []Slot{SlotTime}, map[Slot]string{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Grammar{SlotOrder: tt.order}
+			assert.Equal(t, tt.expected, g.Assemble(tt.slots))
+		})
+	}
+}
+
+func TestGrammar_List(t *testing.T) {
+	english := EnglishGrammar
+	french := &Grammar{Conjunction: "et", SerialComma: false}
+
+	tests := []struct {
+		name     string
+		grammar  *Grammar
+		items    []string
+		expected string
+	}{
+		{"en empty", english, nil, ""},
+		{"en single", english, []string{"a"}, "a"},
+		{"en pair", english, []string{"a", "b"}, "a and b"},
+		{"en serial comma", english, []string{"a", "b", "c"}, "a, b, and c"},
+		{"fr pair", french, []string{"a", "b"}, "a et b"},
+		{"fr no serial comma", french, []string{"a", "b", "c"}, "a, b et c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.grammar.List(tt.items))
+		})
+	}
+}
+
+func TestGrammar_AgreementHooks(t *testing.T) {
+	// A toy French-like grammar whose ordinal and agreement depend on gender
+	g := &Grammar{
+		Locale: "fr",
+		Plural: PluralRuleFrench,
+		Agree: func(word string, gender Gender, category PluralCategory) string {
+			if gender == GenderFeminine {
+				word += "e"
+			}
+			if category == PluralOther {
+				word += "s"
+			}
+			return word
+		},
+		Ordinal: func(n int, gender Gender) string {
+			if n == 1 && gender == GenderFeminine {
+				return "1re"
+			}
+			if n == 1 {
+				return "1er"
+			}
+			return fmt.Sprintf("%de", n)
+		},
+	}
+
+	assert.Equal(t, "premier", g.Inflect("premier", GenderMasculine, 1))
+	assert.Equal(t, "premiere", g.Inflect("premier", GenderFeminine, 1))
+	assert.Equal(t, "premieres", g.Inflect("premier", GenderFeminine, 3))
+	assert.Equal(t, "1er", g.FormatOrdinal(1, GenderMasculine))
+	assert.Equal(t, "1re", g.FormatOrdinal(1, GenderFeminine))
+	assert.Equal(t, "2e", g.FormatOrdinal(2, GenderMasculine))
+
+	t.Run("nil hooks are identity", func(t *testing.T) {
+		plain := &Grammar{}
+		assert.Equal(t, "word", plain.Inflect("word", GenderFeminine, 2))
+		assert.Equal(t, "7", plain.FormatOrdinal(7, GenderNeuter))
+	})
+}
+
+func TestExpand(t *testing.T) {
+	assert.Equal(t, "no placeholders", Expand("no placeholders", map[string]string{"a": "b"}))
+	assert.Equal(t, "x and y", Expand("{a} and {b}", map[string]string{"a": "x", "b": "y"}))
+	assert.Equal(t, "{missing}", Expand("{missing}", map[string]string{"a": "x"}))
+	assert.Equal(t, "{a}", Expand("{a}", nil))
+}
+
+func TestGrammarRegistry(t *testing.T) {
+	t.Run("english is registered by default", func(t *testing.T) {
+		g, ok := GetGrammar("en")
+		assert.True(t, ok)
+		assert.Equal(t, EnglishGrammar, g)
+	})
+
+	t.Run("unknown locale falls back to english", func(t *testing.T) {
+		g, ok := GetGrammar("zz")
+		assert.False(t, ok)
+		assert.Equal(t, EnglishGrammar, g)
+	})
+
+	t.Run("custom grammars can be registered", func(t *testing.T) {
+		custom := &Grammar{Locale: "test-slots", SlotOrder: []Slot{SlotMonth, SlotTime}}
+		RegisterGrammar(custom)
+		defer delete(grammars, "test-slots")
+
+		g, ok := GetGrammar("test-slots")
+		assert.True(t, ok)
+		assert.Equal(t, custom, g)
+	})
+}
+
+func TestHumanizerWithGrammar(t *testing.T) {
+	parser := cronx.NewParser()
+
+	t.Run("slot order controls sentence assembly", func(t *testing.T) {
+		reordered := *EnglishGrammar
+		reordered.SlotOrder = []Slot{SlotDay, SlotTime}
+
+		schedule, err := parser.Parse("0 9 * * 1")
+		require.NoError(t, err)
+		assert.Equal(t, "every Monday At 09:00", NewHumanizerWithGrammar(&reordered).Humanize(schedule))
+	})
+
+	t.Run("plural forms follow the grammar's plural rule", func(t *testing.T) {
+		schedule, err := parser.Parse("1 9-17 * * *")
+		require.NoError(t, err)
+		result := NewHumanizer().Humanize(schedule)
+		assert.Contains(t, result, "At 1 minute past the hour")
+
+		schedule, err = parser.Parse("5 9-17 * * *")
+		require.NoError(t, err)
+		result = NewHumanizer().Humanize(schedule)
+		assert.Contains(t, result, "At 5 minutes past the hour")
+	})
+
+	t.Run("agreement hook is applied to day names", func(t *testing.T) {
+		shouting := *EnglishGrammar
+		shouting.Agree = func(word string, _ Gender, _ PluralCategory) string {
+			return strings.ToUpper(word)
+		}
+
+		schedule, err := parser.Parse("0 9 * * 1,3")
+		require.NoError(t, err)
+		assert.Contains(t, NewHumanizerWithGrammar(&shouting).Humanize(schedule), "MONDAY and WEDNESDAY")
+	})
+
+	t.Run("list conjunction comes from the grammar", func(t *testing.T) {
+		other := *EnglishGrammar
+		other.Conjunction = "&"
+
+		schedule, err := parser.Parse("0 9 * 1,3 *")
+		require.NoError(t, err)
+		assert.Contains(t, NewHumanizerWithGrammar(&other).Humanize(schedule), "January & March")
+	})
+}
@@ -3,39 +3,199 @@ package human
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hzerrad/cronkit/internal/cronx"
 )
 
-// Humanizer converts cron schedules to human-readable descriptions
+// Humanizer converts cron schedules to human-readable descriptions.
+// Implementations are stateless and safe for concurrent use.
 type Humanizer interface {
 	Humanize(schedule *cronx.Schedule) string
 }
 
 type humanizer struct {
-	// Could add locale/language support here in future
+	grammar *Grammar
+	catalog *Catalog
+	clock   Clock
+}
+
+// Options configures a humanizer created with NewHumanizerWithOptions
+type Options struct {
+	Locale string // Locale of the catalog (default: English)
+	Clock  Clock  // How times of day are written (default: 24-hour clock)
 }
 
 // NewHumanizer creates a new humanizer with English templates (v1)
 func NewHumanizer() Humanizer {
-	return &humanizer{}
+	return NewHumanizerWithGrammar(EnglishGrammar)
+}
+
+// NewHumanizerWithGrammar creates a new humanizer that assembles descriptions
+// using the given grammar (plural rules, slot order, agreement hooks)
+func NewHumanizerWithGrammar(grammar *Grammar) Humanizer {
+	return &humanizer{
+		grammar: grammar,
+		catalog: EnglishCatalog,
+	}
+}
+
+// NewHumanizerWithCatalog creates a new humanizer that describes schedules
+// with the phrases and words of the given catalog
+func NewHumanizerWithCatalog(catalog *Catalog) Humanizer {
+	grammar := catalog.Grammar
+	if grammar == nil {
+		grammar, _ = GetGrammar(catalog.Locale)
+	}
+	return &humanizer{
+		grammar: grammar,
+		catalog: catalog,
+	}
 }
 
-// Humanize converts a parsed cron schedule to human-readable text
+// NewHumanizerForLocale creates a new humanizer for the registered catalog
+// of a locale. Falls back to English if the locale has no catalog.
+func NewHumanizerForLocale(locale string) Humanizer {
+	return NewHumanizerWithOptions(Options{Locale: locale})
+}
+
+// NewHumanizerWithOptions creates a new humanizer for the catalog of
+// opts.Locale that writes times of day on opts.Clock
+func NewHumanizerWithOptions(opts Options) Humanizer {
+	catalog, _ := GetCatalog(opts.Locale)
+	h := NewHumanizerWithCatalog(catalog).(*humanizer)
+	h.clock = opts.Clock
+	return h
+}
+
+// say renders the phrase with the given identifier for count n
+func (h *humanizer) say(id string, n int, args map[string]string) string {
+	return h.grammar.Pluralize(h.catalog.phrase(id), n, args)
+}
+
+// sayDay renders a phrase about a single day of the week, which may agree
+// with the gender of the day's name
+func (h *humanizer) sayDay(id string, day, n int, args map[string]string) string {
+	return h.grammar.Pluralize(h.catalog.dayPhrase(id, day), n, args)
+}
+
+// Humanize converts a parsed cron schedule to human-readable text. Values
+// picked by OpenBSD ~ terms are described as fixed times, with a note that
+// they were picked at random.
 func (h *humanizer) Humanize(schedule *cronx.Schedule) string {
-	var parts []string
+	description := h.humanize(schedule)
+	if schedule.Random {
+		return h.say(phraseRandomPick, 0, map[string]string{"rest": description})
+	}
+	return description
+}
+
+// humanize describes the runs of a schedule
+func (h *humanizer) humanize(schedule *cronx.Schedule) string {
+	if schedule.Rate > 0 {
+		return h.buildRatePart(schedule.Rate)
+	}
+
+	description := h.humanizeMinutes(schedule)
+	if years := h.buildYearPart(schedule.Year); years != "" {
+		description += " " + years
+	}
+	if !schedule.HasSeconds() {
+		return description
+	}
+
+	seconds := h.buildSecondPart(schedule.Second)
+	if seconds == "" {
+		return description
+	}
+
+	// "Every 10 seconds" already implies "every minute"
+	if (schedule.Second.IsEvery() || schedule.Second.IsStep()) && description == h.say(phraseEveryMinute, 1, nil) {
+		return seconds
+	}
+
+	return h.say(phraseWithSeconds, 0, map[string]string{
+		"seconds": seconds,
+		"rest":    lowerFirst(description),
+	})
+}
+
+// buildRatePart describes an EventBridge rate in the largest unit that
+// divides it
+func (h *humanizer) buildRatePart(rate time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case rate%day == 0:
+		return h.say(phraseEveryNDays, int(rate/day), nil)
+	case rate%time.Hour == 0:
+		return h.say(phraseEveryNHours, int(rate/time.Hour), nil)
+	default:
+		return h.say(phraseEveryNMinutes, int(rate/time.Minute), nil)
+	}
+}
+
+// buildSecondPart constructs the seconds portion of the description.
+// It returns an empty string when the job runs at second 0, the 5-field default.
+func (h *humanizer) buildSecondPart(second cronx.Field) string {
+	switch {
+	case second.IsEvery():
+		return h.say(phraseEverySecond, 1, nil)
+	case second.IsStep() && strings.HasPrefix(second.Raw(), "*"):
+		return h.say(phraseEveryNSeconds, second.Step(), nil)
+	case second.IsSingle():
+		if second.Value() == 0 {
+			return ""
+		}
+		return h.say(phraseAtSecond, second.Value(), nil)
+	default:
+		values := second.Values()
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = fmt.Sprintf("%d", v)
+		}
+		return h.say(phraseAtSeconds, len(values), map[string]string{"seconds": h.grammar.List(strs)})
+	}
+}
+
+// lowerFirst lowercases the first letter of s
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToLower(r)) + s[size:]
+}
 
+// humanizeMinutes describes the minute-resolution part of a schedule
+func (h *humanizer) humanizeMinutes(schedule *cronx.Schedule) string {
 	minute := schedule.Minute
 	hour := schedule.Hour
 	dayOfWeek := schedule.DayOfWeek
 	dayOfMonth := schedule.DayOfMonth
+	month := schedule.Month
 
 	// Build the human-readable description by analyzing each field
-	timePart := h.buildTimePart(minute, hour)
-	dayPart := h.buildDayPart(dayOfWeek, dayOfMonth)
-	monthPart := h.buildMonthPart(schedule.Month)
+	slots := map[Slot]string{
+		SlotTime: h.buildTimePart(minute, hour),
+	}
+
+	// Quartz day modifiers (L, W, #) replace the day fields entirely
+	if days := h.buildQuartzDayPart(schedule.Quartz); days != "" {
+		slots[SlotDay] = days
+		slots[SlotMonth] = h.buildMonthPart(month)
+		return h.grammar.Assemble(slots)
+	}
 
-	parts = append(parts, timePart)
+	// Special case: specific day + specific month (e.g., @yearly)
+	if dayOfMonth.IsSingle() && month.IsSingle() && dayOfWeek.IsEvery() {
+		slots[SlotDay] = h.say(phraseOnDate, dayOfMonth.Value(), map[string]string{
+			"month": h.catalog.month(month.Value()),
+			"day":   h.grammar.FormatOrdinal(dayOfMonth.Value(), GenderNeuter),
+		})
+		return h.grammar.Assemble(slots)
+	}
 
 	// For simple patterns (minute-based with wildcard hours/days),
 	// skip "every day" as it's implied
@@ -43,103 +203,96 @@ func (h *humanizer) Humanize(schedule *cronx.Schedule) string {
 		(minute.IsSingle() && minute.Value() == 0)) && hour.IsEvery()
 	isSimplePattern := minuteBasedPattern && dayOfWeek.IsEvery() && dayOfMonth.IsEvery()
 
-	// Special case: specific day + specific month (e.g., @yearly)
-	month := schedule.Month
-	if dayOfMonth.IsSingle() && month.IsSingle() && dayOfWeek.IsEvery() {
-		parts = append(parts, fmt.Sprintf("on %s %d%s",
-			formatMonth(month.Value()),
-			dayOfMonth.Value(),
-			ordinalSuffix(dayOfMonth.Value())))
-		return strings.Join(parts, " ")
-	}
-
-	if dayPart != "" && !isSimplePattern {
-		parts = append(parts, dayPart)
-	}
-
-	if monthPart != "" {
-		parts = append(parts, monthPart)
+	if !isSimplePattern {
+		slots[SlotDay] = h.buildDayPart(dayOfWeek, dayOfMonth)
 	}
+	slots[SlotMonth] = h.buildMonthPart(month)
 
-	return strings.Join(parts, " ")
+	return h.grammar.Assemble(slots)
 }
 
 // buildTimePart constructs the time portion of the description
 func (h *humanizer) buildTimePart(minute, hour cronx.Field) string {
 	// Case 1: Every minute (*, *)
 	if minute.IsEvery() && hour.IsEvery() {
-		return "Every minute"
+		return h.say(phraseEveryMinute, 1, nil)
 	}
 
 	// Case 2: Minute intervals with wildcard hour (*/N, *)
 	if minute.IsStep() && hour.IsEvery() {
-		return fmt.Sprintf("Every %d minutes", minute.Step())
+		return h.say(phraseEveryNMinutes, minute.Step(), nil)
 	}
 
 	// Case 3: Minute intervals within hour range (*/N, N-M)
 	if minute.IsStep() && hour.IsRange() {
-		return fmt.Sprintf("Every %d minutes between %s and %s",
-			minute.Step(),
-			formatHour(hour.RangeStart()),
-			formatHourEnd(hour.RangeEnd()))
+		return h.say(phraseEveryNMinutesBetween, minute.Step(), map[string]string{
+			"start": h.clock.hourStart(hour.RangeStart()),
+			"end":   h.clock.hourEnd(hour.RangeEnd()),
+		})
 	}
 
 	// Case 4: Start of every hour (0, *)
 	if minute.IsSingle() && minute.Value() == 0 && hour.IsEvery() {
-		return "At the start of every hour"
+		return h.say(phraseStartOfEveryHour, 0, nil)
 	}
 
 	// Case 5: Specific minute of every hour (N, *)
 	if minute.IsSingle() && hour.IsEvery() {
-		return fmt.Sprintf("At minute %d of every hour", minute.Value())
+		return h.say(phraseMinuteOfEveryHour, minute.Value(), nil)
 	}
 
 	// Case 6: Specific time (N, M)
 	if minute.IsSingle() && hour.IsSingle() {
 		if minute.Value() == 0 && hour.Value() == 0 {
-			return "At midnight"
+			return h.say(phraseMidnight, 0, nil)
 		}
-		return fmt.Sprintf("At %s", formatTime(hour.Value(), minute.Value()))
+		return h.say(phraseAt, 1, map[string]string{
+			"times": h.clock.time(hour.Value(), minute.Value()),
+		})
 	}
 
 	// Case 7: Specific time with multiple hours (N, M,N,O)
 	if minute.IsSingle() && hour.IsList() {
 		times := make([]string, len(hour.ListValues()))
-		for i, h := range hour.ListValues() {
-			times[i] = formatTime(h, minute.Value())
+		for i, hr := range hour.ListValues() {
+			times[i] = h.clock.time(hr, minute.Value())
 		}
-		return fmt.Sprintf("At %s", formatList(times))
+		return h.say(phraseAt, len(times), map[string]string{"times": h.grammar.List(times)})
 	}
 
 	// Case 8: Step minutes with single hour (*/N, M)
 	if minute.IsStep() && hour.IsSingle() {
-		return fmt.Sprintf("Every %d minutes at %s", minute.Step(), formatHour(hour.Value()))
+		return h.say(phraseEveryNMinutesAt, minute.Step(), map[string]string{
+			"hours": h.clock.hourStart(hour.Value()),
+		})
 	}
 
 	// Case 9: Step minutes with list hour (*/N, M,N,O)
 	if minute.IsStep() && hour.IsList() {
 		times := make([]string, len(hour.ListValues()))
-		for i, h := range hour.ListValues() {
-			times[i] = formatHour(h)
+		for i, hr := range hour.ListValues() {
+			times[i] = h.clock.hourStart(hr)
 		}
-		return fmt.Sprintf("Every %d minutes at %s", minute.Step(), formatList(times))
+		return h.say(phraseEveryNMinutesAt, minute.Step(), map[string]string{
+			"hours": h.grammar.List(times),
+		})
 	}
 
 	// Case 10: Single minute with range hour (N, M-O)
 	if minute.IsSingle() && hour.IsRange() {
-		return fmt.Sprintf("At %d minutes past the hour between %s and %s",
-			minute.Value(),
-			formatHour(hour.RangeStart()),
-			formatHourEnd(hour.RangeEnd()))
+		return h.say(phraseMinutesPastBetween, minute.Value(), map[string]string{
+			"start": h.clock.hourStart(hour.RangeStart()),
+			"end":   h.clock.hourEnd(hour.RangeEnd()),
+		})
 	}
 
 	// Case 11: List minute with single hour (N,M,O, H)
 	if minute.IsList() && hour.IsSingle() {
 		times := make([]string, len(minute.ListValues()))
 		for i, m := range minute.ListValues() {
-			times[i] = formatTime(hour.Value(), m)
+			times[i] = h.clock.time(hour.Value(), m)
 		}
-		return fmt.Sprintf("At %s", formatList(times))
+		return h.say(phraseAt, len(times), map[string]string{"times": h.grammar.List(times)})
 	}
 
 	// Case 12: List minute with range hour (N,M,O, H-J)
@@ -149,20 +302,21 @@ func (h *humanizer) buildTimePart(minute, hour cronx.Field) string {
 		for i, m := range minutes {
 			minuteStrs[i] = fmt.Sprintf("%d", m)
 		}
-		return fmt.Sprintf("At %s minutes past the hour between %s and %s",
-			formatList(minuteStrs),
-			formatHour(hour.RangeStart()),
-			formatHourEnd(hour.RangeEnd()))
+		return h.say(phraseMinuteListPastBetween, len(minutes), map[string]string{
+			"minutes": h.grammar.List(minuteStrs),
+			"start":   h.clock.hourStart(hour.RangeStart()),
+			"end":     h.clock.hourEnd(hour.RangeEnd()),
+		})
 	}
 
 	// Case 13: List minute with list hour (N,M,O, H,J,K) - cartesian product
 	if minute.IsList() && hour.IsList() {
 		times := h.generateTimeCombinations(minute.ListValues(), hour.ListValues())
-		return fmt.Sprintf("At %s", formatList(times))
+		return h.say(phraseAt, len(times), map[string]string{"times": h.grammar.List(times)})
 	}
 
 	// Default fallback
-	return "Runs periodically"
+	return h.say(phrasePeriodically, 0, nil)
 }
 
 // generateTimeCombinations creates a cartesian product of minutes and hours
@@ -171,7 +325,7 @@ func (h *humanizer) generateTimeCombinations(minutes, hours []int) []string {
 	var times []string
 	for _, hour := range hours {
 		for _, minute := range minutes {
-			times = append(times, formatTime(hour, minute))
+			times = append(times, h.clock.time(hour, minute))
 		}
 	}
 	return times
@@ -179,11 +333,6 @@ func (h *humanizer) generateTimeCombinations(minutes, hours []int) []string {
 
 // buildDayPart constructs the day portion of the description
 func (h *humanizer) buildDayPart(dayOfWeek, dayOfMonth cronx.Field) string {
-	// If both are wildcard, return empty (every day)
-	if dayOfWeek.IsEvery() && dayOfMonth.IsEvery() {
-		return "every day"
-	}
-
 	// Day of week has priority
 	if !dayOfWeek.IsEvery() {
 		return h.formatDayOfWeek(dayOfWeek)
@@ -194,7 +343,61 @@ func (h *humanizer) buildDayPart(dayOfWeek, dayOfMonth cronx.Field) string {
 		return h.formatDayOfMonth(dayOfMonth)
 	}
 
-	return "every day"
+	return h.say(phraseEveryDay, 0, nil)
+}
+
+// buildQuartzDayPart describes Quartz day modifiers, or returns an empty
+// string when none are in use
+func (h *humanizer) buildQuartzDayPart(q *cronx.QuartzDays) string {
+	if q == nil {
+		return ""
+	}
+
+	switch {
+	case q.LastWeekday:
+		return h.say(phraseLastWeekdayOfMonth, 0, nil)
+	case q.LastDay && q.LastDayOffset > 0:
+		return h.say(phraseDaysBeforeLastDay, q.LastDayOffset, nil)
+	case q.LastDay:
+		return h.say(phraseLastDayOfMonth, 0, nil)
+	case q.NearestWeekday > 0:
+		return h.say(phraseNearestWeekday, 0, map[string]string{
+			"day": h.grammar.FormatOrdinal(q.NearestWeekday, GenderNeuter),
+		})
+	case q.NthWeekday > 0 && q.NthWeekday < len(englishNth):
+		return h.sayDay(phraseNthDayOfWeek, q.Weekday, q.NthWeekday, map[string]string{
+			"nth": h.grammar.Inflect(h.catalog.nth(q.NthWeekday), h.catalog.dayGender(q.Weekday), 1),
+			"day": h.grammar.Inflect(h.catalog.day(q.Weekday), GenderNeuter, 1),
+		})
+	case q.LastOfWeekday:
+		return h.sayDay(phraseLastDayOfWeek, q.Weekday, 0, map[string]string{
+			"day": h.grammar.Inflect(h.catalog.day(q.Weekday), GenderNeuter, 1),
+		})
+	}
+	return ""
+}
+
+// buildYearPart constructs the year portion of a Quartz description
+func (h *humanizer) buildYearPart(year cronx.Field) string {
+	if year == nil || year.IsEvery() {
+		return ""
+	}
+
+	var years string
+	switch {
+	case year.IsSingle():
+		years = fmt.Sprintf("%d", year.Value())
+	case year.IsRange():
+		years = fmt.Sprintf("%d-%d", year.RangeStart(), year.RangeEnd())
+	default:
+		values := year.Values()
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = fmt.Sprintf("%d", v)
+		}
+		years = h.grammar.List(strs)
+	}
+	return h.say(phraseInYears, 0, map[string]string{"years": years})
 }
 
 // buildMonthPart constructs the month portion of the description
@@ -204,21 +407,22 @@ func (h *humanizer) buildMonthPart(month cronx.Field) string {
 	}
 
 	if month.IsSingle() {
-		return fmt.Sprintf("in %s", formatMonth(month.Value()))
+		return h.say(phraseInMonth, 1, map[string]string{"month": h.catalog.month(month.Value())})
 	}
 
 	if month.IsRange() {
-		return fmt.Sprintf("from %s to %s",
-			formatMonth(month.RangeStart()),
-			formatMonth(month.RangeEnd()))
+		return h.say(phraseMonthRange, 0, map[string]string{
+			"start": h.catalog.month(month.RangeStart()),
+			"end":   h.catalog.month(month.RangeEnd()),
+		})
 	}
 
 	if month.IsList() {
 		months := make([]string, len(month.ListValues()))
 		for i, m := range month.ListValues() {
-			months[i] = formatMonth(m)
+			months[i] = h.catalog.month(m)
 		}
-		return fmt.Sprintf("in %s", formatList(months))
+		return h.say(phraseInMonths, len(months), map[string]string{"months": h.grammar.List(months)})
 	}
 
 	return ""
@@ -229,27 +433,27 @@ func (h *humanizer) formatDayOfWeek(dow cronx.Field) string {
 	if dow.IsRange() {
 		// Special case for Mon-Fri (1-5)
 		if dow.RangeStart() == 1 && dow.RangeEnd() == 5 {
-			return "on weekdays (Mon-Fri)"
+			return h.say(phraseWeekdays, 5, nil)
 		}
-		return fmt.Sprintf("on %s-%s",
-			dayName(dow.RangeStart()),
-			dayName(dow.RangeEnd()))
+		return h.say(phraseDayOfWeekRange, 0, map[string]string{
+			"start": h.grammar.Inflect(h.catalog.day(dow.RangeStart()), GenderNeuter, 1),
+			"end":   h.grammar.Inflect(h.catalog.day(dow.RangeEnd()), GenderNeuter, 1),
+		})
 	}
 
 	if dow.IsList() {
-		days := make([]string, len(dow.ListValues()))
-		for i, d := range dow.ListValues() {
-			days[i] = dayName(d)
+		values := dow.ListValues()
+		days := make([]string, len(values))
+		for i, d := range values {
+			days[i] = h.grammar.Inflect(h.catalog.dayPlural(d), GenderNeuter, len(values))
 		}
-		return fmt.Sprintf("on %s", formatList(days))
+		return h.sayDay(phraseDayOfWeekList, values[0], len(days), map[string]string{"days": h.grammar.List(days)})
 	}
 
 	if dow.IsSingle() {
-		// Special case for Sunday (0)
-		if dow.Value() == 0 {
-			return "every Sunday"
-		}
-		return fmt.Sprintf("every %s", dayName(dow.Value()))
+		return h.sayDay(phraseEveryDayOfWeek, dow.Value(), 1, map[string]string{
+			"day": h.grammar.Inflect(h.catalog.day(dow.Value()), GenderNeuter, 1),
+		})
 	}
 
 	return ""
@@ -259,14 +463,16 @@ func (h *humanizer) formatDayOfWeek(dow cronx.Field) string {
 func (h *humanizer) formatDayOfMonth(dom cronx.Field) string {
 	if dom.IsSingle() {
 		if dom.Value() == 1 {
-			return "on the first day of every month"
+			return h.say(phraseFirstDayOfMonth, 1, nil)
 		}
-		return fmt.Sprintf("on day %d of every month", dom.Value())
+		return h.say(phraseDayOfMonth, dom.Value(), nil)
 	}
 
 	if dom.IsRange() {
-		return fmt.Sprintf("on days %d-%d of every month",
-			dom.RangeStart(), dom.RangeEnd())
+		return h.say(phraseDayOfMonthRange, 0, map[string]string{
+			"start": fmt.Sprintf("%d", dom.RangeStart()),
+			"end":   fmt.Sprintf("%d", dom.RangeEnd()),
+		})
 	}
 
 	return ""
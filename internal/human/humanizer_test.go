@@ -451,6 +451,158 @@ func TestHumanizer_Humanize_IntervalPatterns(t *testing.T) {
 	}
 }
 
+func TestHumanizer_Humanize_Seconds(t *testing.T) {
+	parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+	humanizer := human.NewHumanizer()
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{
+			name:       "second zero is implied",
+			expression: "0 */5 * * * *",
+			expected:   "Every 5 minutes",
+		},
+		{
+			name:       "every second",
+			expression: "* * * * * *",
+			expected:   "Every second",
+		},
+		{
+			name:       "every 10 seconds",
+			expression: "*/10 * * * * *",
+			expected:   "Every 10 seconds",
+		},
+		{
+			name:       "specific second",
+			expression: "30 0 9 * * 1-5",
+			expected:   "At second 30, at 09:00 on weekdays (Mon-Fri)",
+		},
+		{
+			name:       "second list",
+			expression: "0,30 0 0 * * *",
+			expected:   "At seconds 0 and 30, at midnight every day",
+		},
+		{
+			name:       "every 20 seconds on Mondays",
+			expression: "*/20 * * * * 1",
+			expected:   "Every 20 seconds, every minute every Monday",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+
+			result := humanizer.Humanize(schedule)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestHumanizer_Humanize_Quartz(t *testing.T) {
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectQuartz})
+	humanizer := human.NewHumanizer()
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{
+			name:       "last day of the month",
+			expression: "0 0 0 L * ?",
+			expected:   "At midnight on the last day of the month",
+		},
+		{
+			name:       "days before the last day",
+			expression: "0 0 0 L-2 * ?",
+			expected:   "At midnight 2 days before the last day of the month",
+		},
+		{
+			name:       "one day before the last day",
+			expression: "0 0 0 L-1 * ?",
+			expected:   "At midnight 1 day before the last day of the month",
+		},
+		{
+			name:       "last weekday of the month",
+			expression: "0 0 9 LW * ?",
+			expected:   "At 09:00 on the last weekday of the month",
+		},
+		{
+			name:       "nearest weekday",
+			expression: "0 0 9 15W * ?",
+			expected:   "At 09:00 on the weekday nearest the 15th of the month",
+		},
+		{
+			name:       "last Friday of the month",
+			expression: "0 0 12 ? * 6L",
+			expected:   "At 12:00 on the last Friday of the month",
+		},
+		{
+			name:       "third Monday in a month",
+			expression: "0 0 12 ? JAN MON#3",
+			expected:   "At 12:00 on the third Monday of the month in January",
+		},
+		{
+			name:       "day-of-week numbering starts at Sunday=1",
+			expression: "0 15 10 ? * 2-6",
+			expected:   "At 10:15 on weekdays (Mon-Fri)",
+		},
+		{
+			name:       "year field",
+			expression: "0 0 12 ? * 2 2027",
+			expected:   "At 12:00 every Monday in 2027",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+
+			result := humanizer.Humanize(schedule)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestHumanizer_Humanize_AWSRates(t *testing.T) {
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectAWS})
+	humanizer := human.NewHumanizer()
+
+	tests := map[string]string{
+		"rate(1 minute)":    "Every minute",
+		"rate(5 minutes)":   "Every 5 minutes",
+		"rate(120 minutes)": "Every 2 hours",
+		"rate(1 hour)":      "Every hour",
+		"rate(36 hours)":    "Every 36 hours",
+		"rate(1 day)":       "Every day",
+		"rate(3 days)":      "Every 3 days",
+	}
+	for expression, expected := range tests {
+		schedule, err := parser.Parse(expression)
+		require.NoError(t, err, expression)
+		assert.Equal(t, expected, humanizer.Humanize(schedule), expression)
+	}
+}
+
+func TestHumanizer_Humanize_RandomValues(t *testing.T) {
+	parser := cronx.NewParser()
+	schedule, err := parser.Parse("0~30 6 * * *")
+	require.NoError(t, err)
+	require.True(t, schedule.Random)
+	assert.Regexp(t, `^At 06:\d\d every day \(random, fixed per host\)$`, human.NewHumanizer().Humanize(schedule))
+
+	schedule, err = parser.Parse("0 6 * * *")
+	require.NoError(t, err)
+	assert.False(t, schedule.Random)
+	assert.Equal(t, "At 06:00 every day", human.NewHumanizer().Humanize(schedule))
+}
+
 func TestHumanizer_MonthPatterns(t *testing.T) {
 	parser := cronx.NewParser()
 	humanizer := human.NewHumanizer()
@@ -754,3 +906,34 @@ func TestHumanizer_MissingTimePatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestHumanizer_Humanize_12HourClock(t *testing.T) {
+	parser := cronx.NewParser()
+	humanizer := human.NewHumanizerWithOptions(human.Options{Clock: human.Clock12Hour})
+
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"30 14 * * *", "At 2:30 PM every day"},
+		{"0 0 * * *", "At midnight every day"},
+		{"0 12 * * 1-5", "At 12:00 PM on weekdays (Mon-Fri)"},
+		{"0 9,17 * * *", "At 9:00 AM and 5:00 PM every day"},
+		{"*/15 9-17 * * *", "Every 15 minutes between 9:00 AM and 5:59 PM every day"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, humanizer.Humanize(schedule))
+		})
+	}
+
+	t.Run("combines with a locale", func(t *testing.T) {
+		h := human.NewHumanizerWithOptions(human.Options{Locale: "es", Clock: human.Clock12Hour})
+		schedule, err := parser.Parse("30 14 * * *")
+		require.NoError(t, err)
+		assert.Equal(t, "A las 2:30 PM todos los días", h.Humanize(schedule))
+	})
+}
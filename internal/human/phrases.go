@@ -0,0 +1,112 @@
+package human
+
+// Phrase identifiers used by the humanizer
+const (
+	phraseEveryMinute           = "every_minute"
+	phraseEveryNMinutes         = "every_n_minutes"
+	phraseEveryNMinutesBetween  = "every_n_minutes_between"
+	phraseEveryNMinutesAt       = "every_n_minutes_at"
+	phraseStartOfEveryHour      = "start_of_every_hour"
+	phraseMinuteOfEveryHour     = "minute_of_every_hour"
+	phraseMidnight              = "midnight"
+	phraseAt                    = "at"
+	phraseMinutesPastBetween    = "minutes_past_between"
+	phraseMinuteListPastBetween = "minute_list_past_between"
+	phrasePeriodically          = "periodically"
+	phraseEveryDay              = "every_day"
+	phraseWeekdays              = "weekdays"
+	phraseDayOfWeekRange        = "dow_range"
+	phraseDayOfWeekList         = "dow_list"
+	phraseEveryDayOfWeek        = "every_dow"
+	phraseFirstDayOfMonth       = "dom_first"
+	phraseDayOfMonth            = "dom_single"
+	phraseDayOfMonthRange       = "dom_range"
+	phraseInMonth               = "month_single"
+	phraseMonthRange            = "month_range"
+	phraseInMonths              = "month_list"
+	phraseOnDate                = "on_date"
+	phraseEverySecond           = "every_second"
+	phraseEveryNSeconds         = "every_n_seconds"
+	phraseAtSecond              = "at_second"
+	phraseAtSeconds             = "at_seconds"
+	phraseWithSeconds           = "with_seconds"
+	phraseLastDayOfMonth        = "dom_last"
+	phraseDaysBeforeLastDay     = "dom_last_offset"
+	phraseLastWeekdayOfMonth    = "dom_last_weekday"
+	phraseNearestWeekday        = "dom_nearest_weekday"
+	phraseNthDayOfWeek          = "dow_nth"
+	phraseLastDayOfWeek         = "dow_last"
+	phraseInYears               = "year_list"
+	phraseEveryNHours           = "every_n_hours"
+	phraseEveryNDays            = "every_n_days"
+	phraseRandomPick            = "random_pick"
+)
+
+// englishPhrases contains the English phrase templates keyed by phrase identifier
+var englishPhrases = map[string]Forms{
+	phraseEveryMinute: {PluralOther: "Every minute"},
+	phraseEveryNMinutes: {
+		PluralOne:   "Every minute",
+		PluralOther: "Every {n} minutes",
+	},
+	phraseEveryNMinutesBetween: {
+		PluralOne:   "Every minute between {start} and {end}",
+		PluralOther: "Every {n} minutes between {start} and {end}",
+	},
+	phraseEveryNMinutesAt: {
+		PluralOne:   "Every minute at {hours}",
+		PluralOther: "Every {n} minutes at {hours}",
+	},
+	phraseStartOfEveryHour:  {PluralOther: "At the start of every hour"},
+	phraseMinuteOfEveryHour: {PluralOther: "At minute {n} of every hour"},
+	phraseMidnight:          {PluralOther: "At midnight"},
+	phraseAt:                {PluralOther: "At {times}"},
+	phraseMinutesPastBetween: {
+		PluralOne:   "At {n} minute past the hour between {start} and {end}",
+		PluralOther: "At {n} minutes past the hour between {start} and {end}",
+	},
+	phraseMinuteListPastBetween: {PluralOther: "At {minutes} minutes past the hour between {start} and {end}"},
+	phrasePeriodically:          {PluralOther: "Runs periodically"},
+	phraseEveryDay:              {PluralOther: "every day"},
+	phraseWeekdays:              {PluralOther: "on weekdays (Mon-Fri)"},
+	phraseDayOfWeekRange:        {PluralOther: "on {start}-{end}"},
+	phraseDayOfWeekList:         {PluralOther: "on {days}"},
+	phraseEveryDayOfWeek:        {PluralOther: "every {day}"},
+	phraseFirstDayOfMonth:       {PluralOther: "on the first day of every month"},
+	phraseDayOfMonth:            {PluralOther: "on day {n} of every month"},
+	phraseDayOfMonthRange:       {PluralOther: "on days {start}-{end} of every month"},
+	phraseInMonth:               {PluralOther: "in {month}"},
+	phraseMonthRange:            {PluralOther: "from {start} to {end}"},
+	phraseInMonths:              {PluralOther: "in {months}"},
+	phraseOnDate:                {PluralOther: "on {month} {day}"},
+	phraseEverySecond:           {PluralOther: "Every second"},
+	phraseEveryNSeconds: {
+		PluralOne:   "Every second",
+		PluralOther: "Every {n} seconds",
+	},
+	phraseAtSecond:       {PluralOther: "At second {n}"},
+	phraseAtSeconds:      {PluralOther: "At seconds {seconds}"},
+	phraseWithSeconds:    {PluralOther: "{seconds}, {rest}"},
+	phraseLastDayOfMonth: {PluralOther: "on the last day of the month"},
+	phraseDaysBeforeLastDay: {
+		PluralOne:   "{n} day before the last day of the month",
+		PluralOther: "{n} days before the last day of the month",
+	},
+	phraseLastWeekdayOfMonth: {PluralOther: "on the last weekday of the month"},
+	phraseNearestWeekday:     {PluralOther: "on the weekday nearest the {day} of the month"},
+	phraseNthDayOfWeek:       {PluralOther: "on the {nth} {day} of the month"},
+	phraseLastDayOfWeek:      {PluralOther: "on the last {day} of the month"},
+	phraseInYears:            {PluralOther: "in {years}"},
+	phraseEveryNHours: {
+		PluralOne:   "Every hour",
+		PluralOther: "Every {n} hours",
+	},
+	phraseEveryNDays: {
+		PluralOne:   "Every day",
+		PluralOther: "Every {n} days",
+	},
+	phraseRandomPick: {PluralOther: "{rest} (random, fixed per host)"},
+}
+
+// englishNth contains the words used for the nth occurrence of a weekday (Quartz "#")
+var englishNth = [6]string{"", "first", "second", "third", "fourth", "fifth"}
@@ -0,0 +1,88 @@
+package human
+
+import "fmt"
+
+// germanGrammar is the grammar of the German catalog
+var germanGrammar = &Grammar{
+	Locale:      "de",
+	Plural:      PluralRuleOneOther,
+	SlotOrder:   []Slot{SlotTime, SlotDay, SlotMonth},
+	Ordinal:     func(n int, _ Gender) string { return fmt.Sprintf("%d.", n) },
+	Conjunction: "und",
+}
+
+// germanCatalog contains the German phrase templates and words
+var germanCatalog = &Catalog{
+	Locale:  "de",
+	Grammar: germanGrammar,
+	Phrases: map[string]Forms{
+		phraseEveryMinute: {PluralOther: "Jede Minute"},
+		phraseEveryNMinutes: {
+			PluralOne:   "Jede Minute",
+			PluralOther: "Alle {n} Minuten",
+		},
+		phraseEveryNMinutesBetween: {
+			PluralOne:   "Jede Minute zwischen {start} und {end}",
+			PluralOther: "Alle {n} Minuten zwischen {start} und {end}",
+		},
+		phraseEveryNMinutesAt: {
+			PluralOne:   "Jede Minute um {hours}",
+			PluralOther: "Alle {n} Minuten um {hours}",
+		},
+		phraseStartOfEveryHour:  {PluralOther: "Zu Beginn jeder Stunde"},
+		phraseMinuteOfEveryHour: {PluralOther: "In Minute {n} jeder Stunde"},
+		phraseMidnight:          {PluralOther: "Um Mitternacht"},
+		phraseAt:                {PluralOther: "Um {times}"},
+		phraseMinutesPastBetween: {
+			PluralOne:   "{n} Minute nach der vollen Stunde zwischen {start} und {end}",
+			PluralOther: "{n} Minuten nach der vollen Stunde zwischen {start} und {end}",
+		},
+		phraseMinuteListPastBetween: {PluralOther: "In den Minuten {minutes} jeder Stunde zwischen {start} und {end}"},
+		phrasePeriodically:          {PluralOther: "Wird periodisch ausgeführt"},
+		phraseEveryDay:              {PluralOther: "jeden Tag"},
+		phraseWeekdays:              {PluralOther: "werktags (Mo-Fr)"},
+		phraseDayOfWeekRange:        {PluralOther: "von {start} bis {end}"},
+		phraseDayOfWeekList:         {PluralOther: "am {days}"},
+		phraseEveryDayOfWeek:        {PluralOther: "jeden {day}"},
+		phraseFirstDayOfMonth:       {PluralOther: "am ersten Tag jedes Monats"},
+		phraseDayOfMonth:            {PluralOther: "am {n}. Tag jedes Monats"},
+		phraseDayOfMonthRange:       {PluralOther: "vom {start}. bis {end}. Tag jedes Monats"},
+		phraseInMonth:               {PluralOther: "im {month}"},
+		phraseMonthRange:            {PluralOther: "von {start} bis {end}"},
+		phraseInMonths:              {PluralOther: "im {months}"},
+		phraseOnDate:                {PluralOther: "am {day} {month}"},
+		phraseEverySecond:           {PluralOther: "Jede Sekunde"},
+		phraseEveryNSeconds: {
+			PluralOne:   "Jede Sekunde",
+			PluralOther: "Alle {n} Sekunden",
+		},
+		phraseAtSecond:       {PluralOther: "In Sekunde {n}"},
+		phraseAtSeconds:      {PluralOther: "In den Sekunden {seconds}"},
+		phraseWithSeconds:    {PluralOther: "{seconds}, {rest}"},
+		phraseLastDayOfMonth: {PluralOther: "am letzten Tag des Monats"},
+		phraseDaysBeforeLastDay: {
+			PluralOne:   "{n} Tag vor dem letzten Tag des Monats",
+			PluralOther: "{n} Tage vor dem letzten Tag des Monats",
+		},
+		phraseLastWeekdayOfMonth: {PluralOther: "am letzten Werktag des Monats"},
+		phraseNearestWeekday:     {PluralOther: "am Werktag, der dem {day} des Monats am nächsten liegt"},
+		phraseNthDayOfWeek:       {PluralOther: "am {nth} {day} des Monats"},
+		phraseLastDayOfWeek:      {PluralOther: "am letzten {day} des Monats"},
+		phraseInYears:            {PluralOther: "im Jahr {years}"},
+		phraseEveryNHours: {
+			PluralOne:   "Jede Stunde",
+			PluralOther: "Alle {n} Stunden",
+		},
+		phraseEveryNDays: {
+			PluralOne:   "Jeden Tag",
+			PluralOther: "Alle {n} Tage",
+		},
+		phraseRandomPick: {PluralOther: "{rest} (zufällig, je Host fest)"},
+	},
+	Days: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	Months: [12]string{
+		"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember",
+	},
+	Nth: [6]string{"", "ersten", "zweiten", "dritten", "vierten", "fünften"},
+}
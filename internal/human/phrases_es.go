@@ -0,0 +1,89 @@
+package human
+
+import "fmt"
+
+// spanishGrammar is the grammar of the Spanish catalog
+var spanishGrammar = &Grammar{
+	Locale:      "es",
+	Plural:      PluralRuleOneOther,
+	SlotOrder:   []Slot{SlotTime, SlotDay, SlotMonth},
+	Ordinal:     func(n int, _ Gender) string { return fmt.Sprintf("%d", n) },
+	Conjunction: "y",
+}
+
+// spanishCatalog contains the Spanish phrase templates and words
+var spanishCatalog = &Catalog{
+	Locale:  "es",
+	Grammar: spanishGrammar,
+	Phrases: map[string]Forms{
+		phraseEveryMinute: {PluralOther: "Cada minuto"},
+		phraseEveryNMinutes: {
+			PluralOne:   "Cada minuto",
+			PluralOther: "Cada {n} minutos",
+		},
+		phraseEveryNMinutesBetween: {
+			PluralOne:   "Cada minuto entre las {start} y las {end}",
+			PluralOther: "Cada {n} minutos entre las {start} y las {end}",
+		},
+		phraseEveryNMinutesAt: {
+			PluralOne:   "Cada minuto a las {hours}",
+			PluralOther: "Cada {n} minutos a las {hours}",
+		},
+		phraseStartOfEveryHour:  {PluralOther: "Al comienzo de cada hora"},
+		phraseMinuteOfEveryHour: {PluralOther: "En el minuto {n} de cada hora"},
+		phraseMidnight:          {PluralOther: "A medianoche"},
+		phraseAt:                {PluralOther: "A las {times}"},
+		phraseMinutesPastBetween: {
+			PluralOne:   "Al minuto {n} de cada hora entre las {start} y las {end}",
+			PluralOther: "A los {n} minutos de cada hora entre las {start} y las {end}",
+		},
+		phraseMinuteListPastBetween: {PluralOther: "A los minutos {minutes} de cada hora entre las {start} y las {end}"},
+		phrasePeriodically:          {PluralOther: "Se ejecuta periódicamente"},
+		phraseEveryDay:              {PluralOther: "todos los días"},
+		phraseWeekdays:              {PluralOther: "de lunes a viernes"},
+		phraseDayOfWeekRange:        {PluralOther: "de {start} a {end}"},
+		phraseDayOfWeekList:         {PluralOther: "los {days}"},
+		phraseEveryDayOfWeek:        {PluralOther: "cada {day}"},
+		phraseFirstDayOfMonth:       {PluralOther: "el primer día de cada mes"},
+		phraseDayOfMonth:            {PluralOther: "el día {n} de cada mes"},
+		phraseDayOfMonthRange:       {PluralOther: "los días {start} a {end} de cada mes"},
+		phraseInMonth:               {PluralOther: "en {month}"},
+		phraseMonthRange:            {PluralOther: "de {start} a {end}"},
+		phraseInMonths:              {PluralOther: "en {months}"},
+		phraseOnDate:                {PluralOther: "el {day} de {month}"},
+		phraseEverySecond:           {PluralOther: "Cada segundo"},
+		phraseEveryNSeconds: {
+			PluralOne:   "Cada segundo",
+			PluralOther: "Cada {n} segundos",
+		},
+		phraseAtSecond:       {PluralOther: "En el segundo {n}"},
+		phraseAtSeconds:      {PluralOther: "En los segundos {seconds}"},
+		phraseWithSeconds:    {PluralOther: "{seconds}, {rest}"},
+		phraseLastDayOfMonth: {PluralOther: "el último día del mes"},
+		phraseDaysBeforeLastDay: {
+			PluralOne:   "{n} día antes del último día del mes",
+			PluralOther: "{n} días antes del último día del mes",
+		},
+		phraseLastWeekdayOfMonth: {PluralOther: "el último día laborable del mes"},
+		phraseNearestWeekday:     {PluralOther: "el día laborable más cercano al día {day} del mes"},
+		phraseNthDayOfWeek:       {PluralOther: "el {nth} {day} del mes"},
+		phraseLastDayOfWeek:      {PluralOther: "el último {day} del mes"},
+		phraseInYears:            {PluralOther: "en {years}"},
+		phraseEveryNHours: {
+			PluralOne:   "Cada hora",
+			PluralOther: "Cada {n} horas",
+		},
+		phraseEveryNDays: {
+			PluralOne:   "Cada día",
+			PluralOther: "Cada {n} días",
+		},
+		phraseRandomPick: {PluralOther: "{rest} (aleatorio, fijo por host)"},
+	},
+	Days:       [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	DayPlurals: [7]string{"domingos", "lunes", "martes", "miércoles", "jueves", "viernes", "sábados"},
+	Months: [12]string{
+		"enero", "febrero", "marzo", "abril", "mayo", "junio",
+		"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+	},
+	Nth: [6]string{"", "primer", "segundo", "tercer", "cuarto", "quinto"},
+}
@@ -0,0 +1,96 @@
+package human
+
+import "fmt"
+
+// frenchOrdinal writes the first day as "1er" and other days as cardinals
+func frenchOrdinal(n int, _ Gender) string {
+	if n == 1 {
+		return "1er"
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// frenchGrammar is the grammar of the French catalog
+var frenchGrammar = &Grammar{
+	Locale:      "fr",
+	Plural:      PluralRuleFrench,
+	SlotOrder:   []Slot{SlotTime, SlotDay, SlotMonth},
+	Ordinal:     frenchOrdinal,
+	Conjunction: "et",
+}
+
+// frenchCatalog contains the French phrase templates and words
+var frenchCatalog = &Catalog{
+	Locale:  "fr",
+	Grammar: frenchGrammar,
+	Phrases: map[string]Forms{
+		phraseEveryMinute: {PluralOther: "Toutes les minutes"},
+		phraseEveryNMinutes: {
+			PluralOne:   "Toutes les minutes",
+			PluralOther: "Toutes les {n} minutes",
+		},
+		phraseEveryNMinutesBetween: {
+			PluralOne:   "Toutes les minutes entre {start} et {end}",
+			PluralOther: "Toutes les {n} minutes entre {start} et {end}",
+		},
+		phraseEveryNMinutesAt: {
+			PluralOne:   "Toutes les minutes à {hours}",
+			PluralOther: "Toutes les {n} minutes à {hours}",
+		},
+		phraseStartOfEveryHour:  {PluralOther: "Au début de chaque heure"},
+		phraseMinuteOfEveryHour: {PluralOther: "À la minute {n} de chaque heure"},
+		phraseMidnight:          {PluralOther: "À minuit"},
+		phraseAt:                {PluralOther: "À {times}"},
+		phraseMinutesPastBetween: {
+			PluralOne:   "À {n} minute après l'heure entre {start} et {end}",
+			PluralOther: "À {n} minutes après l'heure entre {start} et {end}",
+		},
+		phraseMinuteListPastBetween: {PluralOther: "Aux minutes {minutes} de chaque heure entre {start} et {end}"},
+		phrasePeriodically:          {PluralOther: "S'exécute périodiquement"},
+		phraseEveryDay:              {PluralOther: "tous les jours"},
+		phraseWeekdays:              {PluralOther: "en semaine (lun-ven)"},
+		phraseDayOfWeekRange:        {PluralOther: "du {start} au {end}"},
+		phraseDayOfWeekList:         {PluralOther: "le {days}"},
+		phraseEveryDayOfWeek:        {PluralOther: "chaque {day}"},
+		phraseFirstDayOfMonth:       {PluralOther: "le premier jour de chaque mois"},
+		phraseDayOfMonth:            {PluralOther: "le {n} de chaque mois"},
+		phraseDayOfMonthRange:       {PluralOther: "du {start} au {end} de chaque mois"},
+		phraseInMonth:               {PluralOther: "en {month}"},
+		phraseMonthRange:            {PluralOther: "de {start} à {end}"},
+		phraseInMonths:              {PluralOther: "en {months}"},
+		phraseOnDate:                {PluralOther: "le {day} {month}"},
+		phraseEverySecond:           {PluralOther: "Toutes les secondes"},
+		phraseEveryNSeconds: {
+			PluralOne:   "Toutes les secondes",
+			PluralOther: "Toutes les {n} secondes",
+		},
+		phraseAtSecond:       {PluralOther: "À la seconde {n}"},
+		phraseAtSeconds:      {PluralOther: "Aux secondes {seconds}"},
+		phraseWithSeconds:    {PluralOther: "{seconds}, {rest}"},
+		phraseLastDayOfMonth: {PluralOther: "le dernier jour du mois"},
+		phraseDaysBeforeLastDay: {
+			PluralOne:   "{n} jour avant le dernier jour du mois",
+			PluralOther: "{n} jours avant le dernier jour du mois",
+		},
+		phraseLastWeekdayOfMonth: {PluralOther: "le dernier jour ouvré du mois"},
+		phraseNearestWeekday:     {PluralOther: "le jour ouvré le plus proche du {day} du mois"},
+		phraseNthDayOfWeek:       {PluralOther: "le {nth} {day} du mois"},
+		phraseLastDayOfWeek:      {PluralOther: "le dernier {day} du mois"},
+		phraseInYears:            {PluralOther: "en {years}"},
+		phraseEveryNHours: {
+			PluralOne:   "Toutes les heures",
+			PluralOther: "Toutes les {n} heures",
+		},
+		phraseEveryNDays: {
+			PluralOne:   "Tous les jours",
+			PluralOther: "Tous les {n} jours",
+		},
+		phraseRandomPick: {PluralOther: "{rest} (aléatoire, fixe par hôte)"},
+	},
+	Days: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	Months: [12]string{
+		"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+	},
+	Nth: [6]string{"", "premier", "deuxième", "troisième", "quatrième", "cinquième"},
+}
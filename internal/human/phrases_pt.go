@@ -0,0 +1,114 @@
+package human
+
+import (
+	"fmt"
+	"strings"
+)
+
+// portugueseAgree makes masculine ordinals feminine ("primeiro" becomes
+// "primeira") for the weekdays whose names are feminine
+func portugueseAgree(word string, gender Gender, _ PluralCategory) string {
+	if gender == GenderFeminine && strings.HasSuffix(word, "o") {
+		return strings.TrimSuffix(word, "o") + "a"
+	}
+	return word
+}
+
+// portugueseGrammar is the grammar of the Portuguese catalog
+var portugueseGrammar = &Grammar{
+	Locale:      "pt",
+	Plural:      PluralRuleOneOther,
+	SlotOrder:   []Slot{SlotTime, SlotDay, SlotMonth},
+	Agree:       portugueseAgree,
+	Ordinal:     func(n int, _ Gender) string { return fmt.Sprintf("%d", n) },
+	Conjunction: "e",
+}
+
+// portugueseCatalog contains the Portuguese phrase templates and words.
+// Monday to Friday (segunda-feira to sexta-feira) are feminine.
+var portugueseCatalog = &Catalog{
+	Locale:  "pt",
+	Grammar: portugueseGrammar,
+	Phrases: map[string]Forms{
+		phraseEveryMinute: {PluralOther: "A cada minuto"},
+		phraseEveryNMinutes: {
+			PluralOne:   "A cada minuto",
+			PluralOther: "A cada {n} minutos",
+		},
+		phraseEveryNMinutesBetween: {
+			PluralOne:   "A cada minuto entre {start} e {end}",
+			PluralOther: "A cada {n} minutos entre {start} e {end}",
+		},
+		phraseEveryNMinutesAt: {
+			PluralOne:   "A cada minuto às {hours}",
+			PluralOther: "A cada {n} minutos às {hours}",
+		},
+		phraseStartOfEveryHour:  {PluralOther: "No início de cada hora"},
+		phraseMinuteOfEveryHour: {PluralOther: "No minuto {n} de cada hora"},
+		phraseMidnight:          {PluralOther: "À meia-noite"},
+		phraseAt:                {PluralOther: "Às {times}"},
+		phraseMinutesPastBetween: {
+			PluralOne:   "Ao minuto {n} de cada hora entre {start} e {end}",
+			PluralOther: "Aos {n} minutos de cada hora entre {start} e {end}",
+		},
+		phraseMinuteListPastBetween:           {PluralOther: "Aos minutos {minutes} de cada hora entre {start} e {end}"},
+		phrasePeriodically:                    {PluralOther: "Executa periodicamente"},
+		phraseEveryDay:                        {PluralOther: "todos os dias"},
+		phraseWeekdays:                        {PluralOther: "em dias úteis (seg-sex)"},
+		phraseDayOfWeekRange:                  {PluralOther: "de {start} a {end}"},
+		phraseDayOfWeekList:                   {PluralOther: "aos {days}"},
+		phraseDayOfWeekList + feminineSuffix:  {PluralOther: "às {days}"},
+		phraseEveryDayOfWeek:                  {PluralOther: "todo {day}"},
+		phraseEveryDayOfWeek + feminineSuffix: {PluralOther: "toda {day}"},
+		phraseFirstDayOfMonth:                 {PluralOther: "no primeiro dia de cada mês"},
+		phraseDayOfMonth:                      {PluralOther: "no dia {n} de cada mês"},
+		phraseDayOfMonthRange:                 {PluralOther: "nos dias {start} a {end} de cada mês"},
+		phraseInMonth:                         {PluralOther: "em {month}"},
+		phraseMonthRange:                      {PluralOther: "de {start} a {end}"},
+		phraseInMonths:                        {PluralOther: "em {months}"},
+		phraseOnDate:                          {PluralOther: "em {day} de {month}"},
+		phraseEverySecond:                     {PluralOther: "A cada segundo"},
+		phraseEveryNSeconds: {
+			PluralOne:   "A cada segundo",
+			PluralOther: "A cada {n} segundos",
+		},
+		phraseAtSecond:       {PluralOther: "No segundo {n}"},
+		phraseAtSeconds:      {PluralOther: "Nos segundos {seconds}"},
+		phraseWithSeconds:    {PluralOther: "{seconds}, {rest}"},
+		phraseLastDayOfMonth: {PluralOther: "no último dia do mês"},
+		phraseDaysBeforeLastDay: {
+			PluralOne:   "{n} dia antes do último dia do mês",
+			PluralOther: "{n} dias antes do último dia do mês",
+		},
+		phraseLastWeekdayOfMonth:             {PluralOther: "no último dia útil do mês"},
+		phraseNearestWeekday:                 {PluralOther: "no dia útil mais próximo do dia {day} do mês"},
+		phraseNthDayOfWeek:                   {PluralOther: "no {nth} {day} do mês"},
+		phraseNthDayOfWeek + feminineSuffix:  {PluralOther: "na {nth} {day} do mês"},
+		phraseLastDayOfWeek:                  {PluralOther: "no último {day} do mês"},
+		phraseLastDayOfWeek + feminineSuffix: {PluralOther: "na última {day} do mês"},
+		phraseInYears:                        {PluralOther: "em {years}"},
+		phraseEveryNHours: {
+			PluralOne:   "A cada hora",
+			PluralOther: "A cada {n} horas",
+		},
+		phraseEveryNDays: {
+			PluralOne:   "Todos os dias",
+			PluralOther: "A cada {n} dias",
+		},
+		phraseRandomPick: {PluralOther: "{rest} (aleatório, fixo por host)"},
+	},
+	Days: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+	DayPlurals: [7]string{
+		"domingos", "segundas-feiras", "terças-feiras", "quartas-feiras",
+		"quintas-feiras", "sextas-feiras", "sábados",
+	},
+	DayGenders: [7]Gender{
+		GenderMasculine, GenderFeminine, GenderFeminine, GenderFeminine,
+		GenderFeminine, GenderFeminine, GenderMasculine,
+	},
+	Months: [12]string{
+		"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+		"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+	},
+	Nth: [6]string{"", "primeiro", "segundo", "terceiro", "quarto", "quinto"},
+}
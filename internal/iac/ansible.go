@@ -0,0 +1,128 @@
+package iac
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"gopkg.in/yaml.v3"
+)
+
+// AnsibleOptions configures the tasks written by WriteAnsible
+type AnsibleOptions struct {
+	User     string // Crontab owner, for jobs without a user column (optional)
+	CronFile string // File under /etc/cron.d (or absolute path) to manage instead of a user's crontab (optional)
+	Source   string // Crontab the tasks were made from, named in the header (optional)
+}
+
+// ansibleTask is a task of the ansible.builtin.cron module
+type ansibleTask struct {
+	Name string      `yaml:"name"`
+	Cron ansibleCron `yaml:"ansible.builtin.cron"`
+}
+
+type ansibleCron struct {
+	Name        string `yaml:"name"`
+	Env         bool   `yaml:"env,omitempty"`
+	SpecialTime string `yaml:"special_time,omitempty"`
+	Minute      string `yaml:"minute,omitempty"`
+	Hour        string `yaml:"hour,omitempty"`
+	Day         string `yaml:"day,omitempty"`
+	Month       string `yaml:"month,omitempty"`
+	Weekday     string `yaml:"weekday,omitempty"`
+	Job         string `yaml:"job"`
+	User        string `yaml:"user,omitempty"`
+	CronFile    string `yaml:"cron_file,omitempty"`
+}
+
+// specialTimes maps @aliases to the special_time values of the cron module
+var specialTimes = map[string]string{
+	"@reboot":   "reboot",
+	"@yearly":   "yearly",
+	"@annually": "annually",
+	"@monthly":  "monthly",
+	"@weekly":   "weekly",
+	"@daily":    "daily",
+	"@hourly":   "hourly",
+}
+
+// WriteAnsible writes the variables and jobs of a crontab as a YAML list of
+// ansible.builtin.cron tasks. Jobs are named after their cronkit:name,
+// comment or command; the module finds the entries it manages by these
+// names. Schedules are written as they are in the crontab. Jobs with
+// invalid schedules are left out and returned, as are variables set more
+// than once, which the cron module keeps one value of; variables set after
+// a job are written but returned too, since the module writes them before
+// every job.
+func WriteAnsible(w io.Writer, entries []*crontab.Entry, opts AnsibleOptions) ([]Skipped, error) {
+	var tasks []ansibleTask
+	var skipped []Skipped
+	env := make(map[string]string)
+	used := make(names)
+	jobsSeen := false
+
+	for _, entry := range entries {
+		if name, value, ok := entry.EnvVar(); ok {
+			if previous, seen := env[name]; seen {
+				if previous != value {
+					skipped = append(skipped, Skipped{entry.LineNumber, fmt.Sprintf("%s is set again; Ansible keeps one value per variable (%q)", name, previous)})
+				}
+				continue
+			}
+			env[name] = value
+			if jobsSeen {
+				skipped = append(skipped, Skipped{entry.LineNumber, fmt.Sprintf("%s is set after a job; the cron module writes variables before every job", name)})
+			}
+			tasks = append(tasks, ansibleTask{
+				Name: "Cron variable " + name,
+				Cron: ansibleCron{Name: name, Env: true, Job: value, User: opts.User, CronFile: opts.CronFile},
+			})
+			continue
+		}
+		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
+			continue
+		}
+
+		job := entry.Job
+		jobsSeen = true
+		cron := ansibleCron{Job: job.Command, User: opts.User, CronFile: opts.CronFile}
+		if job.User != "" {
+			cron.User = job.User
+		}
+		if special, ok := specialTimes[strings.ToLower(job.Expression)]; ok {
+			cron.SpecialTime = special
+		} else if fields := strings.Fields(job.Expression); job.Valid && len(fields) == 5 {
+			cron.Minute, cron.Hour, cron.Day, cron.Month, cron.Weekday = fields[0], fields[1], fields[2], fields[3], fields[4]
+		} else {
+			reason := "invalid expression"
+			if job.Error != "" {
+				reason += ": " + job.Error
+			}
+			skipped = append(skipped, Skipped{job.LineNumber, reason})
+			continue
+		}
+
+		name := jobName(job)
+		cron.Name = used.unique(name, fmt.Sprintf("%s (line %d)", name, job.LineNumber))
+		tasks = append(tasks, ansibleTask{Name: "Cron job " + cron.Name, Cron: cron})
+	}
+
+	header := "# ansible.builtin.cron tasks generated by cronkit export ansible"
+	if opts.Source != "" {
+		header += " from " + opts.Source
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return skipped, err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(tasks); err != nil {
+		return nil, fmt.Errorf("failed to write tasks: %w", err)
+	}
+	return skipped, encoder.Close()
+}
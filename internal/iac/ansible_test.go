@@ -0,0 +1,58 @@
+package iac
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, content string, layout crontab.Layout) []*crontab.Entry {
+	t.Helper()
+	entries, err := crontab.ParseReaderLayout(strings.NewReader(content), layout)
+	require.NoError(t, err)
+	return entries
+}
+
+func TestWriteAnsible(t *testing.T) {
+	entries := parse(t, "PATH=/usr/bin:/bin\n# cronkit:name=backup\n0 2 * * * /usr/bin/backup.sh\n@daily /usr/bin/clean.sh\n@daily /usr/bin/clean.sh\n61 * * * * /usr/bin/broken.sh\nMAILTO=ops\nPATH=/bin\n", crontab.LayoutUser)
+
+	var buf bytes.Buffer
+	skipped, err := WriteAnsible(&buf, entries, AnsibleOptions{User: "deploy", Source: "jobs.cron"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "# ansible.builtin.cron tasks generated by cronkit export ansible from jobs.cron\n- name: Cron variable PATH\n"))
+
+	var tasks []ansibleTask
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &tasks))
+	require.Len(t, tasks, 5)
+	assert.Equal(t, ansibleCron{Name: "PATH", Env: true, Job: "/usr/bin:/bin", User: "deploy"}, tasks[0].Cron)
+	assert.Equal(t, "Cron job backup", tasks[1].Name)
+	assert.Equal(t, ansibleCron{Name: "backup", Minute: "0", Hour: "2", Day: "*", Month: "*", Weekday: "*", Job: "/usr/bin/backup.sh", User: "deploy"}, tasks[1].Cron)
+	assert.Equal(t, ansibleCron{Name: "/usr/bin/clean.sh", SpecialTime: "daily", Job: "/usr/bin/clean.sh", User: "deploy"}, tasks[2].Cron)
+	assert.Equal(t, "/usr/bin/clean.sh (line 5)", tasks[3].Cron.Name, "names are unique")
+	assert.Equal(t, "MAILTO", tasks[4].Cron.Name)
+
+	require.Len(t, skipped, 3)
+	assert.Equal(t, 6, skipped[0].Line)
+	assert.Contains(t, skipped[0].Reason, "invalid expression")
+	assert.Equal(t, "line 7: MAILTO is set after a job; the cron module writes variables before every job", skipped[1].String())
+	assert.Equal(t, `line 8: PATH is set again; Ansible keeps one value per variable ("/usr/bin:/bin")`, skipped[2].String())
+
+	t.Run("system crontabs keep their user column", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := WriteAnsible(&buf, parse(t, "*/5 * * * * root /usr/sbin/poll\n", crontab.LayoutSystem), AnsibleOptions{CronFile: "poll"})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "    user: root\n    cron_file: poll\n")
+	})
+
+	t.Run("empty crontab", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := WriteAnsible(&buf, nil, AnsibleOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "# ansible.builtin.cron tasks generated by cronkit export ansible\n[]\n", buf.String())
+	})
+}
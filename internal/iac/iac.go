@@ -0,0 +1,72 @@
+// Package iac writes the jobs of a crontab as infrastructure as code:
+// ansible.builtin.cron tasks that manage the same crontab entries, and
+// Terraform resources that move the jobs to a cloud scheduler (Amazon
+// EventBridge Scheduler or Google Cloud Scheduler).
+package iac
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Skipped is a crontab line that could not be exported, or was exported
+// with a loss worth knowing about
+type Skipped struct {
+	Line   int
+	Reason string
+}
+
+// String describes the skipped line, e.g. "line 3: @reboot has no schedule"
+func (s Skipped) String() string {
+	return fmt.Sprintf("line %d: %s", s.Line, s.Reason)
+}
+
+// maxNameLength bounds the names derived from commands
+const maxNameLength = 60
+
+// jobName returns a name for a job: its cronkit:name, else its comment,
+// else its command, shortened
+func jobName(job *crontab.Job) string {
+	name := job.Metadata.Name
+	if name == "" {
+		name = strings.TrimSpace(job.Comment)
+	}
+	if name == "" {
+		name = strings.Join(strings.Fields(job.Command), " ")
+	}
+	if runes := []rune(name); len(runes) > maxNameLength {
+		name = strings.TrimSpace(string(runes[:maxNameLength])) + "…"
+	}
+	return name
+}
+
+// names hands out names that are unique within an export
+type names map[string]bool
+
+// unique returns name, or alternative when name was handed out already.
+// Alternatives name the crontab line and are unique in turn.
+func (n names) unique(name, alternative string) string {
+	if n[name] {
+		name = alternative
+	}
+	n[name] = true
+	return name
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9]+`)
+
+// identifier turns a name into a Terraform identifier such as
+// "nightly_backup"
+func identifier(name string) string {
+	id := strings.Trim(nonIdentifier.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if len(id) > maxNameLength {
+		id = strings.TrimRight(id[:maxNameLength], "_")
+	}
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "job_" + id
+	}
+	return strings.TrimRight(id, "_")
+}
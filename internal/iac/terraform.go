@@ -0,0 +1,222 @@
+package iac
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/eventbridge"
+)
+
+// Terraform providers
+const (
+	ProviderAWS = "aws" // aws_scheduler_schedule (Amazon EventBridge Scheduler)
+	ProviderGCP = "gcp" // google_cloud_scheduler_job (Google Cloud Scheduler)
+)
+
+// Providers returns the providers WriteTerraform supports
+func Providers() []string {
+	return []string{ProviderAWS, ProviderGCP}
+}
+
+// TerraformOptions configures the resources written by WriteTerraform
+type TerraformOptions struct {
+	Provider string // ProviderAWS or ProviderGCP
+	Timezone string // Time zone of jobs without CRON_TZ= (optional: the scheduler's default, UTC)
+	Source   string // Crontab the resources were made from, named in the header (optional)
+}
+
+// terraformVariable is an input variable of the written configuration
+type terraformVariable struct {
+	name, description string
+}
+
+// terraformSchedule is a schedule expression of a job, and the suffix of
+// the name of its resource
+type terraformSchedule struct {
+	suffix, expression string
+}
+
+// terraformProvider writes the resources of one provider
+type terraformProvider struct {
+	resource  string
+	variables []terraformVariable
+	// schedules converts the schedule of a job, with warnings about what
+	// the conversion changes
+	schedules func(schedule *cronx.Schedule) ([]terraformSchedule, []string, error)
+	// attributes returns the schedule attributes of a resource
+	attributes func(expression, timezone string) [][2]string
+	// blocks returns the nested blocks of a resource, which send input to
+	// the target
+	blocks func(input string) string
+}
+
+var terraformProviders = map[string]terraformProvider{
+	ProviderAWS: {
+		resource: "aws_scheduler_schedule",
+		variables: []terraformVariable{
+			{"target_arn", "ARN of the target that runs the jobs, such as an ECS cluster or a Lambda function"},
+			{"role_arn", "ARN of the IAM role EventBridge Scheduler assumes to invoke the target"},
+		},
+		schedules: func(schedule *cronx.Schedule) ([]terraformSchedule, []string, error) {
+			conv, err := eventbridge.FromCron(schedule)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(conv.Output) == 2 {
+				// Cron runs when either day field matches: one schedule each
+				return []terraformSchedule{{"_by_day", conv.Output[0]}, {"_by_weekday", conv.Output[1]}}, conv.Warnings, nil
+			}
+			return []terraformSchedule{{"", conv.Output[0]}}, conv.Warnings, nil
+		},
+		attributes: func(expression, timezone string) [][2]string {
+			attrs := [][2]string{{"schedule_expression", hclString(expression)}}
+			if timezone != "" {
+				attrs = append(attrs, [2]string{"schedule_expression_timezone", hclString(timezone)})
+			}
+			return attrs
+		},
+		blocks: func(input string) string {
+			return "\n  flexible_time_window {\n    mode = \"OFF\"\n  }\n\n  target {\n" +
+				hclAttributes("    ", [][2]string{{"arn", "var.target_arn"}, {"role_arn", "var.role_arn"}, {"input", input}}) +
+				"  }\n"
+		},
+	},
+	ProviderGCP: {
+		resource: "google_cloud_scheduler_job",
+		variables: []terraformVariable{
+			{"target_uri", "URL Cloud Scheduler posts each job to"},
+		},
+		schedules: func(schedule *cronx.Schedule) ([]terraformSchedule, []string, error) {
+			// Aliases and ~ random values are written as the fields they
+			// stand for
+			fields := []string{schedule.Minute.Raw(), schedule.Hour.Raw(), schedule.DayOfMonth.Raw(), schedule.Month.Raw(), schedule.DayOfWeek.Raw()}
+			return []terraformSchedule{{"", strings.Join(fields, " ")}}, nil, nil
+		},
+		attributes: func(expression, timezone string) [][2]string {
+			attrs := [][2]string{{"schedule", hclString(expression)}}
+			if timezone != "" {
+				attrs = append(attrs, [2]string{"time_zone", hclString(timezone)})
+			}
+			return attrs
+		},
+		blocks: func(input string) string {
+			return "\n  http_target {\n" +
+				hclAttributes("    ", [][2]string{
+					{"uri", "var.target_uri"},
+					{"http_method", `"POST"`},
+					{"headers", `{ "Content-Type" = "application/json" }`},
+					{"body", "base64encode(" + input + ")"},
+				}) +
+				"  }\n"
+		},
+	},
+}
+
+// WriteTerraform writes the jobs of a crontab as Terraform resources of a
+// cloud scheduler, with input variables for the target that runs them. The
+// target receives the job as JSON: {"command": ..., "user": ...}. Jobs whose
+// schedules the scheduler cannot express, such as @reboot jobs, are left
+// out and returned. Amazon EventBridge needs two schedules for jobs that
+// restrict both day fields; conversion warnings are written as comments.
+func WriteTerraform(w io.Writer, entries []*crontab.Entry, opts TerraformOptions) ([]Skipped, error) {
+	provider, ok := terraformProviders[opts.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q (supported: %s)", opts.Provider, strings.Join(Providers(), ", "))
+	}
+
+	var out strings.Builder
+	var skipped []Skipped
+	header := "# Terraform resources generated by cronkit export terraform"
+	if opts.Source != "" {
+		header += " from " + opts.Source
+	}
+	out.WriteString(header + "\n")
+	for _, v := range provider.variables {
+		fmt.Fprintf(&out, "\nvariable %q {\n  description = %s\n  type        = string\n}\n", v.name, hclString(v.description))
+	}
+
+	parser := cronx.NewParser()
+	used := make(names)
+	for _, entry := range entries {
+		if entry.Type != crontab.EntryTypeJob || entry.Job == nil {
+			continue
+		}
+		job := entry.Job
+		if strings.EqualFold(job.Expression, "@reboot") {
+			skipped = append(skipped, Skipped{job.LineNumber, "@reboot jobs have no schedule"})
+			continue
+		}
+		schedule, err := parser.Parse(job.Expression)
+		if err == nil && !job.Valid {
+			err = fmt.Errorf("%s", job.Error)
+		}
+		if err != nil {
+			skipped = append(skipped, Skipped{job.LineNumber, "invalid expression: " + err.Error()})
+			continue
+		}
+		schedules, warnings, err := provider.schedules(schedule)
+		if err != nil {
+			skipped = append(skipped, Skipped{job.LineNumber, err.Error()})
+			continue
+		}
+		if schedule.Resolved != "" {
+			warnings = append(warnings, fmt.Sprintf("random values picked once: %s runs as %s", job.Expression, schedule.Resolved))
+		}
+
+		timezone := job.Timezone
+		if timezone == "" {
+			timezone = opts.Timezone
+		}
+		input := "jsonencode({ command = " + hclString(job.Command) + " })"
+		if job.User != "" {
+			input = "jsonencode({ command = " + hclString(job.Command) + ", user = " + hclString(job.User) + " })"
+		}
+		id := identifier(jobName(job))
+		id = used.unique(id, fmt.Sprintf("%s_line_%d", id, job.LineNumber))
+		description := fmt.Sprintf("crontab line %d: %s", job.LineNumber, jobName(job))
+
+		for _, s := range schedules {
+			fmt.Fprintf(&out, "\n# Line %d: %s %s\n", job.LineNumber, job.Expression, job.Command)
+			for _, warning := range warnings {
+				fmt.Fprintf(&out, "# Warning: %s\n", warning)
+			}
+			name := id + s.suffix
+			fmt.Fprintf(&out, "resource %q %q {\n", provider.resource, name)
+			attrs := [][2]string{{"name", hclString(name)}, {"description", hclString(description)}}
+			out.WriteString(hclAttributes("  ", append(attrs, provider.attributes(s.expression, timezone)...)))
+			out.WriteString(provider.blocks(input))
+			out.WriteString("}\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, out.String()); err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
+
+// hclAttributes writes attributes one per line, aligning their equals signs
+// the way terraform fmt does
+func hclAttributes(indent string, attrs [][2]string) string {
+	width := 0
+	for _, attr := range attrs {
+		width = max(width, len(attr[0]))
+	}
+	var b strings.Builder
+	for _, attr := range attrs {
+		fmt.Fprintf(&b, "%s%-*s = %s\n", indent, width, attr[0], attr[1])
+	}
+	return b.String()
+}
+
+// hclEscaper escapes the characters HCL strings interpret, including the
+// ${ and %{ template sequences
+var hclEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+
+// hclString returns s as a quoted HCL string
+func hclString(s string) string {
+	return `"` + hclEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,92 @@
+package iac
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const terraformCrontab = `# cronkit:name=nightly-backup
+0 2 * * * /usr/bin/backup.sh --to "${BUCKET}"
+*/15 9-17 1,15 * MON-FRI /usr/bin/report.sh
+@reboot /usr/bin/warm.sh
+CRON_TZ=Europe/Paris
+0~10 3 * * * /usr/bin/rotate.sh
+`
+
+func TestWriteTerraform_AWS(t *testing.T) {
+	var buf bytes.Buffer
+	skipped, err := WriteTerraform(&buf, parse(t, terraformCrontab, crontab.LayoutUser), TerraformOptions{Provider: ProviderAWS, Timezone: "UTC"})
+	require.NoError(t, err)
+	output := buf.String()
+
+	assert.Contains(t, output, "variable \"target_arn\" {\n")
+	assert.Contains(t, output, `resource "aws_scheduler_schedule" "nightly_backup" {
+  name                         = "nightly_backup"
+  description                  = "crontab line 2: nightly-backup"
+  schedule_expression          = "cron(0 2 * * ? *)"
+  schedule_expression_timezone = "UTC"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  target {
+    arn      = var.target_arn
+    role_arn = var.role_arn
+    input    = jsonencode({ command = "/usr/bin/backup.sh --to \"$${BUCKET}\"" })
+  }
+}
+`)
+
+	t.Run("both day fields make two schedules", func(t *testing.T) {
+		assert.Contains(t, output, `schedule_expression          = "cron(0/15 9-17 1,15 * ? *)"`)
+		assert.Contains(t, output, `schedule_expression          = "cron(0/15 9-17 ? * MON-FRI *)"`)
+		assert.Contains(t, output, `resource "aws_scheduler_schedule" "usr_bin_report_sh_by_weekday"`)
+	})
+
+	t.Run("CRON_TZ and random values", func(t *testing.T) {
+		assert.Contains(t, output, "# Warning: random values picked once: 0~10 3 * * * runs as ")
+		assert.Contains(t, output, `schedule_expression_timezone = "Europe/Paris"`)
+	})
+
+	assert.Equal(t, []Skipped{{4, "@reboot jobs have no schedule"}}, skipped)
+}
+
+func TestWriteTerraform_GCP(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := WriteTerraform(&buf, parse(t, "@daily /usr/bin/clean.sh\n59 23 * * 1 root /usr/bin/weekly.sh\n", crontab.LayoutSystem), TerraformOptions{Provider: ProviderGCP})
+	require.NoError(t, err)
+	output := buf.String()
+
+	assert.Contains(t, output, "variable \"target_uri\" {\n")
+	assert.Contains(t, output, `resource "google_cloud_scheduler_job" "usr_bin_weekly_sh" {
+  name        = "usr_bin_weekly_sh"
+  description = "crontab line 2: /usr/bin/weekly.sh"
+  schedule    = "59 23 * * 1"
+
+  http_target {
+    uri         = var.target_uri
+    http_method = "POST"
+    headers     = { "Content-Type" = "application/json" }
+    body        = base64encode(jsonencode({ command = "/usr/bin/weekly.sh", user = "root" }))
+  }
+}
+`)
+	assert.NotContains(t, output, "time_zone")
+}
+
+func TestWriteTerraform_UnknownProvider(t *testing.T) {
+	_, err := WriteTerraform(&bytes.Buffer{}, nil, TerraformOptions{Provider: "azure"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown provider "azure"`)
+}
+
+func TestIdentifier(t *testing.T) {
+	assert.Equal(t, "nightly_backup", identifier("Nightly backup!"))
+	assert.Equal(t, "job_2fa_sync", identifier("2FA sync"))
+	assert.Equal(t, "job", identifier("…"))
+}
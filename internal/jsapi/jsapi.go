@@ -0,0 +1,90 @@
+// Package jsapi is the API of cronkit's WebAssembly build, for running the
+// humanizer and validator in browsers (e.g. in an online cron editor). Every
+// function takes and returns JSON, the Response documents of package api, so
+// the js/wasm shim only converts values and the functions can be tested on
+// any platform. None of them reads files, the environment or the user's
+// crontab.
+package jsapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hzerrad/cronkit/internal/api"
+)
+
+// options are the options of a call: the request's locale along with the
+// options of api.Options, e.g. {"locale": "fr", "count": 5}
+type options struct {
+	Locale string `json:"locale,omitempty"`
+	api.Options
+}
+
+// Explain describes a cron expression
+func Explain(expression, opts string) string {
+	return call(api.CommandExplain, expression, "", opts)
+}
+
+// Validate checks a cron expression
+func Validate(expression, opts string) string {
+	return call(api.CommandCheck, expression, "", opts)
+}
+
+// ValidateCrontab checks the content of a crontab
+func ValidateCrontab(crontab, opts string) string {
+	return call(api.CommandCheck, "", crontab, opts)
+}
+
+// NextRuns lists the next runs of a cron expression
+func NextRuns(expression, opts string) string {
+	return call(api.CommandNext, expression, "", opts)
+}
+
+// Exec runs a request document, as read by "cronkit exec"
+func Exec(request string) string {
+	req, err := api.DecodeRequest(bytes.NewReader([]byte(request)))
+	if err != nil {
+		return encode(api.Response{
+			APIVersion: api.Version,
+			Error:      &api.Error{Code: api.ErrInvalidRequest, Message: err.Error()},
+		})
+	}
+	return encode(api.Execute(req))
+}
+
+// call runs a command with options given as a JSON object (empty for none)
+func call(command, expression, crontab, opts string) string {
+	var o options
+	if opts != "" {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(opts)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&o); err != nil {
+			return encode(api.Response{
+				APIVersion: api.Version,
+				Command:    command,
+				Error:      &api.Error{Code: api.ErrInvalidRequest, Message: fmt.Sprintf("invalid options: %v", err)},
+			})
+		}
+	}
+	return encode(api.Execute(api.Request{
+		Command:    command,
+		Expression: expression,
+		Crontab:    crontab,
+		Locale:     o.Locale,
+		Options:    o.Options,
+	}))
+}
+
+// encode encodes a response; responses only hold encodable values
+func encode(resp api.Response) string {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(api.Response{
+			APIVersion: api.Version,
+			Command:    resp.Command,
+			Error:      &api.Error{Code: api.ErrExecutionFailed, Message: err.Error()},
+		})
+	}
+	return string(data)
+}
@@ -0,0 +1,83 @@
+package jsapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// response is a decoded response document
+type response struct {
+	Command string                 `json:"command"`
+	OK      bool                   `json:"ok"`
+	Result  map[string]interface{} `json:"result"`
+	Error   *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func decode(t *testing.T, data string) response {
+	t.Helper()
+	var resp response
+	require.NoError(t, json.Unmarshal([]byte(data), &resp), data)
+	return resp
+}
+
+func TestExplain(t *testing.T) {
+	resp := decode(t, Explain("0 9 * * 1-5", ""))
+	require.True(t, resp.OK)
+	assert.Equal(t, "explain", resp.Command)
+	assert.Equal(t, "At 09:00 on weekdays (Mon-Fri)", resp.Result["description"])
+
+	resp = decode(t, Explain("0 9 * * 1-5", `{"locale": "fr"}`))
+	assert.Equal(t, "À 09:00 en semaine (lun-ven)", resp.Result["description"])
+
+	resp = decode(t, Explain("H 4 * * *", `{"dialect": "jenkins", "jenkinsJob": "nightly"}`))
+	require.True(t, resp.OK)
+	assert.Contains(t, resp.Result, "resolved")
+}
+
+func TestValidate(t *testing.T) {
+	resp := decode(t, Validate("61 * * * *", ""))
+	require.True(t, resp.OK)
+	assert.Equal(t, false, resp.Result["valid"])
+
+	resp = decode(t, ValidateCrontab("0 2 * * * /usr/bin/backup.sh\n", `{"verbose": true}`))
+	require.True(t, resp.OK)
+	assert.Equal(t, true, resp.Result["valid"])
+	assert.Equal(t, float64(1), resp.Result["totalJobs"])
+}
+
+func TestNextRuns(t *testing.T) {
+	resp := decode(t, NextRuns("@daily", `{"count": 2, "from": "2025-01-01T00:00:00Z", "timezone": "Europe/Paris"}`))
+	require.True(t, resp.OK)
+	runs := resp.Result["nextRuns"].([]interface{})
+	require.Len(t, runs, 2)
+	assert.Equal(t, "2025-01-02T00:00:00+01:00", runs[0].(map[string]interface{})["timestamp"])
+}
+
+func TestExec(t *testing.T) {
+	resp := decode(t, Exec(`{"command": "list", "crontab": "0 2 * * * /usr/bin/backup.sh\n"}`))
+	require.True(t, resp.OK)
+	assert.Len(t, resp.Result["jobs"], 1)
+
+	resp = decode(t, Exec(`{"command": `))
+	assert.False(t, resp.OK)
+	assert.Equal(t, "invalid_request", resp.Error.Code)
+}
+
+func TestInvalidOptions(t *testing.T) {
+	for _, opts := range []string{`{"bogus": 1}`, `[1]`, `{"count": "two"}`} {
+		resp := decode(t, NextRuns("@daily", opts))
+		assert.False(t, resp.OK, opts)
+		assert.Equal(t, "next", resp.Command)
+		assert.Equal(t, "invalid_request", resp.Error.Code)
+		assert.Contains(t, resp.Error.Message, "invalid options")
+	}
+
+	resp := decode(t, Explain("", ""))
+	assert.Equal(t, "invalid_request", resp.Error.Code)
+}
@@ -0,0 +1,45 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Crontab writes CronJobs as a crontab. Each job is preceded by a blank line
+// and a directive naming it namespace/name, with its namespace, time zone
+// and concurrency policy. Suspended CronJobs do not run, so they are written
+// as comments. header, when set, is written first as a comment.
+func Crontab(jobs []CronJob, header string) string {
+	var b strings.Builder
+	if header != "" {
+		fmt.Fprintf(&b, "# %s\n", header)
+	}
+	for _, job := range jobs {
+		b.WriteString("\n")
+		directive := fmt.Sprintf("# %s%s=%s %s=%s", crontab.DirectivePrefix, crontab.DirectiveName, directiveValue(job.Namespace+"/"+job.Name), DirectiveNamespace, directiveValue(job.Namespace))
+		if job.TimeZone != "" {
+			directive += fmt.Sprintf(" %s=%s", crontab.DirectiveTimezone, directiveValue(job.TimeZone))
+		}
+		if job.Concurrency != "" {
+			directive += fmt.Sprintf(" %s=%s", DirectiveConcurrency, job.Concurrency)
+		}
+		b.WriteString(directive + "\n")
+
+		if job.Suspended {
+			fmt.Fprintf(&b, "# suspended: %s %s\n", job.Schedule, job.Command)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s\n", job.Schedule, job.Command)
+	}
+	return b.String()
+}
+
+// directiveValue quotes directive values containing spaces
+func directiveValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + value + `"`
+	}
+	return value
+}
@@ -0,0 +1,159 @@
+// Package k8s reads the CronJobs of a Kubernetes cluster and converts them
+// to a crontab, so that every command analyzing crontabs can analyze the
+// schedules of a cluster.
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// listPageSize is how many CronJobs are requested at a time
+const listPageSize = 500
+
+// Directive keys of imported jobs, besides the name and time zone
+const (
+	DirectiveNamespace   = "namespace"   // Namespace of the CronJob
+	DirectiveConcurrency = "concurrency" // concurrencyPolicy, when not Allow
+)
+
+// CronJob is what cronkit reads of a Kubernetes CronJob
+type CronJob struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Schedule    string `json:"schedule"`
+	TimeZone    string `json:"timeZone,omitempty"`
+	Suspended   bool   `json:"suspended"`
+	Concurrency string `json:"concurrencyPolicy,omitempty"`
+	Command     string `json:"command"` // Commands of the job's containers, else their images
+}
+
+// Client reads the CronJobs of a cluster
+type Client struct {
+	clientset kubernetes.Interface
+	Context   string // Kubeconfig context the client uses
+	Namespace string // Namespace of the context, "default" when it sets none
+}
+
+// NewClient returns a client for a context of the kubeconfig at path. The
+// empty path loads $KUBECONFIG, else ~/.kube/config, and the empty context
+// is the current context.
+func NewClient(path, context string) (*Client, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = path
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: context})
+
+	raw, err := config.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if context == "" {
+		context = raw.CurrentContext
+	}
+	rest, err := config.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig context %q: %w", context, err)
+	}
+	namespace, _, err := config.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the namespace of context %q: %w", context, err)
+	}
+	clientset, err := kubernetes.NewForConfig(rest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return &Client{clientset: clientset, Context: context, Namespace: namespace}, nil
+}
+
+// NewClientFor returns a client using clientset, such as a fake clientset
+// in tests
+func NewClientFor(clientset kubernetes.Interface, namespace string) *Client {
+	return &Client{clientset: clientset, Namespace: namespace}
+}
+
+// CronJobs lists the CronJobs of a namespace, or of all namespaces when
+// namespace is empty, sorted by namespace and name
+func (c *Client) CronJobs(ctx context.Context, namespace string) ([]CronJob, error) {
+	var jobs []CronJob
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		list, err := c.clientset.BatchV1().CronJobs(namespace).List(ctx, opts)
+		if err != nil {
+			if namespace == "" {
+				return nil, fmt.Errorf("failed to list CronJobs in all namespaces: %w", err)
+			}
+			return nil, fmt.Errorf("failed to list CronJobs in namespace %s: %w", namespace, err)
+		}
+		for _, item := range list.Items {
+			jobs = append(jobs, FromAPI(item))
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	slices.SortFunc(jobs, func(a, b CronJob) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return jobs, nil
+}
+
+// FromAPI converts a CronJob of the Kubernetes API. A CRON_TZ= or TZ=
+// prefix of the schedule, which older clusters accept, is moved to the
+// time zone.
+func FromAPI(cj batchv1.CronJob) CronJob {
+	job := CronJob{
+		Namespace: cj.Namespace,
+		Name:      cj.Name,
+		Schedule:  strings.TrimSpace(cj.Spec.Schedule),
+		Suspended: cj.Spec.Suspend != nil && *cj.Spec.Suspend,
+		Command:   command(cj.Spec.JobTemplate.Spec.Template.Spec.Containers),
+	}
+	if cj.Spec.TimeZone != nil {
+		job.TimeZone = *cj.Spec.TimeZone
+	}
+	for _, prefix := range []string{crontab.CronTZVar + "=", crontab.TZVar + "="} {
+		if rest, ok := strings.CutPrefix(job.Schedule, prefix); ok {
+			zone, schedule, _ := strings.Cut(rest, " ")
+			job.Schedule = strings.TrimSpace(schedule)
+			if job.TimeZone == "" {
+				job.TimeZone = zone
+			}
+		}
+	}
+	if policy := cj.Spec.ConcurrencyPolicy; policy != "" && policy != batchv1.AllowConcurrent {
+		job.Concurrency = string(policy)
+	}
+	return job
+}
+
+// command describes what the containers of a job run: their command and
+// arguments, or their image when the image's entrypoint is used
+func command(containers []corev1.Container) string {
+	parts := make([]string, 0, len(containers))
+	for _, c := range containers {
+		args := append(slices.Clone(c.Command), c.Args...)
+		if len(args) == 0 {
+			parts = append(parts, c.Image)
+			continue
+		}
+		parts = append(parts, strings.Join(args, " "))
+	}
+	if len(parts) == 0 {
+		return "(no containers)"
+	}
+	// A newline would end the crontab line
+	return strings.Join(strings.Fields(strings.Join(parts, " ; ")), " ")
+}
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// cronJob returns a CronJob running containers on schedule
+func cronJob(namespace, name, schedule string, containers ...corev1.Container) *batchv1.CronJob {
+	cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	cj.Spec.Schedule = schedule
+	cj.Spec.JobTemplate.Spec.Template.Spec.Containers = containers
+	return cj
+}
+
+func TestClient_CronJobs(t *testing.T) {
+	suspend := true
+	paris := "Europe/Paris"
+	invoice := cronJob("billing", "invoice", "0 2 * * *", corev1.Container{Image: "acme/billing:1.2", Command: []string{"/app/invoice"}, Args: []string{"--all"}})
+	invoice.Spec.TimeZone = &paris
+	invoice.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+	cleanup := cronJob("billing", "cleanup", "@hourly", corev1.Container{Image: "acme/cleanup:latest"})
+	cleanup.Spec.Suspend = &suspend
+	report := cronJob("analytics", "report", "CRON_TZ=UTC */15 * * * *", corev1.Container{Image: "acme/report", Args: []string{"run", "--daily"}})
+
+	client := NewClientFor(fake.NewClientset(invoice, cleanup, report), "billing")
+
+	t.Run("one namespace", func(t *testing.T) {
+		jobs, err := client.CronJobs(context.Background(), "billing")
+		require.NoError(t, err)
+		assert.Equal(t, []CronJob{
+			{Namespace: "billing", Name: "cleanup", Schedule: "@hourly", Suspended: true, Command: "acme/cleanup:latest"},
+			{Namespace: "billing", Name: "invoice", Schedule: "0 2 * * *", TimeZone: "Europe/Paris", Concurrency: "Forbid", Command: "/app/invoice --all"},
+		}, jobs)
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		jobs, err := client.CronJobs(context.Background(), "")
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+		assert.Equal(t, CronJob{Namespace: "analytics", Name: "report", Schedule: "*/15 * * * *", TimeZone: "UTC", Command: "run --daily"}, jobs[0])
+	})
+}
+
+func TestCrontab(t *testing.T) {
+	jobs := []CronJob{
+		{Namespace: "billing", Name: "cleanup", Schedule: "@hourly", Suspended: true, Command: "acme/cleanup:latest", Concurrency: "Replace"},
+		{Namespace: "billing", Name: "invoice", Schedule: "0 2 * * *", TimeZone: "Europe/Paris", Concurrency: "Forbid", Command: "/app/invoice --all"},
+		{Namespace: "analytics", Name: "report", Schedule: "*/15 * * * *", Command: "run --daily"},
+	}
+	text := Crontab(jobs, "Kubernetes CronJobs")
+	assert.Equal(t, `# Kubernetes CronJobs
+
+# cronkit:name=billing/cleanup namespace=billing concurrency=Replace
+# suspended: @hourly acme/cleanup:latest
+
+# cronkit:name=billing/invoice namespace=billing tz=Europe/Paris concurrency=Forbid
+0 2 * * * /app/invoice --all
+
+# cronkit:name=analytics/report namespace=analytics
+*/15 * * * * run --daily
+`, text)
+
+	entries, err := crontab.ParseReader(strings.NewReader(text))
+	require.NoError(t, err)
+	var parsed []*crontab.Job
+	for _, entry := range entries {
+		if entry.Type == crontab.EntryTypeJob {
+			parsed = append(parsed, entry.Job)
+		}
+	}
+	require.Len(t, parsed, 2, "suspended CronJobs do not run")
+	assert.Equal(t, "billing/invoice", parsed[0].Metadata.Name)
+	assert.Equal(t, "Europe/Paris", parsed[0].Timezone)
+	assert.Equal(t, map[string]string{"namespace": "billing", "concurrency": "Forbid"}, parsed[0].Metadata.Extra)
+	assert.Equal(t, "analytics/report", parsed[1].Metadata.Name)
+	assert.Empty(t, parsed[1].Timezone, "metadata of suspended jobs does not leak")
+}
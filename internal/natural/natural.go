@@ -0,0 +1,866 @@
+// Package natural translates common English schedule phrases such as
+// "every weekday at 9am" into cron expressions.
+package natural
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Suggestion is a likely meaning of a phrase that could not be translated
+type Suggestion struct {
+	Phrase     string // Unambiguous rewording of the phrase, or a description of the meaning
+	Expression string // Cron expression of the suggestion
+	Dialect    string // Cron dialect the expression needs, empty for standard cron
+}
+
+// Error reports a phrase that is ambiguous or cannot be translated, with
+// suggestions when likely meanings exist
+type Error struct {
+	Input       string       // The phrase that was translated
+	Reason      string       // Why the phrase could not be translated
+	Suggestions []Suggestion // Likely meanings, best first
+}
+
+// Error implements the error interface. Suggestions are listed one per line.
+func (e *Error) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "cannot translate %q: %s", e.Input, e.Reason)
+	if len(e.Suggestions) > 0 {
+		sb.WriteString("\nDid you mean:")
+		for _, s := range e.Suggestions {
+			fmt.Fprintf(&sb, "\n  %s  (%s", s.Phrase, s.Expression)
+			if s.Dialect != "" {
+				fmt.Fprintf(&sb, " with --dialect %s", s.Dialect)
+			}
+			sb.WriteString(")")
+		}
+	}
+	return sb.String()
+}
+
+// Parse translates a phrase into a 5-field cron expression. Supported phrases
+// combine frequencies ("every 15 minutes", "every 2 hours", "hourly",
+// "daily", "weekly", "monthly", "yearly"), times ("at 9am", "at 9:30 and
+// 17:00", "noon", "midnight"), time ranges ("from 9am to 5pm"), days of the
+// week ("on weekdays", "every monday and friday", "mon-fri"), days of the
+// month ("on the 1st", "on the 15th") and months ("in january", "march
+// through may"). Schedules without a time run at midnight.
+//
+// Phrases that are ambiguous, such as a bare hour that may be AM or PM, or
+// that standard cron cannot express, return an *Error with suggestions.
+func Parse(text string) (string, error) {
+	t := &translation{input: strings.TrimSpace(text), tokens: tokenize(text)}
+	if len(t.tokens) == 0 {
+		return "", &Error{Input: t.input, Reason: "the phrase is empty"}
+	}
+	if err := t.scan(); err != nil {
+		return "", err
+	}
+	return t.expression()
+}
+
+// clockTime is a time of day
+type clockTime struct {
+	hour, minute int
+}
+
+// context tells how a bare number is read
+type context int
+
+const (
+	contextNone       context = iota
+	contextTime               // After "at": numbers are times
+	contextDayOfMonth         // After a month name or "day": numbers are days of the month
+)
+
+// kind is the kind of the last value scanned, which "to" makes a range of
+type kind int
+
+const (
+	kindNone kind = iota
+	kindTime
+	kindDayOfWeek
+	kindDayOfMonth
+	kindMonth
+)
+
+// translation is the state of the translation of a phrase
+type translation struct {
+	input  string
+	tokens []string
+	pos    int
+
+	context context
+	last    kind
+	between bool // Inside "between ... and ...", where "and" ends a time range
+
+	everyMinute bool
+	minuteStep  int
+	everyHour   bool
+	hourStep    int
+	dayStep     int
+	monthStep   int
+	weekly      bool
+	monthly     bool
+	yearly      bool
+
+	times       []clockTime
+	timeRange   []clockTime // Start and end of a "from ... to ..." range
+	daysOfWeek  []string
+	daysOfMonth []string
+	months      []string
+}
+
+// fillers are words that carry no meaning of their own
+var fillers = map[string]bool{
+	"on": true, "the": true, "of": true, "in": true, "a": true, "an": true,
+	"run": true, "runs": true, "once": true, "month": true, "year": true,
+	"o'clock": true, "oclock": true, "starting": true, "during": true, "per": true,
+}
+
+// rangeWords join the start and end of a range
+var rangeWords = map[string]bool{
+	"to": true, "through": true, "thru": true, "until": true, "till": true, "-": true,
+}
+
+// dayNames maps day-of-week words to cron values (Sunday=0)
+var dayNames = map[string]int{
+	"sun": 0, "sunday": 0, "sundays": 0,
+	"mon": 1, "monday": 1, "mondays": 1,
+	"tue": 2, "tues": 2, "tuesday": 2, "tuesdays": 2,
+	"wed": 3, "wednesday": 3, "wednesdays": 3,
+	"thu": 4, "thur": 4, "thurs": 4, "thursday": 4, "thursdays": 4,
+	"fri": 5, "friday": 5, "fridays": 5,
+	"sat": 6, "saturday": 6, "saturdays": 6,
+}
+
+// monthNames maps month words to cron values (January=1)
+var monthNames = map[string]int{
+	"jan": 1, "january": 1, "feb": 2, "february": 2, "mar": 3, "march": 3,
+	"apr": 4, "april": 4, "may": 5, "jun": 6, "june": 6, "jul": 7, "july": 7,
+	"aug": 8, "august": 8, "sep": 9, "sept": 9, "september": 9,
+	"oct": 10, "october": 10, "nov": 11, "november": 11, "dec": 12, "december": 12,
+}
+
+// ordinalWords maps the words of the first occurrences to numbers
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+}
+
+// quartzDayNames are the day-of-week names of Quartz expressions (Sunday=0)
+var quartzDayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// keywords are the words the translator understands other than numbers,
+// used to suggest corrections of misspelled words
+var keywords = []string{
+	"every", "each", "other", "and", "at", "from", "between", "to", "through", "until",
+	"minute", "minutes", "hour", "hours", "day", "days", "week", "weeks", "month", "months", "year", "years",
+	"hourly", "daily", "nightly", "weekly", "monthly", "yearly", "annually",
+	"noon", "midnight", "weekday", "weekdays", "weekend", "weekends", "last",
+	"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday",
+	"january", "february", "march", "april", "may", "june", "july", "august",
+	"september", "october", "november", "december",
+}
+
+var (
+	clockPattern   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	ordinalPattern = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)$`)
+)
+
+// tokenize lowercases a phrase and splits it into words. Commas and "&" are
+// read as "and", dashes between words as "to", and "am"/"pm" are joined to
+// the time before them.
+func tokenize(text string) []string {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer("a.m.", "am", "p.m.", "pm", ",", " and ", "&", " and ", "-", " - ").Replace(text)
+
+	var tokens []string
+	for _, word := range strings.Fields(text) {
+		if (word == "am" || word == "pm") && len(tokens) > 0 && clockPattern.MatchString(tokens[len(tokens)-1]+word) {
+			tokens[len(tokens)-1] += word
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// scan reads the tokens of the phrase
+func (t *translation) scan() error {
+	for t.pos < len(t.tokens) {
+		tok := t.tokens[t.pos]
+		var err error
+		switch {
+		case fillers[tok]:
+			t.pos++
+		case tok == "and" && t.between && t.last == kindTime:
+			// "between 9am and 5pm"
+			t.between = false
+			err = t.rangeTo()
+		case tok == "and":
+			// Lists continue the current context
+			t.pos++
+		case tok == "at":
+			t.context = contextTime
+			t.pos++
+		case tok == "every" || tok == "each":
+			err = t.every()
+		case tok == "from" || tok == "between":
+			t.pos++
+			if t.pos < len(t.tokens) && (t.isTime(t.tokens[t.pos]) || isNumber(t.tokens[t.pos])) {
+				t.context = contextTime
+				t.between = tok == "between"
+			}
+		case rangeWords[tok]:
+			err = t.rangeTo()
+		case tok == "hourly":
+			t.everyHour = true
+			t.pos++
+		case tok == "daily" || tok == "nightly" || tok == "day" || tok == "days":
+			if tok == "day" && t.pos+1 < len(t.tokens) && isNumber(t.tokens[t.pos+1]) {
+				t.context = contextDayOfMonth
+			}
+			t.pos++
+		case tok == "weekly":
+			t.weekly = true
+			t.pos++
+		case tok == "monthly":
+			t.monthly = true
+			t.pos++
+		case tok == "yearly" || tok == "annually":
+			t.yearly = true
+			t.pos++
+		case tok == "weekday" || tok == "weekdays":
+			t.daysOfWeek = append(t.daysOfWeek, "1-5")
+			t.last = kindNone
+			t.pos++
+		case tok == "weekend" || tok == "weekends":
+			t.daysOfWeek = append(t.daysOfWeek, "0", "6")
+			t.last = kindNone
+			t.pos++
+		case hasKey(dayNames, tok):
+			t.daysOfWeek = append(t.daysOfWeek, strconv.Itoa(dayNames[tok]))
+			t.last = kindDayOfWeek
+			t.pos++
+		case hasKey(monthNames, tok):
+			t.months = append(t.months, strconv.Itoa(monthNames[tok]))
+			t.last = kindMonth
+			t.context = contextDayOfMonth
+			t.pos++
+		case hasKey(ordinalWords, tok) || tok == "last":
+			err = t.ordinal()
+		case ordinalPattern.MatchString(tok):
+			err = t.dayOfMonth(ordinalPattern.FindStringSubmatch(tok)[1])
+			t.pos++
+		case isNumber(tok) && t.context == contextDayOfMonth:
+			err = t.dayOfMonth(tok)
+			t.pos++
+		case t.isTime(tok) || (isNumber(tok) && t.context == contextTime):
+			err = t.time()
+		case isNumber(tok):
+			err = t.errorf([]string{"at " + tok, "on the " + tok + ordinalSuffix(tok)}, "unclear whether %s is a time or a day of the month", tok)
+		default:
+			err = t.unknownWord()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// every reads "every [N|other] unit", or leaves the word after "every"
+// (a day or month name) to the main scan
+func (t *translation) every() error {
+	t.pos++
+	n := 1
+	if t.pos < len(t.tokens) {
+		next := t.tokens[t.pos]
+		switch {
+		case next == "other":
+			n = 2
+			t.pos++
+		case isNumber(next):
+			if t.pos+1 >= len(t.tokens) || unit(t.tokens[t.pos+1]) == "" {
+				return t.errorf([]string{next + " minutes", next + " hours"}, "%q needs a unit such as minutes or hours", "every "+next)
+			}
+			n, _ = strconv.Atoi(next)
+			t.pos++
+		}
+	}
+	if t.pos >= len(t.tokens) {
+		return nil
+	}
+
+	switch unit(t.tokens[t.pos]) {
+	case "minute":
+		if n == 1 {
+			t.everyMinute = true
+		} else if n > 59 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("every %d minutes is longer than an hour", n)}
+		}
+		t.minuteStep = n
+	case "hour":
+		if n == 1 {
+			t.everyHour = true
+		} else if n > 23 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("every %d hours is longer than a day", n)}
+		}
+		t.hourStep = n
+	case "day":
+		if n > 31 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("every %d days is longer than a month", n)}
+		}
+		t.dayStep = n
+	case "week":
+		if n > 1 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("cron cannot run every %d weeks: weeks do not line up with months", n)}
+		}
+		t.weekly = true
+	case "month":
+		if n > 12 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("every %d months is longer than a year", n)}
+		}
+		t.monthStep = n
+	case "year":
+		if n > 1 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("cron cannot run every %d years", n)}
+		}
+		t.yearly = true
+	default:
+		// "every monday", "every january": the name is read by the main scan
+		if n > 1 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("cron cannot run every other %s", t.tokens[t.pos])}
+		}
+		return nil
+	}
+	t.pos++
+	return nil
+}
+
+// unit returns the singular form of a unit word, or "" for other words
+func unit(word string) string {
+	switch word {
+	case "minute", "minutes", "min", "mins":
+		return "minute"
+	case "hour", "hours", "hr", "hrs":
+		return "hour"
+	case "day", "days":
+		return "day"
+	case "week", "weeks":
+		return "week"
+	case "month", "months":
+		return "month"
+	case "year", "years":
+		return "year"
+	}
+	return ""
+}
+
+// rangeTo turns the last value scanned into a range ending at the next value
+func (t *translation) rangeTo() error {
+	t.pos++
+	if t.pos >= len(t.tokens) {
+		return &Error{Input: t.input, Reason: "a range has no end"}
+	}
+	next := t.tokens[t.pos]
+
+	switch t.last {
+	case kindDayOfWeek:
+		end, ok := dayNames[next]
+		if !ok {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("a range of days ends with %q", next)}
+		}
+		start, _ := strconv.Atoi(t.daysOfWeek[len(t.daysOfWeek)-1])
+		t.daysOfWeek = append(t.daysOfWeek[:len(t.daysOfWeek)-1], span(start, end, 0, 6)...)
+		t.pos++
+	case kindMonth:
+		end, ok := monthNames[next]
+		if !ok {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("a range of months ends with %q", next)}
+		}
+		start, _ := strconv.Atoi(t.months[len(t.months)-1])
+		t.months = append(t.months[:len(t.months)-1], span(start, end, 1, 12)...)
+		t.pos++
+	case kindDayOfMonth:
+		if m := ordinalPattern.FindStringSubmatch(next); m != nil {
+			next = m[1]
+		}
+		end, err := strconv.Atoi(next)
+		if err != nil || end < 1 || end > 31 {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("a range of days of the month ends with %q", next)}
+		}
+		start, _ := strconv.Atoi(t.daysOfMonth[len(t.daysOfMonth)-1])
+		if end < start {
+			return &Error{Input: t.input, Reason: fmt.Sprintf("the range of days of the month %d to %d runs backwards", start, end)}
+		}
+		t.daysOfMonth[len(t.daysOfMonth)-1] = fmt.Sprintf("%d-%d", start, end)
+		t.pos++
+	case kindTime:
+		start := t.times[len(t.times)-1]
+		t.times = t.times[:len(t.times)-1]
+		if err := t.time(); err != nil {
+			return err
+		}
+		end := t.times[len(t.times)-1]
+		t.times = t.times[:len(t.times)-1]
+		if end.hour < start.hour || (end.hour == start.hour && end.minute <= start.minute) {
+			return &Error{Input: t.input, Reason: "time ranges across midnight cannot be expressed in one cron expression"}
+		}
+		t.timeRange = []clockTime{start, end}
+	default:
+		return &Error{Input: t.input, Reason: fmt.Sprintf("%q does not follow a day, month or time", t.tokens[t.pos-1])}
+	}
+	t.last = kindNone
+	return nil
+}
+
+// span returns the values from start to end, wrapping around from max to
+// min, as one range or a list
+func span(start, end, lowest, highest int) []string {
+	if start <= end {
+		if start == end {
+			return []string{strconv.Itoa(start)}
+		}
+		return []string{fmt.Sprintf("%d-%d", start, end)}
+	}
+	var values []string
+	for v := start; v <= highest; v++ {
+		values = append(values, strconv.Itoa(v))
+	}
+	for v := lowest; v <= end; v++ {
+		values = append(values, strconv.Itoa(v))
+	}
+	return values
+}
+
+// ordinal reads "first day", or reports the nth or last weekday and the last
+// day of the month, which need the Quartz dialect
+func (t *translation) ordinal() error {
+	tok := t.tokens[t.pos]
+	next := ""
+	if t.pos+1 < len(t.tokens) {
+		next = t.tokens[t.pos+1]
+	}
+
+	if day, ok := dayNames[next]; ok {
+		field := fmt.Sprintf("%s#%d", quartzDayNames[day], ordinalWords[tok])
+		if tok == "last" {
+			field = fmt.Sprintf("%dL", day+1)
+		}
+		return t.quartzError(fmt.Sprintf("the %s %s of the month cannot be expressed in standard cron", tok, next), "?", field)
+	}
+	if next == "day" || next == "days" {
+		if tok == "last" {
+			return t.quartzError("the last day of the month cannot be expressed in standard cron", "L", "?")
+		}
+		t.pos += 2
+		return t.dayOfMonth(strconv.Itoa(ordinalWords[tok]))
+	}
+	return t.unknownWord()
+}
+
+// quartzError reports a day that only the Quartz dialect can express,
+// suggesting the Quartz expression with the given day fields
+func (t *translation) quartzError(reason, dayOfMonth, dayOfWeek string) error {
+	at := clockTime{}
+	for _, tok := range t.tokens[t.pos:] {
+		if c, ambiguous, ok := parseClock(tok); ok && !ambiguous {
+			at = c
+			break
+		}
+	}
+	return &Error{
+		Input:  t.input,
+		Reason: reason,
+		Suggestions: []Suggestion{{
+			Phrase:     "the Quartz dialect",
+			Expression: fmt.Sprintf("0 %d %d %s * %s", at.minute, at.hour, dayOfMonth, dayOfWeek),
+			Dialect:    "quartz",
+		}},
+	}
+}
+
+// dayOfMonth adds a day of the month. The caller advances past its words.
+func (t *translation) dayOfMonth(value string) error {
+	day, err := strconv.Atoi(value)
+	if err != nil || day < 1 || day > 31 {
+		return &Error{Input: t.input, Reason: fmt.Sprintf("%s is not a day of the month (1-31)", value)}
+	}
+	t.daysOfMonth = append(t.daysOfMonth, strconv.Itoa(day))
+	t.last = kindDayOfMonth
+	t.context = contextNone
+	return nil
+}
+
+// isTime reports whether a word is unmistakably a time of day
+func (t *translation) isTime(word string) bool {
+	if word == "noon" || word == "midnight" {
+		return true
+	}
+	return clockPattern.MatchString(word) && !isNumber(word)
+}
+
+// time reads a time of day. A bare hour from 1 to 12 may be AM or PM, so it
+// is reported with both readings as suggestions.
+func (t *translation) time() error {
+	tok := t.tokens[t.pos]
+	c, ambiguous, ok := parseClock(tok)
+	if !ok {
+		return &Error{Input: t.input, Reason: fmt.Sprintf("%q is not a time of day", tok)}
+	}
+	if ambiguous {
+		return t.errorf([]string{tok + "am", tok + "pm"}, "%q could be AM or PM", tok)
+	}
+	t.times = append(t.times, c)
+	t.last = kindTime
+	t.context = contextTime
+	t.pos++
+	return nil
+}
+
+// parseClock parses a time of day: "noon", "midnight", "9am", "9:30pm",
+// "17:00" or a bare hour, which is ambiguous from 1 to 12
+func parseClock(word string) (clockTime, bool, bool) {
+	switch word {
+	case "noon", "midday":
+		return clockTime{hour: 12}, false, true
+	case "midnight":
+		return clockTime{}, false, true
+	}
+
+	m := clockPattern.FindStringSubmatch(word)
+	if m == nil {
+		return clockTime{}, false, false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if minute > 59 {
+		return clockTime{}, false, false
+	}
+
+	switch {
+	case m[3] != "":
+		if hour < 1 || hour > 12 {
+			return clockTime{}, false, false
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	case hour > 23:
+		return clockTime{}, false, false
+	case m[2] == "" && len(m[1]) == 1 || m[2] == "" && hour >= 10 && hour <= 12:
+		// A bare hour without a leading zero: "9" could be 9am or 9pm
+		return clockTime{hour: hour}, hour >= 1 && hour <= 12, true
+	}
+	return clockTime{hour: hour, minute: minute}, false, true
+}
+
+// unknownWord reports a word the translator does not understand, suggesting
+// the closest keyword when the word looks misspelled
+func (t *translation) unknownWord() error {
+	tok := t.tokens[t.pos]
+	best, bestDistance := "", 3
+	for _, keyword := range keywords {
+		if d := distance(tok, keyword); d < bestDistance {
+			best, bestDistance = keyword, d
+		}
+	}
+	if best == "" || len(tok) < 3 {
+		return &Error{Input: t.input, Reason: fmt.Sprintf("unknown word %q", tok)}
+	}
+	return t.errorf([]string{best}, "unknown word %q", tok)
+}
+
+// errorf returns an *Error whose suggestions replace the current token with
+// each of the given replacements, keeping those that translate
+func (t *translation) errorf(replacements []string, format string, args ...interface{}) error {
+	e := &Error{Input: t.input, Reason: fmt.Sprintf(format, args...)}
+	for _, replacement := range replacements {
+		tokens := append([]string{}, t.tokens[:t.pos]...)
+		tokens = append(tokens, strings.Fields(replacement)...)
+		tokens = append(tokens, t.tokens[t.pos+1:]...)
+		phrase := strings.Join(tokens, " ")
+		if expr, err := Parse(phrase); err == nil {
+			e.Suggestions = append(e.Suggestions, Suggestion{Phrase: phrase, Expression: expr})
+		}
+	}
+	return e
+}
+
+// expression assembles the cron expression of the scanned phrase
+func (t *translation) expression() (string, error) {
+	minute, hour, err := t.timeFields()
+	if err != nil {
+		return "", err
+	}
+
+	dom := join(t.daysOfMonth)
+	month := join(t.months)
+	dow := join(t.daysOfWeek)
+
+	if t.dayStep > 1 {
+		if dom != "*" {
+			return "", &Error{Input: t.input, Reason: "every few days cannot be combined with days of the month"}
+		}
+		dom = fmt.Sprintf("*/%d", t.dayStep)
+	}
+	if t.monthStep > 1 {
+		if month != "*" {
+			return "", &Error{Input: t.input, Reason: "every few months cannot be combined with named months"}
+		}
+		month = fmt.Sprintf("*/%d", t.monthStep)
+	}
+	if t.monthStep > 0 || t.monthly || t.yearly {
+		if dom == "*" && dow == "*" {
+			dom = "1"
+		}
+	}
+	if t.yearly && month == "*" {
+		month = "1"
+	}
+	if t.weekly && dow == "*" && dom == "*" {
+		dow = "0"
+	}
+
+	if dom != "*" && dow != "*" {
+		// Cron runs when either day field matches, which is rarely what
+		// "on the 1st and on mondays" means
+		either := fmt.Sprintf("%s %s %s %s %s", minute, hour, dom, month, dow)
+		return "", &Error{
+			Input:  t.input,
+			Reason: "cron runs a job when either the day of the month or the day of the week matches",
+			Suggestions: []Suggestion{
+				{Phrase: "on either day", Expression: either},
+				{Phrase: "on the days of the month only", Expression: fmt.Sprintf("%s %s %s %s *", minute, hour, dom, month)},
+				{Phrase: "on the days of the week only", Expression: fmt.Sprintf("%s %s * %s %s", minute, hour, month, dow)},
+			},
+		}
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s", minute, hour, dom, month, dow), nil
+}
+
+// timeFields returns the minute and hour fields of the scanned phrase
+func (t *translation) timeFields() (string, string, error) {
+	rangeHours := func(inclusive bool) string {
+		start, end := t.timeRange[0], t.timeRange[1]
+		last := end.hour
+		if !inclusive && end.minute == 0 {
+			// "every 15 minutes from 9am to 5pm" ends at 16:45
+			last--
+		}
+		if last <= start.hour {
+			return strconv.Itoa(start.hour)
+		}
+		return fmt.Sprintf("%d-%d", start.hour, last)
+	}
+
+	switch {
+	case t.everyMinute || t.minuteStep > 1:
+		minute := "*"
+		if t.minuteStep > 1 {
+			minute = fmt.Sprintf("*/%d", t.minuteStep)
+		}
+		if t.timeRange != nil {
+			return minute, rangeHours(false), nil
+		}
+		if len(t.times) > 0 {
+			hours := make([]int, 0, len(t.times))
+			for _, c := range t.times {
+				if c.minute != 0 {
+					return "", "", &Error{Input: t.input, Reason: "a time with minutes cannot be combined with a minute frequency"}
+				}
+				hours = append(hours, c.hour)
+			}
+			return minute, joinInts(hours), nil
+		}
+		return minute, "*", nil
+
+	case t.everyHour || t.hourStep > 1:
+		if len(t.times) > 0 {
+			return "", "", &Error{Input: t.input, Reason: "times of day cannot be combined with an hourly frequency; use a range such as \"from 9am to 5pm\""}
+		}
+		hour := "*"
+		if t.timeRange != nil {
+			hour = rangeHours(true)
+		}
+		if t.hourStep > 1 {
+			if t.timeRange == nil {
+				hour = fmt.Sprintf("*/%d", t.hourStep)
+			} else {
+				hour = fmt.Sprintf("%s/%d", hour, t.hourStep)
+			}
+		}
+		minute := "0"
+		if t.timeRange != nil {
+			minute = strconv.Itoa(t.timeRange[0].minute)
+		}
+		return minute, hour, nil
+
+	case t.timeRange != nil:
+		phrase := "every hour " + strings.Join(t.tokens, " ")
+		e := &Error{Input: t.input, Reason: "a time range needs a frequency such as every hour or every 15 minutes"}
+		if expr, err := Parse(phrase); err == nil {
+			e.Suggestions = []Suggestion{{Phrase: phrase, Expression: expr}}
+		}
+		return "", "", e
+
+	case len(t.times) > 0:
+		return t.timeList()
+	}
+
+	if !t.hasSchedule() {
+		return "", "", &Error{Input: t.input, Reason: "no schedule found; try phrases such as \"every day at 9am\" or \"every 15 minutes\""}
+	}
+	// Day-level schedules without a time run at midnight
+	return "0", "0", nil
+}
+
+// timeList returns the minute and hour fields of a list of times, which cron
+// can express when the times share their minute or their hour
+func (t *translation) timeList() (string, string, error) {
+	minutes := map[int]bool{}
+	hours := map[int]bool{}
+	for _, c := range t.times {
+		minutes[c.minute] = true
+		hours[c.hour] = true
+	}
+	switch {
+	case len(minutes) == 1:
+		return strconv.Itoa(t.times[0].minute), joinInts(keys(hours)), nil
+	case len(hours) == 1:
+		return joinInts(keys(minutes)), strconv.Itoa(t.times[0].hour), nil
+	}
+
+	e := &Error{Input: t.input, Reason: "times that differ in both hour and minute need one cron expression each"}
+	for _, c := range t.times {
+		e.Suggestions = append(e.Suggestions, Suggestion{
+			Phrase:     fmt.Sprintf("at %02d:%02d", c.hour, c.minute),
+			Expression: fmt.Sprintf("%d %d * * *", c.minute, c.hour),
+		})
+	}
+	return "", "", e
+}
+
+// hasSchedule reports whether the phrase named any frequency or day
+func (t *translation) hasSchedule() bool {
+	return t.dayStep > 0 || t.monthStep > 0 || t.weekly || t.monthly || t.yearly ||
+		len(t.daysOfWeek) > 0 || len(t.daysOfMonth) > 0 || len(t.months) > 0 ||
+		containsAny(t.tokens, "daily", "nightly", "day", "days")
+}
+
+// join returns the comma-separated values of a field, or "*" when empty
+func join(values []string) string {
+	if len(values) == 0 {
+		return "*"
+	}
+	seen := map[string]bool{}
+	unique := values[:0:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return strings.Join(unique, ",")
+}
+
+// joinInts returns the sorted, comma-separated unique values
+func joinInts(values []int) string {
+	sort.Ints(values)
+	strs := make([]string, 0, len(values))
+	for i, v := range values {
+		if i > 0 && values[i-1] == v {
+			continue
+		}
+		strs = append(strs, strconv.Itoa(v))
+	}
+	return strings.Join(strs, ",")
+}
+
+// keys returns the keys of a set
+func keys(set map[int]bool) []int {
+	values := make([]int, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	return values
+}
+
+// hasKey reports whether a map has the given key
+func hasKey(m map[string]int, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// containsAny reports whether any of the words is in the tokens
+func containsAny(tokens []string, words ...string) bool {
+	for _, tok := range tokens {
+		for _, w := range words {
+			if tok == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ordinalSuffix returns the English ordinal suffix of a number
+func ordinalSuffix(number string) string {
+	n, _ := strconv.Atoi(number)
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// isNumber reports whether a word is a whole number
+func isNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// distance returns the Levenshtein edit distance between two words
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
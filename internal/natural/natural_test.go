@@ -0,0 +1,147 @@
+package natural_test
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/natural"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		phrase   string
+		expected string
+	}{
+		{"every minute", "* * * * *"},
+		{"every 15 minutes", "*/15 * * * *"},
+		{"every hour", "0 * * * *"},
+		{"hourly", "0 * * * *"},
+		{"every 2 hours", "0 */2 * * *"},
+		{"daily", "0 0 * * *"},
+		{"every day at 9am", "0 9 * * *"},
+		{"every weekday at 9am", "0 9 * * 1-5"},
+		{"Every weekday at 9 AM", "0 9 * * 1-5"},
+		{"weekends at 10:30", "30 10 * * 0,6"},
+		{"at noon on mondays and fridays", "0 12 * * 1,5"},
+		{"every monday, wednesday & friday at 6:15pm", "15 18 * * 1,3,5"},
+		{"mon-fri at 17:00", "0 17 * * 1-5"},
+		{"friday through monday at midnight", "0 0 * * 5,6,0,1"},
+		{"at 9am and 5pm", "0 9,17 * * *"},
+		{"at 9:00 and 9:30", "0,30 9 * * *"},
+		{"every 15 minutes from 9am to 5pm on weekdays", "*/15 9-16 * * 1-5"},
+		{"every 30 minutes between 9:00 and 17:30", "*/30 9-17 * * *"},
+		{"every hour from 9am to 5pm", "0 9-17 * * *"},
+		{"every 2 hours from 8am to 8pm", "0 8-20/2 * * *"},
+		{"every 10 minutes at 2am", "*/10 2 * * *"},
+		{"on the 1st of every month at 6am", "0 6 1 * *"},
+		{"on the 1st and 15th at noon", "0 12 1,15 * *"},
+		{"on the 1st to 7th at 8:00", "0 8 1-7 * *"},
+		{"first day of the month", "0 0 1 * *"},
+		{"monthly", "0 0 1 * *"},
+		{"weekly", "0 0 * * 0"},
+		{"weekly on fridays at 23:00", "0 23 * * 5"},
+		{"yearly", "0 0 1 1 *"},
+		{"every january 1st at midnight", "0 0 1 1 *"},
+		{"july 4th at 9pm", "0 21 4 7 *"},
+		{"every day in march through may at 06:00", "0 6 * 3-5 *"},
+		{"every 3 days at 4am", "0 4 */3 * *"},
+		{"every 3 months", "0 0 1 */3 *"},
+		{"every other hour", "0 */2 * * *"},
+		{"midnight", "0 0 * * *"},
+		{"at 0", "0 0 * * *"},
+		{"at 9 p.m. on sundays", "0 21 * * 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			expression, err := natural.Parse(tt.phrase)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expression)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Run("bare hour is ambiguous", func(t *testing.T) {
+		_, err := natural.Parse("every weekday at 9")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		assert.Contains(t, nerr.Reason, "AM or PM")
+		assert.Equal(t, []natural.Suggestion{
+			{Phrase: "every weekday at 9am", Expression: "0 9 * * 1-5"},
+			{Phrase: "every weekday at 9pm", Expression: "0 21 * * 1-5"},
+		}, nerr.Suggestions)
+		assert.Contains(t, err.Error(), "Did you mean:\n  every weekday at 9am  (0 9 * * 1-5)")
+	})
+
+	t.Run("misspelled words suggest corrections", func(t *testing.T) {
+		_, err := natural.Parse("evry wednesday at 8am")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		require.Len(t, nerr.Suggestions, 1)
+		assert.Equal(t, "0 8 * * 3", nerr.Suggestions[0].Expression)
+	})
+
+	t.Run("number without unit", func(t *testing.T) {
+		_, err := natural.Parse("every 15")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		require.Len(t, nerr.Suggestions, 2)
+		assert.Equal(t, "*/15 * * * *", nerr.Suggestions[0].Expression)
+		assert.Equal(t, "0 */15 * * *", nerr.Suggestions[1].Expression)
+	})
+
+	t.Run("days of the month and week", func(t *testing.T) {
+		_, err := natural.Parse("on the 1st and on mondays at 9am")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		assert.Contains(t, nerr.Reason, "either")
+		require.Len(t, nerr.Suggestions, 3)
+		assert.Equal(t, "0 9 1 * 1", nerr.Suggestions[0].Expression)
+		assert.Equal(t, "0 9 1 * *", nerr.Suggestions[1].Expression)
+		assert.Equal(t, "0 9 * * 1", nerr.Suggestions[2].Expression)
+	})
+
+	t.Run("nth weekday needs quartz", func(t *testing.T) {
+		_, err := natural.Parse("first monday of the month at 9am")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		assert.Equal(t, []natural.Suggestion{
+			{Phrase: "the Quartz dialect", Expression: "0 0 9 ? * MON#1", Dialect: "quartz"},
+		}, nerr.Suggestions)
+	})
+
+	t.Run("last day needs quartz", func(t *testing.T) {
+		_, err := natural.Parse("last day of the month")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		require.Len(t, nerr.Suggestions, 1)
+		assert.Equal(t, "0 0 0 L * ?", nerr.Suggestions[0].Expression)
+	})
+
+	t.Run("times that share neither hour nor minute", func(t *testing.T) {
+		_, err := natural.Parse("at 9:30 and 17:00")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		require.Len(t, nerr.Suggestions, 2)
+		assert.Equal(t, "30 9 * * *", nerr.Suggestions[0].Expression)
+		assert.Equal(t, "0 17 * * *", nerr.Suggestions[1].Expression)
+	})
+
+	t.Run("time range without frequency", func(t *testing.T) {
+		_, err := natural.Parse("from 9am to 5pm")
+		var nerr *natural.Error
+		require.ErrorAs(t, err, &nerr)
+		require.Len(t, nerr.Suggestions, 1)
+		assert.Equal(t, "0 9-17 * * *", nerr.Suggestions[0].Expression)
+	})
+
+	for _, phrase := range []string{"", "whenever", "every 2 weeks", "every other monday", "every 90 minutes", "on the 32nd", "at 25:00"} {
+		t.Run(phrase, func(t *testing.T) {
+			_, err := natural.Parse(phrase)
+			var nerr *natural.Error
+			require.ErrorAs(t, err, &nerr)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package natural
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// offsetPattern matches a term of a "now" offset, e.g. "+2h" or "30m"
+var offsetPattern = regexp.MustCompile(`^([+-]?)(\d+)(w|d|h|m|s)`)
+
+// localLayouts are the date and time layouts without a zone ParseTime reads
+// in now's location
+var localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// ParseTime parses a point in time, absolute or relative to now, in now's
+// location:
+//   - an RFC3339 time (2026-01-05T09:00:00Z), or one without a zone
+//     (2026-01-05T09:00)
+//   - "now", optionally with offsets in weeks, days, hours, minutes and
+//     seconds: "now+2h", "now-30m", "now+1d12h"
+//   - a day, optionally followed by a time of day ("09:00", "9am", "noon"):
+//     "today", "tomorrow", "yesterday", a day of the week ("monday", "next
+//     fri") or a date (2026-01-05)
+//   - a time of day alone, meaning today
+//
+// Days without a time of day start at midnight. A day of the week is its
+// next occurrence after today.
+func ParseTime(text string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t, nil
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(s), now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if strings.HasPrefix(s, "now") {
+		return offset(text, strings.ReplaceAll(s[len("now"):], " ", ""), now)
+	}
+
+	var words []string
+	for _, word := range strings.Fields(s) {
+		if (word == "am" || word == "pm") && len(words) > 0 {
+			words[len(words)-1] += word
+			continue
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+
+	year, month, day := now.Date()
+	rest := words
+	if rest[0] == "next" && len(rest) > 1 && hasKey(dayNames, rest[1]) {
+		rest = rest[1:]
+	}
+	switch first := rest[0]; {
+	case first == "today":
+		rest = rest[1:]
+	case first == "tomorrow":
+		day++
+		rest = rest[1:]
+	case first == "yesterday":
+		day--
+		rest = rest[1:]
+	case hasKey(dayNames, first):
+		days := (dayNames[first] - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		day += days
+		rest = rest[1:]
+	default:
+		if date, err := time.ParseInLocation(time.DateOnly, first, now.Location()); err == nil {
+			year, month, day = date.Date()
+			rest = rest[1:]
+		}
+	}
+
+	if len(rest) > 0 && rest[0] == "at" {
+		rest = rest[1:]
+	}
+	clock := clockTime{}
+	switch {
+	case len(rest) == 1:
+		c, ambiguous, ok := parseClock(rest[0])
+		if !ok {
+			return time.Time{}, unreadable(text)
+		}
+		if ambiguous {
+			return time.Time{}, fmt.Errorf("%q could be %sam or %spm", rest[0], rest[0], rest[0])
+		}
+		clock = c
+	case len(rest) > 1:
+		return time.Time{}, unreadable(text)
+	}
+	return time.Date(year, month, day, clock.hour, clock.minute, 0, 0, now.Location()), nil
+}
+
+// offset adds the terms of a "now" offset, such as "+1d12h", to now. Each
+// term takes the sign of the last term that has one; days and weeks are
+// calendar days.
+func offset(text, terms string, now time.Time) (time.Time, error) {
+	if terms != "" && terms[0] != '+' && terms[0] != '-' {
+		return time.Time{}, unreadable(text)
+	}
+	t := now
+	sign := 1
+	for terms != "" {
+		m := offsetPattern.FindStringSubmatch(terms)
+		if m == nil {
+			return time.Time{}, unreadable(text)
+		}
+		terms = terms[len(m[0]):]
+		switch m[1] {
+		case "+":
+			sign = 1
+		case "-":
+			sign = -1
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, unreadable(text)
+		}
+		n *= sign
+		switch m[3] {
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "d":
+			t = t.AddDate(0, 0, n)
+		case "h":
+			t = t.Add(time.Duration(n) * time.Hour)
+		case "m":
+			t = t.Add(time.Duration(n) * time.Minute)
+		case "s":
+			t = t.Add(time.Duration(n) * time.Second)
+		}
+	}
+	return t, nil
+}
+
+// unreadable reports a time ParseTime cannot read
+func unreadable(text string) error {
+	return fmt.Errorf("cannot read %q as a time (e.g. 2026-01-05T09:00:00Z, now+2h, tomorrow 09:00, monday)", text)
+}
@@ -0,0 +1,67 @@
+package natural_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/natural"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	// A Wednesday
+	now := time.Date(2026, 3, 25, 14, 30, 15, 0, paris)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, paris)
+	}
+
+	tests := []struct {
+		text     string
+		expected time.Time
+	}{
+		{"2026-01-05T09:00:00Z", time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)},
+		{"2026-01-05T09:00", time.Date(2026, 1, 5, 9, 0, 0, 0, paris)},
+		{"now", now},
+		{"NOW", now},
+		{"now+2h", now.Add(2 * time.Hour)},
+		{"now - 30m", now.Add(-30 * time.Minute)},
+		{"now+1d12h", time.Date(2026, 3, 27, 2, 30, 15, 0, paris)},
+		{"now-1h30m", now.Add(-90 * time.Minute)},
+		{"now+1w", time.Date(2026, 4, 1, 14, 30, 15, 0, paris)},
+		// Summer time starts on Sunday the 29th: days are calendar days
+		{"now+5d", time.Date(2026, 3, 30, 14, 30, 15, 0, paris)},
+		{"today", at(25, 0, 0)},
+		{"tomorrow", at(26, 0, 0)},
+		{"tomorrow 09:00", at(26, 9, 0)},
+		{"Tomorrow at 9 am", at(26, 9, 0)},
+		{"yesterday noon", at(24, 12, 0)},
+		{"monday", at(30, 0, 0)},
+		{"next fri 17:45", at(27, 17, 45)},
+		{"wednesday", time.Date(2026, 4, 1, 0, 0, 0, 0, paris)},
+		{"18:00", at(25, 18, 0)},
+		{"9pm", at(25, 21, 0)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, paris)},
+		{"2026-03-01 06:15", time.Date(2026, 3, 1, 6, 15, 0, 0, paris)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := natural.ParseTime(tt.text, now)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "got %s", got)
+		})
+	}
+
+	t.Run("invalid times", func(t *testing.T) {
+		for _, text := range []string{"", "soon", "now+", "now+2y", "now2h", "tomorrow 25:00", "monday tuesday", "next", "tomorrow 9 pm please"} {
+			_, err := natural.ParseTime(text, now)
+			assert.Error(t, err, text)
+		}
+		_, err := natural.ParseTime("tomorrow 9", now)
+		assert.ErrorContains(t, err, `"9" could be 9am or 9pm`)
+		_, err = natural.ParseTime("soon", now)
+		assert.ErrorContains(t, err, `cannot read "soon" as a time`)
+	})
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultSMTPPort is the submission port used when none is configured
+const DefaultSMTPPort = 587
+
+// sendMail delivers a message through an SMTP server; tests replace it
+var sendMail = smtp.SendMail
+
+// email sends the message of an event by email
+type email struct {
+	addr    string
+	auth    smtp.Auth
+	from    string
+	to      []string
+	subject *template.Template
+	message *template.Template
+}
+
+// newEmail returns an email notifier for cfg, using fallback as its message
+// template when it has none
+func newEmail(cfg EmailConfig, fallback string) (*email, error) {
+	if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("email notifications need a host, from and to")
+	}
+	subject, err := parseTemplate("email subject", cfg.Subject, "", DefaultSubject)
+	if err != nil {
+		return nil, err
+	}
+	message, err := parseTemplate("email template", cfg.Template, fallback, DefaultTemplate)
+	if err != nil {
+		return nil, err
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+
+	e := &email{
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		from:    cfg.From,
+		to:      cfg.To,
+		subject: subject,
+		message: message,
+	}
+	if cfg.Username != "" {
+		e.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return e, nil
+}
+
+func (e *email) name() string { return "email" }
+
+func (e *email) send(ctx context.Context, event Event) error {
+	subject, err := render(e.subject, event)
+	if err != nil {
+		return err
+	}
+	body, err := render(e.message, event)
+	if err != nil {
+		return err
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", strings.Join(strings.Fields(subject), " "))
+	fmt.Fprintf(&msg, "Date: %s\r\n", event.Time.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	// net/smtp has no context support: give up waiting at the deadline
+	done := make(chan error, 1)
+	go func() { done <- sendMail(e.addr, e.auth, e.from, e.to, []byte(msg.String())) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
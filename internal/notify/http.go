@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+)
+
+// slack posts the message of an event to a Slack incoming webhook
+type slack struct {
+	url     string
+	message *template.Template
+	client  *http.Client
+}
+
+func (s *slack) name() string { return "slack" }
+
+func (s *slack) send(ctx context.Context, event Event) error {
+	text, err := render(s.message, event)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, s.client, s.url, nil, map[string]string{"text": text})
+}
+
+// webhook posts an event and its message as JSON
+type webhook struct {
+	url     string
+	headers map[string]string
+	message *template.Template
+	client  *http.Client
+}
+
+func (w *webhook) name() string { return "webhook" }
+
+func (w *webhook) send(ctx context.Context, event Event) error {
+	text, err := render(w.message, event)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, w.client, w.url, w.headers, struct {
+		Event
+		Text string `json:"text"`
+	}{event, text})
+}
+
+// postJSON posts body as JSON to url, failing on responses other than 2xx
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s responded %s: %s", url, resp.Status, bytes.TrimSpace(reply))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
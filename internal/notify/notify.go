@@ -0,0 +1,233 @@
+// Package notify sends notifications about crontab problems found while
+// watching or running a crontab (validation regressions, new overlaps and
+// failed runs) to Slack, a generic webhook and email. Notifications are
+// configured in the "notifications" section of the config file:
+//
+//	notifications:
+//	  events: [regression, failure]
+//	  template: "{{.Title}} on {{.Host}}"
+//	  slack:
+//	    webhook-url: https://hooks.slack.com/services/T000/B000/XXXX
+//	  webhook:
+//	    url: https://alerts.example.com/cron
+//	    headers:
+//	      Authorization: Bearer secret
+//	  email:
+//	    host: smtp.example.com
+//	    port: 587
+//	    username: cron
+//	    password: secret
+//	    from: cron@example.com
+//	    to: [ops@example.com]
+//	    subject: "[cronkit] {{.Title}}"
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Event kinds
+const (
+	EventRegression = "regression" // New validation issues after a crontab change
+	EventOverlap    = "overlap"    // More jobs running at once after a crontab change
+	EventFailure    = "failure"    // A job exited with a non-zero code or could not run
+)
+
+// Kinds returns the event kinds, in the order they are documented
+func Kinds() []string {
+	return []string{EventRegression, EventOverlap, EventFailure}
+}
+
+// DefaultTemplate is the message of a notification when no template is
+// configured
+const DefaultTemplate = "[cronkit {{.Source}}] {{.Title}}{{if .Details}}\n{{.Details}}{{end}}"
+
+// DefaultSubject is the subject of notification emails when none is
+// configured
+const DefaultSubject = "[cronkit] {{.Title}}"
+
+// DefaultTimeout bounds each delivery of a notification
+const DefaultTimeout = 10 * time.Second
+
+// Event is what a notification is about. Its fields are available to
+// message templates, e.g. {{.Title}} or {{.ExitCode}}.
+type Event struct {
+	Kind       string    `json:"kind"`
+	Source     string    `json:"source"` // Command that found the problem: watch or daemon
+	Time       time.Time `json:"time"`
+	Host       string    `json:"host"`
+	Crontab    string    `json:"crontab,omitempty"` // File, or empty for the user's crontab
+	Title      string    `json:"title"`             // One-line summary
+	Details    string    `json:"details,omitempty"` // Further lines, such as the new issues
+	Line       int       `json:"line,omitempty"`
+	Expression string    `json:"expression,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	ExitCode   *int      `json:"exitCode,omitempty"` // For failures of jobs that ran
+}
+
+// Config is the "notifications" section of the config file
+type Config struct {
+	Events   []string     `yaml:"events"`   // Kinds to send (default: all)
+	Template string       `yaml:"template"` // Message template (default: DefaultTemplate)
+	Slack    *SlackConfig `yaml:"slack"`
+	Webhook  *HookConfig  `yaml:"webhook"`
+	Email    *EmailConfig `yaml:"email"`
+}
+
+// SlackConfig sends notifications to a Slack incoming webhook
+type SlackConfig struct {
+	WebhookURL string `yaml:"webhook-url"`
+	Template   string `yaml:"template"` // Overrides Config.Template
+}
+
+// HookConfig posts notifications as JSON to a URL. The body is the event
+// with its rendered message in "text".
+type HookConfig struct {
+	URL      string            `yaml:"url"`
+	Headers  map[string]string `yaml:"headers"`
+	Template string            `yaml:"template"` // Overrides Config.Template
+}
+
+// EmailConfig sends notifications by email through an SMTP server
+type EmailConfig struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port"` // Default: 587
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	Subject  string   `yaml:"subject"`  // Subject template (default: DefaultSubject)
+	Template string   `yaml:"template"` // Overrides Config.Template
+}
+
+// Enabled reports whether any destination is configured
+func (c Config) Enabled() bool {
+	return c.Slack != nil || c.Webhook != nil || c.Email != nil
+}
+
+// Validate reports settings that cannot be used, such as unknown event
+// kinds, missing URLs and templates that do not parse
+func (c Config) Validate() error {
+	_, err := New(c)
+	return err
+}
+
+// notifier delivers a notification to one destination
+type notifier interface {
+	name() string
+	send(ctx context.Context, event Event) error
+}
+
+// Notifier sends events to the configured destinations
+type Notifier struct {
+	events    []string
+	notifiers []notifier
+	timeout   time.Duration
+}
+
+// New returns a notifier for cfg. It is nil, and its Notify does nothing,
+// when no destination is configured.
+func New(cfg Config) (*Notifier, error) {
+	for _, kind := range cfg.Events {
+		if !slices.Contains(Kinds(), kind) {
+			return nil, fmt.Errorf("invalid notification event %q (use %s)", kind, strings.Join(Kinds(), ", "))
+		}
+	}
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	n := &Notifier{events: cfg.Events, timeout: DefaultTimeout}
+	client := &http.Client{Timeout: DefaultTimeout}
+	if cfg.Slack != nil {
+		message, err := parseTemplate("slack template", cfg.Slack.Template, cfg.Template, DefaultTemplate)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Slack.WebhookURL == "" {
+			return nil, fmt.Errorf("slack notifications need a webhook-url")
+		}
+		n.notifiers = append(n.notifiers, &slack{url: cfg.Slack.WebhookURL, message: message, client: client})
+	}
+	if cfg.Webhook != nil {
+		message, err := parseTemplate("webhook template", cfg.Webhook.Template, cfg.Template, DefaultTemplate)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Webhook.URL == "" {
+			return nil, fmt.Errorf("webhook notifications need a url")
+		}
+		n.notifiers = append(n.notifiers, &webhook{url: cfg.Webhook.URL, headers: cfg.Webhook.Headers, message: message, client: client})
+	}
+	if cfg.Email != nil {
+		e, err := newEmail(*cfg.Email, cfg.Template)
+		if err != nil {
+			return nil, err
+		}
+		n.notifiers = append(n.notifiers, e)
+	}
+	return n, nil
+}
+
+// Wants reports whether events of a kind are sent
+func (n *Notifier) Wants(kind string) bool {
+	return n != nil && (len(n.events) == 0 || slices.Contains(n.events, kind))
+}
+
+// Notify sends event to every destination, unless its kind is not wanted.
+// Each destination gets DefaultTimeout; the errors of those that failed
+// are joined.
+func (n *Notifier) Notify(ctx context.Context, event Event) error {
+	if !n.Wants(event.Kind) {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Host == "" {
+		event.Host, _ = os.Hostname()
+	}
+
+	var errs []error
+	for _, dest := range n.notifiers {
+		sendCtx, cancel := context.WithTimeout(ctx, n.timeout)
+		if err := dest.send(sendCtx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s notification failed: %w", dest.name(), err))
+		}
+		cancel()
+	}
+	return errors.Join(errs...)
+}
+
+// parseTemplate parses the first non-empty of text, fallback and def
+func parseTemplate(name string, text, fallback, def string) (*template.Template, error) {
+	for _, t := range []string{text, fallback} {
+		if t != "" {
+			def = t
+			break
+		}
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(def)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// render executes a message template for event
+func render(tmpl *template.Template, event Event) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
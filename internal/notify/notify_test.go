@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is an HTTP server recording the requests it receives
+type recorder struct {
+	*httptest.Server
+	mu       sync.Mutex
+	bodies   []map[string]any
+	headers  []http.Header
+	response int
+}
+
+func newRecorder(t *testing.T) *recorder {
+	r := &recorder{response: http.StatusOK}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		r.mu.Lock()
+		r.bodies = append(r.bodies, body)
+		r.headers = append(r.headers, req.Header.Clone())
+		status := r.response
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("no_text"))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func testEvent() Event {
+	exitCode := 2
+	return Event{
+		Kind:       EventFailure,
+		Source:     "daemon",
+		Time:       time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		Host:       "web-1",
+		Title:      "Job on line 3 failed with exit code 2: /usr/bin/backup.sh",
+		Details:    "disk full",
+		Line:       3,
+		Expression: "0 9 * * *",
+		Command:    "/usr/bin/backup.sh",
+		ExitCode:   &exitCode,
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("no destination", func(t *testing.T) {
+		n, err := New(Config{})
+		require.NoError(t, err)
+		assert.Nil(t, n)
+		assert.False(t, n.Wants(EventFailure))
+		assert.NoError(t, n.Notify(context.Background(), testEvent()))
+	})
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{"unknown event", Config{Events: []string{"outage"}}, `invalid notification event "outage"`},
+		{"slack without URL", Config{Slack: &SlackConfig{}}, "slack notifications need a webhook-url"},
+		{"webhook without URL", Config{Webhook: &HookConfig{}}, "webhook notifications need a url"},
+		{"email without recipients", Config{Email: &EmailConfig{Host: "smtp.example.com", From: "cron@example.com"}}, "email notifications need a host, from and to"},
+		{"invalid template", Config{Template: "{{.Title", Slack: &SlackConfig{WebhookURL: "http://localhost"}}, "invalid notification slack template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Error(t, tt.cfg.Validate())
+		})
+	}
+}
+
+func TestNotifier_Slack(t *testing.T) {
+	server := newRecorder(t)
+	n, err := New(Config{Slack: &SlackConfig{WebhookURL: server.URL}})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	require.Len(t, server.bodies, 1)
+	assert.Equal(t, map[string]any{"text": "[cronkit daemon] Job on line 3 failed with exit code 2: /usr/bin/backup.sh\ndisk full"}, server.bodies[0])
+}
+
+func TestNotifier_Webhook(t *testing.T) {
+	server := newRecorder(t)
+	n, err := New(Config{
+		Template: "{{.Kind}} on {{.Host}}: exit {{.ExitCode}}",
+		Webhook:  &HookConfig{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	require.Len(t, server.bodies, 1)
+	body := server.bodies[0]
+	assert.Equal(t, "failure on web-1: exit 2", body["text"])
+	assert.Equal(t, "failure", body["kind"])
+	assert.Equal(t, "0 9 * * *", body["expression"])
+	assert.Equal(t, float64(2), body["exitCode"])
+	assert.Equal(t, "Bearer secret", server.headers[0].Get("Authorization"))
+	assert.Equal(t, "application/json", server.headers[0].Get("Content-Type"))
+
+	t.Run("error responses are reported", func(t *testing.T) {
+		server.response = http.StatusForbidden
+		err := n.Notify(context.Background(), testEvent())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook notification failed")
+		assert.Contains(t, err.Error(), "403 Forbidden: no_text")
+	})
+}
+
+func TestNotifier_Email(t *testing.T) {
+	var sent struct {
+		addr string
+		from string
+		to   []string
+		msg  string
+	}
+	sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		sent.addr, sent.from, sent.to, sent.msg = addr, from, to, string(msg)
+		return nil
+	}
+	t.Cleanup(func() { sendMail = smtp.SendMail })
+
+	n, err := New(Config{Email: &EmailConfig{
+		Host:     "smtp.example.com",
+		Username: "cron",
+		Password: "secret",
+		From:     "cron@example.com",
+		To:       []string{"ops@example.com", "dev@example.com"},
+	}})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.Equal(t, "smtp.example.com:587", sent.addr)
+	assert.Equal(t, "cron@example.com", sent.from)
+	assert.Equal(t, []string{"ops@example.com", "dev@example.com"}, sent.to)
+	assert.Contains(t, sent.msg, "To: ops@example.com, dev@example.com\r\n")
+	assert.Contains(t, sent.msg, "Subject: [cronkit] Job on line 3 failed with exit code 2: /usr/bin/backup.sh\r\n")
+	assert.True(t, strings.HasSuffix(sent.msg, "\r\n\r\n[cronkit daemon] Job on line 3 failed with exit code 2: /usr/bin/backup.sh\r\ndisk full\r\n"), sent.msg)
+
+	t.Run("delivery errors are reported", func(t *testing.T) {
+		sendMail = func(string, smtp.Auth, string, []string, []byte) error { return errors.New("connection refused") }
+		err := n.Notify(context.Background(), testEvent())
+		require.Error(t, err)
+		assert.Equal(t, "email notification failed: connection refused", err.Error())
+	})
+}
+
+func TestNotifier_Events(t *testing.T) {
+	server := newRecorder(t)
+	n, err := New(Config{Events: []string{EventRegression}, Slack: &SlackConfig{WebhookURL: server.URL}})
+	require.NoError(t, err)
+
+	assert.True(t, n.Wants(EventRegression))
+	assert.False(t, n.Wants(EventFailure))
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.Empty(t, server.bodies, "failures are not wanted")
+}
@@ -0,0 +1,53 @@
+// Package parallel spreads independent per-job computations, such as the runs
+// of every job of a large crontab, over a pool of at most GOMAXPROCS workers
+package parallel
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Workers returns how many workers For uses for n items: GOMAXPROCS, but no
+// more than n
+func Workers(n int) int {
+	return max(1, min(runtime.GOMAXPROCS(0), n))
+}
+
+// For calls fn with each index in [0, n) and returns once every call has
+// returned. Calls run on Workers(n) goroutines, which take the next index as
+// they finish one, so fn must be safe for concurrent use and must not depend
+// on the order of calls. With a single worker, calls run on the calling
+// goroutine, in order.
+func For(n int, fn func(i int)) {
+	workers := Workers(n)
+	if workers == 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < n; i = int(next.Add(1) - 1) {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Map returns fn(i) for each index in [0, n), in index order, computed like
+// For
+func Map[T any](n int, fn func(i int) T) []T {
+	results := make([]T, n)
+	For(n, func(i int) {
+		results[i] = fn(i)
+	})
+	return results
+}
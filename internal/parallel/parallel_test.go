@@ -0,0 +1,53 @@
+package parallel
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkers(t *testing.T) {
+	procs := runtime.GOMAXPROCS(0)
+	assert.Equal(t, 1, Workers(0))
+	assert.Equal(t, 1, Workers(1))
+	assert.Equal(t, procs, Workers(procs+100))
+	assert.LessOrEqual(t, Workers(2), 2)
+}
+
+func TestFor(t *testing.T) {
+	t.Run("calls fn once per index", func(t *testing.T) {
+		calls := make([]atomic.Int32, 1000)
+		For(len(calls), func(i int) {
+			calls[i].Add(1)
+		})
+		for i := range calls {
+			assert.Equal(t, int32(1), calls[i].Load(), i)
+		}
+	})
+
+	t.Run("runs in order on a single worker", func(t *testing.T) {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+		var order []int
+		For(5, func(i int) {
+			order = append(order, i)
+		})
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		For(0, func(int) {
+			t.Fatal("fn called without items")
+		})
+	})
+}
+
+func TestMap(t *testing.T) {
+	squares := Map(100, func(i int) int { return i * i })
+	assert.Len(t, squares, 100)
+	for i, sq := range squares {
+		assert.Equal(t, i*i, sq)
+	}
+	assert.Empty(t, Map(0, func(i int) int { return i }))
+}
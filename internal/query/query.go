@@ -0,0 +1,304 @@
+// Package query filters and sorts crontab jobs by their command, directive
+// metadata and schedule, so large crontabs can be queried like a small
+// database
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/check"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// Frequency classifies how often a schedule runs by the shortest interval
+// between its runs
+type Frequency string
+
+const (
+	FrequencySecondly Frequency = "secondly" // Runs less than a minute apart
+	FrequencyMinutely Frequency = "minutely" // Runs less than an hour apart
+	FrequencyHourly   Frequency = "hourly"   // Runs less than a day apart
+	FrequencyDaily    Frequency = "daily"    // Runs less than a week apart
+	FrequencyWeekly   Frequency = "weekly"   // Runs less than 28 days apart
+	FrequencyMonthly  Frequency = "monthly"  // Runs less than a year apart
+	FrequencyYearly   Frequency = "yearly"   // Runs a year or more apart, or once
+	FrequencyNever    Frequency = "never"    // Never runs, or is invalid
+)
+
+// Frequencies lists the frequencies from the most to the least frequent
+var Frequencies = []Frequency{
+	FrequencySecondly, FrequencyMinutely, FrequencyHourly, FrequencyDaily,
+	FrequencyWeekly, FrequencyMonthly, FrequencyYearly, FrequencyNever,
+}
+
+// intervalSample is how many runs are compared to find the shortest interval
+const intervalSample = 8
+
+// Row is a job with the schedule attributes filters and sorts use
+type Row struct {
+	Job        *crontab.Job
+	RunsPerDay int           // Runs on the reference day, as counted by check
+	Interval   time.Duration // Shortest interval between upcoming runs (zero: fewer than two runs)
+	Frequency  Frequency
+	NextRun    time.Time // Zero when the job never runs again or is invalid
+}
+
+// NewRow computes the schedule attributes of a job as of now, in the job's
+// time zone or loc when the crontab does not set one
+func NewRow(scheduler cronx.Scheduler, job *crontab.Job, now time.Time, loc *time.Location) (Row, error) {
+	row := Row{Job: job, Frequency: FrequencyNever}
+	if !job.Valid {
+		return row, nil
+	}
+	jobLoc, err := job.Location(loc)
+	if err != nil {
+		return row, err
+	}
+
+	row.RunsPerDay, err = check.CalculateRunsPerDay(job.Expression, scheduler)
+	if err != nil {
+		return row, fmt.Errorf("line %d: %w", job.LineNumber, err)
+	}
+	times, err := scheduler.Next(job.Expression, now.In(jobLoc), intervalSample)
+	if err != nil {
+		return row, fmt.Errorf("line %d: failed to calculate next runs: %w", job.LineNumber, err)
+	}
+
+	var runs []time.Time
+	for _, t := range times {
+		if !t.IsZero() {
+			runs = append(runs, t)
+		}
+	}
+	if len(runs) == 0 {
+		return row, nil
+	}
+	row.NextRun = runs[0]
+	for i := 1; i < len(runs); i++ {
+		if gap := runs[i].Sub(runs[i-1]); row.Interval == 0 || gap < row.Interval {
+			row.Interval = gap
+		}
+	}
+	row.Frequency = classify(row.Interval)
+	return row, nil
+}
+
+// classify returns the frequency of a schedule whose runs are at least
+// interval apart
+func classify(interval time.Duration) Frequency {
+	const day = 24 * time.Hour
+	switch {
+	case interval == 0:
+		return FrequencyYearly // A single upcoming run
+	case interval < time.Minute:
+		return FrequencySecondly
+	case interval < time.Hour:
+		return FrequencyMinutely
+	case interval < day:
+		return FrequencyHourly
+	case interval < 7*day:
+		return FrequencyDaily
+	case interval < 28*day:
+		return FrequencyWeekly
+	case interval < 365*day:
+		return FrequencyMonthly
+	default:
+		return FrequencyYearly
+	}
+}
+
+// Filter fields
+const (
+	FieldCommand   = "command"   // Substring of the command, ignoring case
+	FieldName      = "name"      // "cronkit:name=" directive
+	FieldOwner     = "owner"     // "cronkit:owner=" directive
+	FieldTag       = "tag"       // One of the "cronkit:tags=" directive
+	FieldFrequency = "frequency" // One of Frequencies
+	FieldRuns      = "runs"      // Runs per day
+)
+
+// Filter is a condition on jobs, such as "owner=infra" or "runs>=24"
+type Filter struct {
+	Field string
+	Op    string // =, != and, for runs, <, <=, > and >=
+	Value string
+	runs  int
+}
+
+// ParseFilter parses a filter of the form <field><op><value>
+func ParseFilter(expr string) (Filter, error) {
+	i := strings.IndexAny(expr, "=!<>")
+	if i < 0 {
+		return Filter{}, fmt.Errorf("invalid filter %q: expected <field><op><value>, e.g. owner=infra or runs>=24", expr)
+	}
+	f := Filter{Field: strings.ToLower(strings.TrimSpace(expr[:i]))}
+	op := expr[i : i+1]
+	if i+1 < len(expr) && expr[i+1] == '=' {
+		op = expr[i : i+2]
+	}
+	f.Op = op
+	f.Value = strings.TrimSpace(expr[i+len(op):])
+	if op == "!" {
+		return Filter{}, fmt.Errorf("invalid filter %q: unknown operator \"!\" (use !=)", expr)
+	}
+
+	switch f.Field {
+	case FieldCommand, FieldName, FieldOwner, FieldTag:
+		if op != "=" && op != "!=" {
+			return Filter{}, fmt.Errorf("invalid filter %q: %s supports = and != only", expr, f.Field)
+		}
+	case FieldFrequency:
+		if op != "=" && op != "!=" {
+			return Filter{}, fmt.Errorf("invalid filter %q: %s supports = and != only", expr, f.Field)
+		}
+		f.Value = strings.ToLower(f.Value)
+		if !validFrequency(Frequency(f.Value)) {
+			return Filter{}, fmt.Errorf("invalid filter %q: unknown frequency %q (use %s)", expr, f.Value, frequencyList())
+		}
+	case FieldRuns:
+		runs, err := strconv.Atoi(f.Value)
+		if err != nil || runs < 0 {
+			return Filter{}, fmt.Errorf("invalid filter %q: runs must be a non-negative number", expr)
+		}
+		f.runs = runs
+	default:
+		return Filter{}, fmt.Errorf("invalid filter %q: unknown field %q (use command, name, owner, tag, frequency or runs)", expr, f.Field)
+	}
+	return f, nil
+}
+
+// Match reports whether a row satisfies the filter
+func (f Filter) Match(row Row) bool {
+	meta := row.Job.Metadata
+	var matches bool
+	switch f.Field {
+	case FieldCommand:
+		matches = strings.Contains(strings.ToLower(row.Job.Command), strings.ToLower(f.Value))
+	case FieldName:
+		matches = strings.EqualFold(meta.Name, f.Value)
+	case FieldOwner:
+		matches = strings.EqualFold(meta.Owner, f.Value)
+	case FieldTag:
+		matches = meta.HasTag(f.Value)
+	case FieldFrequency:
+		matches = row.Frequency == Frequency(f.Value)
+	case FieldRuns:
+		switch f.Op {
+		case "<":
+			return row.RunsPerDay < f.runs
+		case "<=":
+			return row.RunsPerDay <= f.runs
+		case ">":
+			return row.RunsPerDay > f.runs
+		case ">=":
+			return row.RunsPerDay >= f.runs
+		default:
+			matches = row.RunsPerDay == f.runs
+		}
+	}
+	if f.Op == "!=" {
+		return !matches
+	}
+	return matches
+}
+
+// String formats the filter as it is written
+func (f Filter) String() string {
+	return f.Field + f.Op + f.Value
+}
+
+// Apply returns the rows that satisfy every filter
+func Apply(rows []Row, filters []Filter) []Row {
+	kept := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		ok := true
+		for _, f := range filters {
+			if !f.Match(row) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// SortKey orders rows
+type SortKey string
+
+const (
+	SortLine      SortKey = "line"      // By line number
+	SortNext      SortKey = "next"      // Soonest next run first; jobs that never run last
+	SortFrequency SortKey = "frequency" // Most frequent first; jobs that never run last
+)
+
+// ParseSortKey parses a sort key
+func ParseSortKey(s string) (SortKey, error) {
+	switch key := SortKey(strings.ToLower(s)); key {
+	case SortLine, SortNext, SortFrequency:
+		return key, nil
+	default:
+		return "", fmt.Errorf("invalid sort key %q (use line, next or frequency)", s)
+	}
+}
+
+// Sort orders rows by key, then by line number
+func Sort(rows []Row, key SortKey) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		switch key {
+		case SortNext:
+			if !a.NextRun.Equal(b.NextRun) {
+				return before(a.NextRun, b.NextRun)
+			}
+		case SortFrequency:
+			if ra, rb := rank(a.Frequency), rank(b.Frequency); ra != rb {
+				return ra < rb
+			}
+			if a.RunsPerDay != b.RunsPerDay {
+				return a.RunsPerDay > b.RunsPerDay
+			}
+			if a.Interval != b.Interval {
+				return a.Interval < b.Interval
+			}
+		}
+		return a.Job.LineNumber < b.Job.LineNumber
+	})
+}
+
+// before orders times with the zero time last
+func before(a, b time.Time) bool {
+	if a.IsZero() || b.IsZero() {
+		return b.IsZero() && !a.IsZero()
+	}
+	return a.Before(b)
+}
+
+// rank returns the position of a frequency in Frequencies
+func rank(f Frequency) int {
+	for i, known := range Frequencies {
+		if f == known {
+			return i
+		}
+	}
+	return len(Frequencies)
+}
+
+func validFrequency(f Frequency) bool {
+	return rank(f) < len(Frequencies)
+}
+
+func frequencyList() string {
+	names := make([]string, len(Frequencies))
+	for i, f := range Frequencies {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,177 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func job(line int, expression, command string, meta crontab.Metadata) *crontab.Job {
+	return &crontab.Job{LineNumber: line, Expression: expression, Command: command, Valid: true, Metadata: meta}
+}
+
+func rows(t *testing.T, jobs ...*crontab.Job) []Row {
+	t.Helper()
+	scheduler := cronx.NewSchedulerWithSeconds(cronx.SecondsOptional)
+	result := make([]Row, 0, len(jobs))
+	for _, j := range jobs {
+		row, err := NewRow(scheduler, j, now, time.UTC)
+		require.NoError(t, err)
+		result = append(result, row)
+	}
+	return result
+}
+
+func lines(rows []Row) []int {
+	result := make([]int, len(rows))
+	for i, row := range rows {
+		result[i] = row.Job.LineNumber
+	}
+	return result
+}
+
+func TestNewRow(t *testing.T) {
+	tests := []struct {
+		expression string
+		frequency  Frequency
+		runsPerDay int
+	}{
+		{"* * * * * *", FrequencySecondly, 86400},
+		{"*/5 * * * *", FrequencyMinutely, 288},
+		{"0 */2 * * *", FrequencyHourly, 12},
+		{"0 9 * * 1-5", FrequencyDaily, 1},
+		{"0 9 * * 1", FrequencyWeekly, 0},
+		{"0 0 1 * *", FrequencyMonthly, 1}, // The reference day is January 1st
+		{"0 0 1 1 *", FrequencyYearly, 1},
+		{"0 0 30 2 *", FrequencyNever, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			row := rows(t, job(1, tt.expression, "cmd", crontab.Metadata{}))[0]
+			assert.Equal(t, tt.frequency, row.Frequency)
+			assert.Equal(t, tt.runsPerDay, row.RunsPerDay)
+		})
+	}
+
+	t.Run("invalid jobs never run", func(t *testing.T) {
+		row, err := NewRow(cronx.NewScheduler(), &crontab.Job{Expression: "bad"}, now, time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, FrequencyNever, row.Frequency)
+		assert.True(t, row.NextRun.IsZero())
+	})
+
+	t.Run("next run is in the job's time zone", func(t *testing.T) {
+		j := job(1, "0 9 * * *", "cmd", crontab.Metadata{})
+		j.Timezone = "Asia/Tokyo"
+		row := rows(t, j)[0]
+		assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), row.NextRun.UTC())
+	})
+}
+
+func TestParseFilter(t *testing.T) {
+	valid := map[string]Filter{
+		"owner=infra":       {Field: FieldOwner, Op: "=", Value: "infra"},
+		"Tag != db":         {Field: FieldTag, Op: "!=", Value: "db"},
+		"command=a=b":       {Field: FieldCommand, Op: "=", Value: "a=b"},
+		"frequency=Hourly":  {Field: FieldFrequency, Op: "=", Value: "hourly"},
+		"runs>=24":          {Field: FieldRuns, Op: ">=", Value: "24", runs: 24},
+		"runs<2":            {Field: FieldRuns, Op: "<", Value: "2", runs: 2},
+		"name=nightly sync": {Field: FieldName, Op: "=", Value: "nightly sync"},
+	}
+	for expr, want := range valid {
+		t.Run(expr, func(t *testing.T) {
+			got, err := ParseFilter(expr)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	for _, expr := range []string{"owner", "owner!infra", "owner>infra", "color=red", "frequency=often", "runs>=many", "runs=-1"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseFilter(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	all := rows(t,
+		job(1, "0 2 * * *", "/usr/bin/Backup.sh", crontab.Metadata{Owner: "infra", Tags: []string{"db"}}),
+		job(2, "*/5 * * * *", "/usr/bin/poll", crontab.Metadata{Owner: "web"}),
+		job(3, "0 * * * *", "/usr/bin/db-sync", crontab.Metadata{Name: "sync", Tags: []string{"db"}}),
+	)
+
+	tests := []struct {
+		filters []string
+		want    []int
+	}{
+		{nil, []int{1, 2, 3}},
+		{[]string{"command=backup"}, []int{1}},
+		{[]string{"owner=INFRA"}, []int{1}},
+		{[]string{"owner!=infra"}, []int{2, 3}},
+		{[]string{"tag=db"}, []int{1, 3}},
+		{[]string{"tag=db", "runs>=24"}, []int{3}},
+		{[]string{"name=sync"}, []int{3}},
+		{[]string{"frequency=minutely"}, []int{2}},
+		{[]string{"runs=1"}, []int{1}},
+		{[]string{"runs<24"}, []int{1}},
+		{[]string{"runs>24"}, []int{2}},
+		{[]string{"runs<=24"}, []int{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(fmtFilters(tt.filters), func(t *testing.T) {
+			var filters []Filter
+			for _, expr := range tt.filters {
+				f, err := ParseFilter(expr)
+				require.NoError(t, err)
+				filters = append(filters, f)
+			}
+			assert.Equal(t, tt.want, lines(Apply(all, filters)))
+		})
+	}
+}
+
+func fmtFilters(filters []string) string {
+	if len(filters) == 0 {
+		return "none"
+	}
+	s := filters[0]
+	for _, f := range filters[1:] {
+		s += " " + f
+	}
+	return s
+}
+
+func TestSort(t *testing.T) {
+	all := rows(t,
+		job(1, "0 0 30 2 *", "never", crontab.Metadata{}),
+		job(2, "0 0 1 * *", "monthly", crontab.Metadata{}),
+		job(3, "*/5 * * * *", "poll", crontab.Metadata{}),
+		job(4, "0 13 * * *", "daily", crontab.Metadata{}),
+		job(5, "30 12 * * *", "soon", crontab.Metadata{}),
+	)
+
+	Sort(all, SortFrequency)
+	assert.Equal(t, []int{3, 4, 5, 2, 1}, lines(all))
+
+	Sort(all, SortNext)
+	assert.Equal(t, []int{3, 5, 4, 2, 1}, lines(all))
+
+	Sort(all, SortLine)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, lines(all))
+}
+
+func TestParseSortKey(t *testing.T) {
+	key, err := ParseSortKey("Next")
+	require.NoError(t, err)
+	assert.Equal(t, SortNext, key)
+
+	_, err = ParseSortKey("name")
+	assert.Error(t, err)
+}
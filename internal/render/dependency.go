@@ -0,0 +1,147 @@
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// maxDependencyArrows is how many runs of a dependency are drawn as arrows
+// on timeline images
+const maxDependencyArrows = 100
+
+// Dependency is a job that runs after another ("cronkit:after="), with its
+// runs on the timeline paired with the runs they wait for
+type Dependency struct {
+	From string // Job ID of the job that runs first
+	To   string // Job ID of the job that runs after it
+	Runs []crontab.DependencyRun
+}
+
+// Late returns the runs that start before the run they wait for finishes
+func (d Dependency) Late() []crontab.DependencyRun {
+	var late []crontab.DependencyRun
+	for _, r := range d.Runs {
+		if r.Late() {
+			late = append(late, r)
+		}
+	}
+	return late
+}
+
+// Dependencies returns the dependencies between the jobs on the timeline,
+// from the names and "cronkit:after=" directives of their metadata (see
+// SetJobMetadata), in the order of the jobs' first runs. Runs last their
+// job's duration (see SetJobDuration).
+func (tl *Timeline) Dependencies() []Dependency {
+	var order []string
+	runs := make(map[string][]time.Time)
+	for _, run := range tl.jobRuns {
+		if _, ok := runs[run.JobID]; !ok {
+			order = append(order, run.JobID)
+		}
+		runs[run.JobID] = append(runs[run.JobID], run.RunTime)
+	}
+	named := make(map[string]string)
+	for _, id := range order {
+		if name := tl.metadata[id].Name; name != "" && named[name] == "" {
+			named[name] = id
+		}
+	}
+
+	var deps []Dependency
+	for _, id := range order {
+		for _, name := range tl.metadata[id].After {
+			from, ok := named[name]
+			if !ok || from == id {
+				continue
+			}
+			first, then := runs[from], runs[id]
+			sort.Slice(first, func(i, j int) bool { return first[i].Before(first[j]) })
+			sort.Slice(then, func(i, j int) bool { return then[i].Before(then[j]) })
+			deps = append(deps, Dependency{
+				From: from,
+				To:   id,
+				Runs: crontab.PairDependencyRuns(then, first, tl.durations[from]),
+			})
+		}
+	}
+	return deps
+}
+
+// jobLabel names a job by its "cronkit:name=" directive, or its ID
+func (tl *Timeline) jobLabel(jobID string) string {
+	if name := tl.metadata[jobID].Name; name != "" {
+		return name
+	}
+	return jobID
+}
+
+// writeDependencies writes an arrow per dependency between the jobs of the
+// timeline, with the runs that start before the run they wait for finishes
+func (tl *Timeline) writeDependencies(sb *strings.Builder) {
+	deps := tl.Dependencies()
+	if len(deps) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(tl.painter.Paint(color.Heading, "━━━ Dependencies ━━━") + "\n")
+	for _, d := range deps {
+		from, to := tl.jobLabel(d.From), tl.jobLabel(d.To)
+		arrow := from + " ──▶ " + to
+		if duration := tl.durations[d.From]; duration > 0 {
+			arrow = fmt.Sprintf("%s ──%s──▶ %s", from, formatSpan(duration), to)
+		}
+		late := d.Late()
+		switch {
+		case len(d.Runs) == 0:
+			fmt.Fprintf(sb, "  %s: no runs of %s wait for a run of %s on the timeline\n", arrow, to, from)
+		case len(late) == 0:
+			fmt.Fprintf(sb, "  %s: %s\n", arrow, tl.painter.Paintf(color.Success, "%d run(s) in order", len(d.Runs)))
+		default:
+			r := late[0]
+			fmt.Fprintf(sb, "  %s: %s\n", arrow, tl.painter.Paintf(color.Error, "✗ %d of %d run(s) start before %s finishes (first at %s, %s finishes at %s)",
+				len(late), len(d.Runs), from, r.Start.Format(tl.timestampLayout), from, r.Finish.Format(tl.clockLayout)))
+		}
+	}
+}
+
+// formatSpan formats a duration without zero units, e.g. "30m" or "1h30m"
+func formatSpan(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// dependenciesJSON returns the dependencies for JSON output
+func (tl *Timeline) dependenciesJSON() []map[string]interface{} {
+	deps := tl.Dependencies()
+	result := make([]map[string]interface{}, len(deps))
+	for i, d := range deps {
+		late := make([]map[string]interface{}, 0)
+		for _, r := range d.Late() {
+			late = append(late, map[string]interface{}{
+				"time":   r.Start.Format(time.RFC3339),
+				"after":  r.After.Format(time.RFC3339),
+				"finish": r.Finish.Format(time.RFC3339),
+			})
+		}
+		result[i] = map[string]interface{}{
+			"from": d.From,
+			"to":   d.To,
+			"runs": len(d.Runs),
+			"late": late,
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dependencyTestTimeline(reportMinute int) *Timeline {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	tl := NewTimeline(DayView, start, 80)
+	tl.SetJobInfo("job-1", "0 2 * * *", "At 02:00 every day")
+	tl.SetJobDuration("job-1", 30*time.Minute)
+	tl.SetJobMetadata("job-1", crontab.Metadata{Name: "backup", Duration: 30 * time.Minute})
+	tl.SetJobInfo("job-2", "* * * * *", "Every minute")
+	tl.SetJobMetadata("job-2", crontab.Metadata{Name: "report", After: []string{"backup", "unknown"}})
+	tl.AddJobRun("job-1", start.Add(2*time.Hour))
+	tl.AddJobRun("job-2", start.Add(2*time.Hour+time.Duration(reportMinute)*time.Minute))
+	return tl
+}
+
+func TestTimeline_Dependencies(t *testing.T) {
+	t.Run("late runs", func(t *testing.T) {
+		deps := dependencyTestTimeline(15).Dependencies()
+		require.Len(t, deps, 1)
+		assert.Equal(t, "job-1", deps[0].From)
+		assert.Equal(t, "job-2", deps[0].To)
+		require.Len(t, deps[0].Runs, 1)
+		assert.Len(t, deps[0].Late(), 1)
+	})
+
+	t.Run("runs in order", func(t *testing.T) {
+		deps := dependencyTestTimeline(45).Dependencies()
+		require.Len(t, deps, 1)
+		assert.Empty(t, deps[0].Late())
+	})
+
+	t.Run("no directives", func(t *testing.T) {
+		assert.Empty(t, imageTestTimeline().Dependencies())
+	})
+}
+
+func TestTimeline_Render_Dependencies(t *testing.T) {
+	out := dependencyTestTimeline(15).Render(false)
+	assert.Contains(t, out, "━━━ Dependencies ━━━")
+	assert.Contains(t, out, "backup ──30m──▶ report: ✗ 1 of 1 run(s) start before backup finishes (first at 2026-02-02 02:15:00, backup finishes at 02:30)")
+
+	out = dependencyTestTimeline(45).Render(false)
+	assert.Contains(t, out, "backup ──30m──▶ report: 1 run(s) in order")
+
+	assert.NotContains(t, imageTestTimeline().Render(false), "Dependencies")
+
+	t.Run("json", func(t *testing.T) {
+		deps, ok := dependencyTestTimeline(15).RenderJSON()["dependencies"].([]map[string]interface{})
+		require.True(t, ok)
+		require.Len(t, deps, 1)
+		assert.Equal(t, "job-1", deps[0]["from"])
+		assert.Equal(t, 1, deps[0]["runs"])
+		assert.Len(t, deps[0]["late"], 1)
+	})
+}
+
+func TestTimeline_SetPainter(t *testing.T) {
+	theme, err := color.LookupTheme(color.DefaultTheme)
+	require.NoError(t, err)
+	tl := dependencyTestTimeline(15)
+	tl.SetPainter(color.NewPainter(theme))
+	out := tl.Render(true)
+	assert.Contains(t, out, "\x1b[1mTimeline for 2026-02-02 (Day View)\x1b[0m\n")
+	assert.Contains(t, out, "\x1b[1m━━━ Dependencies ━━━\x1b[0m\n")
+	assert.Contains(t, out, "backup ──30m──▶ report: \x1b[31m✗ 1 of 1 run(s)")
+	assert.Contains(t, out, "\x1b[1m━━━ Overlap Summary ━━━\x1b[0m\n")
+
+	tl.SetPainter(nil)
+	assert.NotContains(t, tl.Render(true), "\x1b[")
+}
+
+func TestSVGTimeline_Render_Dependencies(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewSVGTimeline(dependencyTestTimeline(15)).Render(&buf))
+	out := buf.String()
+	assert.Contains(t, out, `<marker id="arrow-late"`)
+	assert.Contains(t, out, `marker-end="url(#arrow-late)"><title>report runs after backup but starts at 02:15, before it finishes at 02:30</title>`)
+	assert.Equal(t, 1, strings.Count(out, "marker-end="))
+}
@@ -0,0 +1,240 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/color"
+)
+
+// GridDay holds the number of runs in each hour of one day of a week or
+// month timeline
+type GridDay struct {
+	Date  time.Time
+	Hours [24]int
+}
+
+// FreeWindow is a stretch of whole hours without runs
+type FreeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the window
+func (w FreeWindow) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// writeJobList writes one line per job with runs on the timeline
+func (tl *Timeline) writeJobList(sb *strings.Builder) {
+	seen := make(map[string]bool)
+	for _, run := range tl.jobRuns {
+		if seen[run.JobID] {
+			continue
+		}
+		seen[run.JobID] = true
+
+		info := tl.jobInfo[run.JobID]
+		switch {
+		case info.Description == "":
+			// Fallback to job ID if no description
+			fmt.Fprintf(sb, "  • %s\n", run.JobID)
+		case strings.HasPrefix(run.JobID, "expr-"):
+			// For single expressions, show just the description
+			fmt.Fprintf(sb, "  • %s\n", info.Description)
+		default:
+			// For crontab jobs, show description with expression in parentheses
+			fmt.Fprintf(sb, "  • %s (%s)\n", info.Description, info.Expression)
+		}
+	}
+}
+
+// writeOverlapSummary writes the overlap windows of the timeline
+func (tl *Timeline) writeOverlapSummary(sb *strings.Builder) {
+	overlaps := tl.DetectOverlaps()
+	stats := tl.GetOverlapStats()
+
+	sb.WriteString("\n")
+	sb.WriteString(tl.painter.Paint(color.Heading, "━━━ Overlap Summary ━━━") + "\n")
+
+	if len(overlaps) == 0 {
+		sb.WriteString("No overlaps detected\n")
+		return
+	}
+
+	fmt.Fprintf(sb, "Total overlap windows: %d\n", stats.TotalWindows)
+	fmt.Fprintf(sb, "Maximum concurrent jobs: %d\n", stats.MaxConcurrent)
+	sb.WriteString("\n")
+	sb.WriteString("Overlaps:\n")
+
+	// Show all overlaps, or limit to first 50 if too many
+	displayOverlaps := overlaps
+	if len(displayOverlaps) > 50 {
+		displayOverlaps = displayOverlaps[:50]
+		fmt.Fprintf(sb, "  (showing first 50 of %d overlap windows)\n", len(overlaps))
+	}
+
+	for _, overlap := range displayOverlaps {
+		fmt.Fprintf(sb, "  %s\n", tl.painter.Paintf(color.Warning, "%s: %d job(s) (%s)",
+			overlap.Time.Format(tl.timestampLayout),
+			overlap.Count,
+			strings.Join(overlap.JobIDs, ", ")))
+	}
+
+	if len(overlaps) > 50 {
+		fmt.Fprintf(sb, "  ... and %d more overlap window(s)\n", len(overlaps)-50)
+	}
+}
+
+// Grid returns the hourly run counts of each day of the timeline. Hours are
+// wall-clock hours of the timeline's time zone.
+func (tl *Timeline) Grid() []GridDay {
+	var days []GridDay
+	for day := tl.startTime; day.Before(tl.endTime); day = day.AddDate(0, 0, 1) {
+		days = append(days, GridDay{Date: day})
+	}
+	for _, run := range tl.jobRuns {
+		t := run.RunTime.In(tl.startTime.Location())
+		for i := range days {
+			y, m, d := days[i].Date.Date()
+			if t.Year() == y && t.Month() == m && t.Day() == d {
+				days[i].Hours[t.Hour()]++
+				break
+			}
+		}
+	}
+	return days
+}
+
+// FreeWindows returns the stretches of whole hours without runs, in order
+func (tl *Timeline) FreeWindows() []FreeWindow {
+	var windows []FreeWindow
+	var current *FreeWindow
+	for _, day := range tl.Grid() {
+		for hour, count := range day.Hours {
+			start := time.Date(day.Date.Year(), day.Date.Month(), day.Date.Day(), hour, 0, 0, 0, day.Date.Location())
+			if count > 0 {
+				current = nil
+				continue
+			}
+			if current == nil {
+				windows = append(windows, FreeWindow{Start: start})
+				current = &windows[len(windows)-1]
+			}
+			current.End = start.Add(time.Hour)
+		}
+	}
+	return windows
+}
+
+// gridChar returns the character of an hour with count runs, shaded
+// relative to the busiest hour
+func gridChar(count, maxCount int) string {
+	if count == 0 {
+		return "·"
+	}
+	shades := []string{"░", "▒", "▓", "█"}
+	level := (count*len(shades) - 1) / maxCount
+	return shades[min(level, len(shades)-1)]
+}
+
+// renderGrid renders a week or month timeline as one row per day and one
+// column per hour
+func (tl *Timeline) renderGrid(showOverlaps bool) string {
+	var sb strings.Builder
+
+	last := tl.endTime.AddDate(0, 0, -1)
+	if tl.view == MonthView {
+		fmt.Fprintf(&sb, "%s\n", tl.painter.Paintf(color.Heading, "Timeline for %s (Month View)", tl.startTime.Format("January 2006")))
+	} else {
+		fmt.Fprintf(&sb, "%s\n", tl.painter.Paintf(color.Heading, "Timeline for %s to %s (Week View)", tl.startTime.Format("2006-01-02"), last.Format("2006-01-02")))
+	}
+	tl.writeJobList(&sb)
+	sb.WriteString("\n")
+
+	// Two characters per hour when there is room, otherwise one
+	const labelWidth = 11 // "Mon 01-02  "
+	cell := 1
+	if tl.width >= labelWidth+48 {
+		cell = 2
+	}
+
+	days := tl.Grid()
+	maxCount := 0
+	for _, day := range days {
+		for _, count := range day.Hours {
+			maxCount = max(maxCount, count)
+		}
+	}
+
+	header := strings.Repeat(" ", labelWidth)
+	for hour := 0; hour < 24; hour += 6 / cell {
+		label := fmt.Sprintf("%02d", hour)
+		header += label + strings.Repeat(" ", 6/cell*cell-len(label))
+	}
+	sb.WriteString(strings.TrimRight(header, " ") + "\n")
+
+	free := 0
+	for _, day := range days {
+		cells := make([]string, len(day.Hours))
+		for hour, count := range day.Hours {
+			if count == 0 {
+				free++
+			}
+			cells[hour] = gridChar(count, maxCount)
+		}
+		sep := ""
+		if cell == 2 {
+			sep = " "
+		}
+		fmt.Fprintf(&sb, "%s  %s\n", day.Date.Format("Mon 01-02"), strings.Join(cells, sep))
+	}
+
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "%s\n", tl.painter.Paintf(color.Muted, "Legend: · = no runs | ░ ▒ ▓ █ = runs per hour (█ = up to %d)", maxCount))
+	fmt.Fprintf(&sb, "Free hours: %d of %d\n", free, len(days)*24)
+
+	var longest FreeWindow
+	for _, w := range tl.FreeWindows() {
+		if w.Duration() > longest.Duration() {
+			longest = w
+		}
+	}
+	if longest.Duration() > 0 {
+		fmt.Fprintf(&sb, "Longest free window: %s to %s (%s)\n",
+			longest.Start.Format("Mon 01-02 "+tl.clockLayout), longest.End.Format("Mon 01-02 "+tl.clockLayout), formatHours(longest.Duration()))
+	}
+
+	tl.writeDependencies(&sb)
+
+	if showOverlaps {
+		tl.writeOverlapSummary(&sb)
+	}
+	return sb.String()
+}
+
+// gridJSON returns the hourly run counts of each day for JSON output
+func (tl *Timeline) gridJSON() []map[string]interface{} {
+	days := tl.Grid()
+	result := make([]map[string]interface{}, len(days))
+	for i, day := range days {
+		result[i] = map[string]interface{}{
+			"date":  day.Date.Format("2006-01-02"),
+			"hours": day.Hours[:],
+		}
+	}
+	return result
+}
+
+// formatHours formats a whole number of hours, e.g. "5h" or "2d 3h"
+func formatHours(d time.Duration) string {
+	hours := int(d.Round(time.Hour).Hours())
+	if hours < 24 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	if hours%24 == 0 {
+		return fmt.Sprintf("%dd", hours/24)
+	}
+	return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimeline_GridViews(t *testing.T) {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC) // A Monday
+
+	week := NewTimeline(WeekView, start, 80)
+	assert.Equal(t, start.AddDate(0, 0, 7), week.endTime)
+	assert.Len(t, week.slots, 7*24)
+	assert.Equal(t, "week", WeekView.String())
+
+	month := NewTimeline(MonthView, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 80)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), month.endTime)
+	assert.Len(t, month.slots, 28*24)
+	assert.Equal(t, "month", MonthView.String())
+	assert.Equal(t, 24, month.findSlotIndex(time.Date(2026, 2, 2, 0, 30, 0, 0, time.UTC)))
+}
+
+func TestTimeline_Grid(t *testing.T) {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	tl := NewTimeline(WeekView, start, 80)
+	tl.SetJobInfo("job-1", "0 2 * * *", "At 02:00 every day")
+
+	for day := 0; day < 7; day++ {
+		tl.AddJobRun("job-1", start.AddDate(0, 0, day).Add(2*time.Hour))
+	}
+	tl.AddJobRun("job-2", start.Add(2*time.Hour))
+	tl.AddJobRun("job-2", start.Add(2*time.Hour+10*time.Second)) // Same minute: dropped
+	tl.AddJobRun("job-2", start.Add(26*time.Hour+time.Minute))
+
+	days := tl.Grid()
+	require.Len(t, days, 7)
+	assert.Equal(t, 2, days[0].Hours[2])
+	assert.Equal(t, 2, days[1].Hours[2])
+	assert.Equal(t, 1, days[6].Hours[2])
+	assert.Zero(t, days[0].Hours[3])
+
+	t.Run("free windows", func(t *testing.T) {
+		windows := tl.FreeWindows()
+		require.Len(t, windows, 8)
+		assert.Equal(t, start, windows[0].Start)
+		assert.Equal(t, 2*time.Hour, windows[0].Duration())
+		assert.Equal(t, 23*time.Hour, windows[1].Duration())
+		assert.Equal(t, start.AddDate(0, 0, 7), windows[7].End)
+	})
+
+	t.Run("render", func(t *testing.T) {
+		output := tl.Render(true)
+		assert.Contains(t, output, "Timeline for 2026-02-02 to 2026-02-08 (Week View)")
+		assert.Contains(t, output, "  • At 02:00 every day (0 2 * * *)")
+		assert.Contains(t, output, "Mon 02-02  · · █ · ·")
+		assert.Contains(t, output, "Sun 02-08  · · ▒ · ·")
+		assert.Contains(t, output, "Free hours: 161 of 168")
+		assert.Contains(t, output, "Longest free window: Mon 02-02 03:00 to Tue 02-03 02:00 (23h)")
+		assert.Contains(t, output, "Maximum concurrent jobs: 2")
+		for _, line := range strings.Split(output, "\n") {
+			assert.Equal(t, strings.TrimRight(line, " "), line)
+		}
+	})
+
+	t.Run("narrow render", func(t *testing.T) {
+		narrow := NewTimeline(MonthView, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 40)
+		narrow.AddJobRun("job-1", time.Date(2026, 2, 1, 5, 0, 0, 0, time.UTC))
+		output := narrow.Render(false)
+		assert.Contains(t, output, "Timeline for February 2026 (Month View)")
+		assert.Contains(t, output, "           00    06    12    18\n")
+		assert.Contains(t, output, "Sun 02-01  ·····█")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		result := tl.RenderJSON()
+		assert.Equal(t, "week", result["view"])
+		days := result["days"].([]map[string]interface{})
+		require.Len(t, days, 7)
+		assert.Equal(t, "2026-02-02", days[0]["date"])
+		assert.Equal(t, 2, days[0]["hours"].([]int)[2])
+	})
+}
+
+func TestFormatHours(t *testing.T) {
+	assert.Equal(t, "5h", formatHours(5*time.Hour))
+	assert.Equal(t, "2d", formatHours(48*time.Hour))
+	assert.Equal(t, "1d 3h", formatHours(27*time.Hour))
+}
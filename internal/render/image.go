@@ -0,0 +1,290 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Image layout, in pixels
+const (
+	imageWidth   = 1000
+	imageMargin  = 16
+	imageLabelW  = 180 // Width of the job label column
+	imageTitleH  = 32
+	imageLaneH   = 24
+	imageAxisH   = 28
+	imageMarkMin = 2 // Minimum width of a run marker
+)
+
+// Image colors
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorText       = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	colorGrid       = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	colorLane       = color.RGBA{0xf5, 0xf7, 0xfa, 0xff}
+	colorRun        = color.RGBA{0x2b, 0x6c, 0xb0, 0xff}
+	colorOverlap    = color.RGBA{0xd6, 0x45, 0x45, 0xff}
+	colorArrow      = color.RGBA{0x55, 0x55, 0x55, 0xff}
+)
+
+// imageMark is a run drawn on a lane
+type imageMark struct {
+	x, w    int
+	title   string
+	overlap bool // Other jobs run in the same minute
+}
+
+// imageLane is the row of one job
+type imageLane struct {
+	label string // Expression, or job ID when unknown
+	title string // Description shown on hover
+	marks []imageMark
+}
+
+// imageArrow is a dependency drawn from the end of a run to the start of
+// the run that waits for it
+type imageArrow struct {
+	x1, y1, x2, y2 int
+	title          string
+	late           bool // The run starts before the run it waits for finishes
+}
+
+// imageTick is a time label on the axis
+type imageTick struct {
+	x     int
+	label string
+}
+
+// imageLayout positions the elements of a timeline image. It is shared by
+// the SVG and PNG renderers.
+type imageLayout struct {
+	width, height int
+	title         string
+	lanes         []imageLane
+	arrows        []imageArrow
+	ticks         []imageTick
+}
+
+// plotX returns the x coordinate of t
+func (tl *Timeline) plotX(t time.Time) int {
+	plotW := imageWidth - 2*imageMargin - imageLabelW
+	offset := float64(t.Sub(tl.startTime)) / float64(tl.endTime.Sub(tl.startTime))
+	return imageMargin + imageLabelW + int(offset*float64(plotW))
+}
+
+// tickStep returns the interval between axis labels and their layout
+func (tl *Timeline) tickStep() (time.Duration, string) {
+	switch tl.view {
+	case HourView:
+		return 5 * time.Minute, "15:04"
+	case WeekView:
+		return 24 * time.Hour, "Mon 01-02"
+	case MonthView:
+		return 3 * 24 * time.Hour, "Jan 2"
+	default:
+		return 3 * time.Hour, "15:04"
+	}
+}
+
+// imageTitle returns the heading of a timeline image
+func (tl *Timeline) imageTitle() string {
+	switch tl.view {
+	case HourView:
+		return fmt.Sprintf("Timeline for %s (Hour View)", tl.startTime.Format("2006-01-02 15:04"))
+	case WeekView:
+		return fmt.Sprintf("Timeline for %s to %s (Week View)", tl.startTime.Format("2006-01-02"), tl.endTime.AddDate(0, 0, -1).Format("2006-01-02"))
+	case MonthView:
+		return fmt.Sprintf("Timeline for %s (Month View)", tl.startTime.Format("January 2006"))
+	default:
+		return fmt.Sprintf("Timeline for %s (Day View)", tl.startTime.Format("2006-01-02"))
+	}
+}
+
+// layout positions the lanes, run markers and axis labels of the timeline
+func (tl *Timeline) layout() imageLayout {
+	overlaps := make(map[time.Time]int)
+	for _, o := range tl.DetectOverlaps() {
+		overlaps[o.Time] = o.Count
+	}
+
+	plotW := imageWidth - 2*imageMargin - imageLabelW
+	markW := max(imageMarkMin, int(float64(plotW)*float64(time.Minute)/float64(tl.endTime.Sub(tl.startTime))))
+
+	var lanes []imageLane
+	index := make(map[string]int)
+	for _, run := range tl.jobRuns {
+		i, ok := index[run.JobID]
+		if !ok {
+			info := tl.jobInfo[run.JobID]
+			lane := imageLane{label: info.Expression, title: info.Description}
+			if lane.label == "" {
+				lane.label = run.JobID
+			}
+			i = len(lanes)
+			index[run.JobID] = i
+			lanes = append(lanes, lane)
+		}
+
+		minute := run.RunTime.Truncate(time.Minute)
+		title := run.RunTime.Format("2006-01-02 15:04:05")
+		if lanes[i].title != "" {
+			title = lanes[i].title + " — " + title
+		}
+		count := overlaps[minute]
+		if count > 1 {
+			title += fmt.Sprintf(" (%d jobs at once)", count)
+		}
+		lanes[i].marks = append(lanes[i].marks, imageMark{
+			x:       tl.plotX(run.RunTime),
+			w:       markW,
+			title:   title,
+			overlap: count > 1,
+		})
+	}
+	for i := range lanes {
+		sort.SliceStable(lanes[i].marks, func(a, b int) bool { return lanes[i].marks[a].x < lanes[i].marks[b].x })
+	}
+
+	plotLeft, plotRight := imageMargin+imageLabelW, imageWidth-imageMargin
+	var arrows []imageArrow
+	for _, d := range tl.Dependencies() {
+		from, to := index[d.From], index[d.To]
+		for _, r := range d.Runs[:min(len(d.Runs), maxDependencyArrows)] {
+			title := fmt.Sprintf("%s runs after %s", tl.jobLabel(d.To), tl.jobLabel(d.From))
+			if r.Late() {
+				title += fmt.Sprintf(" but starts at %s, before it finishes at %s", r.Start.Format("15:04"), r.Finish.Format("15:04"))
+			}
+			arrows = append(arrows, imageArrow{
+				x1:    min(max(tl.plotX(r.Finish), plotLeft), plotRight),
+				y1:    laneY(from) + imageLaneH/2,
+				x2:    tl.plotX(r.Start),
+				y2:    laneY(to) + imageLaneH/2,
+				title: title,
+				late:  r.Late(),
+			})
+		}
+	}
+
+	step, format := tl.tickStep()
+	var ticks []imageTick
+	for t := tl.startTime; t.Before(tl.endTime); t = t.Add(step) {
+		ticks = append(ticks, imageTick{x: tl.plotX(t), label: t.Format(format)})
+	}
+
+	return imageLayout{
+		width:  imageWidth,
+		height: imageTitleH + max(len(lanes), 1)*imageLaneH + imageAxisH + imageMargin,
+		title:  tl.imageTitle(),
+		lanes:  lanes,
+		arrows: arrows,
+		ticks:  ticks,
+	}
+}
+
+// laneY returns the top of lane i
+func laneY(i int) int {
+	return imageTitleH + i*imageLaneH
+}
+
+// PNGTimeline renders a timeline as a PNG image: one lane per job with a
+// marker per run, overlapping runs in red, an arrow from each run to the
+// runs waiting for it ("cronkit:after="), and time labels along the bottom
+type PNGTimeline struct {
+	timeline *Timeline
+}
+
+// NewPNGTimeline creates a PNG renderer for tl
+func NewPNGTimeline(tl *Timeline) *PNGTimeline {
+	return &PNGTimeline{timeline: tl}
+}
+
+// Render writes the PNG image to w
+func (p *PNGTimeline) Render(w io.Writer) error {
+	l := p.timeline.layout()
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	fill := func(r image.Rectangle, c color.Color) {
+		draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+	}
+	text := func(x, y int, s string) {
+		d := font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(colorText),
+			Face: basicfont.Face7x13,
+			Dot:  fixed.P(x, y),
+		}
+		d.DrawString(s)
+	}
+
+	fill(img.Bounds(), colorBackground)
+	text(imageMargin, imageTitleH-12, l.title)
+
+	plotLeft := imageMargin + imageLabelW
+	plotRight := l.width - imageMargin
+	bottom := laneY(max(len(l.lanes), 1))
+	for _, tick := range l.ticks {
+		fill(image.Rect(tick.x, imageTitleH, tick.x+1, bottom), colorGrid)
+		text(tick.x+2, bottom+16, tick.label)
+	}
+
+	for i, lane := range l.lanes {
+		top := laneY(i)
+		if i%2 == 0 {
+			fill(image.Rect(plotLeft, top, plotRight, top+imageLaneH), colorLane)
+		}
+		text(imageMargin, top+imageLaneH/2+4, truncateLabel(lane.label, imageLabelW/7-1))
+		for _, mark := range lane.marks {
+			c := colorRun
+			if mark.overlap {
+				c = colorOverlap
+			}
+			fill(image.Rect(mark.x, top+4, min(mark.x+mark.w, plotRight), top+imageLaneH-4), c)
+		}
+	}
+	for _, a := range l.arrows {
+		c := colorArrow
+		if a.late {
+			c = colorOverlap
+		}
+		drawArrow(img, a.x1, a.y1, a.x2, a.y2, c)
+	}
+	fill(image.Rect(plotLeft, bottom, plotRight, bottom+1), colorText)
+
+	return png.Encode(w, img)
+}
+
+// drawArrow draws a line from (x1, y1) to (x2, y2) with a head at (x2, y2)
+func drawArrow(img *image.RGBA, x1, y1, x2, y2 int, c color.Color) {
+	line := func(x1, y1, x2, y2 float64) {
+		steps := max(math.Abs(x2-x1), math.Abs(y2-y1), 1)
+		for i := 0.0; i <= steps; i++ {
+			img.Set(int(math.Round(x1+(x2-x1)*i/steps)), int(math.Round(y1+(y2-y1)*i/steps)), c)
+		}
+	}
+	line(float64(x1), float64(y1), float64(x2), float64(y2))
+
+	angle := math.Atan2(float64(y2-y1), float64(x2-x1))
+	for _, side := range []float64{-1, 1} {
+		a := angle + math.Pi - side*math.Pi/6
+		line(float64(x2), float64(y2), float64(x2)+6*math.Cos(a), float64(y2)+6*math.Sin(a))
+	}
+}
+
+// truncateLabel shortens s to at most n characters, marking the cut with "..."
+func truncateLabel(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
@@ -0,0 +1,52 @@
+package render
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func imageTestTimeline() *Timeline {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	tl := NewTimeline(DayView, start, 80)
+	tl.SetJobInfo("backup", "0 2 * * *", "At 02:00 every day & <night>")
+	tl.SetJobInfo("report", "0 2 * * 1", "At 02:00 on Monday")
+	tl.AddJobRun("backup", start.Add(2*time.Hour))
+	tl.AddJobRun("report", start.Add(2*time.Hour))
+	return tl
+}
+
+func TestSVGTimeline_Render(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewSVGTimeline(imageTestTimeline()).Render(&buf))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "<svg "))
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(out), "</svg>"))
+	assert.Contains(t, out, `width="1000"`)
+	assert.Contains(t, out, "<title>At 02:00 every day &amp; &lt;night&gt; (0 2 * * *)</title>")
+	assert.Contains(t, out, "<title>At 02:00 on Monday")
+	assert.Contains(t, out, "02:00")
+	assert.NotContains(t, out, "<night>")
+}
+
+func TestPNGTimeline_Render(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewPNGTimeline(imageTestTimeline()).Render(&buf))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, img.Bounds().Dx())
+	assert.Greater(t, img.Bounds().Dy(), 0)
+}
+
+func TestTruncateLabel(t *testing.T) {
+	assert.Equal(t, "short", truncateLabel("short", 10))
+	assert.Equal(t, "abcdefg...", truncateLabel("abcdefghijklmnop", 10))
+	assert.Equal(t, "ééé...", truncateLabel("éééééééé", 6))
+}
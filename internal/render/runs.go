@@ -0,0 +1,134 @@
+package render
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/hzerrad/cronkit/internal/parallel"
+)
+
+// ParseView parses a view name: "day", "hour", "week" or "month"
+func ParseView(name string) (TimelineView, error) {
+	for _, view := range []TimelineView{DayView, HourView, WeekView, MonthView} {
+		if name == view.String() {
+			return view, nil
+		}
+	}
+	return DayView, fmt.Errorf("invalid view type: %s (must be 'day', 'hour', 'week' or 'month')", name)
+}
+
+// ViewStart rounds t down to the start of a view's window: the hour for the
+// hour view, midnight for the day view, Monday for the week view and the
+// first of the month for the month view
+func ViewStart(view TimelineView, t time.Time) time.Time {
+	switch view {
+	case HourView:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case WeekView:
+		// Weeks start on Monday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	case MonthView:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// AddJobs describes each job and computes its runs within the timeline
+// window in parallel, then adds them in job order. Jobs with an invalid
+// expression are left out. Jobs under CRON_TZ= or TZ= run in that zone but
+// are drawn in the zone of the timeline's start time.
+func (tl *Timeline) AddJobs(jobs []*crontab.Job, parser cronx.Parser, humanizer human.Humanizer, scheduler cronx.Scheduler) error {
+	loc := tl.startTime.Location()
+
+	// Day and hour views take a single batch of runs; week and month views
+	// fetch batches until the end of the window
+	runCount := 1000
+	switch tl.view {
+	case DayView:
+		runCount = 200 // Enough to cover a day for most schedules
+	case HourView:
+		runCount = 100 // Enough to cover an hour for most schedules
+	}
+
+	type jobRuns struct {
+		description string
+		runs        []time.Time
+		err         error
+		valid       bool
+	}
+	results := parallel.Map(len(jobs), func(i int) jobRuns {
+		job := jobs[i]
+		schedule, err := parser.Parse(job.Expression)
+		if err != nil {
+			return jobRuns{} // Skip invalid expressions
+		}
+		r := jobRuns{description: humanizer.Humanize(schedule), valid: true}
+
+		// Sub-minute schedules need up to 60 runs per minute
+		jobRunCount := runCount
+		if schedule.HasSeconds() {
+			jobRunCount = runCount * 60
+		}
+		jobLoc, err := job.Location(loc)
+		if err != nil {
+			r.err = err
+			return r
+		}
+		from := tl.startTime.In(jobLoc)
+		for {
+			times, err := scheduler.Next(job.Expression, from, jobRunCount)
+			if err != nil || len(times) == 0 {
+				break // Skip if we can't calculate runs
+			}
+
+			// Add runs that fall within the timeline range
+			done := false
+			for _, runTime := range times {
+				if runTime.IsZero() || !runTime.Before(tl.endTime) {
+					done = true
+					break
+				}
+				if !runTime.Before(tl.startTime) {
+					r.runs = append(r.runs, runTime.In(loc))
+				}
+			}
+			if done || tl.view == DayView || tl.view == HourView {
+				break
+			}
+			from = times[len(times)-1]
+		}
+		return r
+	})
+
+	for i, job := range jobs {
+		r := results[i]
+		if r.err != nil {
+			return r.err
+		}
+		if !r.valid {
+			continue
+		}
+
+		// Generate job ID; host timelines have jobs from several files
+		jobID := fmt.Sprintf("job-%d", job.LineNumber)
+		if job.LineNumber == 0 {
+			jobID = fmt.Sprintf("expr-%s", job.Expression)
+		} else if job.Source != "" {
+			jobID = fmt.Sprintf("%s:%d", job.Source, job.LineNumber)
+		}
+
+		tl.SetJobInfo(jobID, job.Expression, r.description)
+		tl.SetJobDuration(jobID, job.Duration)
+		tl.SetJobMetadata(jobID, job.Metadata)
+		tl.SetJobUser(jobID, job.User)
+		for _, runTime := range r.runs {
+			tl.AddJobRun(jobID, runTime)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/human"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseView(t *testing.T) {
+	for _, view := range []TimelineView{DayView, HourView, WeekView, MonthView} {
+		parsed, err := ParseView(view.String())
+		require.NoError(t, err)
+		assert.Equal(t, view, parsed)
+	}
+
+	_, err := ParseView("year")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid view type: year")
+}
+
+func TestViewStart(t *testing.T) {
+	// A Thursday
+	at := time.Date(2025, 1, 16, 14, 35, 10, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 16, 14, 0, 0, 0, time.UTC), ViewStart(HourView, at))
+	assert.Equal(t, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), ViewStart(DayView, at))
+	assert.Equal(t, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), ViewStart(WeekView, at))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ViewStart(MonthView, at))
+}
+
+func TestTimeline_AddJobs(t *testing.T) {
+	parser := cronx.NewParserWithLocale("en")
+	humanizer := human.NewHumanizerForLocale("en")
+	scheduler := cronx.NewScheduler()
+	start := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	t.Run("adds runs in the window", func(t *testing.T) {
+		tl := NewTimeline(DayView, start, 80)
+		jobs := []*crontab.Job{
+			{LineNumber: 1, Expression: "0 */6 * * *", Command: "/usr/bin/a.sh", Valid: true},
+			{LineNumber: 2, Expression: "invalid", Command: "/usr/bin/b.sh"},
+			{LineNumber: 3, Expression: "0 6 * * *", Command: "/usr/bin/c.sh", Source: "/etc/cron.d/c", Valid: true},
+		}
+		require.NoError(t, tl.AddJobs(jobs, parser, humanizer, scheduler))
+
+		runs := map[string]int{}
+		for _, run := range tl.jobRuns {
+			runs[run.JobID]++
+		}
+		assert.Equal(t, map[string]int{"job-1": 3, "/etc/cron.d/c:3": 1}, runs)
+		assert.Equal(t, "At 06:00 every day", tl.jobInfo["/etc/cron.d/c:3"].Description)
+	})
+
+	t.Run("fetches runs until the end of a month", func(t *testing.T) {
+		tl := NewTimeline(MonthView, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 80)
+		jobs := []*crontab.Job{{Expression: "*/5 * * * *", Valid: true}}
+		require.NoError(t, tl.AddJobs(jobs, parser, humanizer, scheduler))
+		assert.Len(t, tl.jobRuns, 31*24*12-1) // Runs strictly after the start
+		assert.Equal(t, "expr-*/5 * * * *", tl.jobRuns[0].JobID)
+	})
+
+	t.Run("invalid job timezone", func(t *testing.T) {
+		tl := NewTimeline(DayView, start, 80)
+		jobs := []*crontab.Job{{LineNumber: 2, Expression: "0 0 * * *", Valid: true, Timezone: "Mars/Olympus"}}
+		assert.Error(t, tl.AddJobs(jobs, parser, humanizer, scheduler))
+	})
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// SVGTimeline renders a timeline as an SVG image: one lane per job with a
+// marker per run, overlapping runs in red, an arrow from each run to the
+// runs waiting for it ("cronkit:after="), in red when they start before it
+// finishes, and time labels along the bottom. Hovering a lane label shows the job's description, and hovering a marker
+// shows the description and run time.
+type SVGTimeline struct {
+	timeline *Timeline
+}
+
+// NewSVGTimeline creates an SVG renderer for tl
+func NewSVGTimeline(tl *Timeline) *SVGTimeline {
+	return &SVGTimeline{timeline: tl}
+}
+
+// rgb formats a color for SVG attributes
+func rgb(c interface{ RGBA() (r, g, b, a uint32) }) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// Render writes the SVG document to w
+func (s *SVGTimeline) Render(w io.Writer) error {
+	l := s.timeline.layout()
+	esc := html.EscapeString
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(&sb, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", rgb(colorBackground))
+	fmt.Fprintf(&sb, `  <text x="%d" y="%d" font-size="14" font-weight="bold" fill="%s">%s</text>`+"\n",
+		imageMargin, imageTitleH-12, rgb(colorText), esc(l.title))
+
+	plotLeft := imageMargin + imageLabelW
+	plotRight := l.width - imageMargin
+	bottom := laneY(max(len(l.lanes), 1))
+	for _, tick := range l.ticks {
+		fmt.Fprintf(&sb, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n", tick.x, imageTitleH, tick.x, bottom, rgb(colorGrid))
+		fmt.Fprintf(&sb, `  <text x="%d" y="%d" fill="%s">%s</text>`+"\n", tick.x+2, bottom+16, rgb(colorText), esc(tick.label))
+	}
+
+	for i, lane := range l.lanes {
+		top := laneY(i)
+		sb.WriteString("  <g>\n")
+		if i%2 == 0 {
+			fmt.Fprintf(&sb, `    <rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", plotLeft, top, plotRight-plotLeft, imageLaneH, rgb(colorLane))
+		}
+		fmt.Fprintf(&sb, `    <text x="%d" y="%d" fill="%s">%s`, imageMargin, top+imageLaneH/2+4, rgb(colorText), esc(truncateLabel(lane.label, imageLabelW/7-1)))
+		if lane.title != "" {
+			fmt.Fprintf(&sb, `<title>%s</title>`, esc(lane.title+" ("+lane.label+")"))
+		}
+		sb.WriteString("</text>\n")
+		for _, mark := range lane.marks {
+			c := colorRun
+			if mark.overlap {
+				c = colorOverlap
+			}
+			fmt.Fprintf(&sb, `    <rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s</title></rect>`+"\n",
+				mark.x, top+4, min(mark.w, plotRight-mark.x), imageLaneH-8, rgb(c), esc(mark.title))
+		}
+		sb.WriteString("  </g>\n")
+	}
+	if len(l.arrows) > 0 {
+		sb.WriteString("  <defs>\n")
+		for _, m := range []struct {
+			id string
+			c  color.Color
+		}{{"arrow", colorArrow}, {"arrow-late", colorOverlap}} {
+			fmt.Fprintf(&sb, `    <marker id="%s" viewBox="0 0 8 8" refX="8" refY="4" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L8,4 L0,8 z" fill="%s"/></marker>`+"\n", m.id, rgb(m.c))
+		}
+		sb.WriteString("  </defs>\n")
+	}
+	for _, a := range l.arrows {
+		marker, c := "arrow", colorArrow
+		if a.late {
+			marker, c = "arrow-late", colorOverlap
+		}
+		fmt.Fprintf(&sb, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" marker-end="url(#%s)"><title>%s</title></line>`+"\n",
+			a.x1, a.y1, a.x2, a.y2, rgb(c), marker, esc(a.title))
+	}
+	fmt.Fprintf(&sb, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n", plotLeft, bottom, plotRight, bottom, rgb(colorText))
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
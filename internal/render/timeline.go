@@ -5,6 +5,9 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/hzerrad/cronkit/internal/color"
+	"github.com/hzerrad/cronkit/internal/crontab"
 )
 
 // TimelineView represents the type of timeline view
@@ -15,6 +18,10 @@ const (
 	DayView TimelineView = iota
 	// HourView shows 60 minutes
 	HourView
+	// WeekView shows 7 days of 24 hours, as a grid of hourly run counts
+	WeekView
+	// MonthView shows every day of a month, as a grid of hourly run counts
+	MonthView
 )
 
 // String returns the string representation of TimelineView
@@ -24,6 +31,10 @@ func (v TimelineView) String() string {
 		return "day"
 	case HourView:
 		return "hour"
+	case WeekView:
+		return "week"
+	case MonthView:
+		return "month"
 	default:
 		return "unknown"
 	}
@@ -64,6 +75,14 @@ type Timeline struct {
 	jobRuns   []JobRun
 	jobInfo   map[string]JobInfo
 	slots     []time.Time
+	lastRun   map[string]time.Time     // Minute of each job's last run (grid views only)
+	durations map[string]time.Duration // Expected run duration of each job (zero: instantaneous)
+	metadata  map[string]crontab.Metadata
+	users     map[string]string // User each job runs as (system crontabs only)
+
+	timestampLayout string // Layout of overlap times
+	clockLayout     string // Layout of times of day in headers
+	painter         *color.Painter
 }
 
 // NewTimeline creates a new timeline with the specified view, start time, and width
@@ -86,6 +105,15 @@ func NewTimeline(view TimelineView, startTime time.Time, width int) *Timeline {
 		for i := 0; i < 60; i++ {
 			slots[i] = startTime.Add(time.Duration(i) * time.Minute)
 		}
+	case WeekView, MonthView:
+		endTime = startTime.AddDate(0, 0, 7)
+		if view == MonthView {
+			endTime = startTime.AddDate(0, 1, 0)
+		}
+		// Create slots for each hour of the week or month
+		for t := startTime; t.Before(endTime); t = t.Add(time.Hour) {
+			slots = append(slots, t)
+		}
 	}
 
 	return &Timeline{
@@ -96,14 +124,48 @@ func NewTimeline(view TimelineView, startTime time.Time, width int) *Timeline {
 		jobRuns:   make([]JobRun, 0),
 		jobInfo:   make(map[string]JobInfo),
 		slots:     slots,
+		lastRun:   make(map[string]time.Time),
+		durations: make(map[string]time.Duration),
+		metadata:  make(map[string]crontab.Metadata),
+		users:     make(map[string]string),
+
+		timestampLayout: "2006-01-02 15:04:05",
+		clockLayout:     "15:04",
 	}
 }
 
-// AddJobRun adds a job run to the timeline if it falls within the timeline range
+// SetTimeFormat sets the layouts of the text timeline's times: timestamp for
+// overlap times and clock for times of day (e.g., "3:04 PM")
+func (tl *Timeline) SetTimeFormat(timestamp, clock string) {
+	tl.timestampLayout = timestamp
+	tl.clockLayout = clock
+}
+
+// SetPainter colors the text timeline: headings, overlaps and late
+// dependencies. A nil painter (the default) leaves it plain.
+func (tl *Timeline) SetPainter(p *color.Painter) {
+	tl.painter = p
+}
+
+// isGrid reports whether the view is rendered as a day-by-hour grid
+func (tl *Timeline) isGrid() bool {
+	return tl.view == WeekView || tl.view == MonthView
+}
+
+// AddJobRun adds a job run to the timeline if it falls within the timeline range.
+// Week and month views keep one run per job and minute, so sub-minute
+// schedules do not flood long timelines.
 func (tl *Timeline) AddJobRun(jobID string, runTime time.Time) {
 	if runTime.Before(tl.startTime) || !runTime.Before(tl.endTime) {
 		return
 	}
+	if tl.isGrid() {
+		minute := runTime.Truncate(time.Minute)
+		if last, ok := tl.lastRun[jobID]; ok && last.Equal(minute) {
+			return
+		}
+		tl.lastRun[jobID] = minute
+	}
 
 	tl.jobRuns = append(tl.jobRuns, JobRun{
 		JobID:   jobID,
@@ -119,14 +181,42 @@ func (tl *Timeline) SetJobInfo(jobID, expression, description string) {
 	}
 }
 
-// DetectOverlaps finds times where multiple jobs run simultaneously
+// SetJobDuration sets how long the runs of a job are expected to take.
+// Overlap detection treats each run as occupying every minute from its start
+// until it finishes, rather than its start minute only.
+func (tl *Timeline) SetJobDuration(jobID string, d time.Duration) {
+	tl.durations[jobID] = d
+}
+
+// SetJobMetadata sets the metadata declared by a job's "cronkit:" directives
+func (tl *Timeline) SetJobMetadata(jobID string, meta crontab.Metadata) {
+	if !meta.IsZero() {
+		tl.metadata[jobID] = meta
+	}
+}
+
+// SetJobUser sets the user a job runs as, from the user column of system
+// crontabs
+func (tl *Timeline) SetJobUser(jobID, user string) {
+	if user != "" {
+		tl.users[jobID] = user
+	}
+}
+
+// DetectOverlaps finds the minutes in which multiple jobs run simultaneously.
+// Runs last their job's duration (see SetJobDuration), or their start minute.
 func (tl *Timeline) DetectOverlaps() []Overlap {
-	// Group runs by time
+	// Group runs by the minutes they occupy
 	timeGroups := make(map[time.Time][]string)
 	for _, run := range tl.jobRuns {
-		// Round to nearest minute for overlap detection
-		rounded := run.RunTime.Truncate(time.Minute)
-		timeGroups[rounded] = append(timeGroups[rounded], run.JobID)
+		start := run.RunTime.Truncate(time.Minute)
+		end := run.RunTime.Add(tl.durations[run.JobID])
+		for minute := start; minute.Equal(start) || minute.Before(end); minute = minute.Add(time.Minute) {
+			if !minute.Before(tl.endTime) {
+				break
+			}
+			timeGroups[minute] = append(timeGroups[minute], run.JobID)
+		}
 	}
 
 	overlaps := make([]Overlap, 0)
@@ -141,6 +231,10 @@ func (tl *Timeline) DetectOverlaps() []Overlap {
 					uniqueList = append(uniqueList, id)
 				}
 			}
+			// A job whose runs overlap each other does not collide with itself
+			if len(uniqueList) < 2 {
+				continue
+			}
 
 			overlaps = append(overlaps, Overlap{
 				Time:   t,
@@ -201,44 +295,12 @@ func (tl *Timeline) GetOverlapStats() OverlapStats {
 
 // Render generates an ASCII timeline string with optional overlap reporting
 func (tl *Timeline) Render(showOverlaps bool) string {
-	var sb strings.Builder
-
-	// Collect job descriptions early
-	jobIDsSeen := make(map[string]bool)
-	jobList := make([]struct {
-		jobID       string
-		expression  string
-		description string
-	}, 0)
-
-	for _, run := range tl.jobRuns {
-		if !jobIDsSeen[run.JobID] {
-			jobIDsSeen[run.JobID] = true
-			info, hasInfo := tl.jobInfo[run.JobID]
-			if hasInfo {
-				jobList = append(jobList, struct {
-					jobID       string
-					expression  string
-					description string
-				}{
-					jobID:       run.JobID,
-					expression:  info.Expression,
-					description: info.Description,
-				})
-			} else {
-				jobList = append(jobList, struct {
-					jobID       string
-					expression  string
-					description string
-				}{
-					jobID:       run.JobID,
-					expression:  "",
-					description: "",
-				})
-			}
-		}
+	if tl.isGrid() {
+		return tl.renderGrid(showOverlaps)
 	}
 
+	var sb strings.Builder
+
 	// Header
 	var timeRange string
 	var endTimeDisplay time.Time
@@ -246,31 +308,18 @@ func (tl *Timeline) Render(showOverlaps bool) string {
 		// For day view, show 23:59 as the end time
 		endTimeDisplay = tl.endTime.Add(-1 * time.Minute) // Show 23:59 instead of 00:00 next day
 		timeRange = fmt.Sprintf("%s ──────────────────────────────────────────────────────────────── %s",
-			tl.startTime.Format("15:04"), endTimeDisplay.Format("15:04"))
-		sb.WriteString(fmt.Sprintf("Timeline for %s (Day View)\n", tl.startTime.Format("2006-01-02")))
+			tl.startTime.Format(tl.clockLayout), endTimeDisplay.Format(tl.clockLayout))
+		sb.WriteString(tl.painter.Paintf(color.Heading, "Timeline for %s (Day View)", tl.startTime.Format("2006-01-02")) + "\n")
 	} else {
 		// For hour view, show 59 as the end time
 		endTimeDisplay = tl.endTime.Add(-1 * time.Minute) // Show 59 instead of 60
 		timeRange = fmt.Sprintf("%s ──────────────────────────────────────────────────────────────── %s",
-			tl.startTime.Format("15:04"), endTimeDisplay.Format("15:04"))
-		sb.WriteString(fmt.Sprintf("Timeline for %s (Hour View)\n", tl.startTime.Format("2006-01-02 15:04")))
+			tl.startTime.Format(tl.clockLayout), endTimeDisplay.Format(tl.clockLayout))
+		sb.WriteString(tl.painter.Paintf(color.Heading, "Timeline for %s (Hour View)", tl.startTime.Format("2006-01-02 "+tl.clockLayout)) + "\n")
 	}
 
 	// Display job descriptions right after the header
-	for _, job := range jobList {
-		if job.description != "" {
-			// For single expressions, show just the description
-			if strings.HasPrefix(job.jobID, "expr-") {
-				sb.WriteString(fmt.Sprintf("  • %s\n", job.description))
-			} else {
-				// For crontab jobs, show description with expression in parentheses
-				sb.WriteString(fmt.Sprintf("  • %s (%s)\n", job.description, job.expression))
-			}
-		} else {
-			// Fallback to job ID if no description
-			sb.WriteString(fmt.Sprintf("  • %s\n", job.jobID))
-		}
-	}
+	tl.writeJobList(&sb)
 
 	sb.WriteString(timeRange + "\n")
 
@@ -389,8 +438,14 @@ func (tl *Timeline) Render(showOverlaps bool) string {
 			}
 		}
 
-		// Write the timeline line
-		sb.WriteString(string(timelineChars))
+		// Write the timeline line, with overlapping runs painted
+		for _, c := range timelineChars {
+			if c == ' ' || c == '│' {
+				sb.WriteRune(c)
+			} else {
+				sb.WriteString(tl.painter.Paint(color.Warning, string(c)))
+			}
+		}
 		sb.WriteString("  │\n")
 	}
 
@@ -508,43 +563,12 @@ func (tl *Timeline) Render(showOverlaps bool) string {
 
 	// Add legend
 	sb.WriteString("\n")
-	sb.WriteString("Legend: │ = Job execution time | Each marker represents one execution\n")
+	sb.WriteString(tl.painter.Paint(color.Muted, "Legend: │ = Job execution time | Each marker represents one execution") + "\n")
+	tl.writeDependencies(&sb)
 
 	// Add overlap summary if requested
 	if showOverlaps {
-		overlaps := tl.DetectOverlaps()
-		stats := tl.GetOverlapStats()
-
-		sb.WriteString("\n")
-		sb.WriteString("━━━ Overlap Summary ━━━\n")
-
-		if len(overlaps) == 0 {
-			sb.WriteString("No overlaps detected\n")
-		} else {
-			sb.WriteString(fmt.Sprintf("Total overlap windows: %d\n", stats.TotalWindows))
-			sb.WriteString(fmt.Sprintf("Maximum concurrent jobs: %d\n", stats.MaxConcurrent))
-			sb.WriteString("\n")
-			sb.WriteString("Overlaps:\n")
-
-			// Show all overlaps, or limit to first 50 if too many
-			displayOverlaps := overlaps
-			if len(displayOverlaps) > 50 {
-				displayOverlaps = displayOverlaps[:50]
-				sb.WriteString(fmt.Sprintf("  (showing first 50 of %d overlap windows)\n", len(overlaps)))
-			}
-
-			for _, overlap := range displayOverlaps {
-				jobList := strings.Join(overlap.JobIDs, ", ")
-				sb.WriteString(fmt.Sprintf("  %s: %d job(s) (%s)\n",
-					overlap.Time.Format("2006-01-02 15:04:05"),
-					overlap.Count,
-					jobList))
-			}
-
-			if len(overlaps) > 50 {
-				sb.WriteString(fmt.Sprintf("  ... and %d more overlap window(s)\n", len(overlaps)-50))
-			}
-		}
+		tl.writeOverlapSummary(&sb)
 	}
 
 	return sb.String()
@@ -576,6 +600,15 @@ func (tl *Timeline) RenderJSON() map[string]interface{} {
 			jobData["expression"] = info.Expression
 			jobData["description"] = info.Description
 		}
+		if d := tl.durations[jobID]; d > 0 {
+			jobData["duration"] = d.String()
+		}
+		if meta, ok := tl.metadata[jobID]; ok {
+			jobData["metadata"] = meta
+		}
+		if user, ok := tl.users[jobID]; ok {
+			jobData["user"] = user
+		}
 
 		// Add runs
 		overlaps := tl.DetectOverlaps()
@@ -627,7 +660,7 @@ func (tl *Timeline) RenderJSON() map[string]interface{} {
 		"mostProblematic": mostProblematicJSON,
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"view":         tl.view.String(),
 		"startTime":    tl.startTime.Format(time.RFC3339),
 		"endTime":      tl.endTime.Format(time.RFC3339),
@@ -636,6 +669,13 @@ func (tl *Timeline) RenderJSON() map[string]interface{} {
 		"overlaps":     overlapsJSON,
 		"overlapStats": overlapStatsJSON,
 	}
+	if tl.isGrid() {
+		result["days"] = tl.gridJSON()
+	}
+	if deps := tl.dependenciesJSON(); len(deps) > 0 {
+		result["dependencies"] = deps
+	}
+	return result
 }
 
 // findSlotIndex finds the slot index for a given time
@@ -657,6 +697,9 @@ func (tl *Timeline) findSlotIndex(t time.Time) int {
 		if minutes >= 0 && minutes < 60 {
 			return minutes
 		}
+	case WeekView, MonthView:
+		// Find which hour slot
+		return int(t.Sub(tl.startTime).Hours())
 	}
 
 	return -1
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -142,6 +143,32 @@ func TestTimeline_DetectOverlaps(t *testing.T) {
 		overlaps := tl.DetectOverlaps()
 		assert.Len(t, overlaps, 2)
 	})
+
+	t.Run("should detect overlaps with running jobs", func(t *testing.T) {
+		startTime := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+		tl := NewTimeline(DayView, startTime, 80)
+		tl.SetJobDuration("backup", 15*time.Minute)
+
+		tl.AddJobRun("backup", startTime.Add(2*time.Hour))
+		tl.AddJobRun("report", startTime.Add(2*time.Hour+10*time.Minute))
+		tl.AddJobRun("cleanup", startTime.Add(2*time.Hour+15*time.Minute))
+
+		overlaps := tl.DetectOverlaps()
+		require.Len(t, overlaps, 1, "the backup finishes before the cleanup starts")
+		assert.Equal(t, startTime.Add(2*time.Hour+10*time.Minute), overlaps[0].Time)
+		assert.ElementsMatch(t, []string{"backup", "report"}, overlaps[0].JobIDs)
+	})
+
+	t.Run("should not count a job overlapping itself", func(t *testing.T) {
+		startTime := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+		tl := NewTimeline(HourView, startTime, 80)
+		tl.SetJobDuration("slow", 10*time.Minute)
+
+		tl.AddJobRun("slow", startTime)
+		tl.AddJobRun("slow", startTime.Add(5*time.Minute))
+
+		assert.Empty(t, tl.DetectOverlaps())
+	})
 }
 
 func TestTimeline_Render(t *testing.T) {
@@ -258,6 +285,27 @@ func TestTimeline_RenderJSON(t *testing.T) {
 		assert.Equal(t, "Daily at 9am", jobs[0]["description"])
 	})
 
+	t.Run("should render JSON with job metadata", func(t *testing.T) {
+		startTime := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+		tl := NewTimeline(DayView, startTime, 80)
+
+		tl.SetJobMetadata("job-1", crontab.Metadata{Name: "backup", Owner: "infra"})
+		tl.SetJobMetadata("job-2", crontab.Metadata{})
+		tl.AddJobRun("job-1", startTime.Add(1*time.Hour))
+		tl.AddJobRun("job-2", startTime.Add(2*time.Hour))
+
+		result := tl.RenderJSON()
+		jobs := result["jobs"].([]map[string]interface{})
+		require.Len(t, jobs, 2)
+		for _, job := range jobs {
+			if job["id"] == "job-1" {
+				assert.Equal(t, crontab.Metadata{Name: "backup", Owner: "infra"}, job["metadata"])
+			} else {
+				assert.NotContains(t, job, "metadata")
+			}
+		}
+	})
+
 	t.Run("should render JSON with jobs without info", func(t *testing.T) {
 		startTime := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
 		tl := NewTimeline(DayView, startTime, 80)
@@ -386,6 +434,20 @@ func TestTimeline_SetJobInfo(t *testing.T) {
 	})
 }
 
+func TestTimeline_SetTimeFormat(t *testing.T) {
+	startTime := time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC)
+	tl := NewTimeline(HourView, startTime, 80)
+	tl.SetTimeFormat("Jan 2 3:04:05 PM", "3:04 PM")
+	tl.AddJobRun("job-1", startTime.Add(30*time.Minute))
+	tl.AddJobRun("job-2", startTime.Add(30*time.Minute))
+
+	output := tl.Render(true)
+	assert.Contains(t, output, "Timeline for 2025-01-15 2:00 PM (Hour View)")
+	assert.Contains(t, output, "2:00 PM ")
+	assert.Contains(t, output, " 2:59 PM")
+	assert.Contains(t, output, "Jan 15 2:30:00 PM: 2 job(s)")
+}
+
 func TestTimelineView_String(t *testing.T) {
 	t.Run("should return day for DayView", func(t *testing.T) {
 		assert.Equal(t, "day", DayView.String())
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+// detach is a no-op where jobs cannot be started without a terminal
+func detach(cmd *exec.Cmd) {}
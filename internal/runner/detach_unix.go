@@ -0,0 +1,19 @@
+//go:build unix
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// detach starts the job in a session of its own, without a controlling
+// terminal, and makes cancellation terminate its whole process group
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	cmd.WaitDelay = 10 * time.Second
+}
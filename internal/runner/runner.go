@@ -0,0 +1,121 @@
+// Package runner runs cron jobs the way cron does: the command is passed to
+// the job's shell with -c, in cron's minimal environment plus the variables
+// the crontab sets, from the home directory and without a terminal. Text
+// after the first unescaped % of the command is the job's standard input.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Plan is how a job is run
+type Plan struct {
+	Shell   string   // Program the command is passed to ($SHELL)
+	Command string   // Command, without the standard input text
+	Stdin   string   // Standard input, from the text after the first unescaped % (optional)
+	Dir     string   // Working directory ($HOME)
+	Env     []string // Environment as NAME=value pairs, sorted by name
+}
+
+// Result describes a finished run
+type Result struct {
+	Start    time.Time
+	End      time.Time
+	ExitCode int // -1 when the job was killed by a signal
+}
+
+// Duration returns how long the run took
+func (r Result) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// NewPlan returns how cron runs job, with base as the environment cron
+// starts jobs with (see crontab.DefaultEnvironment)
+func NewPlan(job *crontab.Job, base map[string]string) Plan {
+	env := job.Environment(base)
+	shell := env["SHELL"]
+	if shell == "" {
+		shell = crontab.DefaultShell
+	}
+	command, stdin := SplitCommand(job.Command)
+
+	vars := make([]string, 0, len(env))
+	for name, value := range env {
+		vars = append(vars, name+"="+value)
+	}
+	sort.Strings(vars)
+
+	return Plan{Shell: shell, Command: command, Stdin: stdin, Dir: env["HOME"], Env: vars}
+}
+
+// SplitCommand splits a crontab command at its first unescaped %, as cron
+// does: the text before is the command, and the text after is its standard
+// input, with every further unescaped % turned into a newline. \% stands for
+// a literal % in both.
+func SplitCommand(command string) (cmd, stdin string) {
+	var b strings.Builder
+	inStdin := false
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == '\\' && i+1 < len(command) && command[i+1] == '%':
+			b.WriteByte('%')
+			i++
+		case c == '%' && !inStdin:
+			cmd = b.String()
+			b.Reset()
+			inStdin = true
+		case c == '%':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if !inStdin {
+		return b.String(), ""
+	}
+	return cmd, b.String()
+}
+
+// Run runs the plan, writing the job's output to stdout and stderr as it is
+// produced. A job that exits with a non-zero code is not an error; its code
+// is in the result. Cancelling ctx terminates the job and its children.
+func (p Plan) Run(ctx context.Context, stdout, stderr io.Writer) (Result, error) {
+	cmd := exec.CommandContext(ctx, p.Shell, "-c", p.Command)
+	cmd.Dir = p.Dir
+	cmd.Env = p.Env
+	cmd.Stdin = strings.NewReader(p.Stdin)
+	// Wrapping the writers makes the job write to pipes, even when cronkit's
+	// own output is a terminal
+	cmd.Stdout = writer{stdout}
+	cmd.Stderr = writer{stderr}
+	detach(cmd)
+
+	result := Result{Start: time.Now()}
+	err := cmd.Run()
+	result.End = time.Now()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		return result, fmt.Errorf("failed to run job: %w", err)
+	}
+	return result, nil
+}
+
+// writer hides the concrete type of an io.Writer
+type writer struct {
+	io.Writer
+}
@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		cmd     string
+		stdin   string
+	}{
+		{"/usr/bin/backup", "/usr/bin/backup", ""},
+		{`date +\%Y-\%m-\%d`, "date +%Y-%m-%d", ""},
+		{"mail -s report root%Hello%World", "mail -s report root", "Hello\nWorld"},
+		{`cat%100\% done`, "cat", "100% done"},
+		{"cat%", "cat", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			cmd, stdin := SplitCommand(tt.command)
+			assert.Equal(t, tt.cmd, cmd)
+			assert.Equal(t, tt.stdin, stdin)
+		})
+	}
+}
+
+// parseJob returns the last job of a crontab
+func parseJob(t *testing.T, content string) *crontab.Job {
+	t.Helper()
+	entries, err := crontab.ParseReader(strings.NewReader(content))
+	require.NoError(t, err)
+	job := entries[len(entries)-1].Job
+	require.NotNil(t, job)
+	return job
+}
+
+func TestNewPlan(t *testing.T) {
+	base := map[string]string{"HOME": "/home/alice", "SHELL": crontab.DefaultShell, "PATH": crontab.DefaultPath}
+
+	t.Run("cron defaults", func(t *testing.T) {
+		plan := NewPlan(parseJob(t, "0 2 * * * /usr/bin/backup\n"), base)
+		assert.Equal(t, "/bin/sh", plan.Shell)
+		assert.Equal(t, "/usr/bin/backup", plan.Command)
+		assert.Equal(t, "/home/alice", plan.Dir)
+		assert.Equal(t, []string{"HOME=/home/alice", "PATH=/usr/bin:/bin", "SHELL=/bin/sh"}, plan.Env)
+	})
+
+	t.Run("crontab variables", func(t *testing.T) {
+		plan := NewPlan(parseJob(t, "SHELL=/bin/bash\nPATH=/opt/bin\nMAILTO=ops\n0 2 * * * report%body\n"), base)
+		assert.Equal(t, "/bin/bash", plan.Shell)
+		assert.Equal(t, "report", plan.Command)
+		assert.Equal(t, "body", plan.Stdin)
+		assert.Equal(t, []string{"HOME=/home/alice", "MAILTO=ops", "PATH=/opt/bin", "SHELL=/bin/bash"}, plan.Env)
+	})
+}
+
+func TestPlanRun(t *testing.T) {
+	dir := t.TempDir()
+	base := map[string]string{"HOME": dir, "SHELL": crontab.DefaultShell, "PATH": crontab.DefaultPath}
+
+	t.Run("streams output in cron's environment", func(t *testing.T) {
+		plan := NewPlan(parseJob(t, "GREETING=hello\n* * * * * echo $GREETING from $(pwd); cat; test -t 0 || echo no tty >&2%input\n"), base)
+		var stdout, stderr bytes.Buffer
+		result, err := plan.Run(context.Background(), &stdout, &stderr)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.ExitCode)
+		assert.Equal(t, "hello from "+dir+"\ninput", stdout.String())
+		assert.Equal(t, "no tty\n", stderr.String())
+		assert.False(t, result.End.Before(result.Start))
+	})
+
+	t.Run("reports the exit code", func(t *testing.T) {
+		plan := NewPlan(parseJob(t, "* * * * * exit 3\n"), base)
+		result, err := plan.Run(context.Background(), new(bytes.Buffer), new(bytes.Buffer))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.ExitCode)
+	})
+
+	t.Run("cancellation stops the job", func(t *testing.T) {
+		plan := NewPlan(parseJob(t, "* * * * * sleep 30\n"), base)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		result, err := plan.Run(ctx, new(bytes.Buffer), new(bytes.Buffer))
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, result.ExitCode)
+		assert.Less(t, result.Duration(), 10*time.Second)
+	})
+
+	t.Run("missing shell", func(t *testing.T) {
+		plan := NewPlan(parseJob(t, "SHELL=/nonexistent/sh\n* * * * * true\n"), base)
+		_, err := plan.Run(context.Background(), new(bytes.Buffer), new(bytes.Buffer))
+		assert.Error(t, err)
+	})
+}
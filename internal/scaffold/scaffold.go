@@ -0,0 +1,128 @@
+// Package scaffold generates starter crontabs from built-in templates of
+// common jobs, such as backups and certificate renewal, under a preamble
+// setting the shell, PATH and MAILTO of the jobs
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// Defaults of the environment preamble
+const (
+	DefaultShell  = "/bin/bash"
+	DefaultPath   = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+	DefaultMailTo = "root"
+)
+
+// Template is a common job a crontab can start with
+type Template struct {
+	Name        string
+	Description string
+	Command     string
+	Duration    time.Duration // Typical run duration, declared for overlap detection
+	Schedules   []string      // Schedules to choose from, the default first
+}
+
+// DefaultSchedule returns the schedule a job of the template gets when none
+// is chosen
+func (t Template) DefaultSchedule() string {
+	return t.Schedules[0]
+}
+
+// templates are the built-in templates, in the order they are offered
+var templates = []Template{
+	{
+		Name:        "backup",
+		Description: "Back up files and databases to /var/backups",
+		Command:     "/usr/local/bin/backup.sh /var/backups >> /var/log/backup.log 2>&1",
+		Duration:    30 * time.Minute,
+		Schedules:   []string{"30 2 * * *", "0 */6 * * *", "0 3 * * 0"},
+	},
+	{
+		Name:        "logrotate",
+		Description: "Rotate, compress and prune log files",
+		Command:     "/usr/sbin/logrotate /etc/logrotate.conf >> /var/log/logrotate-cron.log 2>&1",
+		Duration:    5 * time.Minute,
+		Schedules:   []string{"0 0 * * *", "0 * * * *", "0 0 * * 0"},
+	},
+	{
+		Name:        "cert-renewal",
+		Description: "Renew TLS certificates due to expire and reload the web server",
+		Command:     `certbot renew --quiet --deploy-hook "systemctl reload nginx" >> /var/log/certbot-renew.log 2>&1`,
+		Duration:    10 * time.Minute,
+		Schedules:   []string{"17 3,15 * * *", "17 3 * * *", "17 3 * * 1"},
+	},
+	{
+		Name:        "report",
+		Description: "Generate and email the activity report of the previous day",
+		Command:     "/usr/local/bin/generate-report.sh --since yesterday >> /var/log/report.log 2>&1",
+		Duration:    15 * time.Minute,
+		Schedules:   []string{"0 7 * * 1-5", "0 7 * * *", "0 7 1 * *"},
+	},
+}
+
+// Templates returns the built-in templates, in the order they are offered
+func Templates() []Template {
+	return append([]Template(nil), templates...)
+}
+
+// Names returns the names of the built-in templates
+func Names() []string {
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Lookup returns the built-in template of a name
+func Lookup(name string) (Template, error) {
+	for _, t := range templates {
+		if t.Name == strings.ToLower(name) {
+			return t, nil
+		}
+	}
+	return Template{}, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// Env is the environment preamble of a crontab. An empty MailTo turns off
+// mailing the output of jobs.
+type Env struct {
+	Shell  string
+	Path   string
+	MailTo string
+}
+
+// Job is a job of a template on a schedule
+type Job struct {
+	Template   Template
+	Expression string
+}
+
+// Render returns the text of a crontab of jobs under the env preamble. Each
+// job is preceded by its description and a "cronkit:" directive naming it
+// and declaring its duration.
+func Render(env Env, jobs []Job) string {
+	var sb strings.Builder
+	sb.WriteString("# Starter crontab generated by cronkit init\n")
+	sb.WriteString("# Edit the commands to suit this host, then check it with: cronkit check --file <crontab>\n\n")
+	fmt.Fprintf(&sb, "SHELL=%s\n", env.Shell)
+	fmt.Fprintf(&sb, "PATH=%s\n", env.Path)
+	if env.MailTo == "" {
+		sb.WriteString("MAILTO=\"\"\n")
+	} else {
+		fmt.Fprintf(&sb, "MAILTO=%s\n", env.MailTo)
+	}
+
+	for _, job := range jobs {
+		metadata := crontab.Metadata{Name: job.Template.Name, Duration: job.Template.Duration}
+		fmt.Fprintf(&sb, "\n# %s\n", job.Template.Description)
+		fmt.Fprintf(&sb, "# %s%s\n", crontab.DirectivePrefix, metadata)
+		fmt.Fprintf(&sb, "%s %s\n", job.Expression, job.Template.Command)
+	}
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package scaffold_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/scaffold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplates(t *testing.T) {
+	assert.Equal(t, []string{"backup", "logrotate", "cert-renewal", "report"}, scaffold.Names())
+
+	parser := cronx.NewParser()
+	for _, tmpl := range scaffold.Templates() {
+		require.NotEmpty(t, tmpl.Schedules, tmpl.Name)
+		for _, expression := range tmpl.Schedules {
+			_, err := parser.Parse(expression)
+			assert.NoError(t, err, "%s: %s", tmpl.Name, expression)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tmpl, err := scaffold.Lookup("Backup")
+	require.NoError(t, err)
+	assert.Equal(t, "backup", tmpl.Name)
+	assert.Equal(t, "30 2 * * *", tmpl.DefaultSchedule())
+
+	_, err = scaffold.Lookup("deploy")
+	assert.EqualError(t, err, `unknown template "deploy" (available: backup, logrotate, cert-renewal, report)`)
+}
+
+func TestRender(t *testing.T) {
+	backup, err := scaffold.Lookup("backup")
+	require.NoError(t, err)
+	certs, err := scaffold.Lookup("cert-renewal")
+	require.NoError(t, err)
+	env := scaffold.Env{Shell: scaffold.DefaultShell, Path: scaffold.DefaultPath, MailTo: "ops@example.com"}
+
+	text := scaffold.Render(env, []scaffold.Job{
+		{Template: backup, Expression: "0 4 * * *"},
+		{Template: certs, Expression: certs.DefaultSchedule()},
+	})
+	assert.Contains(t, text, "SHELL=/bin/bash\nPATH="+scaffold.DefaultPath+"\nMAILTO=ops@example.com\n")
+	assert.Contains(t, text, "\n# Back up files and databases to /var/backups\n# cronkit:name=backup duration=30m\n0 4 * * * /usr/local/bin/backup.sh")
+
+	entries, err := crontab.ParseReader(strings.NewReader(text))
+	require.NoError(t, err)
+	var jobs []*crontab.Job
+	for _, entry := range entries {
+		if entry.Job != nil {
+			jobs = append(jobs, entry.Job)
+		}
+	}
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "backup", jobs[0].Metadata.Name)
+	assert.Equal(t, 30*time.Minute, jobs[0].Duration)
+	assert.Equal(t, "17 3,15 * * *", jobs[1].Expression)
+
+	env.MailTo = ""
+	assert.Contains(t, scaffold.Render(env, nil), "\nMAILTO=\"\"\n")
+}
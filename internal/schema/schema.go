@@ -0,0 +1,50 @@
+// Package schema holds the JSON schemas of the JSON output of cronkit
+// commands. Schemas are embedded in the binary and printed by
+// "cronkit schema <command>", so automation can validate what it consumes.
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Version is the version of the JSON output, written in every payload under
+// the key returned by VersionField. It is raised when a field is removed, renamed or changes
+// meaning; new fields may appear within a version.
+const Version = 1
+
+// VersionField returns the key of Version in a command's JSON output:
+// "schemaVersion", or "SchemaVersion" for stats, whose keys are Go field
+// names
+func VersionField(command string) string {
+	if command == "stats" {
+		return "SchemaVersion"
+	}
+	return "schemaVersion"
+}
+
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// Commands returns the commands with a JSON schema, sorted
+func Commands() []string {
+	entries, _ := schemas.ReadDir("schemas")
+	commands := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		commands = append(commands, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// Get returns the JSON schema of a command's JSON output
+func Get(command string) ([]byte, error) {
+	data, err := schemas.ReadFile(path.Join("schemas", command+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no JSON schema for %q (available: %s)", command, strings.Join(Commands(), ", "))
+	}
+	return data, nil
+}
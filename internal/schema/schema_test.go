@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands(t *testing.T) {
+	assert.Equal(t, []string{"check", "diff", "doc", "next", "stats", "timeline"}, Commands())
+}
+
+func TestGet(t *testing.T) {
+	for _, command := range Commands() {
+		t.Run(command, func(t *testing.T) {
+			data, err := Get(command)
+			require.NoError(t, err)
+
+			var schema map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &schema))
+			assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+			assert.Equal(t, "cronkit/v1/"+command+".json", schema["$id"])
+
+			// Every form of the payload declares the current version
+			forms := []interface{}{schema}
+			if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+				forms = oneOf
+			}
+			for _, form := range forms {
+				properties := form.(map[string]interface{})["properties"].(map[string]interface{})
+				version := properties[VersionField(command)].(map[string]interface{})
+				assert.Equal(t, float64(Version), version["const"])
+				assert.Contains(t, form.(map[string]interface{})["required"], VersionField(command))
+			}
+		})
+	}
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, err := Get("eq")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "available: check, diff, doc, next, stats, timeline")
+	})
+}
@@ -0,0 +1,125 @@
+// Package sla checks recorded job runs against their service level: the
+// maximum run duration annotated with "# @sla:" and how late a run may start
+package sla
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/history"
+)
+
+// DefaultLateThreshold is how long after its scheduled minute a run may
+// start before it is reported as late
+const DefaultLateThreshold = time.Minute
+
+// BreachKind identifies what a run breached
+type BreachKind string
+
+const (
+	// BreachDuration is a run that took longer than the job's SLA
+	BreachDuration BreachKind = "duration"
+	// BreachLate is a run that started too long after its scheduled minute
+	BreachLate BreachKind = "late"
+)
+
+// Breach is a run that did not meet its service level
+type Breach struct {
+	Kind      BreachKind
+	Run       history.Record
+	Scheduled time.Time     // Minute the run was due (zero if unknown)
+	Duration  time.Duration // How long the run took
+	Delay     time.Duration // How long after Scheduled the run started
+}
+
+// JobReport holds the runs and breaches of one job
+type JobReport struct {
+	Job      *crontab.Job
+	Runs     int // Runs recorded since Options.Since
+	Breaches []Breach
+}
+
+// Report is the result of Evaluate
+type Report struct {
+	Jobs     []JobReport // In crontab order
+	Runs     int         // Runs matched to a job
+	Breaches int         // Total breaches across all jobs
+}
+
+// Options configures Evaluate
+type Options struct {
+	Since         time.Time       // Ignore runs that started earlier (zero: keep all)
+	LateThreshold time.Duration   // Allowed start delay (zero: late starts are not checked)
+	Location      *time.Location  // Time zone of jobs without CRON_TZ= (default: time.Local)
+	Scheduler     cronx.Scheduler // Computes scheduled minutes (default: cronx.NewScheduler())
+}
+
+// Evaluate matches run records to jobs and reports the runs that exceeded
+// their job's SLA or started more than opts.LateThreshold after their
+// scheduled minute. A record belongs to a job when the commands are equal
+// and, if the record names an expression, the expressions are equal too.
+// Records without a scheduled time are attributed to the job's last run
+// at or before their start.
+func Evaluate(jobs []*crontab.Job, records []history.Record, opts Options) (*Report, error) {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.Scheduler == nil {
+		opts.Scheduler = cronx.NewScheduler()
+	}
+
+	report := &Report{Jobs: make([]JobReport, 0, len(jobs))}
+	for _, job := range jobs {
+		loc, err := job.Location(opts.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		jr := JobReport{Job: job}
+		for _, rec := range records {
+			if !matches(job, rec) || rec.Start.Before(opts.Since) {
+				continue
+			}
+			jr.Runs++
+
+			duration := rec.Duration()
+			if job.SLA > 0 && duration > job.SLA {
+				jr.Breaches = append(jr.Breaches, Breach{Kind: BreachDuration, Run: rec, Scheduled: rec.Scheduled, Duration: duration})
+			}
+			if opts.LateThreshold <= 0 {
+				continue
+			}
+
+			scheduled := rec.Scheduled
+			if scheduled.IsZero() {
+				// Prev is strict, so look just past the start to catch on-time runs
+				times, err := opts.Scheduler.Prev(job.Expression, rec.Start.In(loc).Add(time.Nanosecond), 1)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: failed to calculate scheduled time: %w", job.LineNumber, err)
+				}
+				scheduled = times[0]
+			}
+			if scheduled.IsZero() {
+				continue
+			}
+			if delay := rec.Start.Sub(scheduled); delay > opts.LateThreshold {
+				jr.Breaches = append(jr.Breaches, Breach{Kind: BreachLate, Run: rec, Scheduled: scheduled, Duration: duration, Delay: delay})
+			}
+		}
+
+		report.Runs += jr.Runs
+		report.Breaches += len(jr.Breaches)
+		report.Jobs = append(report.Jobs, jr)
+	}
+	return report, nil
+}
+
+// matches reports whether rec is a run of job
+func matches(job *crontab.Job, rec history.Record) bool {
+	if rec.Command != job.Command {
+		return false
+	}
+	return rec.Expression == "" || rec.Expression == job.Expression
+}
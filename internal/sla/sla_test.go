@@ -0,0 +1,77 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(hour, min, sec int) time.Time {
+	return time.Date(2026, 10, 16, hour, min, sec, 0, time.UTC)
+}
+
+func TestEvaluate(t *testing.T) {
+	backup := &crontab.Job{LineNumber: 2, Expression: "0 2 * * *", Command: "/usr/bin/backup.sh", SLA: 10 * time.Minute}
+	report := &crontab.Job{LineNumber: 3, Expression: "0 3 * * *", Command: "/usr/bin/report.sh"}
+	jobs := []*crontab.Job{backup, report}
+
+	records := []history.Record{
+		// Within SLA, on time
+		{Command: backup.Command, Start: at(2, 0, 3).AddDate(0, 0, -1), End: at(2, 5, 0).AddDate(0, 0, -1)},
+		// Over SLA
+		{Command: backup.Command, Start: at(2, 0, 5), End: at(2, 14, 5)},
+		// Late start, no SLA
+		{Command: report.Command, Start: at(3, 4, 0), End: at(3, 5, 0)},
+		// Other expression, ignored
+		{Command: report.Command, Expression: "0 4 * * *", Start: at(4, 30, 0), End: at(4, 31, 0)},
+		// Unknown job
+		{Command: "/usr/bin/other.sh", Start: at(5, 0, 0), End: at(6, 0, 0)},
+	}
+
+	t.Run("reports duration and late breaches", func(t *testing.T) {
+		result, err := Evaluate(jobs, records, Options{LateThreshold: time.Minute, Location: time.UTC})
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Runs)
+		assert.Equal(t, 2, result.Breaches)
+		require.Len(t, result.Jobs, 2)
+
+		require.Len(t, result.Jobs[0].Breaches, 1)
+		b := result.Jobs[0].Breaches[0]
+		assert.Equal(t, BreachDuration, b.Kind)
+		assert.Equal(t, 14*time.Minute, b.Duration)
+
+		require.Len(t, result.Jobs[1].Breaches, 1)
+		b = result.Jobs[1].Breaches[0]
+		assert.Equal(t, BreachLate, b.Kind)
+		assert.True(t, b.Scheduled.Equal(at(3, 0, 0)))
+		assert.Equal(t, 4*time.Minute, b.Delay)
+	})
+
+	t.Run("since filters old runs", func(t *testing.T) {
+		result, err := Evaluate(jobs, records, Options{Since: at(2, 30, 0), Location: time.UTC})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Runs)
+		assert.Zero(t, result.Breaches)
+	})
+
+	t.Run("recorded scheduled time", func(t *testing.T) {
+		rec := history.Record{Command: report.Command, Scheduled: at(3, 0, 0), Start: at(3, 0, 30), End: at(3, 1, 0)}
+		result, err := Evaluate(jobs, []history.Record{rec}, Options{LateThreshold: 10 * time.Second, Location: time.UTC})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Breaches)
+		assert.Equal(t, 30*time.Second, result.Jobs[1].Breaches[0].Delay)
+	})
+
+	t.Run("job time zone", func(t *testing.T) {
+		job := &crontab.Job{Expression: "0 9 * * *", Command: "tokyo.sh", Timezone: "Asia/Tokyo"}
+		// 09:00 in Tokyo is 00:00 UTC
+		rec := history.Record{Command: "tokyo.sh", Start: at(0, 0, 10), End: at(0, 1, 0)}
+		result, err := Evaluate([]*crontab.Job{job}, []history.Record{rec}, Options{LateThreshold: time.Minute, Location: time.UTC})
+		require.NoError(t, err)
+		assert.Zero(t, result.Breaches)
+	})
+}
@@ -0,0 +1,194 @@
+// Package snippet keeps a personal library of named cron expressions and
+// job lines, stored as a YAML file in the config directory, so commonly
+// used schedules are reused the same way across crontabs
+package snippet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/hzerrad/cronkit/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvSnippets overrides the library file location
+const EnvSnippets = "CRONKIT_SNIPPETS"
+
+// FileName is the name of the library file in the config directory
+const FileName = "snippets.yaml"
+
+// namePattern is what snippet names may be made of
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ErrNotFound is returned for names missing from a library
+var ErrNotFound = errors.New("no such snippet")
+
+// Snippet is a named cron expression, with the command of a job when it is
+// a whole job line
+type Snippet struct {
+	Name        string `yaml:"name" json:"name"`
+	Expression  string `yaml:"expression" json:"expression"`
+	Command     string `yaml:"command,omitempty" json:"command,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"` // What the snippet is for, as given when saved
+}
+
+// IsJob reports whether the snippet is a job line rather than a schedule
+func (s Snippet) IsJob() bool {
+	return s.Command != ""
+}
+
+// Line returns the crontab line of the snippet, with command instead of its
+// own when command is not empty
+func (s Snippet) Line(command string) string {
+	if command == "" {
+		command = s.Command
+	}
+	if command == "" {
+		return s.Expression
+	}
+	return s.Expression + " " + command
+}
+
+// ValidateName checks that a name is usable for a snippet: letters, digits,
+// dots, dashes and underscores, starting with a letter or digit
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid snippet name %q (use letters, digits, '.', '-' and '_', starting with a letter or digit)", name)
+	}
+	return nil
+}
+
+// Library is a set of snippets by name, read from and saved to a file
+type Library struct {
+	path     string
+	snippets map[string]Snippet
+}
+
+// file is the content of a library file
+type file struct {
+	Snippets []Snippet `yaml:"snippets"`
+}
+
+// DefaultPath returns the library file location: $CRONKIT_SNIPPETS, else
+// snippets.yaml in the config directory (see config.Dir)
+func DefaultPath() (string, error) {
+	if path := os.Getenv(EnvSnippets); path != "" {
+		return path, nil
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate snippet library: %w", err)
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Load reads the library at path. A missing file is an empty library.
+func Load(path string) (*Library, error) {
+	l := &Library{path: path, snippets: make(map[string]Snippet)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snippet library: %w", err)
+	}
+
+	var f file
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid snippet library %s: %w", path, err)
+	}
+	for _, s := range f.Snippets {
+		if err := ValidateName(s.Name); err != nil {
+			return nil, fmt.Errorf("invalid snippet library %s: %w", path, err)
+		}
+		if s.Expression == "" {
+			return nil, fmt.Errorf("invalid snippet library %s: snippet %q without an expression", path, s.Name)
+		}
+		l.snippets[s.Name] = s
+	}
+	return l, nil
+}
+
+// Path returns the path of the library file
+func (l *Library) Path() string {
+	return l.path
+}
+
+// List returns the snippets, sorted by name
+func (l *Library) List() []Snippet {
+	list := make([]Snippet, 0, len(l.snippets))
+	for _, s := range l.snippets {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Get returns the snippet of a name, or ErrNotFound
+func (l *Library) Get(name string) (Snippet, error) {
+	s, ok := l.snippets[name]
+	if !ok {
+		return Snippet{}, fmt.Errorf("%w %q in %s", ErrNotFound, name, l.path)
+	}
+	return s, nil
+}
+
+// Put adds a snippet to the library, replacing the one of the same name
+// only when replace is set
+func (l *Library) Put(s Snippet, replace bool) error {
+	if err := ValidateName(s.Name); err != nil {
+		return err
+	}
+	if _, exists := l.snippets[s.Name]; exists && !replace {
+		return fmt.Errorf("snippet %q already exists", s.Name)
+	}
+	l.snippets[s.Name] = s
+	return nil
+}
+
+// Remove deletes the snippet of a name, or returns ErrNotFound
+func (l *Library) Remove(name string) error {
+	if _, err := l.Get(name); err != nil {
+		return err
+	}
+	delete(l.snippets, name)
+	return nil
+}
+
+// Save writes the library to its file, creating the directory if needed.
+// The file is replaced atomically.
+func (l *Library) Save() error {
+	data, err := yaml.Marshal(file{Snippets: l.List()})
+	if err != nil {
+		return fmt.Errorf("failed to encode snippet library: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to save snippet library: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), "."+filepath.Base(l.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to save snippet library: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), l.path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save snippet library: %w", err)
+	}
+	return nil
+}
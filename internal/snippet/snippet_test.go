@@ -0,0 +1,114 @@
+package snippet_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/snippet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPath(t *testing.T) {
+	t.Run("CRONKIT_SNIPPETS takes precedence", func(t *testing.T) {
+		t.Setenv(snippet.EnvSnippets, "/srv/snippets.yaml")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+		path, err := snippet.DefaultPath()
+		require.NoError(t, err)
+		assert.Equal(t, "/srv/snippets.yaml", path)
+	})
+
+	t.Run("config directory", func(t *testing.T) {
+		t.Setenv(snippet.EnvSnippets, "")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+		path, err := snippet.DefaultPath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/xdg", "cronkit", "snippets.yaml"), path)
+	})
+}
+
+func TestSnippet_Line(t *testing.T) {
+	schedule := snippet.Snippet{Name: "hourly", Expression: "0 * * * *"}
+	assert.False(t, schedule.IsJob())
+	assert.Equal(t, "0 * * * *", schedule.Line(""))
+	assert.Equal(t, "0 * * * * /bin/poll", schedule.Line("/bin/poll"))
+
+	job := snippet.Snippet{Name: "backup", Expression: "30 2 * * *", Command: "/bin/backup"}
+	assert.True(t, job.IsJob())
+	assert.Equal(t, "30 2 * * * /bin/backup", job.Line(""))
+	assert.Equal(t, "30 2 * * * /bin/other", job.Line("/bin/other"))
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"backup", "business-hours", "v1.2_daily", "9am"} {
+		assert.NoError(t, snippet.ValidateName(name), name)
+	}
+	for _, name := range []string{"", "-flag", "two words", "a/b"} {
+		assert.Error(t, snippet.ValidateName(name), name)
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	t.Run("missing file is an empty library", func(t *testing.T) {
+		library, err := snippet.Load(filepath.Join(t.TempDir(), "snippets.yaml"))
+		require.NoError(t, err)
+		assert.Empty(t, library.List())
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cronkit", "snippets.yaml")
+		library, err := snippet.Load(path)
+		require.NoError(t, err)
+		require.NoError(t, library.Put(snippet.Snippet{Name: "weekdays", Expression: "0 9 * * 1-5"}, false))
+		require.NoError(t, library.Put(snippet.Snippet{Name: "backup", Expression: "30 2 * * *", Command: "/bin/backup", Description: "Nightly"}, false))
+		require.NoError(t, library.Save())
+
+		loaded, err := snippet.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, path, loaded.Path())
+		list := loaded.List()
+		require.Len(t, list, 2)
+		assert.Equal(t, "backup", list[0].Name)
+		assert.Equal(t, "Nightly", list[0].Description)
+		assert.Equal(t, "weekdays", list[1].Name)
+	})
+
+	t.Run("put does not replace unless asked", func(t *testing.T) {
+		library, err := snippet.Load(filepath.Join(t.TempDir(), "snippets.yaml"))
+		require.NoError(t, err)
+		require.NoError(t, library.Put(snippet.Snippet{Name: "daily", Expression: "0 0 * * *"}, false))
+		assert.Error(t, library.Put(snippet.Snippet{Name: "daily", Expression: "0 1 * * *"}, false))
+		require.NoError(t, library.Put(snippet.Snippet{Name: "daily", Expression: "0 1 * * *"}, true))
+		s, err := library.Get("daily")
+		require.NoError(t, err)
+		assert.Equal(t, "0 1 * * *", s.Expression)
+		assert.Error(t, library.Put(snippet.Snippet{Name: "bad name", Expression: "0 1 * * *"}, false))
+	})
+
+	t.Run("get and remove missing snippets", func(t *testing.T) {
+		library, err := snippet.Load(filepath.Join(t.TempDir(), "snippets.yaml"))
+		require.NoError(t, err)
+		_, err = library.Get("nope")
+		assert.ErrorIs(t, err, snippet.ErrNotFound)
+		assert.ErrorIs(t, library.Remove("nope"), snippet.ErrNotFound)
+
+		require.NoError(t, library.Put(snippet.Snippet{Name: "daily", Expression: "0 0 * * *"}, false))
+		require.NoError(t, library.Remove("daily"))
+		assert.Empty(t, library.List())
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"unknown field":      "snippets:\n  - name: a\n    expression: '* * * * *'\n    schedule: x\n",
+			"invalid name":       "snippets:\n  - name: 'a b'\n    expression: '* * * * *'\n",
+			"missing expression": "snippets:\n  - name: a\n",
+			"not yaml":           "snippets: [\n",
+		} {
+			path := filepath.Join(t.TempDir(), "snippets.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			_, err := snippet.Load(path)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Migration upgrades a document's data from one schema version to the next
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// Schema describes a kind of versioned document
+type Schema struct {
+	Name       string      // Kind of document, recorded in the file (e.g. "run")
+	Version    int         // Current version, written by this build (>= 1)
+	Migrations []Migration // Migrations[i] upgrades data from version i+1 to i+2
+}
+
+// envelope is the on-disk form of a versioned document
+type envelope struct {
+	Schema  string          `json:"schema"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// upgrade migrates e to the schema's current version
+func (s Schema) upgrade(e envelope) (json.RawMessage, error) {
+	if e.Schema != s.Name {
+		return nil, fmt.Errorf("document has schema %q, expected %q", e.Schema, s.Name)
+	}
+	if e.Version > s.Version {
+		return nil, fmt.Errorf("%s document version %d %w", s.Name, e.Version, ErrNewerVersion)
+	}
+	if len(s.Migrations) < s.Version-1 {
+		return nil, fmt.Errorf("%s schema version %d is missing migrations", s.Name, s.Version)
+	}
+
+	data := e.Data
+	for v := max(e.Version, 1); v < s.Version; v++ {
+		var err error
+		if data, err = s.Migrations[v-1](data); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s document from version %d: %w", s.Name, v, err)
+		}
+	}
+	return data, nil
+}
+
+// Read decodes the document name into v, migrating it to the schema's
+// current version. It returns an error wrapping os.ErrNotExist when the
+// document does not exist.
+func (s *Store) Read(name string, schema Schema, v any) error {
+	unlock, err := s.lock(false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.read(name, schema, v)
+}
+
+func (s *Store) read(name string, schema Schema, v any) error {
+	raw, err := os.ReadFile(s.Path(name))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	var e envelope
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	data, err := schema.upgrade(e)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// Write replaces the document name with v at the schema's current version
+func (s *Store) Write(name string, schema Schema, v any) error {
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.write(name, schema, v)
+}
+
+func (s *Store) write(name string, schema Schema, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	raw, err := json.MarshalIndent(envelope{Schema: schema.Name, Version: schema.Version, Data: data}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return writeAtomic(s.Path(name), append(raw, '\n'))
+}
+
+// Update reads the document name into v (leaving v untouched if it does not
+// exist yet), calls fn and writes v back, all under the exclusive lock. The
+// document is not written when fn returns an error.
+func (s *Store) Update(name string, schema Schema, v any, fn func() error) error {
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.read(name, schema, v); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	return s.write(name, schema, v)
+}
+
+// Append adds v as one JSON line to the log name. Each line records the
+// schema name and version, so logs written by older builds stay readable.
+func (s *Store) Append(name string, schema Schema, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s entry: %w", name, err)
+	}
+	line, err := json.Marshal(envelope{Schema: schema.Name, Version: schema.Version, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s entry: %w", name, err)
+	}
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(s.Path(name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to append to %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+// ReadLog calls fn with the data of every entry of the log name, oldest
+// first, migrated to the schema's current version. A missing log has no
+// entries. Iteration stops at the first error returned by fn.
+func (s *Store) ReadLog(name string, schema Schema, fn func(data json.RawMessage) error) error {
+	unlock, err := s.lock(false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	raw, err := os.ReadFile(s.Path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e envelope
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return fmt.Errorf("failed to read %s line %d: %w", name, line, err)
+		}
+		data, err := schema.upgrade(e)
+		if err != nil {
+			return fmt.Errorf("failed to read %s line %d: %w", name, line, err)
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package state
+
+import "os"
+
+// flock is a no-op on platforms without file locking (e.g. js/wasm), where
+// cronkit runs as a single process
+func flock(_ *os.File, _ bool) error {
+	return nil
+}
+
+// funlock is a no-op on platforms without file locking
+func funlock(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,203 @@
+// Package state manages cronkit's on-disk state: the run records, history,
+// backups and audit log shared by every cronkit invocation on a host.
+//
+// The state directory follows the XDG base directory specification:
+// $CRONKIT_STATE_DIR if set, else $XDG_STATE_HOME/cronkit, else
+// ~/.local/state/cronkit. Its layout is:
+//
+//	layout.json   version of the directory layout
+//	runs/         one record per job run (run)
+//	history/      schedule change history (history)
+//	backups/      crontab copies taken before changes (backup)
+//	audit.log     append-only JSON lines audit log (audit)
+//
+// Every access takes an advisory lock, so concurrent invocations never see
+// or write a partial file: readers share a lock, writers hold it exclusively
+// and replace files atomically. JSON documents carry a schema version and
+// are migrated to the current version as they are read.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Subdirectories and files of the state directory
+const (
+	RunsDir    = "runs"
+	HistoryDir = "history"
+	BackupsDir = "backups"
+	AuditLog   = "audit.log"
+
+	layoutFile = "layout.json"
+	lockName   = ".lock"
+)
+
+// LayoutVersion is the version of the directory layout written by this build
+const LayoutVersion = 1
+
+// EnvStateDir overrides the state directory location
+const EnvStateDir = "CRONKIT_STATE_DIR"
+
+// ErrNewerVersion is returned for state written by a newer cronkit
+var ErrNewerVersion = errors.New("written by a newer version of cronkit")
+
+// layoutMigrations upgrade the directory layout: layoutMigrations[i] moves
+// a directory from version i+1 to version i+2. It is called with the store
+// locked exclusively.
+var layoutMigrations []func(s *Store) error
+
+// DefaultDir returns the state directory: $CRONKIT_STATE_DIR, else
+// $XDG_STATE_HOME/cronkit, else ~/.local/state/cronkit
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(EnvStateDir); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "cronkit"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate state directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "cronkit"), nil
+}
+
+// Store is a state directory. A Store is safe for concurrent use, including
+// by other processes opening the same directory.
+type Store struct {
+	dir string
+}
+
+// Open opens the state directory at dir, creating it and migrating its
+// layout to LayoutVersion if needed
+func Open(dir string) (*Store, error) {
+	s := &Store{dir: dir}
+	for _, sub := range []string{"", RunsDir, HistoryDir, BackupsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("failed to create state directory: %w", err)
+		}
+	}
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := s.migrateLayout(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// OpenDefault opens the state directory returned by DefaultDir
+func OpenDefault() (*Store, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return Open(dir)
+}
+
+// Dir returns the path of the state directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Path returns the path of name within the state directory
+func (s *Store) Path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// layout is the content of layout.json
+type layout struct {
+	Version int `json:"version"`
+}
+
+// migrateLayout brings the directory layout up to LayoutVersion
+func (s *Store) migrateLayout() error {
+	current := layout{Version: LayoutVersion}
+	data, err := os.ReadFile(s.Path(layoutFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// A new directory
+	case err != nil:
+		return fmt.Errorf("failed to read state layout: %w", err)
+	default:
+		if err := json.Unmarshal(data, &current); err != nil {
+			return fmt.Errorf("failed to read state layout: %w", err)
+		}
+	}
+
+	if current.Version > LayoutVersion {
+		return fmt.Errorf("state directory %s (layout version %d) %w", s.dir, current.Version, ErrNewerVersion)
+	}
+	for v := max(current.Version, 1); v < LayoutVersion; v++ {
+		if err := layoutMigrations[v-1](s); err != nil {
+			return fmt.Errorf("failed to migrate state layout from version %d: %w", v, err)
+		}
+	}
+
+	if data != nil && current.Version == LayoutVersion {
+		return nil
+	}
+	data, err = json.Marshal(layout{Version: LayoutVersion})
+	if err != nil {
+		return err
+	}
+	return writeAtomic(s.Path(layoutFile), append(data, '\n'))
+}
+
+// Lock takes the store's exclusive lock, blocking until it is available, and
+// returns the function that releases it. Use it to make several operations
+// atomic; the other Store methods lock on their own and must not be called
+// while holding it.
+func (s *Store) Lock() (func(), error) {
+	return s.lock(true)
+}
+
+// lock takes the store lock, shared or exclusive
+func (s *Store) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(s.Path(lockName), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state lock: %w", err)
+	}
+	if err := flock(f, exclusive); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock state directory: %w", err)
+	}
+	return func() {
+		_ = funlock(f)
+		_ = f.Close()
+	}, nil
+}
+
+// writeAtomic replaces the file at path with data, so readers see either the
+// old or the new content
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDir(t *testing.T) {
+	t.Run("CRONKIT_STATE_DIR takes precedence", func(t *testing.T) {
+		t.Setenv(EnvStateDir, "/srv/cronkit")
+		t.Setenv("XDG_STATE_HOME", "/xdg")
+		dir, err := DefaultDir()
+		require.NoError(t, err)
+		assert.Equal(t, "/srv/cronkit", dir)
+	})
+
+	t.Run("XDG_STATE_HOME", func(t *testing.T) {
+		t.Setenv(EnvStateDir, "")
+		t.Setenv("XDG_STATE_HOME", "/xdg")
+		dir, err := DefaultDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/xdg", "cronkit"), dir)
+	})
+
+	t.Run("relative XDG_STATE_HOME is ignored", func(t *testing.T) {
+		t.Setenv(EnvStateDir, "")
+		t.Setenv("XDG_STATE_HOME", "relative")
+		t.Setenv("HOME", "/home/alice")
+		dir, err := DefaultDir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/home/alice", ".local", "state", "cronkit"), dir)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+	s, err := Open(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, s.Dir())
+
+	for _, sub := range []string{RunsDir, HistoryDir, BackupsDir} {
+		info, err := os.Stat(filepath.Join(dir, sub))
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, layoutFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 1}`, string(data))
+
+	t.Run("reopens an existing directory", func(t *testing.T) {
+		_, err := Open(dir)
+		assert.NoError(t, err)
+	})
+
+	t.Run("refuses a newer layout", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, layoutFile), []byte(`{"version": 99}`), 0600))
+		_, err := Open(dir)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrNewerVersion))
+	})
+}
+
+type counter struct {
+	Count int `json:"count"`
+}
+
+var counterSchema = Schema{Name: "counter", Version: 1}
+
+func TestReadWrite(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	var c counter
+	err = s.Read("counter.json", counterSchema, &c)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	require.NoError(t, s.Write("counter.json", counterSchema, counter{Count: 3}))
+	require.NoError(t, s.Read("counter.json", counterSchema, &c))
+	assert.Equal(t, 3, c.Count)
+
+	err = s.Read("counter.json", Schema{Name: "other", Version: 1}, &c)
+	assert.ErrorContains(t, err, `schema "counter"`)
+}
+
+func TestMigrations(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	// Version 1 stored a bare number; version 2 wraps it in an object
+	v1 := Schema{Name: "counter", Version: 1}
+	require.NoError(t, s.Write("counter.json", v1, 7))
+
+	v2 := Schema{Name: "counter", Version: 2, Migrations: []Migration{
+		func(data json.RawMessage) (json.RawMessage, error) {
+			var n int
+			if err := json.Unmarshal(data, &n); err != nil {
+				return nil, err
+			}
+			return json.Marshal(counter{Count: n})
+		},
+	}}
+
+	var c counter
+	require.NoError(t, s.Read("counter.json", v2, &c))
+	assert.Equal(t, 7, c.Count)
+
+	t.Run("newer documents are refused", func(t *testing.T) {
+		var n int
+		err := s.Read("counter.json", v1, &n)
+		assert.NoError(t, err, "file was not rewritten by the read")
+
+		require.NoError(t, s.Write("counter.json", v2, c))
+		err = s.Read("counter.json", v1, &n)
+		assert.True(t, errors.Is(err, ErrNewerVersion))
+	})
+}
+
+func TestUpdate_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	const workers, increments = 8, 25
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each worker opens its own store, like a separate process
+			s, err := Open(dir)
+			if !assert.NoError(t, err) {
+				return
+			}
+			for range increments {
+				var c counter
+				assert.NoError(t, s.Update("counter.json", counterSchema, &c, func() error {
+					c.Count++
+					return nil
+				}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	s, err := Open(dir)
+	require.NoError(t, err)
+	var c counter
+	require.NoError(t, s.Read("counter.json", counterSchema, &c))
+	assert.Equal(t, workers*increments, c.Count)
+}
+
+func TestUpdate_Error(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, s.Write("counter.json", counterSchema, counter{Count: 1}))
+
+	var c counter
+	err = s.Update("counter.json", counterSchema, &c, func() error {
+		c.Count = 100
+		return errors.New("abort")
+	})
+	assert.EqualError(t, err, "abort")
+
+	require.NoError(t, s.Read("counter.json", counterSchema, &c))
+	assert.Equal(t, 1, c.Count)
+}
+
+func TestAppendReadLog(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	var got []int
+	collect := func(data json.RawMessage) error {
+		var c counter
+		if err := json.Unmarshal(data, &c); err != nil {
+			return err
+		}
+		got = append(got, c.Count)
+		return nil
+	}
+	require.NoError(t, s.ReadLog(AuditLog, counterSchema, collect))
+	assert.Empty(t, got)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Append(AuditLog, counterSchema, counter{Count: i}))
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, s.ReadLog(AuditLog, counterSchema, collect))
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, got)
+
+	t.Run("stops at the first callback error", func(t *testing.T) {
+		calls := 0
+		err := s.ReadLog(AuditLog, counterSchema, func(json.RawMessage) error {
+			calls++
+			return errors.New("stop")
+		})
+		assert.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestLock(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	unlock, err := s.Lock()
+	require.NoError(t, err)
+
+	written := make(chan struct{})
+	go func() {
+		_ = s.Write("counter.json", counterSchema, counter{Count: 1})
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("write did not wait for the lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+	_, err = os.Stat(s.Path("counter.json"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	unlock()
+	<-written
+	_, err = os.Stat(s.Path("counter.json"))
+	assert.NoError(t, err)
+}
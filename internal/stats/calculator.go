@@ -1,22 +1,32 @@
 package stats
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
 
 	"github.com/hzerrad/cronkit/internal/crontab"
 	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/parallel"
 )
 
 // ReferenceDate is a fixed date used for consistent calculations
 // Using 2025-01-01 00:00:00 UTC as a reference point
 var ReferenceDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 
+// localReference returns ReferenceDate's date and time in local time, the
+// zone of jobs without CRON_TZ= or TZ=, so that jobs under other zones fall
+// on the hours of the day they run at locally
+func localReference() time.Time {
+	return time.Date(ReferenceDate.Year(), ReferenceDate.Month(), ReferenceDate.Day(), 0, 0, 0, 0, time.Local)
+}
+
 // Calculator calculates statistics for crontab jobs
 type Calculator struct {
 	scheduler cronx.Scheduler
 	parser    cronx.Parser
+	ctx       context.Context // Nil: never ends
 }
 
 // NewCalculator creates a new statistics calculator
@@ -27,6 +37,24 @@ func NewCalculator() *Calculator {
 	}
 }
 
+// NewCalculatorWithContext creates a statistics calculator that stops
+// computing runs once ctx ends. CalculateMetrics and FindSlots then return the
+// cause of its end; the results of other methods are incomplete.
+func NewCalculatorWithContext(ctx context.Context) *Calculator {
+	c := NewCalculator()
+	c.scheduler = cronx.WithContext(ctx, c.scheduler)
+	c.ctx = ctx
+	return c
+}
+
+// ended returns the cause of the end of the calculator's context, or nil
+func (c *Calculator) ended() error {
+	if c.ctx == nil || c.ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(c.ctx)
+}
+
 // CalculateMetrics calculates comprehensive metrics for a set of jobs
 func (c *Calculator) CalculateMetrics(jobs []*crontab.Job, timeWindow time.Duration) (*Metrics, error) {
 	metrics := &Metrics{
@@ -36,35 +64,29 @@ func (c *Calculator) CalculateMetrics(jobs []*crontab.Job, timeWindow time.Durat
 	}
 
 	// Calculate per-job frequencies
-	for _, job := range jobs {
-		if !job.Valid {
-			continue
-		}
-
-		jobID := fmt.Sprintf("line-%d", job.LineNumber)
-		if job.LineNumber == 0 {
-			jobID = job.Expression
-		}
-
-		runsPerDay, runsPerHour := c.calculateJobFrequency(job.Expression)
-		metrics.JobFrequencies = append(metrics.JobFrequencies, JobFrequency{
-			JobID:       jobID,
-			Expression:  job.Expression,
-			RunsPerDay:  runsPerDay,
-			RunsPerHour: runsPerHour,
-		})
-
-		metrics.TotalRunsPerDay += runsPerDay
-		metrics.TotalRunsPerHour += runsPerHour
+	metrics.JobFrequencies = c.jobFrequencies(jobs)
+	for _, freq := range metrics.JobFrequencies {
+		metrics.TotalRunsPerDay += freq.RunsPerDay
+		metrics.TotalRunsPerHour += freq.RunsPerHour
 	}
 
+	// The hour histogram and the collisions cover the runs from the same
+	// start, so that they agree on the hours of jobs under CRON_TZ=
+	startTime := time.Now().Truncate(time.Minute)
+
 	// Calculate hour histogram
-	c.calculateHourHistogram(jobs, metrics)
+	c.calculateHourHistogram(jobs, startTime, metrics)
 
 	// Calculate collisions
-	collisions := c.CalculateCollisions(jobs, timeWindow)
+	collisions := c.calculateCollisions(jobs, startTime, timeWindow)
 	metrics.Collisions = collisions
 
+	// Calculate field value distribution
+	metrics.Fields = c.CalculateFieldStats(jobs)
+
+	if err := c.ended(); err != nil {
+		return nil, err
+	}
 	return metrics, nil
 }
 
@@ -122,58 +144,131 @@ func (c *Calculator) countRunsInWindow(expression string, startTime, endTime tim
 	return count
 }
 
-// calculateHourHistogram calculates the distribution of runs across hours
-func (c *Calculator) calculateHourHistogram(jobs []*crontab.Job, metrics *Metrics) {
-	startTime := ReferenceDate
+// jobStart returns startTime in the time zone a job is scheduled in, set by
+// a CRON_TZ= or TZ= line, so that its runs are computed in that zone. Jobs
+// without one, or with an unknown one, run in startTime's zone.
+func jobStart(job *crontab.Job, startTime time.Time) time.Time {
+	loc, err := job.Location(startTime.Location())
+	if err != nil {
+		return startTime
+	}
+	return startTime.In(loc)
+}
+
+// forEachRun calls fn with each run of a job in [startTime, endTime), in
+// startTime's zone, querying the scheduler in batches of a day of
+// every-minute runs
+func (c *Calculator) forEachRun(job *crontab.Job, startTime, endTime time.Time, fn func(t time.Time)) {
+	forEachRun(c.scheduler, job, startTime, endTime, fn)
+}
+
+// forEachRun calls fn with each run of a job in [startTime, endTime), as
+// computed by scheduler
+func forEachRun(scheduler cronx.Scheduler, job *crontab.Job, startTime, endTime time.Time, fn func(t time.Time)) {
+	query := jobStart(job, startTime).Add(-time.Second)
+	for query.Before(endTime) {
+		times, err := scheduler.Next(job.Expression, query, MaxRunsPerDay)
+		if err != nil || len(times) == 0 || !times[len(times)-1].After(query) {
+			return
+		}
+		for _, t := range times {
+			if !t.Before(endTime) {
+				return
+			}
+			fn(t.In(startTime.Location()))
+		}
+		query = times[len(times)-1]
+	}
+}
+
+// RunningMinutes returns the minutes of [startTime, endTime) in which a job
+// runs, in startTime's zone. Runs are computed by scheduler in the job's time
+// zone, set by a CRON_TZ= or TZ= line, and last the job's Duration (or their
+// start minute).
+func RunningMinutes(scheduler cronx.Scheduler, job *crontab.Job, startTime, endTime time.Time) map[time.Time]bool {
+	occupied := make(map[time.Time]bool)
+	forEachRun(scheduler, job, startTime, endTime, func(t time.Time) {
+		runStart := t.Truncate(time.Minute)
+		runEnd := t.Add(job.Duration)
+		for minute := runStart; minute.Equal(runStart) || minute.Before(runEnd); minute = minute.Add(time.Minute) {
+			if !minute.Before(endTime) {
+				break
+			}
+			occupied[minute] = true
+		}
+	})
+	return occupied
+}
+
+// calculateHourHistogram calculates the distribution of the runs of the day
+// from startTime across the hours of startTime's zone, computing the jobs in
+// parallel
+func (c *Calculator) calculateHourHistogram(jobs []*crontab.Job, startTime time.Time, metrics *Metrics) {
 	endTime := startTime.Add(OneDay)
 
 	// Use optimized count: worst case is every minute
 	maxRuns := MaxRunsPerDay
 
-	for _, job := range jobs {
+	histograms := parallel.Map(len(jobs), func(i int) []int {
+		job := jobs[i]
 		if !job.Valid {
-			continue
+			return nil
 		}
 
-		times, err := c.scheduler.Next(job.Expression, startTime, maxRuns)
+		times, err := c.scheduler.Next(job.Expression, jobStart(job, startTime), maxRuns)
 		if err != nil {
-			continue
+			return nil
 		}
 
+		histogram := make([]int, HoursInDay)
 		for _, t := range times {
 			if t.After(endTime) || t.Equal(endTime) {
 				break
 			}
 			if !t.Before(startTime) {
-				hour := t.Hour()
-				metrics.HourHistogram[hour]++
+				histogram[t.In(startTime.Location()).Hour()]++
 			}
 		}
+		return histogram
+	})
+
+	for _, histogram := range histograms {
+		for hour, count := range histogram {
+			metrics.HourHistogram[hour] += count
+		}
 	}
 }
 
-// IdentifyMostFrequent returns the top N most frequent jobs
-func (c *Calculator) IdentifyMostFrequent(jobs []*crontab.Job, topN int) []JobFrequency {
-	frequencies := make([]JobFrequency, 0, len(jobs))
-
+// jobFrequencies calculates the frequency of each valid job, in crontab
+// order, computing the jobs in parallel
+func (c *Calculator) jobFrequencies(jobs []*crontab.Job) []JobFrequency {
+	valid := make([]*crontab.Job, 0, len(jobs))
 	for _, job := range jobs {
-		if !job.Valid {
-			continue
+		if job.Valid {
+			valid = append(valid, job)
 		}
+	}
 
+	return parallel.Map(len(valid), func(i int) JobFrequency {
+		job := valid[i]
 		jobID := fmt.Sprintf("line-%d", job.LineNumber)
 		if job.LineNumber == 0 {
 			jobID = job.Expression
 		}
 
 		runsPerDay, runsPerHour := c.calculateJobFrequency(job.Expression)
-		frequencies = append(frequencies, JobFrequency{
+		return JobFrequency{
 			JobID:       jobID,
 			Expression:  job.Expression,
 			RunsPerDay:  runsPerDay,
 			RunsPerHour: runsPerHour,
-		})
-	}
+		}
+	})
+}
+
+// IdentifyMostFrequent returns the top N most frequent jobs
+func (c *Calculator) IdentifyMostFrequent(jobs []*crontab.Job, topN int) []JobFrequency {
+	frequencies := c.jobFrequencies(jobs)
 
 	// Sort by runs per day (descending)
 	sort.Slice(frequencies, func(i, j int) bool {
@@ -203,8 +298,17 @@ func (c *Calculator) IdentifyLeastFrequent(jobs []*crontab.Job, topN int) []JobF
 	return frequencies
 }
 
-// CalculateCollisions calculates collision statistics
+// CalculateCollisions calculates collision statistics. Runs last their job's
+// Duration (or their start minute), so a long job collides with the jobs that
+// start while it runs. Jobs under CRON_TZ= or TZ= run in that zone; busiest
+// hours are hours of local time.
 func (c *Calculator) CalculateCollisions(jobs []*crontab.Job, timeWindow time.Duration) CollisionStats {
+	return c.calculateCollisions(jobs, time.Now().Truncate(time.Minute), timeWindow)
+}
+
+// calculateCollisions calculates the collision statistics of the runs in
+// [startTime, startTime+timeWindow), with busiest hours in startTime's zone
+func (c *Calculator) calculateCollisions(jobs []*crontab.Job, startTime time.Time, timeWindow time.Duration) CollisionStats {
 	stats := CollisionStats{
 		BusiestHours:       []HourStats{},
 		QuietWindows:       []TimeWindow{},
@@ -212,45 +316,70 @@ func (c *Calculator) CalculateCollisions(jobs []*crontab.Job, timeWindow time.Du
 		MaxConcurrent:      0,
 	}
 
-	// Use overlap analysis from check package
-	// For now, simplified implementation
-	startTime := time.Now().Truncate(time.Minute)
 	endTime := startTime.Add(timeWindow)
 
-	// Group runs by minute
+	// Group runs by minute, and count the jobs running in each minute
 	minuteRuns := make(map[time.Time]int)
+	minuteJobs := make(map[time.Time]int)
 	// Estimate max runs based on time window (worst case: every minute)
 	maxRuns := int(timeWindow.Minutes()) + 1
 	if maxRuns > MaxRunsForLongWindow {
 		maxRuns = MaxRunsForLongWindow // Cap at reasonable maximum
 	}
 
-	for _, job := range jobs {
+	// Runs of each job, computed in parallel
+	type jobRuns struct {
+		starts   []time.Time        // Minutes runs start in
+		occupied map[time.Time]bool // Minutes the job runs in
+	}
+	runs := parallel.Map(len(jobs), func(i int) jobRuns {
+		job := jobs[i]
 		if !job.Valid {
-			continue
+			return jobRuns{}
 		}
 
-		times, err := c.scheduler.Next(job.Expression, startTime, maxRuns)
+		times, err := c.scheduler.Next(job.Expression, jobStart(job, startTime), maxRuns)
 		if err != nil {
-			continue
+			return jobRuns{}
 		}
 
+		// Runs occupy every minute until they finish, per the job's duration
+		r := jobRuns{occupied: make(map[time.Time]bool)}
 		for _, t := range times {
 			if t.After(endTime) || t.Equal(endTime) {
 				break
 			}
-			if !t.Before(startTime) {
-				minute := t.Truncate(time.Minute)
-				minuteRuns[minute]++
+			if t.Before(startTime) {
+				continue
+			}
+			start := t.In(startTime.Location()).Truncate(time.Minute)
+			r.starts = append(r.starts, start)
+			end := t.Add(job.Duration)
+			for minute := start; minute.Equal(start) || minute.Before(end); minute = minute.Add(time.Minute) {
+				if !minute.Before(endTime) {
+					break
+				}
+				r.occupied[minute] = true
 			}
 		}
+		return r
+	})
+
+	for _, r := range runs {
+		for _, start := range r.starts {
+			minuteRuns[start]++
+		}
+		for minute := range r.occupied {
+			minuteJobs[minute]++
+		}
 	}
 
 	// Calculate busiest hours
 	hourRuns := make(map[int]int)
 	for minute, count := range minuteRuns {
-		hour := minute.Hour()
-		hourRuns[hour] += count
+		hourRuns[minute.Hour()] += count
+	}
+	for _, count := range minuteJobs {
 		if count > stats.MaxConcurrent {
 			stats.MaxConcurrent = count
 		}
@@ -272,7 +401,7 @@ func (c *Calculator) CalculateCollisions(jobs []*crontab.Job, timeWindow time.Du
 	// Calculate collision frequency
 	totalMinutes := int(timeWindow.Minutes())
 	collisionMinutes := 0
-	for _, count := range minuteRuns {
+	for _, count := range minuteJobs {
 		if count > 1 {
 			collisionMinutes++
 		}
@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/testutil"
+)
+
+// Jobs are computed on up to GOMAXPROCS workers; compare with
+// go test -bench=1kJobs -cpu 1,4 ./internal/stats
+
+// benchmarkJobs parses a generated crontab of n jobs
+func benchmarkJobs(b *testing.B, n int) []*crontab.Job {
+	b.Helper()
+	entries, err := crontab.ParseReader(strings.NewReader(testutil.GenerateCrontab(n)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	jobs, _ := crontab.Partition(entries)
+	return jobs
+}
+
+func BenchmarkCalculateMetrics_1kJobs(b *testing.B) {
+	calc := NewCalculator()
+	jobs := benchmarkJobs(b, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = calc.CalculateMetrics(jobs, OneDay)
+	}
+}
+
+func BenchmarkCalculateHeatmap_1kJobs(b *testing.B) {
+	calc := NewCalculator()
+	jobs := benchmarkJobs(b, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = calc.CalculateHeatmap(jobs)
+	}
+}
+
+func BenchmarkFindSlots_1kJobs(b *testing.B) {
+	calc := NewCalculator()
+	jobs := benchmarkJobs(b, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = calc.FindSlots(jobs, SlotOptions{Duration: OneHour})
+	}
+}
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -14,6 +15,22 @@ func TestNewCalculator(t *testing.T) {
 	assert.NotNil(t, calc)
 }
 
+func TestNewCalculatorWithContext(t *testing.T) {
+	jobs := []*crontab.Job{{LineNumber: 1, Expression: "* * * * *", Valid: true}}
+	ctx, cancel := context.WithCancel(context.Background())
+	calc := NewCalculatorWithContext(ctx)
+
+	metrics, err := calc.CalculateMetrics(jobs, time.Hour)
+	require.NoError(t, err)
+	assert.Positive(t, metrics.TotalRunsPerDay)
+
+	cancel()
+	_, err = calc.CalculateMetrics(jobs, time.Hour)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = calc.FindSlots(jobs, SlotOptions{Duration: time.Minute, WithinEnd: MinutesPerDay})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestCalculateMetrics(t *testing.T) {
 	calc := NewCalculator()
 
@@ -153,6 +170,76 @@ func TestCalculateCollisions(t *testing.T) {
 		// May or may not have collisions depending on window
 		assert.GreaterOrEqual(t, stats.MaxConcurrent, 0)
 	})
+
+	t.Run("should detect collisions with running jobs", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 1, Expression: "0 * * * *", Valid: true, Duration: 45 * time.Minute},
+			{LineNumber: 2, Expression: "30 * * * *", Valid: true},
+		}
+
+		stats := calc.CalculateCollisions(jobs, 24*time.Hour)
+		assert.Equal(t, 2, stats.MaxConcurrent)
+		assert.Greater(t, stats.CollisionFrequency, 0.0)
+		for _, hour := range stats.BusiestHours {
+			assert.LessOrEqual(t, hour.RunCount, 2, "durations do not add runs")
+		}
+	})
+}
+
+func TestCalculateMetrics_Timezones(t *testing.T) {
+	calc := NewCalculator()
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	t.Run("should count runs in the hour of the local time", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 2, Expression: "30 9 * * *", Valid: true, Timezone: "Asia/Tokyo"},
+		}
+
+		metrics, err := calc.CalculateMetrics(jobs, 24*time.Hour)
+		require.NoError(t, err)
+		hour := time.Date(2025, 1, 1, 9, 30, 0, 0, tokyo).In(time.Local).Hour()
+		assert.Equal(t, 1, metrics.HourHistogram[hour])
+	})
+
+	t.Run("should detect collisions across zones", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 2, Expression: "0 9 * * *", Valid: true, Timezone: "Asia/Tokyo"},
+			{LineNumber: 4, Expression: "0 0 * * *", Valid: true, Timezone: "UTC"},
+		}
+
+		stats := calc.CalculateCollisions(jobs, 24*time.Hour)
+		assert.Equal(t, 2, stats.MaxConcurrent)
+	})
+
+	t.Run("should place zoned runs in the same hour in the histogram and busiest hours", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		jobs := []*crontab.Job{
+			{LineNumber: 2, Expression: "0 9 * * *", Valid: true, Timezone: "America/New_York"},
+		}
+
+		// In summer, New York is an hour closer to UTC than on ReferenceDate
+		startTime := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+		metrics := &Metrics{HourHistogram: make([]int, HoursInDay)}
+		calc.calculateHourHistogram(jobs, startTime, metrics)
+		stats := calc.calculateCollisions(jobs, startTime, 24*time.Hour)
+
+		hour := time.Date(2025, 7, 1, 9, 0, 0, 0, newYork).UTC().Hour()
+		assert.Equal(t, 1, metrics.HourHistogram[hour])
+		require.Len(t, stats.BusiestHours, 1)
+		assert.Equal(t, hour, stats.BusiestHours[0].Hour)
+	})
+
+	t.Run("should fall back to local time for unknown zones", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 1, Expression: "0 9 * * *", Valid: true, Timezone: "Nowhere/Invalid"},
+		}
+
+		metrics, err := calc.CalculateMetrics(jobs, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, metrics.HourHistogram[9])
+	})
 }
 
 func TestIdentifyBusiestHours(t *testing.T) {
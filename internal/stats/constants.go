@@ -28,6 +28,8 @@ const (
 	MaxRunsPerDay = MinutesPerDay
 	// MaxRunsForLongWindow is the cap for very long time windows
 	MaxRunsForLongWindow = 10000
+	// MaxWindow is the longest collision analysis window: a week
+	MaxWindow = 7 * OneDay
 )
 
 // Histogram constants
@@ -0,0 +1,177 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// Field names used in field value statistics
+const (
+	FieldMinute    = "minute"
+	FieldHour      = "hour"
+	FieldDayOfWeek = "day-of-week"
+)
+
+// ValueUsage counts the jobs pinned to a single field value
+type ValueUsage struct {
+	Value   int
+	Jobs    int
+	Percent float64 // Share of analyzed jobs using this value (0-100)
+}
+
+// FieldDistribution describes which values of one cron field are used across jobs.
+// Only restricted fields count: a job whose field is a wildcard is tallied in
+// Unrestricted, since it does not pile up on any particular value.
+type FieldDistribution struct {
+	Field        string
+	Values       []ValueUsage // Values used by at least one job, most used first
+	Unrestricted int          // Jobs where the field is a wildcard
+}
+
+// FieldStats contains field value distributions across all valid jobs
+type FieldStats struct {
+	Jobs      int
+	Minute    FieldDistribution
+	Hour      FieldDistribution
+	DayOfWeek FieldDistribution
+}
+
+// CalculateFieldStats tallies minute, hour and day-of-week values across jobs
+// to quantify thundering-herd risk (e.g. most jobs firing at minute 0)
+func (c *Calculator) CalculateFieldStats(jobs []*crontab.Job) FieldStats {
+	minutes := make(map[int]int)
+	hours := make(map[int]int)
+	days := make(map[int]int)
+	fs := FieldStats{
+		Minute:    FieldDistribution{Field: FieldMinute},
+		Hour:      FieldDistribution{Field: FieldHour},
+		DayOfWeek: FieldDistribution{Field: FieldDayOfWeek},
+	}
+
+	for _, job := range jobs {
+		if !job.Valid {
+			continue
+		}
+		schedule, err := c.parser.Parse(job.Expression)
+		if err != nil {
+			continue
+		}
+		fs.Jobs++
+
+		tallyField(schedule.Minute, minutes, &fs.Minute)
+		tallyField(schedule.Hour, hours, &fs.Hour)
+		tallyField(schedule.DayOfWeek, days, &fs.DayOfWeek)
+	}
+
+	fs.Minute.Values = toValueUsage(minutes, fs.Jobs)
+	fs.Hour.Values = toValueUsage(hours, fs.Jobs)
+	fs.DayOfWeek.Values = toValueUsage(days, fs.Jobs)
+
+	return fs
+}
+
+// tallyField adds one job's field values to counts
+func tallyField(field cronx.Field, counts map[int]int, dist *FieldDistribution) {
+	if raw := field.Raw(); raw == "*" || raw == "?" {
+		dist.Unrestricted++
+		return
+	}
+	for _, v := range field.Values() {
+		counts[v]++
+	}
+}
+
+// toValueUsage converts counts to a slice sorted by job count (descending), then value
+func toValueUsage(counts map[int]int, total int) []ValueUsage {
+	usage := make([]ValueUsage, 0, len(counts))
+	for value, jobs := range counts {
+		usage = append(usage, ValueUsage{
+			Value:   value,
+			Jobs:    jobs,
+			Percent: float64(jobs) / float64(total) * 100.0,
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Jobs != usage[j].Jobs {
+			return usage[i].Jobs > usage[j].Jobs
+		}
+		return usage[i].Value < usage[j].Value
+	})
+	return usage
+}
+
+// Top returns the n most used values (all values if n <= 0)
+func (d FieldDistribution) Top(n int) []ValueUsage {
+	if n > 0 && n < len(d.Values) {
+		return d.Values[:n]
+	}
+	return d.Values
+}
+
+// Summary describes the most used value, e.g. "62% of jobs run at minute 0".
+// It returns an empty string when no job restricts the field.
+func (d FieldDistribution) Summary() string {
+	if len(d.Values) == 0 {
+		return ""
+	}
+	top := d.Values[0]
+	return fmt.Sprintf("%.0f%% of jobs run %s", top.Percent, FormatFieldValue(d.Field, top.Value))
+}
+
+// FormatFieldValue formats a field value for display (e.g. "at minute 0", "on Monday")
+func FormatFieldValue(field string, value int) string {
+	switch field {
+	case FieldMinute:
+		return fmt.Sprintf("at minute %d", value)
+	case FieldHour:
+		return fmt.Sprintf("during hour %02d", value)
+	case FieldDayOfWeek:
+		return fmt.Sprintf("on %s", time.Weekday(value%7))
+	default:
+		return fmt.Sprintf("at %s %d", field, value)
+	}
+}
+
+// GenerateFieldTable renders the top n values of each field as a text table with bars
+func GenerateFieldTable(fs FieldStats, n, width int) string {
+	if fs.Jobs == 0 {
+		return "No jobs to analyze"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Field Value Distribution:\n")
+	sb.WriteString(strings.Repeat("=", width+20) + "\n")
+
+	for _, dist := range []FieldDistribution{fs.Minute, fs.Hour, fs.DayOfWeek} {
+		sb.WriteString(fmt.Sprintf("\n%s (%d of %d jobs restricted):\n", dist.Field, fs.Jobs-dist.Unrestricted, fs.Jobs))
+		if len(dist.Values) == 0 {
+			sb.WriteString("  (no restricted values)\n")
+			continue
+		}
+		for _, u := range dist.Top(n) {
+			bar := strings.Repeat("█", int(u.Percent/100*float64(width)))
+			sb.WriteString(fmt.Sprintf("  %-6s │%s %d (%.0f%%)\n", FieldValueLabel(dist.Field, u.Value), bar, u.Jobs, u.Percent))
+		}
+	}
+
+	return sb.String()
+}
+
+// FieldValueLabel returns a short table label for a field value (e.g. ":00", "02:00", "Mon")
+func FieldValueLabel(field string, value int) string {
+	switch field {
+	case FieldMinute:
+		return fmt.Sprintf(":%02d", value)
+	case FieldHour:
+		return fmt.Sprintf("%02d:00", value)
+	case FieldDayOfWeek:
+		return time.Weekday(value % 7).String()[:3]
+	default:
+		return fmt.Sprintf("%d", value)
+	}
+}
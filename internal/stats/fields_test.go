@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFieldStats(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("should tally restricted field values", func(t *testing.T) {
+		jobs := []*crontab.Job{
+			{LineNumber: 1, Expression: "0 2 * * *", Valid: true},
+			{LineNumber: 2, Expression: "0 * * * *", Valid: true},
+			{LineNumber: 3, Expression: "*/30 * * * *", Valid: true},
+			{LineNumber: 4, Expression: "15 2 * * 1", Valid: true},
+			{LineNumber: 5, Expression: "@daily", Valid: true},
+			{LineNumber: 6, Expression: "invalid", Valid: false},
+		}
+
+		fs := calc.CalculateFieldStats(jobs)
+		assert.Equal(t, 5, fs.Jobs)
+
+		require.NotEmpty(t, fs.Minute.Values)
+		assert.Equal(t, ValueUsage{Value: 0, Jobs: 4, Percent: 80}, fs.Minute.Values[0])
+		assert.Equal(t, 0, fs.Minute.Unrestricted)
+
+		require.NotEmpty(t, fs.Hour.Values)
+		assert.Equal(t, 2, fs.Hour.Values[0].Value)
+		assert.Equal(t, 2, fs.Hour.Values[0].Jobs)
+		assert.Equal(t, 2, fs.Hour.Unrestricted)
+
+		require.Len(t, fs.DayOfWeek.Values, 1)
+		assert.Equal(t, 1, fs.DayOfWeek.Values[0].Value)
+		assert.Equal(t, 4, fs.DayOfWeek.Unrestricted)
+	})
+
+	t.Run("should handle no jobs", func(t *testing.T) {
+		fs := calc.CalculateFieldStats(nil)
+		assert.Equal(t, 0, fs.Jobs)
+		assert.Empty(t, fs.Minute.Values)
+		assert.Equal(t, "No jobs to analyze", GenerateFieldTable(fs, 5, 20))
+	})
+
+	t.Run("should be included in metrics", func(t *testing.T) {
+		jobs := []*crontab.Job{{LineNumber: 1, Expression: "0 0 * * *", Valid: true}}
+		metrics, err := calc.CalculateMetrics(jobs, OneDay)
+		require.NoError(t, err)
+		assert.Equal(t, 1, metrics.Fields.Jobs)
+	})
+}
+
+func TestFieldDistribution(t *testing.T) {
+	dist := FieldDistribution{
+		Field: FieldMinute,
+		Values: []ValueUsage{
+			{Value: 0, Jobs: 5, Percent: 62.5},
+			{Value: 30, Jobs: 2, Percent: 25},
+			{Value: 15, Jobs: 1, Percent: 12.5},
+		},
+	}
+
+	t.Run("Top", func(t *testing.T) {
+		assert.Len(t, dist.Top(2), 2)
+		assert.Len(t, dist.Top(0), 3)
+		assert.Len(t, dist.Top(10), 3)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		assert.Equal(t, "62% of jobs run at minute 0", dist.Summary())
+		assert.Empty(t, FieldDistribution{Field: FieldHour}.Summary())
+	})
+}
+
+func TestFormatFieldValue(t *testing.T) {
+	assert.Equal(t, "at minute 5", FormatFieldValue(FieldMinute, 5))
+	assert.Equal(t, "during hour 02", FormatFieldValue(FieldHour, 2))
+	assert.Equal(t, "on Friday", FormatFieldValue(FieldDayOfWeek, 5))
+	assert.Equal(t, ":05", FieldValueLabel(FieldMinute, 5))
+	assert.Equal(t, "14:00", FieldValueLabel(FieldHour, 14))
+	assert.Equal(t, "Sun", FieldValueLabel(FieldDayOfWeek, 0))
+}
+
+func TestGenerateFieldTable(t *testing.T) {
+	calc := NewCalculator()
+	fs := calc.CalculateFieldStats([]*crontab.Job{
+		{LineNumber: 1, Expression: "0 3 * * *", Valid: true},
+		{LineNumber: 2, Expression: "0 * * * *", Valid: true},
+	})
+
+	table := GenerateFieldTable(fs, 5, 20)
+	assert.Contains(t, table, "Field Value Distribution")
+	assert.Contains(t, table, "minute (2 of 2 jobs restricted)")
+	assert.Contains(t, table, ":00")
+	assert.Contains(t, table, "2 (100%)")
+	assert.Contains(t, table, "03:00")
+	assert.Contains(t, table, "(no restricted values)")
+}
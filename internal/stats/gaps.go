@@ -0,0 +1,162 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+const (
+	// DefaultGapRatio is how many times longer than the shortest gap the
+	// longest gap may be before a schedule is flagged as irregular
+	DefaultGapRatio = 1.5
+	// MaxGapRuns caps the runs AnalyzeGaps examines within a horizon
+	MaxGapRuns = 100000
+	// gapBatch is how many runs are requested from the scheduler at a time
+	gapBatch = 1000
+)
+
+// GapOptions configures AnalyzeGaps
+type GapOptions struct {
+	From     time.Time     // Start of the horizon (default: ReferenceDate)
+	Horizon  time.Duration // Length of the horizon (default: one year)
+	MaxRatio float64       // Longest to shortest gap ratio above which gaps are irregular (default: DefaultGapRatio)
+}
+
+// Gap is the time between two consecutive runs
+type Gap struct {
+	Start time.Time // Earlier run
+	End   time.Time // Later run
+}
+
+// Duration returns the length of the gap
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// GapReport describes the intervals between consecutive runs of a schedule
+// within a horizon
+type GapReport struct {
+	Expression string
+	From       time.Time
+	Until      time.Time // End of the horizon, or the last run examined when Truncated
+	Runs       int       // Runs within the horizon
+	Shortest   Gap       // Zero with fewer than two runs
+	Longest    Gap       // Zero with fewer than two runs
+	Mean       time.Duration
+	Ratio      float64 // Longest over shortest gap, 0 with fewer than two runs
+	Irregular  bool    // Ratio is above the MaxRatio option
+	Sparse     bool    // Fewer than two runs, so no gap could be measured
+	Truncated  bool    // MaxGapRuns was reached before the end of the horizon
+}
+
+// AnalyzeGaps computes the shortest, longest and mean intervals between
+// consecutive runs of an expression within a horizon. Schedules whose gaps
+// vary widely, such as "0 0 31 * *" (31 or 61 days), are flagged as
+// irregular, and schedules that run less than twice, such as "0 0 29 2 *",
+// as sparse.
+func AnalyzeGaps(scheduler cronx.Scheduler, expression string, opts GapOptions) (*GapReport, error) {
+	if opts.From.IsZero() {
+		opts.From = ReferenceDate
+	}
+	if opts.Horizon <= 0 {
+		opts.Horizon = 365 * OneDay
+	}
+	if opts.MaxRatio <= 0 {
+		opts.MaxRatio = DefaultGapRatio
+	}
+
+	report := &GapReport{
+		Expression: expression,
+		From:       opts.From,
+		Until:      opts.From.Add(opts.Horizon),
+	}
+
+	var first, prev time.Time
+	query := opts.From.Add(-time.Second) // Runs at From itself count
+	for {
+		times, err := scheduler.Next(expression, query, gapBatch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate runs: %w", err)
+		}
+
+		done := false
+		for _, t := range times {
+			if t.IsZero() || t.After(report.Until) {
+				done = true
+				break
+			}
+			if report.Runs == MaxGapRuns {
+				report.Truncated = true
+				report.Until = prev
+				done = true
+				break
+			}
+			if report.Runs == 0 {
+				first = t
+			} else {
+				gap := Gap{Start: prev, End: t}
+				if report.Runs == 1 || gap.Duration() < report.Shortest.Duration() {
+					report.Shortest = gap
+				}
+				if gap.Duration() > report.Longest.Duration() {
+					report.Longest = gap
+				}
+			}
+			prev = t
+			report.Runs++
+		}
+		// Stop once the horizon is covered or the scheduler made no progress
+		if done || len(times) == 0 || !times[len(times)-1].After(query) {
+			break
+		}
+		query = times[len(times)-1]
+	}
+
+	if report.Runs < 2 {
+		report.Sparse = true
+		return report, nil
+	}
+	report.Mean = prev.Sub(first) / time.Duration(report.Runs-1)
+	if shortest := report.Shortest.Duration(); shortest > 0 {
+		report.Ratio = float64(report.Longest.Duration()) / float64(shortest)
+	}
+	report.Irregular = report.Ratio > opts.MaxRatio
+	return report, nil
+}
+
+// FormatGap formats a gap with its two largest units, e.g. "45m", "1d 6h" or
+// "4y 1d". Years count as 365 days.
+func FormatGap(d time.Duration) string {
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"y", 365 * OneDay},
+		{"d", OneDay},
+		{"h", OneHour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	d = d.Round(time.Second)
+	var parts []string
+	for _, u := range units {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.suffix))
+			d -= n * u.size
+		} else if len(parts) > 0 {
+			// Only adjacent units are shown ("1d 5m" would hide the hours)
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return "0s"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeGaps(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	scheduler := cronx.NewScheduler()
+
+	t.Run("regular schedule", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "0 */6 * * *", GapOptions{From: from, Horizon: 2 * OneDay})
+		require.NoError(t, err)
+		assert.Equal(t, 9, report.Runs, "runs at both ends of the horizon count")
+		assert.Equal(t, 6*time.Hour, report.Shortest.Duration())
+		assert.Equal(t, 6*time.Hour, report.Longest.Duration())
+		assert.Equal(t, 6*time.Hour, report.Mean)
+		assert.Equal(t, 1.0, report.Ratio)
+		assert.False(t, report.Irregular)
+		assert.False(t, report.Sparse)
+	})
+
+	t.Run("step that does not divide the hour", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "*/45 * * * *", GapOptions{From: from, Horizon: OneDay})
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, report.Shortest.Duration())
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 45, 0, 0, time.UTC), report.Shortest.Start)
+		assert.Equal(t, 45*time.Minute, report.Longest.Duration())
+		assert.Equal(t, 3.0, report.Ratio)
+		assert.True(t, report.Irregular)
+	})
+
+	t.Run("day of month missing from some months", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "0 0 31 * *", GapOptions{From: from})
+		require.NoError(t, err)
+		assert.Equal(t, 7, report.Runs)
+		assert.Equal(t, 31*OneDay, report.Shortest.Duration())
+		assert.Equal(t, 61*OneDay, report.Longest.Duration())
+		assert.True(t, report.Irregular)
+	})
+
+	t.Run("max ratio", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "0 9 * * 1-5", GapOptions{From: from, Horizon: 14 * OneDay, MaxRatio: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, report.Ratio)
+		assert.False(t, report.Irregular)
+	})
+
+	t.Run("sparse schedule", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "0 0 29 2 *", GapOptions{From: from})
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.Runs)
+		assert.True(t, report.Sparse)
+		assert.Zero(t, report.Ratio)
+	})
+
+	t.Run("leap days over a decade", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "0 0 29 2 *", GapOptions{From: from, Horizon: 10 * 365 * OneDay})
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Runs)
+		assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), report.Longest.Start)
+		assert.False(t, report.Sparse)
+	})
+
+	t.Run("run cap", func(t *testing.T) {
+		report, err := AnalyzeGaps(scheduler, "* * * * *", GapOptions{From: from})
+		require.NoError(t, err)
+		assert.Equal(t, MaxGapRuns, report.Runs)
+		assert.True(t, report.Truncated)
+		assert.Equal(t, from.Add((MaxGapRuns-1)*time.Minute), report.Until)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := AnalyzeGaps(scheduler, "invalid", GapOptions{From: from})
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatGap(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{30 * time.Second, "30s"},
+		{45 * time.Minute, "45m"},
+		{90 * time.Minute, "1h 30m"},
+		{OneDay + 6*time.Hour, "1d 6h"},
+		{OneDay + 5*time.Minute, "1d"},
+		{61 * OneDay, "61d"},
+		{1461 * OneDay, "4y 1d"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatGap(tt.d), tt.d.String())
+	}
+}
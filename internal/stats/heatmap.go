@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/parallel"
+)
+
+// DaysPerWeek is the number of days in a week (for heatmap rows)
+const DaysPerWeek = 7
+
+// heatmapShades are the density characters of heatmap cells, from an empty
+// cell to the busiest quarter
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// Heatmap counts the runs of a week by weekday (index = time.Weekday,
+// Sunday first) and hour
+type Heatmap [DaysPerWeek][HoursInDay]int
+
+// CalculateHeatmap counts the runs of the valid jobs over the week starting
+// on ReferenceDate's date by weekday and hour of local time
+func (c *Calculator) CalculateHeatmap(jobs []*crontab.Job) Heatmap {
+	var h Heatmap
+	startTime := localReference()
+	endTime := startTime.Add(DaysPerWeek * OneDay)
+
+	// Each job's runs are counted in parallel, then added up
+	counts := parallel.Map(len(jobs), func(i int) *Heatmap {
+		if !jobs[i].Valid {
+			return nil
+		}
+		var jh Heatmap
+		c.forEachRun(jobs[i], startTime, endTime, func(t time.Time) {
+			jh[t.Weekday()][t.Hour()]++
+		})
+		return &jh
+	})
+	for _, jh := range counts {
+		if jh == nil {
+			continue
+		}
+		for day := range jh {
+			for hour, count := range jh[day] {
+				h[day][hour] += count
+			}
+		}
+	}
+	return h
+}
+
+// Max returns the run count of the busiest cell
+func (h Heatmap) Max() int {
+	maxCount := 0
+	for _, day := range h {
+		for _, count := range day {
+			maxCount = max(maxCount, count)
+		}
+	}
+	return maxCount
+}
+
+// heatmapShade returns the density character of a cell, scaled to the
+// busiest cell
+func heatmapShade(count, maxCount int) string {
+	if count == 0 {
+		return heatmapShades[0]
+	}
+	level := (count*(len(heatmapShades)-1) + maxCount - 1) / maxCount
+	return heatmapShades[level]
+}
+
+// GenerateHeatmap renders the heatmap as a weekday × hour grid of density
+// characters, each row ending with the day's run count
+func GenerateHeatmap(h Heatmap) string {
+	maxCount := h.Max()
+	if maxCount == 0 {
+		return "No runs detected"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Weekly Run Heatmap:\n")
+	sb.WriteString(strings.Repeat("=", 4+3*HoursInDay+8) + "\n")
+	sb.WriteString("    ")
+	for hour := 0; hour < HoursInDay; hour++ {
+		sb.WriteString(fmt.Sprintf(" %02d", hour))
+	}
+	sb.WriteString("\n")
+
+	// Monday first, as weeks are read in schedules
+	for i := 1; i <= DaysPerWeek; i++ {
+		day := time.Weekday(i % DaysPerWeek)
+		total := 0
+		sb.WriteString(day.String()[:3] + " ")
+		for _, count := range h[day] {
+			shade := heatmapShade(count, maxCount)
+			sb.WriteString(" " + shade + shade)
+			total += count
+		}
+		sb.WriteString(fmt.Sprintf("  %d\n", total))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%s none  %s ≤25%%  %s ≤50%%  %s ≤75%%  %s >75%% of the busiest hour (%d runs)\n",
+		heatmapShades[0], heatmapShades[1], heatmapShades[2], heatmapShades[3], heatmapShades[4], maxCount))
+	return sb.String()
+}
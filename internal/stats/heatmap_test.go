@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateHeatmap(t *testing.T) {
+	calc := NewCalculator()
+	jobs := []*crontab.Job{
+		{Expression: "* * * * *", Valid: true},
+		{Expression: "0 9 * * MON-FRI", Valid: true},
+		{Expression: "invalid", Valid: false},
+	}
+
+	h := calc.CalculateHeatmap(jobs)
+	assert.Equal(t, 61, h[time.Monday][9])
+	assert.Equal(t, 60, h[time.Sunday][9])
+	assert.Equal(t, 60, h[time.Friday][23])
+	assert.Equal(t, 61, h.Max())
+
+	total := 0
+	for _, day := range h {
+		for _, count := range day {
+			total += count
+		}
+	}
+	assert.Equal(t, DaysPerWeek*MinutesPerDay+5, total)
+}
+
+func TestGenerateHeatmap(t *testing.T) {
+	t.Run("should render a weekday by hour grid", func(t *testing.T) {
+		var h Heatmap
+		h[time.Monday][2] = 8
+		h[time.Monday][3] = 1
+		h[time.Sunday][23] = 4
+
+		result := GenerateHeatmap(h)
+		lines := strings.Split(result, "\n")
+		require.GreaterOrEqual(t, len(lines), 10)
+		assert.Equal(t, "Weekly Run Heatmap:", lines[0])
+		assert.True(t, strings.HasPrefix(lines[2], "     00 01 02"))
+		assert.True(t, strings.HasPrefix(lines[3], "Mon  ·· ·· ██ ░░ ··"))
+		assert.True(t, strings.HasSuffix(lines[3], "  9"))
+		assert.True(t, strings.HasPrefix(lines[9], "Sun "))
+		assert.True(t, strings.HasSuffix(lines[9], " ▒▒  4"))
+		assert.Contains(t, result, "█ >75% of the busiest hour (8 runs)")
+	})
+
+	t.Run("should handle empty data", func(t *testing.T) {
+		assert.Equal(t, "No runs detected", GenerateHeatmap(Heatmap{}))
+	})
+}
@@ -0,0 +1,215 @@
+package stats
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+)
+
+// DefaultSpreadMinutes is how many minutes from a stacked minute onwards
+// stacked jobs are spread over by default
+const DefaultSpreadMinutes = 30
+
+// JitterOptions configures SuggestJitter
+type JitterOptions struct {
+	MinJobs int // Jobs on the same minute that form a stack (default: 2)
+	Spread  int // Minutes from the stacked minute to spread jobs over (default: DefaultSpreadMinutes)
+}
+
+// JobStack is a group of jobs with the same schedule that start on the same
+// minute
+type JobStack struct {
+	Expression string         // Shared schedule, with aliases expanded (e.g. "0 0 * * *")
+	Jobs       []*crontab.Job // In crontab order
+}
+
+// JitterSuggestion proposes a new schedule for a stacked job
+type JitterSuggestion struct {
+	Job        *crontab.Job
+	Expression string // Proposed expression
+	Offset     int    // Minutes after the original start
+}
+
+// JitterPlan is the result of SuggestJitter
+type JitterPlan struct {
+	Stacks      []JobStack         // Ordered by size, largest first
+	Suggestions []JitterSuggestion // In crontab order
+	Unresolved  int                // Stacked jobs left in place for lack of free minutes
+}
+
+// scheduleFamily groups jobs whose schedules differ only in the minute
+type scheduleFamily struct {
+	rest     string       // Hour, day-of-month, month and day-of-week fields
+	occupied map[int]bool // Minutes used by jobs of the family
+	byMinute map[int][]*crontab.Job
+}
+
+// SuggestJitter finds jobs stacked on the same minute, e.g. a dozen jobs at
+// "0 0 * * *", and proposes minute offsets that spread them out. The first
+// job of a stack keeps its schedule; the others move to evenly spaced minutes
+// within opts.Spread minutes after it, skipping minutes already used by jobs
+// that share the rest of the schedule. Jobs never move into another hour.
+//
+// Only jobs that start on a single minute (e.g. "0", or an alias such as
+// @daily) are considered; jobs with seconds fields, Quartz modifiers or
+// minute lists and steps are left alone.
+func (c *Calculator) SuggestJitter(jobs []*crontab.Job, opts JitterOptions) JitterPlan {
+	if opts.MinJobs < 2 {
+		opts.MinJobs = 2
+	}
+	if opts.Spread <= 0 {
+		opts.Spread = DefaultSpreadMinutes
+	}
+
+	families := make(map[string]*scheduleFamily)
+	var order []string
+	for _, job := range jobs {
+		if !job.Valid {
+			continue
+		}
+		schedule, err := c.parser.Parse(job.Expression)
+		if err != nil || schedule.HasSeconds() || schedule.Quartz != nil || !schedule.Minute.IsSingle() {
+			continue
+		}
+
+		rest := fmt.Sprintf("%s %s %s %s", schedule.Hour.Raw(), schedule.DayOfMonth.Raw(), schedule.Month.Raw(), schedule.DayOfWeek.Raw())
+		key := job.Timezone + "\x00" + rest
+		family, ok := families[key]
+		if !ok {
+			family = &scheduleFamily{rest: rest, occupied: make(map[int]bool), byMinute: make(map[int][]*crontab.Job)}
+			families[key] = family
+			order = append(order, key)
+		}
+		minute := schedule.Minute.Value()
+		family.occupied[minute] = true
+		family.byMinute[minute] = append(family.byMinute[minute], job)
+	}
+
+	plan := JitterPlan{Stacks: []JobStack{}, Suggestions: []JitterSuggestion{}}
+	for _, key := range order {
+		family := families[key]
+		minutes := make([]int, 0, len(family.byMinute))
+		for minute := range family.byMinute {
+			minutes = append(minutes, minute)
+		}
+		sort.Ints(minutes)
+
+		for _, minute := range minutes {
+			stacked := family.byMinute[minute]
+			if len(stacked) < opts.MinJobs {
+				continue
+			}
+			plan.Stacks = append(plan.Stacks, JobStack{
+				Expression: fmt.Sprintf("%d %s", minute, family.rest),
+				Jobs:       stacked,
+			})
+
+			last := min(minute+opts.Spread-1, MinutesPerHour-1)
+			step := float64(last-minute+1) / float64(len(stacked))
+			for i, job := range stacked[1:] {
+				target := minute + int(math.Round(float64(i+1)*step))
+				free, ok := freeMinute(family.occupied, target, minute+1, last)
+				if !ok {
+					plan.Unresolved++
+					continue
+				}
+				family.occupied[free] = true
+				plan.Suggestions = append(plan.Suggestions, JitterSuggestion{
+					Job:        job,
+					Expression: fmt.Sprintf("%d %s", free, family.rest),
+					Offset:     free - minute,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Stacks, func(i, j int) bool {
+		return len(plan.Stacks[i].Jobs) > len(plan.Stacks[j].Jobs)
+	})
+	sort.Slice(plan.Suggestions, func(i, j int) bool {
+		return plan.Suggestions[i].Job.LineNumber < plan.Suggestions[j].Job.LineNumber
+	})
+	return plan
+}
+
+// freeMinute returns the first unoccupied minute at or after target within
+// lo-hi, or failing that the last one before it
+func freeMinute(occupied map[int]bool, target, lo, hi int) (int, bool) {
+	target = max(lo, min(target, hi))
+	for m := target; m <= hi; m++ {
+		if !occupied[m] {
+			return m, true
+		}
+	}
+	for m := target - 1; m >= lo; m-- {
+		if !occupied[m] {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// JitterizeOptions configures Jitterize
+type JitterizeOptions struct {
+	Seed   string // Seed of the offsets, e.g. the host name
+	Spread int    // Minutes from the original minute to pick from (default: MinutesPerHour)
+	Random bool   // Write OpenBSD "a~b" ranges, picked by cron itself, instead of seeded minutes
+}
+
+// Jitterize moves jobs off fixed minutes, for fleets of hosts sharing a
+// crontab: each job moves to a minute within opts.Spread minutes after its
+// own, picked by hashing opts.Seed with the job's schedule and command, so a
+// host always picks the same minutes and different hosts pick different
+// ones. With opts.Random, the minute becomes an OpenBSD "a~b" range instead,
+// which cron picks from when it loads the crontab. Jobs never move into
+// another hour.
+//
+// Like SuggestJitter, only jobs that start on a single minute are moved.
+// Suggestions are in crontab order; jobs whose minute does not change are
+// left out.
+func (c *Calculator) Jitterize(jobs []*crontab.Job, opts JitterizeOptions) []JitterSuggestion {
+	if opts.Spread <= 0 {
+		opts.Spread = MinutesPerHour
+	}
+
+	suggestions := []JitterSuggestion{}
+	for _, job := range jobs {
+		if !job.Valid {
+			continue
+		}
+		schedule, err := c.parser.Parse(job.Expression)
+		if err != nil || schedule.HasSeconds() || schedule.Quartz != nil || schedule.Resolved != "" || !schedule.Minute.IsSingle() {
+			continue
+		}
+
+		minute := schedule.Minute.Value()
+		last := min(minute+opts.Spread-1, MinutesPerHour-1)
+		if last == minute {
+			continue
+		}
+		rest := fmt.Sprintf("%s %s %s %s", schedule.Hour.Raw(), schedule.DayOfMonth.Raw(), schedule.Month.Raw(), schedule.DayOfWeek.Raw())
+		if opts.Random {
+			suggestions = append(suggestions, JitterSuggestion{
+				Job:        job,
+				Expression: fmt.Sprintf("%d~%d %s", minute, last, rest),
+			})
+			continue
+		}
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(opts.Seed + "\x00" + job.Expression + "\x00" + job.Command))
+		offset := int(h.Sum64() % uint64(last-minute+1))
+		if offset == 0 {
+			continue
+		}
+		suggestions = append(suggestions, JitterSuggestion{
+			Job:        job,
+			Expression: fmt.Sprintf("%d %s", minute+offset, rest),
+			Offset:     offset,
+		})
+	}
+	return suggestions
+}
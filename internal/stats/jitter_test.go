@@ -0,0 +1,120 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jitterJobs(expressions ...string) []*crontab.Job {
+	jobs := make([]*crontab.Job, len(expressions))
+	for i, expr := range expressions {
+		jobs[i] = &crontab.Job{LineNumber: i + 1, Expression: expr, Command: "job.sh", Valid: true}
+	}
+	return jobs
+}
+
+func TestSuggestJitter(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("spreads a stack over the window", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *", "0 0 * * *", "@daily", "0 0 * * *")
+		plan := calc.SuggestJitter(jobs, JitterOptions{Spread: 20})
+
+		require.Len(t, plan.Stacks, 1)
+		assert.Equal(t, "0 0 * * *", plan.Stacks[0].Expression)
+		assert.Len(t, plan.Stacks[0].Jobs, 4)
+
+		require.Len(t, plan.Suggestions, 3)
+		assert.Equal(t, 2, plan.Suggestions[0].Job.LineNumber)
+		assert.Equal(t, "5 0 * * *", plan.Suggestions[0].Expression)
+		assert.Equal(t, 5, plan.Suggestions[0].Offset)
+		assert.Equal(t, "10 0 * * *", plan.Suggestions[1].Expression)
+		assert.Equal(t, "15 0 * * *", plan.Suggestions[2].Expression)
+		assert.Zero(t, plan.Unresolved)
+	})
+
+	t.Run("skips minutes in use", func(t *testing.T) {
+		jobs := jitterJobs("0 3 * * *", "0 3 * * *", "15 3 * * *")
+		plan := calc.SuggestJitter(jobs, JitterOptions{})
+		require.Len(t, plan.Suggestions, 1)
+		assert.Equal(t, "16 3 * * *", plan.Suggestions[0].Expression)
+	})
+
+	t.Run("stays within the hour", func(t *testing.T) {
+		jobs := jitterJobs("58 * * * *", "58 * * * *", "58 * * * *")
+		plan := calc.SuggestJitter(jobs, JitterOptions{})
+		require.Len(t, plan.Suggestions, 1)
+		assert.Equal(t, "59 * * * *", plan.Suggestions[0].Expression)
+		assert.Equal(t, 1, plan.Unresolved)
+	})
+
+	t.Run("min jobs", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *", "0 0 * * *")
+		plan := calc.SuggestJitter(jobs, JitterOptions{MinJobs: 3})
+		assert.Empty(t, plan.Stacks)
+		assert.Empty(t, plan.Suggestions)
+	})
+
+	t.Run("ignores different schedules and unsupported minutes", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *", "0 1 * * *", "*/15 * * * *", "*/15 * * * *", "0,30 * * * *", "0,30 * * * *")
+		jobs = append(jobs, &crontab.Job{LineNumber: 7, Expression: "bad", Valid: false})
+		plan := calc.SuggestJitter(jobs, JitterOptions{})
+		assert.Empty(t, plan.Stacks)
+	})
+
+	t.Run("time zones are separate", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *", "0 0 * * *")
+		jobs[1].Timezone = "Asia/Tokyo"
+		plan := calc.SuggestJitter(jobs, JitterOptions{})
+		assert.Empty(t, plan.Stacks)
+	})
+}
+
+func TestJitterize(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("moves fixed minutes within the spread", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *", "@hourly", "30 2 * * 1-5")
+		jobs[1].Command = "other.sh"
+		suggestions := calc.Jitterize(jobs, JitterizeOptions{Seed: "web-1", Spread: 20})
+		require.NotEmpty(t, suggestions)
+		for _, s := range suggestions {
+			assert.Greater(t, s.Offset, 0)
+			assert.Less(t, s.Offset, 20)
+		}
+		assert.Equal(t, suggestions, calc.Jitterize(jobs, JitterizeOptions{Seed: "web-1", Spread: 20}), "seeded offsets are stable")
+	})
+
+	t.Run("different seeds pick different minutes", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *")
+		minutes := make(map[string]bool)
+		for _, seed := range []string{"web-1", "web-2", "web-3", "web-4", "web-5"} {
+			expression := jobs[0].Expression
+			if suggestions := calc.Jitterize(jobs, JitterizeOptions{Seed: seed}); len(suggestions) > 0 {
+				expression = suggestions[0].Expression
+			}
+			minutes[expression] = true
+		}
+		assert.Greater(t, len(minutes), 1)
+	})
+
+	t.Run("random ranges", func(t *testing.T) {
+		jobs := jitterJobs("0 0 * * *", "50 * * * *", "59 * * * *")
+		suggestions := calc.Jitterize(jobs, JitterizeOptions{Random: true})
+		require.Len(t, suggestions, 2)
+		assert.Equal(t, "0~59 0 * * *", suggestions[0].Expression)
+		assert.Equal(t, "50~59 * * * *", suggestions[1].Expression)
+
+		suggestions = calc.Jitterize(jobs[:1], JitterizeOptions{Random: true, Spread: 15})
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "0~14 0 * * *", suggestions[0].Expression)
+	})
+
+	t.Run("ignores unsupported minutes", func(t *testing.T) {
+		jobs := jitterJobs("*/5 * * * *", "0,30 * * * *", "~ 3 * * *", "0 0 0 * * *", "@reboot")
+		assert.Empty(t, calc.Jitterize(jobs, JitterizeOptions{Random: true}))
+	})
+}
@@ -9,6 +9,7 @@ type Metrics struct {
 	JobFrequencies   []JobFrequency
 	HourHistogram    []int // 24 elements, index = hour (0-23)
 	Collisions       CollisionStats
+	Fields           FieldStats // Minute, hour and day-of-week value distribution
 }
 
 // JobFrequency represents frequency information for a single job
@@ -0,0 +1,166 @@
+package stats
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/parallel"
+)
+
+// Load summarizes the scheduling load of a set of jobs over a time window
+type Load struct {
+	Jobs           int       // Valid jobs
+	RunsPerDay     int       // Runs of all jobs in a day
+	BusiestHour    HourStats // Hour of the day in which the most runs start
+	OverlapMinutes int       // Minutes with more than one job running
+	MaxConcurrent  int       // Most jobs running in the same minute
+}
+
+// CollisionWindow is a period in which an added job runs with other jobs
+type CollisionWindow struct {
+	Start time.Time
+	End   time.Time // Exclusive
+	Jobs  []string  // IDs of the jobs running, in crontab order, added jobs last
+}
+
+// Simulation is the change in load from adding jobs to a crontab
+type Simulation struct {
+	Start            time.Time
+	Window           time.Duration
+	Before           Load // Load of the crontab
+	After            Load // Load of the crontab with the added jobs
+	CollisionWindows []CollisionWindow
+}
+
+// Simulate overlays hypothetical jobs on a crontab's jobs and compares the
+// load of the window starting at start with and without them. Added jobs
+// are identified as "added-1", "added-2", ...; runs last their Duration
+// (or their start minute), as in CalculateCollisions.
+func (c *Calculator) Simulate(jobs, added []*crontab.Job, start time.Time, window time.Duration) *Simulation {
+	start = start.Truncate(time.Minute)
+	all := append(slices.Clip(jobs), added...)
+	ids := make([]string, len(all))
+	for i, job := range all {
+		if i < len(jobs) {
+			ids[i] = fmt.Sprintf("line-%d", job.LineNumber)
+		} else {
+			ids[i] = fmt.Sprintf("added-%d", i-len(jobs)+1)
+		}
+	}
+
+	// Jobs running in each minute of the window, by index in all
+	running := make(map[time.Time][]int)
+	hourRuns := make([][]int, len(all)) // Runs starting in each hour of the day
+	end := start.Add(window)
+	occupancy := parallel.Map(len(all), func(i int) map[time.Time]bool {
+		job := all[i]
+		if !job.Valid {
+			return nil
+		}
+		hourRuns[i] = make([]int, HoursInDay)
+		occupied := make(map[time.Time]bool)
+		c.forEachRun(job, start, end, func(t time.Time) {
+			hourRuns[i][t.Hour()]++
+			runStart := t.Truncate(time.Minute)
+			runEnd := t.Add(job.Duration)
+			for minute := runStart; minute.Equal(runStart) || minute.Before(runEnd); minute = minute.Add(time.Minute) {
+				if !minute.Before(end) {
+					break
+				}
+				occupied[minute] = true
+			}
+		})
+		return occupied
+	})
+	// Indexes are appended in order, so each minute's are sorted
+	for i, occupied := range occupancy {
+		for minute := range occupied {
+			running[minute] = append(running[minute], i)
+		}
+	}
+
+	sim := &Simulation{
+		Start:  start,
+		Window: window,
+		Before: c.load(all[:len(jobs)], hourRuns, running),
+		After:  c.load(all, hourRuns, running),
+	}
+
+	// Collision windows: minutes in which an added job runs with another job,
+	// merged while the same jobs keep running
+	minutes := make([]time.Time, 0, len(running))
+	for minute, indexes := range running {
+		if len(indexes) > 1 && indexes[len(indexes)-1] >= len(jobs) {
+			minutes = append(minutes, minute)
+		}
+	}
+	sort.Slice(minutes, func(a, b int) bool { return minutes[a].Before(minutes[b]) })
+	var last []int
+	for _, minute := range minutes {
+		indexes := running[minute]
+		if n := len(sim.CollisionWindows); n > 0 && sim.CollisionWindows[n-1].End.Equal(minute) && slices.Equal(last, indexes) {
+			sim.CollisionWindows[n-1].End = minute.Add(time.Minute)
+			continue
+		}
+		cw := CollisionWindow{Start: minute, End: minute.Add(time.Minute)}
+		for _, i := range indexes {
+			cw.Jobs = append(cw.Jobs, ids[i])
+		}
+		sim.CollisionWindows = append(sim.CollisionWindows, cw)
+		last = indexes
+	}
+	return sim
+}
+
+// load summarizes the load of jobs, the first of the simulated jobs, from
+// the runs starting in each hour of the day and the jobs running in each
+// minute, both indexed by simulated job
+func (c *Calculator) load(jobs []*crontab.Job, hourRuns [][]int, running map[time.Time][]int) Load {
+	var l Load
+	n := len(jobs)
+	runsPerDay := parallel.Map(n, func(i int) int {
+		runs := 0
+		if jobs[i].Valid {
+			c.forEachRun(jobs[i], ReferenceDate, ReferenceDate.Add(OneDay), func(time.Time) {
+				runs++
+			})
+		}
+		return runs
+	})
+	for i, job := range jobs {
+		if job.Valid {
+			l.Jobs++
+			l.RunsPerDay += runsPerDay[i]
+		}
+	}
+
+	for hour := 0; hour < HoursInDay; hour++ {
+		stats := HourStats{Hour: hour}
+		for _, runs := range hourRuns[:n] {
+			if runs != nil && runs[hour] > 0 {
+				stats.RunCount += runs[hour]
+				stats.JobCount++
+			}
+		}
+		if stats.RunCount > l.BusiestHour.RunCount {
+			l.BusiestHour = stats
+		}
+	}
+
+	for _, indexes := range running {
+		count := 0
+		for _, i := range indexes {
+			if i < n {
+				count++
+			}
+		}
+		if count > 1 {
+			l.OverlapMinutes++
+		}
+		l.MaxConcurrent = max(l.MaxConcurrent, count)
+	}
+	return l
+}
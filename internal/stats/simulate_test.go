@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	calc := NewCalculator()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []*crontab.Job{
+		{LineNumber: 1, Expression: "0 2 * * *", Valid: true, Duration: 10 * time.Minute},
+		{LineNumber: 2, Expression: "0 */6 * * *", Valid: true},
+		{LineNumber: 3, Expression: "invalid", Valid: false},
+	}
+
+	t.Run("should compare the load with and without the added jobs", func(t *testing.T) {
+		added := []*crontab.Job{{Expression: "*/5 2 * * *", Valid: true}}
+		sim := calc.Simulate(jobs, added, start.Add(30*time.Second), OneDay)
+
+		assert.Equal(t, start, sim.Start)
+		assert.Equal(t, Load{Jobs: 2, RunsPerDay: 5, BusiestHour: HourStats{Hour: 0, RunCount: 1, JobCount: 1}, MaxConcurrent: 1}, sim.Before)
+
+		assert.Equal(t, 3, sim.After.Jobs)
+		assert.Equal(t, 17, sim.After.RunsPerDay)
+		assert.Equal(t, HourStats{Hour: 2, RunCount: 13, JobCount: 2}, sim.After.BusiestHour)
+		assert.Equal(t, 2, sim.After.OverlapMinutes)
+		assert.Equal(t, 2, sim.After.MaxConcurrent)
+
+		require.Len(t, sim.CollisionWindows, 2)
+		assert.Equal(t, CollisionWindow{
+			Start: start.Add(2 * time.Hour),
+			End:   start.Add(2*time.Hour + time.Minute),
+			Jobs:  []string{"line-1", "added-1"},
+		}, sim.CollisionWindows[0])
+		assert.Equal(t, start.Add(2*time.Hour+5*time.Minute), sim.CollisionWindows[1].Start)
+	})
+
+	t.Run("should merge consecutive minutes with the same jobs", func(t *testing.T) {
+		added := []*crontab.Job{{Expression: "0 2 * * *", Valid: true, Duration: 30 * time.Minute}}
+		sim := calc.Simulate(jobs, added, start, OneDay)
+
+		require.Len(t, sim.CollisionWindows, 1)
+		assert.Equal(t, start.Add(2*time.Hour), sim.CollisionWindows[0].Start)
+		assert.Equal(t, start.Add(2*time.Hour+10*time.Minute), sim.CollisionWindows[0].End)
+		assert.Equal(t, 10, sim.After.OverlapMinutes)
+	})
+
+	t.Run("should report no collisions for jobs that run alone", func(t *testing.T) {
+		added := []*crontab.Job{{Expression: "30 3 * * *", Valid: true}}
+		sim := calc.Simulate(jobs, added, start, OneDay)
+
+		assert.Empty(t, sim.CollisionWindows)
+		assert.Equal(t, sim.Before.MaxConcurrent, sim.After.MaxConcurrent)
+		assert.Equal(t, 6, sim.After.RunsPerDay)
+	})
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/parallel"
+)
+
+// DefaultSlotCount is how many slots FindSlots suggests by default
+const DefaultSlotCount = 5
+
+// minutesPerWeek is the number of minutes of the week FindSlots examines
+const minutesPerWeek = DaysPerWeek * MinutesPerDay
+
+// SlotOptions configures FindSlots
+type SlotOptions struct {
+	Duration time.Duration // How long the new job runs (at least a minute)
+	// Time of day range the runs of the new job must fit in, in minutes
+	// after midnight; it wraps past midnight when End is not after Start,
+	// and equal values mean the whole day
+	WithinStart, WithinEnd int
+	Count                  int // Slots to return (default: DefaultSlotCount)
+}
+
+// Slot is a range of daily start times for a new job whose runs meet the
+// same load from the existing jobs
+type Slot struct {
+	Start      int    // First start time, in minutes after midnight
+	End        int    // Last start time, in minutes after midnight
+	Peak       int    // Most jobs running at once during a run, over a week
+	Busy       int    // Minutes of other jobs running during the runs of a week
+	Expression string // Daily schedule starting in the middle of the range
+}
+
+// FindSlots searches a week of the jobs' runs, starting on ReferenceDate's
+// date in local time, for the daily start times of a new job whose runs meet
+// the fewest other jobs, and returns the quietest ranges of start times:
+// fewest jobs running at once first, then fewest busy minutes, then the
+// longest range. Runs of the jobs last their Duration (or their start
+// minute).
+func (c *Calculator) FindSlots(jobs []*crontab.Job, opts SlotOptions) ([]Slot, error) {
+	if opts.Count <= 0 {
+		opts.Count = DefaultSlotCount
+	}
+	duration := int((opts.Duration + time.Minute - 1) / time.Minute)
+	within := (opts.WithinEnd - opts.WithinStart + MinutesPerDay) % MinutesPerDay
+	if within == 0 {
+		within = MinutesPerDay
+	}
+	if duration < 1 || duration > within {
+		return nil, fmt.Errorf("%s does not fit in the %s window", opts.Duration, time.Duration(within)*time.Minute)
+	}
+
+	// Jobs running in each minute of the week
+	running := make([]int, minutesPerWeek)
+	startTime := localReference()
+	endTime := startTime.Add(DaysPerWeek * OneDay)
+	occupancy := parallel.Map(len(jobs), func(i int) []bool {
+		job := jobs[i]
+		if !job.Valid {
+			return nil
+		}
+		occupied := make([]bool, minutesPerWeek)
+		c.forEachRun(job, startTime, endTime, func(t time.Time) {
+			start := int(t.Sub(startTime) / time.Minute)
+			minutes := max(1, int((job.Duration+time.Minute-1)/time.Minute))
+			for k := 0; k < minutes && start+k < minutesPerWeek; k++ {
+				occupied[start+k] = true
+			}
+		})
+		return occupied
+	})
+	if err := c.ended(); err != nil {
+		return nil, err
+	}
+	for _, occupied := range occupancy {
+		for minute, ok := range occupied {
+			if ok {
+				running[minute]++
+			}
+		}
+	}
+
+	// Load of each start time in the window, grouped into ranges of equal load
+	var slots []Slot
+	for offset := 0; offset+duration <= within; offset++ {
+		start := (opts.WithinStart + offset) % MinutesPerDay
+		peak, busy := 0, 0
+		for day := 0; day < DaysPerWeek; day++ {
+			for k := 0; k < duration; k++ {
+				count := running[(day*MinutesPerDay+start+k)%minutesPerWeek]
+				peak = max(peak, count)
+				busy += count
+			}
+		}
+		if n := len(slots); n > 0 && slots[n-1].Peak == peak && slots[n-1].Busy == busy {
+			slots[n-1].End = start
+			continue
+		}
+		slots = append(slots, Slot{Start: start, End: start, Peak: peak, Busy: busy})
+	}
+
+	length := func(s Slot) int {
+		return (s.End - s.Start + MinutesPerDay) % MinutesPerDay
+	}
+	sort.SliceStable(slots, func(a, b int) bool {
+		if slots[a].Peak != slots[b].Peak {
+			return slots[a].Peak < slots[b].Peak
+		}
+		if slots[a].Busy != slots[b].Busy {
+			return slots[a].Busy < slots[b].Busy
+		}
+		return length(slots[a]) > length(slots[b])
+	})
+	if len(slots) > opts.Count {
+		slots = slots[:opts.Count]
+	}
+	for i := range slots {
+		middle := (slots[i].Start + length(slots[i])/2) % MinutesPerDay
+		slots[i].Expression = fmt.Sprintf("%d %d * * *", middle%MinutesPerHour, middle/MinutesPerHour)
+	}
+	return slots, nil
+}
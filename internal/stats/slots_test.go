@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSlots(t *testing.T) {
+	calc := NewCalculator()
+	jobs := []*crontab.Job{
+		{LineNumber: 1, Expression: "0 2 * * *", Valid: true, Duration: 30 * time.Minute},
+		{LineNumber: 2, Expression: "0 4 * * *", Valid: true, Duration: time.Hour},
+		{LineNumber: 3, Expression: "invalid", Valid: false},
+	}
+
+	t.Run("should find the quietest start times within a window", func(t *testing.T) {
+		slots, err := calc.FindSlots(jobs, SlotOptions{Duration: 20 * time.Minute, WithinStart: 2 * 60, WithinEnd: 6 * 60})
+		require.NoError(t, err)
+		require.NotEmpty(t, slots)
+
+		// 02:30-03:40 is the longest free range of starts, then 05:00-05:40
+		assert.Equal(t, Slot{Start: 2*60 + 30, End: 3*60 + 40, Expression: "5 3 * * *"}, slots[0])
+		assert.Equal(t, Slot{Start: 5 * 60, End: 5*60 + 40, Expression: "20 5 * * *"}, slots[1])
+		assert.Equal(t, 1, slots[2].Peak)
+		assert.LessOrEqual(t, len(slots), DefaultSlotCount)
+	})
+
+	t.Run("should report the least busy slots when none is free", func(t *testing.T) {
+		busy := []*crontab.Job{{Expression: "* * * * *", Valid: true}, {Expression: "0-9 3 * * *", Valid: true}}
+		slots, err := calc.FindSlots(busy, SlotOptions{Duration: 5 * time.Minute, WithinStart: 3 * 60, WithinEnd: 4 * 60, Count: 2})
+		require.NoError(t, err)
+		require.Len(t, slots, 2)
+		assert.Equal(t, 1, slots[0].Peak)
+		assert.Equal(t, DaysPerWeek*5, slots[0].Busy)
+		assert.Equal(t, 3*60+10, slots[0].Start)
+		assert.Equal(t, 3*60+55, slots[0].End)
+	})
+
+	t.Run("should search windows that wrap past midnight", func(t *testing.T) {
+		slots, err := calc.FindSlots(jobs, SlotOptions{Duration: time.Hour, WithinStart: 23 * 60, WithinEnd: 60})
+		require.NoError(t, err)
+		require.Len(t, slots, 1)
+		assert.Equal(t, Slot{Start: 23 * 60, End: 0, Expression: "30 23 * * *"}, slots[0])
+	})
+
+	t.Run("should search the whole day by default", func(t *testing.T) {
+		slots, err := calc.FindSlots(jobs, SlotOptions{Duration: time.Minute})
+		require.NoError(t, err)
+		assert.Equal(t, 0, slots[0].Peak)
+		assert.Equal(t, 5*60, slots[0].Start)
+	})
+
+	t.Run("should reject durations that do not fit", func(t *testing.T) {
+		_, err := calc.FindSlots(jobs, SlotOptions{Duration: 2 * time.Hour, WithinStart: 0, WithinEnd: 60})
+		assert.Error(t, err)
+		_, err = calc.FindSlots(jobs, SlotOptions{})
+		assert.Error(t, err)
+	})
+}
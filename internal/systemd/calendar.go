@@ -0,0 +1,392 @@
+// Package systemd converts between cron expressions and systemd timer
+// OnCalendar= calendar event expressions.
+package systemd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// Conversion is the result of converting between cron and OnCalendar syntax
+type Conversion struct {
+	Input    string   // The expression that was converted
+	Output   []string // Converted expressions (cron to systemd may need two OnCalendar= entries)
+	Warnings []string // Semantics that could not be represented exactly
+}
+
+// dayNames are the systemd day-of-week abbreviations indexed by cron value (Sunday=0)
+var dayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// dayValues maps systemd day-of-week names (abbreviated and full) to cron values
+var dayValues = map[string]int{
+	"sun": 0, "sunday": 0,
+	"mon": 1, "monday": 1,
+	"tue": 2, "tuesday": 2,
+	"wed": 3, "wednesday": 3,
+	"thu": 4, "thursday": 4,
+	"fri": 5, "friday": 5,
+	"sat": 6, "saturday": 6,
+}
+
+// shorthands expands systemd's special calendar expressions
+var shorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// FromCron converts a parsed cron schedule to OnCalendar= expressions.
+// A schedule that restricts both day-of-month and day-of-week is converted to
+// two expressions, since cron runs when either day field matches while
+// systemd requires all fields to match.
+func FromCron(schedule *cronx.Schedule) (*Conversion, error) {
+	if schedule.Quartz != nil {
+		return nil, fmt.Errorf("quartz day modifiers (L, W, #) cannot be converted to OnCalendar")
+	}
+
+	second := "00"
+	if schedule.HasSeconds() {
+		second = formatValues(schedule.Second, cronx.MinSecond, cronx.MaxSecond)
+	}
+	clock := fmt.Sprintf("%s:%s:%s",
+		formatValues(schedule.Hour, cronx.MinHour, cronx.MaxHour),
+		formatValues(schedule.Minute, cronx.MinMinute, cronx.MaxMinute),
+		second)
+
+	year := "*"
+	if schedule.Year != nil {
+		year = formatValues(schedule.Year, cronx.MinYear, cronx.MaxYear)
+	}
+	month := formatValues(schedule.Month, cronx.MinMonth, cronx.MaxMonth)
+	day := formatValues(schedule.DayOfMonth, cronx.MinDayOfMonth, cronx.MaxDayOfMonth)
+	weekdays := formatWeekdays(schedule.DayOfWeek)
+
+	conv := &Conversion{Input: schedule.Original}
+
+	// Cron matches days with OR when neither day field starts with '*'
+	if !isStar(schedule.DayOfMonth) && !isStar(schedule.DayOfWeek) {
+		conv.Output = []string{
+			calendar("", year, month, day, clock),
+			calendar(weekdays, year, month, "*", clock),
+		}
+		return conv, nil
+	}
+
+	conv.Output = []string{calendar(weekdays, year, month, day, clock)}
+	return conv, nil
+}
+
+// ToCron converts an OnCalendar= expression to a 5-field cron expression.
+// Seconds, years and time zones have no cron equivalent; they are dropped
+// with a warning. Expressions cron cannot express at all are rejected.
+func ToCron(expression string) (*Conversion, error) {
+	input := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(expression), "OnCalendar="))
+	if input == "" {
+		return nil, fmt.Errorf("empty OnCalendar expression")
+	}
+
+	normalized := input
+	if expanded, ok := shorthands[strings.ToLower(input)]; ok {
+		normalized = expanded
+	}
+
+	conv := &Conversion{Input: input}
+	weekdays, date, clock := "", "*-*-*", "00:00:00"
+	var seenDate, seenTime bool
+
+	tokens := strings.Fields(normalized)
+	for i, tok := range tokens {
+		switch {
+		case strings.Contains(tok, ":"):
+			if seenTime {
+				return nil, fmt.Errorf("unexpected %q: time already given", tok)
+			}
+			clock, seenTime = tok, true
+		case isDateToken(tok):
+			if seenDate {
+				return nil, fmt.Errorf("unexpected %q: date already given", tok)
+			}
+			date, seenDate = tok, true
+		case i == 0 && isLetters(tok):
+			weekdays = tok
+		case i == len(tokens)-1 && i > 0 && isLetters(tok):
+			conv.Warnings = append(conv.Warnings, fmt.Sprintf(
+				"time zone %s dropped: cron uses the system time zone", tok))
+		default:
+			return nil, fmt.Errorf("unrecognized component %q", tok)
+		}
+	}
+
+	dow := "*"
+	if weekdays != "" {
+		var err error
+		if dow, err = convertWeekdays(weekdays); err != nil {
+			return nil, err
+		}
+	}
+
+	dom, month, err := convertDate(date, conv)
+	if err != nil {
+		return nil, err
+	}
+	minute, hour, err := convertTime(clock, conv)
+	if err != nil {
+		return nil, err
+	}
+
+	if dom != "*" && dow != "*" {
+		conv.Warnings = append(conv.Warnings,
+			"systemd runs only when both the weekday and the day of month match; cron runs when either matches")
+	}
+
+	cron := strings.Join([]string{minute, hour, dom, month, dow}, " ")
+	if _, err := cronx.NewParser().Parse(cron); err != nil {
+		return nil, fmt.Errorf("converted expression %q is invalid: %w", cron, err)
+	}
+	conv.Output = []string{cron}
+	return conv, nil
+}
+
+// calendar assembles an OnCalendar= expression from its components
+func calendar(weekdays, year, month, day, clock string) string {
+	date := fmt.Sprintf("%s-%s-%s %s", year, month, day, clock)
+	if weekdays == "" {
+		return date
+	}
+	return weekdays + " " + date
+}
+
+// isStar reports whether a cron day field starts with '*', which makes cron
+// combine the two day fields with AND
+func isStar(f cronx.Field) bool {
+	return strings.HasPrefix(f.Raw(), "*") || f.Raw() == "?"
+}
+
+// formatValues formats the values matched by a cron field as an OnCalendar
+// component: "*", a repetition ("00/15"), or a list of values and ranges
+func formatValues(f cronx.Field, min, max int) string {
+	values := f.Values()
+	if len(values) == max-min+1 {
+		return "*"
+	}
+
+	// A progression that continues to the end of the range is a repetition
+	if len(values) >= 2 {
+		step := values[1] - values[0]
+		progression := step > 1 && values[len(values)-1]+step > max
+		for i := 2; progression && i < len(values); i++ {
+			progression = values[i]-values[i-1] == step
+		}
+		if progression {
+			return fmt.Sprintf("%02d/%d", values[0], step)
+		}
+	}
+
+	var parts []string
+	for _, run := range cronx.Runs(values) {
+		if run[0] == run[1] {
+			parts = append(parts, fmt.Sprintf("%02d", run[0]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%02d..%02d", run[0], run[1]))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatWeekdays formats a cron day-of-week field using systemd day names,
+// or returns an empty string when every day matches
+func formatWeekdays(f cronx.Field) string {
+	values := f.Values()
+	if len(values) == len(dayNames) {
+		return ""
+	}
+
+	var parts []string
+	for _, run := range cronx.Runs(values) {
+		if run[0] == run[1] {
+			parts = append(parts, dayNames[run[0]])
+		} else {
+			parts = append(parts, dayNames[run[0]]+".."+dayNames[run[1]])
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// isDateToken reports whether a token is an OnCalendar date such as *-*-01 or 2026-01-01
+func isDateToken(tok string) bool {
+	return strings.ContainsAny(tok, "-~") && (tok[0] == '*' || (tok[0] >= '0' && tok[0] <= '9'))
+}
+
+// isLetters reports whether a token starts with a letter (a weekday specification)
+func isLetters(tok string) bool {
+	c := tok[0] | 0x20
+	return c >= 'a' && c <= 'z'
+}
+
+// convertWeekdays converts an OnCalendar weekday list (Mon..Fri,Sun) to a cron day-of-week field
+func convertWeekdays(tok string) (string, error) {
+	var parts []string
+	for _, item := range strings.Split(tok, ",") {
+		startName, endName, isRange := strings.Cut(item, "..")
+		if !isRange {
+			startName, endName, isRange = strings.Cut(item, "-")
+		}
+		start, ok := dayValues[strings.ToLower(startName)]
+		if !ok {
+			return "", fmt.Errorf("unknown weekday %q", startName)
+		}
+		if !isRange {
+			parts = append(parts, strconv.Itoa(start))
+			continue
+		}
+		end, ok := dayValues[strings.ToLower(endName)]
+		if !ok {
+			return "", fmt.Errorf("unknown weekday %q", endName)
+		}
+		if start <= end {
+			parts = append(parts, fmt.Sprintf("%d-%d", start, end))
+			continue
+		}
+		// Ranges may wrap around the end of the week (Sat..Mon)
+		for d := start; d != end; d = (d + 1) % len(dayNames) {
+			parts = append(parts, strconv.Itoa(d))
+		}
+		parts = append(parts, strconv.Itoa(end))
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// convertDate converts an OnCalendar date ([year-]month-day) to cron
+// day-of-month and month fields
+func convertDate(date string, conv *Conversion) (string, string, error) {
+	if strings.Contains(date, "~") {
+		return "", "", fmt.Errorf("last-day-of-month syntax (~) in %q cannot be represented in cron", date)
+	}
+
+	parts := strings.Split(date, "-")
+	switch len(parts) {
+	case 2:
+	case 3:
+		if parts[0] != "*" {
+			conv.Warnings = append(conv.Warnings, fmt.Sprintf(
+				"year restriction %s dropped: cron runs every year", parts[0]))
+		}
+		parts = parts[1:]
+	default:
+		return "", "", fmt.Errorf("invalid date %q (expected [year-]month-day)", date)
+	}
+
+	month, err := convertComponent(parts[0], cronx.MinMonth, cronx.MaxMonth)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid month in %q: %w", date, err)
+	}
+	dom, err := convertComponent(parts[1], cronx.MinDayOfMonth, cronx.MaxDayOfMonth)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid day in %q: %w", date, err)
+	}
+	return dom, month, nil
+}
+
+// convertTime converts an OnCalendar time (hour:minute[:second]) to cron
+// minute and hour fields
+func convertTime(clock string, conv *Conversion) (string, string, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return "", "", fmt.Errorf("invalid time %q (expected hour:minute[:second])", clock)
+	}
+
+	if len(parts) == 3 {
+		second, err := convertComponent(parts[2], cronx.MinSecond, cronx.MaxSecond)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid second in %q: %w", clock, err)
+		}
+		if second != "0" {
+			conv.Warnings = append(conv.Warnings, fmt.Sprintf(
+				"seconds %s dropped: cron runs at second 0", parts[2]))
+		}
+	}
+
+	hour, err := convertComponent(parts[0], cronx.MinHour, cronx.MaxHour)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid hour in %q: %w", clock, err)
+	}
+	minute, err := convertComponent(parts[1], cronx.MinMinute, cronx.MaxMinute)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid minute in %q: %w", clock, err)
+	}
+	return minute, hour, nil
+}
+
+// convertComponent converts an OnCalendar component (values, a..b ranges and
+// /n repetitions) to the equivalent cron field
+func convertComponent(tok string, min, max int) (string, error) {
+	if tok == "*" {
+		return "*", nil
+	}
+
+	parts := strings.Split(tok, ",")
+	for i, part := range parts {
+		base, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return "", fmt.Errorf("invalid repetition %q", step)
+			}
+		}
+
+		var field string
+		startStr, endStr, isRange := strings.Cut(base, "..")
+		switch {
+		case base == "*":
+			field = "*"
+		case isRange:
+			start, err := componentValue(startStr, min, max)
+			if err != nil {
+				return "", err
+			}
+			end, err := componentValue(endStr, min, max)
+			if err != nil {
+				return "", err
+			}
+			field = fmt.Sprintf("%d-%d", start, end)
+		default:
+			v, err := componentValue(base, min, max)
+			if err != nil {
+				return "", err
+			}
+			field = strconv.Itoa(v)
+			// A repetition starts at the value and continues to the end of the range
+			if hasStep && v == min {
+				field = "*"
+			} else if hasStep {
+				field = fmt.Sprintf("%d-%d", v, max)
+			}
+		}
+
+		if hasStep {
+			field += "/" + step
+		}
+		parts[i] = field
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// componentValue parses a single numeric component value within min-max
+func componentValue(s string, min, max int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range (%d-%d)", v, min, max)
+	}
+	return v, nil
+}
@@ -0,0 +1,132 @@
+package systemd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/systemd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromCron(t *testing.T) {
+	parser := cronx.NewParserWithSeconds("en", cronx.SecondsOptional)
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{"daily", "0 0 * * *", []string{"*-*-* 00:00:00"}},
+		{"weekdays", "0 9 * * 1-5", []string{"Mon..Fri *-*-* 09:00:00"}},
+		{"minute step", "*/15 * * * *", []string{"*-*-* *:00/15:00"}},
+		{"hour list", "30 8,12,18 * * *", []string{"*-*-* 08,12,18:30:00"}},
+		{"hour range", "0 9-17 * * *", []string{"*-*-* 09..17:00:00"}},
+		{"month step and day range", "0 0 1-5 */3 *", []string{"*-01/3-01..05 00:00:00"}},
+		{"stepped range is expanded", "0 0 1-10/3 * *", []string{"*-*-01,04,07,10 00:00:00"}},
+		{"weekday step", "0 0 * * */2", []string{"Sun,Tue,Thu,Sat *-*-* 00:00:00"}},
+		{"alias", "@weekly", []string{"Sun *-*-* 00:00:00"}},
+		{"seconds", "*/10 * * * * *", []string{"*-*-* *:*:00/10"}},
+		{"both day fields use two entries", "0 0 1,15 * 1", []string{"*-*-01,15 00:00:00", "Mon *-*-* 00:00:00"}},
+		{"star day-of-month uses AND", "0 0 */2 * 1", []string{"Mon *-*-01/2 00:00:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expression)
+			require.NoError(t, err)
+
+			conv, err := systemd.FromCron(schedule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expression, conv.Input)
+			assert.Equal(t, tt.expected, conv.Output)
+			assert.Empty(t, conv.Warnings)
+		})
+	}
+
+	t.Run("quartz modifiers are rejected", func(t *testing.T) {
+		quartz := cronx.NewParserWithOptions("en", cronx.ParserOptions{Dialect: cronx.DialectQuartz})
+		schedule, err := quartz.Parse("0 0 12 ? * 6L")
+		require.NoError(t, err)
+
+		_, err = systemd.FromCron(schedule)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be converted")
+	})
+}
+
+func TestToCron(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+		warnings   int
+	}{
+		{"weekdays", "Mon..Fri *-*-* 09:00:00", "0 9 * * 1-5", 0},
+		{"prefix and shorthand", "OnCalendar=daily", "0 0 * * *", 0},
+		{"weekly runs on Monday", "weekly", "0 0 * * 1", 0},
+		{"quarterly", "quarterly", "0 0 1 1,4,7,10 *", 0},
+		{"time only", "*:0/15", "*/15 * * * *", 0},
+		{"repetition from an offset", "*:05/20", "5-59/20 * * * *", 0},
+		{"date without year", "01-01 12:00", "0 12 1 1 *", 0},
+		{"full weekday names and lists", "Monday,Wednesday 08:00", "0 8 * * 1,3", 0},
+		{"wrapping weekday range", "Sat..Mon 10:00", "0 10 * * 6,0,1", 0},
+		{"seconds are dropped", "*-*-* 12:00:30", "0 12 * * *", 1},
+		{"year is dropped", "2027-06-01 00:00:00", "0 0 1 6 *", 1},
+		{"time zone is dropped", "*-*-* 09:00:00 Europe/Berlin", "0 9 * * *", 1},
+		{"weekday and day use AND", "Mon *-*-01..07 00:00:00", "0 0 1-7 * 1", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := systemd.ToCron(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expected}, conv.Output)
+			assert.Len(t, conv.Warnings, tt.warnings, "%v", conv.Warnings)
+		})
+	}
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			errContain string
+		}{
+			{"", "empty OnCalendar expression"},
+			{"*-02~01", "cannot be represented in cron"},
+			{"*-*-* 25:00", "out of range"},
+			{"*-13-01", "invalid month"},
+			{"Funday *-*-*", "unknown weekday"},
+			{"*-*-* 12", "unrecognized component"},
+			{"12:00 13:00", "time already given"},
+			{"*-*-*-*", "invalid date"},
+			{"*-*-* 1:2:3:4", "invalid time"},
+		}
+		for _, tt := range tests {
+			_, err := systemd.ToCron(tt.expression)
+			require.Error(t, err, tt.expression)
+			assert.Contains(t, err.Error(), tt.errContain, tt.expression)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		parser := cronx.NewParser()
+		for _, expr := range []string{"0 9 * * 1-5", "*/15 * * * *", "30 2 1 */3 *", "0 8-18/2 * * 6,0"} {
+			schedule, err := parser.Parse(expr)
+			require.NoError(t, err)
+			to, err := systemd.FromCron(schedule)
+			require.NoError(t, err)
+			back, err := systemd.ToCron(to.Output[0])
+			require.NoError(t, err, to.Output[0])
+
+			original, err := cronx.NewScheduler().Next(expr, referenceTime, 20)
+			require.NoError(t, err)
+			converted, err := cronx.NewScheduler().Next(back.Output[0], referenceTime, 20)
+			require.NoError(t, err)
+			assert.Equal(t, original, converted, "%s -> %s -> %s", expr, to.Output[0], back.Output[0])
+		}
+	})
+}
+
+// referenceTime is a fixed start time for comparing schedules
+var referenceTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -1,8 +1,10 @@
 package testutil
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -38,3 +40,41 @@ func FileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
 }
+
+// largeCrontabSchedules are the schedules GenerateCrontab cycles through: a
+// mix of frequent, hourly, daily and weekly jobs
+var largeCrontabSchedules = []string{
+	"*/5 * * * *",
+	"%d * * * *",
+	"%d */2 * * *",
+	"%d %d * * *",
+	"%d %d * * 1-5",
+	"%d %d * * 0",
+	"*/15 %d-%d * * *",
+	"%d %d 1 * *",
+}
+
+// GenerateCrontab returns a crontab of n jobs with varied schedules, for
+// benchmarks of large crontabs.
+func GenerateCrontab(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		minute, hour := (i*7)%60, (i*5)%24
+		schedule := largeCrontabSchedules[i%len(largeCrontabSchedules)]
+		var expression string
+		switch strings.Count(schedule, "%d") {
+		case 0:
+			expression = schedule
+		case 1:
+			expression = fmt.Sprintf(schedule, minute)
+		default:
+			if strings.Contains(schedule, "%d-%d") {
+				expression = fmt.Sprintf(schedule, hour/2, hour/2+8)
+			} else {
+				expression = fmt.Sprintf(schedule, minute, hour)
+			}
+		}
+		fmt.Fprintf(&sb, "%s /usr/local/bin/job-%d.sh\n", expression, i)
+	}
+	return sb.String()
+}
@@ -3,6 +3,7 @@ package testutil
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -74,3 +75,22 @@ func TestCreateTempCrontab_ErrorPath(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateCrontab(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(GenerateCrontab(16), "\n"), "\n")
+	if len(lines) != 16 {
+		t.Fatalf("line count mismatch: got %d, want 16", len(lines))
+	}
+	for i, want := range map[int]string{
+		0: "*/5 * * * * /usr/local/bin/job-0.sh",
+		1: "7 * * * * /usr/local/bin/job-1.sh",
+		6: "*/15 3-11 * * * /usr/local/bin/job-6.sh",
+	} {
+		if lines[i] != want {
+			t.Errorf("line %d mismatch: got %q, want %q", i, lines[i], want)
+		}
+	}
+	if content := GenerateCrontab(0); content != "" {
+		t.Errorf("GenerateCrontab(0) should be empty, got %q", content)
+	}
+}
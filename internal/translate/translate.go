@@ -0,0 +1,309 @@
+// Package translate rewrites cron expressions from one dialect to another,
+// such as Spring to Vixie cron, warning about the constructs the target
+// dialect cannot express.
+package translate
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+)
+
+// Conversion is the result of translating an expression between dialects
+type Conversion struct {
+	Input    string   // The expression that was translated
+	Output   []string // Expressions of the target dialect; a job needs all of them to run like the input
+	Warnings []string // Constructs that could not be translated exactly
+}
+
+// target describes what a dialect can express
+type target struct {
+	seconds   cronx.SecondsMode // Whether the dialect has a seconds field
+	bothDays  bool              // A day must match both day fields
+	question  bool              // Exactly one day field must be '?' (Quartz)
+	modifiers bool              // L, W and # day modifiers
+	lastDay   bool              // L in day-of-month, without the other modifiers (cron4j)
+	years     bool              // A trailing year field
+	sundayOne bool              // Day-of-week 1-7, Sunday=1 (Quartz)
+}
+
+var targets = map[cronx.Dialect]target{
+	cronx.DialectStandard: {},
+	cronx.DialectJenkins:  {},
+	cronx.DialectQuartz:   {seconds: cronx.SecondsRequired, bothDays: true, question: true, modifiers: true, years: true, sundayOne: true},
+	cronx.DialectSpring:   {seconds: cronx.SecondsRequired, bothDays: true, modifiers: true},
+	cronx.DialectNodeCron: {seconds: cronx.SecondsOptional, bothDays: true},
+	cronx.DialectCron4j:   {bothDays: true, lastDay: true},
+}
+
+// Targets returns the dialects expressions can be translated to, in the
+// order of cronx.Syntaxes
+func Targets() []cronx.Dialect {
+	var dialects []cronx.Dialect
+	for _, syntax := range cronx.Syntaxes() {
+		if _, ok := targets[syntax.Dialect]; ok {
+			dialects = append(dialects, syntax.Dialect)
+		}
+	}
+	return dialects
+}
+
+// Translate rewrites an expression of dialect from as expressions of dialect
+// to. hashKey seeds Jenkins H tokens and ~ terms, which are translated to the
+// values they pick. Constructs the target cannot express are approximated by
+// fields that run at least as often, with a warning; Vixie cron's rule that
+// days matching either day field run is kept by writing one expression per
+// day field for targets that require both. cron4j patterns joined with | are
+// translated one by one, and a cron4j target joins its expressions with |.
+func Translate(expression string, from, to cronx.Dialect, hashKey string) (*Conversion, error) {
+	t, ok := targets[to]
+	if !ok {
+		names := make([]string, 0, len(targets))
+		for _, d := range Targets() {
+			names = append(names, string(d))
+		}
+		return nil, fmt.Errorf("cannot translate to %s (supported: %s)", to, strings.Join(names, ", "))
+	}
+
+	parser := cronx.NewParserWithOptions("en", cronx.ParserOptions{Seconds: cronx.SecondsOptional, Dialect: from, HashKey: hashKey})
+	check := cronx.NewParserWithOptions("en", cronx.ParserOptions{Seconds: cronx.SecondsOptional, Dialect: to})
+
+	patterns := []string{expression}
+	if from == cronx.DialectCron4j {
+		patterns = strings.Split(expression, cronx.Cron4jSeparator)
+	}
+
+	conv := &Conversion{Input: strings.TrimSpace(expression)}
+	for _, pattern := range patterns {
+		schedule, err := parser.Parse(strings.TrimSpace(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s expression: %w", from, err)
+		}
+		output, warnings, err := t.translate(schedule, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, expr := range output {
+			if _, err := check.Parse(expr); err != nil {
+				return nil, fmt.Errorf("translated expression %q is not valid %s: %w", expr, to, err)
+			}
+		}
+		conv.Output = append(conv.Output, output...)
+		for _, warning := range warnings {
+			if !slices.Contains(conv.Warnings, warning) {
+				conv.Warnings = append(conv.Warnings, warning)
+			}
+		}
+	}
+	if to == cronx.DialectCron4j && len(conv.Output) > 1 {
+		conv.Output = []string{strings.Join(conv.Output, " "+cronx.Cron4jSeparator+" ")}
+	}
+	return conv, nil
+}
+
+// translate writes a schedule as expressions of the target dialect
+func (t target) translate(s *cronx.Schedule, from, to cronx.Dialect) ([]string, []string, error) {
+	if strings.HasPrefix(s.Original, "@every ") {
+		return nil, nil, fmt.Errorf("@every intervals have no cron fields")
+	}
+
+	var warnings []string
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	if s.Rate > 0 && cronx.RateToStandard(s.Rate) == "" {
+		warn("%s does not divide an hour or a day; approximated by the fields %s", s.Original, strings.Join([]string{fieldText(s.Minute), fieldText(s.Hour), fieldText(s.DayOfMonth), fieldText(s.Month), fieldText(s.DayOfWeek)}, " "))
+	}
+	if s.Resolved != "" {
+		warn("%s picks its values once: translated as %s", s.Original, s.Resolved)
+	}
+
+	second := "0"
+	if s.HasSeconds() {
+		second = fieldText(s.Second)
+	}
+	var lead []string
+	switch {
+	case t.seconds == cronx.SecondsRequired, t.seconds == cronx.SecondsOptional && second != "0":
+		lead = []string{second}
+	case second != "0":
+		warn("%s has no seconds field; runs at second 0 of each matching minute instead of at second %s", to, second)
+	}
+
+	var year string
+	if s.Year != nil && !s.Year.IsEvery() {
+		if t.years {
+			year = fieldText(s.Year)
+		} else {
+			warn("%s has no year field; runs every year instead of in %s only", to, fieldText(s.Year))
+		}
+	}
+
+	dom, dow := fieldText(s.DayOfMonth), t.weekdays(s.DayOfWeek)
+	if !restricted(s.DayOfMonth) {
+		dom = "*"
+	}
+	if !restricted(s.DayOfWeek) {
+		dow = "*"
+	}
+	if q := s.Quartz; q != nil {
+		dom, dow = t.dayModifiers(q, dom, dow, to, warn)
+	}
+
+	write := func(dom, dow string) string {
+		if t.question {
+			switch {
+			case dow == "*":
+				dow = "?"
+			case dom == "*":
+				dom = "?"
+			}
+		}
+		fields := append(append([]string{}, lead...), fieldText(s.Minute), fieldText(s.Hour), dom, fieldText(s.Month), dow)
+		if year != "" {
+			fields = append(fields, year)
+		}
+		return strings.Join(fields, " ")
+	}
+
+	if dom == "*" || dow == "*" {
+		return []string{write(dom, dow)}, warnings, nil
+	}
+	either := !s.MatchesBothDays() && s.Quartz == nil
+	switch {
+	case either && (t.bothDays || t.question):
+		// One expression per day field runs on days matching either
+		return []string{write(dom, "*"), write("*", dow)}, warnings, nil
+	case !either && t.question:
+		warn("%s cannot restrict both day fields; day-of-week %s is dropped, so the job runs on more days", to, dow)
+		return []string{write(dom, "*")}, warnings, nil
+	case !either && !t.bothDays:
+		warn("%s runs on days matching either day field, where %s requires both; the job runs on more days", to, from)
+	}
+	return []string{write(dom, dow)}, warnings, nil
+}
+
+// dayModifiers writes the L, W and # day modifiers of a schedule in the day
+// fields of the target, approximating those it lacks by fields that include
+// every day they match
+func (t target) dayModifiers(q *cronx.QuartzDays, dom, dow string, to cronx.Dialect, warn func(string, ...any)) (string, string) {
+	approximate := func(construct, field, value string) {
+		warn("%s has no %s; approximated by %s %s, which also matches other days", to, construct, field, value)
+	}
+
+	switch {
+	case q.LastWeekday && t.modifiers:
+		dom = "LW"
+	case q.LastWeekday:
+		dom = "26-31"
+		approximate("LW (last weekday of the month)", "day-of-month", dom)
+	case q.LastDay && (t.modifiers || t.lastDay && q.LastDayOffset == 0):
+		dom = "L"
+		if q.LastDayOffset > 0 {
+			dom += "-" + strconv.Itoa(q.LastDayOffset)
+		}
+	case q.LastDay && q.LastDayOffset > 0:
+		dom = fmt.Sprintf("%d-%d", 28-q.LastDayOffset, 31-q.LastDayOffset)
+		approximate(fmt.Sprintf("L-%d (%d days before the last day of the month)", q.LastDayOffset, q.LastDayOffset), "day-of-month", dom)
+	case q.LastDay:
+		dom = "28-31"
+		approximate("L (last day of the month)", "day-of-month", dom)
+	case q.NearestWeekday > 0 && t.modifiers:
+		dom = strconv.Itoa(q.NearestWeekday) + "W"
+	case q.NearestWeekday > 0:
+		dom = fmt.Sprintf("%d-%d", max(q.NearestWeekday-2, cronx.MinDayOfMonth), min(q.NearestWeekday+2, cronx.MaxDayOfMonth))
+		approximate(fmt.Sprintf("%dW (weekday nearest day %d)", q.NearestWeekday, q.NearestWeekday), "day-of-month", dom)
+	}
+
+	if !q.HasDayOfWeek() {
+		return dom, dow
+	}
+	weekday := t.weekday(q.Weekday)
+	switch {
+	case t.modifiers && q.NthWeekday > 0:
+		dow = fmt.Sprintf("%s#%d", weekday, q.NthWeekday)
+	case t.modifiers:
+		dow = weekday + "L"
+	case q.NthWeekday > 0 && t.bothDays && dom == "*":
+		// The kth weekday d is the day d within days 7k-6 to 7k
+		dom, dow = fmt.Sprintf("%d-%d", 7*q.NthWeekday-6, 7*q.NthWeekday), weekday
+	case q.NthWeekday > 0:
+		dow = weekday
+		approximate(fmt.Sprintf("# (occurrence %d of day-of-week %s in the month)", q.NthWeekday, weekday), "day-of-week", dow)
+	case t.bothDays && dom == "*":
+		dom, dow = "22-31", weekday
+		approximate(fmt.Sprintf("%sL (last day-of-week %s of the month)", weekday, weekday), "day-of-month 22-31 and day-of-week", dow)
+	default:
+		dow = weekday
+		approximate(fmt.Sprintf("%sL (last day-of-week %s of the month)", weekday, weekday), "day-of-week", dow)
+	}
+	return dom, dow
+}
+
+// weekday writes a day of the week (0-6, Sunday=0) in the target's numbering
+func (t target) weekday(day int) string {
+	if t.sundayOne {
+		return strconv.Itoa(day + 1)
+	}
+	return strconv.Itoa(day)
+}
+
+// weekdays writes a day-of-week field in the target's numbering. Quartz
+// numbers days 1-7, which shifts steps, so its fields are written as the
+// days they match.
+func (t target) weekdays(f cronx.Field) string {
+	if !t.sundayOne || !restricted(f) {
+		return fieldText(f)
+	}
+	values := f.Values()
+	var terms []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		switch {
+		case j == i:
+			terms = append(terms, t.weekday(values[i]))
+		default:
+			terms = append(terms, t.weekday(values[i])+"-"+t.weekday(values[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(terms, ",")
+}
+
+// restricted reports whether a field restricts its values: it has no '*'
+// (or '?') without a step
+func restricted(f cronx.Field) bool {
+	for _, part := range f.Parts() {
+		if (part.Every || part.Raw == "?") && part.Step <= 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldText writes a field with numbers, which every dialect reads alike.
+// A value with a step is written as a range, which Vixie cron requires.
+func fieldText(f cronx.Field) string {
+	parts := f.Parts()
+	terms := make([]string, len(parts))
+	for i, part := range parts {
+		switch {
+		case part.Every || part.Raw == "?":
+			terms[i] = "*"
+		case part.Range || part.Step > 1:
+			terms[i] = fmt.Sprintf("%d-%d", part.Start, part.End)
+		default:
+			terms[i] = strconv.Itoa(part.Start)
+		}
+		if part.Step > 1 {
+			terms[i] += "/" + strconv.Itoa(part.Step)
+		}
+	}
+	return strings.Join(terms, ",")
+}
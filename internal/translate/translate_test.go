@@ -0,0 +1,98 @@
+package translate_test
+
+import (
+	"testing"
+
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"github.com/hzerrad/cronkit/internal/translate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		from, to   cronx.Dialect
+		expected   []string
+	}{
+		{"spring to vixie", "0 0 9 * * MON-FRI", cronx.DialectSpring, cronx.DialectStandard, []string{"0 9 * * 1-5"}},
+		{"spring Sunday is 7", "0 30 6 * * SAT-SUN", cronx.DialectSpring, cronx.DialectStandard, []string{"30 6 * * 0,6"}},
+		{"vixie to spring adds seconds", "*/15 9-17 * * 1-5", cronx.DialectStandard, cronx.DialectSpring, []string{"0 */15 9-17 * * 1-5"}},
+		{"vixie either day becomes one expression per field", "0 12 1 * 1", cronx.DialectStandard, cronx.DialectSpring, []string{"0 0 12 1 * *", "0 0 12 * * 1"}},
+		{"quartz needs ? and numbers days from Sunday=1", "0 12 1 * 1", cronx.DialectStandard, cronx.DialectQuartz, []string{"0 0 12 1 * ?", "0 0 12 ? * 2"}},
+		{"cron4j joins patterns with |", "0 12 1 * 1", cronx.DialectStandard, cronx.DialectCron4j, []string{"0 12 1 * * | 0 12 * * 1"}},
+		{"cron4j patterns are split", "0 5 * * * | 0 12 L * *", cronx.DialectCron4j, cronx.DialectQuartz, []string{"0 0 5 * * ?", "0 0 12 L * ?"}},
+		{"modifiers keep their day", "0 0 12 * * 5L", cronx.DialectSpring, cronx.DialectQuartz, []string{"0 0 12 ? * 6L"}},
+		{"nth weekday in a dialect requiring both days", "0 0 12 ? * FRI#3", cronx.DialectQuartz, cronx.DialectNodeCron, []string{"0 12 15-21 * 5"}},
+		{"last day in cron4j", "0 0 12 L * ?", cronx.DialectQuartz, cronx.DialectCron4j, []string{"0 12 L * *"}},
+		{"node-cron keeps seconds other than 0", "*/10 * * * * *", cronx.DialectNodeCron, cronx.DialectNodeCron, []string{"*/10 * * * * *"}},
+		{"single values with a step become ranges", "0 0/15 * * * ?", cronx.DialectQuartz, cronx.DialectStandard, []string{"0-59/15 * * * *"}},
+		{"aliases", "@weekly", cronx.DialectStandard, cronx.DialectQuartz, []string{"0 0 0 ? * 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := translate.Translate(tt.expression, tt.from, tt.to, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conv.Output)
+			assert.Empty(t, conv.Warnings)
+		})
+	}
+
+	t.Run("untranslatable constructs are approximated with warnings", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			expression string
+			from, to   cronx.Dialect
+			expected   []string
+			warning    string
+		}{
+			{"days matching both fields", "0 0 12 1-7 * MON", cronx.DialectSpring, cronx.DialectStandard, []string{"0 12 1-7 * 1"}, "standard runs on days matching either day field, where spring requires both"},
+			{"last day", "0 0 12 L * ?", cronx.DialectQuartz, cronx.DialectStandard, []string{"0 12 28-31 * *"}, "standard has no L (last day of the month); approximated by day-of-month 28-31"},
+			{"nth weekday", "0 0 12 ? * MON#1", cronx.DialectSpring, cronx.DialectStandard, []string{"0 12 * * 1"}, "standard has no # (occurrence 1 of day-of-week 1 in the month)"},
+			{"nearest weekday", "0 0 9 15W * ?", cronx.DialectQuartz, cronx.DialectCron4j, []string{"0 9 13-17 * *"}, "cron4j has no 15W"},
+			{"seconds", "30 0 12 * * *", cronx.DialectSpring, cronx.DialectCron4j, []string{"0 12 * * *"}, "cron4j has no seconds field; runs at second 0 of each matching minute instead of at second 30"},
+			{"years", "0 0 12 1 1 ? 2027", cronx.DialectQuartz, cronx.DialectSpring, []string{"0 0 12 1 1 *"}, "spring has no year field; runs every year instead of in 2027 only"},
+			{"both days in quartz", "0 12 1-7 * 1", cronx.DialectNodeCron, cronx.DialectQuartz, []string{"0 0 12 1-7 * ?"}, "quartz cannot restrict both day fields; day-of-week 2 is dropped"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				conv, err := translate.Translate(tt.expression, tt.from, tt.to, "")
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, conv.Output)
+				require.Len(t, conv.Warnings, 1)
+				assert.Contains(t, conv.Warnings[0], tt.warning)
+			})
+		}
+	})
+
+	t.Run("hashed values are picked once", func(t *testing.T) {
+		conv, err := translate.Translate("H 4 * * 1-5", cronx.DialectJenkins, cronx.DialectSpring, "my-job")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0 18 4 * * 1-5"}, conv.Output)
+		require.Len(t, conv.Warnings, 1)
+		assert.Contains(t, conv.Warnings[0], "picks its values once")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := translate.Translate("0 9 * * *", cronx.DialectStandard, cronx.DialectAWS, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot translate to aws")
+
+		_, err = translate.Translate("0 9 * * *", cronx.DialectSpring, cronx.DialectStandard, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid spring expression")
+
+		_, err = translate.Translate("@every 90m", cronx.DialectStandard, cronx.DialectSpring, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "@every intervals have no cron fields")
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		assert.Equal(t, []cronx.Dialect{
+			cronx.DialectStandard, cronx.DialectQuartz, cronx.DialectJenkins,
+			cronx.DialectSpring, cronx.DialectNodeCron, cronx.DialectCron4j,
+		}, translate.Targets())
+	})
+}
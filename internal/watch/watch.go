@@ -0,0 +1,135 @@
+// Package watch reports changes to crontab content, either from a file
+// (through file system notifications) or from a source that has to be
+// polled, such as the user's crontab.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// DefaultDebounce is how long a file must stay quiet after a change
+	// before it is read, so that an editor's save is reported once
+	DefaultDebounce = 100 * time.Millisecond
+	// DefaultInterval is how often a polled source is read
+	DefaultInterval = 2 * time.Second
+)
+
+// Options configures a watch
+type Options struct {
+	Debounce time.Duration // Quiet period before a changed file is read (default: DefaultDebounce)
+	Interval time.Duration // Polling interval of Poll (default: DefaultInterval)
+}
+
+// Handler is called with the initial content and then with every content
+// that differs from the previous one. A read error is reported with empty
+// content; watching continues and the next successful read is reported.
+type Handler func(content string, err error)
+
+// tracker suppresses reports that repeat the previous one
+type tracker struct {
+	handler Handler
+	started bool
+	content string
+	err     string
+}
+
+func (t *tracker) report(content string, err error) {
+	var msg string
+	if err != nil {
+		content, msg = "", err.Error()
+	}
+	if t.started && content == t.content && msg == t.err {
+		return
+	}
+	t.started, t.content, t.err = true, content, msg
+	t.handler(content, err)
+}
+
+// File watches the file at path until ctx is canceled. The file's directory
+// is watched rather than the file itself, so changes are still seen when an
+// editor replaces the file instead of writing it in place, and when the file
+// is removed and created again.
+func File(ctx context.Context, path string, opts Options, handler Handler) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", filepath.Dir(path), err)
+	}
+
+	t := &tracker{handler: handler}
+	read := func() {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%s was removed", path)
+		}
+		t.report(string(data), err)
+	}
+	read()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == path && !event.Has(fsnotify.Chmod) {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			t.report("", fmt.Errorf("file watcher: %w", err))
+		case <-timer.C:
+			read()
+		}
+	}
+}
+
+// Poll reads content with read every opts.Interval until ctx is canceled
+func Poll(ctx context.Context, read func() (string, error), opts Options, handler Handler) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	t := &tracker{handler: handler}
+	t.report(read())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			t.report(read())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder collects handler calls from the watch goroutine
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) handle(content string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.events = append(r.events, "error: "+err.Error())
+		return
+	}
+	r.events = append(r.events, content)
+}
+
+func (r *recorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// waitFor waits until the recorder has n events
+func (r *recorder) waitFor(t *testing.T, n int) []string {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(r.snapshot()) >= n }, 5*time.Second, 10*time.Millisecond)
+	return r.snapshot()
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jobs.cron")
+	require.NoError(t, os.WriteFile(path, []byte("0 2 * * * /usr/bin/a\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := &recorder{}
+	done := make(chan error, 1)
+	go func() { done <- File(ctx, path, Options{Debounce: 20 * time.Millisecond}, rec.handle) }()
+
+	assert.Equal(t, []string{"0 2 * * * /usr/bin/a\n"}, rec.waitFor(t, 1))
+
+	t.Run("reports writes", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("0 3 * * * /usr/bin/b\n"), 0644))
+		assert.Equal(t, "0 3 * * * /usr/bin/b\n", rec.waitFor(t, 2)[1])
+	})
+
+	t.Run("reports removal and replacement", func(t *testing.T) {
+		require.NoError(t, os.Remove(path))
+		assert.Contains(t, rec.waitFor(t, 3)[2], "was removed")
+
+		tmp := filepath.Join(dir, "jobs.cron.tmp")
+		require.NoError(t, os.WriteFile(tmp, []byte("0 4 * * * /usr/bin/c\n"), 0644))
+		require.NoError(t, os.Rename(tmp, path))
+		assert.Equal(t, "0 4 * * * /usr/bin/c\n", rec.waitFor(t, 4)[3])
+	})
+
+	t.Run("ignores other files in the directory", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other.cron"), []byte("x"), 0644))
+		time.Sleep(100 * time.Millisecond)
+		assert.Len(t, rec.snapshot(), 4)
+	})
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("File did not return after cancel")
+	}
+}
+
+func TestFile_MissingDirectory(t *testing.T) {
+	err := File(context.Background(), filepath.Join(t.TempDir(), "missing", "jobs.cron"), Options{}, func(string, error) {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to watch")
+}
+
+func TestPoll(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		content = "a"
+		readErr error
+	)
+	read := func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return content, readErr
+	}
+	set := func(c string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		content, readErr = c, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := &recorder{}
+	done := make(chan error, 1)
+	go func() { done <- Poll(ctx, read, Options{Interval: 10 * time.Millisecond}, rec.handle) }()
+
+	rec.waitFor(t, 1)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"a"}, rec.snapshot(), "unchanged content is reported once")
+
+	set("b", nil)
+	assert.Equal(t, "b", rec.waitFor(t, 2)[1])
+
+	set("", errors.New("crontab unavailable"))
+	assert.Equal(t, "error: crontab unavailable", rec.waitFor(t, 3)[2])
+
+	set("b", nil)
+	assert.Equal(t, "b", rec.waitFor(t, 4)[3], "content is reported again after an error")
+
+	cancel()
+	assert.NoError(t, <-done)
+}
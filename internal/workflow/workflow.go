@@ -0,0 +1,162 @@
+// Package workflow reads the cron schedules of GitHub Actions workflows
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hzerrad/cronkit/internal/crontab"
+	"github.com/hzerrad/cronkit/internal/cronx"
+	"gopkg.in/yaml.v3"
+)
+
+// Schedule is one "cron" entry under on.schedule
+type Schedule struct {
+	Cron string // Cron expression as written
+	Line int    // Line of the expression in the workflow file (1-indexed)
+}
+
+// Workflow is a workflow file and its schedules
+type Workflow struct {
+	Path      string
+	Name      string // Value of the top-level "name" key (optional)
+	Schedules []Schedule
+}
+
+// Find returns the workflow files at path: path itself when it is a file, or
+// the .yml and .yaml files in it, sorted by name, when it is a directory
+func Find(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yml" || ext == ".yaml") {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Load reads the workflow file at path
+func Load(path string) (*Workflow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return Parse(file, path)
+}
+
+// Parse reads a workflow file. Workflows without an on.schedule trigger
+// have no schedules; on given as an event name or a list of events is
+// accepted.
+func Parse(r io.Reader, path string) (*Workflow, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid workflow %s: %w", path, err)
+	}
+
+	wf := &Workflow{Path: path}
+	if len(doc.Content) == 0 {
+		return wf, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid workflow %s: expected a mapping at the top level", path)
+	}
+
+	if name := mappingValue(root, "name"); name != nil && name.Kind == yaml.ScalarNode {
+		wf.Name = name.Value
+	}
+
+	on := mappingValue(root, "on")
+	if on == nil || on.Kind != yaml.MappingNode {
+		return wf, nil
+	}
+	schedule := mappingValue(on, "schedule")
+	if schedule == nil {
+		return wf, nil
+	}
+	if schedule.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("invalid workflow %s: line %d: on.schedule must be a list", path, schedule.Line)
+	}
+
+	for _, item := range schedule.Content {
+		cron := mappingValue(item, "cron")
+		if cron == nil || cron.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("invalid workflow %s: line %d: schedule entry without a cron expression", path, item.Line)
+		}
+		wf.Schedules = append(wf.Schedules, Schedule{Cron: cron.Value, Line: cron.Line})
+	}
+	return wf, nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Entries returns the schedules as crontab entries, so they can be checked
+// like any crontab. Jobs run in UTC, as GitHub evaluates schedules, and are
+// named after the workflow. GitHub only accepts five-field expressions, so
+// @ aliases and seconds fields make a job invalid.
+func (wf *Workflow) Entries() []*crontab.Entry {
+	name := wf.Name
+	if name == "" {
+		name = filepath.Base(wf.Path)
+	}
+
+	parser := cronx.NewParser()
+	entries := make([]*crontab.Entry, 0, len(wf.Schedules))
+	for _, s := range wf.Schedules {
+		job := &crontab.Job{
+			LineNumber: s.Line,
+			Expression: strings.TrimSpace(s.Cron),
+			Command:    name,
+			Timezone:   "UTC",
+			Source:     wf.Path,
+			Valid:      true,
+		}
+		if n := len(strings.Fields(job.Expression)); n != 5 {
+			job.Valid = false
+			job.Error = fmt.Sprintf("GitHub Actions schedules need exactly 5 fields (minute hour day-of-month month day-of-week), got %d", n)
+		} else if _, err := parser.Parse(job.Expression); err != nil {
+			job.Valid = false
+			job.Error = err.Error()
+		}
+
+		entries = append(entries, &crontab.Entry{
+			Type:       crontab.EntryTypeJob,
+			LineNumber: s.Line,
+			Raw:        s.Cron,
+			Job:        job,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nightly = `name: Nightly
+on:
+  schedule:
+    - cron: '*/15 * * * *'
+    - cron: "0 3 * * 1-5"
+  workflow_dispatch:
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`
+
+func TestParse(t *testing.T) {
+	t.Run("schedules with line numbers", func(t *testing.T) {
+		wf, err := Parse(strings.NewReader(nightly), "nightly.yml")
+		require.NoError(t, err)
+		assert.Equal(t, "Nightly", wf.Name)
+		assert.Equal(t, []Schedule{
+			{Cron: "*/15 * * * *", Line: 4},
+			{Cron: "0 3 * * 1-5", Line: 5},
+		}, wf.Schedules)
+	})
+
+	t.Run("workflows without schedules", func(t *testing.T) {
+		for _, content := range []string{
+			"on: push\n",
+			"on: [push, pull_request]\n",
+			"on:\n  push:\n    branches: [main]\n",
+			"",
+		} {
+			wf, err := Parse(strings.NewReader(content), "ci.yml")
+			require.NoError(t, err, content)
+			assert.Empty(t, wf.Schedules, content)
+		}
+	})
+
+	t.Run("invalid workflows", func(t *testing.T) {
+		for _, content := range []string{
+			"on: [push\n",
+			"- not a mapping\n",
+			"on:\n  schedule:\n    cron: '0 0 * * *'\n",
+			"on:\n  schedule:\n    - branches: [main]\n",
+		} {
+			_, err := Parse(strings.NewReader(content), "bad.yml")
+			assert.Error(t, err, content)
+		}
+	})
+}
+
+func TestWorkflow_Entries(t *testing.T) {
+	wf := &Workflow{
+		Path: ".github/workflows/backup.yml",
+		Schedules: []Schedule{
+			{Cron: "0 3 * * *", Line: 4},
+			{Cron: "@daily", Line: 5},
+			{Cron: "0 0 3 * * *", Line: 6},
+			{Cron: "61 * * * *", Line: 7},
+		},
+	}
+
+	entries := wf.Entries()
+	require.Len(t, entries, 4)
+
+	job := entries[0].Job
+	assert.True(t, job.Valid)
+	assert.Equal(t, "backup.yml", job.Command)
+	assert.Equal(t, "UTC", job.Timezone)
+	assert.Equal(t, ".github/workflows/backup.yml", job.Source)
+	assert.Equal(t, 4, job.LineNumber)
+
+	assert.False(t, entries[1].Job.Valid)
+	assert.Contains(t, entries[1].Job.Error, "exactly 5 fields")
+	assert.False(t, entries[2].Job.Valid)
+	assert.False(t, entries[3].Job.Valid)
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.yaml", "a.yml", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("on: push\n"), 0o644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub.yml"), 0o755))
+
+	files, err := Find(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.yml"), filepath.Join(dir, "b.yaml")}, files)
+
+	files, err = Find(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "README.md")}, files)
+
+	_, err = Find(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
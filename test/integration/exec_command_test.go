@@ -0,0 +1,59 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Exec Command", func() {
+	Context("when a request file is given", func() {
+		It("should return a JSON response for a check request", func() {
+			requestFile := filepath.Join(GinkgoT().TempDir(), "request.json")
+			request := `{"command": "check", "crontab": "0 0 1 * 1 /usr/bin/report.sh\n", "options": {"verbose": true}}`
+			Expect(os.WriteFile(requestFile, []byte(request), 0o644)).To(Succeed())
+
+			command := exec.Command(pathToCLI, "exec", "--request", requestFile)
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+
+			var resp map[string]interface{}
+			Expect(json.Unmarshal(session.Out.Contents(), &resp)).To(Succeed())
+			Expect(resp["ok"]).To(BeTrue())
+			Expect(resp["command"]).To(Equal("check"))
+			result := resp["result"].(map[string]interface{})
+			Expect(result["totalJobs"]).To(BeNumerically("==", 1))
+		})
+	})
+
+	Context("when the request is piped to stdin", func() {
+		It("should explain an expression", func() {
+			command := exec.Command(pathToCLI, "exec")
+			command.Stdin = strings.NewReader(`{"command": "explain", "expression": "*/15 * * * *"}`)
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(string(session.Out.Contents())).To(ContainSubstring("Every 15 minutes"))
+		})
+
+		It("should exit 1 with an error response for unknown commands", func() {
+			command := exec.Command(pathToCLI, "exec", "--request", "-")
+			command.Stdin = strings.NewReader(`{"command": "frobnicate"}`)
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(1))
+
+			var resp map[string]interface{}
+			Expect(json.Unmarshal(session.Out.Contents(), &resp)).To(Succeed())
+			Expect(resp["ok"]).To(BeFalse())
+			Expect(resp["error"].(map[string]interface{})["code"]).To(Equal("unknown_command"))
+		})
+	})
+})
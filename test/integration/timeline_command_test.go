@@ -311,7 +311,7 @@ var _ = Describe("Timeline Command", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Eventually(session).Should(gexec.Exit(1))
-			Expect(session.Err).To(gbytes.Say("invalid --from time format"))
+			Expect(session.Err).To(gbytes.Say("invalid --from time"))
 		})
 
 		It("should work with --from and hour view", func() {